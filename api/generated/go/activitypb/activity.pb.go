@@ -0,0 +1,576 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: activity.proto
+
+package activitypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ActivityItem is a single entry in the activity feed
+type ActivityItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "wiki", "note", or "quote"
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`                  // "created" or "updated"
+	GuildId       string                 `protobuf:"bytes,4,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Empty for personal notes
+	GuildName     string                 `protobuf:"bytes,5,opt,name=guild_name,json=guildName,proto3" json:"guild_name,omitempty"`
+	Title         string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`                             // Empty for quotes
+	Slug          string                 `protobuf:"bytes,7,opt,name=slug,proto3" json:"slug,omitempty"`                               // Wiki page slug (wiki only)
+	Snippet       string                 `protobuf:"bytes,8,opt,name=snippet,proto3" json:"snippet,omitempty"`                         // Start of the body
+	AuthorName    string                 `protobuf:"bytes,9,opt,name=author_name,json=authorName,proto3" json:"author_name,omitempty"` // Wiki/note author, or who said the quote
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivityItem) Reset() {
+	*x = ActivityItem{}
+	mi := &file_activity_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivityItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivityItem) ProtoMessage() {}
+
+func (x *ActivityItem) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivityItem.ProtoReflect.Descriptor instead.
+func (*ActivityItem) Descriptor() ([]byte, []int) {
+	return file_activity_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ActivityItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ActivityItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ActivityItem) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ActivityItem) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *ActivityItem) GetGuildName() string {
+	if x != nil {
+		return x.GuildName
+	}
+	return ""
+}
+
+func (x *ActivityItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ActivityItem) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *ActivityItem) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *ActivityItem) GetAuthorName() string {
+	if x != nil {
+		return x.AuthorName
+	}
+	return ""
+}
+
+func (x *ActivityItem) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type ListRecentActivityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`       // Empty = all guilds the caller belongs to
+	Since         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`                          // Only return activity after this time (exclusive)
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                         // Default 20, max 100
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token from a previous response; continues after it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecentActivityRequest) Reset() {
+	*x = ListRecentActivityRequest{}
+	mi := &file_activity_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentActivityRequest) ProtoMessage() {}
+
+func (x *ListRecentActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentActivityRequest.ProtoReflect.Descriptor instead.
+func (*ListRecentActivityRequest) Descriptor() ([]byte, []int) {
+	return file_activity_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListRecentActivityRequest) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *ListRecentActivityRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListRecentActivityRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRecentActivityRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListRecentActivityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ActivityItem        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`                    // More activity matched than limit allowed
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Pass as page_token to poll for newer activity; empty while nothing matched
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecentActivityResponse) Reset() {
+	*x = ListRecentActivityResponse{}
+	mi := &file_activity_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentActivityResponse) ProtoMessage() {}
+
+func (x *ListRecentActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentActivityResponse.ProtoReflect.Descriptor instead.
+func (*ListRecentActivityResponse) Descriptor() ([]byte, []int) {
+	return file_activity_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListRecentActivityResponse) GetItems() []*ActivityItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListRecentActivityResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListRecentActivityResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// RecentlyViewedItem is a single entry in the caller's view history
+type RecentlyViewedItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "wiki", "note", or "quote"
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	GuildId       string                 `protobuf:"bytes,3,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Empty for personal notes
+	GuildName     string                 `protobuf:"bytes,4,opt,name=guild_name,json=guildName,proto3" json:"guild_name,omitempty"`
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`     // Empty for quotes
+	Slug          string                 `protobuf:"bytes,6,opt,name=slug,proto3" json:"slug,omitempty"`       // Permalink key: wiki page or note slug, or quote short code
+	Snippet       string                 `protobuf:"bytes,7,opt,name=snippet,proto3" json:"snippet,omitempty"` // Start of the body
+	ViewedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=viewed_at,json=viewedAt,proto3" json:"viewed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecentlyViewedItem) Reset() {
+	*x = RecentlyViewedItem{}
+	mi := &file_activity_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecentlyViewedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecentlyViewedItem) ProtoMessage() {}
+
+func (x *RecentlyViewedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecentlyViewedItem.ProtoReflect.Descriptor instead.
+func (*RecentlyViewedItem) Descriptor() ([]byte, []int) {
+	return file_activity_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecentlyViewedItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RecentlyViewedItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RecentlyViewedItem) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *RecentlyViewedItem) GetGuildName() string {
+	if x != nil {
+		return x.GuildName
+	}
+	return ""
+}
+
+func (x *RecentlyViewedItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *RecentlyViewedItem) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *RecentlyViewedItem) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *RecentlyViewedItem) GetViewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ViewedAt
+	}
+	return nil
+}
+
+type ListRecentlyViewedRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Limit           int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                                              // Default 10, max 50
+	ExcludeOwnEdits bool                   `protobuf:"varint,2,opt,name=exclude_own_edits,json=excludeOwnEdits,proto3" json:"exclude_own_edits,omitempty"` // Leave out items the caller viewed right after saving them
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListRecentlyViewedRequest) Reset() {
+	*x = ListRecentlyViewedRequest{}
+	mi := &file_activity_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentlyViewedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentlyViewedRequest) ProtoMessage() {}
+
+func (x *ListRecentlyViewedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentlyViewedRequest.ProtoReflect.Descriptor instead.
+func (*ListRecentlyViewedRequest) Descriptor() ([]byte, []int) {
+	return file_activity_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListRecentlyViewedRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRecentlyViewedRequest) GetExcludeOwnEdits() bool {
+	if x != nil {
+		return x.ExcludeOwnEdits
+	}
+	return false
+}
+
+type ListRecentlyViewedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*RecentlyViewedItem  `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecentlyViewedResponse) Reset() {
+	*x = ListRecentlyViewedResponse{}
+	mi := &file_activity_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentlyViewedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentlyViewedResponse) ProtoMessage() {}
+
+func (x *ListRecentlyViewedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentlyViewedResponse.ProtoReflect.Descriptor instead.
+func (*ListRecentlyViewedResponse) Descriptor() ([]byte, []int) {
+	return file_activity_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListRecentlyViewedResponse) GetItems() []*RecentlyViewedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_activity_proto protoreflect.FileDescriptor
+
+const file_activity_proto_rawDesc = "" +
+	"\n" +
+	"\x0eactivity.proto\x12\x14hivemind.activity.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa3\x02\n" +
+	"\fActivityItem\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x19\n" +
+	"\bguild_id\x18\x04 \x01(\tR\aguildId\x12\x1d\n" +
+	"\n" +
+	"guild_name\x18\x05 \x01(\tR\tguildName\x12\x14\n" +
+	"\x05title\x18\x06 \x01(\tR\x05title\x12\x12\n" +
+	"\x04slug\x18\a \x01(\tR\x04slug\x12\x18\n" +
+	"\asnippet\x18\b \x01(\tR\asnippet\x12\x1f\n" +
+	"\vauthor_name\x18\t \x01(\tR\n" +
+	"authorName\x128\n" +
+	"\ttimestamp\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x9d\x01\n" +
+	"\x19ListRecentActivityRequest\x12\x19\n" +
+	"\bguild_id\x18\x01 \x01(\tR\aguildId\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\x99\x01\n" +
+	"\x1aListRecentActivityResponse\x128\n" +
+	"\x05items\x18\x01 \x03(\v2\".hivemind.activity.v1.ActivityItemR\x05items\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\"\xef\x01\n" +
+	"\x12RecentlyViewedItem\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x19\n" +
+	"\bguild_id\x18\x03 \x01(\tR\aguildId\x12\x1d\n" +
+	"\n" +
+	"guild_name\x18\x04 \x01(\tR\tguildName\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x12\n" +
+	"\x04slug\x18\x06 \x01(\tR\x04slug\x12\x18\n" +
+	"\asnippet\x18\a \x01(\tR\asnippet\x127\n" +
+	"\tviewed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bviewedAt\"]\n" +
+	"\x19ListRecentlyViewedRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12*\n" +
+	"\x11exclude_own_edits\x18\x02 \x01(\bR\x0fexcludeOwnEdits\"\\\n" +
+	"\x1aListRecentlyViewedResponse\x12>\n" +
+	"\x05items\x18\x01 \x03(\v2(.hivemind.activity.v1.RecentlyViewedItemR\x05items2\x83\x02\n" +
+	"\x0fActivityService\x12w\n" +
+	"\x12ListRecentActivity\x12/.hivemind.activity.v1.ListRecentActivityRequest\x1a0.hivemind.activity.v1.ListRecentActivityResponse\x12w\n" +
+	"\x12ListRecentlyViewed\x12/.hivemind.activity.v1.ListRecentlyViewedRequest\x1a0.hivemind.activity.v1.ListRecentlyViewedResponseB@Z>github.com/devilmonastery/hivemind/api/generated/go/activitypbb\x06proto3"
+
+var (
+	file_activity_proto_rawDescOnce sync.Once
+	file_activity_proto_rawDescData []byte
+)
+
+func file_activity_proto_rawDescGZIP() []byte {
+	file_activity_proto_rawDescOnce.Do(func() {
+		file_activity_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_activity_proto_rawDesc), len(file_activity_proto_rawDesc)))
+	})
+	return file_activity_proto_rawDescData
+}
+
+var file_activity_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_activity_proto_goTypes = []any{
+	(*ActivityItem)(nil),               // 0: hivemind.activity.v1.ActivityItem
+	(*ListRecentActivityRequest)(nil),  // 1: hivemind.activity.v1.ListRecentActivityRequest
+	(*ListRecentActivityResponse)(nil), // 2: hivemind.activity.v1.ListRecentActivityResponse
+	(*RecentlyViewedItem)(nil),         // 3: hivemind.activity.v1.RecentlyViewedItem
+	(*ListRecentlyViewedRequest)(nil),  // 4: hivemind.activity.v1.ListRecentlyViewedRequest
+	(*ListRecentlyViewedResponse)(nil), // 5: hivemind.activity.v1.ListRecentlyViewedResponse
+	(*timestamppb.Timestamp)(nil),      // 6: google.protobuf.Timestamp
+}
+var file_activity_proto_depIdxs = []int32{
+	6, // 0: hivemind.activity.v1.ActivityItem.timestamp:type_name -> google.protobuf.Timestamp
+	6, // 1: hivemind.activity.v1.ListRecentActivityRequest.since:type_name -> google.protobuf.Timestamp
+	0, // 2: hivemind.activity.v1.ListRecentActivityResponse.items:type_name -> hivemind.activity.v1.ActivityItem
+	6, // 3: hivemind.activity.v1.RecentlyViewedItem.viewed_at:type_name -> google.protobuf.Timestamp
+	3, // 4: hivemind.activity.v1.ListRecentlyViewedResponse.items:type_name -> hivemind.activity.v1.RecentlyViewedItem
+	1, // 5: hivemind.activity.v1.ActivityService.ListRecentActivity:input_type -> hivemind.activity.v1.ListRecentActivityRequest
+	4, // 6: hivemind.activity.v1.ActivityService.ListRecentlyViewed:input_type -> hivemind.activity.v1.ListRecentlyViewedRequest
+	2, // 7: hivemind.activity.v1.ActivityService.ListRecentActivity:output_type -> hivemind.activity.v1.ListRecentActivityResponse
+	5, // 8: hivemind.activity.v1.ActivityService.ListRecentlyViewed:output_type -> hivemind.activity.v1.ListRecentlyViewedResponse
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_activity_proto_init() }
+func file_activity_proto_init() {
+	if File_activity_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_activity_proto_rawDesc), len(file_activity_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_activity_proto_goTypes,
+		DependencyIndexes: file_activity_proto_depIdxs,
+		MessageInfos:      file_activity_proto_msgTypes,
+	}.Build()
+	File_activity_proto = out.File
+	file_activity_proto_goTypes = nil
+	file_activity_proto_depIdxs = nil
+}
@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: activity.proto
+
+package activitypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ActivityService_ListRecentActivity_FullMethodName = "/hivemind.activity.v1.ActivityService/ListRecentActivity"
+	ActivityService_ListRecentlyViewed_FullMethodName = "/hivemind.activity.v1.ActivityService/ListRecentlyViewed"
+)
+
+// ActivityServiceClient is the client API for ActivityService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ActivityService provides a combined changelog of guild content
+type ActivityServiceClient interface {
+	// ListRecentActivity returns recently created or updated wiki pages, notes, and quotes.
+	// Without since or page_token the newest items come back, newest first; with either, the
+	// items that follow come back oldest first, so polling with next_page_token never skips any.
+	// Only guilds the caller belongs to are included, and only the caller's own notes.
+	ListRecentActivity(ctx context.Context, in *ListRecentActivityRequest, opts ...grpc.CallOption) (*ListRecentActivityResponse, error)
+	// ListRecentlyViewed returns the wiki pages, notes, and quotes the caller opened most recently,
+	// most recent first. Viewing an item again moves it to the front.
+	ListRecentlyViewed(ctx context.Context, in *ListRecentlyViewedRequest, opts ...grpc.CallOption) (*ListRecentlyViewedResponse, error)
+}
+
+type activityServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewActivityServiceClient(cc grpc.ClientConnInterface) ActivityServiceClient {
+	return &activityServiceClient{cc}
+}
+
+func (c *activityServiceClient) ListRecentActivity(ctx context.Context, in *ListRecentActivityRequest, opts ...grpc.CallOption) (*ListRecentActivityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRecentActivityResponse)
+	err := c.cc.Invoke(ctx, ActivityService_ListRecentActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *activityServiceClient) ListRecentlyViewed(ctx context.Context, in *ListRecentlyViewedRequest, opts ...grpc.CallOption) (*ListRecentlyViewedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRecentlyViewedResponse)
+	err := c.cc.Invoke(ctx, ActivityService_ListRecentlyViewed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ActivityServiceServer is the server API for ActivityService service.
+// All implementations should embed UnimplementedActivityServiceServer
+// for forward compatibility.
+//
+// ActivityService provides a combined changelog of guild content
+type ActivityServiceServer interface {
+	// ListRecentActivity returns recently created or updated wiki pages, notes, and quotes.
+	// Without since or page_token the newest items come back, newest first; with either, the
+	// items that follow come back oldest first, so polling with next_page_token never skips any.
+	// Only guilds the caller belongs to are included, and only the caller's own notes.
+	ListRecentActivity(context.Context, *ListRecentActivityRequest) (*ListRecentActivityResponse, error)
+	// ListRecentlyViewed returns the wiki pages, notes, and quotes the caller opened most recently,
+	// most recent first. Viewing an item again moves it to the front.
+	ListRecentlyViewed(context.Context, *ListRecentlyViewedRequest) (*ListRecentlyViewedResponse, error)
+}
+
+// UnimplementedActivityServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedActivityServiceServer struct{}
+
+func (UnimplementedActivityServiceServer) ListRecentActivity(context.Context, *ListRecentActivityRequest) (*ListRecentActivityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRecentActivity not implemented")
+}
+func (UnimplementedActivityServiceServer) ListRecentlyViewed(context.Context, *ListRecentlyViewedRequest) (*ListRecentlyViewedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRecentlyViewed not implemented")
+}
+func (UnimplementedActivityServiceServer) testEmbeddedByValue() {}
+
+// UnsafeActivityServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ActivityServiceServer will
+// result in compilation errors.
+type UnsafeActivityServiceServer interface {
+	mustEmbedUnimplementedActivityServiceServer()
+}
+
+func RegisterActivityServiceServer(s grpc.ServiceRegistrar, srv ActivityServiceServer) {
+	// If the following call panics, it indicates UnimplementedActivityServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ActivityService_ServiceDesc, srv)
+}
+
+func _ActivityService_ListRecentActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecentActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityServiceServer).ListRecentActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActivityService_ListRecentActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityServiceServer).ListRecentActivity(ctx, req.(*ListRecentActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActivityService_ListRecentlyViewed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecentlyViewedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityServiceServer).ListRecentlyViewed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActivityService_ListRecentlyViewed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityServiceServer).ListRecentlyViewed(ctx, req.(*ListRecentlyViewedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ActivityService_ServiceDesc is the grpc.ServiceDesc for ActivityService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ActivityService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hivemind.activity.v1.ActivityService",
+	HandlerType: (*ActivityServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRecentActivity",
+			Handler:    _ActivityService_ListRecentActivity_Handler,
+		},
+		{
+			MethodName: "ListRecentlyViewed",
+			Handler:    _ActivityService_ListRecentlyViewed_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "activity.proto",
+}
@@ -314,6 +314,251 @@ func (x *ListAllUsersResponse) GetTotalCount() int32 {
 	return 0
 }
 
+// ListUsersRequest filters are combined; unset filters match every user
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // default 50, max 100
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token from the previous response
+	Role          userpb.Role            `protobuf:"varint,3,opt,name=role,proto3,enum=hivemind.user.v1.Role" json:"role,omitempty"`
+	UserType      userpb.UserType        `protobuf:"varint,4,opt,name=user_type,json=userType,proto3,enum=hivemind.user.v1.UserType" json:"user_type,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // "active", "inactive", or empty for both
+	Search        string                 `protobuf:"bytes,6,opt,name=search,proto3" json:"search,omitempty"` // case-insensitive substring of email or display name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetRole() userpb.Role {
+	if x != nil {
+		return x.Role
+	}
+	return userpb.Role(0)
+}
+
+func (x *ListUsersRequest) GetUserType() userpb.UserType {
+	if x != nil {
+		return x.UserType
+	}
+	return userpb.UserType(0)
+}
+
+func (x *ListUsersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserListEntry       `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`           // users matching the filters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListUsersResponse) GetUsers() []*UserListEntry {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListUsersResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type UserListEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Role          userpb.Role            `protobuf:"varint,4,opt,name=role,proto3,enum=hivemind.user.v1.Role" json:"role,omitempty"`
+	UserType      userpb.UserType        `protobuf:"varint,5,opt,name=user_type,json=userType,proto3,enum=hivemind.user.v1.UserType" json:"user_type,omitempty"`
+	Active        bool                   `protobuf:"varint,6,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastLogin     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_login,json=lastLogin,proto3" json:"last_login,omitempty"` // unset if the user never signed in
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserListEntry) Reset() {
+	*x = UserListEntry{}
+	mi := &file_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserListEntry) ProtoMessage() {}
+
+func (x *UserListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserListEntry.ProtoReflect.Descriptor instead.
+func (*UserListEntry) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UserListEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserListEntry) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserListEntry) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *UserListEntry) GetRole() userpb.Role {
+	if x != nil {
+		return x.Role
+	}
+	return userpb.Role(0)
+}
+
+func (x *UserListEntry) GetUserType() userpb.UserType {
+	if x != nil {
+		return x.UserType
+	}
+	return userpb.UserType(0)
+}
+
+func (x *UserListEntry) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *UserListEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UserListEntry) GetLastLogin() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLogin
+	}
+	return nil
+}
+
 type GetUserDetailsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -323,7 +568,7 @@ type GetUserDetailsRequest struct {
 
 func (x *GetUserDetailsRequest) Reset() {
 	*x = GetUserDetailsRequest{}
-	mi := &file_admin_proto_msgTypes[4]
+	mi := &file_admin_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -335,7 +580,7 @@ func (x *GetUserDetailsRequest) String() string {
 func (*GetUserDetailsRequest) ProtoMessage() {}
 
 func (x *GetUserDetailsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[4]
+	mi := &file_admin_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +593,7 @@ func (x *GetUserDetailsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserDetailsRequest.ProtoReflect.Descriptor instead.
 func (*GetUserDetailsRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{4}
+	return file_admin_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetUserDetailsRequest) GetUserId() string {
@@ -369,7 +614,7 @@ type GetUserDetailsResponse struct {
 
 func (x *GetUserDetailsResponse) Reset() {
 	*x = GetUserDetailsResponse{}
-	mi := &file_admin_proto_msgTypes[5]
+	mi := &file_admin_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -381,7 +626,7 @@ func (x *GetUserDetailsResponse) String() string {
 func (*GetUserDetailsResponse) ProtoMessage() {}
 
 func (x *GetUserDetailsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[5]
+	mi := &file_admin_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -394,7 +639,7 @@ func (x *GetUserDetailsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserDetailsResponse.ProtoReflect.Descriptor instead.
 func (*GetUserDetailsResponse) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{5}
+	return file_admin_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetUserDetailsResponse) GetUser() *userpb.User {
@@ -432,7 +677,7 @@ type APITokenSummary struct {
 
 func (x *APITokenSummary) Reset() {
 	*x = APITokenSummary{}
-	mi := &file_admin_proto_msgTypes[6]
+	mi := &file_admin_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -444,7 +689,7 @@ func (x *APITokenSummary) String() string {
 func (*APITokenSummary) ProtoMessage() {}
 
 func (x *APITokenSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[6]
+	mi := &file_admin_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,7 +702,7 @@ func (x *APITokenSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use APITokenSummary.ProtoReflect.Descriptor instead.
 func (*APITokenSummary) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{6}
+	return file_admin_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *APITokenSummary) GetTokenId() string {
@@ -514,7 +759,7 @@ type UserStatistics struct {
 
 func (x *UserStatistics) Reset() {
 	*x = UserStatistics{}
-	mi := &file_admin_proto_msgTypes[7]
+	mi := &file_admin_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -526,7 +771,7 @@ func (x *UserStatistics) String() string {
 func (*UserStatistics) ProtoMessage() {}
 
 func (x *UserStatistics) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[7]
+	mi := &file_admin_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -539,7 +784,7 @@ func (x *UserStatistics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserStatistics.ProtoReflect.Descriptor instead.
 func (*UserStatistics) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{7}
+	return file_admin_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UserStatistics) GetTotalNotes() int32 {
@@ -582,7 +827,7 @@ type UpdateUserRequest struct {
 
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_admin_proto_msgTypes[8]
+	mi := &file_admin_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -594,7 +839,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[8]
+	mi := &file_admin_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -607,7 +852,7 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{8}
+	return file_admin_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateUserRequest) GetUserId() string {
@@ -647,7 +892,7 @@ type UpdateUserResponse struct {
 
 func (x *UpdateUserResponse) Reset() {
 	*x = UpdateUserResponse{}
-	mi := &file_admin_proto_msgTypes[9]
+	mi := &file_admin_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -659,7 +904,7 @@ func (x *UpdateUserResponse) String() string {
 func (*UpdateUserResponse) ProtoMessage() {}
 
 func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[9]
+	mi := &file_admin_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -672,7 +917,7 @@ func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{9}
+	return file_admin_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UpdateUserResponse) GetUser() *userpb.User {
@@ -692,7 +937,7 @@ type DeleteUserRequest struct {
 
 func (x *DeleteUserRequest) Reset() {
 	*x = DeleteUserRequest{}
-	mi := &file_admin_proto_msgTypes[10]
+	mi := &file_admin_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -704,7 +949,7 @@ func (x *DeleteUserRequest) String() string {
 func (*DeleteUserRequest) ProtoMessage() {}
 
 func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[10]
+	mi := &file_admin_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -717,7 +962,7 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{10}
+	return file_admin_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DeleteUserRequest) GetUserId() string {
@@ -734,30 +979,30 @@ func (x *DeleteUserRequest) GetHardDelete() bool {
 	return false
 }
 
-type ImpersonateUserRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	DeviceName       string                 `protobuf:"bytes,2,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`                      // for the impersonation token
-	ExpiresInMinutes int64                  `protobuf:"varint,3,opt,name=expires_in_minutes,json=expiresInMinutes,proto3" json:"expires_in_minutes,omitempty"` // default 60 minutes
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type SetUserActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	RevokeTokens  bool                   `protobuf:"varint,3,opt,name=revoke_tokens,json=revokeTokens,proto3" json:"revoke_tokens,omitempty"` // when deactivating, also revoke all of the user's tokens
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ImpersonateUserRequest) Reset() {
-	*x = ImpersonateUserRequest{}
-	mi := &file_admin_proto_msgTypes[11]
+func (x *SetUserActiveRequest) Reset() {
+	*x = SetUserActiveRequest{}
+	mi := &file_admin_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ImpersonateUserRequest) String() string {
+func (x *SetUserActiveRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImpersonateUserRequest) ProtoMessage() {}
+func (*SetUserActiveRequest) ProtoMessage() {}
 
-func (x *ImpersonateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[11]
+func (x *SetUserActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -768,43 +1013,163 @@ func (x *ImpersonateUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImpersonateUserRequest.ProtoReflect.Descriptor instead.
-func (*ImpersonateUserRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use SetUserActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetUserActiveRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ImpersonateUserRequest) GetUserId() string {
+func (x *SetUserActiveRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *ImpersonateUserRequest) GetDeviceName() string {
+func (x *SetUserActiveRequest) GetActive() bool {
 	if x != nil {
-		return x.DeviceName
+		return x.Active
 	}
-	return ""
+	return false
 }
 
-func (x *ImpersonateUserRequest) GetExpiresInMinutes() int64 {
+func (x *SetUserActiveRequest) GetRevokeTokens() bool {
 	if x != nil {
-		return x.ExpiresInMinutes
+		return x.RevokeTokens
 	}
-	return 0
+	return false
 }
 
-type ImpersonateUserResponse struct {
+type SetUserActiveResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApiToken      string                 `protobuf:"bytes,1,opt,name=api_token,json=apiToken,proto3" json:"api_token,omitempty"` // temporary token for impersonation
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	TokensRevoked bool                   `protobuf:"varint,3,opt,name=tokens_revoked,json=tokensRevoked,proto3" json:"tokens_revoked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserActiveResponse) Reset() {
+	*x = SetUserActiveResponse{}
+	mi := &file_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserActiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserActiveResponse) ProtoMessage() {}
+
+func (x *SetUserActiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserActiveResponse.ProtoReflect.Descriptor instead.
+func (*SetUserActiveResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetUserActiveResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserActiveResponse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *SetUserActiveResponse) GetTokensRevoked() bool {
+	if x != nil {
+		return x.TokensRevoked
+	}
+	return false
+}
+
+type ImpersonateUserRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceName       string                 `protobuf:"bytes,2,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`                      // for the impersonation token
+	ExpiresInMinutes int64                  `protobuf:"varint,3,opt,name=expires_in_minutes,json=expiresInMinutes,proto3" json:"expires_in_minutes,omitempty"` // default 60 minutes
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ImpersonateUserRequest) Reset() {
+	*x = ImpersonateUserRequest{}
+	mi := &file_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateUserRequest) ProtoMessage() {}
+
+func (x *ImpersonateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateUserRequest.ProtoReflect.Descriptor instead.
+func (*ImpersonateUserRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ImpersonateUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ImpersonateUserRequest) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *ImpersonateUserRequest) GetExpiresInMinutes() int64 {
+	if x != nil {
+		return x.ExpiresInMinutes
+	}
+	return 0
+}
+
+type ImpersonateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiToken      string                 `protobuf:"bytes,1,opt,name=api_token,json=apiToken,proto3" json:"api_token,omitempty"` // temporary token for impersonation
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ImpersonateUserResponse) Reset() {
 	*x = ImpersonateUserResponse{}
-	mi := &file_admin_proto_msgTypes[12]
+	mi := &file_admin_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -816,7 +1181,7 @@ func (x *ImpersonateUserResponse) String() string {
 func (*ImpersonateUserResponse) ProtoMessage() {}
 
 func (x *ImpersonateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[12]
+	mi := &file_admin_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -829,7 +1194,7 @@ func (x *ImpersonateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ImpersonateUserResponse.ProtoReflect.Descriptor instead.
 func (*ImpersonateUserResponse) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{12}
+	return file_admin_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ImpersonateUserResponse) GetApiToken() string {
@@ -861,7 +1226,7 @@ type ListAllTokensRequest struct {
 
 func (x *ListAllTokensRequest) Reset() {
 	*x = ListAllTokensRequest{}
-	mi := &file_admin_proto_msgTypes[13]
+	mi := &file_admin_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -873,7 +1238,7 @@ func (x *ListAllTokensRequest) String() string {
 func (*ListAllTokensRequest) ProtoMessage() {}
 
 func (x *ListAllTokensRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[13]
+	mi := &file_admin_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -886,7 +1251,7 @@ func (x *ListAllTokensRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAllTokensRequest.ProtoReflect.Descriptor instead.
 func (*ListAllTokensRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{13}
+	return file_admin_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ListAllTokensRequest) GetPageSize() int32 {
@@ -942,7 +1307,7 @@ type ListAllTokensResponse struct {
 
 func (x *ListAllTokensResponse) Reset() {
 	*x = ListAllTokensResponse{}
-	mi := &file_admin_proto_msgTypes[14]
+	mi := &file_admin_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -954,7 +1319,7 @@ func (x *ListAllTokensResponse) String() string {
 func (*ListAllTokensResponse) ProtoMessage() {}
 
 func (x *ListAllTokensResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[14]
+	mi := &file_admin_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -967,7 +1332,7 @@ func (x *ListAllTokensResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAllTokensResponse.ProtoReflect.Descriptor instead.
 func (*ListAllTokensResponse) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{14}
+	return file_admin_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ListAllTokensResponse) GetTokens() []*TokenWithUser {
@@ -1001,7 +1366,7 @@ type TokenWithUser struct {
 
 func (x *TokenWithUser) Reset() {
 	*x = TokenWithUser{}
-	mi := &file_admin_proto_msgTypes[15]
+	mi := &file_admin_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1013,7 +1378,7 @@ func (x *TokenWithUser) String() string {
 func (*TokenWithUser) ProtoMessage() {}
 
 func (x *TokenWithUser) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[15]
+	mi := &file_admin_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1026,7 +1391,7 @@ func (x *TokenWithUser) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TokenWithUser.ProtoReflect.Descriptor instead.
 func (*TokenWithUser) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{15}
+	return file_admin_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *TokenWithUser) GetToken() *APITokenSummary {
@@ -1053,7 +1418,7 @@ type RevokeUserTokenRequest struct {
 
 func (x *RevokeUserTokenRequest) Reset() {
 	*x = RevokeUserTokenRequest{}
-	mi := &file_admin_proto_msgTypes[16]
+	mi := &file_admin_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1065,7 +1430,7 @@ func (x *RevokeUserTokenRequest) String() string {
 func (*RevokeUserTokenRequest) ProtoMessage() {}
 
 func (x *RevokeUserTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[16]
+	mi := &file_admin_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1078,7 +1443,7 @@ func (x *RevokeUserTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeUserTokenRequest.ProtoReflect.Descriptor instead.
 func (*RevokeUserTokenRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{16}
+	return file_admin_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *RevokeUserTokenRequest) GetUserId() string {
@@ -1107,7 +1472,7 @@ type GetConfigurationResponse struct {
 
 func (x *GetConfigurationResponse) Reset() {
 	*x = GetConfigurationResponse{}
-	mi := &file_admin_proto_msgTypes[17]
+	mi := &file_admin_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1119,7 +1484,7 @@ func (x *GetConfigurationResponse) String() string {
 func (*GetConfigurationResponse) ProtoMessage() {}
 
 func (x *GetConfigurationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[17]
+	mi := &file_admin_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1132,7 +1497,7 @@ func (x *GetConfigurationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConfigurationResponse.ProtoReflect.Descriptor instead.
 func (*GetConfigurationResponse) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{17}
+	return file_admin_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetConfigurationResponse) GetConfig() map[string]string {
@@ -1165,19 +1530,832 @@ type UpdateConfigurationRequest struct {
 
 func (x *UpdateConfigurationRequest) Reset() {
 	*x = UpdateConfigurationRequest{}
-	mi := &file_admin_proto_msgTypes[18]
+	mi := &file_admin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateConfigurationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateConfigurationRequest) ProtoMessage() {}
+
+func (x *UpdateConfigurationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateConfigurationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateConfigurationRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateConfigurationRequest) GetConfig() map[string]string {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type UpdateConfigurationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Warnings      []string               `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateConfigurationResponse) Reset() {
+	*x = UpdateConfigurationResponse{}
+	mi := &file_admin_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateConfigurationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateConfigurationResponse) ProtoMessage() {}
+
+func (x *UpdateConfigurationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateConfigurationResponse.ProtoReflect.Descriptor instead.
+func (*UpdateConfigurationResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateConfigurationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateConfigurationResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type RotateBootstrapTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewToken      string                 `protobuf:"bytes,1,opt,name=new_token,json=newToken,proto3" json:"new_token,omitempty"` // new bootstrap token
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateBootstrapTokenResponse) Reset() {
+	*x = RotateBootstrapTokenResponse{}
+	mi := &file_admin_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateBootstrapTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateBootstrapTokenResponse) ProtoMessage() {}
+
+func (x *RotateBootstrapTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateBootstrapTokenResponse.ProtoReflect.Descriptor instead.
+func (*RotateBootstrapTokenResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RotateBootstrapTokenResponse) GetNewToken() string {
+	if x != nil {
+		return x.NewToken
+	}
+	return ""
+}
+
+func (x *RotateBootstrapTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// Audit and Monitoring
+type GetAuditLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // filter by user
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`               // filter by action type
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditLogsRequest) Reset() {
+	*x = GetAuditLogsRequest{}
+	mi := &file_admin_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditLogsRequest) ProtoMessage() {}
+
+func (x *GetAuditLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditLogsRequest.ProtoReflect.Descriptor instead.
+func (*GetAuditLogsRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetAuditLogsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetAuditLogsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetAuditLogsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetAuditLogsRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *GetAuditLogsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetAuditLogsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type GetAuditLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditLogEntry       `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditLogsResponse) Reset() {
+	*x = GetAuditLogsResponse{}
+	mi := &file_admin_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditLogsResponse) ProtoMessage() {}
+
+func (x *GetAuditLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditLogsResponse.ProtoReflect.Descriptor instead.
+func (*GetAuditLogsResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetAuditLogsResponse) GetEntries() []*AuditLogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetAuditLogsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId   string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"` // Optional: filter by who performed the action
+	TargetType    string                 `protobuf:"bytes,2,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`      // Optional: "wiki_page", "note", "quote", "user", "token", ...
+	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`            // Optional: filter by target ID
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`         // Optional: inclusive
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`               // Optional: inclusive
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`                                 // Default: 50, max 200
+	Offset        int32                  `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditLogRequest) Reset() {
+	*x = ListAuditLogRequest{}
+	mi := &file_admin_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditLogRequest) ProtoMessage() {}
+
+func (x *ListAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListAuditLogRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *ListAuditLogRequest) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *ListAuditLogRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *ListAuditLogRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ListAuditLogRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ListAuditLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAuditLogRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListAuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditLogEntry       `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"` // Newest first
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditLogResponse) Reset() {
+	*x = ListAuditLogResponse{}
+	mi := &file_admin_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditLogResponse) ProtoMessage() {}
+
+func (x *ListAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListAuditLogResponse) GetEntries() []*AuditLogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListAuditLogResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type AuditLogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`                                 // "login", "create_token", "delete_snippet", etc.
+	ResourceType  string                 `protobuf:"bytes,5,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"` // "user", "token", "snippet"
+	ResourceId    string                 `protobuf:"bytes,6,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // additional context
+	IpAddress     string                 `protobuf:"bytes,8,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,9,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditLogEntry) Reset() {
+	*x = AuditLogEntry{}
+	mi := &file_admin_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogEntry) ProtoMessage() {}
+
+func (x *AuditLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogEntry.ProtoReflect.Descriptor instead.
+func (*AuditLogEntry) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AuditLogEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *AuditLogEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *AuditLogEntry) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+type MoveGuildContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourceGuildId string                 `protobuf:"bytes,1,opt,name=source_guild_id,json=sourceGuildId,proto3" json:"source_guild_id,omitempty"`
+	TargetGuildId string                 `protobuf:"bytes,2,opt,name=target_guild_id,json=targetGuildId,proto3" json:"target_guild_id,omitempty"`
+	// Must repeat source_guild_id and target_guild_id; not needed for a dry run
+	ConfirmSourceGuildId string   `protobuf:"bytes,3,opt,name=confirm_source_guild_id,json=confirmSourceGuildId,proto3" json:"confirm_source_guild_id,omitempty"`
+	ConfirmTargetGuildId string   `protobuf:"bytes,4,opt,name=confirm_target_guild_id,json=confirmTargetGuildId,proto3" json:"confirm_target_guild_id,omitempty"`
+	ContentTypes         []string `protobuf:"bytes,5,rep,name=content_types,json=contentTypes,proto3" json:"content_types,omitempty"` // "wiki", "note", "quote"; empty moves everything
+	DryRun               bool     `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                  // Report what would move without writing
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *MoveGuildContentRequest) Reset() {
+	*x = MoveGuildContentRequest{}
+	mi := &file_admin_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveGuildContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveGuildContentRequest) ProtoMessage() {}
+
+func (x *MoveGuildContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveGuildContentRequest.ProtoReflect.Descriptor instead.
+func (*MoveGuildContentRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MoveGuildContentRequest) GetSourceGuildId() string {
+	if x != nil {
+		return x.SourceGuildId
+	}
+	return ""
+}
+
+func (x *MoveGuildContentRequest) GetTargetGuildId() string {
+	if x != nil {
+		return x.TargetGuildId
+	}
+	return ""
+}
+
+func (x *MoveGuildContentRequest) GetConfirmSourceGuildId() string {
+	if x != nil {
+		return x.ConfirmSourceGuildId
+	}
+	return ""
+}
+
+func (x *MoveGuildContentRequest) GetConfirmTargetGuildId() string {
+	if x != nil {
+		return x.ConfirmTargetGuildId
+	}
+	return ""
+}
+
+func (x *MoveGuildContentRequest) GetContentTypes() []string {
+	if x != nil {
+		return x.ContentTypes
+	}
+	return nil
+}
+
+func (x *MoveGuildContentRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type MoveGuildContentResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	DryRun                bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	WikiPages             int32                  `protobuf:"varint,2,opt,name=wiki_pages,json=wikiPages,proto3" json:"wiki_pages,omitempty"`
+	WikiTitles            int32                  `protobuf:"varint,3,opt,name=wiki_titles,json=wikiTitles,proto3" json:"wiki_titles,omitempty"`
+	WikiReferences        int32                  `protobuf:"varint,4,opt,name=wiki_references,json=wikiReferences,proto3" json:"wiki_references,omitempty"`
+	Notes                 int32                  `protobuf:"varint,5,opt,name=notes,proto3" json:"notes,omitempty"`
+	NoteReferences        int32                  `protobuf:"varint,6,opt,name=note_references,json=noteReferences,proto3" json:"note_references,omitempty"`
+	Quotes                int32                  `protobuf:"varint,7,opt,name=quotes,proto3" json:"quotes,omitempty"`
+	ConflictingTitles     []string               `protobuf:"bytes,8,rep,name=conflicting_titles,json=conflictingTitles,proto3" json:"conflicting_titles,omitempty"`                // Wiki titles already used in the target guild; the move is refused while any exist
+	ConflictingNoteSlugs  []string               `protobuf:"bytes,9,rep,name=conflicting_note_slugs,json=conflictingNoteSlugs,proto3" json:"conflicting_note_slugs,omitempty"`     // Note slugs already used in the target guild; the move is refused while any exist
+	ConflictingQuoteCodes []string               `protobuf:"bytes,10,rep,name=conflicting_quote_codes,json=conflictingQuoteCodes,proto3" json:"conflicting_quote_codes,omitempty"` // Quote short codes already used in the target guild; the move is refused while any exist
+	ConflictingNoteTitles []string               `protobuf:"bytes,11,rep,name=conflicting_note_titles,json=conflictingNoteTitles,proto3" json:"conflicting_note_titles,omitempty"` // Unique note titles their author already used in the target guild; the move is refused while any exist
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *MoveGuildContentResponse) Reset() {
+	*x = MoveGuildContentResponse{}
+	mi := &file_admin_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveGuildContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveGuildContentResponse) ProtoMessage() {}
+
+func (x *MoveGuildContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveGuildContentResponse.ProtoReflect.Descriptor instead.
+func (*MoveGuildContentResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MoveGuildContentResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *MoveGuildContentResponse) GetWikiPages() int32 {
+	if x != nil {
+		return x.WikiPages
+	}
+	return 0
+}
+
+func (x *MoveGuildContentResponse) GetWikiTitles() int32 {
+	if x != nil {
+		return x.WikiTitles
+	}
+	return 0
+}
+
+func (x *MoveGuildContentResponse) GetWikiReferences() int32 {
+	if x != nil {
+		return x.WikiReferences
+	}
+	return 0
+}
+
+func (x *MoveGuildContentResponse) GetNotes() int32 {
+	if x != nil {
+		return x.Notes
+	}
+	return 0
+}
+
+func (x *MoveGuildContentResponse) GetNoteReferences() int32 {
+	if x != nil {
+		return x.NoteReferences
+	}
+	return 0
+}
+
+func (x *MoveGuildContentResponse) GetQuotes() int32 {
+	if x != nil {
+		return x.Quotes
+	}
+	return 0
+}
+
+func (x *MoveGuildContentResponse) GetConflictingTitles() []string {
+	if x != nil {
+		return x.ConflictingTitles
+	}
+	return nil
+}
+
+func (x *MoveGuildContentResponse) GetConflictingNoteSlugs() []string {
+	if x != nil {
+		return x.ConflictingNoteSlugs
+	}
+	return nil
+}
+
+func (x *MoveGuildContentResponse) GetConflictingQuoteCodes() []string {
+	if x != nil {
+		return x.ConflictingQuoteCodes
+	}
+	return nil
+}
+
+func (x *MoveGuildContentResponse) GetConflictingNoteTitles() []string {
+	if x != nil {
+		return x.ConflictingNoteTitles
+	}
+	return nil
+}
+
+// Changes who a wiki page, note, or quote is credited to, e.g. content migrated or
+// saved by the bot on someone else's behalf
+type ReassignAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentType   string                 `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // "wiki_page", "note", or "quote"
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"` // User ID of the new author
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignAuthorRequest) Reset() {
+	*x = ReassignAuthorRequest{}
+	mi := &file_admin_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignAuthorRequest) ProtoMessage() {}
+
+func (x *ReassignAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignAuthorRequest.ProtoReflect.Descriptor instead.
+func (*ReassignAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReassignAuthorRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ReassignAuthorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReassignAuthorRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+type ReassignAuthorResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ContentType      string                 `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Id               string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	GuildId          string                 `protobuf:"bytes,3,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Empty for personal notes
+	PreviousAuthorId string                 `protobuf:"bytes,4,opt,name=previous_author_id,json=previousAuthorId,proto3" json:"previous_author_id,omitempty"`
+	AuthorId         string                 `protobuf:"bytes,5,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	AuthorUsername   string                 `protobuf:"bytes,6,opt,name=author_username,json=authorUsername,proto3" json:"author_username,omitempty"` // New author's display name
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReassignAuthorResponse) Reset() {
+	*x = ReassignAuthorResponse{}
+	mi := &file_admin_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateConfigurationRequest) String() string {
+func (x *ReassignAuthorResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateConfigurationRequest) ProtoMessage() {}
+func (*ReassignAuthorResponse) ProtoMessage() {}
 
-func (x *UpdateConfigurationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[18]
+func (x *ReassignAuthorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1188,41 +2366,77 @@ func (x *UpdateConfigurationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateConfigurationRequest.ProtoReflect.Descriptor instead.
-func (*UpdateConfigurationRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ReassignAuthorResponse.ProtoReflect.Descriptor instead.
+func (*ReassignAuthorResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *UpdateConfigurationRequest) GetConfig() map[string]string {
+func (x *ReassignAuthorResponse) GetContentType() string {
 	if x != nil {
-		return x.Config
+		return x.ContentType
 	}
-	return nil
+	return ""
 }
 
-type UpdateConfigurationResponse struct {
+func (x *ReassignAuthorResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReassignAuthorResponse) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *ReassignAuthorResponse) GetPreviousAuthorId() string {
+	if x != nil {
+		return x.PreviousAuthorId
+	}
+	return ""
+}
+
+func (x *ReassignAuthorResponse) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ReassignAuthorResponse) GetAuthorUsername() string {
+	if x != nil {
+		return x.AuthorUsername
+	}
+	return ""
+}
+
+type ListFlaggedContentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Warnings      []string               `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	ContentType   string                 `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // Optional: "wiki_page", "note", or "quote"
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                               // Default: 50, max 200
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateConfigurationResponse) Reset() {
-	*x = UpdateConfigurationResponse{}
-	mi := &file_admin_proto_msgTypes[19]
+func (x *ListFlaggedContentRequest) Reset() {
+	*x = ListFlaggedContentRequest{}
+	mi := &file_admin_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateConfigurationResponse) String() string {
+func (x *ListFlaggedContentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateConfigurationResponse) ProtoMessage() {}
+func (*ListFlaggedContentRequest) ProtoMessage() {}
 
-func (x *UpdateConfigurationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[19]
+func (x *ListFlaggedContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1233,48 +2447,55 @@ func (x *UpdateConfigurationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateConfigurationResponse.ProtoReflect.Descriptor instead.
-func (*UpdateConfigurationResponse) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ListFlaggedContentRequest.ProtoReflect.Descriptor instead.
+func (*ListFlaggedContentRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *UpdateConfigurationResponse) GetSuccess() bool {
+func (x *ListFlaggedContentRequest) GetContentType() string {
 	if x != nil {
-		return x.Success
+		return x.ContentType
 	}
-	return false
+	return ""
 }
 
-func (x *UpdateConfigurationResponse) GetWarnings() []string {
+func (x *ListFlaggedContentRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Warnings
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-type RotateBootstrapTokenResponse struct {
+func (x *ListFlaggedContentRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListFlaggedContentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	NewToken      string                 `protobuf:"bytes,1,opt,name=new_token,json=newToken,proto3" json:"new_token,omitempty"` // new bootstrap token
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Items         []*FlaggedContent      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` // Most recently flagged first
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RotateBootstrapTokenResponse) Reset() {
-	*x = RotateBootstrapTokenResponse{}
-	mi := &file_admin_proto_msgTypes[20]
+func (x *ListFlaggedContentResponse) Reset() {
+	*x = ListFlaggedContentResponse{}
+	mi := &file_admin_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RotateBootstrapTokenResponse) String() string {
+func (x *ListFlaggedContentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RotateBootstrapTokenResponse) ProtoMessage() {}
+func (*ListFlaggedContentResponse) ProtoMessage() {}
 
-func (x *RotateBootstrapTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[20]
+func (x *ListFlaggedContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1285,53 +2506,55 @@ func (x *RotateBootstrapTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RotateBootstrapTokenResponse.ProtoReflect.Descriptor instead.
-func (*RotateBootstrapTokenResponse) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use ListFlaggedContentResponse.ProtoReflect.Descriptor instead.
+func (*ListFlaggedContentResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *RotateBootstrapTokenResponse) GetNewToken() string {
+func (x *ListFlaggedContentResponse) GetItems() []*FlaggedContent {
 	if x != nil {
-		return x.NewToken
+		return x.Items
 	}
-	return ""
+	return nil
 }
 
-func (x *RotateBootstrapTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+func (x *ListFlaggedContentResponse) GetTotal() int32 {
 	if x != nil {
-		return x.ExpiresAt
+		return x.Total
 	}
-	return nil
+	return 0
 }
 
-// Audit and Monitoring
-type GetAuditLogsRequest struct {
+// Content saved under the "flag" moderation policy that matched a blocklist pattern
+type FlaggedContent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // filter by user
-	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`               // filter by action type
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	ContentType   string                 `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // "wiki_page", "note", or "quote"
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	GuildId       string                 `protobuf:"bytes,3,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,4,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"` // Empty for quotes
+	Body          string                 `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"` // The pattern the content matched
+	FlaggedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=flagged_at,json=flaggedAt,proto3" json:"flagged_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAuditLogsRequest) Reset() {
-	*x = GetAuditLogsRequest{}
-	mi := &file_admin_proto_msgTypes[21]
+func (x *FlaggedContent) Reset() {
+	*x = FlaggedContent{}
+	mi := &file_admin_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAuditLogsRequest) String() string {
+func (x *FlaggedContent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAuditLogsRequest) ProtoMessage() {}
+func (*FlaggedContent) ProtoMessage() {}
 
-func (x *GetAuditLogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[21]
+func (x *FlaggedContent) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1342,76 +2565,89 @@ func (x *GetAuditLogsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAuditLogsRequest.ProtoReflect.Descriptor instead.
-func (*GetAuditLogsRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use FlaggedContent.ProtoReflect.Descriptor instead.
+func (*FlaggedContent) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *GetAuditLogsRequest) GetPageSize() int32 {
+func (x *FlaggedContent) GetContentType() string {
 	if x != nil {
-		return x.PageSize
+		return x.ContentType
 	}
-	return 0
+	return ""
 }
 
-func (x *GetAuditLogsRequest) GetPageToken() string {
+func (x *FlaggedContent) GetId() string {
 	if x != nil {
-		return x.PageToken
+		return x.Id
 	}
 	return ""
 }
 
-func (x *GetAuditLogsRequest) GetUserId() string {
+func (x *FlaggedContent) GetGuildId() string {
 	if x != nil {
-		return x.UserId
+		return x.GuildId
 	}
 	return ""
 }
 
-func (x *GetAuditLogsRequest) GetAction() string {
+func (x *FlaggedContent) GetAuthorId() string {
 	if x != nil {
-		return x.Action
+		return x.AuthorId
 	}
 	return ""
 }
 
-func (x *GetAuditLogsRequest) GetStartTime() *timestamppb.Timestamp {
+func (x *FlaggedContent) GetTitle() string {
 	if x != nil {
-		return x.StartTime
+		return x.Title
 	}
-	return nil
+	return ""
 }
 
-func (x *GetAuditLogsRequest) GetEndTime() *timestamppb.Timestamp {
+func (x *FlaggedContent) GetBody() string {
 	if x != nil {
-		return x.EndTime
+		return x.Body
+	}
+	return ""
+}
+
+func (x *FlaggedContent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *FlaggedContent) GetFlaggedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FlaggedAt
 	}
 	return nil
 }
 
-type GetAuditLogsResponse struct {
+type SetReadOnlyModeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entries       []*AuditLogEntry       `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	ReadOnly      bool                   `protobuf:"varint,1,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAuditLogsResponse) Reset() {
-	*x = GetAuditLogsResponse{}
-	mi := &file_admin_proto_msgTypes[22]
+func (x *SetReadOnlyModeRequest) Reset() {
+	*x = SetReadOnlyModeRequest{}
+	mi := &file_admin_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAuditLogsResponse) String() string {
+func (x *SetReadOnlyModeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAuditLogsResponse) ProtoMessage() {}
+func (*SetReadOnlyModeRequest) ProtoMessage() {}
 
-func (x *GetAuditLogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[22]
+func (x *SetReadOnlyModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1422,55 +2658,42 @@ func (x *GetAuditLogsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAuditLogsResponse.ProtoReflect.Descriptor instead.
-func (*GetAuditLogsResponse) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *GetAuditLogsResponse) GetEntries() []*AuditLogEntry {
-	if x != nil {
-		return x.Entries
-	}
-	return nil
+// Deprecated: Use SetReadOnlyModeRequest.ProtoReflect.Descriptor instead.
+func (*SetReadOnlyModeRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *GetAuditLogsResponse) GetNextPageToken() string {
+func (x *SetReadOnlyModeRequest) GetReadOnly() bool {
 	if x != nil {
-		return x.NextPageToken
+		return x.ReadOnly
 	}
-	return ""
+	return false
 }
 
-type AuditLogEntry struct {
+// ReadOnlyMode is this server's maintenance state. It is held in memory per server
+// process and resets to the configured value on restart.
+type ReadOnlyMode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`                                 // "login", "create_token", "delete_snippet", etc.
-	ResourceType  string                 `protobuf:"bytes,5,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"` // "user", "token", "snippet"
-	ResourceId    string                 `protobuf:"bytes,6,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // additional context
-	IpAddress     string                 `protobuf:"bytes,8,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
-	UserAgent     string                 `protobuf:"bytes,9,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	ReadOnly      bool                   `protobuf:"varint,1,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AuditLogEntry) Reset() {
-	*x = AuditLogEntry{}
-	mi := &file_admin_proto_msgTypes[23]
+func (x *ReadOnlyMode) Reset() {
+	*x = ReadOnlyMode{}
+	mi := &file_admin_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AuditLogEntry) String() string {
+func (x *ReadOnlyMode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AuditLogEntry) ProtoMessage() {}
+func (*ReadOnlyMode) ProtoMessage() {}
 
-func (x *AuditLogEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[23]
+func (x *ReadOnlyMode) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1481,72 +2704,16 @@ func (x *AuditLogEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AuditLogEntry.ProtoReflect.Descriptor instead.
-func (*AuditLogEntry) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *AuditLogEntry) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *AuditLogEntry) GetTimestamp() *timestamppb.Timestamp {
-	if x != nil {
-		return x.Timestamp
-	}
-	return nil
-}
-
-func (x *AuditLogEntry) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *AuditLogEntry) GetAction() string {
-	if x != nil {
-		return x.Action
-	}
-	return ""
-}
-
-func (x *AuditLogEntry) GetResourceType() string {
-	if x != nil {
-		return x.ResourceType
-	}
-	return ""
-}
-
-func (x *AuditLogEntry) GetResourceId() string {
-	if x != nil {
-		return x.ResourceId
-	}
-	return ""
-}
-
-func (x *AuditLogEntry) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
-}
-
-func (x *AuditLogEntry) GetIpAddress() string {
-	if x != nil {
-		return x.IpAddress
-	}
-	return ""
+// Deprecated: Use ReadOnlyMode.ProtoReflect.Descriptor instead.
+func (*ReadOnlyMode) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *AuditLogEntry) GetUserAgent() string {
+func (x *ReadOnlyMode) GetReadOnly() bool {
 	if x != nil {
-		return x.UserAgent
+		return x.ReadOnly
 	}
-	return ""
+	return false
 }
 
 type GetMetricsRequest struct {
@@ -1560,7 +2727,7 @@ type GetMetricsRequest struct {
 
 func (x *GetMetricsRequest) Reset() {
 	*x = GetMetricsRequest{}
-	mi := &file_admin_proto_msgTypes[24]
+	mi := &file_admin_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1572,7 +2739,7 @@ func (x *GetMetricsRequest) String() string {
 func (*GetMetricsRequest) ProtoMessage() {}
 
 func (x *GetMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[24]
+	mi := &file_admin_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1585,7 +2752,7 @@ func (x *GetMetricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMetricsRequest.ProtoReflect.Descriptor instead.
 func (*GetMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{24}
+	return file_admin_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *GetMetricsRequest) GetMetricName() string {
@@ -1618,7 +2785,7 @@ type GetMetricsResponse struct {
 
 func (x *GetMetricsResponse) Reset() {
 	*x = GetMetricsResponse{}
-	mi := &file_admin_proto_msgTypes[25]
+	mi := &file_admin_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1630,7 +2797,7 @@ func (x *GetMetricsResponse) String() string {
 func (*GetMetricsResponse) ProtoMessage() {}
 
 func (x *GetMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[25]
+	mi := &file_admin_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1643,7 +2810,7 @@ func (x *GetMetricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMetricsResponse.ProtoReflect.Descriptor instead.
 func (*GetMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{25}
+	return file_admin_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *GetMetricsResponse) GetMetrics() map[string]*MetricValue {
@@ -1668,7 +2835,7 @@ type MetricValue struct {
 
 func (x *MetricValue) Reset() {
 	*x = MetricValue{}
-	mi := &file_admin_proto_msgTypes[26]
+	mi := &file_admin_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1680,7 +2847,7 @@ func (x *MetricValue) String() string {
 func (*MetricValue) ProtoMessage() {}
 
 func (x *MetricValue) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[26]
+	mi := &file_admin_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1693,7 +2860,7 @@ func (x *MetricValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricValue.ProtoReflect.Descriptor instead.
 func (*MetricValue) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{26}
+	return file_admin_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *MetricValue) GetValue() isMetricValue_Value {
@@ -1769,7 +2936,7 @@ type HistogramValue struct {
 
 func (x *HistogramValue) Reset() {
 	*x = HistogramValue{}
-	mi := &file_admin_proto_msgTypes[27]
+	mi := &file_admin_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1781,7 +2948,7 @@ func (x *HistogramValue) String() string {
 func (*HistogramValue) ProtoMessage() {}
 
 func (x *HistogramValue) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[27]
+	mi := &file_admin_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1794,7 +2961,7 @@ func (x *HistogramValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HistogramValue.ProtoReflect.Descriptor instead.
 func (*HistogramValue) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{27}
+	return file_admin_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *HistogramValue) GetBuckets() []float64 {
@@ -1846,7 +3013,31 @@ const file_admin_proto_rawDesc = "" +
 	"\x05users\x18\x01 \x03(\v2\x16.hivemind.user.v1.UserR\x05users\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
 	"\vtotal_count\x18\x03 \x01(\x05R\n" +
-	"totalCount\"0\n" +
+	"totalCount\"\xe3\x01\n" +
+	"\x10ListUsersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12*\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x16.hivemind.user.v1.RoleR\x04role\x127\n" +
+	"\tuser_type\x18\x04 \x01(\x0e2\x1a.hivemind.user.v1.UserTypeR\buserType\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x16\n" +
+	"\x06search\x18\x06 \x01(\tR\x06search\"\x94\x01\n" +
+	"\x11ListUsersResponse\x126\n" +
+	"\x05users\x18\x01 \x03(\v2 .hivemind.admin.v1.UserListEntryR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"\xd4\x02\n" +
+	"\rUserListEntry\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12!\n" +
+	"\fdisplay_name\x18\x03 \x01(\tR\vdisplayName\x12*\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x16.hivemind.user.v1.RoleR\x04role\x127\n" +
+	"\tuser_type\x18\x05 \x01(\x0e2\x1a.hivemind.user.v1.UserTypeR\buserType\x12\x16\n" +
+	"\x06active\x18\x06 \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"last_login\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tlastLogin\"0\n" +
 	"\x15GetUserDetailsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc3\x01\n" +
 	"\x16GetUserDetailsResponse\x12*\n" +
@@ -1880,7 +3071,15 @@ const file_admin_proto_rawDesc = "" +
 	"\x11DeleteUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
 	"\vhard_delete\x18\x02 \x01(\bR\n" +
-	"hardDelete\"\x80\x01\n" +
+	"hardDelete\"l\n" +
+	"\x14SetUserActiveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\x12#\n" +
+	"\rrevoke_tokens\x18\x03 \x01(\bR\frevokeTokens\"o\n" +
+	"\x15SetUserActiveResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\x12%\n" +
+	"\x0etokens_revoked\x18\x03 \x01(\bR\rtokensRevoked\"\x80\x01\n" +
 	"\x16ImpersonateUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
 	"\vdevice_name\x18\x02 \x01(\tR\n" +
@@ -1939,7 +3138,20 @@ const file_admin_proto_rawDesc = "" +
 	"\bend_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"z\n" +
 	"\x14GetAuditLogsResponse\x12:\n" +
 	"\aentries\x18\x01 \x03(\v2 .hivemind.admin.v1.AuditLogEntryR\aentries\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x97\x03\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x97\x02\n" +
+	"\x13ListAuditLogRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12\x1f\n" +
+	"\vtarget_type\x18\x02 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\a \x01(\x05R\x06offset\"h\n" +
+	"\x14ListAuditLogResponse\x12:\n" +
+	"\aentries\x18\x01 \x03(\v2 .hivemind.admin.v1.AuditLogEntryR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\x97\x03\n" +
 	"\rAuditLogEntry\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x128\n" +
 	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x17\n" +
@@ -1955,7 +3167,61 @@ const file_admin_proto_rawDesc = "" +
 	"user_agent\x18\t \x01(\tR\tuserAgent\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa6\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x95\x02\n" +
+	"\x17MoveGuildContentRequest\x12&\n" +
+	"\x0fsource_guild_id\x18\x01 \x01(\tR\rsourceGuildId\x12&\n" +
+	"\x0ftarget_guild_id\x18\x02 \x01(\tR\rtargetGuildId\x125\n" +
+	"\x17confirm_source_guild_id\x18\x03 \x01(\tR\x14confirmSourceGuildId\x125\n" +
+	"\x17confirm_target_guild_id\x18\x04 \x01(\tR\x14confirmTargetGuildId\x12#\n" +
+	"\rcontent_types\x18\x05 \x03(\tR\fcontentTypes\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\"\xc8\x03\n" +
+	"\x18MoveGuildContentResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x1d\n" +
+	"\n" +
+	"wiki_pages\x18\x02 \x01(\x05R\twikiPages\x12\x1f\n" +
+	"\vwiki_titles\x18\x03 \x01(\x05R\n" +
+	"wikiTitles\x12'\n" +
+	"\x0fwiki_references\x18\x04 \x01(\x05R\x0ewikiReferences\x12\x14\n" +
+	"\x05notes\x18\x05 \x01(\x05R\x05notes\x12'\n" +
+	"\x0fnote_references\x18\x06 \x01(\x05R\x0enoteReferences\x12\x16\n" +
+	"\x06quotes\x18\a \x01(\x05R\x06quotes\x12-\n" +
+	"\x12conflicting_titles\x18\b \x03(\tR\x11conflictingTitles\x124\n" +
+	"\x16conflicting_note_slugs\x18\t \x03(\tR\x14conflictingNoteSlugs\x126\n" +
+	"\x17conflicting_quote_codes\x18\n" +
+	" \x03(\tR\x15conflictingQuoteCodes\x126\n" +
+	"\x17conflicting_note_titles\x18\v \x03(\tR\x15conflictingNoteTitles\"g\n" +
+	"\x15ReassignAuthorRequest\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\"\xda\x01\n" +
+	"\x16ReassignAuthorResponse\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x19\n" +
+	"\bguild_id\x18\x03 \x01(\tR\aguildId\x12,\n" +
+	"\x12previous_author_id\x18\x04 \x01(\tR\x10previousAuthorId\x12\x1b\n" +
+	"\tauthor_id\x18\x05 \x01(\tR\bauthorId\x12'\n" +
+	"\x0fauthor_username\x18\x06 \x01(\tR\x0eauthorUsername\"l\n" +
+	"\x19ListFlaggedContentRequest\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"k\n" +
+	"\x1aListFlaggedContentResponse\x127\n" +
+	"\x05items\x18\x01 \x03(\v2!.hivemind.admin.v1.FlaggedContentR\x05items\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xf8\x01\n" +
+	"\x0eFlaggedContent\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x19\n" +
+	"\bguild_id\x18\x03 \x01(\tR\aguildId\x12\x1b\n" +
+	"\tauthor_id\x18\x04 \x01(\tR\bauthorId\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x06 \x01(\tR\x04body\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"flagged_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tflaggedAt\"5\n" +
+	"\x16SetReadOnlyModeRequest\x12\x1b\n" +
+	"\tread_only\x18\x01 \x01(\bR\breadOnly\"+\n" +
+	"\fReadOnlyMode\x12\x1b\n" +
+	"\tread_only\x18\x01 \x01(\bR\breadOnly\"\xa6\x01\n" +
 	"\x11GetMetricsRequest\x12\x1f\n" +
 	"\vmetric_name\x18\x01 \x01(\tR\n" +
 	"metricName\x129\n" +
@@ -1975,26 +3241,33 @@ const file_admin_proto_rawDesc = "" +
 	"\x05value\"B\n" +
 	"\x0eHistogramValue\x12\x18\n" +
 	"\abuckets\x18\x01 \x03(\x01R\abuckets\x12\x16\n" +
-	"\x06counts\x18\x02 \x03(\x03R\x06counts2\xb5\n" +
-	"\n" +
+	"\x06counts\x18\x02 \x03(\x03R\x06counts2\xc4\x10\n" +
 	"\fAdminService\x12Q\n" +
 	"\rGetSystemInfo\x12\x16.google.protobuf.Empty\x1a(.hivemind.admin.v1.GetSystemInfoResponse\x12S\n" +
 	"\x0eGetHealthCheck\x12\x16.google.protobuf.Empty\x1a).hivemind.admin.v1.GetHealthCheckResponse\x12_\n" +
-	"\fListAllUsers\x12&.hivemind.admin.v1.ListAllUsersRequest\x1a'.hivemind.admin.v1.ListAllUsersResponse\x12e\n" +
+	"\fListAllUsers\x12&.hivemind.admin.v1.ListAllUsersRequest\x1a'.hivemind.admin.v1.ListAllUsersResponse\x12V\n" +
+	"\tListUsers\x12#.hivemind.admin.v1.ListUsersRequest\x1a$.hivemind.admin.v1.ListUsersResponse\x12e\n" +
 	"\x0eGetUserDetails\x12(.hivemind.admin.v1.GetUserDetailsRequest\x1a).hivemind.admin.v1.GetUserDetailsResponse\x12Y\n" +
 	"\n" +
 	"UpdateUser\x12$.hivemind.admin.v1.UpdateUserRequest\x1a%.hivemind.admin.v1.UpdateUserResponse\x12J\n" +
 	"\n" +
-	"DeleteUser\x12$.hivemind.admin.v1.DeleteUserRequest\x1a\x16.google.protobuf.Empty\x12h\n" +
+	"DeleteUser\x12$.hivemind.admin.v1.DeleteUserRequest\x1a\x16.google.protobuf.Empty\x12b\n" +
+	"\rSetUserActive\x12'.hivemind.admin.v1.SetUserActiveRequest\x1a(.hivemind.admin.v1.SetUserActiveResponse\x12h\n" +
 	"\x0fImpersonateUser\x12).hivemind.admin.v1.ImpersonateUserRequest\x1a*.hivemind.admin.v1.ImpersonateUserResponse\x12b\n" +
 	"\rListAllTokens\x12'.hivemind.admin.v1.ListAllTokensRequest\x1a(.hivemind.admin.v1.ListAllTokensResponse\x12T\n" +
 	"\x0fRevokeUserToken\x12).hivemind.admin.v1.RevokeUserTokenRequest\x1a\x16.google.protobuf.Empty\x12W\n" +
 	"\x10GetConfiguration\x12\x16.google.protobuf.Empty\x1a+.hivemind.admin.v1.GetConfigurationResponse\x12t\n" +
 	"\x13UpdateConfiguration\x12-.hivemind.admin.v1.UpdateConfigurationRequest\x1a..hivemind.admin.v1.UpdateConfigurationResponse\x12_\n" +
 	"\x14RotateBootstrapToken\x12\x16.google.protobuf.Empty\x1a/.hivemind.admin.v1.RotateBootstrapTokenResponse\x12_\n" +
-	"\fGetAuditLogs\x12&.hivemind.admin.v1.GetAuditLogsRequest\x1a'.hivemind.admin.v1.GetAuditLogsResponse\x12Y\n" +
+	"\fGetAuditLogs\x12&.hivemind.admin.v1.GetAuditLogsRequest\x1a'.hivemind.admin.v1.GetAuditLogsResponse\x12_\n" +
+	"\fListAuditLog\x12&.hivemind.admin.v1.ListAuditLogRequest\x1a'.hivemind.admin.v1.ListAuditLogResponse\x12Y\n" +
 	"\n" +
-	"GetMetrics\x12$.hivemind.admin.v1.GetMetricsRequest\x1a%.hivemind.admin.v1.GetMetricsResponseB=Z;github.com/devilmonastery/hivemind/api/generated/go/adminpbb\x06proto3"
+	"GetMetrics\x12$.hivemind.admin.v1.GetMetricsRequest\x1a%.hivemind.admin.v1.GetMetricsResponse\x12k\n" +
+	"\x10MoveGuildContent\x12*.hivemind.admin.v1.MoveGuildContentRequest\x1a+.hivemind.admin.v1.MoveGuildContentResponse\x12e\n" +
+	"\x0eReassignAuthor\x12(.hivemind.admin.v1.ReassignAuthorRequest\x1a).hivemind.admin.v1.ReassignAuthorResponse\x12q\n" +
+	"\x12ListFlaggedContent\x12,.hivemind.admin.v1.ListFlaggedContentRequest\x1a-.hivemind.admin.v1.ListFlaggedContentResponse\x12J\n" +
+	"\x0fGetReadOnlyMode\x12\x16.google.protobuf.Empty\x1a\x1f.hivemind.admin.v1.ReadOnlyMode\x12]\n" +
+	"\x0fSetReadOnlyMode\x12).hivemind.admin.v1.SetReadOnlyModeRequest\x1a\x1f.hivemind.admin.v1.ReadOnlyModeB=Z;github.com/devilmonastery/hivemind/api/generated/go/adminpbb\x06proto3"
 
 var (
 	file_admin_proto_rawDescOnce sync.Once
@@ -2008,111 +3281,156 @@ func file_admin_proto_rawDescGZIP() []byte {
 	return file_admin_proto_rawDescData
 }
 
-var file_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_admin_proto_goTypes = []any{
 	(*GetSystemInfoResponse)(nil),        // 0: hivemind.admin.v1.GetSystemInfoResponse
 	(*GetHealthCheckResponse)(nil),       // 1: hivemind.admin.v1.GetHealthCheckResponse
 	(*ListAllUsersRequest)(nil),          // 2: hivemind.admin.v1.ListAllUsersRequest
 	(*ListAllUsersResponse)(nil),         // 3: hivemind.admin.v1.ListAllUsersResponse
-	(*GetUserDetailsRequest)(nil),        // 4: hivemind.admin.v1.GetUserDetailsRequest
-	(*GetUserDetailsResponse)(nil),       // 5: hivemind.admin.v1.GetUserDetailsResponse
-	(*APITokenSummary)(nil),              // 6: hivemind.admin.v1.APITokenSummary
-	(*UserStatistics)(nil),               // 7: hivemind.admin.v1.UserStatistics
-	(*UpdateUserRequest)(nil),            // 8: hivemind.admin.v1.UpdateUserRequest
-	(*UpdateUserResponse)(nil),           // 9: hivemind.admin.v1.UpdateUserResponse
-	(*DeleteUserRequest)(nil),            // 10: hivemind.admin.v1.DeleteUserRequest
-	(*ImpersonateUserRequest)(nil),       // 11: hivemind.admin.v1.ImpersonateUserRequest
-	(*ImpersonateUserResponse)(nil),      // 12: hivemind.admin.v1.ImpersonateUserResponse
-	(*ListAllTokensRequest)(nil),         // 13: hivemind.admin.v1.ListAllTokensRequest
-	(*ListAllTokensResponse)(nil),        // 14: hivemind.admin.v1.ListAllTokensResponse
-	(*TokenWithUser)(nil),                // 15: hivemind.admin.v1.TokenWithUser
-	(*RevokeUserTokenRequest)(nil),       // 16: hivemind.admin.v1.RevokeUserTokenRequest
-	(*GetConfigurationResponse)(nil),     // 17: hivemind.admin.v1.GetConfigurationResponse
-	(*UpdateConfigurationRequest)(nil),   // 18: hivemind.admin.v1.UpdateConfigurationRequest
-	(*UpdateConfigurationResponse)(nil),  // 19: hivemind.admin.v1.UpdateConfigurationResponse
-	(*RotateBootstrapTokenResponse)(nil), // 20: hivemind.admin.v1.RotateBootstrapTokenResponse
-	(*GetAuditLogsRequest)(nil),          // 21: hivemind.admin.v1.GetAuditLogsRequest
-	(*GetAuditLogsResponse)(nil),         // 22: hivemind.admin.v1.GetAuditLogsResponse
-	(*AuditLogEntry)(nil),                // 23: hivemind.admin.v1.AuditLogEntry
-	(*GetMetricsRequest)(nil),            // 24: hivemind.admin.v1.GetMetricsRequest
-	(*GetMetricsResponse)(nil),           // 25: hivemind.admin.v1.GetMetricsResponse
-	(*MetricValue)(nil),                  // 26: hivemind.admin.v1.MetricValue
-	(*HistogramValue)(nil),               // 27: hivemind.admin.v1.HistogramValue
-	nil,                                  // 28: hivemind.admin.v1.GetHealthCheckResponse.ChecksEntry
-	nil,                                  // 29: hivemind.admin.v1.GetConfigurationResponse.ConfigEntry
-	nil,                                  // 30: hivemind.admin.v1.UpdateConfigurationRequest.ConfigEntry
-	nil,                                  // 31: hivemind.admin.v1.AuditLogEntry.MetadataEntry
-	nil,                                  // 32: hivemind.admin.v1.GetMetricsResponse.MetricsEntry
-	(*timestamppb.Timestamp)(nil),        // 33: google.protobuf.Timestamp
-	(*userpb.User)(nil),                  // 34: hivemind.user.v1.User
-	(userpb.Role)(0),                     // 35: hivemind.user.v1.Role
-	(*emptypb.Empty)(nil),                // 36: google.protobuf.Empty
+	(*ListUsersRequest)(nil),             // 4: hivemind.admin.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),            // 5: hivemind.admin.v1.ListUsersResponse
+	(*UserListEntry)(nil),                // 6: hivemind.admin.v1.UserListEntry
+	(*GetUserDetailsRequest)(nil),        // 7: hivemind.admin.v1.GetUserDetailsRequest
+	(*GetUserDetailsResponse)(nil),       // 8: hivemind.admin.v1.GetUserDetailsResponse
+	(*APITokenSummary)(nil),              // 9: hivemind.admin.v1.APITokenSummary
+	(*UserStatistics)(nil),               // 10: hivemind.admin.v1.UserStatistics
+	(*UpdateUserRequest)(nil),            // 11: hivemind.admin.v1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),           // 12: hivemind.admin.v1.UpdateUserResponse
+	(*DeleteUserRequest)(nil),            // 13: hivemind.admin.v1.DeleteUserRequest
+	(*SetUserActiveRequest)(nil),         // 14: hivemind.admin.v1.SetUserActiveRequest
+	(*SetUserActiveResponse)(nil),        // 15: hivemind.admin.v1.SetUserActiveResponse
+	(*ImpersonateUserRequest)(nil),       // 16: hivemind.admin.v1.ImpersonateUserRequest
+	(*ImpersonateUserResponse)(nil),      // 17: hivemind.admin.v1.ImpersonateUserResponse
+	(*ListAllTokensRequest)(nil),         // 18: hivemind.admin.v1.ListAllTokensRequest
+	(*ListAllTokensResponse)(nil),        // 19: hivemind.admin.v1.ListAllTokensResponse
+	(*TokenWithUser)(nil),                // 20: hivemind.admin.v1.TokenWithUser
+	(*RevokeUserTokenRequest)(nil),       // 21: hivemind.admin.v1.RevokeUserTokenRequest
+	(*GetConfigurationResponse)(nil),     // 22: hivemind.admin.v1.GetConfigurationResponse
+	(*UpdateConfigurationRequest)(nil),   // 23: hivemind.admin.v1.UpdateConfigurationRequest
+	(*UpdateConfigurationResponse)(nil),  // 24: hivemind.admin.v1.UpdateConfigurationResponse
+	(*RotateBootstrapTokenResponse)(nil), // 25: hivemind.admin.v1.RotateBootstrapTokenResponse
+	(*GetAuditLogsRequest)(nil),          // 26: hivemind.admin.v1.GetAuditLogsRequest
+	(*GetAuditLogsResponse)(nil),         // 27: hivemind.admin.v1.GetAuditLogsResponse
+	(*ListAuditLogRequest)(nil),          // 28: hivemind.admin.v1.ListAuditLogRequest
+	(*ListAuditLogResponse)(nil),         // 29: hivemind.admin.v1.ListAuditLogResponse
+	(*AuditLogEntry)(nil),                // 30: hivemind.admin.v1.AuditLogEntry
+	(*MoveGuildContentRequest)(nil),      // 31: hivemind.admin.v1.MoveGuildContentRequest
+	(*MoveGuildContentResponse)(nil),     // 32: hivemind.admin.v1.MoveGuildContentResponse
+	(*ReassignAuthorRequest)(nil),        // 33: hivemind.admin.v1.ReassignAuthorRequest
+	(*ReassignAuthorResponse)(nil),       // 34: hivemind.admin.v1.ReassignAuthorResponse
+	(*ListFlaggedContentRequest)(nil),    // 35: hivemind.admin.v1.ListFlaggedContentRequest
+	(*ListFlaggedContentResponse)(nil),   // 36: hivemind.admin.v1.ListFlaggedContentResponse
+	(*FlaggedContent)(nil),               // 37: hivemind.admin.v1.FlaggedContent
+	(*SetReadOnlyModeRequest)(nil),       // 38: hivemind.admin.v1.SetReadOnlyModeRequest
+	(*ReadOnlyMode)(nil),                 // 39: hivemind.admin.v1.ReadOnlyMode
+	(*GetMetricsRequest)(nil),            // 40: hivemind.admin.v1.GetMetricsRequest
+	(*GetMetricsResponse)(nil),           // 41: hivemind.admin.v1.GetMetricsResponse
+	(*MetricValue)(nil),                  // 42: hivemind.admin.v1.MetricValue
+	(*HistogramValue)(nil),               // 43: hivemind.admin.v1.HistogramValue
+	nil,                                  // 44: hivemind.admin.v1.GetHealthCheckResponse.ChecksEntry
+	nil,                                  // 45: hivemind.admin.v1.GetConfigurationResponse.ConfigEntry
+	nil,                                  // 46: hivemind.admin.v1.UpdateConfigurationRequest.ConfigEntry
+	nil,                                  // 47: hivemind.admin.v1.AuditLogEntry.MetadataEntry
+	nil,                                  // 48: hivemind.admin.v1.GetMetricsResponse.MetricsEntry
+	(*timestamppb.Timestamp)(nil),        // 49: google.protobuf.Timestamp
+	(*userpb.User)(nil),                  // 50: hivemind.user.v1.User
+	(userpb.Role)(0),                     // 51: hivemind.user.v1.Role
+	(userpb.UserType)(0),                 // 52: hivemind.user.v1.UserType
+	(*emptypb.Empty)(nil),                // 53: google.protobuf.Empty
 }
 var file_admin_proto_depIdxs = []int32{
-	33, // 0: hivemind.admin.v1.GetSystemInfoResponse.start_time:type_name -> google.protobuf.Timestamp
-	28, // 1: hivemind.admin.v1.GetHealthCheckResponse.checks:type_name -> hivemind.admin.v1.GetHealthCheckResponse.ChecksEntry
-	33, // 2: hivemind.admin.v1.GetHealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
-	34, // 3: hivemind.admin.v1.ListAllUsersResponse.users:type_name -> hivemind.user.v1.User
-	34, // 4: hivemind.admin.v1.GetUserDetailsResponse.user:type_name -> hivemind.user.v1.User
-	6,  // 5: hivemind.admin.v1.GetUserDetailsResponse.tokens:type_name -> hivemind.admin.v1.APITokenSummary
-	7,  // 6: hivemind.admin.v1.GetUserDetailsResponse.statistics:type_name -> hivemind.admin.v1.UserStatistics
-	33, // 7: hivemind.admin.v1.APITokenSummary.created_at:type_name -> google.protobuf.Timestamp
-	33, // 8: hivemind.admin.v1.APITokenSummary.last_used:type_name -> google.protobuf.Timestamp
-	33, // 9: hivemind.admin.v1.UserStatistics.first_snippet:type_name -> google.protobuf.Timestamp
-	33, // 10: hivemind.admin.v1.UserStatistics.last_activity:type_name -> google.protobuf.Timestamp
-	35, // 11: hivemind.admin.v1.UpdateUserRequest.role:type_name -> hivemind.user.v1.Role
-	34, // 12: hivemind.admin.v1.UpdateUserResponse.user:type_name -> hivemind.user.v1.User
-	33, // 13: hivemind.admin.v1.ImpersonateUserResponse.expires_at:type_name -> google.protobuf.Timestamp
-	15, // 14: hivemind.admin.v1.ListAllTokensResponse.tokens:type_name -> hivemind.admin.v1.TokenWithUser
-	6,  // 15: hivemind.admin.v1.TokenWithUser.token:type_name -> hivemind.admin.v1.APITokenSummary
-	34, // 16: hivemind.admin.v1.TokenWithUser.user:type_name -> hivemind.user.v1.User
-	29, // 17: hivemind.admin.v1.GetConfigurationResponse.config:type_name -> hivemind.admin.v1.GetConfigurationResponse.ConfigEntry
-	30, // 18: hivemind.admin.v1.UpdateConfigurationRequest.config:type_name -> hivemind.admin.v1.UpdateConfigurationRequest.ConfigEntry
-	33, // 19: hivemind.admin.v1.RotateBootstrapTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
-	33, // 20: hivemind.admin.v1.GetAuditLogsRequest.start_time:type_name -> google.protobuf.Timestamp
-	33, // 21: hivemind.admin.v1.GetAuditLogsRequest.end_time:type_name -> google.protobuf.Timestamp
-	23, // 22: hivemind.admin.v1.GetAuditLogsResponse.entries:type_name -> hivemind.admin.v1.AuditLogEntry
-	33, // 23: hivemind.admin.v1.AuditLogEntry.timestamp:type_name -> google.protobuf.Timestamp
-	31, // 24: hivemind.admin.v1.AuditLogEntry.metadata:type_name -> hivemind.admin.v1.AuditLogEntry.MetadataEntry
-	33, // 25: hivemind.admin.v1.GetMetricsRequest.start_time:type_name -> google.protobuf.Timestamp
-	33, // 26: hivemind.admin.v1.GetMetricsRequest.end_time:type_name -> google.protobuf.Timestamp
-	32, // 27: hivemind.admin.v1.GetMetricsResponse.metrics:type_name -> hivemind.admin.v1.GetMetricsResponse.MetricsEntry
-	27, // 28: hivemind.admin.v1.MetricValue.histogram:type_name -> hivemind.admin.v1.HistogramValue
-	33, // 29: hivemind.admin.v1.MetricValue.timestamp:type_name -> google.protobuf.Timestamp
-	26, // 30: hivemind.admin.v1.GetMetricsResponse.MetricsEntry.value:type_name -> hivemind.admin.v1.MetricValue
-	36, // 31: hivemind.admin.v1.AdminService.GetSystemInfo:input_type -> google.protobuf.Empty
-	36, // 32: hivemind.admin.v1.AdminService.GetHealthCheck:input_type -> google.protobuf.Empty
-	2,  // 33: hivemind.admin.v1.AdminService.ListAllUsers:input_type -> hivemind.admin.v1.ListAllUsersRequest
-	4,  // 34: hivemind.admin.v1.AdminService.GetUserDetails:input_type -> hivemind.admin.v1.GetUserDetailsRequest
-	8,  // 35: hivemind.admin.v1.AdminService.UpdateUser:input_type -> hivemind.admin.v1.UpdateUserRequest
-	10, // 36: hivemind.admin.v1.AdminService.DeleteUser:input_type -> hivemind.admin.v1.DeleteUserRequest
-	11, // 37: hivemind.admin.v1.AdminService.ImpersonateUser:input_type -> hivemind.admin.v1.ImpersonateUserRequest
-	13, // 38: hivemind.admin.v1.AdminService.ListAllTokens:input_type -> hivemind.admin.v1.ListAllTokensRequest
-	16, // 39: hivemind.admin.v1.AdminService.RevokeUserToken:input_type -> hivemind.admin.v1.RevokeUserTokenRequest
-	36, // 40: hivemind.admin.v1.AdminService.GetConfiguration:input_type -> google.protobuf.Empty
-	18, // 41: hivemind.admin.v1.AdminService.UpdateConfiguration:input_type -> hivemind.admin.v1.UpdateConfigurationRequest
-	36, // 42: hivemind.admin.v1.AdminService.RotateBootstrapToken:input_type -> google.protobuf.Empty
-	21, // 43: hivemind.admin.v1.AdminService.GetAuditLogs:input_type -> hivemind.admin.v1.GetAuditLogsRequest
-	24, // 44: hivemind.admin.v1.AdminService.GetMetrics:input_type -> hivemind.admin.v1.GetMetricsRequest
-	0,  // 45: hivemind.admin.v1.AdminService.GetSystemInfo:output_type -> hivemind.admin.v1.GetSystemInfoResponse
-	1,  // 46: hivemind.admin.v1.AdminService.GetHealthCheck:output_type -> hivemind.admin.v1.GetHealthCheckResponse
-	3,  // 47: hivemind.admin.v1.AdminService.ListAllUsers:output_type -> hivemind.admin.v1.ListAllUsersResponse
-	5,  // 48: hivemind.admin.v1.AdminService.GetUserDetails:output_type -> hivemind.admin.v1.GetUserDetailsResponse
-	9,  // 49: hivemind.admin.v1.AdminService.UpdateUser:output_type -> hivemind.admin.v1.UpdateUserResponse
-	36, // 50: hivemind.admin.v1.AdminService.DeleteUser:output_type -> google.protobuf.Empty
-	12, // 51: hivemind.admin.v1.AdminService.ImpersonateUser:output_type -> hivemind.admin.v1.ImpersonateUserResponse
-	14, // 52: hivemind.admin.v1.AdminService.ListAllTokens:output_type -> hivemind.admin.v1.ListAllTokensResponse
-	36, // 53: hivemind.admin.v1.AdminService.RevokeUserToken:output_type -> google.protobuf.Empty
-	17, // 54: hivemind.admin.v1.AdminService.GetConfiguration:output_type -> hivemind.admin.v1.GetConfigurationResponse
-	19, // 55: hivemind.admin.v1.AdminService.UpdateConfiguration:output_type -> hivemind.admin.v1.UpdateConfigurationResponse
-	20, // 56: hivemind.admin.v1.AdminService.RotateBootstrapToken:output_type -> hivemind.admin.v1.RotateBootstrapTokenResponse
-	22, // 57: hivemind.admin.v1.AdminService.GetAuditLogs:output_type -> hivemind.admin.v1.GetAuditLogsResponse
-	25, // 58: hivemind.admin.v1.AdminService.GetMetrics:output_type -> hivemind.admin.v1.GetMetricsResponse
-	45, // [45:59] is the sub-list for method output_type
-	31, // [31:45] is the sub-list for method input_type
-	31, // [31:31] is the sub-list for extension type_name
-	31, // [31:31] is the sub-list for extension extendee
-	0,  // [0:31] is the sub-list for field type_name
+	49, // 0: hivemind.admin.v1.GetSystemInfoResponse.start_time:type_name -> google.protobuf.Timestamp
+	44, // 1: hivemind.admin.v1.GetHealthCheckResponse.checks:type_name -> hivemind.admin.v1.GetHealthCheckResponse.ChecksEntry
+	49, // 2: hivemind.admin.v1.GetHealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
+	50, // 3: hivemind.admin.v1.ListAllUsersResponse.users:type_name -> hivemind.user.v1.User
+	51, // 4: hivemind.admin.v1.ListUsersRequest.role:type_name -> hivemind.user.v1.Role
+	52, // 5: hivemind.admin.v1.ListUsersRequest.user_type:type_name -> hivemind.user.v1.UserType
+	6,  // 6: hivemind.admin.v1.ListUsersResponse.users:type_name -> hivemind.admin.v1.UserListEntry
+	51, // 7: hivemind.admin.v1.UserListEntry.role:type_name -> hivemind.user.v1.Role
+	52, // 8: hivemind.admin.v1.UserListEntry.user_type:type_name -> hivemind.user.v1.UserType
+	49, // 9: hivemind.admin.v1.UserListEntry.created_at:type_name -> google.protobuf.Timestamp
+	49, // 10: hivemind.admin.v1.UserListEntry.last_login:type_name -> google.protobuf.Timestamp
+	50, // 11: hivemind.admin.v1.GetUserDetailsResponse.user:type_name -> hivemind.user.v1.User
+	9,  // 12: hivemind.admin.v1.GetUserDetailsResponse.tokens:type_name -> hivemind.admin.v1.APITokenSummary
+	10, // 13: hivemind.admin.v1.GetUserDetailsResponse.statistics:type_name -> hivemind.admin.v1.UserStatistics
+	49, // 14: hivemind.admin.v1.APITokenSummary.created_at:type_name -> google.protobuf.Timestamp
+	49, // 15: hivemind.admin.v1.APITokenSummary.last_used:type_name -> google.protobuf.Timestamp
+	49, // 16: hivemind.admin.v1.UserStatistics.first_snippet:type_name -> google.protobuf.Timestamp
+	49, // 17: hivemind.admin.v1.UserStatistics.last_activity:type_name -> google.protobuf.Timestamp
+	51, // 18: hivemind.admin.v1.UpdateUserRequest.role:type_name -> hivemind.user.v1.Role
+	50, // 19: hivemind.admin.v1.UpdateUserResponse.user:type_name -> hivemind.user.v1.User
+	49, // 20: hivemind.admin.v1.ImpersonateUserResponse.expires_at:type_name -> google.protobuf.Timestamp
+	20, // 21: hivemind.admin.v1.ListAllTokensResponse.tokens:type_name -> hivemind.admin.v1.TokenWithUser
+	9,  // 22: hivemind.admin.v1.TokenWithUser.token:type_name -> hivemind.admin.v1.APITokenSummary
+	50, // 23: hivemind.admin.v1.TokenWithUser.user:type_name -> hivemind.user.v1.User
+	45, // 24: hivemind.admin.v1.GetConfigurationResponse.config:type_name -> hivemind.admin.v1.GetConfigurationResponse.ConfigEntry
+	46, // 25: hivemind.admin.v1.UpdateConfigurationRequest.config:type_name -> hivemind.admin.v1.UpdateConfigurationRequest.ConfigEntry
+	49, // 26: hivemind.admin.v1.RotateBootstrapTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	49, // 27: hivemind.admin.v1.GetAuditLogsRequest.start_time:type_name -> google.protobuf.Timestamp
+	49, // 28: hivemind.admin.v1.GetAuditLogsRequest.end_time:type_name -> google.protobuf.Timestamp
+	30, // 29: hivemind.admin.v1.GetAuditLogsResponse.entries:type_name -> hivemind.admin.v1.AuditLogEntry
+	49, // 30: hivemind.admin.v1.ListAuditLogRequest.start_time:type_name -> google.protobuf.Timestamp
+	49, // 31: hivemind.admin.v1.ListAuditLogRequest.end_time:type_name -> google.protobuf.Timestamp
+	30, // 32: hivemind.admin.v1.ListAuditLogResponse.entries:type_name -> hivemind.admin.v1.AuditLogEntry
+	49, // 33: hivemind.admin.v1.AuditLogEntry.timestamp:type_name -> google.protobuf.Timestamp
+	47, // 34: hivemind.admin.v1.AuditLogEntry.metadata:type_name -> hivemind.admin.v1.AuditLogEntry.MetadataEntry
+	37, // 35: hivemind.admin.v1.ListFlaggedContentResponse.items:type_name -> hivemind.admin.v1.FlaggedContent
+	49, // 36: hivemind.admin.v1.FlaggedContent.flagged_at:type_name -> google.protobuf.Timestamp
+	49, // 37: hivemind.admin.v1.GetMetricsRequest.start_time:type_name -> google.protobuf.Timestamp
+	49, // 38: hivemind.admin.v1.GetMetricsRequest.end_time:type_name -> google.protobuf.Timestamp
+	48, // 39: hivemind.admin.v1.GetMetricsResponse.metrics:type_name -> hivemind.admin.v1.GetMetricsResponse.MetricsEntry
+	43, // 40: hivemind.admin.v1.MetricValue.histogram:type_name -> hivemind.admin.v1.HistogramValue
+	49, // 41: hivemind.admin.v1.MetricValue.timestamp:type_name -> google.protobuf.Timestamp
+	42, // 42: hivemind.admin.v1.GetMetricsResponse.MetricsEntry.value:type_name -> hivemind.admin.v1.MetricValue
+	53, // 43: hivemind.admin.v1.AdminService.GetSystemInfo:input_type -> google.protobuf.Empty
+	53, // 44: hivemind.admin.v1.AdminService.GetHealthCheck:input_type -> google.protobuf.Empty
+	2,  // 45: hivemind.admin.v1.AdminService.ListAllUsers:input_type -> hivemind.admin.v1.ListAllUsersRequest
+	4,  // 46: hivemind.admin.v1.AdminService.ListUsers:input_type -> hivemind.admin.v1.ListUsersRequest
+	7,  // 47: hivemind.admin.v1.AdminService.GetUserDetails:input_type -> hivemind.admin.v1.GetUserDetailsRequest
+	11, // 48: hivemind.admin.v1.AdminService.UpdateUser:input_type -> hivemind.admin.v1.UpdateUserRequest
+	13, // 49: hivemind.admin.v1.AdminService.DeleteUser:input_type -> hivemind.admin.v1.DeleteUserRequest
+	14, // 50: hivemind.admin.v1.AdminService.SetUserActive:input_type -> hivemind.admin.v1.SetUserActiveRequest
+	16, // 51: hivemind.admin.v1.AdminService.ImpersonateUser:input_type -> hivemind.admin.v1.ImpersonateUserRequest
+	18, // 52: hivemind.admin.v1.AdminService.ListAllTokens:input_type -> hivemind.admin.v1.ListAllTokensRequest
+	21, // 53: hivemind.admin.v1.AdminService.RevokeUserToken:input_type -> hivemind.admin.v1.RevokeUserTokenRequest
+	53, // 54: hivemind.admin.v1.AdminService.GetConfiguration:input_type -> google.protobuf.Empty
+	23, // 55: hivemind.admin.v1.AdminService.UpdateConfiguration:input_type -> hivemind.admin.v1.UpdateConfigurationRequest
+	53, // 56: hivemind.admin.v1.AdminService.RotateBootstrapToken:input_type -> google.protobuf.Empty
+	26, // 57: hivemind.admin.v1.AdminService.GetAuditLogs:input_type -> hivemind.admin.v1.GetAuditLogsRequest
+	28, // 58: hivemind.admin.v1.AdminService.ListAuditLog:input_type -> hivemind.admin.v1.ListAuditLogRequest
+	40, // 59: hivemind.admin.v1.AdminService.GetMetrics:input_type -> hivemind.admin.v1.GetMetricsRequest
+	31, // 60: hivemind.admin.v1.AdminService.MoveGuildContent:input_type -> hivemind.admin.v1.MoveGuildContentRequest
+	33, // 61: hivemind.admin.v1.AdminService.ReassignAuthor:input_type -> hivemind.admin.v1.ReassignAuthorRequest
+	35, // 62: hivemind.admin.v1.AdminService.ListFlaggedContent:input_type -> hivemind.admin.v1.ListFlaggedContentRequest
+	53, // 63: hivemind.admin.v1.AdminService.GetReadOnlyMode:input_type -> google.protobuf.Empty
+	38, // 64: hivemind.admin.v1.AdminService.SetReadOnlyMode:input_type -> hivemind.admin.v1.SetReadOnlyModeRequest
+	0,  // 65: hivemind.admin.v1.AdminService.GetSystemInfo:output_type -> hivemind.admin.v1.GetSystemInfoResponse
+	1,  // 66: hivemind.admin.v1.AdminService.GetHealthCheck:output_type -> hivemind.admin.v1.GetHealthCheckResponse
+	3,  // 67: hivemind.admin.v1.AdminService.ListAllUsers:output_type -> hivemind.admin.v1.ListAllUsersResponse
+	5,  // 68: hivemind.admin.v1.AdminService.ListUsers:output_type -> hivemind.admin.v1.ListUsersResponse
+	8,  // 69: hivemind.admin.v1.AdminService.GetUserDetails:output_type -> hivemind.admin.v1.GetUserDetailsResponse
+	12, // 70: hivemind.admin.v1.AdminService.UpdateUser:output_type -> hivemind.admin.v1.UpdateUserResponse
+	53, // 71: hivemind.admin.v1.AdminService.DeleteUser:output_type -> google.protobuf.Empty
+	15, // 72: hivemind.admin.v1.AdminService.SetUserActive:output_type -> hivemind.admin.v1.SetUserActiveResponse
+	17, // 73: hivemind.admin.v1.AdminService.ImpersonateUser:output_type -> hivemind.admin.v1.ImpersonateUserResponse
+	19, // 74: hivemind.admin.v1.AdminService.ListAllTokens:output_type -> hivemind.admin.v1.ListAllTokensResponse
+	53, // 75: hivemind.admin.v1.AdminService.RevokeUserToken:output_type -> google.protobuf.Empty
+	22, // 76: hivemind.admin.v1.AdminService.GetConfiguration:output_type -> hivemind.admin.v1.GetConfigurationResponse
+	24, // 77: hivemind.admin.v1.AdminService.UpdateConfiguration:output_type -> hivemind.admin.v1.UpdateConfigurationResponse
+	25, // 78: hivemind.admin.v1.AdminService.RotateBootstrapToken:output_type -> hivemind.admin.v1.RotateBootstrapTokenResponse
+	27, // 79: hivemind.admin.v1.AdminService.GetAuditLogs:output_type -> hivemind.admin.v1.GetAuditLogsResponse
+	29, // 80: hivemind.admin.v1.AdminService.ListAuditLog:output_type -> hivemind.admin.v1.ListAuditLogResponse
+	41, // 81: hivemind.admin.v1.AdminService.GetMetrics:output_type -> hivemind.admin.v1.GetMetricsResponse
+	32, // 82: hivemind.admin.v1.AdminService.MoveGuildContent:output_type -> hivemind.admin.v1.MoveGuildContentResponse
+	34, // 83: hivemind.admin.v1.AdminService.ReassignAuthor:output_type -> hivemind.admin.v1.ReassignAuthorResponse
+	36, // 84: hivemind.admin.v1.AdminService.ListFlaggedContent:output_type -> hivemind.admin.v1.ListFlaggedContentResponse
+	39, // 85: hivemind.admin.v1.AdminService.GetReadOnlyMode:output_type -> hivemind.admin.v1.ReadOnlyMode
+	39, // 86: hivemind.admin.v1.AdminService.SetReadOnlyMode:output_type -> hivemind.admin.v1.ReadOnlyMode
+	65, // [65:87] is the sub-list for method output_type
+	43, // [43:65] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
 }
 
 func init() { file_admin_proto_init() }
@@ -2120,7 +3438,7 @@ func file_admin_proto_init() {
 	if File_admin_proto != nil {
 		return
 	}
-	file_admin_proto_msgTypes[26].OneofWrappers = []any{
+	file_admin_proto_msgTypes[42].OneofWrappers = []any{
 		(*MetricValue_Counter)(nil),
 		(*MetricValue_Gauge)(nil),
 		(*MetricValue_Histogram)(nil),
@@ -2131,7 +3449,7 @@ func file_admin_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_proto_rawDesc), len(file_admin_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   33,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,9 +23,11 @@ const (
 	AdminService_GetSystemInfo_FullMethodName        = "/hivemind.admin.v1.AdminService/GetSystemInfo"
 	AdminService_GetHealthCheck_FullMethodName       = "/hivemind.admin.v1.AdminService/GetHealthCheck"
 	AdminService_ListAllUsers_FullMethodName         = "/hivemind.admin.v1.AdminService/ListAllUsers"
+	AdminService_ListUsers_FullMethodName            = "/hivemind.admin.v1.AdminService/ListUsers"
 	AdminService_GetUserDetails_FullMethodName       = "/hivemind.admin.v1.AdminService/GetUserDetails"
 	AdminService_UpdateUser_FullMethodName           = "/hivemind.admin.v1.AdminService/UpdateUser"
 	AdminService_DeleteUser_FullMethodName           = "/hivemind.admin.v1.AdminService/DeleteUser"
+	AdminService_SetUserActive_FullMethodName        = "/hivemind.admin.v1.AdminService/SetUserActive"
 	AdminService_ImpersonateUser_FullMethodName      = "/hivemind.admin.v1.AdminService/ImpersonateUser"
 	AdminService_ListAllTokens_FullMethodName        = "/hivemind.admin.v1.AdminService/ListAllTokens"
 	AdminService_RevokeUserToken_FullMethodName      = "/hivemind.admin.v1.AdminService/RevokeUserToken"
@@ -33,7 +35,13 @@ const (
 	AdminService_UpdateConfiguration_FullMethodName  = "/hivemind.admin.v1.AdminService/UpdateConfiguration"
 	AdminService_RotateBootstrapToken_FullMethodName = "/hivemind.admin.v1.AdminService/RotateBootstrapToken"
 	AdminService_GetAuditLogs_FullMethodName         = "/hivemind.admin.v1.AdminService/GetAuditLogs"
+	AdminService_ListAuditLog_FullMethodName         = "/hivemind.admin.v1.AdminService/ListAuditLog"
 	AdminService_GetMetrics_FullMethodName           = "/hivemind.admin.v1.AdminService/GetMetrics"
+	AdminService_MoveGuildContent_FullMethodName     = "/hivemind.admin.v1.AdminService/MoveGuildContent"
+	AdminService_ReassignAuthor_FullMethodName       = "/hivemind.admin.v1.AdminService/ReassignAuthor"
+	AdminService_ListFlaggedContent_FullMethodName   = "/hivemind.admin.v1.AdminService/ListFlaggedContent"
+	AdminService_GetReadOnlyMode_FullMethodName      = "/hivemind.admin.v1.AdminService/GetReadOnlyMode"
+	AdminService_SetReadOnlyMode_FullMethodName      = "/hivemind.admin.v1.AdminService/SetReadOnlyMode"
 )
 
 // AdminServiceClient is the client API for AdminService service.
@@ -47,9 +55,11 @@ type AdminServiceClient interface {
 	GetHealthCheck(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetHealthCheckResponse, error)
 	// User management
 	ListAllUsers(ctx context.Context, in *ListAllUsersRequest, opts ...grpc.CallOption) (*ListAllUsersResponse, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
 	GetUserDetails(ctx context.Context, in *GetUserDetailsRequest, opts ...grpc.CallOption) (*GetUserDetailsResponse, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetUserActive(ctx context.Context, in *SetUserActiveRequest, opts ...grpc.CallOption) (*SetUserActiveResponse, error)
 	ImpersonateUser(ctx context.Context, in *ImpersonateUserRequest, opts ...grpc.CallOption) (*ImpersonateUserResponse, error)
 	// Token management (admin view of all tokens)
 	ListAllTokens(ctx context.Context, in *ListAllTokensRequest, opts ...grpc.CallOption) (*ListAllTokensResponse, error)
@@ -61,7 +71,16 @@ type AdminServiceClient interface {
 	RotateBootstrapToken(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RotateBootstrapTokenResponse, error)
 	// Audit and monitoring
 	GetAuditLogs(ctx context.Context, in *GetAuditLogsRequest, opts ...grpc.CallOption) (*GetAuditLogsResponse, error)
+	ListAuditLog(ctx context.Context, in *ListAuditLogRequest, opts ...grpc.CallOption) (*ListAuditLogResponse, error)
 	GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsResponse, error)
+	// Content migration
+	MoveGuildContent(ctx context.Context, in *MoveGuildContentRequest, opts ...grpc.CallOption) (*MoveGuildContentResponse, error)
+	ReassignAuthor(ctx context.Context, in *ReassignAuthorRequest, opts ...grpc.CallOption) (*ReassignAuthorResponse, error)
+	// Content moderation
+	ListFlaggedContent(ctx context.Context, in *ListFlaggedContentRequest, opts ...grpc.CallOption) (*ListFlaggedContentResponse, error)
+	// Maintenance mode: while read-only, every RPC that changes data is rejected
+	GetReadOnlyMode(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReadOnlyMode, error)
+	SetReadOnlyMode(ctx context.Context, in *SetReadOnlyModeRequest, opts ...grpc.CallOption) (*ReadOnlyMode, error)
 }
 
 type adminServiceClient struct {
@@ -102,6 +121,16 @@ func (c *adminServiceClient) ListAllUsers(ctx context.Context, in *ListAllUsersR
 	return out, nil
 }
 
+func (c *adminServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminServiceClient) GetUserDetails(ctx context.Context, in *GetUserDetailsRequest, opts ...grpc.CallOption) (*GetUserDetailsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetUserDetailsResponse)
@@ -132,6 +161,16 @@ func (c *adminServiceClient) DeleteUser(ctx context.Context, in *DeleteUserReque
 	return out, nil
 }
 
+func (c *adminServiceClient) SetUserActive(ctx context.Context, in *SetUserActiveRequest, opts ...grpc.CallOption) (*SetUserActiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetUserActiveResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetUserActive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminServiceClient) ImpersonateUser(ctx context.Context, in *ImpersonateUserRequest, opts ...grpc.CallOption) (*ImpersonateUserResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ImpersonateUserResponse)
@@ -202,6 +241,16 @@ func (c *adminServiceClient) GetAuditLogs(ctx context.Context, in *GetAuditLogsR
 	return out, nil
 }
 
+func (c *adminServiceClient) ListAuditLog(ctx context.Context, in *ListAuditLogRequest, opts ...grpc.CallOption) (*ListAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditLogResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminServiceClient) GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetMetricsResponse)
@@ -212,6 +261,56 @@ func (c *adminServiceClient) GetMetrics(ctx context.Context, in *GetMetricsReque
 	return out, nil
 }
 
+func (c *adminServiceClient) MoveGuildContent(ctx context.Context, in *MoveGuildContentRequest, opts ...grpc.CallOption) (*MoveGuildContentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveGuildContentResponse)
+	err := c.cc.Invoke(ctx, AdminService_MoveGuildContent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ReassignAuthor(ctx context.Context, in *ReassignAuthorRequest, opts ...grpc.CallOption) (*ReassignAuthorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReassignAuthorResponse)
+	err := c.cc.Invoke(ctx, AdminService_ReassignAuthor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListFlaggedContent(ctx context.Context, in *ListFlaggedContentRequest, opts ...grpc.CallOption) (*ListFlaggedContentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFlaggedContentResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListFlaggedContent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetReadOnlyMode(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReadOnlyMode, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadOnlyMode)
+	err := c.cc.Invoke(ctx, AdminService_GetReadOnlyMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetReadOnlyMode(ctx context.Context, in *SetReadOnlyModeRequest, opts ...grpc.CallOption) (*ReadOnlyMode, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadOnlyMode)
+	err := c.cc.Invoke(ctx, AdminService_SetReadOnlyMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 // All implementations should embed UnimplementedAdminServiceServer
 // for forward compatibility.
@@ -223,9 +322,11 @@ type AdminServiceServer interface {
 	GetHealthCheck(context.Context, *emptypb.Empty) (*GetHealthCheckResponse, error)
 	// User management
 	ListAllUsers(context.Context, *ListAllUsersRequest) (*ListAllUsersResponse, error)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
 	GetUserDetails(context.Context, *GetUserDetailsRequest) (*GetUserDetailsResponse, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 	DeleteUser(context.Context, *DeleteUserRequest) (*emptypb.Empty, error)
+	SetUserActive(context.Context, *SetUserActiveRequest) (*SetUserActiveResponse, error)
 	ImpersonateUser(context.Context, *ImpersonateUserRequest) (*ImpersonateUserResponse, error)
 	// Token management (admin view of all tokens)
 	ListAllTokens(context.Context, *ListAllTokensRequest) (*ListAllTokensResponse, error)
@@ -237,7 +338,16 @@ type AdminServiceServer interface {
 	RotateBootstrapToken(context.Context, *emptypb.Empty) (*RotateBootstrapTokenResponse, error)
 	// Audit and monitoring
 	GetAuditLogs(context.Context, *GetAuditLogsRequest) (*GetAuditLogsResponse, error)
+	ListAuditLog(context.Context, *ListAuditLogRequest) (*ListAuditLogResponse, error)
 	GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsResponse, error)
+	// Content migration
+	MoveGuildContent(context.Context, *MoveGuildContentRequest) (*MoveGuildContentResponse, error)
+	ReassignAuthor(context.Context, *ReassignAuthorRequest) (*ReassignAuthorResponse, error)
+	// Content moderation
+	ListFlaggedContent(context.Context, *ListFlaggedContentRequest) (*ListFlaggedContentResponse, error)
+	// Maintenance mode: while read-only, every RPC that changes data is rejected
+	GetReadOnlyMode(context.Context, *emptypb.Empty) (*ReadOnlyMode, error)
+	SetReadOnlyMode(context.Context, *SetReadOnlyModeRequest) (*ReadOnlyMode, error)
 }
 
 // UnimplementedAdminServiceServer should be embedded to have
@@ -256,6 +366,9 @@ func (UnimplementedAdminServiceServer) GetHealthCheck(context.Context, *emptypb.
 func (UnimplementedAdminServiceServer) ListAllUsers(context.Context, *ListAllUsersRequest) (*ListAllUsersResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListAllUsers not implemented")
 }
+func (UnimplementedAdminServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUsers not implemented")
+}
 func (UnimplementedAdminServiceServer) GetUserDetails(context.Context, *GetUserDetailsRequest) (*GetUserDetailsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUserDetails not implemented")
 }
@@ -265,6 +378,9 @@ func (UnimplementedAdminServiceServer) UpdateUser(context.Context, *UpdateUserRe
 func (UnimplementedAdminServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteUser not implemented")
 }
+func (UnimplementedAdminServiceServer) SetUserActive(context.Context, *SetUserActiveRequest) (*SetUserActiveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetUserActive not implemented")
+}
 func (UnimplementedAdminServiceServer) ImpersonateUser(context.Context, *ImpersonateUserRequest) (*ImpersonateUserResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ImpersonateUser not implemented")
 }
@@ -286,9 +402,27 @@ func (UnimplementedAdminServiceServer) RotateBootstrapToken(context.Context, *em
 func (UnimplementedAdminServiceServer) GetAuditLogs(context.Context, *GetAuditLogsRequest) (*GetAuditLogsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAuditLogs not implemented")
 }
+func (UnimplementedAdminServiceServer) ListAuditLog(context.Context, *ListAuditLogRequest) (*ListAuditLogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAuditLog not implemented")
+}
 func (UnimplementedAdminServiceServer) GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetMetrics not implemented")
 }
+func (UnimplementedAdminServiceServer) MoveGuildContent(context.Context, *MoveGuildContentRequest) (*MoveGuildContentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MoveGuildContent not implemented")
+}
+func (UnimplementedAdminServiceServer) ReassignAuthor(context.Context, *ReassignAuthorRequest) (*ReassignAuthorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReassignAuthor not implemented")
+}
+func (UnimplementedAdminServiceServer) ListFlaggedContent(context.Context, *ListFlaggedContentRequest) (*ListFlaggedContentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFlaggedContent not implemented")
+}
+func (UnimplementedAdminServiceServer) GetReadOnlyMode(context.Context, *emptypb.Empty) (*ReadOnlyMode, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReadOnlyMode not implemented")
+}
+func (UnimplementedAdminServiceServer) SetReadOnlyMode(context.Context, *SetReadOnlyModeRequest) (*ReadOnlyMode, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetReadOnlyMode not implemented")
+}
 func (UnimplementedAdminServiceServer) testEmbeddedByValue() {}
 
 // UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -363,6 +497,24 @@ func _AdminService_ListAllUsers_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AdminService_GetUserDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUserDetailsRequest)
 	if err := dec(in); err != nil {
@@ -417,6 +569,24 @@ func _AdminService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_SetUserActive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserActiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetUserActive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetUserActive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetUserActive(ctx, req.(*SetUserActiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AdminService_ImpersonateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ImpersonateUserRequest)
 	if err := dec(in); err != nil {
@@ -543,6 +713,24 @@ func _AdminService_GetAuditLogs_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_ListAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListAuditLog(ctx, req.(*ListAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AdminService_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetMetricsRequest)
 	if err := dec(in); err != nil {
@@ -561,6 +749,96 @@ func _AdminService_GetMetrics_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_MoveGuildContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveGuildContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).MoveGuildContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_MoveGuildContent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).MoveGuildContent(ctx, req.(*MoveGuildContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ReassignAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReassignAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ReassignAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ReassignAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ReassignAuthor(ctx, req.(*ReassignAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListFlaggedContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFlaggedContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListFlaggedContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListFlaggedContent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListFlaggedContent(ctx, req.(*ListFlaggedContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetReadOnlyMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetReadOnlyMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetReadOnlyMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetReadOnlyMode(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetReadOnlyMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReadOnlyModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetReadOnlyMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetReadOnlyMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetReadOnlyMode(ctx, req.(*SetReadOnlyModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -580,6 +858,10 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAllUsers",
 			Handler:    _AdminService_ListAllUsers_Handler,
 		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _AdminService_ListUsers_Handler,
+		},
 		{
 			MethodName: "GetUserDetails",
 			Handler:    _AdminService_GetUserDetails_Handler,
@@ -592,6 +874,10 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUser",
 			Handler:    _AdminService_DeleteUser_Handler,
 		},
+		{
+			MethodName: "SetUserActive",
+			Handler:    _AdminService_SetUserActive_Handler,
+		},
 		{
 			MethodName: "ImpersonateUser",
 			Handler:    _AdminService_ImpersonateUser_Handler,
@@ -620,10 +906,34 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAuditLogs",
 			Handler:    _AdminService_GetAuditLogs_Handler,
 		},
+		{
+			MethodName: "ListAuditLog",
+			Handler:    _AdminService_ListAuditLog_Handler,
+		},
 		{
 			MethodName: "GetMetrics",
 			Handler:    _AdminService_GetMetrics_Handler,
 		},
+		{
+			MethodName: "MoveGuildContent",
+			Handler:    _AdminService_MoveGuildContent_Handler,
+		},
+		{
+			MethodName: "ReassignAuthor",
+			Handler:    _AdminService_ReassignAuthor_Handler,
+		},
+		{
+			MethodName: "ListFlaggedContent",
+			Handler:    _AdminService_ListFlaggedContent_Handler,
+		},
+		{
+			MethodName: "GetReadOnlyMode",
+			Handler:    _AdminService_GetReadOnlyMode_Handler,
+		},
+		{
+			MethodName: "SetReadOnlyMode",
+			Handler:    _AdminService_SetReadOnlyMode_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "admin.proto",
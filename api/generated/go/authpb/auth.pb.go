@@ -1148,6 +1148,307 @@ func (x *ListUsersResponse) GetNextPageToken() string {
 	return ""
 }
 
+type GetCurrentUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentUserRequest) Reset() {
+	*x = GetCurrentUserRequest{}
+	mi := &file_auth_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentUserRequest) ProtoMessage() {}
+
+func (x *GetCurrentUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentUserRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{19}
+}
+
+type GetCurrentUserResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	User           *userpb.User           `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AvatarUrl      string                 `protobuf:"bytes,2,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Timezone       string                 `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`              // IANA time zone, empty if unset
+	Discord        *LinkedDiscordAccount  `protobuf:"bytes,4,opt,name=discord,proto3" json:"discord,omitempty"`                // Unset if no Discord account is linked
+	TokenId        string                 `protobuf:"bytes,5,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"` // Empty for bot requests made on behalf of a Discord user
+	TokenExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=token_expires_at,json=tokenExpiresAt,proto3" json:"token_expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetCurrentUserResponse) Reset() {
+	*x = GetCurrentUserResponse{}
+	mi := &file_auth_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentUserResponse) ProtoMessage() {}
+
+func (x *GetCurrentUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentUserResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentUserResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetCurrentUserResponse) GetUser() *userpb.User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GetCurrentUserResponse) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *GetCurrentUserResponse) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *GetCurrentUserResponse) GetDiscord() *LinkedDiscordAccount {
+	if x != nil {
+		return x.Discord
+	}
+	return nil
+}
+
+func (x *GetCurrentUserResponse) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *GetCurrentUserResponse) GetTokenExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TokenExpiresAt
+	}
+	return nil
+}
+
+type LinkedDiscordAccount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DiscordId     string                 `protobuf:"bytes,1,opt,name=discord_id,json=discordId,proto3" json:"discord_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	GlobalName    string                 `protobuf:"bytes,3,opt,name=global_name,json=globalName,proto3" json:"global_name,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	LinkedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=linked_at,json=linkedAt,proto3" json:"linked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkedDiscordAccount) Reset() {
+	*x = LinkedDiscordAccount{}
+	mi := &file_auth_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkedDiscordAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkedDiscordAccount) ProtoMessage() {}
+
+func (x *LinkedDiscordAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkedDiscordAccount.ProtoReflect.Descriptor instead.
+func (*LinkedDiscordAccount) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LinkedDiscordAccount) GetDiscordId() string {
+	if x != nil {
+		return x.DiscordId
+	}
+	return ""
+}
+
+func (x *LinkedDiscordAccount) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LinkedDiscordAccount) GetGlobalName() string {
+	if x != nil {
+		return x.GlobalName
+	}
+	return ""
+}
+
+func (x *LinkedDiscordAccount) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *LinkedDiscordAccount) GetLinkedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LinkedAt
+	}
+	return nil
+}
+
+// Discord OAuth redirect, as for ExchangeAuthCode with the "discord" provider
+type LinkDiscordAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`                                     // Authorization code from redirect
+	CodeVerifier  string                 `protobuf:"bytes,2,opt,name=code_verifier,json=codeVerifier,proto3" json:"code_verifier,omitempty"` // PKCE code verifier
+	RedirectUri   string                 `protobuf:"bytes,3,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"`    // Must match the one used in auth request
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkDiscordAccountRequest) Reset() {
+	*x = LinkDiscordAccountRequest{}
+	mi := &file_auth_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkDiscordAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkDiscordAccountRequest) ProtoMessage() {}
+
+func (x *LinkDiscordAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkDiscordAccountRequest.ProtoReflect.Descriptor instead.
+func (*LinkDiscordAccountRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LinkDiscordAccountRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LinkDiscordAccountRequest) GetCodeVerifier() string {
+	if x != nil {
+		return x.CodeVerifier
+	}
+	return ""
+}
+
+func (x *LinkDiscordAccountRequest) GetRedirectUri() string {
+	if x != nil {
+		return x.RedirectUri
+	}
+	return ""
+}
+
+type LinkDiscordAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Discord       *LinkedDiscordAccount  `protobuf:"bytes,1,opt,name=discord,proto3" json:"discord,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkDiscordAccountResponse) Reset() {
+	*x = LinkDiscordAccountResponse{}
+	mi := &file_auth_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkDiscordAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkDiscordAccountResponse) ProtoMessage() {}
+
+func (x *LinkDiscordAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkDiscordAccountResponse.ProtoReflect.Descriptor instead.
+func (*LinkDiscordAccountResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LinkDiscordAccountResponse) GetDiscord() *LinkedDiscordAccount {
+	if x != nil {
+		return x.Discord
+	}
+	return nil
+}
+
 type GetUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -1157,7 +1458,7 @@ type GetUserRequest struct {
 
 func (x *GetUserRequest) Reset() {
 	*x = GetUserRequest{}
-	mi := &file_auth_proto_msgTypes[19]
+	mi := &file_auth_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1169,7 +1470,7 @@ func (x *GetUserRequest) String() string {
 func (*GetUserRequest) ProtoMessage() {}
 
 func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[19]
+	mi := &file_auth_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1182,7 +1483,7 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{19}
+	return file_auth_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetUserRequest) GetUserId() string {
@@ -1201,7 +1502,7 @@ type GetUserResponse struct {
 
 func (x *GetUserResponse) Reset() {
 	*x = GetUserResponse{}
-	mi := &file_auth_proto_msgTypes[20]
+	mi := &file_auth_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1213,7 +1514,7 @@ func (x *GetUserResponse) String() string {
 func (*GetUserResponse) ProtoMessage() {}
 
 func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[20]
+	mi := &file_auth_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1226,7 +1527,7 @@ func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
 func (*GetUserResponse) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{20}
+	return file_auth_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetUserResponse) GetUser() *userpb.User {
@@ -1246,7 +1547,7 @@ type UpdateUserRoleRequest struct {
 
 func (x *UpdateUserRoleRequest) Reset() {
 	*x = UpdateUserRoleRequest{}
-	mi := &file_auth_proto_msgTypes[21]
+	mi := &file_auth_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1258,7 +1559,7 @@ func (x *UpdateUserRoleRequest) String() string {
 func (*UpdateUserRoleRequest) ProtoMessage() {}
 
 func (x *UpdateUserRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[21]
+	mi := &file_auth_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1271,7 +1572,7 @@ func (x *UpdateUserRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRoleRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRoleRequest) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{21}
+	return file_auth_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *UpdateUserRoleRequest) GetUserId() string {
@@ -1297,7 +1598,7 @@ type UpdateUserRoleResponse struct {
 
 func (x *UpdateUserRoleResponse) Reset() {
 	*x = UpdateUserRoleResponse{}
-	mi := &file_auth_proto_msgTypes[22]
+	mi := &file_auth_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1309,7 +1610,7 @@ func (x *UpdateUserRoleResponse) String() string {
 func (*UpdateUserRoleResponse) ProtoMessage() {}
 
 func (x *UpdateUserRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[22]
+	mi := &file_auth_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1322,7 +1623,7 @@ func (x *UpdateUserRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRoleResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserRoleResponse) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{22}
+	return file_auth_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *UpdateUserRoleResponse) GetUser() *userpb.User {
@@ -1341,7 +1642,7 @@ type DeleteUserRequest struct {
 
 func (x *DeleteUserRequest) Reset() {
 	*x = DeleteUserRequest{}
-	mi := &file_auth_proto_msgTypes[23]
+	mi := &file_auth_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1353,7 +1654,7 @@ func (x *DeleteUserRequest) String() string {
 func (*DeleteUserRequest) ProtoMessage() {}
 
 func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[23]
+	mi := &file_auth_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1366,7 +1667,7 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{23}
+	return file_auth_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *DeleteUserRequest) GetUserId() string {
@@ -1465,7 +1766,31 @@ const file_auth_proto_rawDesc = "" +
 	"\x06filter\x18\x03 \x01(\tR\x06filter\"i\n" +
 	"\x11ListUsersResponse\x12,\n" +
 	"\x05users\x18\x01 \x03(\v2\x16.hivemind.user.v1.UserR\x05users\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\")\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x17\n" +
+	"\x15GetCurrentUserRequest\"\xa2\x02\n" +
+	"\x16GetCurrentUserResponse\x12*\n" +
+	"\x04user\x18\x01 \x01(\v2\x16.hivemind.user.v1.UserR\x04user\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x02 \x01(\tR\tavatarUrl\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x12@\n" +
+	"\adiscord\x18\x04 \x01(\v2&.hivemind.auth.v1.LinkedDiscordAccountR\adiscord\x12\x19\n" +
+	"\btoken_id\x18\x05 \x01(\tR\atokenId\x12D\n" +
+	"\x10token_expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0etokenExpiresAt\"\xca\x01\n" +
+	"\x14LinkedDiscordAccount\x12\x1d\n" +
+	"\n" +
+	"discord_id\x18\x01 \x01(\tR\tdiscordId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1f\n" +
+	"\vglobal_name\x18\x03 \x01(\tR\n" +
+	"globalName\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x04 \x01(\tR\tavatarUrl\x127\n" +
+	"\tlinked_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blinkedAt\"w\n" +
+	"\x19LinkDiscordAccountRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12#\n" +
+	"\rcode_verifier\x18\x02 \x01(\tR\fcodeVerifier\x12!\n" +
+	"\fredirect_uri\x18\x03 \x01(\tR\vredirectUri\"^\n" +
+	"\x1aLinkDiscordAccountResponse\x12@\n" +
+	"\adiscord\x18\x01 \x01(\v2&.hivemind.auth.v1.LinkedDiscordAccountR\adiscord\")\n" +
 	"\x0eGetUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"=\n" +
 	"\x0fGetUserResponse\x12*\n" +
@@ -1476,7 +1801,8 @@ const file_auth_proto_rawDesc = "" +
 	"\x16UpdateUserRoleResponse\x12*\n" +
 	"\x04user\x18\x01 \x01(\v2\x16.hivemind.user.v1.UserR\x04user\",\n" +
 	"\x11DeleteUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId2\x8a\t\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId2\xe0\n" +
+	"\n" +
 	"\vAuthService\x12c\n" +
 	"\x0eGetOAuthConfig\x12'.hivemind.auth.v1.GetOAuthConfigRequest\x1a(.hivemind.auth.v1.GetOAuthConfigResponse\x12i\n" +
 	"\x10ExchangeAuthCode\x12).hivemind.auth.v1.ExchangeAuthCodeRequest\x1a*.hivemind.auth.v1.ExchangeAuthCodeResponse\x12`\n" +
@@ -1486,7 +1812,9 @@ const file_auth_proto_rawDesc = "" +
 	"\fRefreshToken\x12%.hivemind.auth.v1.RefreshTokenRequest\x1a&.hivemind.auth.v1.RefreshTokenResponse\x12Z\n" +
 	"\vRevokeToken\x12$.hivemind.auth.v1.RevokeTokenRequest\x1a%.hivemind.auth.v1.RevokeTokenResponse\x12W\n" +
 	"\n" +
-	"ListTokens\x12#.hivemind.auth.v1.ListTokensRequest\x1a$.hivemind.auth.v1.ListTokensResponse\x12T\n" +
+	"ListTokens\x12#.hivemind.auth.v1.ListTokensRequest\x1a$.hivemind.auth.v1.ListTokensResponse\x12c\n" +
+	"\x0eGetCurrentUser\x12'.hivemind.auth.v1.GetCurrentUserRequest\x1a(.hivemind.auth.v1.GetCurrentUserResponse\x12o\n" +
+	"\x12LinkDiscordAccount\x12+.hivemind.auth.v1.LinkDiscordAccountRequest\x1a,.hivemind.auth.v1.LinkDiscordAccountResponse\x12T\n" +
 	"\tListUsers\x12\".hivemind.auth.v1.ListUsersRequest\x1a#.hivemind.auth.v1.ListUsersResponse\x12N\n" +
 	"\aGetUser\x12 .hivemind.auth.v1.GetUserRequest\x1a!.hivemind.auth.v1.GetUserResponse\x12c\n" +
 	"\x0eUpdateUserRole\x12'.hivemind.auth.v1.UpdateUserRoleRequest\x1a(.hivemind.auth.v1.UpdateUserRoleResponse\x12I\n" +
@@ -1505,82 +1833,96 @@ func file_auth_proto_rawDescGZIP() []byte {
 	return file_auth_proto_rawDescData
 }
 
-var file_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_auth_proto_goTypes = []any{
-	(*GetOAuthConfigRequest)(nil),     // 0: hivemind.auth.v1.GetOAuthConfigRequest
-	(*GetOAuthConfigResponse)(nil),    // 1: hivemind.auth.v1.GetOAuthConfigResponse
-	(*OAuthProvider)(nil),             // 2: hivemind.auth.v1.OAuthProvider
-	(*ExchangeAuthCodeRequest)(nil),   // 3: hivemind.auth.v1.ExchangeAuthCodeRequest
-	(*ExchangeAuthCodeResponse)(nil),  // 4: hivemind.auth.v1.ExchangeAuthCodeResponse
-	(*LoginWithOIDCRequest)(nil),      // 5: hivemind.auth.v1.LoginWithOIDCRequest
-	(*LoginWithOIDCResponse)(nil),     // 6: hivemind.auth.v1.LoginWithOIDCResponse
-	(*RefreshOAuthTokenRequest)(nil),  // 7: hivemind.auth.v1.RefreshOAuthTokenRequest
-	(*RefreshOAuthTokenResponse)(nil), // 8: hivemind.auth.v1.RefreshOAuthTokenResponse
-	(*AuthenticateLocalRequest)(nil),  // 9: hivemind.auth.v1.AuthenticateLocalRequest
-	(*AuthenticateLocalResponse)(nil), // 10: hivemind.auth.v1.AuthenticateLocalResponse
-	(*RefreshTokenRequest)(nil),       // 11: hivemind.auth.v1.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),      // 12: hivemind.auth.v1.RefreshTokenResponse
-	(*RevokeTokenRequest)(nil),        // 13: hivemind.auth.v1.RevokeTokenRequest
-	(*RevokeTokenResponse)(nil),       // 14: hivemind.auth.v1.RevokeTokenResponse
-	(*ListTokensRequest)(nil),         // 15: hivemind.auth.v1.ListTokensRequest
-	(*ListTokensResponse)(nil),        // 16: hivemind.auth.v1.ListTokensResponse
-	(*ListUsersRequest)(nil),          // 17: hivemind.auth.v1.ListUsersRequest
-	(*ListUsersResponse)(nil),         // 18: hivemind.auth.v1.ListUsersResponse
-	(*GetUserRequest)(nil),            // 19: hivemind.auth.v1.GetUserRequest
-	(*GetUserResponse)(nil),           // 20: hivemind.auth.v1.GetUserResponse
-	(*UpdateUserRoleRequest)(nil),     // 21: hivemind.auth.v1.UpdateUserRoleRequest
-	(*UpdateUserRoleResponse)(nil),    // 22: hivemind.auth.v1.UpdateUserRoleResponse
-	(*DeleteUserRequest)(nil),         // 23: hivemind.auth.v1.DeleteUserRequest
-	(*userpb.User)(nil),               // 24: hivemind.user.v1.User
-	(*timestamppb.Timestamp)(nil),     // 25: google.protobuf.Timestamp
-	(*commonpb.APIToken)(nil),         // 26: hivemind.common.v1.APIToken
-	(userpb.Role)(0),                  // 27: hivemind.user.v1.Role
-	(*emptypb.Empty)(nil),             // 28: google.protobuf.Empty
+	(*GetOAuthConfigRequest)(nil),      // 0: hivemind.auth.v1.GetOAuthConfigRequest
+	(*GetOAuthConfigResponse)(nil),     // 1: hivemind.auth.v1.GetOAuthConfigResponse
+	(*OAuthProvider)(nil),              // 2: hivemind.auth.v1.OAuthProvider
+	(*ExchangeAuthCodeRequest)(nil),    // 3: hivemind.auth.v1.ExchangeAuthCodeRequest
+	(*ExchangeAuthCodeResponse)(nil),   // 4: hivemind.auth.v1.ExchangeAuthCodeResponse
+	(*LoginWithOIDCRequest)(nil),       // 5: hivemind.auth.v1.LoginWithOIDCRequest
+	(*LoginWithOIDCResponse)(nil),      // 6: hivemind.auth.v1.LoginWithOIDCResponse
+	(*RefreshOAuthTokenRequest)(nil),   // 7: hivemind.auth.v1.RefreshOAuthTokenRequest
+	(*RefreshOAuthTokenResponse)(nil),  // 8: hivemind.auth.v1.RefreshOAuthTokenResponse
+	(*AuthenticateLocalRequest)(nil),   // 9: hivemind.auth.v1.AuthenticateLocalRequest
+	(*AuthenticateLocalResponse)(nil),  // 10: hivemind.auth.v1.AuthenticateLocalResponse
+	(*RefreshTokenRequest)(nil),        // 11: hivemind.auth.v1.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),       // 12: hivemind.auth.v1.RefreshTokenResponse
+	(*RevokeTokenRequest)(nil),         // 13: hivemind.auth.v1.RevokeTokenRequest
+	(*RevokeTokenResponse)(nil),        // 14: hivemind.auth.v1.RevokeTokenResponse
+	(*ListTokensRequest)(nil),          // 15: hivemind.auth.v1.ListTokensRequest
+	(*ListTokensResponse)(nil),         // 16: hivemind.auth.v1.ListTokensResponse
+	(*ListUsersRequest)(nil),           // 17: hivemind.auth.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),          // 18: hivemind.auth.v1.ListUsersResponse
+	(*GetCurrentUserRequest)(nil),      // 19: hivemind.auth.v1.GetCurrentUserRequest
+	(*GetCurrentUserResponse)(nil),     // 20: hivemind.auth.v1.GetCurrentUserResponse
+	(*LinkedDiscordAccount)(nil),       // 21: hivemind.auth.v1.LinkedDiscordAccount
+	(*LinkDiscordAccountRequest)(nil),  // 22: hivemind.auth.v1.LinkDiscordAccountRequest
+	(*LinkDiscordAccountResponse)(nil), // 23: hivemind.auth.v1.LinkDiscordAccountResponse
+	(*GetUserRequest)(nil),             // 24: hivemind.auth.v1.GetUserRequest
+	(*GetUserResponse)(nil),            // 25: hivemind.auth.v1.GetUserResponse
+	(*UpdateUserRoleRequest)(nil),      // 26: hivemind.auth.v1.UpdateUserRoleRequest
+	(*UpdateUserRoleResponse)(nil),     // 27: hivemind.auth.v1.UpdateUserRoleResponse
+	(*DeleteUserRequest)(nil),          // 28: hivemind.auth.v1.DeleteUserRequest
+	(*userpb.User)(nil),                // 29: hivemind.user.v1.User
+	(*timestamppb.Timestamp)(nil),      // 30: google.protobuf.Timestamp
+	(*commonpb.APIToken)(nil),          // 31: hivemind.common.v1.APIToken
+	(userpb.Role)(0),                   // 32: hivemind.user.v1.Role
+	(*emptypb.Empty)(nil),              // 33: google.protobuf.Empty
 }
 var file_auth_proto_depIdxs = []int32{
 	2,  // 0: hivemind.auth.v1.GetOAuthConfigResponse.providers:type_name -> hivemind.auth.v1.OAuthProvider
-	24, // 1: hivemind.auth.v1.ExchangeAuthCodeResponse.user:type_name -> hivemind.user.v1.User
-	25, // 2: hivemind.auth.v1.ExchangeAuthCodeResponse.expires_at:type_name -> google.protobuf.Timestamp
-	24, // 3: hivemind.auth.v1.LoginWithOIDCResponse.user:type_name -> hivemind.user.v1.User
-	25, // 4: hivemind.auth.v1.LoginWithOIDCResponse.expires_at:type_name -> google.protobuf.Timestamp
-	25, // 5: hivemind.auth.v1.RefreshOAuthTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
-	24, // 6: hivemind.auth.v1.AuthenticateLocalResponse.user:type_name -> hivemind.user.v1.User
-	25, // 7: hivemind.auth.v1.AuthenticateLocalResponse.expires_at:type_name -> google.protobuf.Timestamp
-	25, // 8: hivemind.auth.v1.RefreshTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
-	26, // 9: hivemind.auth.v1.ListTokensResponse.tokens:type_name -> hivemind.common.v1.APIToken
-	24, // 10: hivemind.auth.v1.ListUsersResponse.users:type_name -> hivemind.user.v1.User
-	24, // 11: hivemind.auth.v1.GetUserResponse.user:type_name -> hivemind.user.v1.User
-	27, // 12: hivemind.auth.v1.UpdateUserRoleRequest.role:type_name -> hivemind.user.v1.Role
-	24, // 13: hivemind.auth.v1.UpdateUserRoleResponse.user:type_name -> hivemind.user.v1.User
-	0,  // 14: hivemind.auth.v1.AuthService.GetOAuthConfig:input_type -> hivemind.auth.v1.GetOAuthConfigRequest
-	3,  // 15: hivemind.auth.v1.AuthService.ExchangeAuthCode:input_type -> hivemind.auth.v1.ExchangeAuthCodeRequest
-	5,  // 16: hivemind.auth.v1.AuthService.LoginWithOIDC:input_type -> hivemind.auth.v1.LoginWithOIDCRequest
-	7,  // 17: hivemind.auth.v1.AuthService.RefreshOAuthToken:input_type -> hivemind.auth.v1.RefreshOAuthTokenRequest
-	9,  // 18: hivemind.auth.v1.AuthService.AuthenticateLocal:input_type -> hivemind.auth.v1.AuthenticateLocalRequest
-	11, // 19: hivemind.auth.v1.AuthService.RefreshToken:input_type -> hivemind.auth.v1.RefreshTokenRequest
-	13, // 20: hivemind.auth.v1.AuthService.RevokeToken:input_type -> hivemind.auth.v1.RevokeTokenRequest
-	15, // 21: hivemind.auth.v1.AuthService.ListTokens:input_type -> hivemind.auth.v1.ListTokensRequest
-	17, // 22: hivemind.auth.v1.AuthService.ListUsers:input_type -> hivemind.auth.v1.ListUsersRequest
-	19, // 23: hivemind.auth.v1.AuthService.GetUser:input_type -> hivemind.auth.v1.GetUserRequest
-	21, // 24: hivemind.auth.v1.AuthService.UpdateUserRole:input_type -> hivemind.auth.v1.UpdateUserRoleRequest
-	23, // 25: hivemind.auth.v1.AuthService.DeleteUser:input_type -> hivemind.auth.v1.DeleteUserRequest
-	1,  // 26: hivemind.auth.v1.AuthService.GetOAuthConfig:output_type -> hivemind.auth.v1.GetOAuthConfigResponse
-	4,  // 27: hivemind.auth.v1.AuthService.ExchangeAuthCode:output_type -> hivemind.auth.v1.ExchangeAuthCodeResponse
-	6,  // 28: hivemind.auth.v1.AuthService.LoginWithOIDC:output_type -> hivemind.auth.v1.LoginWithOIDCResponse
-	8,  // 29: hivemind.auth.v1.AuthService.RefreshOAuthToken:output_type -> hivemind.auth.v1.RefreshOAuthTokenResponse
-	10, // 30: hivemind.auth.v1.AuthService.AuthenticateLocal:output_type -> hivemind.auth.v1.AuthenticateLocalResponse
-	12, // 31: hivemind.auth.v1.AuthService.RefreshToken:output_type -> hivemind.auth.v1.RefreshTokenResponse
-	14, // 32: hivemind.auth.v1.AuthService.RevokeToken:output_type -> hivemind.auth.v1.RevokeTokenResponse
-	16, // 33: hivemind.auth.v1.AuthService.ListTokens:output_type -> hivemind.auth.v1.ListTokensResponse
-	18, // 34: hivemind.auth.v1.AuthService.ListUsers:output_type -> hivemind.auth.v1.ListUsersResponse
-	20, // 35: hivemind.auth.v1.AuthService.GetUser:output_type -> hivemind.auth.v1.GetUserResponse
-	22, // 36: hivemind.auth.v1.AuthService.UpdateUserRole:output_type -> hivemind.auth.v1.UpdateUserRoleResponse
-	28, // 37: hivemind.auth.v1.AuthService.DeleteUser:output_type -> google.protobuf.Empty
-	26, // [26:38] is the sub-list for method output_type
-	14, // [14:26] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	29, // 1: hivemind.auth.v1.ExchangeAuthCodeResponse.user:type_name -> hivemind.user.v1.User
+	30, // 2: hivemind.auth.v1.ExchangeAuthCodeResponse.expires_at:type_name -> google.protobuf.Timestamp
+	29, // 3: hivemind.auth.v1.LoginWithOIDCResponse.user:type_name -> hivemind.user.v1.User
+	30, // 4: hivemind.auth.v1.LoginWithOIDCResponse.expires_at:type_name -> google.protobuf.Timestamp
+	30, // 5: hivemind.auth.v1.RefreshOAuthTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	29, // 6: hivemind.auth.v1.AuthenticateLocalResponse.user:type_name -> hivemind.user.v1.User
+	30, // 7: hivemind.auth.v1.AuthenticateLocalResponse.expires_at:type_name -> google.protobuf.Timestamp
+	30, // 8: hivemind.auth.v1.RefreshTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	31, // 9: hivemind.auth.v1.ListTokensResponse.tokens:type_name -> hivemind.common.v1.APIToken
+	29, // 10: hivemind.auth.v1.ListUsersResponse.users:type_name -> hivemind.user.v1.User
+	29, // 11: hivemind.auth.v1.GetCurrentUserResponse.user:type_name -> hivemind.user.v1.User
+	21, // 12: hivemind.auth.v1.GetCurrentUserResponse.discord:type_name -> hivemind.auth.v1.LinkedDiscordAccount
+	30, // 13: hivemind.auth.v1.GetCurrentUserResponse.token_expires_at:type_name -> google.protobuf.Timestamp
+	30, // 14: hivemind.auth.v1.LinkedDiscordAccount.linked_at:type_name -> google.protobuf.Timestamp
+	21, // 15: hivemind.auth.v1.LinkDiscordAccountResponse.discord:type_name -> hivemind.auth.v1.LinkedDiscordAccount
+	29, // 16: hivemind.auth.v1.GetUserResponse.user:type_name -> hivemind.user.v1.User
+	32, // 17: hivemind.auth.v1.UpdateUserRoleRequest.role:type_name -> hivemind.user.v1.Role
+	29, // 18: hivemind.auth.v1.UpdateUserRoleResponse.user:type_name -> hivemind.user.v1.User
+	0,  // 19: hivemind.auth.v1.AuthService.GetOAuthConfig:input_type -> hivemind.auth.v1.GetOAuthConfigRequest
+	3,  // 20: hivemind.auth.v1.AuthService.ExchangeAuthCode:input_type -> hivemind.auth.v1.ExchangeAuthCodeRequest
+	5,  // 21: hivemind.auth.v1.AuthService.LoginWithOIDC:input_type -> hivemind.auth.v1.LoginWithOIDCRequest
+	7,  // 22: hivemind.auth.v1.AuthService.RefreshOAuthToken:input_type -> hivemind.auth.v1.RefreshOAuthTokenRequest
+	9,  // 23: hivemind.auth.v1.AuthService.AuthenticateLocal:input_type -> hivemind.auth.v1.AuthenticateLocalRequest
+	11, // 24: hivemind.auth.v1.AuthService.RefreshToken:input_type -> hivemind.auth.v1.RefreshTokenRequest
+	13, // 25: hivemind.auth.v1.AuthService.RevokeToken:input_type -> hivemind.auth.v1.RevokeTokenRequest
+	15, // 26: hivemind.auth.v1.AuthService.ListTokens:input_type -> hivemind.auth.v1.ListTokensRequest
+	19, // 27: hivemind.auth.v1.AuthService.GetCurrentUser:input_type -> hivemind.auth.v1.GetCurrentUserRequest
+	22, // 28: hivemind.auth.v1.AuthService.LinkDiscordAccount:input_type -> hivemind.auth.v1.LinkDiscordAccountRequest
+	17, // 29: hivemind.auth.v1.AuthService.ListUsers:input_type -> hivemind.auth.v1.ListUsersRequest
+	24, // 30: hivemind.auth.v1.AuthService.GetUser:input_type -> hivemind.auth.v1.GetUserRequest
+	26, // 31: hivemind.auth.v1.AuthService.UpdateUserRole:input_type -> hivemind.auth.v1.UpdateUserRoleRequest
+	28, // 32: hivemind.auth.v1.AuthService.DeleteUser:input_type -> hivemind.auth.v1.DeleteUserRequest
+	1,  // 33: hivemind.auth.v1.AuthService.GetOAuthConfig:output_type -> hivemind.auth.v1.GetOAuthConfigResponse
+	4,  // 34: hivemind.auth.v1.AuthService.ExchangeAuthCode:output_type -> hivemind.auth.v1.ExchangeAuthCodeResponse
+	6,  // 35: hivemind.auth.v1.AuthService.LoginWithOIDC:output_type -> hivemind.auth.v1.LoginWithOIDCResponse
+	8,  // 36: hivemind.auth.v1.AuthService.RefreshOAuthToken:output_type -> hivemind.auth.v1.RefreshOAuthTokenResponse
+	10, // 37: hivemind.auth.v1.AuthService.AuthenticateLocal:output_type -> hivemind.auth.v1.AuthenticateLocalResponse
+	12, // 38: hivemind.auth.v1.AuthService.RefreshToken:output_type -> hivemind.auth.v1.RefreshTokenResponse
+	14, // 39: hivemind.auth.v1.AuthService.RevokeToken:output_type -> hivemind.auth.v1.RevokeTokenResponse
+	16, // 40: hivemind.auth.v1.AuthService.ListTokens:output_type -> hivemind.auth.v1.ListTokensResponse
+	20, // 41: hivemind.auth.v1.AuthService.GetCurrentUser:output_type -> hivemind.auth.v1.GetCurrentUserResponse
+	23, // 42: hivemind.auth.v1.AuthService.LinkDiscordAccount:output_type -> hivemind.auth.v1.LinkDiscordAccountResponse
+	18, // 43: hivemind.auth.v1.AuthService.ListUsers:output_type -> hivemind.auth.v1.ListUsersResponse
+	25, // 44: hivemind.auth.v1.AuthService.GetUser:output_type -> hivemind.auth.v1.GetUserResponse
+	27, // 45: hivemind.auth.v1.AuthService.UpdateUserRole:output_type -> hivemind.auth.v1.UpdateUserRoleResponse
+	33, // 46: hivemind.auth.v1.AuthService.DeleteUser:output_type -> google.protobuf.Empty
+	33, // [33:47] is the sub-list for method output_type
+	19, // [19:33] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_auth_proto_init() }
@@ -1594,7 +1936,7 @@ func file_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_proto_rawDesc), len(file_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   24,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
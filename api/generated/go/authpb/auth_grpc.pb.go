@@ -20,18 +20,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_GetOAuthConfig_FullMethodName    = "/hivemind.auth.v1.AuthService/GetOAuthConfig"
-	AuthService_ExchangeAuthCode_FullMethodName  = "/hivemind.auth.v1.AuthService/ExchangeAuthCode"
-	AuthService_LoginWithOIDC_FullMethodName     = "/hivemind.auth.v1.AuthService/LoginWithOIDC"
-	AuthService_RefreshOAuthToken_FullMethodName = "/hivemind.auth.v1.AuthService/RefreshOAuthToken"
-	AuthService_AuthenticateLocal_FullMethodName = "/hivemind.auth.v1.AuthService/AuthenticateLocal"
-	AuthService_RefreshToken_FullMethodName      = "/hivemind.auth.v1.AuthService/RefreshToken"
-	AuthService_RevokeToken_FullMethodName       = "/hivemind.auth.v1.AuthService/RevokeToken"
-	AuthService_ListTokens_FullMethodName        = "/hivemind.auth.v1.AuthService/ListTokens"
-	AuthService_ListUsers_FullMethodName         = "/hivemind.auth.v1.AuthService/ListUsers"
-	AuthService_GetUser_FullMethodName           = "/hivemind.auth.v1.AuthService/GetUser"
-	AuthService_UpdateUserRole_FullMethodName    = "/hivemind.auth.v1.AuthService/UpdateUserRole"
-	AuthService_DeleteUser_FullMethodName        = "/hivemind.auth.v1.AuthService/DeleteUser"
+	AuthService_GetOAuthConfig_FullMethodName     = "/hivemind.auth.v1.AuthService/GetOAuthConfig"
+	AuthService_ExchangeAuthCode_FullMethodName   = "/hivemind.auth.v1.AuthService/ExchangeAuthCode"
+	AuthService_LoginWithOIDC_FullMethodName      = "/hivemind.auth.v1.AuthService/LoginWithOIDC"
+	AuthService_RefreshOAuthToken_FullMethodName  = "/hivemind.auth.v1.AuthService/RefreshOAuthToken"
+	AuthService_AuthenticateLocal_FullMethodName  = "/hivemind.auth.v1.AuthService/AuthenticateLocal"
+	AuthService_RefreshToken_FullMethodName       = "/hivemind.auth.v1.AuthService/RefreshToken"
+	AuthService_RevokeToken_FullMethodName        = "/hivemind.auth.v1.AuthService/RevokeToken"
+	AuthService_ListTokens_FullMethodName         = "/hivemind.auth.v1.AuthService/ListTokens"
+	AuthService_GetCurrentUser_FullMethodName     = "/hivemind.auth.v1.AuthService/GetCurrentUser"
+	AuthService_LinkDiscordAccount_FullMethodName = "/hivemind.auth.v1.AuthService/LinkDiscordAccount"
+	AuthService_ListUsers_FullMethodName          = "/hivemind.auth.v1.AuthService/ListUsers"
+	AuthService_GetUser_FullMethodName            = "/hivemind.auth.v1.AuthService/GetUser"
+	AuthService_UpdateUserRole_FullMethodName     = "/hivemind.auth.v1.AuthService/UpdateUserRole"
+	AuthService_DeleteUser_FullMethodName         = "/hivemind.auth.v1.AuthService/DeleteUser"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -56,6 +58,11 @@ type AuthServiceClient interface {
 	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
 	RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*RevokeTokenResponse, error)
 	ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error)
+	// Current user profile - identity always comes from the caller's token, never a request argument
+	GetCurrentUser(ctx context.Context, in *GetCurrentUserRequest, opts ...grpc.CallOption) (*GetCurrentUserResponse, error)
+	// Link a Discord account to the caller, e.g. a local admin with none. The caller
+	// completes a Discord authorization code flow and passes the code here.
+	LinkDiscordAccount(ctx context.Context, in *LinkDiscordAccountRequest, opts ...grpc.CallOption) (*LinkDiscordAccountResponse, error)
 	// User management (admin only)
 	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
 	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
@@ -152,6 +159,26 @@ func (c *authServiceClient) ListTokens(ctx context.Context, in *ListTokensReques
 	return out, nil
 }
 
+func (c *authServiceClient) GetCurrentUser(ctx context.Context, in *GetCurrentUserRequest, opts ...grpc.CallOption) (*GetCurrentUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCurrentUserResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetCurrentUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) LinkDiscordAccount(ctx context.Context, in *LinkDiscordAccountRequest, opts ...grpc.CallOption) (*LinkDiscordAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LinkDiscordAccountResponse)
+	err := c.cc.Invoke(ctx, AuthService_LinkDiscordAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListUsersResponse)
@@ -214,6 +241,11 @@ type AuthServiceServer interface {
 	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
 	RevokeToken(context.Context, *RevokeTokenRequest) (*RevokeTokenResponse, error)
 	ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error)
+	// Current user profile - identity always comes from the caller's token, never a request argument
+	GetCurrentUser(context.Context, *GetCurrentUserRequest) (*GetCurrentUserResponse, error)
+	// Link a Discord account to the caller, e.g. a local admin with none. The caller
+	// completes a Discord authorization code flow and passes the code here.
+	LinkDiscordAccount(context.Context, *LinkDiscordAccountRequest) (*LinkDiscordAccountResponse, error)
 	// User management (admin only)
 	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
 	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
@@ -252,6 +284,12 @@ func (UnimplementedAuthServiceServer) RevokeToken(context.Context, *RevokeTokenR
 func (UnimplementedAuthServiceServer) ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListTokens not implemented")
 }
+func (UnimplementedAuthServiceServer) GetCurrentUser(context.Context, *GetCurrentUserRequest) (*GetCurrentUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCurrentUser not implemented")
+}
+func (UnimplementedAuthServiceServer) LinkDiscordAccount(context.Context, *LinkDiscordAccountRequest) (*LinkDiscordAccountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LinkDiscordAccount not implemented")
+}
 func (UnimplementedAuthServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListUsers not implemented")
 }
@@ -428,6 +466,42 @@ func _AuthService_ListTokens_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_GetCurrentUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetCurrentUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetCurrentUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetCurrentUser(ctx, req.(*GetCurrentUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LinkDiscordAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkDiscordAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LinkDiscordAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_LinkDiscordAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LinkDiscordAccount(ctx, req.(*LinkDiscordAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListUsersRequest)
 	if err := dec(in); err != nil {
@@ -539,6 +613,14 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTokens",
 			Handler:    _AuthService_ListTokens_Handler,
 		},
+		{
+			MethodName: "GetCurrentUser",
+			Handler:    _AuthService_GetCurrentUser_Handler,
+		},
+		{
+			MethodName: "LinkDiscordAccount",
+			Handler:    _AuthService_LinkDiscordAccount_Handler,
+		},
 		{
 			MethodName: "ListUsers",
 			Handler:    _AuthService_ListUsers_Handler,
@@ -182,6 +182,75 @@ func (x *SuccessResponse) GetMessage() string {
 	return ""
 }
 
+// Pagination describes where a page of list or search results sits in the full result set
+type Pagination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`                    // Results matching the request, across all pages
+	Returned      int32                  `protobuf:"varint,2,opt,name=returned,proto3" json:"returned,omitempty"`              // Results in this page
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`                  // Results skipped before this page; 0 when paging by page_token
+	HasMore       bool                   `protobuf:"varint,4,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"` // Whether another page follows this one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_common_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Pagination) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Pagination) GetReturned() int32 {
+	if x != nil {
+		return x.Returned
+	}
+	return 0
+}
+
+func (x *Pagination) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Pagination) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
 var File_common_proto protoreflect.FileDescriptor
 
 const file_common_proto_rawDesc = "" +
@@ -203,7 +272,13 @@ const file_common_proto_rawDesc = "" +
 	"revoked_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\"E\n" +
 	"\x0fSuccessResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessageB>Z<github.com/devilmonastery/hivemind/api/generated/go/commonpbb\x06proto3"
+	"\amessage\x18\x02 \x01(\tR\amessage\"q\n" +
+	"\n" +
+	"Pagination\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x1a\n" +
+	"\breturned\x18\x02 \x01(\x05R\breturned\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x19\n" +
+	"\bhas_more\x18\x04 \x01(\bR\ahasMoreB>Z<github.com/devilmonastery/hivemind/api/generated/go/commonpbb\x06proto3"
 
 var (
 	file_common_proto_rawDescOnce sync.Once
@@ -217,17 +292,18 @@ func file_common_proto_rawDescGZIP() []byte {
 	return file_common_proto_rawDescData
 }
 
-var file_common_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_common_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_common_proto_goTypes = []any{
 	(*APIToken)(nil),              // 0: hivemind.common.v1.APIToken
 	(*SuccessResponse)(nil),       // 1: hivemind.common.v1.SuccessResponse
-	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*Pagination)(nil),            // 2: hivemind.common.v1.Pagination
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
 }
 var file_common_proto_depIdxs = []int32{
-	2, // 0: hivemind.common.v1.APIToken.expires_at:type_name -> google.protobuf.Timestamp
-	2, // 1: hivemind.common.v1.APIToken.created_at:type_name -> google.protobuf.Timestamp
-	2, // 2: hivemind.common.v1.APIToken.last_used:type_name -> google.protobuf.Timestamp
-	2, // 3: hivemind.common.v1.APIToken.revoked_at:type_name -> google.protobuf.Timestamp
+	3, // 0: hivemind.common.v1.APIToken.expires_at:type_name -> google.protobuf.Timestamp
+	3, // 1: hivemind.common.v1.APIToken.created_at:type_name -> google.protobuf.Timestamp
+	3, // 2: hivemind.common.v1.APIToken.last_used:type_name -> google.protobuf.Timestamp
+	3, // 3: hivemind.common.v1.APIToken.revoked_at:type_name -> google.protobuf.Timestamp
 	4, // [4:4] is the sub-list for method output_type
 	4, // [4:4] is the sub-list for method input_type
 	4, // [4:4] is the sub-list for extension type_name
@@ -246,7 +322,7 @@ func file_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_proto_rawDesc), len(file_common_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -32,6 +32,7 @@ type Guild struct {
 	Enabled        bool                   `protobuf:"varint,5,opt,name=enabled,proto3" json:"enabled,omitempty"`
 	AddedAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
 	LastActivity   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
+	LastMemberSync *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_member_sync,json=lastMemberSync,proto3" json:"last_member_sync,omitempty"` // Last full member sync; unset if never synced
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -115,6 +116,13 @@ func (x *Guild) GetLastActivity() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Guild) GetLastMemberSync() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastMemberSync
+	}
+	return nil
+}
+
 type UpsertGuildRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	GuildId        string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
@@ -184,8 +192,11 @@ func (x *UpsertGuildRequest) GetOwnerDiscordId() string {
 }
 
 type UpsertGuildResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Guild         *Guild                 `protobuf:"bytes,1,opt,name=guild,proto3" json:"guild,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Guild *Guild                 `protobuf:"bytes,1,opt,name=guild,proto3" json:"guild,omitempty"`
+	// True when this call registered the guild for the first time, rather than
+	// updating a guild the bot already knew, e.g. after a gateway reconnect
+	Created       bool `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -227,6 +238,13 @@ func (x *UpsertGuildResponse) GetGuild() *Guild {
 	return nil
 }
 
+func (x *UpsertGuildResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
 type DisableGuildRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
@@ -683,8 +701,10 @@ func (x *UpsertGuildMemberResponse) GetSuccess() bool {
 }
 
 type UpsertGuildMembersBatchRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Members       []*GuildMember         `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Members []*GuildMember         `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	// Set when members is this guild's complete member list, to record the time as its last member sync
+	SyncedGuildId string `protobuf:"bytes,2,opt,name=synced_guild_id,json=syncedGuildId,proto3" json:"synced_guild_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -726,6 +746,13 @@ func (x *UpsertGuildMembersBatchRequest) GetMembers() []*GuildMember {
 	return nil
 }
 
+func (x *UpsertGuildMembersBatchRequest) GetSyncedGuildId() string {
+	if x != nil {
+		return x.SyncedGuildId
+	}
+	return ""
+}
+
 type UpsertGuildMembersBatchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"` // Number of members upserted
@@ -1054,6 +1081,18 @@ func (x *ListUserGuildsResponse) GetGuildIds() []string {
 type GuildSettings struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Announcements *AnnouncementSettings  `protobuf:"bytes,1,opt,name=announcements,proto3" json:"announcements,omitempty"`
+	Features      *FeatureSettings       `protobuf:"bytes,2,opt,name=features,proto3" json:"features,omitempty"`
+	Appearance    *AppearanceSettings    `protobuf:"bytes,3,opt,name=appearance,proto3" json:"appearance,omitempty"`
+	Webhook       *WebhookSettings       `protobuf:"bytes,4,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Permissions   *PermissionSettings    `protobuf:"bytes,5,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	Posting       *PostingSettings       `protobuf:"bytes,6,opt,name=posting,proto3" json:"posting,omitempty"`
+	Notes         *NoteSettings          `protobuf:"bytes,7,opt,name=notes,proto3" json:"notes,omitempty"`
+	Quotes        *QuoteSettings         `protobuf:"bytes,8,opt,name=quotes,proto3" json:"quotes,omitempty"`
+	References    *ReferenceSettings     `protobuf:"bytes,9,opt,name=references,proto3" json:"references,omitempty"`
+	// Incremented on every update. Send it back as expected_revision to make sure
+	// an update doesn't overwrite changes made since these settings were read.
+	Revision      int64            `protobuf:"varint,10,opt,name=revision,proto3" json:"revision,omitempty"`
+	Messages      *MessageSettings `protobuf:"bytes,11,opt,name=messages,proto3" json:"messages,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1095,6 +1134,185 @@ func (x *GuildSettings) GetAnnouncements() *AnnouncementSettings {
 	return nil
 }
 
+func (x *GuildSettings) GetFeatures() *FeatureSettings {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *GuildSettings) GetAppearance() *AppearanceSettings {
+	if x != nil {
+		return x.Appearance
+	}
+	return nil
+}
+
+func (x *GuildSettings) GetWebhook() *WebhookSettings {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *GuildSettings) GetPermissions() *PermissionSettings {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *GuildSettings) GetPosting() *PostingSettings {
+	if x != nil {
+		return x.Posting
+	}
+	return nil
+}
+
+func (x *GuildSettings) GetNotes() *NoteSettings {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *GuildSettings) GetQuotes() *QuoteSettings {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+func (x *GuildSettings) GetReferences() *ReferenceSettings {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+func (x *GuildSettings) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *GuildSettings) GetMessages() *MessageSettings {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// Guild wording for some bot responses. Events left out use the bot's built-in text.
+type MessageSettings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Template per event: note_created ({title}), quote_saved ({author}), and
+	// no_results ({query}, {kind}). Setting this section replaces all templates.
+	Templates     map[string]string `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageSettings) Reset() {
+	*x = MessageSettings{}
+	mi := &file_discord_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageSettings) ProtoMessage() {}
+
+func (x *MessageSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageSettings.ProtoReflect.Descriptor instead.
+func (*MessageSettings) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MessageSettings) GetTemplates() map[string]string {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+// Per-guild feature toggles. GetGuildSettings always populates these,
+// defaulting to enabled when a guild has never configured them.
+type FeatureSettings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WikiEnabled   bool                   `protobuf:"varint,1,opt,name=wiki_enabled,json=wikiEnabled,proto3" json:"wiki_enabled,omitempty"`
+	NotesEnabled  bool                   `protobuf:"varint,2,opt,name=notes_enabled,json=notesEnabled,proto3" json:"notes_enabled,omitempty"`
+	QuotesEnabled bool                   `protobuf:"varint,3,opt,name=quotes_enabled,json=quotesEnabled,proto3" json:"quotes_enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureSettings) Reset() {
+	*x = FeatureSettings{}
+	mi := &file_discord_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureSettings) ProtoMessage() {}
+
+func (x *FeatureSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureSettings.ProtoReflect.Descriptor instead.
+func (*FeatureSettings) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FeatureSettings) GetWikiEnabled() bool {
+	if x != nil {
+		return x.WikiEnabled
+	}
+	return false
+}
+
+func (x *FeatureSettings) GetNotesEnabled() bool {
+	if x != nil {
+		return x.NotesEnabled
+	}
+	return false
+}
+
+func (x *FeatureSettings) GetQuotesEnabled() bool {
+	if x != nil {
+		return x.QuotesEnabled
+	}
+	return false
+}
+
 type AnnouncementSettings struct {
 	state                    protoimpl.MessageState `protogen:"open.v1"`
 	Enabled                  bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
@@ -1104,13 +1322,14 @@ type AnnouncementSettings struct {
 	NotifyQuoteCreate        bool                   `protobuf:"varint,5,opt,name=notify_quote_create,json=notifyQuoteCreate,proto3" json:"notify_quote_create,omitempty"`
 	CreateThreads            bool                   `protobuf:"varint,6,opt,name=create_threads,json=createThreads,proto3" json:"create_threads,omitempty"`
 	ThreadAutoArchiveMinutes int32                  `protobuf:"varint,7,opt,name=thread_auto_archive_minutes,json=threadAutoArchiveMinutes,proto3" json:"thread_auto_archive_minutes,omitempty"`
+	WeeklyQuoteLeaderboard   bool                   `protobuf:"varint,8,opt,name=weekly_quote_leaderboard,json=weeklyQuoteLeaderboard,proto3" json:"weekly_quote_leaderboard,omitempty"` // Post the week's top quoted members and quoters every week
 	unknownFields            protoimpl.UnknownFields
 	sizeCache                protoimpl.SizeCache
 }
 
 func (x *AnnouncementSettings) Reset() {
 	*x = AnnouncementSettings{}
-	mi := &file_discord_proto_msgTypes[19]
+	mi := &file_discord_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1122,7 +1341,7 @@ func (x *AnnouncementSettings) String() string {
 func (*AnnouncementSettings) ProtoMessage() {}
 
 func (x *AnnouncementSettings) ProtoReflect() protoreflect.Message {
-	mi := &file_discord_proto_msgTypes[19]
+	mi := &file_discord_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1135,7 +1354,7 @@ func (x *AnnouncementSettings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnnouncementSettings.ProtoReflect.Descriptor instead.
 func (*AnnouncementSettings) Descriptor() ([]byte, []int) {
-	return file_discord_proto_rawDescGZIP(), []int{19}
+	return file_discord_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *AnnouncementSettings) GetEnabled() bool {
@@ -1187,29 +1406,38 @@ func (x *AnnouncementSettings) GetThreadAutoArchiveMinutes() int32 {
 	return 0
 }
 
-type UpdateGuildSettingsRequest struct {
+func (x *AnnouncementSettings) GetWeeklyQuoteLeaderboard() bool {
+	if x != nil {
+		return x.WeeklyQuoteLeaderboard
+	}
+	return false
+}
+
+// Per-guild embed colors as "#RRGGBB" hex strings. Empty means the bot default.
+type AppearanceSettings struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
-	Settings      *GuildSettings         `protobuf:"bytes,2,opt,name=settings,proto3" json:"settings,omitempty"`
+	WikiColor     string                 `protobuf:"bytes,1,opt,name=wiki_color,json=wikiColor,proto3" json:"wiki_color,omitempty"`
+	NoteColor     string                 `protobuf:"bytes,2,opt,name=note_color,json=noteColor,proto3" json:"note_color,omitempty"`
+	QuoteColor    string                 `protobuf:"bytes,3,opt,name=quote_color,json=quoteColor,proto3" json:"quote_color,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateGuildSettingsRequest) Reset() {
-	*x = UpdateGuildSettingsRequest{}
-	mi := &file_discord_proto_msgTypes[20]
+func (x *AppearanceSettings) Reset() {
+	*x = AppearanceSettings{}
+	mi := &file_discord_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateGuildSettingsRequest) String() string {
+func (x *AppearanceSettings) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateGuildSettingsRequest) ProtoMessage() {}
+func (*AppearanceSettings) ProtoMessage() {}
 
-func (x *UpdateGuildSettingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_discord_proto_msgTypes[20]
+func (x *AppearanceSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1220,47 +1448,58 @@ func (x *UpdateGuildSettingsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateGuildSettingsRequest.ProtoReflect.Descriptor instead.
-func (*UpdateGuildSettingsRequest) Descriptor() ([]byte, []int) {
-	return file_discord_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use AppearanceSettings.ProtoReflect.Descriptor instead.
+func (*AppearanceSettings) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *UpdateGuildSettingsRequest) GetGuildId() string {
+func (x *AppearanceSettings) GetWikiColor() string {
 	if x != nil {
-		return x.GuildId
+		return x.WikiColor
 	}
 	return ""
 }
 
-func (x *UpdateGuildSettingsRequest) GetSettings() *GuildSettings {
+func (x *AppearanceSettings) GetNoteColor() string {
 	if x != nil {
-		return x.Settings
+		return x.NoteColor
 	}
-	return nil
+	return ""
 }
 
-type UpdateGuildSettingsResponse struct {
+func (x *AppearanceSettings) GetQuoteColor() string {
+	if x != nil {
+		return x.QuoteColor
+	}
+	return ""
+}
+
+// Outbound webhook notified when wiki pages, notes, or quotes are created or updated.
+// Deliveries are signed with an HMAC-SHA256 of the body in the X-Hivemind-Signature header.
+type WebhookSettings struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Settings      *GuildSettings         `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`                               // https URL on a public address to POST events to; empty disables the webhook
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`                         // Write-only: never returned. Empty on update keeps the current secret.
+	SecretSet     bool                   `protobuf:"varint,3,opt,name=secret_set,json=secretSet,proto3" json:"secret_set,omitempty"` // Output only: whether a signing secret is configured
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateGuildSettingsResponse) Reset() {
-	*x = UpdateGuildSettingsResponse{}
-	mi := &file_discord_proto_msgTypes[21]
+func (x *WebhookSettings) Reset() {
+	*x = WebhookSettings{}
+	mi := &file_discord_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateGuildSettingsResponse) String() string {
+func (x *WebhookSettings) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateGuildSettingsResponse) ProtoMessage() {}
+func (*WebhookSettings) ProtoMessage() {}
 
-func (x *UpdateGuildSettingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_discord_proto_msgTypes[21]
+func (x *WebhookSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1271,40 +1510,56 @@ func (x *UpdateGuildSettingsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateGuildSettingsResponse.ProtoReflect.Descriptor instead.
-func (*UpdateGuildSettingsResponse) Descriptor() ([]byte, []int) {
-	return file_discord_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use WebhookSettings.ProtoReflect.Descriptor instead.
+func (*WebhookSettings) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *UpdateGuildSettingsResponse) GetSettings() *GuildSettings {
+func (x *WebhookSettings) GetUrl() string {
 	if x != nil {
-		return x.Settings
+		return x.Url
 	}
-	return nil
+	return ""
 }
 
-type GetGuildSettingsRequest struct {
+func (x *WebhookSettings) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *WebhookSettings) GetSecretSet() bool {
+	if x != nil {
+		return x.SecretSet
+	}
+	return false
+}
+
+// Discord roles required to change content. An empty list lets every guild member
+// do it; the guild owner and Hivemind admins are never restricted.
+type PermissionSettings struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
+	WikiEditRoles []string               `protobuf:"bytes,1,rep,name=wiki_edit_roles,json=wikiEditRoles,proto3" json:"wiki_edit_roles,omitempty"` // Role IDs allowed to create and edit wiki pages
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetGuildSettingsRequest) Reset() {
-	*x = GetGuildSettingsRequest{}
-	mi := &file_discord_proto_msgTypes[22]
+func (x *PermissionSettings) Reset() {
+	*x = PermissionSettings{}
+	mi := &file_discord_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetGuildSettingsRequest) String() string {
+func (x *PermissionSettings) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetGuildSettingsRequest) ProtoMessage() {}
+func (*PermissionSettings) ProtoMessage() {}
 
-func (x *GetGuildSettingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_discord_proto_msgTypes[22]
+func (x *PermissionSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1315,40 +1570,43 @@ func (x *GetGuildSettingsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetGuildSettingsRequest.ProtoReflect.Descriptor instead.
-func (*GetGuildSettingsRequest) Descriptor() ([]byte, []int) {
-	return file_discord_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use PermissionSettings.ProtoReflect.Descriptor instead.
+func (*PermissionSettings) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *GetGuildSettingsRequest) GetGuildId() string {
+func (x *PermissionSettings) GetWikiEditRoles() []string {
 	if x != nil {
-		return x.GuildId
+		return x.WikiEditRoles
 	}
-	return ""
+	return nil
 }
 
-type GetGuildSettingsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Settings      *GuildSettings         `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+// How the bot posts content to a channel from "Make visible to channel".
+type PostingSettings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Post as a reply to the message the content was saved from, when that message
+	// is in the same channel. Falls back to a plain message when it was deleted.
+	ReplyToSource bool `protobuf:"varint,1,opt,name=reply_to_source,json=replyToSource,proto3" json:"reply_to_source,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetGuildSettingsResponse) Reset() {
-	*x = GetGuildSettingsResponse{}
-	mi := &file_discord_proto_msgTypes[23]
+func (x *PostingSettings) Reset() {
+	*x = PostingSettings{}
+	mi := &file_discord_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetGuildSettingsResponse) String() string {
+func (x *PostingSettings) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetGuildSettingsResponse) ProtoMessage() {}
+func (*PostingSettings) ProtoMessage() {}
 
-func (x *GetGuildSettingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_discord_proto_msgTypes[23]
+func (x *PostingSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1359,40 +1617,959 @@ func (x *GetGuildSettingsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetGuildSettingsResponse.ProtoReflect.Descriptor instead.
-func (*GetGuildSettingsResponse) Descriptor() ([]byte, []int) {
-	return file_discord_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use PostingSettings.ProtoReflect.Descriptor instead.
+func (*PostingSettings) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *GetGuildSettingsResponse) GetSettings() *GuildSettings {
+func (x *PostingSettings) GetReplyToSource() bool {
 	if x != nil {
-		return x.Settings
+		return x.ReplyToSource
 	}
-	return nil
+	return false
 }
 
-var File_discord_proto protoreflect.FileDescriptor
+// How notes are saved in a guild.
+type NoteSettings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Reject a note whose title matches, ignoring case, another of the author's
+	// notes in the same guild. Off by default.
+	UniqueTitles  bool `protobuf:"varint,1,opt,name=unique_titles,json=uniqueTitles,proto3" json:"unique_titles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_discord_proto_rawDesc = "" +
-	"\n" +
-	"\rdiscord.proto\x12\x10hivemind.discord\x1a\x1fgoogle/protobuf/timestamp.proto\"\x98\x02\n" +
-	"\x05Guild\x12\x19\n" +
-	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x1d\n" +
-	"\n" +
-	"guild_name\x18\x02 \x01(\tR\tguildName\x12\x19\n" +
-	"\bicon_url\x18\x03 \x01(\tR\aiconUrl\x12(\n" +
-	"\x10owner_discord_id\x18\x04 \x01(\tR\x0eownerDiscordId\x12\x18\n" +
-	"\aenabled\x18\x05 \x01(\bR\aenabled\x125\n" +
-	"\badded_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt\x12?\n" +
-	"\rlast_activity\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\flastActivity\"\x93\x01\n" +
+func (x *NoteSettings) Reset() {
+	*x = NoteSettings{}
+	mi := &file_discord_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteSettings) ProtoMessage() {}
+
+func (x *NoteSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteSettings.ProtoReflect.Descriptor instead.
+func (*NoteSettings) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *NoteSettings) GetUniqueTitles() bool {
+	if x != nil {
+		return x.UniqueTitles
+	}
+	return false
+}
+
+// How quotes are saved in a guild.
+type QuoteSettings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Minimum seconds between one member's quotes in the guild, at most a day.
+	// Hivemind admins are exempt. 0 (the default) disables the cooldown.
+	CooldownSeconds int32 `protobuf:"varint,1,opt,name=cooldown_seconds,json=cooldownSeconds,proto3" json:"cooldown_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *QuoteSettings) Reset() {
+	*x = QuoteSettings{}
+	mi := &file_discord_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteSettings) ProtoMessage() {}
+
+func (x *QuoteSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteSettings.ProtoReflect.Descriptor instead.
+func (*QuoteSettings) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *QuoteSettings) GetCooldownSeconds() int32 {
+	if x != nil {
+		return x.CooldownSeconds
+	}
+	return 0
+}
+
+// How many Discord messages wiki pages and notes may reference in a guild.
+type ReferenceSettings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most message references one wiki page or note may have, at most 10000.
+	// 0 (the default) uses the server default of 500.
+	MaxPerItem    int32 `protobuf:"varint,1,opt,name=max_per_item,json=maxPerItem,proto3" json:"max_per_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReferenceSettings) Reset() {
+	*x = ReferenceSettings{}
+	mi := &file_discord_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferenceSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferenceSettings) ProtoMessage() {}
+
+func (x *ReferenceSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferenceSettings.ProtoReflect.Descriptor instead.
+func (*ReferenceSettings) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReferenceSettings) GetMaxPerItem() int32 {
+	if x != nil {
+		return x.MaxPerItem
+	}
+	return 0
+}
+
+// Only the sections set in settings are replaced; unset sections keep their
+// current values.
+type UpdateGuildSettingsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	GuildId  string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
+	Settings *GuildSettings         `protobuf:"bytes,2,opt,name=settings,proto3" json:"settings,omitempty"`
+	// When set, the update fails with ABORTED unless the stored settings are still
+	// at this revision. 0 applies the update whatever the current revision.
+	ExpectedRevision int64 `protobuf:"varint,3,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateGuildSettingsRequest) Reset() {
+	*x = UpdateGuildSettingsRequest{}
+	mi := &file_discord_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGuildSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGuildSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateGuildSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGuildSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGuildSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdateGuildSettingsRequest) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *UpdateGuildSettingsRequest) GetSettings() *GuildSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *UpdateGuildSettingsRequest) GetExpectedRevision() int64 {
+	if x != nil {
+		return x.ExpectedRevision
+	}
+	return 0
+}
+
+type UpdateGuildSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *GuildSettings         `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGuildSettingsResponse) Reset() {
+	*x = UpdateGuildSettingsResponse{}
+	mi := &file_discord_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGuildSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGuildSettingsResponse) ProtoMessage() {}
+
+func (x *UpdateGuildSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGuildSettingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateGuildSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateGuildSettingsResponse) GetSettings() *GuildSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type GetGuildSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGuildSettingsRequest) Reset() {
+	*x = GetGuildSettingsRequest{}
+	mi := &file_discord_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGuildSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGuildSettingsRequest) ProtoMessage() {}
+
+func (x *GetGuildSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGuildSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetGuildSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetGuildSettingsRequest) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+type GetGuildSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *GuildSettings         `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGuildSettingsResponse) Reset() {
+	*x = GetGuildSettingsResponse{}
+	mi := &file_discord_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGuildSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGuildSettingsResponse) ProtoMessage() {}
+
+func (x *GetGuildSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGuildSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetGuildSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetGuildSettingsResponse) GetSettings() *GuildSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// DiscordUser is the cached profile of a Discord account
+type DiscordUser struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DiscordId         string                 `protobuf:"bytes,1,opt,name=discord_id,json=discordId,proto3" json:"discord_id,omitempty"`
+	DiscordUsername   string                 `protobuf:"bytes,2,opt,name=discord_username,json=discordUsername,proto3" json:"discord_username,omitempty"`
+	DiscordGlobalName string                 `protobuf:"bytes,3,opt,name=discord_global_name,json=discordGlobalName,proto3" json:"discord_global_name,omitempty"`
+	AvatarHash        string                 `protobuf:"bytes,4,opt,name=avatar_hash,json=avatarHash,proto3" json:"avatar_hash,omitempty"`
+	LastSeen          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DiscordUser) Reset() {
+	*x = DiscordUser{}
+	mi := &file_discord_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscordUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscordUser) ProtoMessage() {}
+
+func (x *DiscordUser) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscordUser.ProtoReflect.Descriptor instead.
+func (*DiscordUser) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DiscordUser) GetDiscordId() string {
+	if x != nil {
+		return x.DiscordId
+	}
+	return ""
+}
+
+func (x *DiscordUser) GetDiscordUsername() string {
+	if x != nil {
+		return x.DiscordUsername
+	}
+	return ""
+}
+
+func (x *DiscordUser) GetDiscordGlobalName() string {
+	if x != nil {
+		return x.DiscordGlobalName
+	}
+	return ""
+}
+
+func (x *DiscordUser) GetAvatarHash() string {
+	if x != nil {
+		return x.AvatarHash
+	}
+	return ""
+}
+
+func (x *DiscordUser) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+type ListDiscordUsersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SeenSince      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=seen_since,json=seenSince,proto3" json:"seen_since,omitempty"`                  // Only users seen at or after this time
+	AfterDiscordId string                 `protobuf:"bytes,2,opt,name=after_discord_id,json=afterDiscordId,proto3" json:"after_discord_id,omitempty"` // Pagination cursor (exclusive)
+	Limit          int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                          // Default 500, max 1000
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListDiscordUsersRequest) Reset() {
+	*x = ListDiscordUsersRequest{}
+	mi := &file_discord_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDiscordUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDiscordUsersRequest) ProtoMessage() {}
+
+func (x *ListDiscordUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDiscordUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListDiscordUsersRequest) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListDiscordUsersRequest) GetSeenSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SeenSince
+	}
+	return nil
+}
+
+func (x *ListDiscordUsersRequest) GetAfterDiscordId() string {
+	if x != nil {
+		return x.AfterDiscordId
+	}
+	return ""
+}
+
+func (x *ListDiscordUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListDiscordUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*DiscordUser         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDiscordUsersResponse) Reset() {
+	*x = ListDiscordUsersResponse{}
+	mi := &file_discord_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDiscordUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDiscordUsersResponse) ProtoMessage() {}
+
+func (x *ListDiscordUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDiscordUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListDiscordUsersResponse) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListDiscordUsersResponse) GetUsers() []*DiscordUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type UpdateDiscordUsersBatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// last_seen is written back as given, so callers should echo the listed value
+	Users         []*DiscordUser `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDiscordUsersBatchRequest) Reset() {
+	*x = UpdateDiscordUsersBatchRequest{}
+	mi := &file_discord_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDiscordUsersBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDiscordUsersBatchRequest) ProtoMessage() {}
+
+func (x *UpdateDiscordUsersBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDiscordUsersBatchRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDiscordUsersBatchRequest) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UpdateDiscordUsersBatchRequest) GetUsers() []*DiscordUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type UpdateDiscordUsersBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDiscordUsersBatchResponse) Reset() {
+	*x = UpdateDiscordUsersBatchResponse{}
+	mi := &file_discord_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDiscordUsersBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDiscordUsersBatchResponse) ProtoMessage() {}
+
+func (x *UpdateDiscordUsersBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDiscordUsersBatchResponse.ProtoReflect.Descriptor instead.
+func (*UpdateDiscordUsersBatchResponse) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UpdateDiscordUsersBatchResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// DeadLetter is an outbound Discord call (a channel post, announcement, or
+// interaction reply) that still failed after the bot's retries, so it was dropped.
+type DeadLetter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Operation     string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`                        // What the bot was doing, e.g. "wiki_announcement"
+	GuildId       string                 `protobuf:"bytes,3,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`             // Empty when not sent in a guild
+	ChannelId     string                 `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`       // Empty for interaction replies
+	FailureType   string                 `protobuf:"bytes,5,opt,name=failure_type,json=failureType,proto3" json:"failure_type,omitempty"` // e.g. "rate_limited", "forbidden", "server_error"
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts      int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Payload       string                 `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"` // Summary of what was being sent
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_discord_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeadLetter) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetFailureType() string {
+	if x != nil {
+		return x.FailureType
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type RecordDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetter    *DeadLetter            `protobuf:"bytes,1,opt,name=dead_letter,json=deadLetter,proto3" json:"dead_letter,omitempty"` // id and created_at are assigned by the server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordDeadLetterRequest) Reset() {
+	*x = RecordDeadLetterRequest{}
+	mi := &file_discord_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordDeadLetterRequest) ProtoMessage() {}
+
+func (x *RecordDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*RecordDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RecordDeadLetterRequest) GetDeadLetter() *DeadLetter {
+	if x != nil {
+		return x.DeadLetter
+	}
+	return nil
+}
+
+type RecordDeadLetterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetter    *DeadLetter            `protobuf:"bytes,1,opt,name=dead_letter,json=deadLetter,proto3" json:"dead_letter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordDeadLetterResponse) Reset() {
+	*x = RecordDeadLetterResponse{}
+	mi := &file_discord_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordDeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordDeadLetterResponse) ProtoMessage() {}
+
+func (x *RecordDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*RecordDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RecordDeadLetterResponse) GetDeadLetter() *DeadLetter {
+	if x != nil {
+		return x.DeadLetter
+	}
+	return nil
+}
+
+type ListDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Optional: only this guild's
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                   // Default 50, max 200
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_discord_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListDeadLettersRequest) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *ListDeadLettersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDeadLettersRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters   []*DeadLetter          `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_discord_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResponse) ProtoMessage() {}
+
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_discord_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_discord_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+func (x *ListDeadLettersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+var File_discord_proto protoreflect.FileDescriptor
+
+const file_discord_proto_rawDesc = "" +
+	"\n" +
+	"\rdiscord.proto\x12\x10hivemind.discord\x1a\x1fgoogle/protobuf/timestamp.proto\"\xde\x02\n" +
+	"\x05Guild\x12\x19\n" +
+	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x1d\n" +
+	"\n" +
+	"guild_name\x18\x02 \x01(\tR\tguildName\x12\x19\n" +
+	"\bicon_url\x18\x03 \x01(\tR\aiconUrl\x12(\n" +
+	"\x10owner_discord_id\x18\x04 \x01(\tR\x0eownerDiscordId\x12\x18\n" +
+	"\aenabled\x18\x05 \x01(\bR\aenabled\x125\n" +
+	"\badded_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt\x12?\n" +
+	"\rlast_activity\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\flastActivity\x12D\n" +
+	"\x10last_member_sync\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0elastMemberSync\"\x93\x01\n" +
 	"\x12UpsertGuildRequest\x12\x19\n" +
 	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x1d\n" +
 	"\n" +
 	"guild_name\x18\x02 \x01(\tR\tguildName\x12\x19\n" +
 	"\bicon_url\x18\x03 \x01(\tR\aiconUrl\x12(\n" +
-	"\x10owner_discord_id\x18\x04 \x01(\tR\x0eownerDiscordId\"D\n" +
+	"\x10owner_discord_id\x18\x04 \x01(\tR\x0eownerDiscordId\"^\n" +
 	"\x13UpsertGuildResponse\x12-\n" +
-	"\x05guild\x18\x01 \x01(\v2\x17.hivemind.discord.GuildR\x05guild\"0\n" +
+	"\x05guild\x18\x01 \x01(\v2\x17.hivemind.discord.GuildR\x05guild\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\"0\n" +
 	"\x13DisableGuildRequest\x12\x19\n" +
 	"\bguild_id\x18\x01 \x01(\tR\aguildId\"0\n" +
 	"\x14DisableGuildResponse\x12\x18\n" +
@@ -1431,9 +2608,10 @@ const file_discord_proto_rawDesc = "" +
 	"\vavatar_hash\x18\t \x01(\tR\n" +
 	"avatarHash\"5\n" +
 	"\x19UpsertGuildMemberResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"Y\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x81\x01\n" +
 	"\x1eUpsertGuildMembersBatchRequest\x127\n" +
-	"\amembers\x18\x01 \x03(\v2\x1d.hivemind.discord.GuildMemberR\amembers\"7\n" +
+	"\amembers\x18\x01 \x03(\v2\x1d.hivemind.discord.GuildMemberR\amembers\x12&\n" +
+	"\x0fsynced_guild_id\x18\x02 \x01(\tR\rsyncedGuildId\"7\n" +
 	"\x1fUpsertGuildMembersBatchResponse\x12\x14\n" +
 	"\x05count\x18\x01 \x01(\x05R\x05count\"T\n" +
 	"\x18RemoveGuildMemberRequest\x12\x19\n" +
@@ -1452,9 +2630,33 @@ const file_discord_proto_rawDesc = "" +
 	"\n" +
 	"discord_id\x18\x01 \x01(\tR\tdiscordId\"5\n" +
 	"\x16ListUserGuildsResponse\x12\x1b\n" +
-	"\tguild_ids\x18\x01 \x03(\tR\bguildIds\"]\n" +
+	"\tguild_ids\x18\x01 \x03(\tR\bguildIds\"\xb3\x05\n" +
 	"\rGuildSettings\x12L\n" +
-	"\rannouncements\x18\x01 \x01(\v2&.hivemind.discord.AnnouncementSettingsR\rannouncements\"\xbd\x02\n" +
+	"\rannouncements\x18\x01 \x01(\v2&.hivemind.discord.AnnouncementSettingsR\rannouncements\x12=\n" +
+	"\bfeatures\x18\x02 \x01(\v2!.hivemind.discord.FeatureSettingsR\bfeatures\x12D\n" +
+	"\n" +
+	"appearance\x18\x03 \x01(\v2$.hivemind.discord.AppearanceSettingsR\n" +
+	"appearance\x12;\n" +
+	"\awebhook\x18\x04 \x01(\v2!.hivemind.discord.WebhookSettingsR\awebhook\x12F\n" +
+	"\vpermissions\x18\x05 \x01(\v2$.hivemind.discord.PermissionSettingsR\vpermissions\x12;\n" +
+	"\aposting\x18\x06 \x01(\v2!.hivemind.discord.PostingSettingsR\aposting\x124\n" +
+	"\x05notes\x18\a \x01(\v2\x1e.hivemind.discord.NoteSettingsR\x05notes\x127\n" +
+	"\x06quotes\x18\b \x01(\v2\x1f.hivemind.discord.QuoteSettingsR\x06quotes\x12C\n" +
+	"\n" +
+	"references\x18\t \x01(\v2#.hivemind.discord.ReferenceSettingsR\n" +
+	"references\x12\x1a\n" +
+	"\brevision\x18\n" +
+	" \x01(\x03R\brevision\x12=\n" +
+	"\bmessages\x18\v \x01(\v2!.hivemind.discord.MessageSettingsR\bmessages\"\x9f\x01\n" +
+	"\x0fMessageSettings\x12N\n" +
+	"\ttemplates\x18\x01 \x03(\v20.hivemind.discord.MessageSettings.TemplatesEntryR\ttemplates\x1a<\n" +
+	"\x0eTemplatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x80\x01\n" +
+	"\x0fFeatureSettings\x12!\n" +
+	"\fwiki_enabled\x18\x01 \x01(\bR\vwikiEnabled\x12#\n" +
+	"\rnotes_enabled\x18\x02 \x01(\bR\fnotesEnabled\x12%\n" +
+	"\x0equotes_enabled\x18\x03 \x01(\bR\rquotesEnabled\"\xf7\x02\n" +
 	"\x14AnnouncementSettings\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1d\n" +
 	"\n" +
@@ -1463,16 +2665,86 @@ const file_discord_proto_rawDesc = "" +
 	"\x10notify_wiki_edit\x18\x04 \x01(\bR\x0enotifyWikiEdit\x12.\n" +
 	"\x13notify_quote_create\x18\x05 \x01(\bR\x11notifyQuoteCreate\x12%\n" +
 	"\x0ecreate_threads\x18\x06 \x01(\bR\rcreateThreads\x12=\n" +
-	"\x1bthread_auto_archive_minutes\x18\a \x01(\x05R\x18threadAutoArchiveMinutes\"t\n" +
+	"\x1bthread_auto_archive_minutes\x18\a \x01(\x05R\x18threadAutoArchiveMinutes\x128\n" +
+	"\x18weekly_quote_leaderboard\x18\b \x01(\bR\x16weeklyQuoteLeaderboard\"s\n" +
+	"\x12AppearanceSettings\x12\x1d\n" +
+	"\n" +
+	"wiki_color\x18\x01 \x01(\tR\twikiColor\x12\x1d\n" +
+	"\n" +
+	"note_color\x18\x02 \x01(\tR\tnoteColor\x12\x1f\n" +
+	"\vquote_color\x18\x03 \x01(\tR\n" +
+	"quoteColor\"Z\n" +
+	"\x0fWebhookSettings\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x1d\n" +
+	"\n" +
+	"secret_set\x18\x03 \x01(\bR\tsecretSet\"<\n" +
+	"\x12PermissionSettings\x12&\n" +
+	"\x0fwiki_edit_roles\x18\x01 \x03(\tR\rwikiEditRoles\"9\n" +
+	"\x0fPostingSettings\x12&\n" +
+	"\x0freply_to_source\x18\x01 \x01(\bR\rreplyToSource\"3\n" +
+	"\fNoteSettings\x12#\n" +
+	"\runique_titles\x18\x01 \x01(\bR\funiqueTitles\":\n" +
+	"\rQuoteSettings\x12)\n" +
+	"\x10cooldown_seconds\x18\x01 \x01(\x05R\x0fcooldownSeconds\"5\n" +
+	"\x11ReferenceSettings\x12 \n" +
+	"\fmax_per_item\x18\x01 \x01(\x05R\n" +
+	"maxPerItem\"\xa1\x01\n" +
 	"\x1aUpdateGuildSettingsRequest\x12\x19\n" +
 	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12;\n" +
-	"\bsettings\x18\x02 \x01(\v2\x1f.hivemind.discord.GuildSettingsR\bsettings\"Z\n" +
+	"\bsettings\x18\x02 \x01(\v2\x1f.hivemind.discord.GuildSettingsR\bsettings\x12+\n" +
+	"\x11expected_revision\x18\x03 \x01(\x03R\x10expectedRevision\"Z\n" +
 	"\x1bUpdateGuildSettingsResponse\x12;\n" +
 	"\bsettings\x18\x01 \x01(\v2\x1f.hivemind.discord.GuildSettingsR\bsettings\"4\n" +
 	"\x17GetGuildSettingsRequest\x12\x19\n" +
 	"\bguild_id\x18\x01 \x01(\tR\aguildId\"W\n" +
 	"\x18GetGuildSettingsResponse\x12;\n" +
-	"\bsettings\x18\x01 \x01(\v2\x1f.hivemind.discord.GuildSettingsR\bsettings2\xb5\b\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1f.hivemind.discord.GuildSettingsR\bsettings\"\xe1\x01\n" +
+	"\vDiscordUser\x12\x1d\n" +
+	"\n" +
+	"discord_id\x18\x01 \x01(\tR\tdiscordId\x12)\n" +
+	"\x10discord_username\x18\x02 \x01(\tR\x0fdiscordUsername\x12.\n" +
+	"\x13discord_global_name\x18\x03 \x01(\tR\x11discordGlobalName\x12\x1f\n" +
+	"\vavatar_hash\x18\x04 \x01(\tR\n" +
+	"avatarHash\x127\n" +
+	"\tlast_seen\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\"\x94\x01\n" +
+	"\x17ListDiscordUsersRequest\x129\n" +
+	"\n" +
+	"seen_since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tseenSince\x12(\n" +
+	"\x10after_discord_id\x18\x02 \x01(\tR\x0eafterDiscordId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"O\n" +
+	"\x18ListDiscordUsersResponse\x123\n" +
+	"\x05users\x18\x01 \x03(\v2\x1d.hivemind.discord.DiscordUserR\x05users\"U\n" +
+	"\x1eUpdateDiscordUsersBatchRequest\x123\n" +
+	"\x05users\x18\x01 \x03(\v2\x1d.hivemind.discord.DiscordUserR\x05users\"7\n" +
+	"\x1fUpdateDiscordUsersBatchResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\"\x9e\x02\n" +
+	"\n" +
+	"DeadLetter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12\x19\n" +
+	"\bguild_id\x18\x03 \x01(\tR\aguildId\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x04 \x01(\tR\tchannelId\x12!\n" +
+	"\ffailure_type\x18\x05 \x01(\tR\vfailureType\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12\x18\n" +
+	"\apayload\x18\b \x01(\tR\apayload\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"X\n" +
+	"\x17RecordDeadLetterRequest\x12=\n" +
+	"\vdead_letter\x18\x01 \x01(\v2\x1c.hivemind.discord.DeadLetterR\n" +
+	"deadLetter\"Y\n" +
+	"\x18RecordDeadLetterResponse\x12=\n" +
+	"\vdead_letter\x18\x01 \x01(\v2\x1c.hivemind.discord.DeadLetterR\n" +
+	"deadLetter\"a\n" +
+	"\x16ListDeadLettersRequest\x12\x19\n" +
+	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"p\n" +
+	"\x17ListDeadLettersResponse\x12?\n" +
+	"\fdead_letters\x18\x01 \x03(\v2\x1c.hivemind.discord.DeadLetterR\vdeadLetters\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total2\xf3\v\n" +
 	"\x0eDiscordService\x12Z\n" +
 	"\vUpsertGuild\x12$.hivemind.discord.UpsertGuildRequest\x1a%.hivemind.discord.UpsertGuildResponse\x12]\n" +
 	"\fDisableGuild\x12%.hivemind.discord.DisableGuildRequest\x1a&.hivemind.discord.DisableGuildResponse\x12Q\n" +
@@ -1483,7 +2755,11 @@ const file_discord_proto_rawDesc = "" +
 	"\x14CheckGuildMembership\x12-.hivemind.discord.CheckGuildMembershipRequest\x1a..hivemind.discord.CheckGuildMembershipResponse\x12c\n" +
 	"\x0eListUserGuilds\x12'.hivemind.discord.ListUserGuildsRequest\x1a(.hivemind.discord.ListUserGuildsResponse\x12r\n" +
 	"\x13UpdateGuildSettings\x12,.hivemind.discord.UpdateGuildSettingsRequest\x1a-.hivemind.discord.UpdateGuildSettingsResponse\x12i\n" +
-	"\x10GetGuildSettings\x12).hivemind.discord.GetGuildSettingsRequest\x1a*.hivemind.discord.GetGuildSettingsResponseB?Z=github.com/devilmonastery/hivemind/api/generated/go/discordpbb\x06proto3"
+	"\x10GetGuildSettings\x12).hivemind.discord.GetGuildSettingsRequest\x1a*.hivemind.discord.GetGuildSettingsResponse\x12i\n" +
+	"\x10ListDiscordUsers\x12).hivemind.discord.ListDiscordUsersRequest\x1a*.hivemind.discord.ListDiscordUsersResponse\x12~\n" +
+	"\x17UpdateDiscordUsersBatch\x120.hivemind.discord.UpdateDiscordUsersBatchRequest\x1a1.hivemind.discord.UpdateDiscordUsersBatchResponse\x12i\n" +
+	"\x10RecordDeadLetter\x12).hivemind.discord.RecordDeadLetterRequest\x1a*.hivemind.discord.RecordDeadLetterResponse\x12f\n" +
+	"\x0fListDeadLetters\x12(.hivemind.discord.ListDeadLettersRequest\x1a).hivemind.discord.ListDeadLettersResponseB?Z=github.com/devilmonastery/hivemind/api/generated/go/discordpbb\x06proto3"
 
 var (
 	file_discord_proto_rawDescOnce sync.Once
@@ -1497,7 +2773,7 @@ func file_discord_proto_rawDescGZIP() []byte {
 	return file_discord_proto_rawDescData
 }
 
-var file_discord_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_discord_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_discord_proto_goTypes = []any{
 	(*Guild)(nil),                           // 0: hivemind.discord.Guild
 	(*UpsertGuildRequest)(nil),              // 1: hivemind.discord.UpsertGuildRequest
@@ -1518,52 +2794,99 @@ var file_discord_proto_goTypes = []any{
 	(*ListUserGuildsRequest)(nil),           // 16: hivemind.discord.ListUserGuildsRequest
 	(*ListUserGuildsResponse)(nil),          // 17: hivemind.discord.ListUserGuildsResponse
 	(*GuildSettings)(nil),                   // 18: hivemind.discord.GuildSettings
-	(*AnnouncementSettings)(nil),            // 19: hivemind.discord.AnnouncementSettings
-	(*UpdateGuildSettingsRequest)(nil),      // 20: hivemind.discord.UpdateGuildSettingsRequest
-	(*UpdateGuildSettingsResponse)(nil),     // 21: hivemind.discord.UpdateGuildSettingsResponse
-	(*GetGuildSettingsRequest)(nil),         // 22: hivemind.discord.GetGuildSettingsRequest
-	(*GetGuildSettingsResponse)(nil),        // 23: hivemind.discord.GetGuildSettingsResponse
-	(*timestamppb.Timestamp)(nil),           // 24: google.protobuf.Timestamp
+	(*MessageSettings)(nil),                 // 19: hivemind.discord.MessageSettings
+	(*FeatureSettings)(nil),                 // 20: hivemind.discord.FeatureSettings
+	(*AnnouncementSettings)(nil),            // 21: hivemind.discord.AnnouncementSettings
+	(*AppearanceSettings)(nil),              // 22: hivemind.discord.AppearanceSettings
+	(*WebhookSettings)(nil),                 // 23: hivemind.discord.WebhookSettings
+	(*PermissionSettings)(nil),              // 24: hivemind.discord.PermissionSettings
+	(*PostingSettings)(nil),                 // 25: hivemind.discord.PostingSettings
+	(*NoteSettings)(nil),                    // 26: hivemind.discord.NoteSettings
+	(*QuoteSettings)(nil),                   // 27: hivemind.discord.QuoteSettings
+	(*ReferenceSettings)(nil),               // 28: hivemind.discord.ReferenceSettings
+	(*UpdateGuildSettingsRequest)(nil),      // 29: hivemind.discord.UpdateGuildSettingsRequest
+	(*UpdateGuildSettingsResponse)(nil),     // 30: hivemind.discord.UpdateGuildSettingsResponse
+	(*GetGuildSettingsRequest)(nil),         // 31: hivemind.discord.GetGuildSettingsRequest
+	(*GetGuildSettingsResponse)(nil),        // 32: hivemind.discord.GetGuildSettingsResponse
+	(*DiscordUser)(nil),                     // 33: hivemind.discord.DiscordUser
+	(*ListDiscordUsersRequest)(nil),         // 34: hivemind.discord.ListDiscordUsersRequest
+	(*ListDiscordUsersResponse)(nil),        // 35: hivemind.discord.ListDiscordUsersResponse
+	(*UpdateDiscordUsersBatchRequest)(nil),  // 36: hivemind.discord.UpdateDiscordUsersBatchRequest
+	(*UpdateDiscordUsersBatchResponse)(nil), // 37: hivemind.discord.UpdateDiscordUsersBatchResponse
+	(*DeadLetter)(nil),                      // 38: hivemind.discord.DeadLetter
+	(*RecordDeadLetterRequest)(nil),         // 39: hivemind.discord.RecordDeadLetterRequest
+	(*RecordDeadLetterResponse)(nil),        // 40: hivemind.discord.RecordDeadLetterResponse
+	(*ListDeadLettersRequest)(nil),          // 41: hivemind.discord.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),         // 42: hivemind.discord.ListDeadLettersResponse
+	nil,                                     // 43: hivemind.discord.MessageSettings.TemplatesEntry
+	(*timestamppb.Timestamp)(nil),           // 44: google.protobuf.Timestamp
 }
 var file_discord_proto_depIdxs = []int32{
-	24, // 0: hivemind.discord.Guild.added_at:type_name -> google.protobuf.Timestamp
-	24, // 1: hivemind.discord.Guild.last_activity:type_name -> google.protobuf.Timestamp
-	0,  // 2: hivemind.discord.UpsertGuildResponse.guild:type_name -> hivemind.discord.Guild
-	0,  // 3: hivemind.discord.GetGuildResponse.guild:type_name -> hivemind.discord.Guild
-	24, // 4: hivemind.discord.GuildMember.joined_at:type_name -> google.protobuf.Timestamp
-	24, // 5: hivemind.discord.GuildMember.synced_at:type_name -> google.protobuf.Timestamp
-	24, // 6: hivemind.discord.GuildMember.last_seen:type_name -> google.protobuf.Timestamp
-	24, // 7: hivemind.discord.UpsertGuildMemberRequest.joined_at:type_name -> google.protobuf.Timestamp
-	7,  // 8: hivemind.discord.UpsertGuildMembersBatchRequest.members:type_name -> hivemind.discord.GuildMember
-	19, // 9: hivemind.discord.GuildSettings.announcements:type_name -> hivemind.discord.AnnouncementSettings
-	18, // 10: hivemind.discord.UpdateGuildSettingsRequest.settings:type_name -> hivemind.discord.GuildSettings
-	18, // 11: hivemind.discord.UpdateGuildSettingsResponse.settings:type_name -> hivemind.discord.GuildSettings
-	18, // 12: hivemind.discord.GetGuildSettingsResponse.settings:type_name -> hivemind.discord.GuildSettings
-	1,  // 13: hivemind.discord.DiscordService.UpsertGuild:input_type -> hivemind.discord.UpsertGuildRequest
-	3,  // 14: hivemind.discord.DiscordService.DisableGuild:input_type -> hivemind.discord.DisableGuildRequest
-	5,  // 15: hivemind.discord.DiscordService.GetGuild:input_type -> hivemind.discord.GetGuildRequest
-	8,  // 16: hivemind.discord.DiscordService.UpsertGuildMember:input_type -> hivemind.discord.UpsertGuildMemberRequest
-	10, // 17: hivemind.discord.DiscordService.UpsertGuildMembersBatch:input_type -> hivemind.discord.UpsertGuildMembersBatchRequest
-	12, // 18: hivemind.discord.DiscordService.RemoveGuildMember:input_type -> hivemind.discord.RemoveGuildMemberRequest
-	14, // 19: hivemind.discord.DiscordService.CheckGuildMembership:input_type -> hivemind.discord.CheckGuildMembershipRequest
-	16, // 20: hivemind.discord.DiscordService.ListUserGuilds:input_type -> hivemind.discord.ListUserGuildsRequest
-	20, // 21: hivemind.discord.DiscordService.UpdateGuildSettings:input_type -> hivemind.discord.UpdateGuildSettingsRequest
-	22, // 22: hivemind.discord.DiscordService.GetGuildSettings:input_type -> hivemind.discord.GetGuildSettingsRequest
-	2,  // 23: hivemind.discord.DiscordService.UpsertGuild:output_type -> hivemind.discord.UpsertGuildResponse
-	4,  // 24: hivemind.discord.DiscordService.DisableGuild:output_type -> hivemind.discord.DisableGuildResponse
-	6,  // 25: hivemind.discord.DiscordService.GetGuild:output_type -> hivemind.discord.GetGuildResponse
-	9,  // 26: hivemind.discord.DiscordService.UpsertGuildMember:output_type -> hivemind.discord.UpsertGuildMemberResponse
-	11, // 27: hivemind.discord.DiscordService.UpsertGuildMembersBatch:output_type -> hivemind.discord.UpsertGuildMembersBatchResponse
-	13, // 28: hivemind.discord.DiscordService.RemoveGuildMember:output_type -> hivemind.discord.RemoveGuildMemberResponse
-	15, // 29: hivemind.discord.DiscordService.CheckGuildMembership:output_type -> hivemind.discord.CheckGuildMembershipResponse
-	17, // 30: hivemind.discord.DiscordService.ListUserGuilds:output_type -> hivemind.discord.ListUserGuildsResponse
-	21, // 31: hivemind.discord.DiscordService.UpdateGuildSettings:output_type -> hivemind.discord.UpdateGuildSettingsResponse
-	23, // 32: hivemind.discord.DiscordService.GetGuildSettings:output_type -> hivemind.discord.GetGuildSettingsResponse
-	23, // [23:33] is the sub-list for method output_type
-	13, // [13:23] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	44, // 0: hivemind.discord.Guild.added_at:type_name -> google.protobuf.Timestamp
+	44, // 1: hivemind.discord.Guild.last_activity:type_name -> google.protobuf.Timestamp
+	44, // 2: hivemind.discord.Guild.last_member_sync:type_name -> google.protobuf.Timestamp
+	0,  // 3: hivemind.discord.UpsertGuildResponse.guild:type_name -> hivemind.discord.Guild
+	0,  // 4: hivemind.discord.GetGuildResponse.guild:type_name -> hivemind.discord.Guild
+	44, // 5: hivemind.discord.GuildMember.joined_at:type_name -> google.protobuf.Timestamp
+	44, // 6: hivemind.discord.GuildMember.synced_at:type_name -> google.protobuf.Timestamp
+	44, // 7: hivemind.discord.GuildMember.last_seen:type_name -> google.protobuf.Timestamp
+	44, // 8: hivemind.discord.UpsertGuildMemberRequest.joined_at:type_name -> google.protobuf.Timestamp
+	7,  // 9: hivemind.discord.UpsertGuildMembersBatchRequest.members:type_name -> hivemind.discord.GuildMember
+	21, // 10: hivemind.discord.GuildSettings.announcements:type_name -> hivemind.discord.AnnouncementSettings
+	20, // 11: hivemind.discord.GuildSettings.features:type_name -> hivemind.discord.FeatureSettings
+	22, // 12: hivemind.discord.GuildSettings.appearance:type_name -> hivemind.discord.AppearanceSettings
+	23, // 13: hivemind.discord.GuildSettings.webhook:type_name -> hivemind.discord.WebhookSettings
+	24, // 14: hivemind.discord.GuildSettings.permissions:type_name -> hivemind.discord.PermissionSettings
+	25, // 15: hivemind.discord.GuildSettings.posting:type_name -> hivemind.discord.PostingSettings
+	26, // 16: hivemind.discord.GuildSettings.notes:type_name -> hivemind.discord.NoteSettings
+	27, // 17: hivemind.discord.GuildSettings.quotes:type_name -> hivemind.discord.QuoteSettings
+	28, // 18: hivemind.discord.GuildSettings.references:type_name -> hivemind.discord.ReferenceSettings
+	19, // 19: hivemind.discord.GuildSettings.messages:type_name -> hivemind.discord.MessageSettings
+	43, // 20: hivemind.discord.MessageSettings.templates:type_name -> hivemind.discord.MessageSettings.TemplatesEntry
+	18, // 21: hivemind.discord.UpdateGuildSettingsRequest.settings:type_name -> hivemind.discord.GuildSettings
+	18, // 22: hivemind.discord.UpdateGuildSettingsResponse.settings:type_name -> hivemind.discord.GuildSettings
+	18, // 23: hivemind.discord.GetGuildSettingsResponse.settings:type_name -> hivemind.discord.GuildSettings
+	44, // 24: hivemind.discord.DiscordUser.last_seen:type_name -> google.protobuf.Timestamp
+	44, // 25: hivemind.discord.ListDiscordUsersRequest.seen_since:type_name -> google.protobuf.Timestamp
+	33, // 26: hivemind.discord.ListDiscordUsersResponse.users:type_name -> hivemind.discord.DiscordUser
+	33, // 27: hivemind.discord.UpdateDiscordUsersBatchRequest.users:type_name -> hivemind.discord.DiscordUser
+	44, // 28: hivemind.discord.DeadLetter.created_at:type_name -> google.protobuf.Timestamp
+	38, // 29: hivemind.discord.RecordDeadLetterRequest.dead_letter:type_name -> hivemind.discord.DeadLetter
+	38, // 30: hivemind.discord.RecordDeadLetterResponse.dead_letter:type_name -> hivemind.discord.DeadLetter
+	38, // 31: hivemind.discord.ListDeadLettersResponse.dead_letters:type_name -> hivemind.discord.DeadLetter
+	1,  // 32: hivemind.discord.DiscordService.UpsertGuild:input_type -> hivemind.discord.UpsertGuildRequest
+	3,  // 33: hivemind.discord.DiscordService.DisableGuild:input_type -> hivemind.discord.DisableGuildRequest
+	5,  // 34: hivemind.discord.DiscordService.GetGuild:input_type -> hivemind.discord.GetGuildRequest
+	8,  // 35: hivemind.discord.DiscordService.UpsertGuildMember:input_type -> hivemind.discord.UpsertGuildMemberRequest
+	10, // 36: hivemind.discord.DiscordService.UpsertGuildMembersBatch:input_type -> hivemind.discord.UpsertGuildMembersBatchRequest
+	12, // 37: hivemind.discord.DiscordService.RemoveGuildMember:input_type -> hivemind.discord.RemoveGuildMemberRequest
+	14, // 38: hivemind.discord.DiscordService.CheckGuildMembership:input_type -> hivemind.discord.CheckGuildMembershipRequest
+	16, // 39: hivemind.discord.DiscordService.ListUserGuilds:input_type -> hivemind.discord.ListUserGuildsRequest
+	29, // 40: hivemind.discord.DiscordService.UpdateGuildSettings:input_type -> hivemind.discord.UpdateGuildSettingsRequest
+	31, // 41: hivemind.discord.DiscordService.GetGuildSettings:input_type -> hivemind.discord.GetGuildSettingsRequest
+	34, // 42: hivemind.discord.DiscordService.ListDiscordUsers:input_type -> hivemind.discord.ListDiscordUsersRequest
+	36, // 43: hivemind.discord.DiscordService.UpdateDiscordUsersBatch:input_type -> hivemind.discord.UpdateDiscordUsersBatchRequest
+	39, // 44: hivemind.discord.DiscordService.RecordDeadLetter:input_type -> hivemind.discord.RecordDeadLetterRequest
+	41, // 45: hivemind.discord.DiscordService.ListDeadLetters:input_type -> hivemind.discord.ListDeadLettersRequest
+	2,  // 46: hivemind.discord.DiscordService.UpsertGuild:output_type -> hivemind.discord.UpsertGuildResponse
+	4,  // 47: hivemind.discord.DiscordService.DisableGuild:output_type -> hivemind.discord.DisableGuildResponse
+	6,  // 48: hivemind.discord.DiscordService.GetGuild:output_type -> hivemind.discord.GetGuildResponse
+	9,  // 49: hivemind.discord.DiscordService.UpsertGuildMember:output_type -> hivemind.discord.UpsertGuildMemberResponse
+	11, // 50: hivemind.discord.DiscordService.UpsertGuildMembersBatch:output_type -> hivemind.discord.UpsertGuildMembersBatchResponse
+	13, // 51: hivemind.discord.DiscordService.RemoveGuildMember:output_type -> hivemind.discord.RemoveGuildMemberResponse
+	15, // 52: hivemind.discord.DiscordService.CheckGuildMembership:output_type -> hivemind.discord.CheckGuildMembershipResponse
+	17, // 53: hivemind.discord.DiscordService.ListUserGuilds:output_type -> hivemind.discord.ListUserGuildsResponse
+	30, // 54: hivemind.discord.DiscordService.UpdateGuildSettings:output_type -> hivemind.discord.UpdateGuildSettingsResponse
+	32, // 55: hivemind.discord.DiscordService.GetGuildSettings:output_type -> hivemind.discord.GetGuildSettingsResponse
+	35, // 56: hivemind.discord.DiscordService.ListDiscordUsers:output_type -> hivemind.discord.ListDiscordUsersResponse
+	37, // 57: hivemind.discord.DiscordService.UpdateDiscordUsersBatch:output_type -> hivemind.discord.UpdateDiscordUsersBatchResponse
+	40, // 58: hivemind.discord.DiscordService.RecordDeadLetter:output_type -> hivemind.discord.RecordDeadLetterResponse
+	42, // 59: hivemind.discord.DiscordService.ListDeadLetters:output_type -> hivemind.discord.ListDeadLettersResponse
+	46, // [46:60] is the sub-list for method output_type
+	32, // [32:46] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_discord_proto_init() }
@@ -1577,7 +2900,7 @@ func file_discord_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_discord_proto_rawDesc), len(file_discord_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   24,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
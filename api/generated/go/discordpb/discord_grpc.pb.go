@@ -29,6 +29,10 @@ const (
 	DiscordService_ListUserGuilds_FullMethodName          = "/hivemind.discord.DiscordService/ListUserGuilds"
 	DiscordService_UpdateGuildSettings_FullMethodName     = "/hivemind.discord.DiscordService/UpdateGuildSettings"
 	DiscordService_GetGuildSettings_FullMethodName        = "/hivemind.discord.DiscordService/GetGuildSettings"
+	DiscordService_ListDiscordUsers_FullMethodName        = "/hivemind.discord.DiscordService/ListDiscordUsers"
+	DiscordService_UpdateDiscordUsersBatch_FullMethodName = "/hivemind.discord.DiscordService/UpdateDiscordUsersBatch"
+	DiscordService_RecordDeadLetter_FullMethodName        = "/hivemind.discord.DiscordService/RecordDeadLetter"
+	DiscordService_ListDeadLetters_FullMethodName         = "/hivemind.discord.DiscordService/ListDeadLetters"
 )
 
 // DiscordServiceClient is the client API for DiscordService service.
@@ -57,6 +61,14 @@ type DiscordServiceClient interface {
 	UpdateGuildSettings(ctx context.Context, in *UpdateGuildSettingsRequest, opts ...grpc.CallOption) (*UpdateGuildSettingsResponse, error)
 	// GetGuildSettings retrieves guild settings
 	GetGuildSettings(ctx context.Context, in *GetGuildSettingsRequest, opts ...grpc.CallOption) (*GetGuildSettingsResponse, error)
+	// ListDiscordUsers lists cached Discord users seen recently (for profile sync)
+	ListDiscordUsers(ctx context.Context, in *ListDiscordUsersRequest, opts ...grpc.CallOption) (*ListDiscordUsersResponse, error)
+	// UpdateDiscordUsersBatch refreshes cached profile info for multiple Discord users
+	UpdateDiscordUsersBatch(ctx context.Context, in *UpdateDiscordUsersBatchRequest, opts ...grpc.CallOption) (*UpdateDiscordUsersBatchResponse, error)
+	// RecordDeadLetter records an outbound Discord call the bot gave up on
+	RecordDeadLetter(ctx context.Context, in *RecordDeadLetterRequest, opts ...grpc.CallOption) (*RecordDeadLetterResponse, error)
+	// ListDeadLetters lists recorded outbound Discord calls, most recent first
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
 }
 
 type discordServiceClient struct {
@@ -167,6 +179,46 @@ func (c *discordServiceClient) GetGuildSettings(ctx context.Context, in *GetGuil
 	return out, nil
 }
 
+func (c *discordServiceClient) ListDiscordUsers(ctx context.Context, in *ListDiscordUsersRequest, opts ...grpc.CallOption) (*ListDiscordUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDiscordUsersResponse)
+	err := c.cc.Invoke(ctx, DiscordService_ListDiscordUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discordServiceClient) UpdateDiscordUsersBatch(ctx context.Context, in *UpdateDiscordUsersBatchRequest, opts ...grpc.CallOption) (*UpdateDiscordUsersBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateDiscordUsersBatchResponse)
+	err := c.cc.Invoke(ctx, DiscordService_UpdateDiscordUsersBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discordServiceClient) RecordDeadLetter(ctx context.Context, in *RecordDeadLetterRequest, opts ...grpc.CallOption) (*RecordDeadLetterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordDeadLetterResponse)
+	err := c.cc.Invoke(ctx, DiscordService_RecordDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discordServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, DiscordService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DiscordServiceServer is the server API for DiscordService service.
 // All implementations should embed UnimplementedDiscordServiceServer
 // for forward compatibility.
@@ -193,6 +245,14 @@ type DiscordServiceServer interface {
 	UpdateGuildSettings(context.Context, *UpdateGuildSettingsRequest) (*UpdateGuildSettingsResponse, error)
 	// GetGuildSettings retrieves guild settings
 	GetGuildSettings(context.Context, *GetGuildSettingsRequest) (*GetGuildSettingsResponse, error)
+	// ListDiscordUsers lists cached Discord users seen recently (for profile sync)
+	ListDiscordUsers(context.Context, *ListDiscordUsersRequest) (*ListDiscordUsersResponse, error)
+	// UpdateDiscordUsersBatch refreshes cached profile info for multiple Discord users
+	UpdateDiscordUsersBatch(context.Context, *UpdateDiscordUsersBatchRequest) (*UpdateDiscordUsersBatchResponse, error)
+	// RecordDeadLetter records an outbound Discord call the bot gave up on
+	RecordDeadLetter(context.Context, *RecordDeadLetterRequest) (*RecordDeadLetterResponse, error)
+	// ListDeadLetters lists recorded outbound Discord calls, most recent first
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
 }
 
 // UnimplementedDiscordServiceServer should be embedded to have
@@ -232,6 +292,18 @@ func (UnimplementedDiscordServiceServer) UpdateGuildSettings(context.Context, *U
 func (UnimplementedDiscordServiceServer) GetGuildSettings(context.Context, *GetGuildSettingsRequest) (*GetGuildSettingsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetGuildSettings not implemented")
 }
+func (UnimplementedDiscordServiceServer) ListDiscordUsers(context.Context, *ListDiscordUsersRequest) (*ListDiscordUsersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDiscordUsers not implemented")
+}
+func (UnimplementedDiscordServiceServer) UpdateDiscordUsersBatch(context.Context, *UpdateDiscordUsersBatchRequest) (*UpdateDiscordUsersBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateDiscordUsersBatch not implemented")
+}
+func (UnimplementedDiscordServiceServer) RecordDeadLetter(context.Context, *RecordDeadLetterRequest) (*RecordDeadLetterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordDeadLetter not implemented")
+}
+func (UnimplementedDiscordServiceServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
 func (UnimplementedDiscordServiceServer) testEmbeddedByValue() {}
 
 // UnsafeDiscordServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -432,6 +504,78 @@ func _DiscordService_GetGuildSettings_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DiscordService_ListDiscordUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDiscordUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscordServiceServer).ListDiscordUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiscordService_ListDiscordUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscordServiceServer).ListDiscordUsers(ctx, req.(*ListDiscordUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscordService_UpdateDiscordUsersBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDiscordUsersBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscordServiceServer).UpdateDiscordUsersBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiscordService_UpdateDiscordUsersBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscordServiceServer).UpdateDiscordUsersBatch(ctx, req.(*UpdateDiscordUsersBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscordService_RecordDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordDeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscordServiceServer).RecordDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiscordService_RecordDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscordServiceServer).RecordDeadLetter(ctx, req.(*RecordDeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscordService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscordServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiscordService_ListDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscordServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DiscordService_ServiceDesc is the grpc.ServiceDesc for DiscordService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -479,6 +623,22 @@ var DiscordService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetGuildSettings",
 			Handler:    _DiscordService_GetGuildSettings_Handler,
 		},
+		{
+			MethodName: "ListDiscordUsers",
+			Handler:    _DiscordService_ListDiscordUsers_Handler,
+		},
+		{
+			MethodName: "UpdateDiscordUsersBatch",
+			Handler:    _DiscordService_UpdateDiscordUsersBatch_Handler,
+		},
+		{
+			MethodName: "RecordDeadLetter",
+			Handler:    _DiscordService_RecordDeadLetter_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _DiscordService_ListDeadLetters_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "discord.proto",
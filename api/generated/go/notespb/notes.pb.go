@@ -44,10 +44,19 @@ type Note struct {
 	// Metadata
 	Tags []string `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
 	// Timestamps
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Search result metadata (only populated by SearchNotes)
+	Snippet string  `protobuf:"bytes,16,opt,name=snippet,proto3" json:"snippet,omitempty"` // Body excerpt with matches wrapped in **bold**
+	Rank    float32 `protobuf:"fixed32,17,opt,name=rank,proto3" json:"rank,omitempty"`     // Relevance score, higher is better
+	Slug    string  `protobuf:"bytes,18,opt,name=slug,proto3" json:"slug,omitempty"`       // Permalink slug from the title, unique within the guild (or the author's personal notes)
+	// Size metadata (only populated by ListNotes and SearchNotes), set even when the
+	// body is omitted
+	BodyLength     int32 `protobuf:"varint,19,opt,name=body_length,json=bodyLength,proto3" json:"body_length,omitempty"` // Characters in the body
+	TagCount       int32 `protobuf:"varint,20,opt,name=tag_count,json=tagCount,proto3" json:"tag_count,omitempty"`
+	ReferenceCount int32 `protobuf:"varint,21,opt,name=reference_count,json=referenceCount,proto3" json:"reference_count,omitempty"` // Discord messages added to the note
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Note) Reset() {
@@ -185,6 +194,48 @@ func (x *Note) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Note) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *Note) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Note) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Note) GetBodyLength() int32 {
+	if x != nil {
+		return x.BodyLength
+	}
+	return 0
+}
+
+func (x *Note) GetTagCount() int32 {
+	if x != nil {
+		return x.TagCount
+	}
+	return 0
+}
+
+func (x *Note) GetReferenceCount() int32 {
+	if x != nil {
+		return x.ReferenceCount
+	}
+	return 0
+}
+
 type CreateNoteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"` // Optional
@@ -193,6 +244,7 @@ type CreateNoteRequest struct {
 	ChannelId     string                 `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`         // Optional
 	SourceMsgId   string                 `protobuf:"bytes,5,opt,name=source_msg_id,json=sourceMsgId,proto3" json:"source_msg_id,omitempty"` // Optional
 	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	PreserveId    string                 `protobuf:"bytes,7,opt,name=preserve_id,json=preserveId,proto3" json:"preserve_id,omitempty"` // Admin-only: use this ID instead of generating one (imports)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -269,6 +321,13 @@ func (x *CreateNoteRequest) GetTags() []string {
 	return nil
 }
 
+func (x *CreateNoteRequest) GetPreserveId() string {
+	if x != nil {
+		return x.PreserveId
+	}
+	return ""
+}
+
 type GetNoteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -313,6 +372,58 @@ func (x *GetNoteRequest) GetId() string {
 	return ""
 }
 
+type GetNoteBySlugRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Empty for personal notes
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteBySlugRequest) Reset() {
+	*x = GetNoteBySlugRequest{}
+	mi := &file_notes_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteBySlugRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteBySlugRequest) ProtoMessage() {}
+
+func (x *GetNoteBySlugRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteBySlugRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteBySlugRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetNoteBySlugRequest) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *GetNoteBySlugRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
 type ListNotesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Optional: filter by guild, omit for all notes
@@ -321,13 +432,14 @@ type ListNotesRequest struct {
 	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
 	OrderBy       string                 `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"` // "created_at", "updated_at"
 	Ascending     bool                   `protobuf:"varint,6,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	OmitBody      bool                   `protobuf:"varint,7,opt,name=omit_body,json=omitBody,proto3" json:"omit_body,omitempty"` // Leave body empty in the results; the size metadata is still set
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListNotesRequest) Reset() {
 	*x = ListNotesRequest{}
-	mi := &file_notes_proto_msgTypes[3]
+	mi := &file_notes_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -339,7 +451,7 @@ func (x *ListNotesRequest) String() string {
 func (*ListNotesRequest) ProtoMessage() {}
 
 func (x *ListNotesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[3]
+	mi := &file_notes_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,7 +464,7 @@ func (x *ListNotesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNotesRequest.ProtoReflect.Descriptor instead.
 func (*ListNotesRequest) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{3}
+	return file_notes_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ListNotesRequest) GetGuildId() string {
@@ -397,17 +509,26 @@ func (x *ListNotesRequest) GetAscending() bool {
 	return false
 }
 
+func (x *ListNotesRequest) GetOmitBody() bool {
+	if x != nil {
+		return x.OmitBody
+	}
+	return false
+}
+
 type ListNotesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Notes         []*Note                `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
 	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // Page size used, after applying the server's default and maximum
+	Pagination    *commonpb.Pagination   `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListNotesResponse) Reset() {
 	*x = ListNotesResponse{}
-	mi := &file_notes_proto_msgTypes[4]
+	mi := &file_notes_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -419,7 +540,7 @@ func (x *ListNotesResponse) String() string {
 func (*ListNotesResponse) ProtoMessage() {}
 
 func (x *ListNotesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[4]
+	mi := &file_notes_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -432,7 +553,7 @@ func (x *ListNotesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNotesResponse.ProtoReflect.Descriptor instead.
 func (*ListNotesResponse) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{4}
+	return file_notes_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListNotesResponse) GetNotes() []*Note {
@@ -449,6 +570,20 @@ func (x *ListNotesResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *ListNotesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListNotesResponse) GetPagination() *commonpb.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
 type UpdateNoteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -461,7 +596,7 @@ type UpdateNoteRequest struct {
 
 func (x *UpdateNoteRequest) Reset() {
 	*x = UpdateNoteRequest{}
-	mi := &file_notes_proto_msgTypes[5]
+	mi := &file_notes_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -473,7 +608,7 @@ func (x *UpdateNoteRequest) String() string {
 func (*UpdateNoteRequest) ProtoMessage() {}
 
 func (x *UpdateNoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[5]
+	mi := &file_notes_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -486,7 +621,7 @@ func (x *UpdateNoteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateNoteRequest.ProtoReflect.Descriptor instead.
 func (*UpdateNoteRequest) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{5}
+	return file_notes_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UpdateNoteRequest) GetId() string {
@@ -526,7 +661,7 @@ type DeleteNoteRequest struct {
 
 func (x *DeleteNoteRequest) Reset() {
 	*x = DeleteNoteRequest{}
-	mi := &file_notes_proto_msgTypes[6]
+	mi := &file_notes_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -538,7 +673,7 @@ func (x *DeleteNoteRequest) String() string {
 func (*DeleteNoteRequest) ProtoMessage() {}
 
 func (x *DeleteNoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[6]
+	mi := &file_notes_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -551,7 +686,7 @@ func (x *DeleteNoteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteNoteRequest.ProtoReflect.Descriptor instead.
 func (*DeleteNoteRequest) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{6}
+	return file_notes_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteNoteRequest) GetId() string {
@@ -562,19 +697,25 @@ func (x *DeleteNoteRequest) GetId() string {
 }
 
 type SearchNotesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                    // Full-text search query
-	GuildId       string                 `protobuf:"bytes,2,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Optional: filter by guild
-	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`                      // Optional: filter by tags
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                   // Default: 10
-	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Query           string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                    // Full-text search query
+	GuildId         string                 `protobuf:"bytes,2,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Optional: filter by guild
+	Tags            []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`                      // Optional: filter by tags
+	Limit           int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                   // Default: 10
+	Offset          int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`                 // Kept for older clients; prefer page_token, which doesn't skip or repeat pages as content changes
+	OrderBy         string                 `protobuf:"bytes,6,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"` // "relevance", "created_at", "updated_at" (default: relevance with a query, else created_at)
+	Ascending       bool                   `protobuf:"varint,7,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	AuthorDiscordId string                 `protobuf:"bytes,8,opt,name=author_discord_id,json=authorDiscordId,proto3" json:"author_discord_id,omitempty"` // Optional: only notes written by this Discord user (notes are still limited to the caller's own)
+	PageToken       string                 `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                     // Optional: next_page_token from the previous response with the same query, filters, and ordering; overrides offset
+	OmitBody        bool                   `protobuf:"varint,10,opt,name=omit_body,json=omitBody,proto3" json:"omit_body,omitempty"`                      // Leave body empty in the results; the snippet and size metadata are still set
+	GuildIds        []string               `protobuf:"bytes,11,rep,name=guild_ids,json=guildIds,proto3" json:"guild_ids,omitempty"`                       // Optional: search notes from these guilds; ignored when guild_id is set
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *SearchNotesRequest) Reset() {
 	*x = SearchNotesRequest{}
-	mi := &file_notes_proto_msgTypes[7]
+	mi := &file_notes_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -586,7 +727,7 @@ func (x *SearchNotesRequest) String() string {
 func (*SearchNotesRequest) ProtoMessage() {}
 
 func (x *SearchNotesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[7]
+	mi := &file_notes_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -599,7 +740,7 @@ func (x *SearchNotesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchNotesRequest.ProtoReflect.Descriptor instead.
 func (*SearchNotesRequest) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{7}
+	return file_notes_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SearchNotesRequest) GetQuery() string {
@@ -637,17 +778,62 @@ func (x *SearchNotesRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *SearchNotesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *SearchNotesRequest) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+func (x *SearchNotesRequest) GetAuthorDiscordId() string {
+	if x != nil {
+		return x.AuthorDiscordId
+	}
+	return ""
+}
+
+func (x *SearchNotesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchNotesRequest) GetOmitBody() bool {
+	if x != nil {
+		return x.OmitBody
+	}
+	return false
+}
+
+func (x *SearchNotesRequest) GetGuildIds() []string {
+	if x != nil {
+		return x.GuildIds
+	}
+	return nil
+}
+
 type SearchNotesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Notes         []*Note                `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
 	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                                       // Page size used, after applying the server's default and maximum
+	Pagination    *commonpb.Pagination   `protobuf:"bytes,5,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SearchNotesResponse) Reset() {
 	*x = SearchNotesResponse{}
-	mi := &file_notes_proto_msgTypes[8]
+	mi := &file_notes_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -659,7 +845,7 @@ func (x *SearchNotesResponse) String() string {
 func (*SearchNotesResponse) ProtoMessage() {}
 
 func (x *SearchNotesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[8]
+	mi := &file_notes_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -672,7 +858,7 @@ func (x *SearchNotesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchNotesResponse.ProtoReflect.Descriptor instead.
 func (*SearchNotesResponse) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{8}
+	return file_notes_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SearchNotesResponse) GetNotes() []*Note {
@@ -689,6 +875,27 @@ func (x *SearchNotesResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *SearchNotesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *SearchNotesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchNotesResponse) GetPagination() *commonpb.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
 type AutocompleteNoteTitlesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Optional: filter by guild
@@ -698,7 +905,7 @@ type AutocompleteNoteTitlesRequest struct {
 
 func (x *AutocompleteNoteTitlesRequest) Reset() {
 	*x = AutocompleteNoteTitlesRequest{}
-	mi := &file_notes_proto_msgTypes[9]
+	mi := &file_notes_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -710,7 +917,7 @@ func (x *AutocompleteNoteTitlesRequest) String() string {
 func (*AutocompleteNoteTitlesRequest) ProtoMessage() {}
 
 func (x *AutocompleteNoteTitlesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[9]
+	mi := &file_notes_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -723,7 +930,7 @@ func (x *AutocompleteNoteTitlesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AutocompleteNoteTitlesRequest.ProtoReflect.Descriptor instead.
 func (*AutocompleteNoteTitlesRequest) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{9}
+	return file_notes_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AutocompleteNoteTitlesRequest) GetGuildId() string {
@@ -742,7 +949,7 @@ type AutocompleteNoteTitlesResponse struct {
 
 func (x *AutocompleteNoteTitlesResponse) Reset() {
 	*x = AutocompleteNoteTitlesResponse{}
-	mi := &file_notes_proto_msgTypes[10]
+	mi := &file_notes_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -754,7 +961,7 @@ func (x *AutocompleteNoteTitlesResponse) String() string {
 func (*AutocompleteNoteTitlesResponse) ProtoMessage() {}
 
 func (x *AutocompleteNoteTitlesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[10]
+	mi := &file_notes_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -767,7 +974,7 @@ func (x *AutocompleteNoteTitlesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AutocompleteNoteTitlesResponse.ProtoReflect.Descriptor instead.
 func (*AutocompleteNoteTitlesResponse) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{10}
+	return file_notes_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *AutocompleteNoteTitlesResponse) GetSuggestions() []*NoteTitleSuggestion {
@@ -787,7 +994,7 @@ type NoteTitleSuggestion struct {
 
 func (x *NoteTitleSuggestion) Reset() {
 	*x = NoteTitleSuggestion{}
-	mi := &file_notes_proto_msgTypes[11]
+	mi := &file_notes_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -799,7 +1006,7 @@ func (x *NoteTitleSuggestion) String() string {
 func (*NoteTitleSuggestion) ProtoMessage() {}
 
 func (x *NoteTitleSuggestion) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[11]
+	mi := &file_notes_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -812,7 +1019,7 @@ func (x *NoteTitleSuggestion) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NoteTitleSuggestion.ProtoReflect.Descriptor instead.
 func (*NoteTitleSuggestion) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{11}
+	return file_notes_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *NoteTitleSuggestion) GetId() string {
@@ -844,7 +1051,7 @@ type AttachmentMetadata struct {
 
 func (x *AttachmentMetadata) Reset() {
 	*x = AttachmentMetadata{}
-	mi := &file_notes_proto_msgTypes[12]
+	mi := &file_notes_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -856,7 +1063,7 @@ func (x *AttachmentMetadata) String() string {
 func (*AttachmentMetadata) ProtoMessage() {}
 
 func (x *AttachmentMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[12]
+	mi := &file_notes_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -869,7 +1076,7 @@ func (x *AttachmentMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AttachmentMetadata.ProtoReflect.Descriptor instead.
 func (*AttachmentMetadata) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{12}
+	return file_notes_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *AttachmentMetadata) GetUrl() string {
@@ -938,7 +1145,7 @@ type NoteMessageReference struct {
 
 func (x *NoteMessageReference) Reset() {
 	*x = NoteMessageReference{}
-	mi := &file_notes_proto_msgTypes[13]
+	mi := &file_notes_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -950,7 +1157,7 @@ func (x *NoteMessageReference) String() string {
 func (*NoteMessageReference) ProtoMessage() {}
 
 func (x *NoteMessageReference) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[13]
+	mi := &file_notes_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -963,7 +1170,7 @@ func (x *NoteMessageReference) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NoteMessageReference.ProtoReflect.Descriptor instead.
 func (*NoteMessageReference) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{13}
+	return file_notes_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *NoteMessageReference) GetId() string {
@@ -1089,7 +1296,7 @@ type AddNoteMessageReferenceRequest struct {
 
 func (x *AddNoteMessageReferenceRequest) Reset() {
 	*x = AddNoteMessageReferenceRequest{}
-	mi := &file_notes_proto_msgTypes[14]
+	mi := &file_notes_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1101,7 +1308,7 @@ func (x *AddNoteMessageReferenceRequest) String() string {
 func (*AddNoteMessageReferenceRequest) ProtoMessage() {}
 
 func (x *AddNoteMessageReferenceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[14]
+	mi := &file_notes_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1114,7 +1321,7 @@ func (x *AddNoteMessageReferenceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddNoteMessageReferenceRequest.ProtoReflect.Descriptor instead.
 func (*AddNoteMessageReferenceRequest) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{14}
+	return file_notes_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *AddNoteMessageReferenceRequest) GetNoteId() string {
@@ -1190,13 +1397,15 @@ func (x *AddNoteMessageReferenceRequest) GetAttachments() []*AttachmentMetadata
 type ListNoteMessageReferencesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NoteId        string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Default: all references
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListNoteMessageReferencesRequest) Reset() {
 	*x = ListNoteMessageReferencesRequest{}
-	mi := &file_notes_proto_msgTypes[15]
+	mi := &file_notes_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1208,7 +1417,7 @@ func (x *ListNoteMessageReferencesRequest) String() string {
 func (*ListNoteMessageReferencesRequest) ProtoMessage() {}
 
 func (x *ListNoteMessageReferencesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[15]
+	mi := &file_notes_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1221,7 +1430,7 @@ func (x *ListNoteMessageReferencesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNoteMessageReferencesRequest.ProtoReflect.Descriptor instead.
 func (*ListNoteMessageReferencesRequest) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{15}
+	return file_notes_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ListNoteMessageReferencesRequest) GetNoteId() string {
@@ -1231,16 +1440,32 @@ func (x *ListNoteMessageReferencesRequest) GetNoteId() string {
 	return ""
 }
 
+func (x *ListNoteMessageReferencesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListNoteMessageReferencesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
 type ListNoteMessageReferencesResponse struct {
 	state         protoimpl.MessageState  `protogen:"open.v1"`
 	References    []*NoteMessageReference `protobuf:"bytes,1,rep,name=references,proto3" json:"references,omitempty"`
+	Total         int32                   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Total references, regardless of limit/offset
+	Pagination    *commonpb.Pagination    `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListNoteMessageReferencesResponse) Reset() {
 	*x = ListNoteMessageReferencesResponse{}
-	mi := &file_notes_proto_msgTypes[16]
+	mi := &file_notes_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1252,7 +1477,7 @@ func (x *ListNoteMessageReferencesResponse) String() string {
 func (*ListNoteMessageReferencesResponse) ProtoMessage() {}
 
 func (x *ListNoteMessageReferencesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[16]
+	mi := &file_notes_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1265,7 +1490,7 @@ func (x *ListNoteMessageReferencesResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use ListNoteMessageReferencesResponse.ProtoReflect.Descriptor instead.
 func (*ListNoteMessageReferencesResponse) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{16}
+	return file_notes_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ListNoteMessageReferencesResponse) GetReferences() []*NoteMessageReference {
@@ -1275,11 +1500,25 @@ func (x *ListNoteMessageReferencesResponse) GetReferences() []*NoteMessageRefere
 	return nil
 }
 
+func (x *ListNoteMessageReferencesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListNoteMessageReferencesResponse) GetPagination() *commonpb.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
 var File_notes_proto protoreflect.FileDescriptor
 
 const file_notes_proto_rawDesc = "" +
 	"\n" +
-	"\vnotes.proto\x12\x0ehivemind.notes\x1a\fcommon.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8a\x04\n" +
+	"\vnotes.proto\x12\x0ehivemind.notes\x1a\fcommon.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x05\n" +
 	"\x04Note\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
@@ -1300,7 +1539,14 @@ const file_notes_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xaf\x01\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\asnippet\x18\x10 \x01(\tR\asnippet\x12\x12\n" +
+	"\x04rank\x18\x11 \x01(\x02R\x04rank\x12\x12\n" +
+	"\x04slug\x18\x12 \x01(\tR\x04slug\x12\x1f\n" +
+	"\vbody_length\x18\x13 \x01(\x05R\n" +
+	"bodyLength\x12\x1b\n" +
+	"\ttag_count\x18\x14 \x01(\x05R\btagCount\x12'\n" +
+	"\x0freference_count\x18\x15 \x01(\x05R\x0ereferenceCount\"\xd0\x01\n" +
 	"\x11CreateNoteRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
 	"\x04body\x18\x02 \x01(\tR\x04body\x12\x19\n" +
@@ -1308,35 +1554,58 @@ const file_notes_proto_rawDesc = "" +
 	"\n" +
 	"channel_id\x18\x04 \x01(\tR\tchannelId\x12\"\n" +
 	"\rsource_msg_id\x18\x05 \x01(\tR\vsourceMsgId\x12\x12\n" +
-	"\x04tags\x18\x06 \x03(\tR\x04tags\" \n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x1f\n" +
+	"\vpreserve_id\x18\a \x01(\tR\n" +
+	"preserveId\" \n" +
 	"\x0eGetNoteRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xa8\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"E\n" +
+	"\x14GetNoteBySlugRequest\x12\x19\n" +
+	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\"\xc5\x01\n" +
 	"\x10ListNotesRequest\x12\x19\n" +
 	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x12\n" +
 	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x14\n" +
 	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x19\n" +
 	"\border_by\x18\x05 \x01(\tR\aorderBy\x12\x1c\n" +
-	"\tascending\x18\x06 \x01(\bR\tascending\"U\n" +
+	"\tascending\x18\x06 \x01(\bR\tascending\x12\x1b\n" +
+	"\tomit_body\x18\a \x01(\bR\bomitBody\"\xab\x01\n" +
 	"\x11ListNotesResponse\x12*\n" +
 	"\x05notes\x18\x01 \x03(\v2\x14.hivemind.notes.NoteR\x05notes\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"a\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12>\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1e.hivemind.common.v1.PaginationR\n" +
+	"pagination\"a\n" +
 	"\x11UpdateNoteRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
 	"\x04body\x18\x03 \x01(\tR\x04body\x12\x12\n" +
 	"\x04tags\x18\x04 \x03(\tR\x04tags\"#\n" +
 	"\x11DeleteNoteRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x87\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xc5\x02\n" +
 	"\x12SearchNotesRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x19\n" +
 	"\bguild_id\x18\x02 \x01(\tR\aguildId\x12\x12\n" +
 	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x14\n" +
 	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x05 \x01(\x05R\x06offset\"W\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12\x19\n" +
+	"\border_by\x18\x06 \x01(\tR\aorderBy\x12\x1c\n" +
+	"\tascending\x18\a \x01(\bR\tascending\x12*\n" +
+	"\x11author_discord_id\x18\b \x01(\tR\x0fauthorDiscordId\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\x12\x1b\n" +
+	"\tomit_body\x18\n" +
+	" \x01(\bR\bomitBody\x12\x1b\n" +
+	"\tguild_ids\x18\v \x03(\tR\bguildIds\"\xd5\x01\n" +
 	"\x13SearchNotesResponse\x12*\n" +
 	"\x05notes\x18\x01 \x03(\v2\x14.hivemind.notes.NoteR\x05notes\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\":\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12>\n" +
+	"\n" +
+	"pagination\x18\x05 \x01(\v2\x1e.hivemind.common.v1.PaginationR\n" +
+	"pagination\":\n" +
 	"\x1dAutocompleteNoteTitlesRequest\x12\x19\n" +
 	"\bguild_id\x18\x01 \x01(\tR\aguildId\"g\n" +
 	"\x1eAutocompleteNoteTitlesResponse\x12E\n" +
@@ -1383,17 +1652,24 @@ const file_notes_proto_rawDesc = "" +
 	"\x13author_display_name\x18\b \x01(\tR\x11authorDisplayName\x12G\n" +
 	"\x11message_timestamp\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x10messageTimestamp\x12D\n" +
 	"\vattachments\x18\n" +
-	" \x03(\v2\".hivemind.notes.AttachmentMetadataR\vattachments\";\n" +
+	" \x03(\v2\".hivemind.notes.AttachmentMetadataR\vattachments\"i\n" +
 	" ListNoteMessageReferencesRequest\x12\x17\n" +
-	"\anote_id\x18\x01 \x01(\tR\x06noteId\"i\n" +
+	"\anote_id\x18\x01 \x01(\tR\x06noteId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xbf\x01\n" +
 	"!ListNoteMessageReferencesResponse\x12D\n" +
 	"\n" +
 	"references\x18\x01 \x03(\v2$.hivemind.notes.NoteMessageReferenceR\n" +
-	"references2\xc9\x06\n" +
+	"references\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12>\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1e.hivemind.common.v1.PaginationR\n" +
+	"pagination2\x96\a\n" +
 	"\vNoteService\x12E\n" +
 	"\n" +
 	"CreateNote\x12!.hivemind.notes.CreateNoteRequest\x1a\x14.hivemind.notes.Note\x12?\n" +
-	"\aGetNote\x12\x1e.hivemind.notes.GetNoteRequest\x1a\x14.hivemind.notes.Note\x12P\n" +
+	"\aGetNote\x12\x1e.hivemind.notes.GetNoteRequest\x1a\x14.hivemind.notes.Note\x12K\n" +
+	"\rGetNoteBySlug\x12$.hivemind.notes.GetNoteBySlugRequest\x1a\x14.hivemind.notes.Note\x12P\n" +
 	"\tListNotes\x12 .hivemind.notes.ListNotesRequest\x1a!.hivemind.notes.ListNotesResponse\x12E\n" +
 	"\n" +
 	"UpdateNote\x12!.hivemind.notes.UpdateNoteRequest\x1a\x14.hivemind.notes.Note\x12T\n" +
@@ -1416,63 +1692,70 @@ func file_notes_proto_rawDescGZIP() []byte {
 	return file_notes_proto_rawDescData
 }
 
-var file_notes_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_notes_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_notes_proto_goTypes = []any{
 	(*Note)(nil),                              // 0: hivemind.notes.Note
 	(*CreateNoteRequest)(nil),                 // 1: hivemind.notes.CreateNoteRequest
 	(*GetNoteRequest)(nil),                    // 2: hivemind.notes.GetNoteRequest
-	(*ListNotesRequest)(nil),                  // 3: hivemind.notes.ListNotesRequest
-	(*ListNotesResponse)(nil),                 // 4: hivemind.notes.ListNotesResponse
-	(*UpdateNoteRequest)(nil),                 // 5: hivemind.notes.UpdateNoteRequest
-	(*DeleteNoteRequest)(nil),                 // 6: hivemind.notes.DeleteNoteRequest
-	(*SearchNotesRequest)(nil),                // 7: hivemind.notes.SearchNotesRequest
-	(*SearchNotesResponse)(nil),               // 8: hivemind.notes.SearchNotesResponse
-	(*AutocompleteNoteTitlesRequest)(nil),     // 9: hivemind.notes.AutocompleteNoteTitlesRequest
-	(*AutocompleteNoteTitlesResponse)(nil),    // 10: hivemind.notes.AutocompleteNoteTitlesResponse
-	(*NoteTitleSuggestion)(nil),               // 11: hivemind.notes.NoteTitleSuggestion
-	(*AttachmentMetadata)(nil),                // 12: hivemind.notes.AttachmentMetadata
-	(*NoteMessageReference)(nil),              // 13: hivemind.notes.NoteMessageReference
-	(*AddNoteMessageReferenceRequest)(nil),    // 14: hivemind.notes.AddNoteMessageReferenceRequest
-	(*ListNoteMessageReferencesRequest)(nil),  // 15: hivemind.notes.ListNoteMessageReferencesRequest
-	(*ListNoteMessageReferencesResponse)(nil), // 16: hivemind.notes.ListNoteMessageReferencesResponse
-	(*timestamppb.Timestamp)(nil),             // 17: google.protobuf.Timestamp
-	(*commonpb.SuccessResponse)(nil),          // 18: hivemind.common.v1.SuccessResponse
+	(*GetNoteBySlugRequest)(nil),              // 3: hivemind.notes.GetNoteBySlugRequest
+	(*ListNotesRequest)(nil),                  // 4: hivemind.notes.ListNotesRequest
+	(*ListNotesResponse)(nil),                 // 5: hivemind.notes.ListNotesResponse
+	(*UpdateNoteRequest)(nil),                 // 6: hivemind.notes.UpdateNoteRequest
+	(*DeleteNoteRequest)(nil),                 // 7: hivemind.notes.DeleteNoteRequest
+	(*SearchNotesRequest)(nil),                // 8: hivemind.notes.SearchNotesRequest
+	(*SearchNotesResponse)(nil),               // 9: hivemind.notes.SearchNotesResponse
+	(*AutocompleteNoteTitlesRequest)(nil),     // 10: hivemind.notes.AutocompleteNoteTitlesRequest
+	(*AutocompleteNoteTitlesResponse)(nil),    // 11: hivemind.notes.AutocompleteNoteTitlesResponse
+	(*NoteTitleSuggestion)(nil),               // 12: hivemind.notes.NoteTitleSuggestion
+	(*AttachmentMetadata)(nil),                // 13: hivemind.notes.AttachmentMetadata
+	(*NoteMessageReference)(nil),              // 14: hivemind.notes.NoteMessageReference
+	(*AddNoteMessageReferenceRequest)(nil),    // 15: hivemind.notes.AddNoteMessageReferenceRequest
+	(*ListNoteMessageReferencesRequest)(nil),  // 16: hivemind.notes.ListNoteMessageReferencesRequest
+	(*ListNoteMessageReferencesResponse)(nil), // 17: hivemind.notes.ListNoteMessageReferencesResponse
+	(*timestamppb.Timestamp)(nil),             // 18: google.protobuf.Timestamp
+	(*commonpb.Pagination)(nil),               // 19: hivemind.common.v1.Pagination
+	(*commonpb.SuccessResponse)(nil),          // 20: hivemind.common.v1.SuccessResponse
 }
 var file_notes_proto_depIdxs = []int32{
-	17, // 0: hivemind.notes.Note.created_at:type_name -> google.protobuf.Timestamp
-	17, // 1: hivemind.notes.Note.updated_at:type_name -> google.protobuf.Timestamp
+	18, // 0: hivemind.notes.Note.created_at:type_name -> google.protobuf.Timestamp
+	18, // 1: hivemind.notes.Note.updated_at:type_name -> google.protobuf.Timestamp
 	0,  // 2: hivemind.notes.ListNotesResponse.notes:type_name -> hivemind.notes.Note
-	0,  // 3: hivemind.notes.SearchNotesResponse.notes:type_name -> hivemind.notes.Note
-	11, // 4: hivemind.notes.AutocompleteNoteTitlesResponse.suggestions:type_name -> hivemind.notes.NoteTitleSuggestion
-	17, // 5: hivemind.notes.NoteMessageReference.message_timestamp:type_name -> google.protobuf.Timestamp
-	12, // 6: hivemind.notes.NoteMessageReference.attachments:type_name -> hivemind.notes.AttachmentMetadata
-	17, // 7: hivemind.notes.NoteMessageReference.added_at:type_name -> google.protobuf.Timestamp
-	17, // 8: hivemind.notes.AddNoteMessageReferenceRequest.message_timestamp:type_name -> google.protobuf.Timestamp
-	12, // 9: hivemind.notes.AddNoteMessageReferenceRequest.attachments:type_name -> hivemind.notes.AttachmentMetadata
-	13, // 10: hivemind.notes.ListNoteMessageReferencesResponse.references:type_name -> hivemind.notes.NoteMessageReference
-	1,  // 11: hivemind.notes.NoteService.CreateNote:input_type -> hivemind.notes.CreateNoteRequest
-	2,  // 12: hivemind.notes.NoteService.GetNote:input_type -> hivemind.notes.GetNoteRequest
-	3,  // 13: hivemind.notes.NoteService.ListNotes:input_type -> hivemind.notes.ListNotesRequest
-	5,  // 14: hivemind.notes.NoteService.UpdateNote:input_type -> hivemind.notes.UpdateNoteRequest
-	6,  // 15: hivemind.notes.NoteService.DeleteNote:input_type -> hivemind.notes.DeleteNoteRequest
-	7,  // 16: hivemind.notes.NoteService.SearchNotes:input_type -> hivemind.notes.SearchNotesRequest
-	9,  // 17: hivemind.notes.NoteService.AutocompleteNoteTitles:input_type -> hivemind.notes.AutocompleteNoteTitlesRequest
-	14, // 18: hivemind.notes.NoteService.AddNoteMessageReference:input_type -> hivemind.notes.AddNoteMessageReferenceRequest
-	15, // 19: hivemind.notes.NoteService.ListNoteMessageReferences:input_type -> hivemind.notes.ListNoteMessageReferencesRequest
-	0,  // 20: hivemind.notes.NoteService.CreateNote:output_type -> hivemind.notes.Note
-	0,  // 21: hivemind.notes.NoteService.GetNote:output_type -> hivemind.notes.Note
-	4,  // 22: hivemind.notes.NoteService.ListNotes:output_type -> hivemind.notes.ListNotesResponse
-	0,  // 23: hivemind.notes.NoteService.UpdateNote:output_type -> hivemind.notes.Note
-	18, // 24: hivemind.notes.NoteService.DeleteNote:output_type -> hivemind.common.v1.SuccessResponse
-	8,  // 25: hivemind.notes.NoteService.SearchNotes:output_type -> hivemind.notes.SearchNotesResponse
-	10, // 26: hivemind.notes.NoteService.AutocompleteNoteTitles:output_type -> hivemind.notes.AutocompleteNoteTitlesResponse
-	13, // 27: hivemind.notes.NoteService.AddNoteMessageReference:output_type -> hivemind.notes.NoteMessageReference
-	16, // 28: hivemind.notes.NoteService.ListNoteMessageReferences:output_type -> hivemind.notes.ListNoteMessageReferencesResponse
-	20, // [20:29] is the sub-list for method output_type
-	11, // [11:20] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	19, // 3: hivemind.notes.ListNotesResponse.pagination:type_name -> hivemind.common.v1.Pagination
+	0,  // 4: hivemind.notes.SearchNotesResponse.notes:type_name -> hivemind.notes.Note
+	19, // 5: hivemind.notes.SearchNotesResponse.pagination:type_name -> hivemind.common.v1.Pagination
+	12, // 6: hivemind.notes.AutocompleteNoteTitlesResponse.suggestions:type_name -> hivemind.notes.NoteTitleSuggestion
+	18, // 7: hivemind.notes.NoteMessageReference.message_timestamp:type_name -> google.protobuf.Timestamp
+	13, // 8: hivemind.notes.NoteMessageReference.attachments:type_name -> hivemind.notes.AttachmentMetadata
+	18, // 9: hivemind.notes.NoteMessageReference.added_at:type_name -> google.protobuf.Timestamp
+	18, // 10: hivemind.notes.AddNoteMessageReferenceRequest.message_timestamp:type_name -> google.protobuf.Timestamp
+	13, // 11: hivemind.notes.AddNoteMessageReferenceRequest.attachments:type_name -> hivemind.notes.AttachmentMetadata
+	14, // 12: hivemind.notes.ListNoteMessageReferencesResponse.references:type_name -> hivemind.notes.NoteMessageReference
+	19, // 13: hivemind.notes.ListNoteMessageReferencesResponse.pagination:type_name -> hivemind.common.v1.Pagination
+	1,  // 14: hivemind.notes.NoteService.CreateNote:input_type -> hivemind.notes.CreateNoteRequest
+	2,  // 15: hivemind.notes.NoteService.GetNote:input_type -> hivemind.notes.GetNoteRequest
+	3,  // 16: hivemind.notes.NoteService.GetNoteBySlug:input_type -> hivemind.notes.GetNoteBySlugRequest
+	4,  // 17: hivemind.notes.NoteService.ListNotes:input_type -> hivemind.notes.ListNotesRequest
+	6,  // 18: hivemind.notes.NoteService.UpdateNote:input_type -> hivemind.notes.UpdateNoteRequest
+	7,  // 19: hivemind.notes.NoteService.DeleteNote:input_type -> hivemind.notes.DeleteNoteRequest
+	8,  // 20: hivemind.notes.NoteService.SearchNotes:input_type -> hivemind.notes.SearchNotesRequest
+	10, // 21: hivemind.notes.NoteService.AutocompleteNoteTitles:input_type -> hivemind.notes.AutocompleteNoteTitlesRequest
+	15, // 22: hivemind.notes.NoteService.AddNoteMessageReference:input_type -> hivemind.notes.AddNoteMessageReferenceRequest
+	16, // 23: hivemind.notes.NoteService.ListNoteMessageReferences:input_type -> hivemind.notes.ListNoteMessageReferencesRequest
+	0,  // 24: hivemind.notes.NoteService.CreateNote:output_type -> hivemind.notes.Note
+	0,  // 25: hivemind.notes.NoteService.GetNote:output_type -> hivemind.notes.Note
+	0,  // 26: hivemind.notes.NoteService.GetNoteBySlug:output_type -> hivemind.notes.Note
+	5,  // 27: hivemind.notes.NoteService.ListNotes:output_type -> hivemind.notes.ListNotesResponse
+	0,  // 28: hivemind.notes.NoteService.UpdateNote:output_type -> hivemind.notes.Note
+	20, // 29: hivemind.notes.NoteService.DeleteNote:output_type -> hivemind.common.v1.SuccessResponse
+	9,  // 30: hivemind.notes.NoteService.SearchNotes:output_type -> hivemind.notes.SearchNotesResponse
+	11, // 31: hivemind.notes.NoteService.AutocompleteNoteTitles:output_type -> hivemind.notes.AutocompleteNoteTitlesResponse
+	14, // 32: hivemind.notes.NoteService.AddNoteMessageReference:output_type -> hivemind.notes.NoteMessageReference
+	17, // 33: hivemind.notes.NoteService.ListNoteMessageReferences:output_type -> hivemind.notes.ListNoteMessageReferencesResponse
+	24, // [24:34] is the sub-list for method output_type
+	14, // [14:24] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_notes_proto_init() }
@@ -1486,7 +1769,7 @@ func file_notes_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notes_proto_rawDesc), len(file_notes_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
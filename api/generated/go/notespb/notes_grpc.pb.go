@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	NoteService_CreateNote_FullMethodName                = "/hivemind.notes.NoteService/CreateNote"
 	NoteService_GetNote_FullMethodName                   = "/hivemind.notes.NoteService/GetNote"
+	NoteService_GetNoteBySlug_FullMethodName             = "/hivemind.notes.NoteService/GetNoteBySlug"
 	NoteService_ListNotes_FullMethodName                 = "/hivemind.notes.NoteService/ListNotes"
 	NoteService_UpdateNote_FullMethodName                = "/hivemind.notes.NoteService/UpdateNote"
 	NoteService_DeleteNote_FullMethodName                = "/hivemind.notes.NoteService/DeleteNote"
@@ -41,6 +42,8 @@ type NoteServiceClient interface {
 	CreateNote(ctx context.Context, in *CreateNoteRequest, opts ...grpc.CallOption) (*Note, error)
 	// GetNote retrieves a note by ID (must be owned by caller)
 	GetNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	// GetNoteBySlug retrieves a note by its permalink slug (must be owned by caller)
+	GetNoteBySlug(ctx context.Context, in *GetNoteBySlugRequest, opts ...grpc.CallOption) (*Note, error)
 	// ListNotes lists user's notes with optional filtering
 	ListNotes(ctx context.Context, in *ListNotesRequest, opts ...grpc.CallOption) (*ListNotesResponse, error)
 	// UpdateNote updates an existing note (must be owned by caller)
@@ -85,6 +88,16 @@ func (c *noteServiceClient) GetNote(ctx context.Context, in *GetNoteRequest, opt
 	return out, nil
 }
 
+func (c *noteServiceClient) GetNoteBySlug(ctx context.Context, in *GetNoteBySlugRequest, opts ...grpc.CallOption) (*Note, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Note)
+	err := c.cc.Invoke(ctx, NoteService_GetNoteBySlug_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *noteServiceClient) ListNotes(ctx context.Context, in *ListNotesRequest, opts ...grpc.CallOption) (*ListNotesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListNotesResponse)
@@ -165,6 +178,8 @@ type NoteServiceServer interface {
 	CreateNote(context.Context, *CreateNoteRequest) (*Note, error)
 	// GetNote retrieves a note by ID (must be owned by caller)
 	GetNote(context.Context, *GetNoteRequest) (*Note, error)
+	// GetNoteBySlug retrieves a note by its permalink slug (must be owned by caller)
+	GetNoteBySlug(context.Context, *GetNoteBySlugRequest) (*Note, error)
 	// ListNotes lists user's notes with optional filtering
 	ListNotes(context.Context, *ListNotesRequest) (*ListNotesResponse, error)
 	// UpdateNote updates an existing note (must be owned by caller)
@@ -194,6 +209,9 @@ func (UnimplementedNoteServiceServer) CreateNote(context.Context, *CreateNoteReq
 func (UnimplementedNoteServiceServer) GetNote(context.Context, *GetNoteRequest) (*Note, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetNote not implemented")
 }
+func (UnimplementedNoteServiceServer) GetNoteBySlug(context.Context, *GetNoteBySlugRequest) (*Note, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNoteBySlug not implemented")
+}
 func (UnimplementedNoteServiceServer) ListNotes(context.Context, *ListNotesRequest) (*ListNotesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListNotes not implemented")
 }
@@ -271,6 +289,24 @@ func _NoteService_GetNote_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NoteService_GetNoteBySlug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteBySlugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNoteBySlug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNoteBySlug_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNoteBySlug(ctx, req.(*GetNoteBySlugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NoteService_ListNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListNotesRequest)
 	if err := dec(in); err != nil {
@@ -412,6 +448,10 @@ var NoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNote",
 			Handler:    _NoteService_GetNote_Handler,
 		},
+		{
+			MethodName: "GetNoteBySlug",
+			Handler:    _NoteService_GetNoteBySlug_Handler,
+		},
 		{
 			MethodName: "ListNotes",
 			Handler:    _NoteService_ListNotes_Handler,
@@ -0,0 +1,305 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: preferences.proto
+
+package preferencespb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// UserPreferences holds per-user settings
+type UserPreferences struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DefaultGuildId    string                 `protobuf:"bytes,2,opt,name=default_guild_id,json=defaultGuildId,proto3" json:"default_guild_id,omitempty"`       // Empty when no default is set
+	DefaultGuildName  string                 `protobuf:"bytes,3,opt,name=default_guild_name,json=defaultGuildName,proto3" json:"default_guild_name,omitempty"` // Guild display name (optional)
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	LeaderboardOptOut bool                   `protobuf:"varint,5,opt,name=leaderboard_opt_out,json=leaderboardOptOut,proto3" json:"leaderboard_opt_out,omitempty"` // Left off quote leaderboards, including the weekly post
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UserPreferences) Reset() {
+	*x = UserPreferences{}
+	mi := &file_preferences_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserPreferences) ProtoMessage() {}
+
+func (x *UserPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_preferences_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserPreferences.ProtoReflect.Descriptor instead.
+func (*UserPreferences) Descriptor() ([]byte, []int) {
+	return file_preferences_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserPreferences) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserPreferences) GetDefaultGuildId() string {
+	if x != nil {
+		return x.DefaultGuildId
+	}
+	return ""
+}
+
+func (x *UserPreferences) GetDefaultGuildName() string {
+	if x != nil {
+		return x.DefaultGuildName
+	}
+	return ""
+}
+
+func (x *UserPreferences) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *UserPreferences) GetLeaderboardOptOut() bool {
+	if x != nil {
+		return x.LeaderboardOptOut
+	}
+	return false
+}
+
+type GetPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreferencesRequest) Reset() {
+	*x = GetPreferencesRequest{}
+	mi := &file_preferences_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreferencesRequest) ProtoMessage() {}
+
+func (x *GetPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preferences_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_preferences_proto_rawDescGZIP(), []int{1}
+}
+
+type SetDefaultGuildRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Empty to clear the default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDefaultGuildRequest) Reset() {
+	*x = SetDefaultGuildRequest{}
+	mi := &file_preferences_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDefaultGuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDefaultGuildRequest) ProtoMessage() {}
+
+func (x *SetDefaultGuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preferences_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDefaultGuildRequest.ProtoReflect.Descriptor instead.
+func (*SetDefaultGuildRequest) Descriptor() ([]byte, []int) {
+	return file_preferences_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetDefaultGuildRequest) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+type SetLeaderboardOptOutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OptOut        bool                   `protobuf:"varint,1,opt,name=opt_out,json=optOut,proto3" json:"opt_out,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLeaderboardOptOutRequest) Reset() {
+	*x = SetLeaderboardOptOutRequest{}
+	mi := &file_preferences_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLeaderboardOptOutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLeaderboardOptOutRequest) ProtoMessage() {}
+
+func (x *SetLeaderboardOptOutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preferences_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLeaderboardOptOutRequest.ProtoReflect.Descriptor instead.
+func (*SetLeaderboardOptOutRequest) Descriptor() ([]byte, []int) {
+	return file_preferences_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetLeaderboardOptOutRequest) GetOptOut() bool {
+	if x != nil {
+		return x.OptOut
+	}
+	return false
+}
+
+var File_preferences_proto protoreflect.FileDescriptor
+
+const file_preferences_proto_rawDesc = "" +
+	"\n" +
+	"\x11preferences.proto\x12\x17hivemind.preferences.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xed\x01\n" +
+	"\x0fUserPreferences\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12(\n" +
+	"\x10default_guild_id\x18\x02 \x01(\tR\x0edefaultGuildId\x12,\n" +
+	"\x12default_guild_name\x18\x03 \x01(\tR\x10defaultGuildName\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12.\n" +
+	"\x13leaderboard_opt_out\x18\x05 \x01(\bR\x11leaderboardOptOut\"\x17\n" +
+	"\x15GetPreferencesRequest\"3\n" +
+	"\x16SetDefaultGuildRequest\x12\x19\n" +
+	"\bguild_id\x18\x01 \x01(\tR\aguildId\"6\n" +
+	"\x1bSetLeaderboardOptOutRequest\x12\x17\n" +
+	"\aopt_out\x18\x01 \x01(\bR\x06optOut2\xe6\x02\n" +
+	"\x12PreferencesService\x12j\n" +
+	"\x0eGetPreferences\x12..hivemind.preferences.v1.GetPreferencesRequest\x1a(.hivemind.preferences.v1.UserPreferences\x12l\n" +
+	"\x0fSetDefaultGuild\x12/.hivemind.preferences.v1.SetDefaultGuildRequest\x1a(.hivemind.preferences.v1.UserPreferences\x12v\n" +
+	"\x14SetLeaderboardOptOut\x124.hivemind.preferences.v1.SetLeaderboardOptOutRequest\x1a(.hivemind.preferences.v1.UserPreferencesBCZAgithub.com/devilmonastery/hivemind/api/generated/go/preferencespbb\x06proto3"
+
+var (
+	file_preferences_proto_rawDescOnce sync.Once
+	file_preferences_proto_rawDescData []byte
+)
+
+func file_preferences_proto_rawDescGZIP() []byte {
+	file_preferences_proto_rawDescOnce.Do(func() {
+		file_preferences_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_preferences_proto_rawDesc), len(file_preferences_proto_rawDesc)))
+	})
+	return file_preferences_proto_rawDescData
+}
+
+var file_preferences_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_preferences_proto_goTypes = []any{
+	(*UserPreferences)(nil),             // 0: hivemind.preferences.v1.UserPreferences
+	(*GetPreferencesRequest)(nil),       // 1: hivemind.preferences.v1.GetPreferencesRequest
+	(*SetDefaultGuildRequest)(nil),      // 2: hivemind.preferences.v1.SetDefaultGuildRequest
+	(*SetLeaderboardOptOutRequest)(nil), // 3: hivemind.preferences.v1.SetLeaderboardOptOutRequest
+	(*timestamppb.Timestamp)(nil),       // 4: google.protobuf.Timestamp
+}
+var file_preferences_proto_depIdxs = []int32{
+	4, // 0: hivemind.preferences.v1.UserPreferences.updated_at:type_name -> google.protobuf.Timestamp
+	1, // 1: hivemind.preferences.v1.PreferencesService.GetPreferences:input_type -> hivemind.preferences.v1.GetPreferencesRequest
+	2, // 2: hivemind.preferences.v1.PreferencesService.SetDefaultGuild:input_type -> hivemind.preferences.v1.SetDefaultGuildRequest
+	3, // 3: hivemind.preferences.v1.PreferencesService.SetLeaderboardOptOut:input_type -> hivemind.preferences.v1.SetLeaderboardOptOutRequest
+	0, // 4: hivemind.preferences.v1.PreferencesService.GetPreferences:output_type -> hivemind.preferences.v1.UserPreferences
+	0, // 5: hivemind.preferences.v1.PreferencesService.SetDefaultGuild:output_type -> hivemind.preferences.v1.UserPreferences
+	0, // 6: hivemind.preferences.v1.PreferencesService.SetLeaderboardOptOut:output_type -> hivemind.preferences.v1.UserPreferences
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_preferences_proto_init() }
+func file_preferences_proto_init() {
+	if File_preferences_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_preferences_proto_rawDesc), len(file_preferences_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_preferences_proto_goTypes,
+		DependencyIndexes: file_preferences_proto_depIdxs,
+		MessageInfos:      file_preferences_proto_msgTypes,
+	}.Build()
+	File_preferences_proto = out.File
+	file_preferences_proto_goTypes = nil
+	file_preferences_proto_depIdxs = nil
+}
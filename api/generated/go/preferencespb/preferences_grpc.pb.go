@@ -0,0 +1,205 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: preferences.proto
+
+package preferencespb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PreferencesService_GetPreferences_FullMethodName       = "/hivemind.preferences.v1.PreferencesService/GetPreferences"
+	PreferencesService_SetDefaultGuild_FullMethodName      = "/hivemind.preferences.v1.PreferencesService/SetDefaultGuild"
+	PreferencesService_SetLeaderboardOptOut_FullMethodName = "/hivemind.preferences.v1.PreferencesService/SetLeaderboardOptOut"
+)
+
+// PreferencesServiceClient is the client API for PreferencesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PreferencesService manages per-user preferences for the authenticated caller
+type PreferencesServiceClient interface {
+	// GetPreferences returns the caller's preferences (defaults if none are stored)
+	GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*UserPreferences, error)
+	// SetDefaultGuild sets or clears the guild applied to commands run outside a guild
+	SetDefaultGuild(ctx context.Context, in *SetDefaultGuildRequest, opts ...grpc.CallOption) (*UserPreferences, error)
+	// SetLeaderboardOptOut keeps the caller off (or puts them back on) quote leaderboards
+	SetLeaderboardOptOut(ctx context.Context, in *SetLeaderboardOptOutRequest, opts ...grpc.CallOption) (*UserPreferences, error)
+}
+
+type preferencesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPreferencesServiceClient(cc grpc.ClientConnInterface) PreferencesServiceClient {
+	return &preferencesServiceClient{cc}
+}
+
+func (c *preferencesServiceClient) GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*UserPreferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserPreferences)
+	err := c.cc.Invoke(ctx, PreferencesService_GetPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *preferencesServiceClient) SetDefaultGuild(ctx context.Context, in *SetDefaultGuildRequest, opts ...grpc.CallOption) (*UserPreferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserPreferences)
+	err := c.cc.Invoke(ctx, PreferencesService_SetDefaultGuild_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *preferencesServiceClient) SetLeaderboardOptOut(ctx context.Context, in *SetLeaderboardOptOutRequest, opts ...grpc.CallOption) (*UserPreferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserPreferences)
+	err := c.cc.Invoke(ctx, PreferencesService_SetLeaderboardOptOut_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PreferencesServiceServer is the server API for PreferencesService service.
+// All implementations should embed UnimplementedPreferencesServiceServer
+// for forward compatibility.
+//
+// PreferencesService manages per-user preferences for the authenticated caller
+type PreferencesServiceServer interface {
+	// GetPreferences returns the caller's preferences (defaults if none are stored)
+	GetPreferences(context.Context, *GetPreferencesRequest) (*UserPreferences, error)
+	// SetDefaultGuild sets or clears the guild applied to commands run outside a guild
+	SetDefaultGuild(context.Context, *SetDefaultGuildRequest) (*UserPreferences, error)
+	// SetLeaderboardOptOut keeps the caller off (or puts them back on) quote leaderboards
+	SetLeaderboardOptOut(context.Context, *SetLeaderboardOptOutRequest) (*UserPreferences, error)
+}
+
+// UnimplementedPreferencesServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPreferencesServiceServer struct{}
+
+func (UnimplementedPreferencesServiceServer) GetPreferences(context.Context, *GetPreferencesRequest) (*UserPreferences, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPreferences not implemented")
+}
+func (UnimplementedPreferencesServiceServer) SetDefaultGuild(context.Context, *SetDefaultGuildRequest) (*UserPreferences, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetDefaultGuild not implemented")
+}
+func (UnimplementedPreferencesServiceServer) SetLeaderboardOptOut(context.Context, *SetLeaderboardOptOutRequest) (*UserPreferences, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetLeaderboardOptOut not implemented")
+}
+func (UnimplementedPreferencesServiceServer) testEmbeddedByValue() {}
+
+// UnsafePreferencesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PreferencesServiceServer will
+// result in compilation errors.
+type UnsafePreferencesServiceServer interface {
+	mustEmbedUnimplementedPreferencesServiceServer()
+}
+
+func RegisterPreferencesServiceServer(s grpc.ServiceRegistrar, srv PreferencesServiceServer) {
+	// If the following call panics, it indicates UnimplementedPreferencesServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PreferencesService_ServiceDesc, srv)
+}
+
+func _PreferencesService_GetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PreferencesServiceServer).GetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PreferencesService_GetPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PreferencesServiceServer).GetPreferences(ctx, req.(*GetPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PreferencesService_SetDefaultGuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDefaultGuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PreferencesServiceServer).SetDefaultGuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PreferencesService_SetDefaultGuild_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PreferencesServiceServer).SetDefaultGuild(ctx, req.(*SetDefaultGuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PreferencesService_SetLeaderboardOptOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLeaderboardOptOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PreferencesServiceServer).SetLeaderboardOptOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PreferencesService_SetLeaderboardOptOut_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PreferencesServiceServer).SetLeaderboardOptOut(ctx, req.(*SetLeaderboardOptOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PreferencesService_ServiceDesc is the grpc.ServiceDesc for PreferencesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PreferencesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hivemind.preferences.v1.PreferencesService",
+	HandlerType: (*PreferencesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPreferences",
+			Handler:    _PreferencesService_GetPreferences_Handler,
+		},
+		{
+			MethodName: "SetDefaultGuild",
+			Handler:    _PreferencesService_SetDefaultGuild_Handler,
+		},
+		{
+			MethodName: "SetLeaderboardOptOut",
+			Handler:    _PreferencesService_SetLeaderboardOptOut_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "preferences.proto",
+}
@@ -52,8 +52,19 @@ type Quote struct {
 	// Timestamps
 	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	SourceMsgTimestamp *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=source_msg_timestamp,json=sourceMsgTimestamp,proto3" json:"source_msg_timestamp,omitempty"` // When the original Discord message was sent
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// Search result metadata (only populated by SearchQuotes)
+	Snippet string  `protobuf:"bytes,23,opt,name=snippet,proto3" json:"snippet,omitempty"` // Body excerpt with matches wrapped in **bold**
+	Rank    float32 `protobuf:"fixed32,24,opt,name=rank,proto3" json:"rank,omitempty"`     // Relevance score, higher is better
+	// Set by CreateQuote when the guild already had this quote (same source message or
+	// same text); the existing quote is returned and nothing new is saved
+	WasDuplicate bool   `protobuf:"varint,25,opt,name=was_duplicate,json=wasDuplicate,proto3" json:"was_duplicate,omitempty"`
+	ShortCode    string `protobuf:"bytes,26,opt,name=short_code,json=shortCode,proto3" json:"short_code,omitempty"` // Permalink code, unique within the guild and never changed
+	// Size metadata (only populated by ListQuotes and SearchQuotes), set even when the
+	// body is omitted
+	BodyLength    int32 `protobuf:"varint,27,opt,name=body_length,json=bodyLength,proto3" json:"body_length,omitempty"` // Characters in the body
+	TagCount      int32 `protobuf:"varint,28,opt,name=tag_count,json=tagCount,proto3" json:"tag_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Quote) Reset() {
@@ -240,6 +251,48 @@ func (x *Quote) GetSourceMsgTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Quote) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *Quote) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Quote) GetWasDuplicate() bool {
+	if x != nil {
+		return x.WasDuplicate
+	}
+	return false
+}
+
+func (x *Quote) GetShortCode() string {
+	if x != nil {
+		return x.ShortCode
+	}
+	return ""
+}
+
+func (x *Quote) GetBodyLength() int32 {
+	if x != nil {
+		return x.BodyLength
+	}
+	return 0
+}
+
+func (x *Quote) GetTagCount() int32 {
+	if x != nil {
+		return x.TagCount
+	}
+	return 0
+}
+
 type CreateQuoteRequest struct {
 	state   protoimpl.MessageState `protogen:"open.v1"`
 	Body    string                 `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
@@ -252,6 +305,8 @@ type CreateQuoteRequest struct {
 	Tags                     []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
 	SourceChannelName        string                 `protobuf:"bytes,8,opt,name=source_channel_name,json=sourceChannelName,proto3" json:"source_channel_name,omitempty"`
 	SourceMsgTimestamp       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=source_msg_timestamp,json=sourceMsgTimestamp,proto3" json:"source_msg_timestamp,omitempty"` // When the original message was sent
+	PreserveId               string                 `protobuf:"bytes,10,opt,name=preserve_id,json=preserveId,proto3" json:"preserve_id,omitempty"`                          // Admin-only: use this ID instead of generating one (imports)
+	RejectDuplicate          bool                   `protobuf:"varint,11,opt,name=reject_duplicate,json=rejectDuplicate,proto3" json:"reject_duplicate,omitempty"`          // Fail with ALREADY_EXISTS instead of returning an existing duplicate
 	unknownFields            protoimpl.UnknownFields
 	sizeCache                protoimpl.SizeCache
 }
@@ -349,6 +404,20 @@ func (x *CreateQuoteRequest) GetSourceMsgTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *CreateQuoteRequest) GetPreserveId() string {
+	if x != nil {
+		return x.PreserveId
+	}
+	return ""
+}
+
+func (x *CreateQuoteRequest) GetRejectDuplicate() bool {
+	if x != nil {
+		return x.RejectDuplicate
+	}
+	return false
+}
+
 type GetQuoteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -393,6 +462,110 @@ func (x *GetQuoteRequest) GetId() string {
 	return ""
 }
 
+type GetQuoteByCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteByCodeRequest) Reset() {
+	*x = GetQuoteByCodeRequest{}
+	mi := &file_quotes_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteByCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteByCodeRequest) ProtoMessage() {}
+
+func (x *GetQuoteByCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteByCodeRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteByCodeRequest) Descriptor() ([]byte, []int) {
+	return file_quotes_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetQuoteByCodeRequest) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *GetQuoteByCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type GetQuoteBySourceMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
+	SourceMsgId   string                 `protobuf:"bytes,2,opt,name=source_msg_id,json=sourceMsgId,proto3" json:"source_msg_id,omitempty"` // Discord message ID the quote was saved from
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteBySourceMessageRequest) Reset() {
+	*x = GetQuoteBySourceMessageRequest{}
+	mi := &file_quotes_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteBySourceMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteBySourceMessageRequest) ProtoMessage() {}
+
+func (x *GetQuoteBySourceMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteBySourceMessageRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteBySourceMessageRequest) Descriptor() ([]byte, []int) {
+	return file_quotes_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetQuoteBySourceMessageRequest) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *GetQuoteBySourceMessageRequest) GetSourceMsgId() string {
+	if x != nil {
+		return x.SourceMsgId
+	}
+	return ""
+}
+
 type ListQuotesRequest struct {
 	state                    protoimpl.MessageState `protogen:"open.v1"`
 	GuildId                  string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
@@ -402,13 +575,14 @@ type ListQuotesRequest struct {
 	Offset                   int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
 	OrderBy                  string                 `protobuf:"bytes,6,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"` // "created_at", "random"
 	Ascending                bool                   `protobuf:"varint,7,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	OmitBody                 bool                   `protobuf:"varint,8,opt,name=omit_body,json=omitBody,proto3" json:"omit_body,omitempty"` // Leave body empty in the results; the size metadata is still set
 	unknownFields            protoimpl.UnknownFields
 	sizeCache                protoimpl.SizeCache
 }
 
 func (x *ListQuotesRequest) Reset() {
 	*x = ListQuotesRequest{}
-	mi := &file_quotes_proto_msgTypes[3]
+	mi := &file_quotes_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -420,7 +594,7 @@ func (x *ListQuotesRequest) String() string {
 func (*ListQuotesRequest) ProtoMessage() {}
 
 func (x *ListQuotesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quotes_proto_msgTypes[3]
+	mi := &file_quotes_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,7 +607,7 @@ func (x *ListQuotesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListQuotesRequest.ProtoReflect.Descriptor instead.
 func (*ListQuotesRequest) Descriptor() ([]byte, []int) {
-	return file_quotes_proto_rawDescGZIP(), []int{3}
+	return file_quotes_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListQuotesRequest) GetGuildId() string {
@@ -485,17 +659,26 @@ func (x *ListQuotesRequest) GetAscending() bool {
 	return false
 }
 
+func (x *ListQuotesRequest) GetOmitBody() bool {
+	if x != nil {
+		return x.OmitBody
+	}
+	return false
+}
+
 type ListQuotesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Quotes        []*Quote               `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
 	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // Page size used, after applying the server's default and maximum
+	Pagination    *commonpb.Pagination   `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListQuotesResponse) Reset() {
 	*x = ListQuotesResponse{}
-	mi := &file_quotes_proto_msgTypes[4]
+	mi := &file_quotes_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -507,7 +690,7 @@ func (x *ListQuotesResponse) String() string {
 func (*ListQuotesResponse) ProtoMessage() {}
 
 func (x *ListQuotesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quotes_proto_msgTypes[4]
+	mi := &file_quotes_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,7 +703,7 @@ func (x *ListQuotesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListQuotesResponse.ProtoReflect.Descriptor instead.
 func (*ListQuotesResponse) Descriptor() ([]byte, []int) {
-	return file_quotes_proto_rawDescGZIP(), []int{4}
+	return file_quotes_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListQuotesResponse) GetQuotes() []*Quote {
@@ -537,6 +720,20 @@ func (x *ListQuotesResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *ListQuotesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListQuotesResponse) GetPagination() *commonpb.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
 type DeleteQuoteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -546,7 +743,7 @@ type DeleteQuoteRequest struct {
 
 func (x *DeleteQuoteRequest) Reset() {
 	*x = DeleteQuoteRequest{}
-	mi := &file_quotes_proto_msgTypes[5]
+	mi := &file_quotes_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -558,7 +755,7 @@ func (x *DeleteQuoteRequest) String() string {
 func (*DeleteQuoteRequest) ProtoMessage() {}
 
 func (x *DeleteQuoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quotes_proto_msgTypes[5]
+	mi := &file_quotes_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -571,7 +768,7 @@ func (x *DeleteQuoteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteQuoteRequest.ProtoReflect.Descriptor instead.
 func (*DeleteQuoteRequest) Descriptor() ([]byte, []int) {
-	return file_quotes_proto_rawDescGZIP(), []int{5}
+	return file_quotes_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteQuoteRequest) GetId() string {
@@ -582,17 +779,18 @@ func (x *DeleteQuoteRequest) GetId() string {
 }
 
 type UpdateQuoteRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
-	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Id                      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Body                    string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	Tags                    []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	SourceMsgAuthorUsername string                 `protobuf:"bytes,4,opt,name=source_msg_author_username,json=sourceMsgAuthorUsername,proto3" json:"source_msg_author_username,omitempty"` // Optional: corrected attribution (who said it); empty leaves it unchanged
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *UpdateQuoteRequest) Reset() {
 	*x = UpdateQuoteRequest{}
-	mi := &file_quotes_proto_msgTypes[6]
+	mi := &file_quotes_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -604,7 +802,7 @@ func (x *UpdateQuoteRequest) String() string {
 func (*UpdateQuoteRequest) ProtoMessage() {}
 
 func (x *UpdateQuoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quotes_proto_msgTypes[6]
+	mi := &file_quotes_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -617,7 +815,7 @@ func (x *UpdateQuoteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateQuoteRequest.ProtoReflect.Descriptor instead.
 func (*UpdateQuoteRequest) Descriptor() ([]byte, []int) {
-	return file_quotes_proto_rawDescGZIP(), []int{6}
+	return file_quotes_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UpdateQuoteRequest) GetId() string {
@@ -641,20 +839,31 @@ func (x *UpdateQuoteRequest) GetTags() []string {
 	return nil
 }
 
+func (x *UpdateQuoteRequest) GetSourceMsgAuthorUsername() string {
+	if x != nil {
+		return x.SourceMsgAuthorUsername
+	}
+	return ""
+}
+
 type SearchQuotesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
-	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`  // Full-text search query
-	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`    // Optional: filter by tags
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // Default: 10
+	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Empty with no guild_ids searches every guild the caller is a member of (admins: every guild)
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`                    // Full-text search query
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`                      // Optional: filter by tags
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                   // Default: 10
 	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	OrderBy       string                 `protobuf:"bytes,6,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"` // "relevance", "created_at", "updated_at" (default: relevance with a query, else created_at)
+	Ascending     bool                   `protobuf:"varint,7,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	OmitBody      bool                   `protobuf:"varint,8,opt,name=omit_body,json=omitBody,proto3" json:"omit_body,omitempty"` // Leave body empty in the results; the snippet and size metadata are still set
+	GuildIds      []string               `protobuf:"bytes,9,rep,name=guild_ids,json=guildIds,proto3" json:"guild_ids,omitempty"`  // Optional: search these guilds; ignored when guild_id is set
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SearchQuotesRequest) Reset() {
 	*x = SearchQuotesRequest{}
-	mi := &file_quotes_proto_msgTypes[7]
+	mi := &file_quotes_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -666,7 +875,7 @@ func (x *SearchQuotesRequest) String() string {
 func (*SearchQuotesRequest) ProtoMessage() {}
 
 func (x *SearchQuotesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quotes_proto_msgTypes[7]
+	mi := &file_quotes_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -679,7 +888,7 @@ func (x *SearchQuotesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchQuotesRequest.ProtoReflect.Descriptor instead.
 func (*SearchQuotesRequest) Descriptor() ([]byte, []int) {
-	return file_quotes_proto_rawDescGZIP(), []int{7}
+	return file_quotes_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SearchQuotesRequest) GetGuildId() string {
@@ -717,17 +926,47 @@ func (x *SearchQuotesRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *SearchQuotesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *SearchQuotesRequest) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+func (x *SearchQuotesRequest) GetOmitBody() bool {
+	if x != nil {
+		return x.OmitBody
+	}
+	return false
+}
+
+func (x *SearchQuotesRequest) GetGuildIds() []string {
+	if x != nil {
+		return x.GuildIds
+	}
+	return nil
+}
+
 type SearchQuotesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Quotes        []*Quote               `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
 	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // Page size used, after applying the server's default and maximum
+	Pagination    *commonpb.Pagination   `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SearchQuotesResponse) Reset() {
 	*x = SearchQuotesResponse{}
-	mi := &file_quotes_proto_msgTypes[8]
+	mi := &file_quotes_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -739,7 +978,7 @@ func (x *SearchQuotesResponse) String() string {
 func (*SearchQuotesResponse) ProtoMessage() {}
 
 func (x *SearchQuotesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quotes_proto_msgTypes[8]
+	mi := &file_quotes_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -752,7 +991,7 @@ func (x *SearchQuotesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchQuotesResponse.ProtoReflect.Descriptor instead.
 func (*SearchQuotesResponse) Descriptor() ([]byte, []int) {
-	return file_quotes_proto_rawDescGZIP(), []int{8}
+	return file_quotes_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SearchQuotesResponse) GetQuotes() []*Quote {
@@ -769,6 +1008,20 @@ func (x *SearchQuotesResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *SearchQuotesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchQuotesResponse) GetPagination() *commonpb.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
 type GetRandomQuoteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
@@ -779,7 +1032,7 @@ type GetRandomQuoteRequest struct {
 
 func (x *GetRandomQuoteRequest) Reset() {
 	*x = GetRandomQuoteRequest{}
-	mi := &file_quotes_proto_msgTypes[9]
+	mi := &file_quotes_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -791,7 +1044,7 @@ func (x *GetRandomQuoteRequest) String() string {
 func (*GetRandomQuoteRequest) ProtoMessage() {}
 
 func (x *GetRandomQuoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quotes_proto_msgTypes[9]
+	mi := &file_quotes_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -804,7 +1057,7 @@ func (x *GetRandomQuoteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRandomQuoteRequest.ProtoReflect.Descriptor instead.
 func (*GetRandomQuoteRequest) Descriptor() ([]byte, []int) {
-	return file_quotes_proto_rawDescGZIP(), []int{9}
+	return file_quotes_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetRandomQuoteRequest) GetGuildId() string {
@@ -821,11 +1074,184 @@ func (x *GetRandomQuoteRequest) GetTags() []string {
 	return nil
 }
 
+type GetQuoteStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Entries per leaderboard (default: 10)
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`  // Only count quotes saved at or after this time (default: all time)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteStatsRequest) Reset() {
+	*x = GetQuoteStatsRequest{}
+	mi := &file_quotes_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteStatsRequest) ProtoMessage() {}
+
+func (x *GetQuoteStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteStatsRequest) Descriptor() ([]byte, []int) {
+	return file_quotes_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetQuoteStatsRequest) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *GetQuoteStatsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetQuoteStatsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+// QuoteStatsEntry is one row of a quote leaderboard
+type QuoteStatsEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DiscordId     string                 `protobuf:"bytes,1,opt,name=discord_id,json=discordId,proto3" json:"discord_id,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteStatsEntry) Reset() {
+	*x = QuoteStatsEntry{}
+	mi := &file_quotes_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteStatsEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteStatsEntry) ProtoMessage() {}
+
+func (x *QuoteStatsEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteStatsEntry.ProtoReflect.Descriptor instead.
+func (*QuoteStatsEntry) Descriptor() ([]byte, []int) {
+	return file_quotes_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *QuoteStatsEntry) GetDiscordId() string {
+	if x != nil {
+		return x.DiscordId
+	}
+	return ""
+}
+
+func (x *QuoteStatsEntry) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetQuoteStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalQuotes   int32                  `protobuf:"varint,1,opt,name=total_quotes,json=totalQuotes,proto3" json:"total_quotes,omitempty"`
+	TopQuoters    []*QuoteStatsEntry     `protobuf:"bytes,2,rep,name=top_quoters,json=topQuoters,proto3" json:"top_quoters,omitempty"` // Who saved the most quotes
+	MostQuoted    []*QuoteStatsEntry     `protobuf:"bytes,3,rep,name=most_quoted,json=mostQuoted,proto3" json:"most_quoted,omitempty"` // Whose messages were quoted the most
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteStatsResponse) Reset() {
+	*x = GetQuoteStatsResponse{}
+	mi := &file_quotes_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteStatsResponse) ProtoMessage() {}
+
+func (x *GetQuoteStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetQuoteStatsResponse) Descriptor() ([]byte, []int) {
+	return file_quotes_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetQuoteStatsResponse) GetTotalQuotes() int32 {
+	if x != nil {
+		return x.TotalQuotes
+	}
+	return 0
+}
+
+func (x *GetQuoteStatsResponse) GetTopQuoters() []*QuoteStatsEntry {
+	if x != nil {
+		return x.TopQuoters
+	}
+	return nil
+}
+
+func (x *GetQuoteStatsResponse) GetMostQuoted() []*QuoteStatsEntry {
+	if x != nil {
+		return x.MostQuoted
+	}
+	return nil
+}
+
 var File_quotes_proto protoreflect.FileDescriptor
 
 const file_quotes_proto_rawDesc = "" +
 	"\n" +
-	"\fquotes.proto\x12\x0fhivemind.quotes\x1a\fcommon.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x93\b\n" +
+	"\fquotes.proto\x12\x0fhivemind.quotes\x1a\fcommon.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc3\t\n" +
 	"\x05Quote\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04body\x18\x02 \x01(\tR\x04body\x12\x1b\n" +
@@ -851,7 +1277,15 @@ const file_quotes_proto_rawDesc = "" +
 	"\x04tags\x18\r \x03(\tR\x04tags\x129\n" +
 	"\n" +
 	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12L\n" +
-	"\x14source_msg_timestamp\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x12sourceMsgTimestamp\"\xa2\x03\n" +
+	"\x14source_msg_timestamp\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x12sourceMsgTimestamp\x12\x18\n" +
+	"\asnippet\x18\x17 \x01(\tR\asnippet\x12\x12\n" +
+	"\x04rank\x18\x18 \x01(\x02R\x04rank\x12#\n" +
+	"\rwas_duplicate\x18\x19 \x01(\bR\fwasDuplicate\x12\x1d\n" +
+	"\n" +
+	"short_code\x18\x1a \x01(\tR\tshortCode\x12\x1f\n" +
+	"\vbody_length\x18\x1b \x01(\x05R\n" +
+	"bodyLength\x12\x1b\n" +
+	"\ttag_count\x18\x1c \x01(\x05R\btagCount\"\xee\x03\n" +
 	"\x12CreateQuoteRequest\x12\x12\n" +
 	"\x04body\x18\x01 \x01(\tR\x04body\x12\x19\n" +
 	"\bguild_id\x18\x02 \x01(\tR\aguildId\x12\"\n" +
@@ -861,9 +1295,19 @@ const file_quotes_proto_rawDesc = "" +
 	"\x1asource_msg_author_username\x18\x06 \x01(\tR\x17sourceMsgAuthorUsername\x12\x12\n" +
 	"\x04tags\x18\a \x03(\tR\x04tags\x12.\n" +
 	"\x13source_channel_name\x18\b \x01(\tR\x11sourceChannelName\x12L\n" +
-	"\x14source_msg_timestamp\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x12sourceMsgTimestamp\"!\n" +
+	"\x14source_msg_timestamp\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x12sourceMsgTimestamp\x12\x1f\n" +
+	"\vpreserve_id\x18\n" +
+	" \x01(\tR\n" +
+	"preserveId\x12)\n" +
+	"\x10reject_duplicate\x18\v \x01(\bR\x0frejectDuplicate\"!\n" +
 	"\x0fGetQuoteRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xe9\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"F\n" +
+	"\x15GetQuoteByCodeRequest\x12\x19\n" +
+	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"_\n" +
+	"\x1eGetQuoteBySourceMessageRequest\x12\x19\n" +
+	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\"\n" +
+	"\rsource_msg_id\x18\x02 \x01(\tR\vsourceMsgId\"\x86\x02\n" +
 	"\x11ListQuotesRequest\x12\x19\n" +
 	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12>\n" +
 	"\x1csource_msg_author_discord_id\x18\x02 \x01(\tR\x18sourceMsgAuthorDiscordId\x12\x12\n" +
@@ -871,37 +1315,68 @@ const file_quotes_proto_rawDesc = "" +
 	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12\x19\n" +
 	"\border_by\x18\x06 \x01(\tR\aorderBy\x12\x1c\n" +
-	"\tascending\x18\a \x01(\bR\tascending\"Z\n" +
+	"\tascending\x18\a \x01(\bR\tascending\x12\x1b\n" +
+	"\tomit_body\x18\b \x01(\bR\bomitBody\"\xb0\x01\n" +
 	"\x12ListQuotesResponse\x12.\n" +
 	"\x06quotes\x18\x01 \x03(\v2\x16.hivemind.quotes.QuoteR\x06quotes\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"$\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12>\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1e.hivemind.common.v1.PaginationR\n" +
+	"pagination\"$\n" +
 	"\x12DeleteQuoteRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"L\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x89\x01\n" +
 	"\x12UpdateQuoteRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04body\x18\x02 \x01(\tR\x04body\x12\x12\n" +
-	"\x04tags\x18\x03 \x03(\tR\x04tags\"\x88\x01\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12;\n" +
+	"\x1asource_msg_author_username\x18\x04 \x01(\tR\x17sourceMsgAuthorUsername\"\xfb\x01\n" +
 	"\x13SearchQuotesRequest\x12\x19\n" +
 	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x14\n" +
 	"\x05query\x18\x02 \x01(\tR\x05query\x12\x12\n" +
 	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x14\n" +
 	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x05 \x01(\x05R\x06offset\"\\\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12\x19\n" +
+	"\border_by\x18\x06 \x01(\tR\aorderBy\x12\x1c\n" +
+	"\tascending\x18\a \x01(\bR\tascending\x12\x1b\n" +
+	"\tomit_body\x18\b \x01(\bR\bomitBody\x12\x1b\n" +
+	"\tguild_ids\x18\t \x03(\tR\bguildIds\"\xb2\x01\n" +
 	"\x14SearchQuotesResponse\x12.\n" +
 	"\x06quotes\x18\x01 \x03(\v2\x16.hivemind.quotes.QuoteR\x06quotes\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"F\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12>\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1e.hivemind.common.v1.PaginationR\n" +
+	"pagination\"F\n" +
 	"\x15GetRandomQuoteRequest\x12\x19\n" +
 	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x12\n" +
-	"\x04tags\x18\x02 \x03(\tR\x04tags2\xcb\x04\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"y\n" +
+	"\x14GetQuoteStatsRequest\x12\x19\n" +
+	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"F\n" +
+	"\x0fQuoteStatsEntry\x12\x1d\n" +
+	"\n" +
+	"discord_id\x18\x01 \x01(\tR\tdiscordId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\xc0\x01\n" +
+	"\x15GetQuoteStatsResponse\x12!\n" +
+	"\ftotal_quotes\x18\x01 \x01(\x05R\vtotalQuotes\x12A\n" +
+	"\vtop_quoters\x18\x02 \x03(\v2 .hivemind.quotes.QuoteStatsEntryR\n" +
+	"topQuoters\x12A\n" +
+	"\vmost_quoted\x18\x03 \x03(\v2 .hivemind.quotes.QuoteStatsEntryR\n" +
+	"mostQuoted2\xe1\x06\n" +
 	"\fQuoteService\x12J\n" +
 	"\vCreateQuote\x12#.hivemind.quotes.CreateQuoteRequest\x1a\x16.hivemind.quotes.Quote\x12D\n" +
-	"\bGetQuote\x12 .hivemind.quotes.GetQuoteRequest\x1a\x16.hivemind.quotes.Quote\x12U\n" +
+	"\bGetQuote\x12 .hivemind.quotes.GetQuoteRequest\x1a\x16.hivemind.quotes.Quote\x12P\n" +
+	"\x0eGetQuoteByCode\x12&.hivemind.quotes.GetQuoteByCodeRequest\x1a\x16.hivemind.quotes.Quote\x12b\n" +
+	"\x17GetQuoteBySourceMessage\x12/.hivemind.quotes.GetQuoteBySourceMessageRequest\x1a\x16.hivemind.quotes.Quote\x12U\n" +
 	"\n" +
 	"ListQuotes\x12\".hivemind.quotes.ListQuotesRequest\x1a#.hivemind.quotes.ListQuotesResponse\x12W\n" +
 	"\vDeleteQuote\x12#.hivemind.quotes.DeleteQuoteRequest\x1a#.hivemind.common.v1.SuccessResponse\x12J\n" +
 	"\vUpdateQuote\x12#.hivemind.quotes.UpdateQuoteRequest\x1a\x16.hivemind.quotes.Quote\x12[\n" +
 	"\fSearchQuotes\x12$.hivemind.quotes.SearchQuotesRequest\x1a%.hivemind.quotes.SearchQuotesResponse\x12P\n" +
-	"\x0eGetRandomQuote\x12&.hivemind.quotes.GetRandomQuoteRequest\x1a\x16.hivemind.quotes.QuoteB>Z<github.com/devilmonastery/hivemind/api/generated/go/quotespbb\x06proto3"
+	"\x0eGetRandomQuote\x12&.hivemind.quotes.GetRandomQuoteRequest\x1a\x16.hivemind.quotes.Quote\x12^\n" +
+	"\rGetQuoteStats\x12%.hivemind.quotes.GetQuoteStatsRequest\x1a&.hivemind.quotes.GetQuoteStatsResponseB>Z<github.com/devilmonastery/hivemind/api/generated/go/quotespbb\x06proto3"
 
 var (
 	file_quotes_proto_rawDescOnce sync.Once
@@ -915,46 +1390,63 @@ func file_quotes_proto_rawDescGZIP() []byte {
 	return file_quotes_proto_rawDescData
 }
 
-var file_quotes_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_quotes_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_quotes_proto_goTypes = []any{
-	(*Quote)(nil),                    // 0: hivemind.quotes.Quote
-	(*CreateQuoteRequest)(nil),       // 1: hivemind.quotes.CreateQuoteRequest
-	(*GetQuoteRequest)(nil),          // 2: hivemind.quotes.GetQuoteRequest
-	(*ListQuotesRequest)(nil),        // 3: hivemind.quotes.ListQuotesRequest
-	(*ListQuotesResponse)(nil),       // 4: hivemind.quotes.ListQuotesResponse
-	(*DeleteQuoteRequest)(nil),       // 5: hivemind.quotes.DeleteQuoteRequest
-	(*UpdateQuoteRequest)(nil),       // 6: hivemind.quotes.UpdateQuoteRequest
-	(*SearchQuotesRequest)(nil),      // 7: hivemind.quotes.SearchQuotesRequest
-	(*SearchQuotesResponse)(nil),     // 8: hivemind.quotes.SearchQuotesResponse
-	(*GetRandomQuoteRequest)(nil),    // 9: hivemind.quotes.GetRandomQuoteRequest
-	(*timestamppb.Timestamp)(nil),    // 10: google.protobuf.Timestamp
-	(*commonpb.SuccessResponse)(nil), // 11: hivemind.common.v1.SuccessResponse
+	(*Quote)(nil),                          // 0: hivemind.quotes.Quote
+	(*CreateQuoteRequest)(nil),             // 1: hivemind.quotes.CreateQuoteRequest
+	(*GetQuoteRequest)(nil),                // 2: hivemind.quotes.GetQuoteRequest
+	(*GetQuoteByCodeRequest)(nil),          // 3: hivemind.quotes.GetQuoteByCodeRequest
+	(*GetQuoteBySourceMessageRequest)(nil), // 4: hivemind.quotes.GetQuoteBySourceMessageRequest
+	(*ListQuotesRequest)(nil),              // 5: hivemind.quotes.ListQuotesRequest
+	(*ListQuotesResponse)(nil),             // 6: hivemind.quotes.ListQuotesResponse
+	(*DeleteQuoteRequest)(nil),             // 7: hivemind.quotes.DeleteQuoteRequest
+	(*UpdateQuoteRequest)(nil),             // 8: hivemind.quotes.UpdateQuoteRequest
+	(*SearchQuotesRequest)(nil),            // 9: hivemind.quotes.SearchQuotesRequest
+	(*SearchQuotesResponse)(nil),           // 10: hivemind.quotes.SearchQuotesResponse
+	(*GetRandomQuoteRequest)(nil),          // 11: hivemind.quotes.GetRandomQuoteRequest
+	(*GetQuoteStatsRequest)(nil),           // 12: hivemind.quotes.GetQuoteStatsRequest
+	(*QuoteStatsEntry)(nil),                // 13: hivemind.quotes.QuoteStatsEntry
+	(*GetQuoteStatsResponse)(nil),          // 14: hivemind.quotes.GetQuoteStatsResponse
+	(*timestamppb.Timestamp)(nil),          // 15: google.protobuf.Timestamp
+	(*commonpb.Pagination)(nil),            // 16: hivemind.common.v1.Pagination
+	(*commonpb.SuccessResponse)(nil),       // 17: hivemind.common.v1.SuccessResponse
 }
 var file_quotes_proto_depIdxs = []int32{
-	10, // 0: hivemind.quotes.Quote.created_at:type_name -> google.protobuf.Timestamp
-	10, // 1: hivemind.quotes.Quote.source_msg_timestamp:type_name -> google.protobuf.Timestamp
-	10, // 2: hivemind.quotes.CreateQuoteRequest.source_msg_timestamp:type_name -> google.protobuf.Timestamp
+	15, // 0: hivemind.quotes.Quote.created_at:type_name -> google.protobuf.Timestamp
+	15, // 1: hivemind.quotes.Quote.source_msg_timestamp:type_name -> google.protobuf.Timestamp
+	15, // 2: hivemind.quotes.CreateQuoteRequest.source_msg_timestamp:type_name -> google.protobuf.Timestamp
 	0,  // 3: hivemind.quotes.ListQuotesResponse.quotes:type_name -> hivemind.quotes.Quote
-	0,  // 4: hivemind.quotes.SearchQuotesResponse.quotes:type_name -> hivemind.quotes.Quote
-	1,  // 5: hivemind.quotes.QuoteService.CreateQuote:input_type -> hivemind.quotes.CreateQuoteRequest
-	2,  // 6: hivemind.quotes.QuoteService.GetQuote:input_type -> hivemind.quotes.GetQuoteRequest
-	3,  // 7: hivemind.quotes.QuoteService.ListQuotes:input_type -> hivemind.quotes.ListQuotesRequest
-	5,  // 8: hivemind.quotes.QuoteService.DeleteQuote:input_type -> hivemind.quotes.DeleteQuoteRequest
-	6,  // 9: hivemind.quotes.QuoteService.UpdateQuote:input_type -> hivemind.quotes.UpdateQuoteRequest
-	7,  // 10: hivemind.quotes.QuoteService.SearchQuotes:input_type -> hivemind.quotes.SearchQuotesRequest
-	9,  // 11: hivemind.quotes.QuoteService.GetRandomQuote:input_type -> hivemind.quotes.GetRandomQuoteRequest
-	0,  // 12: hivemind.quotes.QuoteService.CreateQuote:output_type -> hivemind.quotes.Quote
-	0,  // 13: hivemind.quotes.QuoteService.GetQuote:output_type -> hivemind.quotes.Quote
-	4,  // 14: hivemind.quotes.QuoteService.ListQuotes:output_type -> hivemind.quotes.ListQuotesResponse
-	11, // 15: hivemind.quotes.QuoteService.DeleteQuote:output_type -> hivemind.common.v1.SuccessResponse
-	0,  // 16: hivemind.quotes.QuoteService.UpdateQuote:output_type -> hivemind.quotes.Quote
-	8,  // 17: hivemind.quotes.QuoteService.SearchQuotes:output_type -> hivemind.quotes.SearchQuotesResponse
-	0,  // 18: hivemind.quotes.QuoteService.GetRandomQuote:output_type -> hivemind.quotes.Quote
-	12, // [12:19] is the sub-list for method output_type
-	5,  // [5:12] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	16, // 4: hivemind.quotes.ListQuotesResponse.pagination:type_name -> hivemind.common.v1.Pagination
+	0,  // 5: hivemind.quotes.SearchQuotesResponse.quotes:type_name -> hivemind.quotes.Quote
+	16, // 6: hivemind.quotes.SearchQuotesResponse.pagination:type_name -> hivemind.common.v1.Pagination
+	15, // 7: hivemind.quotes.GetQuoteStatsRequest.since:type_name -> google.protobuf.Timestamp
+	13, // 8: hivemind.quotes.GetQuoteStatsResponse.top_quoters:type_name -> hivemind.quotes.QuoteStatsEntry
+	13, // 9: hivemind.quotes.GetQuoteStatsResponse.most_quoted:type_name -> hivemind.quotes.QuoteStatsEntry
+	1,  // 10: hivemind.quotes.QuoteService.CreateQuote:input_type -> hivemind.quotes.CreateQuoteRequest
+	2,  // 11: hivemind.quotes.QuoteService.GetQuote:input_type -> hivemind.quotes.GetQuoteRequest
+	3,  // 12: hivemind.quotes.QuoteService.GetQuoteByCode:input_type -> hivemind.quotes.GetQuoteByCodeRequest
+	4,  // 13: hivemind.quotes.QuoteService.GetQuoteBySourceMessage:input_type -> hivemind.quotes.GetQuoteBySourceMessageRequest
+	5,  // 14: hivemind.quotes.QuoteService.ListQuotes:input_type -> hivemind.quotes.ListQuotesRequest
+	7,  // 15: hivemind.quotes.QuoteService.DeleteQuote:input_type -> hivemind.quotes.DeleteQuoteRequest
+	8,  // 16: hivemind.quotes.QuoteService.UpdateQuote:input_type -> hivemind.quotes.UpdateQuoteRequest
+	9,  // 17: hivemind.quotes.QuoteService.SearchQuotes:input_type -> hivemind.quotes.SearchQuotesRequest
+	11, // 18: hivemind.quotes.QuoteService.GetRandomQuote:input_type -> hivemind.quotes.GetRandomQuoteRequest
+	12, // 19: hivemind.quotes.QuoteService.GetQuoteStats:input_type -> hivemind.quotes.GetQuoteStatsRequest
+	0,  // 20: hivemind.quotes.QuoteService.CreateQuote:output_type -> hivemind.quotes.Quote
+	0,  // 21: hivemind.quotes.QuoteService.GetQuote:output_type -> hivemind.quotes.Quote
+	0,  // 22: hivemind.quotes.QuoteService.GetQuoteByCode:output_type -> hivemind.quotes.Quote
+	0,  // 23: hivemind.quotes.QuoteService.GetQuoteBySourceMessage:output_type -> hivemind.quotes.Quote
+	6,  // 24: hivemind.quotes.QuoteService.ListQuotes:output_type -> hivemind.quotes.ListQuotesResponse
+	17, // 25: hivemind.quotes.QuoteService.DeleteQuote:output_type -> hivemind.common.v1.SuccessResponse
+	0,  // 26: hivemind.quotes.QuoteService.UpdateQuote:output_type -> hivemind.quotes.Quote
+	10, // 27: hivemind.quotes.QuoteService.SearchQuotes:output_type -> hivemind.quotes.SearchQuotesResponse
+	0,  // 28: hivemind.quotes.QuoteService.GetRandomQuote:output_type -> hivemind.quotes.Quote
+	14, // 29: hivemind.quotes.QuoteService.GetQuoteStats:output_type -> hivemind.quotes.GetQuoteStatsResponse
+	20, // [20:30] is the sub-list for method output_type
+	10, // [10:20] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_quotes_proto_init() }
@@ -968,7 +1460,7 @@ func file_quotes_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_quotes_proto_rawDesc), len(file_quotes_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,13 +20,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	QuoteService_CreateQuote_FullMethodName    = "/hivemind.quotes.QuoteService/CreateQuote"
-	QuoteService_GetQuote_FullMethodName       = "/hivemind.quotes.QuoteService/GetQuote"
-	QuoteService_ListQuotes_FullMethodName     = "/hivemind.quotes.QuoteService/ListQuotes"
-	QuoteService_DeleteQuote_FullMethodName    = "/hivemind.quotes.QuoteService/DeleteQuote"
-	QuoteService_UpdateQuote_FullMethodName    = "/hivemind.quotes.QuoteService/UpdateQuote"
-	QuoteService_SearchQuotes_FullMethodName   = "/hivemind.quotes.QuoteService/SearchQuotes"
-	QuoteService_GetRandomQuote_FullMethodName = "/hivemind.quotes.QuoteService/GetRandomQuote"
+	QuoteService_CreateQuote_FullMethodName             = "/hivemind.quotes.QuoteService/CreateQuote"
+	QuoteService_GetQuote_FullMethodName                = "/hivemind.quotes.QuoteService/GetQuote"
+	QuoteService_GetQuoteByCode_FullMethodName          = "/hivemind.quotes.QuoteService/GetQuoteByCode"
+	QuoteService_GetQuoteBySourceMessage_FullMethodName = "/hivemind.quotes.QuoteService/GetQuoteBySourceMessage"
+	QuoteService_ListQuotes_FullMethodName              = "/hivemind.quotes.QuoteService/ListQuotes"
+	QuoteService_DeleteQuote_FullMethodName             = "/hivemind.quotes.QuoteService/DeleteQuote"
+	QuoteService_UpdateQuote_FullMethodName             = "/hivemind.quotes.QuoteService/UpdateQuote"
+	QuoteService_SearchQuotes_FullMethodName            = "/hivemind.quotes.QuoteService/SearchQuotes"
+	QuoteService_GetRandomQuote_FullMethodName          = "/hivemind.quotes.QuoteService/GetRandomQuote"
+	QuoteService_GetQuoteStats_FullMethodName           = "/hivemind.quotes.QuoteService/GetQuoteStats"
 )
 
 // QuoteServiceClient is the client API for QuoteService service.
@@ -39,6 +42,10 @@ type QuoteServiceClient interface {
 	CreateQuote(ctx context.Context, in *CreateQuoteRequest, opts ...grpc.CallOption) (*Quote, error)
 	// GetQuote retrieves a quote by ID
 	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*Quote, error)
+	// GetQuoteByCode retrieves a quote by its permalink short code
+	GetQuoteByCode(ctx context.Context, in *GetQuoteByCodeRequest, opts ...grpc.CallOption) (*Quote, error)
+	// GetQuoteBySourceMessage retrieves the quote saved from a Discord message (NotFound if none)
+	GetQuoteBySourceMessage(ctx context.Context, in *GetQuoteBySourceMessageRequest, opts ...grpc.CallOption) (*Quote, error)
 	// ListQuotes lists quotes in a guild with pagination
 	ListQuotes(ctx context.Context, in *ListQuotesRequest, opts ...grpc.CallOption) (*ListQuotesResponse, error)
 	// DeleteQuote soft-deletes a quote (must be owned by caller or guild admin)
@@ -49,6 +56,9 @@ type QuoteServiceClient interface {
 	SearchQuotes(ctx context.Context, in *SearchQuotesRequest, opts ...grpc.CallOption) (*SearchQuotesResponse, error)
 	// GetRandomQuote retrieves a random quote from a guild
 	GetRandomQuote(ctx context.Context, in *GetRandomQuoteRequest, opts ...grpc.CallOption) (*Quote, error)
+	// GetQuoteStats returns quote totals and leaderboards for a guild
+	// Members who opted out of leaderboards in their preferences are left off them
+	GetQuoteStats(ctx context.Context, in *GetQuoteStatsRequest, opts ...grpc.CallOption) (*GetQuoteStatsResponse, error)
 }
 
 type quoteServiceClient struct {
@@ -79,6 +89,26 @@ func (c *quoteServiceClient) GetQuote(ctx context.Context, in *GetQuoteRequest,
 	return out, nil
 }
 
+func (c *quoteServiceClient) GetQuoteByCode(ctx context.Context, in *GetQuoteByCodeRequest, opts ...grpc.CallOption) (*Quote, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Quote)
+	err := c.cc.Invoke(ctx, QuoteService_GetQuoteByCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quoteServiceClient) GetQuoteBySourceMessage(ctx context.Context, in *GetQuoteBySourceMessageRequest, opts ...grpc.CallOption) (*Quote, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Quote)
+	err := c.cc.Invoke(ctx, QuoteService_GetQuoteBySourceMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *quoteServiceClient) ListQuotes(ctx context.Context, in *ListQuotesRequest, opts ...grpc.CallOption) (*ListQuotesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListQuotesResponse)
@@ -129,6 +159,16 @@ func (c *quoteServiceClient) GetRandomQuote(ctx context.Context, in *GetRandomQu
 	return out, nil
 }
 
+func (c *quoteServiceClient) GetQuoteStats(ctx context.Context, in *GetQuoteStatsRequest, opts ...grpc.CallOption) (*GetQuoteStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuoteStatsResponse)
+	err := c.cc.Invoke(ctx, QuoteService_GetQuoteStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QuoteServiceServer is the server API for QuoteService service.
 // All implementations should embed UnimplementedQuoteServiceServer
 // for forward compatibility.
@@ -139,6 +179,10 @@ type QuoteServiceServer interface {
 	CreateQuote(context.Context, *CreateQuoteRequest) (*Quote, error)
 	// GetQuote retrieves a quote by ID
 	GetQuote(context.Context, *GetQuoteRequest) (*Quote, error)
+	// GetQuoteByCode retrieves a quote by its permalink short code
+	GetQuoteByCode(context.Context, *GetQuoteByCodeRequest) (*Quote, error)
+	// GetQuoteBySourceMessage retrieves the quote saved from a Discord message (NotFound if none)
+	GetQuoteBySourceMessage(context.Context, *GetQuoteBySourceMessageRequest) (*Quote, error)
 	// ListQuotes lists quotes in a guild with pagination
 	ListQuotes(context.Context, *ListQuotesRequest) (*ListQuotesResponse, error)
 	// DeleteQuote soft-deletes a quote (must be owned by caller or guild admin)
@@ -149,6 +193,9 @@ type QuoteServiceServer interface {
 	SearchQuotes(context.Context, *SearchQuotesRequest) (*SearchQuotesResponse, error)
 	// GetRandomQuote retrieves a random quote from a guild
 	GetRandomQuote(context.Context, *GetRandomQuoteRequest) (*Quote, error)
+	// GetQuoteStats returns quote totals and leaderboards for a guild
+	// Members who opted out of leaderboards in their preferences are left off them
+	GetQuoteStats(context.Context, *GetQuoteStatsRequest) (*GetQuoteStatsResponse, error)
 }
 
 // UnimplementedQuoteServiceServer should be embedded to have
@@ -164,6 +211,12 @@ func (UnimplementedQuoteServiceServer) CreateQuote(context.Context, *CreateQuote
 func (UnimplementedQuoteServiceServer) GetQuote(context.Context, *GetQuoteRequest) (*Quote, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetQuote not implemented")
 }
+func (UnimplementedQuoteServiceServer) GetQuoteByCode(context.Context, *GetQuoteByCodeRequest) (*Quote, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetQuoteByCode not implemented")
+}
+func (UnimplementedQuoteServiceServer) GetQuoteBySourceMessage(context.Context, *GetQuoteBySourceMessageRequest) (*Quote, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetQuoteBySourceMessage not implemented")
+}
 func (UnimplementedQuoteServiceServer) ListQuotes(context.Context, *ListQuotesRequest) (*ListQuotesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListQuotes not implemented")
 }
@@ -179,6 +232,9 @@ func (UnimplementedQuoteServiceServer) SearchQuotes(context.Context, *SearchQuot
 func (UnimplementedQuoteServiceServer) GetRandomQuote(context.Context, *GetRandomQuoteRequest) (*Quote, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetRandomQuote not implemented")
 }
+func (UnimplementedQuoteServiceServer) GetQuoteStats(context.Context, *GetQuoteStatsRequest) (*GetQuoteStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetQuoteStats not implemented")
+}
 func (UnimplementedQuoteServiceServer) testEmbeddedByValue() {}
 
 // UnsafeQuoteServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -235,6 +291,42 @@ func _QuoteService_GetQuote_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QuoteService_GetQuoteByCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteByCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuoteServiceServer).GetQuoteByCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuoteService_GetQuoteByCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuoteServiceServer).GetQuoteByCode(ctx, req.(*GetQuoteByCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuoteService_GetQuoteBySourceMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteBySourceMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuoteServiceServer).GetQuoteBySourceMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuoteService_GetQuoteBySourceMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuoteServiceServer).GetQuoteBySourceMessage(ctx, req.(*GetQuoteBySourceMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QuoteService_ListQuotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListQuotesRequest)
 	if err := dec(in); err != nil {
@@ -325,6 +417,24 @@ func _QuoteService_GetRandomQuote_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QuoteService_GetQuoteStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuoteServiceServer).GetQuoteStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuoteService_GetQuoteStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuoteServiceServer).GetQuoteStats(ctx, req.(*GetQuoteStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // QuoteService_ServiceDesc is the grpc.ServiceDesc for QuoteService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -340,6 +450,14 @@ var QuoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetQuote",
 			Handler:    _QuoteService_GetQuote_Handler,
 		},
+		{
+			MethodName: "GetQuoteByCode",
+			Handler:    _QuoteService_GetQuoteByCode_Handler,
+		},
+		{
+			MethodName: "GetQuoteBySourceMessage",
+			Handler:    _QuoteService_GetQuoteBySourceMessage_Handler,
+		},
 		{
 			MethodName: "ListQuotes",
 			Handler:    _QuoteService_ListQuotes_Handler,
@@ -360,6 +478,10 @@ var QuoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRandomQuote",
 			Handler:    _QuoteService_GetRandomQuote_Handler,
 		},
+		{
+			MethodName: "GetQuoteStats",
+			Handler:    _QuoteService_GetQuoteStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "quotes.proto",
@@ -23,6 +23,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// WikiPageStatus is whether a page is a draft or published. Drafts are left out of
+// ListWikiPages, SearchWikiPages, and the activity feed for everyone but their author,
+// and out of AutocompleteWikiTitles and tag suggestions for everyone.
+type WikiPageStatus int32
+
+const (
+	WikiPageStatus_WIKI_PAGE_STATUS_UNSPECIFIED WikiPageStatus = 0 // Treated as published when creating
+	WikiPageStatus_WIKI_PAGE_STATUS_PUBLISHED   WikiPageStatus = 1
+	WikiPageStatus_WIKI_PAGE_STATUS_DRAFT       WikiPageStatus = 2
+)
+
+// Enum value maps for WikiPageStatus.
+var (
+	WikiPageStatus_name = map[int32]string{
+		0: "WIKI_PAGE_STATUS_UNSPECIFIED",
+		1: "WIKI_PAGE_STATUS_PUBLISHED",
+		2: "WIKI_PAGE_STATUS_DRAFT",
+	}
+	WikiPageStatus_value = map[string]int32{
+		"WIKI_PAGE_STATUS_UNSPECIFIED": 0,
+		"WIKI_PAGE_STATUS_PUBLISHED":   1,
+		"WIKI_PAGE_STATUS_DRAFT":       2,
+	}
+)
+
+func (x WikiPageStatus) Enum() *WikiPageStatus {
+	p := new(WikiPageStatus)
+	*p = x
+	return p
+}
+
+func (x WikiPageStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WikiPageStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_wiki_proto_enumTypes[0].Descriptor()
+}
+
+func (WikiPageStatus) Type() protoreflect.EnumType {
+	return &file_wiki_proto_enumTypes[0]
+}
+
+func (x WikiPageStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WikiPageStatus.Descriptor instead.
+func (WikiPageStatus) EnumDescriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{0}
+}
+
 // WikiPage represents a guild knowledge base article
 type WikiPage struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -43,9 +95,24 @@ type WikiPage struct {
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	// URL-friendly slug for the page title
-	Slug          string `protobuf:"bytes,14,opt,name=slug,proto3" json:"slug,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Slug string `protobuf:"bytes,14,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Search result metadata (only populated by SearchWikiPages)
+	Snippet string  `protobuf:"bytes,15,opt,name=snippet,proto3" json:"snippet,omitempty"` // Body excerpt with matches wrapped in **bold**
+	Rank    float32 `protobuf:"fixed32,16,opt,name=rank,proto3" json:"rank,omitempty"`     // Relevance score, higher is better
+	// Pinned pages are listed before all others in ListWikiPages and SearchWikiPages
+	Pinned bool `protobuf:"varint,17,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// Size metadata (only populated by ListWikiPages and SearchWikiPages), set even when
+	// the body is omitted
+	BodyLength     int32 `protobuf:"varint,18,opt,name=body_length,json=bodyLength,proto3" json:"body_length,omitempty"` // Characters in the body
+	TagCount       int32 `protobuf:"varint,19,opt,name=tag_count,json=tagCount,proto3" json:"tag_count,omitempty"`
+	ReferenceCount int32 `protobuf:"varint,20,opt,name=reference_count,json=referenceCount,proto3" json:"reference_count,omitempty"` // Discord messages added to the page
+	// Who last updated the page; empty if it hasn't been edited since it was created.
+	// The username is only populated by GetWikiPage and GetWikiPageByTitle.
+	LastEditorId       string         `protobuf:"bytes,21,opt,name=last_editor_id,json=lastEditorId,proto3" json:"last_editor_id,omitempty"`
+	LastEditorUsername string         `protobuf:"bytes,22,opt,name=last_editor_username,json=lastEditorUsername,proto3" json:"last_editor_username,omitempty"`
+	Status             WikiPageStatus `protobuf:"varint,23,opt,name=status,proto3,enum=hivemind.wiki.WikiPageStatus" json:"status,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *WikiPage) Reset() {
@@ -176,6 +243,69 @@ func (x *WikiPage) GetSlug() string {
 	return ""
 }
 
+func (x *WikiPage) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *WikiPage) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *WikiPage) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *WikiPage) GetBodyLength() int32 {
+	if x != nil {
+		return x.BodyLength
+	}
+	return 0
+}
+
+func (x *WikiPage) GetTagCount() int32 {
+	if x != nil {
+		return x.TagCount
+	}
+	return 0
+}
+
+func (x *WikiPage) GetReferenceCount() int32 {
+	if x != nil {
+		return x.ReferenceCount
+	}
+	return 0
+}
+
+func (x *WikiPage) GetLastEditorId() string {
+	if x != nil {
+		return x.LastEditorId
+	}
+	return ""
+}
+
+func (x *WikiPage) GetLastEditorUsername() string {
+	if x != nil {
+		return x.LastEditorUsername
+	}
+	return ""
+}
+
+func (x *WikiPage) GetStatus() WikiPageStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WikiPageStatus_WIKI_PAGE_STATUS_UNSPECIFIED
+}
+
 type CreateWikiPageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
@@ -183,6 +313,8 @@ type CreateWikiPageRequest struct {
 	GuildId       string                 `protobuf:"bytes,3,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
 	ChannelId     string                 `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"` // Optional: channel where created
 	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	PreserveId    string                 `protobuf:"bytes,6,opt,name=preserve_id,json=preserveId,proto3" json:"preserve_id,omitempty"`          // Admin-only: use this ID instead of generating one (imports)
+	Status        WikiPageStatus         `protobuf:"varint,7,opt,name=status,proto3,enum=hivemind.wiki.WikiPageStatus" json:"status,omitempty"` // Optional: DRAFT to save the page as a draft (default: published)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -252,6 +384,20 @@ func (x *CreateWikiPageRequest) GetTags() []string {
 	return nil
 }
 
+func (x *CreateWikiPageRequest) GetPreserveId() string {
+	if x != nil {
+		return x.PreserveId
+	}
+	return ""
+}
+
+func (x *CreateWikiPageRequest) GetStatus() WikiPageStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WikiPageStatus_WIKI_PAGE_STATUS_UNSPECIFIED
+}
+
 type GetWikiPageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -349,14 +495,17 @@ func (x *GetWikiPageByTitleRequest) GetTitle() string {
 }
 
 type SearchWikiPagesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
-	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`  // Full-text search query
-	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`    // Filter by tags
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // Default: 10
-	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	GuildId         string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Empty with no guild_ids searches every guild the caller is a member of (admins: every guild)
+	Query           string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`                    // Full-text search query
+	Tags            []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`                      // Filter by tags
+	Limit           int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                   // Default: 10
+	Offset          int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	AuthorDiscordId string                 `protobuf:"bytes,6,opt,name=author_discord_id,json=authorDiscordId,proto3" json:"author_discord_id,omitempty"` // Optional: only pages written by this Discord user
+	OmitBody        bool                   `protobuf:"varint,7,opt,name=omit_body,json=omitBody,proto3" json:"omit_body,omitempty"`                       // Leave body empty in the results; the snippet and size metadata are still set
+	GuildIds        []string               `protobuf:"bytes,8,rep,name=guild_ids,json=guildIds,proto3" json:"guild_ids,omitempty"`                        // Optional: search these guilds; ignored when guild_id is set
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *SearchWikiPagesRequest) Reset() {
@@ -424,10 +573,33 @@ func (x *SearchWikiPagesRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *SearchWikiPagesRequest) GetAuthorDiscordId() string {
+	if x != nil {
+		return x.AuthorDiscordId
+	}
+	return ""
+}
+
+func (x *SearchWikiPagesRequest) GetOmitBody() bool {
+	if x != nil {
+		return x.OmitBody
+	}
+	return false
+}
+
+func (x *SearchWikiPagesRequest) GetGuildIds() []string {
+	if x != nil {
+		return x.GuildIds
+	}
+	return nil
+}
+
 type SearchWikiPagesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Pages         []*WikiPage            `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"`
 	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // Page size used, after applying the server's default and maximum
+	Pagination    *commonpb.Pagination   `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -476,6 +648,20 @@ func (x *SearchWikiPagesResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *SearchWikiPagesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchWikiPagesResponse) GetPagination() *commonpb.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
 type UpdateWikiPageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -551,6 +737,8 @@ type UpsertWikiPageRequest struct {
 	GuildId       string                 `protobuf:"bytes,3,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
 	ChannelId     string                 `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"` // Optional: channel where created/updated
 	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	PreserveId    string                 `protobuf:"bytes,6,opt,name=preserve_id,json=preserveId,proto3" json:"preserve_id,omitempty"`          // Admin-only: use this ID instead of generating one (imports; ignored when updating)
+	Status        WikiPageStatus         `protobuf:"varint,7,opt,name=status,proto3,enum=hivemind.wiki.WikiPageStatus" json:"status,omitempty"` // Optional: DRAFT to create the page as a draft (ignored when updating; default: published)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -620,6 +808,20 @@ func (x *UpsertWikiPageRequest) GetTags() []string {
 	return nil
 }
 
+func (x *UpsertWikiPageRequest) GetPreserveId() string {
+	if x != nil {
+		return x.PreserveId
+	}
+	return ""
+}
+
+func (x *UpsertWikiPageRequest) GetStatus() WikiPageStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WikiPageStatus_WIKI_PAGE_STATUS_UNSPECIFIED
+}
+
 type UpsertWikiPageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Page          *WikiPage              `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
@@ -719,10 +921,12 @@ func (x *DeleteWikiPageRequest) GetId() string {
 type ListWikiPagesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Default: 50
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                   // Default: 50
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`                 // Kept for older clients; prefer page_token, which doesn't skip or repeat pages as content changes
 	OrderBy       string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"` // "created_at", "updated_at", "title"
 	Ascending     bool                   `protobuf:"varint,5,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Optional: next_page_token from the previous response; overrides offset
+	OmitBody      bool                   `protobuf:"varint,7,opt,name=omit_body,json=omitBody,proto3" json:"omit_body,omitempty"`   // Leave body empty in the results; the size metadata is still set
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -792,10 +996,27 @@ func (x *ListWikiPagesRequest) GetAscending() bool {
 	return false
 }
 
+func (x *ListWikiPagesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListWikiPagesRequest) GetOmitBody() bool {
+	if x != nil {
+		return x.OmitBody
+	}
+	return false
+}
+
 type ListWikiPagesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Pages         []*WikiPage            `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"`
 	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                                       // Page size used, after applying the server's default and maximum
+	Pagination    *commonpb.Pagination   `protobuf:"bytes,5,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -844,6 +1065,27 @@ func (x *ListWikiPagesResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *ListWikiPagesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListWikiPagesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListWikiPagesResponse) GetPagination() *commonpb.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
 type AutocompleteWikiTitlesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"` // Required: guild context
@@ -1384,27 +1626,28 @@ func (x *AddWikiMessageReferenceRequest) GetAttachments() []*AttachmentMetadata
 	return nil
 }
 
-type ListWikiMessageReferencesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WikiPageId    string                 `protobuf:"bytes,1,opt,name=wiki_page_id,json=wikiPageId,proto3" json:"wiki_page_id,omitempty"`
+type AddWikiMessageReferencesBatchRequest struct {
+	state         protoimpl.MessageState            `protogen:"open.v1"`
+	WikiPageId    string                            `protobuf:"bytes,1,opt,name=wiki_page_id,json=wikiPageId,proto3" json:"wiki_page_id,omitempty"`
+	References    []*AddWikiMessageReferenceRequest `protobuf:"bytes,2,rep,name=references,proto3" json:"references,omitempty"` // wiki_page_id of each reference is ignored
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListWikiMessageReferencesRequest) Reset() {
-	*x = ListWikiMessageReferencesRequest{}
+func (x *AddWikiMessageReferencesBatchRequest) Reset() {
+	*x = AddWikiMessageReferencesBatchRequest{}
 	mi := &file_wiki_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListWikiMessageReferencesRequest) String() string {
+func (x *AddWikiMessageReferencesBatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListWikiMessageReferencesRequest) ProtoMessage() {}
+func (*AddWikiMessageReferencesBatchRequest) ProtoMessage() {}
 
-func (x *ListWikiMessageReferencesRequest) ProtoReflect() protoreflect.Message {
+func (x *AddWikiMessageReferencesBatchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_wiki_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1416,39 +1659,48 @@ func (x *ListWikiMessageReferencesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListWikiMessageReferencesRequest.ProtoReflect.Descriptor instead.
-func (*ListWikiMessageReferencesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddWikiMessageReferencesBatchRequest.ProtoReflect.Descriptor instead.
+func (*AddWikiMessageReferencesBatchRequest) Descriptor() ([]byte, []int) {
 	return file_wiki_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ListWikiMessageReferencesRequest) GetWikiPageId() string {
+func (x *AddWikiMessageReferencesBatchRequest) GetWikiPageId() string {
 	if x != nil {
 		return x.WikiPageId
 	}
 	return ""
 }
 
-type ListWikiMessageReferencesResponse struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	References    []*WikiMessageReference `protobuf:"bytes,1,rep,name=references,proto3" json:"references,omitempty"`
+func (x *AddWikiMessageReferencesBatchRequest) GetReferences() []*AddWikiMessageReferenceRequest {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+type AddWikiMessageReferencesBatchResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Added         int32                          `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Skipped       int32                          `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // Already referenced by the page, or repeated in the request
+	Invalid       []*InvalidWikiMessageReference `protobuf:"bytes,3,rep,name=invalid,proto3" json:"invalid,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListWikiMessageReferencesResponse) Reset() {
-	*x = ListWikiMessageReferencesResponse{}
+func (x *AddWikiMessageReferencesBatchResponse) Reset() {
+	*x = AddWikiMessageReferencesBatchResponse{}
 	mi := &file_wiki_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListWikiMessageReferencesResponse) String() string {
+func (x *AddWikiMessageReferencesBatchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListWikiMessageReferencesResponse) ProtoMessage() {}
+func (*AddWikiMessageReferencesBatchResponse) ProtoMessage() {}
 
-func (x *ListWikiMessageReferencesResponse) ProtoReflect() protoreflect.Message {
+func (x *AddWikiMessageReferencesBatchResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_wiki_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1460,40 +1712,56 @@ func (x *ListWikiMessageReferencesResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListWikiMessageReferencesResponse.ProtoReflect.Descriptor instead.
-func (*ListWikiMessageReferencesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddWikiMessageReferencesBatchResponse.ProtoReflect.Descriptor instead.
+func (*AddWikiMessageReferencesBatchResponse) Descriptor() ([]byte, []int) {
 	return file_wiki_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *ListWikiMessageReferencesResponse) GetReferences() []*WikiMessageReference {
+func (x *AddWikiMessageReferencesBatchResponse) GetAdded() int32 {
 	if x != nil {
-		return x.References
+		return x.Added
+	}
+	return 0
+}
+
+func (x *AddWikiMessageReferencesBatchResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *AddWikiMessageReferencesBatchResponse) GetInvalid() []*InvalidWikiMessageReference {
+	if x != nil {
+		return x.Invalid
 	}
 	return nil
 }
 
-type MergeWikiPagesRequest struct {
+// InvalidWikiMessageReference is a reference from a batch that was rejected and not added
+type InvalidWikiMessageReference struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SourcePageId  string                 `protobuf:"bytes,1,opt,name=source_page_id,json=sourcePageId,proto3" json:"source_page_id,omitempty"` // Page to merge from (will be soft-deleted)
-	TargetPageId  string                 `protobuf:"bytes,2,opt,name=target_page_id,json=targetPageId,proto3" json:"target_page_id,omitempty"` // Page to merge into (will receive combined content)
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // Position in the request's references
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MergeWikiPagesRequest) Reset() {
-	*x = MergeWikiPagesRequest{}
+func (x *InvalidWikiMessageReference) Reset() {
+	*x = InvalidWikiMessageReference{}
 	mi := &file_wiki_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MergeWikiPagesRequest) String() string {
+func (x *InvalidWikiMessageReference) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MergeWikiPagesRequest) ProtoMessage() {}
+func (*InvalidWikiMessageReference) ProtoMessage() {}
 
-func (x *MergeWikiPagesRequest) ProtoReflect() protoreflect.Message {
+func (x *InvalidWikiMessageReference) ProtoReflect() protoreflect.Message {
 	mi := &file_wiki_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1505,98 +1773,940 @@ func (x *MergeWikiPagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MergeWikiPagesRequest.ProtoReflect.Descriptor instead.
-func (*MergeWikiPagesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use InvalidWikiMessageReference.ProtoReflect.Descriptor instead.
+func (*InvalidWikiMessageReference) Descriptor() ([]byte, []int) {
 	return file_wiki_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *MergeWikiPagesRequest) GetSourcePageId() string {
+func (x *InvalidWikiMessageReference) GetIndex() int32 {
 	if x != nil {
-		return x.SourcePageId
+		return x.Index
+	}
+	return 0
+}
+
+func (x *InvalidWikiMessageReference) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
 	}
 	return ""
 }
 
-func (x *MergeWikiPagesRequest) GetTargetPageId() string {
+func (x *InvalidWikiMessageReference) GetReason() string {
 	if x != nil {
-		return x.TargetPageId
+		return x.Reason
 	}
 	return ""
 }
 
-var File_wiki_proto protoreflect.FileDescriptor
+type ListWikiMessageReferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WikiPageId    string                 `protobuf:"bytes,1,opt,name=wiki_page_id,json=wikiPageId,proto3" json:"wiki_page_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Default: all references
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_wiki_proto_rawDesc = "" +
-	"\n" +
-	"\n" +
-	"wiki.proto\x12\rhivemind.wiki\x1a\fcommon.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcc\x03\n" +
-	"\bWikiPage\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
-	"\x04body\x18\x03 \x01(\tR\x04body\x12\x1b\n" +
-	"\tauthor_id\x18\x04 \x01(\tR\bauthorId\x12'\n" +
-	"\x0fauthor_username\x18\x05 \x01(\tR\x0eauthorUsername\x12\x19\n" +
-	"\bguild_id\x18\x06 \x01(\tR\aguildId\x12\x1d\n" +
-	"\n" +
-	"guild_name\x18\a \x01(\tR\tguildName\x12\x1d\n" +
-	"\n" +
-	"channel_id\x18\b \x01(\tR\tchannelId\x12!\n" +
-	"\fchannel_name\x18\t \x01(\tR\vchannelName\x12\x12\n" +
-	"\x04tags\x18\n" +
-	" \x03(\tR\x04tags\x12&\n" +
-	"\x0flinked_page_ids\x18\v \x03(\tR\rlinkedPageIds\x129\n" +
-	"\n" +
-	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
-	"\x04slug\x18\x0e \x01(\tR\x04slug\"\x8f\x01\n" +
-	"\x15CreateWikiPageRequest\x12\x14\n" +
-	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
-	"\x04body\x18\x02 \x01(\tR\x04body\x12\x19\n" +
-	"\bguild_id\x18\x03 \x01(\tR\aguildId\x12\x1d\n" +
-	"\n" +
-	"channel_id\x18\x04 \x01(\tR\tchannelId\x12\x12\n" +
-	"\x04tags\x18\x05 \x03(\tR\x04tags\"$\n" +
-	"\x12GetWikiPageRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"L\n" +
-	"\x19GetWikiPageByTitleRequest\x12\x19\n" +
-	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\"\x8b\x01\n" +
-	"\x16SearchWikiPagesRequest\x12\x19\n" +
-	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x14\n" +
-	"\x05query\x18\x02 \x01(\tR\x05query\x12\x12\n" +
-	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x05 \x01(\x05R\x06offset\"^\n" +
-	"\x17SearchWikiPagesResponse\x12-\n" +
-	"\x05pages\x18\x01 \x03(\v2\x17.hivemind.wiki.WikiPageR\x05pages\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"e\n" +
-	"\x15UpdateWikiPageRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
-	"\x04body\x18\x03 \x01(\tR\x04body\x12\x12\n" +
-	"\x04tags\x18\x04 \x03(\tR\x04tags\"\x8f\x01\n" +
-	"\x15UpsertWikiPageRequest\x12\x14\n" +
-	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
-	"\x04body\x18\x02 \x01(\tR\x04body\x12\x19\n" +
+func (x *ListWikiMessageReferencesRequest) Reset() {
+	*x = ListWikiMessageReferencesRequest{}
+	mi := &file_wiki_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWikiMessageReferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWikiMessageReferencesRequest) ProtoMessage() {}
+
+func (x *ListWikiMessageReferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWikiMessageReferencesRequest.ProtoReflect.Descriptor instead.
+func (*ListWikiMessageReferencesRequest) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListWikiMessageReferencesRequest) GetWikiPageId() string {
+	if x != nil {
+		return x.WikiPageId
+	}
+	return ""
+}
+
+func (x *ListWikiMessageReferencesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListWikiMessageReferencesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListWikiMessageReferencesResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	References    []*WikiMessageReference `protobuf:"bytes,1,rep,name=references,proto3" json:"references,omitempty"`
+	Total         int32                   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Total references, regardless of limit/offset
+	Pagination    *commonpb.Pagination    `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWikiMessageReferencesResponse) Reset() {
+	*x = ListWikiMessageReferencesResponse{}
+	mi := &file_wiki_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWikiMessageReferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWikiMessageReferencesResponse) ProtoMessage() {}
+
+func (x *ListWikiMessageReferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWikiMessageReferencesResponse.ProtoReflect.Descriptor instead.
+func (*ListWikiMessageReferencesResponse) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListWikiMessageReferencesResponse) GetReferences() []*WikiMessageReference {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+func (x *ListWikiMessageReferencesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListWikiMessageReferencesResponse) GetPagination() *commonpb.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type MergeWikiPagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourcePageId  string                 `protobuf:"bytes,1,opt,name=source_page_id,json=sourcePageId,proto3" json:"source_page_id,omitempty"` // Page to merge from (will be soft-deleted)
+	TargetPageId  string                 `protobuf:"bytes,2,opt,name=target_page_id,json=targetPageId,proto3" json:"target_page_id,omitempty"` // Page to merge into (will receive combined content)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeWikiPagesRequest) Reset() {
+	*x = MergeWikiPagesRequest{}
+	mi := &file_wiki_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeWikiPagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeWikiPagesRequest) ProtoMessage() {}
+
+func (x *MergeWikiPagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeWikiPagesRequest.ProtoReflect.Descriptor instead.
+func (*MergeWikiPagesRequest) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *MergeWikiPagesRequest) GetSourcePageId() string {
+	if x != nil {
+		return x.SourcePageId
+	}
+	return ""
+}
+
+func (x *MergeWikiPagesRequest) GetTargetPageId() string {
+	if x != nil {
+		return x.TargetPageId
+	}
+	return ""
+}
+
+type PreviewWikiMergeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourcePageId  string                 `protobuf:"bytes,1,opt,name=source_page_id,json=sourcePageId,proto3" json:"source_page_id,omitempty"` // Page that would be merged away
+	TargetPageId  string                 `protobuf:"bytes,2,opt,name=target_page_id,json=targetPageId,proto3" json:"target_page_id,omitempty"` // Page that would receive the combined content
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewWikiMergeRequest) Reset() {
+	*x = PreviewWikiMergeRequest{}
+	mi := &file_wiki_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewWikiMergeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewWikiMergeRequest) ProtoMessage() {}
+
+func (x *PreviewWikiMergeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewWikiMergeRequest.ProtoReflect.Descriptor instead.
+func (*PreviewWikiMergeRequest) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PreviewWikiMergeRequest) GetSourcePageId() string {
+	if x != nil {
+		return x.SourcePageId
+	}
+	return ""
+}
+
+func (x *PreviewWikiMergeRequest) GetTargetPageId() string {
+	if x != nil {
+		return x.TargetPageId
+	}
+	return ""
+}
+
+type PreviewWikiMergeResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Page           *WikiPage              `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`                                            // Merged target page, not saved
+	SourcePage     *WikiPage              `protobuf:"bytes,2,opt,name=source_page,json=sourcePage,proto3" json:"source_page,omitempty"`              // Source page as it is now
+	ReferenceCount int32                  `protobuf:"varint,3,opt,name=reference_count,json=referenceCount,proto3" json:"reference_count,omitempty"` // Distinct messages referenced by the merged page
+	TitleCount     int32                  `protobuf:"varint,4,opt,name=title_count,json=titleCount,proto3" json:"title_count,omitempty"`             // Titles and aliases that will point at the merged page
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PreviewWikiMergeResponse) Reset() {
+	*x = PreviewWikiMergeResponse{}
+	mi := &file_wiki_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewWikiMergeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewWikiMergeResponse) ProtoMessage() {}
+
+func (x *PreviewWikiMergeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewWikiMergeResponse.ProtoReflect.Descriptor instead.
+func (*PreviewWikiMergeResponse) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PreviewWikiMergeResponse) GetPage() *WikiPage {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *PreviewWikiMergeResponse) GetSourcePage() *WikiPage {
+	if x != nil {
+		return x.SourcePage
+	}
+	return nil
+}
+
+func (x *PreviewWikiMergeResponse) GetReferenceCount() int32 {
+	if x != nil {
+		return x.ReferenceCount
+	}
+	return 0
+}
+
+func (x *PreviewWikiMergeResponse) GetTitleCount() int32 {
+	if x != nil {
+		return x.TitleCount
+	}
+	return 0
+}
+
+type UnmergeWikiPagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourcePageId  string                 `protobuf:"bytes,1,opt,name=source_page_id,json=sourcePageId,proto3" json:"source_page_id,omitempty"` // Page that was merged away (will be restored)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmergeWikiPagesRequest) Reset() {
+	*x = UnmergeWikiPagesRequest{}
+	mi := &file_wiki_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmergeWikiPagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmergeWikiPagesRequest) ProtoMessage() {}
+
+func (x *UnmergeWikiPagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmergeWikiPagesRequest.ProtoReflect.Descriptor instead.
+func (*UnmergeWikiPagesRequest) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UnmergeWikiPagesRequest) GetSourcePageId() string {
+	if x != nil {
+		return x.SourcePageId
+	}
+	return ""
+}
+
+type UnmergeWikiPagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourcePage    *WikiPage              `protobuf:"bytes,1,opt,name=source_page,json=sourcePage,proto3" json:"source_page,omitempty"` // Restored source page
+	TargetPage    *WikiPage              `protobuf:"bytes,2,opt,name=target_page,json=targetPage,proto3" json:"target_page,omitempty"` // Target page with its pre-merge content
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmergeWikiPagesResponse) Reset() {
+	*x = UnmergeWikiPagesResponse{}
+	mi := &file_wiki_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmergeWikiPagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmergeWikiPagesResponse) ProtoMessage() {}
+
+func (x *UnmergeWikiPagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmergeWikiPagesResponse.ProtoReflect.Descriptor instead.
+func (*UnmergeWikiPagesResponse) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UnmergeWikiPagesResponse) GetSourcePage() *WikiPage {
+	if x != nil {
+		return x.SourcePage
+	}
+	return nil
+}
+
+func (x *UnmergeWikiPagesResponse) GetTargetPage() *WikiPage {
+	if x != nil {
+		return x.TargetPage
+	}
+	return nil
+}
+
+type SetWikiPagePinnedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Pinned        bool                   `protobuf:"varint,2,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWikiPagePinnedRequest) Reset() {
+	*x = SetWikiPagePinnedRequest{}
+	mi := &file_wiki_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWikiPagePinnedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWikiPagePinnedRequest) ProtoMessage() {}
+
+func (x *SetWikiPagePinnedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWikiPagePinnedRequest.ProtoReflect.Descriptor instead.
+func (*SetWikiPagePinnedRequest) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetWikiPagePinnedRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetWikiPagePinnedRequest) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+type PublishWikiPageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishWikiPageRequest) Reset() {
+	*x = PublishWikiPageRequest{}
+	mi := &file_wiki_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishWikiPageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishWikiPageRequest) ProtoMessage() {}
+
+func (x *PublishWikiPageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishWikiPageRequest.ProtoReflect.Descriptor instead.
+func (*PublishWikiPageRequest) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PublishWikiPageRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListWikiDraftsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWikiDraftsRequest) Reset() {
+	*x = ListWikiDraftsRequest{}
+	mi := &file_wiki_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWikiDraftsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWikiDraftsRequest) ProtoMessage() {}
+
+func (x *ListWikiDraftsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWikiDraftsRequest.ProtoReflect.Descriptor instead.
+func (*ListWikiDraftsRequest) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListWikiDraftsRequest) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+type ListWikiDraftsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pages         []*WikiPage            `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"` // Bodies are omitted; size metadata is set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWikiDraftsResponse) Reset() {
+	*x = ListWikiDraftsResponse{}
+	mi := &file_wiki_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWikiDraftsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWikiDraftsResponse) ProtoMessage() {}
+
+func (x *ListWikiDraftsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWikiDraftsResponse.ProtoReflect.Descriptor instead.
+func (*ListWikiDraftsResponse) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListWikiDraftsResponse) GetPages() []*WikiPage {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+type TransferWikiPageOwnershipRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	NewOwnerDiscordId string                 `protobuf:"bytes,2,opt,name=new_owner_discord_id,json=newOwnerDiscordId,proto3" json:"new_owner_discord_id,omitempty"` // Must have a linked account and be a member of the page's guild
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TransferWikiPageOwnershipRequest) Reset() {
+	*x = TransferWikiPageOwnershipRequest{}
+	mi := &file_wiki_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferWikiPageOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferWikiPageOwnershipRequest) ProtoMessage() {}
+
+func (x *TransferWikiPageOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferWikiPageOwnershipRequest.ProtoReflect.Descriptor instead.
+func (*TransferWikiPageOwnershipRequest) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *TransferWikiPageOwnershipRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TransferWikiPageOwnershipRequest) GetNewOwnerDiscordId() string {
+	if x != nil {
+		return x.NewOwnerDiscordId
+	}
+	return ""
+}
+
+type SuggestTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       string                 `protobuf:"bytes,1,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
+	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	ExcludeTags   []string               `protobuf:"bytes,3,rep,name=exclude_tags,json=excludeTags,proto3" json:"exclude_tags,omitempty"`         // Tags the content already has
+	ExcludePageId string                 `protobuf:"bytes,4,opt,name=exclude_page_id,json=excludePageId,proto3" json:"exclude_page_id,omitempty"` // Optional: the page being tagged, so its own tags aren't suggested
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                                       // Default 5, max 10
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestTagsRequest) Reset() {
+	*x = SuggestTagsRequest{}
+	mi := &file_wiki_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestTagsRequest) ProtoMessage() {}
+
+func (x *SuggestTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestTagsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestTagsRequest) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SuggestTagsRequest) GetGuildId() string {
+	if x != nil {
+		return x.GuildId
+	}
+	return ""
+}
+
+func (x *SuggestTagsRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *SuggestTagsRequest) GetExcludeTags() []string {
+	if x != nil {
+		return x.ExcludeTags
+	}
+	return nil
+}
+
+func (x *SuggestTagsRequest) GetExcludePageId() string {
+	if x != nil {
+		return x.ExcludePageId
+	}
+	return ""
+}
+
+func (x *SuggestTagsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TagSuggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Score         float32                `protobuf:"fixed32,2,opt,name=score,proto3" json:"score,omitempty"`                         // Higher is a better fit
+	PageCount     int32                  `protobuf:"varint,3,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"` // Similar pages that use the tag
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagSuggestion) Reset() {
+	*x = TagSuggestion{}
+	mi := &file_wiki_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagSuggestion) ProtoMessage() {}
+
+func (x *TagSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagSuggestion.ProtoReflect.Descriptor instead.
+func (*TagSuggestion) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TagSuggestion) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *TagSuggestion) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *TagSuggestion) GetPageCount() int32 {
+	if x != nil {
+		return x.PageCount
+	}
+	return 0
+}
+
+type SuggestTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*TagSuggestion       `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"` // Best first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestTagsResponse) Reset() {
+	*x = SuggestTagsResponse{}
+	mi := &file_wiki_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestTagsResponse) ProtoMessage() {}
+
+func (x *SuggestTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wiki_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestTagsResponse.ProtoReflect.Descriptor instead.
+func (*SuggestTagsResponse) Descriptor() ([]byte, []int) {
+	return file_wiki_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SuggestTagsResponse) GetSuggestions() []*TagSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+var File_wiki_proto protoreflect.FileDescriptor
+
+const file_wiki_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"wiki.proto\x12\rhivemind.wiki\x1a\fcommon.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x88\x06\n" +
+	"\bWikiPage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x12\x1b\n" +
+	"\tauthor_id\x18\x04 \x01(\tR\bauthorId\x12'\n" +
+	"\x0fauthor_username\x18\x05 \x01(\tR\x0eauthorUsername\x12\x19\n" +
+	"\bguild_id\x18\x06 \x01(\tR\aguildId\x12\x1d\n" +
+	"\n" +
+	"guild_name\x18\a \x01(\tR\tguildName\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\b \x01(\tR\tchannelId\x12!\n" +
+	"\fchannel_name\x18\t \x01(\tR\vchannelName\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12&\n" +
+	"\x0flinked_page_ids\x18\v \x03(\tR\rlinkedPageIds\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04slug\x18\x0e \x01(\tR\x04slug\x12\x18\n" +
+	"\asnippet\x18\x0f \x01(\tR\asnippet\x12\x12\n" +
+	"\x04rank\x18\x10 \x01(\x02R\x04rank\x12\x16\n" +
+	"\x06pinned\x18\x11 \x01(\bR\x06pinned\x12\x1f\n" +
+	"\vbody_length\x18\x12 \x01(\x05R\n" +
+	"bodyLength\x12\x1b\n" +
+	"\ttag_count\x18\x13 \x01(\x05R\btagCount\x12'\n" +
+	"\x0freference_count\x18\x14 \x01(\x05R\x0ereferenceCount\x12$\n" +
+	"\x0elast_editor_id\x18\x15 \x01(\tR\flastEditorId\x120\n" +
+	"\x14last_editor_username\x18\x16 \x01(\tR\x12lastEditorUsername\x125\n" +
+	"\x06status\x18\x17 \x01(\x0e2\x1d.hivemind.wiki.WikiPageStatusR\x06status\"\xe7\x01\n" +
+	"\x15CreateWikiPageRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\x12\x19\n" +
+	"\bguild_id\x18\x03 \x01(\tR\aguildId\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x04 \x01(\tR\tchannelId\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1f\n" +
+	"\vpreserve_id\x18\x06 \x01(\tR\n" +
+	"preserveId\x125\n" +
+	"\x06status\x18\a \x01(\x0e2\x1d.hivemind.wiki.WikiPageStatusR\x06status\"$\n" +
+	"\x12GetWikiPageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"L\n" +
+	"\x19GetWikiPageByTitleRequest\x12\x19\n" +
+	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\"\xf1\x01\n" +
+	"\x16SearchWikiPagesRequest\x12\x19\n" +
+	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12*\n" +
+	"\x11author_discord_id\x18\x06 \x01(\tR\x0fauthorDiscordId\x12\x1b\n" +
+	"\tomit_body\x18\a \x01(\bR\bomitBody\x12\x1b\n" +
+	"\tguild_ids\x18\b \x03(\tR\bguildIds\"\xb4\x01\n" +
+	"\x17SearchWikiPagesResponse\x12-\n" +
+	"\x05pages\x18\x01 \x03(\v2\x17.hivemind.wiki.WikiPageR\x05pages\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12>\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1e.hivemind.common.v1.PaginationR\n" +
+	"pagination\"e\n" +
+	"\x15UpdateWikiPageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\"\xe7\x01\n" +
+	"\x15UpsertWikiPageRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\x12\x19\n" +
 	"\bguild_id\x18\x03 \x01(\tR\aguildId\x12\x1d\n" +
 	"\n" +
 	"channel_id\x18\x04 \x01(\tR\tchannelId\x12\x12\n" +
-	"\x04tags\x18\x05 \x03(\tR\x04tags\"_\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1f\n" +
+	"\vpreserve_id\x18\x06 \x01(\tR\n" +
+	"preserveId\x125\n" +
+	"\x06status\x18\a \x01(\x0e2\x1d.hivemind.wiki.WikiPageStatusR\x06status\"_\n" +
 	"\x16UpsertWikiPageResponse\x12+\n" +
 	"\x04page\x18\x01 \x01(\v2\x17.hivemind.wiki.WikiPageR\x04page\x12\x18\n" +
 	"\acreated\x18\x02 \x01(\bR\acreated\"'\n" +
 	"\x15DeleteWikiPageRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x98\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xd4\x01\n" +
 	"\x14ListWikiPagesRequest\x12\x19\n" +
 	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x19\n" +
 	"\border_by\x18\x04 \x01(\tR\aorderBy\x12\x1c\n" +
-	"\tascending\x18\x05 \x01(\bR\tascending\"\\\n" +
+	"\tascending\x18\x05 \x01(\bR\tascending\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x12\x1b\n" +
+	"\tomit_body\x18\a \x01(\bR\bomitBody\"\xda\x01\n" +
 	"\x15ListWikiPagesResponse\x12-\n" +
 	"\x05pages\x18\x01 \x03(\v2\x17.hivemind.wiki.WikiPageR\x05pages\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\":\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12>\n" +
+	"\n" +
+	"pagination\x18\x05 \x01(\v2\x1e.hivemind.common.v1.PaginationR\n" +
+	"pagination\":\n" +
 	"\x1dAutocompleteWikiTitlesRequest\x12\x19\n" +
 	"\bguild_id\x18\x01 \x01(\tR\aguildId\"f\n" +
 	"\x1eAutocompleteWikiTitlesResponse\x12D\n" +
@@ -1649,17 +2759,84 @@ const file_wiki_proto_rawDesc = "" +
 	"\x11message_timestamp\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x10messageTimestamp\x12'\n" +
 	"\x0fattachment_urls\x18\n" +
 	" \x03(\tR\x0eattachmentUrls\x12C\n" +
-	"\vattachments\x18\v \x03(\v2!.hivemind.wiki.AttachmentMetadataR\vattachments\"D\n" +
+	"\vattachments\x18\v \x03(\v2!.hivemind.wiki.AttachmentMetadataR\vattachments\"\x97\x01\n" +
+	"$AddWikiMessageReferencesBatchRequest\x12 \n" +
+	"\fwiki_page_id\x18\x01 \x01(\tR\n" +
+	"wikiPageId\x12M\n" +
+	"\n" +
+	"references\x18\x02 \x03(\v2-.hivemind.wiki.AddWikiMessageReferenceRequestR\n" +
+	"references\"\x9d\x01\n" +
+	"%AddWikiMessageReferencesBatchResponse\x12\x14\n" +
+	"\x05added\x18\x01 \x01(\x05R\x05added\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x05R\askipped\x12D\n" +
+	"\ainvalid\x18\x03 \x03(\v2*.hivemind.wiki.InvalidWikiMessageReferenceR\ainvalid\"j\n" +
+	"\x1bInvalidWikiMessageReference\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"r\n" +
 	" ListWikiMessageReferencesRequest\x12 \n" +
 	"\fwiki_page_id\x18\x01 \x01(\tR\n" +
-	"wikiPageId\"h\n" +
+	"wikiPageId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xbe\x01\n" +
 	"!ListWikiMessageReferencesResponse\x12C\n" +
 	"\n" +
 	"references\x18\x01 \x03(\v2#.hivemind.wiki.WikiMessageReferenceR\n" +
-	"references\"c\n" +
+	"references\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12>\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1e.hivemind.common.v1.PaginationR\n" +
+	"pagination\"c\n" +
 	"\x15MergeWikiPagesRequest\x12$\n" +
 	"\x0esource_page_id\x18\x01 \x01(\tR\fsourcePageId\x12$\n" +
-	"\x0etarget_page_id\x18\x02 \x01(\tR\ftargetPageId2\x84\t\n" +
+	"\x0etarget_page_id\x18\x02 \x01(\tR\ftargetPageId\"e\n" +
+	"\x17PreviewWikiMergeRequest\x12$\n" +
+	"\x0esource_page_id\x18\x01 \x01(\tR\fsourcePageId\x12$\n" +
+	"\x0etarget_page_id\x18\x02 \x01(\tR\ftargetPageId\"\xcb\x01\n" +
+	"\x18PreviewWikiMergeResponse\x12+\n" +
+	"\x04page\x18\x01 \x01(\v2\x17.hivemind.wiki.WikiPageR\x04page\x128\n" +
+	"\vsource_page\x18\x02 \x01(\v2\x17.hivemind.wiki.WikiPageR\n" +
+	"sourcePage\x12'\n" +
+	"\x0freference_count\x18\x03 \x01(\x05R\x0ereferenceCount\x12\x1f\n" +
+	"\vtitle_count\x18\x04 \x01(\x05R\n" +
+	"titleCount\"?\n" +
+	"\x17UnmergeWikiPagesRequest\x12$\n" +
+	"\x0esource_page_id\x18\x01 \x01(\tR\fsourcePageId\"\x8e\x01\n" +
+	"\x18UnmergeWikiPagesResponse\x128\n" +
+	"\vsource_page\x18\x01 \x01(\v2\x17.hivemind.wiki.WikiPageR\n" +
+	"sourcePage\x128\n" +
+	"\vtarget_page\x18\x02 \x01(\v2\x17.hivemind.wiki.WikiPageR\n" +
+	"targetPage\"B\n" +
+	"\x18SetWikiPagePinnedRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06pinned\x18\x02 \x01(\bR\x06pinned\"(\n" +
+	"\x16PublishWikiPageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	"\x15ListWikiDraftsRequest\x12\x19\n" +
+	"\bguild_id\x18\x01 \x01(\tR\aguildId\"G\n" +
+	"\x16ListWikiDraftsResponse\x12-\n" +
+	"\x05pages\x18\x01 \x03(\v2\x17.hivemind.wiki.WikiPageR\x05pages\"c\n" +
+	" TransferWikiPageOwnershipRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12/\n" +
+	"\x14new_owner_discord_id\x18\x02 \x01(\tR\x11newOwnerDiscordId\"\xa4\x01\n" +
+	"\x12SuggestTagsRequest\x12\x19\n" +
+	"\bguild_id\x18\x01 \x01(\tR\aguildId\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\x12!\n" +
+	"\fexclude_tags\x18\x03 \x03(\tR\vexcludeTags\x12&\n" +
+	"\x0fexclude_page_id\x18\x04 \x01(\tR\rexcludePageId\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"V\n" +
+	"\rTagSuggestion\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x02R\x05score\x12\x1d\n" +
+	"\n" +
+	"page_count\x18\x03 \x01(\x05R\tpageCount\"U\n" +
+	"\x13SuggestTagsResponse\x12>\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x1c.hivemind.wiki.TagSuggestionR\vsuggestions*n\n" +
+	"\x0eWikiPageStatus\x12 \n" +
+	"\x1cWIKI_PAGE_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aWIKI_PAGE_STATUS_PUBLISHED\x10\x01\x12\x1a\n" +
+	"\x16WIKI_PAGE_STATUS_DRAFT\x10\x022\xa1\x0f\n" +
 	"\vWikiService\x12O\n" +
 	"\x0eCreateWikiPage\x12$.hivemind.wiki.CreateWikiPageRequest\x1a\x17.hivemind.wiki.WikiPage\x12I\n" +
 	"\vGetWikiPage\x12!.hivemind.wiki.GetWikiPageRequest\x1a\x17.hivemind.wiki.WikiPage\x12W\n" +
@@ -1670,9 +2847,17 @@ const file_wiki_proto_rawDesc = "" +
 	"\x0eUpsertWikiPage\x12$.hivemind.wiki.UpsertWikiPageRequest\x1a%.hivemind.wiki.UpsertWikiPageResponse\x12[\n" +
 	"\x0eDeleteWikiPage\x12$.hivemind.wiki.DeleteWikiPageRequest\x1a#.hivemind.common.v1.SuccessResponse\x12Z\n" +
 	"\rListWikiPages\x12#.hivemind.wiki.ListWikiPagesRequest\x1a$.hivemind.wiki.ListWikiPagesResponse\x12m\n" +
-	"\x17AddWikiMessageReference\x12-.hivemind.wiki.AddWikiMessageReferenceRequest\x1a#.hivemind.wiki.WikiMessageReference\x12~\n" +
+	"\x17AddWikiMessageReference\x12-.hivemind.wiki.AddWikiMessageReferenceRequest\x1a#.hivemind.wiki.WikiMessageReference\x12\x8a\x01\n" +
+	"\x1dAddWikiMessageReferencesBatch\x123.hivemind.wiki.AddWikiMessageReferencesBatchRequest\x1a4.hivemind.wiki.AddWikiMessageReferencesBatchResponse\x12~\n" +
 	"\x19ListWikiMessageReferences\x12/.hivemind.wiki.ListWikiMessageReferencesRequest\x1a0.hivemind.wiki.ListWikiMessageReferencesResponse\x12O\n" +
-	"\x0eMergeWikiPages\x12$.hivemind.wiki.MergeWikiPagesRequest\x1a\x17.hivemind.wiki.WikiPageB<Z:github.com/devilmonastery/hivemind/api/generated/go/wikipbb\x06proto3"
+	"\x0eMergeWikiPages\x12$.hivemind.wiki.MergeWikiPagesRequest\x1a\x17.hivemind.wiki.WikiPage\x12c\n" +
+	"\x10PreviewWikiMerge\x12&.hivemind.wiki.PreviewWikiMergeRequest\x1a'.hivemind.wiki.PreviewWikiMergeResponse\x12c\n" +
+	"\x10UnmergeWikiPages\x12&.hivemind.wiki.UnmergeWikiPagesRequest\x1a'.hivemind.wiki.UnmergeWikiPagesResponse\x12U\n" +
+	"\x11SetWikiPagePinned\x12'.hivemind.wiki.SetWikiPagePinnedRequest\x1a\x17.hivemind.wiki.WikiPage\x12e\n" +
+	"\x19TransferWikiPageOwnership\x12/.hivemind.wiki.TransferWikiPageOwnershipRequest\x1a\x17.hivemind.wiki.WikiPage\x12Q\n" +
+	"\x0fPublishWikiPage\x12%.hivemind.wiki.PublishWikiPageRequest\x1a\x17.hivemind.wiki.WikiPage\x12]\n" +
+	"\x0eListWikiDrafts\x12$.hivemind.wiki.ListWikiDraftsRequest\x1a%.hivemind.wiki.ListWikiDraftsResponse\x12T\n" +
+	"\vSuggestTags\x12!.hivemind.wiki.SuggestTagsRequest\x1a\".hivemind.wiki.SuggestTagsResponseB<Z:github.com/devilmonastery/hivemind/api/generated/go/wikipbb\x06proto3"
 
 var (
 	file_wiki_proto_rawDescOnce sync.Once
@@ -1686,74 +2871,122 @@ func file_wiki_proto_rawDescGZIP() []byte {
 	return file_wiki_proto_rawDescData
 }
 
-var file_wiki_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_wiki_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_wiki_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_wiki_proto_goTypes = []any{
-	(*WikiPage)(nil),                          // 0: hivemind.wiki.WikiPage
-	(*CreateWikiPageRequest)(nil),             // 1: hivemind.wiki.CreateWikiPageRequest
-	(*GetWikiPageRequest)(nil),                // 2: hivemind.wiki.GetWikiPageRequest
-	(*GetWikiPageByTitleRequest)(nil),         // 3: hivemind.wiki.GetWikiPageByTitleRequest
-	(*SearchWikiPagesRequest)(nil),            // 4: hivemind.wiki.SearchWikiPagesRequest
-	(*SearchWikiPagesResponse)(nil),           // 5: hivemind.wiki.SearchWikiPagesResponse
-	(*UpdateWikiPageRequest)(nil),             // 6: hivemind.wiki.UpdateWikiPageRequest
-	(*UpsertWikiPageRequest)(nil),             // 7: hivemind.wiki.UpsertWikiPageRequest
-	(*UpsertWikiPageResponse)(nil),            // 8: hivemind.wiki.UpsertWikiPageResponse
-	(*DeleteWikiPageRequest)(nil),             // 9: hivemind.wiki.DeleteWikiPageRequest
-	(*ListWikiPagesRequest)(nil),              // 10: hivemind.wiki.ListWikiPagesRequest
-	(*ListWikiPagesResponse)(nil),             // 11: hivemind.wiki.ListWikiPagesResponse
-	(*AutocompleteWikiTitlesRequest)(nil),     // 12: hivemind.wiki.AutocompleteWikiTitlesRequest
-	(*AutocompleteWikiTitlesResponse)(nil),    // 13: hivemind.wiki.AutocompleteWikiTitlesResponse
-	(*WikiTitleSuggestion)(nil),               // 14: hivemind.wiki.WikiTitleSuggestion
-	(*WikiMessageReference)(nil),              // 15: hivemind.wiki.WikiMessageReference
-	(*AttachmentMetadata)(nil),                // 16: hivemind.wiki.AttachmentMetadata
-	(*AddWikiMessageReferenceRequest)(nil),    // 17: hivemind.wiki.AddWikiMessageReferenceRequest
-	(*ListWikiMessageReferencesRequest)(nil),  // 18: hivemind.wiki.ListWikiMessageReferencesRequest
-	(*ListWikiMessageReferencesResponse)(nil), // 19: hivemind.wiki.ListWikiMessageReferencesResponse
-	(*MergeWikiPagesRequest)(nil),             // 20: hivemind.wiki.MergeWikiPagesRequest
-	(*timestamppb.Timestamp)(nil),             // 21: google.protobuf.Timestamp
-	(*commonpb.SuccessResponse)(nil),          // 22: hivemind.common.v1.SuccessResponse
+	(WikiPageStatus)(0),                           // 0: hivemind.wiki.WikiPageStatus
+	(*WikiPage)(nil),                              // 1: hivemind.wiki.WikiPage
+	(*CreateWikiPageRequest)(nil),                 // 2: hivemind.wiki.CreateWikiPageRequest
+	(*GetWikiPageRequest)(nil),                    // 3: hivemind.wiki.GetWikiPageRequest
+	(*GetWikiPageByTitleRequest)(nil),             // 4: hivemind.wiki.GetWikiPageByTitleRequest
+	(*SearchWikiPagesRequest)(nil),                // 5: hivemind.wiki.SearchWikiPagesRequest
+	(*SearchWikiPagesResponse)(nil),               // 6: hivemind.wiki.SearchWikiPagesResponse
+	(*UpdateWikiPageRequest)(nil),                 // 7: hivemind.wiki.UpdateWikiPageRequest
+	(*UpsertWikiPageRequest)(nil),                 // 8: hivemind.wiki.UpsertWikiPageRequest
+	(*UpsertWikiPageResponse)(nil),                // 9: hivemind.wiki.UpsertWikiPageResponse
+	(*DeleteWikiPageRequest)(nil),                 // 10: hivemind.wiki.DeleteWikiPageRequest
+	(*ListWikiPagesRequest)(nil),                  // 11: hivemind.wiki.ListWikiPagesRequest
+	(*ListWikiPagesResponse)(nil),                 // 12: hivemind.wiki.ListWikiPagesResponse
+	(*AutocompleteWikiTitlesRequest)(nil),         // 13: hivemind.wiki.AutocompleteWikiTitlesRequest
+	(*AutocompleteWikiTitlesResponse)(nil),        // 14: hivemind.wiki.AutocompleteWikiTitlesResponse
+	(*WikiTitleSuggestion)(nil),                   // 15: hivemind.wiki.WikiTitleSuggestion
+	(*WikiMessageReference)(nil),                  // 16: hivemind.wiki.WikiMessageReference
+	(*AttachmentMetadata)(nil),                    // 17: hivemind.wiki.AttachmentMetadata
+	(*AddWikiMessageReferenceRequest)(nil),        // 18: hivemind.wiki.AddWikiMessageReferenceRequest
+	(*AddWikiMessageReferencesBatchRequest)(nil),  // 19: hivemind.wiki.AddWikiMessageReferencesBatchRequest
+	(*AddWikiMessageReferencesBatchResponse)(nil), // 20: hivemind.wiki.AddWikiMessageReferencesBatchResponse
+	(*InvalidWikiMessageReference)(nil),           // 21: hivemind.wiki.InvalidWikiMessageReference
+	(*ListWikiMessageReferencesRequest)(nil),      // 22: hivemind.wiki.ListWikiMessageReferencesRequest
+	(*ListWikiMessageReferencesResponse)(nil),     // 23: hivemind.wiki.ListWikiMessageReferencesResponse
+	(*MergeWikiPagesRequest)(nil),                 // 24: hivemind.wiki.MergeWikiPagesRequest
+	(*PreviewWikiMergeRequest)(nil),               // 25: hivemind.wiki.PreviewWikiMergeRequest
+	(*PreviewWikiMergeResponse)(nil),              // 26: hivemind.wiki.PreviewWikiMergeResponse
+	(*UnmergeWikiPagesRequest)(nil),               // 27: hivemind.wiki.UnmergeWikiPagesRequest
+	(*UnmergeWikiPagesResponse)(nil),              // 28: hivemind.wiki.UnmergeWikiPagesResponse
+	(*SetWikiPagePinnedRequest)(nil),              // 29: hivemind.wiki.SetWikiPagePinnedRequest
+	(*PublishWikiPageRequest)(nil),                // 30: hivemind.wiki.PublishWikiPageRequest
+	(*ListWikiDraftsRequest)(nil),                 // 31: hivemind.wiki.ListWikiDraftsRequest
+	(*ListWikiDraftsResponse)(nil),                // 32: hivemind.wiki.ListWikiDraftsResponse
+	(*TransferWikiPageOwnershipRequest)(nil),      // 33: hivemind.wiki.TransferWikiPageOwnershipRequest
+	(*SuggestTagsRequest)(nil),                    // 34: hivemind.wiki.SuggestTagsRequest
+	(*TagSuggestion)(nil),                         // 35: hivemind.wiki.TagSuggestion
+	(*SuggestTagsResponse)(nil),                   // 36: hivemind.wiki.SuggestTagsResponse
+	(*timestamppb.Timestamp)(nil),                 // 37: google.protobuf.Timestamp
+	(*commonpb.Pagination)(nil),                   // 38: hivemind.common.v1.Pagination
+	(*commonpb.SuccessResponse)(nil),              // 39: hivemind.common.v1.SuccessResponse
 }
 var file_wiki_proto_depIdxs = []int32{
-	21, // 0: hivemind.wiki.WikiPage.created_at:type_name -> google.protobuf.Timestamp
-	21, // 1: hivemind.wiki.WikiPage.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: hivemind.wiki.SearchWikiPagesResponse.pages:type_name -> hivemind.wiki.WikiPage
-	0,  // 3: hivemind.wiki.UpsertWikiPageResponse.page:type_name -> hivemind.wiki.WikiPage
-	0,  // 4: hivemind.wiki.ListWikiPagesResponse.pages:type_name -> hivemind.wiki.WikiPage
-	14, // 5: hivemind.wiki.AutocompleteWikiTitlesResponse.suggestions:type_name -> hivemind.wiki.WikiTitleSuggestion
-	21, // 6: hivemind.wiki.WikiMessageReference.message_timestamp:type_name -> google.protobuf.Timestamp
-	16, // 7: hivemind.wiki.WikiMessageReference.attachments:type_name -> hivemind.wiki.AttachmentMetadata
-	21, // 8: hivemind.wiki.WikiMessageReference.added_at:type_name -> google.protobuf.Timestamp
-	21, // 9: hivemind.wiki.AddWikiMessageReferenceRequest.message_timestamp:type_name -> google.protobuf.Timestamp
-	16, // 10: hivemind.wiki.AddWikiMessageReferenceRequest.attachments:type_name -> hivemind.wiki.AttachmentMetadata
-	15, // 11: hivemind.wiki.ListWikiMessageReferencesResponse.references:type_name -> hivemind.wiki.WikiMessageReference
-	1,  // 12: hivemind.wiki.WikiService.CreateWikiPage:input_type -> hivemind.wiki.CreateWikiPageRequest
-	2,  // 13: hivemind.wiki.WikiService.GetWikiPage:input_type -> hivemind.wiki.GetWikiPageRequest
-	3,  // 14: hivemind.wiki.WikiService.GetWikiPageByTitle:input_type -> hivemind.wiki.GetWikiPageByTitleRequest
-	4,  // 15: hivemind.wiki.WikiService.SearchWikiPages:input_type -> hivemind.wiki.SearchWikiPagesRequest
-	12, // 16: hivemind.wiki.WikiService.AutocompleteWikiTitles:input_type -> hivemind.wiki.AutocompleteWikiTitlesRequest
-	6,  // 17: hivemind.wiki.WikiService.UpdateWikiPage:input_type -> hivemind.wiki.UpdateWikiPageRequest
-	7,  // 18: hivemind.wiki.WikiService.UpsertWikiPage:input_type -> hivemind.wiki.UpsertWikiPageRequest
-	9,  // 19: hivemind.wiki.WikiService.DeleteWikiPage:input_type -> hivemind.wiki.DeleteWikiPageRequest
-	10, // 20: hivemind.wiki.WikiService.ListWikiPages:input_type -> hivemind.wiki.ListWikiPagesRequest
-	17, // 21: hivemind.wiki.WikiService.AddWikiMessageReference:input_type -> hivemind.wiki.AddWikiMessageReferenceRequest
-	18, // 22: hivemind.wiki.WikiService.ListWikiMessageReferences:input_type -> hivemind.wiki.ListWikiMessageReferencesRequest
-	20, // 23: hivemind.wiki.WikiService.MergeWikiPages:input_type -> hivemind.wiki.MergeWikiPagesRequest
-	0,  // 24: hivemind.wiki.WikiService.CreateWikiPage:output_type -> hivemind.wiki.WikiPage
-	0,  // 25: hivemind.wiki.WikiService.GetWikiPage:output_type -> hivemind.wiki.WikiPage
-	0,  // 26: hivemind.wiki.WikiService.GetWikiPageByTitle:output_type -> hivemind.wiki.WikiPage
-	5,  // 27: hivemind.wiki.WikiService.SearchWikiPages:output_type -> hivemind.wiki.SearchWikiPagesResponse
-	13, // 28: hivemind.wiki.WikiService.AutocompleteWikiTitles:output_type -> hivemind.wiki.AutocompleteWikiTitlesResponse
-	0,  // 29: hivemind.wiki.WikiService.UpdateWikiPage:output_type -> hivemind.wiki.WikiPage
-	8,  // 30: hivemind.wiki.WikiService.UpsertWikiPage:output_type -> hivemind.wiki.UpsertWikiPageResponse
-	22, // 31: hivemind.wiki.WikiService.DeleteWikiPage:output_type -> hivemind.common.v1.SuccessResponse
-	11, // 32: hivemind.wiki.WikiService.ListWikiPages:output_type -> hivemind.wiki.ListWikiPagesResponse
-	15, // 33: hivemind.wiki.WikiService.AddWikiMessageReference:output_type -> hivemind.wiki.WikiMessageReference
-	19, // 34: hivemind.wiki.WikiService.ListWikiMessageReferences:output_type -> hivemind.wiki.ListWikiMessageReferencesResponse
-	0,  // 35: hivemind.wiki.WikiService.MergeWikiPages:output_type -> hivemind.wiki.WikiPage
-	24, // [24:36] is the sub-list for method output_type
-	12, // [12:24] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	37, // 0: hivemind.wiki.WikiPage.created_at:type_name -> google.protobuf.Timestamp
+	37, // 1: hivemind.wiki.WikiPage.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: hivemind.wiki.WikiPage.status:type_name -> hivemind.wiki.WikiPageStatus
+	0,  // 3: hivemind.wiki.CreateWikiPageRequest.status:type_name -> hivemind.wiki.WikiPageStatus
+	1,  // 4: hivemind.wiki.SearchWikiPagesResponse.pages:type_name -> hivemind.wiki.WikiPage
+	38, // 5: hivemind.wiki.SearchWikiPagesResponse.pagination:type_name -> hivemind.common.v1.Pagination
+	0,  // 6: hivemind.wiki.UpsertWikiPageRequest.status:type_name -> hivemind.wiki.WikiPageStatus
+	1,  // 7: hivemind.wiki.UpsertWikiPageResponse.page:type_name -> hivemind.wiki.WikiPage
+	1,  // 8: hivemind.wiki.ListWikiPagesResponse.pages:type_name -> hivemind.wiki.WikiPage
+	38, // 9: hivemind.wiki.ListWikiPagesResponse.pagination:type_name -> hivemind.common.v1.Pagination
+	15, // 10: hivemind.wiki.AutocompleteWikiTitlesResponse.suggestions:type_name -> hivemind.wiki.WikiTitleSuggestion
+	37, // 11: hivemind.wiki.WikiMessageReference.message_timestamp:type_name -> google.protobuf.Timestamp
+	17, // 12: hivemind.wiki.WikiMessageReference.attachments:type_name -> hivemind.wiki.AttachmentMetadata
+	37, // 13: hivemind.wiki.WikiMessageReference.added_at:type_name -> google.protobuf.Timestamp
+	37, // 14: hivemind.wiki.AddWikiMessageReferenceRequest.message_timestamp:type_name -> google.protobuf.Timestamp
+	17, // 15: hivemind.wiki.AddWikiMessageReferenceRequest.attachments:type_name -> hivemind.wiki.AttachmentMetadata
+	18, // 16: hivemind.wiki.AddWikiMessageReferencesBatchRequest.references:type_name -> hivemind.wiki.AddWikiMessageReferenceRequest
+	21, // 17: hivemind.wiki.AddWikiMessageReferencesBatchResponse.invalid:type_name -> hivemind.wiki.InvalidWikiMessageReference
+	16, // 18: hivemind.wiki.ListWikiMessageReferencesResponse.references:type_name -> hivemind.wiki.WikiMessageReference
+	38, // 19: hivemind.wiki.ListWikiMessageReferencesResponse.pagination:type_name -> hivemind.common.v1.Pagination
+	1,  // 20: hivemind.wiki.PreviewWikiMergeResponse.page:type_name -> hivemind.wiki.WikiPage
+	1,  // 21: hivemind.wiki.PreviewWikiMergeResponse.source_page:type_name -> hivemind.wiki.WikiPage
+	1,  // 22: hivemind.wiki.UnmergeWikiPagesResponse.source_page:type_name -> hivemind.wiki.WikiPage
+	1,  // 23: hivemind.wiki.UnmergeWikiPagesResponse.target_page:type_name -> hivemind.wiki.WikiPage
+	1,  // 24: hivemind.wiki.ListWikiDraftsResponse.pages:type_name -> hivemind.wiki.WikiPage
+	35, // 25: hivemind.wiki.SuggestTagsResponse.suggestions:type_name -> hivemind.wiki.TagSuggestion
+	2,  // 26: hivemind.wiki.WikiService.CreateWikiPage:input_type -> hivemind.wiki.CreateWikiPageRequest
+	3,  // 27: hivemind.wiki.WikiService.GetWikiPage:input_type -> hivemind.wiki.GetWikiPageRequest
+	4,  // 28: hivemind.wiki.WikiService.GetWikiPageByTitle:input_type -> hivemind.wiki.GetWikiPageByTitleRequest
+	5,  // 29: hivemind.wiki.WikiService.SearchWikiPages:input_type -> hivemind.wiki.SearchWikiPagesRequest
+	13, // 30: hivemind.wiki.WikiService.AutocompleteWikiTitles:input_type -> hivemind.wiki.AutocompleteWikiTitlesRequest
+	7,  // 31: hivemind.wiki.WikiService.UpdateWikiPage:input_type -> hivemind.wiki.UpdateWikiPageRequest
+	8,  // 32: hivemind.wiki.WikiService.UpsertWikiPage:input_type -> hivemind.wiki.UpsertWikiPageRequest
+	10, // 33: hivemind.wiki.WikiService.DeleteWikiPage:input_type -> hivemind.wiki.DeleteWikiPageRequest
+	11, // 34: hivemind.wiki.WikiService.ListWikiPages:input_type -> hivemind.wiki.ListWikiPagesRequest
+	18, // 35: hivemind.wiki.WikiService.AddWikiMessageReference:input_type -> hivemind.wiki.AddWikiMessageReferenceRequest
+	19, // 36: hivemind.wiki.WikiService.AddWikiMessageReferencesBatch:input_type -> hivemind.wiki.AddWikiMessageReferencesBatchRequest
+	22, // 37: hivemind.wiki.WikiService.ListWikiMessageReferences:input_type -> hivemind.wiki.ListWikiMessageReferencesRequest
+	24, // 38: hivemind.wiki.WikiService.MergeWikiPages:input_type -> hivemind.wiki.MergeWikiPagesRequest
+	25, // 39: hivemind.wiki.WikiService.PreviewWikiMerge:input_type -> hivemind.wiki.PreviewWikiMergeRequest
+	27, // 40: hivemind.wiki.WikiService.UnmergeWikiPages:input_type -> hivemind.wiki.UnmergeWikiPagesRequest
+	29, // 41: hivemind.wiki.WikiService.SetWikiPagePinned:input_type -> hivemind.wiki.SetWikiPagePinnedRequest
+	33, // 42: hivemind.wiki.WikiService.TransferWikiPageOwnership:input_type -> hivemind.wiki.TransferWikiPageOwnershipRequest
+	30, // 43: hivemind.wiki.WikiService.PublishWikiPage:input_type -> hivemind.wiki.PublishWikiPageRequest
+	31, // 44: hivemind.wiki.WikiService.ListWikiDrafts:input_type -> hivemind.wiki.ListWikiDraftsRequest
+	34, // 45: hivemind.wiki.WikiService.SuggestTags:input_type -> hivemind.wiki.SuggestTagsRequest
+	1,  // 46: hivemind.wiki.WikiService.CreateWikiPage:output_type -> hivemind.wiki.WikiPage
+	1,  // 47: hivemind.wiki.WikiService.GetWikiPage:output_type -> hivemind.wiki.WikiPage
+	1,  // 48: hivemind.wiki.WikiService.GetWikiPageByTitle:output_type -> hivemind.wiki.WikiPage
+	6,  // 49: hivemind.wiki.WikiService.SearchWikiPages:output_type -> hivemind.wiki.SearchWikiPagesResponse
+	14, // 50: hivemind.wiki.WikiService.AutocompleteWikiTitles:output_type -> hivemind.wiki.AutocompleteWikiTitlesResponse
+	1,  // 51: hivemind.wiki.WikiService.UpdateWikiPage:output_type -> hivemind.wiki.WikiPage
+	9,  // 52: hivemind.wiki.WikiService.UpsertWikiPage:output_type -> hivemind.wiki.UpsertWikiPageResponse
+	39, // 53: hivemind.wiki.WikiService.DeleteWikiPage:output_type -> hivemind.common.v1.SuccessResponse
+	12, // 54: hivemind.wiki.WikiService.ListWikiPages:output_type -> hivemind.wiki.ListWikiPagesResponse
+	16, // 55: hivemind.wiki.WikiService.AddWikiMessageReference:output_type -> hivemind.wiki.WikiMessageReference
+	20, // 56: hivemind.wiki.WikiService.AddWikiMessageReferencesBatch:output_type -> hivemind.wiki.AddWikiMessageReferencesBatchResponse
+	23, // 57: hivemind.wiki.WikiService.ListWikiMessageReferences:output_type -> hivemind.wiki.ListWikiMessageReferencesResponse
+	1,  // 58: hivemind.wiki.WikiService.MergeWikiPages:output_type -> hivemind.wiki.WikiPage
+	26, // 59: hivemind.wiki.WikiService.PreviewWikiMerge:output_type -> hivemind.wiki.PreviewWikiMergeResponse
+	28, // 60: hivemind.wiki.WikiService.UnmergeWikiPages:output_type -> hivemind.wiki.UnmergeWikiPagesResponse
+	1,  // 61: hivemind.wiki.WikiService.SetWikiPagePinned:output_type -> hivemind.wiki.WikiPage
+	1,  // 62: hivemind.wiki.WikiService.TransferWikiPageOwnership:output_type -> hivemind.wiki.WikiPage
+	1,  // 63: hivemind.wiki.WikiService.PublishWikiPage:output_type -> hivemind.wiki.WikiPage
+	32, // 64: hivemind.wiki.WikiService.ListWikiDrafts:output_type -> hivemind.wiki.ListWikiDraftsResponse
+	36, // 65: hivemind.wiki.WikiService.SuggestTags:output_type -> hivemind.wiki.SuggestTagsResponse
+	46, // [46:66] is the sub-list for method output_type
+	26, // [26:46] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_wiki_proto_init() }
@@ -1766,13 +2999,14 @@ func file_wiki_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wiki_proto_rawDesc), len(file_wiki_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   21,
+			NumEnums:      1,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_wiki_proto_goTypes,
 		DependencyIndexes: file_wiki_proto_depIdxs,
+		EnumInfos:         file_wiki_proto_enumTypes,
 		MessageInfos:      file_wiki_proto_msgTypes,
 	}.Build()
 	File_wiki_proto = out.File
@@ -20,18 +20,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	WikiService_CreateWikiPage_FullMethodName            = "/hivemind.wiki.WikiService/CreateWikiPage"
-	WikiService_GetWikiPage_FullMethodName               = "/hivemind.wiki.WikiService/GetWikiPage"
-	WikiService_GetWikiPageByTitle_FullMethodName        = "/hivemind.wiki.WikiService/GetWikiPageByTitle"
-	WikiService_SearchWikiPages_FullMethodName           = "/hivemind.wiki.WikiService/SearchWikiPages"
-	WikiService_AutocompleteWikiTitles_FullMethodName    = "/hivemind.wiki.WikiService/AutocompleteWikiTitles"
-	WikiService_UpdateWikiPage_FullMethodName            = "/hivemind.wiki.WikiService/UpdateWikiPage"
-	WikiService_UpsertWikiPage_FullMethodName            = "/hivemind.wiki.WikiService/UpsertWikiPage"
-	WikiService_DeleteWikiPage_FullMethodName            = "/hivemind.wiki.WikiService/DeleteWikiPage"
-	WikiService_ListWikiPages_FullMethodName             = "/hivemind.wiki.WikiService/ListWikiPages"
-	WikiService_AddWikiMessageReference_FullMethodName   = "/hivemind.wiki.WikiService/AddWikiMessageReference"
-	WikiService_ListWikiMessageReferences_FullMethodName = "/hivemind.wiki.WikiService/ListWikiMessageReferences"
-	WikiService_MergeWikiPages_FullMethodName            = "/hivemind.wiki.WikiService/MergeWikiPages"
+	WikiService_CreateWikiPage_FullMethodName                = "/hivemind.wiki.WikiService/CreateWikiPage"
+	WikiService_GetWikiPage_FullMethodName                   = "/hivemind.wiki.WikiService/GetWikiPage"
+	WikiService_GetWikiPageByTitle_FullMethodName            = "/hivemind.wiki.WikiService/GetWikiPageByTitle"
+	WikiService_SearchWikiPages_FullMethodName               = "/hivemind.wiki.WikiService/SearchWikiPages"
+	WikiService_AutocompleteWikiTitles_FullMethodName        = "/hivemind.wiki.WikiService/AutocompleteWikiTitles"
+	WikiService_UpdateWikiPage_FullMethodName                = "/hivemind.wiki.WikiService/UpdateWikiPage"
+	WikiService_UpsertWikiPage_FullMethodName                = "/hivemind.wiki.WikiService/UpsertWikiPage"
+	WikiService_DeleteWikiPage_FullMethodName                = "/hivemind.wiki.WikiService/DeleteWikiPage"
+	WikiService_ListWikiPages_FullMethodName                 = "/hivemind.wiki.WikiService/ListWikiPages"
+	WikiService_AddWikiMessageReference_FullMethodName       = "/hivemind.wiki.WikiService/AddWikiMessageReference"
+	WikiService_AddWikiMessageReferencesBatch_FullMethodName = "/hivemind.wiki.WikiService/AddWikiMessageReferencesBatch"
+	WikiService_ListWikiMessageReferences_FullMethodName     = "/hivemind.wiki.WikiService/ListWikiMessageReferences"
+	WikiService_MergeWikiPages_FullMethodName                = "/hivemind.wiki.WikiService/MergeWikiPages"
+	WikiService_PreviewWikiMerge_FullMethodName              = "/hivemind.wiki.WikiService/PreviewWikiMerge"
+	WikiService_UnmergeWikiPages_FullMethodName              = "/hivemind.wiki.WikiService/UnmergeWikiPages"
+	WikiService_SetWikiPagePinned_FullMethodName             = "/hivemind.wiki.WikiService/SetWikiPagePinned"
+	WikiService_TransferWikiPageOwnership_FullMethodName     = "/hivemind.wiki.WikiService/TransferWikiPageOwnership"
+	WikiService_PublishWikiPage_FullMethodName               = "/hivemind.wiki.WikiService/PublishWikiPage"
+	WikiService_ListWikiDrafts_FullMethodName                = "/hivemind.wiki.WikiService/ListWikiDrafts"
+	WikiService_SuggestTags_FullMethodName                   = "/hivemind.wiki.WikiService/SuggestTags"
 )
 
 // WikiServiceClient is the client API for WikiService service.
@@ -60,10 +68,29 @@ type WikiServiceClient interface {
 	ListWikiPages(ctx context.Context, in *ListWikiPagesRequest, opts ...grpc.CallOption) (*ListWikiPagesResponse, error)
 	// AddWikiMessageReference tags a Discord message with a wiki page topic
 	AddWikiMessageReference(ctx context.Context, in *AddWikiMessageReferenceRequest, opts ...grpc.CallOption) (*WikiMessageReference, error)
+	// AddWikiMessageReferencesBatch tags several Discord messages (e.g. a thread) with a wiki page topic in one call
+	// Messages the page already references are skipped; invalid references are reported without dropping the rest
+	AddWikiMessageReferencesBatch(ctx context.Context, in *AddWikiMessageReferencesBatchRequest, opts ...grpc.CallOption) (*AddWikiMessageReferencesBatchResponse, error)
 	// ListWikiMessageReferences retrieves all message references for a wiki page
 	ListWikiMessageReferences(ctx context.Context, in *ListWikiMessageReferencesRequest, opts ...grpc.CallOption) (*ListWikiMessageReferencesResponse, error)
 	// MergeWikiPages merges source page into target page
 	MergeWikiPages(ctx context.Context, in *MergeWikiPagesRequest, opts ...grpc.CallOption) (*WikiPage, error)
+	// PreviewWikiMerge computes the page MergeWikiPages would produce without saving anything
+	PreviewWikiMerge(ctx context.Context, in *PreviewWikiMergeRequest, opts ...grpc.CallOption) (*PreviewWikiMergeResponse, error)
+	// UnmergeWikiPages reverts the most recent merge of a source page, recreating it
+	// Only the user who performed the merge or an admin may undo it, within a retention window
+	UnmergeWikiPages(ctx context.Context, in *UnmergeWikiPagesRequest, opts ...grpc.CallOption) (*UnmergeWikiPagesResponse, error)
+	// SetWikiPagePinned pins or unpins a wiki page so it's listed first (author or admin only)
+	SetWikiPagePinned(ctx context.Context, in *SetWikiPagePinnedRequest, opts ...grpc.CallOption) (*WikiPage, error)
+	// TransferWikiPageOwnership makes another guild member the page author (author or admin only)
+	TransferWikiPageOwnership(ctx context.Context, in *TransferWikiPageOwnershipRequest, opts ...grpc.CallOption) (*WikiPage, error)
+	// PublishWikiPage makes a draft visible to the guild (page author or admin only)
+	PublishWikiPage(ctx context.Context, in *PublishWikiPageRequest, opts ...grpc.CallOption) (*WikiPage, error)
+	// ListWikiDrafts lists the caller's draft pages in a guild, most recently updated first
+	ListWikiDrafts(ctx context.Context, in *ListWikiDraftsRequest, opts ...grpc.CallOption) (*ListWikiDraftsResponse, error)
+	// SuggestTags ranks tags used on the guild's wiki pages that share vocabulary with a body,
+	// for tagging new wiki pages and notes consistently
+	SuggestTags(ctx context.Context, in *SuggestTagsRequest, opts ...grpc.CallOption) (*SuggestTagsResponse, error)
 }
 
 type wikiServiceClient struct {
@@ -174,6 +201,16 @@ func (c *wikiServiceClient) AddWikiMessageReference(ctx context.Context, in *Add
 	return out, nil
 }
 
+func (c *wikiServiceClient) AddWikiMessageReferencesBatch(ctx context.Context, in *AddWikiMessageReferencesBatchRequest, opts ...grpc.CallOption) (*AddWikiMessageReferencesBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddWikiMessageReferencesBatchResponse)
+	err := c.cc.Invoke(ctx, WikiService_AddWikiMessageReferencesBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *wikiServiceClient) ListWikiMessageReferences(ctx context.Context, in *ListWikiMessageReferencesRequest, opts ...grpc.CallOption) (*ListWikiMessageReferencesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListWikiMessageReferencesResponse)
@@ -194,6 +231,76 @@ func (c *wikiServiceClient) MergeWikiPages(ctx context.Context, in *MergeWikiPag
 	return out, nil
 }
 
+func (c *wikiServiceClient) PreviewWikiMerge(ctx context.Context, in *PreviewWikiMergeRequest, opts ...grpc.CallOption) (*PreviewWikiMergeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewWikiMergeResponse)
+	err := c.cc.Invoke(ctx, WikiService_PreviewWikiMerge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiServiceClient) UnmergeWikiPages(ctx context.Context, in *UnmergeWikiPagesRequest, opts ...grpc.CallOption) (*UnmergeWikiPagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnmergeWikiPagesResponse)
+	err := c.cc.Invoke(ctx, WikiService_UnmergeWikiPages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiServiceClient) SetWikiPagePinned(ctx context.Context, in *SetWikiPagePinnedRequest, opts ...grpc.CallOption) (*WikiPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WikiPage)
+	err := c.cc.Invoke(ctx, WikiService_SetWikiPagePinned_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiServiceClient) TransferWikiPageOwnership(ctx context.Context, in *TransferWikiPageOwnershipRequest, opts ...grpc.CallOption) (*WikiPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WikiPage)
+	err := c.cc.Invoke(ctx, WikiService_TransferWikiPageOwnership_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiServiceClient) PublishWikiPage(ctx context.Context, in *PublishWikiPageRequest, opts ...grpc.CallOption) (*WikiPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WikiPage)
+	err := c.cc.Invoke(ctx, WikiService_PublishWikiPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiServiceClient) ListWikiDrafts(ctx context.Context, in *ListWikiDraftsRequest, opts ...grpc.CallOption) (*ListWikiDraftsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWikiDraftsResponse)
+	err := c.cc.Invoke(ctx, WikiService_ListWikiDrafts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiServiceClient) SuggestTags(ctx context.Context, in *SuggestTagsRequest, opts ...grpc.CallOption) (*SuggestTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestTagsResponse)
+	err := c.cc.Invoke(ctx, WikiService_SuggestTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WikiServiceServer is the server API for WikiService service.
 // All implementations should embed UnimplementedWikiServiceServer
 // for forward compatibility.
@@ -220,10 +327,29 @@ type WikiServiceServer interface {
 	ListWikiPages(context.Context, *ListWikiPagesRequest) (*ListWikiPagesResponse, error)
 	// AddWikiMessageReference tags a Discord message with a wiki page topic
 	AddWikiMessageReference(context.Context, *AddWikiMessageReferenceRequest) (*WikiMessageReference, error)
+	// AddWikiMessageReferencesBatch tags several Discord messages (e.g. a thread) with a wiki page topic in one call
+	// Messages the page already references are skipped; invalid references are reported without dropping the rest
+	AddWikiMessageReferencesBatch(context.Context, *AddWikiMessageReferencesBatchRequest) (*AddWikiMessageReferencesBatchResponse, error)
 	// ListWikiMessageReferences retrieves all message references for a wiki page
 	ListWikiMessageReferences(context.Context, *ListWikiMessageReferencesRequest) (*ListWikiMessageReferencesResponse, error)
 	// MergeWikiPages merges source page into target page
 	MergeWikiPages(context.Context, *MergeWikiPagesRequest) (*WikiPage, error)
+	// PreviewWikiMerge computes the page MergeWikiPages would produce without saving anything
+	PreviewWikiMerge(context.Context, *PreviewWikiMergeRequest) (*PreviewWikiMergeResponse, error)
+	// UnmergeWikiPages reverts the most recent merge of a source page, recreating it
+	// Only the user who performed the merge or an admin may undo it, within a retention window
+	UnmergeWikiPages(context.Context, *UnmergeWikiPagesRequest) (*UnmergeWikiPagesResponse, error)
+	// SetWikiPagePinned pins or unpins a wiki page so it's listed first (author or admin only)
+	SetWikiPagePinned(context.Context, *SetWikiPagePinnedRequest) (*WikiPage, error)
+	// TransferWikiPageOwnership makes another guild member the page author (author or admin only)
+	TransferWikiPageOwnership(context.Context, *TransferWikiPageOwnershipRequest) (*WikiPage, error)
+	// PublishWikiPage makes a draft visible to the guild (page author or admin only)
+	PublishWikiPage(context.Context, *PublishWikiPageRequest) (*WikiPage, error)
+	// ListWikiDrafts lists the caller's draft pages in a guild, most recently updated first
+	ListWikiDrafts(context.Context, *ListWikiDraftsRequest) (*ListWikiDraftsResponse, error)
+	// SuggestTags ranks tags used on the guild's wiki pages that share vocabulary with a body,
+	// for tagging new wiki pages and notes consistently
+	SuggestTags(context.Context, *SuggestTagsRequest) (*SuggestTagsResponse, error)
 }
 
 // UnimplementedWikiServiceServer should be embedded to have
@@ -263,12 +389,36 @@ func (UnimplementedWikiServiceServer) ListWikiPages(context.Context, *ListWikiPa
 func (UnimplementedWikiServiceServer) AddWikiMessageReference(context.Context, *AddWikiMessageReferenceRequest) (*WikiMessageReference, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddWikiMessageReference not implemented")
 }
+func (UnimplementedWikiServiceServer) AddWikiMessageReferencesBatch(context.Context, *AddWikiMessageReferencesBatchRequest) (*AddWikiMessageReferencesBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddWikiMessageReferencesBatch not implemented")
+}
 func (UnimplementedWikiServiceServer) ListWikiMessageReferences(context.Context, *ListWikiMessageReferencesRequest) (*ListWikiMessageReferencesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListWikiMessageReferences not implemented")
 }
 func (UnimplementedWikiServiceServer) MergeWikiPages(context.Context, *MergeWikiPagesRequest) (*WikiPage, error) {
 	return nil, status.Error(codes.Unimplemented, "method MergeWikiPages not implemented")
 }
+func (UnimplementedWikiServiceServer) PreviewWikiMerge(context.Context, *PreviewWikiMergeRequest) (*PreviewWikiMergeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PreviewWikiMerge not implemented")
+}
+func (UnimplementedWikiServiceServer) UnmergeWikiPages(context.Context, *UnmergeWikiPagesRequest) (*UnmergeWikiPagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnmergeWikiPages not implemented")
+}
+func (UnimplementedWikiServiceServer) SetWikiPagePinned(context.Context, *SetWikiPagePinnedRequest) (*WikiPage, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetWikiPagePinned not implemented")
+}
+func (UnimplementedWikiServiceServer) TransferWikiPageOwnership(context.Context, *TransferWikiPageOwnershipRequest) (*WikiPage, error) {
+	return nil, status.Error(codes.Unimplemented, "method TransferWikiPageOwnership not implemented")
+}
+func (UnimplementedWikiServiceServer) PublishWikiPage(context.Context, *PublishWikiPageRequest) (*WikiPage, error) {
+	return nil, status.Error(codes.Unimplemented, "method PublishWikiPage not implemented")
+}
+func (UnimplementedWikiServiceServer) ListWikiDrafts(context.Context, *ListWikiDraftsRequest) (*ListWikiDraftsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWikiDrafts not implemented")
+}
+func (UnimplementedWikiServiceServer) SuggestTags(context.Context, *SuggestTagsRequest) (*SuggestTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SuggestTags not implemented")
+}
 func (UnimplementedWikiServiceServer) testEmbeddedByValue() {}
 
 // UnsafeWikiServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -469,6 +619,24 @@ func _WikiService_AddWikiMessageReference_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WikiService_AddWikiMessageReferencesBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWikiMessageReferencesBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiServiceServer).AddWikiMessageReferencesBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WikiService_AddWikiMessageReferencesBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiServiceServer).AddWikiMessageReferencesBatch(ctx, req.(*AddWikiMessageReferencesBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WikiService_ListWikiMessageReferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListWikiMessageReferencesRequest)
 	if err := dec(in); err != nil {
@@ -505,6 +673,132 @@ func _WikiService_MergeWikiPages_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WikiService_PreviewWikiMerge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewWikiMergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiServiceServer).PreviewWikiMerge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WikiService_PreviewWikiMerge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiServiceServer).PreviewWikiMerge(ctx, req.(*PreviewWikiMergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiService_UnmergeWikiPages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmergeWikiPagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiServiceServer).UnmergeWikiPages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WikiService_UnmergeWikiPages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiServiceServer).UnmergeWikiPages(ctx, req.(*UnmergeWikiPagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiService_SetWikiPagePinned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWikiPagePinnedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiServiceServer).SetWikiPagePinned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WikiService_SetWikiPagePinned_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiServiceServer).SetWikiPagePinned(ctx, req.(*SetWikiPagePinnedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiService_TransferWikiPageOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferWikiPageOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiServiceServer).TransferWikiPageOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WikiService_TransferWikiPageOwnership_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiServiceServer).TransferWikiPageOwnership(ctx, req.(*TransferWikiPageOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiService_PublishWikiPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishWikiPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiServiceServer).PublishWikiPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WikiService_PublishWikiPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiServiceServer).PublishWikiPage(ctx, req.(*PublishWikiPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiService_ListWikiDrafts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWikiDraftsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiServiceServer).ListWikiDrafts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WikiService_ListWikiDrafts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiServiceServer).ListWikiDrafts(ctx, req.(*ListWikiDraftsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiService_SuggestTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiServiceServer).SuggestTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WikiService_SuggestTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiServiceServer).SuggestTags(ctx, req.(*SuggestTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WikiService_ServiceDesc is the grpc.ServiceDesc for WikiService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -552,6 +846,10 @@ var WikiService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddWikiMessageReference",
 			Handler:    _WikiService_AddWikiMessageReference_Handler,
 		},
+		{
+			MethodName: "AddWikiMessageReferencesBatch",
+			Handler:    _WikiService_AddWikiMessageReferencesBatch_Handler,
+		},
 		{
 			MethodName: "ListWikiMessageReferences",
 			Handler:    _WikiService_ListWikiMessageReferences_Handler,
@@ -560,6 +858,34 @@ var WikiService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MergeWikiPages",
 			Handler:    _WikiService_MergeWikiPages_Handler,
 		},
+		{
+			MethodName: "PreviewWikiMerge",
+			Handler:    _WikiService_PreviewWikiMerge_Handler,
+		},
+		{
+			MethodName: "UnmergeWikiPages",
+			Handler:    _WikiService_UnmergeWikiPages_Handler,
+		},
+		{
+			MethodName: "SetWikiPagePinned",
+			Handler:    _WikiService_SetWikiPagePinned_Handler,
+		},
+		{
+			MethodName: "TransferWikiPageOwnership",
+			Handler:    _WikiService_TransferWikiPageOwnership_Handler,
+		},
+		{
+			MethodName: "PublishWikiPage",
+			Handler:    _WikiService_PublishWikiPage_Handler,
+		},
+		{
+			MethodName: "ListWikiDrafts",
+			Handler:    _WikiService_ListWikiDrafts_Handler,
+		},
+		{
+			MethodName: "SuggestTags",
+			Handler:    _WikiService_SuggestTags_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "wiki.proto",
@@ -7,7 +7,9 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+	"github.com/devilmonastery/hivemind/bot/internal/bot/outbound"
 	"github.com/devilmonastery/hivemind/internal/client"
+	"github.com/devilmonastery/hivemind/internal/pkg/colorutil"
 )
 
 // PostWikiCreated posts an announcement for a newly created wiki page
@@ -39,7 +41,7 @@ func PostWikiCreated(s *discordgo.Session, grpcClient *client.Client, guildID, t
 	embed := &discordgo.MessageEmbed{
 		Title:       fmt.Sprintf("📚 New Wiki: %s", title),
 		Description: fmt.Sprintf("Created by %s", authorName),
-		Color:       0x00D9FF, // Cyan
+		Color:       announcementColor(settingsResp.Settings.GetAppearance().GetWikiColor(), 0x00D9FF), // Cyan
 		Footer: &discordgo.MessageEmbedFooter{
 			Text: "Use /wiki view to read it",
 		},
@@ -51,7 +53,17 @@ func PostWikiCreated(s *discordgo.Session, grpcClient *client.Client, guildID, t
 	}
 
 	// Post message
-	msg, err := s.ChannelMessageSendEmbed(channelID, embed)
+	var msg *discordgo.Message
+	err = outbound.Send(ctx, grpcClient, outbound.Action{
+		Operation: "wiki_announcement",
+		GuildID:   guildID,
+		ChannelID: channelID,
+		Payload:   embed.Title,
+	}, log, func(opts ...discordgo.RequestOption) error {
+		var sendErr error
+		msg, sendErr = s.ChannelMessageSendEmbed(channelID, embed, opts...)
+		return sendErr
+	})
 	if err != nil {
 		log.Warn("Failed to post wiki announcement",
 			"error", err,
@@ -110,7 +122,7 @@ func PostQuoteCreated(s *discordgo.Session, grpcClient *client.Client, guildID,
 	embed := &discordgo.MessageEmbed{
 		Title:       "💬 New Quote Added",
 		Description: description,
-		Color:       0xFF00D9, // Magenta
+		Color:       announcementColor(settingsResp.Settings.GetAppearance().GetQuoteColor(), 0xFF00D9), // Magenta
 		Footer: &discordgo.MessageEmbedFooter{
 			Text: "Use /quote random to see quotes",
 		},
@@ -123,7 +135,17 @@ func PostQuoteCreated(s *discordgo.Session, grpcClient *client.Client, guildID,
 	}
 
 	// Post message
-	msg, err := s.ChannelMessageSendEmbed(channelID, embed)
+	var msg *discordgo.Message
+	err = outbound.Send(ctx, grpcClient, outbound.Action{
+		Operation: "quote_announcement",
+		GuildID:   guildID,
+		ChannelID: channelID,
+		Payload:   embed.Description,
+	}, log, func(opts ...discordgo.RequestOption) error {
+		var sendErr error
+		msg, sendErr = s.ChannelMessageSendEmbed(channelID, embed, opts...)
+		return sendErr
+	})
 	if err != nil {
 		log.Warn("Failed to post quote announcement",
 			"error", err,
@@ -137,3 +159,11 @@ func PostQuoteCreated(s *discordgo.Session, grpcClient *client.Client, guildID,
 		"channel_id", channelID,
 		"message_id", msg.ID)
 }
+
+// announcementColor returns the guild's configured embed color, or fallback if unset or invalid
+func announcementColor(configured string, fallback int) int {
+	if color, err := colorutil.ParseHex(configured); err == nil {
+		return color
+	}
+	return fallback
+}
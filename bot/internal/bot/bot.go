@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -17,6 +18,7 @@ import (
 
 	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
 	"github.com/devilmonastery/hivemind/bot/internal/bot/handlers"
+	"github.com/devilmonastery/hivemind/bot/internal/bot/membersync"
 	"github.com/devilmonastery/hivemind/bot/internal/config"
 	botgrpc "github.com/devilmonastery/hivemind/bot/internal/grpc"
 	botmetrics "github.com/devilmonastery/hivemind/bot/internal/metrics"
@@ -32,12 +34,21 @@ type Bot struct {
 	session    *discordgo.Session
 	grpcClient *client.Client
 
+	// Gateway connection health
+	gateway *gatewayMonitor
+
 	// Autocomplete cache
 	titlesCache *handlers.TitlesCache
 
+	// Full guild member syncs, shared by the scheduled job and /hivemind sync-members
+	memberSync *membersync.Syncer
+
 	// Sync context for background jobs
 	syncCtx    context.Context
 	syncCancel context.CancelFunc
+
+	// Unix nanos of the last member sync that reached at least one guild
+	lastMemberSync atomic.Int64
 }
 
 // New creates a new Bot instance
@@ -76,10 +87,13 @@ func New(cfg *config.Config, log *slog.Logger) (*Bot, error) {
 		log:         log,
 		session:     session,
 		grpcClient:  grpcClient,
-		titlesCache: handlers.NewTitlesCache(1 * time.Minute),
+		titlesCache: handlers.NewTitlesCache(cfg.Cache.AutocompleteTTL),
+		memberSync:  membersync.New(session, discordpb.NewDiscordServiceClient(grpcClient.Conn()), log),
 		syncCtx:     syncCtx,
 		syncCancel:  syncCancel,
 	}
+	bot.gateway = newGatewayMonitor(log, cfg.Bot.ReconnectTimeout, bot.reopenSession)
+	handlers.SetGuildSettingsTTL(cfg.Cache.GuildSettingsTTL)
 
 	// Register handlers
 	bot.registerHandlers()
@@ -92,6 +106,9 @@ func (b *Bot) registerHandlers() {
 	// Ready event
 	b.session.AddHandler(b.onReady)
 
+	// Gateway connection health (Ready, Resumed, Disconnect)
+	b.gateway.register(b.session)
+
 	// Guild events
 	b.session.AddHandler(b.onGuildCreate)
 	b.session.AddHandler(b.onGuildDelete)
@@ -103,7 +120,7 @@ func (b *Bot) registerHandlers() {
 
 	// Interaction handlers
 	b.session.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		handlers.HandleInteraction(s, i, b.config, b.log, b.grpcClient, b.titlesCache)
+		handlers.HandleInteraction(s, i, b.config, b.log, b.grpcClient, b.titlesCache, b.memberSync)
 	})
 }
 
@@ -121,7 +138,7 @@ func (b *Bot) Start() error {
 		go b.startWithLeaderElection(b.syncCtx)
 	} else {
 		b.log.Info("detected standalone environment, running syncs directly")
-		go b.StartMemberSync(b.syncCtx)
+		go b.runSyncJobs(b.syncCtx)
 	}
 
 	return nil
@@ -143,11 +160,21 @@ func (b *Bot) Stop(ctx context.Context) error {
 
 	// Close Discord session
 	if b.session != nil {
+		b.gateway.stop()
 		return b.session.Close()
 	}
 	return nil
 }
 
+// reopenSession closes the Discord session and opens it again, resuming the gateway
+// session if possible; the gateway monitor calls it whenever the connection drops
+func (b *Bot) reopenSession() error {
+	if err := b.session.Close(); err != nil {
+		b.log.Warn("failed to close Discord session before reopening", slog.String("error", err.Error()))
+	}
+	return b.session.Open()
+}
+
 // onReady is called when the bot successfully connects to Discord
 func (b *Bot) onReady(s *discordgo.Session, event *discordgo.Ready) {
 	start := time.Now()
@@ -177,9 +204,6 @@ func (b *Bot) onReady(s *discordgo.Session, event *discordgo.Ready) {
 		b.log.Info("reactions disabled")
 	}
 
-	// Mark gateway as connected
-	metrics.DiscordGatewayConnected.WithLabelValues("0").Set(1)
-
 	// Set bot status
 	err := s.UpdateGameStatus(0, "/wiki • /note • /quote")
 	if err != nil {
@@ -207,6 +231,20 @@ func (b *Bot) onGuildCreate(s *discordgo.Session, event *discordgo.GuildCreate)
 		slog.Int("member_count", event.MemberCount),
 	)
 
+	if !b.config.Bot.GuildAllowed(event.ID) {
+		status = "denied"
+		b.log.Warn("guild not in allowed_guild_ids, leaving",
+			slog.String("guild_id", event.ID),
+			slog.String("guild_name", event.Name))
+		if err := s.GuildLeave(event.ID); err != nil {
+			status = "error"
+			b.log.Error("failed to leave guild",
+				slog.String("guild_id", event.ID),
+				slog.String("error", err.Error()))
+		}
+		return
+	}
+
 	// Register/update guild in database via gRPC
 	ctx := context.Background()
 	discordClient := discordpb.NewDiscordServiceClient(b.grpcClient.Conn())
@@ -216,7 +254,7 @@ func (b *Bot) onGuildCreate(s *discordgo.Session, event *discordgo.GuildCreate)
 		iconURL = urlutil.DiscordCDNIconURL(event.ID, event.Icon)
 	}
 
-	_, err := discordClient.UpsertGuild(ctx, &discordpb.UpsertGuildRequest{
+	resp, err := discordClient.UpsertGuild(ctx, &discordpb.UpsertGuildRequest{
 		GuildId:        event.ID,
 		GuildName:      event.Name,
 		IconUrl:        iconURL,
@@ -227,10 +265,16 @@ func (b *Bot) onGuildCreate(s *discordgo.Session, event *discordgo.GuildCreate)
 		b.log.Error("failed to upsert guild",
 			slog.String("guild_id", event.ID),
 			slog.String("error", err.Error()))
-	} else {
-		b.log.Info("guild registered in database",
-			slog.String("guild_id", event.ID),
-			slog.String("guild_name", event.Name))
+		return
+	}
+
+	b.log.Info("guild registered in database",
+		slog.String("guild_id", event.ID),
+		slog.String("guild_name", event.Name),
+		slog.Bool("created", resp.Created))
+
+	if shouldWelcome(b.config.Bot.Welcome, resp.Created) {
+		b.sendWelcome(s, event.Guild)
 	}
 }
 
@@ -251,6 +295,11 @@ func (b *Bot) onGuildDelete(s *discordgo.Session, event *discordgo.GuildDelete)
 		slog.String("guild_id", event.ID),
 	)
 
+	// Guilds outside the allowlist were never registered
+	if !b.config.Bot.GuildAllowed(event.ID) {
+		return
+	}
+
 	// Disable guild in database via gRPC
 	ctx := context.Background()
 	discordClient := discordpb.NewDiscordServiceClient(b.grpcClient.Conn())
@@ -282,6 +331,10 @@ func (b *Bot) onGuildMemberAdd(s *discordgo.Session, event *discordgo.GuildMembe
 		metrics.DiscordEventProcessing.WithLabelValues("guild_member_add").Observe(float64(time.Since(start).Milliseconds()))
 	}()
 
+	if !b.config.Bot.GuildAllowed(event.GuildID) {
+		return
+	}
+
 	b.log.Info("member joined guild",
 		slog.String("guild_id", event.GuildID),
 		slog.String("discord_id", event.User.ID),
@@ -335,6 +388,10 @@ func (b *Bot) onGuildMemberUpdate(s *discordgo.Session, event *discordgo.GuildMe
 		metrics.DiscordEventProcessing.WithLabelValues("guild_member_update").Observe(float64(time.Since(start).Milliseconds()))
 	}()
 
+	if !b.config.Bot.GuildAllowed(event.GuildID) {
+		return
+	}
+
 	b.log.Debug("member updated in guild",
 		slog.String("guild_id", event.GuildID),
 		slog.String("discord_id", event.User.ID))
@@ -387,6 +444,10 @@ func (b *Bot) onGuildMemberRemove(s *discordgo.Session, event *discordgo.GuildMe
 		metrics.DiscordEventProcessing.WithLabelValues("guild_member_remove").Observe(float64(time.Since(start).Milliseconds()))
 	}()
 
+	if !b.config.Bot.GuildAllowed(event.GuildID) {
+		return
+	}
+
 	b.log.Info("member left guild",
 		slog.String("guild_id", event.GuildID),
 		slog.String("discord_id", event.User.ID),
@@ -422,7 +483,7 @@ func (b *Bot) startWithLeaderElection(ctx context.Context) {
 	if err != nil {
 		b.log.Error("failed to get k8s config, falling back to direct sync",
 			slog.String("error", err.Error()))
-		b.StartMemberSync(ctx)
+		b.runSyncJobs(ctx)
 		return
 	}
 
@@ -431,7 +492,7 @@ func (b *Bot) startWithLeaderElection(ctx context.Context) {
 	id := os.Getenv("HOSTNAME")
 	if id == "" {
 		b.log.Warn("HOSTNAME not set, falling back to direct sync")
-		b.StartMemberSync(ctx)
+		b.runSyncJobs(ctx)
 		return
 	}
 
@@ -477,9 +538,9 @@ func (b *Bot) startWithLeaderElection(ctx context.Context) {
 		RetryPeriod:     2 * time.Second,
 		Callbacks: leaderelection.LeaderCallbacks{
 			OnStartedLeading: func(ctx context.Context) {
-				b.log.Info("elected as sync leader, starting member sync job",
+				b.log.Info("elected as sync leader, starting sync jobs",
 					slog.String("identity", id))
-				b.StartMemberSync(ctx)
+				b.runSyncJobs(ctx)
 			},
 			OnStoppedLeading: func() {
 				b.log.Warn("lost sync leadership, stopping sync job",
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Plan lists the API calls that bring the commands registered with Discord in
+// line with the wanted definitions
+type Plan struct {
+	Create []*discordgo.ApplicationCommand
+	Update []*discordgo.ApplicationCommand // Wanted definitions carrying the registered command's ID
+	Delete []*discordgo.ApplicationCommand // Registered commands that are no longer wanted
+}
+
+// Empty reports whether the registered commands already match
+func (p Plan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// Diff compares the commands currently registered with Discord against the
+// wanted definitions. Commands are matched by type and name; a matched command
+// is only updated when a field the bot defines differs, so fields Discord fills
+// in with defaults don't cause updates on every run.
+func Diff(current, wanted []*discordgo.ApplicationCommand) Plan {
+	registered := make(map[string]*discordgo.ApplicationCommand, len(current))
+	for _, cmd := range current {
+		registered[commandKey(cmd)] = cmd
+	}
+
+	var plan Plan
+	seen := make(map[string]bool, len(wanted))
+	for _, want := range wanted {
+		key := commandKey(want)
+		seen[key] = true
+
+		have, ok := registered[key]
+		if !ok {
+			plan.Create = append(plan.Create, want)
+			continue
+		}
+		if commandChanged(have, want) {
+			update := *want
+			update.ID = have.ID
+			plan.Update = append(plan.Update, &update)
+		}
+	}
+
+	for _, cmd := range current {
+		if !seen[commandKey(cmd)] {
+			plan.Delete = append(plan.Delete, cmd)
+		}
+	}
+
+	return plan
+}
+
+// commandKey identifies a command; chat, user, and message commands may share a name
+func commandKey(cmd *discordgo.ApplicationCommand) string {
+	return fmt.Sprintf("%d:%s", commandType(cmd), cmd.Name)
+}
+
+// commandType returns the command's type; Discord treats an unset type as a chat command
+func commandType(cmd *discordgo.ApplicationCommand) discordgo.ApplicationCommandType {
+	if cmd.Type == 0 {
+		return discordgo.ChatApplicationCommand
+	}
+	return cmd.Type
+}
+
+// commandChanged reports whether have differs from want in any field the bot defines
+func commandChanged(have, want *discordgo.ApplicationCommand) bool {
+	if have.Description != want.Description {
+		return true
+	}
+	if !reflect.DeepEqual(have.DefaultMemberPermissions, want.DefaultMemberPermissions) {
+		return true
+	}
+	if want.DMPermission != nil && (have.DMPermission == nil || *have.DMPermission != *want.DMPermission) {
+		return true
+	}
+	return !reflect.DeepEqual(canonicalJSON(have.Options), canonicalJSON(want.Options))
+}
+
+// canonicalJSON round-trips v through JSON and drops empty values (null, false,
+// "", 0, [], {}), so options compare equal whether a default was sent or omitted
+func canonicalJSON(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	return dropEmpty(decoded)
+}
+
+func dropEmpty(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			if value = dropEmpty(value); value != nil {
+				out[key] = value
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		out := make([]any, len(v))
+		for i, value := range v {
+			out[i] = dropEmpty(value)
+		}
+		return out
+	case bool:
+		if !v {
+			return nil
+		}
+	case string:
+		if v == "" {
+			return nil
+		}
+	case float64:
+		if v == 0 {
+			return nil
+		}
+	}
+	return v
+}
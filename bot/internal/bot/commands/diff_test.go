@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// registeredCopy returns defs as Discord reports them back: with IDs, an explicit
+// chat type, and defaults the bot never set filled in
+func registeredCopy(t *testing.T, defs []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand {
+	t.Helper()
+	data, err := json.Marshal(defs)
+	if err != nil {
+		t.Fatalf("marshal definitions: %v", err)
+	}
+	var registered []*discordgo.ApplicationCommand
+	if err := json.Unmarshal(data, &registered); err != nil {
+		t.Fatalf("unmarshal definitions: %v", err)
+	}
+	dmAllowed := true
+	for i, cmd := range registered {
+		cmd.ID = "id-" + cmd.Name
+		cmd.ApplicationID = "app-1"
+		cmd.Version = "1"
+		if cmd.Type == 0 {
+			cmd.Type = discordgo.ChatApplicationCommand
+		}
+		if cmd.DMPermission == nil {
+			cmd.DMPermission = &dmAllowed
+		}
+		registered[i] = cmd
+	}
+	return registered
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	defs := GetDefinitions()
+	if plan := Diff(registeredCopy(t, defs), defs); !plan.Empty() {
+		t.Errorf("Diff() of registered definitions = %d create, %d update, %d delete; want no changes",
+			len(plan.Create), len(plan.Update), len(plan.Delete))
+	}
+}
+
+func TestDiff(t *testing.T) {
+	option := func(name string, required bool) *discordgo.ApplicationCommandOption {
+		return &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        name,
+			Description: name,
+			Required:    required,
+		}
+	}
+	ping := &discordgo.ApplicationCommand{Name: "ping", Description: "Check if the bot is alive"}
+	search := &discordgo.ApplicationCommand{
+		Name:        "search",
+		Description: "Search",
+		Options:     []*discordgo.ApplicationCommandOption{option("query", true), option("scope", false)},
+	}
+	saveQuote := &discordgo.ApplicationCommand{Name: "Save as Quote", Type: discordgo.MessageApplicationCommand}
+
+	tests := []struct {
+		name       string
+		current    []*discordgo.ApplicationCommand
+		wanted     []*discordgo.ApplicationCommand
+		wantCreate []string
+		wantUpdate []string
+		wantDelete []string
+	}{
+		{
+			name:       "new commands are created",
+			wanted:     []*discordgo.ApplicationCommand{ping, saveQuote},
+			wantCreate: []string{"ping", "Save as Quote"},
+		},
+		{
+			name:       "commands no longer defined are deleted",
+			current:    registeredCopy(t, []*discordgo.ApplicationCommand{ping, search}),
+			wanted:     []*discordgo.ApplicationCommand{ping},
+			wantDelete: []string{"id-search"},
+		},
+		{
+			name:       "clearing deletes everything",
+			current:    registeredCopy(t, []*discordgo.ApplicationCommand{ping, saveQuote}),
+			wantDelete: []string{"id-ping", "id-Save as Quote"},
+		},
+		{
+			name:    "changed description is updated",
+			current: registeredCopy(t, []*discordgo.ApplicationCommand{ping}),
+			wanted: []*discordgo.ApplicationCommand{
+				{Name: "ping", Description: "Check the bot's latency"},
+			},
+			wantUpdate: []string{"id-ping"},
+		},
+		{
+			name:    "changed option is updated",
+			current: registeredCopy(t, []*discordgo.ApplicationCommand{search}),
+			wanted: []*discordgo.ApplicationCommand{{
+				Name:        "search",
+				Description: "Search",
+				Options:     []*discordgo.ApplicationCommandOption{option("query", true), option("scope", true)},
+			}},
+			wantUpdate: []string{"id-search"},
+		},
+		{
+			name:       "same name with another type is a different command",
+			current:    registeredCopy(t, []*discordgo.ApplicationCommand{{Name: "ping", Type: discordgo.UserApplicationCommand}}),
+			wanted:     []*discordgo.ApplicationCommand{ping},
+			wantCreate: []string{"ping"},
+			wantDelete: []string{"id-ping"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := Diff(tt.current, tt.wanted)
+
+			assertCommands(t, "create", plan.Create, tt.wantCreate, func(c *discordgo.ApplicationCommand) string { return c.Name })
+			assertCommands(t, "update", plan.Update, tt.wantUpdate, func(c *discordgo.ApplicationCommand) string { return c.ID })
+			assertCommands(t, "delete", plan.Delete, tt.wantDelete, func(c *discordgo.ApplicationCommand) string { return c.ID })
+		})
+	}
+}
+
+func assertCommands(t *testing.T, action string, got []*discordgo.ApplicationCommand, want []string, key func(*discordgo.ApplicationCommand) string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: got %d commands, want %v", action, len(got), want)
+		return
+	}
+	for i, cmd := range got {
+		if key(cmd) != want[i] {
+			t.Errorf("%s[%d] = %q, want %q", action, i, key(cmd), want[i])
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+)
+
+// Guild features that can be toggled per server with /hivemind features
+const (
+	FeatureWiki   = "wiki"
+	FeatureNotes  = "notes"
+	FeatureQuotes = "quotes"
+)
+
+// commandFeatures maps slash and context menu commands to the feature that gates them
+var commandFeatures = map[string]string{
+	"wiki":               FeatureWiki,
+	"Add to Wiki":        FeatureWiki,
+	"Add Thread to Wiki": FeatureWiki,
+	"note":               FeatureNotes,
+	"Create Note":        FeatureNotes,
+	"Edit Note for User": FeatureNotes,
+	"View Note for User": FeatureNotes,
+	"quote":              FeatureQuotes,
+	"Save as Quote":      FeatureQuotes,
+}
+
+// FeatureFor returns the feature gating a command, or "" if the command is always available
+func FeatureFor(commandName string) string {
+	return commandFeatures[commandName]
+}
+
+// FeatureEnabled reports whether a feature is enabled by the given settings.
+// Missing settings count as enabled.
+func FeatureEnabled(features *discordpb.FeatureSettings, feature string) bool {
+	if features == nil {
+		return true
+	}
+	switch feature {
+	case FeatureWiki:
+		return features.WikiEnabled
+	case FeatureNotes:
+		return features.NotesEnabled
+	case FeatureQuotes:
+		return features.QuotesEnabled
+	default:
+		return true
+	}
+}
+
+// FilterByFeature returns the definitions whose feature is enabled.
+// Commands that aren't tied to a feature are always kept.
+func FilterByFeature(defs []*discordgo.ApplicationCommand, features *discordpb.FeatureSettings) []*discordgo.ApplicationCommand {
+	filtered := make([]*discordgo.ApplicationCommand, 0, len(defs))
+	for _, def := range defs {
+		if !FeatureEnabled(features, FeatureFor(def.Name)) {
+			continue
+		}
+		filtered = append(filtered, def)
+	}
+	return filtered
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"testing"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+)
+
+func TestFilterByFeature(t *testing.T) {
+	defs := GetDefinitions()
+
+	// Nil settings (guild never configured) keeps everything
+	if got := FilterByFeature(defs, nil); len(got) != len(defs) {
+		t.Errorf("nil features: got %d commands, want %d", len(got), len(defs))
+	}
+
+	features := &discordpb.FeatureSettings{
+		WikiEnabled:   false,
+		NotesEnabled:  true,
+		QuotesEnabled: true,
+	}
+	names := make(map[string]bool)
+	for _, def := range FilterByFeature(defs, features) {
+		names[def.Name] = true
+	}
+
+	for _, name := range []string{"wiki", "Add to Wiki"} {
+		if names[name] {
+			t.Errorf("%q should be filtered out when wiki is disabled", name)
+		}
+	}
+	for _, name := range []string{"note", "quote", "Save as Quote", "ping", "hivemind", "prefs"} {
+		if !names[name] {
+			t.Errorf("%q should be kept when wiki is disabled", name)
+		}
+	}
+}
+
+func TestFeatureForCoversGatedCommands(t *testing.T) {
+	// Every command mapped to a feature must exist in the registry, so renames don't
+	// silently bypass the feature flags
+	registered := make(map[string]bool)
+	for _, def := range GetDefinitions() {
+		registered[def.Name] = true
+	}
+	for name := range commandFeatures {
+		if !registered[name] {
+			t.Errorf("commandFeatures references unknown command %q", name)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package commands
 
-import "github.com/bwmarrin/discordgo"
+import (
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/devilmonastery/hivemind/internal/pkg/msgtemplate"
+)
 
 // GetDefinitions returns all slash command definitions
 func GetDefinitions() []*discordgo.ApplicationCommand {
@@ -24,6 +28,13 @@ func GetDefinitions() []*discordgo.ApplicationCommand {
 							Description: "Search query",
 							Required:    true,
 						},
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "author",
+							Description: "Only pages written by this member",
+							Required:    false,
+						},
+						searchScopeOption("Where to search (default: this guild)", "Everything (Hivemind admins)"),
 					},
 				},
 				{
@@ -40,6 +51,34 @@ func GetDefinitions() []*discordgo.ApplicationCommand {
 						},
 					},
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "whoedited",
+					Description: "See who wrote and last edited a wiki page",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "title",
+							Description:  "Wiki page title",
+							Required:     true,
+							Autocomplete: true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "export",
+					Description: "Download a wiki page as a markdown file",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "title",
+							Description:  "Wiki page title",
+							Required:     true,
+							Autocomplete: true,
+						},
+					},
+				},
 				{
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 					Name:        "edit",
@@ -52,6 +91,12 @@ func GetDefinitions() []*discordgo.ApplicationCommand {
 							Required:     false,
 							Autocomplete: true,
 						},
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "draft",
+							Description: "Save a new page as a draft only you can find until it's published",
+							Required:    false,
+						},
 					},
 				},
 				{
@@ -75,6 +120,29 @@ func GetDefinitions() []*discordgo.ApplicationCommand {
 						},
 					},
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "list",
+					Description: "Browse this server's wiki pages",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "sort",
+							Description: "Page order (default: recently updated)",
+							Required:    false,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "Recently updated", Value: "updated"},
+								{Name: "Newest first", Value: "newest"},
+								{Name: "Title", Value: "title"},
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "drafts",
+					Description: "List your unpublished wiki drafts",
+				},
 			},
 		},
 		{
@@ -100,6 +168,20 @@ func GetDefinitions() []*discordgo.ApplicationCommand {
 						},
 					},
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "share",
+					Description: "Post one of your notes publicly to this channel",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "title",
+							Description:  "Note title (partial match supported)",
+							Required:     true,
+							Autocomplete: true,
+						},
+					},
+				},
 				{
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 					Name:        "search",
@@ -111,12 +193,7 @@ func GetDefinitions() []*discordgo.ApplicationCommand {
 							Description: "Search query",
 							Required:    true,
 						},
-						{
-							Type:        discordgo.ApplicationCommandOptionBoolean,
-							Name:        "guild",
-							Description: "Only search notes from this guild",
-							Required:    false,
-						},
+						searchScopeOption("Which notes to search (default: this guild)", "All my notes, including personal ones"),
 						{
 							Type:        discordgo.ApplicationCommandOptionString,
 							Name:        "tags",
@@ -129,6 +206,18 @@ func GetDefinitions() []*discordgo.ApplicationCommand {
 							Description: "Maximum number of notes to return",
 							Required:    false,
 						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "sort",
+							Description: "Result order (default: relevance)",
+							Required:    false,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "Relevance", Value: "relevance"},
+								{Name: "Newest first", Value: "newest"},
+								{Name: "Oldest first", Value: "oldest"},
+								{Name: "Recently updated", Value: "updated"},
+							},
+						},
 					},
 				},
 			},
@@ -153,7 +242,7 @@ func GetDefinitions() []*discordgo.ApplicationCommand {
 				{
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 					Name:        "search",
-					Description: "Search quotes in this guild",
+					Description: "Search quotes",
 					Options: []*discordgo.ApplicationCommandOption{
 						{
 							Type:        discordgo.ApplicationCommandOptionString,
@@ -161,6 +250,7 @@ func GetDefinitions() []*discordgo.ApplicationCommand {
 							Description: "Search query",
 							Required:    true,
 						},
+						searchScopeOption("Where to search (default: this guild)", "Everything (Hivemind admins)"),
 						{
 							Type:        discordgo.ApplicationCommandOptionString,
 							Name:        "tags",
@@ -173,8 +263,84 @@ func GetDefinitions() []*discordgo.ApplicationCommand {
 							Description: "Maximum number of quotes to return",
 							Required:    false,
 						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "sort",
+							Description: "Result order (default: relevance)",
+							Required:    false,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "Relevance", Value: "relevance"},
+								{Name: "Newest first", Value: "newest"},
+								{Name: "Oldest first", Value: "oldest"},
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "view",
+					Description: "View a quote by its ID, short code, or web link",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "quote",
+							Description: "Quote ID, short code, or a link to the quote on the web",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "stats",
+					Description: "Show quote counts and top contributors in this guild",
+				},
+			},
+		},
+		{
+			Name:        "recent",
+			Description: "Show recently added and edited wiki pages, quotes, and your notes",
+		},
+		{
+			Name:        "prefs",
+			Description: "Manage your personal preferences",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "set-default-guild",
+					Description: "Set the server used by note and quote commands run outside a server",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "guild",
+							Description:  "Server to use by default",
+							Required:     true,
+							Autocomplete: true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "clear-default-guild",
+					Description: "Stop applying a default server outside of servers",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "quote-leaderboards",
+					Description: "Choose whether you appear on quote leaderboards",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "show-me",
+							Description: "False keeps you off /quote stats and the weekly leaderboard",
+							Required:    true,
+						},
 					},
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "show",
+					Description: "Show your current preferences",
+				},
 			},
 		},
 		// Message context menu commands (right-click on messages)
@@ -190,6 +356,10 @@ func GetDefinitions() []*discordgo.ApplicationCommand {
 			Name: "Add to Wiki",
 			Type: discordgo.MessageApplicationCommand,
 		},
+		{
+			Name: "Add Thread to Wiki",
+			Type: discordgo.MessageApplicationCommand,
+		},
 		// User context menu commands (right-click on users)
 		{
 			Name: "Edit Note for User",
@@ -204,6 +374,52 @@ func GetDefinitions() []*discordgo.ApplicationCommand {
 	}
 }
 
+// Scopes offered by the wiki, note, and quote search commands
+const (
+	SearchScopeGuild      = "this-guild"
+	SearchScopeMyGuilds   = "my-guilds"
+	SearchScopeEverything = "everything"
+)
+
+// searchScopeOption returns the "scope" option shared by the search subcommands.
+// everythingLabel names the everything choice, which means something else for notes.
+func searchScopeOption(description, everythingLabel string) *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "scope",
+		Description: description,
+		Required:    false,
+		Choices: []*discordgo.ApplicationCommandOptionChoice{
+			{Name: "This guild", Value: SearchScopeGuild},
+			{Name: "All my guilds", Value: SearchScopeMyGuilds},
+			{Name: everythingLabel, Value: SearchScopeEverything},
+		},
+	}
+}
+
+// Guild settings that can be reset to their defaults with /hivemind reset
+const (
+	SettingAnnouncements    = "announcements"
+	SettingFeatures         = "features"
+	SettingColors           = "colors"
+	SettingWikiEditors      = "wiki-editors"
+	SettingReplies          = "replies"
+	SettingUniqueNoteTitles = "unique-note-titles"
+	SettingQuoteCooldown    = "quote-cooldown"
+	SettingReferenceLimit   = "reference-limit"
+	SettingMessages         = "messages"
+)
+
+// MaxQuoteCooldownSeconds is the longest quote cooldown a guild can set, a day
+const MaxQuoteCooldownSeconds = 24 * 60 * 60
+
+// DefaultReferenceLimit is how many message references each wiki page or note may have
+// until a guild sets its own limit; MaxReferenceLimit is the highest it can set
+const (
+	DefaultReferenceLimit = 500
+	MaxReferenceLimit     = 10000
+)
+
 // getHivemindCommand returns the /hivemind admin configuration command
 func getHivemindCommand() *discordgo.ApplicationCommand {
 	adminPerms := int64(discordgo.PermissionManageServer)
@@ -230,6 +446,184 @@ func getHivemindCommand() *discordgo.ApplicationCommand {
 							discordgo.ChannelTypeGuildNews,
 						},
 					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "weekly-quote-leaderboard",
+						Description: "Also post the week's top quoted members and quoters every week (default: off)",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "features",
+				Description: "Enable or disable wiki, notes, or quotes in this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "feature",
+						Description: "Feature to toggle",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Wiki", Value: "wiki"},
+							{Name: "Notes", Value: "notes"},
+							{Name: "Quotes", Value: "quotes"},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "enabled",
+						Description: "Whether the feature is enabled",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "colors",
+				Description: "Set the embed color for wiki pages, notes, or quotes",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "content",
+						Description: "Content type to recolor",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Wiki", Value: "wiki"},
+							{Name: "Notes", Value: "notes"},
+							{Name: "Quotes", Value: "quotes"},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "color",
+						Description: "Hex color like #00D9FF (omit to reset to default)",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "wiki-editors",
+				Description: "Allow or stop allowing a role to create and edit wiki pages",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionRole,
+						Name:        "role",
+						Description: "Role to change",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "allowed",
+						Description: "Whether members with this role may edit the wiki",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "replies",
+				Description: "Post shared quotes and wiki pages as a reply to the message they were saved from",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "enabled",
+						Description: "Whether to reply to the source message when it is in the same channel",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "unique-note-titles",
+				Description: "Stop members from saving two notes with the same title in this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "enabled",
+						Description: "Whether each member's note titles must be unique",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "quote-cooldown",
+				Description: "Limit how often each member can save a quote in this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "seconds",
+						Description: "Seconds a member must wait between quotes (0 for no limit)",
+						Required:    true,
+						MinValue:    new(float64),
+						MaxValue:    MaxQuoteCooldownSeconds,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "reference-limit",
+				Description: "Limit how many messages each wiki page or note can reference",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "count",
+						Description: "Most referenced messages per page or note (0 for the default of 500)",
+						Required:    true,
+						MinValue:    new(float64),
+						MaxValue:    MaxReferenceLimit,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "messages",
+				Description: "Reword a bot response for this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "event",
+						Description: "Response to reword",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Note created ({title})", Value: msgtemplate.NoteCreated},
+							{Name: "Quote saved ({author})", Value: msgtemplate.QuoteSaved},
+							{Name: "No search results ({query}, {kind})", Value: msgtemplate.NoResults},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "template",
+						Description: "New wording, e.g. \"📒 Filed {title}\" (omit to restore the default)",
+						Required:    false,
+						MaxLength:   msgtemplate.MaxLength,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "reset",
+				Description: "Reset a setting to its default",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "setting",
+						Description: "Setting to reset",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Announcements", Value: SettingAnnouncements},
+							{Name: "Features", Value: SettingFeatures},
+							{Name: "Embed colors", Value: SettingColors},
+							{Name: "Wiki editors", Value: SettingWikiEditors},
+							{Name: "Replies", Value: SettingReplies},
+							{Name: "Unique note titles", Value: SettingUniqueNoteTitles},
+							{Name: "Quote cooldown", Value: SettingQuoteCooldown},
+							{Name: "Reference limit", Value: SettingReferenceLimit},
+							{Name: "Messages", Value: SettingMessages},
+						},
+					},
 				},
 			},
 			{
@@ -237,6 +631,11 @@ func getHivemindCommand() *discordgo.ApplicationCommand {
 				Name:        "show",
 				Description: "Show current bot configuration",
 			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "sync-members",
+				Description: "Resync this server's member list now",
+			},
 		},
 	}
 }
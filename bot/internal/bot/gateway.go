@@ -0,0 +1,159 @@
+package bot
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+// gatewayShard is the shard label for gateway metrics; the bot runs a single shard
+const gatewayShard = "0"
+
+// gatewayMonitor tracks the health of the Discord gateway connection and owns
+// reconnecting it. discordgo's built-in reconnect is turned off (see
+// ShouldReconnectOnError) because it gives up silently when Open fails and would race
+// the monitor; instead the monitor reopens the session as soon as it drops, and again
+// whenever a Ready or Resumed doesn't follow within the reconnect timeout. Open resumes
+// the previous gateway session when discordgo still has one.
+type gatewayMonitor struct {
+	log     *slog.Logger
+	timeout time.Duration
+	reopen  func() error // Closes and reopens the session
+
+	mu             sync.Mutex
+	connected      bool
+	stopped        bool
+	reopening      bool
+	watchdog       *time.Timer // Pending reopen while disconnected; nil while connected
+	failedAttempts int         // Reopens since the connection was last established
+	disconnectedAt time.Time
+}
+
+// newGatewayMonitor creates a monitor that calls reopen when the gateway drops, then
+// again each time timeout passes without the connection being established
+func newGatewayMonitor(log *slog.Logger, timeout time.Duration, reopen func() error) *gatewayMonitor {
+	return &gatewayMonitor{
+		log:     log.With(slog.String("component", "gateway_monitor")),
+		timeout: timeout,
+		reopen:  reopen,
+	}
+}
+
+// register adds the monitor's event handlers to the session and turns off discordgo's
+// own reconnect, which the monitor replaces
+func (m *gatewayMonitor) register(s *discordgo.Session) {
+	s.ShouldReconnectOnError = false
+	s.AddHandler(m.onReady)
+	s.AddHandler(m.onResumed)
+	s.AddHandler(m.onDisconnect)
+}
+
+// stop marks the shutdown as intentional so the final disconnect isn't reconnected
+func (m *gatewayMonitor) stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopped = true
+	m.stopWatchdog()
+}
+
+// onReady is called when a new gateway session is identified
+func (m *gatewayMonitor) onReady(s *discordgo.Session, event *discordgo.Ready) {
+	m.established("identify")
+}
+
+// onResumed is called when a dropped gateway session is resumed
+func (m *gatewayMonitor) onResumed(s *discordgo.Session, event *discordgo.Resumed) {
+	m.established("resume")
+}
+
+// established records that the gateway is connected again; how is "identify" or "resume"
+func (m *gatewayMonitor) established(how string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metrics.DiscordGatewayConnected.WithLabelValues(gatewayShard).Set(1)
+
+	if !m.disconnectedAt.IsZero() {
+		metrics.DiscordGatewayReconnects.WithLabelValues(how).Inc()
+		m.log.Info("gateway reconnected",
+			slog.String("via", how),
+			slog.Int("failed_attempts", m.failedAttempts),
+			slog.Duration("downtime", time.Since(m.disconnectedAt)))
+	}
+
+	m.stopWatchdog()
+	m.connected = true
+	m.failedAttempts = 0
+	m.disconnectedAt = time.Time{}
+}
+
+// onDisconnect is called whenever the gateway websocket closes, including when the
+// monitor closes it to reopen
+func (m *gatewayMonitor) onDisconnect(s *discordgo.Session, event *discordgo.Disconnect) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metrics.DiscordGatewayConnected.WithLabelValues(gatewayShard).Set(0)
+
+	if m.stopped {
+		return
+	}
+
+	if m.connected {
+		m.connected = false
+		m.disconnectedAt = time.Now()
+		m.log.Warn("gateway disconnected, reconnecting")
+	}
+
+	// A reopen is already pending, or in progress and will arm the watchdog when done
+	if m.watchdog != nil || m.reopening {
+		return
+	}
+	m.watchdog = time.AfterFunc(0, m.reopenSession)
+}
+
+// reopenSession reopens the session, then arms the watchdog to try again if the
+// connection isn't established within the timeout
+func (m *gatewayMonitor) reopenSession() {
+	m.mu.Lock()
+	if m.stopped || m.connected {
+		m.watchdog = nil
+		m.mu.Unlock()
+		return
+	}
+	if m.failedAttempts > 0 {
+		m.log.Warn("gateway not reconnected in time, reopening session",
+			slog.Int("failed_attempts", m.failedAttempts),
+			slog.Duration("downtime", time.Since(m.disconnectedAt)))
+	}
+	m.failedAttempts++
+	m.reopening = true
+	m.watchdog = nil
+	m.mu.Unlock()
+
+	metrics.DiscordGatewayReconnects.WithLabelValues("reopen").Inc()
+	err := m.reopen()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reopening = false
+	if err != nil {
+		m.log.Error("failed to reopen gateway session", slog.String("error", err.Error()))
+	}
+	if m.stopped || m.connected {
+		return
+	}
+	m.watchdog = time.AfterFunc(m.timeout, m.reopenSession)
+}
+
+// stopWatchdog cancels any pending reopen; the caller must hold m.mu
+func (m *gatewayMonitor) stopWatchdog() {
+	if m.watchdog != nil {
+		m.watchdog.Stop()
+		m.watchdog = nil
+	}
+}
@@ -0,0 +1,122 @@
+package bot
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+func gatewayConnected() float64 {
+	return testutil.ToFloat64(metrics.DiscordGatewayConnected.WithLabelValues(gatewayShard))
+}
+
+func waitReopened(t *testing.T, reopened <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-reopened:
+	case <-time.After(time.Second):
+		t.Fatalf("session not reopened %s", what)
+	}
+}
+
+func TestGatewayMonitorTransitions(t *testing.T) {
+	reopened := make(chan struct{}, 10)
+	m := newGatewayMonitor(slog.New(slog.NewTextHandler(io.Discard, nil)), time.Hour, func() error {
+		reopened <- struct{}{}
+		return nil
+	})
+
+	m.onReady(nil, &discordgo.Ready{})
+	if got := gatewayConnected(); got != 1 {
+		t.Errorf("after ready: connected metric = %v, want 1", got)
+	}
+
+	// A drop reopens the session straight away
+	m.onDisconnect(nil, &discordgo.Disconnect{})
+	if got := gatewayConnected(); got != 0 {
+		t.Errorf("after drop: connected metric = %v, want 0", got)
+	}
+	waitReopened(t, reopened, "after drop")
+
+	// The disconnect the reopen's Close emits doesn't start another one
+	m.onDisconnect(nil, &discordgo.Disconnect{})
+	select {
+	case <-reopened:
+		t.Fatal("session reopened again before the watchdog timeout")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	m.onResumed(nil, &discordgo.Resumed{})
+	if got := gatewayConnected(); got != 1 {
+		t.Errorf("after resume: connected metric = %v, want 1", got)
+	}
+	m.mu.Lock()
+	failed, watchdog := m.failedAttempts, m.watchdog
+	m.mu.Unlock()
+	if failed != 0 || watchdog != nil {
+		t.Errorf("after resume: failed attempts = %d, watchdog armed = %v, want 0, false", failed, watchdog != nil)
+	}
+}
+
+func TestGatewayMonitorWatchdogRetries(t *testing.T) {
+	reopened := make(chan struct{}, 10)
+	// Open failing outright emits no Disconnect, so only the watchdog can try again
+	m := newGatewayMonitor(slog.New(slog.NewTextHandler(io.Discard, nil)), 10*time.Millisecond, func() error {
+		reopened <- struct{}{}
+		return errors.New("gateway unavailable")
+	})
+
+	m.onReady(nil, &discordgo.Ready{})
+	m.onDisconnect(nil, &discordgo.Disconnect{})
+	waitReopened(t, reopened, "after drop")
+	waitReopened(t, reopened, "by the watchdog")
+	waitReopened(t, reopened, "by the watchdog again")
+
+	m.onReady(nil, &discordgo.Ready{})
+	// Drain a reopen that was already running when the session came back
+	select {
+	case <-reopened:
+	case <-time.After(50 * time.Millisecond):
+	}
+	select {
+	case <-reopened:
+		t.Fatal("session reopened after the connection was established")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestGatewayMonitorStopped(t *testing.T) {
+	m := newGatewayMonitor(slog.New(slog.NewTextHandler(io.Discard, nil)), time.Millisecond, func() error {
+		t.Error("session reopened after stop")
+		return nil
+	})
+
+	m.onReady(nil, &discordgo.Ready{})
+	m.stop()
+	m.onDisconnect(nil, &discordgo.Disconnect{})
+	time.Sleep(20 * time.Millisecond)
+
+	if got := gatewayConnected(); got != 0 {
+		t.Errorf("connected metric = %v, want 0", got)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.watchdog != nil {
+		t.Error("watchdog armed after stop")
+	}
+}
+
+func TestGatewayMonitorRegisterDisablesBuiltInReconnect(t *testing.T) {
+	s := &discordgo.Session{ShouldReconnectOnError: true}
+	newGatewayMonitor(slog.New(slog.NewTextHandler(io.Discard, nil)), time.Second, nil).register(s)
+	if s.ShouldReconnectOnError {
+		t.Error("ShouldReconnectOnError = true, want false so only the monitor reconnects")
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// newTestGRPCClient serves the services register adds on a local port and returns a
+// client connected to them, so handlers can run against fake backend services
+func newTestGRPCClient(t *testing.T, register func(*grpc.Server)) *client.Client {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	register(server)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	grpcClient, err := client.New(client.Options{Address: lis.Addr().String()})
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+	t.Cleanup(func() { grpcClient.Close() })
+	return grpcClient
+}
+
+// indexOf returns the position of the first recorded request with method whose path
+// contains pathPart, or -1
+func (f *fakeDiscord) indexOf(method, pathPart string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for n, req := range f.requests {
+		if req.method == method && strings.Contains(req.path, pathPart) {
+			return n
+		}
+	}
+	return -1
+}
@@ -1,14 +1,29 @@
 package handlers
 
 import (
+	"context"
+	"log/slog"
 	"sync"
 	"time"
 )
 
+// autocompleteFetchTimeout is how long autocomplete waits for the backend on a cache
+// miss, leaving time to answer within the 3 seconds Discord allows
+const autocompleteFetchTimeout = 1500 * time.Millisecond
+
+// autocompleteBackgroundTimeout bounds a fetch that outlived autocompleteFetchTimeout
+// and carries on in the background to fill the cache
+const autocompleteBackgroundTimeout = 10 * time.Second
+
+// staleTitlesFor is how long titles are kept after they expire, to answer autocomplete
+// when the backend is slow. Older entries are evicted.
+const staleTitlesFor = 10 * time.Minute
+
 // TitlesCacheEntry holds cached titles with expiration
 type TitlesCacheEntry struct {
-	Titles    []TitleSuggestion
-	ExpiresAt time.Time
+	Titles     []TitleSuggestion
+	ExpiresAt  time.Time
+	StaleUntil time.Time // When the entry is evicted rather than used as a fallback
 }
 
 // TitleSuggestion represents a cached title
@@ -18,79 +33,180 @@ type TitleSuggestion struct {
 	Slug  string
 }
 
-// TitlesCache manages autocomplete caches with thread-safe operations
+// TitlesCache manages autocomplete caches with thread-safe operations.
+// Expired entries are kept for staleTitlesFor, so a slow backend can be answered
+// with stale titles.
 type TitlesCache struct {
-	wikiCache sync.Map // map[guildID]TitlesCacheEntry
-	noteCache sync.Map // map[userID:guildID]TitlesCacheEntry
-	ttl       time.Duration
+	wikiCache    sync.Map // map[guildID]TitlesCacheEntry
+	noteCache    sync.Map // map[userID:guildID]TitlesCacheEntry
+	fetching     sync.Map // map[fetch key]struct{} for fetches in progress
+	mu           sync.Mutex
+	generations  map[string]uint64 // fetch key -> times invalidated, guarded by mu
+	ttl          time.Duration
+	fetchTimeout time.Duration
 }
 
 // NewTitlesCache creates a new titles cache
 func NewTitlesCache(ttl time.Duration) *TitlesCache {
 	return &TitlesCache{
-		ttl: ttl,
+		generations:  make(map[string]uint64),
+		ttl:          ttl,
+		fetchTimeout: autocompleteFetchTimeout,
 	}
 }
 
-// GetWikiTitles returns cached wiki titles or nil if cache miss/expired
-func (c *TitlesCache) GetWikiTitles(guildID string) []TitleSuggestion {
-	val, ok := c.wikiCache.Load(guildID)
+// wikiTitlesKey is the fetch key of a guild's wiki titles
+func wikiTitlesKey(guildID string) string {
+	return "wiki:" + guildID
+}
+
+// noteTitlesKey is the fetch key of a user's note titles in a guild
+func noteTitlesKey(userID, guildID string) string {
+	return "note:" + userID + ":" + guildID
+}
+
+// loadTitles returns the titles cached in m under key, or nil if none are cached.
+// Expired titles are only returned when stale is true, and are evicted once they
+// are too old to fall back on.
+func loadTitles(m *sync.Map, key string, stale bool) []TitleSuggestion {
+	val, ok := m.Load(key)
 	if !ok {
 		return nil
 	}
 
 	entry := val.(TitlesCacheEntry)
-	if time.Now().After(entry.ExpiresAt) {
-		c.wikiCache.Delete(guildID)
+	now := time.Now()
+	if now.After(entry.StaleUntil) {
+		m.Delete(key)
+		return nil
+	}
+	if !stale && now.After(entry.ExpiresAt) {
 		return nil
 	}
 
 	return entry.Titles
 }
 
+// newEntry returns a cache entry for titles fetched now
+func (c *TitlesCache) newEntry(titles []TitleSuggestion) TitlesCacheEntry {
+	expiresAt := time.Now().Add(c.ttl)
+	return TitlesCacheEntry{
+		Titles:     titles,
+		ExpiresAt:  expiresAt,
+		StaleUntil: expiresAt.Add(staleTitlesFor),
+	}
+}
+
+// invalidate drops cached titles with drop and bumps key's generation, so a fetch
+// that started before can't store its outdated titles
+func (c *TitlesCache) invalidate(key string, drop func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[key]++
+	drop()
+}
+
+// GetWikiTitles returns cached wiki titles or nil if cache miss/expired
+func (c *TitlesCache) GetWikiTitles(guildID string) []TitleSuggestion {
+	return loadTitles(&c.wikiCache, guildID, false)
+}
+
+// GetStaleWikiTitles returns cached wiki titles for a guild even if expired, or nil if none are cached
+func (c *TitlesCache) GetStaleWikiTitles(guildID string) []TitleSuggestion {
+	return loadTitles(&c.wikiCache, guildID, true)
+}
+
 // SetWikiTitles caches wiki titles for a guild
 func (c *TitlesCache) SetWikiTitles(guildID string, titles []TitleSuggestion) {
-	c.wikiCache.Store(guildID, TitlesCacheEntry{
-		Titles:    titles,
-		ExpiresAt: time.Now().Add(c.ttl),
-	})
+	c.wikiCache.Store(guildID, c.newEntry(titles))
 }
 
 // InvalidateWikiTitles removes cached wiki titles for a guild
 func (c *TitlesCache) InvalidateWikiTitles(guildID string) {
-	c.wikiCache.Delete(guildID)
+	c.invalidate(wikiTitlesKey(guildID), func() {
+		c.wikiCache.Delete(guildID)
+	})
 }
 
 // GetNoteTitles returns cached note titles or nil if cache miss/expired
 func (c *TitlesCache) GetNoteTitles(userID, guildID string) []TitleSuggestion {
-	key := userID + ":" + guildID
-	val, ok := c.noteCache.Load(key)
-	if !ok {
-		return nil
-	}
-
-	entry := val.(TitlesCacheEntry)
-	if time.Now().After(entry.ExpiresAt) {
-		c.noteCache.Delete(key)
-		return nil
-	}
+	return loadTitles(&c.noteCache, userID+":"+guildID, false)
+}
 
-	return entry.Titles
+// GetStaleNoteTitles returns cached note titles for a user in a guild even if expired,
+// or nil if none are cached
+func (c *TitlesCache) GetStaleNoteTitles(userID, guildID string) []TitleSuggestion {
+	return loadTitles(&c.noteCache, userID+":"+guildID, true)
 }
 
 // SetNoteTitles caches note titles for a user in a guild
 func (c *TitlesCache) SetNoteTitles(userID, guildID string, titles []TitleSuggestion) {
-	key := userID + ":" + guildID
-	c.noteCache.Store(key, TitlesCacheEntry{
-		Titles:    titles,
-		ExpiresAt: time.Now().Add(c.ttl),
-	})
+	c.noteCache.Store(userID+":"+guildID, c.newEntry(titles))
 }
 
 // InvalidateNoteTitles removes cached note titles for a user in a guild
 func (c *TitlesCache) InvalidateNoteTitles(userID, guildID string) {
-	key := userID + ":" + guildID
-	c.noteCache.Delete(key)
+	c.invalidate(noteTitlesKey(userID, guildID), func() {
+		c.noteCache.Delete(userID + ":" + guildID)
+	})
+}
+
+// fetchTitles fetches titles for a cache miss and stores them with store. If the fetch
+// fails, or takes longer than the cache's fetch timeout, it returns stale instead; a
+// slow fetch carries on in the background so the next keystroke finds the titles
+// cached. While a fetch for key is in progress, further calls return stale at once.
+// Titles are not stored if key is invalidated while they are being fetched.
+func (c *TitlesCache) fetchTitles(ctx context.Context, key string, stale []TitleSuggestion, fetch func(ctx context.Context) ([]TitleSuggestion, error), store func([]TitleSuggestion), log *slog.Logger) ([]TitleSuggestion, error) {
+	if _, inProgress := c.fetching.LoadOrStore(key, struct{}{}); inProgress {
+		return stale, nil
+	}
+
+	c.mu.Lock()
+	generation := c.generations[key]
+	c.mu.Unlock()
+
+	type result struct {
+		titles []TitleSuggestion
+		err    error
+	}
+	done := make(chan result, 1)
+
+	// Not tied to the interaction, so a slow fetch can outlive the autocomplete response
+	fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), autocompleteBackgroundTimeout)
+	start := time.Now()
+	go func() {
+		titles, err := fetch(fetchCtx)
+		cancel()
+		if err == nil {
+			c.mu.Lock()
+			if c.generations[key] == generation {
+				store(titles)
+			}
+			c.mu.Unlock()
+		} else if time.Since(start) >= c.fetchTimeout {
+			log.Warn("background autocomplete fetch failed", "key", key, "error", err)
+		}
+		c.fetching.Delete(key)
+		done <- result{titles, err}
+	}()
+
+	timer := time.NewTimer(c.fetchTimeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-done:
+		if r.err != nil && stale != nil {
+			log.Warn("autocomplete fetch failed, using stale titles", "key", key, "error", r.err)
+			return stale, nil
+		}
+		return r.titles, r.err
+	case <-timer.C:
+		log.Warn("autocomplete fetch is slow, using stale titles",
+			"key", key,
+			"timeout", c.fetchTimeout,
+			"stale_titles", len(stale))
+		return stale, nil
+	}
 }
 
 // FilterTitles filters cached titles by query (case-insensitive substring match)
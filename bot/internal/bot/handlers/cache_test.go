@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestTitlesCache_InvalidateWikiTitles(t *testing.T) {
+	c := NewTitlesCache(time.Hour)
+	c.SetWikiTitles("guild-1", []TitleSuggestion{{ID: "1", Title: "Dragons"}})
+	c.SetWikiTitles("guild-2", []TitleSuggestion{{ID: "2", Title: "Castles"}})
+
+	c.InvalidateWikiTitles("guild-1")
+
+	if got := c.GetWikiTitles("guild-1"); got != nil {
+		t.Errorf("GetWikiTitles(guild-1) after invalidation = %v, want nil", got)
+	}
+	if got := c.GetWikiTitles("guild-2"); len(got) != 1 {
+		t.Errorf("GetWikiTitles(guild-2) = %v, other guilds should stay cached", got)
+	}
+}
+
+func TestTitlesCache_InvalidateNoteTitles(t *testing.T) {
+	c := NewTitlesCache(time.Hour)
+	c.SetNoteTitles("user-1", "guild-1", []TitleSuggestion{{ID: "1", Title: "Shopping"}})
+	c.SetNoteTitles("user-1", "guild-2", []TitleSuggestion{{ID: "2", Title: "Raid plan"}})
+	c.SetNoteTitles("user-2", "guild-1", []TitleSuggestion{{ID: "3", Title: "Ideas"}})
+
+	c.InvalidateNoteTitles("user-1", "guild-1")
+
+	if got := c.GetNoteTitles("user-1", "guild-1"); got != nil {
+		t.Errorf("GetNoteTitles(user-1, guild-1) after invalidation = %v, want nil", got)
+	}
+	if got := c.GetNoteTitles("user-1", "guild-2"); len(got) != 1 {
+		t.Errorf("GetNoteTitles(user-1, guild-2) = %v, other guilds should stay cached", got)
+	}
+	if got := c.GetNoteTitles("user-2", "guild-1"); len(got) != 1 {
+		t.Errorf("GetNoteTitles(user-2, guild-1) = %v, other users should stay cached", got)
+	}
+}
+
+func TestTitlesCache_TTL(t *testing.T) {
+	c := NewTitlesCache(-time.Second) // already expired when stored
+	c.SetWikiTitles("guild-1", []TitleSuggestion{{ID: "1", Title: "Dragons"}})
+	c.SetNoteTitles("user-1", "guild-1", []TitleSuggestion{{ID: "1", Title: "Shopping"}})
+
+	if got := c.GetWikiTitles("guild-1"); got != nil {
+		t.Errorf("GetWikiTitles() past TTL = %v, want nil", got)
+	}
+	if got := c.GetNoteTitles("user-1", "guild-1"); got != nil {
+		t.Errorf("GetNoteTitles() past TTL = %v, want nil", got)
+	}
+}
+
+func TestTitlesCache_EvictsOldEntries(t *testing.T) {
+	c := NewTitlesCache(-staleTitlesFor - time.Second) // too old to fall back on when stored
+	c.SetWikiTitles("guild-1", []TitleSuggestion{{ID: "1", Title: "Dragons"}})
+	c.SetNoteTitles("user-1", "guild-1", []TitleSuggestion{{ID: "1", Title: "Shopping"}})
+
+	if got := c.GetWikiTitles("guild-1"); got != nil {
+		t.Errorf("GetWikiTitles() past the stale window = %v, want nil", got)
+	}
+	if got := c.GetStaleNoteTitles("user-1", "guild-1"); got != nil {
+		t.Errorf("GetStaleNoteTitles() past the stale window = %v, want nil", got)
+	}
+	if _, ok := c.wikiCache.Load("guild-1"); ok {
+		t.Error("old wiki titles were not evicted")
+	}
+	if _, ok := c.noteCache.Load("user-1:guild-1"); ok {
+		t.Error("old note titles were not evicted")
+	}
+}
+
+func TestTitlesCache_InvalidateDuringFetch(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c := NewTitlesCache(time.Hour)
+	c.fetchTimeout = 10 * time.Millisecond
+
+	release := make(chan struct{})
+	fetched := make(chan struct{})
+	fetch := func(ctx context.Context) ([]TitleSuggestion, error) {
+		<-release
+		defer close(fetched)
+		return []TitleSuggestion{{ID: "1", Title: "Dragons"}}, nil
+	}
+	store := func(titles []TitleSuggestion) { c.SetWikiTitles("guild-1", titles) }
+
+	if _, err := c.fetchTitles(context.Background(), wikiTitlesKey("guild-1"), nil, fetch, store, log); err != nil {
+		t.Fatalf("fetchTitles() error = %v", err)
+	}
+
+	// A page is created while the titles are still being fetched
+	c.InvalidateWikiTitles("guild-1")
+	close(release)
+	<-fetched
+	// Let the background fetch finish storing, or not
+	for i := 0; i < 100; i++ {
+		if _, inProgress := c.fetching.Load(wikiTitlesKey("guild-1")); !inProgress {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if got := c.GetStaleWikiTitles("guild-1"); got != nil {
+		t.Errorf("GetStaleWikiTitles() after invalidating during a fetch = %v, want nil", got)
+	}
+}
+
+func TestTitlesCache_FetchTimeoutFallsBackToStale(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c := NewTitlesCache(-time.Second) // already expired when stored
+	c.fetchTimeout = 10 * time.Millisecond
+	c.SetWikiTitles("guild-1", []TitleSuggestion{{ID: "1", Title: "Dragons"}})
+
+	if got := c.GetWikiTitles("guild-1"); got != nil {
+		t.Fatalf("GetWikiTitles() past TTL = %v, want nil", got)
+	}
+	stale := c.GetStaleWikiTitles("guild-1")
+	if len(stale) != 1 {
+		t.Fatalf("GetStaleWikiTitles() = %v, want the expired titles", stale)
+	}
+
+	release := make(chan struct{})
+	stored := make(chan struct{})
+	fresh := []TitleSuggestion{{ID: "1", Title: "Dragons"}, {ID: "2", Title: "Castles"}}
+	fetch := func(ctx context.Context) ([]TitleSuggestion, error) {
+		<-release
+		return fresh, nil
+	}
+	store := func(titles []TitleSuggestion) {
+		c.SetWikiTitles("guild-1", titles)
+		close(stored)
+	}
+
+	got, err := c.fetchTitles(context.Background(), "wiki:guild-1", stale, fetch, store, log)
+	if err != nil {
+		t.Fatalf("fetchTitles() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Title != "Dragons" {
+		t.Errorf("fetchTitles() after timeout = %v, want the stale titles", got)
+	}
+
+	// Another keystroke while the fetch is still running doesn't start a second one
+	got, err = c.fetchTitles(context.Background(), "wiki:guild-1", stale, func(ctx context.Context) ([]TitleSuggestion, error) {
+		t.Error("fetch started while another was in progress")
+		return nil, nil
+	}, store, log)
+	if err != nil || len(got) != 1 {
+		t.Errorf("fetchTitles() during a fetch = %v, %v, want the stale titles", got, err)
+	}
+
+	// The slow fetch still fills the cache for the next keystroke
+	close(release)
+	select {
+	case <-stored:
+	case <-time.After(time.Second):
+		t.Fatal("background fetch never stored its titles")
+	}
+	if got := c.GetStaleWikiTitles("guild-1"); len(got) != 2 {
+		t.Errorf("GetStaleWikiTitles() after the background fetch = %v, want the fresh titles", got)
+	}
+}
+
+func TestTitlesCache_FetchError(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c := NewTitlesCache(time.Hour)
+	fail := func(ctx context.Context) ([]TitleSuggestion, error) {
+		return nil, errors.New("backend down")
+	}
+	store := func([]TitleSuggestion) { t.Error("failed fetch stored titles") }
+
+	stale := []TitleSuggestion{{ID: "1", Title: "Shopping"}}
+	if got, err := c.fetchTitles(context.Background(), "note:u1:g1", stale, fail, store, log); err != nil || len(got) != 1 {
+		t.Errorf("fetchTitles() with stale titles = %v, %v, want the stale titles", got, err)
+	}
+	if _, err := c.fetchTitles(context.Background(), "note:u1:g1", nil, fail, store, log); err == nil {
+		t.Error("fetchTitles() without stale titles returned no error")
+	}
+}
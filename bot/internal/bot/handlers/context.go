@@ -10,15 +10,30 @@ import (
 // discordContextFor creates a gRPC context with Discord user identity from an interaction.
 // It extracts the Discord user ID, guild ID, and preferred username (nick if set, otherwise username)
 // and embeds them as metadata for the backend to identify the user making the request.
+// Retries of backend calls made with it stop before the interaction token expires.
 func discordContextFor(i *discordgo.InteractionCreate) context.Context {
-	username := i.Member.User.Username
-	if i.Member.Nick != "" {
+	user := interactionUser(i)
+	username := user.Username
+	if i.Member != nil && i.Member.Nick != "" {
 		username = i.Member.Nick
 	}
+	ctx := botgrpc.WithInteractionDeadline(context.Background(), i.ID)
 	return botgrpc.WithDiscordContext(
-		context.Background(),
-		i.Member.User.ID,
+		ctx,
+		user.ID,
 		i.GuildID,
 		username,
 	)
 }
+
+// interactionUser returns the user who triggered an interaction.
+// Guild interactions populate i.Member, while DM interactions only populate i.User.
+func interactionUser(i *discordgo.InteractionCreate) *discordgo.User {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User
+	}
+	if i.User != nil {
+		return i.User
+	}
+	return &discordgo.User{}
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
@@ -14,10 +16,11 @@ import (
 	"github.com/devilmonastery/hivemind/bot/internal/bot/announcements"
 	"github.com/devilmonastery/hivemind/bot/internal/config"
 	"github.com/devilmonastery/hivemind/internal/client"
+	"github.com/devilmonastery/hivemind/internal/pkg/msgtemplate"
 )
 
 // handleContextMenuQuote handles "Save as Quote" context menu command
-func handleContextMenuQuote(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, grpcClient *client.Client) {
+func handleContextMenuQuote(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
 	// Get the target message
 	targetID := i.ApplicationCommandData().TargetID
 	message := i.ApplicationCommandData().Resolved.Messages[targetID]
@@ -27,6 +30,24 @@ func handleContextMenuQuote(s *discordgo.Session, i *discordgo.InteractionCreate
 		return
 	}
 
+	// Offer the existing quote instead of a modal that would only save it again
+	if existing := findQuoteBySourceMessage(i, targetID, grpcClient, log); existing != nil {
+		params := duplicateQuoteMessage(existing, cfg, guildEmbedColors(existing.GuildId, grpcClient, log).Quote)
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content:    params.Content,
+				Embeds:     params.Embeds,
+				Components: params.Components,
+				Flags:      params.Flags,
+			},
+		})
+		if err != nil {
+			log.Error("Failed to show existing quote", "error", err)
+		}
+		return
+	}
+
 	// Show modal with pre-filled data
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
@@ -55,6 +76,28 @@ func handleContextMenuQuote(s *discordgo.Session, i *discordgo.InteractionCreate
 	}
 }
 
+// findQuoteBySourceMessage returns the quote already saved from messageID in the
+// interaction's guild, or nil if there is none or the lookup fails
+func findQuoteBySourceMessage(i *discordgo.InteractionCreate, messageID string, grpcClient *client.Client, log *slog.Logger) *quotespb.Quote {
+	if i.GuildID == "" {
+		return nil
+	}
+
+	quoteClient := quotespb.NewQuoteServiceClient(grpcClient.Conn())
+	quote, err := quoteClient.GetQuoteBySourceMessage(discordContextFor(i), &quotespb.GetQuoteBySourceMessageRequest{
+		GuildId:     i.GuildID,
+		SourceMsgId: messageID,
+	})
+	if err != nil {
+		// CreateQuote still catches duplicates, so a failed lookup only costs the shortcut
+		if status.Code(err) != codes.NotFound {
+			log.Warn("failed to look up quote by source message", "error", err, "message_id", messageID)
+		}
+		return nil
+	}
+	return quote
+}
+
 // handleContextMenuNote handles "Create Note" context menu command
 func handleContextMenuNote(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
 	// Get the target message
@@ -121,6 +164,10 @@ func handleContextMenuWiki(s *discordgo.Session, i *discordgo.InteractionCreate,
 		return
 	}
 
+	if !requireWikiEditRole(s, i, log, grpcClient) {
+		return
+	}
+
 	// Defer to avoid timeout while fetching pages
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
@@ -138,8 +185,9 @@ func handleContextMenuWiki(s *discordgo.Session, i *discordgo.InteractionCreate,
 	ctx := discordContextFor(i)
 
 	pagesResp, err := wikiClient.ListWikiPages(ctx, &wikipb.ListWikiPagesRequest{
-		GuildId: i.GuildID,
-		Limit:   25, // Discord limit for select menu options
+		GuildId:  i.GuildID,
+		Limit:    25, // Discord limit for select menu options
+		OmitBody: true,
 	})
 
 	var selectOptions []discordgo.SelectMenuOption
@@ -163,8 +211,9 @@ func handleContextMenuWiki(s *discordgo.Session, i *discordgo.InteractionCreate,
 			}
 
 			selectOptions = append(selectOptions, discordgo.SelectMenuOption{
-				Label: displayTitle,
-				Value: page.Title, // Store full title in value
+				Label:       displayTitle,
+				Value:       page.Title, // Store full title in value
+				Description: contentSizeSummary(page.BodyLength, page.ReferenceCount),
 			})
 		}
 	} else if err != nil {
@@ -197,7 +246,7 @@ func handleContextMenuWiki(s *discordgo.Session, i *discordgo.InteractionCreate,
 			"error", err, 
 			"guild_id", i.GuildID,
 			"channel_id", i.ChannelID,
-			"user_id", i.Member.User.ID)
+			"user_id", interactionUser(i).ID)
 	} else {
 		log.Info("Successfully created wiki page select menu", "message_id", followupMsg.ID)
 	}
@@ -377,20 +426,25 @@ func handleContextQuoteModal(s *discordgo.Session, i *discordgo.InteractionCreat
 	if err != nil {
 		log.Error("Failed to create quote", "error", err)
 		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("❌ Failed to create quote: %v", err),
+			Content: quoteCreateFailedMessage(err),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		})
 		return
 	}
 
+	if resp.WasDuplicate {
+		respondDuplicateQuote(s, i, resp, cfg, grpcClient, log)
+		return
+	}
+
 	// Add reaction to source message
 	if sourceMessageID != "" && sourceChannelID != "" {
 		addQuoteReaction(s, cfg, sourceChannelID, sourceMessageID, log)
 	}
 
 	// Show the created quote with standard embed
-	embed := buildQuoteEmbed(resp)
-	embed.Title = "✅ Quote Saved"
+	embed := buildQuoteEmbed(resp, guildEmbedColors(resp.GuildId, grpcClient, log).Quote)
+	embed.Title = quoteSavedTitle(resp, grpcClient, log)
 
 	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
 		Embeds: []*discordgo.MessageEmbed{embed},
@@ -440,7 +494,7 @@ func handleContextQuoteModal(s *discordgo.Session, i *discordgo.InteractionCreat
 }
 
 // handleContextNoteModal handles the modal submission for context menu note
-func handleContextNoteModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+func handleContextNoteModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
 	data := i.ModalSubmitData()
 
 	var title, body string
@@ -499,12 +553,10 @@ func handleContextNoteModal(s *discordgo.Session, i *discordgo.InteractionCreate
 	resp, err := noteClient.CreateNote(ctx, req)
 	if err != nil {
 		log.Error("Failed to create note", "error", err)
-		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("❌ Failed to create note: %v", err),
-			Flags:   discordgo.MessageFlagsEphemeral,
-		})
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, noteSaveFailedMessage("create", title, err))
 		return
 	}
+	cache.InvalidateNoteTitles(interactionUser(i).ID, resp.GuildId)
 
 	// If we have a message ID, add it as a reference to the note
 	if messageID != "" {
@@ -536,7 +588,7 @@ func handleContextNoteModal(s *discordgo.Session, i *discordgo.InteractionCreate
 				MessageId:         message.ID,
 				ChannelId:         message.ChannelID,
 				GuildId:           i.GuildID,
-				Content:           message.Content,
+				Content:           messageReferenceContent(message),
 				AuthorId:          message.Author.ID,
 				AuthorUsername:    message.Author.Username,
 				AuthorDisplayName: authorDisplayName,
@@ -559,8 +611,8 @@ func handleContextNoteModal(s *discordgo.Session, i *discordgo.InteractionCreate
 	refs := fetchNoteMessageReferences(ctx, noteClient, resp.Id, log)
 
 	// Show standard note embed
-	embed, components := createNoteEmbed(resp, refs, cfg, log)
-	embed.Title = "✅ Note Created\n\n" + embed.Title
+	embed, components := createNoteEmbed(s, resp, refs, cfg, guildEmbedColors(resp.GuildId, grpcClient, log).Note, log)
+	embed.Title = noteCreatedHeading(resp, grpcClient, log) + "\n\n" + embed.Title
 
 	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
 		Embeds:     []*discordgo.MessageEmbed{embed},
@@ -573,7 +625,7 @@ func handleContextNoteModal(s *discordgo.Session, i *discordgo.InteractionCreate
 }
 
 // handleContextWikiModal handles the modal submission for context menu wiki
-func handleContextWikiModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+func handleContextWikiModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
 	data := i.ModalSubmitData()
 
 	var title, body string
@@ -669,6 +721,7 @@ func handleContextWikiModal(s *discordgo.Session, i *discordgo.InteractionCreate
 		})
 		return
 	}
+	cache.InvalidateWikiTitles(i.GuildID)
 
 	page := resp.Page
 
@@ -702,7 +755,7 @@ func handleContextWikiModal(s *discordgo.Session, i *discordgo.InteractionCreate
 				MessageId:         message.ID,
 				ChannelId:         message.ChannelID,
 				GuildId:           i.GuildID,
-				Content:           message.Content,
+				Content:           messageReferenceContent(message),
 				AuthorId:          message.Author.ID,
 				AuthorUsername:    message.Author.Username,
 				AuthorDisplayName: authorDisplayName,
@@ -725,7 +778,7 @@ func handleContextWikiModal(s *discordgo.Session, i *discordgo.InteractionCreate
 	refs := fetchWikiMessageReferences(ctx, wikiClient, page.Id, log)
 
 	// Show standard wiki embed
-	embed, components := showWikiDetailEmbed(s, page, refs, cfg, "", false)
+	embed, components := showWikiDetailEmbed(s, page, refs, cfg, guildEmbedColors(page.GuildId, grpcClient, log).Wiki, "", false)
 
 	// Set title based on whether page was created or updated
 	if resp.Created {
@@ -771,7 +824,7 @@ func handleContextMenuWikiRef(s *discordgo.Session, i *discordgo.InteractionCrea
 }
 
 // handleContextWikiUnifiedModal handles submission of the unified wiki modal
-func handleContextWikiUnifiedModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+func handleContextWikiUnifiedModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
 	// Parse custom ID: context_wiki_unified_modal:MessageID:PageID
 	customID := i.ModalSubmitData().CustomID
 	parts := strings.Split(customID, ":")
@@ -828,7 +881,8 @@ func handleContextWikiUnifiedModal(s *discordgo.Session, i *discordgo.Interactio
 	// Use the guild ID from the interaction instead
 	guildID := i.GuildID
 
-	// Note: message.Content might be empty if bot lacks MESSAGE_CONTENT intent
+	// Note: message.Content might be empty if bot lacks MESSAGE_CONTENT intent;
+	// messageReferenceContent substitutes a placeholder for the stored reference
 	// Log what we got
 	log.Info("Fetched message for wiki reference",
 		"message_id", message.ID,
@@ -898,6 +952,10 @@ func handleContextWikiUnifiedModal(s *discordgo.Session, i *discordgo.Interactio
 			}
 			return
 		}
+		if upsertResp.Created {
+			// Refresh /wiki autocomplete so the new title shows up
+			cache.InvalidateWikiTitles(upsertResp.Page.GuildId)
+		}
 
 		// Add message as reference to the page (whether new or existing)
 		_, err = wikiClient.AddWikiMessageReference(discordContextFor(i), &wikipb.AddWikiMessageReferenceRequest{
@@ -905,7 +963,7 @@ func handleContextWikiUnifiedModal(s *discordgo.Session, i *discordgo.Interactio
 			MessageId:         message.ID,
 			ChannelId:         message.ChannelID,
 			GuildId:           guildID,
-			Content:           message.Content,
+			Content:           messageReferenceContent(message),
 			AuthorId:          message.Author.ID,
 			AuthorUsername:    message.Author.Username,
 			AuthorDisplayName: authorDisplayName,
@@ -921,7 +979,9 @@ func handleContextWikiUnifiedModal(s *discordgo.Session, i *discordgo.Interactio
 
 		// Send appropriate success message
 		var content string
-		if !upsertResp.Created {
+		if isReferenceLimitError(err) {
+			content = fmt.Sprintf("⚠️ Message not added to **%s**: %s", title, wikiReferenceLimitMessage)
+		} else if !upsertResp.Created {
 			content = fmt.Sprintf("✅ Message added to existing wiki page: **%s**", title)
 		} else {
 			content = fmt.Sprintf("✅ Wiki page created: **%s**", title)
@@ -989,7 +1049,7 @@ func handleContextWikiUnifiedModal(s *discordgo.Session, i *discordgo.Interactio
 			MessageId:         message.ID,
 			ChannelId:         message.ChannelID,
 			GuildId:           guildID,
-			Content:           message.Content,
+			Content:           messageReferenceContent(message),
 			AuthorId:          message.Author.ID,
 			AuthorUsername:    message.Author.Username,
 			AuthorDisplayName: authorDisplayName,
@@ -997,8 +1057,12 @@ func handleContextWikiUnifiedModal(s *discordgo.Session, i *discordgo.Interactio
 			Attachments:       attachments,
 		})
 		if err != nil {
+			content := fmt.Sprintf("❌ Failed to add message reference: %v", err)
+			if isReferenceLimitError(err) {
+				content = "❌ " + wikiReferenceLimitMessage
+			}
 			_, followupErr := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-				Content: fmt.Sprintf("❌ Failed to add message reference: %v", err),
+				Content: content,
 				Flags:   discordgo.MessageFlagsEphemeral,
 			})
 			if followupErr != nil {
@@ -1187,7 +1251,9 @@ func handleContextMenuViewNotesForUser(s *discordgo.Session, i *discordgo.Intera
 
 	// If no notes found, show message
 	if len(resp.Notes) == 0 {
-		content := fmt.Sprintf("📝 No notes found mentioning **@%s**\n\nCreate a new note using the \"Add Note for User\" context menu option.", user.Username)
+		content := guildMessage(guildMessageTemplates(i.GuildID, grpcClient, log), msgtemplate.NoResults,
+			fmt.Sprintf("📝 No notes found mentioning **@%s**\n\nCreate a new note using the \"Add Note for User\" context menu option.", user.Username),
+			map[string]string{"query": "@" + user.Username, "kind": "notes"})
 		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
 			Content: content,
 			Flags:   discordgo.MessageFlagsEphemeral,
@@ -1200,7 +1266,7 @@ func handleContextMenuViewNotesForUser(s *discordgo.Session, i *discordgo.Intera
 	for idx, note := range resp.Notes {
 		// Fetch message references for each note
 		refs := fetchNoteMessageReferences(ctx, noteClient, note.Id, log)
-		embed, components := createNoteEmbed(note, refs, cfg, log)
+		embed, components := createNoteEmbed(s, note, refs, cfg, guildEmbedColors(note.GuildId, grpcClient, log).Note, log)
 
 		// Add note number to embed title
 		if idx == 0 {
@@ -1219,7 +1285,7 @@ func handleContextMenuViewNotesForUser(s *discordgo.Session, i *discordgo.Intera
 }
 
 // handleUserNoteModal handles submission of the user note modal
-func handleUserNoteModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+func handleUserNoteModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
 	// Extract user ID and note ID from custom ID (format: user_note_modal:{userID}:{noteID})
 	parts := strings.SplitN(i.ModalSubmitData().CustomID, ":", 3)
 	if len(parts) != 3 {
@@ -1307,12 +1373,10 @@ func handleUserNoteModal(s *discordgo.Session, i *discordgo.InteractionCreate, c
 		resultNote, err = noteClient.UpdateNote(ctx, updateReq)
 		if err != nil {
 			log.Error("Failed to update note", "note_id", noteID, "error", err)
-			_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-				Content: fmt.Sprintf("❌ Failed to update note: %v", err),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			})
+			_, _ = s.FollowupMessageCreate(i.Interaction, true, noteSaveFailedMessage("update", title, err))
 			return
 		}
+		cache.InvalidateNoteTitles(interactionUser(i).ID, resultNote.GuildId)
 		actionText = "updated"
 	} else {
 		// Create new note
@@ -1326,12 +1390,10 @@ func handleUserNoteModal(s *discordgo.Session, i *discordgo.InteractionCreate, c
 		resultNote, err = noteClient.CreateNote(ctx, createReq)
 		if err != nil {
 			log.Error("Failed to create note", "error", err)
-			_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-				Content: fmt.Sprintf("❌ Failed to create note: %v", err),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			})
+			_, _ = s.FollowupMessageCreate(i.Interaction, true, noteSaveFailedMessage("create", title, err))
 			return
 		}
+		cache.InvalidateNoteTitles(interactionUser(i).ID, resultNote.GuildId)
 
 		// Add user message reference for new notes
 		refReq := &notespb.AddNoteMessageReferenceRequest{
@@ -1357,13 +1419,13 @@ func handleUserNoteModal(s *discordgo.Session, i *discordgo.InteractionCreate, c
 	// Success response - show standard note embed
 	// Fetch message references
 	refs := fetchNoteMessageReferences(ctx, noteClient, resultNote.Id, log)
-	embed, components := createNoteEmbed(resultNote, refs, cfg, log)
+	embed, components := createNoteEmbed(s, resultNote, refs, cfg, guildEmbedColors(resultNote.GuildId, grpcClient, log).Note, log)
 
 	// Add action text to title
 	if actionText == "updated" {
 		embed.Title = "✅ Note Updated\n\n" + embed.Title
 	} else {
-		embed.Title = "✅ Note Created\n\n" + embed.Title
+		embed.Title = noteCreatedHeading(resultNote, grpcClient, log) + "\n\n" + embed.Title
 	}
 
 	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
@@ -1379,7 +1441,7 @@ func handleUserNoteModal(s *discordgo.Session, i *discordgo.InteractionCreate, c
 		"note_id", resultNote.Id,
 		"action", actionText,
 		"title", title,
-		"author_id", i.Member.User.ID,
+		"author_id", interactionUser(i).ID,
 		"target_user_id", targetUserID,
 	)
 }
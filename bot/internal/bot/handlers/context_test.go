@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestInteractionUser(t *testing.T) {
+	member := &discordgo.User{ID: "member"}
+	dmUser := &discordgo.User{ID: "dm-user"}
+
+	tests := []struct {
+		name        string
+		interaction *discordgo.Interaction
+		want        string
+	}{
+		{name: "guild", interaction: &discordgo.Interaction{Member: &discordgo.Member{User: member}}, want: "member"},
+		// DMs have no member, only the user
+		{name: "dm", interaction: &discordgo.Interaction{User: dmUser}, want: "dm-user"},
+		{name: "member without user", interaction: &discordgo.Interaction{Member: &discordgo.Member{}, User: dmUser}, want: "dm-user"},
+		{name: "neither", interaction: &discordgo.Interaction{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := interactionUser(&discordgo.InteractionCreate{Interaction: tt.interaction})
+			if got == nil || got.ID != tt.want {
+				t.Errorf("interactionUser() = %+v, want ID %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// genericDeferredError is shown when deferred work fails with an error that has no user-facing message
+const genericDeferredError = "Something went wrong, please try again."
+
+// slowBackendError is shown when a backend call runs past its timeout
+const slowBackendError = "The backend is slow right now, please try again."
+
+// readOnlyError is shown when the server rejects a change during maintenance
+const readOnlyError = "Hivemind is in read-only maintenance mode, so changes can't be saved right now. Please try again later."
+
+// replyError is a failure whose message is meant for the user. err, if set, is
+// only logged.
+type replyError struct {
+	message string
+	err     error
+}
+
+func (e *replyError) Error() string {
+	if e.err == nil {
+		return e.message
+	}
+	return e.message + ": " + e.err.Error()
+}
+
+func (e *replyError) Unwrap() error {
+	return e.err
+}
+
+// userError returns an error that respondDeferred shows to the user as message
+func userError(message string, err error) error {
+	return &replyError{message: message, err: err}
+}
+
+// respondDeferred acknowledges the interaction with an ephemeral deferred
+// response, runs work, and edits the response with what work returns. Discord
+// drops interactions that aren't answered within 3 seconds, so handlers that call
+// the backend before replying go through this rather than responding directly.
+// If work fails the response is edited to show the error instead of being left
+// on "thinking"; errors made with userError show their message, others a generic one.
+// A backend call that timed out, or a change rejected during maintenance, says so
+// whatever message it was wrapped with.
+func respondDeferred(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, work func() (*discordgo.WebhookEdit, error)) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("failed to defer interaction", slog.String("error", err.Error()))
+		return
+	}
+
+	edit, err := work()
+	if err != nil {
+		log.Error("deferred interaction failed", slog.String("error", err.Error()))
+		edit = deferredErrorEdit(err)
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, edit); err != nil {
+		log.Error("failed to edit deferred response", slog.String("error", err.Error()))
+	}
+}
+
+// deferredErrorEdit builds the edit that replaces a deferred response when its work fails
+func deferredErrorEdit(err error) *discordgo.WebhookEdit {
+	message := genericDeferredError
+	var replyErr *replyError
+	if status.Code(err) == codes.DeadlineExceeded {
+		message = slowBackendError
+	} else if client.IsReadOnly(err) {
+		message = readOnlyError
+	} else if errors.As(err, &replyErr) {
+		message = replyErr.message
+	}
+	return &discordgo.WebhookEdit{Content: ptrString("❌ " + message)}
+}
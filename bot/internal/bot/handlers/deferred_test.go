@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// recordedRequest is a Discord API call captured by fakeDiscord
+type recordedRequest struct {
+	method string
+	path   string
+	body   map[string]any
+}
+
+// fakeDiscord answers every Discord API request with an empty object and records it.
+// Requests whose path contains failPath, when set, are refused with 403 Forbidden.
+type fakeDiscord struct {
+	mu       sync.Mutex
+	requests []recordedRequest
+	failPath string
+}
+
+func (f *fakeDiscord) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := recordedRequest{method: req.Method, path: req.URL.Path}
+	if req.Body != nil {
+		data, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(data, &rec.body)
+	}
+	f.mu.Lock()
+	f.requests = append(f.requests, rec)
+	fail := f.failPath != "" && strings.Contains(rec.path, f.failPath)
+	f.mu.Unlock()
+
+	code := http.StatusOK
+	if fail {
+		code = http.StatusForbidden
+	}
+	return &http.Response{
+		StatusCode: code,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+func newFakeDiscordSession(t *testing.T) (*discordgo.Session, *fakeDiscord) {
+	t.Helper()
+	s, err := discordgo.New("Bot test-token")
+	if err != nil {
+		t.Fatalf("discordgo.New: %v", err)
+	}
+	fake := &fakeDiscord{}
+	s.Client = &http.Client{Transport: fake}
+	return s, fake
+}
+
+func testInteraction() *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:    "interaction-1",
+		AppID: "app-1",
+		Token: "interaction-token",
+		Type:  discordgo.InteractionApplicationCommand,
+	}}
+}
+
+func TestRespondDeferred(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name        string
+		work        func() (*discordgo.WebhookEdit, error)
+		wantContent string
+	}{
+		{
+			name: "success",
+			work: func() (*discordgo.WebhookEdit, error) {
+				return &discordgo.WebhookEdit{Content: ptrString("🔍 Found **2** wiki pages")}, nil
+			},
+			wantContent: "🔍 Found **2** wiki pages",
+		},
+		{
+			name: "user error",
+			work: func() (*discordgo.WebhookEdit, error) {
+				return nil, userError("Failed to search wiki pages", errors.New("rpc error: code = Unavailable"))
+			},
+			wantContent: "❌ Failed to search wiki pages",
+		},
+		{
+			name: "backend timeout",
+			work: func() (*discordgo.WebhookEdit, error) {
+				return nil, userError("Failed to search wiki pages", status.Error(codes.DeadlineExceeded, "context deadline exceeded"))
+			},
+			wantContent: "❌ " + slowBackendError,
+		},
+		{
+			name: "read-only mode",
+			work: func() (*discordgo.WebhookEdit, error) {
+				return nil, userError("Failed to create note", status.Error(codes.FailedPrecondition, client.ReadOnlyMessage+"; changes are disabled until it ends"))
+			},
+			wantContent: "❌ " + readOnlyError,
+		},
+		{
+			name: "other failed precondition",
+			work: func() (*discordgo.WebhookEdit, error) {
+				return nil, userError("Can't undo this merge", status.Error(codes.FailedPrecondition, "merge already undone"))
+			},
+			wantContent: "❌ Can't undo this merge",
+		},
+		{
+			name: "internal error",
+			work: func() (*discordgo.WebhookEdit, error) {
+				return nil, errors.New("connection refused")
+			},
+			wantContent: "❌ " + genericDeferredError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, fake := newFakeDiscordSession(t)
+			respondDeferred(s, testInteraction(), log, tt.work)
+
+			if len(fake.requests) != 2 {
+				t.Fatalf("got %d Discord requests, want defer then edit: %+v", len(fake.requests), fake.requests)
+			}
+
+			deferReq := fake.requests[0]
+			if deferReq.method != http.MethodPost || !strings.HasSuffix(deferReq.path, "/interactions/interaction-1/interaction-token/callback") {
+				t.Errorf("first request = %s %s, want interaction callback", deferReq.method, deferReq.path)
+			}
+			if got := deferReq.body["type"]; got != float64(discordgo.InteractionResponseDeferredChannelMessageWithSource) {
+				t.Errorf("callback type = %v, want deferred channel message", got)
+			}
+
+			editReq := fake.requests[1]
+			if editReq.method != http.MethodPatch || !strings.HasSuffix(editReq.path, "/webhooks/app-1/interaction-token/messages/@original") {
+				t.Errorf("second request = %s %s, want edit of the original response", editReq.method, editReq.path)
+			}
+			if got := editReq.body["content"]; got != tt.wantContent {
+				t.Errorf("edited content = %v, want %q", got, tt.wantContent)
+			}
+		})
+	}
+}
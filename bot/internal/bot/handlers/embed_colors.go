@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"log/slog"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+	"github.com/devilmonastery/hivemind/internal/client"
+	"github.com/devilmonastery/hivemind/internal/pkg/colorutil"
+)
+
+// Default embed colors, used when a guild hasn't customized them
+const (
+	defaultWikiColor  = 0x00D9FF // Cyan
+	defaultNoteColor  = 0x5865F2 // Discord blurple
+	defaultQuoteColor = 0x5865F2 // Discord blurple
+)
+
+// embedColors holds the embed color for each content type
+type embedColors struct {
+	Wiki  int
+	Note  int
+	Quote int
+}
+
+// embedColorsFromSettings applies a guild's appearance settings over the defaults.
+// Unset or unparseable colors keep the default.
+func embedColorsFromSettings(appearance *discordpb.AppearanceSettings) embedColors {
+	colors := embedColors{
+		Wiki:  defaultWikiColor,
+		Note:  defaultNoteColor,
+		Quote: defaultQuoteColor,
+	}
+	if appearance == nil {
+		return colors
+	}
+	if c, err := colorutil.ParseHex(appearance.WikiColor); err == nil {
+		colors.Wiki = c
+	}
+	if c, err := colorutil.ParseHex(appearance.NoteColor); err == nil {
+		colors.Note = c
+	}
+	if c, err := colorutil.ParseHex(appearance.QuoteColor); err == nil {
+		colors.Quote = c
+	}
+	return colors
+}
+
+// guildEmbedColors fetches the embed colors for a guild. Personal content (no guild)
+// and settings lookup failures use the defaults.
+func guildEmbedColors(guildID string, grpcClient *client.Client, log *slog.Logger) embedColors {
+	if guildID == "" {
+		return embedColorsFromSettings(nil)
+	}
+
+	settings, err := guildSettings(guildID, grpcClient)
+	if err != nil {
+		log.Debug("failed to fetch guild settings for embed colors, using defaults",
+			slog.String("guild_id", guildID),
+			slog.String("error", err.Error()))
+		return embedColorsFromSettings(nil)
+	}
+
+	return embedColorsFromSettings(settings.GetAppearance())
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"testing"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+)
+
+func TestEmbedColorsFromSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		appearance *discordpb.AppearanceSettings
+		want       embedColors
+	}{
+		{
+			name:       "nil uses defaults",
+			appearance: nil,
+			want:       embedColors{Wiki: defaultWikiColor, Note: defaultNoteColor, Quote: defaultQuoteColor},
+		},
+		{
+			name:       "custom colors",
+			appearance: &discordpb.AppearanceSettings{WikiColor: "#FF0000", NoteColor: "00ff00", QuoteColor: "#0000FF"},
+			want:       embedColors{Wiki: 0xFF0000, Note: 0x00FF00, Quote: 0x0000FF},
+		},
+		{
+			name:       "invalid and empty fall back per type",
+			appearance: &discordpb.AppearanceSettings{WikiColor: "not-a-color", QuoteColor: "#123456"},
+			want:       embedColors{Wiki: defaultWikiColor, Note: defaultNoteColor, Quote: 0x123456},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := embedColorsFromSettings(tt.appearance); got != tt.want {
+				t.Errorf("embedColorsFromSettings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
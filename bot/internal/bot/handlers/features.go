@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/devilmonastery/hivemind/bot/internal/bot/commands"
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// commandAllowed checks whether the command's feature is enabled in the interaction's guild.
+// Commands outside a guild, ungated commands, and backend failures are always allowed so
+// a settings outage never locks users out.
+func commandAllowed(i *discordgo.InteractionCreate, commandName string, log *slog.Logger, grpcClient *client.Client) bool {
+	feature := commands.FeatureFor(commandName)
+	if feature == "" || i.GuildID == "" {
+		return true
+	}
+
+	settings, err := guildSettings(i.GuildID, grpcClient)
+	if err != nil {
+		log.Warn("failed to fetch guild settings, allowing command",
+			slog.String("guild_id", i.GuildID),
+			slog.String("command", commandName),
+			slog.String("error", err.Error()))
+		return true
+	}
+
+	return commands.FeatureEnabled(settings.GetFeatures(), feature)
+}
+
+// wikiEditorRoleMessage is shown when a guild's wiki editor roles exclude the member
+const wikiEditorRoleMessage = "This server only lets members with a wiki editor role create or edit wiki pages"
+
+// requireWikiEditRole checks the guild's wiki editor roles before a wiki modal opens, responding
+// with a permission message when the member has none of them and isn't a Hivemind admin. Like
+// commandAllowed it fails open; the backend enforces the same roles when the page is saved.
+func requireWikiEditRole(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, grpcClient *client.Client) bool {
+	if i.GuildID == "" || i.Member == nil {
+		return true
+	}
+
+	settings, err := guildSettings(i.GuildID, grpcClient)
+	if err != nil {
+		log.Warn("failed to fetch guild settings, allowing wiki edit",
+			slog.String("guild_id", i.GuildID),
+			slog.String("error", err.Error()))
+		return true
+	}
+
+	var ownerID string
+	if guild, err := s.State.Guild(i.GuildID); err == nil {
+		ownerID = guild.OwnerID
+	}
+
+	if memberHasRequiredRole(i.Member, ownerID, settings.GetPermissions().GetWikiEditRoles()) {
+		return true
+	}
+	if isHivemindAdmin(discordContextFor(i), grpcClient, log) {
+		return true
+	}
+	respondError(s, i, wikiEditorRoleMessage, log)
+	return false
+}
+
+// memberHasRequiredRole reports whether member may act given the required role IDs:
+// no required roles allows everyone, and the guild owner is never restricted
+func memberHasRequiredRole(member *discordgo.Member, ownerID string, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+	if member.User != nil && ownerID != "" && member.User.ID == ownerID {
+		return true
+	}
+	for _, role := range member.Roles {
+		if slices.Contains(required, role) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc"
+
+	authpb "github.com/devilmonastery/hivemind/api/generated/go/authpb"
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+	userpb "github.com/devilmonastery/hivemind/api/generated/go/userpb"
+)
+
+func TestMemberHasRequiredRole(t *testing.T) {
+	member := func(id string, roles ...string) *discordgo.Member {
+		return &discordgo.Member{User: &discordgo.User{ID: id}, Roles: roles}
+	}
+	required := []string{"editors", "mods"}
+
+	tests := []struct {
+		name     string
+		member   *discordgo.Member
+		required []string
+		want     bool
+	}{
+		{name: "no roles configured", member: member("u1"), want: true},
+		{name: "has a required role", member: member("u1", "everyone", "mods"), required: required, want: true},
+		{name: "missing required roles", member: member("u1", "everyone"), required: required},
+		{name: "guild owner", member: member("owner"), required: required, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := memberHasRequiredRole(tt.member, "owner", tt.required); got != tt.want {
+				t.Errorf("memberHasRequiredRole() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeRoleServer reports the caller's Hivemind role
+type fakeRoleServer struct {
+	authpb.UnimplementedAuthServiceServer
+	role userpb.Role
+}
+
+func (f *fakeRoleServer) GetCurrentUser(ctx context.Context, req *authpb.GetCurrentUserRequest) (*authpb.GetCurrentUserResponse, error) {
+	return &authpb.GetCurrentUserResponse{User: &userpb.User{Role: f.role}}, nil
+}
+
+func TestRequireWikiEditRole_AdminBypass(t *testing.T) {
+	tests := []struct {
+		name string
+		role userpb.Role
+		want bool
+	}{
+		{name: "hivemind admin", role: userpb.Role_ROLE_ADMIN, want: true},
+		{name: "regular user", role: userpb.Role_ROLE_USER},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, discord := newFakeDiscordSession(t)
+			grpcClient := newTestGRPCClient(t, func(server *grpc.Server) {
+				discordpb.RegisterDiscordServiceServer(server, &fakeSettingsServer{settings: &discordpb.GuildSettings{
+					Permissions: &discordpb.PermissionSettings{WikiEditRoles: []string{"editors"}},
+				}})
+				authpb.RegisterAuthServiceServer(server, &fakeRoleServer{role: tt.role})
+			})
+			i := testInteraction()
+			i.GuildID = "g-wiki-roles-" + tt.role.String()
+			i.Member = &discordgo.Member{User: &discordgo.User{ID: "u1"}, Roles: []string{"everyone"}}
+
+			if got := requireWikiEditRole(s, i, slog.New(slog.NewTextHandler(io.Discard, nil)), grpcClient); got != tt.want {
+				t.Fatalf("requireWikiEditRole() = %v, want %v", got, tt.want)
+			}
+			if responded := discord.indexOf(http.MethodPost, "/callback") >= 0; responded == tt.want {
+				t.Errorf("permission message sent = %v, want %v", responded, !tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// defaultGuildSettingsTTL is how long guild settings are cached unless SetGuildSettingsTTL
+// changes it
+const defaultGuildSettingsTTL = 30 * time.Second
+
+// guildSettingsFetchTimeout bounds a settings lookup on a cache miss
+const guildSettingsFetchTimeout = 2 * time.Second
+
+// guildSettingsEntry holds a guild's cached settings with expiration
+type guildSettingsEntry struct {
+	settings  *discordpb.GuildSettings
+	expiresAt time.Time
+}
+
+// guildSettingsCache keeps each guild's settings for a short time, so the checks and
+// lookups that run on most interactions don't each call the backend. /hivemind setters
+// invalidate their guild on this instance; other bot instances see a change once the
+// TTL runs out.
+type guildSettingsCache struct {
+	mu          sync.Mutex
+	entries     map[string]guildSettingsEntry
+	generations map[string]uint64 // Bumped by invalidate so an earlier fetch isn't stored
+	ttl         time.Duration
+}
+
+// settingsCache is the guild settings cache shared by all handlers
+var settingsCache = newGuildSettingsCache(defaultGuildSettingsTTL)
+
+func newGuildSettingsCache(ttl time.Duration) *guildSettingsCache {
+	return &guildSettingsCache{
+		entries:     make(map[string]guildSettingsEntry),
+		generations: make(map[string]uint64),
+		ttl:         ttl,
+	}
+}
+
+// SetGuildSettingsTTL sets how long guild settings are cached and drops everything cached
+func SetGuildSettingsTTL(ttl time.Duration) {
+	settingsCache.mu.Lock()
+	defer settingsCache.mu.Unlock()
+	settingsCache.ttl = ttl
+	clear(settingsCache.entries)
+}
+
+// get returns a guild's settings from the cache, fetching them on a miss. The returned
+// settings are shared and must not be modified.
+func (c *guildSettingsCache) get(guildID string, fetch func(ctx context.Context) (*discordpb.GuildSettings, error)) (*discordpb.GuildSettings, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[guildID]
+	generation := c.generations[guildID]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.settings, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), guildSettingsFetchTimeout)
+	defer cancel()
+	settings, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Settings changed while fetching; the fetch may have read the old ones
+	if c.generations[guildID] == generation {
+		c.entries[guildID] = guildSettingsEntry{settings: settings, expiresAt: time.Now().Add(c.ttl)}
+	}
+	return settings, nil
+}
+
+// invalidate drops a guild's cached settings after they change
+func (c *guildSettingsCache) invalidate(guildID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, guildID)
+	c.generations[guildID]++
+}
+
+// guildSettings returns a guild's settings, cached for a short time
+func guildSettings(guildID string, grpcClient *client.Client) (*discordpb.GuildSettings, error) {
+	return settingsCache.get(guildID, func(ctx context.Context) (*discordpb.GuildSettings, error) {
+		discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
+		resp, err := discordClient.GetGuildSettings(ctx, &discordpb.GetGuildSettingsRequest{
+			GuildId: guildID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.GetSettings(), nil
+	})
+}
+
+// updateGuildSettings saves a change to a guild's settings and drops the cached copy.
+// A failed update may still have been applied, so the copy is dropped either way.
+func updateGuildSettings(ctx context.Context, grpcClient *client.Client, req *discordpb.UpdateGuildSettingsRequest) (*discordpb.UpdateGuildSettingsResponse, error) {
+	discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
+	defer settingsCache.invalidate(req.GuildId)
+	return discordClient.UpdateGuildSettings(ctx, req)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+)
+
+func TestGuildSettingsCache(t *testing.T) {
+	c := newGuildSettingsCache(time.Hour)
+	fetches := 0
+	fetch := func(ctx context.Context) (*discordpb.GuildSettings, error) {
+		fetches++
+		return &discordpb.GuildSettings{Posting: &discordpb.PostingSettings{ReplyToSource: fetches > 1}}, nil
+	}
+
+	for n := 0; n < 3; n++ {
+		if _, err := c.get("g1", fetch); err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("fetches = %d after repeated lookups, want 1", fetches)
+	}
+
+	c.invalidate("g1")
+	settings, err := c.get("g1", fetch)
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	if fetches != 2 || !settings.GetPosting().GetReplyToSource() {
+		t.Errorf("after invalidate: fetches = %d, settings = %v, want a fresh fetch", fetches, settings)
+	}
+}
+
+func TestGuildSettingsCacheExpires(t *testing.T) {
+	c := newGuildSettingsCache(-time.Second) // already expired when stored
+	fetches := 0
+	fetch := func(ctx context.Context) (*discordpb.GuildSettings, error) {
+		fetches++
+		return &discordpb.GuildSettings{}, nil
+	}
+	_, _ = c.get("g1", fetch)
+	_, _ = c.get("g1", fetch)
+	if fetches != 2 {
+		t.Errorf("fetches = %d, want 2 once the entry expired", fetches)
+	}
+}
+
+func TestGuildSettingsCacheSkipsErrors(t *testing.T) {
+	c := newGuildSettingsCache(time.Hour)
+	if _, err := c.get("g1", func(ctx context.Context) (*discordpb.GuildSettings, error) {
+		return nil, errors.New("backend down")
+	}); err == nil {
+		t.Fatal("get() error = nil, want the fetch error")
+	}
+
+	fetched := false
+	_, _ = c.get("g1", func(ctx context.Context) (*discordpb.GuildSettings, error) {
+		fetched = true
+		return &discordpb.GuildSettings{}, nil
+	})
+	if !fetched {
+		t.Error("a failed fetch was cached")
+	}
+}
+
+// A fetch that started before the settings changed must not store the old settings
+func TestGuildSettingsCacheInvalidateDuringFetch(t *testing.T) {
+	c := newGuildSettingsCache(time.Hour)
+	stale := &discordpb.GuildSettings{Posting: &discordpb.PostingSettings{ReplyToSource: false}}
+	if _, err := c.get("g1", func(ctx context.Context) (*discordpb.GuildSettings, error) {
+		c.invalidate("g1")
+		return stale, nil
+	}); err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+
+	fetched := false
+	_, _ = c.get("g1", func(ctx context.Context) (*discordpb.GuildSettings, error) {
+		fetched = true
+		return &discordpb.GuildSettings{}, nil
+	})
+	if !fetched {
+		t.Error("settings fetched before an invalidate were cached")
+	}
+}
+
+func TestUpdateGuildSettingsInvalidatesCache(t *testing.T) {
+	grpcClient := newTestGRPCClient(t, func(server *grpc.Server) {
+		discordpb.RegisterDiscordServiceServer(server, &fakeSettingsServer{})
+	})
+	_, _ = settingsCache.get("g-update", func(ctx context.Context) (*discordpb.GuildSettings, error) {
+		return &discordpb.GuildSettings{}, nil
+	})
+
+	if _, err := updateGuildSettings(context.Background(), grpcClient, &discordpb.UpdateGuildSettingsRequest{
+		GuildId:  "g-update",
+		Settings: &discordpb.GuildSettings{},
+	}); err != nil {
+		t.Fatalf("updateGuildSettings() error = %v", err)
+	}
+
+	settingsCache.mu.Lock()
+	_, cached := settingsCache.entries["g-update"]
+	settingsCache.mu.Unlock()
+	if cached {
+		t.Error("settings still cached after an update")
+	}
+}
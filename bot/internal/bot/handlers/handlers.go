@@ -7,13 +7,14 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 
+	"github.com/devilmonastery/hivemind/bot/internal/bot/membersync"
 	"github.com/devilmonastery/hivemind/bot/internal/config"
 	"github.com/devilmonastery/hivemind/internal/client"
 	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
 )
 
 // HandleInteraction routes interactions to the appropriate handler
-func HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
+func HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache, memberSync *membersync.Syncer) {
 	start := time.Now()
 	interactionType := i.Type.String()
 	customID := ""
@@ -36,19 +37,29 @@ func HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate, cfg
 		metrics.DiscordInteractions.WithLabelValues(interactionType, customID, status).Inc()
 	}()
 
+	// onGuildCreate leaves such guilds; this catches interactions that race the leave
+	if i.GuildID != "" && !cfg.Bot.GuildAllowed(i.GuildID) {
+		log.Warn("rejecting interaction from guild not in allowed_guild_ids",
+			slog.String("guild_id", i.GuildID))
+		if i.Type != discordgo.InteractionApplicationCommandAutocomplete {
+			respondError(s, i, "This bot is not enabled for this server.", log)
+		}
+		return
+	}
+
 	switch i.Type {
 	case discordgo.InteractionApplicationCommand:
-		handleCommand(s, i, cfg, log, grpcClient, start)
+		handleCommand(s, i, cfg, log, grpcClient, cache, memberSync, start)
 	case discordgo.InteractionMessageComponent:
-		handleComponent(s, i, cfg, log, grpcClient)
+		handleComponent(s, i, cfg, log, grpcClient, cache)
 	case discordgo.InteractionModalSubmit:
-		handleModal(s, i, cfg, log, grpcClient)
+		handleModal(s, i, cfg, log, grpcClient, cache)
 	case discordgo.InteractionApplicationCommandAutocomplete:
 		handleAutocomplete(s, i, cfg, log, grpcClient, cache)
 	}
 }
 
-func handleCommand(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, start time.Time) {
+func handleCommand(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache, memberSync *membersync.Syncer, start time.Time) {
 	commandName := i.ApplicationCommandData().Name
 	subcommand := ""
 
@@ -59,7 +70,7 @@ func handleCommand(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *co
 
 	log.Info("command received",
 		slog.String("command", commandName),
-		slog.String("user_id", i.Member.User.ID),
+		slog.String("user_id", interactionUser(i).ID),
 		slog.String("guild_id", i.GuildID),
 	)
 
@@ -75,24 +86,35 @@ func handleCommand(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *co
 		metrics.DiscordCommandDuration.WithLabelValues(commandName, subcommand).Observe(float64(duration))
 	}()
 
+	if !commandAllowed(i, commandName, log, grpcClient) {
+		respondError(s, i, "This feature is disabled in this server", log)
+		return
+	}
+
 	switch commandName {
 	case "ping":
 		handlePing(s, i, log, grpcClient)
 	case "wiki":
-		handleWiki(s, i, cfg, log, grpcClient)
+		handleWiki(s, i, cfg, log, grpcClient, cache)
 	case "note":
 		handleNote(s, i, cfg, log, grpcClient)
 	case "quote":
 		handleQuote(s, i, log, grpcClient)
 	case "hivemind":
-		handleHivemind(s, i, log, grpcClient)
+		handleHivemind(s, i, log, grpcClient, memberSync)
+	case "prefs":
+		handlePrefs(s, i, log, grpcClient)
+	case "recent":
+		handleRecent(s, i, cfg, log, grpcClient)
 	// Context menu commands
 	case "Save as Quote":
-		handleContextMenuQuote(s, i, log, grpcClient)
+		handleContextMenuQuote(s, i, cfg, log, grpcClient)
 	case "Create Note":
 		handleContextMenuNote(s, i, cfg, log, grpcClient)
 	case "Add to Wiki":
 		handleContextMenuWiki(s, i, log, grpcClient)
+	case "Add Thread to Wiki":
+		handleContextMenuWikiThread(s, i, log, grpcClient)
 	// User context menu commands
 	case "Edit Note for User":
 		handleContextMenuAddNoteForUser(s, i, cfg, log, grpcClient)
@@ -103,12 +125,12 @@ func handleCommand(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *co
 	}
 }
 
-func handleComponent(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+func handleComponent(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
 	customID := i.MessageComponentData().CustomID
 
 	log.Info("component interaction received",
 		slog.String("custom_id", customID),
-		slog.String("user_id", i.Member.User.ID),
+		slog.String("user_id", interactionUser(i).ID),
 	)
 
 	// Split customID by colon to get the handler type
@@ -128,30 +150,60 @@ func handleComponent(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *
 	case "wiki_select":
 		handleWikiSelectMenu(s, i, remainder, cfg, log, grpcClient)
 	case "wiki_action_btn":
-		handleWikiActionButton(s, i, customID, cfg, log, grpcClient)
+		handleWikiActionButton(s, i, customID, cfg, log, grpcClient, cache)
 	case "wiki_edit_btn":
 		handleWikiEditButton(s, i, remainder, cfg, log, grpcClient)
 	case "wiki_add_to_chat":
 		handleWikiAddToChat(s, i, remainder, cfg, log, grpcClient)
 	case "wiki_close":
 		handleWikiClose(s, i, log)
+	case "wiki_refs":
+		handleWikiReferences(s, i, remainder, false, cfg, log, grpcClient)
+	case "wiki_refs_page":
+		handleWikiReferences(s, i, remainder, true, cfg, log, grpcClient)
+	case "wiki_merge_confirm":
+		handleWikiMergeConfirm(s, i, remainder, cfg, log, grpcClient, cache)
+	case "wiki_unmerge":
+		handleWikiUnmerge(s, i, remainder, cfg, log, grpcClient, cache)
+	case "wiki_transfer_select":
+		handleWikiTransferSelect(s, i, remainder, log, grpcClient)
 	case "wiki_unified_select":
 		log.Info("routing to handleWikiUnifiedSelect", slog.String("messageID", remainder))
 		handleWikiUnifiedSelect(s, i, remainder, log, grpcClient)
 	case "wiki_page_select":
 		handleWikiPageSelect(s, i, log, grpcClient)
+	case "wiki_thread_select":
+		handleWikiThreadSelect(s, i, remainder, log, grpcClient)
+	case "wiki_list_select":
+		handleWikiListSelect(s, i, cfg, log, grpcClient)
+	case "wiki_list_page":
+		handleWikiListPage(s, i, remainder, cfg, log, grpcClient)
+	case "wiki_add_tag":
+		handleWikiAddTag(s, i, remainder, log, grpcClient)
+	case "note_add_tag":
+		handleNoteAddTag(s, i, remainder, log, grpcClient)
 	case "note_edit_btn":
 		handleNoteEditButton(s, i, remainder, log, grpcClient)
 	case "note_delete_btn":
 		handleNoteDeleteButton(s, i, remainder, log, grpcClient)
 	case "note_delete_confirm":
-		handleNoteDeleteConfirm(s, i, remainder, log, grpcClient)
+		handleNoteDeleteConfirm(s, i, remainder, log, grpcClient, cache)
 	case "note_delete_cancel":
 		handleNoteDeleteCancel(s, i, log)
+	case "note_open_btn":
+		handleNoteOpenButton(s, i, remainder, cfg, log, grpcClient)
 	case "note_close_btn":
 		handleNoteCloseButton(s, i, log)
+	case "note_share_btn":
+		handleNoteShareButton(s, i, remainder, cfg, log, grpcClient)
+	case "note_refs":
+		handleNoteReferences(s, i, remainder, false, cfg, log, grpcClient)
+	case "note_refs_page":
+		handleNoteReferences(s, i, remainder, true, cfg, log, grpcClient)
 	case "quote_add_to_chat":
-		handleQuoteAddToChat(s, i, remainder, log, grpcClient)
+		handleQuoteAddToChat(s, i, remainder, false, log, grpcClient)
+	case "quote_add_to_chat_context":
+		handleQuoteAddToChat(s, i, remainder, true, log, grpcClient)
 	case "quote_edit_btn":
 		handleQuoteEditButton(s, i, remainder, log, grpcClient)
 	case "quote_dismiss":
@@ -165,12 +217,12 @@ func handleComponent(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *
 	}
 }
 
-func handleModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+func handleModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
 	customID := i.ModalSubmitData().CustomID
 
 	log.Info("modal submission received",
 		slog.String("custom_id", customID),
-		slog.String("user_id", i.Member.User.ID),
+		slog.String("user_id", interactionUser(i).ID),
 	)
 
 	// Split customID by colon to get the handler type
@@ -186,23 +238,23 @@ func handleModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *conf
 	switch handlerType {
 	case "context_wiki_unified_modal":
 		log.Info("routing to handleContextWikiUnifiedModal", slog.String("custom_id", customID))
-		handleContextWikiUnifiedModal(s, i, cfg, log, grpcClient)
-	case "wiki_edit_modal":
-		handleWikiEditModal(s, i, cfg, log, grpcClient)
+		handleContextWikiUnifiedModal(s, i, cfg, log, grpcClient, cache)
+	case "wiki_edit_modal", "wiki_draft_modal":
+		handleWikiEditModal(s, i, cfg, log, grpcClient, cache)
 	case "note_create_modal":
-		handleNoteCreateModal(s, i, cfg, log, grpcClient)
+		handleNoteCreateModal(s, i, cfg, log, grpcClient, cache)
 	case "note_edit_modal":
-		handleNoteEditModal(s, i, cfg, log, grpcClient)
+		handleNoteEditModal(s, i, cfg, log, grpcClient, cache)
 	case "quote_edit_modal":
 		handleQuoteEditModal(s, i, log, grpcClient)
 	case "context_quote_modal":
 		handleContextQuoteModal(s, i, cfg, log, grpcClient)
 	case "context_note_modal":
-		handleContextNoteModal(s, i, cfg, log, grpcClient)
+		handleContextNoteModal(s, i, cfg, log, grpcClient, cache)
 	case "context_wiki_modal":
-		handleContextWikiModal(s, i, cfg, log, grpcClient)
+		handleContextWikiModal(s, i, cfg, log, grpcClient, cache)
 	case "user_note_modal":
-		handleUserNoteModal(s, i, cfg, log, grpcClient)
+		handleUserNoteModal(s, i, cfg, log, grpcClient, cache)
 	default:
 		log.Warn("no handler found for modal", slog.String("custom_id", customID))
 		respondError(s, i, "Unknown modal", log)
@@ -214,7 +266,7 @@ func handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate, cf
 
 	log.Debug("autocomplete request received",
 		slog.String("command", data.Name),
-		slog.String("user_id", i.Member.User.ID),
+		slog.String("user_id", interactionUser(i).ID),
 	)
 
 	switch data.Name {
@@ -222,6 +274,8 @@ func handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate, cf
 		handleNoteAutocomplete(s, i, log, grpcClient, cache)
 	case "wiki":
 		handleWikiAutocomplete(s, i, log, grpcClient, cache)
+	case "prefs":
+		handlePrefsAutocomplete(s, i, log, grpcClient)
 	default:
 		log.Warn("no autocomplete handler for command", slog.String("command", data.Name))
 	}
@@ -2,21 +2,39 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+	"github.com/devilmonastery/hivemind/bot/internal/bot/commands"
+	"github.com/devilmonastery/hivemind/bot/internal/bot/membersync"
 	"github.com/devilmonastery/hivemind/internal/client"
+	"github.com/devilmonastery/hivemind/internal/pkg/colorutil"
+	"github.com/devilmonastery/hivemind/internal/pkg/msgtemplate"
 )
 
-func handleHivemind(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, grpcClient *client.Client) {
+func handleHivemind(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, grpcClient *client.Client, memberSync *membersync.Syncer) {
 	if i.Member == nil {
 		respondError(s, i, "This command can only be used in servers", log)
 		return
 	}
 
+	// Server admins can override the command's default permissions in the
+	// integration settings, so check the invoking member as well
+	if !canManageGuild(i.Member) {
+		respondError(s, i, "You need the Manage Server permission to configure Hivemind", log)
+		return
+	}
+
 	// Get subcommand
 	options := i.ApplicationCommandData().Options
 	if len(options) == 0 {
@@ -27,13 +45,41 @@ func handleHivemind(s *discordgo.Session, i *discordgo.InteractionCreate, log *s
 	switch options[0].Name {
 	case "setup-announcements":
 		handleSetupAnnouncements(s, i, options[0], log, grpcClient)
+	case "features":
+		handleSetFeature(s, i, options[0], log, grpcClient)
+	case "colors":
+		handleSetColor(s, i, options[0], log, grpcClient)
+	case "wiki-editors":
+		handleSetWikiEditors(s, i, options[0], log, grpcClient)
+	case "replies":
+		handleSetReplies(s, i, options[0], log, grpcClient)
+	case "unique-note-titles":
+		handleSetUniqueNoteTitles(s, i, options[0], log, grpcClient)
+	case "quote-cooldown":
+		handleSetQuoteCooldown(s, i, options[0], log, grpcClient)
+	case "reference-limit":
+		handleSetReferenceLimit(s, i, options[0], log, grpcClient)
+	case "messages":
+		handleSetMessage(s, i, options[0], log, grpcClient)
+	case "reset":
+		handleResetSetting(s, i, options[0], log, grpcClient)
 	case "show":
 		handleShowConfig(s, i, log, grpcClient)
+	case "sync-members":
+		handleSyncMembers(s, i, log, grpcClient, memberSync)
 	default:
 		respondError(s, i, "Unknown subcommand", log)
 	}
 }
 
+// canManageGuild reports whether member may change guild settings: Manage Server or Administrator
+func canManageGuild(member *discordgo.Member) bool {
+	if member == nil {
+		return false
+	}
+	return member.Permissions&(discordgo.PermissionManageServer|discordgo.PermissionAdministrator) != 0
+}
+
 func handleSetupAnnouncements(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
 	// Acknowledge immediately
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -50,38 +96,56 @@ func handleSetupAnnouncements(s *discordgo.Session, i *discordgo.InteractionCrea
 	ctx := context.Background()
 	discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
 
+	// Fetch current settings so the weekly leaderboard only changes when given
+	resp, err := discordClient.GetGuildSettings(ctx, &discordpb.GetGuildSettingsRequest{
+		GuildId: i.GuildID,
+	})
+	if err != nil {
+		log.Error("Failed to fetch guild settings", "error", err, "guild_id", i.GuildID)
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: "❌ Failed to fetch settings. Please try again.",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+
 	var channelID string
 	var channelName string
 	var enabled bool
+	weeklyLeaderboard := resp.GetSettings().GetAnnouncements().GetWeeklyQuoteLeaderboard()
 
-	// Check if channel was provided
-	if len(subcommand.Options) > 0 {
-		channel := subcommand.Options[0].ChannelValue(s)
-		channelID = channel.ID
-		channelName = channel.Name
-		enabled = true
-	} else {
-		// No channel = disable announcements
-		enabled = false
+	// No channel = disable announcements
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "channel":
+			channel := opt.ChannelValue(s)
+			channelID = channel.ID
+			channelName = channel.Name
+			enabled = true
+		case "weekly-quote-leaderboard":
+			weeklyLeaderboard = opt.BoolValue()
+		}
 	}
 
 	// Update guild settings via gRPC
-	_, err = discordClient.UpdateGuildSettings(ctx, &discordpb.UpdateGuildSettingsRequest{
+	_, err = updateGuildSettings(ctx, grpcClient, &discordpb.UpdateGuildSettingsRequest{
 		GuildId: i.GuildID,
 		Settings: &discordpb.GuildSettings{
 			Announcements: &discordpb.AnnouncementSettings{
-				Enabled:           enabled,
-				ChannelId:         channelID,
-				NotifyWikiCreate:  true,
-				NotifyWikiEdit:    false,
-				NotifyQuoteCreate: true,
+				Enabled:                enabled,
+				ChannelId:              channelID,
+				NotifyWikiCreate:       true,
+				NotifyWikiEdit:         false,
+				NotifyQuoteCreate:      true,
+				WeeklyQuoteLeaderboard: weeklyLeaderboard,
 			},
 		},
+		ExpectedRevision: resp.GetSettings().GetRevision(),
 	})
 	if err != nil {
 		log.Error("Failed to update guild settings", "error", err, "guild_id", i.GuildID)
 		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: "❌ Failed to update settings. Please try again.",
+			Content: settingsUpdateErrorMessage(err),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		})
 		return
@@ -91,6 +155,9 @@ func handleSetupAnnouncements(s *discordgo.Session, i *discordgo.InteractionCrea
 	var content string
 	if enabled {
 		content = fmt.Sprintf("✅ Announcements enabled!\n\nNew wikis and quotes will be posted to <#%s>", channelID)
+		if weeklyLeaderboard {
+			content += ", along with a weekly quote leaderboard"
+		}
 	} else {
 		content = "✅ Announcements disabled"
 	}
@@ -108,10 +175,691 @@ func handleSetupAnnouncements(s *discordgo.Session, i *discordgo.InteractionCrea
 		"enabled", enabled,
 		"channel_id", channelID,
 		"channel_name", channelName,
-		"admin_id", i.Member.User.ID,
+		"weekly_quote_leaderboard", weeklyLeaderboard,
+		"admin_id", interactionUser(i).ID,
+	)
+}
+
+func handleSetFeature(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
+	// Acknowledge immediately
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("Failed to acknowledge interaction", "error", err)
+		return
+	}
+
+	var feature string
+	var enabled bool
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "feature":
+			feature = opt.StringValue()
+		case "enabled":
+			enabled = opt.BoolValue()
+		}
+	}
+
+	ctx := context.Background()
+	discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
+
+	// Fetch current flags so only the chosen feature changes
+	resp, err := discordClient.GetGuildSettings(ctx, &discordpb.GetGuildSettingsRequest{
+		GuildId: i.GuildID,
+	})
+	if err != nil {
+		log.Error("Failed to fetch guild settings", "error", err, "guild_id", i.GuildID)
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: "❌ Failed to fetch settings. Please try again.",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+
+	features := resp.GetSettings().GetFeatures()
+	if features == nil {
+		features = &discordpb.FeatureSettings{WikiEnabled: true, NotesEnabled: true, QuotesEnabled: true}
+	}
+	switch feature {
+	case commands.FeatureWiki:
+		features.WikiEnabled = enabled
+	case commands.FeatureNotes:
+		features.NotesEnabled = enabled
+	case commands.FeatureQuotes:
+		features.QuotesEnabled = enabled
+	default:
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: "❌ Unknown feature",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+
+	_, err = updateGuildSettings(ctx, grpcClient, &discordpb.UpdateGuildSettingsRequest{
+		GuildId: i.GuildID,
+		Settings: &discordpb.GuildSettings{
+			Features: features,
+		},
+		ExpectedRevision: resp.GetSettings().GetRevision(),
+	})
+	if err != nil {
+		log.Error("Failed to update guild settings", "error", err, "guild_id", i.GuildID)
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: settingsUpdateErrorMessage(err),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+
+	content := fmt.Sprintf("✅ %s enabled", featureLabel(feature))
+	if !enabled {
+		content = fmt.Sprintf("✅ %s disabled. Its commands will reply that the feature is disabled in this server.", featureLabel(feature))
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: content,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		log.Error("Failed to send followup", "error", err)
+	}
+
+	log.Info("Updated guild feature flag",
+		"guild_id", i.GuildID,
+		"feature", feature,
+		"enabled", enabled,
+		"admin_id", interactionUser(i).ID,
+	)
+}
+
+func handleSetColor(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
+	// Acknowledge immediately
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("Failed to acknowledge interaction", "error", err)
+		return
+	}
+
+	var content string
+	var color string
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "content":
+			content = opt.StringValue()
+		case "color":
+			color = strings.TrimSpace(opt.StringValue())
+		}
+	}
+
+	// Validate before touching settings so typos get a clear message
+	if color != "" {
+		normalized, err := colorutil.Normalize(color)
+		if err != nil {
+			_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+				Content: fmt.Sprintf("❌ `%s` is not a valid color. Use a hex value like `#00D9FF`.", color),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			})
+			return
+		}
+		color = normalized
+	}
+
+	ctx := context.Background()
+	discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
+
+	// Fetch current colors so only the chosen content type changes
+	resp, err := discordClient.GetGuildSettings(ctx, &discordpb.GetGuildSettingsRequest{
+		GuildId: i.GuildID,
+	})
+	if err != nil {
+		log.Error("Failed to fetch guild settings", "error", err, "guild_id", i.GuildID)
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: "❌ Failed to fetch settings. Please try again.",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+
+	appearance := resp.GetSettings().GetAppearance()
+	if appearance == nil {
+		appearance = &discordpb.AppearanceSettings{}
+	}
+	switch content {
+	case commands.FeatureWiki:
+		appearance.WikiColor = color
+	case commands.FeatureNotes:
+		appearance.NoteColor = color
+	case commands.FeatureQuotes:
+		appearance.QuoteColor = color
+	default:
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: "❌ Unknown content type",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+
+	_, err = updateGuildSettings(ctx, grpcClient, &discordpb.UpdateGuildSettingsRequest{
+		GuildId: i.GuildID,
+		Settings: &discordpb.GuildSettings{
+			Appearance: appearance,
+		},
+		ExpectedRevision: resp.GetSettings().GetRevision(),
+	})
+	if err != nil {
+		log.Error("Failed to update guild settings", "error", err, "guild_id", i.GuildID)
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: settingsUpdateErrorMessage(err),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+
+	reply := fmt.Sprintf("✅ %s embeds now use `%s`", featureLabel(content), color)
+	if color == "" {
+		reply = fmt.Sprintf("✅ %s embeds reset to the default color", featureLabel(content))
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: reply,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		log.Error("Failed to send followup", "error", err)
+	}
+
+	log.Info("Updated guild embed color",
+		"guild_id", i.GuildID,
+		"content", content,
+		"color", color,
+		"admin_id", interactionUser(i).ID,
+	)
+}
+
+func handleSetWikiEditors(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
+	// Acknowledge immediately
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("Failed to acknowledge interaction", "error", err)
+		return
+	}
+
+	var roleID string
+	var allowed bool
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "role":
+			roleID, _ = opt.Value.(string)
+		case "allowed":
+			allowed = opt.BoolValue()
+		}
+	}
+
+	ctx := context.Background()
+	discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
+
+	// Fetch current roles so only the chosen role changes
+	resp, err := discordClient.GetGuildSettings(ctx, &discordpb.GetGuildSettingsRequest{
+		GuildId: i.GuildID,
+	})
+	if err != nil {
+		log.Error("Failed to fetch guild settings", "error", err, "guild_id", i.GuildID)
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: "❌ Failed to fetch settings. Please try again.",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+
+	roles := slices.DeleteFunc(slices.Clone(resp.GetSettings().GetPermissions().GetWikiEditRoles()), func(r string) bool {
+		return r == roleID
+	})
+	if allowed {
+		roles = append(roles, roleID)
+	}
+
+	_, err = updateGuildSettings(ctx, grpcClient, &discordpb.UpdateGuildSettingsRequest{
+		GuildId: i.GuildID,
+		Settings: &discordpb.GuildSettings{
+			Permissions: &discordpb.PermissionSettings{WikiEditRoles: roles},
+		},
+		ExpectedRevision: resp.GetSettings().GetRevision(),
+	})
+	if err != nil {
+		log.Error("Failed to update guild settings", "error", err, "guild_id", i.GuildID)
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: settingsUpdateErrorMessage(err),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content:         "✅ " + wikiEditorsSummary(roles),
+		Flags:           discordgo.MessageFlagsEphemeral,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		log.Error("Failed to send followup", "error", err)
+	}
+
+	log.Info("Updated guild wiki editor roles",
+		"guild_id", i.GuildID,
+		"role_id", roleID,
+		"allowed", allowed,
+		"admin_id", interactionUser(i).ID,
+	)
+}
+
+// saveGuildSettings acknowledges a setter command, saves settings and replies with
+// content. The update expects the settings' current revision, so it fails rather
+// than overwrite a concurrent change. A failure is reported to the user and
+// returns false.
+func saveGuildSettings(s *discordgo.Session, i *discordgo.InteractionCreate, settings *discordpb.GuildSettings, content string, log *slog.Logger, grpcClient *client.Client) bool {
+	// Acknowledge immediately
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("Failed to acknowledge interaction", "error", err)
+		return false
+	}
+
+	ctx := context.Background()
+	discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
+
+	resp, err := discordClient.GetGuildSettings(ctx, &discordpb.GetGuildSettingsRequest{
+		GuildId: i.GuildID,
+	})
+	if err != nil {
+		log.Error("Failed to fetch guild settings", "error", err, "guild_id", i.GuildID)
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: "❌ Failed to fetch settings. Please try again.",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return false
+	}
+
+	_, err = updateGuildSettings(ctx, grpcClient, &discordpb.UpdateGuildSettingsRequest{
+		GuildId:          i.GuildID,
+		Settings:         settings,
+		ExpectedRevision: resp.GetSettings().GetRevision(),
+	})
+	if err != nil {
+		log.Error("Failed to update guild settings", "error", err, "guild_id", i.GuildID)
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: settingsUpdateErrorMessage(err),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return false
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: content,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		log.Error("Failed to send followup", "error", err)
+	}
+	return true
+}
+
+func handleSetReplies(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
+	var enabled bool
+	for _, opt := range subcommand.Options {
+		if opt.Name == "enabled" {
+			enabled = opt.BoolValue()
+		}
+	}
+
+	content := "✅ Shared quotes and wiki pages will reply to the message they were saved from when it is in the same channel"
+	if !enabled {
+		content = "✅ Shared quotes and wiki pages will be posted as new messages"
+	}
+
+	settings := &discordpb.GuildSettings{
+		Posting: &discordpb.PostingSettings{ReplyToSource: enabled},
+	}
+	if !saveGuildSettings(s, i, settings, content, log, grpcClient) {
+		return
+	}
+
+	log.Info("Updated guild reply setting",
+		"guild_id", i.GuildID,
+		"reply_to_source", enabled,
+		"admin_id", interactionUser(i).ID,
 	)
 }
 
+func handleSetUniqueNoteTitles(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
+	var enabled bool
+	for _, opt := range subcommand.Options {
+		if opt.Name == "enabled" {
+			enabled = opt.BoolValue()
+		}
+	}
+
+	content := "✅ Members can no longer save two notes with the same title in this server"
+	if !enabled {
+		content = "✅ Members can save notes with the same title as their other notes"
+	}
+
+	settings := &discordpb.GuildSettings{
+		Notes: &discordpb.NoteSettings{UniqueTitles: enabled},
+	}
+	if !saveGuildSettings(s, i, settings, content, log, grpcClient) {
+		return
+	}
+
+	log.Info("Updated guild note title setting",
+		"guild_id", i.GuildID,
+		"unique_titles", enabled,
+		"admin_id", interactionUser(i).ID,
+	)
+}
+
+func handleSetQuoteCooldown(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
+	var seconds int64
+	for _, opt := range subcommand.Options {
+		if opt.Name == "seconds" {
+			seconds = opt.IntValue()
+		}
+	}
+
+	content := fmt.Sprintf("✅ Members must now wait %s between quotes in this server", formatSeconds(seconds))
+	if seconds == 0 {
+		content = "✅ Members can save quotes as often as they like"
+	}
+
+	settings := &discordpb.GuildSettings{
+		Quotes: &discordpb.QuoteSettings{CooldownSeconds: int32(seconds)},
+	}
+	if !saveGuildSettings(s, i, settings, content, log, grpcClient) {
+		return
+	}
+
+	log.Info("Updated guild quote cooldown",
+		"guild_id", i.GuildID,
+		"cooldown_seconds", seconds,
+		"admin_id", interactionUser(i).ID,
+	)
+}
+
+func handleSetReferenceLimit(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
+	var count int64
+	for _, opt := range subcommand.Options {
+		if opt.Name == "count" {
+			count = opt.IntValue()
+		}
+	}
+
+	settings := &discordpb.GuildSettings{
+		References: &discordpb.ReferenceSettings{MaxPerItem: int32(count)},
+	}
+	if !saveGuildSettings(s, i, settings, "✅ "+referenceLimitSummary(int32(count)), log, grpcClient) {
+		return
+	}
+
+	log.Info("Updated guild reference limit",
+		"guild_id", i.GuildID,
+		"max_per_item", count,
+		"admin_id", interactionUser(i).ID,
+	)
+}
+
+// referenceLimitSummary describes how many messages each wiki page or note may
+// reference, where 0 means the default
+func referenceLimitSummary(limit int32) string {
+	if limit <= 0 {
+		return fmt.Sprintf("Each wiki page and note can reference up to %d messages (the default)", commands.DefaultReferenceLimit)
+	}
+	return fmt.Sprintf("Each wiki page and note can reference up to %d messages", limit)
+}
+
+func handleSetMessage(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
+	var event, tmpl string
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "event":
+			event = opt.StringValue()
+		case "template":
+			tmpl = strings.TrimSpace(opt.StringValue())
+		}
+	}
+
+	// Validate before acknowledging so a broken template gets a plain error
+	if tmpl != "" {
+		if err := msgtemplate.Validate(event, tmpl); err != nil {
+			respondError(s, i, fmt.Sprintf("That template can't be used: %v", err), log)
+			return
+		}
+	}
+
+	// Acknowledge immediately
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("Failed to acknowledge interaction", "error", err)
+		return
+	}
+
+	ctx := context.Background()
+	discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
+
+	// Fetch current templates so only the chosen event changes
+	resp, err := discordClient.GetGuildSettings(ctx, &discordpb.GetGuildSettingsRequest{
+		GuildId: i.GuildID,
+	})
+	if err != nil {
+		log.Error("Failed to fetch guild settings", "error", err, "guild_id", i.GuildID)
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: "❌ Failed to fetch settings. Please try again.",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+
+	templates := maps.Clone(resp.GetSettings().GetMessages().GetTemplates())
+	if templates == nil {
+		templates = map[string]string{}
+	}
+	if tmpl == "" {
+		delete(templates, event)
+	} else {
+		templates[event] = tmpl
+	}
+
+	_, err = updateGuildSettings(ctx, grpcClient, &discordpb.UpdateGuildSettingsRequest{
+		GuildId: i.GuildID,
+		Settings: &discordpb.GuildSettings{
+			Messages: &discordpb.MessageSettings{Templates: templates},
+		},
+		ExpectedRevision: resp.GetSettings().GetRevision(),
+	})
+	if err != nil {
+		log.Error("Failed to update guild settings", "error", err, "guild_id", i.GuildID)
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: settingsUpdateErrorMessage(err),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+
+	content := fmt.Sprintf("✅ The `%s` response now reads: %s", event, tmpl)
+	if tmpl == "" {
+		content = fmt.Sprintf("✅ The `%s` response is back to the default", event)
+	}
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content:         content,
+		Flags:           discordgo.MessageFlagsEphemeral,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		log.Error("Failed to send followup", "error", err)
+	}
+
+	log.Info("Updated guild message template",
+		"guild_id", i.GuildID,
+		"event", event,
+		"custom", tmpl != "",
+		"admin_id", interactionUser(i).ID,
+	)
+}
+
+// messageTemplatesSummary lists the responses a guild has reworded
+func messageTemplatesSummary(templates map[string]string) string {
+	if len(templates) == 0 {
+		return "All responses use the default wording"
+	}
+	var sb strings.Builder
+	for _, event := range msgtemplate.Events() {
+		if tmpl, ok := templates[event]; ok {
+			fmt.Fprintf(&sb, "`%s`: %s\n", event, tmpl)
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func handleResetSetting(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
+	var setting string
+	for _, opt := range subcommand.Options {
+		if opt.Name == "setting" {
+			setting = opt.StringValue()
+		}
+	}
+
+	// Validate before acknowledging so an unknown setting gets a plain error
+	defaults, err := defaultGuildSettings(setting)
+	if err != nil {
+		respondError(s, i, fmt.Sprintf("Unknown setting `%s`", setting), log)
+		return
+	}
+
+	// Only the section being reset is sent, so the server keeps the others
+	content := fmt.Sprintf("✅ %s reset to the default", settingLabel(setting))
+	if !saveGuildSettings(s, i, defaults, content, log, grpcClient) {
+		return
+	}
+
+	log.Info("Reset guild setting",
+		"guild_id", i.GuildID,
+		"setting", setting,
+		"admin_id", interactionUser(i).ID,
+	)
+}
+
+// defaultGuildSettings returns settings holding only the default value of one
+// section, for UpdateGuildSettings to overwrite that section and keep the rest
+func defaultGuildSettings(setting string) (*discordpb.GuildSettings, error) {
+	switch setting {
+	case commands.SettingAnnouncements:
+		return &discordpb.GuildSettings{Announcements: &discordpb.AnnouncementSettings{}}, nil
+	case commands.SettingFeatures:
+		return &discordpb.GuildSettings{Features: &discordpb.FeatureSettings{WikiEnabled: true, NotesEnabled: true, QuotesEnabled: true}}, nil
+	case commands.SettingColors:
+		return &discordpb.GuildSettings{Appearance: &discordpb.AppearanceSettings{}}, nil
+	case commands.SettingWikiEditors:
+		return &discordpb.GuildSettings{Permissions: &discordpb.PermissionSettings{}}, nil
+	case commands.SettingReplies:
+		return &discordpb.GuildSettings{Posting: &discordpb.PostingSettings{}}, nil
+	case commands.SettingUniqueNoteTitles:
+		return &discordpb.GuildSettings{Notes: &discordpb.NoteSettings{}}, nil
+	case commands.SettingQuoteCooldown:
+		return &discordpb.GuildSettings{Quotes: &discordpb.QuoteSettings{}}, nil
+	case commands.SettingReferenceLimit:
+		return &discordpb.GuildSettings{References: &discordpb.ReferenceSettings{}}, nil
+	case commands.SettingMessages:
+		return &discordpb.GuildSettings{Messages: &discordpb.MessageSettings{}}, nil
+	default:
+		return nil, fmt.Errorf("unknown setting %q", setting)
+	}
+}
+
+// settingLabel returns the display name for a resettable setting
+func settingLabel(setting string) string {
+	switch setting {
+	case commands.SettingAnnouncements:
+		return "🔔 Announcements"
+	case commands.SettingFeatures:
+		return "🧩 Features"
+	case commands.SettingColors:
+		return "🎨 Embed colors"
+	case commands.SettingWikiEditors:
+		return "🔒 Wiki editors"
+	case commands.SettingReplies:
+		return "↩️ Replies"
+	case commands.SettingUniqueNoteTitles:
+		return "📝 Unique note titles"
+	case commands.SettingQuoteCooldown:
+		return "⏳ Quote cooldown"
+	case commands.SettingReferenceLimit:
+		return "🔗 Reference limit"
+	case commands.SettingMessages:
+		return "💬 Messages"
+	default:
+		return setting
+	}
+}
+
+// wikiEditorsSummary describes who may create and edit wiki pages
+func wikiEditorsSummary(roles []string) string {
+	if len(roles) == 0 {
+		return "Every member can create and edit wiki pages"
+	}
+	mentions := make([]string, len(roles))
+	for idx, role := range roles {
+		mentions[idx] = fmt.Sprintf("<@&%s>", role)
+	}
+	return "Only the server owner and members with " + strings.Join(mentions, ", ") + " can create and edit wiki pages"
+}
+
+// settingsUpdateErrorMessage explains why a guild settings update failed
+func settingsUpdateErrorMessage(err error) string {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return fmt.Sprintf("❌ %s", status.Convert(err).Message())
+	case codes.Aborted:
+		return "❌ Someone else changed the settings at the same time. Please try again."
+	default:
+		return "❌ Failed to update settings. Please try again."
+	}
+}
+
+// featureLabel returns the display name for a feature
+func featureLabel(feature string) string {
+	switch feature {
+	case commands.FeatureWiki:
+		return "📚 Wiki"
+	case commands.FeatureNotes:
+		return "📝 Notes"
+	case commands.FeatureQuotes:
+		return "💬 Quotes"
+	default:
+		return feature
+	}
+}
+
 func handleShowConfig(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, grpcClient *client.Client) {
 	// Acknowledge immediately
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -189,6 +937,9 @@ func handleShowConfig(s *discordgo.Session, i *discordgo.InteractionCreate, log
 			if ann.NotifyQuoteCreate {
 				notifications = append(notifications, "• 💬 New quotes")
 			}
+			if ann.WeeklyQuoteLeaderboard {
+				notifications = append(notifications, "• 🏆 Weekly quote leaderboard")
+			}
 
 			if len(notifications) > 0 {
 				embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
@@ -206,8 +957,88 @@ func handleShowConfig(s *discordgo.Session, i *discordgo.InteractionCreate, log
 		})
 	}
 
+	// Features section
+	var featureLines []string
+	for _, feature := range []string{commands.FeatureWiki, commands.FeatureNotes, commands.FeatureQuotes} {
+		state := "✅"
+		if !commands.FeatureEnabled(resp.GetSettings().GetFeatures(), feature) {
+			state = "❌"
+		}
+		featureLines = append(featureLines, fmt.Sprintf("%s %s", state, featureLabel(feature)))
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "🧩 Features",
+		Value:  strings.Join(featureLines, "\n"),
+		Inline: false,
+	})
+
+	// Appearance section
+	colors := embedColorsFromSettings(resp.GetSettings().GetAppearance())
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name: "🎨 Embed Colors",
+		Value: fmt.Sprintf("%s: `%s`\n%s: `%s`\n%s: `%s`",
+			featureLabel(commands.FeatureWiki), colorutil.FormatHex(colors.Wiki),
+			featureLabel(commands.FeatureNotes), colorutil.FormatHex(colors.Note),
+			featureLabel(commands.FeatureQuotes), colorutil.FormatHex(colors.Quote)),
+		Inline: false,
+	})
+
+	// Permissions section
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "🔒 Wiki Editors",
+		Value:  wikiEditorsSummary(resp.GetSettings().GetPermissions().GetWikiEditRoles()),
+		Inline: false,
+	})
+
+	// Posting section
+	replies := "❌ Shared content is posted as a new message"
+	if resp.GetSettings().GetPosting().GetReplyToSource() {
+		replies = "✅ Shared content replies to the message it was saved from"
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "↩️ Replies",
+		Value:  replies,
+		Inline: false,
+	})
+
+	// Notes section
+	noteTitles := "❌ Members may reuse their note titles"
+	if resp.GetSettings().GetNotes().GetUniqueTitles() {
+		noteTitles = "✅ Each member's note titles must be unique"
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "📝 Unique Note Titles",
+		Value:  noteTitles,
+		Inline: false,
+	})
+
+	// Quotes section
+	quoteCooldown := "❌ Members may save quotes as often as they like"
+	if seconds := resp.GetSettings().GetQuotes().GetCooldownSeconds(); seconds > 0 {
+		quoteCooldown = fmt.Sprintf("✅ Members wait %s between quotes", formatSeconds(int64(seconds)))
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "⏳ Quote Cooldown",
+		Value:  quoteCooldown,
+		Inline: false,
+	})
+
+	// References section
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "🔗 Reference Limit",
+		Value:  referenceLimitSummary(resp.GetSettings().GetReferences().GetMaxPerItem()),
+		Inline: false,
+	})
+
+	// Messages section
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "💬 Messages",
+		Value:  messageTemplatesSummary(resp.GetSettings().GetMessages().GetTemplates()),
+		Inline: false,
+	})
+
 	embed.Footer = &discordgo.MessageEmbedFooter{
-		Text: "Use /hivemind setup-announcements to configure",
+		Text: "Use /hivemind setup-announcements, /hivemind features, /hivemind colors, /hivemind wiki-editors, /hivemind replies, /hivemind unique-note-titles, /hivemind quote-cooldown, /hivemind reference-limit, or /hivemind messages to configure, or /hivemind reset to undo",
 	}
 
 	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
@@ -218,3 +1049,47 @@ func handleShowConfig(s *discordgo.Session, i *discordgo.InteractionCreate, log
 		log.Error("Failed to send followup", "error", err)
 	}
 }
+
+// handleSyncMembers fetches the guild's full member list from Discord now rather than
+// waiting for the daily sync, and reports how many members were synced
+func handleSyncMembers(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, grpcClient *client.Client, memberSync *membersync.Syncer) {
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
+
+		// Read the previous sync time first, since this sync replaces it
+		var previous *timestamppb.Timestamp
+		guildResp, err := discordClient.GetGuild(discordContextFor(i), &discordpb.GetGuildRequest{GuildId: i.GuildID})
+		if err != nil {
+			log.Warn("failed to fetch guild before member sync",
+				slog.String("guild_id", i.GuildID),
+				slog.String("error", err.Error()))
+		} else {
+			previous = guildResp.GetGuild().GetLastMemberSync()
+		}
+
+		// Large guilds take several Discord requests, so don't hold the sync to the
+		// interaction's backend deadline
+		count, err := memberSync.Sync(context.Background(), i.GuildID)
+		if errors.Is(err, membersync.ErrSyncInProgress) {
+			return nil, userError("A member sync for this server is already running. Please try again in a few minutes.", err)
+		}
+		if err != nil {
+			return nil, userError("Failed to sync members. Please try again.", err)
+		}
+
+		return &discordgo.WebhookEdit{Content: ptrString(syncMembersMessage(count, previous))}, nil
+	})
+}
+
+// syncMembersMessage reports a finished member sync and when the one before it ran
+func syncMembersMessage(count int, previous *timestamppb.Timestamp) string {
+	members := "members"
+	if count == 1 {
+		members = "member"
+	}
+	message := fmt.Sprintf("✅ Synced %d %s.", count, members)
+	if previous == nil {
+		return message + " This is the first recorded member sync for this server."
+	}
+	return message + " The previous sync ran " + discordTimestamp(previous, timestampRelative) + "."
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+	"github.com/devilmonastery/hivemind/bot/internal/bot/commands"
+)
+
+func TestCanManageGuild(t *testing.T) {
+	tests := []struct {
+		name   string
+		member *discordgo.Member
+		want   bool
+	}{
+		{name: "no member (DM)", member: nil, want: false},
+		{name: "no permissions", member: &discordgo.Member{}, want: false},
+		{name: "unrelated permissions", member: &discordgo.Member{Permissions: discordgo.PermissionSendMessages | discordgo.PermissionManageMessages}, want: false},
+		{name: "manage server", member: &discordgo.Member{Permissions: discordgo.PermissionManageServer}, want: true},
+		{name: "administrator", member: &discordgo.Member{Permissions: discordgo.PermissionAdministrator}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canManageGuild(tt.member); got != tt.want {
+				t.Errorf("canManageGuild() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultGuildSettings(t *testing.T) {
+	for _, setting := range []string{commands.SettingAnnouncements, commands.SettingFeatures, commands.SettingColors, commands.SettingWikiEditors, commands.SettingReplies, commands.SettingUniqueNoteTitles, commands.SettingQuoteCooldown, commands.SettingReferenceLimit, commands.SettingMessages} {
+		t.Run(setting, func(t *testing.T) {
+			settings, err := defaultGuildSettings(setting)
+			if err != nil {
+				t.Fatalf("defaultGuildSettings(%q) error = %v", setting, err)
+			}
+
+			// Exactly one section is set, so the server leaves the others alone
+			sections := 0
+			for _, set := range []bool{settings.Announcements != nil, settings.Features != nil, settings.Appearance != nil, settings.Permissions != nil, settings.Webhook != nil, settings.Posting != nil, settings.Notes != nil, settings.Quotes != nil, settings.References != nil, settings.Messages != nil} {
+				if set {
+					sections++
+				}
+			}
+			if sections != 1 {
+				t.Errorf("defaultGuildSettings(%q) sets %d sections, want 1", setting, sections)
+			}
+		})
+	}
+
+	features, _ := defaultGuildSettings(commands.SettingFeatures)
+	for _, feature := range []string{commands.FeatureWiki, commands.FeatureNotes, commands.FeatureQuotes} {
+		if !commands.FeatureEnabled(features.Features, feature) {
+			t.Errorf("default features disable %s", feature)
+		}
+	}
+
+	announcements, _ := defaultGuildSettings(commands.SettingAnnouncements)
+	if announcements.Announcements.Enabled || announcements.Announcements.ChannelId != "" {
+		t.Errorf("default announcements = %+v, want disabled with no channel", announcements.Announcements)
+	}
+
+	if _, err := defaultGuildSettings("webhook"); err == nil {
+		t.Error("defaultGuildSettings(\"webhook\") error = nil, want unknown setting")
+	}
+}
+
+func TestSettingsUpdateErrorMessage(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: status.Error(codes.InvalidArgument, "invalid color"), want: "invalid color"},
+		{err: status.Error(codes.Aborted, "stale"), want: "at the same time"},
+		{err: status.Error(codes.Internal, "boom"), want: "Failed to update settings"},
+	}
+
+	for _, tt := range tests {
+		if got := settingsUpdateErrorMessage(tt.err); !strings.Contains(got, tt.want) {
+			t.Errorf("settingsUpdateErrorMessage(%v) = %q, want it to contain %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+// fakeSettingsServer serves settings and records guild settings updates
+type fakeSettingsServer struct {
+	discordpb.UnimplementedDiscordServiceServer
+	mu       sync.Mutex
+	settings *discordpb.GuildSettings
+	updates  []*discordpb.UpdateGuildSettingsRequest
+}
+
+func (f *fakeSettingsServer) GetGuildSettings(ctx context.Context, req *discordpb.GetGuildSettingsRequest) (*discordpb.GetGuildSettingsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &discordpb.GetGuildSettingsResponse{Settings: f.settings}, nil
+}
+
+func (f *fakeSettingsServer) UpdateGuildSettings(ctx context.Context, req *discordpb.UpdateGuildSettingsRequest) (*discordpb.UpdateGuildSettingsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.updates = append(f.updates, req)
+	return &discordpb.UpdateGuildSettingsResponse{Settings: req.Settings}, nil
+}
+
+// Re-running setup-announcements without the weekly option must keep the current value
+func TestSetupAnnouncements_KeepsWeeklyLeaderboard(t *testing.T) {
+	s, _ := newFakeDiscordSession(t)
+	settings := &fakeSettingsServer{settings: &discordpb.GuildSettings{
+		Announcements: &discordpb.AnnouncementSettings{Enabled: true, ChannelId: "c1", WeeklyQuoteLeaderboard: true},
+	}}
+	grpcClient := newTestGRPCClient(t, func(server *grpc.Server) {
+		discordpb.RegisterDiscordServiceServer(server, settings)
+	})
+
+	tests := []struct {
+		name    string
+		options []*discordgo.ApplicationCommandInteractionDataOption
+		want    bool
+	}{
+		{name: "option absent", want: true},
+		{
+			name: "option false",
+			options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "weekly-quote-leaderboard", Type: discordgo.ApplicationCommandOptionBoolean, Value: false},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings.updates = nil
+			subcommand := &discordgo.ApplicationCommandInteractionDataOption{Name: "setup-announcements", Options: tt.options}
+
+			handleSetupAnnouncements(s, testInteraction(), subcommand, slog.New(slog.NewTextHandler(io.Discard, nil)), grpcClient)
+
+			if len(settings.updates) != 1 {
+				t.Fatalf("UpdateGuildSettings() calls = %d, want 1", len(settings.updates))
+			}
+			if got := settings.updates[0].Settings.GetAnnouncements().GetWeeklyQuoteLeaderboard(); got != tt.want {
+				t.Errorf("WeeklyQuoteLeaderboard = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Every setter must expect the revision it read, so a concurrent change isn't overwritten
+func TestSetters_SendExpectedRevision(t *testing.T) {
+	s, _ := newFakeDiscordSession(t)
+	settings := &fakeSettingsServer{settings: &discordpb.GuildSettings{Revision: 7}}
+	grpcClient := newTestGRPCClient(t, func(server *grpc.Server) {
+		discordpb.RegisterDiscordServiceServer(server, settings)
+	})
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	setters := map[string]func(*discordgo.ApplicationCommandInteractionDataOption){
+		"setup-announcements": func(sub *discordgo.ApplicationCommandInteractionDataOption) {
+			handleSetupAnnouncements(s, testInteraction(), sub, log, grpcClient)
+		},
+		"quote-cooldown": func(sub *discordgo.ApplicationCommandInteractionDataOption) {
+			handleSetQuoteCooldown(s, testInteraction(), sub, log, grpcClient)
+		},
+		"reference-limit": func(sub *discordgo.ApplicationCommandInteractionDataOption) {
+			handleSetReferenceLimit(s, testInteraction(), sub, log, grpcClient)
+		},
+		"reset": func(sub *discordgo.ApplicationCommandInteractionDataOption) {
+			sub.Options = []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "setting", Type: discordgo.ApplicationCommandOptionString, Value: commands.SettingAnnouncements},
+			}
+			handleResetSetting(s, testInteraction(), sub, log, grpcClient)
+		},
+	}
+
+	for name, set := range setters {
+		settings.updates = nil
+		set(&discordgo.ApplicationCommandInteractionDataOption{Name: name})
+
+		if len(settings.updates) != 1 {
+			t.Fatalf("%s: UpdateGuildSettings() calls = %d, want 1", name, len(settings.updates))
+		}
+		if got := settings.updates[0].ExpectedRevision; got != 7 {
+			t.Errorf("%s: ExpectedRevision = %d, want 7", name, got)
+		}
+	}
+}
+
+// Interactions from a member without an embedded user, or from a DM, must not crash the setters
+func TestSetQuoteCooldown_MemberWithoutUser(t *testing.T) {
+	s, discord := newFakeDiscordSession(t)
+	settings := &fakeSettingsServer{}
+	grpcClient := newTestGRPCClient(t, func(server *grpc.Server) {
+		discordpb.RegisterDiscordServiceServer(server, settings)
+	})
+
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:      "i1",
+		AppID:   "app",
+		Token:   "token",
+		GuildID: "g1",
+		Member:  &discordgo.Member{Permissions: discordgo.PermissionManageServer},
+	}}
+	subcommand := &discordgo.ApplicationCommandInteractionDataOption{
+		Name: "quote-cooldown",
+		Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			{Name: "seconds", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(30)},
+		},
+	}
+
+	handleSetQuoteCooldown(s, i, subcommand, slog.New(slog.NewTextHandler(io.Discard, nil)), grpcClient)
+
+	if len(settings.updates) != 1 || settings.updates[0].Settings.GetQuotes().GetCooldownSeconds() != 30 {
+		t.Errorf("UpdateGuildSettings() calls = %v, want one setting a 30s cooldown", settings.updates)
+	}
+	if discord.indexOf(http.MethodPost, "/webhooks/app/token") < 0 {
+		t.Errorf("Discord requests = %+v, want a followup with the result", discord.requests)
+	}
+}
+
+func TestHivemind_DMRejected(t *testing.T) {
+	s, discord := newFakeDiscordSession(t)
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:    "i1",
+		Token: "token",
+		Type:  discordgo.InteractionApplicationCommand,
+		User:  &discordgo.User{ID: "u1"},
+		Data:  discordgo.ApplicationCommandInteractionData{Name: "hivemind"},
+	}}
+
+	handleHivemind(s, i, slog.New(slog.NewTextHandler(io.Discard, nil)), nil, nil)
+
+	if len(discord.requests) != 1 {
+		t.Fatalf("Discord requests = %+v, want one reply", discord.requests)
+	}
+	data, _ := discord.requests[0].body["data"].(map[string]any)
+	if content, _ := data["content"].(string); !strings.Contains(content, "only be used in servers") {
+		t.Errorf("Discord requests = %+v, want one reply saying the command only works in servers", discord.requests)
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// emptyMessagePlaceholder is stored when a message has no text and nothing else to describe
+const emptyMessagePlaceholder = "[no text content]"
+
+// messageReferenceContent returns the text to store for a message reference.
+// Callers fetch the message via REST first, but Content can still be empty without the
+// MESSAGE_CONTENT intent or for attachment-only posts. In that case a placeholder is built
+// from the attachments, embeds, and stickers (e.g. "[image] filename.png") so the saved
+// reference isn't blank.
+func messageReferenceContent(message *discordgo.Message) string {
+	if message == nil {
+		return emptyMessagePlaceholder
+	}
+	if strings.TrimSpace(message.Content) != "" {
+		return message.Content
+	}
+
+	var parts []string
+	for _, attachment := range message.Attachments {
+		parts = append(parts, fmt.Sprintf("[%s] %s", attachmentKind(attachment.ContentType), attachment.Filename))
+	}
+	for _, embed := range message.Embeds {
+		switch {
+		case embed.Title != "":
+			parts = append(parts, "[embed] "+embed.Title)
+		case embed.URL != "":
+			parts = append(parts, "[embed] "+embed.URL)
+		default:
+			parts = append(parts, "[embed]")
+		}
+	}
+	for _, sticker := range message.StickerItems {
+		parts = append(parts, "[sticker] "+sticker.Name)
+	}
+
+	if len(parts) == 0 {
+		return emptyMessagePlaceholder
+	}
+	return strings.Join(parts, "\n")
+}
+
+// attachmentKind returns a short label for an attachment's MIME type
+func attachmentKind(contentType string) string {
+	switch {
+	case strings.HasPrefix(contentType, "image/"):
+		return "image"
+	case strings.HasPrefix(contentType, "video/"):
+		return "video"
+	case strings.HasPrefix(contentType, "audio/"):
+		return "audio"
+	default:
+		return "file"
+	}
+}
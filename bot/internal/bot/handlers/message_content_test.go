@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestMessageReferenceContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		message *discordgo.Message
+		want    string
+	}{
+		{
+			name:    "text content is kept",
+			message: &discordgo.Message{Content: "hello world"},
+			want:    "hello world",
+		},
+		{
+			name: "text content wins over attachments",
+			message: &discordgo.Message{
+				Content:     "look at this",
+				Attachments: []*discordgo.MessageAttachment{{Filename: "cat.png", ContentType: "image/png"}},
+			},
+			want: "look at this",
+		},
+		{
+			name: "attachment only",
+			message: &discordgo.Message{
+				Attachments: []*discordgo.MessageAttachment{{Filename: "cat.png", ContentType: "image/png"}},
+			},
+			want: "[image] cat.png",
+		},
+		{
+			name: "multiple attachment kinds",
+			message: &discordgo.Message{
+				Attachments: []*discordgo.MessageAttachment{
+					{Filename: "clip.mp4", ContentType: "video/mp4"},
+					{Filename: "memo.ogg", ContentType: "audio/ogg"},
+					{Filename: "notes.pdf", ContentType: "application/pdf"},
+					{Filename: "unknown.bin"},
+				},
+			},
+			want: "[video] clip.mp4\n[audio] memo.ogg\n[file] notes.pdf\n[file] unknown.bin",
+		},
+		{
+			name: "embeds and stickers",
+			message: &discordgo.Message{
+				Embeds: []*discordgo.MessageEmbed{
+					{Title: "Release notes"},
+					{URL: "https://example.com"},
+					{},
+				},
+				StickerItems: []*discordgo.StickerItem{{Name: "wave"}},
+			},
+			want: "[embed] Release notes\n[embed] https://example.com\n[embed]\n[sticker] wave",
+		},
+		{
+			name:    "whitespace-only content is treated as empty",
+			message: &discordgo.Message{Content: "  \n "},
+			want:    emptyMessagePlaceholder,
+		},
+		{
+			name:    "fully empty message",
+			message: &discordgo.Message{},
+			want:    emptyMessagePlaceholder,
+		},
+		{
+			name:    "nil message",
+			message: nil,
+			want:    emptyMessagePlaceholder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := messageReferenceContent(tt.message); got != tt.want {
+				t.Errorf("messageReferenceContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
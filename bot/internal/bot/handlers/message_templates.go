@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/devilmonastery/hivemind/internal/client"
+	"github.com/devilmonastery/hivemind/internal/pkg/msgtemplate"
+)
+
+// guildMessageTemplates fetches the message templates a guild has set, keyed by event.
+// Personal content (no guild) and failed lookups get none, so callers use the built-in text.
+func guildMessageTemplates(guildID string, grpcClient *client.Client, log *slog.Logger) map[string]string {
+	if guildID == "" {
+		return nil
+	}
+
+	settings, err := guildSettings(guildID, grpcClient)
+	if err != nil {
+		log.Debug("failed to fetch guild settings for message templates, using defaults",
+			slog.String("guild_id", guildID),
+			slog.String("error", err.Error()))
+		return nil
+	}
+
+	return settings.GetMessages().GetTemplates()
+}
+
+// guildMessage renders the guild's template for event with vars, or returns fallback,
+// the built-in text, when the guild hasn't set one
+func guildMessage(templates map[string]string, event, fallback string, vars map[string]string) string {
+	tmpl, ok := templates[event]
+	if !ok || tmpl == "" {
+		return fallback
+	}
+	return msgtemplate.Render(tmpl, vars)
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/devilmonastery/hivemind/internal/pkg/msgtemplate"
+)
+
+func TestGuildMessage(t *testing.T) {
+	templates := map[string]string{
+		msgtemplate.NoteCreated: "📒 Filed {title}",
+		msgtemplate.QuoteSaved:  "",
+	}
+
+	tests := []struct {
+		name      string
+		templates map[string]string
+		event     string
+		want      string
+	}{
+		{name: "guild template", templates: templates, event: msgtemplate.NoteCreated, want: "📒 Filed Raid plan"},
+		{name: "empty template keeps the default", templates: templates, event: msgtemplate.QuoteSaved, want: "default"},
+		{name: "unset event keeps the default", templates: templates, event: msgtemplate.NoResults, want: "default"},
+		{name: "no templates", templates: nil, event: msgtemplate.NoteCreated, want: "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := guildMessage(tt.templates, tt.event, "default", map[string]string{"title": "Raid plan"})
+			if got != tt.want {
+				t.Errorf("guildMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,18 +7,30 @@ import (
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
 	"github.com/devilmonastery/hivemind/bot/internal/config"
 	"github.com/devilmonastery/hivemind/internal/client"
+	"github.com/devilmonastery/hivemind/internal/pkg/msgtemplate"
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
 	"github.com/devilmonastery/hivemind/internal/pkg/urlutil"
 )
 
-// mustBuildNoteURL builds a note URL and panics on error (should never happen with valid baseURL)
-func mustBuildNoteURL(baseURL, noteID string) string {
-	url, err := urlutil.BuildNoteViewURL(baseURL, noteID)
+// mustBuildNoteURL builds a note's web URL, preferring its slug permalink over its ID
+// for notes saved before slugs existed, and never fails
+func mustBuildNoteURL(baseURL string, note *notespb.Note) string {
+	if note.Slug != "" {
+		if url, err := urlutil.BuildNoteSlugURL(baseURL, note.GuildId, note.Slug); err == nil {
+			return url
+		}
+	}
+	url, err := urlutil.BuildNoteViewURL(baseURL, note.Id)
 	if err != nil {
 		// Fallback to simple concatenation if URL parsing fails
-		return baseURL + "/note?id=" + noteID
+		return baseURL + "/note?id=" + note.Id
 	}
 	return url
 }
@@ -60,6 +72,8 @@ func handleNote(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *confi
 		handleNoteCreate(s, i, subcommand, log, grpcClient)
 	case "view":
 		handleNoteView(s, i, subcommand, cfg, log, grpcClient)
+	case "share":
+		handleNoteShare(s, i, subcommand, cfg, log, grpcClient)
 	case "search":
 		handleNoteSearch(s, i, subcommand, log, grpcClient)
 	default:
@@ -108,7 +122,7 @@ func handleNoteCreate(s *discordgo.Session, i *discordgo.InteractionCreate, subc
 }
 
 // handleNoteCreateModal handles the modal submission for note creation
-func handleNoteCreateModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+func handleNoteCreateModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
 	data := i.ModalSubmitData()
 
 	var title, body string
@@ -165,28 +179,28 @@ func handleNoteCreateModal(s *discordgo.Session, i *discordgo.InteractionCreate,
 		Tags:  tags,
 	}
 
-	// Add guild context if in a guild
+	// Add guild context if in a guild, otherwise fall back to the user's default guild
 	if i.GuildID != "" {
 		req.GuildId = i.GuildID
 		req.ChannelId = i.ChannelID
+	} else {
+		req.GuildId = guildIDFor(ctx, i, grpcClient, log)
 	}
 
 	resp, err := noteClient.CreateNote(ctx, req)
 	if err != nil {
 		log.Error("Failed to create note", "error", err)
-		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("❌ Failed to create note: %v", err),
-			Flags:   discordgo.MessageFlagsEphemeral,
-		})
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, noteSaveFailedMessage("create", title, err))
 		return
 	}
+	cache.InvalidateNoteTitles(interactionUser(i).ID, resp.GuildId)
 
 	// Fetch message references
 	refs := fetchNoteMessageReferences(ctx, noteClient, resp.Id, log)
 
 	// Show standard note embed
-	embed, components := createNoteEmbed(resp, refs, cfg, log)
-	embed.Title = "✅ Note Created\n\n" + embed.Title
+	embed, components := createNoteEmbed(s, resp, refs, cfg, guildEmbedColors(resp.GuildId, grpcClient, log).Note, log)
+	embed.Title = noteCreatedHeading(resp, grpcClient, log) + "\n\n" + embed.Title
 
 	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
 		Embeds:     []*discordgo.MessageEmbed{embed},
@@ -196,11 +210,83 @@ func handleNoteCreateModal(s *discordgo.Session, i *discordgo.InteractionCreate,
 	if err != nil {
 		log.Error("Failed to send followup", "error", err)
 	}
+
+	if resp.GuildId != "" {
+		sendTagSuggestions(s, i, "note_add_tag", resp.Id, &wikipb.SuggestTagsRequest{
+			GuildId:     resp.GuildId,
+			Body:        resp.Body,
+			ExcludeTags: resp.Tags,
+		}, log, grpcClient)
+	}
+}
+
+// noteTitleConflict returns the ID of the note whose title a failed create or update
+// collided with, or "" if it failed for another reason
+func noteTitleConflict(err error) string {
+	if status.Code(err) != codes.AlreadyExists || !rpcerr.HasReason(err, rpcerr.ReasonNoteTitleTaken) {
+		return ""
+	}
+	return rpcerr.Metadata(err, rpcerr.MetadataNoteID)
+}
+
+// noteSaveFailedMessage builds the follow-up shown when creating or updating a note
+// fails. A title that's already taken offers to open the existing note instead.
+func noteSaveFailedMessage(action, title string, err error) *discordgo.WebhookParams {
+	existingID := noteTitleConflict(err)
+	if existingID == "" {
+		return &discordgo.WebhookParams{
+			Content: fmt.Sprintf("❌ Failed to %s note: %v", action, err),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		}
+	}
+
+	return &discordgo.WebhookParams{
+		Content: fmt.Sprintf("❌ You already have a note titled **%s**, and this server requires note titles to be unique. Pick another title or open the existing note.", title),
+		Flags:   discordgo.MessageFlagsEphemeral,
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "📝 Open existing note",
+						Style:    discordgo.PrimaryButton,
+						CustomID: fmt.Sprintf("note_open_btn:%s", existingID),
+					},
+				},
+			},
+		},
+	}
+}
+
+// handleNoteOpenButton shows a note ephemerally from the button offered when a note
+// title is already taken
+func handleNoteOpenButton(s *discordgo.Session, i *discordgo.InteractionCreate, noteID string, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		noteClient := notespb.NewNoteServiceClient(grpcClient.Conn())
+		ctx := discordContextFor(i)
+
+		note, err := noteClient.GetNote(ctx, &notespb.GetNoteRequest{Id: noteID})
+		if err != nil {
+			return nil, userError("Failed to fetch note", err)
+		}
+
+		refs := fetchNoteMessageReferences(ctx, noteClient, note.Id, log)
+		embed, components := createNoteEmbed(s, note, refs, cfg, guildEmbedColors(note.GuildId, grpcClient, log).Note, log)
+		return &discordgo.WebhookEdit{
+			Embeds:     &[]*discordgo.MessageEmbed{embed},
+			Components: &components,
+		}, nil
+	})
+}
+
+// noteCreatedHeading returns the line shown above a newly created note: the guild's
+// note_created template, or "✅ Note Created"
+func noteCreatedHeading(note *notespb.Note, grpcClient *client.Client, log *slog.Logger) string {
+	return guildMessage(guildMessageTemplates(note.GuildId, grpcClient, log), msgtemplate.NoteCreated,
+		"✅ Note Created", map[string]string{"title": note.Title})
 }
 
-// handleNoteList lists user's notes
 // createNoteEmbed creates an embed for displaying a note with action buttons
-func createNoteEmbed(note *notespb.Note, references []*notespb.NoteMessageReference, cfg *config.Config, log *slog.Logger) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+func createNoteEmbed(s *discordgo.Session, note *notespb.Note, references []*notespb.NoteMessageReference, cfg *config.Config, color int, log *slog.Logger) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
 	title := note.Title
 	if title == "" {
 		title = "(untitled)"
@@ -209,29 +295,25 @@ func createNoteEmbed(note *notespb.Note, references []*notespb.NoteMessageRefere
 	embed := &discordgo.MessageEmbed{
 		Title:       title,
 		Description: note.Body,
-		Color:       0x5865F2,
-		Timestamp:   note.CreatedAt.AsTime().Format("2006-01-02T15:04:05Z07:00"),
+		Color:       color,
+		Fields:      createdUpdatedFields(note.CreatedAt, note.UpdatedAt),
 	}
 
 	// Add message references field if any exist
 	if len(references) > 0 {
+		images := make([]referenceImage, len(references))
+		for idx, ref := range references {
+			images[idx] = referenceImage{sentAt: ref.MessageTimestamp, url: firstImageURL(ref.Attachments)}
+		}
+		setReferenceImage(embed, images)
+
 		// Build reference list with datetime and content preview
 		refsList := ""
-		displayCount := min(5, len(references)) // Show up to 5
+		displayCount := min(embedReferenceLimit, len(references))
 		for idx := 0; idx < displayCount; idx++ {
 			ref := references[idx]
 			messageLink := urlutil.DiscordMessageURL(ref.GuildId, ref.ChannelId, ref.MessageId)
-
-			// Format timestamp
-			timestamp := ref.MessageTimestamp.AsTime().Format("2006-01-02 15:04")
-
-			// Truncate content preview
-			contentPreview := ref.Content
-			if len(contentPreview) > 60 {
-				contentPreview = contentPreview[:57] + "..."
-			}
-
-			refsList += fmt.Sprintf("• [%s](%s) - %s\n  _%s_\n", ref.AuthorUsername, messageLink, timestamp, contentPreview)
+			refsList += referenceLine(ref.AuthorUsername, messageLink, ref.MessageTimestamp, ref.Content, stateMentionResolver(s, ref.GuildId))
 		}
 		if len(references) > displayCount {
 			refsList += fmt.Sprintf("_...and %d more_", len(references)-displayCount)
@@ -241,13 +323,11 @@ func createNoteEmbed(note *notespb.Note, references []*notespb.NoteMessageRefere
 			slog.Int("ref_count", len(references)),
 			slog.Int("displayed", displayCount))
 
-		embed.Fields = []*discordgo.MessageEmbedField{
-			{
-				Name:   fmt.Sprintf("📌 Referenced Messages (%d)", len(references)),
-				Value:  refsList,
-				Inline: false,
-			},
-		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("📌 Referenced Messages (%d)", len(references)),
+			Value:  refsList,
+			Inline: false,
+		})
 	} else {
 		log.Info("no message references to display for note")
 	}
@@ -271,13 +351,18 @@ func createNoteEmbed(note *notespb.Note, references []*notespb.NoteMessageRefere
 					},
 				},
 				discordgo.Button{
-					Label: "View on Web",
-					Style: discordgo.LinkButton,
-					URL:   mustBuildNoteURL(getWebBaseURL(cfg), note.Id),
+					Label:    "Make visible to channel",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("note_share_btn:%s", note.Id),
 					Emoji: &discordgo.ComponentEmoji{
-						Name: "🌐",
+						Name: "📢",
 					},
 				},
+				discordgo.Button{
+					Label: webLinkLabel(cfg),
+					Style: discordgo.LinkButton,
+					URL:   mustBuildNoteURL(getWebBaseURL(cfg), note),
+				},
 				discordgo.Button{
 					Label:    "Close",
 					Style:    discordgo.SecondaryButton,
@@ -290,9 +375,30 @@ func createNoteEmbed(note *notespb.Note, references []*notespb.NoteMessageRefere
 		},
 	}
 
+	if len(references) > embedReferenceLimit {
+		components = append(components, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{showAllReferencesButton("note_refs", note.Id)},
+		})
+	}
+
 	return embed, components
 }
 
+// matchNotesByTitle returns up to limit notes whose title contains titleQuery, ignoring case
+func matchNotesByTitle(notes []*notespb.Note, titleQuery string, limit int) []*notespb.Note {
+	var matches []*notespb.Note
+	titleLower := strings.ToLower(titleQuery)
+	for _, note := range notes {
+		if strings.Contains(strings.ToLower(note.Title), titleLower) {
+			matches = append(matches, note)
+			if len(matches) >= limit {
+				break
+			}
+		}
+	}
+	return matches
+}
+
 // handleNoteView shows a specific note
 func handleNoteView(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
 	if len(subcommand.Options) == 0 {
@@ -304,7 +410,7 @@ func handleNoteView(s *discordgo.Session, i *discordgo.InteractionCreate, subcom
 
 	log.Info("note view command",
 		"title_query", titleQuery,
-		"user_id", i.Member.User.ID,
+		"user_id", interactionUser(i).ID,
 		"guild_id", i.GuildID)
 
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -323,7 +429,7 @@ func handleNoteView(s *discordgo.Session, i *discordgo.InteractionCreate, subcom
 
 	// List notes and filter by title (exact or partial match)
 	listResp, err := noteClient.ListNotes(ctx, &notespb.ListNotesRequest{
-		GuildId: i.GuildID,
+		GuildId: guildIDFor(ctx, i, grpcClient, log),
 		Limit:   100, // Get enough notes to find matches
 	})
 	if err != nil {
@@ -340,29 +446,18 @@ func handleNoteView(s *discordgo.Session, i *discordgo.InteractionCreate, subcom
 		"title_query", titleQuery)
 
 	// Filter notes by title (case-insensitive partial match)
-	var matchingNotes []*notespb.Note
-	titleLower := strings.ToLower(titleQuery)
-	for _, note := range listResp.Notes {
-		log.Info("note view - checking note",
-			"note_id", note.Id,
-			"note_title", note.Title,
-			"guild_id", note.GuildId)
-		noteTitleLower := strings.ToLower(note.Title)
-		if strings.Contains(noteTitleLower, titleLower) {
-			matchingNotes = append(matchingNotes, note)
-			if len(matchingNotes) >= 5 {
-				break // Limit to 5 matches
-			}
-		}
-	}
+	matchingNotes := matchNotesByTitle(listResp.Notes, titleQuery, 5)
 
 	log.Info("note view - filtering complete",
 		"matching_notes", len(matchingNotes))
 
 	// No results
 	if len(matchingNotes) == 0 {
+		content := guildMessage(guildMessageTemplates(i.GuildID, grpcClient, log), msgtemplate.NoResults,
+			fmt.Sprintf("📝 No notes found matching \"%s\"", titleQuery),
+			map[string]string{"query": titleQuery, "kind": "notes"})
 		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("📝 No notes found matching \"%s\"", titleQuery),
+			Content: content,
 			Flags:   discordgo.MessageFlagsEphemeral,
 		})
 		return
@@ -379,7 +474,7 @@ func handleNoteView(s *discordgo.Session, i *discordgo.InteractionCreate, subcom
 			slog.String("note_title", note.Title),
 			slog.Int("ref_count", len(refs)))
 
-		embed, components := createNoteEmbed(note, refs, cfg, log)
+		embed, components := createNoteEmbed(s, note, refs, cfg, guildEmbedColors(note.GuildId, grpcClient, log).Note, log)
 
 		_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
 			Embeds:     []*discordgo.MessageEmbed{embed},
@@ -428,24 +523,19 @@ func handleNoteSearch(s *discordgo.Session, i *discordgo.InteractionCreate, subc
 		return
 	}
 
-	var query, guildID string
+	var query string
 	var tags []string
 	limit := int32(25) // Increased to match Discord's dropdown limit
-
-	// Default to current guild if command is run in a guild
-	if i.GuildID != "" {
-		guildID = i.GuildID
-	}
+	var orderBy string
+	var ascending bool
+	var scope string
 
 	for _, opt := range subcommand.Options {
 		switch opt.Name {
 		case "query":
 			query = opt.StringValue()
-		case "guild":
-			// Allow users to override default and search across all guilds
-			if !opt.BoolValue() {
-				guildID = "" // Search all guilds
-			}
+		case "scope":
+			scope = opt.StringValue()
 		case "tags":
 			tagStr := opt.StringValue()
 			parts := strings.Split(tagStr, ",")
@@ -460,126 +550,116 @@ func handleNoteSearch(s *discordgo.Session, i *discordgo.InteractionCreate, subc
 			if limit > 25 {
 				limit = 25 // Cap at Discord's dropdown limit
 			}
+		case "sort":
+			orderBy, ascending = searchSortOrder(opt.StringValue())
 		}
 	}
 
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Flags: discordgo.MessageFlagsEphemeral,
-		},
-	})
-	if err != nil {
-		log.Error("Failed to defer response", "error", err)
-		return
-	}
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		noteClient := notespb.NewNoteServiceClient(grpcClient.Conn())
+		ctx := discordContextFor(i)
 
-	noteClient := notespb.NewNoteServiceClient(grpcClient.Conn())
-	ctx := discordContextFor(i)
+		// Notes are always the caller's own, so anyone may search all of them
+		guildID, guildIDs, err := resolveSearchScope(ctx, i, scope, true, grpcClient, log)
+		if err != nil {
+			return nil, err
+		}
 
-	req := &notespb.SearchNotesRequest{
-		Query:   query,
-		GuildId: guildID,
-		Tags:    tags,
-		Limit:   limit,
-	}
+		req := &notespb.SearchNotesRequest{
+			Query:     query,
+			GuildId:   guildID,
+			GuildIds:  guildIDs,
+			Tags:      tags,
+			Limit:     limit,
+			OrderBy:   orderBy,
+			Ascending: ascending,
+		}
 
-	resp, err := noteClient.SearchNotes(ctx, req)
-	if err != nil {
-		log.Error("Failed to search notes", "error", err)
-		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("❌ Failed to search notes: %v", err),
-			Flags:   discordgo.MessageFlagsEphemeral,
-		})
-		return
-	}
+		resp, err := noteClient.SearchNotes(ctx, req)
+		if err != nil {
+			return nil, userError(fmt.Sprintf("Failed to search notes: %v", err), err)
+		}
 
-	if len(resp.Notes) == 0 {
-		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("No notes found matching \"%s\"", query),
-			Flags:   discordgo.MessageFlagsEphemeral,
-		})
-		return
-	}
+		if len(resp.Notes) == 0 {
+			return &discordgo.WebhookEdit{
+				Content: ptrString(guildMessage(guildMessageTemplates(i.GuildID, grpcClient, log), msgtemplate.NoResults,
+					fmt.Sprintf("No notes found matching \"%s\"", query),
+					map[string]string{"query": query, "kind": "notes"})),
+			}, nil
+		}
 
-	// Show search results with a dropdown to select and post a note
-	// Limit to 25 results (Discord's max for select menus)
-	displayLimit := len(resp.Notes)
-	if displayLimit > 25 {
-		displayLimit = 25
-	}
+		// Show search results with a dropdown to select and post a note
+		// Limit to 25 results (Discord's max for select menus)
+		displayLimit := len(resp.Notes)
+		if displayLimit > 25 {
+			displayLimit = 25
+		}
 
-	var content strings.Builder
-	content.WriteString(fmt.Sprintf("🔍 Found %d note(s) matching \"%s\"\n", resp.Total, query))
-	content.WriteString("Select a note from the dropdown to view:")
-
-	// Build select menu options
-	options := []discordgo.SelectMenuOption{}
-	for idx := 0; idx < displayLimit; idx++ {
-		note := resp.Notes[idx]
-
-		// Create a label from title or body snippet (truncate if too long - Discord max is 100 chars)
-		label := note.Title
-		if label == "" {
-			// Use body snippet if no title
-			label = note.Body
-			if len(label) > 100 {
+		var content strings.Builder
+		content.WriteString(fmt.Sprintf("🔍 Found %d note(s) matching \"%s\"\n", resp.Total, query))
+		content.WriteString("Select a note from the dropdown to view:")
+
+		// Build select menu options
+		options := []discordgo.SelectMenuOption{}
+		for idx := 0; idx < displayLimit; idx++ {
+			note := resp.Notes[idx]
+
+			// Create a label from title or body snippet (truncate if too long - Discord max is 100 chars)
+			label := note.Title
+			if label == "" {
+				// Use body snippet if no title
+				label = note.Body
+				if len(label) > 100 {
+					label = label[:97] + "..."
+				}
+			} else if len(label) > 100 {
 				label = label[:97] + "..."
 			}
-		} else if len(label) > 100 {
-			label = label[:97] + "..."
-		}
 
-		// Create a description with body snippet or tags
-		description := ""
-		if note.Title != "" && note.Body != "" {
-			// If we have a title, show body snippet in description
-			description = note.Body
-			if len(description) > 100 {
-				description = description[:97] + "..."
-			}
-		} else if len(note.Tags) > 0 {
-			// Otherwise show tags
-			description = "Tags: " + strings.Join(note.Tags, ", ")
-			if len(description) > 100 {
-				description = description[:97] + "..."
+			// Create a description with body snippet or tags
+			description := ""
+			if note.Title != "" && note.Body != "" {
+				// If we have a title, show the matching snippet in description
+				description = searchExcerpt(note.Snippet, note.Body)
+			} else if len(note.Tags) > 0 {
+				// Otherwise show tags
+				description = "Tags: " + strings.Join(note.Tags, ", ")
+				if len(description) > 100 {
+					description = description[:97] + "..."
+				}
 			}
-		}
 
-		options = append(options, discordgo.SelectMenuOption{
-			Label:       label,
-			Description: description,
-			Value:       note.Id,
-			Emoji: &discordgo.ComponentEmoji{
-				Name: "📝",
-			},
-		})
-	}
+			options = append(options, discordgo.SelectMenuOption{
+				Label:       label,
+				Description: description,
+				Value:       note.Id,
+				Emoji: &discordgo.ComponentEmoji{
+					Name: "📝",
+				},
+			})
+		}
 
-	components := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.SelectMenu{
-					CustomID:    "view_note_select",
-					Placeholder: "Choose a note to view...",
-					Options:     options,
+		components := []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.SelectMenu{
+						CustomID:    "view_note_select",
+						Placeholder: "Choose a note to view...",
+						Options:     options,
+					},
 				},
 			},
-		},
-	}
+		}
 
-	if resp.Total > int32(displayLimit) {
-		content.WriteString(fmt.Sprintf("\n\n_Showing first %d of %d results. Refine your search for more specific results._", displayLimit, resp.Total))
-	}
+		if resp.Total > int32(displayLimit) {
+			content.WriteString(fmt.Sprintf("\n\n_Showing first %d of %d results. Refine your search for more specific results._", displayLimit, resp.Total))
+		}
 
-	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-		Content:    content.String(),
-		Components: components,
-		Flags:      discordgo.MessageFlagsEphemeral,
+		return &discordgo.WebhookEdit{
+			Content:    ptrString(content.String()),
+			Components: &components,
+		}, nil
 	})
-	if err != nil {
-		log.Error("Failed to send followup", "error", err)
-	}
 }
 
 // handleViewNoteSelect handles dropdown selection to view a note ephemerally
@@ -620,7 +700,7 @@ func handleViewNoteSelect(s *discordgo.Session, i *discordgo.InteractionCreate,
 		slog.Int("ref_count", len(refs)))
 
 	// Use standard embed function with action buttons
-	embed, components := createNoteEmbed(note, refs, cfg, log)
+	embed, components := createNoteEmbed(s, note, refs, cfg, guildEmbedColors(note.GuildId, grpcClient, log).Note, log)
 
 	// Display the note ephemerally with action buttons
 	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -742,7 +822,7 @@ func handleNoteDeleteButton(s *discordgo.Session, i *discordgo.InteractionCreate
 
 // handleNoteCloseButton handles the close button click
 // handleNoteEditModal handles the edit note modal submission
-func handleNoteEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+func handleNoteEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
 	// Extract note ID from custom ID (format: note_edit_modal:{noteID})
 	parts := strings.SplitN(i.ModalSubmitData().CustomID, ":", 2)
 	if len(parts) != 2 {
@@ -812,17 +892,15 @@ func handleNoteEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, c
 	resultNote, err := noteClient.UpdateNote(ctx, updateReq)
 	if err != nil {
 		log.Error("Failed to update note", "note_id", noteID, "error", err)
-		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("❌ Failed to update note: %v", err),
-			Flags:   discordgo.MessageFlagsEphemeral,
-		})
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, noteSaveFailedMessage("update", title, err))
 		return
 	}
+	cache.InvalidateNoteTitles(interactionUser(i).ID, resultNote.GuildId)
 
 	// Success response - show standard note embed
 	// Fetch message references
 	refs := fetchNoteMessageReferences(ctx, noteClient, resultNote.Id, log)
-	embed, components := createNoteEmbed(resultNote, refs, cfg, log)
+	embed, components := createNoteEmbed(s, resultNote, refs, cfg, guildEmbedColors(resultNote.GuildId, grpcClient, log).Note, log)
 
 	// Add success message to title
 	embed.Title = "✅ Note Updated\n\n" + embed.Title
@@ -839,7 +917,7 @@ func handleNoteEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, c
 	log.Info("Note updated via edit button",
 		"note_id", resultNote.Id,
 		"title", title,
-		"author_id", i.Member.User.ID,
+		"author_id", interactionUser(i).ID,
 	)
 }
 
@@ -860,7 +938,7 @@ func handleNoteCloseButton(s *discordgo.Session, i *discordgo.InteractionCreate,
 }
 
 // handleNoteDeleteConfirm handles the confirmed delete action
-func handleNoteDeleteConfirm(s *discordgo.Session, i *discordgo.InteractionCreate, noteID string, log *slog.Logger, grpcClient *client.Client) {
+func handleNoteDeleteConfirm(s *discordgo.Session, i *discordgo.InteractionCreate, noteID string, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
 	// Defer the response
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredMessageUpdate,
@@ -882,6 +960,7 @@ func handleNoteDeleteConfirm(s *discordgo.Session, i *discordgo.InteractionCreat
 		})
 		return
 	}
+	cache.InvalidateNoteTitles(interactionUser(i).ID, guildIDFor(ctx, i, grpcClient, log))
 
 	// Update message to show success
 	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
@@ -892,7 +971,7 @@ func handleNoteDeleteConfirm(s *discordgo.Session, i *discordgo.InteractionCreat
 		log.Error("Failed to update message", "error", err)
 	}
 
-	log.Info("Note deleted", "note_id", noteID, "user_id", i.Member.User.ID)
+	log.Info("Note deleted", "note_id", noteID, "user_id", interactionUser(i).ID)
 }
 
 // handleNoteDeleteCancel handles cancelling the delete action
@@ -929,41 +1008,52 @@ func handleNoteAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate
 		return
 	}
 
-	// Only handle title autocomplete for "view" subcommand
-	if data.Options[0].Name != "view" || focusedOption.Name != "title" {
+	// Only handle title autocomplete for the "view" and "share" subcommands
+	if (data.Options[0].Name != "view" && data.Options[0].Name != "share") || focusedOption.Name != "title" {
 		return
 	}
 
 	query := focusedOption.StringValue()
-	userID := i.Member.User.ID
+	userID := interactionUser(i).ID
+	ctx := discordContextFor(i)
+	guildID := guildIDFor(ctx, i, grpcClient, log)
 
 	// Check local cache first
-	cachedTitles := cache.GetNoteTitles(userID, i.GuildID)
+	cachedTitles := cache.GetNoteTitles(userID, guildID)
 
-	// If cache miss, fetch from server and populate cache
+	// If cache miss, fetch from server and populate cache, falling back to expired
+	// titles if the server is slow
 	if cachedTitles == nil {
 		noteClient := notespb.NewNoteServiceClient(grpcClient.Conn())
-		ctx := discordContextFor(i)
+		fetch := func(ctx context.Context) ([]TitleSuggestion, error) {
+			autocompleteResp, err := noteClient.AutocompleteNoteTitles(ctx, &notespb.AutocompleteNoteTitlesRequest{
+				GuildId: guildID,
+			})
+			if err != nil {
+				return nil, err
+			}
 
-		autocompleteResp, err := noteClient.AutocompleteNoteTitles(ctx, &notespb.AutocompleteNoteTitlesRequest{
-			GuildId: i.GuildID,
-		})
+			// Convert to cache format
+			titles := make([]TitleSuggestion, len(autocompleteResp.Suggestions))
+			for idx, suggestion := range autocompleteResp.Suggestions {
+				titles[idx] = TitleSuggestion{
+					ID:    suggestion.Id,
+					Title: suggestion.Title,
+				}
+			}
+			return titles, nil
+		}
+		store := func(titles []TitleSuggestion) {
+			// User-specific
+			cache.SetNoteTitles(userID, guildID, titles)
+		}
+
+		var err error
+		cachedTitles, err = cache.fetchTitles(ctx, noteTitlesKey(userID, guildID), cache.GetStaleNoteTitles(userID, guildID), fetch, store, log)
 		if err != nil {
 			log.Error("Failed to fetch note titles for cache", "error", err)
 			return
 		}
-
-		// Convert to cache format
-		cachedTitles = make([]TitleSuggestion, len(autocompleteResp.Suggestions))
-		for idx, suggestion := range autocompleteResp.Suggestions {
-			cachedTitles[idx] = TitleSuggestion{
-				ID:    suggestion.Id,
-				Title: suggestion.Title,
-			}
-		}
-
-		// Store in cache (user-specific)
-		cache.SetNoteTitles(userID, i.GuildID, cachedTitles)
 	}
 
 	// Filter titles locally
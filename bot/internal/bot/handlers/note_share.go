@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	"github.com/devilmonastery/hivemind/bot/internal/config"
+	"github.com/devilmonastery/hivemind/internal/client"
+	"github.com/devilmonastery/hivemind/internal/pkg/msgtemplate"
+)
+
+// handleNoteShare posts the note matching the title option to the current channel
+func handleNoteShare(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+	if len(subcommand.Options) == 0 {
+		respondError(s, i, "Please provide a note title", log)
+		return
+	}
+	titleQuery := subcommand.Options[0].StringValue()
+
+	noteClient := notespb.NewNoteServiceClient(grpcClient.Conn())
+	ctx := discordContextFor(i)
+
+	guildID := guildIDFor(ctx, i, grpcClient, log)
+	listResp, err := noteClient.ListNotes(ctx, &notespb.ListNotesRequest{
+		GuildId: guildID,
+		Limit:   100,
+	})
+	if err != nil {
+		log.Error("Failed to list notes for sharing", "error", err)
+		respondError(s, i, fmt.Sprintf("Failed to list notes: %v", err), log)
+		return
+	}
+
+	// Prefer an exact title so a short title isn't ambiguous with longer ones
+	var note *notespb.Note
+	for _, n := range listResp.Notes {
+		if strings.EqualFold(n.Title, titleQuery) {
+			note = n
+			break
+		}
+	}
+	if note == nil {
+		matches := matchNotesByTitle(listResp.Notes, titleQuery, 2)
+		switch len(matches) {
+		case 0:
+			respondError(s, i, guildMessage(guildMessageTemplates(guildID, grpcClient, log), msgtemplate.NoResults,
+				fmt.Sprintf("No notes found matching \"%s\"", titleQuery),
+				map[string]string{"query": titleQuery, "kind": "notes"}), log)
+			return
+		case 1:
+			note = matches[0]
+		default:
+			respondError(s, i, fmt.Sprintf("More than one note matches \"%s\" - please use the exact title", titleQuery), log)
+			return
+		}
+	}
+
+	shareNote(s, i, note.Id, cfg, log, grpcClient)
+}
+
+// handleNoteShareButton posts the note from the "Make visible to channel" button to the
+// current channel and confirms on the author's ephemeral view
+func handleNoteShareButton(s *discordgo.Session, i *discordgo.InteractionCreate, noteID string, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+	data, ok := fetchNoteShare(s, i, noteID, cfg, log, grpcClient)
+	if !ok {
+		return
+	}
+	promoteToChannel(s, i, "note_share", data.Embeds, data.Components, nil, grpcClient, log)
+}
+
+// shareNote posts the note publicly as the reply to the interaction
+func shareNote(s *discordgo.Session, i *discordgo.InteractionCreate, noteID string, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+	data, ok := fetchNoteShare(s, i, noteID, cfg, log, grpcClient)
+	if !ok {
+		return
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+	if err != nil {
+		log.Error("Failed to share note", "note_id", noteID, "error", err)
+	}
+}
+
+// fetchNoteShare fetches the note, which the backend only returns to its author while they
+// are still in the note's guild, and builds its public message. On failure it responds
+// with an error and returns false.
+func fetchNoteShare(s *discordgo.Session, i *discordgo.InteractionCreate, noteID string, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) (*discordgo.InteractionResponseData, bool) {
+	noteClient := notespb.NewNoteServiceClient(grpcClient.Conn())
+	ctx := discordContextFor(i)
+
+	note, err := noteClient.GetNote(ctx, &notespb.GetNoteRequest{Id: noteID})
+	if err != nil {
+		log.Error("Failed to fetch note for sharing", "note_id", noteID, "error", err)
+		respondError(s, i, "Failed to fetch note", log)
+		return nil, false
+	}
+
+	// Don't carry a note from one server into another server's channel
+	if i.GuildID != "" && note.GuildId != "" && note.GuildId != i.GuildID {
+		respondError(s, i, "This note belongs to another server and can't be shared here", log)
+		return nil, false
+	}
+
+	refs := fetchNoteMessageReferences(ctx, noteClient, note.Id, log)
+	return noteShareResponse(s, note, refs, interactionUser(i), cfg, guildEmbedColors(note.GuildId, grpcClient, log).Note, log), true
+}
+
+// noteShareResponse builds the public message for a shared note: the note embed with its
+// references, credited to the sharer, and only the buttons that don't act on the author's
+// own copy
+func noteShareResponse(s *discordgo.Session, note *notespb.Note, refs []*notespb.NoteMessageReference, sharedBy *discordgo.User, cfg *config.Config, color int, log *slog.Logger) *discordgo.InteractionResponseData {
+	embed, components := createNoteEmbed(s, note, refs, cfg, color, log)
+	if sharedBy != nil && sharedBy.Username != "" {
+		embed.Author = &discordgo.MessageEmbedAuthor{
+			Name:    "📢 Shared by " + sharedBy.Username,
+			IconURL: sharedBy.AvatarURL(""),
+		}
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: publicComponents(components),
+	}
+}
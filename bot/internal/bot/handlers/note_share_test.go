@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	"github.com/devilmonastery/hivemind/bot/internal/config"
+)
+
+func TestNoteShareResponse(t *testing.T) {
+	note := &notespb.Note{Id: "n1", Title: "Raid plan", Body: "Meet at the portal", GuildId: "g1"}
+	refs := []*notespb.NoteMessageReference{
+		{GuildId: "g1", ChannelId: "c1", MessageId: "m1", AuthorUsername: "ada", Content: "bring potions"},
+	}
+	cfg := &config.Config{Backend: config.BackendConfig{WebBaseURL: "https://hivemind.example"}}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	data := noteShareResponse(nil, note, refs, &discordgo.User{ID: "u1", Username: "grace"}, cfg, 0x123456, log)
+
+	if data.Flags&discordgo.MessageFlagsEphemeral != 0 {
+		t.Errorf("shared note is ephemeral, want a public message")
+	}
+	if len(data.Embeds) != 1 {
+		t.Fatalf("got %d embeds, want 1", len(data.Embeds))
+	}
+	embed := data.Embeds[0]
+	if embed.Title != "Raid plan" || embed.Author == nil || !strings.Contains(embed.Author.Name, "grace") {
+		t.Errorf("embed = %q by %v, want note title credited to the sharer", embed.Title, embed.Author)
+	}
+	var hasRefs bool
+	for _, field := range embed.Fields {
+		if strings.Contains(field.Value, "bring potions") {
+			hasRefs = true
+		}
+	}
+	if !hasRefs {
+		t.Errorf("shared embed is missing the note's references")
+	}
+
+	row := data.Components[0].(discordgo.ActionsRow)
+	if len(row.Components) != 1 {
+		t.Fatalf("got %d buttons, want only the web link", len(row.Components))
+	}
+	if button := row.Components[0].(discordgo.Button); button.URL != "https://hivemind.example/note?id=n1" {
+		t.Errorf("button URL = %q, want link to the note", button.URL)
+	}
+}
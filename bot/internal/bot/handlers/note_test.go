@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// titleTakenError is the server's error when the user already has a note titled alike
+func titleTakenError(code codes.Code, noteID string) error {
+	return rpcerr.NewWithMetadata(code, rpcerr.ReasonNoteTitleTaken, "note title already in use",
+		map[string]string{rpcerr.MetadataNoteID: noteID})
+}
+
+func TestNoteTitleConflict(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "title taken", err: titleTakenError(codes.AlreadyExists, "n1"), want: "n1"},
+		{name: "message only", err: status.Error(codes.AlreadyExists, "note title already in use: n1")},
+		{name: "preserved id in use", err: status.Error(codes.AlreadyExists, "id is already in use: n1")},
+		{name: "other failure", err: titleTakenError(codes.Internal, "n1")},
+		{name: "not a status", err: errors.New("note title already in use: n1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := noteTitleConflict(tt.err); got != tt.want {
+				t.Errorf("noteTitleConflict() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoteSaveFailedMessage(t *testing.T) {
+	params := noteSaveFailedMessage("create", "Groceries", titleTakenError(codes.AlreadyExists, "n1"))
+	if !strings.Contains(params.Content, "**Groceries**") {
+		t.Errorf("content = %q, want it to name the title", params.Content)
+	}
+	if len(params.Components) != 1 {
+		t.Fatalf("got %d component rows, want 1", len(params.Components))
+	}
+	button := params.Components[0].(discordgo.ActionsRow).Components[0].(discordgo.Button)
+	if button.CustomID != "note_open_btn:n1" {
+		t.Errorf("button custom ID = %q, want note_open_btn:n1", button.CustomID)
+	}
+
+	params = noteSaveFailedMessage("update", "Groceries", status.Error(codes.Internal, "boom"))
+	if len(params.Components) != 0 || !strings.HasPrefix(params.Content, "❌ Failed to update note") {
+		t.Errorf("generic failure = %q with %d components, want the plain error", params.Content, len(params.Components))
+	}
+}
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+	preferencespb "github.com/devilmonastery/hivemind/api/generated/go/preferencespb"
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// guildIDFor returns the guild an interaction should be scoped to. Inside a guild
+// that's the interaction's guild; outside one (e.g. in DMs) it falls back to the
+// user's default guild preference. Returns "" if neither is available.
+func guildIDFor(ctx context.Context, i *discordgo.InteractionCreate, grpcClient *client.Client, log *slog.Logger) string {
+	if i.GuildID != "" {
+		return i.GuildID
+	}
+
+	prefsClient := preferencespb.NewPreferencesServiceClient(grpcClient.Conn())
+	prefs, err := prefsClient.GetPreferences(ctx, &preferencespb.GetPreferencesRequest{})
+	if err != nil {
+		log.Warn("failed to fetch user preferences, continuing without default guild",
+			slog.String("user_id", interactionUser(i).ID),
+			slog.String("error", err.Error()))
+		return ""
+	}
+	return prefs.DefaultGuildId
+}
+
+// handlePrefs routes /prefs subcommands
+func handlePrefs(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, grpcClient *client.Client) {
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		respondError(s, i, "No subcommand specified", log)
+		return
+	}
+
+	switch options[0].Name {
+	case "set-default-guild":
+		var guildID string
+		for _, opt := range options[0].Options {
+			if opt.Name == "guild" {
+				guildID = strings.TrimSpace(opt.StringValue())
+			}
+		}
+		if guildID == "" {
+			respondError(s, i, "Please choose a server", log)
+			return
+		}
+		handlePrefsSetDefaultGuild(s, i, guildID, log, grpcClient)
+	case "clear-default-guild":
+		handlePrefsSetDefaultGuild(s, i, "", log, grpcClient)
+	case "quote-leaderboards":
+		showMe := true
+		for _, opt := range options[0].Options {
+			if opt.Name == "show-me" {
+				showMe = opt.BoolValue()
+			}
+		}
+		handlePrefsQuoteLeaderboards(s, i, showMe, log, grpcClient)
+	case "show":
+		handlePrefsShow(s, i, log, grpcClient)
+	default:
+		respondError(s, i, "Unknown prefs subcommand", log)
+	}
+}
+
+// handlePrefsSetDefaultGuild sets (or clears, when guildID is empty) the user's default guild
+func handlePrefsSetDefaultGuild(s *discordgo.Session, i *discordgo.InteractionCreate, guildID string, log *slog.Logger, grpcClient *client.Client) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("Failed to defer response", "error", err)
+		return
+	}
+
+	prefsClient := preferencespb.NewPreferencesServiceClient(grpcClient.Conn())
+	prefs, err := prefsClient.SetDefaultGuild(discordContextFor(i), &preferencespb.SetDefaultGuildRequest{
+		GuildId: guildID,
+	})
+
+	var content string
+	switch {
+	case status.Code(err) == codes.PermissionDenied:
+		content = "❌ You can only choose a server you're a member of."
+	case err != nil:
+		log.Error("Failed to set default guild", "error", err, "guild_id", guildID)
+		content = fmt.Sprintf("❌ Failed to update preferences: %v", err)
+	case prefs.DefaultGuildId == "":
+		content = "✅ Default server cleared. Notes created outside a server will be personal."
+	default:
+		content = fmt.Sprintf("✅ Default server set to **%s**. Note and quote commands run outside a server will use it.", defaultGuildLabel(prefs))
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: content,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		log.Error("Failed to send followup", "error", err)
+	}
+}
+
+// handlePrefsQuoteLeaderboards puts the user on or keeps them off quote leaderboards
+func handlePrefsQuoteLeaderboards(s *discordgo.Session, i *discordgo.InteractionCreate, showMe bool, log *slog.Logger, grpcClient *client.Client) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("Failed to defer response", "error", err)
+		return
+	}
+
+	prefsClient := preferencespb.NewPreferencesServiceClient(grpcClient.Conn())
+	_, err = prefsClient.SetLeaderboardOptOut(discordContextFor(i), &preferencespb.SetLeaderboardOptOutRequest{
+		OptOut: !showMe,
+	})
+
+	var content string
+	switch {
+	case err != nil:
+		log.Error("Failed to set leaderboard opt-out", "error", err)
+		content = fmt.Sprintf("❌ Failed to update preferences: %v", err)
+	case showMe:
+		content = "✅ You'll appear on quote leaderboards again."
+	default:
+		content = "✅ You won't appear on /quote stats or the weekly quote leaderboard. Your quotes still count towards server totals."
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: content,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		log.Error("Failed to send followup", "error", err)
+	}
+}
+
+// handlePrefsShow displays the user's current preferences
+func handlePrefsShow(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, grpcClient *client.Client) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("Failed to defer response", "error", err)
+		return
+	}
+
+	prefsClient := preferencespb.NewPreferencesServiceClient(grpcClient.Conn())
+	prefs, err := prefsClient.GetPreferences(discordContextFor(i), &preferencespb.GetPreferencesRequest{})
+	if err != nil {
+		log.Error("Failed to get preferences", "error", err)
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: fmt.Sprintf("❌ Failed to load preferences: %v", err),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+
+	defaultGuild := "_not set_"
+	if prefs.DefaultGuildId != "" {
+		defaultGuild = defaultGuildLabel(prefs)
+	}
+
+	leaderboards := "Shown"
+	if prefs.LeaderboardOptOut {
+		leaderboards = "Hidden"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: "⚙️ Your Preferences",
+		Color: 0x5865F2,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Default Server",
+				Value:  defaultGuild,
+				Inline: false,
+			},
+			{
+				Name:   "Quote Leaderboards",
+				Value:  leaderboards,
+				Inline: false,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Use /prefs set-default-guild or /prefs quote-leaderboards to change",
+		},
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embed},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		log.Error("Failed to send followup", "error", err)
+	}
+}
+
+// handlePrefsAutocomplete suggests guilds the user is a member of
+func handlePrefsAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, grpcClient *client.Client) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 || data.Options[0].Name != "set-default-guild" {
+		return
+	}
+
+	var query string
+	for _, opt := range data.Options[0].Options {
+		if opt.Focused {
+			query = strings.ToLower(opt.StringValue())
+		}
+	}
+
+	discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
+	resp, err := discordClient.ListUserGuilds(discordContextFor(i), &discordpb.ListUserGuildsRequest{
+		DiscordId: interactionUser(i).ID,
+	})
+	if err != nil {
+		log.Error("Failed to list user guilds for autocomplete", "error", err)
+		return
+	}
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(resp.GuildIds))
+	for _, guildID := range resp.GuildIds {
+		name := guildID
+		if guild, err := s.State.Guild(guildID); err == nil && guild.Name != "" {
+			name = guild.Name
+		}
+		if query != "" && !strings.Contains(strings.ToLower(name), query) {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateString(name, 100),
+			Value: guildID,
+		})
+		if len(choices) == 25 { // Discord limit for autocomplete choices
+			break
+		}
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		log.Error("Failed to respond to prefs autocomplete", "error", err)
+	}
+}
+
+// defaultGuildLabel returns the guild name for display, falling back to the ID
+func defaultGuildLabel(prefs *preferencespb.UserPreferences) string {
+	if prefs.DefaultGuildName != "" {
+		return prefs.DefaultGuildName
+	}
+	return prefs.DefaultGuildId
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/devilmonastery/hivemind/bot/internal/bot/outbound"
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// publicComponents keeps only the components of an ephemeral detail view that are safe to
+// post to a channel. Link buttons work for anyone; buttons with a custom ID edit, delete,
+// or dismiss the owner's copy, so they are dropped along with any rows left empty.
+func publicComponents(components []discordgo.MessageComponent) []discordgo.MessageComponent {
+	var public []discordgo.MessageComponent
+	for _, component := range components {
+		row, ok := component.(discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		var kept []discordgo.MessageComponent
+		for _, c := range row.Components {
+			if button, ok := c.(discordgo.Button); ok && button.Style == discordgo.LinkButton {
+				kept = append(kept, button)
+			}
+		}
+		if len(kept) > 0 {
+			public = append(public, discordgo.ActionsRow{Components: kept})
+		}
+	}
+	return public
+}
+
+// promoteToChannel handles a "Make visible to channel" click: it posts a public copy of the
+// content to the channel without owner-only buttons, then confirms on the ephemeral message.
+// The click is acknowledged first because the send may be retried for longer than Discord
+// waits for a response; the buttons stay if the send fails so it can be tried again.
+// A non-nil reply posts the copy as a reply to that message. operation names the post in
+// send metrics and the dead-letter log.
+func promoteToChannel(s *discordgo.Session, i *discordgo.InteractionCreate, operation string, embeds []*discordgo.MessageEmbed, components []discordgo.MessageComponent, reply *discordgo.MessageReference, grpcClient *client.Client, log *slog.Logger) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	if err != nil {
+		log.Error("Failed to acknowledge interaction", "error", err)
+		return
+	}
+
+	postPromoted(s, i, operation, embeds, components, reply, grpcClient, log)
+}
+
+// postPromoted is promoteToChannel once the click has been acknowledged, for handlers
+// that acknowledge it themselves before the backend calls that build the post
+func postPromoted(s *discordgo.Session, i *discordgo.InteractionCreate, operation string, embeds []*discordgo.MessageEmbed, components []discordgo.MessageComponent, reply *discordgo.MessageReference, grpcClient *client.Client, log *slog.Logger) {
+	err := sendToChannel(s, channelAction(operation, i, embeds), &discordgo.MessageSend{
+		Embeds:     embeds,
+		Components: publicComponents(components),
+	}, reply, grpcClient, log)
+	if err != nil {
+		log.Error("Failed to post to channel", "channel_id", i.ChannelID, "error", err)
+		content := "❌ Failed to post to chat. Please try again."
+		if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+			log.Error("Failed to update interaction", "error", err)
+		}
+		return
+	}
+
+	content := "✅ Posted to chat!"
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content:    &content,
+		Components: &[]discordgo.MessageComponent{}, // Remove buttons
+	})
+	if err != nil {
+		log.Error("Failed to update interaction", "error", err)
+	}
+}
+
+// channelAction describes a post of embeds to the interaction's channel for outbound.Send
+func channelAction(operation string, i *discordgo.InteractionCreate, embeds []*discordgo.MessageEmbed) outbound.Action {
+	action := outbound.Action{
+		Operation: operation,
+		GuildID:   i.GuildID,
+		ChannelID: i.ChannelID,
+	}
+	if len(embeds) > 0 {
+		action.Payload = embeds[0].Title
+		if action.Payload == "" {
+			action.Payload = embeds[0].Description
+		}
+	}
+	return action
+}
+
+// sendToChannel posts msg to action's channel, as a reply to reply when it is non-nil,
+// retrying through outbound.Send. The reference does not fail when the source message is
+// gone, but if Discord still rejects it as unknown the message is sent again without it.
+func sendToChannel(s *discordgo.Session, action outbound.Action, msg *discordgo.MessageSend, reply *discordgo.MessageReference, grpcClient *client.Client, log *slog.Logger) error {
+	msg.Reference = reply
+	return outbound.Send(context.Background(), grpcClient, action, log, func(opts ...discordgo.RequestOption) error {
+		_, err := s.ChannelMessageSendComplex(action.ChannelID, msg, opts...)
+		if err != nil && msg.Reference != nil && isUnknownMessage(err) {
+			log.Debug("source message is gone, posting without a reply",
+				"channel_id", action.ChannelID,
+				"message_id", msg.Reference.MessageID)
+			msg.Reference = nil
+			_, err = s.ChannelMessageSendComplex(action.ChannelID, msg, opts...)
+		}
+		return err
+	})
+}
+
+// isUnknownMessage reports whether err is Discord's response for a message that does not exist
+func isUnknownMessage(err error) bool {
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) {
+		return false
+	}
+	if restErr.Message != nil {
+		return restErr.Message.Code == discordgo.ErrCodeUnknownMessage
+	}
+	return restErr.Response != nil && restErr.Response.StatusCode == http.StatusNotFound
+}
+
+// sourceReply returns a reference that makes a post in channelID a reply to the source
+// message, or nil to post a plain message: when replies are off, there is no source, or
+// the source is in another channel (Discord only allows replies within a channel)
+func sourceReply(enabled bool, guildID, channelID, sourceChannelID, sourceMessageID string) *discordgo.MessageReference {
+	if !enabled || sourceMessageID == "" || sourceChannelID != channelID {
+		return nil
+	}
+	failIfNotExists := false
+	return &discordgo.MessageReference{
+		MessageID:       sourceMessageID,
+		ChannelID:       sourceChannelID,
+		GuildID:         guildID,
+		FailIfNotExists: &failIfNotExists,
+	}
+}
+
+// guildRepliesToSource reports whether a guild posts content as a reply to its source
+// message. Personal content and settings lookup failures post plain messages.
+func guildRepliesToSource(guildID string, grpcClient *client.Client, log *slog.Logger) bool {
+	if guildID == "" {
+		return false
+	}
+
+	settings, err := guildSettings(guildID, grpcClient)
+	if err != nil {
+		log.Debug("failed to fetch guild settings for replies, posting plain messages",
+			slog.String("guild_id", guildID),
+			slog.String("error", err.Error()))
+		return false
+	}
+
+	return settings.GetPosting().GetReplyToSource()
+}
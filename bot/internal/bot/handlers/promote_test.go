@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc"
+
+	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	quotespb "github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	"github.com/devilmonastery/hivemind/bot/internal/config"
+)
+
+func TestPublicComponents(t *testing.T) {
+	cfg := &config.Config{Backend: config.BackendConfig{WebBaseURL: "https://hivemind.example"}}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// Enough references to add the "show all" row, which only works for the author
+	var refs []*notespb.NoteMessageReference
+	for n := 0; n <= embedReferenceLimit; n++ {
+		refs = append(refs, &notespb.NoteMessageReference{GuildId: "g1", ChannelId: "c1", MessageId: fmt.Sprintf("m%d", n)})
+	}
+	_, noteComponents := createNoteEmbed(nil, &notespb.Note{Id: "n1", Title: "Raid plan", GuildId: "g1"}, refs, cfg, 0, log)
+
+	quote := &quotespb.Quote{Id: "q1", Body: "The cake is a lie", AuthorDiscordId: "u1"}
+
+	tests := []struct {
+		name       string
+		components []discordgo.MessageComponent
+		wantURLs   []string
+	}{
+		{
+			name:       "note",
+			components: noteComponents,
+			wantURLs:   []string{"https://hivemind.example/note?id=n1"},
+		},
+		{
+			name:       "quote author",
+			components: buildQuoteActionButtons(quote, "u1", log),
+		},
+		{
+			name:       "quote other user",
+			components: buildQuoteActionButtons(quote, "u2", log),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var urls []string
+			for _, component := range publicComponents(tt.components) {
+				row := component.(discordgo.ActionsRow)
+				if len(row.Components) == 0 {
+					t.Errorf("public components include an empty row")
+				}
+				for _, c := range row.Components {
+					button := c.(discordgo.Button)
+					if button.CustomID != "" {
+						t.Errorf("button %q with custom ID %q survived promotion", button.Label, button.CustomID)
+					}
+					urls = append(urls, button.URL)
+				}
+			}
+			if fmt.Sprint(urls) != fmt.Sprint(tt.wantURLs) {
+				t.Errorf("surviving buttons = %v, want %v", urls, tt.wantURLs)
+			}
+		})
+	}
+}
+
+// The confirmation is only shown once the public copy has been sent
+func TestPromoteToChannel_ConfirmsAfterSend(t *testing.T) {
+	s, discord := newFakeDiscordSession(t)
+	i := testInteraction()
+	i.ChannelID = "c1"
+
+	promoteToChannel(s, i, "promote_note", []*discordgo.MessageEmbed{{Title: "Raid plan"}}, nil, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	deferred := discord.indexOf(http.MethodPost, "/interactions/interaction-1/interaction-token/callback")
+	sent := discord.indexOf(http.MethodPost, "/channels/c1/messages")
+	confirmed := discord.indexOf(http.MethodPatch, "/messages/@original")
+	if deferred < 0 || sent < deferred || confirmed < sent {
+		t.Fatalf("Discord requests = %+v, want acknowledge, send, then confirm", discord.requests)
+	}
+	if content := discord.requests[confirmed].body["content"]; content != "✅ Posted to chat!" {
+		t.Errorf("confirmation content = %v, want the posted message", content)
+	}
+}
+
+// A failed send reports the failure and keeps the buttons so the user can try again
+func TestPromoteToChannel_SendFails(t *testing.T) {
+	s, discord := newFakeDiscordSession(t)
+	discord.failPath = "/channels/c1/messages"
+	grpcClient := newTestGRPCClient(t, func(*grpc.Server) {})
+	i := testInteraction()
+	i.ChannelID = "c1"
+
+	promoteToChannel(s, i, "promote_note", []*discordgo.MessageEmbed{{Title: "Raid plan"}}, nil, nil, grpcClient, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	edited := discord.indexOf(http.MethodPatch, "/messages/@original")
+	if edited < 0 {
+		t.Fatalf("Discord requests = %+v, want the failure reported", discord.requests)
+	}
+	body := discord.requests[edited].body
+	if content, _ := body["content"].(string); !strings.HasPrefix(content, "❌") {
+		t.Errorf("edit content = %q, want a failure message", content)
+	}
+	if _, ok := body["components"]; ok {
+		t.Errorf("edit components = %v, want the buttons left in place", body["components"])
+	}
+}
+
+func TestSourceReply(t *testing.T) {
+	tests := []struct {
+		name            string
+		enabled         bool
+		sourceChannelID string
+		sourceMessageID string
+		wantReply       bool
+	}{
+		{name: "same channel", enabled: true, sourceChannelID: "c1", sourceMessageID: "m1", wantReply: true},
+		{name: "replies off", sourceChannelID: "c1", sourceMessageID: "m1"},
+		{name: "other channel", enabled: true, sourceChannelID: "c2", sourceMessageID: "m1"},
+		{name: "no source message", enabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply := sourceReply(tt.enabled, "g1", "c1", tt.sourceChannelID, tt.sourceMessageID)
+			if (reply != nil) != tt.wantReply {
+				t.Fatalf("sourceReply() = %+v, want reply %v", reply, tt.wantReply)
+			}
+			if reply == nil {
+				return
+			}
+			if reply.MessageID != "m1" || reply.ChannelID != "c1" || reply.GuildID != "g1" {
+				t.Errorf("sourceReply() = %+v, want message m1 in c1 of g1", reply)
+			}
+			// A deleted source message must not stop the post
+			if reply.FailIfNotExists == nil || *reply.FailIfNotExists {
+				t.Errorf("FailIfNotExists = %v, want false", reply.FailIfNotExists)
+			}
+		})
+	}
+}
+
+func TestIsUnknownMessage(t *testing.T) {
+	unknown := &discordgo.RESTError{Message: &discordgo.APIErrorMessage{Code: discordgo.ErrCodeUnknownMessage}}
+	if !isUnknownMessage(fmt.Errorf("send: %w", unknown)) {
+		t.Error("isUnknownMessage(unknown message) = false, want true")
+	}
+	missingAccess := &discordgo.RESTError{Message: &discordgo.APIErrorMessage{Code: discordgo.ErrCodeMissingAccess}}
+	if isUnknownMessage(missingAccess) {
+		t.Error("isUnknownMessage(missing access) = true, want false")
+	}
+	if isUnknownMessage(fmt.Errorf("network down")) {
+		t.Error("isUnknownMessage(plain error) = true, want false")
+	}
+}
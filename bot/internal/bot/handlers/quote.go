@@ -1,28 +1,50 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
 	quotespb "github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	"github.com/devilmonastery/hivemind/bot/internal/bot/outbound"
+	"github.com/devilmonastery/hivemind/bot/internal/config"
 	"github.com/devilmonastery/hivemind/internal/client"
+	"github.com/devilmonastery/hivemind/internal/pkg/msgtemplate"
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
 	"github.com/devilmonastery/hivemind/internal/pkg/urlutil"
 )
 
+// quoteAttribution returns who a quote is shown as said by
+// Prefer guild nickname, fallback to username
+func quoteAttribution(quote *quotespb.Quote) string {
+	if quote.SourceMsgAuthorGuildNick != "" {
+		return quote.SourceMsgAuthorGuildNick
+	}
+	return quote.SourceMsgAuthorUsername
+}
+
+// quoteSavedTitle returns the title of a newly saved quote's embed: the guild's
+// quote_saved template, or "✅ Quote Saved"
+func quoteSavedTitle(quote *quotespb.Quote, grpcClient *client.Client, log *slog.Logger) string {
+	return guildMessage(guildMessageTemplates(quote.GuildId, grpcClient, log), msgtemplate.QuoteSaved,
+		"✅ Quote Saved", map[string]string{"author": quoteAttribution(quote)})
+}
+
 // buildQuoteEmbed creates a standardized embed for displaying a quote
-func buildQuoteEmbed(quote *quotespb.Quote) *discordgo.MessageEmbed {
+func buildQuoteEmbed(quote *quotespb.Quote, color int) *discordgo.MessageEmbed {
 	// Format the quote body with markdown quote styling
 	quoteText := ""
 
 	// Add attribution header if we have the original author
-	// Prefer guild nickname, fallback to username
-	sourceAuthorName := quote.SourceMsgAuthorGuildNick
-	if sourceAuthorName == "" {
-		sourceAuthorName = quote.SourceMsgAuthorUsername
-	}
-	if sourceAuthorName != "" {
+	if sourceAuthorName := quoteAttribution(quote); sourceAuthorName != "" {
 		quoteText = fmt.Sprintf("**%s** said:\n", sourceAuthorName)
 	}
 
@@ -37,7 +59,7 @@ func buildQuoteEmbed(quote *quotespb.Quote) *discordgo.MessageEmbed {
 
 	// Add timestamp if available
 	if quote.SourceMsgTimestamp != nil {
-		footer = append(footer, fmt.Sprintf("_<t:%d:D>_", quote.SourceMsgTimestamp.Seconds))
+		footer = append(footer, "_"+discordTimestamp(quote.SourceMsgTimestamp, timestampLongDate)+"_")
 	}
 
 	// Prefer guild nickname, fallback to username for quote author
@@ -49,8 +71,7 @@ func buildQuoteEmbed(quote *quotespb.Quote) *discordgo.MessageEmbed {
 		footer = append(footer, fmt.Sprintf("_added by %s_", authorName))
 	}
 
-	if quote.SourceMsgId != "" && quote.SourceChannelId != "" && quote.GuildId != "" {
-		messageURL := urlutil.DiscordMessageURL(quote.GuildId, quote.SourceChannelId, quote.SourceMsgId)
+	if messageURL := quoteSourceURL(quote); messageURL != "" {
 		footer = append(footer, fmt.Sprintf("_[original message](%s)_", messageURL))
 	}
 
@@ -60,7 +81,7 @@ func buildQuoteEmbed(quote *quotespb.Quote) *discordgo.MessageEmbed {
 
 	embed := &discordgo.MessageEmbed{
 		Description: quoteText,
-		Color:       0x5865F2, // Discord blurple
+		Color:       color,
 	}
 
 	// Add tags field if present
@@ -77,17 +98,127 @@ func buildQuoteEmbed(quote *quotespb.Quote) *discordgo.MessageEmbed {
 	return embed
 }
 
+// quoteSourceURL returns the link to the message a quote was saved from, or "" unless its
+// guild, channel, and message IDs are all known
+func quoteSourceURL(quote *quotespb.Quote) string {
+	if quote.GuildId == "" || quote.SourceChannelId == "" || quote.SourceMsgId == "" {
+		return ""
+	}
+	return urlutil.DiscordMessageURL(quote.GuildId, quote.SourceChannelId, quote.SourceMsgId)
+}
+
+// quoteJumpComponents returns a "Jump to original" link button for a quote posted with its
+// context, or nil when the quote has no source message to link to
+func quoteJumpComponents(quote *quotespb.Quote) []discordgo.MessageComponent {
+	messageURL := quoteSourceURL(quote)
+	if messageURL == "" {
+		return nil
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label: "🔗 Jump to original",
+					Style: discordgo.LinkButton,
+					URL:   messageURL,
+				},
+			},
+		},
+	}
+}
+
+// buildQuoteURL builds a quote's web URL, preferring its short code permalink over its ID
+// for quotes saved before codes existed
+func buildQuoteURL(baseURL string, quote *quotespb.Quote) (string, error) {
+	if quote.ShortCode != "" {
+		return urlutil.BuildQuoteCodeURL(baseURL, quote.GuildId, quote.ShortCode)
+	}
+	return urlutil.BuildQuoteViewURL(baseURL, quote.Id)
+}
+
+// duplicateQuoteMessage builds the follow-up shown when a message was already saved as a quote
+func duplicateQuoteMessage(quote *quotespb.Quote, cfg *config.Config, color int) *discordgo.WebhookParams {
+	embed := buildQuoteEmbed(quote, color)
+	embed.Title = "ℹ️ Already Saved"
+
+	params := &discordgo.WebhookParams{
+		Content: "This message is already saved as a quote.",
+		Embeds:  []*discordgo.MessageEmbed{embed},
+		Flags:   discordgo.MessageFlagsEphemeral,
+	}
+	if quoteURL, err := buildQuoteURL(getWebBaseURL(cfg), quote); err == nil {
+		params.Components = []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label: webLinkLabel(cfg),
+						Style: discordgo.LinkButton,
+						URL:   quoteURL,
+					},
+				},
+			},
+		}
+	}
+	return params
+}
+
+// respondDuplicateQuote tells the user their quote already exists instead of confirming a new one
+func respondDuplicateQuote(s *discordgo.Session, i *discordgo.InteractionCreate, quote *quotespb.Quote, cfg *config.Config, grpcClient *client.Client, log *slog.Logger) {
+	params := duplicateQuoteMessage(quote, cfg, guildEmbedColors(quote.GuildId, grpcClient, log).Quote)
+	err := outbound.Send(context.Background(), grpcClient, outbound.Action{
+		Operation: "duplicate_quote_reply",
+		GuildID:   i.GuildID,
+		Payload:   quote.Body,
+	}, log, func(opts ...discordgo.RequestOption) error {
+		_, err := s.FollowupMessageCreate(i.Interaction, true, params, opts...)
+		return err
+	})
+	if err != nil {
+		log.Error("failed to send duplicate quote followup", slog.String("error", err.Error()))
+	}
+}
+
+// quoteCreateFailedMessage returns the text shown when saving a quote fails, telling
+// the user how long to wait when they hit the guild's cooldown
+func quoteCreateFailedMessage(err error) string {
+	if rpcerr.HasReason(err, rpcerr.ReasonQuoteCooldown) {
+		if wait := rpcerr.RetryDelay(err); wait > 0 {
+			// Round up so the wait is never shown as 0 seconds
+			seconds := int64((wait + time.Second - 1) / time.Second)
+			return fmt.Sprintf("⏳ You can save another quote in %s.", formatSeconds(seconds))
+		}
+	}
+	return fmt.Sprintf("❌ Failed to create quote: %v", err)
+}
+
+// formatSeconds renders a number of seconds as "1 second" or "N seconds"
+func formatSeconds(seconds int64) string {
+	if seconds == 1 {
+		return "1 second"
+	}
+	return fmt.Sprintf("%d seconds", seconds)
+}
+
 // buildQuoteActionButtons creates the standard action buttons for quote interactions
 // Only shows the Edit button if currentUserDiscordID matches the quote's author_discord_id
 func buildQuoteActionButtons(quote *quotespb.Quote, currentUserDiscordID string, log *slog.Logger) []discordgo.MessageComponent {
 	buttons := []discordgo.MessageComponent{
 		discordgo.Button{
-			Label:    "📢 Add to Chat",
+			Label:    "📢 Make visible to channel",
 			Style:    discordgo.SuccessButton,
 			CustomID: fmt.Sprintf("quote_add_to_chat:%s", quote.Id),
 		},
 	}
 
+	// Offer a post that links back to the conversation when there is one to link to
+	if quoteSourceURL(quote) != "" {
+		buttons = append(buttons, discordgo.Button{
+			Label:    "🔗 Post with link",
+			Style:    discordgo.SuccessButton,
+			CustomID: fmt.Sprintf("quote_add_to_chat_context:%s", quote.Id),
+		})
+	}
+
 	// Debug logging
 	log.Info("Checking quote edit permission",
 		"quote_id", quote.Id,
@@ -132,6 +263,10 @@ func handleQuote(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog
 		handleQuoteRandom(s, i, subcommand, log, grpcClient)
 	case "search":
 		handleQuoteSearch(s, i, subcommand, log, grpcClient)
+	case "view":
+		handleQuoteView(s, i, subcommand, log, grpcClient)
+	case "stats":
+		handleQuoteStats(s, i, log, grpcClient)
 	default:
 		respondError(s, i, "Unknown quote subcommand", log)
 	}
@@ -153,68 +288,178 @@ func handleQuoteRandom(s *discordgo.Session, i *discordgo.InteractionCreate, sub
 		}
 	}
 
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Flags: discordgo.MessageFlagsEphemeral,
-		},
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		quoteClient := quotespb.NewQuoteServiceClient(grpcClient.Conn())
+		ctx := discordContextFor(i)
+
+		guildID := guildIDFor(ctx, i, grpcClient, log)
+		if guildID == "" {
+			return nil, userError("Quotes belong to a server. Run this in a server, or set one with `/prefs set-default-guild`.", nil)
+		}
+
+		resp, err := quoteClient.GetRandomQuote(ctx, &quotespb.GetRandomQuoteRequest{
+			GuildId: guildID,
+			Tags:    tags,
+		})
+		if err != nil {
+			return nil, userError(fmt.Sprintf("Failed to get random quote: %v", err), err)
+		}
+
+		// Use standard quote embed
+		embed := buildQuoteEmbed(resp, guildEmbedColors(resp.GuildId, grpcClient, log).Quote)
+
+		// Get current user Discord ID
+		var discordID string
+		if i.Member != nil && i.Member.User != nil {
+			discordID = i.Member.User.ID
+		} else if i.User != nil {
+			discordID = i.User.ID
+		}
+
+		// Build action buttons (ephemeral - user decides whether to share)
+		components := buildQuoteActionButtons(resp, discordID, log)
+
+		return &discordgo.WebhookEdit{
+			Embeds:     &[]*discordgo.MessageEmbed{embed},
+			Components: &components,
+		}, nil
 	})
-	if err != nil {
-		log.Error("Failed to defer response", "error", err)
-		return
+}
+
+// quoteRef identifies the quote asked for by /quote view: either its ID, or its
+// permalink short code within a guild
+type quoteRef struct {
+	ID      string
+	Code    string
+	GuildID string // Guild a short code belongs to, when it came from a pasted link
+}
+
+// parseQuoteRef reads the quote option of /quote view, which may be a quote ID, a short
+// code, or a pasted web link (/quote?id=... or /quote?code=...&guild_id=...).
+// It reports false when input names no quote.
+func parseQuoteRef(input string) (quoteRef, bool) {
+	// Discord users wrap links in <> to stop them unfurling
+	input = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(input), "<"), ">")
+	if input == "" {
+		return quoteRef{}, false
 	}
 
-	quoteClient := quotespb.NewQuoteServiceClient(grpcClient.Conn())
-	ctx := discordContextFor(i)
+	if strings.Contains(input, "://") {
+		u, err := url.Parse(input)
+		if err != nil || strings.TrimSuffix(u.Path, "/") != "/quote" {
+			return quoteRef{}, false
+		}
+		query := u.Query()
+		if id := query.Get("id"); id != "" {
+			return quoteRef{ID: id}, true
+		}
+		if code := query.Get("code"); code != "" {
+			return quoteRef{Code: strings.ToLower(code), GuildID: query.Get("guild_id")}, true
+		}
+		return quoteRef{}, false
+	}
 
-	resp, err := quoteClient.GetRandomQuote(ctx, &quotespb.GetRandomQuoteRequest{
-		GuildId: i.GuildID,
-		Tags:    tags,
-	})
-	if err != nil {
-		log.Error("Failed to get random quote", "error", err)
-		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("❌ Failed to get random quote: %v", err),
-			Flags:   discordgo.MessageFlagsEphemeral,
-		})
-		return
+	// Quote IDs are numeric Snowflakes; short codes never contain only digits
+	if strings.Trim(input, "0123456789") == "" {
+		return quoteRef{ID: input}, true
 	}
+	return quoteRef{Code: strings.ToLower(input)}, true
+}
 
-	// Use standard quote embed
-	embed := buildQuoteEmbed(resp)
+// fetchQuoteRef looks up the quote ref names. Short codes without a guild of their own
+// are resolved in guildID.
+func fetchQuoteRef(ctx context.Context, quoteClient quotespb.QuoteServiceClient, ref quoteRef, guildID string) (*quotespb.Quote, error) {
+	if ref.ID != "" {
+		return quoteClient.GetQuote(ctx, &quotespb.GetQuoteRequest{Id: ref.ID})
+	}
+	if ref.GuildID != "" {
+		guildID = ref.GuildID
+	}
+	return quoteClient.GetQuoteByCode(ctx, &quotespb.GetQuoteByCodeRequest{
+		GuildId: guildID,
+		Code:    ref.Code,
+	})
+}
 
-	// Get current user Discord ID
-	var discordID string
-	if i.Member != nil && i.Member.User != nil {
-		discordID = i.Member.User.ID
-	} else if i.User != nil {
-		discordID = i.User.ID
+// quoteViewErrorMessage explains why /quote view couldn't show a quote
+func quoteViewErrorMessage(err error) string {
+	switch status.Code(err) {
+	case codes.NotFound, codes.PermissionDenied:
+		return "Quote not found. It may have been deleted, or belong to a server you're not in."
+	case codes.InvalidArgument:
+		return "That doesn't look like a quote ID, short code, or quote link."
+	default:
+		return "Failed to fetch quote"
 	}
+}
 
-	// Build action buttons (ephemeral - user decides whether to share)
-	components := buildQuoteActionButtons(resp, discordID, log)
+// handleQuoteView shows one quote, picked by ID, short code, or web link, ephemerally
+// with action buttons
+func handleQuoteView(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
+	var input string
+	for _, opt := range subcommand.Options {
+		if opt.Name == "quote" {
+			input = opt.StringValue()
+		}
+	}
 
-	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-		Embeds:     []*discordgo.MessageEmbed{embed},
-		Components: components,
-		Flags:      discordgo.MessageFlagsEphemeral,
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		ref, ok := parseQuoteRef(input)
+		if !ok {
+			return nil, userError("That doesn't look like a quote ID, short code, or quote link.", nil)
+		}
+
+		quoteClient := quotespb.NewQuoteServiceClient(grpcClient.Conn())
+		ctx := discordContextFor(i)
+
+		// Short codes are only unique within a guild
+		var guildID string
+		if ref.Code != "" && ref.GuildID == "" {
+			guildID = guildIDFor(ctx, i, grpcClient, log)
+			if guildID == "" {
+				return nil, userError("Short codes belong to a server. Run this in a server, set one with `/prefs set-default-guild`, or paste the quote's link.", nil)
+			}
+		}
+
+		quote, err := fetchQuoteRef(ctx, quoteClient, ref, guildID)
+		if err != nil {
+			return nil, userError(quoteViewErrorMessage(err), err)
+		}
+
+		embed := buildQuoteEmbed(quote, guildEmbedColors(quote.GuildId, grpcClient, log).Quote)
+
+		var discordID string
+		if i.Member != nil && i.Member.User != nil {
+			discordID = i.Member.User.ID
+		} else if i.User != nil {
+			discordID = i.User.ID
+		}
+
+		// Ephemeral, like /quote random: the user decides whether to share it
+		components := buildQuoteActionButtons(quote, discordID, log)
+
+		return &discordgo.WebhookEdit{
+			Embeds:     &[]*discordgo.MessageEmbed{embed},
+			Components: &components,
+		}, nil
 	})
-	if err != nil {
-		log.Error("Failed to send followup", "error", err)
-	}
 }
 
 // handleQuoteList lists quotes
 // handleQuoteSearch searches quotes
 func handleQuoteSearch(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
-	var query string
+	var query, scope string
 	var tags []string
 	limit := int32(10)
+	var orderBy string
+	var ascending bool
 
 	for _, opt := range subcommand.Options {
 		switch opt.Name {
 		case "query":
 			query = opt.StringValue()
+		case "scope":
+			scope = opt.StringValue()
 		case "tags":
 			tagStr := opt.StringValue()
 			parts := strings.Split(tagStr, ",")
@@ -226,6 +471,8 @@ func handleQuoteSearch(s *discordgo.Session, i *discordgo.InteractionCreate, sub
 			}
 		case "limit":
 			limit = int32(opt.IntValue())
+		case "sort":
+			orderBy, ascending = searchSortOrder(opt.StringValue())
 		}
 	}
 
@@ -234,11 +481,104 @@ func handleQuoteSearch(s *discordgo.Session, i *discordgo.InteractionCreate, sub
 		return
 	}
 
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		quoteClient := quotespb.NewQuoteServiceClient(grpcClient.Conn())
+		ctx := discordContextFor(i)
+
+		guildID, guildIDs, err := resolveSearchScope(ctx, i, scope, false, grpcClient, log)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := quoteClient.SearchQuotes(ctx, &quotespb.SearchQuotesRequest{
+			Query:     query,
+			GuildId:   guildID,
+			GuildIds:  guildIDs,
+			Tags:      tags,
+			Limit:     limit,
+			OrderBy:   orderBy,
+			Ascending: ascending,
+		})
+		if err != nil {
+			return nil, userError(fmt.Sprintf("Failed to search quotes: %v", err), err)
+		}
+
+		if len(resp.Quotes) == 0 {
+			return &discordgo.WebhookEdit{
+				Content: ptrString(guildMessage(guildMessageTemplates(i.GuildID, grpcClient, log), msgtemplate.NoResults,
+					fmt.Sprintf("No quotes found matching \"%s\"", query),
+					map[string]string{"query": query, "kind": "quotes"})),
+			}, nil
+		}
+
+		// Show search results with a dropdown to select and post a quote
+		// Limit to 25 results (Discord's max for select menus)
+		displayLimit := len(resp.Quotes)
+		if displayLimit > 25 {
+			displayLimit = 25
+		}
+
+		var content strings.Builder
+		content.WriteString(fmt.Sprintf("🔍 Found %d quote(s) matching \"%s\"\n", resp.Total, query))
+		content.WriteString("Select a quote from the dropdown to post it to the channel:")
+
+		// Build select menu options
+		options := []discordgo.SelectMenuOption{}
+		for idx := 0; idx < displayLimit; idx++ {
+			quote := resp.Quotes[idx]
+			// Create a label from the matching snippet (Discord max is 100 chars)
+			label := searchExcerpt(quote.Snippet, quote.Body)
+
+			// Create a description with attribution
+			description := ""
+			sourceAuthorName := quote.SourceMsgAuthorGuildNick
+			if sourceAuthorName == "" {
+				sourceAuthorName = quote.SourceMsgAuthorUsername
+			}
+			if sourceAuthorName != "" {
+				description = fmt.Sprintf("by %s", sourceAuthorName)
+				if len(description) > 100 {
+					description = description[:97] + "..."
+				}
+			}
+
+			options = append(options, discordgo.SelectMenuOption{
+				Label:       label,
+				Description: description,
+				Value:       quote.Id,
+				Emoji: &discordgo.ComponentEmoji{
+					Name: "💬",
+				},
+			})
+		}
+
+		components := []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.SelectMenu{
+						CustomID:    "post_quote_select",
+						Placeholder: "Choose a quote to post...",
+						Options:     options,
+					},
+				},
+			},
+		}
+
+		if resp.Total > int32(displayLimit) {
+			content.WriteString(fmt.Sprintf("\n\n_Showing first %d of %d results. Refine your search for more specific results._", displayLimit, resp.Total))
+		}
+
+		return &discordgo.WebhookEdit{
+			Content:    ptrString(content.String()),
+			Components: &components,
+		}, nil
+	})
+}
+
+// handleQuoteStats shows the guild's quote total and leaderboards
+func handleQuoteStats(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, grpcClient *client.Client) {
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Flags: discordgo.MessageFlagsEphemeral,
-		},
 	})
 	if err != nil {
 		log.Error("Failed to defer response", "error", err)
@@ -248,96 +588,94 @@ func handleQuoteSearch(s *discordgo.Session, i *discordgo.InteractionCreate, sub
 	quoteClient := quotespb.NewQuoteServiceClient(grpcClient.Conn())
 	ctx := discordContextFor(i)
 
-	resp, err := quoteClient.SearchQuotes(ctx, &quotespb.SearchQuotesRequest{
-		Query:   query,
-		GuildId: i.GuildID,
-		Tags:    tags,
-		Limit:   limit,
-	})
-	if err != nil {
-		log.Error("Failed to search quotes", "error", err)
+	guildID := guildIDFor(ctx, i, grpcClient, log)
+	if guildID == "" {
 		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("❌ Failed to search quotes: %v", err),
+			Content: "❌ Quotes belong to a server. Run this in a server, or set one with `/prefs set-default-guild`.",
 		})
 		return
 	}
 
-	if len(resp.Quotes) == 0 {
+	resp, err := quoteClient.GetQuoteStats(ctx, &quotespb.GetQuoteStatsRequest{
+		GuildId: guildID,
+		Limit:   quoteStatsLimit,
+	})
+	if err != nil {
+		log.Error("Failed to get quote stats", "error", err)
 		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("No quotes found matching \"%s\"", query),
-			Flags:   discordgo.MessageFlagsEphemeral,
+			Content: fmt.Sprintf("❌ Failed to get quote stats: %v", err),
 		})
 		return
 	}
 
-	// Show search results with a dropdown to select and post a quote
-	// Limit to 25 results (Discord's max for select menus)
-	displayLimit := len(resp.Quotes)
-	if displayLimit > 25 {
-		displayLimit = 25
+	embed := buildQuoteStatsEmbed(resp, guildEmbedColors(guildID, grpcClient, log).Quote)
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embed},
+		// Render mentions as names without pinging anyone
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		log.Error("Failed to send followup", "error", err)
 	}
+}
 
-	var content strings.Builder
-	content.WriteString(fmt.Sprintf("🔍 Found %d quote(s) matching \"%s\"\n", resp.Total, query))
-	content.WriteString("Select a quote from the dropdown to post it to the channel:")
+// quoteStatsLimit is the number of entries shown per quote leaderboard
+const quoteStatsLimit = 5
 
-	// Build select menu options
-	options := []discordgo.SelectMenuOption{}
-	for idx := 0; idx < displayLimit; idx++ {
-		quote := resp.Quotes[idx]
-		// Create a label (truncate if too long - Discord max is 100 chars)
-		label := quote.Body
-		if len(label) > 100 {
-			label = label[:97] + "..."
-		}
+// quoteStatsMedals decorate the first three places of a leaderboard
+var quoteStatsMedals = []string{"🥇", "🥈", "🥉"}
 
-		// Create a description with attribution
-		description := ""
-		sourceAuthorName := quote.SourceMsgAuthorGuildNick
-		if sourceAuthorName == "" {
-			sourceAuthorName = quote.SourceMsgAuthorUsername
-		}
-		if sourceAuthorName != "" {
-			description = fmt.Sprintf("by %s", sourceAuthorName)
-			if len(description) > 100 {
-				description = description[:97] + "..."
-			}
-		}
+// buildQuoteStatsEmbed renders quote stats as an embed with one field per leaderboard
+func buildQuoteStatsEmbed(stats *quotespb.GetQuoteStatsResponse, color int) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       "📊 Quote Stats",
+		Description: fmt.Sprintf("**%d** quote(s) saved in this server", stats.TotalQuotes),
+		Color:       color,
+	}
+	if stats.TotalQuotes == 0 {
+		embed.Description += "\nRight-click a message and choose **Save as Quote** to start the collection."
+		return embed
+	}
 
-		options = append(options, discordgo.SelectMenuOption{
-			Label:       label,
-			Description: description,
-			Value:       quote.Id,
-			Emoji: &discordgo.ComponentEmoji{
-				Name: "💬",
-			},
-		})
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: "Top quoters", Value: formatQuoteLeaderboard(stats.TopQuoters), Inline: true},
+		{Name: "Most quoted", Value: formatQuoteLeaderboard(stats.MostQuoted), Inline: true},
 	}
+	return embed
+}
 
-	components := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.SelectMenu{
-					CustomID:    "post_quote_select",
-					Placeholder: "Choose a quote to post...",
-					Options:     options,
-				},
-			},
+// WeeklyQuoteLeaderboardEmbed renders the past week's quote stats for the weekly
+// leaderboard post, in the guild's quote color
+func WeeklyQuoteLeaderboardEmbed(stats *quotespb.GetQuoteStatsResponse, appearance *discordpb.AppearanceSettings) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       "🏆 This Week's Quotes",
+		Description: fmt.Sprintf("**%d** quote(s) saved in the last 7 days", stats.TotalQuotes),
+		Color:       embedColorsFromSettings(appearance).Quote,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Top quoters", Value: formatQuoteLeaderboard(stats.TopQuoters), Inline: true},
+			{Name: "Most quoted", Value: formatQuoteLeaderboard(stats.MostQuoted), Inline: true},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Use /prefs quote-leaderboards to hide yourself from leaderboards",
 		},
 	}
+}
 
-	if resp.Total > int32(displayLimit) {
-		content.WriteString(fmt.Sprintf("\n\n_Showing first %d of %d results. Refine your search for more specific results._", displayLimit, resp.Total))
+// formatQuoteLeaderboard renders leaderboard rows as mention lines, with medals for the top three
+func formatQuoteLeaderboard(entries []*quotespb.QuoteStatsEntry) string {
+	if len(entries) == 0 {
+		return "_Nobody yet_"
 	}
 
-	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-		Content:    content.String(),
-		Components: components,
-		Flags:      discordgo.MessageFlagsEphemeral,
-	})
-	if err != nil {
-		log.Error("Failed to send followup", "error", err)
+	var sb strings.Builder
+	for idx, entry := range entries {
+		place := fmt.Sprintf("%d.", idx+1)
+		if idx < len(quoteStatsMedals) {
+			place = quoteStatsMedals[idx]
+		}
+		fmt.Fprintf(&sb, "%s <@%s> — %d\n", place, entry.DiscordId, entry.Count)
 	}
+	return strings.TrimSuffix(sb.String(), "\n")
 }
 
 // handlePostQuoteSelect handles dropdown selection to post a quote to the channel
@@ -371,7 +709,7 @@ func handlePostQuoteSelect(s *discordgo.Session, i *discordgo.InteractionCreate,
 	}
 
 	// Show the quote ephemerally with action buttons
-	embed := buildQuoteEmbed(quote)
+	embed := buildQuoteEmbed(quote, guildEmbedColors(quote.GuildId, grpcClient, log).Quote)
 
 	// Get current user Discord ID
 	var discordID string
@@ -396,8 +734,19 @@ func handlePostQuoteSelect(s *discordgo.Session, i *discordgo.InteractionCreate,
 	}
 }
 
-// handleQuoteAddToChat posts the quote to the channel when "Add to Chat" is clicked
-func handleQuoteAddToChat(s *discordgo.Session, i *discordgo.InteractionCreate, quoteID string, log *slog.Logger, grpcClient *client.Client) {
+// handleQuoteAddToChat posts the quote to the channel when "Make visible to channel" is clicked.
+// withContext, from "Post with link", adds a button that jumps to the original message.
+func handleQuoteAddToChat(s *discordgo.Session, i *discordgo.InteractionCreate, quoteID string, withContext bool, log *slog.Logger, grpcClient *client.Client) {
+	// Fetching the quote and the guild's settings are backend calls, so acknowledge
+	// the click before making them
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	if err != nil {
+		log.Error("Failed to acknowledge interaction", "error", err)
+		return
+	}
+
 	quoteClient := quotespb.NewQuoteServiceClient(grpcClient.Conn())
 	ctx := discordContextFor(i)
 
@@ -407,41 +756,23 @@ func handleQuoteAddToChat(s *discordgo.Session, i *discordgo.InteractionCreate,
 	})
 	if err != nil {
 		log.Error("Failed to fetch quote for add to chat", "quote_id", quoteID, "error", err)
-		_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "❌ Failed to fetch quote",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
-		return
-	}
-
-	// Update the ephemeral message to show success
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseUpdateMessage,
-		Data: &discordgo.InteractionResponseData{
-			Content:    "✅ Posted to chat!",
-			Components: []discordgo.MessageComponent{}, // Remove buttons
-			Flags:      discordgo.MessageFlagsEphemeral,
-		},
-	})
-	if err != nil {
-		log.Error("Failed to update interaction", "error", err)
+		if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: ptrString("❌ Failed to fetch quote")}); err != nil {
+			log.Error("Failed to update interaction", "error", err)
+		}
 		return
 	}
 
-	// Post the quote to the channel
-	embed := buildQuoteEmbed(quote)
+	// Post the same embed the user saw, minus the buttons that act on their copy
+	embed := buildQuoteEmbed(quote, guildEmbedColors(quote.GuildId, grpcClient, log).Quote)
 	log.Debug("sending quote message to Discord",
 		"channel_id", i.ChannelID,
 		"quote_id", quote.Id)
-	_, err = s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
-		Embeds: []*discordgo.MessageEmbed{embed},
-	})
-	if err != nil {
-		log.Error("Failed to post quote to channel", "error", err)
+	reply := sourceReply(guildRepliesToSource(quote.GuildId, grpcClient, log), quote.GuildId, i.ChannelID, quote.SourceChannelId, quote.SourceMsgId)
+	var components []discordgo.MessageComponent
+	if withContext {
+		components = quoteJumpComponents(quote)
 	}
+	postPromoted(s, i, "quote_post", []*discordgo.MessageEmbed{embed}, components, reply, grpcClient, log)
 }
 
 // handleQuoteEditButton opens a modal for editing the quote
@@ -498,6 +829,19 @@ func handleQuoteEditButton(s *discordgo.Session, i *discordgo.InteractionCreate,
 						},
 					},
 				},
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "quote_attribution",
+							Label:       "Attributed to",
+							Style:       discordgo.TextInputShort,
+							Placeholder: "Who said it",
+							Required:    false,
+							Value:       quoteAttribution(quote),
+							MaxLength:   100,
+						},
+					},
+				},
 			},
 		},
 	})
@@ -536,7 +880,7 @@ func handleQuoteEditModal(s *discordgo.Session, i *discordgo.InteractionCreate,
 	quoteID := parts[1]
 
 	// Extract form values
-	var body, tagsStr string
+	var body, tagsStr, attribution string
 	for _, component := range data.Components {
 		if actionRow, ok := component.(*discordgo.ActionsRow); ok {
 			for _, comp := range actionRow.Components {
@@ -546,6 +890,8 @@ func handleQuoteEditModal(s *discordgo.Session, i *discordgo.InteractionCreate,
 						body = textInput.Value
 					case "quote_tags":
 						tagsStr = textInput.Value
+					case "quote_attribution":
+						attribution = strings.TrimSpace(textInput.Value)
 					}
 				}
 			}
@@ -574,9 +920,10 @@ func handleQuoteEditModal(s *discordgo.Session, i *discordgo.InteractionCreate,
 	ctx := discordContextFor(i)
 
 	_, err := quoteClient.UpdateQuote(ctx, &quotespb.UpdateQuoteRequest{
-		Id:   quoteID,
-		Body: body,
-		Tags: tags,
+		Id:                      quoteID,
+		Body:                    body,
+		Tags:                    tags,
+		SourceMsgAuthorUsername: attribution,
 	})
 	if err != nil {
 		log.Error("Failed to update quote", "quote_id", quoteID, "error", err)
@@ -595,7 +942,7 @@ func handleQuoteEditModal(s *discordgo.Session, i *discordgo.InteractionCreate,
 	}
 
 	// Show the updated quote with action buttons
-	embed := buildQuoteEmbed(updatedQuote)
+	embed := buildQuoteEmbed(updatedQuote, guildEmbedColors(updatedQuote.GuildId, grpcClient, log).Quote)
 
 	// Get current user Discord ID
 	var discordID string
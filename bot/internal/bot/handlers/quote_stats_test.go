@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"testing"
+
+	quotespb "github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+)
+
+func TestFormatQuoteLeaderboard(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []*quotespb.QuoteStatsEntry
+		want    string
+	}{
+		{
+			name:    "empty",
+			entries: nil,
+			want:    "_Nobody yet_",
+		},
+		{
+			name: "medals then numbers",
+			entries: []*quotespb.QuoteStatsEntry{
+				{DiscordId: "1", Count: 9},
+				{DiscordId: "2", Count: 5},
+				{DiscordId: "3", Count: 3},
+				{DiscordId: "4", Count: 1},
+			},
+			want: "🥇 <@1> — 9\n🥈 <@2> — 5\n🥉 <@3> — 3\n4. <@4> — 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatQuoteLeaderboard(tt.entries); got != tt.want {
+				t.Errorf("formatQuoteLeaderboard() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildQuoteStatsEmbedEmpty(t *testing.T) {
+	embed := buildQuoteStatsEmbed(&quotespb.GetQuoteStatsResponse{}, defaultQuoteColor)
+	if len(embed.Fields) != 0 {
+		t.Errorf("expected no leaderboard fields for an empty guild, got %d", len(embed.Fields))
+	}
+	if embed.Color != defaultQuoteColor {
+		t.Errorf("Color = %#x, want %#x", embed.Color, defaultQuoteColor)
+	}
+}
@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	quotespb "github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	"github.com/devilmonastery/hivemind/bot/internal/config"
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
+)
+
+func TestDuplicateQuoteMessage(t *testing.T) {
+	quote := &quotespb.Quote{Id: "q1", Body: "The cake is a lie", GuildId: "g1", WasDuplicate: true}
+
+	cfg := &config.Config{Backend: config.BackendConfig{WebBaseURL: "https://hivemind.example", WebLabel: "OurWiki"}}
+	params := duplicateQuoteMessage(quote, cfg, 0x123456)
+
+	if !strings.Contains(params.Content, "already saved as a quote") {
+		t.Errorf("Content = %q, want already-saved notice", params.Content)
+	}
+	if params.Flags != discordgo.MessageFlagsEphemeral {
+		t.Errorf("Flags = %v, want ephemeral", params.Flags)
+	}
+	if len(params.Embeds) != 1 || !strings.Contains(params.Embeds[0].Description, "The cake is a lie") {
+		t.Errorf("Embeds = %+v, want the existing quote", params.Embeds)
+	}
+
+	button := params.Components[0].(discordgo.ActionsRow).Components[0].(discordgo.Button)
+	if button.URL != "https://hivemind.example/quote?id=q1" {
+		t.Errorf("button URL = %q, want link to the existing quote", button.URL)
+	}
+	if button.Label != "🌐 View on OurWiki" {
+		t.Errorf("button label = %q, want configured web label", button.Label)
+	}
+}
+
+// duplicateQuoteServer answers every CreateQuote with an existing quote flagged as a duplicate
+type duplicateQuoteServer struct {
+	quotespb.UnimplementedQuoteServiceServer
+}
+
+func (duplicateQuoteServer) CreateQuote(ctx context.Context, req *quotespb.CreateQuoteRequest) (*quotespb.Quote, error) {
+	return &quotespb.Quote{Id: "q1", Body: req.Body, GuildId: "g1", WasDuplicate: true}, nil
+}
+
+func TestHandleContextQuoteModal_Duplicate(t *testing.T) {
+	s, discord := newFakeDiscordSession(t)
+	grpcClient := newTestGRPCClient(t, func(server *grpc.Server) {
+		quotespb.RegisterQuoteServiceServer(server, duplicateQuoteServer{})
+	})
+	i := testInteraction()
+	i.Type = discordgo.InteractionModalSubmit
+	i.GuildID = "g1"
+	i.ChannelID = "c1"
+	i.Member = &discordgo.Member{User: &discordgo.User{ID: "u1", Username: "ada"}}
+	i.Data = discordgo.ModalSubmitInteractionData{
+		CustomID: "context_quote_modal",
+		Components: []discordgo.MessageComponent{
+			&discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				&discordgo.TextInput{CustomID: "quote_text", Value: "The cake is a lie"},
+			}},
+		},
+	}
+
+	handleContextQuoteModal(s, i, &config.Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)), grpcClient)
+
+	followup := discord.indexOf(http.MethodPost, "/webhooks/app-1/interaction-token")
+	if followup < 0 {
+		t.Fatalf("Discord requests = %+v, want a followup", discord.requests)
+	}
+	if content, _ := discord.requests[followup].body["content"].(string); !strings.Contains(content, "already saved as a quote") {
+		t.Errorf("followup content = %q, want already-saved notice", content)
+	}
+	if n := discord.indexOf(http.MethodPost, "/channels/c1/messages"); n >= 0 {
+		t.Errorf("duplicate quote was announced: %+v", discord.requests[n])
+	}
+}
+
+func TestWebLinksPreferPermalinks(t *testing.T) {
+	const base = "https://hivemind.example"
+
+	noteTests := []struct {
+		note *notespb.Note
+		want string
+	}{
+		{note: &notespb.Note{Id: "n1", GuildId: "g1", Slug: "raid-plan"}, want: base + "/note?slug=raid-plan&guild_id=g1"},
+		{note: &notespb.Note{Id: "n1", Slug: "raid-plan"}, want: base + "/note?slug=raid-plan"},
+		{note: &notespb.Note{Id: "n1", GuildId: "g1"}, want: base + "/note?id=n1"},
+	}
+	for _, tt := range noteTests {
+		if got := mustBuildNoteURL(base, tt.note); got != tt.want {
+			t.Errorf("mustBuildNoteURL(%v) = %q, want %q", tt.note, got, tt.want)
+		}
+	}
+
+	quoteTests := []struct {
+		quote *quotespb.Quote
+		want  string
+	}{
+		{quote: &quotespb.Quote{Id: "q1", GuildId: "g1", ShortCode: "ab3d5fgh"}, want: base + "/quote?code=ab3d5fgh&guild_id=g1"},
+		{quote: &quotespb.Quote{Id: "q1", GuildId: "g1"}, want: base + "/quote?id=q1"},
+	}
+	for _, tt := range quoteTests {
+		if got, err := buildQuoteURL(base, tt.quote); err != nil || got != tt.want {
+			t.Errorf("buildQuoteURL(%v) = %q, %v, want %q", tt.quote, got, err, tt.want)
+		}
+	}
+}
+
+func TestQuoteJumpComponents(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tests := []struct {
+		name    string
+		quote   *quotespb.Quote
+		wantURL string
+	}{
+		{
+			name:    "with source",
+			quote:   &quotespb.Quote{Id: "q1", GuildId: "g1", SourceChannelId: "c1", SourceMsgId: "m1"},
+			wantURL: "https://discord.com/channels/g1/c1/m1",
+		},
+		{name: "no source", quote: &quotespb.Quote{Id: "q1", GuildId: "g1"}},
+		{name: "missing guild", quote: &quotespb.Quote{Id: "q1", SourceChannelId: "c1", SourceMsgId: "m1"}},
+		{name: "missing channel", quote: &quotespb.Quote{Id: "q1", GuildId: "g1", SourceMsgId: "m1"}},
+		{name: "missing message", quote: &quotespb.Quote{Id: "q1", GuildId: "g1", SourceChannelId: "c1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			components := quoteJumpComponents(tt.quote)
+			if tt.wantURL == "" {
+				if components != nil {
+					t.Errorf("quoteJumpComponents() = %+v, want none", components)
+				}
+			} else {
+				public := publicComponents(components)
+				if len(public) != 1 {
+					t.Fatalf("quoteJumpComponents() = %+v, want one row that survives promotion", components)
+				}
+				button := public[0].(discordgo.ActionsRow).Components[0].(discordgo.Button)
+				if button.Style != discordgo.LinkButton || button.URL != tt.wantURL {
+					t.Errorf("jump button = %+v, want a link to %s", button, tt.wantURL)
+				}
+			}
+
+			var offered bool
+			for _, c := range buildQuoteActionButtons(tt.quote, "", log)[0].(discordgo.ActionsRow).Components {
+				if strings.HasPrefix(c.(discordgo.Button).CustomID, "quote_add_to_chat_context:") {
+					offered = true
+				}
+			}
+			if offered != (tt.wantURL != "") {
+				t.Errorf("\"Post with link\" offered = %v, want %v", offered, tt.wantURL != "")
+			}
+		})
+	}
+}
+
+// cooldownError is the server's error when the guild's quote cooldown has wait left
+func cooldownError(wait time.Duration) error {
+	return rpcerr.NewWithRetryDelay(codes.ResourceExhausted, rpcerr.ReasonQuoteCooldown, "quote cooldown has not passed", wait)
+}
+
+func TestQuoteCreateFailedMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "cooldown", err: cooldownError(42 * time.Second), want: "⏳ You can save another quote in 42 seconds."},
+		{name: "rounds up", err: cooldownError(300 * time.Millisecond), want: "⏳ You can save another quote in 1 second."},
+		{name: "message only", err: status.Error(codes.ResourceExhausted, "quote cooldown has not passed"), want: "❌ Failed to create quote: rpc error: code = ResourceExhausted desc = quote cooldown has not passed"},
+		{name: "other exhaustion", err: status.Error(codes.ResourceExhausted, "too many requests"), want: "❌ Failed to create quote: rpc error: code = ResourceExhausted desc = too many requests"},
+		{name: "not a status", err: errors.New("boom"), want: "❌ Failed to create quote: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quoteCreateFailedMessage(tt.err); got != tt.want {
+				t.Errorf("quoteCreateFailedMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuoteRef(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   quoteRef
+		wantOK bool
+	}{
+		{name: "id", input: " 1790512345678901248 ", want: quoteRef{ID: "1790512345678901248"}, wantOK: true},
+		{name: "short code", input: "AbCd2345", want: quoteRef{Code: "abcd2345"}, wantOK: true},
+		{name: "permalink", input: "https://hivemind.example/quote?code=abcd2345&guild_id=g1", want: quoteRef{Code: "abcd2345", GuildID: "g1"}, wantOK: true},
+		{name: "id link", input: "https://hivemind.example/quote?id=1790512345678901248", want: quoteRef{ID: "1790512345678901248"}, wantOK: true},
+		{name: "link without embed", input: "<https://hivemind.example/quote?code=abcd2345&guild_id=g1>", want: quoteRef{Code: "abcd2345", GuildID: "g1"}, wantOK: true},
+		{name: "link to another page", input: "https://hivemind.example/note?slug=abcd2345", wantOK: false},
+		{name: "link without a quote", input: "https://hivemind.example/quote", wantOK: false},
+		{name: "empty", input: "   ", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseQuoteRef(tt.input)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseQuoteRef(%q) = %+v, %v, want %+v, %v", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// fakeQuoteLookupClient answers quote lookups with quote, or NotFound when it's nil,
+// recording the code lookup it was given
+type fakeQuoteLookupClient struct {
+	quotespb.QuoteServiceClient
+	quote    *quotespb.Quote
+	byCodeIn *quotespb.GetQuoteByCodeRequest
+}
+
+func (c *fakeQuoteLookupClient) GetQuote(ctx context.Context, in *quotespb.GetQuoteRequest, opts ...grpc.CallOption) (*quotespb.Quote, error) {
+	if c.quote == nil {
+		return nil, status.Error(codes.NotFound, "quote not found")
+	}
+	return c.quote, nil
+}
+
+func (c *fakeQuoteLookupClient) GetQuoteByCode(ctx context.Context, in *quotespb.GetQuoteByCodeRequest, opts ...grpc.CallOption) (*quotespb.Quote, error) {
+	c.byCodeIn = in
+	if c.quote == nil {
+		return nil, status.Error(codes.NotFound, "quote not found")
+	}
+	return c.quote, nil
+}
+
+func TestFetchQuoteRef(t *testing.T) {
+	ctx := context.Background()
+	found := &fakeQuoteLookupClient{quote: &quotespb.Quote{Id: "q1"}}
+
+	// A pasted permalink names its own guild, overriding the one the command ran in
+	if _, err := fetchQuoteRef(ctx, found, quoteRef{Code: "abcd2345", GuildID: "g1"}, "g2"); err != nil {
+		t.Fatalf("fetchQuoteRef() error = %v", err)
+	}
+	if found.byCodeIn.GuildId != "g1" || found.byCodeIn.Code != "abcd2345" {
+		t.Errorf("GetQuoteByCode got %+v, want code abcd2345 in g1", found.byCodeIn)
+	}
+	if _, err := fetchQuoteRef(ctx, found, quoteRef{Code: "abcd2345"}, "g2"); err != nil || found.byCodeIn.GuildId != "g2" {
+		t.Errorf("bare code looked up in %q (err %v), want g2", found.byCodeIn.GuildId, err)
+	}
+
+	missing := &fakeQuoteLookupClient{}
+	for _, ref := range []quoteRef{{ID: "404"}, {Code: "zzzzzzzz"}} {
+		_, err := fetchQuoteRef(ctx, missing, ref, "g1")
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("fetchQuoteRef(%+v) error = %v, want NotFound", ref, err)
+		}
+		if got := quoteViewErrorMessage(err); !strings.Contains(got, "Quote not found") {
+			t.Errorf("quoteViewErrorMessage() = %q, want a not found message", got)
+		}
+	}
+
+	if got := quoteViewErrorMessage(errors.New("connection reset")); got != "Failed to fetch quote" {
+		t.Errorf("quoteViewErrorMessage() = %q, want the generic failure", got)
+	}
+}
+
+// The click is acknowledged before the quote is fetched, so a slow backend can't
+// outlast Discord's response deadline
+func TestHandleQuoteAddToChat_AcknowledgesFirst(t *testing.T) {
+	s, discord := newFakeDiscordSession(t)
+	grpcClient := newTestGRPCClient(t, func(*grpc.Server) {}) // GetQuote fails as unimplemented
+	i := testInteraction()
+
+	handleQuoteAddToChat(s, i, "q1", false, slog.New(slog.NewTextHandler(io.Discard, nil)), grpcClient)
+
+	deferred := discord.indexOf(http.MethodPost, "/interactions/interaction-1/interaction-token/callback")
+	edited := discord.indexOf(http.MethodPatch, "/messages/@original")
+	if deferred < 0 || edited < deferred {
+		t.Fatalf("Discord requests = %+v, want acknowledge then edit", discord.requests)
+	}
+	if got := discord.requests[deferred].body["type"]; got != float64(discordgo.InteractionResponseDeferredMessageUpdate) {
+		t.Errorf("response type = %v, want a deferred update", got)
+	}
+	if content := discord.requests[edited].body["content"]; content != "❌ Failed to fetch quote" {
+		t.Errorf("edit content = %v, want the fetch failure", content)
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	activitypb "github.com/devilmonastery/hivemind/api/generated/go/activitypb"
+	"github.com/devilmonastery/hivemind/bot/internal/config"
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// recentActivityLimit is how many items /recent shows
+const recentActivityLimit = 10
+
+// recentSnippetLength is the longest quote or untitled snippet /recent shows
+const recentSnippetLength = 80
+
+// recentActivityEmoji marks each activity item type in /recent
+var recentActivityEmoji = map[string]string{
+	"wiki":  "📚",
+	"note":  "📝",
+	"quote": "💬",
+}
+
+// handleRecent shows the newest wiki pages, quotes, and the caller's notes in this guild
+func handleRecent(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+	if i.GuildID == "" {
+		respondError(s, i, "Recent activity can only be shown in a server", log)
+		return
+	}
+
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		ctx := discordContextFor(i)
+		activityClient := activitypb.NewActivityServiceClient(grpcClient.Conn())
+
+		resp, err := activityClient.ListRecentActivity(ctx, &activitypb.ListRecentActivityRequest{
+			GuildId: i.GuildID,
+			Limit:   recentActivityLimit,
+		})
+		if err != nil {
+			return nil, userError("Failed to load recent activity", err)
+		}
+
+		content := recentActivityMessage(resp.Items, getWebBaseURL(cfg))
+		activityURL := getWebBaseURL(cfg) + "/activity?guild_id=" + url.QueryEscape(i.GuildID)
+		components := []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label: webLinkLabel(cfg),
+						Style: discordgo.LinkButton,
+						URL:   activityURL,
+					},
+				},
+			},
+		}
+		return &discordgo.WebhookEdit{Content: &content, Components: &components}, nil
+	})
+}
+
+// recentActivityMessage lists activity items one per line, newest first
+func recentActivityMessage(items []*activitypb.ActivityItem, baseURL string) string {
+	if len(items) == 0 {
+		return "🕒 Nothing has been added or edited in this server yet."
+	}
+
+	var msg strings.Builder
+	msg.WriteString("🕒 **Recent activity**\n")
+	for _, item := range items {
+		emoji, ok := recentActivityEmoji[item.Type]
+		if !ok {
+			continue
+		}
+
+		name := item.Title
+		if name == "" {
+			name = "“" + truncateString(strings.Join(strings.Fields(item.Snippet), " "), recentSnippetLength) + "”"
+		} else {
+			name = "**" + name + "**"
+		}
+		if item.Type == "wiki" && item.Slug != "" {
+			name = fmt.Sprintf("[%s](%s)", name, mustBuildWikiURL(baseURL, item.GuildId, item.Slug))
+		}
+
+		fmt.Fprintf(&msg, "\n%s %s %s %s", emoji, name, item.Action, discordTimestamp(item.Timestamp, timestampRelative))
+		if item.AuthorName != "" {
+			if item.Type == "quote" {
+				fmt.Fprintf(&msg, " — %s", item.AuthorName)
+			} else {
+				fmt.Fprintf(&msg, " by %s", item.AuthorName)
+			}
+		}
+	}
+	return msg.String()
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	activitypb "github.com/devilmonastery/hivemind/api/generated/go/activitypb"
+)
+
+func TestRecentActivityMessage(t *testing.T) {
+	if got := recentActivityMessage(nil, "https://hivemind.example"); !strings.Contains(got, "Nothing has been added") {
+		t.Errorf("empty feed = %q, want the nothing-yet message", got)
+	}
+
+	ts := timestamppb.Now()
+	items := []*activitypb.ActivityItem{
+		{Type: "wiki", Action: "updated", GuildId: "g1", Title: "House Rules", Slug: "house-rules", AuthorName: "Ada", Timestamp: ts},
+		{Type: "quote", Action: "created", Snippet: "first line\nsecond line", AuthorName: "Bob", Timestamp: ts},
+		{Type: "note", Action: "created", Title: "Todo", Timestamp: ts},
+		{Type: "poll", Action: "created", Title: "Unknown type"},
+	}
+	got := recentActivityMessage(items, "https://hivemind.example")
+
+	for _, want := range []string{
+		"📚 [**House Rules**](https://hivemind.example/wiki?",
+		"updated " + discordTimestamp(ts, timestampRelative) + " by Ada",
+		"💬 “first line second line” created",
+		"— Bob",
+		"📝 **Todo** created",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("message = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "Unknown type") {
+		t.Errorf("message = %q, want unknown item types left out", got)
+	}
+}
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
+)
+
+// wikiReferenceLimitMessage is shown when a message can't be added to a wiki page
+// because the page is at its guild's reference limit
+const wikiReferenceLimitMessage = "This page has reached its reference limit."
+
+// isReferenceLimitError reports whether err is the server refusing a message reference
+// because the wiki page or note is at its guild's reference limit
+func isReferenceLimitError(err error) bool {
+	return status.Code(err) == codes.FailedPrecondition && rpcerr.HasReason(err, rpcerr.ReasonReferenceLimit)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
+)
+
+func TestIsReferenceLimitError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"limit reached", rpcerr.New(codes.FailedPrecondition, rpcerr.ReasonReferenceLimit, "reference limit reached: 500"), true},
+		{"message only", status.Error(codes.FailedPrecondition, "reference limit reached: 500"), false},
+		{"other precondition", status.Error(codes.FailedPrecondition, "wiki is read-only"), false},
+		{"other code", rpcerr.New(codes.Internal, rpcerr.ReasonReferenceLimit, "reference limit reached: 500"), false},
+		{"not a status", errors.New("reference limit reached: 500"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isReferenceLimitError(tt.err); got != tt.want {
+				t.Errorf("isReferenceLimitError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/bot/internal/config"
+	"github.com/devilmonastery/hivemind/internal/client"
+	"github.com/devilmonastery/hivemind/internal/pkg/urlutil"
+)
+
+// defaultReferencesPageSize is how many message references each page of "Show all
+// references" lists when the config doesn't say
+const defaultReferencesPageSize = 10
+
+// embedReferenceLimit is how many message references the detail embeds show inline
+const embedReferenceLimit = 5
+
+// referencePreviewLength is how many characters of a referenced message are shown in embeds
+const referencePreviewLength = 60
+
+// referenceLine formats a single message reference for display in an embed.
+// resolve names the message's mentions; it may be nil.
+func referenceLine(author, link string, timestamp *timestamppb.Timestamp, content string, resolve mentionResolver) string {
+	return fmt.Sprintf("• [%s](%s) - %s\n  _%s_\n", author, link, discordTimestamp(timestamp, timestampShortDateTime), referencePreview(content, resolve))
+}
+
+var (
+	// mentionPattern matches user (<@id>, <@!id>), role (<@&id>), and channel (<#id>) mentions
+	mentionPattern = regexp.MustCompile(`<(@!?|@&|#)(\d+)>`)
+
+	// customEmojiPattern matches static (<:name:id>) and animated (<a:name:id>) custom emoji
+	customEmojiPattern = regexp.MustCompile(`<a?:(\w+):\d+>`)
+)
+
+// mentionKind is the kind of thing a Discord mention points at
+type mentionKind int
+
+const (
+	mentionUser mentionKind = iota
+	mentionRole
+	mentionChannel
+)
+
+// mentionResolver returns the display name for a mentioned user, role, or channel ID,
+// or "" if it isn't known
+type mentionResolver func(kind mentionKind, id string) string
+
+// stateMentionResolver names mentions from the session's gateway cache, so building a
+// preview never calls the Discord API. Anything not cached is left unnamed.
+func stateMentionResolver(s *discordgo.Session, guildID string) mentionResolver {
+	if s == nil || s.State == nil {
+		return nil
+	}
+	return func(kind mentionKind, id string) string {
+		switch kind {
+		case mentionUser:
+			if member, err := s.State.Member(guildID, id); err == nil && member.User != nil {
+				return member.DisplayName()
+			}
+		case mentionRole:
+			if role, err := s.State.Role(guildID, id); err == nil {
+				return role.Name
+			}
+		case mentionChannel:
+			if channel, err := s.State.Channel(id); err == nil {
+				return channel.Name
+			}
+		}
+		return ""
+	}
+}
+
+// referencePreview renders a stored message's content as a one-line preview. Mentions
+// become "@name" or "#name" (generic "@user", "@role", or "#channel" when resolve can't
+// name them) and custom emoji become ":name:", so that neither raw IDs nor a token cut in
+// half by truncation show up. The stored content itself is never changed.
+func referencePreview(content string, resolve mentionResolver) string {
+	content = customEmojiPattern.ReplaceAllString(content, ":$1:")
+	content = mentionPattern.ReplaceAllStringFunc(content, func(token string) string {
+		match := mentionPattern.FindStringSubmatch(token)
+		kind, prefix, fallback := mentionUser, "@", "user"
+		switch match[1] {
+		case "@&":
+			kind, fallback = mentionRole, "role"
+		case "#":
+			kind, prefix, fallback = mentionChannel, "#", "channel"
+		}
+		name := ""
+		if resolve != nil {
+			name = resolve(kind, match[2])
+		}
+		if name == "" {
+			name = fallback
+		}
+		return prefix + name
+	})
+	content = strings.Join(strings.Fields(content), " ")
+
+	if utf8.RuneCountInString(content) > referencePreviewLength {
+		runes := []rune(content)
+		content = string(runes[:referencePreviewLength-3]) + "..."
+	}
+	return content
+}
+
+// imageAttachment is the attachment metadata needed to preview a referenced image.
+// It is satisfied by both the note and wiki AttachmentMetadata messages.
+type imageAttachment interface {
+	GetUrl() string
+	GetContentType() string
+	GetFilename() string
+}
+
+// firstImageURL returns the URL of the first image among a message's attachments, or ""
+// when it has none. Attachments saved without a content type are judged by file name.
+// Only http(s) URLs count, since Discord must be able to fetch the image itself.
+func firstImageURL[A imageAttachment](attachments []A) string {
+	for _, att := range attachments {
+		contentType := att.GetContentType()
+		if contentType == "" {
+			contentType = mime.TypeByExtension(path.Ext(att.GetFilename()))
+		}
+		if !strings.HasPrefix(contentType, "image/") {
+			continue
+		}
+		if u, err := url.Parse(att.GetUrl()); err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != "" {
+			return u.String()
+		}
+	}
+	return ""
+}
+
+// referenceImage is an image preview candidate from one referenced message
+type referenceImage struct {
+	sentAt *timestamppb.Timestamp
+	url    string // "" when the message has no image
+}
+
+// setReferenceImage shows the image from the most recently sent referenced message
+// that has one as the embed's image. Embeds without image references are left as text.
+func setReferenceImage(embed *discordgo.MessageEmbed, images []referenceImage) {
+	var latest *referenceImage
+	for idx := range images {
+		img := &images[idx]
+		if img.url == "" {
+			continue
+		}
+		if latest == nil || img.sentAt.AsTime().After(latest.sentAt.AsTime()) {
+			latest = img
+		}
+	}
+	if latest != nil {
+		embed.Image = &discordgo.MessageEmbedImage{URL: latest.url}
+	}
+}
+
+// showAllReferencesButton opens the reference pager at its first page; customIDPrefix is
+// "wiki_refs" or "note_refs"
+func showAllReferencesButton(customIDPrefix, id string) discordgo.Button {
+	return discordgo.Button{
+		Label:    "Show all references",
+		Style:    discordgo.SecondaryButton,
+		CustomID: pagedCustomID(customIDPrefix, id, 0),
+		Emoji:    &discordgo.ComponentEmoji{Name: "📚"},
+	}
+}
+
+// referencesPageSize returns the configured number of references per pager page
+func referencesPageSize(cfg *config.Config) int {
+	if cfg == nil || cfg.Features.ReferencesPageSize <= 0 {
+		return defaultReferencesPageSize
+	}
+	return cfg.Features.ReferencesPageSize
+}
+
+// pagedCustomID encodes a navigation button as "<customIDPrefix>:<id>:<offset>", where
+// id is whatever the handler needs to reload the list (a wiki page, a note, a sort order)
+func pagedCustomID(customIDPrefix, id string, offset int) string {
+	return fmt.Sprintf("%s:%s:%d", customIDPrefix, id, offset)
+}
+
+// parsePagedCustomID splits an "<id>:<offset>" component remainder
+func parsePagedCustomID(remainder string) (string, int, error) {
+	id, offsetStr, ok := strings.Cut(remainder, ":")
+	if !ok || id == "" {
+		return "", 0, fmt.Errorf("invalid paged custom_id: %q", remainder)
+	}
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		return "", 0, fmt.Errorf("invalid page offset: %q", offsetStr)
+	}
+	return id, offset, nil
+}
+
+// lastPageOffset is the offset of the last page total items fill; an empty list is still one page
+func lastPageOffset(total, pageSize int) int {
+	return max(0, (total-1)/pageSize*pageSize)
+}
+
+// clampPageOffset keeps offset within the pages total items fill, e.g. after items
+// were removed while the list was open
+func clampPageOffset(offset, total, pageSize int) int {
+	return min(max(offset, 0), lastPageOffset(total, pageSize))
+}
+
+// pageNavigationComponents builds the "page X of Y" navigation row.
+// Navigation buttons use "<customIDPrefix>_page:<id>:<offset>" so they update the message in place.
+// Next is enabled only when hasMore reports another page after this one.
+func pageNavigationComponents(customIDPrefix, id string, offset, total, pageSize int, hasMore bool) []discordgo.MessageComponent {
+	pageCount := lastPageOffset(total, pageSize)/pageSize + 1
+	page := offset/pageSize + 1
+	pagePrefix := customIDPrefix + "_page"
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "◀ Prev",
+					Style:    discordgo.SecondaryButton,
+					CustomID: pagedCustomID(pagePrefix, id, max(0, offset-pageSize)),
+					Disabled: offset == 0,
+				},
+				discordgo.Button{
+					Label:    fmt.Sprintf("Page %d of %d", page, pageCount),
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("%s_label:%s", pagePrefix, id),
+					Disabled: true,
+				},
+				discordgo.Button{
+					Label:    "Next ▶",
+					Style:    discordgo.SecondaryButton,
+					CustomID: pagedCustomID(pagePrefix, id, min(offset+pageSize, lastPageOffset(total, pageSize))),
+					Disabled: !hasMore,
+				},
+			},
+		},
+	}
+}
+
+// respondReferencesPage sends a page of references. The first page opens an ephemeral
+// follow-up so the original embed stays put; later pages edit that follow-up in place.
+func respondReferencesPage(s *discordgo.Session, i *discordgo.InteractionCreate, update bool, embed *discordgo.MessageEmbed, components []discordgo.MessageComponent, log *slog.Logger) {
+	responseType := discordgo.InteractionResponseChannelMessageWithSource
+	if update {
+		responseType = discordgo.InteractionResponseUpdateMessage
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: responseType,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("failed to send references page", slog.String("error", err.Error()))
+	}
+}
+
+// handleWikiReferences shows one page of a wiki page's message references
+func handleWikiReferences(s *discordgo.Session, i *discordgo.InteractionCreate, remainder string, update bool, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+	pageID, offset, err := parsePagedCustomID(remainder)
+	if err != nil {
+		log.Warn("invalid wiki references button", slog.String("error", err.Error()))
+		respondError(s, i, "Invalid button", log)
+		return
+	}
+
+	ctx := discordContextFor(i)
+	wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+
+	page, err := wikiClient.GetWikiPage(ctx, &wikipb.GetWikiPageRequest{Id: pageID})
+	if err != nil {
+		log.Error("failed to fetch wiki page for references",
+			slog.String("page_id", pageID),
+			slog.String("error", err.Error()))
+		respondError(s, i, "Failed to load wiki page", log)
+		return
+	}
+
+	pageSize := referencesPageSize(cfg)
+	list := func(offset int) (*wikipb.ListWikiMessageReferencesResponse, error) {
+		return wikiClient.ListWikiMessageReferences(ctx, &wikipb.ListWikiMessageReferencesRequest{
+			WikiPageId: pageID,
+			Limit:      int32(pageSize),
+			Offset:     int32(offset),
+		})
+	}
+
+	resp, err := list(offset)
+	// References were removed since the pager was shown; jump to the new last page
+	if err == nil {
+		if clamped := clampPageOffset(offset, int(resp.Total), pageSize); clamped != offset {
+			offset = clamped
+			resp, err = list(offset)
+		}
+	}
+	if err != nil {
+		log.Error("failed to list wiki message references",
+			slog.String("page_id", pageID),
+			slog.String("error", err.Error()))
+		respondError(s, i, "Failed to load references", log)
+		return
+	}
+
+	var lines strings.Builder
+	for _, ref := range resp.References {
+		link := urlutil.DiscordMessageURL(ref.GuildId, ref.ChannelId, ref.MessageId)
+		lines.WriteString(referenceLine(ref.AuthorUsername, link, ref.MessageTimestamp, ref.Content, stateMentionResolver(s, ref.GuildId)))
+	}
+	if lines.Len() == 0 {
+		lines.WriteString("_No references on this page_")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("📌 References for %s (%d)", page.Title, resp.Total),
+		Description: lines.String(),
+		Color:       guildEmbedColors(page.GuildId, grpcClient, log).Wiki,
+	}
+
+	respondReferencesPage(s, i, update, embed, pageNavigationComponents("wiki_refs", pageID, offset, int(resp.Total), pageSize, resp.GetPagination().GetHasMore()), log)
+}
+
+// handleNoteReferences shows one page of a note's message references
+func handleNoteReferences(s *discordgo.Session, i *discordgo.InteractionCreate, remainder string, update bool, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+	noteID, offset, err := parsePagedCustomID(remainder)
+	if err != nil {
+		log.Warn("invalid note references button", slog.String("error", err.Error()))
+		respondError(s, i, "Invalid button", log)
+		return
+	}
+
+	ctx := discordContextFor(i)
+	noteClient := notespb.NewNoteServiceClient(grpcClient.Conn())
+
+	note, err := noteClient.GetNote(ctx, &notespb.GetNoteRequest{Id: noteID})
+	if err != nil {
+		log.Error("failed to fetch note for references",
+			slog.String("note_id", noteID),
+			slog.String("error", err.Error()))
+		respondError(s, i, "Failed to load note", log)
+		return
+	}
+
+	pageSize := referencesPageSize(cfg)
+	list := func(offset int) (*notespb.ListNoteMessageReferencesResponse, error) {
+		return noteClient.ListNoteMessageReferences(ctx, &notespb.ListNoteMessageReferencesRequest{
+			NoteId: noteID,
+			Limit:  int32(pageSize),
+			Offset: int32(offset),
+		})
+	}
+
+	resp, err := list(offset)
+	// References were removed since the pager was shown; jump to the new last page
+	if err == nil {
+		if clamped := clampPageOffset(offset, int(resp.Total), pageSize); clamped != offset {
+			offset = clamped
+			resp, err = list(offset)
+		}
+	}
+	if err != nil {
+		log.Error("failed to list note message references",
+			slog.String("note_id", noteID),
+			slog.String("error", err.Error()))
+		respondError(s, i, "Failed to load references", log)
+		return
+	}
+
+	var lines strings.Builder
+	for _, ref := range resp.References {
+		link := urlutil.DiscordMessageURL(ref.GuildId, ref.ChannelId, ref.MessageId)
+		lines.WriteString(referenceLine(ref.AuthorUsername, link, ref.MessageTimestamp, ref.Content, stateMentionResolver(s, ref.GuildId)))
+	}
+	if lines.Len() == 0 {
+		lines.WriteString("_No references on this page_")
+	}
+
+	title := note.Title
+	if title == "" {
+		title = "(untitled)"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("📌 References for %s (%d)", title, resp.Total),
+		Description: lines.String(),
+		Color:       guildEmbedColors(note.GuildId, grpcClient, log).Note,
+	}
+
+	respondReferencesPage(s, i, update, embed, pageNavigationComponents("note_refs", noteID, offset, int(resp.Total), pageSize, resp.GetPagination().GetHasMore()), log)
+}
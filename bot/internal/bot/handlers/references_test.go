@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+)
+
+func TestReferencePreview(t *testing.T) {
+	// A gateway cache that knows some of the mentioned IDs
+	s := &discordgo.Session{State: discordgo.NewState()}
+	guild := &discordgo.Guild{
+		ID:       "g1",
+		Roles:    []*discordgo.Role{{ID: "201", Name: "Raiders"}},
+		Channels: []*discordgo.Channel{{ID: "301", GuildID: "g1", Name: "general"}},
+		Members: []*discordgo.Member{
+			{GuildID: "g1", User: &discordgo.User{ID: "101", Username: "ada"}},
+			{GuildID: "g1", User: &discordgo.User{ID: "102", Username: "grace"}, Nick: "Admiral"},
+		},
+	}
+	if err := s.State.GuildAdd(guild); err != nil {
+		t.Fatalf("GuildAdd() error = %v", err)
+	}
+	resolve := stateMentionResolver(s, "g1")
+
+	tests := []struct {
+		name    string
+		content string
+		resolve mentionResolver
+		want    string
+	}{
+		{name: "user mention", content: "thanks <@101>!", resolve: resolve, want: "thanks @ada!"},
+		{name: "nickname mention", content: "ping <@!102>", resolve: resolve, want: "ping @Admiral"},
+		{name: "role mention", content: "<@&201> meet at 8", resolve: resolve, want: "@Raiders meet at 8"},
+		{name: "channel mention", content: "see <#301>", resolve: resolve, want: "see #general"},
+		{name: "unknown ids", content: "<@999> in <#998> for <@&997>", resolve: resolve, want: "@user in #channel for @role"},
+		{name: "no resolver", content: "<@101> <#301>", want: "@user #channel"},
+		{name: "custom emoji", content: "nice <:pog:123456789012345678>", want: "nice :pog:"},
+		{name: "animated emoji", content: "<a:party_blob:123456789012345678> woo", want: ":party_blob: woo"},
+		{name: "newlines", content: "line one\n\nline two", want: "line one line two"},
+		{
+			name:    "truncated after rendering",
+			content: strings.Repeat("<:pog:123456789012345678>", 30),
+			want:    strings.Repeat(":pog:", 11) + ":p...",
+		},
+		{
+			name:    "multibyte text",
+			content: strings.Repeat("é", 70),
+			want:    strings.Repeat("é", 57) + "...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := referencePreview(tt.content, tt.resolve); got != tt.want {
+				t.Errorf("referencePreview(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstImageURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		attachments []*notespb.AttachmentMetadata
+		want        string
+	}{
+		{name: "none"},
+		{
+			name:        "image first",
+			attachments: []*notespb.AttachmentMetadata{{Url: "https://cdn.example/cat.png", ContentType: "image/png"}},
+			want:        "https://cdn.example/cat.png",
+		},
+		{
+			name: "image after other files",
+			attachments: []*notespb.AttachmentMetadata{
+				{Url: "https://cdn.example/clip.mp4", ContentType: "video/mp4"},
+				{Url: "https://cdn.example/notes.pdf", ContentType: "application/pdf"},
+				{Url: "https://cdn.example/cat.jpg", ContentType: "image/jpeg"},
+				{Url: "https://cdn.example/dog.png", ContentType: "image/png"},
+			},
+			want: "https://cdn.example/cat.jpg",
+		},
+		{
+			name:        "no images",
+			attachments: []*notespb.AttachmentMetadata{{Url: "https://cdn.example/clip.mp4", ContentType: "video/mp4"}},
+		},
+		{
+			name:        "content type from file name",
+			attachments: []*notespb.AttachmentMetadata{{Url: "https://cdn.example/cat.gif", Filename: "cat.gif"}},
+			want:        "https://cdn.example/cat.gif",
+		},
+		{
+			name: "unfetchable URL skipped",
+			attachments: []*notespb.AttachmentMetadata{
+				{Url: "attachment://cat.png", ContentType: "image/png"},
+				{Url: "https://cdn.example/dog.png", ContentType: "image/png"},
+			},
+			want: "https://cdn.example/dog.png",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := firstImageURL(tt.attachments); got != tt.want {
+				t.Errorf("firstImageURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	wiki := []*wikipb.AttachmentMetadata{{Url: "https://cdn.example/map.webp", ContentType: "image/webp"}}
+	if got := firstImageURL(wiki); got != "https://cdn.example/map.webp" {
+		t.Errorf("firstImageURL(wiki) = %q, want the map", got)
+	}
+}
+
+func TestSetReferenceImage(t *testing.T) {
+	at := func(day int) *timestamppb.Timestamp {
+		return timestamppb.New(time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC))
+	}
+
+	embed := &discordgo.MessageEmbed{}
+	setReferenceImage(embed, []referenceImage{
+		{sentAt: at(1), url: "https://cdn.example/old.png"},
+		{sentAt: at(3)}, // Newest message, but text only
+		{sentAt: at(2), url: "https://cdn.example/new.png"},
+	})
+	if embed.Image == nil || embed.Image.URL != "https://cdn.example/new.png" {
+		t.Errorf("Image = %+v, want the most recent image", embed.Image)
+	}
+
+	embed = &discordgo.MessageEmbed{}
+	setReferenceImage(embed, []referenceImage{{sentAt: at(1)}})
+	if embed.Image != nil {
+		t.Errorf("Image = %+v, want none for text-only references", embed.Image)
+	}
+}
+
+func TestPagedCustomID(t *testing.T) {
+	customID := pagedCustomID("wiki_refs_page", "page-1", 30)
+	if customID != "wiki_refs_page:page-1:30" {
+		t.Fatalf("pagedCustomID() = %q, want wiki_refs_page:page-1:30", customID)
+	}
+
+	// The router strips the prefix and hands the handler the rest
+	_, remainder, _ := strings.Cut(customID, ":")
+	id, offset, err := parsePagedCustomID(remainder)
+	if err != nil || id != "page-1" || offset != 30 {
+		t.Errorf("parsePagedCustomID(%q) = %q, %d, %v, want page-1, 30, nil", remainder, id, offset, err)
+	}
+
+	for _, remainder := range []string{"", "page-1", ":20", "page-1:", "page-1:two", "page-1:-10"} {
+		if _, _, err := parsePagedCustomID(remainder); err == nil {
+			t.Errorf("parsePagedCustomID(%q) succeeded, want an error", remainder)
+		}
+	}
+}
+
+func TestClampPageOffset(t *testing.T) {
+	tests := []struct {
+		offset, total, want int
+	}{
+		{offset: 0, total: 0, want: 0},
+		{offset: 30, total: 0, want: 0},
+		{offset: -10, total: 25, want: 0},
+		{offset: 10, total: 25, want: 10},
+		{offset: 20, total: 25, want: 20},
+		{offset: 30, total: 25, want: 20},
+		{offset: 20, total: 20, want: 10},
+	}
+	for _, tt := range tests {
+		if got := clampPageOffset(tt.offset, tt.total, 10); got != tt.want {
+			t.Errorf("clampPageOffset(%d, %d, 10) = %d, want %d", tt.offset, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestPageNavigationComponents(t *testing.T) {
+	buttons := func(offset, total int) (discordgo.Button, discordgo.Button, discordgo.Button) {
+		hasMore := offset+10 < total
+		row := pageNavigationComponents("note_refs", "n1", offset, total, 10, hasMore)[0].(discordgo.ActionsRow).Components
+		return row[0].(discordgo.Button), row[1].(discordgo.Button), row[2].(discordgo.Button)
+	}
+
+	prev, label, next := buttons(0, 25)
+	if !prev.Disabled || next.Disabled || next.CustomID != "note_refs_page:n1:10" {
+		t.Errorf("first page: prev disabled=%v, next %q disabled=%v", prev.Disabled, next.CustomID, next.Disabled)
+	}
+	if label.Label != "Page 1 of 3" || label.CustomID != "note_refs_page_label:n1" {
+		t.Errorf("label = %q (%q), want Page 1 of 3", label.Label, label.CustomID)
+	}
+
+	prev, label, next = buttons(20, 25)
+	if prev.Disabled || prev.CustomID != "note_refs_page:n1:10" || !next.Disabled {
+		t.Errorf("last page: prev %q disabled=%v, next disabled=%v", prev.CustomID, prev.Disabled, next.Disabled)
+	}
+	if label.Label != "Page 3 of 3" {
+		t.Errorf("label = %q, want Page 3 of 3", label.Label)
+	}
+
+	// Even a disabled Next never points past the last page
+	if _, _, next = buttons(0, 5); !next.Disabled || next.CustomID != "note_refs_page:n1:0" {
+		t.Errorf("single page: next %q disabled=%v, want disabled at offset 0", next.CustomID, next.Disabled)
+	}
+
+	// Next follows the server's has_more, not the page count
+	row := pageNavigationComponents("note_refs", "n1", 0, 25, 10, false)[0].(discordgo.ActionsRow).Components
+	if next := row[2].(discordgo.Button); !next.Disabled {
+		t.Error("next enabled without has_more")
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/bwmarrin/discordgo"
+
+	authpb "github.com/devilmonastery/hivemind/api/generated/go/authpb"
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+	userpb "github.com/devilmonastery/hivemind/api/generated/go/userpb"
+	"github.com/devilmonastery/hivemind/bot/internal/bot/commands"
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// resolveSearchScope maps a search command's scope option to the guild_id and guild_ids
+// of its search request. Searching everything needs a Hivemind admin unless
+// everythingForAll is set, as for notes, which only ever include the caller's own.
+func resolveSearchScope(ctx context.Context, i *discordgo.InteractionCreate, scope string, everythingForAll bool, grpcClient *client.Client, log *slog.Logger) (string, []string, error) {
+	everythingAllowed := func() bool {
+		return everythingForAll || isHivemindAdmin(ctx, grpcClient, log)
+	}
+	userGuilds := func() ([]string, error) {
+		discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
+		resp, err := discordClient.ListUserGuilds(ctx, &discordpb.ListUserGuildsRequest{
+			DiscordId: interactionUser(i).ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.GuildIds, nil
+	}
+	return searchScopeFilter(scope, guildIDFor(ctx, i, grpcClient, log), everythingAllowed, userGuilds)
+}
+
+// searchScopeFilter maps a search scope to a guild_id and guild_ids. Without a scope it
+// searches the current guild or, outside of one, as widely as the caller may.
+// everythingAllowed and userGuilds are only called when the scope needs them.
+func searchScopeFilter(scope, currentGuildID string, everythingAllowed func() bool, userGuilds func() ([]string, error)) (string, []string, error) {
+	if scope == "" {
+		switch {
+		case currentGuildID != "":
+			scope = commands.SearchScopeGuild
+		case everythingAllowed():
+			scope = commands.SearchScopeEverything
+		default:
+			scope = commands.SearchScopeMyGuilds
+		}
+	}
+
+	switch scope {
+	case commands.SearchScopeGuild:
+		if currentGuildID == "" {
+			return "", nil, userError("Run this in a server, set one with `/prefs set-default-guild`, or pick another scope.", nil)
+		}
+		return currentGuildID, nil, nil
+	case commands.SearchScopeMyGuilds:
+		guildIDs, err := userGuilds()
+		if err != nil {
+			return "", nil, userError("Failed to look up your servers", err)
+		}
+		if len(guildIDs) == 0 {
+			return "", nil, userError("You aren't in any servers that use Hivemind.", nil)
+		}
+		return "", guildIDs, nil
+	case commands.SearchScopeEverything:
+		if !everythingAllowed() {
+			return "", nil, userError("Only Hivemind admins can search everything. Pick this guild or all your guilds instead.", nil)
+		}
+		return "", nil, nil
+	default:
+		return "", nil, userError(fmt.Sprintf("Unknown scope `%s`", scope), nil)
+	}
+}
+
+// isHivemindAdmin reports whether the user behind ctx is a Hivemind admin. Lookup
+// failures count as not an admin.
+func isHivemindAdmin(ctx context.Context, grpcClient *client.Client, log *slog.Logger) bool {
+	authClient := authpb.NewAuthServiceClient(grpcClient.Conn())
+	resp, err := authClient.GetCurrentUser(ctx, &authpb.GetCurrentUserRequest{})
+	if err != nil {
+		log.Warn("failed to look up current user, treating as not an admin",
+			slog.String("error", err.Error()))
+		return false
+	}
+	return resp.GetUser().GetRole() == userpb.Role_ROLE_ADMIN
+}
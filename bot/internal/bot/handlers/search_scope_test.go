@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/bot/internal/bot/commands"
+)
+
+func TestSearchScopeFilter(t *testing.T) {
+	myGuilds := []string{"g1", "g2"}
+
+	tests := []struct {
+		name           string
+		scope          string
+		currentGuildID string
+		admin          bool
+		guilds         []string
+		guildsErr      error
+		wantGuildID    string
+		wantGuildIDs   []string
+		wantErr        bool
+		wantAdminCheck bool
+		wantGuildsCall bool
+	}{
+		{name: "default in guild", currentGuildID: "g1", wantGuildID: "g1"},
+		{name: "default outside guild for admin", admin: true, wantAdminCheck: true},
+		{name: "default outside guild", guilds: myGuilds, wantGuildIDs: myGuilds, wantAdminCheck: true, wantGuildsCall: true},
+		{name: "this guild", scope: commands.SearchScopeGuild, currentGuildID: "g2", wantGuildID: "g2"},
+		{name: "this guild without one", scope: commands.SearchScopeGuild, wantErr: true},
+		{name: "my guilds", scope: commands.SearchScopeMyGuilds, currentGuildID: "g1", guilds: myGuilds, wantGuildIDs: myGuilds, wantGuildsCall: true},
+		{name: "my guilds none", scope: commands.SearchScopeMyGuilds, wantErr: true, wantGuildsCall: true},
+		{name: "my guilds lookup fails", scope: commands.SearchScopeMyGuilds, guildsErr: errors.New("unavailable"), wantErr: true, wantGuildsCall: true},
+		{name: "everything for admin", scope: commands.SearchScopeEverything, currentGuildID: "g1", admin: true, wantAdminCheck: true},
+		{name: "everything denied", scope: commands.SearchScopeEverything, currentGuildID: "g1", wantErr: true, wantAdminCheck: true},
+		{name: "unknown scope", scope: "galaxy", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var adminChecks, guildsCalls int
+			everythingAllowed := func() bool {
+				adminChecks++
+				return tt.admin
+			}
+			userGuilds := func() ([]string, error) {
+				guildsCalls++
+				return tt.guilds, tt.guildsErr
+			}
+
+			guildID, guildIDs, err := searchScopeFilter(tt.scope, tt.currentGuildID, everythingAllowed, userGuilds)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("searchScopeFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if guildID != tt.wantGuildID || !slices.Equal(guildIDs, tt.wantGuildIDs) {
+				t.Errorf("searchScopeFilter() = %q, %v, want %q, %v", guildID, guildIDs, tt.wantGuildID, tt.wantGuildIDs)
+			}
+			if (adminChecks > 0) != tt.wantAdminCheck {
+				t.Errorf("everythingAllowed called %d times, want called = %v", adminChecks, tt.wantAdminCheck)
+			}
+			if (guildsCalls > 0) != tt.wantGuildsCall {
+				t.Errorf("userGuilds called %d times, want called = %v", guildsCalls, tt.wantGuildsCall)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// tagSuggestionTimeout bounds the SuggestTags call so a slow backend only means no
+// suggestions, never a late one
+const tagSuggestionTimeout = 2 * time.Second
+
+// sendTagSuggestions follows up newly created content with buttons for tags used on
+// similar wiki pages in the guild. customIDPrefix is "wiki_add_tag" or "note_add_tag"
+// and id the page or note the buttons tag. It sends nothing when there are no
+// suggestions or the backend can't be reached; suggestions are a convenience, not part
+// of saving the content.
+func sendTagSuggestions(s *discordgo.Session, i *discordgo.InteractionCreate, customIDPrefix, id string, req *wikipb.SuggestTagsRequest, log *slog.Logger, grpcClient *client.Client) {
+	ctx, cancel := context.WithTimeout(discordContextFor(i), tagSuggestionTimeout)
+	defer cancel()
+
+	wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+	resp, err := wikiClient.SuggestTags(ctx, req)
+	if err != nil {
+		log.Warn("failed to suggest tags",
+			slog.String("id", id),
+			slog.String("error", err.Error()))
+		return
+	}
+	if len(resp.Suggestions) == 0 {
+		return
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content:    "🏷️ Similar pages are tagged with these. Add any that fit:",
+		Flags:      discordgo.MessageFlagsEphemeral,
+		Components: tagSuggestionComponents(customIDPrefix, id, resp.Suggestions),
+	})
+	if err != nil {
+		log.Error("failed to send tag suggestions", slog.String("error", err.Error()))
+	}
+}
+
+// tagSuggestionComponents builds a row of add-tag buttons, one per suggestion
+func tagSuggestionComponents(customIDPrefix, id string, suggestions []*wikipb.TagSuggestion) []discordgo.MessageComponent {
+	// An action row holds at most 5 buttons
+	if len(suggestions) > 5 {
+		suggestions = suggestions[:5]
+	}
+
+	buttons := make([]discordgo.MessageComponent, len(suggestions))
+	for n, suggestion := range suggestions {
+		buttons[n] = discordgo.Button{
+			Label:    "#" + suggestion.Tag,
+			Style:    discordgo.SecondaryButton,
+			CustomID: fmt.Sprintf("%s:%s:%s", customIDPrefix, id, suggestion.Tag),
+		}
+	}
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
+}
+
+// handleWikiAddTag adds a suggested tag to a wiki page. Tags come from a page's hashtags,
+// so the tag is appended to the body as one and survives later edits.
+func handleWikiAddTag(s *discordgo.Session, i *discordgo.InteractionCreate, remainder string, log *slog.Logger, grpcClient *client.Client) {
+	respondTagAdded(s, i, "wiki_add_tag", remainder, log, func(ctx context.Context, pageID, tag string) (string, []string, error) {
+		wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+
+		page, err := wikiClient.GetWikiPage(ctx, &wikipb.GetWikiPageRequest{Id: pageID})
+		if err != nil {
+			return "", nil, userError("Wiki page not found", err)
+		}
+		if slices.Contains(page.Tags, tag) {
+			return page.Title, page.Tags, nil
+		}
+
+		body := appendHashtag(page.Body, tag)
+		page, err = wikiClient.UpdateWikiPage(ctx, &wikipb.UpdateWikiPageRequest{
+			Id:    page.Id,
+			Title: page.Title,
+			Body:  body,
+			Tags:  extractHashtags(body),
+		})
+		if err != nil {
+			return "", nil, userError("Failed to add tag", err)
+		}
+		return page.Title, page.Tags, nil
+	})
+}
+
+// handleNoteAddTag adds a suggested tag to a note, as a hashtag like handleWikiAddTag
+func handleNoteAddTag(s *discordgo.Session, i *discordgo.InteractionCreate, remainder string, log *slog.Logger, grpcClient *client.Client) {
+	respondTagAdded(s, i, "note_add_tag", remainder, log, func(ctx context.Context, noteID, tag string) (string, []string, error) {
+		noteClient := notespb.NewNoteServiceClient(grpcClient.Conn())
+
+		note, err := noteClient.GetNote(ctx, &notespb.GetNoteRequest{Id: noteID})
+		if err != nil {
+			return "", nil, userError("Note not found", err)
+		}
+		if slices.Contains(note.Tags, tag) {
+			return note.Title, note.Tags, nil
+		}
+
+		body := appendHashtag(note.Body, tag)
+		note, err = noteClient.UpdateNote(ctx, &notespb.UpdateNoteRequest{
+			Id:    note.Id,
+			Title: note.Title,
+			Body:  body,
+			Tags:  extractHashtags(body),
+		})
+		if err != nil {
+			return "", nil, userError("Failed to add tag", err)
+		}
+		return note.Title, note.Tags, nil
+	})
+}
+
+// respondTagAdded handles a click on an add-tag button (remainder: "<id>:<tag>"). The
+// click is acknowledged before addTag makes its backend calls; the suggestion message is
+// then edited to confirm, keeping the buttons for tags the content doesn't have yet.
+// addTag returns the content's title and tags after the change.
+func respondTagAdded(s *discordgo.Session, i *discordgo.InteractionCreate, customIDPrefix, remainder string, log *slog.Logger, addTag func(ctx context.Context, id, tag string) (string, []string, error)) {
+	id, tag, ok := strings.Cut(remainder, ":")
+	if !ok || id == "" || tag == "" {
+		respondError(s, i, "Invalid tag suggestion", log)
+		return
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	if err != nil {
+		log.Error("failed to defer interaction", slog.String("error", err.Error()))
+		return
+	}
+
+	title, tags, err := addTag(discordContextFor(i), id, tag)
+	if err != nil {
+		log.Error("failed to add suggested tag",
+			slog.String("id", id),
+			slog.String("tag", tag),
+			slog.String("error", err.Error()))
+		if _, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: *deferredErrorEdit(err).Content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		}); err != nil {
+			log.Error("failed to report tag failure", slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	// Keep the buttons for the tags that haven't been added yet
+	var remaining []*wikipb.TagSuggestion
+	for _, row := range i.Message.Components {
+		actionsRow, ok := row.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, component := range actionsRow.Components {
+			button, ok := component.(*discordgo.Button)
+			if !ok {
+				continue
+			}
+			suggested := strings.TrimPrefix(button.Label, "#")
+			if !slices.Contains(tags, suggested) {
+				remaining = append(remaining, &wikipb.TagSuggestion{Tag: suggested})
+			}
+		}
+	}
+
+	content := fmt.Sprintf("✅ Tagged **%s** with #%s", title, tag)
+	components := []discordgo.MessageComponent{}
+	if len(remaining) > 0 {
+		components = tagSuggestionComponents(customIDPrefix, id, remaining)
+	}
+
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content:    &content,
+		Components: &components,
+	})
+	if err != nil {
+		log.Error("failed to confirm tag", slog.String("error", err.Error()))
+	}
+}
+
+// appendHashtag adds #tag to the end of body, on the last line when that line is
+// already only hashtags and on a new paragraph otherwise
+func appendHashtag(body, tag string) string {
+	body = strings.TrimRight(body, " \n")
+	if body == "" {
+		return "#" + tag
+	}
+	if isHashtagLine(body[strings.LastIndex(body, "\n")+1:]) {
+		return body + " #" + tag
+	}
+	return body + "\n\n#" + tag
+}
+
+// isHashtagLine reports whether line holds nothing but hashtags
+func isHashtagLine(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, field := range fields {
+		if !strings.HasPrefix(field, "#") {
+			return false
+		}
+	}
+	return true
+}
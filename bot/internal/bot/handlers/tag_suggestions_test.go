@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+)
+
+func TestAppendHashtag(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "new paragraph after text", body: "Raid nights are on Friday.", want: "Raid nights are on Friday.\n\n#raids"},
+		{name: "joins a trailing hashtag line", body: "Raid nights are on Friday.\n\n#events #weekly\n", want: "Raid nights are on Friday.\n\n#events #weekly #raids"},
+		{name: "text with a hashtag is not a hashtag line", body: "Ask in #general first", want: "Ask in #general first\n\n#raids"},
+		{name: "empty body", body: "", want: "#raids"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appendHashtag(tt.body, "raids"); got != tt.want {
+				t.Errorf("appendHashtag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagSuggestionComponents(t *testing.T) {
+	var suggestions []*wikipb.TagSuggestion
+	for _, tag := range []string{"a", "b", "c", "d", "e", "f"} {
+		suggestions = append(suggestions, &wikipb.TagSuggestion{Tag: tag})
+	}
+
+	rows := tagSuggestionComponents("wiki_add_tag", "p1", suggestions)
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	buttons := rows[0].(discordgo.ActionsRow).Components
+	if len(buttons) != 5 {
+		t.Fatalf("got %d buttons, want at most 5", len(buttons))
+	}
+	first := buttons[0].(discordgo.Button)
+	if first.Label != "#a" || first.CustomID != "wiki_add_tag:p1:a" {
+		t.Errorf("first button = %q %q, want #a wiki_add_tag:p1:a", first.Label, first.CustomID)
+	}
+}
+
+// The click is acknowledged before the tag is added, and the confirmation keeps the
+// buttons for the suggestions not yet added
+func TestRespondTagAdded(t *testing.T) {
+	s, discord := newFakeDiscordSession(t)
+	i := testInteraction()
+	i.Message = &discordgo.Message{Components: []discordgo.MessageComponent{
+		&discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			&discordgo.Button{Label: "#raids"},
+			&discordgo.Button{Label: "#events"},
+		}},
+	}}
+
+	respondTagAdded(s, i, "note_add_tag", "n1:raids", slog.New(slog.NewTextHandler(io.Discard, nil)), func(ctx context.Context, id, tag string) (string, []string, error) {
+		if discord.indexOf(http.MethodPost, "/interactions/interaction-1/interaction-token/callback") < 0 {
+			t.Error("tag added before the click was acknowledged")
+		}
+		if id != "n1" || tag != "raids" {
+			t.Errorf("addTag(%q, %q), want n1, raids", id, tag)
+		}
+		return "Raid plan", []string{"raids"}, nil
+	})
+
+	edited := discord.indexOf(http.MethodPatch, "/messages/@original")
+	if edited < 0 {
+		t.Fatalf("Discord requests = %+v, want the suggestion message edited", discord.requests)
+	}
+	body := discord.requests[edited].body
+	if body["content"] != "✅ Tagged **Raid plan** with #raids" {
+		t.Errorf("content = %v", body["content"])
+	}
+	rows, _ := body["components"].([]any)
+	if len(rows) != 1 {
+		t.Fatalf("components = %v, want one row", body["components"])
+	}
+	buttons := rows[0].(map[string]any)["components"].([]any)
+	if len(buttons) != 1 || buttons[0].(map[string]any)["custom_id"] != "note_add_tag:n1:events" {
+		t.Errorf("remaining buttons = %v, want only #events", buttons)
+	}
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Discord timestamp styles, rendered in each viewer's own timezone and locale
+const (
+	timestampShortDateTime = "f" // June 20, 2021 4:20 PM
+	timestampLongDate      = "D" // June 20, 2021
+	timestampRelative      = "R" // 2 months ago
+)
+
+// discordTimestamp renders ts as Discord timestamp markdown (<t:unix:style>).
+// Sub-second precision is dropped; a nil timestamp renders as "".
+func discordTimestamp(ts *timestamppb.Timestamp, style string) string {
+	if ts == nil {
+		return ""
+	}
+	// Seconds is already floored: protobuf keeps Nanos in [0, 1e9)
+	return fmt.Sprintf("<t:%d:%s>", ts.Seconds, style)
+}
+
+// embedTimestamp formats ts for MessageEmbed.Timestamp, which Discord also shows in
+// the viewer's timezone. A nil timestamp leaves the embed without one.
+func embedTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format("2006-01-02T15:04:05Z07:00")
+}
+
+// createdUpdatedFields returns inline "Created" and, if the content was edited
+// later, "Updated" embed fields
+func createdUpdatedFields(created, updated *timestamppb.Timestamp) []*discordgo.MessageEmbedField {
+	var fields []*discordgo.MessageEmbedField
+	if created != nil {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "Created",
+			Value:  discordTimestamp(created, timestampShortDateTime),
+			Inline: true,
+		})
+	}
+	if updated != nil && (created == nil || updated.Seconds > created.Seconds) {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "Updated",
+			Value:  discordTimestamp(updated, timestampRelative),
+			Inline: true,
+		})
+	}
+	return fields
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDiscordTimestamp(t *testing.T) {
+	tests := []struct {
+		name  string
+		ts    *timestamppb.Timestamp
+		style string
+		want  string
+	}{
+		{name: "nil", ts: nil, style: timestampShortDateTime, want: ""},
+		{name: "whole seconds", ts: timestamppb.New(time.Unix(1700000000, 0)), style: timestampShortDateTime, want: "<t:1700000000:f>"},
+		{name: "sub-second is truncated", ts: timestamppb.New(time.Unix(1700000000, 999_999_999)), style: timestampLongDate, want: "<t:1700000000:D>"},
+		{name: "before the epoch floors", ts: timestamppb.New(time.Unix(-1, 500_000_000)), style: timestampRelative, want: "<t:-1:R>"},
+		{name: "far future", ts: timestamppb.New(time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)), style: timestampShortDateTime, want: "<t:253402300799:f>"},
+		{name: "non-UTC zone", ts: timestamppb.New(time.Date(2024, 1, 1, 9, 0, 0, 0, time.FixedZone("UTC+9", 9*3600))), style: timestampShortDateTime, want: "<t:1704067200:f>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := discordTimestamp(tt.ts, tt.style); got != tt.want {
+				t.Errorf("discordTimestamp() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatedUpdatedFields(t *testing.T) {
+	created := timestamppb.New(time.Unix(1700000000, 0))
+
+	fields := createdUpdatedFields(created, created)
+	if len(fields) != 1 || fields[0].Value != "<t:1700000000:f>" {
+		t.Errorf("unedited content: got %+v, want only Created", fields)
+	}
+
+	fields = createdUpdatedFields(created, timestamppb.New(time.Unix(1700003600, 0)))
+	if len(fields) != 2 || fields[1].Name != "Updated" || fields[1].Value != "<t:1700003600:R>" {
+		t.Errorf("edited content: got %+v, want Created and Updated", fields)
+	}
+
+	if fields := createdUpdatedFields(nil, nil); len(fields) != 0 {
+		t.Errorf("no timestamps: got %d fields, want 0", len(fields))
+	}
+}
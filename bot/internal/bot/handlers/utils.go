@@ -4,3 +4,32 @@ package handlers
 func ptrString(s string) *string {
 	return &s
 }
+
+// searchSortOrder maps a search command's "sort" choice to the order_by and
+// ascending fields of SearchNotesRequest/SearchQuotesRequest. Unknown or empty
+// choices return "" so the server picks its default.
+func searchSortOrder(choice string) (orderBy string, ascending bool) {
+	switch choice {
+	case "relevance":
+		return "relevance", false
+	case "newest":
+		return "created_at", false
+	case "oldest":
+		return "created_at", true
+	case "updated":
+		return "updated_at", false
+	}
+	return "", false
+}
+
+// wikiListSortOrder maps the /wiki list "sort" choice to the order_by and
+// ascending fields of ListWikiPagesRequest, defaulting to recently updated
+func wikiListSortOrder(choice string) (orderBy string, ascending bool) {
+	switch choice {
+	case "newest":
+		return "created_at", false
+	case "title":
+		return "title", true
+	}
+	return "updated_at", false
+}
@@ -5,14 +5,19 @@ import (
 	"fmt"
 	"log/slog"
 	"regexp"
+	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
 	"github.com/devilmonastery/hivemind/bot/internal/bot/announcements"
 	"github.com/devilmonastery/hivemind/bot/internal/config"
 	"github.com/devilmonastery/hivemind/internal/client"
+	"github.com/devilmonastery/hivemind/internal/pkg/msgtemplate"
 	"github.com/devilmonastery/hivemind/internal/pkg/urlutil"
 )
 
@@ -24,6 +29,15 @@ func getWebBaseURL(cfg *config.Config) string {
 	return "http://localhost:8080" // Default for development
 }
 
+// webLinkLabel returns the text for "View on Web" link buttons, using the configured web label
+func webLinkLabel(cfg *config.Config) string {
+	label := "Web"
+	if cfg != nil && cfg.Backend.WebLabel != "" {
+		label = cfg.Backend.WebLabel
+	}
+	return "🌐 View on " + label
+}
+
 // mustBuildWikiURL builds a wiki URL and returns a fallback on error (should never happen with valid baseURL)
 func mustBuildWikiURL(baseURL, guildID, slug string) string {
 	url, err := urlutil.BuildWikiViewURL(baseURL, guildID, slug)
@@ -56,8 +70,22 @@ func fetchWikiMessageReferences(ctx context.Context, wikiClient wikipb.WikiServi
 	return resp.References
 }
 
+// wikiPageEmoji picks the select-menu emoji for a page: pinned, tagged, or plain
+func wikiPageEmoji(page *wikipb.WikiPage) string {
+	switch {
+	case page.Status == wikipb.WikiPageStatus_WIKI_PAGE_STATUS_DRAFT:
+		return "📝"
+	case page.Pinned:
+		return "📌"
+	case len(page.Tags) > 0:
+		return "🏷️"
+	default:
+		return "📄"
+	}
+}
+
 // showWikiDetailEmbed creates the detailed embed and action buttons for a wiki page
-func showWikiDetailEmbed(s *discordgo.Session, page *wikipb.WikiPage, references []*wikipb.WikiMessageReference, cfg *config.Config, query string, showBackButton bool) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+func showWikiDetailEmbed(s *discordgo.Session, page *wikipb.WikiPage, references []*wikipb.WikiMessageReference, cfg *config.Config, color int, query string, showBackButton bool) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
 	// Get channel name
 	slog.Default().Debug("fetching channel for wiki page from Discord API",
 		"channel_id", page.ChannelId)
@@ -79,11 +107,19 @@ func showWikiDetailEmbed(s *discordgo.Session, page *wikipb.WikiPage, references
 		}
 	}
 
+	title := page.Title
+	if page.Pinned {
+		title = "📌 " + title
+	}
+	if page.Status == wikipb.WikiPageStatus_WIKI_PAGE_STATUS_DRAFT {
+		title = "📝 Draft: " + title
+	}
+
 	// Create detailed embed
 	embed := &discordgo.MessageEmbed{
-		Title:       page.Title,
+		Title:       title,
 		Description: page.Body,
-		Color:       0x00D9FF, // Cyan
+		Color:       color,
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Name:   "From",
@@ -101,28 +137,29 @@ func showWikiDetailEmbed(s *discordgo.Session, page *wikipb.WikiPage, references
 				Inline: false,
 			},
 		},
-		Timestamp: page.CreatedAt.AsTime().Format("2006-01-02T15:04:05Z"),
+	}
+	embed.Fields = append(embed.Fields, createdUpdatedFields(page.CreatedAt, page.UpdatedAt)...)
+	if page.LastEditorId != "" {
+		// Discord shows the timestamp after the footer text in the viewer's timezone
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: "Last edited by " + wikiLastEditorName(page) + " on"}
+		embed.Timestamp = embedTimestamp(page.UpdatedAt)
 	}
 
 	// Add message references field if any exist
 	if len(references) > 0 {
+		images := make([]referenceImage, len(references))
+		for idx, ref := range references {
+			images[idx] = referenceImage{sentAt: ref.MessageTimestamp, url: firstImageURL(ref.Attachments)}
+		}
+		setReferenceImage(embed, images)
+
 		// Build reference list with datetime and content preview
 		refsList := ""
-		displayCount := min(5, len(references)) // Show up to 5
+		displayCount := min(embedReferenceLimit, len(references))
 		for idx := 0; idx < displayCount; idx++ {
 			ref := references[idx]
 			messageLink := urlutil.DiscordMessageURL(ref.GuildId, ref.ChannelId, ref.MessageId)
-
-			// Format timestamp
-			timestamp := ref.MessageTimestamp.AsTime().Format("2006-01-02 15:04")
-
-			// Truncate content preview
-			contentPreview := ref.Content
-			if len(contentPreview) > 60 {
-				contentPreview = contentPreview[:57] + "..."
-			}
-
-			refsList += fmt.Sprintf("• [%s](%s) - %s\n  _%s_\n", ref.AuthorUsername, messageLink, timestamp, contentPreview)
+			refsList += referenceLine(ref.AuthorUsername, messageLink, ref.MessageTimestamp, ref.Content, stateMentionResolver(s, ref.GuildId))
 		}
 		if len(references) > displayCount {
 			refsList += fmt.Sprintf("_...and %d more_", len(references)-displayCount)
@@ -144,7 +181,7 @@ func showWikiDetailEmbed(s *discordgo.Session, page *wikipb.WikiPage, references
 	// Build action buttons
 	var components []discordgo.MessageComponent
 
-	// First row: Cancel and optionally Back
+	// First row: Cancel, Publish for drafts, and optionally Back
 	firstRow := []discordgo.MessageComponent{
 		discordgo.Button{
 			Label:    "❌ Cancel",
@@ -152,6 +189,13 @@ func showWikiDetailEmbed(s *discordgo.Session, page *wikipb.WikiPage, references
 			CustomID: fmt.Sprintf("wiki_action_btn:cancel:%s", page.Id),
 		},
 	}
+	if page.Status == wikipb.WikiPageStatus_WIKI_PAGE_STATUS_DRAFT {
+		firstRow = append(firstRow, discordgo.Button{
+			Label:    "🚀 Publish",
+			Style:    discordgo.SuccessButton,
+			CustomID: fmt.Sprintf("wiki_action_btn:publish:%s", page.Id),
+		})
+	}
 	if showBackButton {
 		firstRow = append(firstRow, discordgo.Button{
 			Label:    "◀ Back to Results",
@@ -159,11 +203,24 @@ func showWikiDetailEmbed(s *discordgo.Session, page *wikipb.WikiPage, references
 			CustomID: fmt.Sprintf("wiki_action_btn:back:%s:%s", page.Id, query),
 		})
 	}
+	if len(references) > embedReferenceLimit {
+		firstRow = append(firstRow, showAllReferencesButton("wiki_refs", page.Id))
+	}
 	components = append(components, discordgo.ActionsRow{
 		Components: firstRow,
 	})
 
-	// Second row: Add to Chat, Edit, View on Web
+	pinButton := discordgo.Button{
+		Label:    "📌 Pin",
+		Style:    discordgo.SecondaryButton,
+		CustomID: fmt.Sprintf("wiki_action_btn:pin:%s", page.Id),
+	}
+	if page.Pinned {
+		pinButton.Label = "📌 Unpin"
+		pinButton.CustomID = fmt.Sprintf("wiki_action_btn:unpin:%s", page.Id)
+	}
+
+	// Second row: Add to Chat, Edit, Pin/Unpin, Transfer Ownership, View on Web
 	components = append(components, discordgo.ActionsRow{
 		Components: []discordgo.MessageComponent{
 			discordgo.Button{
@@ -176,8 +233,14 @@ func showWikiDetailEmbed(s *discordgo.Session, page *wikipb.WikiPage, references
 				Style:    discordgo.PrimaryButton,
 				CustomID: fmt.Sprintf("wiki_action_btn:edit:%s:%s", page.Id, page.Title),
 			},
+			pinButton,
+			discordgo.Button{
+				Label:    "🔑 Transfer Ownership",
+				Style:    discordgo.SecondaryButton,
+				CustomID: fmt.Sprintf("wiki_action_btn:transfer:%s", page.Id),
+			},
 			discordgo.Button{
-				Label: "🌐 View on Web",
+				Label: webLinkLabel(cfg),
 				Style: discordgo.LinkButton,
 				URL:   mustBuildWikiURL(getWebBaseURL(cfg), page.GuildId, page.Slug),
 			},
@@ -221,7 +284,7 @@ func stripMarkdownAndNewlines(text string) string {
 }
 
 // handleWiki routes /wiki subcommands to the appropriate handler
-func handleWiki(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+func handleWiki(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
 	options := i.ApplicationCommandData().Options
 	if len(options) == 0 {
 		respondError(s, i, "No subcommand provided", log)
@@ -238,18 +301,32 @@ func handleWiki(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *confi
 	case "edit":
 		handleWikiEdit(s, i, subcommand, cfg, log, grpcClient)
 	case "merge":
-		handleWikiMerge(s, i, subcommand, cfg, log, grpcClient)
+		handleWikiMerge(s, i, subcommand, log, grpcClient)
+	case "list":
+		handleWikiList(s, i, subcommand, cfg, log, grpcClient)
+	case "whoedited":
+		handleWikiWhoEdited(s, i, subcommand, log, grpcClient)
+	case "drafts":
+		handleWikiDrafts(s, i, log, grpcClient)
+	case "export":
+		handleWikiExport(s, i, subcommand, log, grpcClient)
 	default:
 		respondError(s, i, "Unknown wiki subcommand", log)
 	}
 }
 
 func handleWikiSearch(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
-	// Parse query parameter
-	var query string
+	// Parse query and optional author and scope parameters
+	var query, authorID, scope string
 	for _, opt := range subcommand.Options {
-		if opt.Name == "query" {
+		switch opt.Name {
+		case "query":
 			query = opt.StringValue()
+		case "scope":
+			scope = opt.StringValue()
+		case "author":
+			// User options resolve the picked member (or a pasted mention) to its Discord ID
+			authorID = opt.UserValue(nil).ID
 		}
 	}
 
@@ -258,102 +335,103 @@ func handleWikiSearch(s *discordgo.Session, i *discordgo.InteractionCreate, subc
 		return
 	}
 
-	ctx := discordContextFor(i)
-
-	// Call backend to search wiki pages
-	wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
-	resp, err := wikiClient.SearchWikiPages(ctx, &wikipb.SearchWikiPagesRequest{
-		GuildId: i.GuildID,
-		Query:   query,
-		Limit:   5,
-	})
-	if err != nil {
-		log.Error("failed to search wiki pages",
-			slog.String("error", err.Error()),
-			slog.String("query", query))
-		respondError(s, i, fmt.Sprintf("Failed to search: %v", err), log)
-		return
-	}
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		ctx := discordContextFor(i)
 
-	// If only one result, show it directly
-	if len(resp.Pages) == 1 {
-		page := resp.Pages[0]
-		// Fetch message references
-		refs := fetchWikiMessageReferences(ctx, wikiClient, page.Id, log)
-		log.Info("wiki search single result - displaying with references",
-			slog.String("page_id", page.Id),
-			slog.String("page_title", page.Title),
-			slog.Int("ref_count", len(refs)))
-		embed, components := showWikiDetailEmbed(s, page, refs, cfg, query, false)
+		guildID, guildIDs, err := resolveSearchScope(ctx, i, scope, false, grpcClient, log)
+		if err != nil {
+			return nil, err
+		}
 
-		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Embeds:     []*discordgo.MessageEmbed{embed},
-				Components: components,
-				Flags:      discordgo.MessageFlagsEphemeral,
-			},
+		// Call backend to search wiki pages
+		wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+		resp, err := wikiClient.SearchWikiPages(ctx, &wikipb.SearchWikiPagesRequest{
+			GuildId:         guildID,
+			GuildIds:        guildIDs,
+			Query:           query,
+			AuthorDiscordId: authorID,
+			Limit:           5,
 		})
 		if err != nil {
-			log.Error("failed to respond to wiki search", slog.String("error", err.Error()))
+			return nil, userError("Failed to search wiki pages", err)
 		}
-		return
-	}
 
-	// Multiple results - build select menu
-	var options []discordgo.SelectMenuOption
-	for idx, page := range resp.Pages {
-		if idx >= 25 { // Discord limit for select menu options
-			break
+		if len(resp.Pages) == 0 {
+			return &discordgo.WebhookEdit{
+				Content: ptrString(guildMessage(guildMessageTemplates(i.GuildID, grpcClient, log), msgtemplate.NoResults,
+					fmt.Sprintf("🔍 No wiki pages found for: %s", wikiSearchDescription(query, authorID)),
+					map[string]string{"query": query, "kind": "wiki pages"})),
+			}, nil
 		}
 
-		// Create short excerpt for description (max 100 chars)
-		excerpt := stripMarkdownAndNewlines(page.Body)
-		if len(excerpt) > 97 {
-			excerpt = excerpt[:97] + "..."
+		// If only one result, show it directly
+		if len(resp.Pages) == 1 {
+			page := resp.Pages[0]
+			// Fetch message references
+			refs := fetchWikiMessageReferences(ctx, wikiClient, page.Id, log)
+			log.Info("wiki search single result - displaying with references",
+				slog.String("page_id", page.Id),
+				slog.String("page_title", page.Title),
+				slog.Int("ref_count", len(refs)))
+			embed, components := showWikiDetailEmbed(s, page, refs, cfg, guildEmbedColors(page.GuildId, grpcClient, log).Wiki, query, false)
+
+			return &discordgo.WebhookEdit{
+				Embeds:     &[]*discordgo.MessageEmbed{embed},
+				Components: &components,
+			}, nil
 		}
 
-		// Format emoji based on tags
-		emoji := "📄"
-		if len(page.Tags) > 0 {
-			emoji = "🏷️"
+		// Multiple results - build select menu
+		components := []discordgo.MessageComponent{
+			wikiPageSelectMenu(fmt.Sprintf("wiki_select:%s", query), fmt.Sprintf("Select from %d results...", len(resp.Pages)), resp.Pages),
+		}
+
+		return &discordgo.WebhookEdit{
+			Content:    ptrString(fmt.Sprintf("🔍 Found **%d** wiki pages for: %s", resp.Total, wikiSearchDescription(query, authorID))),
+			Components: &components,
+		}, nil
+	})
+}
+
+// wikiSearchDescription describes a wiki search for result messages, e.g. "**deploy** by <@123>"
+func wikiSearchDescription(query, authorID string) string {
+	desc := fmt.Sprintf("**%s**", query)
+	if authorID != "" {
+		desc += fmt.Sprintf(" by <@%s>", authorID)
+	}
+	return desc
+}
+
+// wikiPageSelectMenu builds a row with a select menu of wiki pages. Each option's
+// value is "wiki_result:<page ID>"; Discord allows at most 25 options.
+func wikiPageSelectMenu(customID, placeholder string, pages []*wikipb.WikiPage) discordgo.ActionsRow {
+	var options []discordgo.SelectMenuOption
+	for idx, page := range pages {
+		if idx >= 25 { // Discord limit for select menu options
+			break
 		}
 
 		options = append(options, discordgo.SelectMenuOption{
 			Label:       truncateString(page.Title, 100),
 			Value:       fmt.Sprintf("wiki_result:%s", page.Id),
-			Description: excerpt,
+			Description: wikiPageOptionDescription(page),
 			Emoji: &discordgo.ComponentEmoji{
-				Name: emoji,
+				Name: wikiPageEmoji(page),
 			},
 		})
 	}
 
-	components := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.SelectMenu{
-					CustomID:    fmt.Sprintf("wiki_select:%s", query),
-					Placeholder: fmt.Sprintf("Select from %d results...", len(resp.Pages)),
-					Options:     options,
-					MinValues:   intPtr(1),
-					MaxValues:   1,
-				},
+	return discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.SelectMenu{
+				CustomID:    customID,
+				Placeholder: placeholder,
+				Options:     options,
+				MinValues:   intPtr(1),
+				MaxValues:   1,
 			},
 		},
 	}
-
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content:    fmt.Sprintf("🔍 Found **%d** wiki pages for: **%s**", resp.Total, query),
-			Components: components,
-			Flags:      discordgo.MessageFlagsEphemeral,
-		},
-	})
-	if err != nil {
-		log.Error("failed to respond to wiki search", slog.String("error", err.Error()))
-	}
 }
 
 // intPtr returns a pointer to an int
@@ -366,7 +444,48 @@ func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
-	return s[:maxLen-3] + "..."
+	// Back up to a rune boundary so multi-byte characters aren't split
+	cut := maxLen - 3
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
+
+// searchExcerpt returns a plain-text excerpt for a search result select menu,
+// preferring the server-generated snippet over the start of the body
+func searchExcerpt(snippet, body string) string {
+	text := snippet
+	if text == "" {
+		text = body
+	}
+	return truncateString(stripMarkdownAndNewlines(text), 100)
+}
+
+// wikiPageOptionDescription describes a page in a select menu: the matching snippet for
+// search results, otherwise its size, so lists can leave the body out
+func wikiPageOptionDescription(page *wikipb.WikiPage) string {
+	if page.Snippet != "" {
+		return searchExcerpt(page.Snippet, page.Body)
+	}
+	return contentSizeSummary(page.BodyLength, page.ReferenceCount)
+}
+
+// contentSizeSummary describes a body length and message reference count,
+// e.g. "📄 1.2k chars · 3 refs"
+func contentSizeSummary(bodyLength, referenceCount int32) string {
+	size := fmt.Sprintf("%d chars", bodyLength)
+	if bodyLength >= 1000 {
+		size = fmt.Sprintf("%.1fk chars", float64(bodyLength)/1000)
+	}
+	switch referenceCount {
+	case 0:
+		return "📄 " + size
+	case 1:
+		return "📄 " + size + " · 1 ref"
+	default:
+		return fmt.Sprintf("📄 %s · %d refs", size, referenceCount)
+	}
 }
 
 func handleWikiGet(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
@@ -383,51 +502,107 @@ func handleWikiGet(s *discordgo.Session, i *discordgo.InteractionCreate, subcomm
 		return
 	}
 
-	ctx := discordContextFor(i)
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		ctx := discordContextFor(i)
 
-	// Lookup by slug (GetWikiPageByTitle normalizes input to slug for lookup)
-	wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
-	page, err := wikiClient.GetWikiPageByTitle(ctx, &wikipb.GetWikiPageByTitleRequest{
-		GuildId: i.GuildID,
-		Title:   slug, // Backend normalizes to slug for lookup
+		// Lookup by slug (GetWikiPageByTitle normalizes input to slug for lookup)
+		wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+		page, err := wikiClient.GetWikiPageByTitle(ctx, &wikipb.GetWikiPageByTitleRequest{
+			GuildId: i.GuildID,
+			Title:   slug, // Backend normalizes to slug for lookup
+		})
+		if err != nil {
+			return nil, userError(fmt.Sprintf("Wiki page not found: **%s**", slug), err)
+		}
+
+		// Fetch message references
+		refs := fetchWikiMessageReferences(ctx, wikiClient, page.Id, log)
+		log.Info("wiki view - displaying with references",
+			slog.String("page_id", page.Id),
+			slog.String("page_title", page.Title),
+			slog.Int("ref_count", len(refs)))
+
+		// Use the standard embed function to include references
+		embed, components := showWikiDetailEmbed(s, page, refs, cfg, guildEmbedColors(page.GuildId, grpcClient, log).Wiki, "", false)
+
+		return &discordgo.WebhookEdit{
+			Embeds:     &[]*discordgo.MessageEmbed{embed},
+			Components: &components,
+		}, nil
 	})
-	if err != nil {
-		log.Error("failed to get wiki page",
-			slog.String("error", err.Error()),
-			slog.String("slug", slug))
-		respondError(s, i, fmt.Sprintf("Wiki page not found: **%s**", slug), log)
-		return
+}
+
+// handleWikiWhoEdited shows who wrote a wiki page and who last edited it
+func handleWikiWhoEdited(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
+	var slug string
+	for _, opt := range subcommand.Options {
+		if opt.Name == "title" {
+			slug = opt.StringValue()
+		}
 	}
 
-	// Fetch message references
-	refs := fetchWikiMessageReferences(ctx, wikiClient, page.Id, log)
-	log.Info("wiki view - displaying with references",
-		slog.String("page_id", page.Id),
-		slog.String("page_title", page.Title),
-		slog.Int("ref_count", len(refs)))
+	if slug == "" {
+		respondError(s, i, "Title is required", log)
+		return
+	}
 
-	// Use the standard embed function to include references
-	embed, components := showWikiDetailEmbed(s, page, refs, cfg, "", false)
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+		page, err := wikiClient.GetWikiPageByTitle(discordContextFor(i), &wikipb.GetWikiPageByTitleRequest{
+			GuildId: i.GuildID,
+			Title:   slug,
+		})
+		if err != nil {
+			return nil, userError(fmt.Sprintf("Wiki page not found: **%s**", slug), err)
+		}
 
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds:     []*discordgo.MessageEmbed{embed},
-			Components: components,
-			Flags:      discordgo.MessageFlagsEphemeral,
-		},
+		content := wikiWhoEditedMessage(page)
+		return &discordgo.WebhookEdit{Content: &content}, nil
 	})
-	if err != nil {
-		log.Error("failed to respond to wiki get", slog.String("error", err.Error()))
+}
+
+// wikiWhoEditedMessage describes a page's author and last editor, with Discord
+// timestamps for when it was created and last edited. Pages last edited before
+// editors were recorded, or whose editor was deleted, have no editor; their edit
+// time is still shown.
+func wikiWhoEditedMessage(page *wikipb.WikiPage) string {
+	author := page.AuthorUsername
+	if author == "" {
+		author = "Unknown"
+	}
+
+	message := fmt.Sprintf("📖 **%s**\nWritten by **%s** on %s", page.Title, author, discordTimestamp(page.CreatedAt, timestampShortDateTime))
+	if page.LastEditorId == "" {
+		if page.GetUpdatedAt().AsTime().After(page.GetCreatedAt().AsTime()) {
+			return message + fmt.Sprintf("\nLast edited on %s (editor not recorded)", discordTimestamp(page.UpdatedAt, timestampShortDateTime))
+		}
+		return message + "\nNot edited since it was created"
+	}
+	return message + fmt.Sprintf("\nLast edited by **%s** on %s", wikiLastEditorName(page), discordTimestamp(page.UpdatedAt, timestampShortDateTime))
+}
+
+// wikiLastEditorName is the display name of the user who last edited a page
+func wikiLastEditorName(page *wikipb.WikiPage) string {
+	if page.LastEditorUsername == "" {
+		return "Unknown"
 	}
+	return page.LastEditorUsername
 }
 
 func handleWikiEdit(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
-	// Parse optional title parameter
+	if !requireWikiEditRole(s, i, log, grpcClient) {
+		return
+	}
+
+	// Parse optional title and draft parameters
 	var title string
+	var draft bool
 	for _, opt := range subcommand.Options {
-		if opt.Name == "title" {
+		switch opt.Name {
+		case "title":
 			title = opt.StringValue()
+		case "draft":
+			draft = opt.BoolValue()
 		}
 	}
 
@@ -459,6 +634,10 @@ func handleWikiEdit(s *discordgo.Session, i *discordgo.InteractionCreate, subcom
 		// Creating new page - include title field
 		modalCustomID = "wiki_edit_modal"
 		modalTitle = "Create Wiki Page"
+		if draft {
+			modalCustomID = "wiki_draft_modal"
+			modalTitle = "Create Wiki Draft"
+		}
 		components = append(components, discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				discordgo.TextInput{
@@ -505,7 +684,9 @@ func handleWikiEdit(s *discordgo.Session, i *discordgo.InteractionCreate, subcom
 	}
 }
 
-func handleWikiMerge(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+// handleWikiMerge previews merging one page into another; the merge itself only
+// happens once the user presses "Confirm merge" (see handleWikiMergeConfirm)
+func handleWikiMerge(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
 	// Parse source and target slug parameters
 	var sourceSlug, targetSlug string
 	for _, opt := range subcommand.Options {
@@ -573,33 +754,149 @@ func handleWikiMerge(s *discordgo.Session, i *discordgo.InteractionCreate, subco
 		return
 	}
 
-	// Perform merge
-	mergedPage, err := wikiClient.MergeWikiPages(ctx, &wikipb.MergeWikiPagesRequest{
+	// Compute the merged page without changing anything
+	preview, err := wikiClient.PreviewWikiMerge(ctx, &wikipb.PreviewWikiMergeRequest{
 		SourcePageId: sourceResp.Id,
 		TargetPageId: targetResp.Id,
 	})
 	if err != nil {
-		log.Error("failed to merge wiki pages",
+		log.Error("failed to preview wiki merge",
 			slog.String("source_id", sourceResp.Id),
 			slog.String("target_id", targetResp.Id),
 			slog.String("error", err.Error()))
 		_, _ = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-			Content: ptrString("❌ Failed to merge wiki pages"),
+			Content: ptrString("❌ Failed to preview wiki merge"),
+		})
+		return
+	}
+
+	embed := wikiMergePreviewEmbed(preview, guildEmbedColors(targetResp.GuildId, grpcClient, log).Wiki)
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Confirm merge",
+					Style:    discordgo.DangerButton,
+					CustomID: fmt.Sprintf("wiki_merge_confirm:%s:%s", sourceResp.Id, targetResp.Id),
+					Emoji:    &discordgo.ComponentEmoji{Name: "🔀"},
+				},
+				discordgo.Button{
+					Label:    "Cancel",
+					Style:    discordgo.SecondaryButton,
+					CustomID: "wiki_close",
+				},
+			},
+		},
+	}
+
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	})
+	if err != nil {
+		log.Error("failed to send merge preview", slog.String("error", err.Error()))
+	}
+}
+
+// wikiMergePreviewEmbed summarizes a merge preview: the combined body (truncated),
+// tags, and how many references and titles will end up on the merged page
+func wikiMergePreviewEmbed(preview *wikipb.PreviewWikiMergeResponse, color int) *discordgo.MessageEmbed {
+	tags := "_none_"
+	if len(preview.Page.Tags) > 0 {
+		sortedTags := append([]string(nil), preview.Page.Tags...)
+		sort.Strings(sortedTags)
+		tags = truncateString(strings.Join(sortedTags, ", "), 1024)
+	}
+
+	return &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("🔀 Merge **%s** into **%s**?", preview.SourcePage.Title, preview.Page.Title),
+		Description: "Nothing has been changed yet. The merged page will look like this:\n\n" +
+			truncateString(preview.Page.Body, 3500),
+		Color: color,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: fmt.Sprintf("Tags (%d)", len(preview.Page.Tags)), Value: tags},
+			{Name: "References", Value: fmt.Sprintf("%d", preview.ReferenceCount), Inline: true},
+			{Name: "Titles", Value: fmt.Sprintf("%d", preview.TitleCount), Inline: true},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("%s will redirect to %s after the merge", preview.SourcePage.Title, preview.Page.Title),
+		},
+	}
+}
+
+// handleWikiMergeConfirm performs a merge previewed by handleWikiMerge.
+// remainder is "<sourcePageID>:<targetPageID>".
+func handleWikiMergeConfirm(s *discordgo.Session, i *discordgo.InteractionCreate, remainder string, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
+	sourcePageID, targetPageID, ok := strings.Cut(remainder, ":")
+	if !ok || sourcePageID == "" || targetPageID == "" {
+		log.Warn("invalid wiki merge confirm custom_id", slog.String("remainder", remainder))
+		return
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	if err != nil {
+		log.Error("failed to defer interaction", slog.String("error", err.Error()))
+		return
+	}
+
+	ctx := discordContextFor(i)
+	wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+
+	// The source's title is only needed for the success message
+	sourceTitle := sourcePageID
+	if source, err := wikiClient.GetWikiPage(ctx, &wikipb.GetWikiPageRequest{Id: sourcePageID}); err == nil {
+		sourceTitle = source.Title
+	}
+
+	// Perform merge
+	mergedPage, err := wikiClient.MergeWikiPages(ctx, &wikipb.MergeWikiPagesRequest{
+		SourcePageId: sourcePageID,
+		TargetPageId: targetPageID,
+	})
+	if err != nil {
+		log.Error("failed to merge wiki pages",
+			slog.String("source_id", sourcePageID),
+			slog.String("target_id", targetPageID),
+			slog.String("error", err.Error()))
+		message := "❌ Failed to merge wiki pages"
+		if status.Code(err) == codes.NotFound {
+			message = "❌ One of these pages no longer exists - it may already have been merged"
+		}
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: message,
+			Flags:   discordgo.MessageFlagsEphemeral,
 		})
 		return
 	}
+	cache.InvalidateWikiTitles(mergedPage.GuildId)
 
 	// Fetch message references for the merged page
 	refs := fetchWikiMessageReferences(ctx, wikiClient, mergedPage.Id, log)
 
 	// Show standard wiki embed with success header
-	embed, components := showWikiDetailEmbed(s, mergedPage, refs, cfg, "", false)
+	embed, components := showWikiDetailEmbed(s, mergedPage, refs, cfg, guildEmbedColors(mergedPage.GuildId, grpcClient, log).Wiki, "", false)
 	embed.Title = fmt.Sprintf("✅ Successfully merged **%s** into **%s**\n\n%s",
-		sourceResp.Title,
+		sourceTitle,
 		mergedPage.Title,
 		embed.Title)
 
-	// Send success response with embed and buttons
+	// Offer to undo the merge; the backend only allows the merging user or an admin to use it
+	if len(components) < 5 {
+		components = append(components, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Undo merge",
+					Style:    discordgo.DangerButton,
+					CustomID: "wiki_unmerge:" + sourcePageID,
+					Emoji:    &discordgo.ComponentEmoji{Name: "↩️"},
+				},
+			},
+		})
+	}
+
+	// Replace the preview with the merged page and buttons
 	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 		Embeds:     &[]*discordgo.MessageEmbed{embed},
 		Components: &components,
@@ -609,17 +906,82 @@ func handleWikiMerge(s *discordgo.Session, i *discordgo.InteractionCreate, subco
 	}
 }
 
+// handleWikiUnmerge handles the "Undo merge" button on a merge confirmation
+func handleWikiUnmerge(s *discordgo.Session, i *discordgo.InteractionCreate, sourcePageID string, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	if err != nil {
+		log.Error("failed to defer interaction", slog.String("error", err.Error()))
+		return
+	}
+
+	ctx := discordContextFor(i)
+	wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+
+	resp, err := wikiClient.UnmergeWikiPages(ctx, &wikipb.UnmergeWikiPagesRequest{
+		SourcePageId: sourcePageID,
+	})
+	if err != nil {
+		log.Error("failed to undo wiki merge",
+			slog.String("source_id", sourcePageID),
+			slog.String("error", err.Error()))
+
+		message := "❌ Failed to undo merge"
+		switch status.Code(err) {
+		case codes.PermissionDenied:
+			message = "❌ Only the user who performed the merge or an admin can undo it"
+		case codes.NotFound:
+			message = "❌ This merge has already been undone"
+		case codes.FailedPrecondition:
+			message = fmt.Sprintf("❌ Can't undo this merge: %s", status.Convert(err).Message())
+		}
+		// Follow up privately so the confirmation stays intact for the merging user
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: message,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+	cache.InvalidateWikiTitles(resp.SourcePage.GuildId)
+
+	refs := fetchWikiMessageReferences(ctx, wikiClient, resp.SourcePage.Id, log)
+	embed, components := showWikiDetailEmbed(s, resp.SourcePage, refs, cfg, guildEmbedColors(resp.SourcePage.GuildId, grpcClient, log).Wiki, "", false)
+	embed.Title = fmt.Sprintf("↩️ Restored **%s** (split back out of **%s**)\n\n%s",
+		resp.SourcePage.Title,
+		resp.TargetPage.Title,
+		embed.Title)
+
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	})
+	if err != nil {
+		log.Error("failed to send unmerge confirmation", slog.String("error", err.Error()))
+	}
+
+	log.Info("wiki merge undone",
+		slog.String("source_id", resp.SourcePage.Id),
+		slog.String("target_id", resp.TargetPage.Id),
+		slog.String("user_id", interactionUser(i).ID))
+}
+
 // handleWikiEditModal processes the modal submission for wiki page creation/editing
-func handleWikiEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+func handleWikiEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
 	data := i.ModalSubmitData()
 
 	// Check if this is an edit (custom ID format: wiki_edit_modal:OriginalTitle)
+	// or a new draft (wiki_draft_modal)
 	var originalTitle string
 	parts := strings.SplitN(data.CustomID, ":", 2)
 	if len(parts) == 2 {
 		// Editing existing page - use original title
 		originalTitle = parts[1]
 	}
+	pageStatus := wikipb.WikiPageStatus_WIKI_PAGE_STATUS_PUBLISHED
+	if parts[0] == "wiki_draft_modal" {
+		pageStatus = wikipb.WikiPageStatus_WIKI_PAGE_STATUS_DRAFT
+	}
 
 	var title, body string
 	for _, comp := range data.Components {
@@ -672,6 +1034,7 @@ func handleWikiEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, c
 		Tags:      tags,
 		GuildId:   i.GuildID,
 		ChannelId: i.ChannelID,
+		Status:    pageStatus,
 	})
 	if err != nil {
 		log.Error("failed to upsert wiki page",
@@ -680,6 +1043,7 @@ func handleWikiEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, c
 		respondError(s, i, fmt.Sprintf("Failed to save wiki page: %v", err), log)
 		return
 	}
+	cache.InvalidateWikiTitles(i.GuildID)
 
 	// Success response
 	actionVerb := "created"
@@ -687,6 +1051,9 @@ func handleWikiEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, c
 		actionVerb = "updated"
 	}
 	content := fmt.Sprintf("✅ Wiki page %s: **%s**", actionVerb, resp.Page.Title)
+	if resp.Page.Status == wikipb.WikiPageStatus_WIKI_PAGE_STATUS_DRAFT {
+		content = fmt.Sprintf("📝 Draft %s: **%s**\nOnly you can find it until it's published. Use `/wiki drafts` to review and publish it.", actionVerb, resp.Page.Title)
+	}
 
 	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -699,8 +1066,18 @@ func handleWikiEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, c
 		log.Error("failed to respond to wiki create", slog.String("error", err.Error()))
 	}
 
-	// Post announcement if this was a new wiki page (not an edit)
-	if resp.Created && i.Member != nil && i.Member.User != nil {
+	if resp.Created && i.GuildID != "" {
+		sendTagSuggestions(s, i, "wiki_add_tag", resp.Page.Id, &wikipb.SuggestTagsRequest{
+			GuildId:       resp.Page.GuildId,
+			Body:          resp.Page.Body,
+			ExcludeTags:   resp.Page.Tags,
+			ExcludePageId: resp.Page.Id,
+		}, log, grpcClient)
+	}
+
+	// Post announcement if this was a new wiki page (not an edit); drafts are
+	// announced when they're published
+	if resp.Created && resp.Page.Status != wikipb.WikiPageStatus_WIKI_PAGE_STATUS_DRAFT && i.Member != nil && i.Member.User != nil {
 		// Get guild nickname for the author
 		authorName := i.Member.User.Username
 		if i.Member.Nick != "" {
@@ -722,6 +1099,10 @@ func handleWikiEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, c
 
 // handleWikiEditButton handles the wiki edit button click
 func handleWikiEditButton(s *discordgo.Session, i *discordgo.InteractionCreate, title string, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+	if !requireWikiEditRole(s, i, log, grpcClient) {
+		return
+	}
+
 	// Fetch existing content
 	ctx := discordContextFor(i)
 	wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
@@ -795,7 +1176,7 @@ func handleWikiAddToChat(s *discordgo.Session, i *discordgo.InteractionCreate, t
 		Footer: &discordgo.MessageEmbedFooter{
 			Text: fmt.Sprintf("Created by %s", page.AuthorUsername),
 		},
-		Timestamp: page.CreatedAt.AsTime().Format("2006-01-02T15:04:05Z"),
+		Timestamp: embedTimestamp(page.CreatedAt),
 	}
 
 	if len(page.Tags) > 0 {
@@ -890,7 +1271,7 @@ func handleWikiSelectMenu(s *discordgo.Session, i *discordgo.InteractionCreate,
 	refs := fetchWikiMessageReferences(ctx, wikiClient, selectedPage.Id, log)
 
 	// Create detailed embed and components
-	embed, components := showWikiDetailEmbed(s, selectedPage, refs, cfg, query, true)
+	embed, components := showWikiDetailEmbed(s, selectedPage, refs, cfg, guildEmbedColors(selectedPage.GuildId, grpcClient, log).Wiki, query, true)
 
 	// Update the message with the detailed view
 	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -907,7 +1288,7 @@ func handleWikiSelectMenu(s *discordgo.Session, i *discordgo.InteractionCreate,
 }
 
 // handleWikiActionButton handles action buttons on wiki detail view
-func handleWikiActionButton(s *discordgo.Session, i *discordgo.InteractionCreate, customID string, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+func handleWikiActionButton(s *discordgo.Session, i *discordgo.InteractionCreate, customID string, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
 	// Parse customID: wiki_action_btn:action:params...
 	parts := splitCustomID(customID, "wiki_action_btn:")
 	if len(parts) < 1 {
@@ -953,44 +1334,8 @@ func handleWikiActionButton(s *discordgo.Session, i *discordgo.InteractionCreate
 		}
 
 		// Rebuild select menu
-		var options []discordgo.SelectMenuOption
-		for idx, page := range resp.Pages {
-			if idx >= 25 {
-				break
-			}
-
-			excerpt := stripMarkdownAndNewlines(page.Body)
-			if len(excerpt) > 97 {
-				excerpt = excerpt[:97] + "..."
-			}
-
-			emoji := "📄"
-			if len(page.Tags) > 0 {
-				emoji = "🏷️"
-			}
-
-			options = append(options, discordgo.SelectMenuOption{
-				Label:       truncateString(page.Title, 100),
-				Value:       fmt.Sprintf("wiki_result:%s", page.Id),
-				Description: excerpt,
-				Emoji: &discordgo.ComponentEmoji{
-					Name: emoji,
-				},
-			})
-		}
-
 		components := []discordgo.MessageComponent{
-			discordgo.ActionsRow{
-				Components: []discordgo.MessageComponent{
-					discordgo.SelectMenu{
-						CustomID:    fmt.Sprintf("wiki_select:%s", query),
-						Placeholder: fmt.Sprintf("Select from %d results...", len(resp.Pages)),
-						Options:     options,
-						MinValues:   intPtr(1),
-						MaxValues:   1,
-					},
-				},
-			},
+			wikiPageSelectMenu(fmt.Sprintf("wiki_select:%s", query), fmt.Sprintf("Select from %d results...", len(resp.Pages)), resp.Pages),
 		}
 
 		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -1013,6 +1358,21 @@ func handleWikiActionButton(s *discordgo.Session, i *discordgo.InteractionCreate
 		}
 		wikiID := parts[1]
 
+		// Fetching the page, its references, and the guild's settings are backend
+		// calls, so acknowledge the click before making them
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredMessageUpdate,
+		})
+		if err != nil {
+			log.Error("failed to defer interaction", slog.String("error", err.Error()))
+			return
+		}
+		postFailed := func(message string) {
+			if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: ptrString(message)}); err != nil {
+				log.Error("failed to update message", slog.String("error", err.Error()))
+			}
+		}
+
 		// Fetch the wiki page again
 		ctx := discordContextFor(i)
 		wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
@@ -1023,6 +1383,7 @@ func handleWikiActionButton(s *discordgo.Session, i *discordgo.InteractionCreate
 		})
 		if err != nil {
 			log.Error("failed to fetch wiki page", slog.String("error", err.Error()))
+			postFailed("❌ Failed to load wiki page")
 			return
 		}
 
@@ -1035,6 +1396,7 @@ func handleWikiActionButton(s *discordgo.Session, i *discordgo.InteractionCreate
 		}
 
 		if page == nil {
+			postFailed("❌ Wiki page not found")
 			return
 		}
 
@@ -1042,25 +1404,31 @@ func handleWikiActionButton(s *discordgo.Session, i *discordgo.InteractionCreate
 		refs := fetchWikiMessageReferences(ctx, wikiClient, page.Id, log)
 
 		// Create embed for posting (reuse the embed function, discard components)
-		embed, _ := showWikiDetailEmbed(s, page, refs, cfg, "", false) // Send as new message in channel
+		embed, _ := showWikiDetailEmbed(s, page, refs, cfg, guildEmbedColors(page.GuildId, grpcClient, log).Wiki, "", false) // Send as new message in channel
 		log.Debug("sending wiki page embed to Discord",
 			"channel_id", i.ChannelID,
 			"page_id", page.Id)
-		_, err = s.ChannelMessageSendEmbed(i.ChannelID, embed)
+		// Reply to the newest message in this channel that was added to the page
+		var reply *discordgo.MessageReference
+		for _, ref := range refs {
+			if ref.ChannelId == i.ChannelID {
+				reply = sourceReply(guildRepliesToSource(page.GuildId, grpcClient, log), page.GuildId, i.ChannelID, ref.ChannelId, ref.MessageId)
+				break
+			}
+		}
+		embeds := []*discordgo.MessageEmbed{embed}
+		err = sendToChannel(s, channelAction("wiki_post", i, embeds), &discordgo.MessageSend{Embeds: embeds}, reply, grpcClient, log)
 		if err != nil {
 			log.Error("failed to post wiki to channel", slog.String("error", err.Error()))
+			postFailed("❌ Failed to post to chat. Please try again.")
 			return
 		}
 
 		// Update original ephemeral message
-		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseUpdateMessage,
-			Data: &discordgo.InteractionResponseData{
-				Content:    "✅ Wiki page posted to channel!",
-				Embeds:     []*discordgo.MessageEmbed{},
-				Components: []discordgo.MessageComponent{},
-				Flags:      discordgo.MessageFlagsEphemeral,
-			},
+		_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content:    ptrString("✅ Wiki page posted to channel!"),
+			Embeds:     &[]*discordgo.MessageEmbed{},
+			Components: &[]discordgo.MessageComponent{},
 		})
 		if err != nil {
 			log.Error("failed to update message", slog.String("error", err.Error()))
@@ -1073,6 +1441,219 @@ func handleWikiActionButton(s *discordgo.Session, i *discordgo.InteractionCreate
 		}
 		title := parts[2]
 		handleWikiEditButton(s, i, title, cfg, log, grpcClient)
+
+	case "pin", "unpin":
+		if len(parts) < 2 {
+			return
+		}
+		handleWikiPinButton(s, i, parts[1], action == "pin", cfg, log, grpcClient)
+
+	case "transfer":
+		if len(parts) < 2 {
+			return
+		}
+		handleWikiTransferButton(s, i, parts[1], log)
+
+	case "publish":
+		if len(parts) < 2 {
+			return
+		}
+		handleWikiPublishButton(s, i, parts[1], cfg, log, grpcClient, cache)
+	}
+}
+
+// handleWikiPublishButton publishes a draft, refreshes its detail view in place and
+// announces the page as new
+func handleWikiPublishButton(s *discordgo.Session, i *discordgo.InteractionCreate, pageID string, cfg *config.Config, log *slog.Logger, grpcClient *client.Client, cache *TitlesCache) {
+	// Publishing, fetching references, and the guild's colors are three backend calls,
+	// so acknowledge the click before making them
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	if err != nil {
+		log.Error("failed to defer interaction", slog.String("error", err.Error()))
+		return
+	}
+
+	ctx := discordContextFor(i)
+	wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+
+	page, err := wikiClient.PublishWikiPage(ctx, &wikipb.PublishWikiPageRequest{Id: pageID})
+	if err != nil {
+		log.Error("failed to publish wiki page",
+			slog.String("page_id", pageID),
+			slog.String("error", err.Error()))
+		message := "❌ Failed to publish wiki page"
+		if status.Code(err) == codes.PermissionDenied {
+			message = "❌ Only the page author or an admin can publish it"
+		}
+		_, _ = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: message,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return
+	}
+	cache.InvalidateWikiTitles(page.GuildId)
+
+	refs := fetchWikiMessageReferences(ctx, wikiClient, page.Id, log)
+	embed, components := showWikiDetailEmbed(s, page, refs, cfg, guildEmbedColors(page.GuildId, grpcClient, log).Wiki, "", false)
+
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content:    ptrString("✅ Wiki page published"),
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	})
+	if err != nil {
+		log.Error("failed to refresh wiki page after publish", slog.String("error", err.Error()))
+	}
+
+	announcements.PostWikiCreated(
+		s,
+		grpcClient,
+		page.GuildId,
+		page.Title,
+		page.AuthorUsername,
+		page.Slug,
+		cfg.Backend.WebBaseURL,
+		log,
+	)
+
+	log.Info("wiki page published",
+		slog.String("page_id", page.Id),
+		slog.String("user_id", interactionUser(i).ID))
+}
+
+// handleWikiPinButton pins or unpins a page and refreshes its detail view in place
+func handleWikiPinButton(s *discordgo.Session, i *discordgo.InteractionCreate, pageID string, pinned bool, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+	ctx := discordContextFor(i)
+	wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+
+	page, err := wikiClient.SetWikiPagePinned(ctx, &wikipb.SetWikiPagePinnedRequest{
+		Id:     pageID,
+		Pinned: pinned,
+	})
+	if err != nil {
+		log.Error("failed to set wiki page pinned",
+			slog.String("page_id", pageID),
+			slog.Bool("pinned", pinned),
+			slog.String("error", err.Error()))
+		if status.Code(err) == codes.PermissionDenied {
+			respondError(s, i, "Only the page author or an admin can pin or unpin it", log)
+			return
+		}
+		respondError(s, i, "Failed to update pin", log)
+		return
+	}
+
+	refs := fetchWikiMessageReferences(ctx, wikiClient, page.Id, log)
+	embed, components := showWikiDetailEmbed(s, page, refs, cfg, guildEmbedColors(page.GuildId, grpcClient, log).Wiki, "", false)
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("failed to refresh wiki page after pin", slog.String("error", err.Error()))
+	}
+
+	log.Info("wiki page pin updated",
+		slog.String("page_id", page.Id),
+		slog.Bool("pinned", page.Pinned),
+		slog.String("user_id", interactionUser(i).ID))
+}
+
+// handleWikiTransferButton asks who should become the page's new owner. Anyone can
+// open the picker; the backend only lets the page author or an admin complete it.
+func handleWikiTransferButton(s *discordgo.Session, i *discordgo.InteractionCreate, pageID string, log *slog.Logger) {
+	minValues := 1
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: "🔑 Who should own this page? Only the page author or an admin can transfer it.",
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.SelectMenu{
+							MenuType:    discordgo.UserSelectMenu,
+							CustomID:    "wiki_transfer_select:" + pageID,
+							Placeholder: "Choose the new owner...",
+							MinValues:   &minValues,
+							MaxValues:   1,
+						},
+					},
+				},
+			},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("failed to show wiki transfer picker", slog.String("error", err.Error()))
+	}
+}
+
+// handleWikiTransferSelect transfers a page to the user picked in the transfer picker
+func handleWikiTransferSelect(s *discordgo.Session, i *discordgo.InteractionCreate, pageID string, log *slog.Logger, grpcClient *client.Client) {
+	data := i.MessageComponentData()
+	if len(data.Values) == 0 {
+		return
+	}
+	newOwnerID := data.Values[0]
+
+	content := ""
+	if user, ok := data.Resolved.Users[newOwnerID]; ok && user.Bot {
+		content = "❌ Bots can't own wiki pages"
+	} else {
+		ctx := discordContextFor(i)
+		wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+
+		page, err := wikiClient.TransferWikiPageOwnership(ctx, &wikipb.TransferWikiPageOwnershipRequest{
+			Id:                pageID,
+			NewOwnerDiscordId: newOwnerID,
+		})
+		if err != nil {
+			log.Error("failed to transfer wiki page ownership",
+				slog.String("page_id", pageID),
+				slog.String("new_owner_discord_id", newOwnerID),
+				slog.String("error", err.Error()))
+			content = wikiTransferErrorMessage(err, newOwnerID)
+		} else {
+			content = fmt.Sprintf("✅ <@%s> now owns **%s**", newOwnerID, page.Title)
+			log.Info("wiki page ownership transferred",
+				slog.String("page_id", page.Id),
+				slog.String("new_owner_discord_id", newOwnerID),
+				slog.String("user_id", interactionUser(i).ID))
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:         content,
+			Components:      []discordgo.MessageComponent{},
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+			Flags:           discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("failed to update wiki transfer picker", slog.String("error", err.Error()))
+	}
+}
+
+// wikiTransferErrorMessage explains why a page couldn't be transferred to newOwnerID
+func wikiTransferErrorMessage(err error, newOwnerID string) string {
+	switch status.Code(err) {
+	case codes.PermissionDenied:
+		return "❌ Only the page author or an admin can transfer ownership"
+	case codes.NotFound:
+		return "❌ Wiki page not found"
+	case codes.FailedPrecondition:
+		return fmt.Sprintf("❌ <@%s> can't own this page: %s", newOwnerID, status.Convert(err).Message())
+	default:
+		return "❌ Failed to transfer ownership"
 	}
 }
 
@@ -1223,31 +1804,39 @@ func handleWikiAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate
 	// Check local cache first
 	cachedTitles := cache.GetWikiTitles(i.GuildID)
 
-	// If cache miss, fetch from server and populate cache
+	// If cache miss, fetch from server and populate cache, falling back to expired
+	// titles if the server is slow
 	if cachedTitles == nil {
 		wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
-		ctx := discordContextFor(i)
+		fetch := func(ctx context.Context) ([]TitleSuggestion, error) {
+			autocompleteResp, err := wikiClient.AutocompleteWikiTitles(ctx, &wikipb.AutocompleteWikiTitlesRequest{
+				GuildId: i.GuildID,
+			})
+			if err != nil {
+				return nil, err
+			}
 
-		autocompleteResp, err := wikiClient.AutocompleteWikiTitles(ctx, &wikipb.AutocompleteWikiTitlesRequest{
-			GuildId: i.GuildID,
-		})
+			// Convert to cache format
+			titles := make([]TitleSuggestion, len(autocompleteResp.Suggestions))
+			for idx, suggestion := range autocompleteResp.Suggestions {
+				titles[idx] = TitleSuggestion{
+					ID:    suggestion.Id,
+					Title: suggestion.Title,
+					Slug:  suggestion.Slug,
+				}
+			}
+			return titles, nil
+		}
+		store := func(titles []TitleSuggestion) {
+			cache.SetWikiTitles(i.GuildID, titles)
+		}
+
+		var err error
+		cachedTitles, err = cache.fetchTitles(discordContextFor(i), wikiTitlesKey(i.GuildID), cache.GetStaleWikiTitles(i.GuildID), fetch, store, log)
 		if err != nil {
 			log.Error("Failed to fetch wiki titles for cache", "error", err)
 			return
 		}
-
-		// Convert to cache format
-		cachedTitles = make([]TitleSuggestion, len(autocompleteResp.Suggestions))
-		for idx, suggestion := range autocompleteResp.Suggestions {
-			cachedTitles[idx] = TitleSuggestion{
-				ID:    suggestion.Id,
-				Title: suggestion.Title,
-				Slug:  suggestion.Slug,
-			}
-		}
-
-		// Store in cache
-		cache.SetWikiTitles(i.GuildID, cachedTitles)
 	}
 
 	// Filter titles locally
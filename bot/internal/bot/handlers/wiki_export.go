@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/internal/client"
+	"github.com/devilmonastery/hivemind/internal/pkg/urlutil"
+)
+
+// wikiExportFrontmatter is the YAML header at the top of an exported wiki page
+type wikiExportFrontmatter struct {
+	Title      string                `yaml:"title"`
+	Tags       []string              `yaml:"tags,omitempty"`
+	Author     string                `yaml:"author,omitempty"`
+	Created    string                `yaml:"created,omitempty"`
+	Updated    string                `yaml:"updated,omitempty"`
+	References []wikiExportReference `yaml:"references,omitempty"`
+}
+
+// wikiExportReference is a referenced Discord message listed in the frontmatter
+type wikiExportReference struct {
+	Author string `yaml:"author,omitempty"`
+	Sent   string `yaml:"sent,omitempty"`
+	URL    string `yaml:"url"`
+}
+
+// handleWikiExport sends a wiki page's markdown, with a frontmatter header, as a
+// .md attachment. A file is used whatever the page's size so long pages aren't cut
+// at Discord's message length limit.
+func handleWikiExport(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, log *slog.Logger, grpcClient *client.Client) {
+	var slug string
+	for _, opt := range subcommand.Options {
+		if opt.Name == "title" {
+			slug = opt.StringValue()
+		}
+	}
+
+	if slug == "" {
+		respondError(s, i, "Title is required", log)
+		return
+	}
+
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		ctx := discordContextFor(i)
+		wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+		page, err := wikiClient.GetWikiPageByTitle(ctx, &wikipb.GetWikiPageByTitleRequest{
+			GuildId: i.GuildID,
+			Title:   slug,
+		})
+		if err != nil {
+			return nil, userError(fmt.Sprintf("Wiki page not found: **%s**", slug), err)
+		}
+
+		references := fetchWikiMessageReferences(ctx, wikiClient, page.Id, log)
+		markdown, err := wikiPageMarkdown(page, references)
+		if err != nil {
+			return nil, err
+		}
+
+		content := fmt.Sprintf("📄 Markdown export of **%s**", page.Title)
+		return &discordgo.WebhookEdit{
+			Content: &content,
+			Files: []*discordgo.File{{
+				Name:        wikiExportFilename(page),
+				ContentType: "text/markdown",
+				Reader:      strings.NewReader(markdown),
+			}},
+		}, nil
+	})
+}
+
+// wikiPageMarkdown renders a page as markdown with a YAML frontmatter header
+// holding its title, tags, author, dates and referenced messages
+func wikiPageMarkdown(page *wikipb.WikiPage, references []*wikipb.WikiMessageReference) (string, error) {
+	frontmatter := wikiExportFrontmatter{
+		Title:   page.Title,
+		Tags:    page.Tags,
+		Author:  page.AuthorUsername,
+		Created: exportTimestamp(page.CreatedAt),
+		Updated: exportTimestamp(page.UpdatedAt),
+	}
+	for _, ref := range references {
+		frontmatter.References = append(frontmatter.References, wikiExportReference{
+			Author: ref.AuthorUsername,
+			Sent:   exportTimestamp(ref.MessageTimestamp),
+			URL:    urlutil.DiscordMessageURL(ref.GuildId, ref.ChannelId, ref.MessageId),
+		})
+	}
+
+	header, err := yaml.Marshal(frontmatter)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode frontmatter: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.Write(header)
+	b.WriteString("---\n\n")
+	b.WriteString(strings.TrimRight(page.Body, "\n"))
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+// exportTimestamp formats a timestamp for the frontmatter, or "" when it's unset
+func exportTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339)
+}
+
+// wikiExportFilename names the exported file after the page's slug
+func wikiExportFilename(page *wikipb.WikiPage) string {
+	if page.Slug == "" {
+		return "wiki-page.md"
+	}
+	return page.Slug + ".md"
+}
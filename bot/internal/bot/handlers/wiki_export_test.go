@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+)
+
+func TestWikiPageMarkdown(t *testing.T) {
+	page := &wikipb.WikiPage{
+		Title:          "House Rules: Part 1",
+		Body:           "# Rules\n\nBe nice.\n",
+		AuthorUsername: "alice",
+		Tags:           []string{"rules", "meta"},
+		CreatedAt:      timestamppb.New(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)),
+		UpdatedAt:      timestamppb.New(time.Date(2024, 3, 2, 8, 30, 0, 0, time.UTC)),
+	}
+	references := []*wikipb.WikiMessageReference{{
+		GuildId:          "g1",
+		ChannelId:        "c1",
+		MessageId:        "m1",
+		AuthorUsername:   "bob",
+		MessageTimestamp: timestamppb.New(time.Date(2024, 2, 28, 9, 0, 0, 0, time.UTC)),
+	}}
+
+	got, err := wikiPageMarkdown(page, references)
+	if err != nil {
+		t.Fatalf("wikiPageMarkdown() error = %v", err)
+	}
+
+	want := `---
+title: 'House Rules: Part 1'
+tags:
+    - rules
+    - meta
+author: alice
+created: "2024-03-01T12:00:00Z"
+updated: "2024-03-02T08:30:00Z"
+references:
+    - author: bob
+      sent: "2024-02-28T09:00:00Z"
+      url: https://discord.com/channels/g1/c1/m1
+---
+
+# Rules
+
+Be nice.
+`
+	if got != want {
+		t.Errorf("wikiPageMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWikiPageMarkdown_NoTagsOrReferences(t *testing.T) {
+	got, err := wikiPageMarkdown(&wikipb.WikiPage{Title: "Empty", Body: "Nothing yet"}, nil)
+	if err != nil {
+		t.Fatalf("wikiPageMarkdown() error = %v", err)
+	}
+
+	want := "---\ntitle: Empty\n---\n\nNothing yet\n"
+	if got != want {
+		t.Errorf("wikiPageMarkdown() = %q, want %q", got, want)
+	}
+}
+
+func TestWikiExportFilename(t *testing.T) {
+	if got := wikiExportFilename(&wikipb.WikiPage{Slug: "house-rules"}); got != "house-rules.md" {
+		t.Errorf("wikiExportFilename() = %q, want house-rules.md", got)
+	}
+	if got := wikiExportFilename(&wikipb.WikiPage{}); got != "wiki-page.md" {
+		t.Errorf("wikiExportFilename() without a slug = %q, want wiki-page.md", got)
+	}
+}
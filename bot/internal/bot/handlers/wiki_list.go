@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/bot/internal/config"
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// wikiListPageSize is how many wiki pages each page of /wiki list shows
+const wikiListPageSize = 10
+
+// wikiListSortLabels describes each /wiki list sort choice in the header
+var wikiListSortLabels = map[string]string{
+	"updated": "recently updated first",
+	"newest":  "newest first",
+	"title":   "by title",
+}
+
+// handleWikiList shows the first page of the guild's wiki pages
+func handleWikiList(s *discordgo.Session, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+	if i.GuildID == "" {
+		respondError(s, i, "Wiki pages can only be listed in a server", log)
+		return
+	}
+
+	sort := "updated"
+	for _, opt := range subcommand.Options {
+		if opt.Name == "sort" && opt.StringValue() != "" {
+			sort = opt.StringValue()
+		}
+	}
+
+	respondWikiList(s, i, sort, 0, false, log, grpcClient)
+}
+
+// handleWikiListPage handles the Prev/Next buttons of /wiki list (remainder: "<sort>:<offset>")
+func handleWikiListPage(s *discordgo.Session, i *discordgo.InteractionCreate, remainder string, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+	sort, offset, err := parsePagedCustomID(remainder)
+	if err != nil {
+		log.Warn("invalid wiki list button", slog.String("error", err.Error()))
+		respondError(s, i, "Invalid button", log)
+		return
+	}
+
+	respondWikiList(s, i, sort, offset, true, log, grpcClient)
+}
+
+// respondWikiList queries one page of ListWikiPages and sends it, or updates the
+// existing list message in place when update is set
+func respondWikiList(s *discordgo.Session, i *discordgo.InteractionCreate, sort string, offset int, update bool, log *slog.Logger, grpcClient *client.Client) {
+	ctx := discordContextFor(i)
+	wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+	orderBy, ascending := wikiListSortOrder(sort)
+
+	list := func(offset int) (*wikipb.ListWikiPagesResponse, error) {
+		return wikiClient.ListWikiPages(ctx, &wikipb.ListWikiPagesRequest{
+			GuildId:   i.GuildID,
+			Limit:     wikiListPageSize,
+			Offset:    int32(offset),
+			OrderBy:   orderBy,
+			Ascending: ascending,
+			OmitBody:  true, // The select menu shows sizes, not excerpts
+		})
+	}
+
+	resp, err := list(offset)
+	// Pages were deleted since the list was shown; jump to the new last page
+	if err == nil {
+		if clamped := clampPageOffset(offset, int(resp.Total), wikiListPageSize); clamped != offset {
+			offset = clamped
+			resp, err = list(offset)
+		}
+	}
+	if err != nil {
+		log.Error("failed to list wiki pages",
+			slog.String("guild_id", i.GuildID),
+			slog.String("error", err.Error()))
+		respondError(s, i, "Failed to list wiki pages", log)
+		return
+	}
+
+	content, components := wikiListMessage(resp.Pages, int(resp.Total), resp.GetPagination().GetHasMore(), sort, offset)
+
+	responseType := discordgo.InteractionResponseChannelMessageWithSource
+	if update {
+		responseType = discordgo.InteractionResponseUpdateMessage
+	}
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: responseType,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Embeds:     []*discordgo.MessageEmbed{},
+			Components: components,
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("failed to respond to wiki list", slog.String("error", err.Error()))
+	}
+}
+
+// wikiListMessage builds the header, page select menu, and Prev/Next row for one page of /wiki list.
+// hasMore is the server's report of whether another page follows and enables the Next button.
+func wikiListMessage(pages []*wikipb.WikiPage, total int, hasMore bool, sort string, offset int) (string, []discordgo.MessageComponent) {
+	if total == 0 {
+		return "📚 This server has no wiki pages yet. Use `/wiki edit` to create one.", []discordgo.MessageComponent{}
+	}
+
+	noun := "wiki pages"
+	if total == 1 {
+		noun = "wiki page"
+	}
+	var header strings.Builder
+	fmt.Fprintf(&header, "📚 **%d** %s", total, noun)
+	if label, ok := wikiListSortLabels[sort]; ok {
+		fmt.Fprintf(&header, ", %s", label)
+	}
+
+	first := offset + 1
+	last := offset + len(pages)
+	components := []discordgo.MessageComponent{
+		wikiPageSelectMenu(fmt.Sprintf("wiki_list_select:%s:%d", sort, offset), fmt.Sprintf("Select a page (%d–%d)...", first, last), pages),
+	}
+	if total > wikiListPageSize {
+		components = append(components, pageNavigationComponents("wiki_list", sort, offset, total, wikiListPageSize, hasMore)...)
+	}
+
+	return header.String(), components
+}
+
+// handleWikiDrafts lists the caller's unpublished drafts in this guild
+func handleWikiDrafts(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, grpcClient *client.Client) {
+	if i.GuildID == "" {
+		respondError(s, i, "Wiki drafts can only be listed in a server", log)
+		return
+	}
+
+	ctx := discordContextFor(i)
+	wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+
+	resp, err := wikiClient.ListWikiDrafts(ctx, &wikipb.ListWikiDraftsRequest{GuildId: i.GuildID})
+	if err != nil {
+		log.Error("failed to list wiki drafts",
+			slog.String("guild_id", i.GuildID),
+			slog.String("error", err.Error()))
+		respondError(s, i, "Failed to list wiki drafts", log)
+		return
+	}
+
+	content, components := wikiDraftsMessage(resp.Pages)
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: components,
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("failed to respond to wiki drafts", slog.String("error", err.Error()))
+	}
+}
+
+// wikiDraftsMessage builds the header and page select menu for /wiki drafts. Picking a
+// draft opens the same detail view as /wiki list, where it can be published.
+func wikiDraftsMessage(pages []*wikipb.WikiPage) (string, []discordgo.MessageComponent) {
+	if len(pages) == 0 {
+		return "📝 You have no wiki drafts in this server. Use `/wiki edit draft:True` to start one.", []discordgo.MessageComponent{}
+	}
+
+	noun := "drafts"
+	if len(pages) == 1 {
+		noun = "draft"
+	}
+	header := fmt.Sprintf("📝 You have **%d** wiki %s. Only you can find them until they're published.", len(pages), noun)
+	if len(pages) > 25 {
+		header += " Showing the 25 most recently updated."
+	}
+
+	return header, []discordgo.MessageComponent{
+		wikiPageSelectMenu("wiki_list_select:drafts", "Select a draft...", pages),
+	}
+}
+
+// handleWikiListSelect shows the detail view of the page picked from /wiki list
+func handleWikiListSelect(s *discordgo.Session, i *discordgo.InteractionCreate, cfg *config.Config, log *slog.Logger, grpcClient *client.Client) {
+	data := i.MessageComponentData()
+	if len(data.Values) == 0 {
+		return
+	}
+
+	pageID, ok := strings.CutPrefix(data.Values[0], "wiki_result:")
+	if !ok || pageID == "" {
+		return
+	}
+
+	ctx := discordContextFor(i)
+	wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+
+	page, err := wikiClient.GetWikiPage(ctx, &wikipb.GetWikiPageRequest{Id: pageID})
+	if err != nil {
+		log.Error("failed to fetch wiki page",
+			slog.String("page_id", pageID),
+			slog.String("error", err.Error()))
+		respondError(s, i, "Wiki page not found", log)
+		return
+	}
+
+	refs := fetchWikiMessageReferences(ctx, wikiClient, page.Id, log)
+	embed, components := showWikiDetailEmbed(s, page, refs, cfg, guildEmbedColors(page.GuildId, grpcClient, log).Wiki, "", false)
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    "",
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("failed to update message with wiki details", slog.String("error", err.Error()))
+	}
+}
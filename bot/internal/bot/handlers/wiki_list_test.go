@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+)
+
+func wikiListFixture(n int) []*wikipb.WikiPage {
+	pages := make([]*wikipb.WikiPage, n)
+	for idx := range pages {
+		pages[idx] = &wikipb.WikiPage{Id: fmt.Sprintf("p%d", idx), Title: fmt.Sprintf("Page %d", idx), BodyLength: 1234, ReferenceCount: int32(idx)}
+	}
+	return pages
+}
+
+func TestWikiListMessageEmpty(t *testing.T) {
+	content, components := wikiListMessage(nil, 0, false, "updated", 0)
+	if !strings.Contains(content, "no wiki pages") {
+		t.Errorf("content = %q, want empty-guild message", content)
+	}
+	if len(components) != 0 {
+		t.Errorf("got %d components, want none", len(components))
+	}
+}
+
+func TestWikiListMessagePaging(t *testing.T) {
+	content, components := wikiListMessage(wikiListFixture(5), 25, false, "title", 20)
+
+	if !strings.Contains(content, "**25** wiki pages") || !strings.Contains(content, "by title") {
+		t.Errorf("content = %q, want total count and sort in header", content)
+	}
+	if len(components) != 2 {
+		t.Fatalf("got %d component rows, want select menu and navigation", len(components))
+	}
+
+	menu := components[0].(discordgo.ActionsRow).Components[0].(discordgo.SelectMenu)
+	if menu.CustomID != "wiki_list_select:title:20" || len(menu.Options) != 5 {
+		t.Errorf("select menu = %q with %d options", menu.CustomID, len(menu.Options))
+	}
+	if menu.Options[0].Value != "wiki_result:p0" {
+		t.Errorf("first option value = %q, want wiki_result:p0", menu.Options[0].Value)
+	}
+	if got, want := menu.Options[2].Description, "📄 1.2k chars · 2 refs"; got != want {
+		t.Errorf("option description = %q, want %q", got, want)
+	}
+
+	nav := components[1].(discordgo.ActionsRow).Components
+	prev, label, next := nav[0].(discordgo.Button), nav[1].(discordgo.Button), nav[2].(discordgo.Button)
+	if prev.CustomID != "wiki_list_page:title:10" || prev.Disabled {
+		t.Errorf("prev = %q disabled=%v, want enabled wiki_list_page:title:10", prev.CustomID, prev.Disabled)
+	}
+	if label.Label != "Page 3 of 3" {
+		t.Errorf("label = %q, want Page 3 of 3", label.Label)
+	}
+	if !next.Disabled {
+		t.Error("next should be disabled on the last page")
+	}
+}
+
+func TestWikiListMessageNextFollowsHasMore(t *testing.T) {
+	_, components := wikiListMessage(wikiListFixture(10), 25, true, "updated", 0)
+	if len(components) != 2 {
+		t.Fatalf("got %d component rows, want select menu and navigation", len(components))
+	}
+	next := components[1].(discordgo.ActionsRow).Components[2].(discordgo.Button)
+	if next.Disabled || next.CustomID != "wiki_list_page:updated:10" {
+		t.Errorf("next = %q disabled=%v, want enabled wiki_list_page:updated:10", next.CustomID, next.Disabled)
+	}
+}
+
+func TestWikiListMessageSinglePage(t *testing.T) {
+	content, components := wikiListMessage(wikiListFixture(1), 1, false, "updated", 0)
+	if !strings.Contains(content, "**1** wiki page,") {
+		t.Errorf("content = %q, want singular count", content)
+	}
+	if len(components) != 1 {
+		t.Errorf("got %d component rows, want only the select menu", len(components))
+	}
+}
+
+func TestWikiDraftsMessage(t *testing.T) {
+	content, components := wikiDraftsMessage(nil)
+	if !strings.Contains(content, "no wiki drafts") || len(components) != 0 {
+		t.Errorf("empty drafts = %q with %d components, want no-drafts message only", content, len(components))
+	}
+
+	drafts := wikiListFixture(2)
+	for _, page := range drafts {
+		page.Status = wikipb.WikiPageStatus_WIKI_PAGE_STATUS_DRAFT
+	}
+	content, components = wikiDraftsMessage(drafts)
+	if !strings.Contains(content, "**2** wiki drafts") {
+		t.Errorf("content = %q, want draft count", content)
+	}
+	menu := components[0].(discordgo.ActionsRow).Components[0].(discordgo.SelectMenu)
+	if !strings.HasPrefix(menu.CustomID, "wiki_list_select:") || len(menu.Options) != 2 {
+		t.Errorf("select menu = %q with %d options, want wiki_list_select with 2", menu.CustomID, len(menu.Options))
+	}
+	if menu.Options[0].Emoji.Name != "📝" {
+		t.Errorf("draft option emoji = %q, want 📝", menu.Options[0].Emoji.Name)
+	}
+}
+
+func TestWikiListSortOrder(t *testing.T) {
+	tests := []struct {
+		choice        string
+		wantOrderBy   string
+		wantAscending bool
+	}{
+		{choice: "", wantOrderBy: "updated_at"},
+		{choice: "updated", wantOrderBy: "updated_at"},
+		{choice: "newest", wantOrderBy: "created_at"},
+		{choice: "title", wantOrderBy: "title", wantAscending: true},
+	}
+
+	for _, tt := range tests {
+		orderBy, ascending := wikiListSortOrder(tt.choice)
+		if orderBy != tt.wantOrderBy || ascending != tt.wantAscending {
+			t.Errorf("wikiListSortOrder(%q) = (%q, %v), want (%q, %v)", tt.choice, orderBy, ascending, tt.wantOrderBy, tt.wantAscending)
+		}
+	}
+}
+
+func TestContentSizeSummary(t *testing.T) {
+	tests := []struct {
+		bodyLength     int32
+		referenceCount int32
+		want           string
+	}{
+		{bodyLength: 0, want: "📄 0 chars"},
+		{bodyLength: 999, referenceCount: 1, want: "📄 999 chars · 1 ref"},
+		{bodyLength: 1000, want: "📄 1.0k chars"},
+		{bodyLength: 12345, referenceCount: 3, want: "📄 12.3k chars · 3 refs"},
+	}
+
+	for _, tt := range tests {
+		if got := contentSizeSummary(tt.bodyLength, tt.referenceCount); got != tt.want {
+			t.Errorf("contentSizeSummary(%d, %d) = %q, want %q", tt.bodyLength, tt.referenceCount, got, tt.want)
+		}
+	}
+}
+
+func TestWikiPageOptionDescription(t *testing.T) {
+	page := &wikipb.WikiPage{Snippet: "the **raid** starts at 8", BodyLength: 2048}
+	if got := wikiPageOptionDescription(page); got != "the raid starts at 8" {
+		t.Errorf("search result description = %q, want the snippet", got)
+	}
+	page.Snippet = ""
+	if got := wikiPageOptionDescription(page); got != "📄 2.0k chars" {
+		t.Errorf("list description = %q, want the size", got)
+	}
+}
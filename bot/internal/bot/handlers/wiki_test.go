@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+)
+
+func TestWikiWhoEditedMessage(t *testing.T) {
+	page := &wikipb.WikiPage{
+		Title:          "House Rules",
+		AuthorUsername: "alice",
+		CreatedAt:      &timestamppb.Timestamp{Seconds: 1000},
+		UpdatedAt:      &timestamppb.Timestamp{Seconds: 1000},
+	}
+
+	got := wikiWhoEditedMessage(page)
+	if !strings.Contains(got, "Written by **alice** on <t:1000:f>") || !strings.Contains(got, "Not edited since it was created") {
+		t.Errorf("unedited page = %q", got)
+	}
+
+	// Edited before editors were recorded: the edit time is known, the editor isn't
+	page.UpdatedAt = &timestamppb.Timestamp{Seconds: 2000}
+	if got := wikiWhoEditedMessage(page); !strings.Contains(got, "Last edited on <t:2000:f> (editor not recorded)") {
+		t.Errorf("edit without an editor = %q", got)
+	}
+
+	page.LastEditorId = "u2"
+	page.LastEditorUsername = "bob"
+	got = wikiWhoEditedMessage(page)
+	if !strings.Contains(got, "Last edited by **bob** on <t:2000:f>") {
+		t.Errorf("edited page = %q, want bob as the last editor", got)
+	}
+
+	// An editor without a display name in this guild is still reported
+	page.LastEditorUsername = ""
+	if got := wikiWhoEditedMessage(page); !strings.Contains(got, "Last edited by **Unknown**") {
+		t.Errorf("editor without a name = %q", got)
+	}
+}
+
+func TestWikiTransferErrorMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "not author or admin", err: status.Error(codes.PermissionDenied, "forbidden"), want: "Only the page author or an admin"},
+		{name: "not a member", err: status.Error(codes.FailedPrecondition, "new owner is not a member of the page's guild"), want: "<@42> can't own this page: new owner is not a member"},
+		{name: "missing page", err: status.Error(codes.NotFound, "wiki page not found"), want: "Wiki page not found"},
+		{name: "other", err: errors.New("connection reset"), want: "Failed to transfer ownership"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wikiTransferErrorMessage(tt.err, "42"); !strings.Contains(got, tt.want) {
+				t.Errorf("wikiTransferErrorMessage() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
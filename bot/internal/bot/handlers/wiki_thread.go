@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// maxThreadMessages caps how many of a thread's messages "Add Thread to Wiki" saves,
+// matching the most the server accepts in one batch
+const maxThreadMessages = 500
+
+// handleContextMenuWikiThread handles the "Add Thread to Wiki" context menu command.
+// It works on any message in a thread, or on the message a thread was started from,
+// and asks which wiki page the thread's messages should be added to.
+func handleContextMenuWikiThread(s *discordgo.Session, i *discordgo.InteractionCreate, log *slog.Logger, grpcClient *client.Client) {
+	targetID := i.ApplicationCommandData().TargetID
+	message := i.ApplicationCommandData().Resolved.Messages[targetID]
+	if message == nil {
+		respondError(s, i, "Could not find the target message", log)
+		return
+	}
+
+	threadID := threadForMessage(s, i.ChannelID, message)
+	if threadID == "" {
+		respondError(s, i, "Use this on a message in a thread, or on the message that started one", log)
+		return
+	}
+
+	if !requireWikiEditRole(s, i, log, grpcClient) {
+		return
+	}
+
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+		pagesResp, err := wikiClient.ListWikiPages(discordContextFor(i), &wikipb.ListWikiPagesRequest{
+			GuildId:  i.GuildID,
+			Limit:    25, // Discord limit for select menu options
+			OmitBody: true,
+		})
+		if err != nil {
+			return nil, userError("Failed to fetch wiki pages", err)
+		}
+		if len(pagesResp.Pages) == 0 {
+			return nil, userError("There are no wiki pages yet. Create one with **Add to Wiki** first", nil)
+		}
+
+		options := make([]discordgo.SelectMenuOption, 0, len(pagesResp.Pages))
+		for _, page := range pagesResp.Pages {
+			label := page.Title
+			if len(label) > 100 {
+				label = label[:97] + "..."
+			}
+			options = append(options, discordgo.SelectMenuOption{
+				Label:       label,
+				Value:       page.Id,
+				Description: contentSizeSummary(page.BodyLength, page.ReferenceCount),
+			})
+		}
+
+		components := []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.SelectMenu{
+						CustomID:    "wiki_thread_select:" + threadID,
+						Placeholder: "Choose a wiki page...",
+						Options:     options,
+					},
+				},
+			},
+		}
+		return &discordgo.WebhookEdit{
+			Content:    ptrString("**Add this thread's messages to a wiki page:**"),
+			Components: &components,
+		}, nil
+	})
+}
+
+// handleWikiThreadSelect adds the messages of threadID to the wiki page picked in the select menu
+func handleWikiThreadSelect(s *discordgo.Session, i *discordgo.InteractionCreate, threadID string, log *slog.Logger, grpcClient *client.Client) {
+	values := i.MessageComponentData().Values
+	if len(values) == 0 {
+		respondError(s, i, "No page selected", log)
+		return
+	}
+	pageID := values[0]
+
+	respondDeferred(s, i, log, func() (*discordgo.WebhookEdit, error) {
+		wikiClient := wikipb.NewWikiServiceClient(grpcClient.Conn())
+		ctx := discordContextFor(i)
+
+		page, err := wikiClient.GetWikiPage(ctx, &wikipb.GetWikiPageRequest{Id: pageID})
+		if err != nil {
+			return nil, userError("Failed to fetch the wiki page", err)
+		}
+
+		messages, err := fetchThreadMessages(s, threadID, maxThreadMessages)
+		if err != nil {
+			return nil, userError("Failed to read the thread's messages", err)
+		}
+		refs := threadReferenceRequests(i.GuildID, messages)
+		if len(refs) == 0 {
+			return nil, userError("The thread has no messages to add", nil)
+		}
+
+		resp, err := wikiClient.AddWikiMessageReferencesBatch(ctx, &wikipb.AddWikiMessageReferencesBatchRequest{
+			WikiPageId: page.Id,
+			References: refs,
+		})
+		if isReferenceLimitError(err) {
+			return nil, userError(wikiReferenceLimitMessage, err)
+		}
+		if err != nil {
+			return nil, userError("Failed to add the thread to the wiki page", err)
+		}
+		for _, invalid := range resp.Invalid {
+			log.Warn("thread message rejected for wiki page",
+				slog.String("wiki_page_id", page.Id),
+				slog.String("message_id", invalid.MessageId),
+				slog.String("reason", invalid.Reason))
+		}
+
+		return &discordgo.WebhookEdit{
+			Content:    ptrString(threadImportSummary(page.Title, resp)),
+			Components: &[]discordgo.MessageComponent{},
+		}, nil
+	})
+}
+
+// threadForMessage returns the ID of the thread message belongs to or started, or "" if neither
+func threadForMessage(s *discordgo.Session, channelID string, message *discordgo.Message) string {
+	if message.Thread != nil {
+		return message.Thread.ID
+	}
+
+	channel, err := s.State.Channel(channelID)
+	if err != nil {
+		channel, err = s.Channel(channelID)
+	}
+	if err == nil && channel.IsThread() {
+		return channel.ID
+	}
+	return ""
+}
+
+// fetchThreadMessages returns up to limit of a thread's messages, newest first as Discord lists them
+func fetchThreadMessages(s *discordgo.Session, threadID string, limit int) ([]*discordgo.Message, error) {
+	var messages []*discordgo.Message
+	before := ""
+	for len(messages) < limit {
+		batch, err := s.ChannelMessages(threadID, min(100, limit-len(messages)), before, "", "")
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, batch...)
+		if len(batch) < 100 {
+			break
+		}
+		before = batch[len(batch)-1].ID
+	}
+	return messages, nil
+}
+
+// threadReferenceRequests converts a thread's messages, newest first, into wiki
+// references in the order they were posted. System messages such as member joins and
+// pins are left out; the thread's starter message stands in for the post it quotes.
+func threadReferenceRequests(guildID string, messages []*discordgo.Message) []*wikipb.AddWikiMessageReferenceRequest {
+	refs := make([]*wikipb.AddWikiMessageReferenceRequest, 0, len(messages))
+	for idx := len(messages) - 1; idx >= 0; idx-- {
+		message := messages[idx]
+		switch message.Type {
+		case discordgo.MessageTypeDefault, discordgo.MessageTypeReply:
+		case discordgo.MessageTypeThreadStarterMessage:
+			if message.ReferencedMessage == nil {
+				continue
+			}
+			message = message.ReferencedMessage
+		default:
+			continue
+		}
+		if message.Author == nil {
+			continue
+		}
+
+		attachments := make([]*wikipb.AttachmentMetadata, 0, len(message.Attachments))
+		for _, attachment := range message.Attachments {
+			attachments = append(attachments, &wikipb.AttachmentMetadata{
+				Url:         attachment.URL,
+				ContentType: attachment.ContentType,
+				Filename:    attachment.Filename,
+				Width:       int32(attachment.Width),
+				Height:      int32(attachment.Height),
+				Size:        int64(attachment.Size),
+			})
+		}
+
+		authorDisplayName := message.Author.Username
+		if message.Member != nil && message.Member.Nick != "" {
+			authorDisplayName = message.Member.Nick
+		}
+
+		refs = append(refs, &wikipb.AddWikiMessageReferenceRequest{
+			MessageId:         message.ID,
+			ChannelId:         message.ChannelID,
+			GuildId:           guildID,
+			Content:           messageReferenceContent(message),
+			AuthorId:          message.Author.ID,
+			AuthorUsername:    message.Author.Username,
+			AuthorDisplayName: authorDisplayName,
+			MessageTimestamp:  timestamppb.New(message.Timestamp),
+			Attachments:       attachments,
+		})
+	}
+	return refs
+}
+
+// threadImportSummary describes the result of adding a thread to the wiki page titled title
+func threadImportSummary(title string, resp *wikipb.AddWikiMessageReferencesBatchResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "✅ Added **%d** thread messages to **%s**", resp.Added, title)
+	if resp.Skipped > 0 {
+		fmt.Fprintf(&b, "\n%d already on the page", resp.Skipped)
+	}
+	if len(resp.Invalid) > 0 {
+		fmt.Fprintf(&b, "\n⚠️ %d couldn't be added", len(resp.Invalid))
+	}
+	return b.String()
+}
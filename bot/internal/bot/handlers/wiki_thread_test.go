@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+)
+
+func TestThreadReferenceRequests(t *testing.T) {
+	author := &discordgo.User{ID: "u1", Username: "alice"}
+	posted := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	starter := &discordgo.Message{ID: "thread-1", ChannelID: "parent", Type: discordgo.MessageTypeDefault, Author: author, Content: "Where do dragons live?", Timestamp: posted}
+
+	// Newest first, as Discord lists them
+	messages := []*discordgo.Message{
+		{ID: "m3", ChannelID: "thread-1", Type: discordgo.MessageTypeReply, Author: author, Content: "Caves", Timestamp: posted.Add(2 * time.Minute),
+			Member: &discordgo.Member{Nick: "Al"}},
+		{ID: "m-join", ChannelID: "thread-1", Type: discordgo.MessageTypeGuildMemberJoin, Author: author},
+		{ID: "m2", ChannelID: "thread-1", Type: discordgo.MessageTypeDefault, Author: author, Timestamp: posted.Add(time.Minute),
+			Attachments: []*discordgo.MessageAttachment{{URL: "https://cdn/map.png", Filename: "map.png", ContentType: "image/png"}}},
+		{ID: "m1", ChannelID: "thread-1", Type: discordgo.MessageTypeThreadStarterMessage, ReferencedMessage: starter},
+	}
+
+	refs := threadReferenceRequests("g1", messages)
+
+	var ids []string
+	for _, ref := range refs {
+		ids = append(ids, ref.MessageId)
+		if ref.GuildId != "g1" {
+			t.Errorf("reference %s guild = %q, want g1", ref.MessageId, ref.GuildId)
+		}
+	}
+	if got := strings.Join(ids, " "); got != "thread-1 m2 m3" {
+		t.Fatalf("references = %q, want the starter then replies oldest first without system messages", got)
+	}
+
+	if refs[0].ChannelId != "parent" || refs[0].Content != "Where do dragons live?" {
+		t.Errorf("starter reference = %+v, want the quoted post in the parent channel", refs[0])
+	}
+	if refs[1].Content != "[image] map.png" || len(refs[1].Attachments) != 1 {
+		t.Errorf("attachment-only reference content = %q with %d attachments", refs[1].Content, len(refs[1].Attachments))
+	}
+	if refs[2].AuthorDisplayName != "Al" || !refs[2].MessageTimestamp.AsTime().Equal(posted.Add(2*time.Minute)) {
+		t.Errorf("reply reference = %+v, want nickname and message time", refs[2])
+	}
+}
+
+func TestThreadImportSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *wikipb.AddWikiMessageReferencesBatchResponse
+		want string
+	}{
+		{
+			name: "all added",
+			resp: &wikipb.AddWikiMessageReferencesBatchResponse{Added: 3},
+			want: "✅ Added **3** thread messages to **Dragons**",
+		},
+		{
+			name: "skipped and invalid",
+			resp: &wikipb.AddWikiMessageReferencesBatchResponse{
+				Added:   1,
+				Skipped: 2,
+				Invalid: []*wikipb.InvalidWikiMessageReference{{Index: 3, MessageId: "m4", Reason: "author_id is required"}},
+			},
+			want: "✅ Added **1** thread messages to **Dragons**\n2 already on the page\n⚠️ 1 couldn't be added",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := threadImportSummary("Dragons", tt.resp); got != tt.want {
+				t.Errorf("threadImportSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Package membersync copies a guild's full member list from Discord to the backend.
+package membersync
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+)
+
+// pageSize is the most members Discord returns per request
+const pageSize = 1000
+
+// ErrSyncInProgress is returned when a sync of the same guild is already running
+var ErrSyncInProgress = errors.New("member sync already in progress for this guild")
+
+// MemberFetcher lists a guild's members a page at a time; *discordgo.Session implements it
+type MemberFetcher interface {
+	GuildMembers(guildID string, after string, limit int, options ...discordgo.RequestOption) ([]*discordgo.Member, error)
+}
+
+// Syncer runs member syncs, allowing only one at a time per guild so the scheduled
+// job and manual requests don't fetch the same guild twice over
+type Syncer struct {
+	fetcher       MemberFetcher
+	discordClient discordpb.DiscordServiceClient
+	log           *slog.Logger
+
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// New creates a Syncer
+func New(fetcher MemberFetcher, discordClient discordpb.DiscordServiceClient, log *slog.Logger) *Syncer {
+	return &Syncer{
+		fetcher:       fetcher,
+		discordClient: discordClient,
+		log:           log,
+		running:       make(map[string]bool),
+	}
+}
+
+// Sync fetches every member of guildID from Discord and upserts them in one batch,
+// returning how many were synced. It returns ErrSyncInProgress without doing anything
+// if the guild is already being synced.
+func (s *Syncer) Sync(ctx context.Context, guildID string) (int, error) {
+	if !s.acquire(guildID) {
+		return 0, ErrSyncInProgress
+	}
+	defer s.release(guildID)
+
+	s.log.Debug("syncing guild members",
+		slog.String("guild_id", guildID))
+
+	var allMembers []*discordpb.GuildMember
+	after := ""
+
+	// Paginate through all members
+	for {
+		s.log.Debug("fetching guild members from Discord API",
+			slog.String("guild_id", guildID),
+			slog.String("after", after),
+			slog.Int("limit", pageSize))
+
+		members, err := s.fetcher.GuildMembers(guildID, after, pageSize)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(members) == 0 {
+			break
+		}
+
+		for _, m := range members {
+			allMembers = append(allMembers, memberToProto(guildID, m))
+		}
+
+		s.log.Debug("fetched member batch",
+			slog.String("guild_id", guildID),
+			slog.Int("batch_size", len(members)),
+			slog.Int("total_fetched", len(allMembers)))
+
+		// A short page is the last one
+		if len(members) < pageSize {
+			break
+		}
+
+		// Use last member's ID for pagination
+		after = members[len(members)-1].User.ID
+	}
+
+	// Upsert even an empty list so the backend records the sync time
+	_, err := s.discordClient.UpsertGuildMembersBatch(ctx, &discordpb.UpsertGuildMembersBatchRequest{
+		Members:       allMembers,
+		SyncedGuildId: guildID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.log.Info("completed guild member sync",
+		slog.String("guild_id", guildID),
+		slog.Int("total_members", len(allMembers)))
+
+	return len(allMembers), nil
+}
+
+// acquire marks guildID as syncing, returning false if it already was
+func (s *Syncer) acquire(guildID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[guildID] {
+		return false
+	}
+	s.running[guildID] = true
+	return true
+}
+
+func (s *Syncer) release(guildID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, guildID)
+}
+
+// memberToProto converts a Discord member to its protobuf message
+func memberToProto(guildID string, m *discordgo.Member) *discordpb.GuildMember {
+	pbMember := &discordpb.GuildMember{
+		GuildId:         guildID,
+		DiscordId:       m.User.ID,
+		Roles:           m.Roles,
+		DiscordUsername: m.User.Username,
+	}
+
+	if m.Nick != "" {
+		pbMember.GuildNick = m.Nick
+	}
+	if m.Avatar != "" {
+		pbMember.GuildAvatarHash = m.Avatar
+	}
+	if m.User.GlobalName != "" {
+		pbMember.DiscordGlobalName = m.User.GlobalName
+	}
+	if m.User.Avatar != "" {
+		pbMember.AvatarHash = m.User.Avatar
+	}
+
+	return pbMember
+}
@@ -0,0 +1,92 @@
+package membersync
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/grpc"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+)
+
+// blockingFetcher returns one member per guild. The first fetch of a guild in block
+// waits on release.
+type blockingFetcher struct {
+	block   map[string]bool
+	started chan string
+	release chan struct{}
+}
+
+func (f *blockingFetcher) GuildMembers(guildID, after string, limit int, options ...discordgo.RequestOption) ([]*discordgo.Member, error) {
+	if f.block[guildID] {
+		delete(f.block, guildID)
+		f.started <- guildID
+		<-f.release
+	}
+	return []*discordgo.Member{{User: &discordgo.User{ID: "u1", Username: "alice"}}}, nil
+}
+
+type fakeDiscordClient struct {
+	discordpb.DiscordServiceClient
+	synced chan *discordpb.UpsertGuildMembersBatchRequest
+}
+
+func (c *fakeDiscordClient) UpsertGuildMembersBatch(ctx context.Context, req *discordpb.UpsertGuildMembersBatchRequest, opts ...grpc.CallOption) (*discordpb.UpsertGuildMembersBatchResponse, error) {
+	c.synced <- req
+	return &discordpb.UpsertGuildMembersBatchResponse{Count: int32(len(req.Members))}, nil
+}
+
+func TestSyncRejectsConcurrentSyncOfSameGuild(t *testing.T) {
+	fetcher := &blockingFetcher{
+		block:   map[string]bool{"g1": true},
+		started: make(chan string),
+		release: make(chan struct{}),
+	}
+	client := &fakeDiscordClient{synced: make(chan *discordpb.UpsertGuildMembersBatchRequest, 4)}
+	syncer := New(fetcher, client, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	type result struct {
+		count int
+		err   error
+	}
+	first := make(chan result)
+	go func() {
+		count, err := syncer.Sync(ctx, "g1")
+		first <- result{count, err}
+	}()
+	<-fetcher.started
+
+	if _, err := syncer.Sync(ctx, "g1"); !errors.Is(err, ErrSyncInProgress) {
+		t.Errorf("second Sync() of same guild error = %v, want ErrSyncInProgress", err)
+	}
+	if count, err := syncer.Sync(ctx, "g2"); err != nil || count != 1 {
+		t.Errorf("Sync() of other guild = %d, %v, want 1, nil", count, err)
+	}
+
+	close(fetcher.release)
+	if r := <-first; r.err != nil || r.count != 1 {
+		t.Fatalf("first Sync() = %d, %v, want 1, nil", r.count, r.err)
+	}
+
+	// The guard is released once the first sync finishes
+	if count, err := syncer.Sync(ctx, "g1"); err != nil || count != 1 {
+		t.Errorf("Sync() after first finished = %d, %v, want 1, nil", count, err)
+	}
+
+	close(client.synced)
+	var guilds []string
+	for req := range client.synced {
+		if req.SyncedGuildId != req.Members[0].GuildId {
+			t.Errorf("SyncedGuildId = %q for members of %q", req.SyncedGuildId, req.Members[0].GuildId)
+		}
+		guilds = append(guilds, req.SyncedGuildId)
+	}
+	if len(guilds) != 3 {
+		t.Errorf("upserted members of %v, want 3 syncs", guilds)
+	}
+}
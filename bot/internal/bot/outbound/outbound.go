@@ -0,0 +1,211 @@
+// Package outbound sends messages to Discord with retries, and records the ones
+// that still fail so operators can see what the bot dropped.
+package outbound
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+	botmetrics "github.com/devilmonastery/hivemind/bot/internal/metrics"
+	"github.com/devilmonastery/hivemind/internal/client"
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+// maxPayloadLength caps the summary of a dropped message kept in the dead-letter log
+const maxPayloadLength = 1000
+
+// Action describes an outbound Discord call for its metrics and dead-letter record
+type Action struct {
+	Operation string // e.g. "wiki_announcement"; used as a metric label, so keep it fixed
+	GuildID   string
+	ChannelID string
+	Payload   string // Summary of what was sent, kept if the call is dropped
+}
+
+// retryPolicy decides how often and how long to wait between attempts at a call
+type retryPolicy struct {
+	attempts      int           // Total attempts, including the first
+	baseDelay     time.Duration // Wait before the first retry of an error without Retry-After, doubled per retry
+	maxRetryAfter time.Duration // Longer rate limits give up rather than wait
+	sleep         func(ctx context.Context, d time.Duration) error
+}
+
+// defaultPolicy keeps retries short, since most sends answer an interaction that
+// the user is waiting on
+var defaultPolicy = retryPolicy{
+	attempts:      3,
+	baseDelay:     500 * time.Millisecond,
+	maxRetryAfter: 5 * time.Second,
+	sleep:         sleepContext,
+}
+
+// Send calls send, retrying rate limits after their Retry-After and connection failures
+// with exponential backoff. Sends create messages and are not idempotent, so errors that
+// may come after Discord acted on the request, such as a server error or a lost response,
+// are not retried; neither are other client errors, such as a deleted channel or a
+// missing permission. A call that still fails is recorded in the server's dead-letter
+// log and its error returned.
+//
+// send must pass the options it is given to its discordgo call: they turn off discordgo's
+// own rate limit retries so the wait can be capped here.
+func Send(ctx context.Context, grpcClient *client.Client, action Action, log *slog.Logger, send func(opts ...discordgo.RequestOption) error) error {
+	attempts, err := defaultPolicy.run(ctx, action.Operation, send)
+	if err != nil {
+		recordDeadLetter(grpcClient, action, attempts, err, log)
+	}
+	return err
+}
+
+// run calls send until it succeeds, fails with an error that isn't worth retrying, or
+// runs out of attempts, and returns how many attempts it made
+func (p retryPolicy) run(ctx context.Context, operation string, send func(opts ...discordgo.RequestOption) error) (int, error) {
+	for attempt := 1; ; attempt++ {
+		err := send(discordgo.WithRetryOnRatelimit(false), discordgo.WithRestRetries(0))
+		if err == nil {
+			return attempt, nil
+		}
+
+		wait, retryable := p.backoff(err, attempt)
+		if !retryable || attempt >= p.attempts {
+			metrics.DiscordSendFailures.WithLabelValues(operation, failureType(err), "dropped").Inc()
+			return attempt, err
+		}
+		metrics.DiscordSendFailures.WithLabelValues(operation, failureType(err), "retried").Inc()
+
+		if sleepErr := p.sleep(ctx, wait); sleepErr != nil {
+			return attempt, err
+		}
+	}
+}
+
+// backoff returns how long to wait before retrying after the given failed attempt, and
+// whether to retry at all. Only failures Discord never acted on are retried: rate limits,
+// which are rejected before anything happens, and requests that were never sent.
+func (p retryPolicy) backoff(err error, attempt int) (time.Duration, bool) {
+	if wait, ok := retryAfter(err); ok {
+		return wait, wait <= p.maxRetryAfter
+	}
+
+	if statusCode(err) != http.StatusTooManyRequests && !notSent(err) {
+		return 0, false
+	}
+	return p.baseDelay << (attempt - 1), true
+}
+
+// notSent reports whether err stopped the request before it reached Discord: a failed
+// DNS lookup or connection
+func notSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryAfter returns how long Discord asked the caller to wait after a 429: from the
+// rate limit body discordgo parsed, or else the Retry-After header
+func retryAfter(err error) (time.Duration, bool) {
+	var rateLimitErr *discordgo.RateLimitError
+	if errors.As(err, &rateLimitErr) && rateLimitErr.RateLimit != nil && rateLimitErr.TooManyRequests != nil {
+		return rateLimitErr.RetryAfter, true
+	}
+
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) && restErr.Response != nil && restErr.Response.StatusCode == http.StatusTooManyRequests {
+		return parseRetryAfter(restErr.Response.Header.Get("Retry-After"))
+	}
+	return 0, false
+}
+
+// parseRetryAfter parses a Retry-After header. Discord sends it in seconds, possibly
+// with a fraction.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || seconds < 0 || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		return 0, false
+	}
+	return time.Duration(seconds * float64(time.Second)), true
+}
+
+// statusCode returns the HTTP status of a failed Discord call, or 0 if it got no response
+func statusCode(err error) int {
+	var rateLimitErr *discordgo.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return http.StatusTooManyRequests
+	}
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) && restErr.Response != nil {
+		return restErr.Response.StatusCode
+	}
+	return 0
+}
+
+// failureType classifies err the same way as the Discord API error metrics
+func failureType(err error) string {
+	if code := statusCode(err); code != 0 {
+		return botmetrics.ClassifyDiscordError(code, nil)
+	}
+	return botmetrics.ClassifyDiscordError(0, err)
+}
+
+// recordDeadLetter stores a dropped call on the server. Failing to record it is only logged.
+func recordDeadLetter(grpcClient *client.Client, action Action, attempts int, sendErr error, log *slog.Logger) {
+	log.Error("dropping Discord call after retries",
+		slog.String("operation", action.Operation),
+		slog.String("guild_id", action.GuildID),
+		slog.String("channel_id", action.ChannelID),
+		slog.Int("attempts", attempts),
+		slog.String("error", sendErr.Error()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
+	_, err := discordClient.RecordDeadLetter(ctx, &discordpb.RecordDeadLetterRequest{
+		DeadLetter: &discordpb.DeadLetter{
+			Operation:   action.Operation,
+			GuildId:     action.GuildID,
+			ChannelId:   action.ChannelID,
+			FailureType: failureType(sendErr),
+			Error:       sendErr.Error(),
+			Attempts:    int32(attempts),
+			Payload:     truncate(action.Payload, maxPayloadLength),
+		},
+	})
+	if err != nil {
+		log.Warn("failed to record dropped Discord call",
+			slog.String("operation", action.Operation),
+			slog.String("error", err.Error()))
+	}
+}
+
+// truncate shortens s to at most max runes
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
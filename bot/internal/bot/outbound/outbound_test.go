@@ -0,0 +1,207 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func restError(code int, header http.Header) *discordgo.RESTError {
+	return &discordgo.RESTError{
+		Response: &http.Response{StatusCode: code, Status: fmt.Sprintf("%d", code), Header: header},
+	}
+}
+
+func rateLimitError(retryAfter time.Duration) *discordgo.RateLimitError {
+	return &discordgo.RateLimitError{RateLimit: &discordgo.RateLimit{
+		TooManyRequests: &discordgo.TooManyRequests{RetryAfter: retryAfter},
+	}}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: "2", want: 2 * time.Second, wantOK: true},
+		{value: "0.25", want: 250 * time.Millisecond, wantOK: true},
+		{value: " 1.5 ", want: 1500 * time.Millisecond, wantOK: true},
+		{value: "0", want: 0, wantOK: true},
+		{value: ""},
+		{value: "soon"},
+		{value: "-1"},
+		{value: "Inf"},
+		{value: "NaN"},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "rate limit body", err: rateLimitError(1200 * time.Millisecond), want: 1200 * time.Millisecond, wantOK: true},
+		{name: "wrapped rate limit", err: fmt.Errorf("send: %w", rateLimitError(time.Second)), want: time.Second, wantOK: true},
+		{name: "retry-after header", err: restError(429, http.Header{"Retry-After": {"3"}}), want: 3 * time.Second, wantOK: true},
+		{name: "429 without header", err: restError(429, http.Header{})},
+		{name: "header on another status", err: restError(503, http.Header{"Retry-After": {"3"}})},
+		{name: "network error", err: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := retryAfter(tt.err)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("retryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyRun(t *testing.T) {
+	tests := []struct {
+		name         string
+		errs         []error // Returned by successive attempts; nil once they run out
+		wantAttempts int
+		wantSleeps   []time.Duration
+		wantErr      bool
+	}{
+		{name: "success", wantAttempts: 1},
+		{
+			name:         "rate limited once",
+			errs:         []error{rateLimitError(2 * time.Second)},
+			wantAttempts: 2,
+			wantSleeps:   []time.Duration{2 * time.Second},
+		},
+		{
+			name:         "retry-after header",
+			errs:         []error{restError(429, http.Header{"Retry-After": {"0.5"}})},
+			wantAttempts: 2,
+			wantSleeps:   []time.Duration{500 * time.Millisecond},
+		},
+		{
+			name:         "rate limit without retry-after backs off until attempts run out",
+			errs:         []error{restError(429, nil), restError(429, nil), restError(429, nil), nil},
+			wantAttempts: 3,
+			wantSleeps:   []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+			wantErr:      true,
+		},
+		{
+			name:         "connection refused",
+			errs:         []error{&url.Error{Op: "Post", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}},
+			wantAttempts: 2,
+			wantSleeps:   []time.Duration{100 * time.Millisecond},
+		},
+		{
+			name:         "DNS failure",
+			errs:         []error{&url.Error{Op: "Post", Err: &net.DNSError{Err: "no such host", Name: "discord.com"}}},
+			wantAttempts: 2,
+			wantSleeps:   []time.Duration{100 * time.Millisecond},
+		},
+		{
+			// Discord may have created the message before failing, so a retry could post it twice
+			name:         "server error is not retried",
+			errs:         []error{restError(502, nil)},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "lost response is not retried",
+			errs:         []error{&url.Error{Op: "Post", Err: &net.OpError{Op: "read", Err: errors.New("connection reset")}}},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "forbidden is not retried",
+			errs:         []error{restError(403, nil)},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "unknown channel is not retried",
+			errs:         []error{restError(404, nil)},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "rate limit longer than the cap is not waited out",
+			errs:         []error{rateLimitError(time.Minute)},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sleeps []time.Duration
+			p := retryPolicy{
+				attempts:      3,
+				baseDelay:     100 * time.Millisecond,
+				maxRetryAfter: 5 * time.Second,
+				sleep: func(ctx context.Context, d time.Duration) error {
+					sleeps = append(sleeps, d)
+					return nil
+				},
+			}
+
+			calls := 0
+			attempts, err := p.run(context.Background(), "test", func(opts ...discordgo.RequestOption) error {
+				calls++
+				if len(opts) == 0 {
+					t.Error("send got no request options")
+				}
+				if calls <= len(tt.errs) {
+					return tt.errs[calls-1]
+				}
+				return nil
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts || calls != tt.wantAttempts {
+				t.Errorf("run() = %d attempts with %d calls, want %d", attempts, calls, tt.wantAttempts)
+			}
+			if fmt.Sprint(sleeps) != fmt.Sprint(tt.wantSleeps) {
+				t.Errorf("slept %v, want %v", sleeps, tt.wantSleeps)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyRunStopsWhenCanceled(t *testing.T) {
+	p := retryPolicy{
+		attempts:  3,
+		baseDelay: time.Millisecond,
+		sleep:     sleepContext,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sendErr := restError(429, nil)
+	calls := 0
+	attempts, err := p.run(ctx, "test", func(opts ...discordgo.RequestOption) error {
+		calls++
+		return sendErr
+	})
+	if !errors.Is(err, sendErr) || attempts != 1 || calls != 1 {
+		t.Errorf("run() = %d attempts, %d calls, error %v; want 1 attempt with the send error", attempts, calls, err)
+	}
+}
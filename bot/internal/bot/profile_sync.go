@@ -0,0 +1,157 @@
+package bot
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+)
+
+// profileSyncBatchSize is how many stored users are fetched (and written back) per page
+const profileSyncBatchSize = 500
+
+// StartProfileSync periodically refreshes cached usernames, global names, and avatar
+// hashes for recently active Discord users, so embeds show current profiles
+func (b *Bot) StartProfileSync(ctx context.Context) {
+	interval := b.config.Sync.ProfileSyncInterval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	b.log.Info("starting profile sync background job",
+		slog.Duration("interval", interval),
+		slog.Duration("active_within", b.config.Sync.ProfileSyncActiveWithin))
+
+	// No initial run: give the member sync a chance to populate state first
+	for {
+		select {
+		case <-ctx.Done():
+			b.log.Info("stopping profile sync background job")
+			return
+		case <-ticker.C:
+			b.syncUserProfiles(ctx)
+		}
+	}
+}
+
+// memberDataFresh reports whether a member sync has completed recently enough
+// for the stored user list to be trusted
+func (b *Bot) memberDataFresh() bool {
+	last := b.lastMemberSync.Load()
+	if last == 0 {
+		return false
+	}
+	return time.Since(time.Unix(0, last)) < 2*memberSyncInterval
+}
+
+// syncUserProfiles walks recently active Discord users and writes back any profile changes
+func (b *Bot) syncUserProfiles(ctx context.Context) {
+	if !b.memberDataFresh() {
+		b.log.Warn("skipping profile sync, member data is stale")
+		return
+	}
+
+	b.log.Info("starting scheduled profile sync")
+
+	discordClient := discordpb.NewDiscordServiceClient(b.grpcClient.Conn())
+
+	// Pace REST lookups; users found in the gateway state don't consume a slot
+	limiter := time.NewTicker(time.Second / time.Duration(b.config.Sync.ProfileSyncRequestsPerSecond))
+	defer limiter.Stop()
+
+	seenSince := timestamppb.New(time.Now().Add(-b.config.Sync.ProfileSyncActiveWithin))
+	after := ""
+	checked, updated, failed := 0, 0, 0
+
+	for {
+		resp, err := discordClient.ListDiscordUsers(ctx, &discordpb.ListDiscordUsersRequest{
+			SeenSince:      seenSince,
+			AfterDiscordId: after,
+			Limit:          profileSyncBatchSize,
+		})
+		if err != nil {
+			b.log.Error("failed to list discord users for profile sync",
+				slog.String("error", err.Error()))
+			return
+		}
+		if len(resp.Users) == 0 {
+			break
+		}
+
+		var changed []*discordpb.DiscordUser
+		for _, stored := range resp.Users {
+			current, err := b.lookupDiscordUser(ctx, stored.DiscordId, limiter.C)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				b.log.Debug("failed to fetch discord user",
+					slog.String("discord_id", stored.DiscordId),
+					slog.String("error", err.Error()))
+				failed++
+				continue
+			}
+			checked++
+
+			if current.Username != "" && profileChanged(stored, current) {
+				changed = append(changed, &discordpb.DiscordUser{
+					DiscordId:         stored.DiscordId,
+					DiscordUsername:   current.Username,
+					DiscordGlobalName: current.GlobalName,
+					AvatarHash:        current.Avatar,
+					LastSeen:          stored.LastSeen,
+				})
+			}
+		}
+
+		if len(changed) > 0 {
+			if _, err := discordClient.UpdateDiscordUsersBatch(ctx, &discordpb.UpdateDiscordUsersBatchRequest{
+				Users: changed,
+			}); err != nil {
+				b.log.Error("failed to update discord user profiles",
+					slog.Int("count", len(changed)),
+					slog.String("error", err.Error()))
+				failed += len(changed)
+			} else {
+				updated += len(changed)
+			}
+		}
+
+		if len(resp.Users) < profileSyncBatchSize {
+			break
+		}
+		after = resp.Users[len(resp.Users)-1].DiscordId
+	}
+
+	b.log.Info("completed scheduled profile sync",
+		slog.Int("checked", checked),
+		slog.Int("updated", updated),
+		slog.Int("failed", failed))
+}
+
+// lookupDiscordUser returns a user's current profile, preferring the gateway state
+// cache and only calling the REST API (paced by limiter) on a cache miss
+func (b *Bot) lookupDiscordUser(ctx context.Context, discordID string, limiter <-chan time.Time) (*discordgo.User, error) {
+	for _, guild := range b.session.State.Guilds {
+		if member, err := b.session.State.Member(guild.ID, discordID); err == nil && member.User != nil {
+			return member.User, nil
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-limiter:
+	}
+	return b.session.User(discordID, discordgo.WithContext(ctx))
+}
+
+// profileChanged reports whether the stored profile differs from Discord's
+func profileChanged(stored *discordpb.DiscordUser, current *discordgo.User) bool {
+	return stored.DiscordUsername != current.Username ||
+		stored.DiscordGlobalName != current.GlobalName ||
+		stored.AvatarHash != current.Avatar
+}
@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+	quotespb "github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	"github.com/devilmonastery/hivemind/bot/internal/bot/commands"
+	"github.com/devilmonastery/hivemind/bot/internal/bot/handlers"
+	"github.com/devilmonastery/hivemind/bot/internal/bot/outbound"
+)
+
+// quoteLeaderboardWindow is the rolling window the weekly quote leaderboard covers
+const quoteLeaderboardWindow = 7 * 24 * time.Hour
+
+// quoteLeaderboardLimit is the number of entries shown per weekly leaderboard
+const quoteLeaderboardLimit = 5
+
+// StartQuoteLeaderboard posts the weekly quote leaderboard to every guild that opted
+// in, at the configured weekday and hour (UTC)
+func (b *Bot) StartQuoteLeaderboard(ctx context.Context) {
+	day := b.config.Sync.QuoteLeaderboardDay()
+	hour := b.config.Sync.QuoteLeaderboardHour
+
+	b.log.Info("starting weekly quote leaderboard background job",
+		slog.String("weekday", day.String()),
+		slog.Int("hour_utc", hour))
+
+	for {
+		next := nextQuoteLeaderboardPost(time.Now(), day, hour)
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			b.log.Info("stopping weekly quote leaderboard background job")
+			return
+		case <-timer.C:
+			b.postQuoteLeaderboards(ctx, next)
+		}
+	}
+}
+
+// nextQuoteLeaderboardPost returns the first time strictly after now that falls on
+// the given weekday and hour in UTC
+func nextQuoteLeaderboardPost(now time.Time, day time.Weekday, hour int) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, time.UTC)
+	next = next.AddDate(0, 0, (int(day)-int(next.Weekday())+7)%7)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next
+}
+
+// postQuoteLeaderboards posts the leaderboard for the week ending at now to every guild
+func (b *Bot) postQuoteLeaderboards(ctx context.Context, now time.Time) {
+	b.log.Info("posting weekly quote leaderboards")
+
+	discordClient := discordpb.NewDiscordServiceClient(b.grpcClient.Conn())
+	quoteClient := quotespb.NewQuoteServiceClient(b.grpcClient.Conn())
+	since := timestamppb.New(now.Add(-quoteLeaderboardWindow))
+
+	posted := 0
+	for _, guild := range b.session.State.Guilds {
+		if b.postQuoteLeaderboard(ctx, discordClient, quoteClient, guild.ID, since) {
+			posted++
+		}
+	}
+
+	b.log.Info("finished posting weekly quote leaderboards", slog.Int("posted", posted))
+}
+
+// postQuoteLeaderboard posts one guild's weekly leaderboard to its announcement channel,
+// if the guild has quotes and weekly leaderboards enabled and saved a quote this week.
+// It reports whether a leaderboard was posted.
+func (b *Bot) postQuoteLeaderboard(ctx context.Context, discordClient discordpb.DiscordServiceClient, quoteClient quotespb.QuoteServiceClient, guildID string, since *timestamppb.Timestamp) bool {
+	settingsResp, err := discordClient.GetGuildSettings(ctx, &discordpb.GetGuildSettingsRequest{
+		GuildId: guildID,
+	})
+	if err != nil {
+		b.log.Debug("failed to fetch guild settings for quote leaderboard",
+			slog.String("guild_id", guildID),
+			slog.String("error", err.Error()))
+		return false
+	}
+
+	settings := settingsResp.GetSettings()
+	announcements := settings.GetAnnouncements()
+	if !announcements.GetEnabled() ||
+		!announcements.GetWeeklyQuoteLeaderboard() ||
+		announcements.GetChannelId() == "" ||
+		!commands.FeatureEnabled(settings.GetFeatures(), commands.FeatureQuotes) {
+		return false
+	}
+
+	stats, err := quoteClient.GetQuoteStats(ctx, &quotespb.GetQuoteStatsRequest{
+		GuildId: guildID,
+		Limit:   quoteLeaderboardLimit,
+		Since:   since,
+	})
+	if err != nil {
+		b.log.Error("failed to get weekly quote stats",
+			slog.String("guild_id", guildID),
+			slog.String("error", err.Error()))
+		return false
+	}
+	if stats.TotalQuotes == 0 {
+		return false
+	}
+
+	channelID := announcements.GetChannelId()
+	embed := handlers.WeeklyQuoteLeaderboardEmbed(stats, settings.GetAppearance())
+	err = outbound.Send(ctx, b.grpcClient, outbound.Action{
+		Operation: "quote_leaderboard",
+		GuildID:   guildID,
+		ChannelID: channelID,
+		Payload:   embed.Title,
+	}, b.log, func(opts ...discordgo.RequestOption) error {
+		_, sendErr := b.session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			// Render mentions as names without pinging anyone
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		}, opts...)
+		return sendErr
+	})
+	if err != nil {
+		b.log.Warn("failed to post weekly quote leaderboard",
+			slog.String("guild_id", guildID),
+			slog.String("channel_id", channelID),
+			slog.String("error", err.Error()))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextQuoteLeaderboardPost(t *testing.T) {
+	// 2024-06-05 is a Wednesday
+	wednesdayNoon := time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		day  time.Weekday
+		hour int
+		want time.Time
+	}{
+		{name: "later this week", now: wednesdayNoon, day: time.Friday, hour: 9, want: time.Date(2024, 6, 7, 9, 0, 0, 0, time.UTC)},
+		{name: "later today", now: wednesdayNoon, day: time.Wednesday, hour: 18, want: time.Date(2024, 6, 5, 18, 0, 0, 0, time.UTC)},
+		{name: "earlier today rolls to next week", now: wednesdayNoon, day: time.Wednesday, hour: 9, want: time.Date(2024, 6, 12, 9, 0, 0, 0, time.UTC)},
+		{name: "exactly now rolls to next week", now: wednesdayNoon, day: time.Wednesday, hour: 12, want: time.Date(2024, 6, 12, 12, 0, 0, 0, time.UTC)},
+		{name: "earlier in the week", now: wednesdayNoon, day: time.Monday, hour: 0, want: time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)},
+		{name: "converts to UTC", now: time.Date(2024, 6, 5, 22, 0, 0, 0, time.FixedZone("UTC-5", -5*3600)), day: time.Thursday, hour: 2, want: time.Date(2024, 6, 6, 2, 0, 0, 0, time.UTC).AddDate(0, 0, 7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextQuoteLeaderboardPost(tt.now, tt.day, tt.hour); !got.Equal(tt.want) {
+				t.Errorf("nextQuoteLeaderboardPost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/devilmonastery/hivemind/bot/internal/config"
+)
+
+// defaultWelcomeMessage is posted when bot.welcome.message isn't set
+const defaultWelcomeMessage = `👋 Thanks for adding Hivemind to **{{.GuildName}}**!
+
+• ` + "`/wiki`" + ` - build a shared wiki from your conversations
+• ` + "`/note`" + ` - keep private notes on messages and members
+• ` + "`/quote`" + ` - save memorable messages
+• ` + "`/hivemind`" + ` - server settings, for admins
+{{if .WebURL}}
+Browse everything on the web at {{.WebURL}}{{end}}{{if .DocsURL}}
+Docs: {{.DocsURL}}{{end}}`
+
+// welcomeData is what a welcome message template can use
+type welcomeData struct {
+	GuildName string
+	WebURL    string
+	DocsURL   string
+}
+
+// shouldWelcome reports whether a guild the gateway announced should be greeted: only
+// when UpsertGuild registered it for the first time, so reconnects, which resend every
+// guild, don't greet it again
+func shouldWelcome(cfg config.WelcomeConfig, created bool) bool {
+	return cfg.Enabled && created
+}
+
+// welcomeMessage renders the configured welcome template, or the default one, for a guild
+func welcomeMessage(cfg config.WelcomeConfig, guildName, webURL string) (string, error) {
+	text := cfg.Message
+	if text == "" {
+		text = defaultWelcomeMessage
+	}
+	tmpl, err := template.New("welcome").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse welcome message: %w", err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, welcomeData{GuildName: guildName, WebURL: webURL, DocsURL: cfg.DocsURL}); err != nil {
+		return "", fmt.Errorf("failed to render welcome message: %w", err)
+	}
+	return b.String(), nil
+}
+
+// sendWelcome greets a guild the bot has just joined in its system channel, falling
+// back to a DM to the owner when there's no system channel or posting there fails
+func (b *Bot) sendWelcome(s *discordgo.Session, guild *discordgo.Guild) {
+	content, err := welcomeMessage(b.config.Bot.Welcome, guild.Name, b.config.Backend.WebBaseURL)
+	if err != nil {
+		b.log.Error("failed to build welcome message",
+			slog.String("guild_id", guild.ID),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	if guild.SystemChannelID != "" {
+		_, err := s.ChannelMessageSend(guild.SystemChannelID, content)
+		if err == nil {
+			b.log.Info("sent welcome message",
+				slog.String("guild_id", guild.ID),
+				slog.String("channel_id", guild.SystemChannelID))
+			return
+		}
+		b.log.Warn("failed to post welcome message in system channel, trying the owner",
+			slog.String("guild_id", guild.ID),
+			slog.String("channel_id", guild.SystemChannelID),
+			slog.String("error", err.Error()))
+	}
+
+	if guild.OwnerID == "" {
+		return
+	}
+	dm, err := s.UserChannelCreate(guild.OwnerID)
+	if err == nil {
+		_, err = s.ChannelMessageSend(dm.ID, content)
+	}
+	if err != nil {
+		b.log.Warn("failed to send welcome message to guild owner",
+			slog.String("guild_id", guild.ID),
+			slog.String("owner_id", guild.OwnerID),
+			slog.String("error", err.Error()))
+		return
+	}
+	b.log.Info("sent welcome message to guild owner",
+		slog.String("guild_id", guild.ID),
+		slog.String("owner_id", guild.OwnerID))
+}
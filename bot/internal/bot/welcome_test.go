@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/bot/internal/config"
+)
+
+func TestShouldWelcome(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		created bool
+		want    bool
+	}{
+		{name: "first join", enabled: true, created: true, want: true},
+		{name: "reconnect", enabled: true, created: false, want: false},
+		{name: "disabled", enabled: false, created: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldWelcome(config.WelcomeConfig{Enabled: tt.enabled}, tt.created); got != tt.want {
+				t.Errorf("shouldWelcome() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWelcomeMessage(t *testing.T) {
+	got, err := welcomeMessage(config.WelcomeConfig{DocsURL: "https://docs.example.com"}, "Dragons", "https://hivemind.example.com")
+	if err != nil {
+		t.Fatalf("welcomeMessage() error = %v", err)
+	}
+	for _, want := range []string{"**Dragons**", "`/wiki`", "https://hivemind.example.com", "Docs: https://docs.example.com"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("default welcome message is missing %q:\n%s", want, got)
+		}
+	}
+
+	got, err = welcomeMessage(config.WelcomeConfig{}, "Dragons", "")
+	if err != nil {
+		t.Fatalf("welcomeMessage() without links error = %v", err)
+	}
+	if strings.Contains(got, "Docs:") || strings.Contains(got, "web at") {
+		t.Errorf("welcome message without links mentions them:\n%s", got)
+	}
+
+	custom := config.WelcomeConfig{Message: "Hi {{.GuildName}}, see {{.DocsURL}}", DocsURL: "https://docs.example.com"}
+	if got, _ := welcomeMessage(custom, "Dragons", ""); got != "Hi Dragons, see https://docs.example.com" {
+		t.Errorf("custom welcome message = %q", got)
+	}
+}
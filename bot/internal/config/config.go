@@ -2,7 +2,11 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
+	"text/template"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -13,29 +17,75 @@ type Config struct {
 	Backend  BackendConfig  `yaml:"backend"`
 	Logging  LoggingConfig  `yaml:"logging"`
 	Features FeaturesConfig `yaml:"features"`
+	Sync     SyncConfig     `yaml:"sync"`
+	Cache    CacheConfig    `yaml:"cache"`
 }
 
 // BotConfig holds Discord bot specific configuration
 type BotConfig struct {
-	Token         string `yaml:"token"`
-	ApplicationID string `yaml:"application_id"`
+	Token            string        `yaml:"token"`
+	ApplicationID    string        `yaml:"application_id"`
+	AllowedGuildIDs  []string      `yaml:"allowed_guild_ids"` // If set, the bot leaves and ignores every other guild
+	ReconnectTimeout time.Duration `yaml:"reconnect_timeout"` // How long to wait for a reopened gateway session before trying again
+	Welcome          WelcomeConfig `yaml:"welcome"`
+}
+
+// WelcomeConfig controls the message the bot posts when it is added to a guild it
+// hasn't been in before. It goes to the guild's system channel, or to the owner by
+// DM when the guild has none or the bot can't post there.
+type WelcomeConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Message string `yaml:"message"`  // text/template with .GuildName, .WebURL and .DocsURL; empty uses the built-in message
+	DocsURL string `yaml:"docs_url"` // Documentation linked from the message
+}
+
+// GuildAllowed reports whether the bot may serve guildID. An empty allowlist allows every guild.
+func (c BotConfig) GuildAllowed(guildID string) bool {
+	if len(c.AllowedGuildIDs) == 0 {
+		return true
+	}
+	for _, id := range c.AllowedGuildIDs {
+		if id == guildID {
+			return true
+		}
+	}
+	return false
 }
 
 // BackendConfig holds backend server connection details
 type BackendConfig struct {
-	GRPCHost     string `yaml:"grpc_host"`
-	GRPCPort     int    `yaml:"grpc_port"`
-	TLSEnabled   bool   `yaml:"tls_enabled"`
-	ServiceToken string `yaml:"service_token"`               // Service account token for bot auth
-	WebBaseURL   string `yaml:"web_base_url"`                // Base URL for web interface links
-	MetricsPort  int    `yaml:"metrics_port" default:"9100"` // Metrics server port
+	GRPCHost     string        `yaml:"grpc_host"`
+	GRPCPort     int           `yaml:"grpc_port"`
+	TLSEnabled   bool          `yaml:"tls_enabled"`
+	ServiceToken string        `yaml:"service_token"`               // Service account token for bot auth
+	WebBaseURL   string        `yaml:"web_base_url"`                // Base URL for web interface links
+	WebLabel     string        `yaml:"web_label"`                   // Name shown on web link buttons ("View on <label>")
+	MetricsPort  int           `yaml:"metrics_port" default:"9100"` // Metrics server port
+	Retry        RetryConfig   `yaml:"retry"`
+	CallTimeout  time.Duration `yaml:"call_timeout"` // Time allowed for each backend call attempt before it fails as slow
+}
+
+// RetryConfig controls retries of backend calls that fail with a transient error
+type RetryConfig struct {
+	MaxAttempts    int           `yaml:"max_attempts"`    // Total tries per call including the first; 1 disables retries
+	InitialBackoff time.Duration `yaml:"initial_backoff"` // Wait before the first retry
+	MaxBackoff     time.Duration `yaml:"max_backoff"`     // Cap on the wait between retries
 }
 
 // LoggingConfig holds logging configuration
 type LoggingConfig struct {
-	Level  string `yaml:"level"`
-	Format string `yaml:"format"`
-	Output string `yaml:"output"`
+	Level    string            `yaml:"level"`
+	Format   string            `yaml:"format"`
+	Output   string            `yaml:"output"`
+	Sampling LogSamplingConfig `yaml:"sampling"`
+}
+
+// LogSamplingConfig rate-limits repetitive Info logs; Warn and Error are never sampled
+type LogSamplingConfig struct {
+	Enabled    bool          `yaml:"enabled"`
+	Initial    int           `yaml:"initial"`    // Records per message per window before sampling kicks in
+	Thereafter int           `yaml:"thereafter"` // After that, emit every Nth record
+	Window     time.Duration `yaml:"window"`     // How long counts are kept before resetting
 }
 
 // ReactionsConfig holds emoji reaction configuration
@@ -54,6 +104,42 @@ type FeaturesConfig struct {
 	MaxNoteSize  int             `yaml:"max_note_size"`
 	MaxWikiSize  int             `yaml:"max_wiki_size"`
 	Reactions    ReactionsConfig `yaml:"reactions"` // Emoji reactions for saved content
+
+	// ReferencesPageSize is how many message references each page of the
+	// "Show all references" viewer lists (1-20)
+	ReferencesPageSize int `yaml:"references_page_size"`
+}
+
+// SyncConfig holds background sync job configuration
+type SyncConfig struct {
+	ProfileSyncInterval          time.Duration `yaml:"profile_sync_interval"`            // How often to refresh cached user profiles
+	ProfileSyncActiveWithin      time.Duration `yaml:"profile_sync_active_within"`       // Only refresh users seen within this window
+	ProfileSyncRequestsPerSecond int           `yaml:"profile_sync_requests_per_second"` // Discord API rate limit for profile lookups
+	QuoteLeaderboardWeekday      string        `yaml:"quote_leaderboard_weekday"`        // Day the weekly quote leaderboard is posted, e.g. "monday"
+	QuoteLeaderboardHour         int           `yaml:"quote_leaderboard_hour"`           // Hour (UTC, 0-23) the weekly quote leaderboard is posted
+}
+
+// QuoteLeaderboardDay returns the configured weekday for the weekly quote leaderboard.
+// Load validates it, so an unknown name only happens for hand-built configs (Monday).
+func (c SyncConfig) QuoteLeaderboardDay() time.Weekday {
+	day, _ := parseWeekday(c.QuoteLeaderboardWeekday)
+	return day
+}
+
+// parseWeekday parses a day name such as "monday", case-insensitively
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) {
+			return day, true
+		}
+	}
+	return time.Monday, false
+}
+
+// CacheConfig holds in-memory cache configuration
+type CacheConfig struct {
+	AutocompleteTTL  time.Duration `yaml:"autocomplete_ttl"`   // How long wiki/note titles are cached for autocomplete
+	GuildSettingsTTL time.Duration `yaml:"guild_settings_ttl"` // How long guild settings are cached between backend lookups
 }
 
 // Load reads the configuration from a YAML file
@@ -78,6 +164,29 @@ func Load(path string) (*Config, error) {
 	if cfg.Bot.ApplicationID == "" {
 		return nil, fmt.Errorf("bot.application_id is required")
 	}
+	if err := validateWebBaseURL(cfg.Backend.WebBaseURL); err != nil {
+		return nil, err
+	}
+	if _, err := template.New("welcome").Parse(cfg.Bot.Welcome.Message); err != nil {
+		return nil, fmt.Errorf("bot.welcome.message is not a valid template: %w", err)
+	}
+	if cfg.Sync.QuoteLeaderboardWeekday == "" {
+		cfg.Sync.QuoteLeaderboardWeekday = "monday"
+	}
+	if _, ok := parseWeekday(cfg.Sync.QuoteLeaderboardWeekday); !ok {
+		return nil, fmt.Errorf("sync.quote_leaderboard_weekday %q is not a day of the week", cfg.Sync.QuoteLeaderboardWeekday)
+	}
+	if cfg.Sync.QuoteLeaderboardHour < 0 || cfg.Sync.QuoteLeaderboardHour > 23 {
+		return nil, fmt.Errorf("sync.quote_leaderboard_hour must be between 0 and 23, got %d", cfg.Sync.QuoteLeaderboardHour)
+	}
+	// Twenty reference lines stay well inside Discord's embed description limit
+	if cfg.Features.ReferencesPageSize < 0 || cfg.Features.ReferencesPageSize > 20 {
+		return nil, fmt.Errorf("features.references_page_size must be between 1 and 20, got %d", cfg.Features.ReferencesPageSize)
+	}
+	// The profile sync paces itself with a ticker of one second divided by this rate
+	if cfg.Sync.ProfileSyncRequestsPerSecond < 0 {
+		return nil, fmt.Errorf("sync.profile_sync_requests_per_second must be positive, got %d", cfg.Sync.ProfileSyncRequestsPerSecond)
+	}
 
 	// Set defaults
 	if cfg.Logging.Level == "" {
@@ -92,6 +201,54 @@ func Load(path string) (*Config, error) {
 	if cfg.Features.MaxWikiSize == 0 {
 		cfg.Features.MaxWikiSize = 50000
 	}
+	if cfg.Features.ReferencesPageSize == 0 {
+		cfg.Features.ReferencesPageSize = 10
+	}
+	if cfg.Sync.ProfileSyncInterval == 0 {
+		cfg.Sync.ProfileSyncInterval = 6 * time.Hour
+	}
+	if cfg.Sync.ProfileSyncActiveWithin == 0 {
+		cfg.Sync.ProfileSyncActiveWithin = 30 * 24 * time.Hour
+	}
+	if cfg.Sync.ProfileSyncRequestsPerSecond == 0 {
+		cfg.Sync.ProfileSyncRequestsPerSecond = 5
+	}
+	if cfg.Backend.Retry.MaxAttempts == 0 {
+		cfg.Backend.Retry.MaxAttempts = 3
+	}
+	if cfg.Backend.Retry.InitialBackoff == 0 {
+		cfg.Backend.Retry.InitialBackoff = 200 * time.Millisecond
+	}
+	if cfg.Backend.Retry.MaxBackoff == 0 {
+		cfg.Backend.Retry.MaxBackoff = 2 * time.Second
+	}
+	if cfg.Backend.CallTimeout == 0 {
+		cfg.Backend.CallTimeout = 5 * time.Second
+	}
+	if cfg.Cache.AutocompleteTTL == 0 {
+		cfg.Cache.AutocompleteTTL = time.Minute
+	}
+	if cfg.Cache.GuildSettingsTTL == 0 {
+		cfg.Cache.GuildSettingsTTL = 30 * time.Second
+	}
+	if cfg.Bot.ReconnectTimeout <= 0 {
+		cfg.Bot.ReconnectTimeout = 30 * time.Second
+	}
 
 	return &cfg, nil
 }
+
+// validateWebBaseURL requires backend.web_base_url, if set, to be an absolute http(s) URL
+func validateWebBaseURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("backend.web_base_url %q is not a valid URL: %w", raw, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("backend.web_base_url %q must be an absolute http(s) URL, e.g. https://hivemind.example.com", raw)
+	}
+	return nil
+}
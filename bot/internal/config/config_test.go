@@ -0,0 +1,95 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGuildAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		guildID string
+		want    bool
+	}{
+		{name: "empty allowlist allows all", allowed: nil, guildID: "111", want: true},
+		{name: "listed guild", allowed: []string{"111", "222"}, guildID: "222", want: true},
+		{name: "unlisted guild", allowed: []string{"111", "222"}, guildID: "333", want: false},
+		{name: "empty guild ID", allowed: []string{"111"}, guildID: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := BotConfig{AllowedGuildIDs: tt.allowed}
+			if got := cfg.GuildAllowed(tt.guildID); got != tt.want {
+				t.Errorf("GuildAllowed(%q) = %v, want %v", tt.guildID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWebBaseURL(t *testing.T) {
+	tests := []struct {
+		raw     string
+		wantErr bool
+	}{
+		{raw: "", wantErr: false},
+		{raw: "https://wiki.example.com", wantErr: false},
+		{raw: "http://localhost:8080", wantErr: false},
+		{raw: "https://example.com/hivemind/", wantErr: false},
+		{raw: "wiki.example.com", wantErr: true},
+		{raw: "/relative/path", wantErr: true},
+		{raw: "ftp://example.com", wantErr: true},
+		{raw: "https://", wantErr: true},
+		{raw: "http://[::1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := validateWebBaseURL(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateWebBaseURL(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadProfileSyncRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    string
+		want    int
+		wantErr bool
+	}{
+		{name: "unset uses the default", rate: "", want: 5},
+		{name: "zero uses the default", rate: "0", want: 5},
+		{name: "configured", rate: "2", want: 2},
+		{name: "negative", rate: "-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "bot:\n  token: t\n  application_id: a\n"
+			if tt.rate != "" {
+				yaml += "sync:\n  profile_sync_requests_per_second: " + tt.rate + "\n"
+			}
+			path := filepath.Join(t.TempDir(), "bot.yaml")
+			if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			cfg, err := Load(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Load() succeeded with rate %s, want an error", tt.rate)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := cfg.Sync.ProfileSyncRequestsPerSecond; got != tt.want {
+				t.Errorf("ProfileSyncRequestsPerSecond = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,14 +12,23 @@ func NewClient(cfg *config.Config) (*client.Client, error) {
 	// Build server address from config
 	serverAddress := fmt.Sprintf("%s:%d", cfg.Backend.GRPCHost, cfg.Backend.GRPCPort)
 
-	// Create token manager if service token is provided
-	var tokenManager *StaticTokenManager
+	opts := client.Options{
+		Address:    serverAddress,
+		ServerName: cfg.Backend.GRPCHost,
+		Retry: &client.RetryPolicy{
+			MaxAttempts:    cfg.Backend.Retry.MaxAttempts,
+			InitialBackoff: cfg.Backend.Retry.InitialBackoff,
+			MaxBackoff:     cfg.Backend.Retry.MaxBackoff,
+		},
+		CallTimeout: cfg.Backend.CallTimeout,
+	}
+
+	// Authenticate with the service token if one is provided
 	if cfg.Backend.ServiceToken != "" {
-		tokenManager = NewStaticTokenManager(cfg.Backend.ServiceToken)
+		opts.TokenManager = NewStaticTokenManager(cfg.Backend.ServiceToken)
 	}
 
-	// Create gRPC client with or without authentication
-	grpcClient, err := client.NewClient(serverAddress, cfg.Backend.GRPCHost, tokenManager)
+	grpcClient, err := client.New(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gRPC client: %w", err)
 	}
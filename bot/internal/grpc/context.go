@@ -2,8 +2,12 @@ package grpc
 
 import (
 	"context"
+	"time"
 
+	"github.com/bwmarrin/discordgo"
 	"google.golang.org/grpc/metadata"
+
+	"github.com/devilmonastery/hivemind/internal/client"
 )
 
 const (
@@ -15,6 +19,12 @@ const (
 
 	// MetadataKeyDiscordUsername is the metadata key for Discord username (for logging)
 	MetadataKeyDiscordUsername = "x-discord-username"
+
+	// InteractionTokenLifetime is how long Discord accepts follow-ups to an interaction
+	InteractionTokenLifetime = 15 * time.Minute
+
+	// interactionRetryMargin leaves time to send the reply after the last retry
+	interactionRetryMargin = time.Minute
 )
 
 // WithDiscordContext adds Discord user context to a gRPC context
@@ -41,3 +51,14 @@ func WithDiscordContext(ctx context.Context, discordUserID, guildID, username st
 func WithDiscordUser(ctx context.Context, discordUserID string) context.Context {
 	return WithDiscordContext(ctx, discordUserID, "", "")
 }
+
+// WithInteractionDeadline stops backend retries in time for the interaction's
+// follow-up to be sent before its token expires. The interaction's creation time
+// is taken from its snowflake ID; an unparseable ID leaves ctx unchanged.
+func WithInteractionDeadline(ctx context.Context, interactionID string) context.Context {
+	created, err := discordgo.SnowflakeTimestamp(interactionID)
+	if err != nil {
+		return ctx
+	}
+	return client.WithRetryDeadline(ctx, created.Add(InteractionTokenLifetime-interactionRetryMargin))
+}
@@ -67,7 +67,7 @@ func (t *discordMetricsTransport) RoundTrip(req *http.Request) (*http.Response,
 
 	// Track errors
 	if err != nil || statusCode >= 400 {
-		errorType := classifyDiscordError(statusCode, err)
+		errorType := ClassifyDiscordError(statusCode, err)
 		metrics.DiscordAPIErrors.WithLabelValues(route, bucket, errorType).Inc()
 	}
 
@@ -131,8 +131,9 @@ func normalizeDiscordRoute(path string) string {
 	return normalized
 }
 
-// classifyDiscordError categorizes Discord API errors for metrics
-func classifyDiscordError(statusCode int, err error) string {
+// ClassifyDiscordError categorizes Discord API errors for metrics by HTTP status, or by err
+// for requests that got no response
+func ClassifyDiscordError(statusCode int, err error) string {
 	if err != nil {
 		errStr := err.Error()
 		switch {
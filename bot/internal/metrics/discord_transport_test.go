@@ -117,9 +117,9 @@ func TestClassifyDiscordError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := classifyDiscordError(tt.statusCode, tt.err)
+			result := ClassifyDiscordError(tt.statusCode, tt.err)
 			if result != tt.expected {
-				t.Errorf("classifyDiscordError(%d, %v) = %q, want %q", tt.statusCode, tt.err, result, tt.expected)
+				t.Errorf("ClassifyDiscordError(%d, %v) = %q, want %q", tt.statusCode, tt.err, result, tt.expected)
 			}
 		})
 	}
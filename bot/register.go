@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/spf13/cobra"
 
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
 	"github.com/devilmonastery/hivemind/bot/internal/bot/commands"
 	"github.com/devilmonastery/hivemind/bot/internal/config"
+	botgrpc "github.com/devilmonastery/hivemind/bot/internal/grpc"
 )
 
 func newRegisterCommand() *cobra.Command {
@@ -17,20 +21,26 @@ func newRegisterCommand() *cobra.Command {
 		configPath string
 		guildID    string
 		global     bool
-		cleanup    bool
+		clear      bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "register",
 		Short: "Register slash commands with Discord",
-		Long: `Register all slash commands with Discord API. 
-Use --guild for testing (instant), or --global for production (takes up to 1 hour to propagate).
-Use --cleanup to remove ALL commands without registering new ones (useful for fixing duplicates).
+		Long: `Register all slash commands with the Discord API.
+Commands are registered globally by default, which can take up to 1 hour to propagate.
+Use --guild to register them to a single guild instead, where they're available instantly (for development).
+Use --clear to remove all commands from the chosen scope (useful for fixing duplicates).
 
-Note: Using bulk overwrite to prevent duplicates. This replaces ALL commands atomically.`,
+Registered commands are compared with the bot's definitions first, and only the
+commands that were added, changed, or removed are sent to Discord.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			log := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
+			if global && guildID != "" {
+				return fmt.Errorf("--global and --guild can't be used together")
+			}
+
 			// Load configuration
 			cfg, err := config.Load(configPath)
 			if err != nil {
@@ -43,72 +53,108 @@ Note: Using bulk overwrite to prevent duplicates. This replaces ALL commands ato
 				return fmt.Errorf("failed to create Discord session: %w", err)
 			}
 
-			// Cleanup mode: remove all commands
-			if cleanup {
-				if global {
-					log.Info("removing all global commands")
-					_, err := session.ApplicationCommandBulkOverwrite(cfg.Bot.ApplicationID, "", []*discordgo.ApplicationCommand{})
-					if err != nil {
-						return fmt.Errorf("failed to remove global commands: %w", err)
-					}
-					log.Info("all global commands removed")
-				} else {
-					if guildID == "" {
-						return fmt.Errorf("--guild is required when not using --global")
-					}
-					log.Info("removing all guild commands", slog.String("guild_id", guildID))
-					_, err := session.ApplicationCommandBulkOverwrite(cfg.Bot.ApplicationID, guildID, []*discordgo.ApplicationCommand{})
-					if err != nil {
-						return fmt.Errorf("failed to remove guild commands: %w", err)
-					}
-					log.Info("all guild commands removed", slog.String("guild_id", guildID))
-				}
-				return nil
+			scope := slog.String("scope", "global")
+			if guildID != "" {
+				scope = slog.String("guild_id", guildID)
 			}
 
-			// Get command definitions
-			commandDefs := commands.GetDefinitions()
-
-			// Use bulk overwrite to atomically replace all commands (prevents duplicates)
-			if global {
-				log.Info("registering commands globally using bulk overwrite (may take up to 1 hour)",
-					slog.Int("command_count", len(commandDefs)))
-
-				registeredCmds, err := session.ApplicationCommandBulkOverwrite(cfg.Bot.ApplicationID, "", commandDefs)
-				if err != nil {
-					return fmt.Errorf("failed to bulk register commands: %w", err)
+			// Clearing is syncing to an empty set of commands
+			var wanted []*discordgo.ApplicationCommand
+			if !clear {
+				wanted = commands.GetDefinitions()
+				if guildID != "" {
+					// Guild-scoped commands can honor the guild's feature flags; global ones can't
+					wanted = filterByGuildFeatures(cmd.Context(), cfg, guildID, wanted, log)
 				}
+			}
 
-				for _, registeredCmd := range registeredCmds {
-					log.Info("registered command", slog.String("name", registeredCmd.Name))
-				}
-			} else {
-				if guildID == "" {
-					return fmt.Errorf("--guild is required when not using --global")
-				}
-				log.Info("registering commands for guild using bulk overwrite",
-					slog.String("guild_id", guildID),
-					slog.Int("command_count", len(commandDefs)))
+			current, err := session.ApplicationCommands(cfg.Bot.ApplicationID, guildID)
+			if err != nil {
+				return fmt.Errorf("failed to fetch registered commands: %w", err)
+			}
 
-				registeredCmds, err := session.ApplicationCommandBulkOverwrite(cfg.Bot.ApplicationID, guildID, commandDefs)
-				if err != nil {
-					return fmt.Errorf("failed to bulk register commands: %w", err)
-				}
+			plan := commands.Diff(current, wanted)
+			if plan.Empty() {
+				log.Info("registered commands are already up to date", scope, slog.Int("command_count", len(current)))
+				return nil
+			}
 
-				for _, registeredCmd := range registeredCmds {
-					log.Info("registered command", slog.String("name", registeredCmd.Name))
-				}
+			log.Info("syncing commands", scope,
+				slog.Int("create", len(plan.Create)),
+				slog.Int("update", len(plan.Update)),
+				slog.Int("delete", len(plan.Delete)))
+
+			if err := applyCommandPlan(session, cfg.Bot.ApplicationID, guildID, plan, log); err != nil {
+				return err
 			}
 
-			log.Info("command registration complete - old commands automatically removed")
+			if guildID == "" && !clear {
+				log.Info("command registration complete - global changes may take up to 1 hour to appear")
+			} else {
+				log.Info("command registration complete")
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&configPath, "config", "c", "configs/dev-bot.yaml", "path to configuration file")
-	cmd.Flags().StringVar(&guildID, "guild", "", "guild ID for guild-specific commands (instant, for testing)")
-	cmd.Flags().BoolVar(&global, "global", false, "register commands globally (slower, for production)")
-	cmd.Flags().BoolVar(&cleanup, "cleanup", false, "remove all commands without registering new ones")
+	cmd.Flags().StringVar(&guildID, "guild", "", "register to this guild only (instant, for development); default is global")
+	cmd.Flags().BoolVar(&clear, "clear", false, "remove all commands from the chosen scope instead of registering them")
+	cmd.Flags().BoolVar(&global, "global", false, "register commands globally")
+	cmd.Flags().BoolVar(&clear, "cleanup", false, "remove all commands from the chosen scope")
+	_ = cmd.Flags().MarkDeprecated("global", "global registration is the default; omit --guild instead")
+	_ = cmd.Flags().MarkDeprecated("cleanup", "use --clear instead")
 
 	return cmd
 }
+
+// applyCommandPlan sends the creates, updates, and deletes in plan to Discord
+func applyCommandPlan(session *discordgo.Session, appID, guildID string, plan commands.Plan, log *slog.Logger) error {
+	for _, def := range plan.Create {
+		if _, err := session.ApplicationCommandCreate(appID, guildID, def); err != nil {
+			return fmt.Errorf("failed to create command %s: %w", def.Name, err)
+		}
+		log.Info("created command", slog.String("name", def.Name))
+	}
+
+	for _, def := range plan.Update {
+		if _, err := session.ApplicationCommandEdit(appID, guildID, def.ID, def); err != nil {
+			return fmt.Errorf("failed to update command %s: %w", def.Name, err)
+		}
+		log.Info("updated command", slog.String("name", def.Name))
+	}
+
+	for _, def := range plan.Delete {
+		if err := session.ApplicationCommandDelete(appID, guildID, def.ID); err != nil {
+			return fmt.Errorf("failed to delete command %s: %w", def.Name, err)
+		}
+		log.Info("deleted command", slog.String("name", def.Name))
+	}
+
+	return nil
+}
+
+// filterByGuildFeatures drops commands for features disabled in the guild.
+// If the backend can't be reached, all commands are registered.
+func filterByGuildFeatures(ctx context.Context, cfg *config.Config, guildID string, defs []*discordgo.ApplicationCommand, log *slog.Logger) []*discordgo.ApplicationCommand {
+	grpcClient, err := botgrpc.NewClient(cfg)
+	if err != nil {
+		log.Warn("failed to connect to backend, registering all commands", slog.String("error", err.Error()))
+		return defs
+	}
+	defer grpcClient.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	discordClient := discordpb.NewDiscordServiceClient(grpcClient.Conn())
+	resp, err := discordClient.GetGuildSettings(ctx, &discordpb.GetGuildSettingsRequest{
+		GuildId: guildID,
+	})
+	if err != nil {
+		log.Warn("failed to fetch guild settings, registering all commands", slog.String("error", err.Error()))
+		return defs
+	}
+
+	return commands.FilterByFeature(defs, resp.GetSettings().GetFeatures())
+}
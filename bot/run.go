@@ -14,6 +14,7 @@ import (
 
 	"github.com/devilmonastery/hivemind/bot/internal/bot"
 	"github.com/devilmonastery/hivemind/bot/internal/config"
+	"github.com/devilmonastery/hivemind/internal/pkg/logger"
 )
 
 func newRunCommand() *cobra.Command {
@@ -44,14 +45,15 @@ func newRunCommand() *cobra.Command {
 			}
 
 			// Initialize logger
-			log := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			var handler slog.Handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 				Level: parseLogLevel(logLevel),
-			}))
+			})
 			if logFormat == "text" {
-				log = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+				handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 					Level: parseLogLevel(logLevel),
-				}))
+				})
 			}
+			log := slog.New(logger.NewSamplingHandler(handler, logger.SamplingConfig(cfg.Logging.Sampling)))
 
 			// Set as global default logger so slog.Default() returns this configured logger
 			slog.SetDefault(log)
@@ -118,7 +118,7 @@ func (p *GenericOIDCProvider) ValidateIDToken(ctx context.Context, idToken strin
 		}
 
 		return publicKey, nil
-	})
+	}, jwt.WithoutClaimsValidation()) // Time claims are checked below, with leeway for clock skew
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
@@ -133,6 +133,20 @@ func (p *GenericOIDCProvider) ValidateIDToken(ctx context.Context, idToken strin
 		return nil, fmt.Errorf("invalid claims format")
 	}
 
+	leeway := cfg.IDTokenClockSkew()
+	skewedClaim, skew, err := checkTimeClaims(mapClaims, time.Now(), leeway)
+	if err != nil {
+		return nil, err
+	}
+	if skewedClaim != "" {
+		// Only accepted because of the leeway; frequent warnings point at real drift
+		slog.Warn("accepted ID token within clock skew leeway",
+			slog.String("provider", p.name),
+			slog.String("claim", skewedClaim),
+			slog.Duration("skew", skew),
+			slog.Duration("leeway", leeway))
+	}
+
 	// Validate issuer
 	iss, _ := mapClaims["iss"].(string)
 	if iss != p.issuer && iss != discovery.Issuer {
@@ -244,6 +258,58 @@ func (p *GenericOIDCProvider) ValidateIDToken(ctx context.Context, idToken strin
 	return claims, nil
 }
 
+// checkTimeClaims checks an ID token's exp, iat, and nbf against now, allowing them to be
+// off by up to leeway. If the token is only valid because of the leeway it returns the
+// claim that was furthest off and by how much; otherwise the claim is empty.
+func checkTimeClaims(claims jwt.MapClaims, now time.Time, leeway time.Duration) (string, time.Duration, error) {
+	var skewedClaim string
+	var skew time.Duration
+	note := func(claim string, off time.Duration) {
+		if skewedClaim == "" || off > skew {
+			skewedClaim, skew = claim, off
+		}
+	}
+
+	exp, err := claims.GetExpirationTime()
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid exp claim: %w", err)
+	}
+	if exp != nil {
+		// Valid until exp, exclusive
+		if over := now.Sub(exp.Time); over >= 0 {
+			if over >= leeway {
+				return "", 0, fmt.Errorf("token expired %s ago", over.Round(time.Second))
+			}
+			note("exp", over)
+		}
+	}
+
+	for _, check := range []struct {
+		claim string
+		get   func() (*jwt.NumericDate, error)
+	}{
+		{"iat", claims.GetIssuedAt},
+		{"nbf", claims.GetNotBefore},
+	} {
+		t, err := check.get()
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid %s claim: %w", check.claim, err)
+		}
+		if t == nil {
+			continue
+		}
+		// Valid from the claim's time, inclusive
+		if ahead := t.Sub(now); ahead > 0 {
+			if ahead > leeway {
+				return "", 0, fmt.Errorf("token %s is %s in the future", check.claim, ahead.Round(time.Second))
+			}
+			note(check.claim, ahead)
+		}
+	}
+
+	return skewedClaim, skew, nil
+}
+
 // extractName extracts the display name from claims (provider-specific logic)
 func (p *GenericOIDCProvider) extractName(claims jwt.MapClaims) string {
 	// Try various name fields in order of preference
@@ -0,0 +1,77 @@
+package oidc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/devilmonastery/hivemind/internal/config"
+)
+
+func TestCheckTimeClaims(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration) float64 {
+		return float64(now.Add(offset).Unix())
+	}
+	leeway := 60 * time.Second
+
+	tests := []struct {
+		name      string
+		claims    jwt.MapClaims
+		leeway    time.Duration
+		wantClaim string
+		wantSkew  time.Duration
+		wantErr   bool
+	}{
+		{name: "fresh token", claims: jwt.MapClaims{"iat": at(-time.Minute), "exp": at(time.Hour)}, leeway: leeway},
+		{name: "no time claims", claims: jwt.MapClaims{}, leeway: leeway},
+		{name: "expired just inside leeway", claims: jwt.MapClaims{"exp": at(-59 * time.Second)}, leeway: leeway, wantClaim: "exp", wantSkew: 59 * time.Second},
+		{name: "expired at the leeway", claims: jwt.MapClaims{"exp": at(-60 * time.Second)}, leeway: leeway, wantErr: true},
+		{name: "expired outside leeway", claims: jwt.MapClaims{"exp": at(-2 * time.Minute)}, leeway: leeway, wantErr: true},
+		{name: "expires now without leeway", claims: jwt.MapClaims{"exp": at(0)}, wantErr: true},
+		{name: "issued just inside leeway", claims: jwt.MapClaims{"iat": at(30 * time.Second), "exp": at(time.Hour)}, leeway: leeway, wantClaim: "iat", wantSkew: 30 * time.Second},
+		{name: "issued at the leeway", claims: jwt.MapClaims{"iat": at(60 * time.Second)}, leeway: leeway, wantClaim: "iat", wantSkew: 60 * time.Second},
+		{name: "issued outside leeway", claims: jwt.MapClaims{"iat": at(61 * time.Second)}, leeway: leeway, wantErr: true},
+		{name: "issued in the future without leeway", claims: jwt.MapClaims{"iat": at(time.Second)}, wantErr: true},
+		{name: "not before inside leeway", claims: jwt.MapClaims{"nbf": at(10 * time.Second)}, leeway: leeway, wantClaim: "nbf", wantSkew: 10 * time.Second},
+		{name: "not before outside leeway", claims: jwt.MapClaims{"nbf": at(2 * time.Minute)}, leeway: leeway, wantErr: true},
+		{
+			name:      "furthest claim reported",
+			claims:    jwt.MapClaims{"iat": at(20 * time.Second), "nbf": at(40 * time.Second), "exp": at(time.Hour)},
+			leeway:    leeway,
+			wantClaim: "nbf",
+			wantSkew:  40 * time.Second,
+		},
+		{name: "malformed exp", claims: jwt.MapClaims{"exp": "tomorrow"}, leeway: leeway, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim, skew, err := checkTimeClaims(tt.claims, now, tt.leeway)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkTimeClaims() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if claim != tt.wantClaim || skew != tt.wantSkew {
+				t.Errorf("checkTimeClaims() = %q, %v, want %q, %v", claim, skew, tt.wantClaim, tt.wantSkew)
+			}
+		})
+	}
+}
+
+func TestIDTokenClockSkew(t *testing.T) {
+	tests := []struct {
+		clockSkew time.Duration
+		want      time.Duration
+	}{
+		{clockSkew: 0, want: config.DefaultIDTokenClockSkew},
+		{clockSkew: 30 * time.Second, want: 30 * time.Second},
+		{clockSkew: -1, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := (config.ProviderConfig{ClockSkew: tt.clockSkew}).IDTokenClockSkew(); got != tt.want {
+			t.Errorf("IDTokenClockSkew() with clock_skew %v = %v, want %v", tt.clockSkew, got, tt.want)
+		}
+	}
+}
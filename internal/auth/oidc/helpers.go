@@ -1,5 +1,7 @@
 package oidc
 
+import "strings"
+
 // isUserAllowed checks if a user is allowed based on domain and individual user allowlists
 func isUserAllowed(email, hostedDomain string, allowedDomains, allowedUsers []string) bool {
 	// If no restrictions are configured, allow all users
@@ -19,7 +21,7 @@ func isUserAllowed(email, hostedDomain string, allowedDomains, allowedUsers []st
 	// Check domain allowlist
 	if len(allowedDomains) > 0 {
 		// Check email domain
-		if isEmailInAllowedDomains(email, allowedDomains) {
+		if EmailDomainAllowed(email, allowedDomains, false) {
 			return true
 		}
 
@@ -32,9 +34,23 @@ func isUserAllowed(email, hostedDomain string, allowedDomains, allowedUsers []st
 	return false
 }
 
-func isEmailInAllowedDomains(email string, allowedDomains []string) bool {
-	for _, domain := range allowedDomains {
-		if len(email) > len(domain)+1 && email[len(email)-len(domain)-1:] == "@"+domain {
+// EmailDomainAllowed reports whether the domain of email is in allowedDomains.
+// Domains are compared case-insensitively. With matchSubdomains, an allowed
+// domain also admits its subdomains (example.com admits eng.example.com);
+// otherwise the domain must match exactly.
+func EmailDomainAllowed(email string, allowedDomains []string, matchSubdomains bool) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 || at == len(email)-1 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+
+	for _, allowed := range allowedDomains {
+		allowed = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(allowed), "@"))
+		if allowed == "" {
+			continue
+		}
+		if domain == allowed || (matchSubdomains && strings.HasSuffix(domain, "."+allowed)) {
 			return true
 		}
 	}
@@ -14,70 +14,143 @@ import (
 	authpb "github.com/devilmonastery/hivemind/api/generated/go/authpb"
 )
 
-// Client wraps gRPC clients with automatic token refresh
+// Client wraps a gRPC connection with automatic token refresh
 type Client struct {
 	conn         *grpc.ClientConn
 	tokenManager TokenManager
 	authClient   authpb.AuthServiceClient
 }
 
-// NewClient creates a new gRPC client with automatic token refresh
-// If tokenManager is nil, no auth interceptor will be added (useful for creating a base connection).
-// if serverName is not empty, it is used as the remote peer name.
-func NewClient(serverAddress string, serverName string, tokenManager TokenManager) (*Client, error) {
-	opts := []grpc.DialOption{
-		// Keep connections alive to prevent EOF errors
-		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			Time:                10 * time.Second, // Send keepalive ping every 10 seconds
-			Timeout:             3 * time.Second,  // Wait 3 seconds for ping ack
-			PermitWithoutStream: true,             // Allow pings when no active streams
-		}),
+// TLSMode selects the transport security used to reach the server
+type TLSMode int
+
+const (
+	// TLSAuto uses plaintext for localhost and cluster-internal addresses, TLS otherwise
+	TLSAuto TLSMode = iota
+	// TLSEnabled always uses TLS with the system certificate pool
+	TLSEnabled
+	// TLSDisabled always uses plaintext
+	TLSDisabled
+)
+
+// defaultKeepalive keeps idle connections alive to prevent EOF errors
+var defaultKeepalive = keepalive.ClientParameters{
+	Time:                10 * time.Second, // Send keepalive ping every 10 seconds
+	Timeout:             3 * time.Second,  // Wait 3 seconds for ping ack
+	PermitWithoutStream: true,             // Allow pings when no active streams
+}
+
+// Options configures a Client
+type Options struct {
+	// Address is the server's host:port
+	Address string
+
+	// ServerName overrides the name used for TLS verification and SNI.
+	// Defaults to the host part of Address.
+	ServerName string
+
+	// TLS selects transport security (default: TLSAuto)
+	TLS TLSMode
+
+	// TokenManager supplies the token sent with each RPC. If nil, calls are
+	// unauthenticated. Tokens with a token ID are refreshed when the server
+	// responds with Unauthenticated.
+	TokenManager TokenManager
+
+	// Keepalive overrides the default keepalive parameters
+	Keepalive *keepalive.ClientParameters
+
+	// Retry retries calls that fail with a transient error. If nil, calls are
+	// not retried.
+	Retry *RetryPolicy
+
+	// CallTimeout limits how long each attempt of a call may take. Zero means
+	// calls only end when their context does.
+	CallTimeout time.Duration
+
+	// Metrics records the count and latency of every call, including any
+	// retries, in the shared metrics registry
+	Metrics bool
+}
+
+// New creates a gRPC client configured by opts
+func New(opts Options) (*Client, error) {
+	dialOpts := dialOptions(opts)
+
+	// Metrics wrap everything else so latency covers the whole call
+	var interceptors []grpc.UnaryClientInterceptor
+	if opts.Metrics {
+		interceptors = append(interceptors, MetricsInterceptor())
 	}
 
-	// Auto-detect TLS based on server address
-	// Use TLS for production hosts, insecure for localhost
-	if isLocalhost(serverAddress) {
-		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	} else {
-		// Extract server name for SNI (remove port if present)
-		if serverName == "" {
-			if idx := strings.LastIndex(serverAddress, ":"); idx != -1 {
-				serverName = serverAddress[:idx]
-			}
-		}
+	// Retries wrap the auth interceptor so every attempt can refresh the token
+	if opts.Retry != nil {
+		interceptors = append(interceptors, RetryInterceptor(*opts.Retry))
+	}
 
-		// Use system certificates for TLS with proper SNI
-		creds := credentials.NewTLS(&tls.Config{
-			ServerName: serverName, // Required for SNI with virtual hosting
-			MinVersion: tls.VersionTLS12,
-		})
-		opts = append(opts, grpc.WithTransportCredentials(creds))
+	// The timeout sits inside the retries so each attempt gets its own, and a
+	// timed-out attempt can still be retried
+	if opts.CallTimeout > 0 {
+		interceptors = append(interceptors, TimeoutInterceptor(opts.CallTimeout))
 	}
 
-	// Only add interceptor if we have a token manager
-	if tokenManager != nil {
-		interceptor := NewAuthInterceptor(tokenManager, serverAddress)
-		opts = append(opts,
-			grpc.WithUnaryInterceptor(interceptor.Unary()),
-			grpc.WithStreamInterceptor(interceptor.Stream()),
-		)
+	// Attach the token to every call and refresh it on Unauthenticated
+	if opts.TokenManager != nil {
+		interceptor := NewAuthInterceptor(opts.TokenManager, opts)
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(NewTokenCredentials(opts.TokenManager)))
+		interceptors = append(interceptors, interceptor.Unary())
+	}
+
+	if len(interceptors) > 0 {
+		dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(interceptors...))
 	}
 
 	// Create connection with options
 	// Note: gRPC internally manages connection pooling, so creating multiple
 	// clients to the same address will reuse underlying connections
-	conn, err := grpc.NewClient(serverAddress, opts...)
+	conn, err := grpc.NewClient(opts.Address, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
 	}
 
 	return &Client{
 		conn:         conn,
-		tokenManager: tokenManager,
+		tokenManager: opts.TokenManager,
 		authClient:   authpb.NewAuthServiceClient(conn),
 	}, nil
 }
 
+// dialOptions returns the transport and keepalive options shared by every
+// connection to the server, without any authentication
+func dialOptions(opts Options) []grpc.DialOption {
+	params := defaultKeepalive
+	if opts.Keepalive != nil {
+		params = *opts.Keepalive
+	}
+	dialOpts := []grpc.DialOption{grpc.WithKeepaliveParams(params)}
+
+	useTLS := opts.TLS == TLSEnabled || (opts.TLS == TLSAuto && !isLocalhost(opts.Address))
+	if !useTLS {
+		return append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	// Extract server name for SNI (remove port if present)
+	serverName := opts.ServerName
+	if serverName == "" {
+		serverName = opts.Address
+		if idx := strings.LastIndex(opts.Address, ":"); idx != -1 {
+			serverName = opts.Address[:idx]
+		}
+	}
+
+	// Use system certificates for TLS with proper SNI
+	creds := credentials.NewTLS(&tls.Config{
+		ServerName: serverName, // Required for SNI with virtual hosting
+		MinVersion: tls.VersionTLS12,
+	})
+	return append(dialOpts, grpc.WithTransportCredentials(creds))
+}
+
 // isLocalhost checks if an address is localhost/127.0.0.1 or a cluster-internal address
 func isLocalhost(address string) bool {
 	lower := strings.ToLower(address)
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// TokenCredentials attaches the current token from a TokenManager to every RPC.
+// The token is read on each call, so a refreshed token is picked up immediately.
+type TokenCredentials struct {
+	tokenManager TokenManager
+}
+
+var _ credentials.PerRPCCredentials = (*TokenCredentials)(nil)
+
+// NewTokenCredentials creates per-RPC credentials backed by tokenManager
+func NewTokenCredentials(tokenManager TokenManager) *TokenCredentials {
+	return &TokenCredentials{tokenManager: tokenManager}
+}
+
+// GetRequestMetadata returns the authorization header for the current token
+func (c *TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := c.tokenManager.GetToken()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+// RequireTransportSecurity returns false so tokens can be sent to localhost
+// and cluster-internal addresses over plaintext
+func (c *TokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+type fakeTokenManager struct {
+	token   string
+	tokenID string
+	err     error
+}
+
+func (f *fakeTokenManager) GetToken() (string, error)             { return f.token, f.err }
+func (f *fakeTokenManager) GetTokenID() (string, error)           { return f.tokenID, nil }
+func (f *fakeTokenManager) SaveToken(token, tokenID string) error { f.token = token; return nil }
+func (f *fakeTokenManager) ClearToken() error                     { f.token = ""; return nil }
+
+func TestTokenCredentialsMetadata(t *testing.T) {
+	creds := NewTokenCredentials(&fakeTokenManager{token: "abc123"})
+
+	md, err := creds.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("GetRequestMetadata() error = %v", err)
+	}
+	if got, want := md["authorization"], "Bearer abc123"; got != want {
+		t.Errorf("authorization = %q, want %q", got, want)
+	}
+	if creds.RequireTransportSecurity() {
+		t.Error("RequireTransportSecurity() = true, want false")
+	}
+}
+
+func TestTokenCredentialsError(t *testing.T) {
+	wantErr := errors.New("no token")
+	creds := NewTokenCredentials(&fakeTokenManager{err: wantErr})
+
+	if _, err := creds.GetRequestMetadata(context.Background()); !errors.Is(err, wantErr) {
+		t.Errorf("GetRequestMetadata() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestClientSendsAuthorization(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+
+	var got []string
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		got = md.Get("authorization")
+		return handler(ctx, req)
+	}))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	defer server.Stop()
+
+	c, err := New(Options{
+		Address:      lis.Addr().String(),
+		TokenManager: &fakeTokenManager{token: "abc123"},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer c.Close()
+
+	if _, err := healthpb.NewHealthClient(c.Conn()).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != "Bearer abc123" {
+		t.Errorf("authorization metadata = %v, want [Bearer abc123]", got)
+	}
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	authpb "github.com/devilmonastery/hivemind/api/generated/go/authpb"
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
+)
+
+// refreshServer issues newToken from RefreshToken and rejects health checks
+// made with any other token
+type refreshServer struct {
+	authpb.UnimplementedAuthServiceServer
+
+	mu        sync.Mutex
+	newToken  string
+	failCalls bool
+	reauth    bool // Refreshes fail because the OAuth session is gone
+	checks    int
+	refreshes int
+}
+
+func (s *refreshServer) RefreshToken(ctx context.Context, req *authpb.RefreshTokenRequest) (*authpb.RefreshTokenResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshes++
+	if s.reauth {
+		return nil, rpcerr.New(codes.Unauthenticated, rpcerr.ReasonReauthRequired, "refresh token was rejected")
+	}
+	if req.TokenId != "token-id" {
+		return nil, status.Error(codes.Unauthenticated, "unknown token id")
+	}
+	return &authpb.RefreshTokenResponse{ApiToken: s.newToken}, nil
+}
+
+func (s *refreshServer) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if info.FullMethod == authpb.AuthService_RefreshToken_FullMethodName {
+		return handler(ctx, req)
+	}
+
+	s.mu.Lock()
+	s.checks++
+	failCalls := s.failCalls
+	s.mu.Unlock()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	auth := md.Get("authorization")
+	if failCalls || len(auth) != 1 || auth[0] != "Bearer "+s.newToken {
+		return nil, status.Error(codes.Unauthenticated, "token expired")
+	}
+	return handler(ctx, req)
+}
+
+func startRefreshServer(t *testing.T, srv *refreshServer) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	server := grpc.NewServer(grpc.UnaryInterceptor(srv.intercept))
+	authpb.RegisterAuthServiceServer(server, srv)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestInterceptorRefreshesAndRetries(t *testing.T) {
+	srv := &refreshServer{newToken: "new-token"}
+	tm := &fakeTokenManager{token: "old-token", tokenID: "token-id"}
+
+	c, err := New(Options{Address: startRefreshServer(t, srv), TokenManager: tm})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer c.Close()
+
+	if _, err := healthpb.NewHealthClient(c.Conn()).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if tm.token != "new-token" {
+		t.Errorf("saved token = %q, want %q", tm.token, "new-token")
+	}
+	if srv.checks != 2 || srv.refreshes != 1 {
+		t.Errorf("checks = %d, refreshes = %d, want 2 and 1", srv.checks, srv.refreshes)
+	}
+}
+
+func TestInterceptorRetriesOnlyOnce(t *testing.T) {
+	srv := &refreshServer{newToken: "new-token", failCalls: true}
+	tm := &fakeTokenManager{token: "old-token", tokenID: "token-id"}
+
+	c, err := New(Options{Address: startRefreshServer(t, srv), TokenManager: tm})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer c.Close()
+
+	_, err = healthpb.NewHealthClient(c.Conn()).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if !errors.Is(err, ErrLoginRequired) {
+		t.Errorf("Check() error = %v, want ErrLoginRequired", err)
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Check() code = %v, want Unauthenticated", status.Code(err))
+	}
+	if srv.checks != 2 || srv.refreshes != 1 {
+		t.Errorf("checks = %d, refreshes = %d, want 2 and 1", srv.checks, srv.refreshes)
+	}
+}
+
+func TestInterceptorRefreshFailure(t *testing.T) {
+	srv := &refreshServer{newToken: "new-token"}
+	tm := &fakeTokenManager{token: "old-token", tokenID: "revoked-id"}
+
+	c, err := New(Options{Address: startRefreshServer(t, srv), TokenManager: tm})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer c.Close()
+
+	_, err = healthpb.NewHealthClient(c.Conn()).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if !errors.Is(err, ErrLoginRequired) {
+		t.Errorf("Check() error = %v, want ErrLoginRequired", err)
+	}
+	if srv.checks != 1 {
+		t.Errorf("checks = %d, want 1", srv.checks)
+	}
+	// The refresh may have failed for a passing reason, so the token is kept
+	if tm.token != "old-token" {
+		t.Errorf("stored token = %q, want it kept", tm.token)
+	}
+}
+
+func TestInterceptorReauthRequiredClearsToken(t *testing.T) {
+	srv := &refreshServer{newToken: "new-token", reauth: true}
+	tm := &fakeTokenManager{token: "old-token", tokenID: "token-id"}
+
+	c, err := New(Options{Address: startRefreshServer(t, srv), TokenManager: tm})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer c.Close()
+
+	_, err = healthpb.NewHealthClient(c.Conn()).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if !errors.Is(err, ErrLoginRequired) {
+		t.Errorf("Check() error = %v, want ErrLoginRequired", err)
+	}
+	if tm.token != "" {
+		t.Errorf("stored token = %q, want it cleared", tm.token)
+	}
+}
+
+func TestInterceptorWithoutTokenID(t *testing.T) {
+	srv := &refreshServer{newToken: "new-token"}
+	tm := &fakeTokenManager{token: "service-token"}
+
+	c, err := New(Options{Address: startRefreshServer(t, srv), TokenManager: tm})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer c.Close()
+
+	_, err = healthpb.NewHealthClient(c.Conn()).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if status.Code(err) != codes.Unauthenticated || errors.Is(err, ErrLoginRequired) {
+		t.Errorf("Check() error = %v, want the server's Unauthenticated error", err)
+	}
+	if srv.refreshes != 0 {
+		t.Errorf("refreshes = %d, want 0", srv.refreshes)
+	}
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+// MetricsInterceptor returns a unary client interceptor that records the count
+// and latency of every call in the shared metrics registry
+func MetricsInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		metrics.RecordGRPCClientCall(method, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
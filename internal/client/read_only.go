@@ -0,0 +1,18 @@
+package client
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReadOnlyMessage starts the status message of calls a server in read-only
+// maintenance mode rejects with FailedPrecondition
+const ReadOnlyMessage = "server is in read-only maintenance mode"
+
+// IsReadOnly reports whether err is a call rejected because the server is in
+// read-only maintenance mode, as opposed to any other failed precondition
+func IsReadOnly(err error) bool {
+	return status.Code(err) == codes.FailedPrecondition && strings.Contains(status.Convert(err).Message(), ReadOnlyMessage)
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy configures retries of calls that fail with a transient error
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first (<= 1 disables retries)
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait between retries
+	MaxBackoff time.Duration
+
+	// Multiplier grows the backoff after each retry (default 2)
+	Multiplier float64
+}
+
+// DefaultRetryPolicy rides out a server restart without holding up callers for long
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+}
+
+// readMethodPrefixes are RPC name prefixes of calls that only read state
+var readMethodPrefixes = []string{
+	"Get", "List", "Search", "Autocomplete", "Check", "Validate", "Preview", "Suggest",
+}
+
+// idempotentMethods are the mutations that are safe to run more than once because they
+// set state outright rather than appending to it, counting, or notifying anyone. Upserts
+// and updates in general don't qualify; UpsertWikiPage, for one, records a revision and
+// sends webhooks on every call.
+var idempotentMethods = map[string]bool{
+	"UpsertGuild":             true,
+	"UpsertGuildMember":       true,
+	"UpsertGuildMembersBatch": true,
+}
+
+type retryDeadlineKey struct{}
+
+// WithRetryDeadline stops retries that would start after deadline. Unlike a
+// context deadline it never cuts off an attempt that is already in flight.
+func WithRetryDeadline(ctx context.Context, deadline time.Time) context.Context {
+	return context.WithValue(ctx, retryDeadlineKey{}, deadline)
+}
+
+// RetryInterceptor returns a unary client interceptor that retries
+// Unavailable and DeadlineExceeded errors with exponential backoff.
+// Mutations are only retried when listed in idempotentMethods.
+func RetryInterceptor(policy RetryPolicy) grpc.UnaryClientInterceptor {
+	if policy.Multiplier < 1 {
+		policy.Multiplier = 2
+	}
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if policy.MaxAttempts <= 1 || !isRetryableMethod(method) {
+			return err
+		}
+
+		backoff := policy.InitialBackoff
+		for attempt := 2; attempt <= policy.MaxAttempts && isTransient(ctx, err); attempt++ {
+			wait := jitter(backoff)
+			if !retryDeadlineAllows(ctx, wait) {
+				break
+			}
+
+			slog.Warn("retrying gRPC call after transient error",
+				slog.String("method", method),
+				slog.Int("attempt", attempt),
+				slog.Duration("backoff", wait),
+				slog.String("error", err.Error()))
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+
+			err = invoker(ctx, method, req, reply, cc, opts...)
+
+			backoff = time.Duration(float64(backoff) * policy.Multiplier)
+			if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+				backoff = policy.MaxBackoff
+			}
+		}
+
+		return err
+	}
+}
+
+// isTransient reports whether err is worth retrying. A DeadlineExceeded caused by
+// the caller's own context running out is final.
+func isTransient(ctx context.Context, err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return ctx.Err() == nil
+	}
+	return false
+}
+
+// isRetryableMethod reports whether method (e.g. "/hivemind.wiki.WikiService/GetWikiPage")
+// is a read or listed in idempotentMethods
+func isRetryableMethod(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, prefix := range readMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return idempotentMethods[name]
+}
+
+// retryDeadlineAllows reports whether a retry after wait still starts before
+// both the context deadline and any WithRetryDeadline deadline
+func retryDeadlineAllows(ctx context.Context, wait time.Duration) bool {
+	next := time.Now().Add(wait)
+	if deadline, ok := ctx.Deadline(); ok && !next.Before(deadline) {
+		return false
+	}
+	if deadline, ok := ctx.Value(retryDeadlineKey{}).(time.Time); ok && !next.Before(deadline) {
+		return false
+	}
+	return true
+}
+
+// jitter spreads retries from many callers over [d/2, d)
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)))
+}
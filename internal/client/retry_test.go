@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// flakyServer fails the first failures calls with code, then lets calls through
+type flakyServer struct {
+	mu       sync.Mutex
+	failures int
+	code     codes.Code
+	calls    int
+}
+
+func (s *flakyServer) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	s.mu.Lock()
+	s.calls++
+	fail := s.calls <= s.failures
+	s.mu.Unlock()
+
+	if fail {
+		return nil, status.Error(s.code, "server restarting")
+	}
+	return handler(ctx, req)
+}
+
+func (s *flakyServer) callCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func startFlakyServer(t *testing.T, srv *flakyServer) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	server := grpc.NewServer(grpc.UnaryInterceptor(srv.intercept))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func TestRetryInterceptorFlakyServer(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int
+		code      codes.Code
+		wantCode  codes.Code
+		wantCalls int
+	}{
+		{name: "recovers after restart", failures: 2, code: codes.Unavailable, wantCode: codes.OK, wantCalls: 3},
+		{name: "deadline exceeded is retried", failures: 1, code: codes.DeadlineExceeded, wantCode: codes.OK, wantCalls: 2},
+		{name: "gives up after max attempts", failures: 5, code: codes.Unavailable, wantCode: codes.Unavailable, wantCalls: 3},
+		{name: "permanent errors are not retried", failures: 5, code: codes.NotFound, wantCode: codes.NotFound, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &flakyServer{failures: tt.failures, code: tt.code}
+			policy := testRetryPolicy
+			c, err := New(Options{Address: startFlakyServer(t, srv), Retry: &policy})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			defer c.Close()
+
+			_, err = healthpb.NewHealthClient(c.Conn()).Check(context.Background(), &healthpb.HealthCheckRequest{})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Check() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if got := srv.callCount(); got != tt.wantCalls {
+				t.Errorf("server saw %d calls, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryInterceptorMutations(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		ctx       context.Context
+		wantCalls int
+	}{
+		{name: "read", method: "/hivemind.wiki.WikiService/GetWikiPage", ctx: context.Background(), wantCalls: 3},
+		{name: "mutation", method: "/hivemind.note.NoteService/CreateNote", ctx: context.Background(), wantCalls: 1},
+		{name: "idempotent mutation", method: "/hivemind.discord.DiscordService/UpsertGuildMember", ctx: context.Background(), wantCalls: 3},
+		{name: "upsert with side effects", method: "/hivemind.wiki.WikiService/UpsertWikiPage", ctx: context.Background(), wantCalls: 1},
+		{name: "update", method: "/hivemind.discord.DiscordService/UpdateGuildSettings", ctx: context.Background(), wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				calls++
+				return status.Error(codes.Unavailable, "down")
+			}
+
+			err := RetryInterceptor(testRetryPolicy)(tt.ctx, tt.method, nil, nil, nil, invoker)
+			if status.Code(err) != codes.Unavailable {
+				t.Errorf("code = %v, want Unavailable", status.Code(err))
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("invoker called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryInterceptorRespectsRetryDeadline(t *testing.T) {
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unavailable, "down")
+	}
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour}
+
+	ctx := WithRetryDeadline(context.Background(), time.Now().Add(time.Minute))
+	done := make(chan struct{})
+	go func() {
+		RetryInterceptor(policy)(ctx, "/hivemind.wiki.WikiService/GetWikiPage", nil, nil, nil, invoker)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("retry waited past the retry deadline")
+	}
+	if calls != 1 {
+		t.Errorf("invoker called %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// startHungServer starts a server whose calls never answer until the client gives up
+func startHungServer(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestCallTimeoutHungServer(t *testing.T) {
+	policy := testRetryPolicy
+	c, err := New(Options{Address: startHungServer(t), Retry: &policy, CallTimeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer c.Close()
+
+	start := time.Now()
+	_, err = healthpb.NewHealthClient(c.Conn()).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Check() code = %v, want %v", status.Code(err), codes.DeadlineExceeded)
+	}
+	// Each of the three attempts gets its own timeout
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Check() took %v, want it to give up after the per-attempt timeouts", elapsed)
+	}
+}
+
+func TestTimeoutInterceptorKeepsEarlierDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	want, _ := ctx.Deadline()
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		if got, _ := ctx.Deadline(); !got.Equal(want) {
+			t.Errorf("deadline = %v, want the caller's earlier %v", got, want)
+		}
+		return nil
+	}
+	if err := TimeoutInterceptor(time.Hour)(ctx, "/hivemind.wiki.WikiService/GetWikiPage", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("interceptor error = %v", err)
+	}
+}
@@ -7,12 +7,15 @@ import (
 
 // Config represents the application configuration
 type Config struct {
-	Database    DatabaseConfig `yaml:"database"`
-	GRPC        GRPCConfig     `yaml:"grpc"`
-	Auth        AuthConfig     `yaml:"auth"`
-	Logging     LoggingConfig  `yaml:"logging"`
-	Environment string         `yaml:"environment" default:"local"`       // local, dev, prod
-	VaultPath   string         `yaml:"vault_path" default:"/mnt/secrets"` // Path where Vault secrets are mounted
+	Database    DatabaseConfig    `yaml:"database"`
+	GRPC        GRPCConfig        `yaml:"grpc"`
+	Auth        AuthConfig        `yaml:"auth"`
+	Logging     LoggingConfig     `yaml:"logging"`
+	Content     ContentConfig     `yaml:"content"`
+	Maintenance MaintenanceConfig `yaml:"maintenance"`
+	IDGenerator IDGeneratorConfig `yaml:"id_generator"`
+	Environment string            `yaml:"environment" default:"local"`       // local, dev, prod
+	VaultPath   string            `yaml:"vault_path" default:"/mnt/secrets"` // Path where Vault secrets are mounted
 }
 
 // ServerConfig holds general server configuration
@@ -51,6 +54,15 @@ type AuthConfig struct {
 	EncryptionKey string           `yaml:"encryption_key"` // AES-256 key for encrypting refresh tokens (32 bytes base64)
 	DevBotToken   string           `yaml:"dev_bot_token"`  // Optional: Static bot token for development only (DO NOT USE IN PRODUCTION)
 	Providers     []ProviderConfig `yaml:"providers"`
+	Cleanup       CleanupConfig    `yaml:"cleanup"`
+}
+
+// CleanupConfig holds the background job that deletes expired and revoked API tokens
+// and expired OIDC sessions. When several servers share a database, only one runs it at a time.
+type CleanupConfig struct {
+	Interval         time.Duration `yaml:"interval"`          // Time between runs; zero or less disables cleanup
+	TokenRetention   time.Duration `yaml:"token_retention"`   // How long API tokens are kept after they expire or are revoked
+	SessionRetention time.Duration `yaml:"session_retention"` // How long OIDC sessions are kept after they expire
 }
 
 // JWTConfig holds JWT token configuration
@@ -67,10 +79,45 @@ type ProviderConfig struct {
 	ClientSecret   string   `yaml:"client_secret,omitempty"`         // OAuth client secret
 	Issuer         string   `yaml:"issuer,omitempty"`                // OIDC issuer URL (for discovery)
 	Scopes         []string `yaml:"scopes,omitempty"`                // OAuth scopes (e.g., ["openid", "email", "profile"])
-	AllowedDomains []string `yaml:"allowed_domains,omitempty"`       // Email domain allowlist
+	AllowedDomains []string `yaml:"allowed_domains,omitempty"`       // Email domain allowlist (empty = any domain)
 	AllowedUsers   []string `yaml:"allowed_users,omitempty"`         // Individual user email allowlist
 	AllowedOrgs    []string `yaml:"allowed_organizations,omitempty"` // GitHub orgs, etc.
 	AutoProvision  bool     `yaml:"auto_provision" default:"true"`   // Auto-create users on first login
+
+	// AllowSubdomains lets allowed_domains entries also admit their subdomains (example.com admits
+	// eng.example.com). By default the email domain must match an entry exactly.
+	AllowSubdomains bool `yaml:"allow_subdomains,omitempty"`
+
+	// Prompt is sent as the prompt parameter of the authorization URL (e.g. "consent", "login", "select_account").
+	// Defaults to "consent" when unset.
+	Prompt string `yaml:"prompt,omitempty"`
+
+	// AuthParams are extra query parameters added to the authorization URL (e.g. access_type: offline).
+	// They override prompt; an empty value removes the parameter. Core OAuth parameters can't be overridden.
+	AuthParams map[string]string `yaml:"auth_params,omitempty"`
+
+	// ClockSkew is how far ID token time claims (exp, iat, nbf) may be off from the server's
+	// clock, to allow for drift between the server and the IdP. Defaults to
+	// DefaultIDTokenClockSkew when unset; a negative value checks them exactly.
+	ClockSkew time.Duration `yaml:"clock_skew,omitempty"`
+}
+
+// DefaultOAuthPrompt is the prompt parameter used when a provider doesn't configure one
+const DefaultOAuthPrompt = "consent"
+
+// DefaultIDTokenClockSkew is the ID token clock skew allowed when a provider doesn't configure one
+const DefaultIDTokenClockSkew = 60 * time.Second
+
+// IDTokenClockSkew returns the clock skew allowed when checking the provider's ID token time claims
+func (c ProviderConfig) IDTokenClockSkew() time.Duration {
+	switch {
+	case c.ClockSkew == 0:
+		return DefaultIDTokenClockSkew
+	case c.ClockSkew < 0:
+		return 0
+	default:
+		return c.ClockSkew
+	}
 }
 
 // LoggingConfig holds logging configuration
@@ -78,6 +125,90 @@ type LoggingConfig struct {
 	Level  string `yaml:"level" default:"info"`    // debug, info, warn, error
 	Format string `yaml:"format" default:"json"`   // text, json
 	Output string `yaml:"output" default:"stdout"` // stdout, stderr, or file path
+
+	Sampling LogSamplingConfig `yaml:"sampling"`
+}
+
+// LogSamplingConfig rate-limits repetitive Info logs; Warn and Error are never sampled
+type LogSamplingConfig struct {
+	Enabled    bool          `yaml:"enabled"`
+	Initial    int           `yaml:"initial"`    // Records per message per window before sampling kicks in
+	Thereafter int           `yaml:"thereafter"` // After that, emit every Nth record
+	Window     time.Duration `yaml:"window"`     // How long counts are kept before resetting
+}
+
+// ContentConfig holds limits on user-submitted content.
+// Body lengths are in bytes; zero or less means no limit.
+type ContentConfig struct {
+	MaxWikiBodyLength  int `yaml:"max_wiki_body_length" default:"65536"`
+	MaxNoteBodyLength  int `yaml:"max_note_body_length" default:"16384"`
+	MaxQuoteBodyLength int `yaml:"max_quote_body_length" default:"4096"`
+
+	Moderation ModerationConfig `yaml:"moderation"`
+	Pagination PaginationConfig `yaml:"pagination"`
+}
+
+// PaginationConfig holds the page sizes for listing and searching each content type
+type PaginationConfig struct {
+	Wiki   PageLimits `yaml:"wiki"`
+	Notes  PageLimits `yaml:"notes"`
+	Quotes PageLimits `yaml:"quotes"`
+}
+
+// PageLimits bounds the limit clients may request from a list or search RPC.
+// DefaultLimit is used when no limit is given and larger requests are clamped to
+// MaxLimit; zero or less leaves either unset. SearchDefaultLimit replaces
+// DefaultLimit for search RPCs, whose results are ranked and usually read from the top;
+// zero or less falls back to DefaultLimit.
+type PageLimits struct {
+	DefaultLimit       int `yaml:"default_limit"`
+	SearchDefaultLimit int `yaml:"search_default_limit"`
+	MaxLimit           int `yaml:"max_limit"`
+}
+
+// Apply returns the page size to use for a client's requested limit
+func (l PageLimits) Apply(requested int) int {
+	limit := requested
+	if limit <= 0 {
+		limit = l.DefaultLimit
+	}
+	if l.MaxLimit > 0 && limit > l.MaxLimit {
+		limit = l.MaxLimit
+	}
+	return limit
+}
+
+// ApplySearch returns the page size to use for a client's requested search limit
+func (l PageLimits) ApplySearch(requested int) int {
+	if l.SearchDefaultLimit > 0 {
+		l.DefaultLimit = l.SearchDefaultLimit
+	}
+	return l.Apply(requested)
+}
+
+// ModerationConfig holds the blocklist checked before wiki pages, notes, and quotes are saved.
+// Moderation is off when no patterns are configured.
+type ModerationConfig struct {
+	Patterns []string `yaml:"patterns"`                // Regular expressions matched against titles and bodies
+	Policy   string   `yaml:"policy" default:"reject"` // reject: refuse the change; flag: save it and flag it for review
+}
+
+// Moderation policies
+const (
+	ModerationPolicyReject = "reject"
+	ModerationPolicyFlag   = "flag"
+)
+
+// MaintenanceConfig holds operator switches for maintenance windows
+type MaintenanceConfig struct {
+	ReadOnly bool `yaml:"read_only"` // Start with writes rejected; admins can toggle it at runtime
+}
+
+// IDGeneratorConfig holds Snowflake ID generation settings
+type IDGeneratorConfig struct {
+	// NodeID must be unique per running server; unset derives it from the Kubernetes pod
+	// name, or uses 1 outside Kubernetes. HIVEMIND_NODE_ID overrides it.
+	NodeID *int64 `yaml:"node_id"`
 }
 
 // ConnectionString returns the PostgreSQL connection string
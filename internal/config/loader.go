@@ -4,10 +4,15 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
 
+// NodeIDEnvVar overrides id_generator.node_id, so replicas can share a config file
+const NodeIDEnvVar = "HIVEMIND_NODE_ID"
+
 // expandEnvVars expands environment variables in the format ${VAR} or $VAR
 // Uses Go's built-in os.ExpandEnv which is the idiomatic way to handle this
 func expandEnvVars(data []byte) []byte {
@@ -43,6 +48,26 @@ func Load(configPath string) (*Config, error) {
 			Host: "localhost",
 			Port: 9091,
 		},
+		Auth: AuthConfig{
+			Cleanup: CleanupConfig{
+				Interval:         time.Hour,
+				TokenRetention:   7 * 24 * time.Hour,
+				SessionRetention: 24 * time.Hour,
+			},
+		},
+		Content: ContentConfig{
+			MaxWikiBodyLength:  64 * 1024,
+			MaxNoteBodyLength:  16 * 1024,
+			MaxQuoteBodyLength: 4 * 1024,
+			Moderation: ModerationConfig{
+				Policy: ModerationPolicyReject,
+			},
+			Pagination: PaginationConfig{
+				Wiki:   PageLimits{DefaultLimit: 50, SearchDefaultLimit: 10, MaxLimit: 100},
+				Notes:  PageLimits{DefaultLimit: 20, MaxLimit: 100},
+				Quotes: PageLimits{DefaultLimit: 20, MaxLimit: 100},
+			},
+		},
 	}
 
 	// If no config path is provided, search in default locations
@@ -68,9 +93,18 @@ func Load(configPath string) (*Config, error) {
 		slog.Debug("no config file found, using defaults")
 	}
 
-	// Validate configuration
-	if err := validate(config); err != nil {
-		return nil, err
+	// Environment variables take precedence
+	if envNodeID := os.Getenv(NodeIDEnvVar); envNodeID != "" {
+		nodeID, err := strconv.ParseInt(envNodeID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an integer: %w", NodeIDEnvVar, err)
+		}
+		config.IDGenerator.NodeID = &nodeID
+	}
+
+	// Check what every command needs; the server checks the rest with Validate
+	if problems := config.loadProblems(); len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
 	}
 
 	return config, nil
@@ -102,24 +136,3 @@ func fileExists(filename string) bool {
 	}
 	return !info.IsDir()
 }
-
-// validate performs basic validation on the configuration
-func validate(config *Config) error {
-	// Validate PostgreSQL configuration
-	if config.Database.Postgres.Host == "" {
-		return fmt.Errorf("postgres host is required")
-	}
-	if config.Database.Postgres.Database == "" {
-		return fmt.Errorf("postgres database name is required")
-	}
-	if config.Database.Postgres.User == "" {
-		return fmt.Errorf("postgres user is required")
-	}
-
-	// Validate GRPC port is reasonable
-	if config.GRPC.Port < 1 || config.GRPC.Port > 65535 {
-		return fmt.Errorf("grpc.port must be between 1 and 65535")
-	}
-
-	return nil
-}
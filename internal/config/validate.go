@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/devilmonastery/hivemind/internal/pkg/idgen"
+)
+
+// ValidationError lists every problem found in a configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+// Log levels and formats the logger understands; empty leaves the command-line default
+var (
+	validLogLevels  = []string{"", "debug", "info", "warn", "error"}
+	validLogFormats = []string{"", "text", "json"}
+	validSSLModes   = []string{"", "disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+)
+
+// Validate checks everything the server needs before it starts, returning a
+// *ValidationError that lists every problem rather than stopping at the first.
+// Load already rejects configs missing what every command needs; this adds the
+// settings only a running server uses, such as the JWT key and OAuth providers.
+// Local password login needs no configuration, so no provider is required.
+func (c *Config) Validate() error {
+	problems := c.loadProblems()
+
+	if c.Auth.JWT.SigningKey == "" {
+		problems = append(problems, "auth.jwt.signing_key is required")
+	}
+	if c.Auth.JWT.Lifetime <= 0 {
+		problems = append(problems, "auth.jwt.lifetime must be positive")
+	}
+
+	names := make(map[string]bool, len(c.Auth.Providers))
+	for idx, p := range c.Auth.Providers {
+		label := fmt.Sprintf("auth.providers[%d]", idx)
+		if p.Name == "" {
+			problems = append(problems, label+".name is required")
+		} else if names[p.Name] {
+			problems = append(problems, fmt.Sprintf("%s: provider %q is configured more than once", label, p.Name))
+		}
+		names[p.Name] = true
+		if p.ClientID == "" {
+			problems = append(problems, label+".client_id is required")
+		}
+		if p.Issuer == "" {
+			problems = append(problems, label+".issuer is required for OIDC discovery")
+		}
+	}
+
+	if !slices.Contains(validLogLevels, c.Logging.Level) {
+		problems = append(problems, fmt.Sprintf("logging.level %q must be one of debug, info, warn, error", c.Logging.Level))
+	}
+	if !slices.Contains(validLogFormats, c.Logging.Format) {
+		problems = append(problems, fmt.Sprintf("logging.format %q must be text or json", c.Logging.Format))
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// loadProblems lists problems with the settings every command, including the server's
+// CLI subcommands, needs to run
+func (c *Config) loadProblems() []string {
+	var problems []string
+
+	pg := c.Database.Postgres
+	if pg.Host == "" {
+		problems = append(problems, "database.postgres.host is required")
+	}
+	if pg.Port < 1 || pg.Port > 65535 {
+		problems = append(problems, "database.postgres.port must be between 1 and 65535")
+	}
+	if pg.Database == "" {
+		problems = append(problems, "database.postgres.database is required")
+	}
+	if pg.User == "" {
+		problems = append(problems, "database.postgres.user is required")
+	}
+	if !slices.Contains(validSSLModes, pg.SSLMode) {
+		problems = append(problems, fmt.Sprintf("database.postgres.sslmode %q is not a PostgreSQL sslmode", pg.SSLMode))
+	}
+
+	if c.GRPC.Port < 1 || c.GRPC.Port > 65535 {
+		problems = append(problems, "grpc.port must be between 1 and 65535")
+	}
+
+	switch c.Content.Moderation.Policy {
+	case ModerationPolicyReject, ModerationPolicyFlag:
+	default:
+		problems = append(problems, fmt.Sprintf("content.moderation.policy must be %q or %q", ModerationPolicyReject, ModerationPolicyFlag))
+	}
+
+	if cleanup := c.Auth.Cleanup; cleanup.TokenRetention < 0 || cleanup.SessionRetention < 0 {
+		problems = append(problems, "auth.cleanup.token_retention and auth.cleanup.session_retention must not be negative")
+	}
+
+	if nodeID := c.IDGenerator.NodeID; nodeID != nil {
+		if err := idgen.ValidateNodeID(*nodeID); err != nil {
+			problems = append(problems, fmt.Sprintf("id_generator.node_id: %v", err))
+		} else if *nodeID == idgen.CLINodeID {
+			problems = append(problems, fmt.Sprintf("id_generator.node_id: %d is reserved for server CLI commands", idgen.CLINodeID))
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/pkg/idgen"
+)
+
+// validConfig returns a config that passes Validate
+func validConfig() *Config {
+	return &Config{
+		Database: DatabaseConfig{Postgres: PostgresConfig{Host: "localhost", Port: 5432, Database: "hivemind", User: "postgres", SSLMode: "disable"}},
+		GRPC:     GRPCConfig{Host: "localhost", Port: 9091},
+		Auth: AuthConfig{
+			JWT: JWTConfig{SigningKey: "secret", Lifetime: time.Hour},
+			Providers: []ProviderConfig{
+				{Name: "google", ClientID: "client", Issuer: "https://accounts.google.com"},
+			},
+		},
+		Logging: LoggingConfig{Level: "info", Format: "json"},
+		Content: ContentConfig{Moderation: ModerationConfig{Policy: ModerationPolicyReject}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	reservedNodeID := idgen.CLINodeID
+
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   []string
+	}{
+		{name: "valid", modify: func(c *Config) {}},
+		{name: "no providers uses local login", modify: func(c *Config) { c.Auth.Providers = nil }},
+		{
+			name: "missing jwt settings",
+			modify: func(c *Config) {
+				c.Auth.JWT = JWTConfig{}
+			},
+			want: []string{"auth.jwt.signing_key is required", "auth.jwt.lifetime must be positive"},
+		},
+		{
+			name: "database connection",
+			modify: func(c *Config) {
+				c.Database.Postgres = PostgresConfig{Port: 70000, SSLMode: "sometimes"}
+			},
+			want: []string{
+				"database.postgres.host is required",
+				"database.postgres.port must be between 1 and 65535",
+				"database.postgres.database is required",
+				"database.postgres.user is required",
+				`database.postgres.sslmode "sometimes" is not a PostgreSQL sslmode`,
+			},
+		},
+		{
+			name: "providers",
+			modify: func(c *Config) {
+				c.Auth.Providers = append(c.Auth.Providers,
+					ProviderConfig{Name: "google", ClientID: "other", Issuer: "https://accounts.google.com"},
+					ProviderConfig{},
+				)
+			},
+			want: []string{
+				`auth.providers[1]: provider "google" is configured more than once`,
+				"auth.providers[2].name is required",
+				"auth.providers[2].client_id is required",
+				"auth.providers[2].issuer is required for OIDC discovery",
+			},
+		},
+		{
+			name: "logging",
+			modify: func(c *Config) {
+				c.Logging.Level = "verbose"
+				c.Logging.Format = "xml"
+			},
+			want: []string{
+				`logging.level "verbose" must be one of debug, info, warn, error`,
+				`logging.format "xml" must be text or json`,
+			},
+		},
+		{
+			name: "problems from every section are reported together",
+			modify: func(c *Config) {
+				c.GRPC.Port = 0
+				c.Auth.JWT.SigningKey = ""
+				c.Content.Moderation.Policy = "shadowban"
+				c.IDGenerator.NodeID = &reservedNodeID
+			},
+			want: []string{
+				"grpc.port must be between 1 and 65535",
+				`content.moderation.policy must be "reject" or "flag"`,
+				fmt.Sprintf("id_generator.node_id: %d is reserved for server CLI commands", idgen.CLINodeID),
+				"auth.jwt.signing_key is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+			}
+			if !slices.Equal(validationErr.Problems, tt.want) {
+				t.Errorf("Validate() problems =\n%q\nwant\n%q", validationErr.Problems, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadLeavesServerSettingsToValidate(t *testing.T) {
+	dir := t.TempDir()
+
+	// CLI commands load configs without a JWT key, so Load mustn't require one
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("grpc:\n  port: 9091\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	var validationErr *ValidationError
+	if err := cfg.Validate(); !errors.As(err, &validationErr) || !slices.Contains(validationErr.Problems, "auth.jwt.signing_key is required") {
+		t.Errorf("Validate() error = %v, want the missing signing key reported", err)
+	}
+
+	// Settings every command needs are still rejected when loading
+	path = filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(path, []byte("grpc:\n  port: 0\ncontent:\n  moderation:\n    policy: shadowban\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = Load(path)
+	if !errors.As(err, &validationErr) || len(validationErr.Problems) != 2 {
+		t.Errorf("Load() error = %v, want both problems reported", err)
+	}
+}
@@ -51,6 +51,31 @@ const (
 	ActionSnippetUpdated AuditAction = "snippet.updated"
 	ActionSnippetDeleted AuditAction = "snippet.deleted"
 
+	// Wiki page actions
+	ActionWikiPageCreated              AuditAction = "wiki_page.created"
+	ActionWikiPageUpdated              AuditAction = "wiki_page.updated"
+	ActionWikiPageDeleted              AuditAction = "wiki_page.deleted"
+	ActionWikiPageMerged               AuditAction = "wiki_page.merged"
+	ActionWikiPageUnmerged             AuditAction = "wiki_page.unmerged"
+	ActionWikiPageOwnershipTransferred AuditAction = "wiki_page.ownership_transferred"
+	ActionWikiPagePublished            AuditAction = "wiki_page.published"
+
+	// Note actions
+	ActionNoteCreated AuditAction = "note.created"
+	ActionNoteUpdated AuditAction = "note.updated"
+	ActionNoteDeleted AuditAction = "note.deleted"
+
+	// Quote actions
+	ActionQuoteCreated AuditAction = "quote.created"
+	ActionQuoteUpdated AuditAction = "quote.updated"
+	ActionQuoteDeleted AuditAction = "quote.deleted"
+
+	// Guild actions
+	ActionGuildContentMoved AuditAction = "guild.content_moved"
+
+	// Content actions
+	ActionContentAuthorReassigned AuditAction = "content.author_reassigned"
+
 	// System actions
 	ActionSystemStartup  AuditAction = "system.startup"
 	ActionSystemShutdown AuditAction = "system.shutdown"
@@ -65,6 +90,10 @@ const (
 	ResourceToken       AuditResource = "token"
 	ResourceOIDCSession AuditResource = "oidc_session"
 	ResourceSnippet     AuditResource = "snippet"
+	ResourceWikiPage    AuditResource = "wiki_page"
+	ResourceNote        AuditResource = "note"
+	ResourceQuote       AuditResource = "quote"
+	ResourceGuild       AuditResource = "guild"
 	ResourceSystem      AuditResource = "system"
 )
 
@@ -2,27 +2,51 @@ package entities
 
 import "time"
 
+// WikiPageStatus is whether a wiki page is a draft or published
+type WikiPageStatus string
+
+const (
+	WikiPagePublished WikiPageStatus = "published"
+	WikiPageDraft     WikiPageStatus = "draft" // Only listed and searchable for its author until published
+)
+
 // WikiPage represents a guild knowledge base article
 type WikiPage struct {
-	ID                string     `json:"id"`
-	Title             string     `json:"title"`
-	Slug              string     `json:"slug"`
-	Body              string     `json:"body"`
-	AuthorID          string     `json:"author_id"`
-	AuthorDisplayName string     `json:"author_display_name,omitempty"` // Resolved display name from view
-	GuildID           string     `json:"guild_id"`
-	GuildName         string     `json:"guild_name,omitempty"`
-	ChannelID         string     `json:"channel_id,omitempty"`
-	Tags              []string   `json:"tags,omitempty"`
-	CreatedAt         time.Time  `json:"created_at"`
-	UpdatedAt         time.Time  `json:"updated_at"`
-	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
+	ID                string `json:"id"`
+	Title             string `json:"title"`
+	Slug              string `json:"slug"`
+	Body              string `json:"body"`
+	AuthorID          string `json:"author_id"`
+	AuthorDisplayName string `json:"author_display_name,omitempty"` // Resolved display name from view
+	// LastEditorID is the user who last updated the page, empty if it hasn't been edited
+	LastEditorID          string         `json:"last_editor_id,omitempty"`
+	LastEditorDisplayName string         `json:"last_editor_display_name,omitempty"` // Only set by GetByID
+	GuildID               string         `json:"guild_id"`
+	GuildName             string         `json:"guild_name,omitempty"`
+	ChannelID             string         `json:"channel_id,omitempty"`
+	Tags                  []string       `json:"tags,omitempty"`
+	Pinned                bool           `json:"pinned,omitempty"` // Pinned pages sort before all others in lists
+	Status                WikiPageStatus `json:"status,omitempty"` // Empty is treated as published
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+	DeletedAt             *time.Time     `json:"deleted_at,omitempty"`
+	Snippet               string         `json:"snippet,omitempty"`         // Highlighted excerpt, only set by search
+	Rank                  float32        `json:"rank,omitempty"`            // Search relevance, only set by search
+	BodyLength            int            `json:"body_length,omitempty"`     // Characters in the body, only set by list and search
+	TagCount              int            `json:"tag_count,omitempty"`       // Only set by list and search
+	ReferenceCount        int            `json:"reference_count,omitempty"` // Message references, only set by list and search
+}
+
+// IsDraft reports whether the page hasn't been published yet
+func (p *WikiPage) IsDraft() bool {
+	return p.Status == WikiPageDraft
 }
 
 // Note represents a private user note
 type Note struct {
 	ID                string     `json:"id"`
 	Title             string     `json:"title,omitempty"`
+	Slug              string     `json:"slug,omitempty"` // Permalink slug, unique within the guild (or the author's personal notes)
 	Body              string     `json:"body"`
 	AuthorID          string     `json:"author_id"`
 	AuthorDisplayName string     `json:"author_display_name,omitempty"` // Resolved display name from view
@@ -35,11 +59,20 @@ type Note struct {
 	CreatedAt         time.Time  `json:"created_at"`
 	UpdatedAt         time.Time  `json:"updated_at"`
 	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
+	Snippet           string     `json:"snippet,omitempty"`         // Highlighted excerpt, only set by search
+	Rank              float32    `json:"rank,omitempty"`            // Search relevance, only set by search
+	BodyLength        int        `json:"body_length,omitempty"`     // Characters in the body, only set by list and search
+	TagCount          int        `json:"tag_count,omitempty"`       // Only set by list and search
+	ReferenceCount    int        `json:"reference_count,omitempty"` // Message references, only set by list and search
+	// UniqueTitle makes a save fail if the author has another live note in the guild with
+	// the same title, ignoring case. Set when the guild requires unique note titles.
+	UniqueTitle bool `json:"-"`
 }
 
 // Quote represents a saved memorable message from Discord
 type Quote struct {
 	ID                             string     `json:"id"`
+	ShortCode                      string     `json:"short_code,omitempty"` // Permalink code, unique within the guild
 	Body                           string     `json:"body"`
 	AuthorID                       string     `json:"author_id"`         // Who saved the quote (internal user ID)
 	AuthorDiscordID                string     `json:"author_discord_id"` // Discord ID of who saved the quote
@@ -63,6 +96,38 @@ type Quote struct {
 	Tags                           []string   `json:"tags,omitempty"`
 	CreatedAt                      time.Time  `json:"created_at"`
 	DeletedAt                      *time.Time `json:"deleted_at,omitempty"`
+	Snippet                        string     `json:"snippet,omitempty"`     // Highlighted excerpt, only set by search
+	Rank                           float32    `json:"rank,omitempty"`        // Search relevance, only set by search
+	BodyLength                     int        `json:"body_length,omitempty"` // Characters in the body, only set by list and search
+	TagCount                       int        `json:"tag_count,omitempty"`   // Only set by list and search
+	// Cooldown makes a save fail if the author saved another quote in the guild less
+	// than this long ago. Set when the guild has a quote cooldown.
+	Cooldown time.Duration `json:"-"`
+}
+
+// QuoteStats summarizes quoting activity in a guild
+type QuoteStats struct {
+	TotalQuotes int               `json:"total_quotes"`
+	TopQuoters  []QuoteStatsEntry `json:"top_quoters"` // By author_discord_id (who saved the quote)
+	MostQuoted  []QuoteStatsEntry `json:"most_quoted"` // By source_msg_author_discord_id (who said it)
+}
+
+// QuoteStatsEntry is one row of a quote leaderboard
+type QuoteStatsEntry struct {
+	DiscordID string `json:"discord_id"`
+	Count     int    `json:"count"`
+}
+
+// FlaggedContent is a wiki page, note, or quote that content moderation flagged for review
+type FlaggedContent struct {
+	ContentType AuditResource `json:"content_type"` // ResourceWikiPage, ResourceNote, or ResourceQuote
+	ID          string        `json:"id"`
+	GuildID     string        `json:"guild_id,omitempty"`
+	AuthorID    string        `json:"author_id"`
+	Title       string        `json:"title,omitempty"` // Empty for quotes
+	Body        string        `json:"body"`
+	Reason      string        `json:"reason"` // The moderation pattern the content matched
+	FlaggedAt   time.Time     `json:"flagged_at"`
 }
 
 // WikiMessageReference represents a Discord message tagged with a wiki page topic
@@ -125,3 +190,120 @@ type AttachmentMetadata struct {
 	Height      int    `json:"height,omitempty"` // For images/videos
 	Size        int64  `json:"size,omitempty"`   // File size in bytes
 }
+
+// WikiMergeLog records a wiki page merge with enough state to undo it
+type WikiMergeLog struct {
+	ID               string                  `json:"id"`
+	GuildID          string                  `json:"guild_id"`
+	SourcePageID     string                  `json:"source_page_id"`
+	TargetPageID     string                  `json:"target_page_id"`
+	MergedByUserID   string                  `json:"merged_by_user_id,omitempty"`
+	SourcePage       *WikiPage               `json:"source_page"`       // Source page as it was before the merge
+	SourceTitles     []*WikiTitle            `json:"source_titles"`     // Canonical title and aliases that pointed at the source
+	SourceReferences []*WikiMessageReference `json:"source_references"` // Message references attached to the source
+	TargetBodyBefore string                  `json:"target_body_before"`
+	TargetTagsBefore []string                `json:"target_tags_before,omitempty"`
+	MergedBody       string                  `json:"merged_body"` // Target body right after the merge, to detect later edits
+	MergedAt         time.Time               `json:"merged_at"`
+	UndoneAt         *time.Time              `json:"undone_at,omitempty"`
+}
+
+// Activity item types
+const (
+	ActivityTypeWiki  = "wiki"
+	ActivityTypeNote  = "note"
+	ActivityTypeQuote = "quote"
+)
+
+// Activity actions
+const (
+	ActivityActionCreated = "created"
+	ActivityActionUpdated = "updated"
+)
+
+// ActivityItem is a created or updated wiki page, note, or quote in the activity feed
+type ActivityItem struct {
+	Type       string    `json:"type"`   // ActivityTypeWiki, ActivityTypeNote, or ActivityTypeQuote
+	ID         string    `json:"id"`     // ID of the wiki page, note, or quote
+	Action     string    `json:"action"` // ActivityActionCreated or ActivityActionUpdated
+	GuildID    string    `json:"guild_id,omitempty"`
+	GuildName  string    `json:"guild_name,omitempty"`
+	Title      string    `json:"title,omitempty"`
+	Slug       string    `json:"slug,omitempty"` // Wiki pages only
+	Snippet    string    `json:"snippet,omitempty"`
+	AuthorName string    `json:"author_name,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// RecentlyViewedItem is a wiki page, note, or quote from a user's view history
+type RecentlyViewedItem struct {
+	Type      string    `json:"type"` // ActivityTypeWiki, ActivityTypeNote, or ActivityTypeQuote
+	ID        string    `json:"id"`
+	GuildID   string    `json:"guild_id,omitempty"` // Empty for personal notes
+	GuildName string    `json:"guild_name,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Slug      string    `json:"slug,omitempty"` // Permalink key: wiki page or note slug, or quote short code
+	Snippet   string    `json:"snippet,omitempty"`
+	ViewedAt  time.Time `json:"viewed_at"`
+}
+
+// Content types that can be moved between guilds
+const (
+	ContentTypeWiki  = "wiki"
+	ContentTypeNote  = "note"
+	ContentTypeQuote = "quote"
+)
+
+// GuildContentMove reports what moving content between guilds changed, or would change in a dry run.
+// Soft-deleted content is counted and moved too, so it can still be restored afterwards.
+type GuildContentMove struct {
+	SourceGuildID     string   `json:"source_guild_id"`
+	TargetGuildID     string   `json:"target_guild_id"`
+	ContentTypes      []string `json:"content_types"` // ContentType* values
+	DryRun            bool     `json:"dry_run"`
+	WikiPages         int      `json:"wiki_pages"`
+	WikiTitles        int      `json:"wiki_titles"`
+	WikiReferences    int      `json:"wiki_references"`
+	Notes             int      `json:"notes"`
+	NoteReferences    int      `json:"note_references"`
+	Quotes            int      `json:"quotes"`
+	ConflictingTitles []string `json:"conflicting_titles,omitempty"` // Wiki title slugs already used in the target guild
+
+	ConflictingNoteSlugs  []string `json:"conflicting_note_slugs,omitempty"`  // Note slugs already used in the target guild
+	ConflictingNoteTitles []string `json:"conflicting_note_titles,omitempty"` // Unique note titles their author already used in the target guild
+	ConflictingQuoteCodes []string `json:"conflicting_quote_codes,omitempty"` // Quote short codes already used in the target guild
+}
+
+// HasConflicts reports whether anything to be moved is already used in the target guild
+func (m *GuildContentMove) HasConflicts() bool {
+	return len(m.ConflictingTitles) > 0 || len(m.ConflictingNoteSlugs) > 0 || len(m.ConflictingNoteTitles) > 0 ||
+		len(m.ConflictingQuoteCodes) > 0
+}
+
+// Conflicts describes each conflict, e.g. "note slug todo", for error messages
+func (m *GuildContentMove) Conflicts() []string {
+	var conflicts []string
+	for _, slug := range m.ConflictingTitles {
+		conflicts = append(conflicts, "wiki title "+slug)
+	}
+	for _, slug := range m.ConflictingNoteSlugs {
+		conflicts = append(conflicts, "note slug "+slug)
+	}
+	for _, title := range m.ConflictingNoteTitles {
+		conflicts = append(conflicts, "note title "+title)
+	}
+	for _, code := range m.ConflictingQuoteCodes {
+		conflicts = append(conflicts, "quote "+code)
+	}
+	return conflicts
+}
+
+// AuthorReassignment reports a wiki page, note, or quote whose author an admin changed
+type AuthorReassignment struct {
+	ContentType      AuditResource `json:"content_type"` // ResourceWikiPage, ResourceNote, or ResourceQuote
+	ID               string        `json:"id"`
+	GuildID          string        `json:"guild_id,omitempty"` // Empty for personal notes
+	PreviousAuthorID string        `json:"previous_author_id"`
+	AuthorID         string        `json:"author_id"`
+	AuthorName       string        `json:"author_name,omitempty"` // New author's display name
+}
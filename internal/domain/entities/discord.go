@@ -58,3 +58,16 @@ func (m *GuildMember) DisplayName() string {
 	}
 	return ""
 }
+
+// DeadLetter is an outbound Discord call the bot dropped after exhausting its retries
+type DeadLetter struct {
+	ID          string    `json:"id" db:"id"`
+	Operation   string    `json:"operation" db:"operation"`
+	GuildID     *string   `json:"guild_id,omitempty" db:"guild_id"`
+	ChannelID   *string   `json:"channel_id,omitempty" db:"channel_id"`
+	FailureType string    `json:"failure_type" db:"failure_type"`
+	Error       string    `json:"error" db:"error"`
+	Attempts    int       `json:"attempts" db:"attempts"`
+	Payload     string    `json:"payload" db:"payload"` // Summary of what was being sent
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
@@ -74,3 +74,13 @@ func (u *User) VerifyPassword(password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(*u.PasswordHash), []byte(password))
 	return err == nil
 }
+
+// UserPreferences holds per-user settings that apply across clients
+type UserPreferences struct {
+	UserID         string  `json:"user_id" db:"user_id"`
+	DefaultGuildID *string `json:"default_guild_id,omitempty" db:"default_guild_id"` // Applied to commands run outside a guild
+	// LeaderboardOptOut keeps the user off quote leaderboards, as a quoter and as the one quoted
+	LeaderboardOptOut bool      `json:"leaderboard_opt_out" db:"leaderboard_opt_out"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
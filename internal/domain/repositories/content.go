@@ -2,38 +2,86 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
 )
 
+// Orderings accepted by NoteRepository.Search and QuoteRepository.Search
+const (
+	SearchOrderRelevance = "relevance"
+	SearchOrderCreatedAt = "created_at"
+	SearchOrderUpdatedAt = "updated_at"
+)
+
+// TaggedMatch is the tags of a piece of content that matched a full-text query,
+// with how well it matched
+type TaggedMatch struct {
+	Tags []string
+	Rank float64
+}
+
 // WikiPageRepository defines operations for wiki page persistence
 type WikiPageRepository interface {
-	// Create creates a new wiki page
+	// Create creates a new wiki page and its canonical title in one transaction
+	// Returns ErrWikiTitleTaken when the title's slug is already used in the guild
 	Create(ctx context.Context, page *entities.WikiPage) error
 
 	// GetByID retrieves a wiki page by ID
 	// userDiscordID filters by guild membership (empty string = admin, no filter)
+	// Returns ErrWikiPageNotFound when the page doesn't exist or the filter hides it
 	GetByID(ctx context.Context, id string, userDiscordID string) (*entities.WikiPage, error)
 
+	// IDExists reports whether any wiki page, including soft-deleted ones, uses id
+	IDExists(ctx context.Context, id string) (bool, error)
+
 	// GetByGuildAndSlug retrieves a wiki page by guild ID and slug (normalized for lookup)
 	// userDiscordID filters by guild membership (empty string = admin, no filter)
 	GetByGuildAndSlug(ctx context.Context, guildID, slug string, userDiscordID string) (*entities.WikiPage, error)
 
-	// Update updates an existing wiki page
+	// Update updates an existing wiki page. A changed title becomes the page's canonical
+	// title and the previous one is kept as an alias.
+	// Returns ErrWikiTitleTaken when the new title's slug belongs to another page in the guild
 	Update(ctx context.Context, page *entities.WikiPage) error
 
 	// Delete soft-deletes a wiki page
 	Delete(ctx context.Context, id string) error
 
 	// List lists wiki pages in a guild with pagination
+	// cursor continues after a previous page and takes precedence over offset (nil = use offset);
+	// the returned cursor is nil on the last page
 	// userDiscordID filters to only guilds where user is a member (empty string = admin, no filter)
-	List(ctx context.Context, guildID string, limit, offset int, orderBy string, ascending bool, userDiscordID string) ([]*entities.WikiPage, int, error)
+	// and hides drafts from everyone but their author; with an empty userDiscordID every draft is hidden
+	List(ctx context.Context, guildID string, limit, offset int, cursor *PageCursor, orderBy string, ascending bool, userDiscordID string) ([]*entities.WikiPage, int, *PageCursor, error)
 
-	// Search performs full-text search on wiki pages
+	// Search performs full-text search on wiki pages in guildIDs (empty = every guild)
+	// authorDiscordID limits results to pages written by that Discord user (empty = any author)
 	// userDiscordID filters to only guilds where user is a member (empty string = admin, no filter)
-	Search(ctx context.Context, guildID, query string, tags []string, limit, offset int, userDiscordID string) ([]*entities.WikiPage, int, error)
+	// and hides drafts from everyone but their author; with an empty userDiscordID every draft is hidden
+	Search(ctx context.Context, guildIDs []string, query string, tags []string, authorDiscordID string, limit, offset int, userDiscordID string) ([]*entities.WikiPage, int, error)
+
+	// FindTaggedMatches returns the tags of up to limit tagged pages in guildID matching
+	// the full-text query, best match first. excludeID skips one page (empty = none)
+	// userDiscordID filters by guild membership (empty string = admin, no filter)
+	FindTaggedMatches(ctx context.Context, guildID, query, excludeID string, limit int, userDiscordID string) ([]TaggedMatch, error)
+
+	// Restore un-deletes a soft-deleted wiki page and resets its title, body, and tags
+	Restore(ctx context.Context, page *entities.WikiPage) error
+
+	// SetPinned pins or unpins a wiki page; pinned pages sort first in List and Search
+	SetPinned(ctx context.Context, id string, pinned bool) error
 
-	// GetTitlesForGuild retrieves only the ID, title, and slug of all wiki pages in a guild
+	// SetAuthor makes authorID the author of a wiki page without marking it as edited
+	SetAuthor(ctx context.Context, id, authorID string) error
+
+	// SetStatus makes a wiki page a draft or publishes it
+	SetStatus(ctx context.Context, id string, status entities.WikiPageStatus) error
+
+	// ListDrafts lists authorID's draft pages in a guild, most recently updated first.
+	// Bodies are left empty; the size metadata is set.
+	ListDrafts(ctx context.Context, guildID, authorID string) ([]*entities.WikiPage, error)
+
+	// GetTitlesForGuild retrieves only the ID, title, and slug of all published wiki pages in a guild
 	GetTitlesForGuild(ctx context.Context, guildID string) ([]struct {
 		ID    string
 		Title string
@@ -60,18 +108,36 @@ type WikiTitleRepository interface {
 
 	// UpdatePageID updates the page ID for all non-canonical titles pointing to oldPageID
 	UpdatePageID(ctx context.Context, oldPageID, newPageID string) (int, error)
+
+	// Reassign points a title at a page and sets whether it is the canonical title
+	Reassign(ctx context.Context, titleID, pageID string, isCanonical bool) error
 }
 
 // NoteRepository defines operations for note persistence
 type NoteRepository interface {
 	// Create creates a new note
+	// Returns a NoteTitleTakenError when note.UniqueTitle is set and the title is taken
 	Create(ctx context.Context, note *entities.Note) error
 
 	// GetByID retrieves a note by ID
 	// userDiscordID filters by guild membership (empty string = admin, no filter)
+	// Returns ErrNoteNotFound when the note doesn't exist
 	GetByID(ctx context.Context, id string, userDiscordID string) (*entities.Note, error)
 
+	// FindIDBySlug returns the ID of the live note with the given permalink slug among
+	// guildID's notes, or among authorID's personal notes when guildID is empty.
+	// It returns "" when there is none.
+	FindIDBySlug(ctx context.Context, guildID, authorID, slug string) (string, error)
+
+	// FindIDByTitle returns the ID of authorID's live note in guildID whose title
+	// matches title ignoring case, skipping excludeID. It returns "" when there is none.
+	FindIDByTitle(ctx context.Context, guildID, authorID, title, excludeID string) (string, error)
+
+	// IDExists reports whether any note, including soft-deleted ones, uses id
+	IDExists(ctx context.Context, id string) (bool, error)
+
 	// Update updates an existing note
+	// Returns a NoteTitleTakenError when note.UniqueTitle is set and the title is taken
 	Update(ctx context.Context, note *entities.Note) error
 
 	// Delete soft-deletes a note
@@ -82,8 +148,12 @@ type NoteRepository interface {
 	List(ctx context.Context, authorID, guildID string, tags []string, limit, offset int, orderBy string, ascending bool, userDiscordID string) ([]*entities.Note, int, error)
 
 	// Search performs full-text search on notes
+	// orderBy is one of the SearchOrder* values (empty = relevance if query is set, else created_at)
+	// authorDiscordID additionally requires authorID to be linked to that Discord user (empty = no filter)
+	// cursor continues after a previous page and takes precedence over offset (nil = use offset);
+	// the returned cursor is nil on the last page
 	// userDiscordID filters to only guilds where user is a member (empty string = admin, no filter)
-	Search(ctx context.Context, authorID string, query, guildID string, tags []string, limit, offset int, userDiscordID string) ([]*entities.Note, int, error)
+	Search(ctx context.Context, authorID string, query string, guildIDs []string, tags []string, authorDiscordID string, limit, offset int, cursor *PageCursor, orderBy string, ascending bool, userDiscordID string) ([]*entities.Note, int, *PageCursor, error)
 
 	// GetTitlesForUser retrieves only the ID and title of all notes for a user in a guild
 	GetTitlesForUser(ctx context.Context, authorID, guildID string) ([]struct {
@@ -94,35 +164,68 @@ type NoteRepository interface {
 
 // QuoteRepository defines operations for quote persistence
 type QuoteRepository interface {
-	// Create creates a new quote
+	// Create creates a new quote. With a Cooldown set it returns a QuoteCooldownError,
+	// and saves nothing, if the author's last quote in the guild is more recent.
 	Create(ctx context.Context, quote *entities.Quote) error
 
 	// GetByID retrieves a quote by ID
 	// userDiscordID filters by guild membership (empty string = admin, no filter)
 	GetByID(ctx context.Context, id string, userDiscordID string) (*entities.Quote, error)
 
+	// IDExists reports whether any quote, including soft-deleted ones, uses id
+	IDExists(ctx context.Context, id string) (bool, error)
+
+	// FindDuplicateID returns the ID of a live quote in guildID saved from the same
+	// source message (when sourceMsgID is set) or with the same body, ignoring case
+	// and whitespace. It returns "" when there is none.
+	FindDuplicateID(ctx context.Context, guildID, sourceMsgID, body string) (string, error)
+
+	// FindIDBySourceMessage returns the ID of the live quote in guildID saved from
+	// sourceMsgID, or "" when there is none
+	FindIDBySourceMessage(ctx context.Context, guildID, sourceMsgID string) (string, error)
+
+	// FindIDByShortCode returns the ID of the live quote in guildID with the given
+	// permalink short code, or "" when there is none
+	FindIDByShortCode(ctx context.Context, guildID, code string) (string, error)
+
 	// Delete soft-deletes a quote
 	Delete(ctx context.Context, id string) error
 
-	// Update updates a quote's body and tags
-	Update(ctx context.Context, id, body string, tags []string) error
+	// Update updates a quote's body and tags. A non-empty attribution is shown in place of
+	// the names of the Discord user the quote was saved from, whose ID is kept.
+	Update(ctx context.Context, id, body string, tags []string, attribution string) error
 
 	// List lists quotes in a guild with pagination
 	// userDiscordID filters to only guilds where user is a member (empty string = admin, no filter)
 	List(ctx context.Context, guildID string, limit, offset int, orderBy string, ascending bool, userDiscordID string) ([]*entities.Quote, int, error)
 
-	// Search performs full-text search on quotes
+	// Search performs full-text search on quotes in guildIDs (empty = every guild)
+	// orderBy is one of the SearchOrder* values (empty = relevance if query is set, else created_at)
 	// userDiscordID filters to only guilds where user is a member (empty string = admin, no filter)
-	Search(ctx context.Context, guildID, query string, tags []string, limit, offset int, userDiscordID string) ([]*entities.Quote, int, error)
+	Search(ctx context.Context, guildIDs []string, query string, tags []string, limit, offset int, orderBy string, ascending bool, userDiscordID string) ([]*entities.Quote, int, error)
 
 	// GetRandom retrieves a random quote from a guild
 	GetRandom(ctx context.Context, guildID string, tags []string) (*entities.Quote, error)
+
+	// Stats returns the guild's quote total and the top limit quoters and quoted users,
+	// counting only quotes saved at or after since (zero = all time). Users who opted
+	// out of leaderboards are left off them but still count towards the total.
+	// userDiscordID filters to only guilds where user is a member (empty string = admin, no filter)
+	Stats(ctx context.Context, guildID string, limit int, since time.Time, userDiscordID string) (*entities.QuoteStats, error)
 }
 
 // WikiMessageReferenceRepository defines operations for wiki message reference persistence
 type WikiMessageReferenceRepository interface {
 	// Create creates a new wiki message reference (no-op if already exists)
-	Create(ctx context.Context, ref *entities.WikiMessageReference) error
+	// Returns ErrReferenceLimitReached, adding nothing, if the page would then have more
+	// than maxRefs references (maxRefs <= 0 = no limit)
+	Create(ctx context.Context, ref *entities.WikiMessageReference, maxRefs int) error
+
+	// CreateBatch creates several references to one page in one transaction, skipping messages
+	// the page already references, and returns how many were created
+	// Returns ErrReferenceLimitReached, adding nothing, if the page would then have more
+	// than maxRefs references (maxRefs <= 0 = no limit)
+	CreateBatch(ctx context.Context, refs []*entities.WikiMessageReference, maxRefs int) (int, error)
 
 	// GetByPageID retrieves all message references for a wiki page (ordered by added_at DESC)
 	GetByPageID(ctx context.Context, pageID string) ([]*entities.WikiMessageReference, error)
@@ -144,7 +247,9 @@ type WikiMessageReferenceRepository interface {
 // NoteMessageReferenceRepository defines operations for note message reference persistence
 type NoteMessageReferenceRepository interface {
 	// Create creates a new note message reference (no-op if already exists)
-	Create(ctx context.Context, ref *entities.NoteMessageReference) error
+	// Returns ErrReferenceLimitReached, adding nothing, if the note would then have more
+	// than maxRefs references (maxRefs <= 0 = no limit)
+	Create(ctx context.Context, ref *entities.NoteMessageReference, maxRefs int) error
 
 	// GetByNoteID retrieves all message references for a note (ordered by added_at DESC)
 	GetByNoteID(ctx context.Context, noteID string) ([]*entities.NoteMessageReference, error)
@@ -158,3 +263,71 @@ type NoteMessageReferenceRepository interface {
 	// DeleteByMessageID deletes all references to a specific message (cleanup if message deleted)
 	DeleteByMessageID(ctx context.Context, messageID string) error
 }
+
+// WikiMergeLogRepository defines operations for the wiki merge undo log
+type WikiMergeLogRepository interface {
+	// Create records a merge
+	Create(ctx context.Context, entry *entities.WikiMergeLog) error
+
+	// GetLatestBySourcePage retrieves the most recent merge of a source page that hasn't been undone
+	// Returns nil if there is none
+	GetLatestBySourcePage(ctx context.Context, sourcePageID string) (*entities.WikiMergeLog, error)
+
+	// MarkUndone records that a merge was reverted
+	MarkUndone(ctx context.Context, id string) error
+}
+
+// ActivityRepository defines read operations for the combined content activity feed
+type ActivityRepository interface {
+	// ListRecent returns up to limit wiki pages, notes, and quotes created or updated after since
+	// and after cursor, and whether more matched. Without since or cursor it returns the newest
+	// items, newest first; with either it returns the items that follow, oldest first, so
+	// polling never skips any. The returned cursor is the position of the newest item returned
+	// (the given cursor when there are none) for the next poll to continue from.
+	// guildID limits results to one guild (empty = all guilds).
+	// userID selects whose notes are included; notes are never shown to other users.
+	// userDiscordID filters wiki pages and quotes by guild membership (empty string = admin, no filter)
+	ListRecent(ctx context.Context, guildID, userID, userDiscordID string, since time.Time, cursor *PageCursor, limit int) ([]*entities.ActivityItem, bool, *PageCursor, error)
+}
+
+// RecentlyViewedRepository tracks the wiki pages, notes, and quotes each user viewed last
+type RecentlyViewedRepository interface {
+	// RecordView moves an item (contentType is an entities.ActivityType* value) to the front of the
+	// user's history, adding it if needed, then trims the history to the keep most recent items
+	RecordView(ctx context.Context, userID, contentType, contentID string, viewedAt time.Time, keep int) error
+
+	// List returns the user's history, most recently viewed first, skipping deleted content.
+	// userDiscordID filters wiki pages and quotes by guild membership (empty string = admin, no filter).
+	// With authoredOnly set, only wiki pages and quotes the user authored are listed, for
+	// users without a Discord account to check membership with.
+	// With excludeOwnEdits set, items the user authored and viewed within a few minutes of
+	// saving them (such as the page shown after an edit) are left out.
+	List(ctx context.Context, userID, userDiscordID string, authoredOnly, excludeOwnEdits bool, limit int) ([]*entities.RecentlyViewedItem, error)
+}
+
+// GuildContentRepository moves content between guilds and authors
+type GuildContentRepository interface {
+	// MoveContent reassigns content of the given types (entities.ContentType* values) from
+	// sourceGuildID to targetGuildID in a single transaction. Wiki titles, message references,
+	// and merge history follow their wiki pages and notes.
+	// Returns ErrGuildMoveConflict, with the conflicts in the result, if a wiki title, note slug,
+	// or quote short code is already used in the target guild. With dryRun set, nothing is
+	// written and conflicts are only reported.
+	MoveContent(ctx context.Context, sourceGuildID, targetGuildID string, contentTypes []string, dryRun bool) (*entities.GuildContentMove, error)
+
+	// ReassignAuthor makes authorID the author of a live wiki page, note, or quote
+	// (entities.ResourceWikiPage, ResourceNote, or ResourceQuote). A quote's saver
+	// Discord ID follows the new author. Returns ErrContentNotFound if there is no such content.
+	ReassignAuthor(ctx context.Context, contentType entities.AuditResource, id, authorID string) (*entities.AuthorReassignment, error)
+}
+
+// ModerationRepository defines operations for content flagged by moderation
+type ModerationRepository interface {
+	// SetFlagged flags a wiki page, note, or quote for review with the reason it was
+	// flagged, or clears its flag when reason is empty
+	SetFlagged(ctx context.Context, contentType entities.AuditResource, id, reason string) error
+
+	// ListFlagged lists flagged content that hasn't been deleted, most recently flagged first
+	// contentType limits the list to one kind of content (empty = all)
+	ListFlagged(ctx context.Context, contentType entities.AuditResource, limit, offset int) ([]*entities.FlaggedContent, int, error)
+}
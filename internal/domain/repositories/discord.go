@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
 )
@@ -26,10 +27,17 @@ type DiscordUserRepository interface {
 	// UpdateLastSeen updates the last_seen timestamp for a Discord user
 	UpdateLastSeen(ctx context.Context, discordID string) error
 
+	// ListActive retrieves Discord users seen since the given time, ordered by
+	// Discord ID and starting after afterDiscordID (keyset pagination)
+	ListActive(ctx context.Context, seenSince time.Time, afterDiscordID string, limit int) ([]*entities.DiscordUser, error)
+
 	// Delete removes a Discord user record (unlinking)
 	Delete(ctx context.Context, discordID string) error
 }
 
+// GuildSettingsRevisionKey is the settings key holding a guild's settings revision
+const GuildSettingsRevisionKey = "revision"
+
 // DiscordGuildRepository handles Discord guild data persistence
 type DiscordGuildRepository interface {
 	// Create creates a new Discord guild record
@@ -53,8 +61,11 @@ type DiscordGuildRepository interface {
 	// Delete removes a guild record
 	Delete(ctx context.Context, guildID string) error
 
-	// UpdateSettings updates the settings JSONB for a guild
-	UpdateSettings(ctx context.Context, guildID string, settings map[string]interface{}) error
+	// UpdateSettings merges patch into a guild's settings JSONB in one statement, replacing
+	// only the top-level sections patch contains, and increments the settings revision.
+	// A non-zero expectedRevision makes the update fail with ErrStaleGuildSettings if the
+	// stored revision differs. Returns the merged settings.
+	UpdateSettings(ctx context.Context, guildID string, patch map[string]interface{}, expectedRevision int64) (map[string]interface{}, error)
 
 	// GetSettings retrieves the settings JSONB for a guild
 	GetSettings(ctx context.Context, guildID string) (map[string]interface{}, error)
@@ -83,6 +94,9 @@ type GuildMemberRepository interface {
 	// UpdateLastSeen updates the last_seen timestamp for a guild member
 	UpdateLastSeen(ctx context.Context, guildID, discordID string) error
 
+	// UpdateSyncedAt updates the synced_at timestamp for a guild member
+	UpdateSyncedAt(ctx context.Context, guildID, discordID string, syncedAt time.Time) error
+
 	// DeleteMember removes a member record (when they leave)
 	DeleteMember(ctx context.Context, guildID, discordID string) error
 
@@ -96,3 +110,12 @@ type GuildMemberRepository interface {
 	// This should be called after batch upserting guild members to keep display names in sync
 	RefreshDisplayNames(ctx context.Context, guildID string) error
 }
+
+// DeadLetterRepository handles outbound Discord calls the bot dropped
+type DeadLetterRepository interface {
+	// Create records a dropped call
+	Create(ctx context.Context, deadLetter *entities.DeadLetter) error
+
+	// List returns dropped calls, most recent first, optionally for one guild, with the total
+	List(ctx context.Context, guildID string, limit, offset int) ([]*entities.DeadLetter, int, error)
+}
@@ -1,6 +1,10 @@
 package repositories
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // Domain-specific repository errors
 var (
@@ -25,9 +29,76 @@ var (
 	// ErrDiscordGuildNotFound is returned when a Discord guild cannot be found
 	ErrDiscordGuildNotFound = errors.New("discord guild not found")
 
+	// ErrStaleGuildSettings is returned when guild settings changed since the revision an update expected
+	ErrStaleGuildSettings = errors.New("guild settings changed since they were read")
+
 	// ErrGuildMemberNotFound is returned when a guild member record cannot be found
 	ErrGuildMemberNotFound = errors.New("guild member not found")
 
+	// ErrWikiPageNotFound is returned when a wiki page doesn't exist or is hidden from the caller
+	ErrWikiPageNotFound = errors.New("wiki page not found")
+
+	// ErrWikiTitleTaken is returned when a wiki page title's slug is already used by another page in the guild
+	ErrWikiTitleTaken = errors.New("wiki page title is already used in this guild")
+
+	// ErrNoteNotFound is returned when a note cannot be found
+	ErrNoteNotFound = errors.New("note not found")
+
+	// ErrNoteTitleTaken is returned when a guild requires unique note titles and the
+	// author already has a note with the title being saved; see NoteTitleTakenError
+	ErrNoteTitleTaken = errors.New("note title already in use")
+
+	// ErrContentNotFound is returned when a wiki page, note, or quote looked up by content type cannot be found
+	ErrContentNotFound = errors.New("content not found")
+
 	// ErrAuditLogNotFound is returned when an audit log cannot be found
 	ErrAuditLogNotFound = errors.New("audit log not found")
+
+	// ErrUserPreferencesNotFound is returned when a user has no stored preferences
+	ErrUserPreferencesNotFound = errors.New("user preferences not found")
+
+	// ErrGuildMoveConflict is returned when moved content would reuse wiki titles, note slugs,
+	// or quote short codes that already exist in the target guild
+	ErrGuildMoveConflict = errors.New("content already exists in the target guild")
+
+	// ErrInvalidPageToken is returned for page tokens that can't be decoded or were issued for another ordering
+	ErrInvalidPageToken = errors.New("invalid page token")
+
+	// ErrReferenceLimitReached is returned when adding message references would take a wiki page
+	// or note past its reference limit
+	ErrReferenceLimitReached = errors.New("reference limit reached")
+
+	// ErrQuoteCooldown is returned when a guild's quote cooldown hasn't passed since the
+	// author's last quote there; see QuoteCooldownError
+	ErrQuoteCooldown = errors.New("quote cooldown has not passed")
 )
+
+// NoteTitleTakenError is the ErrNoteTitleTaken returned for a save, naming the note that
+// already has the title
+type NoteTitleTakenError struct {
+	ExistingID string
+}
+
+func (e *NoteTitleTakenError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrNoteTitleTaken, e.ExistingID)
+}
+
+// Is makes errors.Is(err, ErrNoteTitleTaken) match
+func (e *NoteTitleTakenError) Is(target error) bool {
+	return target == ErrNoteTitleTaken
+}
+
+// QuoteCooldownError is the ErrQuoteCooldown returned for a save, saying how long the
+// author must still wait
+type QuoteCooldownError struct {
+	Remaining time.Duration
+}
+
+func (e *QuoteCooldownError) Error() string {
+	return fmt.Sprintf("%s: %s remaining", ErrQuoteCooldown, e.Remaining.Round(time.Second))
+}
+
+// Is makes errors.Is(err, ErrQuoteCooldown) match
+func (e *QuoteCooldownError) Is(target error) bool {
+	return target == ErrQuoteCooldown
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// PageCursor marks where the next page of a keyset-paginated listing starts: the
+// sort key values and ID of the previous page's last row. Unlike offsets, cursors
+// don't skip or repeat rows when content is added or removed between pages.
+// Clients only see cursors as opaque page tokens.
+type PageCursor struct {
+	Order string   `json:"o"` // Ordering the cursor was issued for
+	Keys  []string `json:"k"` // Sort key values of the last row, as text
+	ID    string   `json:"i"` // ID of the last row, the final tie-breaker
+}
+
+// Token encodes the cursor as an opaque page token (empty for a nil cursor)
+func (c *PageCursor) Token() string {
+	if c == nil {
+		return ""
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParsePageToken decodes a page token produced by PageCursor.Token.
+// An empty token returns a nil cursor, meaning the first page.
+func ParsePageToken(token string) (*PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	cursor := &PageCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil || cursor.Order == "" || cursor.ID == "" {
+		return nil, ErrInvalidPageToken
+	}
+	return cursor, nil
+}
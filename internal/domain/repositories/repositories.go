@@ -30,6 +30,13 @@ type Transaction interface {
 	GetRepositories() *Repositories
 }
 
+// Transactor runs a unit of work in a single database transaction
+type Transactor interface {
+	// InTx calls fn in a transaction, committing if it returns nil and rolling back otherwise.
+	// Repository calls made with the ctx passed to fn join the transaction.
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 // HealthChecker defines health check interface for repositories
 type HealthChecker interface {
 	// HealthCheck performs a health check on the repository
@@ -27,8 +27,13 @@ type UserRepository interface {
 	// Delete a user (soft delete by setting deleted_at)
 	Delete(ctx context.Context, id string) error
 
-	// List users with pagination and optional filtering
-	List(ctx context.Context, opts ListUsersOptions) ([]*entities.User, int64, error)
+	// SetActive enables or disables a user account. Unlike Update it works on
+	// disabled users, which GetByID refuses to load.
+	SetActive(ctx context.Context, id string, active bool) error
+
+	// List users with pagination and optional filtering. Returns the total number
+	// of matches and the cursor for the next page (nil on the last page).
+	List(ctx context.Context, opts ListUsersOptions) ([]*entities.User, int64, *PageCursor, error)
 
 	// UpdateLastLogin updates the user's last login timestamp
 	UpdateLastLogin(ctx context.Context, userID string, loginTime time.Time) error
@@ -45,14 +50,24 @@ type ListUsersOptions struct {
 	// Pagination
 	Limit  int
 	Offset int
+	Cursor *PageCursor // continues after a previous page; replaces Offset
 
 	// Filtering
 	Role     *entities.Role     // filter by role
 	UserType *entities.UserType // filter by user type
 	IsActive *bool              // filter by active status
-	Search   string             // search in display_name or email
+	Search   string             // case-insensitive substring of display_name or email
 
 	// Sorting
 	SortBy    string // field to sort by (created_at, display_name, email, last_login)
 	SortOrder string // asc or desc
 }
+
+// UserPreferencesRepository defines the interface for user preference data access
+type UserPreferencesRepository interface {
+	// GetByUserID retrieves preferences for a user, returning ErrUserPreferencesNotFound if none are stored
+	GetByUserID(ctx context.Context, userID string) (*entities.UserPreferences, error)
+
+	// Upsert creates or updates preferences for a user
+	Upsert(ctx context.Context, prefs *entities.UserPreferences) error
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// RecentlyViewedCap is the number of views kept in each user's history
+const RecentlyViewedCap = 50
+
+// ActivityService handles business logic for the recent activity feed and view history
+type ActivityService struct {
+	activityRepo repositories.ActivityRepository
+	viewRepo     repositories.RecentlyViewedRepository
+}
+
+// NewActivityService creates a new activity service
+func NewActivityService(activityRepo repositories.ActivityRepository, viewRepo repositories.RecentlyViewedRepository) *ActivityService {
+	return &ActivityService{
+		activityRepo: activityRepo,
+		viewRepo:     viewRepo,
+	}
+}
+
+// ListRecentActivity returns up to limit activity items after since or cursor, whether
+// more matched, and the cursor to poll from next (see ActivityRepository.ListRecent)
+func (s *ActivityService) ListRecentActivity(ctx context.Context, guildID, userID, userDiscordID string, since time.Time, cursor *repositories.PageCursor, limit int) ([]*entities.ActivityItem, bool, *repositories.PageCursor, error) {
+	items, hasMore, next, err := s.activityRepo.ListRecent(ctx, guildID, userID, userDiscordID, since, cursor, limit)
+	if err != nil {
+		return nil, false, nil, fmt.Errorf("failed to list recent activity: %w", err)
+	}
+	return items, hasMore, next, nil
+}
+
+// RecordView adds a wiki page, note, or quote (contentType is an entities.ActivityType* value)
+// to the front of the user's view history
+func (s *ActivityService) RecordView(ctx context.Context, userID, contentType, contentID string) error {
+	if err := s.viewRepo.RecordView(ctx, userID, contentType, contentID, time.Now(), RecentlyViewedCap); err != nil {
+		return fmt.Errorf("failed to record view: %w", err)
+	}
+	return nil
+}
+
+// ListRecentlyViewed returns up to limit items from the user's view history, most recent first
+func (s *ActivityService) ListRecentlyViewed(ctx context.Context, userID, userDiscordID string, authoredOnly, excludeOwnEdits bool, limit int) ([]*entities.RecentlyViewedItem, error) {
+	items, err := s.viewRepo.List(ctx, userID, userDiscordID, authoredOnly, excludeOwnEdits, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recently viewed: %w", err)
+	}
+	return items, nil
+}
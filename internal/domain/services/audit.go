@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/devilmonastery/hivemind/internal/auth"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// contentAuditor writes audit log entries for wiki, note, and quote mutations.
+// Audit failures are logged and never fail the mutation itself.
+type contentAuditor struct {
+	repo repositories.AuditRepository
+}
+
+// record writes an entry for a successful mutation. The actor is the
+// authenticated user in ctx; guildID and any extra metadata go in the entry's metadata.
+func (a contentAuditor) record(ctx context.Context, action entities.AuditAction, resource entities.AuditResource, resourceID, guildID string, metadata map[string]any) {
+	if a.repo == nil {
+		return // Audit logging not configured
+	}
+
+	var actorID *string
+	if user, err := auth.GetUserFromContext(ctx); err == nil {
+		actorID = &user.UserID
+	}
+
+	entry := entities.NewAuditLog(actorID, action, resource).WithResourceID(resourceID)
+	if guildID != "" {
+		entry.WithMetadata("guild_id", guildID)
+	}
+	for key, value := range metadata {
+		entry.WithMetadata(key, value)
+	}
+
+	if err := a.repo.Create(ctx, entry); err != nil {
+		slog.Default().Warn("failed to write audit log",
+			slog.String("action", string(action)),
+			slog.String("resource_id", resourceID),
+			slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/internal/auth"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+type fakeAuditRepo struct {
+	repositories.AuditRepository
+	logs []*entities.AuditLog
+	err  error
+}
+
+func (r *fakeAuditRepo) Create(ctx context.Context, log *entities.AuditLog) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func TestCreateWikiPage_WritesAuditLog(t *testing.T) {
+	f := newWikiMergeFixture()
+	audit := &fakeAuditRepo{}
+	f.svc.audit = contentAuditor{repo: audit}
+
+	ctx := auth.SetUserInContext(context.Background(), &auth.UserContext{UserID: "user-1"})
+	page, err := f.svc.CreateWikiPage(ctx, &entities.WikiPage{Title: "Audited", Body: "b", GuildID: "g1"}, "")
+	if err != nil {
+		t.Fatalf("CreateWikiPage() error = %v", err)
+	}
+
+	if len(audit.logs) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(audit.logs))
+	}
+	entry := audit.logs[0]
+	if entry.Action != entities.ActionWikiPageCreated || entry.Resource != entities.ResourceWikiPage {
+		t.Errorf("entry = %s on %s, want %s on %s", entry.Action, entry.Resource, entities.ActionWikiPageCreated, entities.ResourceWikiPage)
+	}
+	if entry.ResourceID == nil || *entry.ResourceID != page.ID {
+		t.Errorf("ResourceID = %v, want %q", entry.ResourceID, page.ID)
+	}
+	if entry.UserID == nil || *entry.UserID != "user-1" {
+		t.Errorf("UserID = %v, want user-1", entry.UserID)
+	}
+	if entry.Metadata["guild_id"] != "g1" {
+		t.Errorf("guild_id = %v, want g1", entry.Metadata["guild_id"])
+	}
+}
+
+func TestMergeWikiPages_WritesAuditLog(t *testing.T) {
+	f := newWikiMergeFixture()
+	audit := &fakeAuditRepo{}
+	f.svc.audit = contentAuditor{repo: audit}
+
+	if _, err := f.svc.MergeWikiPages(context.Background(), "src", "tgt", "admin"); err != nil {
+		t.Fatalf("MergeWikiPages() error = %v", err)
+	}
+
+	if len(audit.logs) != 1 || audit.logs[0].Action != entities.ActionWikiPageMerged {
+		t.Fatalf("audit entries = %+v, want one %s", audit.logs, entities.ActionWikiPageMerged)
+	}
+	if got := audit.logs[0].Metadata["target_page_id"]; got != "tgt" {
+		t.Errorf("target_page_id = %v, want tgt", got)
+	}
+	if audit.logs[0].UserID != nil {
+		t.Errorf("UserID = %v, want nil without an authenticated user", *audit.logs[0].UserID)
+	}
+}
+
+func TestAuditFailureDoesNotFailMutation(t *testing.T) {
+	f := newWikiMergeFixture()
+	f.svc.audit = contentAuditor{repo: &fakeAuditRepo{err: errors.New("audit table is gone")}}
+
+	if err := f.svc.DeleteWikiPage(context.Background(), "tgt", ""); err != nil {
+		t.Fatalf("DeleteWikiPage() error = %v, want nil despite audit failure", err)
+	}
+	if f.pages.pages["tgt"].DeletedAt == nil {
+		t.Error("page was not deleted")
+	}
+}
@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
 	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/notify"
 	"github.com/devilmonastery/hivemind/internal/pkg/idgen"
 )
 
@@ -17,6 +20,8 @@ type DiscordService struct {
 	discordGuildRepo repositories.DiscordGuildRepository
 	guildMemberRepo  repositories.GuildMemberRepository
 	userRepo         repositories.UserRepository
+	deadLetterRepo   repositories.DeadLetterRepository
+	displayNames     *displayNameRefresher
 	logger           *slog.Logger
 }
 
@@ -26,6 +31,7 @@ func NewDiscordService(
 	discordGuildRepo repositories.DiscordGuildRepository,
 	guildMemberRepo repositories.GuildMemberRepository,
 	userRepo repositories.UserRepository,
+	deadLetterRepo repositories.DeadLetterRepository,
 	logger *slog.Logger,
 ) *DiscordService {
 	return &DiscordService{
@@ -33,10 +39,17 @@ func NewDiscordService(
 		discordGuildRepo: discordGuildRepo,
 		guildMemberRepo:  guildMemberRepo,
 		userRepo:         userRepo,
+		deadLetterRepo:   deadLetterRepo,
+		displayNames:     newDisplayNameRefresher(guildMemberRepo, displayNameRefreshDelay, logger),
 		logger:           logger,
 	}
 }
 
+// Close refreshes the display names of guilds whose refresh is still queued
+func (s *DiscordService) Close() {
+	s.displayNames.flush()
+}
+
 // GetOrCreateUserFromDiscord gets or creates a Hivemind user from Discord info
 // This implements auto-provisioning: first time a Discord user interacts, we create their account
 func (s *DiscordService) GetOrCreateUserFromDiscord(
@@ -259,13 +272,15 @@ func (s *DiscordService) GetOrCreateGuild(
 }
 
 // UpsertGuild creates or updates a Discord guild with full information
+// Reports whether the guild was created, i.e. the bot hasn't seen it before, as
+// opposed to updated when the gateway resends guilds after a reconnect
 func (s *DiscordService) UpsertGuild(
 	ctx context.Context,
 	guildID string,
 	guildName string,
 	iconURL string,
 	ownerDiscordID string,
-) (*entities.DiscordGuild, error) {
+) (*entities.DiscordGuild, bool, error) {
 	// Try to find existing guild
 	guild, err := s.discordGuildRepo.GetByID(ctx, guildID)
 	if err == nil {
@@ -281,19 +296,19 @@ func (s *DiscordService) UpsertGuild(
 		guild.LastActivity = &now
 
 		if updateErr := s.discordGuildRepo.Update(ctx, guild); updateErr != nil {
-			return nil, fmt.Errorf("failed to update guild: %w", updateErr)
+			return nil, false, fmt.Errorf("failed to update guild: %w", updateErr)
 		}
 
 		s.logger.Info("guild updated",
 			slog.String("guild_id", guildID),
 			slog.String("guild_name", guildName))
 
-		return guild, nil
+		return guild, false, nil
 	}
 
 	// If error is not "not found", return it
 	if err != repositories.ErrDiscordGuildNotFound {
-		return nil, fmt.Errorf("failed to query discord guild: %w", err)
+		return nil, false, fmt.Errorf("failed to query discord guild: %w", err)
 	}
 
 	// Create new guild
@@ -319,14 +334,14 @@ func (s *DiscordService) UpsertGuild(
 	}
 
 	if err := s.discordGuildRepo.Create(ctx, guild); err != nil {
-		return nil, fmt.Errorf("failed to create discord guild: %w", err)
+		return nil, false, fmt.Errorf("failed to create discord guild: %w", err)
 	}
 
 	s.logger.Info("guild registered",
 		slog.String("guild_id", guildID),
 		slog.String("guild_name", guildName))
 
-	return guild, nil
+	return guild, true, nil
 }
 
 // DisableGuild marks a guild as disabled (when bot is removed)
@@ -352,6 +367,11 @@ func (s *DiscordService) GetGuild(ctx context.Context, guildID string) (*entitie
 	return s.discordGuildRepo.GetByID(ctx, guildID)
 }
 
+// RecordMemberSync records now as the time a guild's full member list was last synced
+func (s *DiscordService) RecordMemberSync(ctx context.Context, guildID string) error {
+	return s.discordGuildRepo.UpdateMemberSyncTime(ctx, guildID)
+}
+
 // GetDiscordUserByHivemindID gets a Discord user by their Hivemind user ID
 func (s *DiscordService) GetDiscordUserByHivemindID(ctx context.Context, userID string) (*entities.DiscordUser, error) {
 	// Query by user_id field (the reverse lookup from GetOrCreateUserFromDiscord)
@@ -362,21 +382,82 @@ func (s *DiscordService) GetDiscordUserByHivemindID(ctx context.Context, userID
 	return discordUser, nil
 }
 
-// UpsertGuildMember creates or updates a guild member record
+// UpsertGuildMember creates or updates a guild member record, and the member's Discord
+// user record when discordUser isn't nil. Discord sends member updates for changes
+// Hivemind doesn't keep, so nothing is written when the username, global name, avatar,
+// nick, guild avatar and roles all match the stored records; only the user's last_seen
+// and the member's synced_at are bumped. A change queues a refresh of the guild's
+// display names, batched with other members' changes.
+// Returns whether any of the compared fields were written.
 func (s *DiscordService) UpsertGuildMember(
 	ctx context.Context,
+	discordUser *entities.DiscordUser,
 	member *entities.GuildMember,
-) error {
-	if err := s.guildMemberRepo.Upsert(ctx, member); err != nil {
-		return err
+) (bool, error) {
+	changed := false
+
+	if discordUser != nil {
+		existing, err := s.discordUserRepo.GetByDiscordID(ctx, discordUser.DiscordID)
+		if err != nil && !errors.Is(err, repositories.ErrDiscordUserNotFound) {
+			return false, fmt.Errorf("failed to get discord user: %w", err)
+		}
+		if existing == nil || discordUserChanged(existing, discordUser) {
+			if err := s.discordUserRepo.Upsert(ctx, discordUser); err != nil {
+				return false, fmt.Errorf("failed to upsert discord user: %w", err)
+			}
+			changed = true
+		} else if err := s.discordUserRepo.UpdateLastSeen(ctx, discordUser.DiscordID); err != nil {
+			return false, fmt.Errorf("failed to update discord user last seen: %w", err)
+		}
 	}
 
-	// Refresh display names for this specific guild member
-	if err := s.guildMemberRepo.RefreshDisplayNames(ctx, member.GuildID); err != nil {
-		return fmt.Errorf("failed to refresh display names: %w", err)
+	existing, err := s.guildMemberRepo.GetMember(ctx, member.GuildID, member.DiscordID)
+	if err != nil && !errors.Is(err, repositories.ErrGuildMemberNotFound) {
+		return false, fmt.Errorf("failed to get guild member: %w", err)
+	}
+	if existing == nil || guildMemberChanged(existing, member) {
+		if err := s.guildMemberRepo.Upsert(ctx, member); err != nil {
+			return false, err
+		}
+		changed = true
+	} else if err := s.guildMemberRepo.UpdateSyncedAt(ctx, member.GuildID, member.DiscordID, member.SyncedAt); err != nil {
+		return false, fmt.Errorf("failed to update guild member synced at: %w", err)
 	}
 
-	return nil
+	if changed {
+		s.displayNames.schedule(member.GuildID)
+	}
+	return changed, nil
+}
+
+// discordUserChanged reports whether updated differs from existing in the fields a
+// member update carries. An unset name or avatar matches an empty one.
+func discordUserChanged(existing, updated *entities.DiscordUser) bool {
+	return existing.DiscordUsername != updated.DiscordUsername ||
+		stringValue(existing.DiscordGlobalName) != stringValue(updated.DiscordGlobalName) ||
+		stringValue(existing.AvatarHash) != stringValue(updated.AvatarHash)
+}
+
+// guildMemberChanged reports whether updated differs from existing in nick, guild
+// avatar, or roles. Roles are compared ignoring order.
+func guildMemberChanged(existing, updated *entities.GuildMember) bool {
+	if stringValue(existing.GuildNick) != stringValue(updated.GuildNick) ||
+		stringValue(existing.GuildAvatarHash) != stringValue(updated.GuildAvatarHash) {
+		return true
+	}
+	existingRoles := slices.Clone(existing.Roles)
+	updatedRoles := slices.Clone(updated.Roles)
+	slices.Sort(existingRoles)
+	slices.Sort(updatedRoles)
+	return !slices.Equal(existingRoles, updatedRoles)
+}
+
+// stringValue returns *p, or "" when p is nil
+func stringValue(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
 }
 
 // UpsertDiscordUser creates or updates a discord user record
@@ -408,6 +489,20 @@ func (s *DiscordService) UpsertDiscordUsersBatch(
 	return nil
 }
 
+// ListActiveDiscordUsers returns Discord users seen since the given time, paginated by Discord ID
+func (s *DiscordService) ListActiveDiscordUsers(
+	ctx context.Context,
+	seenSince time.Time,
+	afterDiscordID string,
+	limit int,
+) ([]*entities.DiscordUser, error) {
+	users, err := s.discordUserRepo.ListActive(ctx, seenSince, afterDiscordID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active discord users: %w", err)
+	}
+	return users, nil
+}
+
 // UpsertGuildMembersBatch efficiently upserts multiple members
 func (s *DiscordService) UpsertGuildMembersBatch(
 	ctx context.Context,
@@ -486,29 +581,111 @@ func (s *DiscordService) UpdateMemberLastSeen(
 	return nil
 }
 
-// UpdateGuildSettings updates guild-specific settings
-func (s *DiscordService) UpdateGuildSettings(ctx context.Context, guildID string, settings map[string]interface{}) error {
-	// Validate guild exists
-	_, err := s.discordGuildRepo.GetByID(ctx, guildID)
-	if err != nil {
-		return fmt.Errorf("guild not found: %w", err)
-	}
-
+// UpdateGuildSettings replaces the top-level settings sections in patch, keeping the
+// others, and returns the merged settings. A non-zero expectedRevision rejects the
+// update with repositories.ErrStaleGuildSettings if the settings changed since then.
+func (s *DiscordService) UpdateGuildSettings(ctx context.Context, guildID string, patch map[string]interface{}, expectedRevision int64) (map[string]interface{}, error) {
 	// Add version if not present
-	if _, ok := settings["version"]; !ok {
-		settings["version"] = 1
+	if _, ok := patch["version"]; !ok {
+		patch["version"] = 1
 	}
 
-	err = s.discordGuildRepo.UpdateSettings(ctx, guildID, settings)
+	settings, err := s.discordGuildRepo.UpdateSettings(ctx, guildID, patch, expectedRevision)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	s.logger.Info("guild settings updated",
 		slog.String("component", "discord_service"),
 		slog.String("guild_id", guildID))
 
-	return nil
+	return settings, nil
+}
+
+// GuildWebhook returns the outbound webhook configured in a guild's settings, or nil if none is set.
+// Implements notify.WebhookSource.
+func (s *DiscordService) GuildWebhook(ctx context.Context, guildID string) (*notify.Webhook, error) {
+	settings, err := s.discordGuildRepo.GetSettings(ctx, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guild settings: %w", err)
+	}
+
+	webhook, ok := settings["webhook"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	url, _ := webhook["url"].(string)
+	if url == "" {
+		return nil, nil
+	}
+	secret, _ := webhook["secret"].(string)
+
+	return &notify.Webhook{URL: url, Secret: secret}, nil
+}
+
+// UniqueNoteTitles reports whether a guild's settings require each author's note titles
+// to be unique. A guild that isn't registered has no such requirement.
+// Implements NoteTitlePolicy.
+func (s *DiscordService) UniqueNoteTitles(ctx context.Context, guildID string) (bool, error) {
+	settings, err := s.discordGuildRepo.GetSettings(ctx, guildID)
+	if errors.Is(err, repositories.ErrDiscordGuildNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get guild settings: %w", err)
+	}
+
+	notes, ok := settings["notes"].(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	unique, _ := notes["unique_titles"].(bool)
+	return unique, nil
+}
+
+// QuoteCooldown returns how long a guild's settings make each member wait between
+// quotes, or zero for no limit. A guild that isn't registered has no limit.
+// Implements QuoteCooldownPolicy.
+func (s *DiscordService) QuoteCooldown(ctx context.Context, guildID string) (time.Duration, error) {
+	settings, err := s.discordGuildRepo.GetSettings(ctx, guildID)
+	if errors.Is(err, repositories.ErrDiscordGuildNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get guild settings: %w", err)
+	}
+
+	quotes, ok := settings["quotes"].(map[string]interface{})
+	if !ok {
+		return 0, nil
+	}
+	// Settings are stored as JSON, so numbers come back as float64
+	seconds, _ := quotes["cooldown_seconds"].(float64)
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// MaxReferences returns how many message references each wiki page and note in a guild
+// may have: the guild's setting, or DefaultMaxReferences when it has none or isn't
+// registered. Implements ReferenceLimitPolicy.
+func (s *DiscordService) MaxReferences(ctx context.Context, guildID string) (int, error) {
+	settings, err := s.discordGuildRepo.GetSettings(ctx, guildID)
+	if errors.Is(err, repositories.ErrDiscordGuildNotFound) {
+		return DefaultMaxReferences, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get guild settings: %w", err)
+	}
+
+	references, ok := settings["references"].(map[string]interface{})
+	if !ok {
+		return DefaultMaxReferences, nil
+	}
+	// Settings are stored as JSON, so numbers come back as float64
+	limit, _ := references["max_per_item"].(float64)
+	if limit <= 0 {
+		return DefaultMaxReferences, nil
+	}
+	return int(limit), nil
 }
 
 // GetGuildSettings retrieves guild settings
@@ -527,3 +704,28 @@ func (s *DiscordService) GetGuildSettings(ctx context.Context, guildID string) (
 
 	return settings, nil
 }
+
+// RecordDeadLetter stores an outbound Discord call the bot dropped after its retries
+func (s *DiscordService) RecordDeadLetter(ctx context.Context, deadLetter *entities.DeadLetter) error {
+	deadLetter.ID = idgen.GenerateID()
+	deadLetter.CreatedAt = time.Now()
+
+	s.logger.Warn("recording dropped Discord call",
+		slog.String("operation", deadLetter.Operation),
+		slog.String("failure_type", deadLetter.FailureType),
+		slog.Int("attempts", deadLetter.Attempts))
+
+	if err := s.deadLetterRepo.Create(ctx, deadLetter); err != nil {
+		return fmt.Errorf("failed to record dead letter: %w", err)
+	}
+	return nil
+}
+
+// ListDeadLetters returns dropped outbound Discord calls, most recent first, with the total
+func (s *DiscordService) ListDeadLetters(ctx context.Context, guildID string, limit, offset int) ([]*entities.DeadLetter, int, error) {
+	deadLetters, total, err := s.deadLetterRepo.List(ctx, guildID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	return deadLetters, total, nil
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// recordingMemberRepo stores guild members and counts writes and display name refreshes
+type recordingMemberRepo struct {
+	repositories.GuildMemberRepository
+	members   map[string]*entities.GuildMember // guild ID + discord ID -> member
+	upserts   int
+	touches   int            // synced_at-only updates
+	refreshes map[string]int // guild ID -> refreshes
+}
+
+func (r *recordingMemberRepo) GetMember(ctx context.Context, guildID, discordID string) (*entities.GuildMember, error) {
+	member, ok := r.members[guildID+discordID]
+	if !ok {
+		return nil, repositories.ErrGuildMemberNotFound
+	}
+	copied := *member
+	return &copied, nil
+}
+
+func (r *recordingMemberRepo) Upsert(ctx context.Context, member *entities.GuildMember) error {
+	copied := *member
+	r.members[member.GuildID+member.DiscordID] = &copied
+	r.upserts++
+	return nil
+}
+
+func (r *recordingMemberRepo) UpdateSyncedAt(ctx context.Context, guildID, discordID string, syncedAt time.Time) error {
+	member, ok := r.members[guildID+discordID]
+	if !ok {
+		return repositories.ErrGuildMemberNotFound
+	}
+	member.SyncedAt = syncedAt
+	r.touches++
+	return nil
+}
+
+func (r *recordingMemberRepo) RefreshDisplayNames(ctx context.Context, guildID string) error {
+	r.refreshes[guildID]++
+	return nil
+}
+
+// recordingDiscordUserRepo stores Discord users and counts writes
+type recordingDiscordUserRepo struct {
+	repositories.DiscordUserRepository
+	users   map[string]*entities.DiscordUser
+	upserts int
+	touches int // last_seen-only updates
+}
+
+func (r *recordingDiscordUserRepo) GetByDiscordID(ctx context.Context, discordID string) (*entities.DiscordUser, error) {
+	user, ok := r.users[discordID]
+	if !ok {
+		return nil, repositories.ErrDiscordUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *recordingDiscordUserRepo) Upsert(ctx context.Context, user *entities.DiscordUser) error {
+	copied := *user
+	r.users[user.DiscordID] = &copied
+	r.upserts++
+	return nil
+}
+
+func (r *recordingDiscordUserRepo) UpdateLastSeen(ctx context.Context, discordID string) error {
+	r.touches++
+	return nil
+}
+
+func TestUpsertGuildMember_ChangeDetection(t *testing.T) {
+	members := &recordingMemberRepo{members: map[string]*entities.GuildMember{}, refreshes: map[string]int{}}
+	users := &recordingDiscordUserRepo{users: map[string]*entities.DiscordUser{}}
+	svc := NewDiscordService(users, nil, members, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	str := func(s string) *string { return &s }
+	upsert := func(globalName, nick string, roles ...string) bool {
+		t.Helper()
+		changed, err := svc.UpsertGuildMember(ctx,
+			&entities.DiscordUser{DiscordID: "u1", DiscordUsername: "alice", DiscordGlobalName: str(globalName)},
+			&entities.GuildMember{GuildID: "g1", DiscordID: "u1", GuildNick: str(nick), Roles: roles, SyncedAt: time.Now()},
+		)
+		if err != nil {
+			t.Fatalf("UpsertGuildMember() error = %v", err)
+		}
+		svc.displayNames.flush()
+		return changed
+	}
+
+	if !upsert("Alice", "Al", "r1", "r2") {
+		t.Error("UpsertGuildMember() of a new member reported no change")
+	}
+	if users.upserts != 1 || members.upserts != 1 || members.refreshes["g1"] != 1 {
+		t.Fatalf("after new member: %d user writes, %d member writes, %d refreshes; want 1 each", users.upserts, members.upserts, members.refreshes["g1"])
+	}
+
+	// Same data, roles in another order: nothing is written or refreshed
+	if upsert("Alice", "Al", "r2", "r1") {
+		t.Error("UpsertGuildMember() with unchanged data reported a change")
+	}
+	if users.upserts != 1 || members.upserts != 1 || members.refreshes["g1"] != 1 {
+		t.Errorf("after no-op update: %d user writes, %d member writes, %d refreshes; want 1 each", users.upserts, members.upserts, members.refreshes["g1"])
+	}
+	// ...but the member still counts as seen and synced
+	if users.touches != 1 || members.touches != 1 {
+		t.Errorf("after no-op update: %d last_seen and %d synced_at updates, want 1 each", users.touches, members.touches)
+	}
+
+	// A new nick writes only the member; a new global name only the user
+	if !upsert("Alice", "Ally", "r1", "r2") || users.upserts != 1 || members.upserts != 2 {
+		t.Errorf("after nick change: %d user writes, %d member writes; want 1, 2", users.upserts, members.upserts)
+	}
+	if !upsert("Alicia", "Ally", "r1", "r2") || users.upserts != 2 || members.upserts != 2 {
+		t.Errorf("after global name change: %d user writes, %d member writes; want 2, 2", users.upserts, members.upserts)
+	}
+	if !upsert("Alicia", "Ally", "r1") || members.upserts != 3 {
+		t.Errorf("after role change: %d member writes, want 3", members.upserts)
+	}
+	if members.refreshes["g1"] != 4 {
+		t.Errorf("refreshes = %d, want 4, one per change", members.refreshes["g1"])
+	}
+}
+
+func TestDiscordServiceClose_FlushesRefreshes(t *testing.T) {
+	members := &recordingMemberRepo{members: map[string]*entities.GuildMember{}, refreshes: map[string]int{}}
+	users := &recordingDiscordUserRepo{users: map[string]*entities.DiscordUser{}}
+	svc := NewDiscordService(users, nil, members, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if _, err := svc.UpsertGuildMember(context.Background(), nil, &entities.GuildMember{GuildID: "g1", DiscordID: "u1"}); err != nil {
+		t.Fatalf("UpsertGuildMember() error = %v", err)
+	}
+	if members.refreshes["g1"] != 0 {
+		t.Fatalf("refreshed before the delay: %v", members.refreshes)
+	}
+
+	svc.Close()
+	if members.refreshes["g1"] != 1 {
+		t.Errorf("refreshes after Close = %v, want the queued guild refreshed", members.refreshes)
+	}
+}
+
+func TestDisplayNameRefresher_Batches(t *testing.T) {
+	members := &recordingMemberRepo{refreshes: map[string]int{}}
+	refresher := newDisplayNameRefresher(members, displayNameRefreshDelay, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	refresher.schedule("g1")
+	refresher.schedule("g1")
+	refresher.schedule("g2")
+	if len(members.refreshes) != 0 {
+		t.Fatalf("refreshed before the delay: %v", members.refreshes)
+	}
+
+	refresher.flush()
+	if members.refreshes["g1"] != 1 || members.refreshes["g2"] != 1 {
+		t.Errorf("refreshes = %v, want one per guild", members.refreshes)
+	}
+
+	// Nothing is left queued
+	refresher.flush()
+	if members.refreshes["g1"] != 1 || members.refreshes["g2"] != 1 {
+		t.Errorf("refreshes after a second flush = %v, want unchanged", members.refreshes)
+	}
+}
+
+// storingGuildRepo keeps registered guilds in memory
+type storingGuildRepo struct {
+	repositories.DiscordGuildRepository
+	guilds map[string]*entities.DiscordGuild
+}
+
+func (r *storingGuildRepo) GetByID(ctx context.Context, guildID string) (*entities.DiscordGuild, error) {
+	guild, ok := r.guilds[guildID]
+	if !ok {
+		return nil, repositories.ErrDiscordGuildNotFound
+	}
+	copied := *guild
+	return &copied, nil
+}
+
+func (r *storingGuildRepo) Create(ctx context.Context, guild *entities.DiscordGuild) error {
+	copied := *guild
+	r.guilds[guild.GuildID] = &copied
+	return nil
+}
+
+func (r *storingGuildRepo) Update(ctx context.Context, guild *entities.DiscordGuild) error {
+	copied := *guild
+	r.guilds[guild.GuildID] = &copied
+	return nil
+}
+
+func TestUpsertGuild_FirstJoinVersusReconnect(t *testing.T) {
+	guilds := &storingGuildRepo{guilds: map[string]*entities.DiscordGuild{}}
+	svc := NewDiscordService(nil, guilds, nil, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	first, created, err := svc.UpsertGuild(ctx, "g1", "Guild", "", "owner")
+	if err != nil {
+		t.Fatalf("UpsertGuild() error = %v", err)
+	}
+	if !created {
+		t.Error("UpsertGuild() of a new guild reported created = false")
+	}
+
+	// The gateway sends GUILD_CREATE again for every guild after a reconnect
+	again, created, err := svc.UpsertGuild(ctx, "g1", "Guild Renamed", "", "owner")
+	if err != nil {
+		t.Fatalf("UpsertGuild() again error = %v", err)
+	}
+	if created {
+		t.Error("UpsertGuild() of a registered guild reported created = true")
+	}
+	if !again.AddedAt.Equal(first.AddedAt) || again.GuildName != "Guild Renamed" {
+		t.Errorf("UpsertGuild() again = added %v, name %q; want the first AddedAt and the new name", again.AddedAt, again.GuildName)
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// displayNameRefreshDelay is how long member changes are collected before a guild's
+// display names are refreshed, so a burst of updates costs one refresh
+const displayNameRefreshDelay = 5 * time.Second
+
+// displayNameRefresher batches RefreshDisplayNames calls. Guilds are queued with
+// schedule and refreshed together once the delay passes; a guild queued several times
+// meanwhile is refreshed once. DiscordService.Close flushes refreshes still queued at
+// shutdown.
+type displayNameRefresher struct {
+	repo   repositories.GuildMemberRepository
+	delay  time.Duration
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	pending map[string]bool
+	timer   *time.Timer
+}
+
+func newDisplayNameRefresher(repo repositories.GuildMemberRepository, delay time.Duration, logger *slog.Logger) *displayNameRefresher {
+	return &displayNameRefresher{
+		repo:    repo,
+		delay:   delay,
+		logger:  logger,
+		pending: make(map[string]bool),
+	}
+}
+
+// schedule queues a refresh of guildID's display names
+func (r *displayNameRefresher) schedule(guildID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending[guildID] = true
+	if r.timer == nil {
+		r.timer = time.AfterFunc(r.delay, r.flush)
+	}
+}
+
+// flush refreshes every queued guild
+func (r *displayNameRefresher) flush() {
+	r.mu.Lock()
+	guildIDs := r.pending
+	r.pending = make(map[string]bool)
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	r.mu.Unlock()
+
+	for guildID := range guildIDs {
+		if err := r.repo.RefreshDisplayNames(context.Background(), guildID); err != nil {
+			r.logger.Warn("failed to refresh display names",
+				slog.String("guild_id", guildID),
+				slog.String("error", err.Error()))
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"github.com/devilmonastery/hivemind/internal/domain/repositories"
 )
@@ -27,3 +29,40 @@ func IsUserInactive(err error) bool {
 func IsUserNotFound(err error) bool {
 	return errors.Is(err, repositories.ErrUserNotFound)
 }
+
+// ErrIDInUse is returned when a caller-supplied ID for new content is already taken
+var ErrIDInUse = errors.New("id is already in use")
+
+// checkPreservedID rejects a caller-supplied ID that any existing row already uses.
+// An empty id means one will be generated, so there is nothing to check.
+func checkPreservedID(ctx context.Context, id string, exists func(context.Context, string) (bool, error)) error {
+	if id == "" {
+		return nil
+	}
+	taken, err := exists(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check id: %w", err)
+	}
+	if taken {
+		return fmt.Errorf("%w: %s", ErrIDInUse, id)
+	}
+	return nil
+}
+
+// ErrDuplicateQuote is returned when the guild already has the quote being created; see DuplicateQuoteError
+var ErrDuplicateQuote = errors.New("quote already saved")
+
+// DuplicateQuoteError is the ErrDuplicateQuote returned for a create, naming the quote
+// already saved. The quote itself isn't loaded, since the caller may not be allowed to see it.
+type DuplicateQuoteError struct {
+	ExistingID string
+}
+
+func (e *DuplicateQuoteError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrDuplicateQuote, e.ExistingID)
+}
+
+// Is makes errors.Is(err, ErrDuplicateQuote) match
+func (e *DuplicateQuoteError) Is(target error) bool {
+	return target == ErrDuplicateQuote
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+var (
+	// ErrSameGuild is returned when content would be moved into the guild it is already in
+	ErrSameGuild = errors.New("source and target guild must be different")
+
+	// ErrUnknownContentType is returned for content types other than wiki, note, and quote
+	ErrUnknownContentType = errors.New("unknown content type")
+)
+
+// allContentTypes is the default, and order, of content types for a guild move
+var allContentTypes = []string{entities.ContentTypeWiki, entities.ContentTypeNote, entities.ContentTypeQuote}
+
+// GuildContentService moves wiki pages, notes, and quotes between guilds and authors
+type GuildContentService struct {
+	contentRepo repositories.GuildContentRepository
+	guildRepo   repositories.DiscordGuildRepository
+	userRepo    repositories.UserRepository
+	audit       contentAuditor
+}
+
+// NewGuildContentService creates a new guild content service
+func NewGuildContentService(contentRepo repositories.GuildContentRepository, guildRepo repositories.DiscordGuildRepository, userRepo repositories.UserRepository, auditRepo repositories.AuditRepository) *GuildContentService {
+	return &GuildContentService{
+		contentRepo: contentRepo,
+		guildRepo:   guildRepo,
+		userRepo:    userRepo,
+		audit:       contentAuditor{repo: auditRepo},
+	}
+}
+
+// MoveGuildContent reassigns content from sourceGuildID to targetGuildID, e.g. when a community
+// migrates to a new server. contentTypes selects what moves (empty = everything).
+// With dryRun set, the returned counts describe what would move and nothing is written.
+// Note: No ACL check needed - moving content is an admin-only operation
+func (s *GuildContentService) MoveGuildContent(ctx context.Context, sourceGuildID, targetGuildID string, contentTypes []string, dryRun bool) (*entities.GuildContentMove, error) {
+	if sourceGuildID == targetGuildID {
+		return nil, ErrSameGuild
+	}
+
+	types, err := normalizeContentTypes(contentTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, guildID := range []string{sourceGuildID, targetGuildID} {
+		if _, err := s.guildRepo.GetByID(ctx, guildID); err != nil {
+			return nil, fmt.Errorf("guild %s: %w", guildID, err)
+		}
+	}
+
+	move, err := s.contentRepo.MoveContent(ctx, sourceGuildID, targetGuildID, types, dryRun)
+	if err != nil {
+		return move, err
+	}
+
+	if !dryRun {
+		s.audit.record(ctx, entities.ActionGuildContentMoved, entities.ResourceGuild, sourceGuildID, sourceGuildID, map[string]any{
+			"target_guild_id": targetGuildID,
+			"content_types":   types,
+			"wiki_pages":      move.WikiPages,
+			"notes":           move.Notes,
+			"quotes":          move.Quotes,
+		})
+	}
+
+	return move, nil
+}
+
+// ReassignAuthor makes authorID the author of a wiki page, note, or quote, e.g. content
+// migrated from elsewhere or saved by the bot on someone else's behalf. Author names in
+// responses are looked up from the author's Discord account, so a quote can only be
+// given to a user with one linked (repositories.ErrDiscordUserNotFound otherwise).
+// Note: No ACL check needed - reassigning authors is an admin-only operation
+func (s *GuildContentService) ReassignAuthor(ctx context.Context, contentType entities.AuditResource, id, authorID string) (*entities.AuthorReassignment, error) {
+	switch contentType {
+	case entities.ResourceWikiPage, entities.ResourceNote, entities.ResourceQuote:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownContentType, contentType)
+	}
+
+	author, err := s.userRepo.GetByID(ctx, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("author %s: %w", authorID, err)
+	}
+
+	reassignment, err := s.contentRepo.ReassignAuthor(ctx, contentType, id, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign author: %w", err)
+	}
+	reassignment.AuthorName = author.DisplayName
+
+	s.audit.record(ctx, entities.ActionContentAuthorReassigned, contentType, id, reassignment.GuildID, map[string]any{
+		"previous_author_id": reassignment.PreviousAuthorID,
+		"author_id":          authorID,
+	})
+
+	return reassignment, nil
+}
+
+// normalizeContentTypes validates and de-duplicates content types, defaulting to all of them
+func normalizeContentTypes(contentTypes []string) ([]string, error) {
+	if len(contentTypes) == 0 {
+		return allContentTypes, nil
+	}
+
+	requested := make(map[string]bool, len(contentTypes))
+	for _, t := range contentTypes {
+		switch t {
+		case entities.ContentTypeWiki, entities.ContentTypeNote, entities.ContentTypeQuote:
+			requested[t] = true
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownContentType, t)
+		}
+	}
+
+	var types []string
+	for _, t := range allContentTypes {
+		if requested[t] {
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
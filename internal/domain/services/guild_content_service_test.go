@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+type fakeGuildRepo struct {
+	repositories.DiscordGuildRepository
+	guilds   map[string]bool
+	owners   map[string]string
+	settings map[string]map[string]interface{}
+}
+
+func (r *fakeGuildRepo) GetByID(ctx context.Context, guildID string) (*entities.DiscordGuild, error) {
+	if !r.guilds[guildID] {
+		return nil, repositories.ErrDiscordGuildNotFound
+	}
+	guild := &entities.DiscordGuild{GuildID: guildID}
+	if owner, ok := r.owners[guildID]; ok {
+		guild.OwnerID = &owner
+	}
+	return guild, nil
+}
+
+func (r *fakeGuildRepo) GetSettings(ctx context.Context, guildID string) (map[string]interface{}, error) {
+	if !r.guilds[guildID] {
+		return nil, repositories.ErrDiscordGuildNotFound
+	}
+	return r.settings[guildID], nil
+}
+
+type fakeGuildContentRepo struct {
+	calls    []string
+	types    []string
+	authors  map[string]string // content ID -> author ID
+	unlinked map[string]bool   // user IDs with no linked Discord account
+}
+
+func (r *fakeGuildContentRepo) ReassignAuthor(ctx context.Context, contentType entities.AuditResource, id, authorID string) (*entities.AuthorReassignment, error) {
+	previous, ok := r.authors[id]
+	if !ok {
+		return nil, repositories.ErrContentNotFound
+	}
+	if contentType == entities.ResourceQuote && r.unlinked[authorID] {
+		return nil, repositories.ErrDiscordUserNotFound
+	}
+	r.authors[id] = authorID
+	return &entities.AuthorReassignment{ContentType: contentType, ID: id, GuildID: "g1", PreviousAuthorID: previous, AuthorID: authorID}, nil
+}
+
+func (r *fakeGuildContentRepo) MoveContent(ctx context.Context, sourceGuildID, targetGuildID string, contentTypes []string, dryRun bool) (*entities.GuildContentMove, error) {
+	r.calls = append(r.calls, sourceGuildID+"->"+targetGuildID)
+	r.types = contentTypes
+	return &entities.GuildContentMove{SourceGuildID: sourceGuildID, TargetGuildID: targetGuildID, ContentTypes: contentTypes, DryRun: dryRun, Quotes: 3}, nil
+}
+
+func newGuildContentFixture() (*GuildContentService, *fakeGuildContentRepo, *fakeAuditRepo) {
+	content := &fakeGuildContentRepo{authors: map[string]string{"q1": "bot"}, unlinked: map[string]bool{"u2": true}}
+	audit := &fakeAuditRepo{}
+	users := &fakeUserRepo{users: map[string]*entities.User{
+		"bot": {ID: "bot", DisplayName: "Hivemind"},
+		"u1":  {ID: "u1", DisplayName: "Ada"},
+		"u2":  {ID: "u2", DisplayName: "Grace"},
+	}}
+	svc := NewGuildContentService(content, &fakeGuildRepo{guilds: map[string]bool{"g1": true, "g2": true}}, users, audit)
+	return svc, content, audit
+}
+
+func TestMoveGuildContent_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		target  string
+		types   []string
+		wantErr error
+	}{
+		{name: "same guild", source: "g1", target: "g1", wantErr: ErrSameGuild},
+		{name: "unknown type", source: "g1", target: "g2", types: []string{"wiki", "polls"}, wantErr: ErrUnknownContentType},
+		{name: "missing target", source: "g1", target: "g3", wantErr: repositories.ErrDiscordGuildNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, content, _ := newGuildContentFixture()
+			_, err := svc.MoveGuildContent(context.Background(), tt.source, tt.target, tt.types, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MoveGuildContent() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(content.calls) != 0 {
+				t.Errorf("repository called %d times, want 0", len(content.calls))
+			}
+		})
+	}
+}
+
+func TestMoveGuildContent_NormalizesTypes(t *testing.T) {
+	svc, content, _ := newGuildContentFixture()
+
+	if _, err := svc.MoveGuildContent(context.Background(), "g1", "g2", []string{"quote", "wiki", "quote"}, true); err != nil {
+		t.Fatalf("MoveGuildContent() error = %v", err)
+	}
+	if want := []string{"wiki", "quote"}; !reflect.DeepEqual(content.types, want) {
+		t.Errorf("content types = %v, want %v", content.types, want)
+	}
+
+	if _, err := svc.MoveGuildContent(context.Background(), "g1", "g2", nil, true); err != nil {
+		t.Fatalf("MoveGuildContent() error = %v", err)
+	}
+	if !reflect.DeepEqual(content.types, allContentTypes) {
+		t.Errorf("content types = %v, want %v", content.types, allContentTypes)
+	}
+}
+
+func TestMoveGuildContent_AuditsOnlyRealMoves(t *testing.T) {
+	svc, _, audit := newGuildContentFixture()
+
+	if _, err := svc.MoveGuildContent(context.Background(), "g1", "g2", nil, true); err != nil {
+		t.Fatalf("MoveGuildContent(dry run) error = %v", err)
+	}
+	if len(audit.logs) != 0 {
+		t.Fatalf("dry run wrote %d audit entries, want 0", len(audit.logs))
+	}
+
+	if _, err := svc.MoveGuildContent(context.Background(), "g1", "g2", nil, false); err != nil {
+		t.Fatalf("MoveGuildContent() error = %v", err)
+	}
+	if len(audit.logs) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(audit.logs))
+	}
+	entry := audit.logs[0]
+	if entry.Action != entities.ActionGuildContentMoved || entry.Metadata["target_guild_id"] != "g2" {
+		t.Errorf("entry = %s %v", entry.Action, entry.Metadata)
+	}
+}
+
+func TestReassignAuthor(t *testing.T) {
+	svc, content, audit := newGuildContentFixture()
+
+	reassignment, err := svc.ReassignAuthor(context.Background(), entities.ResourceQuote, "q1", "u1")
+	if err != nil {
+		t.Fatalf("ReassignAuthor() error = %v", err)
+	}
+	if reassignment.PreviousAuthorID != "bot" || reassignment.AuthorID != "u1" || reassignment.AuthorName != "Ada" {
+		t.Errorf("reassignment = %+v, want bot -> u1 (Ada)", reassignment)
+	}
+	if content.authors["q1"] != "u1" {
+		t.Errorf("stored author = %q, want u1", content.authors["q1"])
+	}
+
+	if len(audit.logs) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(audit.logs))
+	}
+	entry := audit.logs[0]
+	if entry.Action != entities.ActionContentAuthorReassigned || entry.Resource != entities.ResourceQuote ||
+		entry.Metadata["previous_author_id"] != "bot" || entry.Metadata["author_id"] != "u1" {
+		t.Errorf("entry = %s %s %v", entry.Action, entry.Resource, entry.Metadata)
+	}
+}
+
+func TestReassignAuthor_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType entities.AuditResource
+		id          string
+		authorID    string
+		wantErr     error
+	}{
+		{name: "unknown type", contentType: "snippet", id: "q1", authorID: "u1", wantErr: ErrUnknownContentType},
+		{name: "unknown author", contentType: entities.ResourceQuote, id: "q1", authorID: "nobody", wantErr: repositories.ErrUserNotFound},
+		{name: "unknown content", contentType: entities.ResourceNote, id: "n404", authorID: "u1", wantErr: repositories.ErrContentNotFound},
+		{name: "quote author without discord", contentType: entities.ResourceQuote, id: "q1", authorID: "u2", wantErr: repositories.ErrDiscordUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, content, audit := newGuildContentFixture()
+			if _, err := svc.ReassignAuthor(context.Background(), tt.contentType, tt.id, tt.authorID); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReassignAuthor() error = %v, want %v", err, tt.wantErr)
+			}
+			if content.authors["q1"] != "bot" {
+				t.Errorf("author changed to %q on a failed reassignment", content.authors["q1"])
+			}
+			if len(audit.logs) != 0 {
+				t.Errorf("failed reassignment wrote %d audit entries", len(audit.logs))
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// PermissionWikiEdit is the guild permissions key listing the Discord roles allowed to
+// create and edit wiki pages
+const PermissionWikiEdit = "wiki_edit_roles"
+
+// ErrMissingRole is returned when a guild restricts an action to roles the member doesn't have
+var ErrMissingRole = errors.New("missing a role required for this action")
+
+// RequiredRoles returns the role IDs a guild's settings require for action; empty means anyone may
+func RequiredRoles(settings map[string]interface{}, action string) []string {
+	permissions, ok := settings["permissions"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var roles []string
+	switch v := permissions[action].(type) {
+	case []string:
+		roles = v
+	case []interface{}:
+		for _, r := range v {
+			if id, ok := r.(string); ok && id != "" {
+				roles = append(roles, id)
+			}
+		}
+	}
+	return roles
+}
+
+// CheckGuildRole returns ErrMissingRole unless discordID may perform action in guildID:
+// the guild has no roles configured for it, discordID owns the guild, or their synced
+// member roles include one of the configured roles.
+// Note: Hivemind admins bypass this check in the handlers
+func (s *DiscordService) CheckGuildRole(ctx context.Context, guildID, discordID, action string) error {
+	settings, err := s.discordGuildRepo.GetSettings(ctx, guildID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDiscordGuildNotFound) {
+			return nil // Unknown guilds have nothing configured
+		}
+		return fmt.Errorf("failed to get guild settings: %w", err)
+	}
+
+	required := RequiredRoles(settings, action)
+	if len(required) == 0 {
+		return nil
+	}
+	if discordID == "" {
+		return ErrMissingRole
+	}
+
+	guild, err := s.discordGuildRepo.GetByID(ctx, guildID)
+	if err != nil {
+		return fmt.Errorf("failed to get guild: %w", err)
+	}
+	if guild.OwnerID != nil && *guild.OwnerID == discordID {
+		return nil
+	}
+
+	member, err := s.guildMemberRepo.GetMember(ctx, guildID, discordID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrGuildMemberNotFound) {
+			return ErrMissingRole
+		}
+		return fmt.Errorf("failed to get guild member: %w", err)
+	}
+	for _, role := range member.Roles {
+		if slices.Contains(required, role) {
+			return nil
+		}
+	}
+
+	s.logger.Info("guild role check denied",
+		slog.String("guild_id", guildID),
+		slog.String("discord_id", discordID),
+		slog.String("action", action))
+	return ErrMissingRole
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+type fakeGuildMemberRepo struct {
+	repositories.GuildMemberRepository
+	roles map[string][]string // discord ID -> roles
+}
+
+func (r *fakeGuildMemberRepo) GetMember(ctx context.Context, guildID, discordID string) (*entities.GuildMember, error) {
+	roles, ok := r.roles[discordID]
+	if !ok {
+		return nil, repositories.ErrGuildMemberNotFound
+	}
+	return &entities.GuildMember{GuildID: guildID, DiscordID: discordID, Roles: roles}, nil
+}
+
+func TestCheckGuildRole(t *testing.T) {
+	guilds := &fakeGuildRepo{
+		guilds: map[string]bool{"g1": true, "open": true},
+		owners: map[string]string{"g1": "owner"},
+		settings: map[string]map[string]interface{}{
+			// Stored settings come back from JSONB as []interface{}
+			"g1": {"permissions": map[string]interface{}{PermissionWikiEdit: []interface{}{"editors", "mods"}}},
+		},
+	}
+	members := &fakeGuildMemberRepo{roles: map[string][]string{
+		"editor": {"everyone", "editors"},
+		"mod":    {"mods"},
+		"member": {"everyone"},
+		"owner":  {},
+		"lurker": nil,
+	}}
+	svc := NewDiscordService(nil, guilds, members, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tests := []struct {
+		name      string
+		guildID   string
+		discordID string
+		wantErr   error
+	}{
+		{name: "member with a required role", guildID: "g1", discordID: "editor"},
+		{name: "member with another required role", guildID: "g1", discordID: "mod"},
+		{name: "guild owner without the role", guildID: "g1", discordID: "owner"},
+		{name: "member without the role", guildID: "g1", discordID: "member", wantErr: ErrMissingRole},
+		{name: "member with no roles", guildID: "g1", discordID: "lurker", wantErr: ErrMissingRole},
+		{name: "not a synced member", guildID: "g1", discordID: "outsider", wantErr: ErrMissingRole},
+		{name: "no discord identity", guildID: "g1", discordID: "", wantErr: ErrMissingRole},
+		{name: "guild without configured roles", guildID: "open", discordID: "member"},
+		{name: "unknown guild", guildID: "missing", discordID: "member"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.CheckGuildRole(context.Background(), tt.guildID, tt.discordID, PermissionWikiEdit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckGuildRole() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequiredRoles(t *testing.T) {
+	settings := map[string]interface{}{
+		"permissions": map[string]interface{}{PermissionWikiEdit: []string{"r1", "r2"}},
+	}
+	if got := RequiredRoles(settings, PermissionWikiEdit); len(got) != 2 {
+		t.Errorf("RequiredRoles() = %v, want [r1 r2]", got)
+	}
+	if got := RequiredRoles(map[string]interface{}{}, PermissionWikiEdit); len(got) != 0 {
+		t.Errorf("RequiredRoles() on empty settings = %v, want none", got)
+	}
+}
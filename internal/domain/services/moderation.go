@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// ErrContentRejected is returned when text matches a moderation pattern under the reject policy
+var ErrContentRejected = errors.New("content was rejected by moderation")
+
+// ModerationService checks wiki pages, notes, and quotes against a configured blocklist
+// before they are saved. Depending on the policy, matching content is either rejected
+// or saved and flagged for admins to review. With no patterns it does nothing.
+type ModerationService struct {
+	patterns    []*regexp.Regexp
+	flagMatches bool // Save and flag matching content instead of rejecting it
+	repo        repositories.ModerationRepository
+}
+
+// NewModerationService compiles the blocklist patterns
+// flagMatches saves and flags matching content for review instead of rejecting it
+func NewModerationService(patterns []string, flagMatches bool, repo repositories.ModerationRepository) (*ModerationService, error) {
+	s := &ModerationService{flagMatches: flagMatches, repo: repo}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid moderation pattern %q: %w", pattern, err)
+		}
+		s.patterns = append(s.patterns, re)
+	}
+	return s, nil
+}
+
+// review checks text that is about to be saved. Under the reject policy a match returns
+// ErrContentRejected; under the flag policy it returns the pattern that matched, to be
+// passed to flag once the content is saved. Clean text, or a nil service, returns "".
+func (s *ModerationService) review(texts ...string) (string, error) {
+	if s == nil {
+		return "", nil
+	}
+	for _, re := range s.patterns {
+		for _, text := range texts {
+			if !re.MatchString(text) {
+				continue
+			}
+			if !s.flagMatches {
+				return "", ErrContentRejected
+			}
+			return re.String(), nil
+		}
+	}
+	return "", nil
+}
+
+// flag records the result of review for saved content: a non-empty reason flags it, an
+// empty one clears any earlier flag. Failures are logged and never fail the save itself.
+func (s *ModerationService) flag(ctx context.Context, contentType entities.AuditResource, id, reason string) {
+	if s == nil || s.repo == nil || len(s.patterns) == 0 {
+		return
+	}
+	if err := s.repo.SetFlagged(ctx, contentType, id, reason); err != nil {
+		slog.Default().Warn("failed to flag content for review",
+			slog.String("content_type", string(contentType)),
+			slog.String("id", id),
+			slog.String("error", err.Error()))
+	}
+}
+
+// ListFlagged lists content flagged for review, most recently flagged first
+// contentType limits the list to one kind of content (empty = all)
+func (s *ModerationService) ListFlagged(ctx context.Context, contentType entities.AuditResource, limit, offset int) ([]*entities.FlaggedContent, int, error) {
+	if s == nil || s.repo == nil {
+		return nil, 0, nil
+	}
+	items, total, err := s.repo.ListFlagged(ctx, contentType, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list flagged content: %w", err)
+	}
+	return items, total, nil
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+type fakeModerationRepo struct {
+	repositories.ModerationRepository
+	flags map[string]string // content ID -> reason
+}
+
+func (r *fakeModerationRepo) SetFlagged(ctx context.Context, contentType entities.AuditResource, id, reason string) error {
+	if reason == "" {
+		delete(r.flags, id)
+		return nil
+	}
+	r.flags[id] = reason
+	return nil
+}
+
+func TestNewModerationService_InvalidPattern(t *testing.T) {
+	if _, err := NewModerationService([]string{"(unclosed"}, false, nil); err == nil {
+		t.Fatal("NewModerationService() error = nil, want an error for an invalid pattern")
+	}
+}
+
+func TestModeration_CreateQuote(t *testing.T) {
+	patterns := []string{`(?i)\bbuy cheap\b`, `https?://spam\.example`}
+
+	tests := []struct {
+		name        string
+		patterns    []string
+		flagMatches bool
+		body        string
+		wantErr     error
+		wantFlag    string
+	}{
+		{name: "clean", patterns: patterns, body: "The cake is a lie"},
+		{name: "no patterns", body: "Buy cheap cake at https://spam.example"},
+		{name: "reject", patterns: patterns, body: "BUY CHEAP cake", wantErr: ErrContentRejected},
+		{name: "reject link", patterns: patterns, body: "see https://spam.example/cake", wantErr: ErrContentRejected},
+		{name: "flag", patterns: patterns, flagMatches: true, body: "see https://spam.example/cake", wantFlag: `https?://spam\.example`},
+		{name: "flag clean", patterns: patterns, flagMatches: true, body: "The cake is a lie"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := &fakeModerationRepo{flags: map[string]string{}}
+			moderation, err := NewModerationService(tt.patterns, tt.flagMatches, flags)
+			if err != nil {
+				t.Fatalf("NewModerationService() error = %v", err)
+			}
+			repo := newFakeQuoteRepo()
+			svc := NewQuoteService(repo, nil, nil, moderation, nil)
+
+			_, err = svc.CreateQuote(context.Background(), &entities.Quote{GuildID: "g1", Body: tt.body})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateQuote() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(repo.created) != 0 {
+					t.Error("rejected quote was saved")
+				}
+				return
+			}
+			if len(repo.created) != 1 {
+				t.Fatalf("saved %d quotes, want 1", len(repo.created))
+			}
+			if got := flags.flags["new"]; got != tt.wantFlag {
+				t.Errorf("flag reason = %q, want %q", got, tt.wantFlag)
+			}
+		})
+	}
+}
+
+func TestModeration_UpdateQuoteClearsFlag(t *testing.T) {
+	flags := &fakeModerationRepo{flags: map[string]string{"q1": `(?i)\bbuy cheap\b`}}
+	moderation, err := NewModerationService([]string{`(?i)\bbuy cheap\b`}, true, flags)
+	if err != nil {
+		t.Fatalf("NewModerationService() error = %v", err)
+	}
+	repo := newFakeQuoteRepo(&entities.Quote{ID: "q1", GuildID: "g1", Body: "Buy cheap cake"})
+	svc := NewQuoteService(repo, nil, nil, moderation, nil)
+
+	if _, err := svc.UpdateQuote(context.Background(), "q1", "The cake is a lie", nil, "", ""); err != nil {
+		t.Fatalf("UpdateQuote() error = %v", err)
+	}
+	if reason, ok := flags.flags["q1"]; ok {
+		t.Errorf("flag = %q after editing out the match, want it cleared", reason)
+	}
+}
+
+func TestModeration_NilService(t *testing.T) {
+	var moderation *ModerationService
+	if reason, err := moderation.review("anything"); reason != "" || err != nil {
+		t.Errorf("review() = %q, %v, want no-op", reason, err)
+	}
+	moderation.flag(context.Background(), entities.ResourceNote, "n1", "reason")
+}
+
+// Wiki pages and notes are moderated on create and on update, title included
+func TestModeration_WikiPagesAndNotes(t *testing.T) {
+	const pattern = `(?i)\bbuy cheap\b`
+
+	saves := map[string]func(moderation *ModerationService, title, body string) (string, error){
+		"CreateWikiPage": func(moderation *ModerationService, title, body string) (string, error) {
+			svc := NewWikiService(&fakeWikiPageRepo{pages: map[string]*entities.WikiPage{}}, nil, nil, nil, nil, nil, nil, moderation, nil)
+			page, err := svc.CreateWikiPage(context.Background(), &entities.WikiPage{GuildID: "g1", Title: title, Body: body}, "")
+			if err != nil {
+				return "", err
+			}
+			return page.ID, nil
+		},
+		"UpdateWikiPage": func(moderation *ModerationService, title, body string) (string, error) {
+			repo := &fakeWikiPageRepo{pages: map[string]*entities.WikiPage{"w1": {ID: "w1", GuildID: "g1", Title: "Rules", Body: "Be nice"}}}
+			svc := NewWikiService(repo, nil, nil, nil, nil, nil, nil, moderation, nil)
+			_, err := svc.UpdateWikiPage(context.Background(), &entities.WikiPage{ID: "w1", GuildID: "g1", Title: title, Body: body}, "")
+			if err == nil && repo.pages["w1"].Body != body {
+				t.Errorf("UpdateWikiPage() saved body %q, want %q", repo.pages["w1"].Body, body)
+			}
+			if err != nil && repo.pages["w1"].Body != "Be nice" {
+				t.Errorf("rejected UpdateWikiPage() saved body %q", repo.pages["w1"].Body)
+			}
+			return "w1", err
+		},
+		"CreateNote": func(moderation *ModerationService, title, body string) (string, error) {
+			repo := &fakeNoteRepo{notes: map[string]*entities.Note{}}
+			svc := NewNoteService(repo, nil, nil, nil, moderation, nil, nil)
+			_, err := svc.CreateNote(context.Background(), &entities.Note{AuthorID: "u1", Title: title, Body: body})
+			if err != nil && len(repo.notes) != 0 {
+				t.Error("rejected note was saved")
+			}
+			return "new", err
+		},
+		"UpdateNote": func(moderation *ModerationService, title, body string) (string, error) {
+			repo := &fakeNoteRepo{notes: map[string]*entities.Note{"n1": {ID: "n1", AuthorID: "u1", Title: "Groceries", Body: "Eggs"}}}
+			svc := NewNoteService(repo, nil, nil, nil, moderation, nil, nil)
+			_, err := svc.UpdateNote(context.Background(), &entities.Note{ID: "n1", AuthorID: "u1", Title: title, Body: body}, "")
+			if err != nil && repo.notes["n1"].Body != "Eggs" {
+				t.Errorf("rejected UpdateNote() saved body %q", repo.notes["n1"].Body)
+			}
+			return "n1", err
+		},
+	}
+
+	tests := []struct {
+		name        string
+		flagMatches bool
+		title       string
+		body        string
+		wantErr     error
+		wantFlag    string
+	}{
+		{name: "clean", title: "Cake", body: "The cake is a lie"},
+		{name: "reject body", title: "Cake", body: "Buy cheap cake", wantErr: ErrContentRejected},
+		{name: "reject title", title: "BUY CHEAP cake", body: "The cake is a lie", wantErr: ErrContentRejected},
+		{name: "flag", flagMatches: true, title: "Cake", body: "Buy cheap cake", wantFlag: pattern},
+		{name: "flag clean", flagMatches: true, title: "Cake", body: "The cake is a lie"},
+	}
+
+	for name, save := range saves {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				flags := &fakeModerationRepo{flags: map[string]string{}}
+				moderation, err := NewModerationService([]string{pattern}, tt.flagMatches, flags)
+				if err != nil {
+					t.Fatalf("NewModerationService() error = %v", err)
+				}
+
+				id, err := save(moderation, tt.title, tt.body)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("%s() error = %v, want %v", name, err, tt.wantErr)
+				}
+				if got := flags.flags[id]; got != tt.wantFlag {
+					t.Errorf("flag reason = %q, want %q", got, tt.wantFlag)
+				}
+			})
+		}
+	}
+}
+
+// Merging appends the source body to the target, so the merged text is moderated
+// before anything is written
+func TestModeration_MergeWikiPages(t *testing.T) {
+	const pattern = `(?i)\bbuy cheap\b`
+
+	t.Run("reject", func(t *testing.T) {
+		f := newWikiMergeFixture()
+		f.pages.pages["src"].Body = "Buy cheap dragon eggs"
+		moderation, err := NewModerationService([]string{pattern}, false, &fakeModerationRepo{flags: map[string]string{}})
+		if err != nil {
+			t.Fatalf("NewModerationService() error = %v", err)
+		}
+		f.svc.moderation = moderation
+		before := f.state()
+
+		if _, err := f.svc.MergeWikiPages(context.Background(), "src", "tgt", "admin"); !errors.Is(err, ErrContentRejected) {
+			t.Fatalf("MergeWikiPages() error = %v, want %v", err, ErrContentRejected)
+		}
+		if !reflect.DeepEqual(f.state(), before) {
+			t.Error("rejected merge changed pages, titles, or references")
+		}
+		if len(f.history.entries) != 0 {
+			t.Errorf("rejected merge wrote %d merge log entries, want 0", len(f.history.entries))
+		}
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		f := newWikiMergeFixture()
+		f.pages.pages["src"].Body = "Buy cheap dragon eggs"
+		flags := &fakeModerationRepo{flags: map[string]string{}}
+		moderation, err := NewModerationService([]string{pattern}, true, flags)
+		if err != nil {
+			t.Fatalf("NewModerationService() error = %v", err)
+		}
+		f.svc.moderation = moderation
+
+		if _, err := f.svc.MergeWikiPages(context.Background(), "src", "tgt", "admin"); err != nil {
+			t.Fatalf("MergeWikiPages() error = %v", err)
+		}
+		if got := flags.flags["tgt"]; got != pattern {
+			t.Errorf("target flag reason = %q, want %q", got, pattern)
+		}
+	})
+}
@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
 	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/notify"
+	"github.com/devilmonastery/hivemind/internal/pkg/textutil"
 )
 
 // noteTitlesCacheEntry holds cached note titles for a user in a guild
@@ -20,31 +23,102 @@ type noteTitlesCacheEntry struct {
 type NoteService struct {
 	noteRepo       repositories.NoteRepository
 	noteRefRepo    repositories.NoteMessageReferenceRepository
+	notifier       ContentNotifier
+	audit          contentAuditor
+	moderation     *ModerationService
+	titlePolicy    NoteTitlePolicy
+	refLimits      ReferenceLimitPolicy
 	titlesCache    sync.Map // map[authorID:guildID]noteTitlesCacheEntry
 	titlesCacheTTL time.Duration
 }
 
+// NoteTitlePolicy reports whether a guild requires each author's note titles to be unique.
+// Implemented by DiscordService from the guild's settings.
+type NoteTitlePolicy interface {
+	UniqueNoteTitles(ctx context.Context, guildID string) (bool, error)
+}
+
 // NewNoteService creates a new note service
-func NewNoteService(noteRepo repositories.NoteRepository, noteRefRepo repositories.NoteMessageReferenceRepository) *NoteService {
+// notifier may be nil to disable webhook notifications, moderation to disable content moderation,
+// titlePolicy to allow duplicate note titles everywhere, and refLimits to give every note
+// DefaultMaxReferences
+func NewNoteService(noteRepo repositories.NoteRepository, noteRefRepo repositories.NoteMessageReferenceRepository, notifier ContentNotifier, auditRepo repositories.AuditRepository, moderation *ModerationService, titlePolicy NoteTitlePolicy, refLimits ReferenceLimitPolicy) *NoteService {
 	return &NoteService{
 		noteRepo:       noteRepo,
 		noteRefRepo:    noteRefRepo,
+		notifier:       notifier,
+		audit:          contentAuditor{repo: auditRepo},
+		moderation:     moderation,
+		titlePolicy:    titlePolicy,
+		refLimits:      refLimits,
 		titlesCacheTTL: 1 * time.Minute,
 	}
 }
 
 // CreateNote creates a new note
 func (s *NoteService) CreateNote(ctx context.Context, note *entities.Note) (*entities.Note, error) {
+	tags, err := textutil.NormalizeTags(note.Tags)
+	if err != nil {
+		return nil, err
+	}
+	note.Tags = tags
+
+	flagReason, err := s.moderation.review(note.Title, note.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPreservedID(ctx, note.ID, s.noteRepo.IDExists); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkUniqueTitle(ctx, note); err != nil {
+		return nil, err
+	}
+
 	if err := s.noteRepo.Create(ctx, note); err != nil {
 		return nil, fmt.Errorf("failed to create note: %w", err)
 	}
+	if flagReason != "" {
+		s.moderation.flag(ctx, entities.ResourceNote, note.ID, flagReason)
+	}
 
 	// Invalidate cache for this user+guild
 	s.invalidateNoteTitlesCache(note.AuthorID, note.GuildID)
 
+	notifyContent(s.notifier, noteEvent(notify.EventNoteCreated, note))
+	s.audit.record(ctx, entities.ActionNoteCreated, entities.ResourceNote, note.ID, note.GuildID, nil)
+
 	return note, nil
 }
 
+// checkUniqueTitle rejects note's title when its guild requires unique titles and the
+// author already has another note with it, and marks note so the repository enforces it
+// against concurrent saves too. Personal notes are never checked.
+func (s *NoteService) checkUniqueTitle(ctx context.Context, note *entities.Note) error {
+	if s.titlePolicy == nil || note.GuildID == "" || note.Title == "" {
+		return nil
+	}
+	unique, err := s.titlePolicy.UniqueNoteTitles(ctx, note.GuildID)
+	if err != nil {
+		return fmt.Errorf("failed to get note title policy: %w", err)
+	}
+	if !unique {
+		return nil
+	}
+	note.UniqueTitle = true
+
+	// Notes saved before the guild turned the setting on aren't in the unique index
+	existingID, err := s.noteRepo.FindIDByTitle(ctx, note.GuildID, note.AuthorID, note.Title, note.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check note title: %w", err)
+	}
+	if existingID != "" {
+		return &repositories.NoteTitleTakenError{ExistingID: existingID}
+	}
+	return nil
+}
+
 // GetNote retrieves a note by ID
 func (s *NoteService) GetNote(ctx context.Context, id string, userDiscordID string) (*entities.Note, error) {
 	note, err := s.noteRepo.GetByID(ctx, id, userDiscordID)
@@ -54,16 +128,53 @@ func (s *NoteService) GetNote(ctx context.Context, id string, userDiscordID stri
 	return note, nil
 }
 
+// GetNoteBySlug retrieves a note by its permalink slug within guildID, or within
+// authorID's personal notes when guildID is empty
+func (s *NoteService) GetNoteBySlug(ctx context.Context, guildID, authorID, slug string, userDiscordID string) (*entities.Note, error) {
+	id, err := s.noteRepo.FindIDBySlug(ctx, guildID, authorID, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find note by slug: %w", err)
+	}
+	if id == "" {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrNoteNotFound, slug)
+	}
+	return s.GetNote(ctx, id, userDiscordID)
+}
+
 // UpdateNote updates an existing note
 func (s *NoteService) UpdateNote(ctx context.Context, note *entities.Note, userDiscordID string) (*entities.Note, error) {
+	tags, err := textutil.NormalizeTags(note.Tags)
+	if err != nil {
+		return nil, err
+	}
+	note.Tags = tags
+
+	flagReason, err := s.moderation.review(note.Title, note.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkUniqueTitle(ctx, note); err != nil {
+		return nil, err
+	}
+
 	if err := s.noteRepo.Update(ctx, note); err != nil {
 		return nil, fmt.Errorf("failed to update note: %w", err)
 	}
+	s.moderation.flag(ctx, entities.ResourceNote, note.ID, flagReason)
 
 	// Invalidate cache for this user+guild
 	s.invalidateNoteTitlesCache(note.AuthorID, note.GuildID)
 
-	return s.noteRepo.GetByID(ctx, note.ID, userDiscordID)
+	updated, err := s.noteRepo.GetByID(ctx, note.ID, userDiscordID)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyContent(s.notifier, noteEvent(notify.EventNoteUpdated, updated))
+	s.audit.record(ctx, entities.ActionNoteUpdated, entities.ResourceNote, updated.ID, updated.GuildID, nil)
+
+	return updated, nil
 }
 
 // DeleteNote soft-deletes a note
@@ -81,6 +192,8 @@ func (s *NoteService) DeleteNote(ctx context.Context, id string, userDiscordID s
 	// Invalidate cache for this user+guild
 	s.invalidateNoteTitlesCache(note.AuthorID, note.GuildID)
 
+	s.audit.record(ctx, entities.ActionNoteDeleted, entities.ResourceNote, id, note.GuildID, nil)
+
 	return nil
 }
 
@@ -93,16 +206,21 @@ func (s *NoteService) ListNotes(ctx context.Context, authorID, guildID string, t
 	return notes, total, nil
 }
 
-// SearchNotes searches notes by full-text query
-func (s *NoteService) SearchNotes(ctx context.Context, authorID, query, guildID string, tags []string, limit, offset int, userDiscordID string) ([]*entities.Note, int, error) {
-	notes, total, err := s.noteRepo.Search(ctx, authorID, query, guildID, tags, limit, offset, userDiscordID)
+// SearchNotes searches notes by full-text query, limited to notes from guildIDs unless it is empty
+// orderBy is one of the repositories.SearchOrder* values; empty picks relevance for queries, created_at otherwise
+// authorDiscordID narrows the author's notes to those written as that Discord user (empty = no filter)
+// cursor continues after a previous page instead of using offset; the returned cursor is nil on the last page
+func (s *NoteService) SearchNotes(ctx context.Context, authorID, query string, guildIDs []string, tags []string, authorDiscordID string, limit, offset int, cursor *repositories.PageCursor, orderBy string, ascending bool, userDiscordID string) ([]*entities.Note, int, *repositories.PageCursor, error) {
+	notes, total, next, err := s.noteRepo.Search(ctx, authorID, query, guildIDs, tags, authorDiscordID, limit, offset, cursor, orderBy, ascending, userDiscordID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to search notes: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to search notes: %w", err)
 	}
-	return notes, total, nil
+	return notes, total, next, nil
 }
 
 // AddMessageReference adds a message reference to a note
+// Returns an error wrapping repositories.ErrReferenceLimitReached when the note is at its
+// guild's reference limit
 func (s *NoteService) AddMessageReference(ctx context.Context, ref *entities.NoteMessageReference, userDiscordID string) (*entities.NoteMessageReference, error) {
 	// First verify the note exists and belongs to the user making the request
 	note, err := s.noteRepo.GetByID(ctx, ref.NoteID, userDiscordID)
@@ -110,10 +228,17 @@ func (s *NoteService) AddMessageReference(ctx context.Context, ref *entities.Not
 		return nil, fmt.Errorf("failed to get note: %w", err)
 	}
 	if note == nil {
-		return nil, fmt.Errorf("note not found")
+		return nil, fmt.Errorf("%w: %s", repositories.ErrNoteNotFound, ref.NoteID)
 	}
 
-	if err := s.noteRefRepo.Create(ctx, ref); err != nil {
+	limit, err := maxReferences(ctx, s.refLimits, note.GuildID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.noteRefRepo.Create(ctx, ref, limit); err != nil {
+		if errors.Is(err, repositories.ErrReferenceLimitReached) {
+			return nil, fmt.Errorf("%w: %d", repositories.ErrReferenceLimitReached, limit)
+		}
 		return nil, fmt.Errorf("failed to add message reference: %w", err)
 	}
 	return ref, nil
@@ -127,7 +252,7 @@ func (s *NoteService) ListMessageReferences(ctx context.Context, noteID string,
 		return nil, fmt.Errorf("failed to get note: %w", err)
 	}
 	if note == nil {
-		return nil, fmt.Errorf("note not found")
+		return nil, fmt.Errorf("%w: %s", repositories.ErrNoteNotFound, noteID)
 	}
 
 	refs, err := s.noteRefRepo.GetByNoteID(ctx, noteID)
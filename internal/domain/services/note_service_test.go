@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// fakeNoteRepo matches titles the way FindIDByTitle's query does
+type fakeNoteRepo struct {
+	repositories.NoteRepository
+	notes map[string]*entities.Note
+}
+
+func (r *fakeNoteRepo) FindIDByTitle(ctx context.Context, guildID, authorID, title, excludeID string) (string, error) {
+	for _, n := range r.notes {
+		if n.GuildID == guildID && n.AuthorID == authorID && n.ID != excludeID && strings.EqualFold(n.Title, title) {
+			return n.ID, nil
+		}
+	}
+	return "", nil
+}
+
+func (r *fakeNoteRepo) IDExists(ctx context.Context, id string) (bool, error) {
+	_, ok := r.notes[id]
+	return ok, nil
+}
+
+func (r *fakeNoteRepo) GetByID(ctx context.Context, id string, userDiscordID string) (*entities.Note, error) {
+	n, ok := r.notes[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrNoteNotFound, id)
+	}
+	return n, nil
+}
+
+func (r *fakeNoteRepo) Create(ctx context.Context, note *entities.Note) error {
+	if note.ID == "" {
+		note.ID = "new"
+	}
+	r.notes[note.ID] = note
+	return nil
+}
+
+func (r *fakeNoteRepo) Update(ctx context.Context, note *entities.Note) error {
+	n, ok := r.notes[note.ID]
+	if !ok {
+		return fmt.Errorf("%w: %s", repositories.ErrNoteNotFound, note.ID)
+	}
+	n.Title, n.Body = note.Title, note.Body
+	return nil
+}
+
+// fakeTitlePolicy requires unique titles in the listed guilds
+type fakeTitlePolicy map[string]bool
+
+func (p fakeTitlePolicy) UniqueNoteTitles(ctx context.Context, guildID string) (bool, error) {
+	return p[guildID], nil
+}
+
+func TestNoteService_UniqueTitles(t *testing.T) {
+	newRepo := func() *fakeNoteRepo {
+		return &fakeNoteRepo{notes: map[string]*entities.Note{
+			"n1": {ID: "n1", AuthorID: "u1", GuildID: "g1", Title: "Groceries", Body: "Eggs"},
+			"n2": {ID: "n2", AuthorID: "u1", GuildID: "g1", Title: "Chores", Body: "Dishes"},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		policy    NoteTitlePolicy
+		note      *entities.Note
+		update    bool
+		wantTaken string // ID of the conflicting note, or "" to expect success
+	}{
+		{
+			name:      "enabled: create collides ignoring case",
+			policy:    fakeTitlePolicy{"g1": true},
+			note:      &entities.Note{AuthorID: "u1", GuildID: "g1", Title: "groceries", Body: "Milk"},
+			wantTaken: "n1",
+		},
+		{
+			name:      "enabled: rename onto another note collides",
+			policy:    fakeTitlePolicy{"g1": true},
+			note:      &entities.Note{ID: "n2", AuthorID: "u1", GuildID: "g1", Title: "Groceries", Body: "Dishes"},
+			update:    true,
+			wantTaken: "n1",
+		},
+		{
+			name:   "enabled: keeping a note's own title is allowed",
+			policy: fakeTitlePolicy{"g1": true},
+			note:   &entities.Note{ID: "n1", AuthorID: "u1", GuildID: "g1", Title: "Groceries", Body: "Milk"},
+			update: true,
+		},
+		{
+			name:   "enabled: another author may reuse the title",
+			policy: fakeTitlePolicy{"g1": true},
+			note:   &entities.Note{AuthorID: "u2", GuildID: "g1", Title: "Groceries", Body: "Milk"},
+		},
+		{
+			name:   "enabled: personal notes are not checked",
+			policy: fakeTitlePolicy{"": true},
+			note:   &entities.Note{AuthorID: "u1", Title: "Groceries", Body: "Milk"},
+		},
+		{
+			name:   "disabled: duplicate title allowed",
+			policy: fakeTitlePolicy{},
+			note:   &entities.Note{AuthorID: "u1", GuildID: "g1", Title: "Groceries", Body: "Milk"},
+		},
+		{
+			name:   "no policy: duplicate title allowed",
+			note:   &entities.Note{ID: "n2", AuthorID: "u1", GuildID: "g1", Title: "Groceries", Body: "Dishes"},
+			update: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewNoteService(newRepo(), nil, nil, nil, nil, tt.policy, nil)
+			ctx := context.Background()
+
+			var err error
+			if tt.update {
+				_, err = svc.UpdateNote(ctx, tt.note, "")
+			} else {
+				_, err = svc.CreateNote(ctx, tt.note)
+			}
+
+			if tt.wantTaken == "" {
+				if err != nil {
+					t.Fatalf("save error = %v, want success", err)
+				}
+				// The repository enforces the title against concurrent saves too
+				policy, _ := tt.policy.(fakeTitlePolicy)
+				if want := tt.note.GuildID != "" && policy[tt.note.GuildID]; tt.note.UniqueTitle != want {
+					t.Errorf("UniqueTitle = %v, want %v", tt.note.UniqueTitle, want)
+				}
+				return
+			}
+			if !errors.Is(err, repositories.ErrNoteTitleTaken) {
+				t.Fatalf("save error = %v, want ErrNoteTitleTaken", err)
+			}
+			var taken *repositories.NoteTitleTakenError
+			if !errors.As(err, &taken) || taken.ExistingID != tt.wantTaken {
+				t.Errorf("save error = %v, want it to name %s", err, tt.wantTaken)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/notify"
+)
+
+// ContentNotifier receives content change events for guild webhooks.
+// Notify must not block; *notify.Dispatcher queues events and delivers them in the background.
+type ContentNotifier interface {
+	Notify(event notify.Event)
+}
+
+func wikiPageEvent(eventType string, page *entities.WikiPage) notify.Event {
+	return notify.Event{
+		Type:    eventType,
+		GuildID: page.GuildID,
+		Content: notify.Content{
+			ID:        page.ID,
+			Title:     page.Title,
+			Body:      page.Body,
+			Tags:      page.Tags,
+			AuthorID:  page.AuthorID,
+			ChannelID: page.ChannelID,
+			CreatedAt: page.CreatedAt,
+			UpdatedAt: page.UpdatedAt,
+		},
+	}
+}
+
+// noteEvent leaves out the title, tags, and body: notes are private to their author,
+// so the guild's webhook only learns that one was saved
+func noteEvent(eventType string, note *entities.Note) notify.Event {
+	return notify.Event{
+		Type:    eventType,
+		GuildID: note.GuildID,
+		Content: notify.Content{
+			ID:        note.ID,
+			AuthorID:  note.AuthorID,
+			ChannelID: note.ChannelID,
+			CreatedAt: note.CreatedAt,
+			UpdatedAt: note.UpdatedAt,
+		},
+	}
+}
+
+// quoteEvent uses updatedAt since quotes don't track their own update time
+func quoteEvent(eventType string, quote *entities.Quote, updatedAt time.Time) notify.Event {
+	return notify.Event{
+		Type:    eventType,
+		GuildID: quote.GuildID,
+		Content: notify.Content{
+			ID:        quote.ID,
+			Body:      quote.Body,
+			Tags:      quote.Tags,
+			AuthorID:  quote.AuthorID,
+			ChannelID: quote.SourceChannelID,
+			CreatedAt: quote.CreatedAt,
+			UpdatedAt: updatedAt,
+		},
+	}
+}
+
+// notifyContent sends event if a notifier is configured
+func notifyContent(n ContentNotifier, event notify.Event) {
+	if n != nil {
+		n.Notify(event)
+	}
+}
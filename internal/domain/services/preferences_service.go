@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// ErrNotGuildMember is returned when a user references a guild they don't belong to
+var ErrNotGuildMember = errors.New("not a member of guild")
+
+// PreferencesService handles business logic for user preferences
+type PreferencesService struct {
+	prefsRepo        repositories.UserPreferencesRepository
+	guildMemberRepo  repositories.GuildMemberRepository
+	discordGuildRepo repositories.DiscordGuildRepository
+}
+
+// NewPreferencesService creates a new preferences service
+func NewPreferencesService(
+	prefsRepo repositories.UserPreferencesRepository,
+	guildMemberRepo repositories.GuildMemberRepository,
+	discordGuildRepo repositories.DiscordGuildRepository,
+) *PreferencesService {
+	return &PreferencesService{
+		prefsRepo:        prefsRepo,
+		guildMemberRepo:  guildMemberRepo,
+		discordGuildRepo: discordGuildRepo,
+	}
+}
+
+// GetPreferences retrieves a user's preferences, returning empty defaults if none are stored
+func (s *PreferencesService) GetPreferences(ctx context.Context, userID string) (*entities.UserPreferences, error) {
+	prefs, err := s.prefsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserPreferencesNotFound) {
+			return &entities.UserPreferences{UserID: userID}, nil
+		}
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// SetDefaultGuild sets the user's default guild, or clears it when guildID is empty.
+// The user must be a member of the guild (checked via their Discord ID).
+func (s *PreferencesService) SetDefaultGuild(ctx context.Context, userID, discordID, guildID string) (*entities.UserPreferences, error) {
+	prefs, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if guildID == "" {
+		prefs.DefaultGuildID = nil
+	} else {
+		if discordID == "" {
+			return nil, ErrNotGuildMember
+		}
+		isMember, err := s.guildMemberRepo.IsMember(ctx, guildID, discordID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check guild membership: %w", err)
+		}
+		if !isMember {
+			return nil, ErrNotGuildMember
+		}
+		prefs.DefaultGuildID = &guildID
+	}
+
+	if err := s.prefsRepo.Upsert(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// SetLeaderboardOptOut keeps the user off quote leaderboards, or puts them back on
+func (s *PreferencesService) SetLeaderboardOptOut(ctx context.Context, userID string, optOut bool) (*entities.UserPreferences, error) {
+	prefs, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs.LeaderboardOptOut = optOut
+	if err := s.prefsRepo.Upsert(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// GuildName returns the display name of a guild, or an empty string if it is unknown
+func (s *PreferencesService) GuildName(ctx context.Context, guildID string) string {
+	guild, err := s.discordGuildRepo.GetByID(ctx, guildID)
+	if err != nil || guild == nil {
+		return ""
+	}
+	return guild.GuildName
+}
@@ -3,31 +3,109 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/devilmonastery/hivemind/internal/auth"
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
 	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/notify"
+	"github.com/devilmonastery/hivemind/internal/pkg/textutil"
 )
 
 // QuoteService handles business logic for quotes
 type QuoteService struct {
-	quoteRepo repositories.QuoteRepository
+	quoteRepo      repositories.QuoteRepository
+	notifier       ContentNotifier
+	audit          contentAuditor
+	moderation     *ModerationService
+	cooldownPolicy QuoteCooldownPolicy
+}
+
+// QuoteCooldownPolicy reports how long a guild makes each member wait between saving
+// quotes (zero for no limit). Implemented by DiscordService from the guild's settings.
+type QuoteCooldownPolicy interface {
+	QuoteCooldown(ctx context.Context, guildID string) (time.Duration, error)
 }
 
 // NewQuoteService creates a new quote service
-func NewQuoteService(quoteRepo repositories.QuoteRepository) *QuoteService {
+// notifier may be nil to disable webhook notifications, moderation to disable content moderation,
+// and cooldownPolicy to let members save quotes as often as they like
+func NewQuoteService(quoteRepo repositories.QuoteRepository, notifier ContentNotifier, auditRepo repositories.AuditRepository, moderation *ModerationService, cooldownPolicy QuoteCooldownPolicy) *QuoteService {
 	return &QuoteService{
-		quoteRepo: quoteRepo,
+		quoteRepo:      quoteRepo,
+		notifier:       notifier,
+		audit:          contentAuditor{repo: auditRepo},
+		moderation:     moderation,
+		cooldownPolicy: cooldownPolicy,
 	}
 }
 
-// CreateQuote creates a new quote
+// CreateQuote creates a new quote.
+// If the guild already has a quote from the same source message or with the same
+// text, nothing is saved and a DuplicateQuoteError names the existing quote.
+// Quotes imported with a preserved ID skip the duplicate check.
 func (s *QuoteService) CreateQuote(ctx context.Context, quote *entities.Quote) (*entities.Quote, error) {
+	tags, err := textutil.NormalizeTags(quote.Tags)
+	if err != nil {
+		return nil, err
+	}
+	quote.Tags = tags
+
+	flagReason, err := s.moderation.review(quote.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.ID != "" {
+		if err := checkPreservedID(ctx, quote.ID, s.quoteRepo.IDExists); err != nil {
+			return nil, err
+		}
+	} else {
+		duplicateID, err := s.quoteRepo.FindDuplicateID(ctx, quote.GuildID, quote.SourceMsgID, quote.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for duplicate quote: %w", err)
+		}
+		if duplicateID != "" {
+			return nil, &DuplicateQuoteError{ExistingID: duplicateID}
+		}
+
+		if err := s.setCooldown(ctx, quote); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := s.quoteRepo.Create(ctx, quote); err != nil {
 		return nil, fmt.Errorf("failed to create quote: %w", err)
 	}
+	if flagReason != "" {
+		s.moderation.flag(ctx, entities.ResourceQuote, quote.ID, flagReason)
+	}
+
+	notifyContent(s.notifier, quoteEvent(notify.EventQuoteCreated, quote, quote.CreatedAt))
+	s.audit.record(ctx, entities.ActionQuoteCreated, entities.ResourceQuote, quote.ID, quote.GuildID, nil)
+
 	return quote, nil
 }
 
+// setCooldown sets quote's cooldown to the guild's, for the repository to enforce
+// atomically with the save. Admins are never limited.
+func (s *QuoteService) setCooldown(ctx context.Context, quote *entities.Quote) error {
+	if s.cooldownPolicy == nil || quote.GuildID == "" {
+		return nil
+	}
+	if user, err := auth.GetUserFromContext(ctx); err == nil && user.Role == string(entities.RoleAdmin) {
+		return nil
+	}
+
+	cooldown, err := s.cooldownPolicy.QuoteCooldown(ctx, quote.GuildID)
+	if err != nil {
+		return fmt.Errorf("failed to get quote cooldown: %w", err)
+	}
+	quote.Cooldown = cooldown
+	return nil
+}
+
 // GetQuote retrieves a quote by ID
 func (s *QuoteService) GetQuote(ctx context.Context, id string, userDiscordID string) (*entities.Quote, error) {
 	quote, err := s.quoteRepo.GetByID(ctx, id, userDiscordID)
@@ -37,25 +115,98 @@ func (s *QuoteService) GetQuote(ctx context.Context, id string, userDiscordID st
 	return quote, nil
 }
 
+// GetQuoteBySourceMessage retrieves the quote in guildID saved from sourceMsgID,
+// or nil if the message hasn't been quoted
+// userDiscordID filters by guild membership (empty = admin)
+func (s *QuoteService) GetQuoteBySourceMessage(ctx context.Context, guildID, sourceMsgID string, userDiscordID string) (*entities.Quote, error) {
+	id, err := s.quoteRepo.FindIDBySourceMessage(ctx, guildID, sourceMsgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find quote by source message: %w", err)
+	}
+	if id == "" {
+		return nil, nil
+	}
+
+	quote, err := s.quoteRepo.GetByID(ctx, id, userDiscordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quote: %w", err)
+	}
+	return quote, nil
+}
+
+// GetQuoteByCode retrieves the quote in guildID with the given permalink short code,
+// or nil if there is none
+// userDiscordID filters by guild membership (empty = admin)
+func (s *QuoteService) GetQuoteByCode(ctx context.Context, guildID, code string, userDiscordID string) (*entities.Quote, error) {
+	id, err := s.quoteRepo.FindIDByShortCode(ctx, guildID, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find quote by code: %w", err)
+	}
+	if id == "" {
+		return nil, nil
+	}
+	return s.GetQuote(ctx, id, userDiscordID)
+}
+
 // DeleteQuote soft-deletes a quote
 func (s *QuoteService) DeleteQuote(ctx context.Context, id string) error {
+	// Fetch the quote to get its guild ID for the audit log
+	quote, err := s.quoteRepo.GetByID(ctx, id, "")
+	if err != nil {
+		return fmt.Errorf("failed to get quote: %w", err)
+	}
+
 	if err := s.quoteRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete quote: %w", err)
 	}
+
+	s.audit.record(ctx, entities.ActionQuoteDeleted, entities.ResourceQuote, id, quote.GuildID, nil)
+
 	return nil
 }
 
-// UpdateQuote updates a quote's body and tags
-func (s *QuoteService) UpdateQuote(ctx context.Context, id, body string, tags []string, userDiscordID string) (*entities.Quote, error) {
-	if err := s.quoteRepo.Update(ctx, id, body, tags); err != nil {
+// UpdateQuote updates a quote's body and tags, and its attribution when attribution
+// is set and differs from the current one
+func (s *QuoteService) UpdateQuote(ctx context.Context, id, body string, tags []string, attribution, userDiscordID string) (*entities.Quote, error) {
+	tags, err := textutil.NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	flagReason, err := s.moderation.review(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var details map[string]any
+	attribution = strings.TrimSpace(attribution)
+	if attribution != "" {
+		existing, err := s.quoteRepo.GetByID(ctx, id, userDiscordID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get quote: %w", err)
+		}
+		if previous := quoteAttribution(existing); attribution != previous {
+			details = map[string]any{"previous_attribution": previous, "attribution": attribution}
+		}
+	}
+
+	if details == nil {
+		attribution = ""
+	}
+	if err := s.quoteRepo.Update(ctx, id, body, tags, attribution); err != nil {
 		return nil, fmt.Errorf("failed to update quote: %w", err)
 	}
+	s.moderation.flag(ctx, entities.ResourceQuote, id, flagReason)
 
 	// Fetch and return the updated quote
 	quote, err := s.quoteRepo.GetByID(ctx, id, userDiscordID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get updated quote: %w", err)
 	}
+
+	notifyContent(s.notifier, quoteEvent(notify.EventQuoteUpdated, quote, time.Now()))
+	s.audit.record(ctx, entities.ActionQuoteUpdated, entities.ResourceQuote, quote.ID, quote.GuildID, details)
+
 	return quote, nil
 }
 
@@ -68,15 +219,34 @@ func (s *QuoteService) ListQuotes(ctx context.Context, guildID string, limit, of
 	return quotes, total, nil
 }
 
-// SearchQuotes searches quotes by full-text query
-func (s *QuoteService) SearchQuotes(ctx context.Context, guildID, query string, tags []string, limit, offset int, userDiscordID string) ([]*entities.Quote, int, error) {
-	quotes, total, err := s.quoteRepo.Search(ctx, guildID, query, tags, limit, offset, userDiscordID)
+// SearchQuotes searches quotes in guildIDs (empty = every guild) by full-text query
+// orderBy is one of the repositories.SearchOrder* values; empty picks relevance for queries, created_at otherwise
+func (s *QuoteService) SearchQuotes(ctx context.Context, guildIDs []string, query string, tags []string, limit, offset int, orderBy string, ascending bool, userDiscordID string) ([]*entities.Quote, int, error) {
+	quotes, total, err := s.quoteRepo.Search(ctx, guildIDs, query, tags, limit, offset, orderBy, ascending, userDiscordID)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to search quotes: %w", err)
 	}
 	return quotes, total, nil
 }
 
+// DefaultQuoteStatsLimit is the number of entries per leaderboard when none is requested
+const DefaultQuoteStatsLimit = 10
+
+// GetQuoteStats returns quote totals and leaderboards for a guild, counting quotes saved
+// at or after since (zero = all time). Members who opted out of leaderboards are left
+// off them, but their quotes still count towards the total.
+func (s *QuoteService) GetQuoteStats(ctx context.Context, guildID string, limit int, since time.Time, userDiscordID string) (*entities.QuoteStats, error) {
+	if limit <= 0 {
+		limit = DefaultQuoteStatsLimit
+	}
+
+	stats, err := s.quoteRepo.Stats(ctx, guildID, limit, since, userDiscordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quote stats: %w", err)
+	}
+	return stats, nil
+}
+
 // GetRandomQuote retrieves a random quote from a guild
 func (s *QuoteService) GetRandomQuote(ctx context.Context, guildID string, tags []string) (*entities.Quote, error) {
 	quote, err := s.quoteRepo.GetRandom(ctx, guildID, tags)
@@ -85,3 +255,14 @@ func (s *QuoteService) GetRandomQuote(ctx context.Context, guildID string, tags
 	}
 	return quote, nil
 }
+
+// quoteAttribution returns the name a quote is shown as said by, matching the bot's
+// preference for guild nickname, then display name, then the saved username
+func quoteAttribution(quote *entities.Quote) string {
+	for _, name := range []string{quote.SourceMsgAuthorGuildNick, quote.SourceMsgAuthorDisplayName, quote.SourceMsgAuthorUsername} {
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/auth"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// fakeQuoteRepo matches duplicates the way quoteDuplicateQuery does
+type fakeQuoteRepo struct {
+	repositories.QuoteRepository
+	quotes  map[string]*entities.Quote
+	created []*entities.Quote
+}
+
+func newFakeQuoteRepo(quotes ...*entities.Quote) *fakeQuoteRepo {
+	r := &fakeQuoteRepo{quotes: map[string]*entities.Quote{}}
+	for _, q := range quotes {
+		r.quotes[q.ID] = q
+	}
+	return r
+}
+
+func (r *fakeQuoteRepo) FindDuplicateID(ctx context.Context, guildID, sourceMsgID, body string) (string, error) {
+	normalize := func(s string) string { return strings.ToLower(strings.Join(strings.Fields(s), " ")) }
+	sameText := ""
+	for _, q := range r.quotes {
+		if q.GuildID != guildID {
+			continue
+		}
+		if sourceMsgID != "" && q.SourceMsgID == sourceMsgID {
+			return q.ID, nil
+		}
+		if normalize(q.Body) == normalize(body) {
+			sameText = q.ID
+		}
+	}
+	return sameText, nil
+}
+
+func (r *fakeQuoteRepo) GetByID(ctx context.Context, id string, userDiscordID string) (*entities.Quote, error) {
+	q, ok := r.quotes[id]
+	if !ok {
+		return nil, errors.New("quote not found")
+	}
+	return q, nil
+}
+
+func (r *fakeQuoteRepo) IDExists(ctx context.Context, id string) (bool, error) {
+	_, ok := r.quotes[id]
+	return ok, nil
+}
+
+// Create enforces quote.Cooldown like the postgres repository
+func (r *fakeQuoteRepo) Create(ctx context.Context, quote *entities.Quote) error {
+	if quote.Cooldown > 0 {
+		for _, q := range r.quotes {
+			if q.GuildID != quote.GuildID || q.AuthorID != quote.AuthorID {
+				continue
+			}
+			if remaining := quote.Cooldown - time.Since(q.CreatedAt); remaining > 0 {
+				return &repositories.QuoteCooldownError{Remaining: remaining}
+			}
+		}
+	}
+	if quote.ID == "" {
+		quote.ID = "new"
+	}
+	r.quotes[quote.ID] = quote
+	r.created = append(r.created, quote)
+	return nil
+}
+
+func (r *fakeQuoteRepo) Update(ctx context.Context, id, body string, tags []string, attribution string) error {
+	q, ok := r.quotes[id]
+	if !ok {
+		return errors.New("quote not found")
+	}
+	q.Body, q.Tags = body, tags
+	// Like the repository, a corrected attribution replaces the Discord user's names
+	if attribution != "" {
+		q.SourceMsgAuthorDisplayName, q.SourceMsgAuthorGuildNick = attribution, ""
+	}
+	return nil
+}
+
+func TestUpdateQuote_Attribution(t *testing.T) {
+	repo := newFakeQuoteRepo(&entities.Quote{
+		ID:                         "q1",
+		GuildID:                    "g1",
+		Body:                       "The cake is a lie",
+		SourceMsgAuthorDiscordID:   "d1",
+		SourceMsgAuthorUsername:    "glados",
+		SourceMsgAuthorDisplayName: "GLaDOS",
+	})
+	svc := NewQuoteService(repo, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	got, err := svc.UpdateQuote(ctx, "q1", "The cake is a lie", nil, "  Wheatley ", "")
+	if err != nil {
+		t.Fatalf("UpdateQuote() error = %v", err)
+	}
+	if quoteAttribution(got) != "Wheatley" || got.SourceMsgAuthorDiscordID != "d1" {
+		t.Errorf("attribution = %q (discord %q), want Wheatley with the discord ID kept", quoteAttribution(got), got.SourceMsgAuthorDiscordID)
+	}
+
+	// An empty attribution leaves the corrected one in place
+	got, err = svc.UpdateQuote(ctx, "q1", "Still alive", nil, "", "")
+	if err != nil {
+		t.Fatalf("UpdateQuote() error = %v", err)
+	}
+	if quoteAttribution(got) != "Wheatley" || got.Body != "Still alive" {
+		t.Errorf("quote = %q by %q, want new body with attribution kept", got.Body, quoteAttribution(got))
+	}
+}
+
+func TestCreateQuote_Duplicates(t *testing.T) {
+	existing := &entities.Quote{ID: "q1", GuildID: "g1", SourceMsgID: "m1", Body: "The cake is a lie"}
+
+	tests := []struct {
+		name    string
+		quote   *entities.Quote
+		wantDup bool
+	}{
+		{name: "same source message", quote: &entities.Quote{GuildID: "g1", SourceMsgID: "m1", Body: "different trim"}, wantDup: true},
+		{name: "same text", quote: &entities.Quote{GuildID: "g1", SourceMsgID: "m2", Body: "  the CAKE is\na lie "}, wantDup: true},
+		{name: "same text without source", quote: &entities.Quote{GuildID: "g1", Body: "The cake is a lie"}, wantDup: true},
+		{name: "other guild", quote: &entities.Quote{GuildID: "g2", SourceMsgID: "m1", Body: "The cake is a lie"}},
+		{name: "different text", quote: &entities.Quote{GuildID: "g1", SourceMsgID: "m3", Body: "Still alive"}},
+		{name: "preserved ID skips the check", quote: &entities.Quote{ID: "q9", GuildID: "g1", SourceMsgID: "m1", Body: "The cake is a lie"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeQuoteRepo(existing)
+			svc := NewQuoteService(repo, nil, nil, nil, nil)
+
+			got, err := svc.CreateQuote(context.Background(), tt.quote)
+			if tt.wantDup {
+				var dup *DuplicateQuoteError
+				if !errors.As(err, &dup) || !errors.Is(err, ErrDuplicateQuote) {
+					t.Fatalf("CreateQuote() error = %v, want DuplicateQuoteError", err)
+				}
+				if got != nil || dup.ExistingID != existing.ID {
+					t.Errorf("CreateQuote() = %v, %v, want only the existing quote's ID %s", got, err, existing.ID)
+				}
+				if len(repo.created) != 0 {
+					t.Errorf("duplicate was saved")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CreateQuote() error = %v", err)
+			}
+			if len(repo.created) != 1 {
+				t.Errorf("got %d saved quotes, want 1", len(repo.created))
+			}
+		})
+	}
+}
+
+// fakeCooldownPolicy sets the quote cooldown of the listed guilds
+type fakeCooldownPolicy map[string]time.Duration
+
+func (p fakeCooldownPolicy) QuoteCooldown(ctx context.Context, guildID string) (time.Duration, error) {
+	return p[guildID], nil
+}
+
+func TestCreateQuote_Cooldown(t *testing.T) {
+	newRepo := func(lastQuote time.Duration) *fakeQuoteRepo {
+		return newFakeQuoteRepo(&entities.Quote{
+			ID:        "q1",
+			GuildID:   "g1",
+			AuthorID:  "u1",
+			Body:      "The cake is a lie",
+			CreatedAt: time.Now().Add(-lastQuote),
+		})
+	}
+	member := auth.SetUserInContext(context.Background(), &auth.UserContext{UserID: "u1", Role: string(entities.RoleUser)})
+	admin := auth.SetUserInContext(context.Background(), &auth.UserContext{UserID: "u1", Role: string(entities.RoleAdmin)})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		policy    QuoteCooldownPolicy
+		lastQuote time.Duration // how long ago u1 last quoted in g1
+		quote     *entities.Quote
+		wantWait  time.Duration // remaining wait in the error, or 0 to expect success
+	}{
+		{
+			name:      "within cooldown",
+			ctx:       member,
+			policy:    fakeCooldownPolicy{"g1": time.Minute},
+			lastQuote: 20 * time.Second,
+			quote:     &entities.Quote{GuildID: "g1", AuthorID: "u1", Body: "Still alive"},
+			wantWait:  40 * time.Second,
+		},
+		{
+			name:      "after cooldown",
+			ctx:       member,
+			policy:    fakeCooldownPolicy{"g1": time.Minute},
+			lastQuote: 2 * time.Minute,
+			quote:     &entities.Quote{GuildID: "g1", AuthorID: "u1", Body: "Still alive"},
+		},
+		{
+			name:      "another author is not limited",
+			ctx:       member,
+			policy:    fakeCooldownPolicy{"g1": time.Minute},
+			lastQuote: time.Second,
+			quote:     &entities.Quote{GuildID: "g1", AuthorID: "u2", Body: "Still alive"},
+		},
+		{
+			name:      "another guild is not limited",
+			ctx:       member,
+			policy:    fakeCooldownPolicy{"g1": time.Minute},
+			lastQuote: time.Second,
+			quote:     &entities.Quote{GuildID: "g2", AuthorID: "u1", Body: "Still alive"},
+		},
+		{
+			name:      "admins bypass the cooldown",
+			ctx:       admin,
+			policy:    fakeCooldownPolicy{"g1": time.Minute},
+			lastQuote: time.Second,
+			quote:     &entities.Quote{GuildID: "g1", AuthorID: "u1", Body: "Still alive"},
+		},
+		{
+			name:      "zero cooldown is disabled",
+			ctx:       member,
+			policy:    fakeCooldownPolicy{},
+			lastQuote: time.Second,
+			quote:     &entities.Quote{GuildID: "g1", AuthorID: "u1", Body: "Still alive"},
+		},
+		{
+			name:      "no policy",
+			ctx:       member,
+			lastQuote: time.Second,
+			quote:     &entities.Quote{GuildID: "g1", AuthorID: "u1", Body: "Still alive"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepo(tt.lastQuote)
+			svc := NewQuoteService(repo, nil, nil, nil, tt.policy)
+
+			_, err := svc.CreateQuote(tt.ctx, tt.quote)
+			if tt.wantWait == 0 {
+				if err != nil {
+					t.Fatalf("CreateQuote() error = %v, want success", err)
+				}
+				if len(repo.created) != 1 {
+					t.Errorf("created %d quotes, want 1", len(repo.created))
+				}
+				return
+			}
+			var cooldown *repositories.QuoteCooldownError
+			if !errors.As(err, &cooldown) {
+				t.Fatalf("CreateQuote() error = %v, want QuoteCooldownError", err)
+			}
+			if got := cooldown.Remaining.Round(time.Second); got != tt.wantWait {
+				t.Errorf("remaining wait = %v, want %v", got, tt.wantWait)
+			}
+			if len(repo.created) != 0 {
+				t.Errorf("created %d quotes, want none", len(repo.created))
+			}
+		})
+	}
+}
+
+// Stats counts quotes per saver and per quoted member like quoteLeaderboardQuery,
+// most first with ties broken by Discord ID
+func (r *fakeQuoteRepo) Stats(ctx context.Context, guildID string, limit int, since time.Time, userDiscordID string) (*entities.QuoteStats, error) {
+	quoters, quoted := map[string]int{}, map[string]int{}
+	stats := &entities.QuoteStats{}
+	for _, q := range r.quotes {
+		if q.GuildID != guildID || q.CreatedAt.Before(since) {
+			continue
+		}
+		stats.TotalQuotes++
+		quoters[q.AuthorDiscordID]++
+		quoted[q.SourceMsgAuthorDiscordID]++
+	}
+
+	rank := func(counts map[string]int) []entities.QuoteStatsEntry {
+		entries := []entities.QuoteStatsEntry{}
+		for id, count := range counts {
+			entries = append(entries, entities.QuoteStatsEntry{DiscordID: id, Count: count})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].Count != entries[j].Count {
+				return entries[i].Count > entries[j].Count
+			}
+			return entries[i].DiscordID < entries[j].DiscordID
+		})
+		return entries[:min(limit, len(entries))]
+	}
+	stats.TopQuoters = rank(quoters)
+	stats.MostQuoted = rank(quoted)
+	return stats, nil
+}
+
+func TestGetQuoteStats_Window(t *testing.T) {
+	now := time.Now()
+	quote := func(id, saver, speaker string, age time.Duration) *entities.Quote {
+		return &entities.Quote{ID: id, GuildID: "g1", AuthorDiscordID: saver, SourceMsgAuthorDiscordID: speaker, CreatedAt: now.Add(-age)}
+	}
+	day := 24 * time.Hour
+	repo := newFakeQuoteRepo(
+		quote("q1", "alice", "dave", 0),
+		quote("q2", "alice", "dave", day),
+		quote("q3", "alice", "erin", 2*day),
+		quote("q4", "bob", "dave", 3*day),
+		quote("q5", "carol", "erin", 6*day),
+		quote("q6", "carol", "erin", 8*day), // Outside the week
+		quote("q7", "carol", "erin", 30*day),
+		quote("q8", "bob", "frank", 0),
+	)
+
+	tests := []struct {
+		name           string
+		limit          int
+		since          time.Time
+		wantTotal      int
+		wantTopQuoters string
+		wantMostQuoted string
+	}{
+		{
+			name:           "all time",
+			limit:          10,
+			wantTotal:      8,
+			wantTopQuoters: "alice:3 carol:3 bob:2",
+			wantMostQuoted: "erin:4 dave:3 frank:1",
+		},
+		{
+			name:           "last week",
+			limit:          10,
+			since:          now.Add(-7 * day),
+			wantTotal:      6,
+			wantTopQuoters: "alice:3 bob:2 carol:1",
+			wantMostQuoted: "dave:3 erin:2 frank:1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewQuoteService(repo, nil, nil, nil, nil)
+
+			stats, err := svc.GetQuoteStats(context.Background(), "g1", tt.limit, tt.since, "")
+			if err != nil {
+				t.Fatalf("GetQuoteStats() error = %v", err)
+			}
+
+			format := func(entries []entities.QuoteStatsEntry) string {
+				parts := make([]string, len(entries))
+				for i, e := range entries {
+					parts[i] = fmt.Sprintf("%s:%d", e.DiscordID, e.Count)
+				}
+				return strings.Join(parts, " ")
+			}
+			if stats.TotalQuotes != tt.wantTotal {
+				t.Errorf("TotalQuotes = %d, want %d", stats.TotalQuotes, tt.wantTotal)
+			}
+			if got := format(stats.TopQuoters); got != tt.wantTopQuoters {
+				t.Errorf("TopQuoters = %q, want %q", got, tt.wantTopQuoters)
+			}
+			if got := format(stats.MostQuoted); got != tt.wantMostQuoted {
+				t.Errorf("MostQuoted = %q, want %q", got, tt.wantMostQuoted)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultMaxReferences is how many message references a wiki page or note may have in a
+// guild that hasn't set its own limit, and outside guilds
+const DefaultMaxReferences = 500
+
+// ReferenceLimitPolicy reports how many message references each wiki page and note in a
+// guild may have. Implemented by DiscordService from the guild's settings.
+type ReferenceLimitPolicy interface {
+	MaxReferences(ctx context.Context, guildID string) (int, error)
+}
+
+// maxReferences returns guildID's reference limit, DefaultMaxReferences without a policy
+func maxReferences(ctx context.Context, policy ReferenceLimitPolicy, guildID string) (int, error) {
+	if policy == nil || guildID == "" {
+		return DefaultMaxReferences, nil
+	}
+	limit, err := policy.MaxReferences(ctx, guildID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reference limit: %w", err)
+	}
+	return limit, nil
+}
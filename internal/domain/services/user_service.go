@@ -2,8 +2,12 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"golang.org/x/crypto/bcrypt"
 
@@ -288,11 +292,12 @@ func (s *UserService) GetUserByID(ctx context.Context, userID string) (*entities
 	return user, nil
 }
 
-// ListUsers lists users with filtering and pagination
-func (s *UserService) ListUsers(ctx context.Context, opts repositories.ListUsersOptions) ([]*entities.User, int64, error) {
-	users, total, err := s.userRepo.List(ctx, opts)
+// ListUsers lists users with filtering and pagination, returning the total number
+// of matches and the cursor for the next page
+func (s *UserService) ListUsers(ctx context.Context, opts repositories.ListUsersOptions) ([]*entities.User, int64, *repositories.PageCursor, error) {
+	users, total, next, err := s.userRepo.List(ctx, opts)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to list users: %w", err)
 	}
 
 	// Clear password hashes for security
@@ -300,7 +305,7 @@ func (s *UserService) ListUsers(ctx context.Context, opts repositories.ListUsers
 		user.PasswordHash = nil
 	}
 
-	return users, total, nil
+	return users, total, next, nil
 }
 
 // UpdateUser updates user information
@@ -360,33 +365,134 @@ func (s *UserService) UpdateUser(ctx context.Context, userID string, updates map
 	return user, nil
 }
 
-// DeactivateUser deactivates a user account
-func (s *UserService) DeactivateUser(ctx context.Context, userID, deactivatedBy string) error {
+// ErrSelfDeactivation is returned when an admin tries to deactivate their own account
+var ErrSelfDeactivation = errors.New("cannot deactivate your own account")
+
+// SetUserActive deactivates or reactivates a user account. Admins may not
+// deactivate themselves, which could lock every admin out.
+func (s *UserService) SetUserActive(ctx context.Context, userID string, active bool, changedBy string) error {
+	if !active && userID == changedBy {
+		return ErrSelfDeactivation
+	}
+
+	if err := s.userRepo.SetActive(ctx, userID, active); err != nil {
+		return fmt.Errorf("failed to set user active state: %w", err)
+	}
+
+	action := "deactivated"
+	if active {
+		action = "reactivated"
+	}
+	auditLog := entities.NewAuditLog(&changedBy, entities.ActionUserUpdated, entities.ResourceUser).
+		WithResourceID(userID).
+		WithMetadata("changed_by", changedBy).
+		WithMetadata("action", action)
+
+	if err := s.auditLog(ctx, auditLog); err != nil {
+		// Log audit failure but don't fail the operation
+	}
+
+	return nil
+}
+
+// Profile validation errors
+var (
+	ErrInvalidDisplayName = errors.New("invalid display name")
+	ErrInvalidTimezone    = errors.New("invalid timezone")
+	ErrInvalidAvatarURL   = errors.New("invalid avatar URL")
+)
+
+// maxDisplayNameLength caps display names, counted in characters
+const maxDisplayNameLength = 100
+
+// UpdateProfile changes a user's own display name and/or timezone. Empty values
+// leave the field unchanged. Timezones must be IANA names known to time.LoadLocation.
+func (s *UserService) UpdateProfile(ctx context.Context, userID, displayName, timezone string) (*entities.User, error) {
+	displayName = strings.TrimSpace(displayName)
+	if utf8.RuneCountInString(displayName) > maxDisplayNameLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrInvalidDisplayName, maxDisplayNameLength)
+	}
+	if timezone != "" {
+		if err := validateTimezone(timezone); err != nil {
+			return nil, err
+		}
+	}
+
 	user, err := s.userRepo.GetByID(ctx, userID)
-	if err != nil || user == nil {
-		return fmt.Errorf("failed to get user: %w", err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	if !user.Active() {
-		return nil // Already inactive
+	changed := false
+	if displayName != "" && displayName != user.DisplayName {
+		user.DisplayName = displayName
+		changed = true
+	}
+	if timezone != "" && (user.Timezone == nil || *user.Timezone != timezone) {
+		user.Timezone = &timezone
+		changed = true
 	}
 
-	user.IsActive = false
-	user.UpdatedAt = time.Now()
+	return s.saveProfile(ctx, user, changed, "profile_updated")
+}
 
-	if err := s.userRepo.Update(ctx, user); err != nil {
-		return fmt.Errorf("failed to deactivate user: %w", err)
+// UpdateAvatar sets a user's own avatar to an absolute http(s) URL, or clears it when avatarURL is empty
+func (s *UserService) UpdateAvatar(ctx context.Context, userID, avatarURL string) (*entities.User, error) {
+	avatarURL = strings.TrimSpace(avatarURL)
+	if avatarURL != "" {
+		u, err := url.Parse(avatarURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%w: must be an absolute http(s) URL", ErrInvalidAvatarURL)
+		}
 	}
 
-	// Log user deactivation
-	auditLog := entities.NewAuditLog(&deactivatedBy, entities.ActionUserUpdated, entities.ResourceUser).
-		WithResourceID(user.ID).
-		WithMetadata("deactivated_by", deactivatedBy).
-		WithMetadata("action", "deactivated")
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
 
-	if err := s.auditRepo.Create(ctx, auditLog); err != nil {
-		// Log audit failure but don't fail the operation
+	current := ""
+	if user.AvatarURL != nil {
+		current = *user.AvatarURL
+	}
+	changed := avatarURL != current
+	if avatarURL == "" {
+		user.AvatarURL = nil
+	} else {
+		user.AvatarURL = &avatarURL
+	}
+
+	return s.saveProfile(ctx, user, changed, "avatar_updated")
+}
+
+// saveProfile stores a self-service profile change and audits it as the user's own action
+func (s *UserService) saveProfile(ctx context.Context, user *entities.User, changed bool, action string) (*entities.User, error) {
+	if changed {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to update user: %w", err)
+		}
+
+		auditLog := entities.NewAuditLog(&user.ID, entities.ActionUserUpdated, entities.ResourceUser).
+			WithResourceID(user.ID).
+			WithMetadata("action", action)
+		if err := s.auditLog(ctx, auditLog); err != nil {
+			// Log audit failure but don't fail the operation
+		}
 	}
 
+	// Clear password hash for security
+	user.PasswordHash = nil
+	return user, nil
+}
+
+// validateTimezone accepts IANA time zone names such as "America/New_York" or "UTC".
+// "Local" is rejected since it means the server's zone, not the user's.
+func validateTimezone(timezone string) error {
+	if timezone == "Local" {
+		return fmt.Errorf("%w: %q is not an IANA time zone", ErrInvalidTimezone, timezone)
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("%w: %q is not an IANA time zone", ErrInvalidTimezone, timezone)
+	}
 	return nil
 }
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+type fakeUserRepo struct {
+	repositories.UserRepository
+	users   map[string]*entities.User
+	updates int
+}
+
+func (r *fakeUserRepo) GetByID(ctx context.Context, id string) (*entities.User, error) {
+	if u, ok := r.users[id]; ok {
+		copied := *u
+		return &copied, nil
+	}
+	return nil, repositories.ErrUserNotFound
+}
+
+func (r *fakeUserRepo) Update(ctx context.Context, user *entities.User) error {
+	r.updates++
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+func (r *fakeUserRepo) SetActive(ctx context.Context, id string, active bool) error {
+	u, ok := r.users[id]
+	if !ok {
+		return repositories.ErrUserNotFound
+	}
+	u.IsActive = active
+	return nil
+}
+
+func TestUpdateProfile(t *testing.T) {
+	berlin := "Europe/Berlin"
+
+	tests := []struct {
+		name        string
+		displayName string
+		timezone    string
+		wantErr     error
+		wantName    string
+		wantZone    string
+		wantUpdates int
+	}{
+		{name: "both", displayName: "  Grace  ", timezone: "America/New_York", wantName: "Grace", wantZone: "America/New_York", wantUpdates: 1},
+		{name: "empty leaves unchanged", wantName: "Ada", wantZone: berlin},
+		{name: "same values", displayName: "Ada", timezone: berlin, wantName: "Ada", wantZone: berlin},
+		{name: "utc", timezone: "UTC", wantName: "Ada", wantZone: "UTC", wantUpdates: 1},
+		{name: "unknown timezone", timezone: "Mars/Olympus_Mons", wantErr: ErrInvalidTimezone},
+		{name: "offset is not a zone name", timezone: "+02:00", wantErr: ErrInvalidTimezone},
+		{name: "server local zone", timezone: "Local", wantErr: ErrInvalidTimezone},
+		{name: "long name", displayName: string(make([]rune, maxDisplayNameLength+1)), wantErr: ErrInvalidDisplayName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeUserRepo{users: map[string]*entities.User{
+				"u1": {ID: "u1", DisplayName: "Ada", Timezone: &berlin},
+			}}
+			svc := NewUserService(repo, nil)
+
+			user, err := svc.UpdateProfile(context.Background(), "u1", tt.displayName, tt.timezone)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateProfile() error = %v, want %v", err, tt.wantErr)
+			}
+			if repo.updates != tt.wantUpdates {
+				t.Errorf("saved %d times, want %d", repo.updates, tt.wantUpdates)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if user.DisplayName != tt.wantName || user.Timezone == nil || *user.Timezone != tt.wantZone {
+				t.Errorf("profile = %q in %v, want %q in %q", user.DisplayName, user.Timezone, tt.wantName, tt.wantZone)
+			}
+		})
+	}
+}
+
+func TestUpdateAvatar(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr error
+		want    *string
+	}{
+		{name: "set", url: "https://cdn.example.com/a.png", want: stringPtr("https://cdn.example.com/a.png")},
+		{name: "clear", url: ""},
+		{name: "relative", url: "/a.png", wantErr: ErrInvalidAvatarURL},
+		{name: "not http", url: "javascript:alert(1)", wantErr: ErrInvalidAvatarURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeUserRepo{users: map[string]*entities.User{
+				"u1": {ID: "u1", AvatarURL: stringPtr("https://cdn.example.com/old.png")},
+			}}
+			svc := NewUserService(repo, nil)
+
+			_, err := svc.UpdateAvatar(context.Background(), "u1", tt.url)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateAvatar() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got := repo.users["u1"].AvatarURL
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("stored avatar = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetUserActive(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    string
+		active    bool
+		changedBy string
+		wantErr   error
+	}{
+		{name: "deactivate", userID: "u1", active: false, changedBy: "admin"},
+		{name: "reactivate", userID: "u1", active: true, changedBy: "admin"},
+		{name: "self deactivation", userID: "admin", active: false, changedBy: "admin", wantErr: ErrSelfDeactivation},
+		{name: "self reactivation", userID: "admin", active: true, changedBy: "admin"},
+		{name: "unknown user", userID: "nobody", active: false, changedBy: "admin", wantErr: repositories.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeUserRepo{users: map[string]*entities.User{
+				"u1":    {ID: "u1", IsActive: !tt.active},
+				"admin": {ID: "admin", IsActive: !tt.active},
+			}}
+			svc := NewUserService(repo, nil)
+
+			err := svc.SetUserActive(context.Background(), tt.userID, tt.active, tt.changedBy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetUserActive() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got := repo.users[tt.userID].IsActive; got != tt.active {
+				t.Errorf("IsActive = %v, want %v", got, tt.active)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -2,12 +2,51 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
 	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/notify"
+	"github.com/devilmonastery/hivemind/internal/pkg/textutil"
+)
+
+// wikiMergeUndoWindow is how long after a merge it can still be undone
+const wikiMergeUndoWindow = 7 * 24 * time.Hour
+
+const (
+	// DefaultTagSuggestions and MaxTagSuggestions bound how many tags SuggestTags returns
+	DefaultTagSuggestions = 5
+	MaxTagSuggestions     = 10
+	// tagSuggestionTerms is how many of a body's key terms SuggestTags searches for
+	tagSuggestionTerms = 8
+	// tagSuggestionPages is how many of the best matching pages SuggestTags draws tags from
+	tagSuggestionPages = 50
+)
+
+var (
+	// ErrMergeNotFound is returned when a page has no merge that can be undone
+	ErrMergeNotFound = errors.New("no undoable merge found for page")
+	// ErrMergeUndoExpired is returned when the merge is older than the undo window
+	ErrMergeUndoExpired = errors.New("merge is too old to undo")
+	// ErrMergeUndoForbidden is returned when the caller neither performed the merge nor is an admin
+	ErrMergeUndoForbidden = errors.New("only the user who performed the merge or an admin can undo it")
+	// ErrMergeTargetModified is returned when the merged page was edited after the merge
+	ErrMergeTargetModified = errors.New("merged page has been edited since the merge")
+	// ErrPinForbidden is returned when the caller is neither the page author nor an admin
+	ErrPinForbidden = errors.New("only the page author or an admin can pin or unpin it")
+	// ErrTransferForbidden is returned when the caller is neither the page author nor an admin
+	ErrTransferForbidden = errors.New("only the page author or an admin can transfer ownership")
+	// ErrPublishForbidden is returned when the caller is neither the draft's author nor an admin
+	ErrPublishForbidden = errors.New("only the page author or an admin can publish it")
+	// ErrNewOwnerNotLinked is returned when the new owner has no Hivemind account to own the page
+	ErrNewOwnerNotLinked = errors.New("new owner has not linked a Hivemind account")
+	// ErrNewOwnerNotMember is returned when the new owner isn't a member of the page's guild
+	ErrNewOwnerNotMember = errors.New("new owner is not a member of the page's guild")
 )
 
 // wikiTitlesCacheEntry holds cached wiki titles for a guild
@@ -25,16 +64,31 @@ type WikiService struct {
 	wikiRepo       repositories.WikiPageRepository
 	wikiRefRepo    repositories.WikiMessageReferenceRepository
 	wikiTitleRepo  repositories.WikiTitleRepository
+	mergeLogRepo   repositories.WikiMergeLogRepository
+	transactor     repositories.Transactor
+	notifier       ContentNotifier
+	audit          contentAuditor
+	moderation     *ModerationService
+	refLimits      ReferenceLimitPolicy
 	titlesCache    sync.Map // map[guildID]wikiTitlesCacheEntry
 	titlesCacheTTL time.Duration
 }
 
 // NewWikiService creates a new wiki service
-func NewWikiService(wikiRepo repositories.WikiPageRepository, wikiRefRepo repositories.WikiMessageReferenceRepository, wikiTitleRepo repositories.WikiTitleRepository) *WikiService {
+// notifier may be nil to disable webhook notifications, moderation to disable content moderation,
+// and refLimits to give every page DefaultMaxReferences. transactor may be nil where the
+// repositories aren't backed by a database, in which case merges aren't atomic.
+func NewWikiService(wikiRepo repositories.WikiPageRepository, wikiRefRepo repositories.WikiMessageReferenceRepository, wikiTitleRepo repositories.WikiTitleRepository, mergeLogRepo repositories.WikiMergeLogRepository, transactor repositories.Transactor, notifier ContentNotifier, auditRepo repositories.AuditRepository, moderation *ModerationService, refLimits ReferenceLimitPolicy) *WikiService {
 	return &WikiService{
 		wikiRepo:       wikiRepo,
 		wikiRefRepo:    wikiRefRepo,
 		wikiTitleRepo:  wikiTitleRepo,
+		mergeLogRepo:   mergeLogRepo,
+		transactor:     transactor,
+		notifier:       notifier,
+		audit:          contentAuditor{repo: auditRepo},
+		moderation:     moderation,
+		refLimits:      refLimits,
 		titlesCacheTTL: 1 * time.Minute,
 	}
 }
@@ -42,26 +96,56 @@ func NewWikiService(wikiRepo repositories.WikiPageRepository, wikiRefRepo reposi
 // CreateWikiPage creates a new wiki page
 // userDiscordID is used for ACL check on duplicate detection (empty = admin)
 func (s *WikiService) CreateWikiPage(ctx context.Context, page *entities.WikiPage, userDiscordID string) (*entities.WikiPage, error) {
+	tags, err := textutil.NormalizeTags(page.Tags)
+	if err != nil {
+		return nil, err
+	}
+	page.Tags = tags
+
+	flagReason, err := s.moderation.review(page.Title, page.Body)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check for duplicate title in guild - keeps explicit error message
 	existing, err := s.wikiRepo.GetByGuildAndSlug(ctx, page.GuildID, page.Title, userDiscordID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check for duplicate: %w", err)
 	}
 	if existing != nil {
-		return nil, fmt.Errorf("wiki page with title '%s' already exists in this guild", page.Title)
+		return nil, fmt.Errorf("%w: %s", repositories.ErrWikiTitleTaken, page.Title)
+	}
+
+	if err := checkPreservedID(ctx, page.ID, s.wikiRepo.IDExists); err != nil {
+		return nil, err
 	}
 
 	// Create the page
 	if err := s.wikiRepo.Create(ctx, page); err != nil {
 		return nil, fmt.Errorf("failed to create wiki page: %w", err)
 	}
+	if flagReason != "" {
+		s.moderation.flag(ctx, entities.ResourceWikiPage, page.ID, flagReason)
+	}
 
 	// Invalidate cache for this guild
 	s.titlesCache.Delete(page.GuildID)
 
+	s.notifyWikiPage(notify.EventWikiPageCreated, page)
+	s.audit.record(ctx, entities.ActionWikiPageCreated, entities.ResourceWikiPage, page.ID, page.GuildID, nil)
+
 	return page, nil
 }
 
+// notifyWikiPage sends a page event to the guild's webhooks. Drafts are kept quiet
+// until they're published.
+func (s *WikiService) notifyWikiPage(eventType string, page *entities.WikiPage) {
+	if page.IsDraft() {
+		return
+	}
+	notifyContent(s.notifier, wikiPageEvent(eventType, page))
+}
+
 // GetWikiPage retrieves a wiki page by ID
 // userDiscordID filters by guild membership (empty = admin)
 func (s *WikiService) GetWikiPage(ctx context.Context, id string, userDiscordID string) (*entities.WikiPage, error) {
@@ -75,20 +159,51 @@ func (s *WikiService) GetWikiPage(ctx context.Context, id string, userDiscordID
 // UpdateWikiPage updates an existing wiki page
 // userDiscordID filters by guild membership (empty = admin)
 func (s *WikiService) UpdateWikiPage(ctx context.Context, page *entities.WikiPage, userDiscordID string) (*entities.WikiPage, error) {
+	tags, err := textutil.NormalizeTags(page.Tags)
+	if err != nil {
+		return nil, err
+	}
+	page.Tags = tags
+
+	flagReason, err := s.moderation.review(page.Title, page.Body)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := s.wikiRepo.Update(ctx, page); err != nil {
 		return nil, fmt.Errorf("failed to update wiki page: %w", err)
 	}
+	s.moderation.flag(ctx, entities.ResourceWikiPage, page.ID, flagReason)
 
 	// Invalidate cache for this guild
 	s.titlesCache.Delete(page.GuildID)
 
 	// Fetch updated page
-	return s.wikiRepo.GetByID(ctx, page.ID, userDiscordID)
+	updated, err := s.wikiRepo.GetByID(ctx, page.ID, userDiscordID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyWikiPage(notify.EventWikiPageUpdated, updated)
+	s.audit.record(ctx, entities.ActionWikiPageUpdated, entities.ResourceWikiPage, updated.ID, updated.GuildID, nil)
+
+	return updated, nil
 }
 
 // UpsertWikiPage creates a new wiki page or updates an existing one with the same title
 // userDiscordID filters by guild membership (empty = admin)
 func (s *WikiService) UpsertWikiPage(ctx context.Context, page *entities.WikiPage, userDiscordID string) (*entities.WikiPage, bool, error) {
+	tags, err := textutil.NormalizeTags(page.Tags)
+	if err != nil {
+		return nil, false, err
+	}
+	page.Tags = tags
+
+	flagReason, err := s.moderation.review(page.Title, page.Body)
+	if err != nil {
+		return nil, false, err
+	}
+
 	// Check if a page with this title already exists in the guild
 	existing, err := s.wikiRepo.GetByGuildAndSlug(ctx, page.GuildID, page.Title, userDiscordID)
 	if err != nil {
@@ -96,37 +211,200 @@ func (s *WikiService) UpsertWikiPage(ctx context.Context, page *entities.WikiPag
 	}
 
 	if existing != nil {
-		// Update existing page
-		page.ID = existing.ID
-		page.CreatedAt = existing.CreatedAt
-		page.AuthorID = existing.AuthorID
-
-		if err := s.wikiRepo.Update(ctx, page); err != nil {
-			return nil, false, fmt.Errorf("failed to update wiki page: %w", err)
-		}
-
-		// Invalidate cache for this guild
-		s.titlesCache.Delete(page.GuildID)
+		updated, err := s.upsertExisting(ctx, page, existing, flagReason, userDiscordID)
+		return updated, false, err
+	}
 
-		// Fetch updated page
-		updated, err := s.wikiRepo.GetByID(ctx, page.ID, userDiscordID)
+	// Create new page
+	if err := checkPreservedID(ctx, page.ID, s.wikiRepo.IDExists); err != nil {
+		return nil, false, err
+	}
+	err = s.wikiRepo.Create(ctx, page)
+	if errors.Is(err, repositories.ErrWikiTitleTaken) {
+		// Another request created a page with this title since the lookup above; update it instead
+		existing, err = s.wikiRepo.GetByGuildAndSlug(ctx, page.GuildID, page.Title, userDiscordID)
 		if err != nil {
-			return nil, false, fmt.Errorf("failed to fetch updated page: %w", err)
+			return nil, false, fmt.Errorf("failed to check for existing page: %w", err)
+		}
+		if existing == nil {
+			// The title belongs to a page the caller can't see
+			return nil, false, fmt.Errorf("%w: %s", repositories.ErrWikiTitleTaken, page.Title)
 		}
-		return updated, false, nil
+		updated, err := s.upsertExisting(ctx, page, existing, flagReason, userDiscordID)
+		return updated, false, err
 	}
-
-	// Create new page
-	if err := s.wikiRepo.Create(ctx, page); err != nil {
+	if err != nil {
 		return nil, false, fmt.Errorf("failed to create wiki page: %w", err)
 	}
+	if flagReason != "" {
+		s.moderation.flag(ctx, entities.ResourceWikiPage, page.ID, flagReason)
+	}
 
 	// Invalidate cache for this guild
 	s.titlesCache.Delete(page.GuildID)
 
+	s.notifyWikiPage(notify.EventWikiPageCreated, page)
+	s.audit.record(ctx, entities.ActionWikiPageCreated, entities.ResourceWikiPage, page.ID, page.GuildID, nil)
+
 	return page, true, nil
 }
 
+// upsertExisting applies an upsert to the existing page with the same title. The
+// existing page keeps its canonical title, so upserting by an alias doesn't rename it,
+// and its author; the upserting user is recorded as the last editor.
+func (s *WikiService) upsertExisting(ctx context.Context, page, existing *entities.WikiPage, flagReason, userDiscordID string) (*entities.WikiPage, error) {
+	page.ID = existing.ID
+	page.Title = existing.Title
+	page.CreatedAt = existing.CreatedAt
+	page.LastEditorID = page.AuthorID
+	page.AuthorID = existing.AuthorID
+
+	if err := s.wikiRepo.Update(ctx, page); err != nil {
+		return nil, fmt.Errorf("failed to update wiki page: %w", err)
+	}
+	s.moderation.flag(ctx, entities.ResourceWikiPage, page.ID, flagReason)
+
+	// Invalidate cache for this guild
+	s.titlesCache.Delete(page.GuildID)
+
+	// Fetch updated page
+	updated, err := s.wikiRepo.GetByID(ctx, page.ID, userDiscordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch updated page: %w", err)
+	}
+
+	s.notifyWikiPage(notify.EventWikiPageUpdated, updated)
+	s.audit.record(ctx, entities.ActionWikiPageUpdated, entities.ResourceWikiPage, updated.ID, updated.GuildID, nil)
+
+	return updated, nil
+}
+
+// SetWikiPagePinned pins or unpins a wiki page so it's listed before other pages in its guild
+// Only the page author or an admin may change it; userDiscordID filters by guild membership (empty = admin)
+func (s *WikiService) SetWikiPagePinned(ctx context.Context, id string, pinned bool, userID, userDiscordID string, isAdmin bool) (*entities.WikiPage, error) {
+	page, err := s.wikiRepo.GetByID(ctx, id, userDiscordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wiki page: %w", err)
+	}
+	if page == nil {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, id)
+	}
+
+	if !isAdmin && page.AuthorID != userID {
+		return nil, ErrPinForbidden
+	}
+
+	if page.Pinned == pinned {
+		return page, nil
+	}
+
+	if err := s.wikiRepo.SetPinned(ctx, id, pinned); err != nil {
+		return nil, fmt.Errorf("failed to set wiki page pinned: %w", err)
+	}
+	page.Pinned = pinned
+
+	return page, nil
+}
+
+// PublishWikiPage makes a draft visible to its guild. Only the page author or an admin
+// may publish it; publishing a page that isn't a draft does nothing.
+// userDiscordID filters by guild membership (empty = admin)
+func (s *WikiService) PublishWikiPage(ctx context.Context, id string, userID, userDiscordID string, isAdmin bool) (*entities.WikiPage, error) {
+	page, err := s.wikiRepo.GetByID(ctx, id, userDiscordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wiki page: %w", err)
+	}
+	if page == nil {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, id)
+	}
+
+	if !isAdmin && page.AuthorID != userID {
+		return nil, ErrPublishForbidden
+	}
+
+	if !page.IsDraft() {
+		return page, nil
+	}
+
+	if err := s.wikiRepo.SetStatus(ctx, id, entities.WikiPagePublished); err != nil {
+		return nil, fmt.Errorf("failed to publish wiki page: %w", err)
+	}
+	page.Status = entities.WikiPagePublished
+
+	// The title can now be autocompleted
+	s.titlesCache.Delete(page.GuildID)
+
+	// To everyone else in the guild the page is new
+	s.notifyWikiPage(notify.EventWikiPageCreated, page)
+	s.audit.record(ctx, entities.ActionWikiPagePublished, entities.ResourceWikiPage, page.ID, page.GuildID, nil)
+
+	return page, nil
+}
+
+// ListWikiDrafts lists userID's draft pages in a guild, most recently updated first
+func (s *WikiService) ListWikiDrafts(ctx context.Context, guildID, userID string) ([]*entities.WikiPage, error) {
+	pages, err := s.wikiRepo.ListDrafts(ctx, guildID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wiki drafts: %w", err)
+	}
+	return pages, nil
+}
+
+// TransferWikiPageOwnership makes newOwner the author of a wiki page, e.g. when the original
+// author has left the community. Only the page author or an admin may transfer it, and the new
+// owner must have a linked account and be a member of the page's guild.
+// userDiscordID filters by guild membership (empty = admin)
+func (s *WikiService) TransferWikiPageOwnership(ctx context.Context, id string, newOwner *entities.DiscordUser, userID, userDiscordID string, isAdmin bool) (*entities.WikiPage, error) {
+	page, err := s.wikiRepo.GetByID(ctx, id, userDiscordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wiki page: %w", err)
+	}
+	if page == nil {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, id)
+	}
+
+	if !isAdmin && page.AuthorID != userID {
+		return nil, ErrTransferForbidden
+	}
+	if newOwner == nil || newOwner.UserID == nil || *newOwner.UserID == "" {
+		return nil, ErrNewOwnerNotLinked
+	}
+
+	// The page is only visible to members of its guild, so looking it up as the
+	// new owner checks their membership
+	visible, err := s.wikiRepo.GetByID(ctx, id, newOwner.DiscordID)
+	if err != nil && !errors.Is(err, repositories.ErrWikiPageNotFound) {
+		return nil, fmt.Errorf("failed to check new owner membership: %w", err)
+	}
+	if visible == nil {
+		return nil, ErrNewOwnerNotMember
+	}
+
+	newOwnerID := *newOwner.UserID
+	if page.AuthorID == newOwnerID {
+		return page, nil
+	}
+
+	if err := s.wikiRepo.SetAuthor(ctx, id, newOwnerID); err != nil {
+		return nil, fmt.Errorf("failed to set wiki page author: %w", err)
+	}
+
+	s.audit.record(ctx, entities.ActionWikiPageOwnershipTransferred, entities.ResourceWikiPage, id, page.GuildID, map[string]any{
+		"previous_author_id": page.AuthorID,
+		"author_id":          newOwnerID,
+	})
+
+	// Re-read the page so the author's display name follows the new owner
+	updated, err := s.wikiRepo.GetByID(ctx, id, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wiki page: %w", err)
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, id)
+	}
+	return updated, nil
+}
+
 // DeleteWikiPage soft-deletes a wiki page
 // userDiscordID filters by guild membership (empty = admin)
 func (s *WikiService) DeleteWikiPage(ctx context.Context, id string, userDiscordID string) error {
@@ -143,23 +421,28 @@ func (s *WikiService) DeleteWikiPage(ctx context.Context, id string, userDiscord
 	// Invalidate cache for this guild
 	s.titlesCache.Delete(page.GuildID)
 
+	s.notifyWikiPage(notify.EventWikiPageDeleted, page)
+	s.audit.record(ctx, entities.ActionWikiPageDeleted, entities.ResourceWikiPage, id, page.GuildID, nil)
+
 	return nil
 }
 
 // ListWikiPages lists wiki pages in a guild
+// cursor continues after a previous page instead of using offset; the returned cursor is nil on the last page
 // userDiscordID filters to only guilds where user is a member (empty = admin)
-func (s *WikiService) ListWikiPages(ctx context.Context, guildID string, limit, offset int, orderBy string, ascending bool, userDiscordID string) ([]*entities.WikiPage, int, error) {
-	pages, total, err := s.wikiRepo.List(ctx, guildID, limit, offset, orderBy, ascending, userDiscordID)
+func (s *WikiService) ListWikiPages(ctx context.Context, guildID string, limit, offset int, cursor *repositories.PageCursor, orderBy string, ascending bool, userDiscordID string) ([]*entities.WikiPage, int, *repositories.PageCursor, error) {
+	pages, total, next, err := s.wikiRepo.List(ctx, guildID, limit, offset, cursor, orderBy, ascending, userDiscordID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list wiki pages: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to list wiki pages: %w", err)
 	}
-	return pages, total, nil
+	return pages, total, next, nil
 }
 
-// SearchWikiPages searches wiki pages in a guild
+// SearchWikiPages searches wiki pages in guildIDs (empty = every guild)
+// authorDiscordID limits results to one author's pages (empty = any author)
 // userDiscordID filters to only guilds where user is a member (empty = admin)
-func (s *WikiService) SearchWikiPages(ctx context.Context, guildID, query string, tags []string, limit, offset int, userDiscordID string) ([]*entities.WikiPage, int, error) {
-	pages, total, err := s.wikiRepo.Search(ctx, guildID, query, tags, limit, offset, userDiscordID)
+func (s *WikiService) SearchWikiPages(ctx context.Context, guildIDs []string, query string, tags []string, authorDiscordID string, limit, offset int, userDiscordID string) ([]*entities.WikiPage, int, error) {
+	pages, total, err := s.wikiRepo.Search(ctx, guildIDs, query, tags, authorDiscordID, limit, offset, userDiscordID)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to search wiki pages: %w", err)
 	}
@@ -176,14 +459,177 @@ func (s *WikiService) GetWikiPageByTitle(ctx context.Context, guildID, slug stri
 	return page, nil
 }
 
+// TagSuggestion is a tag used on guild content similar to a body, for SuggestTags
+type TagSuggestion struct {
+	Tag   string
+	Score float64 // summed full-text rank of the similar pages using the tag
+	Pages int     // how many similar pages use the tag
+}
+
+// SuggestTags ranks the tags on wiki pages in guildID that share vocabulary with body,
+// so new content can be tagged consistently with what's already there. A page's tags
+// count for more the better it matches the body's key terms. Tags in exclude, and the
+// page excludePageID (empty = none), are left out; limit <= 0 uses DefaultTagSuggestions.
+// userDiscordID filters by guild membership (empty = admin)
+func (s *WikiService) SuggestTags(ctx context.Context, guildID, body, excludePageID string, exclude []string, limit int, userDiscordID string) ([]TagSuggestion, error) {
+	if limit <= 0 {
+		limit = DefaultTagSuggestions
+	}
+	limit = min(limit, MaxTagSuggestions)
+
+	terms := textutil.KeyTerms(body, tagSuggestionTerms)
+	if guildID == "" || len(terms) == 0 {
+		return nil, nil
+	}
+
+	// websearch_to_tsquery reads "or" as the OR operator, so any one term matches
+	matches, err := s.wikiRepo.FindTaggedMatches(ctx, guildID, strings.Join(terms, " or "), excludePageID, tagSuggestionPages, userDiscordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find similar wiki pages: %w", err)
+	}
+
+	skip := make(map[string]bool, len(exclude))
+	for _, tag := range exclude {
+		skip[strings.ToLower(strings.TrimPrefix(tag, "#"))] = true
+	}
+
+	byTag := make(map[string]*TagSuggestion)
+	for _, match := range matches {
+		for _, tag := range match.Tags {
+			if skip[tag] {
+				continue
+			}
+			suggestion, ok := byTag[tag]
+			if !ok {
+				suggestion = &TagSuggestion{Tag: tag}
+				byTag[tag] = suggestion
+			}
+			suggestion.Score += match.Rank
+			suggestion.Pages++
+		}
+	}
+
+	suggestions := make([]TagSuggestion, 0, len(byTag))
+	for _, suggestion := range byTag {
+		suggestions = append(suggestions, *suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Pages != b.Pages {
+			return a.Pages > b.Pages
+		}
+		return a.Tag < b.Tag
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
 // AddWikiMessageReference adds a Discord message reference to a wiki page
+// Returns an error wrapping repositories.ErrReferenceLimitReached when the page is at
+// its guild's reference limit
 func (s *WikiService) AddWikiMessageReference(ctx context.Context, ref *entities.WikiMessageReference) error {
-	if err := s.wikiRefRepo.Create(ctx, ref); err != nil {
+	limit, err := maxReferences(ctx, s.refLimits, ref.GuildID)
+	if err != nil {
+		return err
+	}
+	if err := s.wikiRefRepo.Create(ctx, ref, limit); err != nil {
+		if errors.Is(err, repositories.ErrReferenceLimitReached) {
+			return fmt.Errorf("%w: %d", repositories.ErrReferenceLimitReached, limit)
+		}
 		return fmt.Errorf("failed to add wiki message reference: %w", err)
 	}
 	return nil
 }
 
+// WikiReferenceBatchResult reports what AddWikiMessageReferences did with a batch
+type WikiReferenceBatchResult struct {
+	Added   int
+	Skipped int // Already referenced by the page, or repeated within the batch
+	Invalid []InvalidWikiReference
+}
+
+// InvalidWikiReference is a reference in a batch that was rejected and not added
+type InvalidWikiReference struct {
+	Index  int // Position in the batch
+	Reason string
+}
+
+// AddWikiMessageReferences adds several Discord message references to a wiki page at once.
+// Invalid references are reported in the result rather than failing the batch; the
+// rest are added in one transaction, skipping messages the page already references.
+// Nothing is added, and the error wraps repositories.ErrReferenceLimitReached, if they
+// would take the page past its guild's reference limit.
+// userDiscordID filters by guild membership (empty = admin)
+func (s *WikiService) AddWikiMessageReferences(ctx context.Context, pageID string, refs []*entities.WikiMessageReference, userDiscordID string) (*WikiReferenceBatchResult, error) {
+	page, err := s.wikiRepo.GetByID(ctx, pageID, userDiscordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wiki page: %w", err)
+	}
+	if page == nil {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, pageID)
+	}
+
+	result := &WikiReferenceBatchResult{}
+	valid := make([]*entities.WikiMessageReference, 0, len(refs))
+	seen := make(map[string]bool, len(refs))
+	for i, ref := range refs {
+		if ref.GuildID == "" {
+			ref.GuildID = page.GuildID
+		}
+		if reason := invalidWikiReferenceReason(ref, page.GuildID); reason != "" {
+			result.Invalid = append(result.Invalid, InvalidWikiReference{Index: i, Reason: reason})
+			continue
+		}
+		if seen[ref.MessageID] {
+			result.Skipped++
+			continue
+		}
+		seen[ref.MessageID] = true
+		ref.WikiPageID = page.ID
+		valid = append(valid, ref)
+	}
+
+	if len(valid) > 0 {
+		limit, err := maxReferences(ctx, s.refLimits, page.GuildID)
+		if err != nil {
+			return nil, err
+		}
+		added, err := s.wikiRefRepo.CreateBatch(ctx, valid, limit)
+		if err != nil {
+			if errors.Is(err, repositories.ErrReferenceLimitReached) {
+				return nil, fmt.Errorf("%w: %d", repositories.ErrReferenceLimitReached, limit)
+			}
+			return nil, fmt.Errorf("failed to add wiki message references: %w", err)
+		}
+		result.Added = added
+		result.Skipped += len(valid) - added
+	}
+
+	return result, nil
+}
+
+// invalidWikiReferenceReason returns why ref can't be added to a page in guildID, or "" if it can
+func invalidWikiReferenceReason(ref *entities.WikiMessageReference, guildID string) string {
+	switch {
+	case ref.MessageID == "":
+		return "message_id is required"
+	case ref.ChannelID == "":
+		return "channel_id is required"
+	case ref.AuthorID == "":
+		return "author_id is required"
+	case ref.MessageTimestamp.IsZero():
+		return "message_timestamp is required"
+	case ref.GuildID != guildID:
+		return "message is from a different guild than the wiki page"
+	}
+	return ""
+}
+
 // ListWikiMessageReferences retrieves all message references for a wiki page
 func (s *WikiService) ListWikiMessageReferences(ctx context.Context, pageID string) ([]*entities.WikiMessageReference, error) {
 	refs, err := s.wikiRefRepo.GetByPageID(ctx, pageID)
@@ -193,7 +639,7 @@ func (s *WikiService) ListWikiMessageReferences(ctx context.Context, pageID stri
 	return refs, nil
 }
 
-// AutocompleteWikiTitles returns all wiki page titles for a guild (lightweight for autocomplete)
+// AutocompleteWikiTitles returns all published wiki page titles for a guild (lightweight for autocomplete)
 func (s *WikiService) AutocompleteWikiTitles(ctx context.Context, guildID string) ([]struct {
 	ID    string
 	Title string
@@ -241,46 +687,79 @@ func (s *WikiService) getWikiTitlesForGuild(ctx context.Context, guildID string)
 	return titles, nil
 }
 
-// MergeWikiPages merges sourcePageID into targetPageID:
-// - Appends source body to target body
-// - Converts source's canonical title to an alias pointing to target
-// - Transfers all other source aliases to point to target
-// - Transfers all message references from source to target
-// - Soft-deletes source page
-// - Flattens any existing aliases pointing to source (redirects them to target)
-// - Invalidates title cache for guild
-// Note: No ACL check needed - merge is an admin-only operation
-func (s *WikiService) MergeWikiPages(ctx context.Context, sourcePageID, targetPageID, mergedByUserID string) (*entities.WikiPage, error) {
-	// Fetch both pages (no ACL filter - admin operation)
-	sourcePage, err := s.wikiRepo.GetByID(ctx, sourcePageID, "")
+// WikiMergeResult describes merging a source page into a target page
+type WikiMergeResult struct {
+	Source         *entities.WikiPage // Source page as it was before the merge
+	Merged         *entities.WikiPage // Target page with the combined body and tags
+	ReferenceCount int                // Distinct messages referenced by the merged page
+	TitleCount     int                // Titles (canonical and aliases) pointing at the merged page
+}
+
+// planWikiMerge loads and validates both pages and computes the merged content without
+// writing anything. It also returns the merge log entry MergeWikiPages records.
+// userDiscordID filters both pages by guild membership (empty = admin)
+func (s *WikiService) planWikiMerge(ctx context.Context, sourcePageID, targetPageID, mergedByUserID, userDiscordID string) (*WikiMergeResult, *entities.WikiMergeLog, error) {
+	sourcePage, err := s.wikiRepo.GetByID(ctx, sourcePageID, userDiscordID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch source page: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch source page: %w", err)
 	}
 	if sourcePage == nil {
-		return nil, fmt.Errorf("source page not found: %s", sourcePageID)
+		return nil, nil, fmt.Errorf("source %w: %s", repositories.ErrWikiPageNotFound, sourcePageID)
 	}
 
-	targetPage, err := s.wikiRepo.GetByID(ctx, targetPageID, "")
+	targetPage, err := s.wikiRepo.GetByID(ctx, targetPageID, userDiscordID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch target page: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch target page: %w", err)
 	}
 	if targetPage == nil {
-		return nil, fmt.Errorf("target page not found: %s", targetPageID)
+		return nil, nil, fmt.Errorf("target %w: %s", repositories.ErrWikiPageNotFound, targetPageID)
 	}
 
 	// Validate: must be in same guild
 	if sourcePage.GuildID != targetPage.GuildID {
-		return nil, fmt.Errorf("cannot merge pages from different guilds")
+		return nil, nil, fmt.Errorf("cannot merge pages from different guilds")
 	}
 
 	// Validate: cannot merge page into itself
 	if sourcePageID == targetPageID {
-		return nil, fmt.Errorf("cannot merge page into itself")
+		return nil, nil, fmt.Errorf("cannot merge page into itself")
 	}
 
+	// Snapshot everything the merge changes before touching it
+	sourceTitles, err := s.wikiTitleRepo.ListByPageID(ctx, sourcePageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch source titles: %w", err)
+	}
+	sourceRefs, err := s.wikiRefRepo.GetByPageID(ctx, sourcePageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch source references: %w", err)
+	}
+	targetTitles, err := s.wikiTitleRepo.ListByPageID(ctx, targetPageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch target titles: %w", err)
+	}
+	targetRefs, err := s.wikiRefRepo.GetByPageID(ctx, targetPageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch target references: %w", err)
+	}
+	sourceSnapshot := *sourcePage
+	mergeLog := &entities.WikiMergeLog{
+		GuildID:          sourcePage.GuildID,
+		SourcePageID:     sourcePageID,
+		TargetPageID:     targetPageID,
+		MergedByUserID:   mergedByUserID,
+		SourcePage:       &sourceSnapshot,
+		SourceTitles:     sourceTitles,
+		SourceReferences: sourceRefs,
+		TargetBodyBefore: targetPage.Body,
+		TargetTagsBefore: append([]string(nil), targetPage.Tags...),
+	}
+
+	merged := *targetPage
+
 	// 1. Merge content: append source body to target body (with separator)
 	separator := "\n\n---\n\n"
-	targetPage.Body = targetPage.Body + separator + sourcePage.Body
+	merged.Body = targetPage.Body + separator + sourcePage.Body
 
 	// 2. Merge tags: union of both sets (deduplicate)
 	tagSet := make(map[string]bool)
@@ -294,44 +773,240 @@ func (s *WikiService) MergeWikiPages(ctx context.Context, sourcePageID, targetPa
 	for tag := range tagSet {
 		mergedTags = append(mergedTags, tag)
 	}
-	targetPage.Tags = mergedTags
+	merged.Tags = mergedTags
+
+	mergeLog.MergedBody = merged.Body
 
-	// 3. Update target page with merged content
-	if err := s.wikiRepo.Update(ctx, targetPage); err != nil {
-		return nil, fmt.Errorf("failed to update target page: %w", err)
+	// References to the same message on both pages are only kept once
+	referencedMessages := make(map[string]bool, len(sourceRefs)+len(targetRefs))
+	for _, ref := range append(sourceRefs, targetRefs...) {
+		referencedMessages[ref.MessageID] = true
 	}
 
-	// 4. Transfer all message references from source to target
-	transferred, err := s.wikiRefRepo.TransferReferences(ctx, sourcePageID, targetPageID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to transfer references: %w", err)
+	result := &WikiMergeResult{
+		Source:         sourcePage,
+		Merged:         &merged,
+		ReferenceCount: len(referencedMessages),
+		TitleCount:     len(sourceTitles) + len(targetTitles),
 	}
-	_ = transferred // Track for logging if needed
+	return result, mergeLog, nil
+}
 
-	// 5. Convert source canonical title to alias pointing to target
-	// This makes the old page name redirect to the merged page
-	convertedCount, err := s.wikiTitleRepo.ConvertToAlias(ctx, sourcePageID, targetPageID)
+// PreviewWikiPageMerge returns what MergeWikiPages would produce, without saving anything
+// userDiscordID filters both pages by guild membership (empty = admin)
+func (s *WikiService) PreviewWikiPageMerge(ctx context.Context, sourcePageID, targetPageID, userDiscordID string) (*WikiMergeResult, error) {
+	result, _, err := s.planWikiMerge(ctx, sourcePageID, targetPageID, "", userDiscordID)
+	return result, err
+}
+
+// MergeWikiPages merges sourcePageID into targetPageID:
+// - Appends source body to target body, moderated like an edit of the target
+// - Converts source's canonical title to an alias pointing to target
+// - Transfers all other source aliases to point to target
+// - Transfers all message references from source to target
+// - Soft-deletes source page
+// - Flattens any existing aliases pointing to source (redirects them to target)
+// - Invalidates title cache for guild
+// A snapshot of the source page is written to the merge log so the merge can be undone.
+// The log entry and every change are written in one transaction.
+// Note: No ACL check here - the handler checks the caller may edit both pages
+func (s *WikiService) MergeWikiPages(ctx context.Context, sourcePageID, targetPageID, mergedByUserID string) (*WikiMergeResult, error) {
+	result, mergeLog, err := s.planWikiMerge(ctx, sourcePageID, targetPageID, mergedByUserID, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert source title to alias: %w", err)
+		return nil, err
 	}
-	_ = convertedCount // Track for logging if needed
+	sourcePage, targetPage := result.Source, result.Merged
 
-	// 6. Flatten other aliases: Update all non-canonical titles pointing to source → point to target
-	// This ensures no title chains: aliases always point directly to canonical page
-	flattened, err := s.wikiTitleRepo.UpdatePageID(ctx, sourcePageID, targetPageID)
+	// The merged body is new text on the target, so it is moderated like an edit
+	flagReason, err := s.moderation.review(targetPage.Title, targetPage.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to flatten aliases: %w", err)
+		return nil, err
 	}
-	_ = flattened // Track for logging if needed
 
-	// 7. Soft-delete source page
-	if err := s.wikiRepo.Delete(ctx, sourcePageID); err != nil {
-		return nil, fmt.Errorf("failed to delete source page: %w", err)
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		if err := s.mergeLogRepo.Create(ctx, mergeLog); err != nil {
+			return fmt.Errorf("failed to record merge: %w", err)
+		}
+
+		// 3. Update target page with merged content
+		targetPage.LastEditorID = mergedByUserID
+		if err := s.wikiRepo.Update(ctx, targetPage); err != nil {
+			return fmt.Errorf("failed to update target page: %w", err)
+		}
+
+		// 4. Transfer all message references from source to target
+		if _, err := s.wikiRefRepo.TransferReferences(ctx, sourcePageID, targetPageID); err != nil {
+			return fmt.Errorf("failed to transfer references: %w", err)
+		}
+
+		// 5. Convert source canonical title to alias pointing to target
+		// This makes the old page name redirect to the merged page
+		if _, err := s.wikiTitleRepo.ConvertToAlias(ctx, sourcePageID, targetPageID); err != nil {
+			return fmt.Errorf("failed to convert source title to alias: %w", err)
+		}
+
+		// 6. Flatten other aliases: Update all non-canonical titles pointing to source → point to target
+		// This ensures no title chains: aliases always point directly to canonical page
+		if _, err := s.wikiTitleRepo.UpdatePageID(ctx, sourcePageID, targetPageID); err != nil {
+			return fmt.Errorf("failed to flatten aliases: %w", err)
+		}
+
+		// 7. Soft-delete source page
+		if err := s.wikiRepo.Delete(ctx, sourcePageID); err != nil {
+			return fmt.Errorf("failed to delete source page: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	s.moderation.flag(ctx, entities.ResourceWikiPage, targetPageID, flagReason)
 
 	// 8. Invalidate title cache for guild
 	s.titlesCache.Delete(sourcePage.GuildID)
 
+	// To webhooks the source is gone and its content now lives on the target
+	s.notifyWikiPage(notify.EventWikiPageDeleted, sourcePage)
+	s.notifyWikiPage(notify.EventWikiPageUpdated, targetPage)
+
+	s.audit.record(ctx, entities.ActionWikiPageMerged, entities.ResourceWikiPage, sourcePageID, sourcePage.GuildID,
+		map[string]any{"target_page_id": targetPageID})
+
 	// Return merged target page
-	return targetPage, nil
+	return result, nil
+}
+
+// inTx runs fn in a transaction, or directly when the service has no transactor
+func (s *WikiService) inTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.transactor == nil {
+		return fn(ctx)
+	}
+	return s.transactor.InTx(ctx, fn)
+}
+
+// GetWikiMergeTarget returns the page sourcePageID was most recently merged into, or
+// ErrMergeNotFound if that merge was undone or never happened
+// userDiscordID filters by guild membership (empty = admin)
+func (s *WikiService) GetWikiMergeTarget(ctx context.Context, sourcePageID, userDiscordID string) (*entities.WikiPage, error) {
+	mergeLog, err := s.mergeLogRepo.GetLatestBySourcePage(ctx, sourcePageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch merge log: %w", err)
+	}
+	if mergeLog == nil {
+		return nil, ErrMergeNotFound
+	}
+
+	target, err := s.wikiRepo.GetByID(ctx, mergeLog.TargetPageID, userDiscordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch target page: %w", err)
+	}
+	if target == nil {
+		return nil, fmt.Errorf("target %w: %s", repositories.ErrWikiPageNotFound, mergeLog.TargetPageID)
+	}
+	return target, nil
+}
+
+// UnmergeWikiPages reverts the most recent merge of sourcePageID:
+// - Restores the target page's body and tags from before the merge
+// - Recreates the source page from its snapshot
+// - Points the source's titles back at it, including its canonical title
+// - Removes the reference copies made on the target and re-attaches the originals to the source
+// Only the user who performed the merge or an admin may undo it, and only within wikiMergeUndoWindow.
+// Refuses if the target page was edited after the merge, since those edits would be lost.
+// All changes are written in one transaction, so a failed undo leaves the merge in place.
+func (s *WikiService) UnmergeWikiPages(ctx context.Context, sourcePageID, userID string, isAdmin bool) (*entities.WikiPage, *entities.WikiPage, error) {
+	mergeLog, err := s.mergeLogRepo.GetLatestBySourcePage(ctx, sourcePageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch merge log: %w", err)
+	}
+	if mergeLog == nil || mergeLog.SourcePage == nil {
+		return nil, nil, ErrMergeNotFound
+	}
+
+	if !isAdmin && mergeLog.MergedByUserID != userID {
+		return nil, nil, ErrMergeUndoForbidden
+	}
+	if time.Since(mergeLog.MergedAt) > wikiMergeUndoWindow {
+		return nil, nil, ErrMergeUndoExpired
+	}
+
+	targetPage, err := s.wikiRepo.GetByID(ctx, mergeLog.TargetPageID, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch target page: %w", err)
+	}
+	if targetPage == nil {
+		return nil, nil, fmt.Errorf("target %w: %s", repositories.ErrWikiPageNotFound, mergeLog.TargetPageID)
+	}
+	if targetPage.Body != mergeLog.MergedBody {
+		return nil, nil, ErrMergeTargetModified
+	}
+
+	sourcePage := *mergeLog.SourcePage
+	err = s.inTx(ctx, func(ctx context.Context) error {
+		// 1. Put the target back the way it was
+		targetPage.Body = mergeLog.TargetBodyBefore
+		targetPage.Tags = mergeLog.TargetTagsBefore
+		targetPage.LastEditorID = userID
+		if err := s.wikiRepo.Update(ctx, targetPage); err != nil {
+			return fmt.Errorf("failed to restore target page: %w", err)
+		}
+
+		// 2. Recreate the source page from the snapshot
+		if err := s.wikiRepo.Restore(ctx, &sourcePage); err != nil {
+			return fmt.Errorf("failed to restore source page: %w", err)
+		}
+
+		// 3. Point the source's titles back at it
+		for _, title := range mergeLog.SourceTitles {
+			if err := s.wikiTitleRepo.Reassign(ctx, title.ID, sourcePageID, title.IsCanonical); err != nil {
+				return fmt.Errorf("failed to restore title %q: %w", title.DisplayTitle, err)
+			}
+		}
+
+		// 4. Drop the reference copies the merge made on the target; references the
+		// target already had before the merge were never copied and are left alone
+		copyIDs := make(map[string]bool, len(mergeLog.SourceReferences))
+		for _, ref := range mergeLog.SourceReferences {
+			copyIDs[ref.ID+"_xfer_"+mergeLog.TargetPageID] = true
+		}
+		targetRefs, err := s.wikiRefRepo.GetByPageID(ctx, mergeLog.TargetPageID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch target references: %w", err)
+		}
+		for _, ref := range targetRefs {
+			if copyIDs[ref.ID] {
+				if err := s.wikiRefRepo.Delete(ctx, ref.ID); err != nil {
+					return fmt.Errorf("failed to remove transferred reference: %w", err)
+				}
+			}
+		}
+
+		// 5. Re-attach the original references (no-op for any still present)
+		for _, ref := range mergeLog.SourceReferences {
+			original := *ref
+			original.WikiPageID = sourcePageID
+			// No limit: these references were on the page before the merge
+			if err := s.wikiRefRepo.Create(ctx, &original, 0); err != nil {
+				return fmt.Errorf("failed to restore reference: %w", err)
+			}
+		}
+
+		if err := s.mergeLogRepo.MarkUndone(ctx, mergeLog.ID); err != nil {
+			return fmt.Errorf("failed to mark merge undone: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.titlesCache.Delete(mergeLog.GuildID)
+
+	s.notifyWikiPage(notify.EventWikiPageCreated, &sourcePage)
+	s.notifyWikiPage(notify.EventWikiPageUpdated, targetPage)
+
+	s.audit.record(ctx, entities.ActionWikiPageUnmerged, entities.ResourceWikiPage, sourcePageID, mergeLog.GuildID,
+		map[string]any{"target_page_id": mergeLog.TargetPageID})
+
+	return &sourcePage, targetPage, nil
 }
@@ -0,0 +1,1146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/notify"
+	"github.com/devilmonastery/hivemind/internal/pkg/idgen"
+)
+
+// fakeWikiPageRepo keeps pages in memory; soft-deleted pages stay in the map
+type fakeWikiPageRepo struct {
+	repositories.WikiPageRepository
+	pages   map[string]*entities.WikiPage
+	members map[string]string // Discord ID -> guild ID; nil skips the ACL check
+}
+
+func (r *fakeWikiPageRepo) GetByID(ctx context.Context, id, userDiscordID string) (*entities.WikiPage, error) {
+	page, ok := r.pages[id]
+	if !ok || page.DeletedAt != nil {
+		return nil, nil
+	}
+	if r.members != nil && userDiscordID != "" && r.members[userDiscordID] != page.GuildID {
+		return nil, nil
+	}
+	copied := *page
+	copied.Tags = append([]string(nil), page.Tags...)
+	return &copied, nil
+}
+
+// FindTaggedMatches ranks pages by how many of the query's "or"-separated terms appear
+// in their title or body, standing in for the full-text match and ts_rank
+func (r *fakeWikiPageRepo) FindTaggedMatches(ctx context.Context, guildID, query, excludeID string, limit int, userDiscordID string) ([]repositories.TaggedMatch, error) {
+	var matches []repositories.TaggedMatch
+	for _, page := range r.pages {
+		if page.GuildID != guildID || page.ID == excludeID || page.DeletedAt != nil || len(page.Tags) == 0 {
+			continue
+		}
+		words := strings.Fields(strings.ToLower(page.Title + " " + page.Body))
+		var rank float64
+		for _, term := range strings.Split(query, " or ") {
+			for _, word := range words {
+				if strings.Trim(word, ".,!?") == term {
+					rank++
+					break
+				}
+			}
+		}
+		if rank > 0 {
+			matches = append(matches, repositories.TaggedMatch{Tags: page.Tags, Rank: rank})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Rank > matches[j].Rank })
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+func (r *fakeWikiPageRepo) GetByGuildAndSlug(ctx context.Context, guildID, slug, userDiscordID string) (*entities.WikiPage, error) {
+	for _, page := range r.pages {
+		if page.GuildID == guildID && page.DeletedAt == nil && strings.EqualFold(page.Title, slug) {
+			copied := *page
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeWikiPageRepo) IDExists(ctx context.Context, id string) (bool, error) {
+	_, ok := r.pages[id]
+	return ok, nil
+}
+
+func (r *fakeWikiPageRepo) Create(ctx context.Context, page *entities.WikiPage) error {
+	if page.ID == "" {
+		page.ID = idgen.GenerateID()
+	}
+	copied := *page
+	r.pages[page.ID] = &copied
+	return nil
+}
+
+func (r *fakeWikiPageRepo) Update(ctx context.Context, page *entities.WikiPage) error {
+	existing, ok := r.pages[page.ID]
+	if !ok || existing.DeletedAt != nil {
+		return fmt.Errorf("wiki page not found: %s", page.ID)
+	}
+	copied := *page
+	r.pages[page.ID] = &copied
+	return nil
+}
+
+func (r *fakeWikiPageRepo) Delete(ctx context.Context, id string) error {
+	now := time.Now()
+	r.pages[id].DeletedAt = &now
+	return nil
+}
+
+func (r *fakeWikiPageRepo) Restore(ctx context.Context, page *entities.WikiPage) error {
+	existing, ok := r.pages[page.ID]
+	if !ok || existing.DeletedAt == nil {
+		return fmt.Errorf("deleted wiki page not found: %s", page.ID)
+	}
+	copied := *page
+	copied.DeletedAt = nil
+	r.pages[page.ID] = &copied
+	return nil
+}
+
+func (r *fakeWikiPageRepo) SetPinned(ctx context.Context, id string, pinned bool) error {
+	existing, ok := r.pages[id]
+	if !ok || existing.DeletedAt != nil {
+		return fmt.Errorf("wiki page not found: %s", id)
+	}
+	existing.Pinned = pinned
+	return nil
+}
+
+func (r *fakeWikiPageRepo) SetStatus(ctx context.Context, id string, status entities.WikiPageStatus) error {
+	existing, ok := r.pages[id]
+	if !ok || existing.DeletedAt != nil {
+		return fmt.Errorf("wiki page not found: %s", id)
+	}
+	existing.Status = status
+	return nil
+}
+
+func (r *fakeWikiPageRepo) SetAuthor(ctx context.Context, id, authorID string) error {
+	existing, ok := r.pages[id]
+	if !ok || existing.DeletedAt != nil {
+		return fmt.Errorf("wiki page not found: %s", id)
+	}
+	existing.AuthorID = authorID
+	return nil
+}
+
+type fakeWikiTitleRepo struct {
+	repositories.WikiTitleRepository
+	titles map[string]*entities.WikiTitle
+}
+
+func (r *fakeWikiTitleRepo) ListByPageID(ctx context.Context, pageID string) ([]*entities.WikiTitle, error) {
+	var titles []*entities.WikiTitle
+	for _, t := range r.titles {
+		if t.PageID == pageID {
+			copied := *t
+			titles = append(titles, &copied)
+		}
+	}
+	return titles, nil
+}
+
+func (r *fakeWikiTitleRepo) ConvertToAlias(ctx context.Context, sourcePageID, targetPageID string) (int, error) {
+	n := 0
+	for _, t := range r.titles {
+		if t.PageID == sourcePageID && t.IsCanonical {
+			t.PageID = targetPageID
+			t.IsCanonical = false
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeWikiTitleRepo) UpdatePageID(ctx context.Context, oldPageID, newPageID string) (int, error) {
+	n := 0
+	for _, t := range r.titles {
+		if t.PageID == oldPageID && !t.IsCanonical {
+			t.PageID = newPageID
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeWikiTitleRepo) Reassign(ctx context.Context, titleID, pageID string, isCanonical bool) error {
+	t, ok := r.titles[titleID]
+	if !ok {
+		return fmt.Errorf("wiki title not found: %s", titleID)
+	}
+	t.PageID = pageID
+	t.IsCanonical = isCanonical
+	return nil
+}
+
+// fakeWikiRefRepo mirrors the (wiki_page_id, message_id) uniqueness of the real table
+type fakeWikiRefRepo struct {
+	repositories.WikiMessageReferenceRepository
+	refs map[string]*entities.WikiMessageReference
+}
+
+func (r *fakeWikiRefRepo) exists(pageID, messageID string) bool {
+	for _, ref := range r.refs {
+		if ref.WikiPageID == pageID && ref.MessageID == messageID {
+			return true
+		}
+	}
+	return false
+}
+
+// count returns how many references pageID has
+func (r *fakeWikiRefRepo) count(pageID string) int {
+	n := 0
+	for _, ref := range r.refs {
+		if ref.WikiPageID == pageID {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *fakeWikiRefRepo) Create(ctx context.Context, ref *entities.WikiMessageReference, maxRefs int) error {
+	if r.exists(ref.WikiPageID, ref.MessageID) {
+		return nil
+	}
+	if maxRefs > 0 && r.count(ref.WikiPageID) >= maxRefs {
+		return repositories.ErrReferenceLimitReached
+	}
+	copied := *ref
+	r.refs[ref.ID] = &copied
+	return nil
+}
+
+func (r *fakeWikiRefRepo) CreateBatch(ctx context.Context, refs []*entities.WikiMessageReference, maxRefs int) (int, error) {
+	var fresh []*entities.WikiMessageReference
+	for _, ref := range refs {
+		if !r.exists(ref.WikiPageID, ref.MessageID) {
+			fresh = append(fresh, ref)
+		}
+	}
+	if maxRefs > 0 && len(fresh) > 0 && r.count(fresh[0].WikiPageID)+len(fresh) > maxRefs {
+		return 0, repositories.ErrReferenceLimitReached
+	}
+
+	added := 0
+	for _, ref := range fresh {
+		if ref.ID == "" {
+			ref.ID = idgen.GenerateID()
+		}
+		copied := *ref
+		r.refs[ref.ID] = &copied
+		added++
+	}
+	return added, nil
+}
+
+func (r *fakeWikiRefRepo) GetByPageID(ctx context.Context, pageID string) ([]*entities.WikiMessageReference, error) {
+	var refs []*entities.WikiMessageReference
+	for _, ref := range r.refs {
+		if ref.WikiPageID == pageID {
+			copied := *ref
+			refs = append(refs, &copied)
+		}
+	}
+	return refs, nil
+}
+
+func (r *fakeWikiRefRepo) Delete(ctx context.Context, id string) error {
+	if _, ok := r.refs[id]; !ok {
+		return errors.New("not found")
+	}
+	delete(r.refs, id)
+	return nil
+}
+
+func (r *fakeWikiRefRepo) TransferReferences(ctx context.Context, sourcePageID, targetPageID string) (int, error) {
+	sources, _ := r.GetByPageID(ctx, sourcePageID)
+	n := 0
+	for _, ref := range sources {
+		if r.exists(targetPageID, ref.MessageID) {
+			continue
+		}
+		ref.ID = ref.ID + "_xfer_" + targetPageID
+		ref.WikiPageID = targetPageID
+		r.refs[ref.ID] = ref
+		n++
+	}
+	return n, nil
+}
+
+type fakeWikiMergeLogRepo struct {
+	entries []*entities.WikiMergeLog
+}
+
+func (r *fakeWikiMergeLogRepo) Create(ctx context.Context, entry *entities.WikiMergeLog) error {
+	entry.ID = fmt.Sprintf("merge-%d", len(r.entries)+1)
+	entry.MergedAt = time.Now()
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *fakeWikiMergeLogRepo) GetLatestBySourcePage(ctx context.Context, sourcePageID string) (*entities.WikiMergeLog, error) {
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		if e := r.entries[i]; e.SourcePageID == sourcePageID && e.UndoneAt == nil {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeWikiMergeLogRepo) MarkUndone(ctx context.Context, id string) error {
+	for _, e := range r.entries {
+		if e.ID == id {
+			now := time.Now()
+			e.UndoneAt = &now
+			return nil
+		}
+	}
+	return fmt.Errorf("wiki merge log entry not found: %s", id)
+}
+
+type wikiMergeFixture struct {
+	svc     *WikiService
+	pages   *fakeWikiPageRepo
+	titles  *fakeWikiTitleRepo
+	refs    *fakeWikiRefRepo
+	history *fakeWikiMergeLogRepo
+}
+
+// newWikiMergeFixture sets up two pages in one guild, each with titles and
+// references, including a message referenced by both pages
+func newWikiMergeFixture() *wikiMergeFixture {
+	pages := &fakeWikiPageRepo{pages: map[string]*entities.WikiPage{
+		"src": {ID: "src", Title: "Dragons", Body: "Dragons breathe fire", GuildID: "g1", Tags: []string{"lore"}},
+		"tgt": {ID: "tgt", Title: "Monsters", Body: "Monsters are scary", GuildID: "g1", Tags: []string{"bestiary"}},
+	}}
+	titles := &fakeWikiTitleRepo{titles: map[string]*entities.WikiTitle{
+		"t-src":   {ID: "t-src", GuildID: "g1", DisplayTitle: "Dragons", PageSlug: "dragons", PageID: "src", IsCanonical: true},
+		"t-wyrm":  {ID: "t-wyrm", GuildID: "g1", DisplayTitle: "Wyrms", PageSlug: "wyrms", PageID: "src"},
+		"t-tgt":   {ID: "t-tgt", GuildID: "g1", DisplayTitle: "Monsters", PageSlug: "monsters", PageID: "tgt", IsCanonical: true},
+		"t-beast": {ID: "t-beast", GuildID: "g1", DisplayTitle: "Beasts", PageSlug: "beasts", PageID: "tgt"},
+	}}
+	refs := &fakeWikiRefRepo{refs: map[string]*entities.WikiMessageReference{
+		"r1": {ID: "r1", WikiPageID: "src", MessageID: "m1", GuildID: "g1"},
+		"r2": {ID: "r2", WikiPageID: "src", MessageID: "m-shared", GuildID: "g1"},
+		"r3": {ID: "r3", WikiPageID: "tgt", MessageID: "m-shared", GuildID: "g1"},
+		"r4": {ID: "r4", WikiPageID: "tgt", MessageID: "m4", GuildID: "g1"},
+	}}
+	history := &fakeWikiMergeLogRepo{}
+
+	f := &wikiMergeFixture{
+		pages:   pages,
+		titles:  titles,
+		refs:    refs,
+		history: history,
+	}
+	f.svc = NewWikiService(pages, refs, titles, history, fixtureTransactor{f}, nil, nil, nil, nil)
+	return f
+}
+
+// fixtureTransactor rolls the fixture's repositories back when a transaction fails
+type fixtureTransactor struct {
+	f *wikiMergeFixture
+}
+
+func (t fixtureTransactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	restore := t.f.save()
+	if err := fn(ctx); err != nil {
+		restore()
+		return err
+	}
+	return nil
+}
+
+// save copies the fixture's repositories and returns a func that puts the copies back
+func (f *wikiMergeFixture) save() (restore func()) {
+	pages := make(map[string]*entities.WikiPage, len(f.pages.pages))
+	for id, p := range f.pages.pages {
+		page := *p
+		pages[id] = &page
+	}
+	titles := make(map[string]*entities.WikiTitle, len(f.titles.titles))
+	for id, t := range f.titles.titles {
+		title := *t
+		titles[id] = &title
+	}
+	refs := make(map[string]*entities.WikiMessageReference, len(f.refs.refs))
+	for id, r := range f.refs.refs {
+		ref := *r
+		refs[id] = &ref
+	}
+	entries := make([]*entities.WikiMergeLog, len(f.history.entries))
+	for i, e := range f.history.entries {
+		entry := *e
+		entries[i] = &entry
+	}
+	return func() {
+		f.pages.pages, f.titles.titles, f.refs.refs, f.history.entries = pages, titles, refs, entries
+	}
+}
+
+// failingPageDeleteRepo fails the last step of a merge
+type failingPageDeleteRepo struct {
+	*fakeWikiPageRepo
+}
+
+func (r failingPageDeleteRepo) Delete(ctx context.Context, id string) error {
+	return errors.New("connection reset")
+}
+
+// failingMarkUndoneRepo fails the last step of an unmerge
+type failingMarkUndoneRepo struct {
+	*fakeWikiMergeLogRepo
+}
+
+func (r failingMarkUndoneRepo) MarkUndone(ctx context.Context, id string) error {
+	return errors.New("connection reset")
+}
+
+// state captures everything a merge touches, for before/after comparison
+type wikiMergeState struct {
+	pages  map[string]entities.WikiPage
+	titles map[string]entities.WikiTitle
+	refs   []string // "id@page"
+}
+
+func (f *wikiMergeFixture) state() wikiMergeState {
+	st := wikiMergeState{
+		pages:  map[string]entities.WikiPage{},
+		titles: map[string]entities.WikiTitle{},
+	}
+	for id, p := range f.pages.pages {
+		page := *p
+		sort.Strings(page.Tags)
+		// Undoing a merge is itself an edit, so the editor isn't restored
+		page.UpdatedAt = time.Time{}
+		page.LastEditorID = ""
+		st.pages[id] = page
+	}
+	for id, t := range f.titles.titles {
+		st.titles[id] = *t
+	}
+	for id, r := range f.refs.refs {
+		st.refs = append(st.refs, id+"@"+r.WikiPageID)
+	}
+	sort.Strings(st.refs)
+	return st
+}
+
+func TestPreviewWikiPageMerge_HidesOtherGuildsPages(t *testing.T) {
+	f := newWikiMergeFixture()
+	f.pages.members = map[string]string{"d-member": "g1", "d-outsider": "g2"}
+
+	if _, err := f.svc.PreviewWikiPageMerge(context.Background(), "src", "tgt", "d-outsider"); !errors.Is(err, repositories.ErrWikiPageNotFound) {
+		t.Errorf("PreviewWikiPageMerge() as outsider error = %v, want ErrWikiPageNotFound", err)
+	}
+	if _, err := f.svc.PreviewWikiPageMerge(context.Background(), "src", "tgt", "d-member"); err != nil {
+		t.Errorf("PreviewWikiPageMerge() as member error = %v", err)
+	}
+}
+
+func TestPreviewWikiPageMerge_LeavesPagesUnchanged(t *testing.T) {
+	ctx := context.Background()
+	f := newWikiMergeFixture()
+	before := f.state()
+
+	preview, err := f.svc.PreviewWikiPageMerge(ctx, "src", "tgt", "")
+	if err != nil {
+		t.Fatalf("PreviewWikiPageMerge() error = %v", err)
+	}
+	if preview.Merged.Body != "Monsters are scary\n\n---\n\nDragons breathe fire" {
+		t.Errorf("preview body = %q", preview.Merged.Body)
+	}
+	tags := append([]string(nil), preview.Merged.Tags...)
+	sort.Strings(tags)
+	if !reflect.DeepEqual(tags, []string{"bestiary", "lore"}) {
+		t.Errorf("preview tags = %v", tags)
+	}
+	// m1, m-shared, m4: the shared message is only counted once
+	if preview.ReferenceCount != 3 {
+		t.Errorf("preview reference count = %d, want 3", preview.ReferenceCount)
+	}
+	if preview.TitleCount != 4 {
+		t.Errorf("preview title count = %d, want 4", preview.TitleCount)
+	}
+
+	if after := f.state(); !reflect.DeepEqual(before, after) {
+		t.Errorf("preview modified state:\nbefore %+v\nafter  %+v", before, after)
+	}
+	if len(f.history.entries) != 0 {
+		t.Errorf("preview recorded %d merge log entries, want 0", len(f.history.entries))
+	}
+}
+
+func TestUnmergeWikiPages_RestoresOriginalPages(t *testing.T) {
+	ctx := context.Background()
+	f := newWikiMergeFixture()
+	before := f.state()
+
+	result, err := f.svc.MergeWikiPages(ctx, "src", "tgt", "user-1")
+	if err != nil {
+		t.Fatalf("MergeWikiPages() error = %v", err)
+	}
+	if f.pages.pages["src"].DeletedAt == nil {
+		t.Fatal("source page should be soft-deleted after merge")
+	}
+	if f.titles.titles["t-src"].PageID != "tgt" {
+		t.Fatal("source title should point at target after merge")
+	}
+	if _, ok := f.refs.refs["r1_xfer_tgt"]; !ok {
+		t.Fatal("source reference should be copied to target after merge")
+	}
+	if result.Merged.Body != "Monsters are scary\n\n---\n\nDragons breathe fire" {
+		t.Fatalf("merged body = %q", result.Merged.Body)
+	}
+
+	source, target, err := f.svc.UnmergeWikiPages(ctx, "src", "user-1", false)
+	if err != nil {
+		t.Fatalf("UnmergeWikiPages() error = %v", err)
+	}
+	if source.ID != "src" || target.ID != "tgt" {
+		t.Errorf("UnmergeWikiPages() returned pages %q, %q", source.ID, target.ID)
+	}
+	if editor := f.pages.pages["tgt"].LastEditorID; editor != "user-1" {
+		t.Errorf("target last editor after unmerge = %q, want user-1", editor)
+	}
+
+	after := f.state()
+	if !reflect.DeepEqual(before.pages, after.pages) {
+		t.Errorf("pages after unmerge = %+v, want %+v", after.pages, before.pages)
+	}
+	if !reflect.DeepEqual(before.titles, after.titles) {
+		t.Errorf("titles after unmerge = %+v, want %+v", after.titles, before.titles)
+	}
+	if !reflect.DeepEqual(before.refs, after.refs) {
+		t.Errorf("references after unmerge = %v, want %v", after.refs, before.refs)
+	}
+
+	// The merge is consumed; a second undo has nothing to revert
+	if _, _, err := f.svc.UnmergeWikiPages(ctx, "src", "user-1", false); !errors.Is(err, ErrMergeNotFound) {
+		t.Errorf("second UnmergeWikiPages() error = %v, want ErrMergeNotFound", err)
+	}
+}
+
+func TestMergeWikiPages_FailureLeavesPagesUnchanged(t *testing.T) {
+	ctx := context.Background()
+	f := newWikiMergeFixture()
+	before := f.state()
+	f.svc.wikiRepo = failingPageDeleteRepo{f.pages}
+
+	if _, err := f.svc.MergeWikiPages(ctx, "src", "tgt", "user-1"); err == nil {
+		t.Fatal("MergeWikiPages() succeeded despite the failing delete")
+	}
+	if after := f.state(); !reflect.DeepEqual(before, after) {
+		t.Errorf("failed merge modified state:\nbefore %+v\nafter  %+v", before, after)
+	}
+	if len(f.history.entries) != 0 {
+		t.Errorf("failed merge recorded %d merge log entries, want 0", len(f.history.entries))
+	}
+}
+
+func TestUnmergeWikiPages_FailureCanBeRetried(t *testing.T) {
+	ctx := context.Background()
+	f := newWikiMergeFixture()
+	original := f.state()
+	if _, err := f.svc.MergeWikiPages(ctx, "src", "tgt", "user-1"); err != nil {
+		t.Fatalf("MergeWikiPages() error = %v", err)
+	}
+	merged := f.state()
+
+	f.svc.mergeLogRepo = failingMarkUndoneRepo{f.history}
+	if _, _, err := f.svc.UnmergeWikiPages(ctx, "src", "user-1", false); err == nil {
+		t.Fatal("UnmergeWikiPages() succeeded despite the failing merge log update")
+	}
+	if after := f.state(); !reflect.DeepEqual(merged, after) {
+		t.Errorf("failed unmerge modified state:\nbefore %+v\nafter  %+v", merged, after)
+	}
+
+	// Nothing was half-undone, so the target still matches the merge and a retry works
+	f.svc.mergeLogRepo = f.history
+	if _, _, err := f.svc.UnmergeWikiPages(ctx, "src", "user-1", false); err != nil {
+		t.Fatalf("retried UnmergeWikiPages() error = %v", err)
+	}
+	after := f.state()
+	if !reflect.DeepEqual(original.pages, after.pages) || !reflect.DeepEqual(original.refs, after.refs) {
+		t.Errorf("state after retried unmerge = %+v, want %+v", after, original)
+	}
+}
+
+func TestMergeAndUnmergeWikiPages_NotifyWebhooks(t *testing.T) {
+	ctx := context.Background()
+	f := newWikiMergeFixture()
+	notifier := &recordingNotifier{}
+	f.svc.notifier = notifier
+
+	if _, err := f.svc.MergeWikiPages(ctx, "src", "tgt", "user-1"); err != nil {
+		t.Fatalf("MergeWikiPages() error = %v", err)
+	}
+	if _, _, err := f.svc.UnmergeWikiPages(ctx, "src", "user-1", false); err != nil {
+		t.Fatalf("UnmergeWikiPages() error = %v", err)
+	}
+
+	var got []string
+	for _, event := range notifier.events {
+		got = append(got, event.Type+" "+event.Content.ID)
+	}
+	want := []string{
+		notify.EventWikiPageDeleted + " src",
+		notify.EventWikiPageUpdated + " tgt",
+		notify.EventWikiPageCreated + " src",
+		notify.EventWikiPageUpdated + " tgt",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestUnmergeWikiPages_Guards(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		userID  string
+		isAdmin bool
+		setup   func(f *wikiMergeFixture)
+		wantErr error
+	}{
+		{name: "other user", userID: "user-2", wantErr: ErrMergeUndoForbidden},
+		{name: "admin", userID: "admin-1", isAdmin: true},
+		{
+			name:   "expired",
+			userID: "user-1",
+			setup: func(f *wikiMergeFixture) {
+				f.history.entries[0].MergedAt = time.Now().Add(-wikiMergeUndoWindow - time.Hour)
+			},
+			wantErr: ErrMergeUndoExpired,
+		},
+		{
+			name:   "target edited",
+			userID: "user-1",
+			setup: func(f *wikiMergeFixture) {
+				f.pages.pages["tgt"].Body += "\n\nmore"
+			},
+			wantErr: ErrMergeTargetModified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newWikiMergeFixture()
+			if _, err := f.svc.MergeWikiPages(ctx, "src", "tgt", "user-1"); err != nil {
+				t.Fatalf("MergeWikiPages() error = %v", err)
+			}
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+
+			_, _, err := f.svc.UnmergeWikiPages(ctx, "src", tt.userID, tt.isAdmin)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UnmergeWikiPages() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetWikiPagePinned(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		isAdmin bool
+		wantErr error
+	}{
+		{name: "author", userID: "author"},
+		{name: "admin", userID: "someone-else", isAdmin: true},
+		{name: "other user", userID: "someone-else", wantErr: ErrPinForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newWikiMergeFixture()
+			f.pages.pages["tgt"].AuthorID = "author"
+
+			page, err := f.svc.SetWikiPagePinned(context.Background(), "tgt", true, tt.userID, "", tt.isAdmin)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("SetWikiPagePinned() error = %v, want %v", err, tt.wantErr)
+				}
+				if f.pages.pages["tgt"].Pinned {
+					t.Error("page was pinned despite the error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetWikiPagePinned() error = %v", err)
+			}
+			if !page.Pinned || !f.pages.pages["tgt"].Pinned {
+				t.Error("page was not pinned")
+			}
+
+			page, err = f.svc.SetWikiPagePinned(context.Background(), "tgt", false, tt.userID, "", tt.isAdmin)
+			if err != nil {
+				t.Fatalf("SetWikiPagePinned(unpin) error = %v", err)
+			}
+			if page.Pinned || f.pages.pages["tgt"].Pinned {
+				t.Error("page was not unpinned")
+			}
+		})
+	}
+}
+
+// recordingNotifier keeps the events it's sent
+type recordingNotifier struct {
+	events []notify.Event
+}
+
+func (n *recordingNotifier) Notify(event notify.Event) {
+	n.events = append(n.events, event)
+}
+
+func TestPublishWikiPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		isAdmin bool
+		wantErr error
+	}{
+		{name: "author", userID: "author"},
+		{name: "admin", userID: "someone-else", isAdmin: true},
+		{name: "other user", userID: "someone-else", wantErr: ErrPublishForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := &fakeWikiPageRepo{pages: map[string]*entities.WikiPage{}}
+			notifier := &recordingNotifier{}
+			svc := NewWikiService(pages, nil, nil, nil, nil, notifier, nil, nil, nil)
+			ctx := context.Background()
+
+			draft, err := svc.CreateWikiPage(ctx, &entities.WikiPage{
+				Title: "Raid Rules", Body: "Work in progress", GuildID: "g1", AuthorID: "author", Status: entities.WikiPageDraft,
+			}, "")
+			if err != nil {
+				t.Fatalf("CreateWikiPage() error = %v", err)
+			}
+			draft.Body = "Still in progress"
+			if _, err := svc.UpdateWikiPage(ctx, draft, ""); err != nil {
+				t.Fatalf("UpdateWikiPage() error = %v", err)
+			}
+			if len(notifier.events) != 0 {
+				t.Fatalf("sent %d events for a draft, want none until it's published", len(notifier.events))
+			}
+
+			page, err := svc.PublishWikiPage(ctx, draft.ID, tt.userID, "", tt.isAdmin)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("PublishWikiPage() error = %v, want %v", err, tt.wantErr)
+				}
+				if !pages.pages[draft.ID].IsDraft() || len(notifier.events) != 0 {
+					t.Error("page was published despite the error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PublishWikiPage() error = %v", err)
+			}
+			if page.IsDraft() || pages.pages[draft.ID].IsDraft() {
+				t.Error("page is still a draft")
+			}
+			if len(notifier.events) != 1 || notifier.events[0].Type != notify.EventWikiPageCreated {
+				t.Errorf("events = %+v, want one page created event on publishing", notifier.events)
+			}
+
+			// Publishing again changes nothing
+			if _, err := svc.PublishWikiPage(ctx, draft.ID, tt.userID, "", tt.isAdmin); err != nil || len(notifier.events) != 1 {
+				t.Errorf("second PublishWikiPage() = %v with %d events, want no error and no new event", err, len(notifier.events))
+			}
+		})
+	}
+}
+
+func TestTransferWikiPageOwnership(t *testing.T) {
+	linked := func(discordID, userID string) *entities.DiscordUser {
+		return &entities.DiscordUser{DiscordID: discordID, UserID: &userID}
+	}
+
+	tests := []struct {
+		name     string
+		userID   string
+		isAdmin  bool
+		newOwner *entities.DiscordUser
+		wantErr  error
+	}{
+		{name: "author", userID: "author", newOwner: linked("d-heir", "heir")},
+		{name: "admin", userID: "someone-else", isAdmin: true, newOwner: linked("d-heir", "heir")},
+		{name: "other user", userID: "someone-else", newOwner: linked("d-heir", "heir"), wantErr: ErrTransferForbidden},
+		{name: "new owner not linked", userID: "author", newOwner: &entities.DiscordUser{DiscordID: "d-heir"}, wantErr: ErrNewOwnerNotLinked},
+		{name: "new owner unknown", userID: "author", wantErr: ErrNewOwnerNotLinked},
+		{name: "new owner in another guild", userID: "author", newOwner: linked("d-outsider", "outsider"), wantErr: ErrNewOwnerNotMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := &fakeWikiPageRepo{
+				pages: map[string]*entities.WikiPage{
+					"w1": {ID: "w1", Title: "Rules", GuildID: "g1", AuthorID: "author"},
+				},
+				members: map[string]string{"d-heir": "g1", "d-outsider": "g2"},
+			}
+			audit := &fakeAuditRepo{}
+			svc := NewWikiService(pages, nil, nil, nil, nil, nil, audit, nil, nil)
+
+			page, err := svc.TransferWikiPageOwnership(context.Background(), "w1", tt.newOwner, tt.userID, "", tt.isAdmin)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("TransferWikiPageOwnership() error = %v, want %v", err, tt.wantErr)
+				}
+				if got := pages.pages["w1"].AuthorID; got != "author" {
+					t.Errorf("author = %q despite the error, want unchanged", got)
+				}
+				if len(audit.logs) != 0 {
+					t.Errorf("recorded %d audit entries despite the error", len(audit.logs))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("TransferWikiPageOwnership() error = %v", err)
+			}
+			if page.AuthorID != "heir" || pages.pages["w1"].AuthorID != "heir" {
+				t.Errorf("author = %q (stored %q), want heir", page.AuthorID, pages.pages["w1"].AuthorID)
+			}
+			if len(audit.logs) != 1 || audit.logs[0].Action != entities.ActionWikiPageOwnershipTransferred {
+				t.Fatalf("audit logs = %+v, want one ownership transfer", audit.logs)
+			}
+		})
+	}
+}
+
+func TestCreateWikiPage_IDs(t *testing.T) {
+	restore := idgen.SetGenerator(idgen.Sequence("page-"))
+	defer restore()
+
+	tests := []struct {
+		name    string
+		id      string
+		wantID  string
+		wantErr error
+	}{
+		{name: "generated", wantID: "page-1"},
+		{name: "preserved", id: "123456789", wantID: "123456789"},
+		{name: "preserved id in use", id: "tgt", wantErr: ErrIDInUse},
+		{name: "preserved id of deleted page", id: "gone", wantErr: ErrIDInUse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newWikiMergeFixture()
+			deletedAt := time.Now()
+			f.pages.pages["gone"] = &entities.WikiPage{ID: "gone", Title: "Gone", GuildID: "g1", DeletedAt: &deletedAt}
+
+			page, err := f.svc.CreateWikiPage(context.Background(), &entities.WikiPage{
+				ID:      tt.id,
+				Title:   "Imported",
+				Body:    "From the old wiki",
+				GuildID: "g1",
+			}, "")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CreateWikiPage() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateWikiPage() error = %v", err)
+			}
+			if page.ID != tt.wantID {
+				t.Errorf("CreateWikiPage() ID = %q, want %q", page.ID, tt.wantID)
+			}
+			if _, ok := f.pages.pages[tt.wantID]; !ok {
+				t.Errorf("page %q was not stored", tt.wantID)
+			}
+		})
+	}
+}
+
+func TestAddWikiMessageReferences(t *testing.T) {
+	f := newWikiMergeFixture()
+	sent := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ref := func(messageID, guildID string) *entities.WikiMessageReference {
+		return &entities.WikiMessageReference{
+			MessageID:        messageID,
+			ChannelID:        "thread-1",
+			GuildID:          guildID,
+			AuthorID:         "author",
+			AuthorUsername:   "author",
+			MessageTimestamp: sent,
+		}
+	}
+	noChannel := ref("m6", "g1")
+	noChannel.ChannelID = ""
+
+	result, err := f.svc.AddWikiMessageReferences(context.Background(), "tgt", []*entities.WikiMessageReference{
+		ref("m4", "g1"),       // Already referenced by the page
+		ref("m5", ""),         // Guild defaults to the page's
+		ref("m5", "g1"),       // Repeated within the batch
+		ref("", "g1"),         // Invalid: no message
+		ref("m7", "g2"),       // Invalid: another guild
+		noChannel,             // Invalid: no channel
+		ref("m-shared", "g1"), // Also already referenced by the page
+		ref("m8", "g1"),
+	}, "")
+	if err != nil {
+		t.Fatalf("AddWikiMessageReferences() error = %v", err)
+	}
+
+	if result.Added != 2 || result.Skipped != 3 {
+		t.Errorf("AddWikiMessageReferences() added %d, skipped %d; want 2 added, 3 skipped", result.Added, result.Skipped)
+	}
+	var invalid []int
+	for _, inv := range result.Invalid {
+		invalid = append(invalid, inv.Index)
+	}
+	if !reflect.DeepEqual(invalid, []int{3, 4, 5}) {
+		t.Errorf("invalid indexes = %v, want [3 4 5]", invalid)
+	}
+
+	// One bad reference mustn't cost the valid ones their place
+	for _, messageID := range []string{"m4", "m5", "m8", "m-shared"} {
+		if !f.refs.exists("tgt", messageID) {
+			t.Errorf("page has no reference to %s", messageID)
+		}
+	}
+	for _, messageID := range []string{"m6", "m7"} {
+		if f.refs.exists("tgt", messageID) {
+			t.Errorf("invalid reference to %s was added", messageID)
+		}
+	}
+	refs, _ := f.refs.GetByPageID(context.Background(), "tgt")
+	if len(refs) != 4 {
+		t.Errorf("page has %d references, want 4 with no duplicates", len(refs))
+	}
+
+	if _, err := f.svc.AddWikiMessageReferences(context.Background(), "missing", []*entities.WikiMessageReference{ref("m9", "g1")}, ""); !errors.Is(err, repositories.ErrWikiPageNotFound) {
+		t.Errorf("AddWikiMessageReferences() on a missing page error = %v, want ErrWikiPageNotFound", err)
+	}
+}
+
+// fakeReferenceLimits sets the reference limit of the listed guilds
+type fakeReferenceLimits map[string]int
+
+func (l fakeReferenceLimits) MaxReferences(ctx context.Context, guildID string) (int, error) {
+	if limit, ok := l[guildID]; ok {
+		return limit, nil
+	}
+	return DefaultMaxReferences, nil
+}
+
+func TestAddWikiMessageReference_Limit(t *testing.T) {
+	f := newWikiMergeFixture()
+	f.svc.refLimits = fakeReferenceLimits{"g1": 3}
+	ctx := context.Background()
+	ref := func(pageID, messageID string) *entities.WikiMessageReference {
+		return &entities.WikiMessageReference{
+			WikiPageID:       pageID,
+			MessageID:        messageID,
+			ChannelID:        "c1",
+			GuildID:          "g1",
+			AuthorID:         "author",
+			MessageTimestamp: time.Now(),
+		}
+	}
+
+	// tgt starts with two references, so one more reaches the limit
+	if err := f.svc.AddWikiMessageReference(ctx, ref("tgt", "m5")); err != nil {
+		t.Fatalf("AddWikiMessageReference() up to the limit error = %v", err)
+	}
+	err := f.svc.AddWikiMessageReference(ctx, ref("tgt", "m6"))
+	if !errors.Is(err, repositories.ErrReferenceLimitReached) {
+		t.Fatalf("AddWikiMessageReference() past the limit error = %v, want ErrReferenceLimitReached", err)
+	}
+	if !strings.HasSuffix(err.Error(), ": 3") {
+		t.Errorf("AddWikiMessageReference() past the limit error = %q, want it to name the limit", err)
+	}
+	// A message the page already references is still a no-op at the limit
+	if err := f.svc.AddWikiMessageReference(ctx, ref("tgt", "m4")); err != nil {
+		t.Errorf("AddWikiMessageReference() of an existing reference at the limit error = %v", err)
+	}
+
+	// src also has two; a batch of two would take it past the limit, so none are added
+	_, err = f.svc.AddWikiMessageReferences(ctx, "src", []*entities.WikiMessageReference{ref("", "m7"), ref("", "m8")}, "")
+	if !errors.Is(err, repositories.ErrReferenceLimitReached) {
+		t.Errorf("AddWikiMessageReferences() past the limit error = %v, want ErrReferenceLimitReached", err)
+	}
+	if f.refs.count("src") != 2 {
+		t.Errorf("src has %d references after a rejected batch, want 2", f.refs.count("src"))
+	}
+}
+
+// racingWikiPageRepo lets another request create rival just after the caller's title
+// lookup, so the caller's Create hits the unique title like it would in the database
+type racingWikiPageRepo struct {
+	*fakeWikiPageRepo
+	rival *entities.WikiPage
+}
+
+func (r *racingWikiPageRepo) Create(ctx context.Context, page *entities.WikiPage) error {
+	if r.rival != nil {
+		r.pages[r.rival.ID] = r.rival
+		r.rival = nil
+		return fmt.Errorf("%w: %s", repositories.ErrWikiTitleTaken, page.Title)
+	}
+	return r.fakeWikiPageRepo.Create(ctx, page)
+}
+
+func TestUpsertWikiPage_LosesCreateRace(t *testing.T) {
+	pages := &racingWikiPageRepo{
+		fakeWikiPageRepo: &fakeWikiPageRepo{pages: map[string]*entities.WikiPage{}},
+		rival:            &entities.WikiPage{ID: "rival", Title: "House Rules", Body: "First!", AuthorID: "u2", GuildID: "g1"},
+	}
+	svc := NewWikiService(pages, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	page, created, err := svc.UpsertWikiPage(context.Background(), &entities.WikiPage{
+		Title:    "house rules",
+		Body:     "Be nice",
+		AuthorID: "u1",
+		GuildID:  "g1",
+	}, "")
+	if err != nil {
+		t.Fatalf("UpsertWikiPage() error = %v", err)
+	}
+	if created {
+		t.Error("UpsertWikiPage() reported a create, want an update of the page that won the race")
+	}
+	if page.ID != "rival" || page.Body != "Be nice" || page.Title != "House Rules" || page.AuthorID != "u2" {
+		t.Errorf("UpsertWikiPage() = %+v, want the rival page with the new body and its own title and author", page)
+	}
+	if len(pages.pages) != 1 {
+		t.Errorf("repository has %d pages, want only the rival", len(pages.pages))
+	}
+}
+
+func TestUpsertWikiPage_RecordsLastEditor(t *testing.T) {
+	pages := &fakeWikiPageRepo{pages: map[string]*entities.WikiPage{
+		"p1": {ID: "p1", Title: "House Rules", Body: "Be nice", AuthorID: "u1", GuildID: "g1"},
+	}}
+	svc := NewWikiService(pages, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	page, created, err := svc.UpsertWikiPage(context.Background(), &entities.WikiPage{
+		Title:    "House Rules",
+		Body:     "Be very nice",
+		AuthorID: "u2",
+		GuildID:  "g1",
+	}, "")
+	if err != nil {
+		t.Fatalf("UpsertWikiPage() error = %v", err)
+	}
+	if created {
+		t.Fatal("UpsertWikiPage() reported a create, want an update")
+	}
+	if page.AuthorID != "u1" || page.LastEditorID != "u2" {
+		t.Errorf("UpsertWikiPage() author = %q, last editor = %q, want u1 and u2", page.AuthorID, page.LastEditorID)
+	}
+	if stored := pages.pages["p1"]; stored.AuthorID != "u1" || stored.LastEditorID != "u2" {
+		t.Errorf("stored author = %q, last editor = %q, want u1 and u2", stored.AuthorID, stored.LastEditorID)
+	}
+}
+
+func TestCreateWikiPage_TitleTaken(t *testing.T) {
+	f := newWikiMergeFixture()
+	_, err := f.svc.CreateWikiPage(context.Background(), &entities.WikiPage{Title: f.pages.pages["tgt"].Title, Body: "Again", GuildID: "g1"}, "")
+	if !errors.Is(err, repositories.ErrWikiTitleTaken) {
+		t.Errorf("CreateWikiPage() error = %v, want ErrWikiTitleTaken", err)
+	}
+}
+
+func TestSuggestTags(t *testing.T) {
+	pages := &fakeWikiPageRepo{pages: map[string]*entities.WikiPage{
+		"raids":    {ID: "raids", GuildID: "g1", Title: "Raid schedule", Body: "Weekly raid nights, bring potions and flasks.", Tags: []string{"raids", "events"}},
+		"potions":  {ID: "potions", GuildID: "g1", Title: "Alchemy", Body: "Brewing potions and flasks for raid nights.", Tags: []string{"alchemy", "raids"}},
+		"recipes":  {ID: "recipes", GuildID: "g1", Title: "Bread", Body: "Sourdough starter and baking times.", Tags: []string{"cooking"}},
+		"other":    {ID: "other", GuildID: "g2", Title: "Raids", Body: "Raid potions flasks nights.", Tags: []string{"elsewhere"}},
+		"untagged": {ID: "untagged", GuildID: "g1", Title: "Notes", Body: "Raid potions."},
+	}}
+	svc := NewWikiService(pages, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	tags := func(suggestions []TagSuggestion) []string {
+		var out []string
+		for _, s := range suggestions {
+			out = append(out, s.Tag)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name          string
+		body          string
+		excludePageID string
+		exclude       []string
+		limit         int
+		want          []string
+	}{
+		{
+			name: "shared vocabulary surfaces shared tags",
+			body: "Which potions and flasks should I bring to raid nights?",
+			want: []string{"raids", "events", "alchemy"},
+		},
+		{
+			name: "unrelated body suggests nothing from raid pages",
+			body: "My sourdough starter needs longer baking",
+			want: []string{"cooking"},
+		},
+		{
+			name:    "tags already on the content are left out",
+			body:    "Which potions and flasks should I bring to raid nights?",
+			exclude: []string{"#Raids"},
+			want:    []string{"events", "alchemy"},
+		},
+		{
+			name:          "the page being tagged is left out",
+			body:          "Brewing potions and flasks",
+			excludePageID: "potions",
+			want:          []string{"events", "raids"},
+		},
+		{
+			name:  "limited",
+			body:  "Which potions and flasks should I bring to raid nights?",
+			limit: 1,
+			want:  []string{"raids"},
+		},
+		{
+			name: "no key terms",
+			body: "it is what it is",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions, err := svc.SuggestTags(ctx, "g1", tt.body, tt.excludePageID, tt.exclude, tt.limit, "")
+			if err != nil {
+				t.Fatalf("SuggestTags() error = %v", err)
+			}
+			if got := tags(suggestions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SuggestTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+// activitySnippetLength is the number of body characters returned per activity item
+const activitySnippetLength = 200
+
+type activityRepository struct {
+	db  *sql.DB
+	log *slog.Logger
+}
+
+// NewActivityRepository creates a new PostgreSQL activity feed repository
+func NewActivityRepository(db *sql.DB) repositories.ActivityRepository {
+	return &activityRepository{
+		db:  db,
+		log: slog.Default().With(slog.String("repo", "activity")),
+	}
+}
+
+// ListRecent merges recent wiki pages, notes, and quotes into a single time-ordered feed
+func (r *activityRepository) ListRecent(ctx context.Context, guildID, userID, userDiscordID string, since time.Time, cursor *repositories.PageCursor, limit int) ([]*entities.ActivityItem, bool, *repositories.PageCursor, error) {
+	start := time.Now()
+	var err error
+	var rowCount int64
+	defer func() {
+		metrics.RecordDBOperation("activity", "list_recent", time.Since(start), rowCount, err)
+	}()
+
+	if limit <= 0 {
+		limit = 20
+	}
+
+	// $1 since, $2 snippet length, $3 the caller (note owner, and the only one who sees
+	// their draft wiki pages); optional filters follow
+	args := []interface{}{since, activitySnippetLength, userID}
+
+	wikiFilter, quoteFilter, noteFilter := "", "", ""
+	wikiACL, quoteACL := "", ""
+
+	if guildID != "" {
+		args = append(args, guildID)
+		n := len(args)
+		wikiFilter = fmt.Sprintf(" AND wp.guild_id = $%d", n)
+		noteFilter = fmt.Sprintf(" AND n.guild_id = $%d", n)
+		quoteFilter = fmt.Sprintf(" AND q.guild_id = $%d", n)
+	}
+
+	if userDiscordID != "" {
+		args = append(args, userDiscordID)
+		n := len(args)
+		wikiACL = fmt.Sprintf(`
+			INNER JOIN guild_members gm_acl ON wp.guild_id = gm_acl.guild_id AND gm_acl.discord_id = $%d`, n)
+		quoteACL = fmt.Sprintf(`
+			INNER JOIN guild_members gm_acl ON q.guild_id = gm_acl.guild_id AND gm_acl.discord_id = $%d`, n)
+	}
+
+	// Polling walks forward from since or the cursor, oldest first, so a limit cut
+	// never skips items; the first page shows the newest items
+	polling := !since.IsZero() || cursor != nil
+	order := activityOrder(!polling)
+	afterFilter := ""
+	if cursor != nil {
+		var after string
+		var afterArgs []interface{}
+		after, afterArgs, err = order.afterCondition(cursor, len(args)+1)
+		if err != nil {
+			return nil, false, nil, err
+		}
+		afterFilter = "WHERE " + after
+		args = append(args, afterArgs...)
+	}
+
+	// Fetch one extra row to tell whether there is more
+	args = append(args, limit+1)
+
+	// Rows are written with separate time.Now() calls for created_at and updated_at,
+	// so allow a small gap before treating a row as edited
+	query := fmt.Sprintf(`
+		SELECT type, id, action, guild_id, guild_name, title, slug, snippet, author_name, ts, %s AS sort_keys
+		FROM (
+			SELECT 'wiki' AS type, wp.id,
+			       CASE WHEN wp.updated_at > wp.created_at + INTERVAL '1 second' THEN 'updated' ELSE 'created' END AS action,
+			       wp.guild_id, dg.guild_name, wp.title, wt.page_slug AS slug,
+			       LEFT(wp.body, $2) AS snippet,
+			       COALESCE(udn.display_name, u.name) AS author_name,
+			       wp.updated_at AS ts
+			FROM wiki_pages wp
+			LEFT JOIN discord_guilds dg ON wp.guild_id = dg.guild_id
+			LEFT JOIN wiki_titles wt ON wp.id = wt.page_id AND wt.is_canonical = TRUE
+			LEFT JOIN users u ON wp.author_id = u.id
+			LEFT JOIN discord_users du ON u.id = du.user_id
+			LEFT JOIN user_display_names udn ON du.discord_id = udn.discord_id AND wp.guild_id = udn.guild_id%s
+			WHERE wp.deleted_at IS NULL AND (wp.status = 'published' OR wp.author_id = $3) AND wp.updated_at > $1%s
+
+			UNION ALL
+
+			SELECT 'note' AS type, n.id,
+			       CASE WHEN n.updated_at > n.created_at + INTERVAL '1 second' THEN 'updated' ELSE 'created' END AS action,
+			       n.guild_id, dg.guild_name, n.title, NULL AS slug,
+			       LEFT(n.body, $2) AS snippet,
+			       u.name AS author_name,
+			       n.updated_at AS ts
+			FROM notes n
+			LEFT JOIN discord_guilds dg ON n.guild_id = dg.guild_id
+			LEFT JOIN users u ON n.author_id = u.id
+			WHERE n.deleted_at IS NULL AND n.author_id = $3 AND n.updated_at > $1%s
+
+			UNION ALL
+
+			SELECT 'quote' AS type, q.id,
+			       CASE WHEN q.updated_at > q.created_at + INTERVAL '1 second' THEN 'updated' ELSE 'created' END AS action,
+			       q.guild_id, dg.guild_name, NULL AS title, NULL AS slug,
+			       LEFT(q.body, $2) AS snippet,
+			       COALESCE(q.source_msg_author_override, udn_source.display_name, q.source_msg_author_username) AS author_name,
+			       COALESCE(q.updated_at, q.created_at) AS ts
+			FROM quotes q
+			LEFT JOIN discord_guilds dg ON q.guild_id = dg.guild_id
+			LEFT JOIN user_display_names udn_source ON q.source_msg_author_discord_id = udn_source.discord_id AND q.guild_id = udn_source.guild_id%s
+			WHERE q.deleted_at IS NULL AND COALESCE(q.updated_at, q.created_at) > $1%s
+		) activity
+		%s
+		ORDER BY %s
+		LIMIT $%d
+	`, order.keysExpr(), wikiACL, wikiFilter, noteFilter, quoteACL, quoteFilter, afterFilter, order.orderClause(), len(args))
+
+	r.log.Debug("executing activity query",
+		slog.String("guild_id", guildID),
+		slog.Time("since", since),
+		slog.Bool("polling", polling),
+		slog.Int("limit", limit))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	defer rows.Close()
+
+	items := []*entities.ActivityItem{}
+	var rowKeys []pq.StringArray
+	for rows.Next() {
+		item := &entities.ActivityItem{}
+		var guildIDCol, guildName, title, slug, snippet, authorName sql.NullString
+		var sortKeys pq.StringArray
+
+		if err = rows.Scan(
+			&item.Type, &item.ID, &item.Action,
+			&guildIDCol, &guildName, &title, &slug, &snippet, &authorName,
+			&item.Timestamp, &sortKeys,
+		); err != nil {
+			return nil, false, nil, err
+		}
+
+		item.GuildID = guildIDCol.String
+		item.GuildName = guildName.String
+		item.Title = title.String
+		item.Slug = slug.String
+		item.Snippet = snippet.String
+		item.AuthorName = authorName.String
+		items = append(items, item)
+		rowKeys = append(rowKeys, sortKeys)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, false, nil, err
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	// The next poll continues after the newest item returned: the first row of the
+	// newest-first page, or the last row when polling
+	next := cursor
+	if len(items) > 0 {
+		newest := rowKeys[0]
+		if polling {
+			newest = rowKeys[len(items)-1]
+		}
+		next = activityOrder(false).cursorAt(newest)
+	}
+
+	rowCount = int64(len(items))
+	return items, hasMore, next, nil
+}
+
+// activityOrder orders the feed by time, with the item ID breaking ties so cursors have
+// a unique position. Both directions share a cursor name: a cursor taken from the
+// newest-first page is used to poll forward from there.
+func activityOrder(newestFirst bool) keysetOrder {
+	return keysetOrder{
+		name: "activity",
+		columns: []keysetColumn{
+			{expr: "ts", cast: "timestamp", desc: newestFirst},
+			{expr: "id", cast: "text", desc: newestFirst},
+		},
+	}
+}
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+)
+
+// openActivityTestDB connects to HIVEMIND_TEST_DATABASE_URL and creates temporary tables
+// that shadow everything the activity feed reads. Tests using it need a real PostgreSQL
+// server and are skipped when the variable isn't set.
+func openActivityTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	fixture := `
+		CREATE TEMP TABLE wiki_pages (
+			id TEXT PRIMARY KEY, title TEXT, body TEXT NOT NULL, author_id TEXT NOT NULL, guild_id TEXT NOT NULL,
+			status TEXT NOT NULL DEFAULT 'published', created_at TIMESTAMP, updated_at TIMESTAMP, deleted_at TIMESTAMP
+		);
+		CREATE TEMP TABLE wiki_titles (page_id TEXT NOT NULL, page_slug TEXT NOT NULL, is_canonical BOOLEAN NOT NULL DEFAULT TRUE);
+		CREATE TEMP TABLE notes (
+			id TEXT PRIMARY KEY, title TEXT, body TEXT NOT NULL, author_id TEXT NOT NULL, guild_id TEXT,
+			created_at TIMESTAMP, updated_at TIMESTAMP, deleted_at TIMESTAMP
+		);
+		CREATE TEMP TABLE quotes (
+			id TEXT PRIMARY KEY, body TEXT NOT NULL, guild_id TEXT NOT NULL, source_msg_author_discord_id TEXT,
+			source_msg_author_username TEXT, created_at TIMESTAMP, updated_at TIMESTAMP, deleted_at TIMESTAMP
+		);
+		CREATE TEMP TABLE discord_guilds (guild_id TEXT PRIMARY KEY, guild_name TEXT);
+		CREATE TEMP TABLE guild_members (guild_id TEXT NOT NULL, discord_id TEXT NOT NULL);
+		CREATE TEMP TABLE users (id TEXT PRIMARY KEY, name TEXT);
+		CREATE TEMP TABLE discord_users (user_id TEXT, discord_id TEXT PRIMARY KEY);
+		CREATE TEMP TABLE user_display_names (discord_id TEXT, guild_id TEXT, display_name TEXT);
+		INSERT INTO discord_guilds VALUES ('g1', 'Guild One');
+		INSERT INTO users VALUES ('u-author', 'Author'), ('u-other', 'Other');
+		INSERT INTO discord_users VALUES ('u-author', 'd-author'), ('u-other', 'd-other');
+		INSERT INTO guild_members VALUES ('g1', 'd-author'), ('g1', 'd-other')`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+	return db
+}
+
+func TestActivityListRecentHidesOthersDrafts(t *testing.T) {
+	db := openActivityTestDB(t)
+	now := time.Now().UTC()
+	if _, err := db.Exec(`
+		INSERT INTO wiki_pages (id, title, body, author_id, guild_id, status, created_at, updated_at) VALUES
+			('p1', 'House Rules', 'Be nice', 'u-author', 'g1', 'published', $1, $1),
+			('p2', 'Raid Rules', 'Work in progress', 'u-author', 'g1', 'draft', $1, $1)`, now); err != nil {
+		t.Fatalf("failed to insert pages: %v", err)
+	}
+
+	repo := NewActivityRepository(db)
+	for _, tt := range []struct {
+		name          string
+		userID        string
+		userDiscordID string
+		want          string
+	}{
+		{name: "another member", userID: "u-other", userDiscordID: "d-other", want: "p1"},
+		{name: "the author", userID: "u-author", userDiscordID: "d-author", want: "p1 p2"},
+		{name: "admin", userID: "u-admin", want: "p1"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			items, _, _, err := repo.ListRecent(context.Background(), "g1", tt.userID, tt.userDiscordID, now.Add(-time.Hour), nil, 10)
+			if err != nil {
+				t.Fatalf("ListRecent() error = %v", err)
+			}
+			var ids []string
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
+			if got := strings.Join(ids, " "); got != tt.want {
+				t.Errorf("ListRecent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Polling with the returned cursor must reach every item exactly once, even when a page
+// boundary falls between items with the same timestamp
+func TestActivityListRecentPollsWithoutSkipping(t *testing.T) {
+	db := openActivityTestDB(t)
+	base := time.Date(2024, 1, 6, 12, 0, 0, 123456000, time.UTC)
+	if _, err := db.Exec(`
+		INSERT INTO wiki_pages (id, title, body, author_id, guild_id, created_at, updated_at) VALUES
+			('p1', 'House Rules', 'Be nice', 'u-author', 'g1', $1, $1),
+			('p2', 'Raid Rules', 'Bring potions', 'u-author', 'g1', $1, $1);
+		INSERT INTO notes (id, title, body, author_id, guild_id, created_at, updated_at) VALUES
+			('n1', 'Todo', 'Water plants', 'u-author', 'g1', $1, $1);
+		INSERT INTO quotes (id, body, guild_id, source_msg_author_username, created_at, updated_at) VALUES
+			('q1', 'First!', 'g1', 'ada', $1, $1),
+			('q2', 'Later', 'g1', 'ada', $2, $2)`, base, base.Add(time.Minute)); err != nil {
+		t.Fatalf("failed to insert content: %v", err)
+	}
+
+	repo := NewActivityRepository(db)
+	ctx := context.Background()
+
+	// The first page is newest first, and its cursor points at the newest item
+	items, hasMore, cursor, err := repo.ListRecent(ctx, "", "u-author", "d-author", time.Time{}, nil, 2)
+	if err != nil {
+		t.Fatalf("ListRecent() error = %v", err)
+	}
+	if got := activityIDs(items); got != "q2 q1" || !hasMore {
+		t.Errorf("first page = %q, has more = %v, want \"q2 q1\" with more", got, hasMore)
+	}
+	if cursor == nil || cursor.ID != "q2" {
+		t.Fatalf("first page cursor = %+v, want one at q2", cursor)
+	}
+
+	// Polling from the start of the day walks forward two at a time
+	var seen []string
+	since := base.Add(-time.Hour)
+	cursor = nil
+	for page := 0; page < 5; page++ {
+		items, hasMore, cursor, err = repo.ListRecent(ctx, "", "u-author", "d-author", since, cursor, 2)
+		if err != nil {
+			t.Fatalf("ListRecent() page %d error = %v", page, err)
+		}
+		if ids := activityIDs(items); ids != "" {
+			seen = append(seen, ids)
+		}
+		if !hasMore {
+			break
+		}
+	}
+	if got := strings.Join(seen, " "); got != "n1 p1 p2 q1 q2" {
+		t.Errorf("polled items = %q, want each once in (time, id) order", got)
+	}
+
+	// Nothing newer leaves the cursor where it was
+	items, _, next, err := repo.ListRecent(ctx, "", "u-author", "d-author", since, cursor, 2)
+	if err != nil {
+		t.Fatalf("ListRecent() error = %v", err)
+	}
+	if len(items) != 0 || next == nil || next.ID != "q2" {
+		t.Errorf("poll at the end = %q with cursor %+v, want nothing and the cursor kept at q2", activityIDs(items), next)
+	}
+}
+
+func TestActivityListRecentReportsEditedQuotes(t *testing.T) {
+	db := openActivityTestDB(t)
+	created := time.Now().UTC().Add(-time.Hour)
+	if _, err := db.Exec(`
+		INSERT INTO quotes (id, body, guild_id, source_msg_author_username, created_at, updated_at) VALUES
+			('q1', 'Fresh', 'g1', 'ada', $1, $1),
+			('q2', 'Edited', 'g1', 'ada', $1, $2)`, created, created.Add(time.Minute)); err != nil {
+		t.Fatalf("failed to insert quotes: %v", err)
+	}
+
+	items, _, _, err := NewActivityRepository(db).ListRecent(context.Background(), "g1", "u-other", "d-other", time.Time{}, nil, 10)
+	if err != nil {
+		t.Fatalf("ListRecent() error = %v", err)
+	}
+	actions := map[string]string{}
+	for _, item := range items {
+		actions[item.ID] = item.Action
+	}
+	if actions["q1"] != "created" || actions["q2"] != "updated" {
+		t.Errorf("quote actions = %v, want q1 created and q2 updated", actions)
+	}
+}
+
+func activityIDs(items []*entities.ActivityItem) string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return strings.Join(ids, " ")
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+type deadLetterRepository struct {
+	db  *sql.DB
+	log *slog.Logger
+}
+
+// NewDeadLetterRepository creates a new PostgreSQL repository for dropped Discord calls
+func NewDeadLetterRepository(db *sql.DB) repositories.DeadLetterRepository {
+	return &deadLetterRepository{
+		db:  db,
+		log: slog.Default().With(slog.String("repo", "dead_letter")),
+	}
+}
+
+func (r *deadLetterRepository) Create(ctx context.Context, deadLetter *entities.DeadLetter) error {
+	start := time.Now()
+	var err error
+	var rowsAffected int64
+	defer func() {
+		metrics.RecordDBOperation("dead_letter", "create", time.Since(start), rowsAffected, err)
+	}()
+
+	r.log.Debug("recording dead letter",
+		slog.String("id", deadLetter.ID),
+		slog.String("operation", deadLetter.Operation),
+		slog.String("failure_type", deadLetter.FailureType))
+
+	query := `
+		INSERT INTO discord_dead_letters (
+			id, operation, guild_id, channel_id, failure_type, error, attempts, payload, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		deadLetter.ID,
+		deadLetter.Operation,
+		deadLetter.GuildID,
+		deadLetter.ChannelID,
+		deadLetter.FailureType,
+		deadLetter.Error,
+		deadLetter.Attempts,
+		deadLetter.Payload,
+		deadLetter.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err = result.RowsAffected()
+	return err
+}
+
+func (r *deadLetterRepository) List(ctx context.Context, guildID string, limit, offset int) ([]*entities.DeadLetter, int, error) {
+	start := time.Now()
+	var err error
+	var rowCount int64
+	defer func() {
+		metrics.RecordDBOperation("dead_letter", "list", time.Since(start), rowCount, err)
+	}()
+
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := `
+		SELECT id, operation, guild_id, channel_id, failure_type, error, attempts, payload, created_at, COUNT(*) OVER ()
+		FROM discord_dead_letters
+		WHERE $1 = '' OR guild_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, guildID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var deadLetters []*entities.DeadLetter
+	total := 0
+	for rows.Next() {
+		deadLetter := &entities.DeadLetter{}
+		if err = rows.Scan(
+			&deadLetter.ID,
+			&deadLetter.Operation,
+			&deadLetter.GuildID,
+			&deadLetter.ChannelID,
+			&deadLetter.FailureType,
+			&deadLetter.Error,
+			&deadLetter.Attempts,
+			&deadLetter.Payload,
+			&deadLetter.CreatedAt,
+			&total,
+		); err != nil {
+			return nil, 0, err
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	rowCount = int64(len(deadLetters))
+
+	return deadLetters, total, nil
+}
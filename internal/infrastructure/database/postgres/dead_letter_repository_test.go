@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+)
+
+// TestDeadLetters records and lists dropped Discord calls in a temporary table that
+// shadows the real one. It needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestDeadLetters(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	schema := `
+		CREATE TEMP TABLE discord_dead_letters (id TEXT PRIMARY KEY, operation TEXT NOT NULL, guild_id TEXT, channel_id TEXT, failure_type TEXT NOT NULL, error TEXT NOT NULL, attempts INT NOT NULL, payload TEXT NOT NULL DEFAULT '', created_at TIMESTAMP NOT NULL)`
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	repo := NewDeadLetterRepository(db)
+	base := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	guild := "g1"
+	for i, d := range []*entities.DeadLetter{
+		{ID: "d1", Operation: "wiki_announcement", GuildID: &guild, FailureType: "forbidden", Error: "HTTP 403", Attempts: 1},
+		{ID: "d2", Operation: "duplicate_quote_reply", FailureType: "rate_limited", Error: "rate limited", Attempts: 3},
+		{ID: "d3", Operation: "quote_post", GuildID: &guild, FailureType: "server_error", Error: "HTTP 502", Attempts: 3, Payload: "Quote"},
+	} {
+		d.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := repo.Create(ctx, d); err != nil {
+			t.Fatalf("Create(%s) error = %v", d.ID, err)
+		}
+	}
+
+	ids := func(deadLetters []*entities.DeadLetter) []string {
+		var ids []string
+		for _, d := range deadLetters {
+			ids = append(ids, d.ID)
+		}
+		return ids
+	}
+
+	all, total, err := repo.List(ctx, "", 2, 0)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if got := ids(all); total != 3 || len(got) != 2 || got[0] != "d3" || got[1] != "d2" {
+		t.Errorf("List() = %v of %d, want [d3 d2] of 3", got, total)
+	}
+	if all[1].GuildID != nil || all[0].Payload != "Quote" || all[0].Attempts != 3 {
+		t.Errorf("List() returned %+v, %+v with fields not round-tripped", all[0], all[1])
+	}
+
+	guildOnly, total, err := repo.List(ctx, "g1", 10, 0)
+	if err != nil {
+		t.Fatalf("List(g1) error = %v", err)
+	}
+	if got := ids(guildOnly); total != 2 || len(got) != 2 || got[0] != "d3" || got[1] != "d1" {
+		t.Errorf("List(g1) = %v of %d, want [d3 d1] of 2", got, total)
+	}
+}
@@ -69,7 +69,7 @@ func (r *DiscordGuildRepository) GetByID(ctx context.Context, guildID string) (*
 
 	query := `
 		SELECT guild_id, guild_name, icon_url, owner_discord_id,
-		       enabled, settings, added_at, last_activity
+		       enabled, settings, added_at, last_activity, last_member_sync
 		FROM discord_guilds
 		WHERE guild_id = $1
 	`
@@ -201,7 +201,7 @@ func (r *DiscordGuildRepository) List(ctx context.Context, enabledOnly bool) ([]
 	}()
 	query := `
 		SELECT guild_id, guild_name, icon_url, owner_discord_id,
-		       enabled, settings, added_at, last_activity
+		       enabled, settings, added_at, last_activity, last_member_sync
 		FROM discord_guilds
 	`
 
@@ -241,8 +241,11 @@ func (r *DiscordGuildRepository) Delete(ctx context.Context, guildID string) err
 	return nil
 }
 
-// UpdateSettings updates the settings JSONB for a guild
-func (r *DiscordGuildRepository) UpdateSettings(ctx context.Context, guildID string, settings map[string]interface{}) error {
+// UpdateSettings merges patch into the settings JSONB for a guild and increments its
+// revision, stored under repositories.GuildSettingsRevisionKey. The merge happens in
+// a single UPDATE, so concurrent updates to different sections can't overwrite each
+// other: the row lock makes the second one merge into the first one's result.
+func (r *DiscordGuildRepository) UpdateSettings(ctx context.Context, guildID string, patch map[string]interface{}, expectedRevision int64) (map[string]interface{}, error) {
 	start := time.Now()
 	var err error
 	var rowsAffected int64
@@ -250,35 +253,49 @@ func (r *DiscordGuildRepository) UpdateSettings(ctx context.Context, guildID str
 		metrics.RecordDBOperation("discord_guild", "update_settings", time.Since(start), rowsAffected, err)
 	}()
 
-	settingsJSON, err := json.Marshal(settings)
+	patchJSON, err := json.Marshal(patch)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	query := `
 		UPDATE discord_guilds
-		SET settings = $1, last_activity = CURRENT_TIMESTAMP
+		SET settings = COALESCE(settings, '{}'::jsonb) || $1::jsonb
+				|| jsonb_build_object('revision', COALESCE((settings->>'revision')::bigint, 0) + 1),
+			last_activity = CURRENT_TIMESTAMP
 		WHERE guild_id = $2
+			AND ($3::bigint = 0 OR COALESCE((settings->>'revision')::bigint, 0) = $3)
+		RETURNING settings
 	`
 
-	result, err := r.db.ExecContext(ctx, query, settingsJSON, guildID)
-	if err != nil {
-		return err
+	var settingsJSON []byte
+	err = r.db.QueryRowContext(ctx, query, patchJSON, guildID, expectedRevision).Scan(&settingsJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Either the guild doesn't exist or its settings moved past expectedRevision
+		var exists bool
+		if err = r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM discord_guilds WHERE guild_id = $1)`, guildID).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, repositories.ErrDiscordGuildNotFound
+		}
+		return nil, repositories.ErrStaleGuildSettings
 	}
-
-	rowsAffected, err = result.RowsAffected()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	rowsAffected = 1
 
-	if rowsAffected == 0 {
-		return repositories.ErrDiscordGuildNotFound
+	var settings map[string]interface{}
+	if err = json.Unmarshal(settingsJSON, &settings); err != nil {
+		return nil, err
 	}
 
 	r.log.Debug("updated guild settings",
-		slog.String("guild_id", guildID))
+		slog.String("guild_id", guildID),
+		slog.Any("revision", settings[repositories.GuildSettingsRevisionKey]))
 
-	return nil
+	return settings, nil
 }
 
 // GetSettings retrieves the settings JSONB for a guild
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// TestUpdateSettingsConcurrentPatches needs a real PostgreSQL server and is skipped
+// unless HIVEMIND_TEST_DATABASE_URL is set.
+func TestUpdateSettingsConcurrentPatches(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	fixture := `
+		CREATE TEMP TABLE discord_guilds (
+			guild_id TEXT PRIMARY KEY, settings JSONB DEFAULT '{}'::jsonb, last_activity TIMESTAMP
+		);
+		INSERT INTO discord_guilds (guild_id, settings) VALUES ('g1', '{"version": 1, "notes": {"unique_titles": true}}')`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	repo := NewDiscordGuildRepository(sqlx.NewDb(db, "postgres"))
+	ctx := context.Background()
+
+	// Two admins change different sections, both starting from the same read
+	patches := []map[string]interface{}{
+		{"features": map[string]interface{}{"quotes_enabled": false}},
+		{"posting": map[string]interface{}{"reply_to_source": true}},
+	}
+	errs := make([]error, len(patches))
+	var wg sync.WaitGroup
+	for i, patch := range patches {
+		wg.Add(1)
+		go func(i int, patch map[string]interface{}) {
+			defer wg.Done()
+			_, errs[i] = repo.UpdateSettings(ctx, "g1", patch, 0)
+		}(i, patch)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("UpdateSettings() error = %v", err)
+		}
+	}
+
+	settings, err := repo.GetSettings(ctx, "g1")
+	if err != nil {
+		t.Fatalf("GetSettings() error = %v", err)
+	}
+	for _, section := range []string{"notes", "features", "posting"} {
+		if _, ok := settings[section]; !ok {
+			t.Errorf("settings lost the %q section: %v", section, settings)
+		}
+	}
+	if got := settings[repositories.GuildSettingsRevisionKey]; got != float64(2) {
+		t.Errorf("revision = %v, want 2", got)
+	}
+
+	// An update expecting the revision before those two is stale
+	_, err = repo.UpdateSettings(ctx, "g1", map[string]interface{}{"quotes": map[string]interface{}{"cooldown_seconds": 30}}, 1)
+	if !errors.Is(err, repositories.ErrStaleGuildSettings) {
+		t.Errorf("UpdateSettings() with a stale revision error = %v, want %v", err, repositories.ErrStaleGuildSettings)
+	}
+	if _, err := repo.UpdateSettings(ctx, "g1", map[string]interface{}{"quotes": map[string]interface{}{"cooldown_seconds": 30}}, 2); err != nil {
+		t.Errorf("UpdateSettings() with the current revision error = %v", err)
+	}
+	if _, err := repo.UpdateSettings(ctx, "missing", map[string]interface{}{}, 0); !errors.Is(err, repositories.ErrDiscordGuildNotFound) {
+		t.Errorf("UpdateSettings() for a missing guild error = %v, want %v", err, repositories.ErrDiscordGuildNotFound)
+	}
+}
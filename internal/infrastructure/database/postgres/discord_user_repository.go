@@ -195,6 +195,35 @@ func (r *DiscordUserRepository) UpdateLastSeen(ctx context.Context, discordID st
 	return err
 }
 
+// ListActive retrieves Discord users seen since the given time, paginated by Discord ID
+func (r *DiscordUserRepository) ListActive(ctx context.Context, seenSince time.Time, afterDiscordID string, limit int) ([]*entities.DiscordUser, error) {
+	start := time.Now()
+	var err error
+	var rowCount int64
+	defer func() {
+		metrics.RecordDBOperation("discord_user", "list_active", time.Since(start), rowCount, err)
+	}()
+
+	query := `
+		SELECT discord_id, user_id, discord_username, discord_global_name,
+		       avatar_hash, linked_at, last_seen
+		FROM discord_users
+		WHERE last_seen >= $1
+		  AND discord_id > $2
+		ORDER BY discord_id
+		LIMIT $3
+	`
+
+	var users []*entities.DiscordUser
+	err = r.db.SelectContext(ctx, &users, query, seenSince, afterDiscordID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	rowCount = int64(len(users))
+	return users, nil
+}
+
 // Delete removes a Discord user record (unlinking)
 func (r *DiscordUserRepository) Delete(ctx context.Context, discordID string) error {
 	query := `DELETE FROM discord_users WHERE discord_id = $1`
@@ -0,0 +1,342 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+// guildMoveStep changes guild_id on the rows of one table selected by where, which
+// may only use $1 (the source guild). Steps for child tables select rows through
+// their parent, so they must run before the parent's own step.
+type guildMoveStep struct {
+	table string
+	where string
+	count func(move *entities.GuildContentMove, n int)
+}
+
+// guildMoveSteps lists, per content type, every table that carries a guild_id, children first
+var guildMoveSteps = map[string][]guildMoveStep{
+	entities.ContentTypeWiki: {
+		{
+			table: "wiki_titles",
+			where: "guild_id = $1",
+			count: func(m *entities.GuildContentMove, n int) { m.WikiTitles = n },
+		},
+		{
+			table: "wiki_message_references",
+			where: "wiki_page_id IN (SELECT id FROM wiki_pages WHERE guild_id = $1)",
+			count: func(m *entities.GuildContentMove, n int) { m.WikiReferences = n },
+		},
+		{
+			table: "wiki_merge_log",
+			where: "guild_id = $1",
+		},
+		{
+			table: "wiki_pages",
+			where: "guild_id = $1",
+			count: func(m *entities.GuildContentMove, n int) { m.WikiPages = n },
+		},
+	},
+	entities.ContentTypeNote: {
+		{
+			table: "note_message_references",
+			where: "note_id IN (SELECT id FROM notes WHERE guild_id = $1)",
+			count: func(m *entities.GuildContentMove, n int) { m.NoteReferences = n },
+		},
+		{
+			table: "notes",
+			where: "guild_id = $1",
+			count: func(m *entities.GuildContentMove, n int) { m.Notes = n },
+		},
+	},
+	entities.ContentTypeQuote: {
+		{
+			table: "quotes",
+			where: "guild_id = $1",
+			count: func(m *entities.GuildContentMove, n int) { m.Quotes = n },
+		},
+	},
+}
+
+// guildMoveConflict finds values of one kind of content that are unique per guild and
+// used in both the source ($1) and target ($2) guild, so moving would break a unique index
+type guildMoveConflict struct {
+	query string
+	set   func(move *entities.GuildContentMove, values []string)
+}
+
+// guildMoveConflicts lists, per content type, the unique-per-guild values a move can't carry over
+var guildMoveConflicts = map[string][]guildMoveConflict{
+	entities.ContentTypeWiki: {
+		{
+			query: `
+				SELECT s.page_slug
+				FROM wiki_titles s
+				JOIN wiki_titles t ON t.guild_id = $2 AND t.page_slug = s.page_slug
+				WHERE s.guild_id = $1
+				ORDER BY s.page_slug`,
+			set: func(m *entities.GuildContentMove, v []string) { m.ConflictingTitles = v },
+		},
+	},
+	entities.ContentTypeNote: {
+		{
+			// Matches idx_notes_scope_slug, which only covers notes that aren't deleted
+			query: `
+				SELECT s.slug
+				FROM notes s
+				JOIN notes t ON t.guild_id = $2 AND t.slug = s.slug AND t.deleted_at IS NULL
+				WHERE s.guild_id = $1 AND s.deleted_at IS NULL
+				ORDER BY s.slug`,
+			set: func(m *entities.GuildContentMove, v []string) { m.ConflictingNoteSlugs = v },
+		},
+		{
+			// Matches idx_notes_unique_title: an author's title, saved while the guild
+			// required unique titles, that they also used in the target guild
+			query: `
+				SELECT DISTINCT s.unique_title
+				FROM notes s
+				JOIN notes t ON t.guild_id = $2 AND t.author_id = s.author_id AND t.unique_title = s.unique_title AND t.deleted_at IS NULL
+				WHERE s.guild_id = $1 AND s.deleted_at IS NULL AND s.unique_title IS NOT NULL
+				ORDER BY s.unique_title`,
+			set: func(m *entities.GuildContentMove, v []string) { m.ConflictingNoteTitles = v },
+		},
+	},
+	entities.ContentTypeQuote: {
+		{
+			query: `
+				SELECT s.short_code
+				FROM quotes s
+				JOIN quotes t ON t.guild_id = $2 AND t.short_code = s.short_code
+				WHERE s.guild_id = $1
+				ORDER BY s.short_code`,
+			set: func(m *entities.GuildContentMove, v []string) { m.ConflictingQuoteCodes = v },
+		},
+	},
+}
+
+// authorReassignment is how to change the author of one kind of content. set adds to
+// the SET clause to keep columns derived from the author in step; it may use $1 (the
+// content ID), $2 (the new author's user ID), and, when discordID is set, $3 (the new
+// author's Discord ID).
+type authorReassignment struct {
+	table     string
+	set       string
+	discordID bool
+}
+
+// authorReassignments lists, per kind of content, how to change its author
+var authorReassignments = map[entities.AuditResource]authorReassignment{
+	entities.ResourceWikiPage: {table: "wiki_pages"},
+	entities.ResourceNote: {
+		table: "notes",
+		// A personal note's slug is unique among its author's notes; on a clash with
+		// one of the new author's, append the note ID as the slug backfill does
+		set: `slug = CASE WHEN guild_id IS NULL AND EXISTS (
+				SELECT 1 FROM notes other
+				WHERE other.guild_id IS NULL AND other.author_id = $2 AND other.slug = notes.slug
+				  AND other.deleted_at IS NULL AND other.id <> notes.id
+			) THEN slug || '-' || id ELSE slug END`,
+	},
+	entities.ResourceQuote: {
+		// Quote author names are looked up by author_discord_id, so the new author needs one
+		table:     "quotes",
+		set:       "author_discord_id = $3",
+		discordID: true,
+	},
+}
+
+type guildContentRepository struct {
+	db  *sql.DB
+	log *slog.Logger
+}
+
+// NewGuildContentRepository creates a new PostgreSQL guild content repository
+func NewGuildContentRepository(db *sql.DB) repositories.GuildContentRepository {
+	return &guildContentRepository{
+		db:  db,
+		log: slog.Default().With(slog.String("repo", "guild_content")),
+	}
+}
+
+func (r *guildContentRepository) MoveContent(ctx context.Context, sourceGuildID, targetGuildID string, contentTypes []string, dryRun bool) (*entities.GuildContentMove, error) {
+	start := time.Now()
+	var err error
+	var rowsAffected int64
+	defer func() {
+		metrics.RecordDBOperation("guild_content", "move", time.Since(start), rowsAffected, err)
+	}()
+
+	move := &entities.GuildContentMove{
+		SourceGuildID: sourceGuildID,
+		TargetGuildID: targetGuildID,
+		ContentTypes:  contentTypes,
+		DryRun:        dryRun,
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Check every content type before moving any, so a dry run reports all conflicts
+	for _, contentType := range contentTypes {
+		for _, conflict := range guildMoveConflicts[contentType] {
+			var values []string
+			values, err = guildMoveConflictValues(ctx, tx, conflict, sourceGuildID, targetGuildID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check %s conflicts: %w", contentType, err)
+			}
+			conflict.set(move, values)
+		}
+	}
+	if move.HasConflicts() && !dryRun {
+		err = repositories.ErrGuildMoveConflict
+		return move, err
+	}
+
+	for _, contentType := range contentTypes {
+		for _, step := range guildMoveSteps[contentType] {
+			var n int
+			n, err = runGuildMoveStep(ctx, tx, step, sourceGuildID, targetGuildID, dryRun)
+			if err != nil {
+				return nil, fmt.Errorf("failed to move %s: %w", step.table, err)
+			}
+			rowsAffected += int64(n)
+			if step.count != nil {
+				step.count(move, n)
+			}
+		}
+	}
+
+	if dryRun {
+		rowsAffected = 0
+		return move, nil
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	r.log.Info("moved guild content",
+		slog.String("source_guild_id", sourceGuildID),
+		slog.String("target_guild_id", targetGuildID),
+		slog.Any("content_types", contentTypes),
+		slog.Int64("rows", rowsAffected))
+
+	return move, nil
+}
+
+// runGuildMoveStep moves one table's rows, or only counts them in a dry run
+func runGuildMoveStep(ctx context.Context, tx *sql.Tx, step guildMoveStep, sourceGuildID, targetGuildID string, dryRun bool) (int, error) {
+	if dryRun {
+		var n int
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", step.table, step.where)
+		err := tx.QueryRowContext(ctx, query, sourceGuildID).Scan(&n)
+		return n, err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET guild_id = $2 WHERE %s", step.table, step.where)
+	result, err := tx.ExecContext(ctx, query, sourceGuildID, targetGuildID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// guildMoveConflictValues returns the values conflict finds in both guilds
+func guildMoveConflictValues(ctx context.Context, tx *sql.Tx, conflict guildMoveConflict, sourceGuildID, targetGuildID string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, conflict.query, sourceGuildID, targetGuildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+func (r *guildContentRepository) ReassignAuthor(ctx context.Context, contentType entities.AuditResource, id, authorID string) (*entities.AuthorReassignment, error) {
+	start := time.Now()
+	var err error
+	var rowsAffected int64
+	defer func() {
+		metrics.RecordDBOperation("guild_content", "reassign_author", time.Since(start), rowsAffected, err)
+	}()
+
+	step, ok := authorReassignments[contentType]
+	if !ok {
+		err = fmt.Errorf("unknown content type %q", contentType)
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	reassignment := &entities.AuthorReassignment{ContentType: contentType, ID: id, AuthorID: authorID}
+	query := fmt.Sprintf("SELECT author_id, COALESCE(guild_id, '') FROM %s WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", step.table)
+	err = tx.QueryRowContext(ctx, query, id).Scan(&reassignment.PreviousAuthorID, &reassignment.GuildID)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("%w: %s %s", repositories.ErrContentNotFound, contentType, id)
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	args := []any{id, authorID}
+	if step.discordID {
+		var discordID string
+		err = tx.QueryRowContext(ctx, "SELECT discord_id FROM discord_users WHERE user_id = $1 ORDER BY linked_at DESC LIMIT 1", authorID).Scan(&discordID)
+		if err == sql.ErrNoRows {
+			err = fmt.Errorf("%w: user %s has no linked Discord account", repositories.ErrDiscordUserNotFound, authorID)
+			return nil, err
+		}
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, discordID)
+	}
+
+	set := "author_id = $2"
+	if step.set != "" {
+		set += ", " + step.set
+	}
+	result, err := tx.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET %s WHERE id = $1", step.table, set), args...)
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected, err = result.RowsAffected(); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	r.log.Info("reassigned content author",
+		slog.String("content_type", string(contentType)),
+		slog.String("id", id),
+		slog.String("previous_author_id", reassignment.PreviousAuthorID),
+		slog.String("author_id", authorID))
+
+	return reassignment, nil
+}
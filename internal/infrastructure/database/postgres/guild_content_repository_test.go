@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+func TestGuildMoveStepsChildrenFirst(t *testing.T) {
+	parents := map[string]string{
+		"wiki_message_references": "wiki_pages",
+		"note_message_references": "notes",
+	}
+
+	for contentType, steps := range guildMoveSteps {
+		position := make(map[string]int, len(steps))
+		for i, step := range steps {
+			position[step.table] = i
+		}
+		for child, parent := range parents {
+			ci, hasChild := position[child]
+			if !hasChild {
+				continue
+			}
+			pi, hasParent := position[parent]
+			if !hasParent || ci > pi {
+				t.Errorf("%s: %s must be moved before %s", contentType, child, parent)
+			}
+			if !strings.Contains(steps[ci].where, "FROM "+parent) {
+				t.Errorf("%s: %s rows must be selected through %s, got %q", contentType, child, parent, steps[ci].where)
+			}
+		}
+	}
+}
+
+// TestMoveContent runs a guild move against temporary tables that shadow the real
+// schema. It needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestMoveContent(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	schema := `
+		CREATE TEMP TABLE wiki_pages (id TEXT PRIMARY KEY, guild_id TEXT NOT NULL);
+		CREATE TEMP TABLE wiki_titles (page_slug TEXT NOT NULL, guild_id TEXT NOT NULL, UNIQUE (guild_id, page_slug));
+		CREATE TEMP TABLE wiki_message_references (wiki_page_id TEXT NOT NULL, guild_id TEXT NOT NULL);
+		CREATE TEMP TABLE wiki_merge_log (guild_id TEXT NOT NULL);
+		CREATE TEMP TABLE notes (id TEXT PRIMARY KEY, guild_id TEXT, slug TEXT, deleted_at TIMESTAMP, author_id TEXT, unique_title TEXT);
+		CREATE TEMP TABLE note_message_references (note_id TEXT NOT NULL, guild_id TEXT NOT NULL);
+		CREATE TEMP TABLE quotes (id TEXT PRIMARY KEY, guild_id TEXT NOT NULL, short_code TEXT);
+		INSERT INTO wiki_pages VALUES ('p1', 'g1'), ('p2', 'g2');
+		INSERT INTO wiki_titles VALUES ('alpha', 'g1'), ('beta', 'g2');
+		INSERT INTO wiki_message_references VALUES ('p1', 'g1'), ('p2', 'g2');
+		INSERT INTO notes VALUES ('n1', 'g1', 'todo', NULL), ('n2', 'g2', 'done', NULL);
+		INSERT INTO note_message_references VALUES ('n1', 'g1');
+		INSERT INTO quotes VALUES ('q1', 'g1', 'aaaa1111'), ('q2', 'g1', 'bbbb2222'), ('q3', 'g2', 'cccc3333')`
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	repo := NewGuildContentRepository(db)
+	all := []string{entities.ContentTypeWiki, entities.ContentTypeNote, entities.ContentTypeQuote}
+
+	countIn := func(table, guildID string) int {
+		t.Helper()
+		var n int
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+" WHERE guild_id = $1", guildID).Scan(&n); err != nil {
+			t.Fatalf("failed to count %s: %v", table, err)
+		}
+		return n
+	}
+
+	dry, err := repo.MoveContent(ctx, "g1", "g2", all, true)
+	if err != nil {
+		t.Fatalf("MoveContent(dry run) error = %v", err)
+	}
+	if dry.WikiPages != 1 || dry.WikiReferences != 1 || dry.Notes != 1 || dry.NoteReferences != 1 || dry.Quotes != 2 {
+		t.Errorf("dry run counts = %+v", dry)
+	}
+	if n := countIn("wiki_pages", "g1"); n != 1 {
+		t.Errorf("dry run moved wiki pages, %d left in g1", n)
+	}
+
+	// A title already used in the target aborts the whole move
+	if _, err := db.ExecContext(ctx, "INSERT INTO wiki_titles VALUES ('beta', 'g1')"); err != nil {
+		t.Fatalf("failed to add conflicting title: %v", err)
+	}
+	move, err := repo.MoveContent(ctx, "g1", "g2", all, false)
+	if !errors.Is(err, repositories.ErrGuildMoveConflict) {
+		t.Fatalf("MoveContent() error = %v, want ErrGuildMoveConflict", err)
+	}
+	if len(move.ConflictingTitles) != 1 || move.ConflictingTitles[0] != "beta" {
+		t.Errorf("ConflictingTitles = %v, want [beta]", move.ConflictingTitles)
+	}
+	if n := countIn("quotes", "g1"); n != 2 {
+		t.Errorf("conflicting move changed quotes, %d left in g1", n)
+	}
+
+	if _, err := db.ExecContext(ctx, "DELETE FROM wiki_titles WHERE guild_id = 'g1' AND page_slug = 'beta'"); err != nil {
+		t.Fatalf("failed to remove conflicting title: %v", err)
+	}
+
+	// A note slug, an author's unique note title, or a quote short code already used in the
+	// target is reported by a dry run and refuses the move; a deleted note in the target
+	// doesn't count, and neither does another author's note with the same title
+	collisions := `
+		INSERT INTO notes VALUES ('n3', 'g2', 'todo', NULL), ('n4', 'g1', 'old', NULL), ('n5', 'g2', 'old', NOW());
+		INSERT INTO notes VALUES ('n6', 'g1', 'n6', NULL, 'u1', 'groceries'), ('n7', 'g2', 'n7', NULL, 'u1', 'groceries'),
+			('n8', 'g1', 'n8', NULL, 'u1', 'chores'), ('n9', 'g2', 'n9', NULL, 'u2', 'chores');
+		INSERT INTO quotes VALUES ('q4', 'g2', 'bbbb2222')`
+	if _, err := db.ExecContext(ctx, collisions); err != nil {
+		t.Fatalf("failed to add colliding notes and quotes: %v", err)
+	}
+	dry, err = repo.MoveContent(ctx, "g1", "g2", all, true)
+	if err != nil {
+		t.Fatalf("MoveContent(dry run) error = %v", err)
+	}
+	if len(dry.ConflictingNoteSlugs) != 1 || dry.ConflictingNoteSlugs[0] != "todo" {
+		t.Errorf("ConflictingNoteSlugs = %v, want [todo]", dry.ConflictingNoteSlugs)
+	}
+	if len(dry.ConflictingNoteTitles) != 1 || dry.ConflictingNoteTitles[0] != "groceries" {
+		t.Errorf("ConflictingNoteTitles = %v, want [groceries]", dry.ConflictingNoteTitles)
+	}
+	if len(dry.ConflictingQuoteCodes) != 1 || dry.ConflictingQuoteCodes[0] != "bbbb2222" {
+		t.Errorf("ConflictingQuoteCodes = %v, want [bbbb2222]", dry.ConflictingQuoteCodes)
+	}
+	if _, err := repo.MoveContent(ctx, "g1", "g2", all, false); !errors.Is(err, repositories.ErrGuildMoveConflict) {
+		t.Fatalf("MoveContent() error = %v, want ErrGuildMoveConflict", err)
+	}
+	if n := countIn("notes", "g1"); n != 4 {
+		t.Errorf("conflicting move changed notes, %d left in g1", n)
+	}
+	if _, err := db.ExecContext(ctx, "DELETE FROM notes WHERE id IN ('n3', 'n7'); DELETE FROM quotes WHERE id = 'q4'"); err != nil {
+		t.Fatalf("failed to remove colliding notes and quotes: %v", err)
+	}
+	if _, err := repo.MoveContent(ctx, "g1", "g2", all, false); err != nil {
+		t.Fatalf("MoveContent() error = %v", err)
+	}
+	for _, table := range []string{"wiki_pages", "wiki_titles", "wiki_message_references", "notes", "note_message_references", "quotes"} {
+		if n := countIn(table, "g1"); n != 0 {
+			t.Errorf("%s: %d rows left in g1 after move", table, n)
+		}
+	}
+	if n := countIn("wiki_message_references", "g2"); n != 2 {
+		t.Errorf("wiki_message_references in g2 = %d, want 2", n)
+	}
+}
+
+// TestReassignAuthor changes authors in temporary tables that shadow the real schema.
+// It needs a real PostgreSQL server and is skipped unless HIVEMIND_TEST_DATABASE_URL is set.
+func TestReassignAuthor(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	schema := `
+		CREATE TEMP TABLE discord_users (discord_id TEXT PRIMARY KEY, user_id TEXT, linked_at TIMESTAMP);
+		CREATE TEMP TABLE wiki_pages (id TEXT PRIMARY KEY, guild_id TEXT NOT NULL, author_id TEXT NOT NULL, deleted_at TIMESTAMP);
+		CREATE TEMP TABLE notes (id TEXT PRIMARY KEY, guild_id TEXT, author_id TEXT NOT NULL, slug TEXT, deleted_at TIMESTAMP);
+		CREATE TEMP TABLE quotes (id TEXT PRIMARY KEY, guild_id TEXT NOT NULL, author_id TEXT NOT NULL, author_discord_id TEXT, deleted_at TIMESTAMP);
+		INSERT INTO discord_users VALUES ('d-bot', 'bot', NOW()), ('d-ada', 'ada', NOW());
+		INSERT INTO wiki_pages VALUES ('p1', 'g1', 'bot', NULL), ('p2', 'g1', 'bot', NOW());
+		INSERT INTO notes VALUES ('n1', NULL, 'bot', 'todo', NULL), ('n2', NULL, 'ada', 'todo', NULL), ('n3', 'g1', 'bot', 'todo', NULL);
+		INSERT INTO quotes VALUES ('q1', 'g1', 'bot', 'd-bot', NULL), ('q2', 'g1', 'bot', 'd-bot', NULL)`
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	repo := NewGuildContentRepository(db)
+	queryRow := func(query string, dest ...any) {
+		t.Helper()
+		if err := db.QueryRowContext(ctx, query).Scan(dest...); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+
+	reassignment, err := repo.ReassignAuthor(ctx, entities.ResourceQuote, "q1", "ada")
+	if err != nil {
+		t.Fatalf("ReassignAuthor(quote) error = %v", err)
+	}
+	if reassignment.PreviousAuthorID != "bot" || reassignment.GuildID != "g1" {
+		t.Errorf("reassignment = %+v, want previous author bot in g1", reassignment)
+	}
+	var authorID, discordID string
+	queryRow("SELECT author_id, author_discord_id FROM quotes WHERE id = 'q1'", &authorID, &discordID)
+	if authorID != "ada" || discordID != "d-ada" {
+		t.Errorf("quote author = %s (%s), want ada (d-ada)", authorID, discordID)
+	}
+
+	// grace has no linked Discord account, so the quote keeps its author rather than
+	// losing the Discord ID its author name is looked up by
+	if _, err := repo.ReassignAuthor(ctx, entities.ResourceQuote, "q2", "grace"); !errors.Is(err, repositories.ErrDiscordUserNotFound) {
+		t.Errorf("ReassignAuthor(quote to unlinked user) error = %v, want ErrDiscordUserNotFound", err)
+	}
+	queryRow("SELECT author_id, author_discord_id FROM quotes WHERE id = 'q2'", &authorID, &discordID)
+	if authorID != "bot" || discordID != "d-bot" {
+		t.Errorf("quote q2 author = %s (%s), want unchanged bot (d-bot)", authorID, discordID)
+	}
+
+	// ada already has a personal note with slug "todo"; a guild note's slug is unaffected
+	if _, err := repo.ReassignAuthor(ctx, entities.ResourceNote, "n1", "ada"); err != nil {
+		t.Fatalf("ReassignAuthor(personal note) error = %v", err)
+	}
+	if _, err := repo.ReassignAuthor(ctx, entities.ResourceNote, "n3", "ada"); err != nil {
+		t.Fatalf("ReassignAuthor(guild note) error = %v", err)
+	}
+	var slug1, slug3 string
+	queryRow("SELECT slug FROM notes WHERE id = 'n1'", &slug1)
+	queryRow("SELECT slug FROM notes WHERE id = 'n3'", &slug3)
+	if slug1 != "todo-n1" || slug3 != "todo" {
+		t.Errorf("slugs = %q, %q, want todo-n1, todo", slug1, slug3)
+	}
+
+	if _, err := repo.ReassignAuthor(ctx, entities.ResourceWikiPage, "p2", "ada"); !errors.Is(err, repositories.ErrContentNotFound) {
+		t.Errorf("ReassignAuthor(deleted page) error = %v, want ErrContentNotFound", err)
+	}
+}
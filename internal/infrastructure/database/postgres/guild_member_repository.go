@@ -255,6 +255,31 @@ func (r *GuildMemberRepository) UpdateLastSeen(ctx context.Context, guildID, dis
 	return nil
 }
 
+// UpdateSyncedAt updates the synced_at timestamp for a guild member
+func (r *GuildMemberRepository) UpdateSyncedAt(ctx context.Context, guildID, discordID string, syncedAt time.Time) error {
+	query := `
+		UPDATE guild_members
+		SET synced_at = $3
+		WHERE guild_id = $1 AND discord_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, guildID, discordID, syncedAt)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repositories.ErrGuildMemberNotFound
+	}
+
+	return nil
+}
+
 // DeleteMember removes a member record (when they leave)
 func (r *GuildMemberRepository) DeleteMember(ctx context.Context, guildID, discordID string) error {
 	start := time.Now()
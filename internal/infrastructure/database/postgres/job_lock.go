@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// jobLockSpace is the first key of the advisory locks held while a background job runs,
+// keeping them apart from the node ID locks
+const jobLockSpace = 0x48564a42 // "HVJB"
+
+// Background jobs that must run on only one server at a time
+const (
+	JobAuthCleanup int32 = 1 // Deleting expired API tokens and OIDC sessions
+)
+
+// TryLockJob takes a session advisory lock on job, so servers sharing the database
+// can skip a run another server is already doing. ok is false, with a nil release,
+// when the lock is held elsewhere; otherwise the lock is held on a dedicated
+// connection until release is called.
+func (c *Connection) TryLockJob(ctx context.Context, job int32) (release func() error, ok bool, err error) {
+	conn, err := c.DB.Connx(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get connection for job lock: %w", err)
+	}
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1, $2)`, jobLockSpace, job).Scan(&locked); err != nil {
+		conn.Close()
+		return nil, false, fmt.Errorf("failed to lock job %d: %w", job, err)
+	}
+	if !locked {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	return func() error {
+		// Closing only returns the connection to the pool, so unlock explicitly
+		defer conn.Close()
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1, $2)`, jobLockSpace, job); err != nil {
+			return fmt.Errorf("failed to unlock job %d: %w", job, err)
+		}
+		return nil
+	}, true, nil
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+// TestTryLockJob needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestTryLockJob(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	// Advisory locks are per session, so each "server" needs its own pool
+	first, err := NewConnection(dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer first.Close()
+	second, err := NewConnection(dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer second.Close()
+
+	ctx := context.Background()
+
+	release, ok, err := first.TryLockJob(ctx, JobAuthCleanup)
+	if err != nil || !ok {
+		t.Fatalf("TryLockJob() = %v, %v, want lock", ok, err)
+	}
+	if _, ok, err := second.TryLockJob(ctx, JobAuthCleanup); err != nil || ok {
+		t.Fatalf("second TryLockJob() = %v, %v, want held elsewhere", ok, err)
+	}
+
+	if err := release(); err != nil {
+		t.Fatalf("release() error = %v", err)
+	}
+	releaseSecond, ok, err := second.TryLockJob(ctx, JobAuthCleanup)
+	if err != nil || !ok {
+		t.Fatalf("TryLockJob() after release = %v, %v, want lock", ok, err)
+	}
+	if err := releaseSecond(); err != nil {
+		t.Errorf("release() error = %v", err)
+	}
+}
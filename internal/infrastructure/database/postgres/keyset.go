@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// keysetColumn is one column of a result ordering
+type keysetColumn struct {
+	expr string // SQL expression sorted on; must not be NULL
+	cast string // Postgres type a cursor's text value is cast back to
+	desc bool
+}
+
+// keysetOrder is a total ordering for keyset pagination. The last column must be
+// unique (the row ID) so every row has a distinct position.
+type keysetOrder struct {
+	name    string // Identifies the ordering in page cursors
+	columns []keysetColumn
+}
+
+// orderClause returns the ORDER BY clause for the ordering
+func (o keysetOrder) orderClause() string {
+	parts := make([]string, len(o.columns))
+	for i, col := range o.columns {
+		direction := "ASC"
+		if col.desc {
+			direction = "DESC"
+		}
+		parts[i] = fmt.Sprintf("%s %s", col.expr, direction)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// keysExpr returns a select expression producing the row's sort keys as a text
+// array, which nextCursor turns into the cursor for the following page
+func (o keysetOrder) keysExpr() string {
+	parts := make([]string, len(o.columns))
+	for i, col := range o.columns {
+		parts[i] = col.expr + "::text"
+	}
+	return "ARRAY[" + strings.Join(parts, ", ") + "]"
+}
+
+// afterCondition returns a WHERE condition selecting the rows that sort after
+// cursor, using placeholders from $param on, and the arguments for them.
+// Orderings that go one way compare as a single row value, (a, id) < ($1, $2);
+// mixed directions expand to (a > $1) OR (a = $1 AND id < $2).
+func (o keysetOrder) afterCondition(cursor *repositories.PageCursor, param int) (string, []interface{}, error) {
+	if cursor.Order != o.name || len(cursor.Keys) != len(o.columns)-1 {
+		return "", nil, repositories.ErrInvalidPageToken
+	}
+
+	values := append(append([]string{}, cursor.Keys...), cursor.ID)
+	args := make([]interface{}, len(values))
+	placeholders := make([]string, len(values))
+	for i, v := range values {
+		args[i] = v
+		placeholders[i] = fmt.Sprintf("$%d::%s", param+i, o.columns[i].cast)
+	}
+
+	if o.uniformDirection() {
+		exprs := make([]string, len(o.columns))
+		for i, col := range o.columns {
+			exprs[i] = col.expr
+		}
+		return fmt.Sprintf("(%s) %s (%s)", strings.Join(exprs, ", "), keysetOperator(o.columns[0].desc), strings.Join(placeholders, ", ")), args, nil
+	}
+
+	alternatives := make([]string, len(o.columns))
+	for i, col := range o.columns {
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, fmt.Sprintf("%s = %s", o.columns[j].expr, placeholders[j]))
+		}
+		terms = append(terms, fmt.Sprintf("%s %s %s", col.expr, keysetOperator(col.desc), placeholders[i]))
+		alternatives[i] = "(" + strings.Join(terms, " AND ") + ")"
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args, nil
+}
+
+// nextCursor returns the cursor following the first limit rows, given the sort
+// keys of each fetched row. Callers fetch limit+1 rows; fewer means there is no
+// next page and the cursor is nil.
+func (o keysetOrder) nextCursor(rowKeys []pq.StringArray, limit int) *repositories.PageCursor {
+	if limit <= 0 || len(rowKeys) <= limit {
+		return nil
+	}
+	return o.cursorAt(rowKeys[limit-1])
+}
+
+// cursorAt returns the cursor positioned at the row with the given sort keys
+func (o keysetOrder) cursorAt(keys pq.StringArray) *repositories.PageCursor {
+	return &repositories.PageCursor{
+		Order: o.name,
+		Keys:  append([]string{}, keys[:len(keys)-1]...),
+		ID:    keys[len(keys)-1],
+	}
+}
+
+func (o keysetOrder) uniformDirection() bool {
+	for _, col := range o.columns {
+		if col.desc != o.columns[0].desc {
+			return false
+		}
+	}
+	return true
+}
+
+func keysetOperator(desc bool) string {
+	if desc {
+		return "<"
+	}
+	return ">"
+}
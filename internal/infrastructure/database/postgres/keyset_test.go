@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+func TestKeysetAfterCondition(t *testing.T) {
+	tests := []struct {
+		name  string
+		order keysetOrder
+		want  string
+	}{
+		{
+			name:  "one direction compares row values",
+			order: searchKeysetOrder("n", "created_at", false, ""),
+			want:  "(n.created_at, n.id) < ($3::timestamp, $4::text)",
+		},
+		{
+			name:  "users by last login",
+			order: userListOrder("last_login", "asc"),
+			want:  "(COALESCE(last_seen, '-infinity'::timestamp), id) > ($3::timestamp, $4::text)",
+		},
+		{
+			name:  "mixed directions expand",
+			order: wikiListOrder("title", true),
+			want: "((wp.pinned < $3::boolean) OR (wp.pinned = $3::boolean AND COALESCE(wp.title, '') > $4::text) OR " +
+				"(wp.pinned = $3::boolean AND COALESCE(wp.title, '') = $4::text AND wp.id > $5::text))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := make([]string, len(tt.order.columns)-1)
+			cursor := &repositories.PageCursor{Order: tt.order.name, Keys: keys, ID: "last"}
+			got, args, err := tt.order.afterCondition(cursor, 3)
+			if err != nil {
+				t.Fatalf("afterCondition() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("afterCondition() = %q, want %q", got, tt.want)
+			}
+			if len(args) != len(tt.order.columns) || args[len(args)-1] != "last" {
+				t.Errorf("afterCondition() args = %v, want one per column ending with the ID", args)
+			}
+		})
+	}
+}
+
+func TestKeysetAfterConditionRejectsOtherOrderings(t *testing.T) {
+	byTitle := wikiListOrder("title", true)
+	cursor := byTitle.nextCursor([]pq.StringArray{{"false", "Alpha", "a"}, {"false", "Beta", "b"}}, 1)
+
+	if _, _, err := byTitle.afterCondition(cursor, 1); err != nil {
+		t.Fatalf("afterCondition() with its own cursor: %v", err)
+	}
+	for _, other := range []keysetOrder{wikiListOrder("title", false), wikiListOrder("created_at", true), searchKeysetOrder("n", "", true, "")} {
+		if _, _, err := other.afterCondition(cursor, 1); !errors.Is(err, repositories.ErrInvalidPageToken) {
+			t.Errorf("%s accepted a cursor for %s: err = %v", other.name, byTitle.name, err)
+		}
+	}
+}
+
+func TestKeysetNextCursor(t *testing.T) {
+	order := searchKeysetOrder("n", "updated_at", false, "")
+	rows := []pq.StringArray{
+		{"2024-01-03 00:00:00", "c"},
+		{"2024-01-02 00:00:00", "b"},
+		{"2024-01-01 00:00:00", "a"},
+	}
+
+	if got := order.nextCursor(rows, 3); got != nil {
+		t.Errorf("nextCursor() on the last page = %+v, want nil", got)
+	}
+
+	got := order.nextCursor(rows, 2)
+	want := &repositories.PageCursor{Order: order.name, Keys: []string{"2024-01-02 00:00:00"}, ID: "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("nextCursor() = %+v, want %+v", got, want)
+	}
+
+	parsed, err := repositories.ParsePageToken(got.Token())
+	if err != nil || !reflect.DeepEqual(parsed, want) {
+		t.Errorf("ParsePageToken(Token()) = %+v, %v; want %+v", parsed, err, want)
+	}
+	for _, token := range []string{"not a token!", "e30"} {
+		if _, err := repositories.ParsePageToken(token); !errors.Is(err, repositories.ErrInvalidPageToken) {
+			t.Errorf("ParsePageToken(%q) error = %v, want ErrInvalidPageToken", token, err)
+		}
+	}
+}
+
+// TestKeysetPagingStable pages through wiki list orderings with cursors while rows
+// are inserted between pages, against a temporary table that shadows wiki_pages.
+// It needs a real PostgreSQL server and is skipped unless HIVEMIND_TEST_DATABASE_URL is set.
+func TestKeysetPagingStable(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	// b and c share a created_at, so only the id tie-breaker orders them
+	fixture := `
+		CREATE TEMP TABLE wiki_pages (id TEXT PRIMARY KEY, title TEXT, pinned BOOLEAN, created_at TIMESTAMP, updated_at TIMESTAMP);
+		INSERT INTO wiki_pages (id, title, pinned, created_at) VALUES
+			('p1', 'Rules', TRUE, '2024-01-05'),
+			('a', 'Alpha', FALSE, '2024-01-07'),
+			('b', 'Bravo', FALSE, '2024-01-06 12:00:00.123456'),
+			('c', 'Charlie', FALSE, '2024-01-06 12:00:00.123456'),
+			('d', 'Delta', FALSE, '2024-01-03'),
+			('e', 'Echo', FALSE, '2024-01-02'),
+			('f', 'Foxtrot', FALSE, '2024-01-01')`
+
+	tests := []struct {
+		name      string
+		ascending bool
+		insert    string // Run after the first page
+		want      string
+	}{
+		{
+			// n sorts before the cursor and is not seen; offset paging would repeat c
+			name:   "newest first",
+			insert: "('n', 'New', FALSE, '2024-02-01'), ('g', 'Golf', FALSE, '2024-01-04')",
+			want:   "p1 a c b g d e f",
+		},
+		{
+			// g sorts before the cursor and is not seen; offset paging would repeat e
+			name:      "oldest first",
+			ascending: true,
+			insert:    "('n', 'New', FALSE, '2024-02-01'), ('g', 'Golf', FALSE, '2023-12-01')",
+			want:      "p1 f e d b c a n",
+		},
+	}
+
+	const pageSize = 3
+	for _, tt := range tests {
+		if _, err := db.Exec("DROP TABLE IF EXISTS pg_temp.wiki_pages; " + fixture); err != nil {
+			t.Fatalf("failed to create fixture: %v", err)
+		}
+
+		order := wikiListOrder("created_at", tt.ascending)
+		var cursor *repositories.PageCursor
+		var got []string
+		for page := 0; page == 0 || cursor != nil; page++ {
+			if page > 10 {
+				t.Fatalf("%s: paging did not terminate", tt.name)
+			}
+
+			where := "TRUE"
+			var args []interface{}
+			if cursor != nil {
+				where, args, err = order.afterCondition(cursor, 1)
+				if err != nil {
+					t.Fatalf("%s: afterCondition: %v", tt.name, err)
+				}
+			}
+			rows, err := db.Query("SELECT wp.id, "+order.keysExpr()+" FROM wiki_pages wp WHERE "+where+
+				" ORDER BY "+order.orderClause()+" LIMIT "+strconv.Itoa(pageSize+1), args...)
+			if err != nil {
+				t.Fatalf("%s: query failed: %v", tt.name, err)
+			}
+			var rowKeys []pq.StringArray
+			for rows.Next() {
+				var id string
+				var keys pq.StringArray
+				if err := rows.Scan(&id, &keys); err != nil {
+					t.Fatalf("%s: scan failed: %v", tt.name, err)
+				}
+				if len(rowKeys) < pageSize {
+					got = append(got, id)
+				}
+				rowKeys = append(rowKeys, keys)
+			}
+			rows.Close()
+			cursor = order.nextCursor(rowKeys, pageSize)
+
+			if page == 0 {
+				if _, err := db.Exec("INSERT INTO wiki_pages (id, title, pinned, created_at) VALUES " + tt.insert); err != nil {
+					t.Fatalf("%s: insert failed: %v", tt.name, err)
+				}
+			}
+		}
+
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("%s: paged ids = %q, want %q", tt.name, strings.Join(got, " "), tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+// moderatedTables maps each kind of moderated content to its table
+var moderatedTables = map[entities.AuditResource]string{
+	entities.ResourceWikiPage: "wiki_pages",
+	entities.ResourceNote:     "notes",
+	entities.ResourceQuote:    "quotes",
+}
+
+// flaggedContentQuery selects flagged, live content of every kind in one shape
+const flaggedContentQuery = `
+	SELECT 'wiki_page' AS content_type, id, guild_id, author_id, COALESCE(title, '') AS title, body, COALESCE(flagged_reason, '') AS reason, flagged_at
+	FROM wiki_pages WHERE flagged AND deleted_at IS NULL
+	UNION ALL
+	SELECT 'note', id, COALESCE(guild_id, ''), author_id, COALESCE(title, ''), body, COALESCE(flagged_reason, ''), flagged_at
+	FROM notes WHERE flagged AND deleted_at IS NULL
+	UNION ALL
+	SELECT 'quote', id, guild_id, author_id, '', body, COALESCE(flagged_reason, ''), flagged_at
+	FROM quotes WHERE flagged AND deleted_at IS NULL`
+
+type moderationRepository struct {
+	db  *sql.DB
+	log *slog.Logger
+}
+
+// NewModerationRepository creates a new PostgreSQL moderation repository
+func NewModerationRepository(db *sql.DB) repositories.ModerationRepository {
+	return &moderationRepository{
+		db:  db,
+		log: slog.Default().With(slog.String("repo", "moderation")),
+	}
+}
+
+func (r *moderationRepository) SetFlagged(ctx context.Context, contentType entities.AuditResource, id, reason string) error {
+	start := time.Now()
+	var err error
+	var rowsAffected int64
+	defer func() {
+		metrics.RecordDBOperation("moderation", "set_flagged", time.Since(start), rowsAffected, err)
+	}()
+
+	table, ok := moderatedTables[contentType]
+	if !ok {
+		err = fmt.Errorf("unknown content type %q", contentType)
+		return err
+	}
+
+	r.log.Debug("setting content flag",
+		slog.String("content_type", string(contentType)),
+		slog.String("id", id),
+		slog.Bool("flagged", reason != ""))
+
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET flagged = $2 <> '', flagged_reason = NULLIF($2, ''),
+			flagged_at = CASE WHEN $2 <> '' THEN CURRENT_TIMESTAMP END
+		WHERE id = $1
+	`, table)
+
+	result, err := r.db.ExecContext(ctx, query, id, reason)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err = result.RowsAffected()
+	return err
+}
+
+func (r *moderationRepository) ListFlagged(ctx context.Context, contentType entities.AuditResource, limit, offset int) ([]*entities.FlaggedContent, int, error) {
+	start := time.Now()
+	var err error
+	var rowCount int64
+	defer func() {
+		metrics.RecordDBOperation("moderation", "list_flagged", time.Since(start), rowCount, err)
+	}()
+
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := `
+		SELECT content_type, id, guild_id, author_id, title, body, reason, flagged_at, COUNT(*) OVER ()
+		FROM (` + flaggedContentQuery + `) flagged
+		WHERE $1 = '' OR content_type = $1
+		ORDER BY flagged_at DESC NULLS LAST, id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, string(contentType), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var items []*entities.FlaggedContent
+	total := 0
+	for rows.Next() {
+		item := &entities.FlaggedContent{}
+		var flaggedAt sql.NullTime
+		if err = rows.Scan(&item.ContentType, &item.ID, &item.GuildID, &item.AuthorID, &item.Title, &item.Body, &item.Reason, &flaggedAt, &total); err != nil {
+			return nil, 0, err
+		}
+		if flaggedAt.Valid {
+			item.FlaggedAt = flaggedAt.Time
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	rowCount = int64(len(items))
+
+	// An offset past the end returns no rows, and with them no total
+	if len(items) == 0 && offset > 0 {
+		query := `SELECT COUNT(*) FROM (` + flaggedContentQuery + `) flagged WHERE $1 = '' OR content_type = $1`
+		if err = r.db.QueryRowContext(ctx, query, string(contentType)).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return items, total, nil
+}
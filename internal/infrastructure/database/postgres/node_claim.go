@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// nodeIDLockSpace is the first key of the advisory locks held on Snowflake node IDs,
+// keeping them apart from any other advisory locks on the database
+const nodeIDLockSpace = 0x48564d44 // "HVMD"
+
+// ErrNodeIDInUse is returned when another server already holds a Snowflake node ID
+var ErrNodeIDInUse = errors.New("snowflake node ID is already held by another server")
+
+// ClaimNodeID takes a session advisory lock on a Snowflake node ID, so a second server
+// started with the same node ID fails instead of generating duplicate IDs. The lock is
+// held on a dedicated connection until release is called or the process exits.
+func (c *Connection) ClaimNodeID(ctx context.Context, nodeID int64) (release func() error, err error) {
+	conn, err := c.DB.Connx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection for node ID lock: %w", err)
+	}
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1, $2)`, nodeIDLockSpace, nodeID).Scan(&locked); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to lock node ID %d: %w", nodeID, err)
+	}
+	if !locked {
+		conn.Close()
+		return nil, fmt.Errorf("%w: %d", ErrNodeIDInUse, nodeID)
+	}
+
+	return func() error {
+		// Closing only returns the connection to the pool, so unlock explicitly
+		defer conn.Close()
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1, $2)`, nodeIDLockSpace, nodeID); err != nil {
+			return fmt.Errorf("failed to unlock node ID %d: %w", nodeID, err)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+// TestClaimNodeID needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestClaimNodeID(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	// Advisory locks are per session, so each "server" needs its own pool
+	first, err := NewConnection(dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer first.Close()
+	second, err := NewConnection(dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer second.Close()
+
+	ctx := context.Background()
+	const nodeID = 1000
+
+	release, err := first.ClaimNodeID(ctx, nodeID)
+	if err != nil {
+		t.Fatalf("ClaimNodeID() error = %v", err)
+	}
+	if _, err := second.ClaimNodeID(ctx, nodeID); !errors.Is(err, ErrNodeIDInUse) {
+		t.Fatalf("second ClaimNodeID() error = %v, want %v", err, ErrNodeIDInUse)
+	}
+
+	if err := release(); err != nil {
+		t.Fatalf("release() error = %v", err)
+	}
+	releaseSecond, err := second.ClaimNodeID(ctx, nodeID)
+	if err != nil {
+		t.Fatalf("ClaimNodeID() after release error = %v", err)
+	}
+	if err := releaseSecond(); err != nil {
+		t.Errorf("release() error = %v", err)
+	}
+}
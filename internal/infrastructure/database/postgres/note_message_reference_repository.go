@@ -26,7 +26,7 @@ func NewNoteMessageReferenceRepository(db *sql.DB) repositories.NoteMessageRefer
 	}
 }
 
-func (r *noteMessageReferenceRepository) Create(ctx context.Context, ref *entities.NoteMessageReference) error {
+func (r *noteMessageReferenceRepository) Create(ctx context.Context, ref *entities.NoteMessageReference, maxRefs int) error {
 	start := time.Now()
 	var err error
 	defer func() {
@@ -66,9 +66,21 @@ func (r *noteMessageReferenceRepository) Create(ctx context.Context, ref *entiti
 		RETURNING id, added_at
 	`
 
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if maxRefs > 0 {
+		if err = lockReferenceOwner(ctx, tx, "notes", ref.NoteID); err != nil {
+			return err
+		}
+	}
+
 	var returnedID string
 	var returnedAddedAt time.Time
-	err = r.db.QueryRowContext(ctx, query,
+	err = tx.QueryRowContext(ctx, query,
 		ref.ID, ref.NoteID, ref.MessageID, ref.ChannelID, nullString(ref.GuildID),
 		ref.Content, ref.AuthorID, ref.AuthorUsername, nullString(ref.AuthorDisplayName),
 		ref.MessageTimestamp, attachmentMetadata, ref.AddedAt,
@@ -82,6 +94,15 @@ func (r *noteMessageReferenceRepository) Create(ctx context.Context, ref *entiti
 		return err
 	}
 
+	if maxRefs > 0 {
+		if err = checkReferenceLimit(ctx, tx, "note_message_references", "note_id", ref.NoteID, maxRefs); err != nil {
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
 	// Update with returned values
 	ref.ID = returnedID
 	ref.AddedAt = returnedAddedAt
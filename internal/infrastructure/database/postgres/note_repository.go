@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -43,17 +44,124 @@ func (r *noteRepository) Create(ctx context.Context, note *entities.Note) error
 	note.UpdatedAt = time.Now()
 
 	query := `
-		INSERT INTO notes (id, title, body, author_id, guild_id, channel_id, source_msg_id, source_channel_id, tags, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO notes (id, title, slug, body, author_id, guild_id, channel_id, source_msg_id, source_channel_id, tags, created_at, updated_at, unique_title)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, CASE WHEN $13 THEN LOWER($2) END)
 	`
-	_, err = r.db.ExecContext(ctx, query,
-		note.ID, nullString(note.Title), note.Body, note.AuthorID, nullString(note.GuildID),
-		nullString(note.ChannelID), nullString(note.SourceMsgID), nullString(note.SourceChannelID),
-		pq.Array(note.Tags), note.CreatedAt, note.UpdatedAt,
-	)
+	// Another note saved at the same moment may claim the same slug; pick again
+	for attempt := 0; attempt < maxPermalinkAttempts; attempt++ {
+		note.Slug, err = r.freeSlug(ctx, note.ID, note.GuildID, note.AuthorID, noteSlug(note.Title))
+		if err != nil {
+			return err
+		}
+		_, err = r.db.ExecContext(ctx, query,
+			note.ID, nullString(note.Title), note.Slug, note.Body, note.AuthorID, nullString(note.GuildID),
+			nullString(note.ChannelID), nullString(note.SourceMsgID), nullString(note.SourceChannelID),
+			pq.Array(note.Tags), note.CreatedAt, note.UpdatedAt, note.UniqueTitle,
+		)
+		if !isUniqueViolation(err) || isNoteTitleTaken(err) {
+			break
+		}
+	}
+	if isNoteTitleTaken(err) {
+		err = r.titleTakenError(ctx, note.GuildID, note.AuthorID, note.Title, note.ID)
+	}
 	return err
 }
 
+// noteUniqueTitleIndex is the unique index on the titles of notes saved while their
+// guild requires unique titles
+const noteUniqueTitleIndex = "idx_notes_unique_title"
+
+// isNoteTitleTaken reports whether err is a violation of noteUniqueTitleIndex
+func isNoteTitleTaken(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == noteUniqueTitleIndex
+}
+
+// titleTakenError returns the NoteTitleTakenError for a save of note id whose title
+// another of the author's notes in the guild already has
+func (r *noteRepository) titleTakenError(ctx context.Context, guildID, authorID, title, id string) error {
+	existingID, err := r.FindIDByTitle(ctx, guildID, authorID, title, id)
+	if err != nil {
+		return fmt.Errorf("failed to find note with the title: %w", err)
+	}
+	return &repositories.NoteTitleTakenError{ExistingID: existingID}
+}
+
+// freeSlug returns the first slug from base not used by another live note in the same
+// scope as note id: the guild's notes, or the author's personal notes
+func (r *noteRepository) freeSlug(ctx context.Context, id, guildID, authorID, base string) (string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT slug FROM notes
+		WHERE COALESCE(guild_id, 'user:' || author_id) = COALESCE($1, 'user:' || $2)
+		  AND deleted_at IS NULL AND id <> $3
+		  AND (slug = $4 OR slug LIKE $5)
+	`, nullString(guildID), authorID, id, base, likePrefix(base+"-"))
+	if err != nil {
+		return "", fmt.Errorf("failed to list taken slugs: %w", err)
+	}
+	defer rows.Close()
+
+	taken := map[string]bool{}
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return "", err
+		}
+		taken[s] = true
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return nextFreeSlug(base, taken), nil
+}
+
+// FindIDBySlug looks a note up by its permalink slug within the guild, or within
+// the author's personal notes when guildID is empty
+func (r *noteRepository) FindIDBySlug(ctx context.Context, guildID, authorID, slug string) (string, error) {
+	start := time.Now()
+	var err error
+	defer func() {
+		metrics.RecordDBOperation("note", "find_by_slug", time.Since(start), -1, err)
+	}()
+
+	var id string
+	err = r.db.QueryRowContext(ctx, `
+		SELECT id FROM notes
+		WHERE COALESCE(guild_id, 'user:' || author_id) = COALESCE($1, 'user:' || $2)
+		  AND slug = $3 AND deleted_at IS NULL
+	`, nullString(guildID), authorID, slug).Scan(&id)
+	if err == sql.ErrNoRows {
+		err = nil
+		return "", nil
+	}
+	return id, err
+}
+
+// FindIDByTitle looks up another of the author's notes in the guild with the same
+// title, ignoring case
+func (r *noteRepository) FindIDByTitle(ctx context.Context, guildID, authorID, title, excludeID string) (string, error) {
+	start := time.Now()
+	var err error
+	defer func() {
+		metrics.RecordDBOperation("note", "find_by_title", time.Since(start), -1, err)
+	}()
+
+	var id string
+	err = r.db.QueryRowContext(ctx, `
+		SELECT id FROM notes
+		WHERE guild_id = $1 AND author_id = $2 AND LOWER(title) = LOWER($3)
+		  AND id <> $4 AND deleted_at IS NULL
+		ORDER BY created_at
+		LIMIT 1
+	`, guildID, authorID, title, excludeID).Scan(&id)
+	if err == sql.ErrNoRows {
+		err = nil
+		return "", nil
+	}
+	return id, err
+}
+
 func (r *noteRepository) GetByID(ctx context.Context, id string, userDiscordID string) (*entities.Note, error) {
 	start := time.Now()
 	var err error
@@ -64,7 +172,7 @@ func (r *noteRepository) GetByID(ctx context.Context, id string, userDiscordID s
 	// Notes are personal - no ACL check needed, just retrieve by ID
 	// Ownership verification happens at the service layer (author_id check)
 	query := `
-		SELECT n.id, n.title, n.body, n.author_id, n.guild_id, n.channel_id, n.source_msg_id, n.source_channel_id, n.tags, n.created_at, n.updated_at, n.deleted_at,
+		SELECT n.id, n.title, n.slug, n.body, n.author_id, n.guild_id, n.channel_id, n.source_msg_id, n.source_channel_id, n.tags, n.created_at, n.updated_at, n.deleted_at,
 		       udn.display_name
 		FROM notes n
 		LEFT JOIN users u ON n.author_id = u.id
@@ -75,17 +183,17 @@ func (r *noteRepository) GetByID(ctx context.Context, id string, userDiscordID s
 
 	note := &entities.Note{}
 	var tags pq.StringArray
-	var title, guildID, channelID, sourceMsgID, sourceChannelID, authorDisplayName sql.NullString
+	var title, permalink, guildID, channelID, sourceMsgID, sourceChannelID, authorDisplayName sql.NullString
 	var deletedAt sql.NullTime
 
 	err = r.db.QueryRowContext(ctx, query, id).Scan(
-		&note.ID, &title, &note.Body, &note.AuthorID, &guildID,
+		&note.ID, &title, &permalink, &note.Body, &note.AuthorID, &guildID,
 		&channelID, &sourceMsgID, &sourceChannelID, &tags,
 		&note.CreatedAt, &note.UpdatedAt, &deletedAt,
 		&authorDisplayName,
 	)
 	if err == sql.ErrNoRows {
-		err = fmt.Errorf("note not found: %s", id)
+		err = fmt.Errorf("%w: %s", repositories.ErrNoteNotFound, id)
 		return nil, err
 	}
 	if err != nil {
@@ -93,6 +201,7 @@ func (r *noteRepository) GetByID(ctx context.Context, id string, userDiscordID s
 	}
 
 	note.Title = title.String
+	note.Slug = permalink.String
 	note.GuildID = guildID.String
 	note.ChannelID = channelID.String
 	note.SourceMsgID = sourceMsgID.String
@@ -116,14 +225,44 @@ func (r *noteRepository) Update(ctx context.Context, note *entities.Note) error
 
 	note.UpdatedAt = time.Now()
 
+	// The slug follows the title, but a note keeps its current slug (including any
+	// numeric suffix) while that still matches, so its permalink stays put
+	var guildID, currentSlug sql.NullString
+	var authorID string
+	err = r.db.QueryRowContext(ctx, `SELECT guild_id, author_id, slug FROM notes WHERE id = $1 AND deleted_at IS NULL`, note.ID).
+		Scan(&guildID, &authorID, &currentSlug)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("%w: %s", repositories.ErrNoteNotFound, note.ID)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE notes
-		SET title = $2, body = $3, tags = $4, updated_at = $5
+		SET title = $2, slug = $3, body = $4, tags = $5, updated_at = $6, unique_title = CASE WHEN $7 THEN LOWER($2) END
 		WHERE id = $1 AND deleted_at IS NULL
 	`
-	result, err := r.db.ExecContext(ctx, query,
-		note.ID, nullString(note.Title), note.Body, pq.Array(note.Tags), note.UpdatedAt,
-	)
+	base := noteSlug(note.Title)
+	var result sql.Result
+	for attempt := 0; attempt < maxPermalinkAttempts; attempt++ {
+		if attempt == 0 && hasSlugBase(currentSlug.String, base) {
+			note.Slug = currentSlug.String
+		} else if note.Slug, err = r.freeSlug(ctx, note.ID, guildID.String, authorID, base); err != nil {
+			return err
+		}
+		result, err = r.db.ExecContext(ctx, query,
+			note.ID, nullString(note.Title), note.Slug, note.Body, pq.Array(note.Tags), note.UpdatedAt, note.UniqueTitle,
+		)
+		if !isUniqueViolation(err) || isNoteTitleTaken(err) {
+			break
+		}
+	}
+	if isNoteTitleTaken(err) {
+		err = r.titleTakenError(ctx, guildID.String, authorID, note.Title, note.ID)
+		return err
+	}
 	if err != nil {
 		return err
 	}
@@ -133,13 +272,25 @@ func (r *noteRepository) Update(ctx context.Context, note *entities.Note) error
 		return err
 	}
 	if rowsAffected == 0 {
-		err = fmt.Errorf("note not found: %s", note.ID)
+		err = fmt.Errorf("%w: %s", repositories.ErrNoteNotFound, note.ID)
 		return err
 	}
 
 	return nil
 }
 
+func (r *noteRepository) IDExists(ctx context.Context, id string) (bool, error) {
+	start := time.Now()
+	var err error
+	defer func() {
+		metrics.RecordDBOperation("note", "id_exists", time.Since(start), -1, err)
+	}()
+
+	var exists bool
+	err = r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM notes WHERE id = $1)`, id).Scan(&exists)
+	return exists, err
+}
+
 func (r *noteRepository) Delete(ctx context.Context, id string) error {
 	start := time.Now()
 	var err error
@@ -163,7 +314,7 @@ func (r *noteRepository) Delete(ctx context.Context, id string) error {
 		return err
 	}
 	if rowsAffected == 0 {
-		err = fmt.Errorf("note not found: %s", id)
+		err = fmt.Errorf("%w: %s", repositories.ErrNoteNotFound, id)
 		return err
 	}
 
@@ -235,8 +386,8 @@ func (r *noteRepository) List(ctx context.Context, authorID, guildID string, tag
 
 	// Get notes
 	query := fmt.Sprintf(`
-		SELECT n.id, n.title, n.body, n.author_id, n.guild_id, dg.guild_name, n.channel_id, n.source_msg_id, n.source_channel_id, n.tags, n.created_at, n.updated_at,
-		       udn.display_name
+		SELECT n.id, n.title, n.slug, n.body, n.author_id, n.guild_id, dg.guild_name, n.channel_id, n.source_msg_id, n.source_channel_id, n.tags, n.created_at, n.updated_at,
+		       udn.display_name, %s, %s
 		FROM %s
 		LEFT JOIN discord_guilds dg ON n.guild_id = dg.guild_id
 		LEFT JOIN users u ON n.author_id = u.id
@@ -245,7 +396,7 @@ func (r *noteRepository) List(ctx context.Context, authorID, guildID string, tag
 		WHERE %s
 		ORDER BY n.%s %s
 		LIMIT $%d OFFSET $%d
-	`, fromClause, whereClause, orderBy, direction, argCount+1, argCount+2)
+	`, contentSizeExpr("n"), referenceCountExpr("note_message_references", "note_id", "n"), fromClause, whereClause, orderBy, direction, argCount+1, argCount+2)
 
 	args = append(args, limit, offset)
 	r.log.Debug("executing select query", slog.String("query", query), slog.Any("args", args))
@@ -260,19 +411,20 @@ func (r *noteRepository) List(ctx context.Context, authorID, guildID string, tag
 	for rows.Next() {
 		note := &entities.Note{}
 		var tags pq.StringArray
-		var title, guildID, guildName, channelID, sourceMsgID, sourceChannelID, authorDisplayName sql.NullString
+		var title, permalink, guildID, guildName, channelID, sourceMsgID, sourceChannelID, authorDisplayName sql.NullString
 
 		err := rows.Scan(
-			&note.ID, &title, &note.Body, &note.AuthorID, &guildID, &guildName,
+			&note.ID, &title, &permalink, &note.Body, &note.AuthorID, &guildID, &guildName,
 			&channelID, &sourceMsgID, &sourceChannelID, &tags,
 			&note.CreatedAt, &note.UpdatedAt,
-			&authorDisplayName,
+			&authorDisplayName, &note.BodyLength, &note.TagCount, &note.ReferenceCount,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
 
 		note.Title = title.String
+		note.Slug = permalink.String
 		note.GuildID = guildID.String
 		note.GuildName = guildName.String
 		note.ChannelID = channelID.String
@@ -287,7 +439,7 @@ func (r *noteRepository) List(ctx context.Context, authorID, guildID string, tag
 	return notes, total, nil
 }
 
-func (r *noteRepository) Search(ctx context.Context, authorID string, query, guildID string, tags []string, limit, offset int, userDiscordID string) ([]*entities.Note, int, error) {
+func (r *noteRepository) Search(ctx context.Context, authorID string, query string, guildIDs []string, tags []string, authorDiscordID string, limit, offset int, cursor *repositories.PageCursor, orderBy string, ascending bool, userDiscordID string) ([]*entities.Note, int, *repositories.PageCursor, error) {
 	start := time.Now()
 	var err error
 	var rowCount int64
@@ -305,18 +457,26 @@ func (r *noteRepository) Search(ctx context.Context, authorID string, query, gui
 	args := []interface{}{authorID}
 	argCount := 1
 
-	// Full-text search on title and body using ILIKE (search_vector column doesn't exist)
-	if query != "" {
+	// Full-text search on title and body, falling back to ILIKE when the
+	// query has no token long enough for the full-text parser
+	fullText := query != "" && useFullTextSearch(query)
+	var queryParamPos int
+	if fullText {
+		argCount++
+		queryParamPos = argCount
+		conditions = append(conditions, searchMatchExpr("n.search_vector", argCount))
+		args = append(args, query)
+	} else if query != "" {
 		argCount++
 		conditions = append(conditions, fmt.Sprintf("(n.title ILIKE $%d OR n.body ILIKE $%d)", argCount, argCount))
-		args = append(args, "%"+query+"%")
+		args = append(args, ilikePattern(query))
 	}
 
 	// Guild filtering
-	if guildID != "" {
+	if len(guildIDs) > 0 {
 		argCount++
-		conditions = append(conditions, fmt.Sprintf("n.guild_id = $%d", argCount))
-		args = append(args, guildID)
+		conditions = append(conditions, guildFilterExpr("n.guild_id", argCount))
+		args = append(args, pq.Array(guildIDs))
 
 		// Add ACL check only when filtering by guild (ensure user is a member)
 		if userDiscordID != "" {
@@ -335,64 +495,109 @@ func (r *noteRepository) Search(ctx context.Context, authorID string, query, gui
 		args = append(args, pq.Array(tags))
 	}
 
+	// Author filtering
+	if authorDiscordID != "" {
+		argCount++
+		conditions = append(conditions, authorFilterExpr("n.author_id", argCount))
+		args = append(args, authorDiscordID)
+	}
+
 	whereClause := strings.Join(conditions, " AND ")
 
-	// Get total count
+	// Get total count (of all results, not just those after the cursor)
 	var total int
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", fromClause, whereClause)
 	if err2 := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err2 != nil {
 		err = err2
-		return nil, 0, err
+		return nil, 0, nil, err
+	}
+
+	// Get notes with ranking and snippet
+	rankClause := "0::real"
+	snippetClause := "''"
+	var rankExpr string
+	if fullText {
+		rankExpr = searchRankExpr("n.search_vector", queryParamPos)
+		rankClause = rankExpr
+		snippetClause = searchHeadlineExpr("n.body", queryParamPos)
+	}
+	order := searchKeysetOrder("n", orderBy, ascending, rankExpr)
+
+	// A cursor replaces the offset: continue after the previous page's last row
+	if cursor != nil {
+		var after string
+		var afterArgs []interface{}
+		after, afterArgs, err = order.afterCondition(cursor, argCount+1)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		whereClause += " AND " + after
+		args = append(args, afterArgs...)
+		argCount += len(afterArgs)
+		offset = 0
 	}
 
-	// Get notes (always order by created_at since we don't have search_vector for ranking)
 	searchQuery := fmt.Sprintf(`
-		SELECT n.id, n.title, n.body, n.author_id, n.guild_id, n.channel_id, n.source_msg_id, n.source_channel_id, n.tags, n.created_at, n.updated_at,
-		       udn.display_name
+		SELECT n.id, n.title, n.slug, n.body, n.author_id, n.guild_id, n.channel_id, n.source_msg_id, n.source_channel_id, n.tags, n.created_at, n.updated_at,
+		       udn.display_name, %s, %s, %s AS rank, %s AS snippet, %s AS sort_keys
 		FROM %s
 		LEFT JOIN users u ON n.author_id = u.id
 		LEFT JOIN discord_users du ON u.id = du.user_id
 		LEFT JOIN user_display_names udn ON du.discord_id = udn.discord_id AND n.guild_id = udn.guild_id
 		WHERE %s
-		ORDER BY n.created_at DESC
+		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, fromClause, whereClause, argCount+1, argCount+2)
+	`, contentSizeExpr("n"), referenceCountExpr("note_message_references", "note_id", "n"), rankClause, snippetClause, order.keysExpr(), fromClause, whereClause, order.orderClause(), argCount+1, argCount+2)
 
-	args = append(args, limit, offset)
+	// Fetch one extra row to tell whether there is a next page
+	args = append(args, limit+1, offset)
 
 	rows, err := r.db.QueryContext(ctx, searchQuery, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 	defer rows.Close()
 
 	notes := []*entities.Note{}
+	var rowKeys []pq.StringArray
 	for rows.Next() {
 		note := &entities.Note{}
 		var tagArray pq.StringArray
-		var title, guildID, channelID, sourceMsgID, sourceChannelID, authorDisplayName sql.NullString
+		var title, permalink, guildID, channelID, sourceMsgID, sourceChannelID, authorDisplayName sql.NullString
+		var sortKeys pq.StringArray
 
 		err := rows.Scan(
-			&note.ID, &title, &note.Body, &note.AuthorID, &guildID,
+			&note.ID, &title, &permalink, &note.Body, &note.AuthorID, &guildID,
 			&channelID, &sourceMsgID, &sourceChannelID, &tagArray,
 			&note.CreatedAt, &note.UpdatedAt,
-			&authorDisplayName,
+			&authorDisplayName, &note.BodyLength, &note.TagCount, &note.ReferenceCount, &note.Rank, &note.Snippet, &sortKeys,
 		)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, nil, err
 		}
 
 		note.Title = title.String
+		note.Slug = permalink.String
 		note.GuildID = guildID.String
 		note.ChannelID = channelID.String
 		note.SourceMsgID = sourceMsgID.String
 		note.SourceChannelID = sourceChannelID.String
 		note.AuthorDisplayName = authorDisplayName.String
 		note.Tags = tagArray
+		if query != "" && !fullText {
+			note.Snippet = fallbackSnippet(note.Body, query)
+		}
 		notes = append(notes, note)
+		rowKeys = append(rowKeys, sortKeys)
 	}
 
-	return notes, total, nil
+	next := order.nextCursor(rowKeys, limit)
+	if len(notes) > limit {
+		notes = notes[:limit]
+	}
+
+	rowCount = int64(len(notes))
+	return notes, total, next, nil
 }
 
 // GetTitlesForUser retrieves only the ID and title of all notes visible to a user in a guild (lightweight for autocomplete)
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// TestNoteUniqueTitleIndex saves notes into a temporary table that shadows notes,
+// with the unique title index. It needs a real PostgreSQL server and is skipped
+// unless HIVEMIND_TEST_DATABASE_URL is set.
+func TestNoteUniqueTitleIndex(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	fixture := `
+		CREATE TEMP TABLE notes (
+			id TEXT PRIMARY KEY, title TEXT, slug TEXT, body TEXT NOT NULL, author_id TEXT NOT NULL,
+			guild_id TEXT, channel_id TEXT, source_msg_id TEXT, source_channel_id TEXT, tags TEXT[],
+			created_at TIMESTAMP, updated_at TIMESTAMP, deleted_at TIMESTAMP, unique_title TEXT
+		);
+		CREATE UNIQUE INDEX idx_notes_unique_title ON notes (guild_id, author_id, unique_title)
+		WHERE deleted_at IS NULL AND unique_title IS NOT NULL`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	repo := NewNoteRepository(db)
+	ctx := context.Background()
+	note := func(id, author, title string, unique bool) *entities.Note {
+		return &entities.Note{ID: id, AuthorID: author, GuildID: "g1", Title: title, Body: "Eggs", UniqueTitle: unique}
+	}
+
+	// Unique saves conflict with each other ignoring case, but not with other authors'
+	// notes or saves made while the guild allowed duplicates
+	steps := []struct {
+		name      string
+		note      *entities.Note
+		update    bool
+		wantTaken string
+	}{
+		{name: "first", note: note("n1", "u1", "Groceries", true)},
+		{name: "same title", note: note("n2", "u1", "GROCERIES", true), wantTaken: "n1"},
+		{name: "other author", note: note("n3", "u2", "Groceries", true)},
+		{name: "duplicates allowed", note: note("n4", "u1", "Groceries", false)},
+		{name: "other title", note: note("n5", "u1", "Chores", true)},
+		{name: "rename onto taken title", note: note("n5", "u1", "groceries", true), update: true, wantTaken: "n1"},
+		{name: "keep own title", note: note("n1", "u1", "Groceries", true), update: true},
+	}
+
+	for _, step := range steps {
+		if step.update {
+			err = repo.Update(ctx, step.note)
+		} else {
+			err = repo.Create(ctx, step.note)
+		}
+
+		if step.wantTaken == "" {
+			if err != nil {
+				t.Fatalf("%s: save error = %v", step.name, err)
+			}
+			continue
+		}
+		var taken *repositories.NoteTitleTakenError
+		if !errors.As(err, &taken) {
+			t.Fatalf("%s: save error = %v, want NoteTitleTakenError", step.name, err)
+		}
+		// Duplicates saved while allowed are not in the index, so either match is fine
+		if taken.ExistingID != step.wantTaken && taken.ExistingID != "n4" {
+			t.Errorf("%s: existing note = %q, want %q", step.name, taken.ExistingID, step.wantTaken)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"crypto/rand"
+	"strconv"
+	"strings"
+
+	"github.com/gosimple/slug"
+)
+
+const (
+	// maxNoteSlugLength caps slugs from long titles so permalinks stay readable
+	maxNoteSlugLength = 80
+
+	// shortCodeLength is the length of a quote's permalink code
+	shortCodeLength = 8
+
+	// shortCodeAlphabet avoids characters that are easy to misread in a URL
+	shortCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+	// maxPermalinkAttempts bounds retries when a concurrent save takes the same slug or code
+	maxPermalinkAttempts = 3
+)
+
+// noteSlug derives a note's permalink slug from its title. Untitled notes, and titles
+// with nothing URL-safe in them, fall back to "note".
+func noteSlug(title string) string {
+	s := slug.Make(title)
+	if len(s) > maxNoteSlugLength {
+		s = strings.TrimRight(s[:maxNoteSlugLength], "-_")
+	}
+	if s == "" {
+		return "note"
+	}
+	return s
+}
+
+// nextFreeSlug returns base if it is not taken, otherwise base with the lowest
+// numeric suffix ("base-2", "base-3", ...) that is not taken
+func nextFreeSlug(base string, taken map[string]bool) string {
+	if !taken[base] {
+		return base
+	}
+	for n := 2; ; n++ {
+		candidate := base + "-" + strconv.Itoa(n)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+// hasSlugBase reports whether s is base or base with a suffix added by nextFreeSlug,
+// meaning a note keeping that slug still matches its title
+func hasSlugBase(s, base string) bool {
+	if s == base {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(s, base+"-")
+	if !ok {
+		return false
+	}
+	n, err := strconv.Atoi(suffix)
+	return err == nil && n >= 2 && strconv.Itoa(n) == suffix
+}
+
+// newShortCode returns a random quote permalink code
+func newShortCode() string {
+	buf := make([]byte, shortCodeLength)
+	rand.Read(buf) // Never fails as of Go 1.24
+	for i, b := range buf {
+		buf[i] = shortCodeAlphabet[int(b)%len(shortCodeAlphabet)]
+	}
+	return string(buf)
+}
+
+// likePrefix escapes s for use as a literal prefix in a LIKE pattern
+func likePrefix(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s) + "%"
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNoteSlug(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Raid Plan", want: "raid-plan"},
+		{title: "  Raid   plan!! (v2) ", want: "raid-plan-v2"},
+		{title: "Café notes", want: "cafe-notes"},
+		{title: "", want: "note"},
+		{title: "!!!", want: "note"},
+	}
+
+	for _, tt := range tests {
+		if got := noteSlug(tt.title); got != tt.want {
+			t.Errorf("noteSlug(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+
+	long := noteSlug(strings.Repeat("word ", 40))
+	if len(long) > maxNoteSlugLength || strings.HasSuffix(long, "-") {
+		t.Errorf("noteSlug(long title) = %q, want at most %d characters without a trailing dash", long, maxNoteSlugLength)
+	}
+}
+
+func TestNextFreeSlug(t *testing.T) {
+	tests := []struct {
+		name  string
+		taken []string
+		want  string
+	}{
+		{name: "free", want: "raid-plan"},
+		{name: "taken", taken: []string{"raid-plan"}, want: "raid-plan-2"},
+		{name: "suffixes taken", taken: []string{"raid-plan", "raid-plan-2", "raid-plan-3"}, want: "raid-plan-4"},
+		{name: "gap reused", taken: []string{"raid-plan", "raid-plan-3"}, want: "raid-plan-2"},
+		{name: "only suffix taken", taken: []string{"raid-plan-2"}, want: "raid-plan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taken := map[string]bool{}
+			for _, s := range tt.taken {
+				taken[s] = true
+			}
+			if got := nextFreeSlug("raid-plan", taken); got != tt.want {
+				t.Errorf("nextFreeSlug() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasSlugBase(t *testing.T) {
+	tests := []struct {
+		slug string
+		want bool
+	}{
+		{slug: "raid-plan", want: true},
+		{slug: "raid-plan-2", want: true},
+		{slug: "raid-plan-12", want: true},
+		{slug: "raid-plan-1", want: false},
+		{slug: "raid-plan-02", want: false},
+		{slug: "raid-plan-v2", want: false},
+		{slug: "raid", want: false},
+		{slug: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := hasSlugBase(tt.slug, "raid-plan"); got != tt.want {
+			t.Errorf("hasSlugBase(%q, %q) = %v, want %v", tt.slug, "raid-plan", got, tt.want)
+		}
+	}
+}
+
+func TestNewShortCode(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		code := newShortCode()
+		if len(code) != shortCodeLength {
+			t.Fatalf("newShortCode() = %q, want %d characters", code, shortCodeLength)
+		}
+		if strings.Trim(code, shortCodeAlphabet) != "" {
+			t.Fatalf("newShortCode() = %q, want only %q", code, shortCodeAlphabet)
+		}
+		seen[code] = true
+	}
+	if len(seen) < 100 {
+		t.Errorf("got %d distinct codes from 100 draws", len(seen))
+	}
+}
+
+func TestLikePrefix(t *testing.T) {
+	if got, want := likePrefix(`a_b%c\-`), `a\_b\%c\\-%`; got != want {
+		t.Errorf("likePrefix() = %q, want %q", got, want)
+	}
+}
@@ -41,12 +41,74 @@ func (r *quoteRepository) Create(ctx context.Context, quote *entities.Quote) err
 	}
 	quote.CreatedAt = time.Now()
 
-	query := `
-		INSERT INTO quotes (id, body, author_id, author_discord_id, guild_id, source_msg_id, source_channel_id, source_channel_name, source_msg_author_discord_id, source_msg_author_username, source_msg_timestamp, tags, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-	`
-	_, err = r.db.ExecContext(ctx, query,
-		quote.ID, quote.Body, quote.AuthorID, quote.AuthorDiscordID, quote.GuildID,
+	// A fresh code clashes with one already in the guild only by rare chance; draw again
+	for attempt := 0; attempt < maxPermalinkAttempts; attempt++ {
+		quote.ShortCode = newShortCode()
+		if quote.Cooldown > 0 {
+			err = r.createAfterCooldown(ctx, quote)
+		} else {
+			err = insertQuote(ctx, r.db, quote)
+		}
+		if !isUniqueViolation(err) {
+			break
+		}
+	}
+	return err
+}
+
+// quoteCooldownLockSpace is the first key of the advisory locks held while a quote
+// is saved under a cooldown, keeping them apart from the job and node ID locks
+const quoteCooldownLockSpace = 0x48565143 // "HVQC"
+
+// quoteLastCreatedQuery finds when author $2 last saved a quote in guild $1. Deleted
+// quotes count, so deleting one doesn't reset a cooldown.
+const quoteLastCreatedQuery = `
+	SELECT MAX(created_at)
+	FROM quotes
+	WHERE guild_id = $1
+	  AND author_id = $2`
+
+// createAfterCooldown inserts quote unless its author saved another quote in the guild
+// within quote.Cooldown. The author's saves in the guild are serialized by an advisory
+// lock, so two at once can't both pass the check.
+func (r *quoteRepository) createAfterCooldown(ctx context.Context, quote *entities.Quote) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2 || ':' || $3))`,
+		quoteCooldownLockSpace, quote.GuildID, quote.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	var last sql.NullTime
+	if err := tx.QueryRowContext(ctx, quoteLastCreatedQuery, quote.GuildID, quote.AuthorID).Scan(&last); err != nil {
+		return err
+	}
+	if remaining := quote.Cooldown - quote.CreatedAt.Sub(last.Time); last.Valid && remaining > 0 {
+		return &repositories.QuoteCooldownError{Remaining: remaining}
+	}
+
+	if err := insertQuote(ctx, tx, quote); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// execer runs statements on a *sql.DB or in a *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// insertQuote inserts quote with db, which may be a transaction
+func insertQuote(ctx context.Context, db execer, quote *entities.Quote) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO quotes (id, short_code, body, author_id, author_discord_id, guild_id, source_msg_id, source_channel_id, source_channel_name, source_msg_author_discord_id, source_msg_author_username, source_msg_timestamp, tags, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $14)`,
+		quote.ID, quote.ShortCode, quote.Body, quote.AuthorID, quote.AuthorDiscordID, quote.GuildID,
 		quote.SourceMsgID, quote.SourceChannelID, quote.SourceChannelName, quote.SourceMsgAuthorDiscordID,
 		quote.SourceMsgAuthorUsername, quote.SourceMsgTimestamp, pq.Array(quote.Tags), quote.CreatedAt,
 	)
@@ -61,11 +123,11 @@ func (r *quoteRepository) GetByID(ctx context.Context, id string, userDiscordID
 	}()
 
 	query := `
-		SELECT q.id, q.body, q.author_id, q.author_discord_id, u.name, q.guild_id, dg.guild_name,
+		SELECT q.id, q.short_code, q.body, q.author_id, q.author_discord_id, u.name, q.guild_id, dg.guild_name,
 		       q.source_msg_id, q.source_channel_id, q.source_channel_name,
 		       q.source_msg_author_discord_id, q.source_msg_author_username, q.source_msg_timestamp, q.tags, q.created_at, q.deleted_at,
 		       udn_author.display_name, udn_author.guild_nick, udn_author.guild_avatar_hash, udn_author.user_avatar_hash,
-		       udn_source.display_name, udn_source.guild_nick, udn_source.guild_avatar_hash, udn_source.user_avatar_hash
+		       ` + quoteSourceAuthorNames + `, udn_source.guild_avatar_hash, udn_source.user_avatar_hash
 		FROM quotes q
 		LEFT JOIN discord_guilds dg ON q.guild_id = dg.guild_id
 		LEFT JOIN users u ON q.author_id = u.id
@@ -83,6 +145,7 @@ func (r *quoteRepository) GetByID(ctx context.Context, id string, userDiscordID
 
 	quote := &entities.Quote{}
 	var tags pq.StringArray
+	var shortCode sql.NullString
 	var guildName, authorUsername, sourceChannelName, sourceMsgAuthorUsername sql.NullString
 	var authorDisplayName, authorGuildNick, authorGuildAvatarHash, authorUserAvatarHash sql.NullString
 	var sourceAuthorDisplayName, sourceAuthorGuildNick, sourceAuthorGuildAvatarHash, sourceAuthorUserAvatarHash sql.NullString
@@ -92,7 +155,7 @@ func (r *quoteRepository) GetByID(ctx context.Context, id string, userDiscordID
 	var sourceMsgTimestamp sql.NullTime
 	if userDiscordID != "" {
 		err = r.db.QueryRowContext(ctx, query, id, userDiscordID).Scan(
-			&quote.ID, &quote.Body, &quote.AuthorID, &authorDiscordID, &authorUsername, &quote.GuildID, &guildName,
+			&quote.ID, &shortCode, &quote.Body, &quote.AuthorID, &authorDiscordID, &authorUsername, &quote.GuildID, &guildName,
 			&quote.SourceMsgID, &quote.SourceChannelID, &sourceChannelName,
 			&quote.SourceMsgAuthorDiscordID, &sourceMsgAuthorUsername,
 			&sourceMsgTimestamp, &tags, &quote.CreatedAt, &deletedAt,
@@ -101,7 +164,7 @@ func (r *quoteRepository) GetByID(ctx context.Context, id string, userDiscordID
 		)
 	} else {
 		err = r.db.QueryRowContext(ctx, query, id).Scan(
-			&quote.ID, &quote.Body, &quote.AuthorID, &authorDiscordID, &authorUsername, &quote.GuildID, &guildName,
+			&quote.ID, &shortCode, &quote.Body, &quote.AuthorID, &authorDiscordID, &authorUsername, &quote.GuildID, &guildName,
 			&quote.SourceMsgID, &quote.SourceChannelID, &sourceChannelName,
 			&quote.SourceMsgAuthorDiscordID, &sourceMsgAuthorUsername,
 			&sourceMsgTimestamp, &tags, &quote.CreatedAt, &deletedAt,
@@ -118,6 +181,7 @@ func (r *quoteRepository) GetByID(ctx context.Context, id string, userDiscordID
 	}
 
 	quote.AuthorDiscordID = authorDiscordID.String
+	quote.ShortCode = shortCode.String
 	quote.GuildName = guildName.String
 	quote.AuthorUsername = authorUsername.String
 	quote.AuthorDisplayName = authorDisplayName.String
@@ -141,6 +205,104 @@ func (r *quoteRepository) GetByID(ctx context.Context, id string, userDiscordID
 	return quote, nil
 }
 
+func (r *quoteRepository) IDExists(ctx context.Context, id string) (bool, error) {
+	start := time.Now()
+	var err error
+	defer func() {
+		metrics.RecordDBOperation("quote", "id_exists", time.Since(start), -1, err)
+	}()
+
+	var exists bool
+	err = r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM quotes WHERE id = $1)`, id).Scan(&exists)
+	return exists, err
+}
+
+// quoteSourceAuthorNames selects the display name and guild nickname of whoever a quote is
+// attributed to. A corrected attribution replaces the Discord user's names while keeping
+// their ID, so the quote still counts toward their stats.
+const quoteSourceAuthorNames = `COALESCE(q.source_msg_author_override, udn_source.display_name),
+		       CASE WHEN q.source_msg_author_override IS NULL THEN udn_source.guild_nick END`
+
+// quoteDuplicateQuery finds a live quote in guild $1 from source message $2 (if not
+// empty) or whose body matches $3 after trimming, collapsing whitespace, and lowercasing.
+// A same-message match wins over a same-text one.
+const quoteDuplicateQuery = `
+	SELECT id
+	FROM quotes
+	WHERE guild_id = $1
+	  AND deleted_at IS NULL
+	  AND ((source_msg_id = $2 AND $2 <> '')
+	       OR lower(regexp_replace(btrim(body), '\s+', ' ', 'g')) = lower(regexp_replace(btrim($3), '\s+', ' ', 'g')))
+	ORDER BY (source_msg_id = $2 AND $2 <> '') DESC, created_at
+	LIMIT 1`
+
+func (r *quoteRepository) FindDuplicateID(ctx context.Context, guildID, sourceMsgID, body string) (string, error) {
+	start := time.Now()
+	var err error
+	defer func() {
+		metrics.RecordDBOperation("quote", "find_duplicate", time.Since(start), -1, err)
+	}()
+
+	var id string
+	err = r.db.QueryRowContext(ctx, quoteDuplicateQuery, guildID, sourceMsgID, body).Scan(&id)
+	if err == sql.ErrNoRows {
+		err = nil
+		return "", nil
+	}
+	return id, err
+}
+
+// quoteBySourceMessageQuery finds the oldest live quote in guild $1 saved from
+// source message $2, using idx_quotes_guild_source_msg
+const quoteBySourceMessageQuery = `
+	SELECT id
+	FROM quotes
+	WHERE guild_id = $1
+	  AND source_msg_id = $2
+	  AND deleted_at IS NULL
+	ORDER BY created_at
+	LIMIT 1`
+
+func (r *quoteRepository) FindIDBySourceMessage(ctx context.Context, guildID, sourceMsgID string) (string, error) {
+	start := time.Now()
+	var err error
+	defer func() {
+		metrics.RecordDBOperation("quote", "find_by_source_message", time.Since(start), -1, err)
+	}()
+
+	var id string
+	err = r.db.QueryRowContext(ctx, quoteBySourceMessageQuery, guildID, sourceMsgID).Scan(&id)
+	if err == sql.ErrNoRows {
+		err = nil
+		return "", nil
+	}
+	return id, err
+}
+
+// quoteByShortCodeQuery finds the live quote in guild $1 with short code $2
+const quoteByShortCodeQuery = `
+	SELECT id
+	FROM quotes
+	WHERE guild_id = $1
+	  AND short_code = $2
+	  AND deleted_at IS NULL`
+
+func (r *quoteRepository) FindIDByShortCode(ctx context.Context, guildID, code string) (string, error) {
+	start := time.Now()
+	var err error
+	defer func() {
+		metrics.RecordDBOperation("quote", "find_by_short_code", time.Since(start), -1, err)
+	}()
+
+	var id string
+	err = r.db.QueryRowContext(ctx, quoteByShortCodeQuery, guildID, code).Scan(&id)
+	if err == sql.ErrNoRows {
+		err = nil
+		return "", nil
+	}
+	return id, err
+}
+
 func (r *quoteRepository) Delete(ctx context.Context, id string) error {
 	start := time.Now()
 	var err error
@@ -171,7 +333,7 @@ func (r *quoteRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *quoteRepository) Update(ctx context.Context, id, body string, tags []string) error {
+func (r *quoteRepository) Update(ctx context.Context, id, body string, tags []string, attribution string) error {
 	start := time.Now()
 	var err error
 	var rowsAffected int64
@@ -181,10 +343,11 @@ func (r *quoteRepository) Update(ctx context.Context, id, body string, tags []st
 
 	query := `
 		UPDATE quotes
-		SET body = $2, tags = $3
+		SET body = $2, tags = $3, updated_at = $4,
+		    source_msg_author_override = COALESCE(NULLIF($5, ''), source_msg_author_override)
 		WHERE id = $1 AND deleted_at IS NULL
 	`
-	result, err := r.db.ExecContext(ctx, query, id, body, pq.Array(tags))
+	result, err := r.db.ExecContext(ctx, query, id, body, pq.Array(tags), time.Now(), attribution)
 	if err != nil {
 		return err
 	}
@@ -270,16 +433,17 @@ func (r *quoteRepository) List(ctx context.Context, guildID string, limit, offse
 
 	// Get quotes
 	query := fmt.Sprintf(`
-		SELECT q.id, q.body, q.author_id, q.author_discord_id, u.name, q.guild_id, dg.guild_name, 
+		SELECT q.id, q.short_code, q.body, q.author_id, q.author_discord_id, u.name, q.guild_id, dg.guild_name, 
 		       q.source_msg_id, q.source_channel_id, q.source_channel_name,
 		       q.source_msg_author_discord_id, q.source_msg_author_username, q.source_msg_timestamp, q.tags, q.created_at,
 		       udn_author.display_name, udn_author.guild_nick, udn_author.guild_avatar_hash, udn_author.user_avatar_hash,
-		       udn_source.display_name, udn_source.guild_nick, udn_source.guild_avatar_hash, udn_source.user_avatar_hash
+		       %s, udn_source.guild_avatar_hash, udn_source.user_avatar_hash,
+		       %s
 		%s
 		WHERE %s
 		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, baseFrom, whereClause, orderClause, argCount+1, argCount+2)
+	`, quoteSourceAuthorNames, contentSizeExpr("q"), baseFrom, whereClause, orderClause, argCount+1, argCount+2)
 
 	r.log.Debug("executing select query",
 		slog.String("query", query),
@@ -297,24 +461,27 @@ func (r *quoteRepository) List(ctx context.Context, guildID string, limit, offse
 	for rows.Next() {
 		quote := &entities.Quote{}
 		var tags pq.StringArray
+		var shortCode sql.NullString
 		var guildName, authorDiscordID, authorUsername, sourceChannelName, sourceMsgAuthorUsername sql.NullString
 		var authorDisplayName, authorGuildNick, authorGuildAvatarHash, authorUserAvatarHash sql.NullString
 		var sourceAuthorDisplayName, sourceAuthorGuildNick, sourceAuthorGuildAvatarHash, sourceAuthorUserAvatarHash sql.NullString
 		var sourceMsgTimestamp sql.NullTime
 
 		err := rows.Scan(
-			&quote.ID, &quote.Body, &quote.AuthorID, &authorDiscordID, &authorUsername, &quote.GuildID, &guildName,
+			&quote.ID, &shortCode, &quote.Body, &quote.AuthorID, &authorDiscordID, &authorUsername, &quote.GuildID, &guildName,
 			&quote.SourceMsgID, &quote.SourceChannelID, &sourceChannelName,
 			&quote.SourceMsgAuthorDiscordID, &sourceMsgAuthorUsername,
 			&sourceMsgTimestamp, &tags, &quote.CreatedAt,
 			&authorDisplayName, &authorGuildNick, &authorGuildAvatarHash, &authorUserAvatarHash,
 			&sourceAuthorDisplayName, &sourceAuthorGuildNick, &sourceAuthorGuildAvatarHash, &sourceAuthorUserAvatarHash,
+			&quote.BodyLength, &quote.TagCount,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
 
 		quote.AuthorDiscordID = authorDiscordID.String
+		quote.ShortCode = shortCode.String
 		quote.GuildName = guildName.String
 		quote.AuthorUsername = authorUsername.String
 		quote.AuthorDisplayName = authorDisplayName.String
@@ -338,7 +505,7 @@ func (r *quoteRepository) List(ctx context.Context, guildID string, limit, offse
 	return quotes, total, nil
 }
 
-func (r *quoteRepository) Search(ctx context.Context, guildID, query string, tags []string, limit, offset int, userDiscordID string) ([]*entities.Quote, int, error) {
+func (r *quoteRepository) Search(ctx context.Context, guildIDs []string, query string, tags []string, limit, offset int, orderBy string, ascending bool, userDiscordID string) ([]*entities.Quote, int, error) {
 	start := time.Now()
 	var err error
 	var rowCount int64
@@ -351,9 +518,14 @@ func (r *quoteRepository) Search(ctx context.Context, guildID, query string, tag
 	}
 
 	// Build search conditions and args first
-	conditions := []string{"q.guild_id = $1", "q.deleted_at IS NULL"}
-	args := []interface{}{guildID}
-	argCount := 1
+	conditions := []string{"q.deleted_at IS NULL"}
+	args := []interface{}{}
+	argCount := 0
+	if len(guildIDs) > 0 {
+		argCount++
+		conditions = append(conditions, guildFilterExpr("q.guild_id", argCount))
+		args = append(args, pq.Array(guildIDs))
+	}
 
 	// Build base FROM clause with JOINs
 	baseFrom := `FROM quotes q
@@ -370,14 +542,20 @@ func (r *quoteRepository) Search(ctx context.Context, guildID, query string, tag
 		args = append(args, userDiscordID)
 	}
 
-	// Full-text search on body
+	// Full-text search on body, falling back to ILIKE when the query has no
+	// token long enough for the full-text parser
+	fullText := query != "" && useFullTextSearch(query)
 	var queryParamPos int
-	if query != "" {
+	if fullText {
 		argCount++
 		queryParamPos = argCount
 		// Use hybrid search vector: searches both english (stemmed, weight A) and simple (literal, weight B)
-		conditions = append(conditions, fmt.Sprintf("(q.search_vector @@ websearch_to_tsquery('english', $%d) OR q.search_vector @@ websearch_to_tsquery('simple', $%d))", argCount, argCount))
+		conditions = append(conditions, searchMatchExpr("q.search_vector", argCount))
 		args = append(args, query)
+	} else if query != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("q.body ILIKE $%d", argCount))
+		args = append(args, ilikePattern(query))
 	}
 
 	// Tag filtering
@@ -397,31 +575,30 @@ func (r *quoteRepository) Search(ctx context.Context, guildID, query string, tag
 		return nil, 0, err
 	}
 
-	// Get quotes with ranking
-	var searchQuery string
-	if query != "" {
-		searchQuery = fmt.Sprintf(`
-			SELECT q.id, q.body, q.author_id, q.author_discord_id, u.name, q.guild_id, dg.guild_name,
-			       q.source_msg_id, q.source_channel_id, q.source_channel_name,
-			       q.source_msg_author_discord_id, q.source_msg_author_username, q.source_msg_timestamp, q.tags, q.created_at,
-			       udn_author.display_name, udn_author.guild_nick, udn_source.display_name, udn_source.guild_nick
-			%s
-			WHERE %s
-			ORDER BY ts_rank(q.search_vector, websearch_to_tsquery('english', $%d)) DESC
-			LIMIT $%d OFFSET $%d
-		`, baseFrom, whereClause, queryParamPos, argCount+1, argCount+2)
-	} else {
-		searchQuery = fmt.Sprintf(`
-			SELECT q.id, q.body, q.author_id, q.author_discord_id, u.name, q.guild_id, dg.guild_name,
-			       q.source_msg_id, q.source_channel_id, q.source_channel_name,
-			       q.source_msg_author_discord_id, q.source_msg_author_username, q.source_msg_timestamp, q.tags, q.created_at,
-			       udn_author.display_name, udn_author.guild_nick, udn_source.display_name, udn_source.guild_nick
-			%s
-			WHERE %s
-			ORDER BY created_at DESC
-			LIMIT $%d OFFSET $%d
-		`, baseFrom, whereClause, argCount+1, argCount+2)
+	// Get quotes with ranking and snippet
+	rankClause := "0::real"
+	snippetClause := "''"
+	if fullText {
+		rankClause = searchRankExpr("q.search_vector", queryParamPos)
+		snippetClause = searchHeadlineExpr("q.body", queryParamPos)
+	}
+	// Quote search lists edited quotes where they were first added, so updated_at orders by creation
+	if orderBy == repositories.SearchOrderUpdatedAt {
+		orderBy = repositories.SearchOrderCreatedAt
 	}
+	orderByClause := searchOrderClause("q", orderBy, ascending, fullText)
+
+	searchQuery := fmt.Sprintf(`
+		SELECT q.id, q.short_code, q.body, q.author_id, q.author_discord_id, u.name, q.guild_id, dg.guild_name,
+		       q.source_msg_id, q.source_channel_id, q.source_channel_name,
+		       q.source_msg_author_discord_id, q.source_msg_author_username, q.source_msg_timestamp, q.tags, q.created_at,
+		       udn_author.display_name, udn_author.guild_nick, %s,
+		       %s, %s AS rank, %s AS snippet
+		%s
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, quoteSourceAuthorNames, contentSizeExpr("q"), rankClause, snippetClause, baseFrom, whereClause, orderByClause, argCount+1, argCount+2)
 
 	args = append(args, limit, offset)
 
@@ -435,22 +612,25 @@ func (r *quoteRepository) Search(ctx context.Context, guildID, query string, tag
 	for rows.Next() {
 		quote := &entities.Quote{}
 		var tagArray pq.StringArray
+		var shortCode sql.NullString
 		var guildName, authorDiscordID, authorUsername, sourceChannelName, sourceMsgAuthorUsername sql.NullString
 		var authorDisplayName, authorGuildNick, sourceAuthorDisplayName, sourceAuthorGuildNick sql.NullString
 		var sourceMsgTimestamp sql.NullTime
 
 		err := rows.Scan(
-			&quote.ID, &quote.Body, &quote.AuthorID, &authorDiscordID, &authorUsername, &quote.GuildID, &guildName,
+			&quote.ID, &shortCode, &quote.Body, &quote.AuthorID, &authorDiscordID, &authorUsername, &quote.GuildID, &guildName,
 			&quote.SourceMsgID, &quote.SourceChannelID, &sourceChannelName,
 			&quote.SourceMsgAuthorDiscordID, &sourceMsgAuthorUsername,
 			&sourceMsgTimestamp, &tagArray, &quote.CreatedAt,
 			&authorDisplayName, &authorGuildNick, &sourceAuthorDisplayName, &sourceAuthorGuildNick,
+			&quote.BodyLength, &quote.TagCount, &quote.Rank, &quote.Snippet,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
 
 		quote.AuthorDiscordID = authorDiscordID.String
+		quote.ShortCode = shortCode.String
 		quote.GuildName = guildName.String
 		quote.AuthorUsername = authorUsername.String
 		quote.AuthorDisplayName = authorDisplayName.String
@@ -463,6 +643,9 @@ func (r *quoteRepository) Search(ctx context.Context, guildID, query string, tag
 			quote.SourceMsgTimestamp = sourceMsgTimestamp.Time
 		}
 		quote.Tags = tagArray
+		if query != "" && !fullText {
+			quote.Snippet = fallbackSnippet(quote.Body, query)
+		}
 		quotes = append(quotes, quote)
 	}
 
@@ -490,10 +673,10 @@ func (r *quoteRepository) GetRandom(ctx context.Context, guildID string, tags []
 	whereClause := strings.Join(conditions, " AND ")
 
 	query := fmt.Sprintf(`
-		SELECT q.id, q.body, q.author_id, q.author_discord_id, u.name, q.guild_id, dg.guild_name,
+		SELECT q.id, q.short_code, q.body, q.author_id, q.author_discord_id, u.name, q.guild_id, dg.guild_name,
 		       q.source_msg_id, q.source_channel_id, q.source_channel_name,
 		       q.source_msg_author_discord_id, q.source_msg_author_username, q.source_msg_timestamp, q.tags, q.created_at,
-		       udn_author.display_name, udn_author.guild_nick, udn_source.display_name, udn_source.guild_nick
+		       udn_author.display_name, udn_author.guild_nick, %s
 		FROM quotes q
 		LEFT JOIN discord_guilds dg ON q.guild_id = dg.guild_id
 		LEFT JOIN users u ON q.author_id = u.id
@@ -502,16 +685,17 @@ func (r *quoteRepository) GetRandom(ctx context.Context, guildID string, tags []
 		WHERE %s
 		ORDER BY RANDOM()
 		LIMIT 1
-	`, whereClause)
+	`, quoteSourceAuthorNames, whereClause)
 
 	quote := &entities.Quote{}
 	var tagArray pq.StringArray
+	var shortCode sql.NullString
 	var guildName, authorDiscordID, authorUsername, sourceChannelName, sourceMsgAuthorUsername sql.NullString
 	var authorDisplayName, authorGuildNick, sourceAuthorDisplayName, sourceAuthorGuildNick sql.NullString
 	var sourceMsgTimestamp sql.NullTime
 
 	if err2 := r.db.QueryRowContext(ctx, query, args...).Scan(
-		&quote.ID, &quote.Body, &quote.AuthorID, &authorDiscordID, &authorUsername, &quote.GuildID, &guildName,
+		&quote.ID, &shortCode, &quote.Body, &quote.AuthorID, &authorDiscordID, &authorUsername, &quote.GuildID, &guildName,
 		&quote.SourceMsgID, &quote.SourceChannelID, &sourceChannelName,
 		&quote.SourceMsgAuthorDiscordID, &sourceMsgAuthorUsername,
 		&sourceMsgTimestamp, &tagArray, &quote.CreatedAt,
@@ -524,6 +708,7 @@ func (r *quoteRepository) GetRandom(ctx context.Context, guildID string, tags []
 	}
 
 	quote.AuthorDiscordID = authorDiscordID.String
+	quote.ShortCode = shortCode.String
 	quote.GuildName = guildName.String
 	quote.AuthorUsername = authorUsername.String
 	quote.AuthorDisplayName = authorDisplayName.String
@@ -538,3 +723,93 @@ func (r *quoteRepository) GetRandom(ctx context.Context, guildID string, tags []
 	quote.Tags = tagArray
 	return quote, nil
 }
+
+// quoteLeaderboardQuery counts a guild's non-deleted quotes per distinct value of
+// column, most first with ties broken by ID. The query takes the guild ID as $1,
+// the row limit as $2, and as $3 the earliest created_at to count (NULL counts
+// every quote); from is the quotes relation, aliased q. Users who opted out of
+// leaderboards in user_preferences are skipped before the limit is applied.
+func quoteLeaderboardQuery(from, column string) string {
+	return fmt.Sprintf(`
+		SELECT q.%[2]s, COUNT(*) AS quote_count
+		FROM %[1]s
+		WHERE q.guild_id = $1 AND q.deleted_at IS NULL AND COALESCE(q.%[2]s, '') <> ''
+		  AND ($3::timestamp IS NULL OR q.created_at >= $3)
+		  AND NOT EXISTS (
+			SELECT 1 FROM user_preferences up
+			JOIN discord_users du ON du.user_id = up.user_id
+			WHERE du.discord_id = q.%[2]s AND up.leaderboard_opt_out
+		  )
+		GROUP BY q.%[2]s
+		ORDER BY quote_count DESC, q.%[2]s ASC
+		LIMIT $2
+	`, from, column)
+}
+
+func (r *quoteRepository) Stats(ctx context.Context, guildID string, limit int, since time.Time, userDiscordID string) (*entities.QuoteStats, error) {
+	start := time.Now()
+	var err error
+	defer func() {
+		metrics.RecordDBOperation("quote", "stats", time.Since(start), -1, err)
+	}()
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	stats := &entities.QuoteStats{
+		TopQuoters: []entities.QuoteStatsEntry{},
+		MostQuoted: []entities.QuoteStatsEntry{},
+	}
+
+	// Non-admins only see stats for guilds they belong to
+	if userDiscordID != "" {
+		var member bool
+		err = r.db.QueryRowContext(ctx,
+			`SELECT EXISTS(SELECT 1 FROM guild_members WHERE guild_id = $1 AND discord_id = $2)`,
+			guildID, userDiscordID,
+		).Scan(&member)
+		if err != nil || !member {
+			return stats, err
+		}
+	}
+
+	window := sql.NullTime{Time: since, Valid: !since.IsZero()}
+
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM quotes q
+		 WHERE q.guild_id = $1 AND q.deleted_at IS NULL AND ($2::timestamp IS NULL OR q.created_at >= $2)`,
+		guildID, window,
+	).Scan(&stats.TotalQuotes)
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.TopQuoters, err = r.leaderboard(ctx, "author_discord_id", guildID, limit, window); err != nil {
+		return nil, err
+	}
+	if stats.MostQuoted, err = r.leaderboard(ctx, "source_msg_author_discord_id", guildID, limit, window); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// leaderboard runs quoteLeaderboardQuery against the quotes table
+func (r *quoteRepository) leaderboard(ctx context.Context, column, guildID string, limit int, since sql.NullTime) ([]entities.QuoteStatsEntry, error) {
+	rows, err := r.db.QueryContext(ctx, quoteLeaderboardQuery("quotes q", column), guildID, limit, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []entities.QuoteStatsEntry{}
+	for rows.Next() {
+		var entry entities.QuoteStatsEntry
+		if err := rows.Scan(&entry.DiscordID, &entry.Count); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,321 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// TestQuoteLeaderboardQuery runs the leaderboard aggregation against a small
+// fixture dataset, with temporary tables shadowing the opt-out preferences. It needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestQuoteLeaderboardQuery(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	// bob opted out of leaderboards; carol has preferences but did not
+	prefs := `
+		CREATE TEMP TABLE discord_users (user_id TEXT, discord_id TEXT);
+		CREATE TEMP TABLE user_preferences (user_id TEXT, leaderboard_opt_out BOOLEAN);
+		INSERT INTO discord_users VALUES ('u-bob', 'bob'), ('u-carol', 'carol');
+		INSERT INTO user_preferences VALUES ('u-bob', TRUE), ('u-carol', FALSE)`
+	if _, err := db.Exec(prefs); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	// g1: alice saved 3 (one deleted), bob 2, carol 2; dave was quoted 4 times.
+	// Within the last week only alice (twice) and carol saved quotes, quoting dave
+	// twice and erin once. g2 rows must never be counted for g1.
+	fixture := `(VALUES
+		('g1', 'alice', 'dave', NOW(), NULL::timestamp),
+		('g1', 'alice', 'dave', NOW() - INTERVAL '30 days', NULL),
+		('g1', 'alice', 'erin', NOW() - INTERVAL '1 day', NULL),
+		('g1', 'alice', 'dave', NOW(), TIMESTAMP '2024-01-01'),
+		('g1', 'carol', 'dave', NOW() - INTERVAL '6 days', NULL),
+		('g1', 'carol', 'erin', NOW() - INTERVAL '8 days', NULL),
+		('g1', 'bob', 'dave', NOW() - INTERVAL '9 days', NULL),
+		('g1', 'bob', '', NOW() - INTERVAL '10 days', NULL),
+		('g2', 'zed', 'zed', NOW(), NULL),
+		('g2', 'zed', 'zed', NOW(), NULL),
+		('g2', 'zed', 'zed', NOW(), NULL),
+		('g2', 'zed', 'zed', NOW(), NULL)
+	) AS q(guild_id, author_discord_id, source_msg_author_discord_id, created_at, deleted_at)`
+
+	weekAgo := sql.NullTime{Time: time.Now().Add(-7 * 24 * time.Hour), Valid: true}
+
+	tests := []struct {
+		column string
+		limit  int
+		since  sql.NullTime
+		want   string
+	}{
+		{column: "author_discord_id", limit: 10, want: "alice:3 carol:2"},
+		// Opt-outs are dropped before the limit, so the board stays full
+		{column: "author_discord_id", limit: 2, want: "alice:3 carol:2"},
+		{column: "source_msg_author_discord_id", limit: 10, want: "dave:4 erin:2"},
+		{column: "author_discord_id", limit: 10, since: weekAgo, want: "alice:2 carol:1"},
+		{column: "source_msg_author_discord_id", limit: 10, since: weekAgo, want: "dave:2 erin:1"},
+	}
+
+	for _, tt := range tests {
+		rows, err := db.Query(quoteLeaderboardQuery(fixture, tt.column), "g1", tt.limit, tt.since)
+		if err != nil {
+			t.Fatalf("%s: query failed: %v", tt.column, err)
+		}
+
+		var got []string
+		for rows.Next() {
+			var id string
+			var count int
+			if err := rows.Scan(&id, &count); err != nil {
+				t.Fatalf("%s: scan failed: %v", tt.column, err)
+			}
+			got = append(got, fmt.Sprintf("%s:%d", id, count))
+		}
+		rows.Close()
+
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("leaderboard(%s, limit %d, since %v) = %q, want %q", tt.column, tt.limit, tt.since.Valid, strings.Join(got, " "), tt.want)
+		}
+	}
+}
+
+// TestQuoteDuplicateQuery runs the duplicate lookup against a temporary table that
+// shadows quotes. It needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestQuoteDuplicateQuery(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	fixture := `
+		CREATE TEMP TABLE quotes (id TEXT, guild_id TEXT, source_msg_id TEXT, body TEXT, created_at TIMESTAMP, deleted_at TIMESTAMP);
+		INSERT INTO quotes VALUES
+			('q1', 'g1', 'm1', 'The cake is a lie', '2024-01-01', NULL),
+			('q2', 'g1', '', 'Still alive', '2024-01-02', NULL),
+			('q3', 'g1', 'm3', 'Deleted quote', '2024-01-03', '2024-02-01'),
+			('q4', 'g2', 'm4', 'Other guild', '2024-01-04', NULL)`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		guildID     string
+		sourceMsgID string
+		body        string
+		want        string
+	}{
+		{name: "same source message", guildID: "g1", sourceMsgID: "m1", body: "edited text", want: "q1"},
+		{name: "same text, whitespace and case", guildID: "g1", sourceMsgID: "m9", body: "  still\n ALIVE ", want: "q2"},
+		{name: "source message wins over text", guildID: "g1", sourceMsgID: "m1", body: "Still alive", want: "q1"},
+		{name: "empty source never matches empty source", guildID: "g1", sourceMsgID: "", body: "new quote", want: ""},
+		{name: "deleted quotes are ignored", guildID: "g1", sourceMsgID: "m3", body: "Deleted quote", want: ""},
+		{name: "other guilds are ignored", guildID: "g1", sourceMsgID: "m4", body: "Other guild", want: ""},
+	}
+
+	for _, tt := range tests {
+		var got string
+		err := db.QueryRow(quoteDuplicateQuery, tt.guildID, tt.sourceMsgID, tt.body).Scan(&got)
+		if err != nil && err != sql.ErrNoRows {
+			t.Fatalf("%s: query failed: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: duplicate = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestQuoteBySourceMessageQuery runs the source message lookup against a temporary
+// table that shadows quotes. It needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestQuoteBySourceMessageQuery(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	fixture := `
+		CREATE TEMP TABLE quotes (id TEXT, guild_id TEXT, source_msg_id TEXT, created_at TIMESTAMP, deleted_at TIMESTAMP);
+		INSERT INTO quotes VALUES
+			('q1', 'g1', 'm1', '2024-01-01', NULL),
+			('q2', 'g2', 'm1', '2024-01-02', NULL),
+			('q3', 'g1', 'm3', '2024-01-03', '2024-02-01'),
+			('q4', 'g1', 'm4', '2024-01-05', NULL),
+			('q5', 'g1', 'm4', '2024-01-04', NULL)`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		guildID     string
+		sourceMsgID string
+		want        string
+	}{
+		{name: "hit", guildID: "g1", sourceMsgID: "m1", want: "q1"},
+		{name: "same message in another guild", guildID: "g2", sourceMsgID: "m1", want: "q2"},
+		{name: "miss", guildID: "g1", sourceMsgID: "m9", want: ""},
+		{name: "message only quoted in another guild", guildID: "g3", sourceMsgID: "m1", want: ""},
+		{name: "deleted quotes are ignored", guildID: "g1", sourceMsgID: "m3", want: ""},
+		{name: "oldest of several", guildID: "g1", sourceMsgID: "m4", want: "q5"},
+	}
+
+	for _, tt := range tests {
+		var got string
+		err := db.QueryRow(quoteBySourceMessageQuery, tt.guildID, tt.sourceMsgID).Scan(&got)
+		if err != nil && err != sql.ErrNoRows {
+			t.Fatalf("%s: query failed: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: quote = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestQuoteCreate_Cooldown saves quotes under a cooldown into a temporary table that
+// shadows quotes. It needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestQuoteCreate_Cooldown(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	fixture := `
+		CREATE TEMP TABLE quotes (
+			id TEXT PRIMARY KEY, short_code TEXT, body TEXT, author_id TEXT, author_discord_id TEXT, guild_id TEXT,
+			source_msg_id TEXT, source_channel_id TEXT, source_channel_name TEXT, source_msg_author_discord_id TEXT,
+			source_msg_author_username TEXT, source_msg_timestamp TIMESTAMP, tags TEXT[],
+			created_at TIMESTAMP, updated_at TIMESTAMP, deleted_at TIMESTAMP
+		)`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	repo := NewQuoteRepository(db)
+	ctx := context.Background()
+	quote := func(author string, cooldown time.Duration) *entities.Quote {
+		return &entities.Quote{AuthorID: author, GuildID: "g1", Body: "Still alive", Cooldown: cooldown}
+	}
+
+	if err := repo.Create(ctx, quote("u1", time.Minute)); err != nil {
+		t.Fatalf("first quote: %v", err)
+	}
+	var cooldown *repositories.QuoteCooldownError
+	if err := repo.Create(ctx, quote("u1", time.Minute)); !errors.As(err, &cooldown) {
+		t.Fatalf("second quote error = %v, want QuoteCooldownError", err)
+	}
+	if cooldown.Remaining <= 0 || cooldown.Remaining > time.Minute {
+		t.Errorf("remaining wait = %v, want under a minute", cooldown.Remaining)
+	}
+	if err := repo.Create(ctx, quote("u2", time.Minute)); err != nil {
+		t.Errorf("another author's quote: %v", err)
+	}
+	if err := repo.Create(ctx, quote("u1", 0)); err != nil {
+		t.Errorf("quote without a cooldown: %v", err)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM quotes`).Scan(&count); err != nil {
+		t.Fatalf("failed to count quotes: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("saved %d quotes, want 3", count)
+	}
+}
+
+// TestUpdateAttributionKeepsSourceAuthor edits quotes in a temporary table that shadows
+// the real one. It needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestUpdateAttributionKeepsSourceAuthor(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	fixture := `
+		CREATE TEMP TABLE quotes (
+			id TEXT PRIMARY KEY, body TEXT, tags TEXT[], source_msg_author_discord_id TEXT,
+			source_msg_author_override TEXT, updated_at TIMESTAMP, deleted_at TIMESTAMP
+		);
+		INSERT INTO quotes (id, body, source_msg_author_discord_id) VALUES ('q1', 'The cake is a lie', 'd-glados')`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	repo := NewQuoteRepository(db)
+	ctx := context.Background()
+	check := func(wantBody string) {
+		t.Helper()
+		var body, discordID, override string
+		if err := db.QueryRow(`SELECT body, source_msg_author_discord_id, source_msg_author_override FROM quotes WHERE id = 'q1'`).Scan(&body, &discordID, &override); err != nil {
+			t.Fatalf("failed to read quote: %v", err)
+		}
+		if body != wantBody || discordID != "d-glados" || override != "Wheatley" {
+			t.Errorf("quote = %q by %q (override %q), want %q by d-glados (override Wheatley)", body, discordID, override, wantBody)
+		}
+	}
+
+	if err := repo.Update(ctx, "q1", "The cake is a lie", nil, "Wheatley"); err != nil {
+		t.Fatalf("Update() with attribution error = %v", err)
+	}
+	check("The cake is a lie")
+
+	// An empty attribution leaves the correction in place
+	if err := repo.Update(ctx, "q1", "Still alive", nil, ""); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	check("Still alive")
+}
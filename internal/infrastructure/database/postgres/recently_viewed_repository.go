@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+// justEditedWindow is how soon after saving their own item a user's view of it counts
+// as the page shown after the edit, for List's excludeOwnEdits
+const justEditedWindow = "5 minutes"
+
+type recentlyViewedRepository struct {
+	db  *sql.DB
+	log *slog.Logger
+}
+
+// NewRecentlyViewedRepository creates a new PostgreSQL view history repository
+func NewRecentlyViewedRepository(db *sql.DB) repositories.RecentlyViewedRepository {
+	return &recentlyViewedRepository{
+		db:  db,
+		log: slog.Default().With(slog.String("repo", "recently_viewed")),
+	}
+}
+
+// RecordView upserts the view, so a repeated view moves the item to the front instead of
+// adding a second row, then deletes everything past the keep most recent views
+func (r *recentlyViewedRepository) RecordView(ctx context.Context, userID, contentType, contentID string, viewedAt time.Time, keep int) error {
+	start := time.Now()
+	var err error
+	var rowCount int64
+	defer func() {
+		metrics.RecordDBOperation("recently_viewed", "record_view", time.Since(start), rowCount, err)
+	}()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO recently_viewed (user_id, content_type, content_id, viewed_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, content_type, content_id) DO UPDATE SET viewed_at = EXCLUDED.viewed_at
+	`, userID, contentType, contentID, viewedAt)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM recently_viewed
+		WHERE user_id = $1 AND (content_type, content_id) NOT IN (
+			SELECT content_type, content_id FROM recently_viewed
+			WHERE user_id = $1
+			ORDER BY viewed_at DESC, content_type, content_id
+			LIMIT $2
+		)
+	`, userID, keep)
+	if err != nil {
+		return err
+	}
+	if trimmed, _ := result.RowsAffected(); trimmed > 0 {
+		r.log.Debug("trimmed view history", slog.String("user_id", userID), slog.Int64("rows", trimmed))
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	rowCount = 1
+	return nil
+}
+
+// List joins the history to the content it points at, so deleted items drop out
+func (r *recentlyViewedRepository) List(ctx context.Context, userID, userDiscordID string, authoredOnly, excludeOwnEdits bool, limit int) ([]*entities.RecentlyViewedItem, error) {
+	start := time.Now()
+	var err error
+	var rowCount int64
+	defer func() {
+		metrics.RecordDBOperation("recently_viewed", "list", time.Since(start), rowCount, err)
+	}()
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	// $1 user, $2 snippet length, $3 limit; the membership filter follows
+	args := []interface{}{userID, activitySnippetLength, limit}
+
+	wikiACL, quoteACL := "", ""
+	if userDiscordID != "" {
+		args = append(args, userDiscordID)
+		n := len(args)
+		wikiACL = fmt.Sprintf(`
+			INNER JOIN guild_members gm_acl ON wp.guild_id = gm_acl.guild_id AND gm_acl.discord_id = $%d`, n)
+		quoteACL = fmt.Sprintf(`
+			INNER JOIN guild_members gm_acl ON q.guild_id = gm_acl.guild_id AND gm_acl.discord_id = $%d`, n)
+	}
+
+	// Notes are always the user's own; quotes don't track edits, so saving one counts
+	wikiOwnEdits, noteOwnEdits, quoteOwnEdits := "", "", ""
+	if excludeOwnEdits {
+		wikiOwnEdits = fmt.Sprintf(" AND NOT (wp.author_id = $1 AND wp.updated_at >= rv.viewed_at - INTERVAL '%s')", justEditedWindow)
+		noteOwnEdits = fmt.Sprintf(" AND NOT (n.updated_at >= rv.viewed_at - INTERVAL '%s')", justEditedWindow)
+		quoteOwnEdits = fmt.Sprintf(" AND NOT (q.author_id = $1 AND q.created_at >= rv.viewed_at - INTERVAL '%s')", justEditedWindow)
+	}
+
+	// Without membership to check, only the user's own pages and quotes are shown
+	wikiAuthored, quoteAuthored := "", ""
+	if authoredOnly {
+		wikiAuthored = " AND wp.author_id = $1"
+		quoteAuthored = " AND q.author_id = $1"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT type, id, guild_id, guild_name, title, slug, snippet, viewed_at
+		FROM (
+			SELECT 'wiki' AS type, wp.id, wp.guild_id, dg.guild_name, wp.title, wt.page_slug AS slug,
+			       LEFT(wp.body, $2) AS snippet, rv.viewed_at
+			FROM recently_viewed rv
+			INNER JOIN wiki_pages wp ON rv.content_id = wp.id
+			LEFT JOIN discord_guilds dg ON wp.guild_id = dg.guild_id
+			LEFT JOIN wiki_titles wt ON wp.id = wt.page_id AND wt.is_canonical = TRUE%s
+			WHERE rv.user_id = $1 AND rv.content_type = 'wiki' AND wp.deleted_at IS NULL%s%s
+
+			UNION ALL
+
+			SELECT 'note' AS type, n.id, n.guild_id, dg.guild_name, n.title, n.slug,
+			       LEFT(n.body, $2) AS snippet, rv.viewed_at
+			FROM recently_viewed rv
+			INNER JOIN notes n ON rv.content_id = n.id
+			LEFT JOIN discord_guilds dg ON n.guild_id = dg.guild_id
+			WHERE rv.user_id = $1 AND rv.content_type = 'note' AND n.author_id = $1 AND n.deleted_at IS NULL%s
+
+			UNION ALL
+
+			SELECT 'quote' AS type, q.id, q.guild_id, dg.guild_name, NULL AS title, q.short_code AS slug,
+			       LEFT(q.body, $2) AS snippet, rv.viewed_at
+			FROM recently_viewed rv
+			INNER JOIN quotes q ON rv.content_id = q.id
+			LEFT JOIN discord_guilds dg ON q.guild_id = dg.guild_id%s
+			WHERE rv.user_id = $1 AND rv.content_type = 'quote' AND q.deleted_at IS NULL%s%s
+		) viewed
+		ORDER BY viewed_at DESC, type, id
+		LIMIT $3
+	`, wikiACL, wikiAuthored, wikiOwnEdits, noteOwnEdits, quoteACL, quoteAuthored, quoteOwnEdits)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []*entities.RecentlyViewedItem{}
+	for rows.Next() {
+		item := &entities.RecentlyViewedItem{}
+		var guildID, guildName, title, slug, snippet sql.NullString
+
+		if err = rows.Scan(&item.Type, &item.ID, &guildID, &guildName, &title, &slug, &snippet, &item.ViewedAt); err != nil {
+			return nil, err
+		}
+
+		item.GuildID = guildID.String
+		item.GuildName = guildName.String
+		item.Title = title.String
+		item.Slug = slug.String
+		item.Snippet = snippet.String
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rowCount = int64(len(items))
+	return items, nil
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestRecentlyViewed records and lists views in temporary tables that shadow the real
+// schema. It needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestRecentlyViewed(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	schema := `
+		CREATE TEMP TABLE recently_viewed (user_id TEXT NOT NULL, content_type TEXT NOT NULL, content_id TEXT NOT NULL, viewed_at TIMESTAMP NOT NULL, PRIMARY KEY (user_id, content_type, content_id));
+		CREATE TEMP TABLE discord_guilds (guild_id TEXT PRIMARY KEY, guild_name TEXT);
+		CREATE TEMP TABLE guild_members (guild_id TEXT NOT NULL, discord_id TEXT NOT NULL);
+		CREATE TEMP TABLE wiki_pages (id TEXT PRIMARY KEY, guild_id TEXT NOT NULL, author_id TEXT NOT NULL, title TEXT, body TEXT, updated_at TIMESTAMP, deleted_at TIMESTAMP);
+		CREATE TEMP TABLE wiki_titles (page_id TEXT NOT NULL, page_slug TEXT NOT NULL, is_canonical BOOLEAN);
+		CREATE TEMP TABLE notes (id TEXT PRIMARY KEY, guild_id TEXT, author_id TEXT NOT NULL, title TEXT, slug TEXT, body TEXT, updated_at TIMESTAMP, deleted_at TIMESTAMP);
+		CREATE TEMP TABLE quotes (id TEXT PRIMARY KEY, guild_id TEXT NOT NULL, author_id TEXT NOT NULL, short_code TEXT, body TEXT, created_at TIMESTAMP, deleted_at TIMESTAMP);
+		INSERT INTO discord_guilds VALUES ('g1', 'Guild One'), ('g2', 'Guild Two');
+		INSERT INTO guild_members VALUES ('g1', 'd-ada');
+		INSERT INTO wiki_pages VALUES
+			('p1', 'g1', 'bob', 'Raid Plan', 'body', '2024-01-01', NULL),
+			('p2', 'g2', 'bob', 'Secret', 'body', '2024-01-01', NULL),
+			('p3', 'g1', 'bob', 'Gone', 'body', '2024-01-01', '2024-01-02'),
+			('p4', 'g1', 'ada', 'Mine', 'body', '2024-01-10 12:06', NULL);
+		INSERT INTO wiki_titles VALUES ('p1', 'raid-plan', TRUE), ('p4', 'mine', TRUE);
+		INSERT INTO notes VALUES ('n1', NULL, 'ada', 'Todo', 'todo', 'body', '2024-01-01', NULL);
+		INSERT INTO quotes VALUES ('q1', 'g1', 'bob', 'ab3d5fgh', 'quote body', '2024-01-01', NULL)`
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	repo := NewRecentlyViewedRepository(db)
+	base := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	view := func(minute int, contentType, id string, keep int) {
+		t.Helper()
+		if err := repo.RecordView(ctx, "ada", contentType, id, base.Add(time.Duration(minute)*time.Minute), keep); err != nil {
+			t.Fatalf("RecordView(%s %s) error = %v", contentType, id, err)
+		}
+	}
+	history := func() []string {
+		t.Helper()
+		rows, err := db.QueryContext(ctx, "SELECT content_id FROM recently_viewed WHERE user_id = 'ada' ORDER BY viewed_at DESC")
+		if err != nil {
+			t.Fatalf("failed to read history: %v", err)
+		}
+		defer rows.Close()
+		ids := []string{}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				t.Fatalf("failed to scan history: %v", err)
+			}
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	// A repeated view moves the item to the front instead of adding a row
+	view(1, "wiki", "p1", 3)
+	view(2, "note", "n1", 3)
+	view(3, "quote", "q1", 3)
+	view(4, "wiki", "p1", 3)
+	if got, want := history(), []string{"p1", "q1", "n1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("history after repeat view = %v, want %v", got, want)
+	}
+
+	// Going past the cap drops the oldest view
+	view(5, "wiki", "p2", 3)
+	if got, want := history(), []string{"p2", "p1", "q1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("history after cap = %v, want %v", got, want)
+	}
+
+	// Deleted pages and guilds ada isn't in are skipped; admins (no Discord ID) see every guild
+	view(6, "note", "n1", 10)
+	view(7, "wiki", "p3", 10)
+	view(8, "wiki", "p4", 10)
+	listIDs := func(userDiscordID string, excludeOwnEdits bool) []string {
+		t.Helper()
+		items, err := repo.List(ctx, "ada", userDiscordID, false, excludeOwnEdits, 10)
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		ids := make([]string, len(items))
+		for i, item := range items {
+			ids[i] = item.ID
+		}
+		return ids
+	}
+	if got, want := listIDs("d-ada", false), []string{"p4", "n1", "p1", "q1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+	if got, want := listIDs("", false), []string{"p4", "n1", "p2", "p1", "q1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List() as admin = %v, want %v", got, want)
+	}
+
+	// p4 was viewed right after ada saved it
+	if got, want := listIDs("d-ada", true), []string{"n1", "p1", "q1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List(excludeOwnEdits) = %v, want %v", got, want)
+	}
+
+	// Without a Discord account, ada still sees her own note and page
+	own, err := repo.List(ctx, "ada", "", true, false, 10)
+	if err != nil {
+		t.Fatalf("List(authoredOnly) error = %v", err)
+	}
+	if len(own) != 2 || own[0].ID != "p4" || own[1].ID != "n1" {
+		t.Errorf("List(authoredOnly) = %+v, want p4 and n1", own)
+	}
+
+	items, err := repo.List(ctx, "ada", "d-ada", false, false, 10)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if items[2].Slug != "raid-plan" || items[2].GuildName != "Guild One" || items[3].Slug != "ab3d5fgh" {
+		t.Errorf("List() items = %+v %+v, want permalink keys and guild names", items[2], items[3])
+	}
+}
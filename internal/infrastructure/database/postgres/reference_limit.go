@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// lockReferenceOwner locks the wiki page or note (the row id in ownerTable) that references
+// are being added to, so concurrent adds to it wait for tx and count what it added
+func lockReferenceOwner(ctx context.Context, tx *sql.Tx, ownerTable, id string) error {
+	var locked int
+	err := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT 1 FROM %s WHERE id = $1 FOR UPDATE", ownerTable), id).Scan(&locked)
+	if err == sql.ErrNoRows {
+		// The reference insert reports the missing owner
+		return nil
+	}
+	return err
+}
+
+// checkReferenceLimit returns ErrReferenceLimitReached when the owner id has more than
+// maxRefs rows in refTable, counting the rows tx has added
+func checkReferenceLimit(ctx context.Context, tx *sql.Tx, refTable, ownerColumn, id string, maxRefs int) error {
+	var count int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s = $1", refTable, ownerColumn)
+	if err := tx.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		return err
+	}
+	if count > maxRefs {
+		return repositories.ErrReferenceLimitReached
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// minFullTextTokenLength is the shortest query token worth handing to the
+// full-text parser. Shorter tokens ("go", "db", "x") are usually dropped as
+// stop words or stemmed into something else, so a query made up only of them
+// falls back to substring matching.
+const minFullTextTokenLength = 3
+
+// headlineOptions configures ts_headline snippets. Matches are wrapped in
+// markdown bold so clients can either render or strip the highlight.
+const headlineOptions = `StartSel=**, StopSel=**, MinWords=10, MaxWords=30, ShortWord=2, MaxFragments=2, FragmentDelimiter=" … "`
+
+// fallbackSnippetRadius is how many characters of context surround the match
+// in snippets built for ILIKE results.
+const fallbackSnippetRadius = 60
+
+// useFullTextSearch reports whether the query has at least one token long
+// enough for tsvector matching to be meaningful.
+func useFullTextSearch(query string) bool {
+	for _, token := range strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		if utf8.RuneCountInString(token) >= minFullTextTokenLength {
+			return true
+		}
+	}
+	return false
+}
+
+// tsQueryExpr builds the hybrid tsquery (english stemmed OR simple literal)
+// that matches the hybrid search_vector columns.
+func tsQueryExpr(param int) string {
+	return fmt.Sprintf("(websearch_to_tsquery('english', $%d) || websearch_to_tsquery('simple', $%d))", param, param)
+}
+
+// searchMatchExpr returns a WHERE condition matching vector against the query parameter.
+func searchMatchExpr(vector string, param int) string {
+	return fmt.Sprintf("%s @@ %s", vector, tsQueryExpr(param))
+}
+
+// searchRankExpr returns the ts_rank expression used to order search results.
+func searchRankExpr(vector string, param int) string {
+	return fmt.Sprintf("ts_rank(%s, %s)", vector, tsQueryExpr(param))
+}
+
+// searchHeadlineExpr returns a ts_headline expression producing a highlighted snippet of text.
+func searchHeadlineExpr(text string, param int) string {
+	return fmt.Sprintf("ts_headline('english', %s, %s, '%s')", text, tsQueryExpr(param), headlineOptions)
+}
+
+// authorFilterExpr returns a WHERE condition matching rows whose author (the users.id in
+// authorColumn) is linked to the Discord user in the param placeholder
+func authorFilterExpr(authorColumn string, param int) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM discord_users adu WHERE adu.user_id = %s AND adu.discord_id = $%d)", authorColumn, param)
+}
+
+// guildFilterExpr returns a WHERE condition matching rows whose guildColumn is one of the
+// guild IDs in the param placeholder, which must be bound to pq.Array of them
+func guildFilterExpr(guildColumn string, param int) string {
+	return fmt.Sprintf("%s = ANY($%d)", guildColumn, param)
+}
+
+// contentSizeExpr selects the body length and tag count of the row aliased as alias, so
+// list and search results carry sizes that clients can show without the body
+func contentSizeExpr(alias string) string {
+	return fmt.Sprintf("char_length(%[1]s.body), COALESCE(cardinality(%[1]s.tags), 0)", alias)
+}
+
+// referenceCountExpr counts the rows of a message reference table whose column points at
+// the row aliased as alias
+func referenceCountExpr(table, column, alias string) string {
+	return fmt.Sprintf("(SELECT COUNT(*) FROM %s ref WHERE ref.%s = %s.id)", table, column, alias)
+}
+
+// searchOrderClause builds the ORDER BY for note and quote search on the table
+// aliased as alias. orderBy is one of the repositories.SearchOrder* values; empty
+// or unknown values sort by relevance for full-text queries and by created_at
+// otherwise. Relevance falls back to created_at for ILIKE matches, which have no
+// rank. Ties are broken by id so paging through results is stable.
+func searchOrderClause(alias, orderBy string, ascending, fullText bool) string {
+	orderBy = resolveSearchOrder(orderBy, fullText)
+
+	direction := "DESC"
+	if ascending {
+		direction = "ASC"
+	}
+
+	if orderBy == repositories.SearchOrderRelevance {
+		return fmt.Sprintf("rank %s, %s.created_at DESC, %s.id DESC", direction, alias, alias)
+	}
+	return fmt.Sprintf("%s.%s %s, %s.id %s", alias, orderBy, direction, alias, direction)
+}
+
+// searchKeysetOrder is searchOrderClause as a keysetOrder for cursor pagination.
+// rankExpr is the ts_rank expression for full-text queries, empty otherwise; it is
+// repeated in place of the rank alias because WHERE clauses can't refer to aliases.
+func searchKeysetOrder(alias, orderBy string, ascending bool, rankExpr string) keysetOrder {
+	orderBy = resolveSearchOrder(orderBy, rankExpr != "")
+
+	direction := "desc"
+	if ascending {
+		direction = "asc"
+	}
+	order := keysetOrder{name: alias + ":" + orderBy + ":" + direction}
+
+	if orderBy == repositories.SearchOrderRelevance {
+		order.columns = []keysetColumn{
+			{expr: rankExpr, cast: "real", desc: !ascending},
+			{expr: alias + ".created_at", cast: "timestamp", desc: true},
+			{expr: alias + ".id", cast: "text", desc: true},
+		}
+		return order
+	}
+
+	order.columns = []keysetColumn{
+		{expr: alias + "." + orderBy, cast: "timestamp", desc: !ascending},
+		{expr: alias + ".id", cast: "text", desc: !ascending},
+	}
+	return order
+}
+
+// resolveSearchOrder maps a requested search ordering to the one actually used
+func resolveSearchOrder(orderBy string, fullText bool) string {
+	switch orderBy {
+	case repositories.SearchOrderCreatedAt, repositories.SearchOrderUpdatedAt:
+		return orderBy
+	case repositories.SearchOrderRelevance:
+		if fullText {
+			return orderBy
+		}
+	default:
+		if fullText {
+			return repositories.SearchOrderRelevance
+		}
+	}
+	return repositories.SearchOrderCreatedAt
+}
+
+// ilikePattern escapes LIKE wildcards in query and wraps it for substring matching.
+func ilikePattern(query string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(query)
+	return "%" + escaped + "%"
+}
+
+// fallbackSnippet builds a snippet around the first case-insensitive occurrence
+// of query in text, highlighted the same way as ts_headline output. If the
+// query does not appear in text (e.g. it only matched a title), the start of
+// the text is returned instead.
+func fallbackSnippet(text, query string) string {
+	runes := []rune(text)
+	needle := []rune(strings.TrimSpace(query))
+
+	idx := -1
+	if len(needle) > 0 {
+		idx = indexFold(runes, needle)
+	}
+
+	if idx < 0 {
+		if len(runes) <= fallbackSnippetRadius*2 {
+			return text
+		}
+		return strings.TrimSpace(string(runes[:fallbackSnippetRadius*2])) + "…"
+	}
+
+	start := idx - fallbackSnippetRadius
+	if start < 0 {
+		start = 0
+	}
+	end := idx + len(needle) + fallbackSnippetRadius
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	b.WriteString(string(runes[start:idx]))
+	b.WriteString("**")
+	b.WriteString(string(runes[idx : idx+len(needle)]))
+	b.WriteString("**")
+	b.WriteString(string(runes[idx+len(needle) : end]))
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// indexFold returns the rune index of the first case-insensitive occurrence of needle in haystack, or -1.
+func indexFold(haystack, needle []rune) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j, r := range needle {
+			if unicode.ToLower(haystack[i+j]) != unicode.ToLower(r) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
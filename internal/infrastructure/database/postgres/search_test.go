@@ -0,0 +1,414 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestUseFullTextSearch(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{name: "empty", query: "", want: false},
+		{name: "single short token", query: "go", want: false},
+		{name: "only short tokens", query: "a db ci", want: false},
+		{name: "short tokens with punctuation", query: "c++ & c#", want: false},
+		{name: "one long token", query: "deploy", want: true},
+		{name: "mixed short and long tokens", query: "go modules", want: true},
+		{name: "exactly minimum length", query: "api", want: true},
+		{name: "multibyte token", query: "café", want: true},
+		{name: "quoted phrase", query: `"release notes"`, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := useFullTextSearch(tt.query); got != tt.want {
+				t.Errorf("useFullTextSearch(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestILikePattern(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "go", want: "%go%"},
+		{query: "100%", want: `%100\%%`},
+		{query: "snake_case", want: `%snake\_case%`},
+		{query: `a\b`, want: `%a\\b%`},
+	}
+
+	for _, tt := range tests {
+		if got := ilikePattern(tt.query); got != tt.want {
+			t.Errorf("ilikePattern(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFallbackSnippet(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		query string
+		want  string
+	}{
+		{
+			name:  "match in short text",
+			text:  "we use Go for the backend",
+			query: "go",
+			want:  "we use **Go** for the backend",
+		},
+		{
+			name:  "no match returns text",
+			text:  "nothing to see here",
+			query: "xy",
+			want:  "nothing to see here",
+		},
+		{
+			name:  "match is trimmed with ellipses",
+			text:  "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa db bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+			query: "DB",
+			want:  "…aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa **db** bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb…",
+		},
+		{
+			name:  "multibyte text",
+			text:  "ünïcödé ök",
+			query: "ÖK",
+			want:  "ünïcödé **ök**",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fallbackSnippet(tt.text, tt.query); got != tt.want {
+				t.Errorf("fallbackSnippet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSearchRankTermFrequency checks that the ranking expression used by the
+// search queries scores documents with more occurrences of the query higher.
+// It needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestSearchRankTermFrequency(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	docs := []string{
+		"The deploy pipeline runs tests.",
+		"Deploy steps: deploy the server, then deploy the bot.",
+		"Deploy, deploy, deploy: every deploy gets a changelog entry.",
+	}
+
+	// Same hybrid vector the generated search_vector columns use for bodies
+	vector := "(setweight(to_tsvector('english', $2), 'A') || setweight(to_tsvector('simple', $2), 'B'))"
+	query := "SELECT " + searchRankExpr(vector, 1)
+
+	ranks := make([]float32, len(docs))
+	for i, doc := range docs {
+		if err := db.QueryRow(query, "deploy", doc).Scan(&ranks[i]); err != nil {
+			t.Fatalf("failed to rank document %d: %v", i, err)
+		}
+	}
+
+	for i := 1; i < len(ranks); i++ {
+		if ranks[i] <= ranks[i-1] {
+			t.Errorf("rank of document %d (%f) should exceed document %d (%f)", i, ranks[i], i-1, ranks[i-1])
+		}
+	}
+}
+
+func TestSearchOrderClause(t *testing.T) {
+	tests := []struct {
+		name      string
+		orderBy   string
+		ascending bool
+		fullText  bool
+		want      string
+	}{
+		{name: "default with query", fullText: true, want: "rank DESC, n.created_at DESC, n.id DESC"},
+		{name: "default without query", want: "n.created_at DESC, n.id DESC"},
+		{name: "relevance", orderBy: "relevance", fullText: true, want: "rank DESC, n.created_at DESC, n.id DESC"},
+		{name: "relevance without rank", orderBy: "relevance", want: "n.created_at DESC, n.id DESC"},
+		{name: "created ascending", orderBy: "created_at", ascending: true, fullText: true, want: "n.created_at ASC, n.id ASC"},
+		{name: "updated", orderBy: "updated_at", want: "n.updated_at DESC, n.id DESC"},
+		{name: "unknown", orderBy: "body; DROP TABLE notes", fullText: true, want: "rank DESC, n.created_at DESC, n.id DESC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := searchOrderClause("n", tt.orderBy, tt.ascending, tt.fullText); got != tt.want {
+				t.Errorf("searchOrderClause(%q, %v, %v) = %q, want %q", tt.orderBy, tt.ascending, tt.fullText, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSearchOrderClauseSequence runs each search ordering against a small
+// fixture dataset. Like TestSearchRankTermFrequency it needs a real PostgreSQL
+// server and is skipped unless HIVEMIND_TEST_DATABASE_URL is set.
+func TestSearchOrderClauseSequence(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	// a: oldest, best match, edited last; b: middle, ties c on rank; c: newest
+	fixture := `(VALUES
+		('a', TIMESTAMP '2024-01-01', TIMESTAMP '2024-03-01', 0.9::real),
+		('b', TIMESTAMP '2024-01-02', TIMESTAMP '2024-01-02', 0.5::real),
+		('c', TIMESTAMP '2024-01-03', TIMESTAMP '2024-01-03', 0.5::real)
+	) AS n(id, created_at, updated_at, rank)`
+
+	tests := []struct {
+		orderBy   string
+		ascending bool
+		fullText  bool
+		want      string
+	}{
+		{orderBy: "relevance", fullText: true, want: "acb"},
+		{orderBy: "", fullText: true, want: "acb"},
+		{orderBy: "", fullText: false, want: "cba"},
+		{orderBy: "created_at", fullText: true, want: "cba"},
+		{orderBy: "created_at", ascending: true, fullText: true, want: "abc"},
+		{orderBy: "updated_at", fullText: true, want: "acb"},
+		{orderBy: "updated_at", ascending: true, want: "bca"},
+	}
+
+	for _, tt := range tests {
+		clause := searchOrderClause("n", tt.orderBy, tt.ascending, tt.fullText)
+		rows, err := db.Query("SELECT n.id FROM " + fixture + " ORDER BY " + clause)
+		if err != nil {
+			t.Fatalf("ORDER BY %s: %v", clause, err)
+		}
+		var got string
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				t.Fatalf("ORDER BY %s: %v", clause, err)
+			}
+			got += id
+		}
+		rows.Close()
+
+		if got != tt.want {
+			t.Errorf("ORDER BY %s returned %q, want %q", clause, got, tt.want)
+		}
+	}
+}
+
+// TestAuthorFilterExpr runs the author filter, alone and combined with a text
+// match, against temporary tables that shadow the real schema. It needs a real
+// PostgreSQL server and is skipped unless HIVEMIND_TEST_DATABASE_URL is set.
+func TestAuthorFilterExpr(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	fixture := `
+		CREATE TEMP TABLE discord_users (discord_id TEXT PRIMARY KEY, user_id TEXT);
+		CREATE TEMP TABLE wiki_pages (id TEXT PRIMARY KEY, author_id TEXT, body TEXT);
+		INSERT INTO discord_users VALUES ('d-alice', 'u-alice'), ('d-bob', 'u-bob'), ('d-unlinked', NULL);
+		INSERT INTO wiki_pages VALUES
+			('a1', 'u-alice', 'How we deploy the bot'),
+			('a2', 'u-alice', 'Raid schedule'),
+			('b1', 'u-bob', 'Deploy checklist'),
+			('x1', 'u-nobody', 'Deploy notes from a deleted account')`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		authorID string
+		query    string
+		want     string
+	}{
+		{name: "author only", authorID: "d-alice", want: "a1 a2"},
+		{name: "other author", authorID: "d-bob", want: "b1"},
+		{name: "author and text", authorID: "d-alice", query: "deploy", want: "a1"},
+		{name: "text matches only another author", authorID: "d-bob", query: "raid", want: ""},
+		{name: "unknown author", authorID: "d-carol", want: ""},
+		{name: "unlinked author", authorID: "d-unlinked", want: ""},
+	}
+
+	for _, tt := range tests {
+		conditions := []string{authorFilterExpr("wp.author_id", 1)}
+		args := []interface{}{tt.authorID}
+		if tt.query != "" {
+			conditions = append(conditions, "wp.body ILIKE $2")
+			args = append(args, ilikePattern(tt.query))
+		}
+
+		rows, err := db.Query("SELECT wp.id FROM wiki_pages wp WHERE "+strings.Join(conditions, " AND ")+" ORDER BY wp.id", args...)
+		if err != nil {
+			t.Fatalf("%s: query failed: %v", tt.name, err)
+		}
+		var got []string
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				t.Fatalf("%s: scan failed: %v", tt.name, err)
+			}
+			got = append(got, id)
+		}
+		rows.Close()
+
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("%s: pages = %q, want %q", tt.name, strings.Join(got, " "), tt.want)
+		}
+	}
+}
+
+// TestContentCountExprs runs the body length, tag count, and reference count expressions
+// against temporary tables that shadow the real schema. It needs a real PostgreSQL
+// server and is skipped unless HIVEMIND_TEST_DATABASE_URL is set.
+func TestContentCountExprs(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	fixture := `
+		CREATE TEMP TABLE wiki_pages (id TEXT PRIMARY KEY, body TEXT, tags TEXT[]);
+		CREATE TEMP TABLE wiki_message_references (id TEXT PRIMARY KEY, wiki_page_id TEXT);
+		INSERT INTO wiki_pages VALUES
+			('p1', 'Raid plan', ARRAY['raid', 'weekly', 'pinned']),
+			('p2', 'Café ☕', '{}'),
+			('p3', '', NULL);
+		INSERT INTO wiki_message_references VALUES ('r1', 'p1'), ('r2', 'p1'), ('r3', 'p2'), ('r4', 'other')`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	query := fmt.Sprintf("SELECT wp.id, %s, %s FROM wiki_pages wp ORDER BY wp.id",
+		contentSizeExpr("wp"), referenceCountExpr("wiki_message_references", "wiki_page_id", "wp"))
+	rows, err := db.Query(query)
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	defer rows.Close()
+
+	var got []string
+	for rows.Next() {
+		var id string
+		var bodyLength, tagCount, referenceCount int
+		if err := rows.Scan(&id, &bodyLength, &tagCount, &referenceCount); err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		got = append(got, fmt.Sprintf("%s:%d/%d/%d", id, bodyLength, tagCount, referenceCount))
+	}
+
+	// Lengths count characters rather than bytes, and missing tags count as none
+	if want := "p1:9/3/2 p2:6/0/1 p3:0/0/0"; strings.Join(got, " ") != want {
+		t.Errorf("counts = %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+// TestGuildFilterExpr runs the multi-guild filter, with and without the membership join
+// non-admin searches add, against temporary tables that shadow the real schema. It needs
+// a real PostgreSQL server and is skipped unless HIVEMIND_TEST_DATABASE_URL is set.
+func TestGuildFilterExpr(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	fixture := `
+		CREATE TEMP TABLE quotes (id TEXT PRIMARY KEY, guild_id TEXT);
+		CREATE TEMP TABLE guild_members (guild_id TEXT, discord_id TEXT);
+		INSERT INTO quotes VALUES ('a1', 'g-a'), ('a2', 'g-a'), ('b1', 'g-b'), ('c1', 'g-c');
+		INSERT INTO guild_members VALUES ('g-a', 'd-ada'), ('g-b', 'd-ada'), ('g-c', 'd-bob')`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		guildIDs []string
+		member   string // Non-empty adds the membership join for this Discord user
+		want     string
+	}{
+		{name: "one guild", guildIDs: []string{"g-b"}, want: "b1"},
+		{name: "several guilds", guildIDs: []string{"g-a", "g-c"}, want: "a1 a2 c1"},
+		{name: "unknown guild", guildIDs: []string{"g-x"}, want: ""},
+		{name: "member of all requested", guildIDs: []string{"g-a", "g-b"}, member: "d-ada", want: "a1 a2 b1"},
+		{name: "requested guild the user is not in", guildIDs: []string{"g-b", "g-c"}, member: "d-ada", want: "b1"},
+	}
+
+	for _, tt := range tests {
+		from := "quotes q"
+		args := []interface{}{pq.Array(tt.guildIDs)}
+		if tt.member != "" {
+			from += " INNER JOIN guild_members gm ON q.guild_id = gm.guild_id AND gm.discord_id = $2"
+			args = append(args, tt.member)
+		}
+
+		rows, err := db.Query("SELECT q.id FROM "+from+" WHERE "+guildFilterExpr("q.guild_id", 1)+" ORDER BY q.id", args...)
+		if err != nil {
+			t.Fatalf("%s: query failed: %v", tt.name, err)
+		}
+		var got []string
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				t.Fatalf("%s: scan failed: %v", tt.name, err)
+			}
+			got = append(got, id)
+		}
+		rows.Close()
+
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("%s: quotes = %q, want %q", tt.name, strings.Join(got, " "), tt.want)
+		}
+	}
+}
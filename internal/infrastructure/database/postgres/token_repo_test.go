@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TestAuthCleanupEligibility deletes expired and revoked API tokens and expired OIDC
+// sessions from temporary tables that shadow the real ones, checking which rows a
+// cleanup run with a given cutoff removes. It needs a real PostgreSQL server and is
+// skipped unless HIVEMIND_TEST_DATABASE_URL is set.
+func TestAuthCleanupEligibility(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sqlx.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	// Cleanup runs on 2024-02-01 with the cutoff a day earlier. Token records outlive
+	// the JWTs issued for them, so t-live has a future record expiry however old its
+	// JWT is and must be kept until it's revoked.
+	fixture := `
+		CREATE TEMP TABLE api_tokens (id TEXT PRIMARY KEY, expires_at TIMESTAMP NOT NULL, revoked_at TIMESTAMP);
+		INSERT INTO api_tokens (id, expires_at, revoked_at) VALUES
+			('t-expired-old', '2024-01-10', NULL),
+			('t-expired-recent', '2024-01-31 12:00', NULL),
+			('t-live', '2024-03-01', NULL),
+			('t-revoked-old', '2024-03-01', '2024-01-15'),
+			('t-revoked-recent', '2024-03-01', '2024-01-31 12:00'),
+			('t-expired-revoked-recent', '2024-01-10', '2024-01-31 12:00');
+		CREATE TEMP TABLE oidc_sessions (id TEXT PRIMARY KEY, expires_at TIMESTAMP NOT NULL);
+		INSERT INTO oidc_sessions (id, expires_at) VALUES
+			('s-expired-old', '2024-01-10'),
+			('s-expired-recent', '2024-01-31 12:00'),
+			('s-live', '2024-03-01')`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	ctx := context.Background()
+	cutoff := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	remaining := func(table string) string {
+		var ids []string
+		if err := db.Select(&ids, `SELECT id FROM `+table+` ORDER BY id`); err != nil {
+			t.Fatalf("failed to list %s: %v", table, err)
+		}
+		return strings.Join(ids, " ")
+	}
+
+	tokens := NewTokenRepository(db)
+	expired, err := tokens.DeleteExpired(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("DeleteExpired() error = %v", err)
+	}
+	revoked, err := tokens.DeleteRevokedBefore(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("DeleteRevokedBefore() error = %v", err)
+	}
+	// A token expired long enough ago goes even if it was only just revoked
+	if expired != 2 || revoked != 1 {
+		t.Errorf("deleted %d expired and %d revoked tokens, want 2 and 1", expired, revoked)
+	}
+	if got, want := remaining("api_tokens"), "t-expired-recent t-live t-revoked-recent"; got != want {
+		t.Errorf("remaining tokens = %q, want %q", got, want)
+	}
+
+	sessions := NewSessionRepository(db)
+	deleted, err := sessions.CleanupExpiredSessions(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("CleanupExpiredSessions() error = %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted %d sessions, want 1", deleted)
+	}
+	if got, want := remaining("oidc_sessions"), "s-expired-recent s-live"; got != want {
+		t.Errorf("remaining sessions = %q, want %q", got, want)
+	}
+}
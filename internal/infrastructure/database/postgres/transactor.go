@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// txKey is the context key InTx stores its transaction under
+type txKey struct{}
+
+// transactor implements repositories.Transactor with a *sql.Tx carried in the context
+type transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor creates a Transactor for repositories sharing db
+func NewTransactor(db *sql.DB) repositories.Transactor {
+	return &transactor{db: db}
+}
+
+// InTx runs fn in a transaction. A call nested in another InTx joins the outer transaction.
+func (t *transactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// querier runs statements on a *sql.DB or in a *sql.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction InTx put in ctx, or db outside of one
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// scopedTx is a transaction a repository method began itself, or the one from InTx that it
+// joined. Commit and Rollback of a joined transaction are left to InTx, so they do nothing.
+type scopedTx struct {
+	*sql.Tx
+	joined bool
+}
+
+// beginTx starts a transaction on db, or joins the one InTx put in ctx
+func beginTx(ctx context.Context, db *sql.DB) (scopedTx, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return scopedTx{Tx: tx, joined: true}, nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return scopedTx{}, err
+	}
+	return scopedTx{Tx: tx}, nil
+}
+
+func (t scopedTx) Commit() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t scopedTx) Rollback() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+// TestTransactorInTx needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestTransactorInTx(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	c, err := NewConnection(dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer c.Close()
+	db := c.DB.DB
+	// TEMP tables are per session
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, `CREATE TEMP TABLE tx_test (id TEXT PRIMARY KEY)`); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	count := func() int {
+		var n int
+		if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM tx_test`).Scan(&n); err != nil {
+			t.Fatalf("failed to count rows: %v", err)
+		}
+		return n
+	}
+	insert := func(ctx context.Context, id string) error {
+		_, err := conn(ctx, db).ExecContext(ctx, `INSERT INTO tx_test (id) VALUES ($1)`, id)
+		return err
+	}
+
+	transactor := NewTransactor(db)
+	errFail := errors.New("fail")
+
+	err = transactor.InTx(ctx, func(ctx context.Context) error {
+		if err := insert(ctx, "a"); err != nil {
+			return err
+		}
+		// A repository method that begins its own transaction joins this one
+		tx, err := beginTx(ctx, db)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+		if _, err := tx.ExecContext(ctx, `INSERT INTO tx_test (id) VALUES ('b')`); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		return errFail
+	})
+	if !errors.Is(err, errFail) {
+		t.Fatalf("InTx() error = %v, want %v", err, errFail)
+	}
+	if n := count(); n != 0 {
+		t.Fatalf("rows after rollback = %d, want 0", n)
+	}
+
+	if err := transactor.InTx(ctx, func(ctx context.Context) error { return insert(ctx, "a") }); err != nil {
+		t.Fatalf("InTx() error = %v", err)
+	}
+	if n := count(); n != 1 {
+		t.Errorf("rows after commit = %d, want 1", n)
+	}
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+// UserPreferencesRepository implements repositories.UserPreferencesRepository for PostgreSQL
+type UserPreferencesRepository struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+// NewUserPreferencesRepository creates a new user preferences repository
+func NewUserPreferencesRepository(db *sqlx.DB) repositories.UserPreferencesRepository {
+	return &UserPreferencesRepository{
+		db:  db,
+		log: slog.Default().With(slog.String("repo", "user_preferences")),
+	}
+}
+
+// GetByUserID retrieves preferences for a user
+func (r *UserPreferencesRepository) GetByUserID(ctx context.Context, userID string) (*entities.UserPreferences, error) {
+	start := time.Now()
+	var err error
+	var rowCount int64
+	defer func() {
+		metrics.RecordDBOperation("user_preferences", "get_by_user_id", time.Since(start), rowCount, err)
+	}()
+
+	query := `
+		SELECT user_id, default_guild_id, leaderboard_opt_out, created_at, updated_at
+		FROM user_preferences
+		WHERE user_id = $1
+	`
+
+	var prefs entities.UserPreferences
+	err = r.db.GetContext(ctx, &prefs, query, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repositories.ErrUserPreferencesNotFound
+		}
+		return nil, err
+	}
+
+	rowCount = 1
+	return &prefs, nil
+}
+
+// Upsert creates or updates preferences for a user
+func (r *UserPreferencesRepository) Upsert(ctx context.Context, prefs *entities.UserPreferences) error {
+	start := time.Now()
+	var err error
+	defer func() {
+		metrics.RecordDBOperation("user_preferences", "upsert", time.Since(start), 1, err)
+	}()
+
+	r.log.Debug("upserting user preferences", slog.String("user_id", prefs.UserID))
+
+	query := `
+		INSERT INTO user_preferences (user_id, default_guild_id, leaderboard_opt_out, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			default_guild_id = EXCLUDED.default_guild_id,
+			leaderboard_opt_out = EXCLUDED.leaderboard_opt_out,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+
+	err = r.db.QueryRowContext(ctx, query, prefs.UserID, prefs.DefaultGuildID, prefs.LeaderboardOptOut).Scan(&prefs.CreatedAt, &prefs.UpdatedAt)
+	return err
+}
@@ -9,7 +9,7 @@ import (
 	"time"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
@@ -347,8 +347,39 @@ func (r *UserRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// List users with pagination and optional filtering
-func (r *UserRepository) List(ctx context.Context, opts repositories.ListUsersOptions) ([]*entities.User, int64, error) {
+// SetActive enables or disables a user account
+func (r *UserRepository) SetActive(ctx context.Context, id string, active bool) error {
+	start := time.Now()
+	var err error
+	var rowsAffected int64
+	defer func() {
+		metrics.RecordDBOperation("user", "set_active", time.Since(start), rowsAffected, err)
+	}()
+
+	query := `UPDATE users SET disabled = $1, updated_at = $2 WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, !active, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to set user active state: %w", err)
+	}
+
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		err = repositories.ErrUserNotFound
+		return err
+	}
+
+	return nil
+}
+
+// List users with pagination and optional filtering. A cursor in opts continues
+// after a previous page and replaces the offset; the returned cursor is nil on the
+// last page.
+func (r *UserRepository) List(ctx context.Context, opts repositories.ListUsersOptions) ([]*entities.User, int64, *repositories.PageCursor, error) {
 	start := time.Now()
 	var err error
 	var rowCount int64
@@ -381,10 +412,10 @@ func (r *UserRepository) List(ctx context.Context, opts repositories.ListUsersOp
 	}
 
 	if opts.Search != "" {
-		conditions = append(conditions, fmt.Sprintf("(name LIKE $%d OR email LIKE $%d)", paramIndex, paramIndex+1))
-		searchPattern := "%" + opts.Search + "%"
-		args = append(args, searchPattern, searchPattern)
-		paramIndex += 2
+		// Case-insensitive substring match, served by the trigram indexes
+		conditions = append(conditions, fmt.Sprintf("(name ILIKE $%d OR email ILIKE $%d)", paramIndex, paramIndex))
+		args = append(args, ilikePattern(opts.Search))
+		paramIndex++
 	}
 
 	// Build WHERE clause
@@ -393,42 +424,14 @@ func (r *UserRepository) List(ctx context.Context, opts repositories.ListUsersOp
 		whereClause = "WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	// Count total records
+	// Count total records (of all matches, not just those after the cursor)
 	countQuery := "SELECT COUNT(*) FROM users " + whereClause
 	var total int64
 	err = r.db.GetContext(ctx, &total, countQuery, args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count users: %w", err)
-	}
-
-	// Build ORDER BY clause
-	orderBy := "created_at DESC" // default
-	if opts.SortBy != "" {
-		// Map domain field names to database column names
-		sortFieldMap := map[string]string{
-			"created_at":   "created_at",
-			"display_name": "name", // domain uses display_name, db uses name
-			"email":        "email",
-			"last_login":   "last_seen", // domain uses last_login, db uses last_seen
-		}
-		if dbField, exists := sortFieldMap[opts.SortBy]; exists {
-			direction := "DESC"
-			if opts.SortOrder == "asc" {
-				direction = "ASC"
-			}
-			orderBy = fmt.Sprintf("%s %s", dbField, direction)
-		}
+		return nil, 0, nil, fmt.Errorf("failed to count users: %w", err)
 	}
 
-	// Build main query with pagination
-	query := fmt.Sprintf(`
-		SELECT id, email, name, password_hash, role, user_type,
-		       disabled, created_at, updated_at, last_seen, avatar_url, timezone
-		FROM users 
-		%s 
-		ORDER BY %s 
-		LIMIT $%d OFFSET $%d`, whereClause, orderBy, paramIndex, paramIndex+1)
-
 	// Set default pagination
 	limit := opts.Limit
 	if limit <= 0 {
@@ -439,12 +442,53 @@ func (r *UserRepository) List(ctx context.Context, opts repositories.ListUsersOp
 		offset = 0
 	}
 
-	args = append(args, limit, offset)
+	// A cursor replaces the offset: continue after the previous page's last row
+	order := userListOrder(opts.SortBy, opts.SortOrder)
+	if opts.Cursor != nil {
+		var after string
+		var afterArgs []interface{}
+		after, afterArgs, err = order.afterCondition(opts.Cursor, paramIndex)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		if whereClause == "" {
+			whereClause = "WHERE " + after
+		} else {
+			whereClause += " AND " + after
+		}
+		args = append(args, afterArgs...)
+		paramIndex += len(afterArgs)
+		offset = 0
+	}
 
-	var rows []userRow
+	// Fetch one extra row to tell whether there is a next page
+	query := fmt.Sprintf(`
+		SELECT id, email, name, password_hash, role, user_type,
+		       disabled, created_at, updated_at, last_seen, avatar_url, timezone,
+		       %s AS sort_keys
+		FROM users 
+		%s 
+		ORDER BY %s 
+		LIMIT $%d OFFSET $%d`, order.keysExpr(), whereClause, order.orderClause(), paramIndex, paramIndex+1)
+
+	args = append(args, limit+1, offset)
+
+	var rows []struct {
+		userRow
+		SortKeys pq.StringArray `db:"sort_keys"`
+	}
 	err = r.db.SelectContext(ctx, &rows, query, args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	rowKeys := make([]pq.StringArray, len(rows))
+	for i, row := range rows {
+		rowKeys[i] = row.SortKeys
+	}
+	next := order.nextCursor(rowKeys, limit)
+	if len(rows) > limit {
+		rows = rows[:limit]
 	}
 
 	rowCount = int64(len(rows))
@@ -455,7 +499,41 @@ func (r *UserRepository) List(ctx context.Context, opts repositories.ListUsersOp
 		users[i] = row.toEntity()
 	}
 
-	return users, total, nil
+	return users, total, next, nil
+}
+
+// userListOrder builds the ordering for List from the requested sort field and
+// direction, newest first by default, with the user ID breaking ties so cursors
+// have a unique position
+func userListOrder(sortBy, sortOrder string) keysetOrder {
+	desc := sortOrder != "asc"
+	column := keysetColumn{expr: "created_at", cast: "timestamp", desc: desc}
+	switch sortBy {
+	case "display_name":
+		column.expr = "name"
+		column.cast = "text"
+	case "email":
+		column.expr = "COALESCE(email, '')"
+		column.cast = "text"
+	case "last_login":
+		// Users who never signed in sort as the oldest
+		column.expr = "COALESCE(last_seen, '-infinity'::timestamp)"
+	default:
+		sortBy = "created_at"
+	}
+
+	direction := "desc"
+	if !desc {
+		direction = "asc"
+	}
+
+	return keysetOrder{
+		name: "users:" + sortBy + ":" + direction,
+		columns: []keysetColumn{
+			column,
+			{expr: "id", cast: "text", desc: desc},
+		},
+	}
 }
 
 // UpdateLastLogin updates the user's last login timestamp
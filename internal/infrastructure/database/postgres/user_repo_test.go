@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// TestUserRepositoryList filters and pages through users in a temporary table that
+// shadows users. It needs a real PostgreSQL server and is skipped unless
+// HIVEMIND_TEST_DATABASE_URL is set.
+func TestUserRepositoryList(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sqlx.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	// u3 and u4 share a created_at, so only the id tie-breaker orders them
+	fixture := `
+		CREATE TEMP TABLE users (
+			id TEXT PRIMARY KEY, email TEXT, name TEXT NOT NULL, avatar_url TEXT, timezone TEXT,
+			user_type TEXT, role TEXT, password_hash TEXT, disabled BOOLEAN DEFAULT FALSE,
+			created_at TIMESTAMP, updated_at TIMESTAMP, last_seen TIMESTAMP
+		);
+		INSERT INTO users (id, email, name, user_type, role, disabled, created_at, updated_at) VALUES
+			('u1', 'ada@example.com', 'Ada', 'oidc', 'admin', FALSE, '2024-01-01', '2024-01-01'),
+			('u2', 'grace@example.com', 'Grace', 'local', 'user', TRUE, '2024-01-02', '2024-01-02'),
+			('u3', 'linus@kernel.org', 'Linus', 'oidc', 'user', FALSE, '2024-01-03', '2024-01-03'),
+			('u4', 'ken@example.com', 'Ken_100%', 'oidc', 'user', FALSE, '2024-01-03', '2024-01-03'),
+			('u5', 'svc@service.hivemind', 'Service: bot', 'local', 'admin', FALSE, '2024-01-05', '2024-01-05')`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+	ids := func(users []*entities.User) string {
+		out := make([]string, len(users))
+		for i, u := range users {
+			out[i] = u.ID
+		}
+		return strings.Join(out, " ")
+	}
+	admin, local := entities.RoleAdmin, entities.UserTypeLocal
+	active, inactive := true, false
+
+	filters := []struct {
+		name string
+		opts repositories.ListUsersOptions
+		want string
+	}{
+		{name: "no filter, newest first", want: "u5 u4 u3 u2 u1"},
+		{name: "role", opts: repositories.ListUsersOptions{Role: &admin}, want: "u5 u1"},
+		{name: "user type", opts: repositories.ListUsersOptions{UserType: &local}, want: "u5 u2"},
+		{name: "active", opts: repositories.ListUsersOptions{IsActive: &active}, want: "u5 u4 u3 u1"},
+		{name: "inactive", opts: repositories.ListUsersOptions{IsActive: &inactive}, want: "u2"},
+		{name: "email substring ignores case", opts: repositories.ListUsersOptions{Search: "EXAMPLE.com"}, want: "u4 u2 u1"},
+		{name: "name substring", opts: repositories.ListUsersOptions{Search: "lin"}, want: "u3"},
+		{name: "wildcards are literal", opts: repositories.ListUsersOptions{Search: "_100%"}, want: "u4"},
+		{name: "combined", opts: repositories.ListUsersOptions{Role: &admin, IsActive: &active, Search: "ada"}, want: "u1"},
+	}
+	for _, tt := range filters {
+		t.Run(tt.name, func(t *testing.T) {
+			users, total, next, err := repo.List(ctx, tt.opts)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if got := ids(users); got != tt.want {
+				t.Errorf("List() = %s, want %s", got, tt.want)
+			}
+			if int(total) != len(users) || next != nil {
+				t.Errorf("total = %d, next = %+v; want %d and no next page", total, next, len(users))
+			}
+		})
+	}
+
+	t.Run("pages with cursors", func(t *testing.T) {
+		var pages []string
+		opts := repositories.ListUsersOptions{Limit: 2}
+		for {
+			users, total, next, err := repo.List(ctx, opts)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if total != 5 {
+				t.Errorf("total = %d, want 5 on every page", total)
+			}
+			pages = append(pages, ids(users))
+			if next == nil {
+				break
+			}
+			// Round-trip through the page token like a client would
+			if opts.Cursor, err = repositories.ParsePageToken(next.Token()); err != nil {
+				t.Fatalf("ParsePageToken() error = %v", err)
+			}
+			if len(pages) > 5 {
+				t.Fatal("paging did not end")
+			}
+		}
+		if got := strings.Join(pages, " | "); got != "u5 u4 | u3 u2 | u1" {
+			t.Errorf("pages = %s, want u5 u4 | u3 u2 | u1", got)
+		}
+	})
+
+	t.Run("cursor from another ordering", func(t *testing.T) {
+		_, _, next, err := repo.List(ctx, repositories.ListUsersOptions{Limit: 1, SortBy: "email", SortOrder: "asc"})
+		if err != nil || next == nil {
+			t.Fatalf("List() = %+v, %v; want a next page", next, err)
+		}
+		if _, _, _, err := repo.List(ctx, repositories.ListUsersOptions{Cursor: next}); !errors.Is(err, repositories.ErrInvalidPageToken) {
+			t.Errorf("List() error = %v, want ErrInvalidPageToken", err)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/pkg/idgen"
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+type wikiMergeLogRepository struct {
+	db  *sql.DB
+	log *slog.Logger
+}
+
+// NewWikiMergeLogRepository creates a new PostgreSQL wiki merge log repository
+func NewWikiMergeLogRepository(db *sql.DB) repositories.WikiMergeLogRepository {
+	return &wikiMergeLogRepository{
+		db:  db,
+		log: slog.Default().With(slog.String("repo", "wiki_merge_log")),
+	}
+}
+
+func (r *wikiMergeLogRepository) Create(ctx context.Context, entry *entities.WikiMergeLog) error {
+	start := time.Now()
+	var err error
+	defer func() {
+		metrics.RecordDBOperation("wiki_merge_log", "create", time.Since(start), 1, err)
+	}()
+
+	if entry.ID == "" {
+		entry.ID = idgen.GenerateID()
+	}
+	entry.MergedAt = time.Now()
+
+	r.log.Debug("recording wiki merge",
+		slog.String("source_page_id", entry.SourcePageID),
+		slog.String("target_page_id", entry.TargetPageID))
+
+	sourcePage, err := json.Marshal(entry.SourcePage)
+	if err != nil {
+		return err
+	}
+	sourceTitles, err := json.Marshal(entry.SourceTitles)
+	if err != nil {
+		return err
+	}
+	sourceReferences, err := json.Marshal(entry.SourceReferences)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO wiki_merge_log (
+			id, guild_id, source_page_id, target_page_id, merged_by_user_id,
+			source_page, source_titles, source_references,
+			target_body_before, target_tags_before, merged_body, merged_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		entry.ID, entry.GuildID, entry.SourcePageID, entry.TargetPageID, nullString(entry.MergedByUserID),
+		sourcePage, sourceTitles, sourceReferences,
+		entry.TargetBodyBefore, pq.Array(entry.TargetTagsBefore), entry.MergedBody, entry.MergedAt,
+	)
+	return err
+}
+
+func (r *wikiMergeLogRepository) GetLatestBySourcePage(ctx context.Context, sourcePageID string) (*entities.WikiMergeLog, error) {
+	start := time.Now()
+	var err error
+	var rowCount int64
+	defer func() {
+		metrics.RecordDBOperation("wiki_merge_log", "get_latest_by_source_page", time.Since(start), rowCount, err)
+	}()
+
+	query := `
+		SELECT id, guild_id, source_page_id, target_page_id, merged_by_user_id,
+		       source_page, source_titles, source_references,
+		       target_body_before, target_tags_before, merged_body, merged_at, undone_at
+		FROM wiki_merge_log
+		WHERE source_page_id = $1 AND undone_at IS NULL
+		ORDER BY merged_at DESC
+		LIMIT 1
+	`
+
+	entry := &entities.WikiMergeLog{}
+	var mergedBy sql.NullString
+	var sourcePage, sourceTitles, sourceReferences []byte
+	var undoneAt sql.NullTime
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, sourcePageID).Scan(
+		&entry.ID, &entry.GuildID, &entry.SourcePageID, &entry.TargetPageID, &mergedBy,
+		&sourcePage, &sourceTitles, &sourceReferences,
+		&entry.TargetBodyBefore, pq.Array(&entry.TargetTagsBefore), &entry.MergedBody, &entry.MergedAt, &undoneAt,
+	)
+	if err == sql.ErrNoRows {
+		err = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rowCount = 1
+
+	entry.MergedByUserID = mergedBy.String
+	if undoneAt.Valid {
+		entry.UndoneAt = &undoneAt.Time
+	}
+
+	if err = json.Unmarshal(sourcePage, &entry.SourcePage); err != nil {
+		return nil, fmt.Errorf("failed to decode source page snapshot: %w", err)
+	}
+	if err = json.Unmarshal(sourceTitles, &entry.SourceTitles); err != nil {
+		return nil, fmt.Errorf("failed to decode source titles snapshot: %w", err)
+	}
+	if err = json.Unmarshal(sourceReferences, &entry.SourceReferences); err != nil {
+		return nil, fmt.Errorf("failed to decode source references snapshot: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (r *wikiMergeLogRepository) MarkUndone(ctx context.Context, id string) error {
+	start := time.Now()
+	var err error
+	var rowsAffected int64
+	defer func() {
+		metrics.RecordDBOperation("wiki_merge_log", "mark_undone", time.Since(start), rowsAffected, err)
+	}()
+
+	query := `UPDATE wiki_merge_log SET undone_at = $2 WHERE id = $1 AND undone_at IS NULL`
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		err = fmt.Errorf("wiki merge log entry not found or already undone: %s", id)
+		return err
+	}
+
+	return nil
+}
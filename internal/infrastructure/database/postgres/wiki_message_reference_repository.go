@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -28,53 +29,73 @@ func NewWikiMessageReferenceRepository(db *sql.DB) repositories.WikiMessageRefer
 	}
 }
 
-func (r *wikiMessageReferenceRepository) Create(ctx context.Context, ref *entities.WikiMessageReference) error {
+// insertWikiMessageReferenceQuery adds one reference, returning no row if the page already references the message
+const insertWikiMessageReferenceQuery = `
+	INSERT INTO wiki_message_references (
+		id, wiki_page_id, message_id, channel_id, guild_id,
+		content, author_id, author_username, author_display_name,
+		message_timestamp, attachment_urls, attachment_metadata, added_at, added_by_user_id
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	ON CONFLICT (wiki_page_id, message_id) DO NOTHING
+	RETURNING id, added_at
+`
+
+// wikiMessageReferenceArgs assigns ref a new ID and added time and returns the
+// arguments for insertWikiMessageReferenceQuery
+func wikiMessageReferenceArgs(ref *entities.WikiMessageReference) ([]interface{}, error) {
+	if ref.ID == "" {
+		ref.ID = idgen.GenerateID()
+	}
+	ref.AddedAt = time.Now()
+
+	// Marshal attachments to JSON for JSONB column; NULL for no attachments
+	var attachmentMetadata interface{}
+	if len(ref.Attachments) > 0 {
+		jsonData, err := json.Marshal(ref.Attachments)
+		if err != nil {
+			return nil, err
+		}
+		attachmentMetadata = jsonData
+	}
+
+	return []interface{}{
+		ref.ID, ref.WikiPageID, ref.MessageID, ref.ChannelID, ref.GuildID,
+		ref.Content, ref.AuthorID, ref.AuthorUsername, nullString(ref.AuthorDisplayName),
+		ref.MessageTimestamp, pq.Array(ref.AttachmentURLs), attachmentMetadata, ref.AddedAt, nullString(ref.AddedByUserID),
+	}, nil
+}
+
+func (r *wikiMessageReferenceRepository) Create(ctx context.Context, ref *entities.WikiMessageReference, maxRefs int) error {
 	start := time.Now()
 	var err error
 	defer func() {
 		metrics.RecordDBOperation("wiki_message_reference", "create", time.Since(start), 1, err)
 	}()
 
-	if ref.ID == "" {
-		ref.ID = idgen.GenerateID()
-	}
-	ref.AddedAt = time.Now()
-
 	r.log.Debug("creating wiki message reference",
 		slog.String("wiki_page_id", ref.WikiPageID),
 		slog.String("message_id", ref.MessageID))
 
-	// Marshal attachments to JSON for JSONB column
-	var attachmentMetadata interface{}
-	if len(ref.Attachments) > 0 {
-		jsonData, marshalErr := json.Marshal(ref.Attachments)
-		if marshalErr != nil {
-			err = marshalErr
+	args, err := wikiMessageReferenceArgs(ref)
+	if err != nil {
+		return err
+	}
+
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if maxRefs > 0 {
+		if err = lockReferenceOwner(ctx, tx.Tx, "wiki_pages", ref.WikiPageID); err != nil {
 			return err
 		}
-		attachmentMetadata = jsonData
-	} else {
-		// Use NULL for empty attachments
-		attachmentMetadata = nil
 	}
 
-	query := `
-		INSERT INTO wiki_message_references (
-			id, wiki_page_id, message_id, channel_id, guild_id,
-			content, author_id, author_username, author_display_name,
-			message_timestamp, attachment_urls, attachment_metadata, added_at, added_by_user_id
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
-		ON CONFLICT (wiki_page_id, message_id) DO NOTHING
-		RETURNING id, added_at
-	`
-
 	var returnedID string
 	var returnedAddedAt time.Time
-	err = r.db.QueryRowContext(ctx, query,
-		ref.ID, ref.WikiPageID, ref.MessageID, ref.ChannelID, ref.GuildID,
-		ref.Content, ref.AuthorID, ref.AuthorUsername, nullString(ref.AuthorDisplayName),
-		ref.MessageTimestamp, pq.Array(ref.AttachmentURLs), attachmentMetadata, ref.AddedAt, nullString(ref.AddedByUserID),
-	).Scan(&returnedID, &returnedAddedAt)
+	err = tx.QueryRowContext(ctx, insertWikiMessageReferenceQuery, args...).Scan(&returnedID, &returnedAddedAt)
 
 	if err == sql.ErrNoRows {
 		// ON CONFLICT DO NOTHING was triggered - reference already exists (no-op)
@@ -84,12 +105,84 @@ func (r *wikiMessageReferenceRepository) Create(ctx context.Context, ref *entiti
 		return err
 	}
 
+	if maxRefs > 0 {
+		if err = checkReferenceLimit(ctx, tx.Tx, "wiki_message_references", "wiki_page_id", ref.WikiPageID, maxRefs); err != nil {
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
 	// Update with returned values
 	ref.ID = returnedID
 	ref.AddedAt = returnedAddedAt
 	return nil
 }
 
+func (r *wikiMessageReferenceRepository) CreateBatch(ctx context.Context, refs []*entities.WikiMessageReference, maxRefs int) (int, error) {
+	start := time.Now()
+	var err error
+	var rowsAffected int64
+	defer func() {
+		metrics.RecordDBOperation("wiki_message_reference", "create_batch", time.Since(start), rowsAffected, err)
+	}()
+
+	r.log.Debug("creating wiki message references", slog.Int("count", len(refs)))
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if maxRefs > 0 && len(refs) > 0 {
+		if err = lockReferenceOwner(ctx, tx, "wiki_pages", refs[0].WikiPageID); err != nil {
+			return 0, err
+		}
+	}
+
+	stmt, err := tx.PrepareContext(ctx, insertWikiMessageReferenceQuery)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	for _, ref := range refs {
+		var args []interface{}
+		args, err = wikiMessageReferenceArgs(ref)
+		if err != nil {
+			return 0, err
+		}
+
+		var returnedID string
+		var returnedAddedAt time.Time
+		err = stmt.QueryRowContext(ctx, args...).Scan(&returnedID, &returnedAddedAt)
+		if err == sql.ErrNoRows {
+			// Already referenced by the page
+			err = nil
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert reference to message %s: %w", ref.MessageID, err)
+		}
+		ref.ID = returnedID
+		ref.AddedAt = returnedAddedAt
+		rowsAffected++
+	}
+
+	if maxRefs > 0 && rowsAffected > 0 {
+		if err = checkReferenceLimit(ctx, tx, "wiki_message_references", "wiki_page_id", refs[0].WikiPageID, maxRefs); err != nil {
+			rowsAffected = 0
+			return 0, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(rowsAffected), nil
+}
+
 func (r *wikiMessageReferenceRepository) GetByPageID(ctx context.Context, pageID string) ([]*entities.WikiMessageReference, error) {
 	start := time.Now()
 	var err error
@@ -113,7 +206,7 @@ func (r *wikiMessageReferenceRepository) GetByPageID(ctx context.Context, pageID
 		ORDER BY wmr.message_timestamp DESC
 	`
 
-	rows, queryErr := r.db.QueryContext(ctx, query, pageID)
+	rows, queryErr := conn(ctx, r.db).QueryContext(ctx, query, pageID)
 	if queryErr != nil {
 		err = queryErr
 		return nil, err
@@ -222,7 +315,7 @@ func (r *wikiMessageReferenceRepository) Delete(ctx context.Context, id string)
 	r.log.Debug("deleting wiki message reference", slog.String("id", id))
 
 	query := `DELETE FROM wiki_message_references WHERE id = $1`
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -285,7 +378,7 @@ func (r *wikiMessageReferenceRepository) TransferReferences(ctx context.Context,
 		ON CONFLICT (wiki_page_id, message_id) DO NOTHING
 	`
 
-	result, err := r.db.ExecContext(ctx, query, sourcePageID, targetPageID)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, sourcePageID, targetPageID)
 	if err != nil {
 		return 0, err
 	}
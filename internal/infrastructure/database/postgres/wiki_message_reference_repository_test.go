@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+// TestWikiMessageReferenceCreateBatch adds references in batches, up to and past a
+// reference limit, against temporary tables that shadow wiki_pages and
+// wiki_message_references. It needs a real PostgreSQL server and
+// is skipped unless HIVEMIND_TEST_DATABASE_URL is set.
+func TestWikiMessageReferenceCreateBatch(t *testing.T) {
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	fixture := `
+		CREATE TEMP TABLE wiki_message_references (
+			id TEXT PRIMARY KEY, wiki_page_id TEXT NOT NULL, message_id TEXT NOT NULL,
+			channel_id TEXT, guild_id TEXT, content TEXT, author_id TEXT, author_username TEXT,
+			author_display_name TEXT, message_timestamp TIMESTAMP, attachment_urls TEXT[],
+			attachment_metadata JSONB, added_at TIMESTAMP, added_by_user_id TEXT,
+			UNIQUE (wiki_page_id, message_id)
+		);
+		CREATE TEMP TABLE wiki_pages (id TEXT PRIMARY KEY);
+		INSERT INTO wiki_pages (id) VALUES ('p1'), ('p2');
+		INSERT INTO wiki_message_references (id, wiki_page_id, message_id) VALUES ('existing', 'p1', 'm1')`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+
+	ref := func(pageID, messageID string) *entities.WikiMessageReference {
+		return &entities.WikiMessageReference{
+			WikiPageID:       pageID,
+			MessageID:        messageID,
+			ChannelID:        "c1",
+			GuildID:          "g1",
+			AuthorID:         "a1",
+			MessageTimestamp: time.Now(),
+			Attachments:      []entities.AttachmentMetadata{{URL: "https://example.com/a.png", ContentType: "image/png"}},
+		}
+	}
+
+	repo := NewWikiMessageReferenceRepository(db)
+	added, err := repo.CreateBatch(context.Background(), []*entities.WikiMessageReference{
+		ref("p1", "m1"), // Already referenced
+		ref("p1", "m2"),
+		ref("p2", "m1"), // Same message, different page
+	}, 0)
+	if err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+	if added != 2 {
+		t.Errorf("CreateBatch() added %d, want 2", added)
+	}
+
+	// Running the same batch again adds nothing
+	added, err = repo.CreateBatch(context.Background(), []*entities.WikiMessageReference{ref("p1", "m2"), ref("p2", "m1")}, 0)
+	if err != nil {
+		t.Fatalf("CreateBatch() again error = %v", err)
+	}
+	if added != 0 {
+		t.Errorf("CreateBatch() again added %d, want 0", added)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM wiki_message_references`).Scan(&count); err != nil {
+		t.Fatalf("count failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("table has %d references, want 3", count)
+	}
+
+	// p1 has two references; a limit of three allows one more and no others
+	if err := repo.Create(context.Background(), ref("p1", "m3"), 3); err != nil {
+		t.Fatalf("Create() up to the limit error = %v", err)
+	}
+	if err := repo.Create(context.Background(), ref("p1", "m4"), 3); !errors.Is(err, repositories.ErrReferenceLimitReached) {
+		t.Errorf("Create() past the limit error = %v, want ErrReferenceLimitReached", err)
+	}
+	added, err = repo.CreateBatch(context.Background(), []*entities.WikiMessageReference{ref("p2", "m2"), ref("p2", "m3")}, 2)
+	if !errors.Is(err, repositories.ErrReferenceLimitReached) || added != 0 {
+		t.Errorf("CreateBatch() past the limit = %d, %v, want 0, ErrReferenceLimitReached", added, err)
+	}
+
+	if err := db.QueryRow(`SELECT COUNT(*) FROM wiki_message_references`).Scan(&count); err != nil {
+		t.Fatalf("count failed: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("table has %d references after the limited adds, want 4", count)
+	}
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -45,22 +46,34 @@ func (r *wikiPageRepository) Create(ctx context.Context, page *entities.WikiPage
 	page.CreatedAt = time.Now()
 	page.UpdatedAt = time.Now()
 
+	if page.Status == "" {
+		page.Status = entities.WikiPagePublished
+	}
+
 	// Generate slug from title
 	page.Slug = slug.Make(page.Title)
 
+	// The page and its canonical title go in together, so a create that loses a race
+	// for the title leaves no untitled page behind
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	// Create the page
 	query := `
-		INSERT INTO wiki_pages (id, title, body, author_id, guild_id, channel_id, channel_name, tags, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO wiki_pages (id, title, body, author_id, guild_id, channel_id, channel_name, tags, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 	r.log.Debug("creating wiki page",
 		slog.String("id", page.ID),
 		slog.String("title", page.Title),
 		slog.String("guild_id", page.GuildID),
 		slog.String("author_id", page.AuthorID))
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
 		page.ID, page.Title, page.Body, page.AuthorID, page.GuildID,
-		nullString(page.ChannelID), "", pq.Array(page.Tags),
+		nullString(page.ChannelID), "", pq.Array(page.Tags), string(page.Status),
 		page.CreatedAt, page.UpdatedAt,
 	)
 	if err != nil {
@@ -68,16 +81,20 @@ func (r *wikiPageRepository) Create(ctx context.Context, page *entities.WikiPage
 	}
 
 	// Create canonical wiki_title entry
-	wikiTitle := &entities.WikiTitle{
-		GuildID:      page.GuildID,
-		DisplayTitle: page.Title,
-		PageSlug:     page.Slug,
-		PageID:       page.ID,
-		IsCanonical:  true,
-		CreatedAt:    page.CreatedAt,
+	query = `
+		INSERT INTO wiki_titles (id, guild_id, display_title, page_slug, page_id, is_canonical, created_at)
+		VALUES ($1, $2, $3, $4, $5, true, $6)
+	`
+	_, err = tx.ExecContext(ctx, query, idgen.GenerateID(), page.GuildID, page.Title, page.Slug, page.ID, page.CreatedAt)
+	if isUniqueViolation(err) {
+		err = fmt.Errorf("%w: %s", repositories.ErrWikiTitleTaken, page.Title)
+		return err
+	}
+	if err != nil {
+		return err
 	}
 
-	err = r.titleRepo.Create(ctx, wikiTitle)
+	err = tx.Commit()
 	return err
 }
 
@@ -92,14 +109,17 @@ func (r *wikiPageRepository) GetByID(ctx context.Context, id string, userDiscord
 		slog.String("id", id),
 		slog.String("user_discord_id", userDiscordID))
 
-	// Build query with optional ACL check via guild_members JOIN
+	// Build query with optional ACL check via guild_members JOIN. The author's and
+	// last editor's display names are resolved by the same joins.
 	query := `
-		SELECT wp.id, wp.title, wp.body, wp.author_id, wp.guild_id, wp.channel_id, wp.tags, wp.created_at, wp.updated_at, wp.deleted_at,
-		       udn.display_name
+		SELECT wp.id, wp.title, wp.body, wp.author_id, wp.guild_id, wp.channel_id, wp.tags, wp.pinned, wp.status, wp.created_at, wp.updated_at, wp.deleted_at,
+		       udn.display_name, wp.last_editor_id, eudn.display_name
 		FROM wiki_pages wp
 		LEFT JOIN users u ON wp.author_id = u.id
 		LEFT JOIN discord_users du ON u.id = du.user_id
 		LEFT JOIN user_display_names udn ON du.discord_id = udn.discord_id AND wp.guild_id = udn.guild_id
+		LEFT JOIN discord_users edu ON wp.last_editor_id = edu.user_id
+		LEFT JOIN user_display_names eudn ON edu.discord_id = eudn.discord_id AND wp.guild_id = eudn.guild_id
 	`
 
 	// Add ACL check if userDiscordID provided (non-admin)
@@ -115,24 +135,24 @@ func (r *wikiPageRepository) GetByID(ctx context.Context, id string, userDiscord
 
 	page := &entities.WikiPage{}
 	var tags pq.StringArray
-	var channelID, authorDisplayName sql.NullString
+	var channelID, authorDisplayName, lastEditorID, lastEditorDisplayName sql.NullString
 	var deletedAt sql.NullTime
 
 	if userDiscordID != "" {
-		err = r.db.QueryRowContext(ctx, query, id, userDiscordID).Scan(
+		err = conn(ctx, r.db).QueryRowContext(ctx, query, id, userDiscordID).Scan(
 			&page.ID, &page.Title, &page.Body, &page.AuthorID, &page.GuildID,
-			&channelID, &tags, &page.CreatedAt, &page.UpdatedAt, &deletedAt,
-			&authorDisplayName,
+			&channelID, &tags, &page.Pinned, &page.Status, &page.CreatedAt, &page.UpdatedAt, &deletedAt,
+			&authorDisplayName, &lastEditorID, &lastEditorDisplayName,
 		)
 	} else {
-		err = r.db.QueryRowContext(ctx, query, id).Scan(
+		err = conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 			&page.ID, &page.Title, &page.Body, &page.AuthorID, &page.GuildID,
-			&channelID, &tags, &page.CreatedAt, &page.UpdatedAt, &deletedAt,
-			&authorDisplayName,
+			&channelID, &tags, &page.Pinned, &page.Status, &page.CreatedAt, &page.UpdatedAt, &deletedAt,
+			&authorDisplayName, &lastEditorID, &lastEditorDisplayName,
 		)
 	}
 	if err == sql.ErrNoRows {
-		err = fmt.Errorf("wiki page not found: %s", id)
+		err = fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, id)
 		return nil, err
 	}
 	if err != nil {
@@ -141,6 +161,8 @@ func (r *wikiPageRepository) GetByID(ctx context.Context, id string, userDiscord
 
 	page.ChannelID = channelID.String
 	page.AuthorDisplayName = authorDisplayName.String
+	page.LastEditorID = lastEditorID.String
+	page.LastEditorDisplayName = lastEditorDisplayName.String
 	page.Tags = tags
 	page.Slug = slug.Make(page.Title)
 	if deletedAt.Valid {
@@ -207,14 +229,106 @@ func (r *wikiPageRepository) Update(ctx context.Context, page *entities.WikiPage
 
 	r.log.Debug("updating wiki page",
 		slog.String("id", page.ID),
-		slog.String("title", page.Title))
+		slog.String("title", page.Title),
+		slog.String("last_editor_id", page.LastEditorID))
+
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
 	query := `
 		UPDATE wiki_pages
-		SET title = $2, body = $3, tags = $4, updated_at = $5
+		SET title = $2, body = $3, tags = $4, updated_at = $5, last_editor_id = $6
 		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING guild_id
 	`
-	result, err := r.db.ExecContext(ctx, query,
+	var guildID string
+	err = tx.QueryRowContext(ctx, query,
+		page.ID, page.Title, page.Body, pq.Array(page.Tags), page.UpdatedAt, nullString(page.LastEditorID),
+	).Scan(&guildID)
+	if err == sql.ErrNoRows {
+		err = fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, page.ID)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	rowsAffected = 1
+
+	if err = renameCanonicalTitle(ctx, tx.Tx, guildID, page.ID, page.Title); err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	return err
+}
+
+// renameCanonicalTitle makes title the canonical title of a page. When the slug is
+// unchanged only the displayed title is updated; otherwise the old canonical title becomes
+// an alias, and the new slug is either added or, if it's already one of the page's
+// aliases, promoted. A slug used by another page returns ErrWikiTitleTaken.
+func renameCanonicalTitle(ctx context.Context, tx *sql.Tx, guildID, pageID, title string) error {
+	newSlug := slug.Make(title)
+
+	var currentSlug string
+	err := tx.QueryRowContext(ctx, `
+		SELECT page_slug FROM wiki_titles WHERE page_id = $1 AND is_canonical
+	`, pageID).Scan(&currentSlug)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == nil && currentSlug == newSlug {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE wiki_titles SET display_title = $2 WHERE page_id = $1 AND is_canonical
+		`, pageID, title)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE wiki_titles SET is_canonical = false WHERE page_id = $1 AND is_canonical
+	`, pageID); err != nil {
+		return err
+	}
+
+	// Claim the slug unless another page already has it
+	var titleID string
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO wiki_titles (id, guild_id, display_title, page_slug, page_id, is_canonical, created_at)
+		VALUES ($1, $2, $3, $4, $5, true, CURRENT_TIMESTAMP)
+		ON CONFLICT (guild_id, page_slug) DO UPDATE
+		SET display_title = EXCLUDED.display_title, is_canonical = true
+		WHERE wiki_titles.page_id = EXCLUDED.page_id
+		RETURNING id
+	`, idgen.GenerateID(), guildID, title, newSlug, pageID).Scan(&titleID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s", repositories.ErrWikiTitleTaken, title)
+	}
+	return err
+}
+
+func (r *wikiPageRepository) Restore(ctx context.Context, page *entities.WikiPage) error {
+	start := time.Now()
+	var err error
+	var rowsAffected int64
+	defer func() {
+		metrics.RecordDBOperation("wiki_page", "restore", time.Since(start), rowsAffected, err)
+	}()
+
+	page.UpdatedAt = time.Now()
+	page.DeletedAt = nil
+
+	r.log.Debug("restoring wiki page",
+		slog.String("id", page.ID),
+		slog.String("title", page.Title))
+
+	query := `
+		UPDATE wiki_pages
+		SET title = $2, body = $3, tags = $4, updated_at = $5, deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
 		page.ID, page.Title, page.Body, pq.Array(page.Tags), page.UpdatedAt,
 	)
 	if err != nil {
@@ -226,13 +340,25 @@ func (r *wikiPageRepository) Update(ctx context.Context, page *entities.WikiPage
 		return err
 	}
 	if rowsAffected == 0 {
-		err = fmt.Errorf("wiki page not found: %s", page.ID)
+		err = fmt.Errorf("deleted wiki page not found: %s", page.ID)
 		return err
 	}
 
 	return nil
 }
 
+func (r *wikiPageRepository) IDExists(ctx context.Context, id string) (bool, error) {
+	start := time.Now()
+	var err error
+	defer func() {
+		metrics.RecordDBOperation("wiki_page", "id_exists", time.Since(start), -1, err)
+	}()
+
+	var exists bool
+	err = r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM wiki_pages WHERE id = $1)`, id).Scan(&exists)
+	return exists, err
+}
+
 func (r *wikiPageRepository) Delete(ctx context.Context, id string) error {
 	start := time.Now()
 	var err error
@@ -248,7 +374,7 @@ func (r *wikiPageRepository) Delete(ctx context.Context, id string) error {
 		SET deleted_at = $2
 		WHERE id = $1 AND deleted_at IS NULL
 	`
-	result, err := r.db.ExecContext(ctx, query, id, time.Now())
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id, time.Now())
 	if err != nil {
 		return err
 	}
@@ -258,14 +384,14 @@ func (r *wikiPageRepository) Delete(ctx context.Context, id string) error {
 		return err
 	}
 	if rowsAffected == 0 {
-		err = fmt.Errorf("wiki page not found: %s", id)
+		err = fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, id)
 		return err
 	}
 
 	return nil
 }
 
-func (r *wikiPageRepository) List(ctx context.Context, guildID string, limit, offset int, orderBy string, ascending bool, userDiscordID string) ([]*entities.WikiPage, int, error) {
+func (r *wikiPageRepository) List(ctx context.Context, guildID string, limit, offset int, cursor *repositories.PageCursor, orderBy string, ascending bool, userDiscordID string) ([]*entities.WikiPage, int, *repositories.PageCursor, error) {
 	start := time.Now()
 	var err error
 	var rowCount int64
@@ -277,21 +403,6 @@ func (r *wikiPageRepository) List(ctx context.Context, guildID string, limit, of
 		limit = 50
 	}
 
-	// Validate orderBy
-	validOrderBy := map[string]bool{
-		"created_at": true,
-		"updated_at": true,
-		"title":      true,
-	}
-	if !validOrderBy[orderBy] {
-		orderBy = "created_at"
-	}
-
-	direction := "DESC"
-	if ascending {
-		direction = "ASC"
-	}
-
 	// Build WHERE clause and FROM clause
 	fromClause := "wiki_pages wp"
 	whereClause := "wp.deleted_at IS NULL"
@@ -299,12 +410,15 @@ func (r *wikiPageRepository) List(ctx context.Context, guildID string, limit, of
 	argCount := 0
 
 	// Add ACL filter if userDiscordID provided (non-admin)
+	draftParam := 0
 	if userDiscordID != "" {
 		fromClause += " INNER JOIN guild_members gm ON wp.guild_id = gm.guild_id"
 		argCount++
 		whereClause += fmt.Sprintf(" AND gm.discord_id = $%d", argCount)
 		args = append(args, userDiscordID)
+		draftParam = argCount
 	}
+	whereClause += " AND " + wikiDraftFilterExpr(draftParam)
 
 	// Add guild filter if specified
 	if guildID != "" {
@@ -313,7 +427,7 @@ func (r *wikiPageRepository) List(ctx context.Context, guildID string, limit, of
 		args = append(args, guildID)
 	}
 
-	// Get total count
+	// Get total count (of all pages, not just those after the cursor)
 	var total int
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", fromClause, whereClause)
 	r.log.Debug("counting wiki pages for list",
@@ -322,13 +436,29 @@ func (r *wikiPageRepository) List(ctx context.Context, guildID string, limit, of
 		slog.String("query", countQuery))
 	if err2 := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err2 != nil {
 		err = err2
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
-	// Get pages with canonical slug from wiki_titles
+	// A cursor replaces the offset: continue after the previous page's last row
+	order := wikiListOrder(orderBy, ascending)
+	if cursor != nil {
+		var after string
+		var afterArgs []interface{}
+		after, afterArgs, err = order.afterCondition(cursor, argCount+1)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		whereClause += " AND " + after
+		args = append(args, afterArgs...)
+		argCount += len(afterArgs)
+		offset = 0
+	}
+
+	// Get pages with canonical slug from wiki_titles, plus one extra row to tell
+	// whether there is a next page
 	query := fmt.Sprintf(`
-		SELECT wp.id, wt.display_title, wp.body, wp.author_id, wp.guild_id, dg.guild_name, wp.channel_id, wp.tags, wp.created_at, wp.updated_at, wt.page_slug,
-		       udn.display_name
+		SELECT wp.id, wt.display_title, wp.body, wp.author_id, wp.guild_id, dg.guild_name, wp.channel_id, wp.tags, wp.pinned, wp.status, wp.created_at, wp.updated_at, wt.page_slug,
+		       udn.display_name, %s, %s, %s AS sort_keys
 		FROM %s
 		LEFT JOIN discord_guilds dg ON wp.guild_id = dg.guild_id
 		LEFT JOIN wiki_titles wt ON wp.id = wt.page_id AND wt.is_canonical = TRUE
@@ -336,35 +466,37 @@ func (r *wikiPageRepository) List(ctx context.Context, guildID string, limit, of
 		LEFT JOIN discord_users du ON u.id = du.user_id
 		LEFT JOIN user_display_names udn ON du.discord_id = udn.discord_id AND wp.guild_id = udn.guild_id
 		WHERE %s
-		ORDER BY wp.%s %s
+		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, fromClause, whereClause, orderBy, direction, argCount+1, argCount+2)
+	`, contentSizeExpr("wp"), referenceCountExpr("wiki_message_references", "wiki_page_id", "wp"), order.keysExpr(), fromClause, whereClause, order.orderClause(), argCount+1, argCount+2)
 
-	args = append(args, limit, offset)
+	args = append(args, limit+1, offset)
 	r.log.Debug("selecting wiki pages for list",
 		slog.String("query", query),
 		slog.Int("limit", limit),
 		slog.Int("offset", offset))
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 	defer rows.Close()
 
 	pages := []*entities.WikiPage{}
+	var rowKeys []pq.StringArray
 	for rows.Next() {
 		page := &entities.WikiPage{}
 		var tags pq.StringArray
 		var guildName, channelID, authorDisplayName sql.NullString
 		var pageSlug sql.NullString
+		var sortKeys pq.StringArray
 
 		err := rows.Scan(
 			&page.ID, &page.Title, &page.Body, &page.AuthorID, &page.GuildID, &guildName,
-			&channelID, &tags, &page.CreatedAt, &page.UpdatedAt, &pageSlug,
-			&authorDisplayName,
+			&channelID, &tags, &page.Pinned, &page.Status, &page.CreatedAt, &page.UpdatedAt, &pageSlug,
+			&authorDisplayName, &page.BodyLength, &page.TagCount, &page.ReferenceCount, &sortKeys,
 		)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, nil, err
 		}
 
 		page.GuildName = guildName.String
@@ -378,13 +510,19 @@ func (r *wikiPageRepository) List(ctx context.Context, guildID string, limit, of
 			page.Slug = slug.Make(page.Title)
 		}
 		pages = append(pages, page)
+		rowKeys = append(rowKeys, sortKeys)
+	}
+
+	next := order.nextCursor(rowKeys, limit)
+	if len(pages) > limit {
+		pages = pages[:limit]
 	}
 
 	rowCount = int64(len(pages))
-	return pages, total, nil
+	return pages, total, next, nil
 }
 
-func (r *wikiPageRepository) Search(ctx context.Context, guildID, query string, tags []string, limit, offset int, userDiscordID string) ([]*entities.WikiPage, int, error) {
+func (r *wikiPageRepository) Search(ctx context.Context, guildIDs []string, query string, tags []string, authorDiscordID string, limit, offset int, userDiscordID string) ([]*entities.WikiPage, int, error) {
 	start := time.Now()
 	var err error
 	var rowCount int64
@@ -397,32 +535,43 @@ func (r *wikiPageRepository) Search(ctx context.Context, guildID, query string,
 	}
 
 	// Build FROM clause and search conditions
-	fromClause := "wiki_pages wp"
+	fromClause := "wiki_pages wp LEFT JOIN wiki_titles wt ON wp.id = wt.page_id AND wt.is_canonical = TRUE"
 	conditions := []string{"wp.deleted_at IS NULL"}
 	args := []interface{}{}
 	argCount := 0
 
 	// Add ACL filter if userDiscordID provided (non-admin)
+	draftParam := 0
 	if userDiscordID != "" {
 		fromClause += " INNER JOIN guild_members gm ON wp.guild_id = gm.guild_id"
 		argCount++
 		conditions = append(conditions, fmt.Sprintf("gm.discord_id = $%d", argCount))
 		args = append(args, userDiscordID)
+		draftParam = argCount
 	}
+	conditions = append(conditions, wikiDraftFilterExpr(draftParam))
 
 	// Add guild filter if specified
-	if guildID != "" {
+	if len(guildIDs) > 0 {
 		argCount++
-		conditions = append(conditions, fmt.Sprintf("wp.guild_id = $%d", argCount))
-		args = append(args, guildID)
+		conditions = append(conditions, guildFilterExpr("wp.guild_id", argCount))
+		args = append(args, pq.Array(guildIDs))
 	}
 
-	// Full-text search on title and body
-	if query != "" {
+	// Full-text search on title and body, falling back to ILIKE when the
+	// query has no token long enough for the full-text parser
+	fullText := query != "" && useFullTextSearch(query)
+	var queryParamPos int
+	if fullText {
 		argCount++
+		queryParamPos = argCount
 		// Use hybrid search vector: searches both english and simple dictionaries
-		conditions = append(conditions, fmt.Sprintf("(wp.search_vector @@ websearch_to_tsquery('english', $%d) OR wp.search_vector @@ websearch_to_tsquery('simple', $%d))", argCount, argCount))
+		conditions = append(conditions, searchMatchExpr("wp.search_vector", argCount))
 		args = append(args, query)
+	} else if query != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("(wt.display_title ILIKE $%d OR wp.body ILIKE $%d)", argCount, argCount))
+		args = append(args, ilikePattern(query))
 	}
 
 	// Tag filtering
@@ -432,48 +581,50 @@ func (r *wikiPageRepository) Search(ctx context.Context, guildID, query string,
 		args = append(args, pq.Array(tags))
 	}
 
+	// Author filtering
+	if authorDiscordID != "" {
+		argCount++
+		conditions = append(conditions, authorFilterExpr("wp.author_id", argCount))
+		args = append(args, authorDiscordID)
+	}
+
 	whereClause := strings.Join(conditions, " AND ")
 
 	// Get total count
 	var total int
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", fromClause, whereClause)
 	r.log.Debug("counting wiki pages for search",
-		slog.String("guild_id", guildID),
+		slog.Any("guild_ids", guildIDs),
 		slog.String("user_discord_id", userDiscordID),
 		slog.String("search_query", query),
 		slog.Any("tags", tags),
+		slog.String("author_discord_id", authorDiscordID),
 		slog.String("count_query", countQuery))
 	if err2 := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err2 != nil {
 		err = err2
 		return nil, 0, err
 	}
 
-	// Get pages with ranking and canonical slug from wiki_titles
-	// Use query text for ranking if provided
-	orderByClause := "wp.created_at DESC"
-	if query != "" {
-		// Find the query parameter position
-		for i, arg := range args {
-			if s, ok := arg.(string); ok && s == query {
-				orderByClause = fmt.Sprintf("ts_rank(wp.search_vector, websearch_to_tsquery('english', $%d)) DESC", i+1)
-				break
-			}
-		}
+	// Get pages with ranking, snippet and canonical slug from wiki_titles
+	rankClause := "0::real"
+	snippetClause := "''"
+	if fullText {
+		rankClause = searchRankExpr("wp.search_vector", queryParamPos)
+		snippetClause = searchHeadlineExpr("wp.body", queryParamPos)
 	}
 
 	searchQuery := fmt.Sprintf(`
-		SELECT wp.id, wt.display_title, wp.body, wp.author_id, wp.guild_id, dg.guild_name, wp.channel_id, wp.tags, wp.created_at, wp.updated_at, wt.page_slug,
-		       udn.display_name
+		SELECT wp.id, wt.display_title, wp.body, wp.author_id, wp.guild_id, dg.guild_name, wp.channel_id, wp.tags, wp.pinned, wp.status, wp.created_at, wp.updated_at, wt.page_slug,
+		       udn.display_name, %s, %s, %s AS rank, %s AS snippet
 		FROM %s
 		LEFT JOIN discord_guilds dg ON wp.guild_id = dg.guild_id
-		LEFT JOIN wiki_titles wt ON wp.id = wt.page_id AND wt.is_canonical = TRUE
 		LEFT JOIN users u ON wp.author_id = u.id
 		LEFT JOIN discord_users du ON u.id = du.user_id
 		LEFT JOIN user_display_names udn ON du.discord_id = udn.discord_id AND wp.guild_id = udn.guild_id
 		WHERE %s
 		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, fromClause, whereClause, orderByClause, argCount+1, argCount+2)
+	`, contentSizeExpr("wp"), referenceCountExpr("wiki_message_references", "wiki_page_id", "wp"), rankClause, snippetClause, fromClause, whereClause, wikiSearchOrderClause(fullText), argCount+1, argCount+2)
 
 	args = append(args, limit, offset)
 
@@ -496,8 +647,8 @@ func (r *wikiPageRepository) Search(ctx context.Context, guildID, query string,
 
 		err := rows.Scan(
 			&page.ID, &page.Title, &page.Body, &page.AuthorID, &page.GuildID,
-			&guildName, &channelID, &tagArray, &page.CreatedAt, &page.UpdatedAt, &pageSlug,
-			&authorDisplayName,
+			&guildName, &channelID, &tagArray, &page.Pinned, &page.Status, &page.CreatedAt, &page.UpdatedAt, &pageSlug,
+			&authorDisplayName, &page.BodyLength, &page.TagCount, &page.ReferenceCount, &page.Rank, &page.Snippet,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -513,6 +664,9 @@ func (r *wikiPageRepository) Search(ctx context.Context, guildID, query string,
 			// Fallback if wiki_titles entry missing
 			page.Slug = slug.Make(page.Title)
 		}
+		if query != "" && !fullText {
+			page.Snippet = fallbackSnippet(page.Body, query)
+		}
 		pages = append(pages, page)
 	}
 
@@ -520,6 +674,283 @@ func (r *wikiPageRepository) Search(ctx context.Context, guildID, query string,
 	return pages, total, nil
 }
 
+func (r *wikiPageRepository) FindTaggedMatches(ctx context.Context, guildID, query, excludeID string, limit int, userDiscordID string) ([]repositories.TaggedMatch, error) {
+	start := time.Now()
+	var err error
+	var rowCount int64
+	defer func() {
+		metrics.RecordDBOperation("wiki_page", "find_tagged_matches", time.Since(start), rowCount, err)
+	}()
+
+	fromClause := "wiki_pages wp"
+	conditions := []string{
+		"wp.deleted_at IS NULL",
+		// Drafts are private to their author, and suggestions are shown to other editors
+		"wp.status = 'published'",
+		"wp.guild_id = $1",
+		"wp.id <> $2",
+		"cardinality(wp.tags) > 0",
+		searchMatchExpr("wp.search_vector", 3),
+	}
+	args := []interface{}{guildID, excludeID, query, limit}
+
+	// Add ACL filter if userDiscordID provided (non-admin)
+	if userDiscordID != "" {
+		fromClause += " INNER JOIN guild_members gm ON wp.guild_id = gm.guild_id"
+		conditions = append(conditions, "gm.discord_id = $5")
+		args = append(args, userDiscordID)
+	}
+
+	matchQuery := fmt.Sprintf(`
+		SELECT wp.tags, %s AS rank
+		FROM %s
+		WHERE %s
+		ORDER BY rank DESC, wp.id
+		LIMIT $4
+	`, searchRankExpr("wp.search_vector", 3), fromClause, strings.Join(conditions, " AND "))
+
+	r.log.Debug("finding tagged wiki pages",
+		slog.String("guild_id", guildID),
+		slog.String("search_query", query),
+		slog.Int("limit", limit))
+	rows, err := r.db.QueryContext(ctx, matchQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []repositories.TaggedMatch
+	for rows.Next() {
+		var tags pq.StringArray
+		var rank float64
+		if err = rows.Scan(&tags, &rank); err != nil {
+			return nil, err
+		}
+		matches = append(matches, repositories.TaggedMatch{Tags: tags, Rank: rank})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rowCount = int64(len(matches))
+	return matches, nil
+}
+
+func (r *wikiPageRepository) SetPinned(ctx context.Context, id string, pinned bool) error {
+	start := time.Now()
+	var err error
+	var rowsAffected int64
+	defer func() {
+		metrics.RecordDBOperation("wiki_page", "set_pinned", time.Since(start), rowsAffected, err)
+	}()
+
+	r.log.Debug("setting wiki page pinned",
+		slog.String("id", id),
+		slog.Bool("pinned", pinned))
+
+	// updated_at is left alone: pinning isn't an edit to the page content
+	query := `
+		UPDATE wiki_pages
+		SET pinned = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, id, pinned)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		err = fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, id)
+		return err
+	}
+
+	return nil
+}
+
+// SetAuthor makes authorID the author of a wiki page
+func (r *wikiPageRepository) SetAuthor(ctx context.Context, id, authorID string) error {
+	start := time.Now()
+	var err error
+	var rowsAffected int64
+	defer func() {
+		metrics.RecordDBOperation("wiki_page", "set_author", time.Since(start), rowsAffected, err)
+	}()
+
+	r.log.Debug("setting wiki page author",
+		slog.String("id", id),
+		slog.String("author_id", authorID))
+
+	// updated_at is left alone: a new owner isn't an edit to the page content
+	query := `
+		UPDATE wiki_pages
+		SET author_id = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, id, authorID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		err = fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, id)
+		return err
+	}
+
+	return nil
+}
+
+// SetStatus makes a wiki page a draft or publishes it
+func (r *wikiPageRepository) SetStatus(ctx context.Context, id string, status entities.WikiPageStatus) error {
+	start := time.Now()
+	var err error
+	var rowsAffected int64
+	defer func() {
+		metrics.RecordDBOperation("wiki_page", "set_status", time.Since(start), rowsAffected, err)
+	}()
+
+	r.log.Debug("setting wiki page status",
+		slog.String("id", id),
+		slog.String("status", string(status)))
+
+	// updated_at is left alone: publishing isn't an edit to the page content
+	query := `
+		UPDATE wiki_pages
+		SET status = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, id, string(status))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		err = fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, id)
+		return err
+	}
+
+	return nil
+}
+
+func (r *wikiPageRepository) ListDrafts(ctx context.Context, guildID, authorID string) ([]*entities.WikiPage, error) {
+	start := time.Now()
+	var err error
+	var rowCount int64
+	defer func() {
+		metrics.RecordDBOperation("wiki_page", "list_drafts", time.Since(start), rowCount, err)
+	}()
+
+	r.log.Debug("listing wiki drafts",
+		slog.String("guild_id", guildID),
+		slog.String("author_id", authorID))
+
+	query := fmt.Sprintf(`
+		SELECT wp.id, wt.display_title, wp.author_id, wp.guild_id, wp.channel_id, wp.tags, wp.pinned, wp.created_at, wp.updated_at, wt.page_slug,
+		       %s, %s
+		FROM wiki_pages wp
+		LEFT JOIN wiki_titles wt ON wp.id = wt.page_id AND wt.is_canonical = TRUE
+		WHERE wp.guild_id = $1 AND wp.author_id = $2 AND wp.status = 'draft' AND wp.deleted_at IS NULL
+		ORDER BY wp.updated_at DESC, wp.id
+	`, contentSizeExpr("wp"), referenceCountExpr("wiki_message_references", "wiki_page_id", "wp"))
+
+	rows, err := r.db.QueryContext(ctx, query, guildID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pages := []*entities.WikiPage{}
+	for rows.Next() {
+		page := &entities.WikiPage{Status: entities.WikiPageDraft}
+		var tags pq.StringArray
+		var title, channelID, pageSlug sql.NullString
+
+		if err = rows.Scan(
+			&page.ID, &title, &page.AuthorID, &page.GuildID, &channelID, &tags, &page.Pinned,
+			&page.CreatedAt, &page.UpdatedAt, &pageSlug, &page.BodyLength, &page.TagCount, &page.ReferenceCount,
+		); err != nil {
+			return nil, err
+		}
+
+		page.Title = title.String
+		page.ChannelID = channelID.String
+		page.Tags = tags
+		if pageSlug.Valid {
+			page.Slug = pageSlug.String
+		} else {
+			// Fallback if wiki_titles entry missing
+			page.Slug = slug.Make(page.Title)
+		}
+		pages = append(pages, page)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rowCount = int64(len(pages))
+	return pages, nil
+}
+
+// wikiDraftFilterExpr returns a WHERE condition that hides drafts from everyone but
+// their author, whose Discord ID is bound to param. With no caller to match (param 0, as
+// for admins and service accounts, which have no membership filter) every draft is hidden.
+func wikiDraftFilterExpr(param int) string {
+	if param == 0 {
+		return "wp.status = 'published'"
+	}
+	return fmt.Sprintf("(wp.status = 'published' OR %s)", authorFilterExpr("wp.author_id", param))
+}
+
+// wikiListOrder builds the ordering for List. Pinned pages always come first; the
+// requested column and direction order pages within the pinned and unpinned groups,
+// with the page ID breaking ties so cursors have a unique position.
+func wikiListOrder(orderBy string, ascending bool) keysetOrder {
+	column := keysetColumn{expr: "wp.created_at", cast: "timestamp", desc: !ascending}
+	switch orderBy {
+	case "updated_at":
+		column.expr = "wp.updated_at"
+	case "title":
+		column.expr = "COALESCE(wp.title, '')"
+		column.cast = "text"
+	default:
+		orderBy = "created_at"
+	}
+
+	direction := "desc"
+	if ascending {
+		direction = "asc"
+	}
+
+	return keysetOrder{
+		name: "wiki:" + orderBy + ":" + direction,
+		columns: []keysetColumn{
+			{expr: "wp.pinned", cast: "boolean", desc: true},
+			column,
+			{expr: "wp.id", cast: "text", desc: !ascending},
+		},
+	}
+}
+
+// wikiSearchOrderClause builds the ORDER BY for Search: pinned pages first, then by
+// relevance when full-text search is used, then newest first
+func wikiSearchOrderClause(fullText bool) string {
+	if fullText {
+		return "wp.pinned DESC, rank DESC, wp.created_at DESC"
+	}
+	return "wp.pinned DESC, wp.created_at DESC"
+}
+
 // GetTitlesForGuild returns only ID, Title, and Slug for all pages in a guild (lightweight for autocomplete)
 func (r *wikiPageRepository) GetTitlesForGuild(ctx context.Context, guildID string) ([]struct {
 	ID    string
@@ -540,7 +971,7 @@ func (r *wikiPageRepository) GetTitlesForGuild(ctx context.Context, guildID stri
 		SELECT wp.id, wt.display_title, wt.page_slug
 		FROM wiki_pages wp
 		LEFT JOIN wiki_titles wt ON wp.id = wt.page_id AND wt.is_canonical = TRUE
-		WHERE wp.guild_id = $1 AND wp.deleted_at IS NULL
+		WHERE wp.guild_id = $1 AND wp.deleted_at IS NULL AND wp.status = 'published'
 		ORDER BY wp.updated_at DESC
 	`
 
@@ -580,6 +1011,12 @@ func (r *wikiPageRepository) GetTitlesForGuild(ctx context.Context, guildID stri
 	return titles, err
 }
 
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
 func nullString(s string) sql.NullString {
 	if s == "" {
 		return sql.NullString{Valid: false}
@@ -0,0 +1,374 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+)
+
+func TestWikiListOrderPinnedFirst(t *testing.T) {
+	tests := []struct {
+		orderBy   string
+		ascending bool
+		want      string
+	}{
+		{orderBy: "created_at", ascending: false, want: "wp.pinned DESC, wp.created_at DESC, wp.id DESC"},
+		{orderBy: "created_at", ascending: true, want: "wp.pinned DESC, wp.created_at ASC, wp.id ASC"},
+		{orderBy: "updated_at", ascending: false, want: "wp.pinned DESC, wp.updated_at DESC, wp.id DESC"},
+		{orderBy: "title", ascending: true, want: "wp.pinned DESC, COALESCE(wp.title, '') ASC, wp.id ASC"},
+		{orderBy: "pinned", ascending: true, want: "wp.pinned DESC, wp.created_at ASC, wp.id ASC"},
+		{orderBy: "", ascending: false, want: "wp.pinned DESC, wp.created_at DESC, wp.id DESC"},
+		{orderBy: "title; DROP TABLE wiki_pages", ascending: false, want: "wp.pinned DESC, wp.created_at DESC, wp.id DESC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.orderBy, func(t *testing.T) {
+			if got := wikiListOrder(tt.orderBy, tt.ascending).orderClause(); got != tt.want {
+				t.Errorf("wikiListOrder(%q, %v) = %q, want %q", tt.orderBy, tt.ascending, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWikiSearchOrderClausePinnedFirst(t *testing.T) {
+	for _, fullText := range []bool{false, true} {
+		got := wikiSearchOrderClause(fullText)
+		if !strings.HasPrefix(got, "wp.pinned DESC, ") {
+			t.Errorf("wikiSearchOrderClause(%v) = %q, want pinned pages first", fullText, got)
+		}
+		if fullText && !strings.Contains(got, "rank DESC") {
+			t.Errorf("wikiSearchOrderClause(true) = %q, want ranking preserved after pinning", got)
+		}
+	}
+}
+
+// openWikiTestDB connects to HIVEMIND_TEST_DATABASE_URL and creates temporary tables
+// that shadow wiki_pages and wiki_titles. Tests using it need a real PostgreSQL server
+// and are skipped when the variable isn't set.
+func openWikiTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("HIVEMIND_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("HIVEMIND_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	// Temporary tables are per connection
+	db.SetMaxOpenConns(1)
+
+	fixture := `
+		CREATE TEMP TABLE wiki_pages (
+			id TEXT PRIMARY KEY, title TEXT, body TEXT NOT NULL, author_id TEXT NOT NULL,
+			guild_id TEXT NOT NULL, channel_id TEXT, channel_name TEXT, tags TEXT[],
+			pinned BOOLEAN NOT NULL DEFAULT FALSE, status TEXT NOT NULL DEFAULT 'published', last_editor_id TEXT,
+			created_at TIMESTAMP, updated_at TIMESTAMP, deleted_at TIMESTAMP
+		);
+		CREATE TEMP TABLE wiki_titles (
+			id TEXT PRIMARY KEY, guild_id TEXT NOT NULL, display_title TEXT NOT NULL,
+			page_slug TEXT NOT NULL, page_id TEXT NOT NULL, is_canonical BOOLEAN NOT NULL DEFAULT false,
+			created_at TIMESTAMP, created_by_user_id TEXT, created_by_merge BOOLEAN NOT NULL DEFAULT false,
+			UNIQUE (guild_id, page_slug)
+		);
+		CREATE UNIQUE INDEX ON wiki_titles(page_id) WHERE is_canonical`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+	return db
+}
+
+func TestWikiPageCreateSameTitleConcurrently(t *testing.T) {
+	db := openWikiTestDB(t)
+
+	// With one connection the creates queue for it; the loser's transaction still has
+	// to roll back its page when the title insert fails
+	repo := NewWikiPageRepository(db, NewWikiTitleRepository(db))
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repo.Create(context.Background(), &entities.WikiPage{
+				Title:    []string{"House Rules", "house rules"}[i],
+				Body:     "Be nice",
+				AuthorID: "u1",
+				GuildID:  "g1",
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	created, taken := 0, 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case errors.Is(err, repositories.ErrWikiTitleTaken):
+			taken++
+		default:
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if created != 1 || taken != 1 {
+		t.Fatalf("Create() succeeded %d times and hit the title %d times, want once each", created, taken)
+	}
+
+	var pages int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM wiki_pages`).Scan(&pages); err != nil {
+		t.Fatalf("count failed: %v", err)
+	}
+	if pages != 1 {
+		t.Errorf("wiki_pages has %d rows, want 1 with no untitled page left by the losing create", pages)
+	}
+}
+
+func TestWikiPageUpdateRenames(t *testing.T) {
+	db := openWikiTestDB(t)
+	fixture := `
+		INSERT INTO wiki_pages (id, title, body, author_id, guild_id) VALUES
+			('p1', 'Rules', 'Be nice', 'u1', 'g1'), ('p2', 'FAQ', 'Ask away', 'u1', 'g1');
+		INSERT INTO wiki_titles (id, guild_id, display_title, page_slug, page_id, is_canonical) VALUES
+			('t1', 'g1', 'Rules', 'rules', 'p1', true), ('t2', 'g1', 'Guidelines', 'guidelines', 'p1', false),
+			('t3', 'g1', 'FAQ', 'faq', 'p2', true)`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to insert pages: %v", err)
+	}
+
+	repo := NewWikiPageRepository(db, NewWikiTitleRepository(db))
+	update := func(id, title string) error {
+		return repo.Update(context.Background(), &entities.WikiPage{ID: id, Title: title, Body: "Updated"})
+	}
+
+	if err := update("p2", "Rules"); !errors.Is(err, repositories.ErrWikiTitleTaken) {
+		t.Fatalf("renaming onto another page's title: error = %v, want ErrWikiTitleTaken", err)
+	}
+	if err := update("p2", "Questions"); err != nil {
+		t.Fatalf("renaming to a free title: %v", err)
+	}
+	// Renaming to one of the page's own aliases promotes it
+	if err := update("p1", "Guidelines"); err != nil {
+		t.Fatalf("renaming to an alias: %v", err)
+	}
+
+	rows, err := db.Query(`SELECT page_id || ':' || page_slug || CASE WHEN is_canonical THEN '*' ELSE '' END FROM wiki_titles ORDER BY 1`)
+	if err != nil {
+		t.Fatalf("query titles: %v", err)
+	}
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			t.Fatalf("scan title: %v", err)
+		}
+		got = append(got, title)
+	}
+	if want := "p1:guidelines* p1:rules p2:faq p2:questions*"; strings.Join(got, " ") != want {
+		t.Errorf("titles = %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+func TestWikiPageUpdateRecordsLastEditor(t *testing.T) {
+	db := openWikiTestDB(t)
+	repo := NewWikiPageRepository(db, NewWikiTitleRepository(db))
+	ctx := context.Background()
+
+	page := &entities.WikiPage{Title: "Rules", Body: "Be nice", AuthorID: "u1", GuildID: "g1"}
+	if err := repo.Create(ctx, page); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	page.Body = "Be very nice"
+	page.LastEditorID = "u2"
+	if err := repo.Update(ctx, page); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	var authorID, lastEditorID string
+	if err := db.QueryRow(`SELECT author_id, last_editor_id FROM wiki_pages WHERE id = $1`, page.ID).Scan(&authorID, &lastEditorID); err != nil {
+		t.Fatalf("query page: %v", err)
+	}
+	if authorID != "u1" || lastEditorID != "u2" {
+		t.Errorf("author = %q, last editor = %q, want u1 and u2", authorID, lastEditorID)
+	}
+}
+
+// addWikiListTables creates the membership, user, and guild tables List and Search
+// join, with u-author and u-other as members of g1
+func addWikiListTables(t *testing.T, db *sql.DB) {
+	t.Helper()
+	fixture := `
+		ALTER TABLE wiki_pages ADD COLUMN search_vector tsvector
+			GENERATED ALWAYS AS (to_tsvector('simple', COALESCE(title, '') || ' ' || body)) STORED;
+		CREATE TEMP TABLE guild_members (guild_id TEXT NOT NULL, discord_id TEXT NOT NULL);
+		CREATE TEMP TABLE users (id TEXT PRIMARY KEY);
+		CREATE TEMP TABLE discord_users (user_id TEXT, discord_id TEXT PRIMARY KEY);
+		CREATE TEMP TABLE user_display_names (discord_id TEXT, guild_id TEXT, display_name TEXT);
+		CREATE TEMP TABLE discord_guilds (guild_id TEXT PRIMARY KEY, guild_name TEXT);
+		CREATE TEMP TABLE wiki_message_references (id TEXT PRIMARY KEY, wiki_page_id TEXT NOT NULL);
+		INSERT INTO users (id) VALUES ('u-author'), ('u-other');
+		INSERT INTO discord_users (user_id, discord_id) VALUES ('u-author', 'd-author'), ('u-other', 'd-other');
+		INSERT INTO guild_members (guild_id, discord_id) VALUES ('g1', 'd-author'), ('g1', 'd-other')`
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+}
+
+// List puts pinned pages first under every ordering, including across page boundaries
+func TestWikiListPinnedFirst(t *testing.T) {
+	db := openWikiTestDB(t)
+	addWikiListTables(t, db)
+	if _, err := db.Exec(`
+		INSERT INTO wiki_pages (id, title, body, author_id, guild_id, pinned, created_at, updated_at) VALUES
+			('p1', 'Alpha', 'a', 'u-author', 'g1', FALSE, '2024-01-01', '2024-01-05'),
+			('p2', 'Bravo', 'b', 'u-author', 'g1', TRUE,  '2024-01-02', '2024-01-02'),
+			('p3', 'Charlie', 'c', 'u-author', 'g1', FALSE, '2024-01-03', '2024-01-03'),
+			('p4', 'Delta', 'd', 'u-author', 'g1', TRUE,  '2024-01-04', '2024-01-01');
+		INSERT INTO wiki_titles (id, guild_id, display_title, page_slug, page_id, is_canonical) VALUES
+			('t1', 'g1', 'Alpha', 'alpha', 'p1', TRUE), ('t2', 'g1', 'Bravo', 'bravo', 'p2', TRUE),
+			('t3', 'g1', 'Charlie', 'charlie', 'p3', TRUE), ('t4', 'g1', 'Delta', 'delta', 'p4', TRUE)`); err != nil {
+		t.Fatalf("failed to insert pages: %v", err)
+	}
+
+	repo := NewWikiPageRepository(db, NewWikiTitleRepository(db))
+	ctx := context.Background()
+	for _, tt := range []struct {
+		orderBy   string
+		ascending bool
+		want      string
+	}{
+		{orderBy: "created_at", ascending: false, want: "Delta Bravo Charlie Alpha"},
+		{orderBy: "created_at", ascending: true, want: "Bravo Delta Alpha Charlie"},
+		{orderBy: "updated_at", ascending: false, want: "Bravo Delta Alpha Charlie"},
+		{orderBy: "title", ascending: true, want: "Bravo Delta Alpha Charlie"},
+	} {
+		// Pages of three put the pinned/unpinned boundary inside the first page
+		var got []string
+		var cursor *repositories.PageCursor
+		for page := 0; page < 3; page++ {
+			pages, _, next, err := repo.List(ctx, "g1", 3, 0, cursor, tt.orderBy, tt.ascending, "d-other")
+			if err != nil {
+				t.Fatalf("List(%s, %v) error = %v", tt.orderBy, tt.ascending, err)
+			}
+			for _, p := range pages {
+				got = append(got, p.Title)
+			}
+			if next == nil {
+				break
+			}
+			cursor = next
+		}
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("List(%s, ascending %v) = %q, want %q", tt.orderBy, tt.ascending, strings.Join(got, " "), tt.want)
+		}
+	}
+}
+
+func TestWikiDraftsHiddenFromOtherMembers(t *testing.T) {
+	db := openWikiTestDB(t)
+	addWikiListTables(t, db)
+
+	repo := NewWikiPageRepository(db, NewWikiTitleRepository(db))
+	ctx := context.Background()
+
+	published := &entities.WikiPage{Title: "House Rules", Body: "Be nice", AuthorID: "u-author", GuildID: "g1"}
+	draft := &entities.WikiPage{Title: "Raid Rules", Body: "Work in progress", AuthorID: "u-author", GuildID: "g1", Status: entities.WikiPageDraft}
+	for _, page := range []*entities.WikiPage{published, draft} {
+		if err := repo.Create(ctx, page); err != nil {
+			t.Fatalf("Create(%q) error = %v", page.Title, err)
+		}
+	}
+	if published.Status != entities.WikiPagePublished {
+		t.Errorf("Create() without a status saved %q, want published", published.Status)
+	}
+
+	titles := func(pages []*entities.WikiPage) string {
+		var got []string
+		for _, page := range pages {
+			got = append(got, page.Title)
+		}
+		sort.Strings(got)
+		return strings.Join(got, ", ")
+	}
+	both := "House Rules, Raid Rules"
+
+	for _, tt := range []struct {
+		viewer string
+		want   string
+	}{
+		{viewer: "d-other", want: "House Rules"},
+		{viewer: "d-author", want: both},
+		// No caller to match, as for admins and the bot: drafts stay hidden
+		{viewer: "", want: "House Rules"},
+	} {
+		found, total, err := repo.Search(ctx, []string{"g1"}, "rules", nil, "", 10, 0, tt.viewer)
+		if err != nil {
+			t.Fatalf("Search() as %q error = %v", tt.viewer, err)
+		}
+		if got := titles(found); got != tt.want || total != len(found) {
+			t.Errorf("Search() as %q = %q (total %d), want %q", tt.viewer, got, total, tt.want)
+		}
+
+		listed, total, _, err := repo.List(ctx, "g1", 10, 0, nil, "created_at", false, tt.viewer)
+		if err != nil {
+			t.Fatalf("List() as %q error = %v", tt.viewer, err)
+		}
+		if got := titles(listed); got != tt.want || total != len(listed) {
+			t.Errorf("List() as %q = %q (total %d), want %q", tt.viewer, got, total, tt.want)
+		}
+	}
+
+	// Tag suggestions are shown to other editors, so they never come from drafts
+	if _, err := db.Exec(`UPDATE wiki_pages SET tags = ARRAY['raids']`); err != nil {
+		t.Fatalf("failed to tag pages: %v", err)
+	}
+	matches, err := repo.FindTaggedMatches(ctx, "g1", "rules", "", 10, "d-author")
+	if err != nil {
+		t.Fatalf("FindTaggedMatches() error = %v", err)
+	}
+	if len(matches) != 1 {
+		t.Errorf("FindTaggedMatches() = %d matches, want only the published page", len(matches))
+	}
+
+	// Autocomplete titles are cached per guild, so they never include drafts
+	guildTitles, err := repo.GetTitlesForGuild(ctx, "g1")
+	if err != nil {
+		t.Fatalf("GetTitlesForGuild() error = %v", err)
+	}
+	if len(guildTitles) != 1 || guildTitles[0].Title != "House Rules" {
+		t.Errorf("GetTitlesForGuild() = %v, want only the published page", guildTitles)
+	}
+
+	drafts, err := repo.ListDrafts(ctx, "g1", "u-author")
+	if err != nil {
+		t.Fatalf("ListDrafts() error = %v", err)
+	}
+	if got := titles(drafts); got != "Raid Rules" {
+		t.Errorf("ListDrafts() for the author = %q, want the draft", got)
+	}
+	if drafts, err := repo.ListDrafts(ctx, "g1", "u-other"); err != nil || len(drafts) != 0 {
+		t.Errorf("ListDrafts() for another member = %d drafts, %v, want none", len(drafts), err)
+	}
+
+	if err := repo.SetStatus(ctx, draft.ID, entities.WikiPagePublished); err != nil {
+		t.Fatalf("SetStatus() error = %v", err)
+	}
+	found, _, err := repo.Search(ctx, []string{"g1"}, "rules", nil, "", 10, 0, "d-other")
+	if err != nil {
+		t.Fatalf("Search() after publishing error = %v", err)
+	}
+	if got := titles(found); got != both {
+		t.Errorf("Search() after publishing = %q, want %q", got, both)
+	}
+}
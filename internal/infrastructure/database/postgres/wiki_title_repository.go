@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -172,7 +173,7 @@ func (r *wikiTitleRepository) ListByPageID(ctx context.Context, pageID string) (
 		ORDER BY is_canonical DESC, created_at ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, pageID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, pageID)
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +230,7 @@ func (r *wikiTitleRepository) UpdatePageID(ctx context.Context, oldPageID, newPa
 		WHERE page_id = $1 AND is_canonical = FALSE
 	`
 
-	result, err := r.db.ExecContext(ctx, query, oldPageID, newPageID)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, oldPageID, newPageID)
 	if err != nil {
 		return 0, err
 	}
@@ -262,7 +263,7 @@ func (r *wikiTitleRepository) ConvertToAlias(ctx context.Context, oldPageID, new
 		WHERE page_id = $1 AND is_canonical = TRUE
 	`
 
-	result, err := r.db.ExecContext(ctx, query, oldPageID, newPageID)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, oldPageID, newPageID)
 	if err != nil {
 		return 0, err
 	}
@@ -275,3 +276,39 @@ func (r *wikiTitleRepository) ConvertToAlias(ctx context.Context, oldPageID, new
 	rowsAffected = rows
 	return int(rows), nil
 }
+
+func (r *wikiTitleRepository) Reassign(ctx context.Context, titleID, pageID string, isCanonical bool) error {
+	start := time.Now()
+	var err error
+	var rowsAffected int64
+	defer func() {
+		metrics.RecordDBOperation("wiki_title", "reassign", time.Since(start), rowsAffected, err)
+	}()
+
+	r.log.Debug("reassigning title",
+		slog.String("title_id", titleID),
+		slog.String("page_id", pageID),
+		slog.Bool("is_canonical", isCanonical))
+
+	query := `
+		UPDATE wiki_titles
+		SET page_id = $2, is_canonical = $3
+		WHERE id = $1
+	`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, titleID, pageID, isCanonical)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		err = fmt.Errorf("wiki title not found: %s", titleID)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+)
+
+// ErrDisallowedAddress is returned for webhooks that point at loopback, private,
+// link-local (including cloud metadata endpoints), or other non-public addresses
+var ErrDisallowedAddress = errors.New("webhook address is not a public internet address")
+
+// sharedAddressSpace is the carrier-grade NAT range, which netip doesn't count as private
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// disallowedAddr reports whether a webhook must not be delivered to addr
+func disallowedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() ||
+		sharedAddressSpace.Contains(addr)
+}
+
+// ValidateURL checks that rawURL is an absolute https URL whose host resolves only to
+// public addresses. Delivery checks each connection again, so a host that's later
+// pointed somewhere private is still refused.
+func ValidateURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("webhook url must be an absolute https URL")
+	}
+
+	host := u.Hostname()
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if disallowedAddr(addr) {
+			return ErrDisallowedAddress
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("webhook host %q could not be resolved: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if disallowedAddr(addr) {
+			return ErrDisallowedAddress
+		}
+	}
+	return nil
+}
+
+// publicOnlyControl is a net.Dialer Control func that refuses to connect to disallowed
+// addresses. It runs after DNS resolution, so it also covers redirects and rebinding.
+func publicOnlyControl(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("unexpected dial address %q: %w", address, err)
+	}
+	if disallowedAddr(addrPort.Addr()) {
+		return ErrDisallowedAddress
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+// Package notify delivers outbound webhook notifications when guild content changes.
+//
+// Deliveries are best-effort: events are queued and POSTed by background workers,
+// retried with exponential backoff on network errors and 5xx/429 responses, and
+// dropped (with a warning) if the queue is full or every attempt fails.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Event types sent in the "event" field and the X-Hivemind-Event header
+const (
+	EventWikiPageCreated = "wiki_page.created"
+	EventWikiPageUpdated = "wiki_page.updated"
+	EventWikiPageDeleted = "wiki_page.deleted"
+	EventNoteCreated     = "note.created"
+	EventNoteUpdated     = "note.updated"
+	EventQuoteCreated    = "quote.created"
+	EventQuoteUpdated    = "quote.updated"
+)
+
+// Request headers set on every delivery
+const (
+	SignatureHeader = "X-Hivemind-Signature"
+	EventHeader     = "X-Hivemind-Event"
+)
+
+// Event is the JSON payload POSTed to a guild's webhook
+type Event struct {
+	Type       string    `json:"event"`
+	GuildID    string    `json:"guild_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Content    Content   `json:"content"`
+}
+
+// Content describes the wiki page, note, or quote the event is about
+type Content struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title,omitempty"`
+	Body      string    `json:"body,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	AuthorID  string    `json:"author_id"`
+	ChannelID string    `json:"channel_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Webhook is a guild's outbound webhook configuration
+type Webhook struct {
+	URL    string
+	Secret string
+}
+
+// WebhookSource looks up the webhook configured for a guild.
+// Returns nil if the guild has none.
+type WebhookSource interface {
+	GuildWebhook(ctx context.Context, guildID string) (*Webhook, error)
+}
+
+// Config controls delivery behavior; zero fields use the defaults below
+type Config struct {
+	QueueSize      int           // Events buffered before new ones are dropped
+	Workers        int           // Concurrent deliveries
+	MaxAttempts    int           // Attempts per event, including the first
+	InitialBackoff time.Duration // Wait before the first retry; doubles after each attempt
+	Timeout        time.Duration // Per-request timeout
+}
+
+const (
+	defaultQueueSize      = 256
+	defaultWorkers        = 2
+	defaultMaxAttempts    = 4
+	defaultInitialBackoff = time.Second
+	defaultTimeout        = 10 * time.Second
+)
+
+// Dispatcher queues events and delivers them to guild webhooks in the background
+type Dispatcher struct {
+	source WebhookSource
+	client *http.Client
+	cfg    Config
+	queue  chan Event
+	log    *slog.Logger
+
+	mu     sync.RWMutex // guards closed and sends on queue
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewDispatcher creates a dispatcher and starts its workers. Call Close to stop them.
+func NewDispatcher(source WebhookSource, cfg Config) *Dispatcher {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultQueueSize
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultWorkers
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = defaultInitialBackoff
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	d := &Dispatcher{
+		source: source,
+		client: newClient(cfg.Timeout),
+		cfg:    cfg,
+		queue:  make(chan Event, cfg.QueueSize),
+		log:    slog.Default().With(slog.String("component", "notify")),
+	}
+
+	for i := 0; i < cfg.Workers; i++ {
+		d.wg.Add(1)
+		go d.worker()
+	}
+
+	return d
+}
+
+// newClient returns an HTTP client that only connects to public addresses
+func newClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // A proxy would make the dial checks test the proxy, not the webhook
+	transport.DialContext = (&net.Dialer{
+		Timeout: timeout,
+		Control: publicOnlyControl,
+	}).DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// Notify queues an event for delivery without blocking.
+// Events without a guild, or sent after Close, are ignored.
+func (d *Dispatcher) Notify(event Event) {
+	if event.GuildID == "" {
+		return
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		return
+	}
+
+	select {
+	case d.queue <- event:
+	default:
+		d.log.Warn("webhook queue full, dropping event",
+			slog.String("event", event.Type),
+			slog.String("guild_id", event.GuildID),
+			slog.String("content_id", event.Content.ID))
+	}
+}
+
+// Close stops accepting events and waits for queued deliveries to finish
+func (d *Dispatcher) Close() {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) worker() {
+	defer d.wg.Done()
+	for event := range d.queue {
+		d.deliver(event)
+	}
+}
+
+// deliver looks up the guild's webhook and POSTs the event, retrying with backoff
+func (d *Dispatcher) deliver(event Event) {
+	ctx := context.Background()
+	log := d.log.With(
+		slog.String("event", event.Type),
+		slog.String("guild_id", event.GuildID),
+		slog.String("content_id", event.Content.ID))
+
+	webhook, err := d.source.GuildWebhook(ctx, event.GuildID)
+	if err != nil {
+		log.Warn("failed to look up guild webhook", slog.String("error", err.Error()))
+		return
+	}
+	if webhook == nil || webhook.URL == "" {
+		return
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Error("failed to encode webhook payload", slog.String("error", err.Error()))
+		return
+	}
+
+	backoff := d.cfg.InitialBackoff
+	for attempt := 1; attempt <= d.cfg.MaxAttempts; attempt++ {
+		retry, err := d.post(ctx, webhook, event.Type, body)
+		if err == nil {
+			log.Debug("webhook delivered", slog.Int("attempt", attempt))
+			return
+		}
+		if !retry || attempt == d.cfg.MaxAttempts {
+			log.Warn("webhook delivery failed",
+				slog.Int("attempt", attempt),
+				slog.String("error", err.Error()))
+			return
+		}
+
+		log.Debug("webhook delivery failed, retrying",
+			slog.Int("attempt", attempt),
+			slog.Duration("backoff", backoff),
+			slog.String("error", err.Error()))
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post sends one delivery attempt and reports whether a failure is worth retrying
+func (d *Dispatcher) post(ctx context.Context, webhook *Webhook, eventType string, body []byte) (bool, error) {
+	req, err := newRequest(ctx, webhook, eventType, body)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return !errors.Is(err, ErrDisallowedAddress), err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+}
+
+// newRequest builds a signed webhook POST for an encoded event
+func newRequest(ctx context.Context, webhook *Webhook, eventType string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "hivemind-webhook")
+	req.Header.Set(EventHeader, eventType)
+	if webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+	}
+	return req, nil
+}
+
+// Sign returns the signature header value for body: "sha256=" followed by the
+// hex-encoded HMAC-SHA256 of the raw request body keyed with secret.
+// Receivers should recompute it and compare with hmac.Equal.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
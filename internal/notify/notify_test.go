@@ -0,0 +1,222 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	// Well-known HMAC-SHA256 example vector
+	got := Sign("key", []byte("The quick brown fox jumps over the lazy dog"))
+	want := "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	if got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+
+	if Sign("other-key", []byte("The quick brown fox jumps over the lazy dog")) == want {
+		t.Error("Sign() produced the same signature for a different secret")
+	}
+}
+
+func TestEventPayloadShape(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	event := Event{
+		Type:       EventWikiPageCreated,
+		GuildID:    "g1",
+		OccurredAt: ts,
+		Content: Content{
+			ID:        "p1",
+			Title:     "Rules",
+			Body:      "Be nice",
+			Tags:      []string{"meta"},
+			AuthorID:  "u1",
+			ChannelID: "c1",
+			CreatedAt: ts,
+			UpdatedAt: ts,
+		},
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"event":       "wiki_page.created",
+		"guild_id":    "g1",
+		"occurred_at": "2024-05-01T12:00:00Z",
+		"content": map[string]interface{}{
+			"id":         "p1",
+			"title":      "Rules",
+			"body":       "Be nice",
+			"tags":       []interface{}{"meta"},
+			"author_id":  "u1",
+			"channel_id": "c1",
+			"created_at": "2024-05-01T12:00:00Z",
+			"updated_at": "2024-05-01T12:00:00Z",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("payload = %v, want %v", got, want)
+	}
+}
+
+type staticSource struct {
+	webhook *Webhook
+}
+
+func (s staticSource) GuildWebhook(ctx context.Context, guildID string) (*Webhook, error) {
+	return s.webhook, nil
+}
+
+// recordingServer fails the first failures requests with a 503, then accepts
+type recordingServer struct {
+	mu       sync.Mutex
+	failures int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (s *recordingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+	s.bodies = append(s.bodies, body)
+	if len(s.requests) <= s.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestDispatcherDeliversSignedPayloadWithRetry(t *testing.T) {
+	recorder := &recordingServer{failures: 2}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	d := NewDispatcher(staticSource{webhook: &Webhook{URL: server.URL, Secret: "s3cret"}}, Config{
+		Workers:        1,
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+	})
+	d.client = server.Client() // The test server is on loopback
+	d.Notify(Event{Type: EventQuoteCreated, GuildID: "g1", Content: Content{ID: "q1"}})
+	d.Close()
+
+	if len(recorder.requests) != 3 {
+		t.Fatalf("got %d requests, want 3 (two failures then success)", len(recorder.requests))
+	}
+
+	last := recorder.requests[2]
+	if got := last.Header.Get(EventHeader); got != EventQuoteCreated {
+		t.Errorf("%s = %q, want %q", EventHeader, got, EventQuoteCreated)
+	}
+	if got, want := last.Header.Get(SignatureHeader), Sign("s3cret", recorder.bodies[2]); !hmac.Equal([]byte(got), []byte(want)) {
+		t.Errorf("%s = %q, want %q", SignatureHeader, got, want)
+	}
+
+	var event Event
+	if err := json.Unmarshal(recorder.bodies[2], &event); err != nil {
+		t.Fatalf("failed to decode delivered payload: %v", err)
+	}
+	if event.Content.ID != "q1" || event.OccurredAt.IsZero() {
+		t.Errorf("delivered event = %+v, want content q1 with occurred_at set", event)
+	}
+}
+
+func TestDispatcherDoesNotRetryClientErrors(t *testing.T) {
+	var count int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		count++
+		mu.Unlock()
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(staticSource{webhook: &Webhook{URL: server.URL}}, Config{
+		Workers:        1,
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+	})
+	d.client = server.Client()
+	d.Notify(Event{Type: EventNoteCreated, GuildID: "g1"})
+	d.Close()
+
+	if count != 1 {
+		t.Errorf("got %d requests, want 1", count)
+	}
+}
+
+func TestDispatcherSkipsGuildsWithoutWebhook(t *testing.T) {
+	d := NewDispatcher(staticSource{}, Config{Workers: 1})
+	d.Notify(Event{Type: EventWikiPageUpdated, GuildID: "g1"})
+	d.Close()
+
+	// Notify after Close must not panic
+	d.Notify(Event{Type: EventWikiPageUpdated, GuildID: "g1"})
+}
+
+func TestDispatcherRefusesLoopbackWebhooks(t *testing.T) {
+	recorder := &recordingServer{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	d := NewDispatcher(staticSource{webhook: &Webhook{URL: server.URL}}, Config{
+		Workers:        1,
+		MaxAttempts:    2,
+		InitialBackoff: time.Millisecond,
+	})
+	d.Notify(Event{Type: EventQuoteCreated, GuildID: "g1"})
+	d.Close()
+
+	if len(recorder.requests) != 0 {
+		t.Errorf("got %d requests to a loopback webhook, want none", len(recorder.requests))
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://93.184.216.34/hook"},
+		{url: "https://[2606:2800:220:1:248:1893:25c8:1946]/hook"},
+		{url: "http://93.184.216.34/hook", wantErr: true},
+		{url: "ftp://93.184.216.34/hook", wantErr: true},
+		{url: "/relative", wantErr: true},
+		{url: "https://127.0.0.1/hook", wantErr: true},
+		{url: "https://[::1]/hook", wantErr: true},
+		{url: "https://10.1.2.3/hook", wantErr: true},
+		{url: "https://192.168.0.10/hook", wantErr: true},
+		{url: "https://172.16.0.1/hook", wantErr: true},
+		{url: "https://100.64.0.1/hook", wantErr: true},
+		{url: "https://169.254.169.254/latest/meta-data", wantErr: true},
+		{url: "https://[fd00:ec2::254]/hook", wantErr: true},
+		{url: "https://[::ffff:127.0.0.1]/hook", wantErr: true},
+		{url: "https://0.0.0.0/hook", wantErr: true},
+		{url: "https://localhost/hook", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := ValidateURL(context.Background(), tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package colorutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseHex parses a 6-digit hex color ("#5865F2" or "5865F2") into its integer value,
+// as used by Discord embed colors
+func ParseHex(s string) (int, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) != 6 {
+		return 0, fmt.Errorf("invalid hex color %q: expected 6 hex digits like #5865F2", s)
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hex color %q: expected 6 hex digits like #5865F2", s)
+	}
+	return int(value), nil
+}
+
+// Normalize validates a hex color and returns it in canonical "#RRGGBB" form
+func Normalize(s string) (string, error) {
+	value, err := ParseHex(s)
+	if err != nil {
+		return "", err
+	}
+	return FormatHex(value), nil
+}
+
+// FormatHex formats an integer color as "#RRGGBB"
+func FormatHex(value int) string {
+	return fmt.Sprintf("#%06X", value&0xFFFFFF)
+}
@@ -0,0 +1,42 @@
+package colorutil
+
+import "testing"
+
+func TestParseHex(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{input: "#5865F2", want: 0x5865F2},
+		{input: "5865f2", want: 0x5865F2},
+		{input: " #00D9FF ", want: 0x00D9FF},
+		{input: "#000000", want: 0},
+		{input: "#FFF", wantErr: true},
+		{input: "#5865F2FF", wantErr: true},
+		{input: "#GGGGGG", wantErr: true},
+		{input: "+12345", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseHex(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseHex(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseHex(%q) = %#06x, want %#06x", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	got, err := Normalize("5865f2")
+	if err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	if got != "#5865F2" {
+		t.Errorf("Normalize() = %q, want %q", got, "#5865F2")
+	}
+}
@@ -0,0 +1,26 @@
+package idgen
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// NodeIDFromHostname derives a node ID from a Kubernetes pod hostname. StatefulSet
+// pods are named <set>-<ordinal>, and the ordinal is unique among the running
+// replicas, so it is used as is and fromOrdinal is true. Any other hostname, such as
+// a Deployment's <name>-<hash>-<suffix>, is hashed, which can collide between pods.
+// The result never equals CLINodeID.
+func NodeIDFromHostname(hostname string) (nodeID int64, fromOrdinal bool) {
+	if i := strings.LastIndexByte(hostname, '-'); i >= 0 {
+		suffix := hostname[i+1:]
+		ordinal, err := strconv.ParseInt(suffix, 10, 64)
+		if err == nil && ordinal >= 0 && ordinal < CLINodeID && strconv.FormatInt(ordinal, 10) == suffix {
+			return ordinal, true
+		}
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(hostname))
+	return int64(h.Sum32()) % CLINodeID, false
+}
@@ -0,0 +1,33 @@
+package idgen
+
+import "testing"
+
+func TestNodeIDFromHostname(t *testing.T) {
+	tests := []struct {
+		hostname    string
+		want        int64
+		fromOrdinal bool
+	}{
+		{hostname: "hivemind-server-0", want: 0, fromOrdinal: true},
+		{hostname: "hivemind-server-7", want: 7, fromOrdinal: true},
+		{hostname: "hivemind-server-07"},
+		{hostname: "hivemind-server-5d9c7f6b8-x2k4p"},
+		{hostname: "laptop"},
+	}
+
+	for _, tt := range tests {
+		got, fromOrdinal := NodeIDFromHostname(tt.hostname)
+		if fromOrdinal != tt.fromOrdinal {
+			t.Errorf("NodeIDFromHostname(%q) fromOrdinal = %v, want %v", tt.hostname, fromOrdinal, tt.fromOrdinal)
+		}
+		if fromOrdinal && got != tt.want {
+			t.Errorf("NodeIDFromHostname(%q) = %d, want %d", tt.hostname, got, tt.want)
+		}
+		if err := ValidateNodeID(got); err != nil || got == CLINodeID {
+			t.Errorf("NodeIDFromHostname(%q) = %d, want a server node ID", tt.hostname, got)
+		}
+		if again, _ := NodeIDFromHostname(tt.hostname); again != got {
+			t.Errorf("NodeIDFromHostname(%q) is not stable: %d then %d", tt.hostname, got, again)
+		}
+	}
+}
@@ -1,18 +1,42 @@
 package idgen
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
 	"sync"
+	"sync/atomic"
 
 	"github.com/bwmarrin/snowflake"
 )
 
+// MaxNodeID is the largest node ID that fits in the node bits of a Snowflake ID.
+// Every process generating IDs at the same time needs its own node ID.
+var MaxNodeID int64 = -1 ^ (-1 << snowflake.NodeBits)
+
+// CLINodeID is reserved for one-off server CLI commands so they never share a
+// node ID with a running server
+var CLINodeID = MaxNodeID
+
+// ErrInvalidNodeID is returned for node IDs outside 0..MaxNodeID
+var ErrInvalidNodeID = errors.New("invalid snowflake node ID")
+
 var (
 	node *snowflake.Node
 	once sync.Once
+
+	// override replaces Snowflake generation when set (see SetGenerator)
+	override   func() string
+	overrideMu sync.RWMutex
 )
 
 // Initialize sets up the Snowflake ID generator with a node ID
 func Initialize(nodeID int64) error {
+	// Checked up front so a bad node ID doesn't use up the once
+	if err := ValidateNodeID(nodeID); err != nil {
+		return err
+	}
+
 	var err error
 	once.Do(func() {
 		node, err = snowflake.NewNode(nodeID)
@@ -20,11 +44,50 @@ func Initialize(nodeID int64) error {
 	return err
 }
 
+// ValidateNodeID checks that nodeID fits in the node bits of a Snowflake ID
+func ValidateNodeID(nodeID int64) error {
+	if nodeID < 0 || nodeID > MaxNodeID {
+		return fmt.Errorf("%w: %d is not between 0 and %d", ErrInvalidNodeID, nodeID, MaxNodeID)
+	}
+	return nil
+}
+
 // GenerateID generates a new Snowflake ID as a string
 func GenerateID() string {
+	overrideMu.RLock()
+	gen := override
+	overrideMu.RUnlock()
+	if gen != nil {
+		return gen()
+	}
+
 	if node == nil {
 		// Initialize with default node ID if not already initialized
 		_ = Initialize(1)
 	}
 	return node.Generate().String()
 }
+
+// SetGenerator replaces the ID generator used by GenerateID and returns a
+// function that restores the previous one. Passing nil restores Snowflake IDs.
+// Intended for tests that need to assert exact IDs.
+func SetGenerator(gen func() string) (restore func()) {
+	overrideMu.Lock()
+	prev := override
+	override = gen
+	overrideMu.Unlock()
+
+	return func() {
+		overrideMu.Lock()
+		override = prev
+		overrideMu.Unlock()
+	}
+}
+
+// Sequence returns a deterministic generator yielding prefix1, prefix2, ...
+func Sequence(prefix string) func() string {
+	var n atomic.Int64
+	return func() string {
+		return prefix + strconv.FormatInt(n.Add(1), 10)
+	}
+}
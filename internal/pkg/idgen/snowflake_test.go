@@ -0,0 +1,48 @@
+package idgen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetGenerator(t *testing.T) {
+	restore := SetGenerator(Sequence("test-"))
+
+	for _, want := range []string{"test-1", "test-2", "test-3"} {
+		if got := GenerateID(); got != want {
+			t.Errorf("GenerateID() = %q, want %q", got, want)
+		}
+	}
+
+	restore()
+	if got := GenerateID(); got == "test-4" {
+		t.Errorf("GenerateID() still uses the override after restore")
+	}
+}
+
+func TestGenerateIDUnique(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id := GenerateID()
+		if seen[id] {
+			t.Fatalf("duplicate ID %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestInitializeRejectsOutOfRange(t *testing.T) {
+	for _, nodeID := range []int64{-1, MaxNodeID + 1, 1 << 20} {
+		if err := Initialize(nodeID); !errors.Is(err, ErrInvalidNodeID) {
+			t.Errorf("Initialize(%d) error = %v, want %v", nodeID, err, ErrInvalidNodeID)
+		}
+	}
+
+	// A rejected node ID must not leave the generator uninitialized
+	if err := Initialize(1); err != nil {
+		t.Fatalf("Initialize(1) error = %v", err)
+	}
+	if GenerateID() == "" {
+		t.Error("GenerateID() returned an empty ID")
+	}
+}
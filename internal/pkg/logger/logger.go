@@ -14,6 +14,7 @@ type Config struct {
 	LogToStderr   bool
 	AlsoLogStderr bool
 	Format        string // "json" or "text"
+	Sampling      SamplingConfig
 }
 
 // SetupLogger creates a configured slog logger
@@ -55,7 +56,7 @@ func SetupLogger(cfg Config) (*slog.Logger, error) {
 		handler = slog.NewTextHandler(writer, opts)
 	}
 
-	return slog.New(handler), nil
+	return slog.New(NewSamplingHandler(handler, cfg.Sampling)), nil
 }
 
 // ParseLevel converts a string to slog.Level
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Sampling defaults, used for any SamplingConfig field left at zero
+const (
+	DefaultSampleInitial    = 10
+	DefaultSampleThereafter = 100
+	DefaultSampleWindow     = time.Minute
+)
+
+// SamplingConfig rate-limits repetitive Info logs. Within each window, the first
+// Initial records with a given message are emitted, then every Thereafter-th one.
+// Debug, Warn, and Error records are never sampled.
+type SamplingConfig struct {
+	Enabled    bool
+	Initial    int
+	Thereafter int
+	Window     time.Duration
+}
+
+// samplingHandler wraps another slog.Handler and drops repeated Info records
+type samplingHandler struct {
+	next    slog.Handler
+	sampler *sampler
+}
+
+// sampler holds the per-message counters; it's shared between a handler and
+// the handlers derived from it via WithAttrs/WithGroup
+type sampler struct {
+	initial    int
+	thereafter int
+	window     time.Duration
+	now        func() time.Time
+
+	mu          sync.Mutex
+	windowStart time.Time
+	counts      map[string]int
+}
+
+// NewSamplingHandler wraps next so that repetitive Info-level messages are sampled
+// according to cfg. If cfg.Enabled is false, next is returned unchanged.
+func NewSamplingHandler(next slog.Handler, cfg SamplingConfig) slog.Handler {
+	if !cfg.Enabled {
+		return next
+	}
+	return &samplingHandler{next: next, sampler: newSampler(cfg, time.Now)}
+}
+
+func newSampler(cfg SamplingConfig, now func() time.Time) *sampler {
+	if cfg.Initial <= 0 {
+		cfg.Initial = DefaultSampleInitial
+	}
+	if cfg.Thereafter <= 0 {
+		cfg.Thereafter = DefaultSampleThereafter
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultSampleWindow
+	}
+	return &sampler{
+		initial:    cfg.Initial,
+		thereafter: cfg.Thereafter,
+		window:     cfg.Window,
+		now:        now,
+		counts:     make(map[string]int),
+	}
+}
+
+// allow records one occurrence of msg and reports whether it should be emitted
+func (s *sampler) allow(msg string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Start a fresh window for every message at once; this also keeps the map
+	// from growing without bound when messages contain unique values
+	now := s.now()
+	if now.Sub(s.windowStart) >= s.window {
+		s.windowStart = now
+		clear(s.counts)
+	}
+
+	s.counts[msg]++
+	n := s.counts[msg]
+	if n <= s.initial {
+		return true
+	}
+	return (n-s.initial)%s.thereafter == 0
+}
+
+func (h *samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level == slog.LevelInfo && !h.sampler.allow(r.Message) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{next: h.next.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{next: h.next.WithGroup(name), sampler: h.sampler}
+}
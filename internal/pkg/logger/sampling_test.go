@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// countingHandler counts the records it receives, by level
+type countingHandler struct {
+	counts map[slog.Level]int
+}
+
+func (h *countingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *countingHandler) Handle(_ context.Context, r slog.Record) error {
+	h.counts[r.Level]++
+	return nil
+}
+
+func (h *countingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *countingHandler) WithGroup(string) slog.Handler      { return h }
+
+func newTestSamplingLogger(cfg SamplingConfig, now func() time.Time) (*slog.Logger, *countingHandler) {
+	counter := &countingHandler{counts: make(map[slog.Level]int)}
+	handler := &samplingHandler{next: counter, sampler: newSampler(cfg, now)}
+	return slog.New(handler), counter
+}
+
+func TestSamplingHandlerDropsRepeatedInfo(t *testing.T) {
+	now := time.Now()
+	log, counter := newTestSamplingLogger(SamplingConfig{Enabled: true, Initial: 3, Thereafter: 10, Window: time.Minute},
+		func() time.Time { return now })
+
+	for i := 0; i < 100; i++ {
+		log.Info("fetching wiki message references", slog.Int("i", i))
+	}
+
+	// First 3, then every 10th of the remaining 97
+	if got, want := counter.counts[slog.LevelInfo], 3+9; got != want {
+		t.Errorf("emitted %d of 100 identical Info records, want %d", got, want)
+	}
+}
+
+func TestSamplingHandlerCountsMessagesSeparately(t *testing.T) {
+	now := time.Now()
+	log, counter := newTestSamplingLogger(SamplingConfig{Enabled: true, Initial: 2, Thereafter: 1000, Window: time.Minute},
+		func() time.Time { return now })
+
+	for i := 0; i < 5; i++ {
+		log.Info("first message")
+		log.With("component", "bot").Info("second message")
+	}
+
+	if got := counter.counts[slog.LevelInfo]; got != 4 {
+		t.Errorf("emitted %d Info records, want 2 per message", got)
+	}
+}
+
+func TestSamplingHandlerPassesWarnAndError(t *testing.T) {
+	now := time.Now()
+	log, counter := newTestSamplingLogger(SamplingConfig{Enabled: true, Initial: 1, Thereafter: 1000, Window: time.Minute},
+		func() time.Time { return now })
+
+	for i := 0; i < 50; i++ {
+		log.Warn("slow query")
+		log.Error("query failed")
+	}
+
+	if counter.counts[slog.LevelWarn] != 50 || counter.counts[slog.LevelError] != 50 {
+		t.Errorf("emitted %d Warn and %d Error records, want 50 each",
+			counter.counts[slog.LevelWarn], counter.counts[slog.LevelError])
+	}
+}
+
+func TestSamplingHandlerResetsEachWindow(t *testing.T) {
+	now := time.Now()
+	log, counter := newTestSamplingLogger(SamplingConfig{Enabled: true, Initial: 2, Thereafter: 1000, Window: time.Minute},
+		func() time.Time { return now })
+
+	for i := 0; i < 10; i++ {
+		log.Info("adding message references field to embed")
+	}
+	now = now.Add(time.Minute)
+	for i := 0; i < 10; i++ {
+		log.Info("adding message references field to embed")
+	}
+
+	if got := counter.counts[slog.LevelInfo]; got != 4 {
+		t.Errorf("emitted %d Info records across two windows, want 4", got)
+	}
+}
+
+func TestNewSamplingHandlerDisabled(t *testing.T) {
+	next := &countingHandler{counts: make(map[slog.Level]int)}
+	if got := NewSamplingHandler(next, SamplingConfig{}); got != slog.Handler(next) {
+		t.Errorf("NewSamplingHandler() with sampling disabled = %T, want the wrapped handler", got)
+	}
+}
@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"strconv"
 	"strings"
 	"time"
 )
@@ -27,6 +28,32 @@ func RecordDBOperation(repo, operation string, duration time.Duration, rowsAffec
 	DBOperations.WithLabelValues(repo, operation, status).Inc()
 }
 
+// RecordHTTPRequest records an HTTP request handled by the web service
+// route: the matched route template (e.g., "/wiki"), not the raw path, to keep cardinality bounded
+// status: the HTTP status code written to the client
+func RecordHTTPRequest(method, route string, status int, duration time.Duration) {
+	HTTPDuration.WithLabelValues(method, route).Observe(float64(duration.Milliseconds()))
+	HTTPRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+}
+
+// RecordGRPCClientCall records an outgoing gRPC call
+// fullMethod: the gRPC method name as passed to interceptors (e.g., "/hivemind.wiki.WikiService/GetWikiPage")
+// statusCode: the gRPC status code name (e.g., "OK", "NotFound")
+func RecordGRPCClientCall(fullMethod, statusCode string, duration time.Duration) {
+	service, method := splitFullMethod(fullMethod)
+	GRPCClientDuration.WithLabelValues(service, method).Observe(float64(duration.Milliseconds()))
+	GRPCClientCalls.WithLabelValues(service, method, statusCode).Inc()
+}
+
+// splitFullMethod splits "/package.Service/Method" into its service and method names
+func splitFullMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
+
 // classifyDBError categorizes database errors for metrics
 func classifyDBError(err error) string {
 	if err == nil {
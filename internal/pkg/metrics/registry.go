@@ -154,6 +154,27 @@ var (
 			Help: "Number of active gRPC connections",
 		},
 	)
+
+	// GRPCClientCalls tracks outgoing gRPC calls made to the backend
+	GRPCClientCalls = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hivemind_grpc_client_calls_total",
+			Help: "Total outgoing gRPC calls by service, method, and status code",
+		},
+		[]string{"service", "method", "status_code"},
+	)
+
+	// GRPCClientDuration tracks outgoing gRPC call latency
+	GRPCClientDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:                            "hivemind_grpc_client_duration_ms",
+			Help:                            "Outgoing gRPC call duration in milliseconds",
+			NativeHistogramBucketFactor:     1.1,
+			NativeHistogramMaxBucketNumber:  100,
+			NativeHistogramMinResetDuration: 1 * time.Hour,
+		},
+		[]string{"service", "method"},
+	)
 )
 
 // HTTP/Web Handler Metrics
@@ -162,9 +183,9 @@ var (
 	HTTPRequests = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "hivemind_http_requests_total",
-			Help: "Total HTTP requests by method, path, and status",
+			Help: "Total HTTP requests by method, route, and status",
 		},
-		[]string{"method", "path", "status"},
+		[]string{"method", "route", "status"},
 	)
 
 	// HTTPDuration tracks HTTP request duration
@@ -176,7 +197,7 @@ var (
 			NativeHistogramMaxBucketNumber:  100,
 			NativeHistogramMinResetDuration: 1 * time.Hour,
 		},
-		[]string{"method", "path"},
+		[]string{"method", "route"},
 	)
 
 	// HTTPActiveRequests tracks active HTTP requests
@@ -256,6 +277,15 @@ var (
 		[]string{"route", "bucket"},
 	)
 
+	// DiscordSendFailures tracks failed attempts at outbound bot messages
+	DiscordSendFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hivemind_discord_send_failures_total",
+			Help: "Total failed outbound Discord sends by operation, failure type, and outcome (retried or dropped)",
+		},
+		[]string{"operation", "failure_type", "outcome"},
+	)
+
 	// DiscordEvents tracks Discord gateway event counts by event type and status
 	DiscordEvents = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -0,0 +1,150 @@
+// Package msgtemplate renders the bot responses a guild can reword. Templates are plain
+// text with variables in braces, e.g. "📝 Saved {title}"; "{{" and "}}" stand for
+// literal braces.
+package msgtemplate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Events a guild can set a message template for
+const (
+	NoteCreated = "note_created" // Heading above a newly created note
+	QuoteSaved  = "quote_saved"  // Title of a newly saved quote
+	NoResults   = "no_results"   // Reply to a wiki, note, or quote search with no matches
+)
+
+// MaxLength is the longest template a guild may set, in characters
+const MaxLength = 200
+
+// eventVariables lists the variables each event's template may use
+var eventVariables = map[string][]string{
+	NoteCreated: {"title"},
+	QuoteSaved:  {"author"},
+	NoResults:   {"query", "kind"},
+}
+
+// Events returns the events that accept a template, sorted
+func Events() []string {
+	events := make([]string, 0, len(eventVariables))
+	for event := range eventVariables {
+		events = append(events, event)
+	}
+	slices.Sort(events)
+	return events
+}
+
+// Variables returns the variables event's template may use, or nil for an unknown event
+func Variables(event string) []string {
+	return eventVariables[event]
+}
+
+// Validate checks that tmpl is a well-formed template for event that only uses the
+// event's variables
+func Validate(event, tmpl string) error {
+	allowed, ok := eventVariables[event]
+	if !ok {
+		return fmt.Errorf("unknown message event %q", event)
+	}
+	if strings.TrimSpace(tmpl) == "" {
+		return fmt.Errorf("template for %s is empty", event)
+	}
+	if n := len([]rune(tmpl)); n > MaxLength {
+		return fmt.Errorf("template for %s is %d characters, the limit is %d", event, n, MaxLength)
+	}
+
+	names, err := parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("template for %s: %w", event, err)
+	}
+	for _, name := range names {
+		if !slices.Contains(allowed, name) {
+			return fmt.Errorf("template for %s uses {%s}; it may use %s", event, name, formatVariables(allowed))
+		}
+	}
+	return nil
+}
+
+// Render substitutes vars into tmpl. Variables missing from vars render empty; a
+// template that doesn't parse is returned unchanged.
+func Render(tmpl string, vars map[string]string) string {
+	var out strings.Builder
+	err := scan(tmpl, func(literal string) {
+		out.WriteString(literal)
+	}, func(name string) {
+		out.WriteString(vars[name])
+	})
+	if err != nil {
+		return tmpl
+	}
+	return out.String()
+}
+
+// parse returns the variable names tmpl uses, in order
+func parse(tmpl string) ([]string, error) {
+	var names []string
+	err := scan(tmpl, func(string) {}, func(name string) {
+		names = append(names, name)
+	})
+	return names, err
+}
+
+// scan walks tmpl, calling literal for plain text and variable for each {name}
+func scan(tmpl string, literal, variable func(string)) error {
+	for len(tmpl) > 0 {
+		i := strings.IndexAny(tmpl, "{}")
+		if i < 0 {
+			literal(tmpl)
+			return nil
+		}
+		literal(tmpl[:i])
+		rest := tmpl[i:]
+
+		switch {
+		case strings.HasPrefix(rest, "{{"):
+			literal("{")
+			tmpl = rest[2:]
+		case strings.HasPrefix(rest, "}}"):
+			literal("}")
+			tmpl = rest[2:]
+		case rest[0] == '}':
+			return fmt.Errorf("unexpected }; write }} for a literal brace")
+		default:
+			end := strings.IndexByte(rest, '}')
+			if end < 0 {
+				return fmt.Errorf("unclosed { in %q; write {{ for a literal brace", rest)
+			}
+			name := rest[1:end]
+			if !validName(name) {
+				return fmt.Errorf("invalid variable {%s}", name)
+			}
+			variable(name)
+			tmpl = rest[end+1:]
+		}
+	}
+	return nil
+}
+
+// validName reports whether name is a lowercase variable name like "title"
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatVariables lists variables as "{a}, {b}"
+func formatVariables(names []string) string {
+	wrapped := make([]string, len(names))
+	for i, name := range names {
+		wrapped[i] = "{" + name + "}"
+	}
+	return strings.Join(wrapped, ", ")
+}
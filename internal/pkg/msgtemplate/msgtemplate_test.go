@@ -0,0 +1,76 @@
+package msgtemplate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		vars map[string]string
+		want string
+	}{
+		{tmpl: "✅ Note Created", want: "✅ Note Created"},
+		{tmpl: "📝 Saved {title}!", vars: map[string]string{"title": "Raid plan"}, want: "📝 Saved Raid plan!"},
+		{tmpl: "No {kind} for {query}", vars: map[string]string{"kind": "quotes", "query": "cats"}, want: "No quotes for cats"},
+		{tmpl: "{{literal}} {title}", vars: map[string]string{"title": "x"}, want: "{literal} x"},
+		{tmpl: "Hi {title}", want: "Hi "},
+		{tmpl: "broken {title", vars: map[string]string{"title": "x"}, want: "broken {title"},
+	}
+
+	for _, tt := range tests {
+		if got := Render(tt.tmpl, tt.vars); got != tt.want {
+			t.Errorf("Render(%q) = %q, want %q", tt.tmpl, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   string
+		tmpl    string
+		wantErr string
+	}{
+		{name: "plain text", event: NoteCreated, tmpl: "📝 Got it"},
+		{name: "allowed variable", event: NoteCreated, tmpl: "📝 Saved {title}"},
+		{name: "escaped braces", event: QuoteSaved, tmpl: "{{quote}} from {author}"},
+		{name: "several variables", event: NoResults, tmpl: "No {kind} matching {query}"},
+		{name: "unknown event", event: "note_deleted", tmpl: "bye", wantErr: "unknown message event"},
+		{name: "empty", event: NoteCreated, tmpl: "  ", wantErr: "empty"},
+		{name: "too long", event: NoteCreated, tmpl: strings.Repeat("a", MaxLength+1), wantErr: "limit"},
+		{name: "variable of another event", event: NoteCreated, tmpl: "Saved {author}", wantErr: "{title}"},
+		{name: "unclosed brace", event: NoteCreated, tmpl: "Saved {title", wantErr: "unclosed {"},
+		{name: "stray closing brace", event: NoteCreated, tmpl: "Saved title}", wantErr: "unexpected }"},
+		{name: "invalid name", event: NoteCreated, tmpl: "Saved {Title}", wantErr: "invalid variable"},
+		{name: "empty name", event: NoteCreated, tmpl: "Saved {}", wantErr: "invalid variable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.event, tt.tmpl)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvents(t *testing.T) {
+	events := Events()
+	if len(events) != 3 || events[0] != NoResults || events[1] != NoteCreated || events[2] != QuoteSaved {
+		t.Errorf("Events() = %v", events)
+	}
+	for _, event := range events {
+		if len(Variables(event)) == 0 {
+			t.Errorf("Variables(%q) is empty", event)
+		}
+	}
+}
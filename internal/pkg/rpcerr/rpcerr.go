@@ -0,0 +1,113 @@
+// Package rpcerr attaches machine-readable reasons to gRPC errors, so clients can
+// tell failures apart without parsing status messages
+package rpcerr
+
+import (
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Domain is the ErrorInfo domain of every reason the server sets
+const Domain = "hivemind"
+
+// Error reasons, carried in an errdetails.ErrorInfo detail
+const (
+	// ReasonReauthRequired means the user's OAuth session is gone, so the token can't
+	// be refreshed until they sign in again
+	ReasonReauthRequired = "REAUTH_REQUIRED"
+
+	// ReasonNoteTitleTaken means the guild requires unique note titles and the author
+	// already has a note with the title; MetadataNoteID names that note
+	ReasonNoteTitleTaken = "NOTE_TITLE_TAKEN"
+
+	// ReasonQuoteCooldown means the guild's quote cooldown hasn't passed since the
+	// author's last quote; the error's RetryInfo says how long is left
+	ReasonQuoteCooldown = "QUOTE_COOLDOWN"
+
+	// ReasonReferenceLimit means the wiki page or note already has as many message
+	// references as its guild allows
+	ReasonReferenceLimit = "REFERENCE_LIMIT_REACHED"
+)
+
+// Metadata keys set alongside reasons
+const (
+	// MetadataNoteID is the ID of the note an error refers to
+	MetadataNoteID = "note_id"
+)
+
+// New returns a status error with code and message that carries reason
+func New(code codes.Code, reason, message string) error {
+	return NewWithMetadata(code, reason, message, nil)
+}
+
+// NewWithMetadata is New with metadata, such as the ID of the resource the error is about
+func NewWithMetadata(code codes.Code, reason, message string, metadata map[string]string) error {
+	return newStatus(code, message, &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   Domain,
+		Metadata: metadata,
+	})
+}
+
+// NewWithRetryDelay is New with a RetryInfo detail telling the client to wait delay
+// before trying again
+func NewWithRetryDelay(code codes.Code, reason, message string, delay time.Duration) error {
+	return newStatus(code, message,
+		&errdetails.ErrorInfo{Reason: reason, Domain: Domain},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)},
+	)
+}
+
+// newStatus returns a status error with code and message that carries details
+func newStatus(code codes.Code, message string, details ...protoadapt.MessageV1) error {
+	st, err := status.New(code, message).WithDetails(details...)
+	if err != nil {
+		// Only fails for codes.OK, which isn't an error
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
+
+// Reason returns the reason attached to err by New, or "" if it has none
+func Reason(err error) string {
+	return errorInfo(err).GetReason()
+}
+
+// HasReason reports whether err carries reason
+func HasReason(err error, reason string) bool {
+	return Reason(err) == reason
+}
+
+// Metadata returns the value of key in the metadata attached to err, or "" if it has none
+func Metadata(err error, key string) string {
+	return errorInfo(err).GetMetadata()[key]
+}
+
+// RetryDelay returns how long the server asked the client to wait before retrying,
+// or 0 if err carries no RetryInfo
+func RetryDelay(err error) time.Duration {
+	st, _ := status.FromError(err)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration()
+		}
+	}
+	return 0
+}
+
+// errorInfo returns the ErrorInfo detail the server attached to err, or nil
+func errorInfo(err error) *errdetails.ErrorInfo {
+	// FromError unwraps wrapped status errors; anything else has no details
+	st, _ := status.FromError(err)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == Domain {
+			return info
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package rpcerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReason(t *testing.T) {
+	err := New(codes.Unauthenticated, ReasonReauthRequired, "please login again")
+	if status.Code(err) != codes.Unauthenticated || status.Convert(err).Message() != "please login again" {
+		t.Errorf("New() = %v, want Unauthenticated with the message", err)
+	}
+
+	otherDomain, _ := status.New(codes.Unauthenticated, "x").WithDetails(&errdetails.ErrorInfo{Reason: ReasonReauthRequired, Domain: "example.com"})
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "with reason", err: err, want: ReasonReauthRequired},
+		{name: "wrapped", err: fmt.Errorf("refresh: %w", err), want: ReasonReauthRequired},
+		{name: "message mentions reason", err: status.Error(codes.Unauthenticated, ReasonReauthRequired), want: ""},
+		{name: "other domain", err: otherDomain.Err(), want: ""},
+		{name: "plain error", err: errors.New("boom"), want: ""},
+		{name: "nil", err: nil, want: ""},
+	}
+	for _, tt := range tests {
+		if got := Reason(tt.err); got != tt.want {
+			t.Errorf("%s: Reason() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	err := NewWithMetadata(codes.AlreadyExists, ReasonNoteTitleTaken, "note title already in use", map[string]string{MetadataNoteID: "n1"})
+	if !HasReason(err, ReasonNoteTitleTaken) {
+		t.Errorf("Reason() = %q, want %q", Reason(err), ReasonNoteTitleTaken)
+	}
+	if got := Metadata(fmt.Errorf("create: %w", err), MetadataNoteID); got != "n1" {
+		t.Errorf("Metadata() = %q, want n1", got)
+	}
+	if got := Metadata(New(codes.AlreadyExists, ReasonNoteTitleTaken, "x"), MetadataNoteID); got != "" {
+		t.Errorf("Metadata() without metadata = %q, want empty", got)
+	}
+	if got := Metadata(errors.New("boom"), MetadataNoteID); got != "" {
+		t.Errorf("Metadata() of a plain error = %q, want empty", got)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	err := NewWithRetryDelay(codes.ResourceExhausted, ReasonQuoteCooldown, "quote cooldown has not passed", 42*time.Second)
+	if !HasReason(err, ReasonQuoteCooldown) {
+		t.Errorf("Reason() = %q, want %q", Reason(err), ReasonQuoteCooldown)
+	}
+	if got := RetryDelay(fmt.Errorf("create: %w", err)); got != 42*time.Second {
+		t.Errorf("RetryDelay() = %v, want 42s", got)
+	}
+	if got := RetryDelay(New(codes.ResourceExhausted, ReasonQuoteCooldown, "x")); got != 0 {
+		t.Errorf("RetryDelay() without RetryInfo = %v, want 0", got)
+	}
+	if got := RetryDelay(errors.New("boom")); got != 0 {
+		t.Errorf("RetryDelay() of a plain error = %v, want 0", got)
+	}
+}
@@ -1,6 +1,8 @@
 package textutil
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
 	"sort"
 	"strings"
@@ -10,6 +12,19 @@ import (
 // Supports formats like: #tag, #my-tag, #tag_name, #tag123
 var hashtagRegex = regexp.MustCompile(`#([\w-]+)`)
 
+// invalidTagChars matches anything that can't appear in a normalized tag
+var invalidTagChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+const (
+	// MaxTagLength is the longest a single normalized tag may be
+	MaxTagLength = 50
+	// MaxTags is the most tags a single wiki page, note, or quote may carry
+	MaxTags = 20
+)
+
+// ErrInvalidTags is wrapped by every error NormalizeTags returns
+var ErrInvalidTags = errors.New("invalid tags")
+
 // ExtractHashtags parses hashtags from text content
 // Returns a sorted list of unique tags
 // Supported formats: #word, #word-with-hyphens, #word_with_underscores, #word123
@@ -49,3 +64,35 @@ func ExtractHashtags(text string) []string {
 
 	return tags
 }
+
+// NormalizeTags converts user- or client-supplied tags to their canonical form:
+// a leading '#' is dropped, tags are lowercased, characters outside [a-z0-9_-]
+// are stripped, empty tags are discarded, and the result is deduplicated and sorted.
+// Returns an error wrapping ErrInvalidTags if a tag exceeds MaxTagLength or
+// more than MaxTags distinct tags remain.
+func NormalizeTags(tags []string) ([]string, error) {
+	tagMap := make(map[string]bool, len(tags))
+	for _, raw := range tags {
+		tag := strings.TrimPrefix(strings.TrimSpace(raw), "#")
+		tag = invalidTagChars.ReplaceAllString(strings.ToLower(tag), "")
+		if tag == "" {
+			continue
+		}
+		if len(tag) > MaxTagLength {
+			return nil, fmt.Errorf("%w: tag %q is longer than %d characters", ErrInvalidTags, tag, MaxTagLength)
+		}
+		tagMap[tag] = true
+	}
+
+	if len(tagMap) > MaxTags {
+		return nil, fmt.Errorf("%w: %d tags given, at most %d allowed", ErrInvalidTags, len(tagMap), MaxTags)
+	}
+
+	normalized := make([]string, 0, len(tagMap))
+	for tag := range tagMap {
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+
+	return normalized, nil
+}
@@ -1,7 +1,11 @@
 package textutil
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -92,3 +96,98 @@ func TestExtractHashtags(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{
+			name: "nil tags",
+			tags: nil,
+			want: []string{},
+		},
+		{
+			name: "mixed case",
+			tags: []string{"Go", "GOLANG", "rUsT"},
+			want: []string{"go", "golang", "rust"},
+		},
+		{
+			name: "duplicate tags",
+			tags: []string{"test", "Test", "#test", " TEST "},
+			want: []string{"test"},
+		},
+		{
+			name: "invalid characters stripped",
+			tags: []string{"#my tag!", "c++", "snake_case", "kebab-case"},
+			want: []string{"c", "kebab-case", "mytag", "snake_case"},
+		},
+		{
+			name: "empty tags dropped",
+			tags: []string{"", "#", "!!!", "ok"},
+			want: []string{"ok"},
+		},
+		{
+			name: "tag at max length",
+			tags: []string{strings.Repeat("a", MaxTagLength)},
+			want: []string{strings.Repeat("a", MaxTagLength)},
+		},
+		{
+			name: "duplicates do not count towards the limit",
+			tags: append(numberedTags(MaxTags), "tag0", "TAG1"),
+			want: sortedNumberedTags(MaxTags),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeTags(tt.tags)
+			if err != nil {
+				t.Fatalf("NormalizeTags() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizeTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTagsLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+	}{
+		{
+			name: "overly long tag",
+			tags: []string{"short", strings.Repeat("a", MaxTagLength+1)},
+		},
+		{
+			name: "too many tags",
+			tags: numberedTags(MaxTags + 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NormalizeTags(tt.tags)
+			if !errors.Is(err, ErrInvalidTags) {
+				t.Errorf("NormalizeTags() error = %v, want ErrInvalidTags", err)
+			}
+		})
+	}
+}
+
+func numberedTags(n int) []string {
+	tags := make([]string, n)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("tag%d", i)
+	}
+	return tags
+}
+
+func sortedNumberedTags(n int) []string {
+	tags := numberedTags(n)
+	sort.Strings(tags)
+	return tags
+}
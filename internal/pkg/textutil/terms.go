@@ -0,0 +1,74 @@
+package textutil
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// minTermLength is the shortest word KeyTerms considers
+const minTermLength = 3
+
+// ignoredSpans matches links, Discord mentions and emoji, and hashtags, which say
+// little about what a text is about
+var ignoredSpans = regexp.MustCompile(`<[^<>\s]+>|https?://\S+|#[\w-]+`)
+
+// stopWords are common English words skipped by KeyTerms
+var stopWords = map[string]bool{
+	"about": true, "after": true, "again": true, "all": true, "also": true, "and": true,
+	"any": true, "are": true, "because": true, "been": true, "before": true, "being": true,
+	"but": true, "can": true, "could": true, "did": true, "does": true, "doing": true,
+	"don": true, "down": true, "each": true, "for": true, "from": true, "get": true,
+	"had": true, "has": true, "have": true, "her": true, "here": true, "him": true,
+	"his": true, "how": true, "into": true, "its": true, "just": true, "like": true,
+	"more": true, "most": true, "not": true, "now": true, "off": true, "once": true,
+	"one": true, "only": true, "other": true, "our": true, "out": true, "over": true,
+	"same": true, "she": true, "should": true, "some": true, "such": true, "than": true,
+	"that": true, "the": true, "their": true, "them": true, "then": true, "there": true,
+	"these": true, "they": true, "this": true, "those": true, "through": true, "too": true,
+	"under": true, "until": true, "use": true, "very": true, "was": true, "way": true,
+	"were": true, "what": true, "when": true, "where": true, "which": true, "while": true,
+	"who": true, "why": true, "will": true, "with": true, "would": true, "you": true,
+	"your": true,
+}
+
+// KeyTerms returns up to n words that best describe text: the most frequent words
+// that aren't stop words, numbers, or shorter than three characters. Links, Discord
+// mentions, and hashtags are ignored. Ties keep the order words first appear in.
+func KeyTerms(text string, n int) []string {
+	text = ignoredSpans.ReplaceAllString(strings.ToLower(text), " ")
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	counts := make(map[string]int)
+	var order []string
+	for _, word := range words {
+		if len([]rune(word)) < minTermLength || stopWords[word] || isNumber(word) {
+			continue
+		}
+		if counts[word] == 0 {
+			order = append(order, word)
+		}
+		counts[word]++
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return counts[order[a]] > counts[order[b]]
+	})
+	if len(order) > n {
+		order = order[:n]
+	}
+	return order
+}
+
+// isNumber reports whether word is made only of digits
+func isNumber(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
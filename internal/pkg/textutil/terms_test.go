@@ -0,0 +1,54 @@
+package textutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyTerms(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		n    int
+		want []string
+	}{
+		{
+			name: "most frequent first",
+			text: "Raid night: the raid starts at eight. Bring potions for the raid and extra potions.",
+			n:    3,
+			want: []string{"raid", "potions", "night"},
+		},
+		{
+			name: "stop words, short words and numbers skipped",
+			text: "It is what it is, and 2024 was a year to be in",
+			n:    5,
+			want: []string{"year"},
+		},
+		{
+			name: "links, mentions and hashtags ignored",
+			text: "Ask <@123456> in <#987654> about https://example.com/guide #meta guide",
+			n:    5,
+			want: []string{"ask", "guide"},
+		},
+		{
+			name: "case folded",
+			text: "Server Rules and server RULES",
+			n:    5,
+			want: []string{"server", "rules"},
+		},
+		{
+			name: "empty",
+			text: "",
+			n:    5,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KeyTerms(tt.text, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("KeyTerms() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
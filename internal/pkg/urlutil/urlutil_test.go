@@ -165,6 +165,51 @@ func TestBuildNoteViewURL(t *testing.T) {
 	}
 }
 
+func TestBuildPermalinkURLs(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func() (string, error)
+		want  string
+	}{
+		{
+			name:  "guild note",
+			build: func() (string, error) { return BuildNoteSlugURL("https://example.com", "123", "raid-plan") },
+			want:  "https://example.com/note?slug=raid-plan&guild_id=123",
+		},
+		{
+			name:  "personal note",
+			build: func() (string, error) { return BuildNoteSlugURL("https://example.com", "", "raid-plan-2") },
+			want:  "https://example.com/note?slug=raid-plan-2",
+		},
+		{
+			name:  "relative note",
+			build: func() (string, error) { return BuildNoteSlugURL("", "123", "raid-plan") },
+			want:  "/note?slug=raid-plan&guild_id=123",
+		},
+		{
+			name:  "quote",
+			build: func() (string, error) { return BuildQuoteCodeURL("https://example.com", "123", "ab3d5fgh") },
+			want:  "https://example.com/quote?code=ab3d5fgh&guild_id=123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.build()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := BuildQuoteCodeURL("://invalid", "123", "ab3d5fgh"); err == nil {
+		t.Error("BuildQuoteCodeURL() error = nil for an invalid base URL")
+	}
+}
+
 func TestBuildWikiViewURL(t *testing.T) {
 	tests := []struct {
 		name    string
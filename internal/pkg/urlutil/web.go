@@ -18,6 +18,48 @@ func BuildNoteViewURL(baseURL, noteID string) (string, error) {
 	return u.String(), nil
 }
 
+// BuildQuoteViewURL builds a web application URL for viewing a quote.
+// Returns a URL like: {baseURL}/quote?id={quoteID}
+func BuildQuoteViewURL(baseURL, quoteID string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	u.Path = "/quote"
+	q := u.Query()
+	q.Set("id", quoteID)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// BuildNoteSlugURL builds a web application permalink for a note from its slug.
+// Returns a URL like: {baseURL}/note?slug={slug}&guild_id={guildID}
+// guild_id is left out for personal notes (empty guildID).
+func BuildNoteSlugURL(baseURL, guildID, slug string) (string, error) {
+	return buildPermalink(baseURL, "/note", "slug", slug, guildID)
+}
+
+// BuildQuoteCodeURL builds a web application permalink for a quote from its short code.
+// Returns a URL like: {baseURL}/quote?code={code}&guild_id={guildID}
+func BuildQuoteCodeURL(baseURL, guildID, code string) (string, error) {
+	return buildPermalink(baseURL, "/quote", "code", code, guildID)
+}
+
+// buildPermalink builds {baseURL}{path}?{key}={value}&guild_id={guildID}, keeping the
+// key first for human-friendliness and leaving out an empty guild_id
+func buildPermalink(baseURL, path, key, value, guildID string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	u.Path = path
+	u.RawQuery = key + "=" + url.QueryEscape(value)
+	if guildID != "" {
+		u.RawQuery += "&guild_id=" + url.QueryEscape(guildID)
+	}
+	return u.String(), nil
+}
+
 // BuildWikiViewURL builds a web application URL for viewing a wiki page.
 // Returns a URL like: {baseURL}/wiki?slug={slug}&guild_id={guildID}
 // The slug parameter is automatically URL-encoded.
@@ -0,0 +1,3 @@
+-- Nothing to revert
+-- The search_vector columns and GIN indexes belong to the initial schema;
+-- migration 000007 only guarantees they exist, so rolling it back must not drop them.
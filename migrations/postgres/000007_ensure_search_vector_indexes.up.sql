@@ -0,0 +1,33 @@
+-- Ensure full-text search columns and GIN indexes exist for ranked search
+-- These were created by the initial schema, but databases restored from older
+-- dumps may be missing them. Search ranking (ts_rank) and snippets
+-- (ts_headline) depend on search_vector being present and indexed.
+
+ALTER TABLE wiki_pages
+ADD COLUMN IF NOT EXISTS search_vector tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(title, '')), 'B') ||
+    setweight(to_tsvector('english', body), 'C') ||
+    setweight(to_tsvector('simple', body), 'D')
+) STORED;
+
+ALTER TABLE notes
+ADD COLUMN IF NOT EXISTS search_vector tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(title, '')), 'B') ||
+    setweight(to_tsvector('english', body), 'C') ||
+    setweight(to_tsvector('simple', body), 'D')
+) STORED;
+
+ALTER TABLE quotes
+ADD COLUMN IF NOT EXISTS search_vector tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', body), 'A') ||
+    setweight(to_tsvector('simple', body), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_wiki_pages_search_vector ON wiki_pages USING GIN(search_vector);
+CREATE INDEX IF NOT EXISTS idx_notes_search_vector ON notes USING GIN(search_vector);
+CREATE INDEX IF NOT EXISTS idx_quotes_search_vector ON quotes USING GIN(search_vector);
@@ -0,0 +1,2 @@
+-- Remove per-user preferences
+DROP TABLE IF EXISTS user_preferences;
@@ -0,0 +1,10 @@
+-- Add per-user preferences
+-- default_guild_id is applied by the bot when note/quote commands are run
+-- outside a guild (e.g. in DMs), so content still lands in a guild scope.
+
+CREATE TABLE user_preferences (
+    user_id TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    default_guild_id TEXT REFERENCES discord_guilds(guild_id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
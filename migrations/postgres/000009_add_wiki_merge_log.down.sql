@@ -0,0 +1,2 @@
+-- Remove wiki merge log
+DROP TABLE IF EXISTS wiki_merge_log;
@@ -0,0 +1,21 @@
+-- Record wiki page merges so they can be undone
+-- Snapshots the source page, its titles, and its message references, plus the
+-- target's content before the merge. undone_at is set once a merge is reverted.
+
+CREATE TABLE wiki_merge_log (
+    id TEXT PRIMARY KEY,
+    guild_id TEXT NOT NULL REFERENCES discord_guilds(guild_id) ON DELETE CASCADE,
+    source_page_id TEXT NOT NULL REFERENCES wiki_pages(id) ON DELETE CASCADE,
+    target_page_id TEXT NOT NULL REFERENCES wiki_pages(id) ON DELETE CASCADE,
+    merged_by_user_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    source_page JSONB NOT NULL,
+    source_titles JSONB NOT NULL DEFAULT '[]'::jsonb,
+    source_references JSONB NOT NULL DEFAULT '[]'::jsonb,
+    target_body_before TEXT NOT NULL,
+    target_tags_before TEXT[] DEFAULT '{}',
+    merged_body TEXT NOT NULL,
+    merged_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    undone_at TIMESTAMP
+);
+
+CREATE INDEX idx_wiki_merge_log_source_page ON wiki_merge_log(source_page_id, merged_at DESC);
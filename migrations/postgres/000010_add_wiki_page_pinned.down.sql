@@ -0,0 +1,6 @@
+-- Remove pinned flag from wiki pages
+
+DROP INDEX IF EXISTS idx_wiki_pages_guild_pinned;
+
+ALTER TABLE wiki_pages
+DROP COLUMN IF EXISTS pinned;
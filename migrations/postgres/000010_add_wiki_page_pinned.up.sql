@@ -0,0 +1,7 @@
+-- Add pinned flag to wiki pages
+-- Pinned pages (rules, FAQ, etc.) are listed before all other pages in a guild
+
+ALTER TABLE wiki_pages
+ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_wiki_pages_guild_pinned ON wiki_pages(guild_id, pinned) WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+-- Remove audit log target index
+
+DROP INDEX IF EXISTS idx_audit_logs_resource;
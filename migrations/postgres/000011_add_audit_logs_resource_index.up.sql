@@ -0,0 +1,3 @@
+-- Index audit log lookups by target (e.g. the history of one wiki page)
+
+CREATE INDEX idx_audit_logs_resource ON audit_logs(resource_type, resource_id);
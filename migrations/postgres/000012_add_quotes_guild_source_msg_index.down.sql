@@ -0,0 +1,3 @@
+-- Remove duplicate-quote lookup index
+
+DROP INDEX IF EXISTS idx_quotes_guild_source_msg;
@@ -0,0 +1,3 @@
+-- Index duplicate-quote lookups by source message within a guild
+
+CREATE INDEX idx_quotes_guild_source_msg ON quotes(guild_id, source_msg_id) WHERE deleted_at IS NULL;
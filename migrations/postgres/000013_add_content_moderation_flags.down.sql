@@ -0,0 +1,20 @@
+-- Remove moderation flags from user-submitted content
+
+DROP INDEX IF EXISTS idx_quotes_flagged;
+DROP INDEX IF EXISTS idx_notes_flagged;
+DROP INDEX IF EXISTS idx_wiki_pages_flagged;
+
+ALTER TABLE quotes
+DROP COLUMN IF EXISTS flagged_at,
+DROP COLUMN IF EXISTS flagged_reason,
+DROP COLUMN IF EXISTS flagged;
+
+ALTER TABLE notes
+DROP COLUMN IF EXISTS flagged_at,
+DROP COLUMN IF EXISTS flagged_reason,
+DROP COLUMN IF EXISTS flagged;
+
+ALTER TABLE wiki_pages
+DROP COLUMN IF EXISTS flagged_at,
+DROP COLUMN IF EXISTS flagged_reason,
+DROP COLUMN IF EXISTS flagged;
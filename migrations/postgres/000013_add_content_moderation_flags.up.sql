@@ -0,0 +1,22 @@
+-- Add moderation flags to user-submitted content
+-- Items whose text matches a configured moderation pattern under the "flag" policy
+-- are saved with flagged set so admins can review them
+
+ALTER TABLE wiki_pages
+ADD COLUMN flagged BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN flagged_reason TEXT,
+ADD COLUMN flagged_at TIMESTAMP;
+
+ALTER TABLE notes
+ADD COLUMN flagged BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN flagged_reason TEXT,
+ADD COLUMN flagged_at TIMESTAMP;
+
+ALTER TABLE quotes
+ADD COLUMN flagged BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN flagged_reason TEXT,
+ADD COLUMN flagged_at TIMESTAMP;
+
+CREATE INDEX idx_wiki_pages_flagged ON wiki_pages(flagged_at) WHERE flagged AND deleted_at IS NULL;
+CREATE INDEX idx_notes_flagged ON notes(flagged_at) WHERE flagged AND deleted_at IS NULL;
+CREATE INDEX idx_quotes_flagged ON quotes(flagged_at) WHERE flagged AND deleted_at IS NULL;
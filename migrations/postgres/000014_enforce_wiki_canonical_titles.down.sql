@@ -0,0 +1,4 @@
+-- Drop the one-canonical-title-per-page constraint
+-- Titles restored or demoted by the up migration are left as they are
+
+DROP INDEX IF EXISTS idx_wiki_titles_one_canonical;
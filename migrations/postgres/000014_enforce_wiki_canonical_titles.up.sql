@@ -0,0 +1,40 @@
+-- Enforce one canonical title per wiki page
+-- Titles were unique per guild slug already, but a page and its canonical title were
+-- written separately, so a create that lost a race for a title left a live page with no
+-- title at all. Pages and titles are now written in one transaction; this cleans up
+-- what earlier races left behind and adds the missing constraint.
+
+-- Pages with more than one canonical title keep the oldest; the rest become aliases
+UPDATE wiki_titles wt
+SET is_canonical = false
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY page_id ORDER BY created_at, id) AS n
+    FROM wiki_titles
+    WHERE is_canonical
+) ranked
+WHERE wt.id = ranked.id AND ranked.n > 1;
+
+-- Live pages without a canonical title get their own title back when its slug is free.
+-- The slug approximates the application's (lowercase, runs of other characters become "-").
+CREATE TEMP TABLE untitled_wiki_pages ON COMMIT DROP AS
+SELECT wp.id, wp.guild_id, wp.created_at,
+       COALESCE(NULLIF(TRIM(wp.title), ''), 'Untitled') AS title,
+       COALESCE(NULLIF(TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(COALESCE(wp.title, '')), '[^a-z0-9]+', '-', 'g')), ''), 'untitled') AS slug
+FROM wiki_pages wp
+WHERE wp.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM wiki_titles wt WHERE wt.page_id = wp.id AND wt.is_canonical);
+
+INSERT INTO wiki_titles (id, guild_id, display_title, page_slug, page_id, is_canonical, created_at)
+SELECT DISTINCT ON (guild_id, slug) 'title-' || id, guild_id, title, slug, id, true, created_at
+FROM untitled_wiki_pages
+ORDER BY guild_id, slug, created_at, id
+ON CONFLICT (guild_id, page_slug) DO NOTHING;
+
+-- Duplicates whose slug is taken keep their title with the page ID appended
+INSERT INTO wiki_titles (id, guild_id, display_title, page_slug, page_id, is_canonical, created_at)
+SELECT 'title-' || u.id, u.guild_id, u.title || ' (' || u.id || ')', u.slug || '-' || LOWER(u.id), u.id, true, u.created_at
+FROM untitled_wiki_pages u
+WHERE NOT EXISTS (SELECT 1 FROM wiki_titles wt WHERE wt.page_id = u.id AND wt.is_canonical)
+ON CONFLICT (guild_id, page_slug) DO NOTHING;
+
+CREATE UNIQUE INDEX idx_wiki_titles_one_canonical ON wiki_titles(page_id) WHERE is_canonical;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_quotes_guild_short_code;
+DROP INDEX IF EXISTS idx_notes_scope_slug;
+
+ALTER TABLE quotes DROP COLUMN IF EXISTS short_code;
+ALTER TABLE notes DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,37 @@
+-- Human-readable permalinks for notes and quotes
+-- Notes get a slug derived from their title, unique among a guild's notes (a personal
+-- note's scope is its author). Quotes get a short random code, unique per guild, that
+-- never changes. Both are generated by the application on create; existing rows are
+-- backfilled here.
+
+ALTER TABLE notes ADD COLUMN slug TEXT;
+ALTER TABLE quotes ADD COLUMN short_code TEXT;
+
+-- The slug approximates the application's (lowercase, runs of other characters become "-").
+-- Notes sharing a slug keep it for the oldest; later ones append their ID.
+UPDATE notes n
+SET slug = CASE WHEN ranked.n = 1 THEN ranked.slug ELSE ranked.slug || '-' || ranked.id END
+FROM (
+    SELECT id, slug, ROW_NUMBER() OVER (PARTITION BY scope, slug ORDER BY created_at, id) AS n
+    FROM (
+        SELECT id, created_at, COALESCE(guild_id, 'user:' || author_id) AS scope,
+               COALESCE(NULLIF(TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(COALESCE(title, '')), '[^a-z0-9]+', '-', 'g')), ''), 'note') AS slug
+        FROM notes
+        WHERE deleted_at IS NULL
+    ) slugged
+) ranked
+WHERE n.id = ranked.id;
+
+-- Existing quotes get a code derived from their ID, lengthened on the rare clash
+UPDATE quotes q
+SET short_code = CASE WHEN ranked.n = 1 THEN SUBSTR(MD5(ranked.id), 1, 8) ELSE SUBSTR(MD5(ranked.id), 1, 16) END
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY guild_id, SUBSTR(MD5(id), 1, 8) ORDER BY created_at, id) AS n
+    FROM quotes
+) ranked
+WHERE q.id = ranked.id;
+
+CREATE UNIQUE INDEX idx_notes_scope_slug ON notes (COALESCE(guild_id, 'user:' || author_id), slug)
+    WHERE slug IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_quotes_guild_short_code ON quotes (guild_id, short_code)
+    WHERE short_code IS NOT NULL;
@@ -0,0 +1,15 @@
+-- Per-user history of recently viewed wiki pages, notes, and quotes
+-- One row per user and item: viewing an item again moves it to the front by
+-- bumping viewed_at, and each user's history is trimmed to a fixed length.
+-- content_id isn't a foreign key because it points into one of three tables;
+-- rows for deleted content are skipped when listing and age out of the history.
+
+CREATE TABLE recently_viewed (
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content_type TEXT NOT NULL, -- wiki, note, or quote
+    content_id TEXT NOT NULL,
+    viewed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, content_type, content_id)
+);
+
+CREATE INDEX idx_recently_viewed_user_viewed_at ON recently_viewed(user_id, viewed_at DESC);
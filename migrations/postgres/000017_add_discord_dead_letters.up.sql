@@ -0,0 +1,18 @@
+-- Outbound Discord calls the bot dropped after exhausting its retries
+-- (channel posts, announcements, and interaction replies), kept so operators
+-- can see what was lost. guild_id isn't a foreign key so rows outlive the guild.
+
+CREATE TABLE discord_dead_letters (
+    id TEXT PRIMARY KEY,
+    operation TEXT NOT NULL,
+    guild_id TEXT,
+    channel_id TEXT,
+    failure_type TEXT NOT NULL,
+    error TEXT NOT NULL,
+    attempts INT NOT NULL,
+    payload TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_discord_dead_letters_created_at ON discord_dead_letters(created_at DESC);
+CREATE INDEX idx_discord_dead_letters_guild_created_at ON discord_dead_letters(guild_id, created_at DESC);
@@ -0,0 +1 @@
+ALTER TABLE wiki_pages DROP COLUMN IF EXISTS last_editor_id;
@@ -0,0 +1,5 @@
+-- Who last edited each wiki page. NULL until the page is first edited after
+-- creation, for pages last edited before this column existed, and when the editor's
+-- account is deleted; /wiki whoedited then shows the edit time without an editor.
+
+ALTER TABLE wiki_pages ADD COLUMN last_editor_id TEXT REFERENCES users(id) ON DELETE SET NULL;
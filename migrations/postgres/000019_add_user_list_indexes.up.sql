@@ -0,0 +1,9 @@
+-- Indexes for the admin user list: the default newest-first keyset ordering,
+-- and trigram indexes so case-insensitive substring searches on email and
+-- name don't scan the whole table.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_users_created_at_id ON users(created_at DESC, id DESC);
+CREATE INDEX idx_users_email_trgm ON users USING GIN(email gin_trgm_ops);
+CREATE INDEX idx_users_name_trgm ON users USING GIN(name gin_trgm_ops);
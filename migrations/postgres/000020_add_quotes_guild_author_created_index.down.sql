@@ -0,0 +1,3 @@
+-- Remove quote cooldown lookup index
+
+DROP INDEX IF EXISTS idx_quotes_guild_author_created;
@@ -0,0 +1,3 @@
+-- Index the lookup of a member's latest quote in a guild, used by the quote cooldown
+
+CREATE INDEX idx_quotes_guild_author_created ON quotes(guild_id, author_id, created_at DESC);
@@ -0,0 +1,3 @@
+-- Remove the quote leaderboard opt-out
+
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS leaderboard_opt_out;
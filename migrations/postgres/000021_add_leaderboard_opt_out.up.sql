@@ -0,0 +1,4 @@
+-- Let members keep themselves off quote leaderboards, including the weekly
+-- leaderboard the bot posts to a guild's announcement channel
+
+ALTER TABLE user_preferences ADD COLUMN leaderboard_opt_out BOOLEAN NOT NULL DEFAULT FALSE;
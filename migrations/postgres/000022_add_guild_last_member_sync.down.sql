@@ -0,0 +1,3 @@
+-- Remove the guild last member sync time
+
+ALTER TABLE discord_guilds DROP COLUMN IF EXISTS last_member_sync;
@@ -0,0 +1,4 @@
+-- When the bot last copied each guild's full member list from Discord, shown by
+-- /hivemind sync-members. NULL until the first full sync after this migration.
+
+ALTER TABLE discord_guilds ADD COLUMN last_member_sync TIMESTAMP;
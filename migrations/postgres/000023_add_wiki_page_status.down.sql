@@ -0,0 +1,6 @@
+-- Remove draft/published status from wiki pages
+
+DROP INDEX IF EXISTS idx_wiki_pages_guild_drafts;
+
+ALTER TABLE wiki_pages
+DROP COLUMN IF EXISTS status;
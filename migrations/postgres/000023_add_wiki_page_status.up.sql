@@ -0,0 +1,8 @@
+-- Add draft/published status to wiki pages
+-- Drafts are left out of lists, searches, and title autocomplete for everyone but
+-- their author until published. Existing pages stay published.
+
+ALTER TABLE wiki_pages
+ADD COLUMN status TEXT NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'published'));
+
+CREATE INDEX idx_wiki_pages_guild_drafts ON wiki_pages(guild_id, author_id) WHERE status = 'draft' AND deleted_at IS NULL;
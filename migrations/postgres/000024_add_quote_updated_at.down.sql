@@ -0,0 +1,4 @@
+-- Remove quote update tracking
+
+ALTER TABLE quotes
+DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,10 @@
+-- Track when quotes are edited so the activity feed can report updates
+-- Existing quotes count as last updated when they were created.
+
+ALTER TABLE quotes
+ADD COLUMN updated_at TIMESTAMP;
+
+UPDATE quotes SET updated_at = created_at;
+
+ALTER TABLE quotes
+ALTER COLUMN updated_at SET DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,6 @@
+-- Remove database enforcement of unique note titles
+
+DROP INDEX IF EXISTS idx_notes_unique_title;
+
+ALTER TABLE notes
+DROP COLUMN IF EXISTS unique_title;
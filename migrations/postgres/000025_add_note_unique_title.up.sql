@@ -0,0 +1,9 @@
+-- Enforce unique note titles in the database, so two saves at once can't both take a
+-- title. unique_title holds the lowercased title of notes saved while their guild
+-- requires unique titles, and is NULL otherwise.
+
+ALTER TABLE notes
+ADD COLUMN unique_title TEXT;
+
+CREATE UNIQUE INDEX idx_notes_unique_title ON notes (guild_id, author_id, unique_title)
+WHERE deleted_at IS NULL AND unique_title IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Remove corrected quote attributions
+
+ALTER TABLE quotes
+DROP COLUMN IF EXISTS source_msg_author_override;
@@ -0,0 +1,6 @@
+-- Let a quote's attribution be corrected without dropping the Discord user it was
+-- detected from, so it stays in the most-quoted stats and keeps its avatar.
+-- When set, the override is shown in place of that user's names.
+
+ALTER TABLE quotes
+ADD COLUMN source_msg_author_override TEXT;
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/infrastructure/database/postgres"
+)
+
+func newAuditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Audit log commands",
+		Long:  "Commands for inspecting the Hivemind audit log",
+	}
+
+	cmd.AddCommand(newListAuditCommand())
+
+	return cmd
+}
+
+func newListAuditCommand() *cobra.Command {
+	var (
+		actorID    string
+		targetType string
+		targetID   string
+		since      time.Duration
+		limit      int
+		configPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recent audit log entries",
+		Long:  "List audit log entries, newest first, optionally filtered by actor, target, and age",
+		Example: `  # Show the last 20 entries
+  server audit list
+
+  # Show everything that happened to one wiki page
+  server audit list --target-type wiki_page --target-id 1234567890
+
+  # Show what a user did in the last day
+  server audit list --actor 1234567890 --since 24h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := repositories.ListAuditLogsOptions{
+				Limit:     limit,
+				SortBy:    "timestamp",
+				SortOrder: "desc",
+			}
+			if actorID != "" {
+				opts.UserID = &actorID
+			}
+			if targetType != "" {
+				resource := entities.AuditResource(targetType)
+				opts.Resource = &resource
+			}
+			if targetID != "" {
+				opts.ResourceID = &targetID
+			}
+			if since > 0 {
+				after := time.Now().Add(-since)
+				opts.CreatedAfter = &after
+			}
+			return listAuditLog(configPath, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&actorID, "actor", "", "Filter by actor user ID")
+	cmd.Flags().StringVar(&targetType, "target-type", "", "Filter by target type (wiki_page, note, quote, user, token)")
+	cmd.Flags().StringVar(&targetID, "target-id", "", "Filter by target ID")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show entries newer than this (e.g. 24h)")
+	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum number of entries to show")
+	cmd.Flags().StringVar(&configPath, "config", "", "Path to config file")
+
+	return cmd
+}
+
+func listAuditLog(configPath string, opts repositories.ListAuditLogsOptions) error {
+	// Load configuration
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Initialize database
+	pgConn, err := postgres.NewConnection(cfg.Database.Postgres.ConnectionString())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pgConn.Close()
+
+	auditRepo := postgres.NewAuditRepository(pgConn.DB)
+
+	logs, total, err := auditRepo.List(context.Background(), opts)
+	if err != nil {
+		return fmt.Errorf("failed to list audit log: %w", err)
+	}
+
+	if len(logs) == 0 {
+		fmt.Println("No audit log entries found")
+		return nil
+	}
+
+	fmt.Printf("\nShowing %d of %d audit log entries:\n\n", len(logs), total)
+	for _, log := range logs {
+		fmt.Println(formatAuditLogLine(log))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// formatAuditLogLine renders one entry as "time  action  target  actor  key=value ..."
+func formatAuditLogLine(log *entities.AuditLog) string {
+	actor := "system"
+	if log.UserID != nil {
+		actor = *log.UserID
+	}
+	target := string(log.Resource)
+	if log.ResourceID != nil {
+		target += ":" + *log.ResourceID
+	}
+
+	line := fmt.Sprintf("%s  %-20s %-32s by %s",
+		log.CreatedAt.Format(time.RFC3339), log.Action, target, actor)
+
+	if len(log.Metadata) > 0 {
+		keys := make([]string, 0, len(log.Metadata))
+		for key := range log.Metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, len(keys))
+		for i, key := range keys {
+			pairs[i] = fmt.Sprintf("%s=%v", key, log.Metadata[key])
+		}
+		line += "  " + strings.Join(pairs, " ")
+	}
+	if !log.Success {
+		line += "  (failed)"
+	}
+	return line
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/internal/infrastructure/database/postgres"
+)
+
+// runAuthCleanup deletes expired and revoked API tokens and expired OIDC sessions every
+// cfg.Interval until ctx is done. Tokens are judged by their own expiry, not that of the
+// JWTs issued for them, so a token that can still be refreshed is never removed.
+func runAuthCleanup(ctx context.Context, cfg config.CleanupConfig, pgConn *postgres.Connection, tokenService *services.TokenService, sessionRepo repositories.SessionRepository, logger *slog.Logger) {
+	if cfg.Interval <= 0 {
+		logger.Info("Expired token and session cleanup disabled")
+		return
+	}
+
+	logger.Info("Starting expired token and session cleanup",
+		"interval", cfg.Interval,
+		"token_retention", cfg.TokenRetention,
+		"session_retention", cfg.SessionRetention)
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		cleanupAuth(ctx, cfg, pgConn, tokenService, sessionRepo, logger)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// cleanupAuth runs one cleanup pass, skipping it while another server holds the job lock
+func cleanupAuth(ctx context.Context, cfg config.CleanupConfig, pgConn *postgres.Connection, tokenService *services.TokenService, sessionRepo repositories.SessionRepository, logger *slog.Logger) {
+	release, ok, err := pgConn.TryLockJob(ctx, postgres.JobAuthCleanup)
+	if err != nil {
+		logger.Warn("Failed to take cleanup lock", "error", err)
+		return
+	}
+	if !ok {
+		logger.Debug("Skipping cleanup, another server is running it")
+		return
+	}
+	defer func() {
+		if err := release(); err != nil {
+			logger.Warn("Failed to release cleanup lock", "error", err)
+		}
+	}()
+
+	expired, err := tokenService.CleanupExpiredTokens(ctx, cfg.TokenRetention)
+	if err != nil {
+		logger.Error("Failed to delete expired API tokens", "error", err)
+	}
+	revoked, err := tokenService.CleanupRevokedTokens(ctx, cfg.TokenRetention)
+	if err != nil {
+		logger.Error("Failed to delete revoked API tokens", "error", err)
+	}
+	sessions, err := sessionRepo.CleanupExpiredSessions(ctx, time.Now().Add(-cfg.SessionRetention))
+	if err != nil {
+		logger.Error("Failed to delete expired OIDC sessions", "error", err)
+	}
+
+	logger.Info("Cleaned up expired tokens and sessions",
+		"expired_tokens", expired,
+		"revoked_tokens", revoked,
+		"oidc_sessions", sessions)
+}
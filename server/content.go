@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/internal/infrastructure/database/postgres"
+	"github.com/devilmonastery/hivemind/internal/pkg/idgen"
+)
+
+func newContentCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "content",
+		Short: "Content management commands",
+		Long:  "Commands for correcting wiki pages, notes, and quotes in Hivemind",
+	}
+
+	cmd.AddCommand(newReassignAuthorCommand())
+
+	return cmd
+}
+
+func newReassignAuthorCommand() *cobra.Command {
+	var (
+		contentType string
+		id          string
+		authorID    string
+		configPath  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "reassign-author",
+		Short: "Change who is credited as the author of a wiki page, note, or quote",
+		Long: `Make another user the author of a wiki page, note, or quote, e.g. content that was
+migrated or saved by the bot on someone else's behalf. The change is written to the audit log.`,
+		Example: `  # Credit a quote to the user who actually saved it
+  server content reassign-author --type quote --id 1234567890 --to 9876543210`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if contentType == "" || id == "" || authorID == "" {
+				return fmt.Errorf("--type, --id, and --to are required")
+			}
+			return reassignAuthor(configPath, entities.AuditResource(contentType), id, authorID)
+		},
+	}
+
+	cmd.Flags().StringVar(&contentType, "type", "", "Content type: wiki_page, note, or quote (required)")
+	cmd.Flags().StringVar(&id, "id", "", "Content ID (required)")
+	cmd.Flags().StringVar(&authorID, "to", "", "User ID of the new author (required)")
+	cmd.Flags().StringVar(&configPath, "config", "", "Path to config file")
+
+	return cmd
+}
+
+func reassignAuthor(configPath string, contentType entities.AuditResource, id, authorID string) error {
+	// Initialize ID generator
+	if err := idgen.Initialize(idgen.CLINodeID); err != nil {
+		return fmt.Errorf("failed to initialize ID generator: %w", err)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Initialize database
+	pgConn, err := postgres.NewConnection(cfg.Database.Postgres.ConnectionString())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pgConn.Close()
+
+	guildContentService := services.NewGuildContentService(
+		postgres.NewGuildContentRepository(pgConn.DB.DB),
+		postgres.NewDiscordGuildRepository(pgConn.DB),
+		postgres.NewUserRepository(pgConn.DB),
+		postgres.NewAuditRepository(pgConn.DB),
+	)
+
+	reassignment, err := guildContentService.ReassignAuthor(context.Background(), contentType, id, authorID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Reassigned %s %s from user %s to %s\n", reassignment.ContentType, reassignment.ID, reassignment.PreviousAuthorID, reassignment.AuthorID)
+	return nil
+}
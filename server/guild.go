@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/internal/infrastructure/database/postgres"
+	"github.com/devilmonastery/hivemind/internal/pkg/idgen"
+)
+
+func newGuildCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "guild",
+		Short: "Guild management commands",
+		Long:  "Commands for managing Discord guild data in Hivemind",
+	}
+
+	cmd.AddCommand(newMoveGuildContentCommand())
+
+	return cmd
+}
+
+func newMoveGuildContentCommand() *cobra.Command {
+	var (
+		sourceGuildID string
+		targetGuildID string
+		confirmSource string
+		confirmTarget string
+		contentTypes  []string
+		dryRun        bool
+		configPath    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "move-content",
+		Short: "Move wiki pages, notes, and quotes to another guild",
+		Long: `Reassign content from one guild to another, e.g. after a community migrates servers.
+Both guild IDs must be repeated with --confirm-from and --confirm-to unless --dry-run is set.`,
+		Example: `  # See what would move
+  server guild move-content --from 111 --to 222 --dry-run
+
+  # Move only the wiki
+  server guild move-content --from 111 --to 222 --confirm-from 111 --confirm-to 222 --types wiki`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if sourceGuildID == "" || targetGuildID == "" {
+				return fmt.Errorf("--from and --to are required")
+			}
+			if !dryRun && (confirmSource != sourceGuildID || confirmTarget != targetGuildID) {
+				return fmt.Errorf("--confirm-from and --confirm-to must repeat the guild IDs (or use --dry-run)")
+			}
+			return moveGuildContent(configPath, sourceGuildID, targetGuildID, contentTypes, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVar(&sourceGuildID, "from", "", "Source guild ID (required)")
+	cmd.Flags().StringVar(&targetGuildID, "to", "", "Target guild ID (required)")
+	cmd.Flags().StringVar(&confirmSource, "confirm-from", "", "Repeat the source guild ID to confirm")
+	cmd.Flags().StringVar(&confirmTarget, "confirm-to", "", "Repeat the target guild ID to confirm")
+	cmd.Flags().StringSliceVar(&contentTypes, "types", nil, "Content types to move (wiki, note, quote); default all")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would move without changing anything")
+	cmd.Flags().StringVar(&configPath, "config", "", "Path to config file")
+
+	return cmd
+}
+
+func moveGuildContent(configPath, sourceGuildID, targetGuildID string, contentTypes []string, dryRun bool) error {
+	// Initialize ID generator
+	if err := idgen.Initialize(idgen.CLINodeID); err != nil {
+		return fmt.Errorf("failed to initialize ID generator: %w", err)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Initialize database
+	pgConn, err := postgres.NewConnection(cfg.Database.Postgres.ConnectionString())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pgConn.Close()
+
+	guildContentService := services.NewGuildContentService(
+		postgres.NewGuildContentRepository(pgConn.DB.DB),
+		postgres.NewDiscordGuildRepository(pgConn.DB),
+		postgres.NewUserRepository(pgConn.DB),
+		postgres.NewAuditRepository(pgConn.DB),
+	)
+
+	move, err := guildContentService.MoveGuildContent(context.Background(), sourceGuildID, targetGuildID, contentTypes, dryRun)
+	if errors.Is(err, repositories.ErrGuildMoveConflict) {
+		return fmt.Errorf("%w: %s", err, strings.Join(move.Conflicts(), ", "))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move guild content: %w", err)
+	}
+
+	fmt.Println(formatGuildContentMove(move))
+	return nil
+}
+
+// formatGuildContentMove summarizes a move, or what a dry run would move
+func formatGuildContentMove(move *entities.GuildContentMove) string {
+	verb := "Moved"
+	if move.DryRun {
+		verb = "Would move"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s from guild %s to %s:\n", verb, move.SourceGuildID, move.TargetGuildID)
+	fmt.Fprintf(&b, "  Wiki pages:  %d (%d titles, %d references)\n", move.WikiPages, move.WikiTitles, move.WikiReferences)
+	fmt.Fprintf(&b, "  Notes:       %d (%d references)\n", move.Notes, move.NoteReferences)
+	fmt.Fprintf(&b, "  Quotes:      %d", move.Quotes)
+	if len(move.ConflictingTitles) > 0 {
+		fmt.Fprintf(&b, "\n  Conflicting wiki titles: %s", strings.Join(move.ConflictingTitles, ", "))
+	}
+	if len(move.ConflictingNoteSlugs) > 0 {
+		fmt.Fprintf(&b, "\n  Conflicting note slugs: %s", strings.Join(move.ConflictingNoteSlugs, ", "))
+	}
+	if len(move.ConflictingNoteTitles) > 0 {
+		fmt.Fprintf(&b, "\n  Conflicting note titles: %s", strings.Join(move.ConflictingNoteTitles, ", "))
+	}
+	if len(move.ConflictingQuoteCodes) > 0 {
+		fmt.Fprintf(&b, "\n  Conflicting quote codes: %s", strings.Join(move.ConflictingQuoteCodes, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+)
+
+// callerDiscordID returns the Discord ID to filter guild content by for the caller.
+// Repositories treat "" as no filter, so only admins and service callers (the bot
+// itself and service accounts) get it; anyone else needs a linked Discord account.
+func callerDiscordID(ctx context.Context, discordUserRepo repositories.DiscordUserRepository, user *interceptors.UserContext) (string, error) {
+	if user.Role == "admin" {
+		return "", nil
+	}
+	// Bot requests on behalf of a Discord user carry its ID
+	if user.DiscordID != "" {
+		return user.DiscordID, nil
+	}
+	if user.Role == interceptors.RoleBot || user.Role == "service_account" {
+		return "", nil
+	}
+
+	discordUser, err := discordUserRepo.GetByUserID(ctx, user.UserID)
+	if err != nil && !errors.Is(err, repositories.ErrDiscordUserNotFound) {
+		slog.Default().Error("failed to look up discord user for ACL",
+			slog.String("user_id", user.UserID),
+			slog.String("error", err.Error()))
+		return "", status.Error(codes.Internal, "failed to look up linked Discord account")
+	}
+	if discordUser == nil || discordUser.DiscordID == "" {
+		return "", status.Error(codes.PermissionDenied, "a linked Discord account is required to view guild content")
+	}
+	return discordUser.DiscordID, nil
+}
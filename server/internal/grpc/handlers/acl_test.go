@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	"github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+)
+
+// brokenDiscordUserRepo fails every lookup, as when the database is unavailable
+type brokenDiscordUserRepo struct {
+	fakeDiscordUserRepo
+}
+
+func (r *brokenDiscordUserRepo) GetByUserID(ctx context.Context, userID string) (*entities.DiscordUser, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestCallerDiscordID(t *testing.T) {
+	linked := &fakeDiscordUserRepo{byUserID: map[string]*entities.DiscordUser{"member": {DiscordID: "d-member"}}}
+
+	tests := []struct {
+		name     string
+		user     *interceptors.UserContext
+		want     string
+		wantCode codes.Code
+	}{
+		{name: "admin sees every guild", user: &interceptors.UserContext{UserID: "admin", Role: "admin"}},
+		{name: "bot without a Discord user", user: &interceptors.UserContext{UserID: "bot", Role: interceptors.RoleBot}},
+		{name: "bot request for a Discord user", user: &interceptors.UserContext{UserID: "member", Role: "user", DiscordID: "d-bot-user"}, want: "d-bot-user"},
+		{name: "linked user", user: &interceptors.UserContext{UserID: "member", Role: "user"}, want: "d-member"},
+		{name: "unlinked user", user: &interceptors.UserContext{UserID: "stranger", Role: "user"}, wantCode: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callerDiscordID(context.Background(), linked, tt.user)
+			if status.Code(err) != tt.wantCode || got != tt.want {
+				t.Errorf("callerDiscordID() = %q, %v, want %q, %s", got, err, tt.want, tt.wantCode)
+			}
+		})
+	}
+
+	_, err := callerDiscordID(context.Background(), &brokenDiscordUserRepo{}, &interceptors.UserContext{UserID: "member", Role: "user"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("callerDiscordID() with a failing lookup error = %v, want Internal", err)
+	}
+}
+
+// An unlinked user must not get the unfiltered admin view of every guild
+func TestSearch_UnlinkedNonAdminDenied(t *testing.T) {
+	ctx := userContext("stranger", "user")
+
+	quotes := NewQuoteHandler(services.NewQuoteService(&fakeQuoteRepo{}, nil, nil, nil, nil), &fakeDiscordUserRepo{}, 0, config.PageLimits{})
+	if _, err := quotes.SearchQuotes(ctx, &quotespb.SearchQuotesRequest{Query: "hello"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("SearchQuotes() error = %v, want PermissionDenied", err)
+	}
+
+	wiki := NewWikiHandler(services.NewWikiService(&fakeACLWikiRepo{}, nil, nil, nil, nil, nil, nil, nil, nil), nil, nil, &fakeDiscordUserRepo{}, 0, config.PageLimits{}, slog.Default())
+	if _, err := wiki.SearchWikiPages(ctx, &wikipb.SearchWikiPagesRequest{Query: "rules"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("SearchWikiPages() error = %v, want PermissionDenied", err)
+	}
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/devilmonastery/hivemind/api/generated/go/activitypb"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultActivityLimit = 20
+	maxActivityLimit     = 100
+
+	defaultRecentlyViewedLimit = 10
+)
+
+// ActivityHandler implements the ActivityService gRPC handler
+type ActivityHandler struct {
+	activitypb.UnimplementedActivityServiceServer
+	activityService *services.ActivityService
+	discordUserRepo repositories.DiscordUserRepository
+	log             *slog.Logger
+}
+
+// NewActivityHandler creates a new activity handler
+func NewActivityHandler(activityService *services.ActivityService, discordUserRepo repositories.DiscordUserRepository) *ActivityHandler {
+	return &ActivityHandler{
+		activityService: activityService,
+		discordUserRepo: discordUserRepo,
+		log:             slog.Default().With(slog.String("handler", "activity")),
+	}
+}
+
+// ListRecentActivity returns recently created or updated content visible to the caller
+func (h *ActivityHandler) ListRecentActivity(ctx context.Context, req *activitypb.ListRecentActivityRequest) (*activitypb.ListRecentActivityResponse, error) {
+	user, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+
+	userDiscordID, err := h.membershipFilter(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultActivityLimit
+	}
+	if limit > maxActivityLimit {
+		limit = maxActivityLimit
+	}
+
+	var since time.Time
+	if req.Since != nil {
+		since = req.Since.AsTime()
+	}
+
+	cursor, err := repositories.ParsePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+
+	items, hasMore, next, err := h.activityService.ListRecentActivity(ctx, req.GuildId, user.UserID, userDiscordID, since, cursor, limit)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list recent activity: %v", err)
+	}
+
+	protoItems := make([]*activitypb.ActivityItem, len(items))
+	for i, item := range items {
+		protoItems[i] = activityItemToProto(item)
+	}
+
+	return &activitypb.ListRecentActivityResponse{
+		Items:         protoItems,
+		HasMore:       hasMore,
+		NextPageToken: next.Token(),
+	}, nil
+}
+
+// ListRecentlyViewed returns the caller's view history
+func (h *ActivityHandler) ListRecentlyViewed(ctx context.Context, req *activitypb.ListRecentlyViewedRequest) (*activitypb.ListRecentlyViewedResponse, error) {
+	user, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+
+	// Without a Discord account there is no membership to check, but the user's own
+	// notes, wiki pages, and quotes are still theirs to see
+	userDiscordID, err := h.membershipFilter(ctx, user)
+	authoredOnly := status.Code(err) == codes.PermissionDenied
+	if err != nil && !authoredOnly {
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultRecentlyViewedLimit
+	}
+	if limit > services.RecentlyViewedCap {
+		limit = services.RecentlyViewedCap
+	}
+
+	items, err := h.activityService.ListRecentlyViewed(ctx, user.UserID, userDiscordID, authoredOnly, req.ExcludeOwnEdits, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list recently viewed: %v", err)
+	}
+
+	protoItems := make([]*activitypb.RecentlyViewedItem, len(items))
+	for i, item := range items {
+		protoItems[i] = &activitypb.RecentlyViewedItem{
+			Type:      item.Type,
+			Id:        item.ID,
+			GuildId:   item.GuildID,
+			GuildName: item.GuildName,
+			Title:     item.Title,
+			Slug:      item.Slug,
+			Snippet:   item.Snippet,
+			ViewedAt:  timestamppb.New(item.ViewedAt),
+		}
+	}
+	return &activitypb.ListRecentlyViewedResponse{Items: protoItems}, nil
+}
+
+// membershipFilter returns the Discord ID to filter guild content by. Admins see every
+// guild and get "".
+func (h *ActivityHandler) membershipFilter(ctx context.Context, user *interceptors.UserContext) (string, error) {
+	if user.Role == "admin" {
+		return "", nil
+	}
+
+	userDiscordID := user.DiscordID
+	if userDiscordID == "" {
+		if discordUser, err := h.discordUserRepo.GetByUserID(ctx, user.UserID); err == nil && discordUser != nil {
+			userDiscordID = discordUser.DiscordID
+		}
+	}
+	// Without a Discord account there is no membership to filter by, so don't fall back to
+	// the unfiltered admin view
+	if userDiscordID == "" {
+		return "", status.Error(codes.PermissionDenied, "a linked Discord account is required to view guild activity")
+	}
+	return userDiscordID, nil
+}
+
+// activityItemToProto converts a domain activity item to protobuf
+func activityItemToProto(item *entities.ActivityItem) *activitypb.ActivityItem {
+	return &activitypb.ActivityItem{
+		Type:       item.Type,
+		Id:         item.ID,
+		Action:     item.Action,
+		GuildId:    item.GuildID,
+		GuildName:  item.GuildName,
+		Title:      item.Title,
+		Slug:       item.Slug,
+		Snippet:    item.Snippet,
+		AuthorName: item.AuthorName,
+		Timestamp:  timestamppb.New(item.Timestamp),
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/api/generated/go/activitypb"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+)
+
+// fakeActivityRepo records the cursor it was asked to continue from and returns one item
+// positioned after it
+type fakeActivityRepo struct {
+	gotCursor *repositories.PageCursor
+}
+
+func (f *fakeActivityRepo) ListRecent(ctx context.Context, guildID, userID, userDiscordID string, since time.Time, cursor *repositories.PageCursor, limit int) ([]*entities.ActivityItem, bool, *repositories.PageCursor, error) {
+	if cursor != nil && cursor.Order != "activity" {
+		return nil, false, nil, repositories.ErrInvalidPageToken
+	}
+	f.gotCursor = cursor
+	item := &entities.ActivityItem{Type: entities.ActivityTypeQuote, ID: "q2", Action: entities.ActivityActionUpdated, Timestamp: time.Now()}
+	next := &repositories.PageCursor{Order: "activity", Keys: []string{"2024-01-06 12:01:00"}, ID: "q2"}
+	return []*entities.ActivityItem{item}, true, next, nil
+}
+
+func TestListRecentActivityPageToken(t *testing.T) {
+	repo := &fakeActivityRepo{}
+	h := NewActivityHandler(services.NewActivityService(repo, nil), &fakeDiscordUserRepo{})
+	ctx := userContext("u-admin", "admin")
+
+	first, err := h.ListRecentActivity(ctx, &activitypb.ListRecentActivityRequest{})
+	if err != nil {
+		t.Fatalf("ListRecentActivity() error = %v", err)
+	}
+	if repo.gotCursor != nil || first.NextPageToken == "" || !first.HasMore {
+		t.Fatalf("first call: cursor = %+v, response = %v, want no cursor and a next page token with more", repo.gotCursor, first)
+	}
+
+	// The token comes back as the cursor to continue from
+	if _, err := h.ListRecentActivity(ctx, &activitypb.ListRecentActivityRequest{PageToken: first.NextPageToken}); err != nil {
+		t.Fatalf("ListRecentActivity(page_token) error = %v", err)
+	}
+	if repo.gotCursor == nil || repo.gotCursor.ID != "q2" {
+		t.Errorf("continued from cursor %+v, want the one at q2", repo.gotCursor)
+	}
+
+	for name, token := range map[string]string{
+		"garbage":        "not-a-token",
+		"other ordering": (&repositories.PageCursor{Order: "wiki:title:asc", Keys: []string{"a"}, ID: "p1"}).Token(),
+	} {
+		_, err := h.ListRecentActivity(ctx, &activitypb.ListRecentActivityRequest{PageToken: token})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s token: code = %v, want InvalidArgument", name, status.Code(err))
+		}
+	}
+}
+
+// fakeViewRepo records the filters its history was listed with
+type fakeViewRepo struct {
+	repositories.RecentlyViewedRepository
+	gotDiscordID    string
+	gotAuthoredOnly bool
+}
+
+func (f *fakeViewRepo) List(ctx context.Context, userID, userDiscordID string, authoredOnly, excludeOwnEdits bool, limit int) ([]*entities.RecentlyViewedItem, error) {
+	f.gotDiscordID = userDiscordID
+	f.gotAuthoredOnly = authoredOnly
+	return nil, nil
+}
+
+func TestListRecentlyViewed_UnlinkedUserSeesOwnItems(t *testing.T) {
+	views := &fakeViewRepo{}
+	h := NewActivityHandler(services.NewActivityService(nil, views), &fakeDiscordUserRepo{})
+
+	if _, err := h.ListRecentlyViewed(userContext("u1", "user"), &activitypb.ListRecentlyViewedRequest{}); err != nil {
+		t.Fatalf("ListRecentlyViewed() error = %v, want the user's own history", err)
+	}
+	if !views.gotAuthoredOnly || views.gotDiscordID != "" {
+		t.Errorf("listed with discord ID %q, authoredOnly %v; want only authored items", views.gotDiscordID, views.gotAuthoredOnly)
+	}
+
+	// The guild activity feed still needs membership to filter by
+	if _, err := h.ListRecentActivity(userContext("u1", "user"), &activitypb.ListRecentActivityRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ListRecentActivity() code = %v, want PermissionDenied", status.Code(err))
+	}
+}
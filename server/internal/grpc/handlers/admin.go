@@ -2,7 +2,11 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -12,24 +16,56 @@ import (
 
 	adminpb "github.com/devilmonastery/hivemind/api/generated/go/adminpb"
 	userpb "github.com/devilmonastery/hivemind/api/generated/go/userpb"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
 	"github.com/devilmonastery/hivemind/internal/domain/repositories"
 	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+)
+
+const (
+	defaultAuditLogLimit = 50
+	maxAuditLogLimit     = 200
+	defaultUserListLimit = 50
+	maxUserListLimit     = 100
 )
 
 // AdminHandler handles admin gRPC requests
 type AdminHandler struct {
 	adminpb.UnimplementedAdminServiceServer
-	userService *services.UserService
+	userService         *services.UserService
+	tokenService        *services.TokenService
+	guildContentService *services.GuildContentService
+	moderationService   *services.ModerationService
+	auditRepo           repositories.AuditRepository
+	readOnly            *interceptors.ReadOnlyMode
 }
 
 // NewAdminHandler creates a new admin handler
-func NewAdminHandler(userService *services.UserService) *AdminHandler {
+func NewAdminHandler(userService *services.UserService, tokenService *services.TokenService, guildContentService *services.GuildContentService, moderationService *services.ModerationService, auditRepo repositories.AuditRepository, readOnly *interceptors.ReadOnlyMode) *AdminHandler {
 	return &AdminHandler{
-		userService: userService,
+		userService:         userService,
+		tokenService:        tokenService,
+		guildContentService: guildContentService,
+		moderationService:   moderationService,
+		auditRepo:           auditRepo,
+		readOnly:            readOnly,
+	}
+}
+
+// requireAdmin returns the calling user, or an error status unless they are an admin
+func requireAdmin(ctx context.Context) (*interceptors.UserContext, error) {
+	user, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+	if user.Role != "admin" {
+		return nil, status.Error(codes.PermissionDenied, "admin access required")
 	}
+	return user, nil
 }
 
-// GetSystemInfo returns basic system information
+// GetSystemInfo returns basic system information. It is open to every user: the bot's
+// /ping uses it as a connectivity check, and it reveals nothing about other users.
 func (h *AdminHandler) GetSystemInfo(ctx context.Context, req *emptypb.Empty) (*adminpb.GetSystemInfoResponse, error) {
 	// For now, return basic info
 	// TODO: Add actual metrics and stats
@@ -51,6 +87,9 @@ func timestampFromTime(t time.Time) *timestamppb.Timestamp {
 
 // GetUserDetails retrieves detailed information about a specific user
 func (h *AdminHandler) GetUserDetails(ctx context.Context, req *adminpb.GetUserDetailsRequest) (*adminpb.GetUserDetailsResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
 	if req.UserId == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
@@ -91,6 +130,9 @@ func (h *AdminHandler) GetUserDetails(ctx context.Context, req *adminpb.GetUserD
 
 // ListAllUsers lists all users with pagination and filtering
 func (h *AdminHandler) ListAllUsers(ctx context.Context, req *adminpb.ListAllUsersRequest) (*adminpb.ListAllUsersResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
 	// Set default pagination if not provided
 	limit := int(req.PageSize)
 	if limit <= 0 || limit > 100 {
@@ -112,7 +154,7 @@ func (h *AdminHandler) ListAllUsers(ctx context.Context, req *adminpb.ListAllUse
 		SortOrder: "desc",
 	}
 
-	users, total, err := h.userService.ListUsers(ctx, opts)
+	users, total, _, err := h.userService.ListUsers(ctx, opts)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
 	}
@@ -153,18 +195,416 @@ func (h *AdminHandler) ListAllUsers(ctx context.Context, req *adminpb.ListAllUse
 	}, nil
 }
 
-// DeleteUser soft deletes a user (deactivates them)
+// ListUsers lists users matching the request's filters, newest first, with
+// keyset pagination so large user bases can be paged through without skipping rows
+func (h *AdminHandler) ListUsers(ctx context.Context, req *adminpb.ListUsersRequest) (*adminpb.ListUsersResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	opts, err := userListOptionsFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	users, total, next, err := h.userService.ListUsers(ctx, opts)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
+	}
+
+	entries := make([]*adminpb.UserListEntry, len(users))
+	for i, u := range users {
+		entries[i] = &adminpb.UserListEntry{
+			UserId:      u.ID,
+			Email:       u.Email,
+			DisplayName: u.DisplayName,
+			Role:        userRoleToProto[u.Role],
+			UserType:    userTypeToProto[u.UserType],
+			Active:      u.IsActive,
+			CreatedAt:   timestampFromTime(u.CreatedAt),
+		}
+		if u.LastLogin != nil {
+			entries[i].LastLogin = timestampFromTime(*u.LastLogin)
+		}
+	}
+
+	return &adminpb.ListUsersResponse{
+		Users:         entries,
+		NextPageToken: next.Token(),
+		TotalCount:    int32(total),
+	}, nil
+}
+
+var (
+	userRoleToProto = map[entities.Role]userpb.Role{
+		entities.RoleUser:  userpb.Role_ROLE_USER,
+		entities.RoleAdmin: userpb.Role_ROLE_ADMIN,
+	}
+	userTypeToProto = map[entities.UserType]userpb.UserType{
+		entities.UserTypeOIDC:   userpb.UserType_USER_TYPE_OIDC,
+		entities.UserTypeLocal:  userpb.UserType_USER_TYPE_LOCAL,
+		entities.UserTypeSystem: userpb.UserType_USER_TYPE_SYSTEM,
+	}
+	userRoleFromProto = map[userpb.Role]entities.Role{
+		userpb.Role_ROLE_USER:  entities.RoleUser,
+		userpb.Role_ROLE_ADMIN: entities.RoleAdmin,
+	}
+	userTypeFromProto = map[userpb.UserType]entities.UserType{
+		userpb.UserType_USER_TYPE_OIDC:   entities.UserTypeOIDC,
+		userpb.UserType_USER_TYPE_LOCAL:  entities.UserTypeLocal,
+		userpb.UserType_USER_TYPE_SYSTEM: entities.UserTypeSystem,
+	}
+)
+
+// userListOptionsFromRequest converts a ListUsersRequest into repository options,
+// clamping the page size and validating the filters and page token
+func userListOptionsFromRequest(req *adminpb.ListUsersRequest) (repositories.ListUsersOptions, error) {
+	opts := repositories.ListUsersOptions{
+		Limit:     int(req.PageSize),
+		Search:    strings.TrimSpace(req.Search),
+		SortBy:    "created_at",
+		SortOrder: "desc",
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = defaultUserListLimit
+	}
+	if opts.Limit > maxUserListLimit {
+		opts.Limit = maxUserListLimit
+	}
+
+	if req.Role != userpb.Role_ROLE_UNSPECIFIED {
+		role, ok := userRoleFromProto[req.Role]
+		if !ok {
+			return opts, status.Errorf(codes.InvalidArgument, "unknown role: %v", req.Role)
+		}
+		opts.Role = &role
+	}
+	if req.UserType != userpb.UserType_USER_TYPE_UNSPECIFIED {
+		userType, ok := userTypeFromProto[req.UserType]
+		if !ok {
+			return opts, status.Errorf(codes.InvalidArgument, "unknown user type: %v", req.UserType)
+		}
+		opts.UserType = &userType
+	}
+
+	switch req.Status {
+	case "":
+	case "active", "inactive":
+		active := req.Status == "active"
+		opts.IsActive = &active
+	default:
+		return opts, status.Errorf(codes.InvalidArgument, "status must be active or inactive, got %q", req.Status)
+	}
+
+	cursor, err := repositories.ParsePageToken(req.PageToken)
+	if err != nil {
+		return opts, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	opts.Cursor = cursor
+
+	return opts, nil
+}
+
+// DeleteUser soft deletes a user (deactivates them), recording the calling admin as the actor
 func (h *AdminHandler) DeleteUser(ctx context.Context, req *adminpb.DeleteUserRequest) (*emptypb.Empty, error) {
+	user, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if req.UserId == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
 
-	// For now, use "system" as the deactivated_by user
-	// TODO: Get actual admin user ID from context/token
-	err := h.userService.DeactivateUser(ctx, req.UserId, "system")
-	if err != nil {
+	if err := h.userService.SetUserActive(ctx, req.UserId, false, user.UserID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrSelfDeactivation):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, repositories.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to deactivate user: %v", err)
 	}
 
 	return &emptypb.Empty{}, nil
 }
+
+// SetUserActive deactivates or reactivates a user account. Deactivating can also
+// revoke all of the user's tokens so their sessions end immediately.
+func (h *AdminHandler) SetUserActive(ctx context.Context, req *adminpb.SetUserActiveRequest) (*adminpb.SetUserActiveResponse, error) {
+	user, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if err := h.userService.SetUserActive(ctx, req.UserId, req.Active, user.UserID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrSelfDeactivation):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, repositories.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to set user active state: %v", err)
+	}
+
+	revoked := false
+	if !req.Active && req.RevokeTokens {
+		if err := h.tokenService.RevokeAllUserTokens(ctx, req.UserId, user.UserID); err != nil {
+			return nil, status.Errorf(codes.Internal, "user deactivated but failed to revoke tokens: %v", err)
+		}
+		revoked = true
+	}
+
+	return &adminpb.SetUserActiveResponse{
+		UserId:        req.UserId,
+		Active:        req.Active,
+		TokensRevoked: revoked,
+	}, nil
+}
+
+// ListAuditLog lists audit log entries, newest first, filtered by actor, target, and time range
+func (h *AdminHandler) ListAuditLog(ctx context.Context, req *adminpb.ListAuditLogRequest) (*adminpb.ListAuditLogResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	opts, err := auditLogOptionsFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, total, err := h.auditRepo.List(ctx, opts)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list audit log: %v", err)
+	}
+
+	entries := make([]*adminpb.AuditLogEntry, len(logs))
+	for i, log := range logs {
+		entries[i] = auditLogToProto(log)
+	}
+
+	return &adminpb.ListAuditLogResponse{
+		Entries: entries,
+		Total:   int32(total),
+	}, nil
+}
+
+// auditLogOptionsFromRequest converts ListAuditLog filters to repository options
+func auditLogOptionsFromRequest(req *adminpb.ListAuditLogRequest) (repositories.ListAuditLogsOptions, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultAuditLogLimit
+	}
+	if limit > maxAuditLogLimit {
+		limit = maxAuditLogLimit
+	}
+
+	opts := repositories.ListAuditLogsOptions{
+		Limit:     limit,
+		Offset:    int(req.Offset),
+		SortBy:    "timestamp",
+		SortOrder: "desc",
+	}
+	if req.ActorUserId != "" {
+		opts.UserID = &req.ActorUserId
+	}
+	if req.TargetType != "" {
+		resource := entities.AuditResource(req.TargetType)
+		opts.Resource = &resource
+	}
+	if req.TargetId != "" {
+		opts.ResourceID = &req.TargetId
+	}
+	if req.StartTime != nil {
+		start := req.StartTime.AsTime()
+		opts.CreatedAfter = &start
+	}
+	if req.EndTime != nil {
+		end := req.EndTime.AsTime()
+		opts.CreatedBefore = &end
+	}
+	if opts.CreatedAfter != nil && opts.CreatedBefore != nil && opts.CreatedBefore.Before(*opts.CreatedAfter) {
+		return opts, status.Error(codes.InvalidArgument, "end_time must not be before start_time")
+	}
+	return opts, nil
+}
+
+// auditLogToProto converts a domain audit log entry to protobuf
+func auditLogToProto(log *entities.AuditLog) *adminpb.AuditLogEntry {
+	entry := &adminpb.AuditLogEntry{
+		Id:           log.ID,
+		Timestamp:    timestampFromTime(log.CreatedAt),
+		Action:       string(log.Action),
+		ResourceType: string(log.Resource),
+	}
+	if log.UserID != nil {
+		entry.UserId = *log.UserID
+	}
+	if log.ResourceID != nil {
+		entry.ResourceId = *log.ResourceID
+	}
+	if log.IPAddress != nil {
+		entry.IpAddress = *log.IPAddress
+	}
+	if log.UserAgent != nil {
+		entry.UserAgent = *log.UserAgent
+	}
+	if len(log.Metadata) > 0 {
+		entry.Metadata = make(map[string]string, len(log.Metadata))
+		for key, value := range log.Metadata {
+			entry.Metadata[key] = fmt.Sprint(value)
+		}
+	}
+	return entry
+}
+
+// MoveGuildContent reassigns wiki pages, notes, and quotes from one guild to another.
+// Both guild IDs must be repeated in the confirm fields unless this is a dry run.
+func (h *AdminHandler) MoveGuildContent(ctx context.Context, req *adminpb.MoveGuildContentRequest) (*adminpb.MoveGuildContentResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.SourceGuildId == "" || req.TargetGuildId == "" {
+		return nil, status.Error(codes.InvalidArgument, "source_guild_id and target_guild_id are required")
+	}
+	if !req.DryRun && (req.ConfirmSourceGuildId != req.SourceGuildId || req.ConfirmTargetGuildId != req.TargetGuildId) {
+		return nil, status.Error(codes.InvalidArgument, "confirm_source_guild_id and confirm_target_guild_id must repeat the guild IDs")
+	}
+
+	move, err := h.guildContentService.MoveGuildContent(ctx, req.SourceGuildId, req.TargetGuildId, req.ContentTypes, req.DryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSameGuild), errors.Is(err, services.ErrUnknownContentType):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, repositories.ErrDiscordGuildNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, repositories.ErrGuildMoveConflict):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v: %s", err, strings.Join(move.Conflicts(), ", "))
+		}
+		return nil, status.Errorf(codes.Internal, "failed to move guild content: %v", err)
+	}
+
+	return &adminpb.MoveGuildContentResponse{
+		DryRun:            move.DryRun,
+		WikiPages:         int32(move.WikiPages),
+		WikiTitles:        int32(move.WikiTitles),
+		WikiReferences:    int32(move.WikiReferences),
+		Notes:             int32(move.Notes),
+		NoteReferences:    int32(move.NoteReferences),
+		Quotes:            int32(move.Quotes),
+		ConflictingTitles: move.ConflictingTitles,
+
+		ConflictingNoteSlugs:  move.ConflictingNoteSlugs,
+		ConflictingNoteTitles: move.ConflictingNoteTitles,
+		ConflictingQuoteCodes: move.ConflictingQuoteCodes,
+	}, nil
+}
+
+// ReassignAuthor changes who a wiki page, note, or quote is credited to
+func (h *AdminHandler) ReassignAuthor(ctx context.Context, req *adminpb.ReassignAuthorRequest) (*adminpb.ReassignAuthorResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.ContentType == "" || req.Id == "" || req.AuthorId == "" {
+		return nil, status.Error(codes.InvalidArgument, "content_type, id, and author_id are required")
+	}
+
+	reassignment, err := h.guildContentService.ReassignAuthor(ctx, entities.AuditResource(req.ContentType), req.Id, req.AuthorId)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnknownContentType):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, repositories.ErrUserNotFound), errors.Is(err, repositories.ErrContentNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, repositories.ErrDiscordUserNotFound):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reassign author: %v", err)
+	}
+
+	return &adminpb.ReassignAuthorResponse{
+		ContentType:      string(reassignment.ContentType),
+		Id:               reassignment.ID,
+		GuildId:          reassignment.GuildID,
+		PreviousAuthorId: reassignment.PreviousAuthorID,
+		AuthorId:         reassignment.AuthorID,
+		AuthorUsername:   reassignment.AuthorName,
+	}, nil
+}
+
+// ListFlaggedContent lists wiki pages, notes, and quotes that content moderation flagged
+// for review, most recently flagged first
+func (h *AdminHandler) ListFlaggedContent(ctx context.Context, req *adminpb.ListFlaggedContentRequest) (*adminpb.ListFlaggedContentResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	contentType := entities.AuditResource(req.ContentType)
+	switch contentType {
+	case "", entities.ResourceWikiPage, entities.ResourceNote, entities.ResourceQuote:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown content_type %q", req.ContentType)
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultAuditLogLimit
+	}
+	if limit > maxAuditLogLimit {
+		limit = maxAuditLogLimit
+	}
+
+	items, total, err := h.moderationService.ListFlagged(ctx, contentType, limit, int(req.Offset))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list flagged content: %v", err)
+	}
+
+	resp := &adminpb.ListFlaggedContentResponse{
+		Items: make([]*adminpb.FlaggedContent, len(items)),
+		Total: int32(total),
+	}
+	for i, item := range items {
+		resp.Items[i] = &adminpb.FlaggedContent{
+			ContentType: string(item.ContentType),
+			Id:          item.ID,
+			GuildId:     item.GuildID,
+			AuthorId:    item.AuthorID,
+			Title:       item.Title,
+			Body:        item.Body,
+			Reason:      item.Reason,
+			FlaggedAt:   timestampFromTime(item.FlaggedAt),
+		}
+	}
+	return resp, nil
+}
+
+// GetReadOnlyMode reports whether this server is in read-only maintenance mode
+func (h *AdminHandler) GetReadOnlyMode(ctx context.Context, req *emptypb.Empty) (*adminpb.ReadOnlyMode, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	return &adminpb.ReadOnlyMode{ReadOnly: h.readOnly.Enabled()}, nil
+}
+
+// SetReadOnlyMode turns read-only maintenance mode on or off for this server
+func (h *AdminHandler) SetReadOnlyMode(ctx context.Context, req *adminpb.SetReadOnlyModeRequest) (*adminpb.ReadOnlyMode, error) {
+	user, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h.readOnly.Set(req.ReadOnly)
+	slog.Warn("read-only maintenance mode changed",
+		slog.Bool("read_only", req.ReadOnly),
+		slog.String("admin_user_id", user.UserID))
+
+	return &adminpb.ReadOnlyMode{ReadOnly: req.ReadOnly}, nil
+}
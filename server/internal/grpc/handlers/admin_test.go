@@ -0,0 +1,487 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	adminpb "github.com/devilmonastery/hivemind/api/generated/go/adminpb"
+	userpb "github.com/devilmonastery/hivemind/api/generated/go/userpb"
+	"github.com/devilmonastery/hivemind/internal/auth"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+)
+
+func TestListAuditLog_RequiresAdmin(t *testing.T) {
+	ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+		UserID: "u1",
+		Role:   "user",
+	})
+
+	_, err := NewAdminHandler(nil, nil, nil, nil, nil, nil).ListAuditLog(ctx, &adminpb.ListAuditLogRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ListAuditLog() code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestUserAdminRPCs_RequireAdmin(t *testing.T) {
+	ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+		UserID: "u1",
+		Role:   "user",
+	})
+	h := NewAdminHandler(nil, nil, nil, nil, nil, nil)
+
+	calls := map[string]func() error{
+		"GetUserDetails": func() error {
+			_, err := h.GetUserDetails(ctx, &adminpb.GetUserDetailsRequest{UserId: "u2"})
+			return err
+		},
+		"ListAllUsers": func() error {
+			_, err := h.ListAllUsers(ctx, &adminpb.ListAllUsersRequest{})
+			return err
+		},
+		"DeleteUser": func() error {
+			_, err := h.DeleteUser(ctx, &adminpb.DeleteUserRequest{UserId: "u2"})
+			return err
+		},
+	}
+	for name, call := range calls {
+		if err := call(); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s() code = %v, want PermissionDenied", name, status.Code(err))
+		}
+	}
+	if _, err := h.ListAllUsers(context.Background(), &adminpb.ListAllUsersRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("ListAllUsers() without a user code = %v, want Unauthenticated", status.Code(err))
+	}
+}
+
+func TestAuditLogOptionsFromRequest(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	opts, err := auditLogOptionsFromRequest(&adminpb.ListAuditLogRequest{
+		ActorUserId: "u1",
+		TargetType:  "wiki_page",
+		TargetId:    "p1",
+		StartTime:   timestamppb.New(start),
+		EndTime:     timestamppb.New(end),
+		Limit:       1000,
+	})
+	if err != nil {
+		t.Fatalf("auditLogOptionsFromRequest() error = %v", err)
+	}
+	if opts.UserID == nil || *opts.UserID != "u1" {
+		t.Errorf("UserID = %v, want u1", opts.UserID)
+	}
+	if opts.Resource == nil || *opts.Resource != entities.ResourceWikiPage {
+		t.Errorf("Resource = %v, want wiki_page", opts.Resource)
+	}
+	if opts.ResourceID == nil || *opts.ResourceID != "p1" {
+		t.Errorf("ResourceID = %v, want p1", opts.ResourceID)
+	}
+	if opts.CreatedAfter == nil || !opts.CreatedAfter.Equal(start) || opts.CreatedBefore == nil || !opts.CreatedBefore.Equal(end) {
+		t.Errorf("time range = %v..%v, want %v..%v", opts.CreatedAfter, opts.CreatedBefore, start, end)
+	}
+	if opts.Limit != maxAuditLogLimit {
+		t.Errorf("Limit = %d, want %d", opts.Limit, maxAuditLogLimit)
+	}
+
+	_, err = auditLogOptionsFromRequest(&adminpb.ListAuditLogRequest{
+		StartTime: timestamppb.New(end),
+		EndTime:   timestamppb.New(start),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("reversed range code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestMoveGuildContent_RequiresConfirmation(t *testing.T) {
+	ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+		UserID: "u1",
+		Role:   "admin",
+	})
+
+	_, err := NewAdminHandler(nil, nil, nil, nil, nil, nil).MoveGuildContent(ctx, &adminpb.MoveGuildContentRequest{
+		SourceGuildId:        "g1",
+		TargetGuildId:        "g2",
+		ConfirmSourceGuildId: "g1",
+		ConfirmTargetGuildId: "g3",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("MoveGuildContent() code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestMoveGuildContent_RequiresAdmin(t *testing.T) {
+	ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+		UserID: "u1",
+		Role:   "user",
+	})
+
+	_, err := NewAdminHandler(nil, nil, nil, nil, nil, nil).MoveGuildContent(ctx, &adminpb.MoveGuildContentRequest{DryRun: true})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("MoveGuildContent() code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestReassignAuthor_RequiresAdmin(t *testing.T) {
+	tests := []struct {
+		role string
+		req  *adminpb.ReassignAuthorRequest
+		want codes.Code
+	}{
+		{role: "user", req: &adminpb.ReassignAuthorRequest{ContentType: "quote", Id: "q1", AuthorId: "u2"}, want: codes.PermissionDenied},
+		{role: "admin", req: &adminpb.ReassignAuthorRequest{ContentType: "quote", Id: "q1"}, want: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+			UserID: "u1",
+			Role:   tt.role,
+		})
+		_, err := NewAdminHandler(nil, nil, nil, nil, nil, nil).ReassignAuthor(ctx, tt.req)
+		if status.Code(err) != tt.want {
+			t.Errorf("ReassignAuthor() as %s code = %v, want %v", tt.role, status.Code(err), tt.want)
+		}
+	}
+}
+
+func TestListFlaggedContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        string
+		contentType string
+		wantCode    codes.Code
+	}{
+		{name: "not an admin", role: "user", wantCode: codes.PermissionDenied},
+		{name: "unknown content type", role: "admin", contentType: "snippet", wantCode: codes.InvalidArgument},
+		// Without a moderation repository there is nothing flagged
+		{name: "all content", role: "admin", wantCode: codes.OK},
+		{name: "one content type", role: "admin", contentType: "quote", wantCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+				UserID: "u1",
+				Role:   tt.role,
+			})
+
+			resp, err := NewAdminHandler(nil, nil, nil, nil, nil, nil).ListFlaggedContent(ctx, &adminpb.ListFlaggedContentRequest{ContentType: tt.contentType})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("ListFlaggedContent() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if err == nil && (len(resp.Items) != 0 || resp.Total != 0) {
+				t.Errorf("ListFlaggedContent() = %v, want nothing flagged", resp)
+			}
+		})
+	}
+}
+
+func TestSetReadOnlyMode(t *testing.T) {
+	mode := interceptors.NewReadOnlyMode(false)
+	h := NewAdminHandler(nil, nil, nil, nil, nil, mode)
+
+	userCtx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{UserID: "u1", Role: "user"})
+	if _, err := h.SetReadOnlyMode(userCtx, &adminpb.SetReadOnlyModeRequest{ReadOnly: true}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("SetReadOnlyMode() as user code = %v, want PermissionDenied", status.Code(err))
+	}
+	if mode.Enabled() {
+		t.Fatal("non-admin turned on read-only mode")
+	}
+
+	adminCtx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{UserID: "a1", Role: "admin"})
+	resp, err := h.SetReadOnlyMode(adminCtx, &adminpb.SetReadOnlyModeRequest{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("SetReadOnlyMode() error = %v", err)
+	}
+	if !resp.ReadOnly || !mode.Enabled() {
+		t.Errorf("read-only = %v (switch %v), want on", resp.ReadOnly, mode.Enabled())
+	}
+	if got, err := h.GetReadOnlyMode(adminCtx, &emptypb.Empty{}); err != nil || !got.ReadOnly {
+		t.Errorf("GetReadOnlyMode() = %v, %v, want on", got, err)
+	}
+}
+
+type discardAuditRepo struct {
+	repositories.AuditRepository
+}
+
+func (discardAuditRepo) Create(ctx context.Context, log *entities.AuditLog) error {
+	return nil
+}
+
+func TestSetUserActive(t *testing.T) {
+	const method = "/hivemind.user.v1.UserService/GetProfile"
+
+	jwtManager := auth.NewJWTManager("test-secret", time.Hour)
+	expiresAt := time.Now().Add(time.Hour)
+	userRepo := &fakeUserRepo{users: map[string]*entities.User{
+		"admin-1": {ID: "admin-1", Role: entities.RoleAdmin, IsActive: true},
+		"user-1":  {ID: "user-1", Role: entities.RoleUser, IsActive: true},
+	}}
+	tokenRepo := &fakeTokenRepo{tokens: map[string]*entities.APIToken{
+		"tok-admin": {ID: "tok-admin", UserID: "admin-1", ExpiresAt: expiresAt},
+		"tok-user":  {ID: "tok-user", UserID: "user-1", ExpiresAt: expiresAt},
+	}}
+	h := NewAdminHandler(
+		services.NewUserService(userRepo, nil),
+		services.NewTokenService(tokenRepo, userRepo, discardAuditRepo{}),
+		nil, nil, nil, nil,
+	)
+	interceptor := interceptors.NewAuthInterceptor(jwtManager, tokenRepo, userRepo, nil, "")
+
+	// call runs a request as the token's user through the auth interceptor
+	call := func(userID, role, tokenID string) error {
+		token, _, err := jwtManager.GenerateToken(userID, userID, role, tokenID)
+		if err != nil {
+			t.Fatalf("GenerateToken() error = %v", err)
+		}
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+		_, err = interceptor.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+		return err
+	}
+	adminCtx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{UserID: "admin-1", Role: "admin"})
+	setActive := func(req *adminpb.SetUserActiveRequest) (*adminpb.SetUserActiveResponse, error) {
+		return h.SetUserActive(adminCtx, req)
+	}
+
+	userCtx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{UserID: "user-1", Role: "user"})
+	if _, err := h.SetUserActive(userCtx, &adminpb.SetUserActiveRequest{UserId: "admin-1"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("SetUserActive() as user code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := setActive(&adminpb.SetUserActiveRequest{UserId: "admin-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("self deactivation code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if _, err := setActive(&adminpb.SetUserActiveRequest{UserId: "nobody"}); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown user code = %v, want NotFound", status.Code(err))
+	}
+
+	if _, err := setActive(&adminpb.SetUserActiveRequest{UserId: "user-1"}); err != nil {
+		t.Fatalf("deactivate error = %v", err)
+	}
+	if err := call("user-1", "user", "tok-user"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("RPC after deactivation code = %v, want PermissionDenied", status.Code(err))
+	}
+	if err := call("admin-1", "admin", "tok-admin"); err != nil {
+		t.Errorf("admin RPC error = %v, want other users unaffected", err)
+	}
+
+	if _, err := setActive(&adminpb.SetUserActiveRequest{UserId: "user-1", Active: true}); err != nil {
+		t.Fatalf("reactivate error = %v", err)
+	}
+	if err := call("user-1", "user", "tok-user"); err != nil {
+		t.Errorf("RPC after reactivation error = %v, want access restored", err)
+	}
+
+	resp, err := setActive(&adminpb.SetUserActiveRequest{UserId: "user-1", RevokeTokens: true})
+	if err != nil {
+		t.Fatalf("deactivate with revoke error = %v", err)
+	}
+	if !resp.TokensRevoked || tokenRepo.tokens["tok-user"].RevokedAt == nil {
+		t.Errorf("tokens revoked = %v, want the user's token revoked", resp.TokensRevoked)
+	}
+	if tokenRepo.tokens["tok-admin"].RevokedAt != nil {
+		t.Error("admin token revoked, want only the deactivated user's tokens")
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	userRepo := &fakeUserRepo{users: map[string]*entities.User{
+		"admin-1": {ID: "admin-1", Role: entities.RoleAdmin, IsActive: true},
+		"user-1":  {ID: "user-1", Role: entities.RoleUser, IsActive: true},
+	}}
+	h := NewAdminHandler(services.NewUserService(userRepo, nil), nil, nil, nil, nil, nil)
+	adminCtx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{UserID: "admin-1", Role: "admin"})
+	userCtx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{UserID: "user-1", Role: "user"})
+
+	if _, err := h.DeleteUser(userCtx, &adminpb.DeleteUserRequest{UserId: "admin-1"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("DeleteUser() as user code = %v, want PermissionDenied", status.Code(err))
+	}
+	if userRepo.disabled["admin-1"] {
+		t.Fatal("non-admin deactivated an admin")
+	}
+	if _, err := h.DeleteUser(adminCtx, &adminpb.DeleteUserRequest{UserId: "admin-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("self deletion code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if _, err := h.DeleteUser(adminCtx, &adminpb.DeleteUserRequest{UserId: "nobody"}); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown user code = %v, want NotFound", status.Code(err))
+	}
+
+	if _, err := h.DeleteUser(adminCtx, &adminpb.DeleteUserRequest{UserId: "user-1"}); err != nil {
+		t.Fatalf("DeleteUser() error = %v", err)
+	}
+	if !userRepo.disabled["user-1"] {
+		t.Error("user still active after DeleteUser")
+	}
+}
+
+func TestUserListOptionsFromRequest(t *testing.T) {
+	cursor := &repositories.PageCursor{Order: "users:created_at:desc", Keys: []string{"2024-01-01 00:00:00"}, ID: "u1"}
+
+	tests := []struct {
+		name    string
+		req     *adminpb.ListUsersRequest
+		check   func(t *testing.T, opts repositories.ListUsersOptions)
+		wantErr codes.Code
+	}{
+		{
+			name: "defaults",
+			req:  &adminpb.ListUsersRequest{},
+			check: func(t *testing.T, opts repositories.ListUsersOptions) {
+				if opts.Limit != defaultUserListLimit || opts.Role != nil || opts.UserType != nil || opts.IsActive != nil || opts.Search != "" || opts.Cursor != nil {
+					t.Errorf("opts = %+v, want only the default limit", opts)
+				}
+			},
+		},
+		{
+			name: "page size clamped",
+			req:  &adminpb.ListUsersRequest{PageSize: 1000},
+			check: func(t *testing.T, opts repositories.ListUsersOptions) {
+				if opts.Limit != maxUserListLimit {
+					t.Errorf("Limit = %d, want %d", opts.Limit, maxUserListLimit)
+				}
+			},
+		},
+		{
+			name: "role",
+			req:  &adminpb.ListUsersRequest{Role: userpb.Role_ROLE_ADMIN},
+			check: func(t *testing.T, opts repositories.ListUsersOptions) {
+				if opts.Role == nil || *opts.Role != entities.RoleAdmin {
+					t.Errorf("Role = %v, want admin", opts.Role)
+				}
+			},
+		},
+		{
+			name: "user type",
+			req:  &adminpb.ListUsersRequest{UserType: userpb.UserType_USER_TYPE_LOCAL},
+			check: func(t *testing.T, opts repositories.ListUsersOptions) {
+				if opts.UserType == nil || *opts.UserType != entities.UserTypeLocal {
+					t.Errorf("UserType = %v, want local", opts.UserType)
+				}
+			},
+		},
+		{
+			name: "active",
+			req:  &adminpb.ListUsersRequest{Status: "active"},
+			check: func(t *testing.T, opts repositories.ListUsersOptions) {
+				if opts.IsActive == nil || !*opts.IsActive {
+					t.Errorf("IsActive = %v, want true", opts.IsActive)
+				}
+			},
+		},
+		{
+			name: "inactive",
+			req:  &adminpb.ListUsersRequest{Status: "inactive"},
+			check: func(t *testing.T, opts repositories.ListUsersOptions) {
+				if opts.IsActive == nil || *opts.IsActive {
+					t.Errorf("IsActive = %v, want false", opts.IsActive)
+				}
+			},
+		},
+		{
+			name: "search",
+			req:  &adminpb.ListUsersRequest{Search: "  ada@ "},
+			check: func(t *testing.T, opts repositories.ListUsersOptions) {
+				if opts.Search != "ada@" {
+					t.Errorf("Search = %q, want ada@", opts.Search)
+				}
+			},
+		},
+		{
+			name: "page token",
+			req:  &adminpb.ListUsersRequest{PageToken: cursor.Token()},
+			check: func(t *testing.T, opts repositories.ListUsersOptions) {
+				if opts.Cursor == nil || opts.Cursor.ID != "u1" || opts.Cursor.Order != cursor.Order {
+					t.Errorf("Cursor = %+v, want %+v", opts.Cursor, cursor)
+				}
+			},
+		},
+		{name: "unknown status", req: &adminpb.ListUsersRequest{Status: "banned"}, wantErr: codes.InvalidArgument},
+		{name: "unknown role", req: &adminpb.ListUsersRequest{Role: userpb.Role(99)}, wantErr: codes.InvalidArgument},
+		{name: "bad page token", req: &adminpb.ListUsersRequest{PageToken: "not a token!"}, wantErr: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := userListOptionsFromRequest(tt.req)
+			if status.Code(err) != tt.wantErr {
+				t.Fatalf("userListOptionsFromRequest() error = %v, want code %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, opts)
+			}
+		})
+	}
+}
+
+// pagedUserRepo returns canned pages and records the options it was listed with
+type pagedUserRepo struct {
+	repositories.UserRepository
+	users []*entities.User
+	next  *repositories.PageCursor
+	opts  repositories.ListUsersOptions
+}
+
+func (r *pagedUserRepo) List(ctx context.Context, opts repositories.ListUsersOptions) ([]*entities.User, int64, *repositories.PageCursor, error) {
+	r.opts = opts
+	return r.users, 7, r.next, nil
+}
+
+func TestListUsers(t *testing.T) {
+	lastLogin := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	next := &repositories.PageCursor{Order: "users:created_at:desc", Keys: []string{"2024-01-01 00:00:00"}, ID: "u2"}
+	repo := &pagedUserRepo{
+		users: []*entities.User{
+			{ID: "u1", Email: "ada@example.com", DisplayName: "Ada", Role: entities.RoleAdmin, UserType: entities.UserTypeOIDC, IsActive: true, LastLogin: &lastLogin},
+			{ID: "u2", Email: "grace@example.com", DisplayName: "Grace", Role: entities.RoleUser, UserType: entities.UserTypeLocal},
+		},
+		next: next,
+	}
+	h := NewAdminHandler(services.NewUserService(repo, nil), nil, nil, nil, nil, nil)
+
+	userCtx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{UserID: "u1", Role: "user"})
+	if _, err := h.ListUsers(userCtx, &adminpb.ListUsersRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ListUsers() as user code = %v, want PermissionDenied", status.Code(err))
+	}
+
+	adminCtx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{UserID: "a1", Role: "admin"})
+	resp, err := h.ListUsers(adminCtx, &adminpb.ListUsersRequest{PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListUsers() error = %v", err)
+	}
+	if resp.TotalCount != 7 || len(resp.Users) != 2 {
+		t.Fatalf("got %d users of %d, want 2 of 7", len(resp.Users), resp.TotalCount)
+	}
+	ada, grace := resp.Users[0], resp.Users[1]
+	if ada.UserId != "u1" || ada.Email != "ada@example.com" || ada.DisplayName != "Ada" || ada.Role != userpb.Role_ROLE_ADMIN ||
+		ada.UserType != userpb.UserType_USER_TYPE_OIDC || !ada.Active || !ada.LastLogin.AsTime().Equal(lastLogin) {
+		t.Errorf("first user = %+v", ada)
+	}
+	if grace.Role != userpb.Role_ROLE_USER || grace.Active || grace.LastLogin != nil {
+		t.Errorf("second user = %+v, want an inactive user who never signed in", grace)
+	}
+
+	// The next page token continues from the cursor the repository returned
+	if _, err := h.ListUsers(adminCtx, &adminpb.ListUsersRequest{PageSize: 2, PageToken: resp.NextPageToken}); err != nil {
+		t.Fatalf("ListUsers() next page error = %v", err)
+	}
+	if repo.opts.Cursor == nil || repo.opts.Cursor.ID != "u2" || repo.opts.Limit != 2 {
+		t.Errorf("next page opts = %+v, want the cursor after u2", repo.opts)
+	}
+
+	repo.next = nil
+	if resp, err := h.ListUsers(adminCtx, &adminpb.ListUsersRequest{}); err != nil || resp.NextPageToken != "" {
+		t.Errorf("last page token = %q, %v; want none", resp.GetNextPageToken(), err)
+	}
+}
@@ -22,6 +22,9 @@ import (
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
 	"github.com/devilmonastery/hivemind/internal/domain/repositories"
 	"github.com/devilmonastery/hivemind/internal/pkg/idgen"
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
+	"github.com/devilmonastery/hivemind/internal/pkg/urlutil"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
 )
 
 var (
@@ -83,17 +86,7 @@ func (s *AuthHandler) GetOAuthConfig(
 			continue
 		}
 
-		// Build authorization URL with placeholders for dynamic values
-		// The web UI and CLI will substitute {redirect_uri}, {state}, {code_challenge}
-		// We manually construct the query string to avoid encoding the placeholder braces
-
-		// Manually build query string with proper encoding for static values only
-		authURL := fmt.Sprintf(
-			"%s?client_id=%s&redirect_uri={redirect_uri}&response_type=code&scope=%s&state={state}&code_challenge={code_challenge}&code_challenge_method=S256&prompt=consent",
-			discovery.AuthorizationEndpoint,
-			url.QueryEscape(providerConfig.ClientID),
-			url.QueryEscape(strings.Join(providerConfig.Scopes, " ")),
-		)
+		authURL := buildAuthorizationURL(discovery.AuthorizationEndpoint, providerConfig, s.log)
 
 		providers = append(providers, &authpb.OAuthProvider{
 			Name:             providerConfig.Name,
@@ -107,35 +100,104 @@ func (s *AuthHandler) GetOAuthConfig(
 	}, nil
 }
 
-// ExchangeAuthCode exchanges an authorization code for tokens server-side
-func (s *AuthHandler) ExchangeAuthCode(
-	ctx context.Context,
-	req *authpb.ExchangeAuthCodeRequest,
-) (*authpb.ExchangeAuthCodeResponse, error) {
+// reservedAuthParams are authorization URL parameters owned by the login flow itself
+var reservedAuthParams = map[string]bool{
+	"client_id":             true,
+	"redirect_uri":          true,
+	"response_type":         true,
+	"scope":                 true,
+	"state":                 true,
+	"code_challenge":        true,
+	"code_challenge_method": true,
+}
+
+// buildAuthorizationURL builds a provider's authorization URL with placeholders for dynamic values.
+// The web UI and CLI substitute {redirect_uri}, {state}, and {code_challenge}, so those are appended
+// verbatim; everything else is URL-encoded.
+func buildAuthorizationURL(endpoint string, providerConfig config.ProviderConfig, log *slog.Logger) string {
+	extra := url.Values{}
+
+	prompt := providerConfig.Prompt
+	if prompt == "" {
+		prompt = config.DefaultOAuthPrompt
+	}
+	extra.Set("prompt", prompt)
+
+	for key, value := range providerConfig.AuthParams {
+		if reservedAuthParams[key] {
+			log.Warn("ignoring reserved auth param",
+				slog.String("provider", providerConfig.Name),
+				slog.String("param", key))
+			continue
+		}
+		if value == "" {
+			extra.Del(key)
+			continue
+		}
+		extra.Set(key, value)
+	}
+
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+
+	authURL := fmt.Sprintf(
+		"%s%sclient_id=%s&redirect_uri={redirect_uri}&response_type=code&scope=%s&state={state}&code_challenge={code_challenge}&code_challenge_method=S256",
+		endpoint,
+		separator,
+		url.QueryEscape(providerConfig.ClientID),
+		url.QueryEscape(strings.Join(providerConfig.Scopes, " ")),
+	)
+	if len(extra) > 0 {
+		authURL += "&" + extra.Encode()
+	}
+	return authURL
+}
+
+// checkEmailDomain enforces the provider's allowed_domains. When any are configured,
+// the user's email must be verified and its domain must match one of them, exactly
+// or, with allow_subdomains, as a subdomain. An empty list allows every domain.
+func checkEmailDomain(providerConfig *config.ProviderConfig, claims *oidc.Claims) error {
+	if len(providerConfig.AllowedDomains) == 0 {
+		return nil
+	}
+	if !claims.EmailVerified || claims.Email == "" {
+		return status.Error(codes.PermissionDenied, "a verified email is required to sign in with this provider")
+	}
+	if !oidc.EmailDomainAllowed(claims.Email, providerConfig.AllowedDomains, providerConfig.AllowSubdomains) {
+		return status.Error(codes.PermissionDenied, "email domain is not allowed for this provider")
+	}
+	return nil
+}
+
+// exchangeCodeForClaims exchanges an authorization code with a configured provider and
+// returns the validated ID token claims. Errors are gRPC statuses.
+func (s *AuthHandler) exchangeCodeForClaims(ctx context.Context, providerName, code, codeVerifier, redirectURI string) (*oidc.Claims, *config.ProviderConfig, error) {
 	// Find provider config
 	var providerConfig *config.ProviderConfig
 	for _, pc := range s.config.Auth.Providers {
-		if pc.Name == req.Provider {
+		if pc.Name == providerName {
 			providerConfig = &pc
 			break
 		}
 	}
 
 	if providerConfig == nil {
-		return nil, status.Errorf(codes.NotFound, "provider %s not configured", req.Provider)
+		return nil, nil, status.Errorf(codes.NotFound, "provider %s not configured", providerName)
 	}
 
 	// Get OIDC discovery document for the provider
 	discovery, err := oidc.GetDiscoveryForProvider(ctx, providerConfig.Issuer)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get OIDC discovery: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "failed to get OIDC discovery: %v", err)
 	}
 
 	// Build OAuth2 config from discovered endpoints
 	oauth2Config := &oauth2.Config{
 		ClientID:     providerConfig.ClientID,
 		ClientSecret: providerConfig.ClientSecret,
-		RedirectURL:  req.RedirectUri,
+		RedirectURL:  redirectURI,
 		Endpoint: oauth2.Endpoint{
 			AuthURL:  discovery.AuthorizationEndpoint,
 			TokenURL: discovery.TokenEndpoint,
@@ -144,26 +206,38 @@ func (s *AuthHandler) ExchangeAuthCode(
 	}
 
 	// Exchange code for token (with PKCE verifier)
-	token, err := oauth2Config.Exchange(ctx, req.Code, oauth2.VerifierOption(req.CodeVerifier))
+	token, err := oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "failed to exchange code: %v", err)
+		return nil, nil, status.Errorf(codes.Unauthenticated, "failed to exchange code: %v", err)
 	}
 
 	// Extract ID token
 	idToken, ok := token.Extra("id_token").(string)
 	if !ok || idToken == "" {
-		return nil, status.Error(codes.Internal, "no ID token in response")
+		return nil, nil, status.Error(codes.Internal, "no ID token in response")
 	}
 
 	// Validate ID token using OIDC provider
-	provider, err := oidc.GetProvider(req.Provider)
+	provider, err := oidc.GetProvider(providerName)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "OIDC provider %s not registered: %v", req.Provider, err)
+		return nil, nil, status.Errorf(codes.Internal, "OIDC provider %s not registered: %v", providerName, err)
 	}
 
 	claims, err := provider.ValidateIDToken(ctx, idToken, token.AccessToken, *providerConfig)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "invalid ID token: %v", err)
+		return nil, nil, status.Errorf(codes.Unauthenticated, "invalid ID token: %v", err)
+	}
+	return claims, providerConfig, nil
+}
+
+// ExchangeAuthCode exchanges an authorization code for tokens server-side
+func (s *AuthHandler) ExchangeAuthCode(
+	ctx context.Context,
+	req *authpb.ExchangeAuthCodeRequest,
+) (*authpb.ExchangeAuthCodeResponse, error) {
+	claims, providerConfig, err := s.exchangeCodeForClaims(ctx, req.Provider, req.Code, req.CodeVerifier, req.RedirectUri)
+	if err != nil {
+		return nil, err
 	}
 
 	// Debug: Log claims
@@ -177,10 +251,8 @@ func (s *AuthHandler) ExchangeAuthCode(
 		return nil, status.Error(codes.PermissionDenied, "email not verified")
 	}
 
-	// Check domain allowlist (domain checking is done in ValidateIDToken for Google)
-	// But we'll keep this as a backup
-	if len(providerConfig.AllowedDomains) > 0 && !claims.EmailVerified {
-		return nil, status.Error(codes.PermissionDenied, "email verification required for domain restrictions")
+	if err := checkEmailDomain(providerConfig, claims); err != nil {
+		return nil, err
 	}
 
 	// Discord-only user provisioning flow
@@ -716,7 +788,7 @@ func (s *AuthHandler) RefreshToken(
 		// Get OIDC session with refresh token
 		oidcSession, err := s.sessionRepo.GetOIDCSessionByUserAndProvider(ctx, user.ID, provider)
 		if err != nil || oidcSession == nil || oidcSession.RefreshToken == nil {
-			return nil, status.Error(codes.Unauthenticated, "no refresh token available - please login again")
+			return nil, rpcerr.New(codes.Unauthenticated, rpcerr.ReasonReauthRequired, "no refresh token available - please login again")
 		}
 
 		// Get provider config
@@ -763,6 +835,16 @@ func (s *AuthHandler) RefreshToken(
 				slog.String("user_id", user.ID),
 				slog.String("provider", provider),
 				slog.String("error", err.Error()))
+			if isInvalidGrant(err) {
+				// The provider revoked or expired the refresh token, so drop the session
+				// rather than retrying a refresh that can never succeed
+				if err := s.sessionRepo.DeleteOIDCSession(ctx, oidcSession.ID); err != nil {
+					s.log.Warn("failed to delete invalid OIDC session",
+						slog.String("session_id", oidcSession.ID),
+						slog.String("error", err.Error()))
+				}
+				return nil, rpcerr.New(codes.Unauthenticated, rpcerr.ReasonReauthRequired, "refresh token was rejected - please login again")
+			}
 			return nil, status.Error(codes.Unauthenticated, "failed to refresh OAuth token - please login again")
 		}
 
@@ -828,6 +910,14 @@ func (s *AuthHandler) RefreshToken(
 	}, nil
 }
 
+// isInvalidGrant reports whether a refresh token exchange failed because the provider
+// no longer accepts the refresh token (RFC 6749 invalid_grant), rather than for a
+// reason a later retry could get past
+func isInvalidGrant(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	return errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_grant"
+}
+
 // RevokeToken handles token revocation
 func (s *AuthHandler) RevokeToken(
 	ctx context.Context,
@@ -886,6 +976,10 @@ func (s *AuthHandler) LoginWithOIDC(
 		return nil, status.Error(codes.PermissionDenied, "email not verified by provider")
 	}
 
+	if err := checkEmailDomain(providerConfig, claims); err != nil {
+		return nil, err
+	}
+
 	// Check if provider config allows auto-provisioning
 	if !providerConfig.AutoProvision {
 		return nil, status.Error(codes.PermissionDenied, "auto-provisioning is disabled for this provider")
@@ -1116,3 +1210,171 @@ func (s *AuthHandler) LoginWithOIDC(
 		IsNewUser: isNewUser,
 	}, nil
 }
+
+// GetCurrentUser returns the authenticated caller's profile.
+// The user is always taken from the auth interceptor context so callers can't look up anyone else.
+func (s *AuthHandler) GetCurrentUser(
+	ctx context.Context,
+	req *authpb.GetCurrentUserRequest,
+) (*authpb.GetCurrentUserResponse, error) {
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userCtx.UserID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) || errors.Is(err, repositories.ErrUserInactive) {
+			return nil, status.Error(codes.Unauthenticated, "user no longer exists or is disabled")
+		}
+		s.log.Error("failed to load current user",
+			slog.String("user_id", userCtx.UserID),
+			slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to load user")
+	}
+
+	protoUser := &userpb.User{
+		UserId:    user.ID,
+		Email:     user.Email,
+		Name:      user.DisplayName,
+		Role:      userpb.Role(userpb.Role_value["ROLE_"+strings.ToUpper(string(user.Role))]),
+		UserType:  userpb.UserType(userpb.UserType_value["USER_TYPE_"+strings.ToUpper(string(user.UserType))]),
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		Disabled:  !user.IsActive,
+		Provider:  "local",
+	}
+	if user.OIDCProvider != nil {
+		protoUser.Provider = *user.OIDCProvider
+	}
+	if user.LastLogin != nil {
+		protoUser.LastSeen = timestamppb.New(*user.LastLogin)
+	}
+
+	resp := &authpb.GetCurrentUserResponse{
+		User:      protoUser,
+		AvatarUrl: stringPtrValue(user.AvatarURL),
+		Timezone:  stringPtrValue(user.Timezone),
+		TokenId:   userCtx.TokenID,
+	}
+
+	discordUser, err := s.discordUserRepo.GetByUserID(ctx, user.ID)
+	switch {
+	case err == nil && discordUser != nil:
+		resp.Discord = linkedDiscordAccountToProto(discordUser)
+	case err != nil && !errors.Is(err, repositories.ErrDiscordUserNotFound):
+		// The profile is still useful without Discord details
+		s.log.Warn("failed to load linked discord account",
+			slog.String("user_id", user.ID),
+			slog.String("error", err.Error()))
+	}
+
+	if userCtx.TokenID != "" {
+		token, err := s.tokenRepo.GetByID(ctx, userCtx.TokenID)
+		if err != nil {
+			s.log.Warn("failed to load current token",
+				slog.String("token_id", userCtx.TokenID),
+				slog.String("error", err.Error()))
+		} else if token != nil {
+			resp.TokenExpiresAt = timestamppb.New(token.ExpiresAt)
+		}
+	}
+
+	return resp, nil
+}
+
+// linkedDiscordAccountToProto converts a linked discord_users record for the caller's profile
+func linkedDiscordAccountToProto(discordUser *entities.DiscordUser) *authpb.LinkedDiscordAccount {
+	return &authpb.LinkedDiscordAccount{
+		DiscordId:  discordUser.DiscordID,
+		Username:   discordUser.DiscordUsername,
+		GlobalName: stringPtrValue(discordUser.DiscordGlobalName),
+		AvatarUrl:  urlutil.ConstructAvatarURL(discordUser.DiscordID, "", "", stringPtrValue(discordUser.AvatarHash), 128),
+		LinkedAt:   timestamppb.New(discordUser.LinkedAt),
+	}
+}
+
+// LinkDiscordAccount links the Discord account that completed an authorization code flow
+// to the caller, for users such as local admins who didn't sign in with Discord.
+// A Discord account linked to someone else is rejected with AlreadyExists.
+func (s *AuthHandler) LinkDiscordAccount(
+	ctx context.Context,
+	req *authpb.LinkDiscordAccountRequest,
+) (*authpb.LinkDiscordAccountResponse, error) {
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	// Bot requests act for a Discord user, who is linked already
+	if userCtx.DiscordID != "" || userCtx.Role == interceptors.RoleBot || userCtx.Role == "service_account" {
+		return nil, status.Error(codes.PermissionDenied, "discord accounts can only be linked by signed-in users")
+	}
+	if req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+
+	claims, _, err := s.exchangeCodeForClaims(ctx, "discord", req.Code, req.CodeVerifier, req.RedirectUri)
+	if err != nil {
+		return nil, err
+	}
+
+	discordUser, err := s.linkDiscordIdentity(ctx, userCtx.UserID, claims)
+	if err != nil {
+		return nil, err
+	}
+	return &authpb.LinkDiscordAccountResponse{Discord: linkedDiscordAccountToProto(discordUser)}, nil
+}
+
+// linkDiscordIdentity points the discord_users record for claims' Discord account at
+// userID, creating it if the bot has never seen the account. Errors are gRPC statuses.
+func (s *AuthHandler) linkDiscordIdentity(ctx context.Context, userID string, claims *oidc.Claims) (*entities.DiscordUser, error) {
+	log := s.log.With(
+		slog.String("flow", "link_discord"),
+		slog.String("user_id", userID),
+		slog.String("discord_id", claims.Subject),
+	)
+
+	current, err := s.discordUserRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, repositories.ErrDiscordUserNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to get linked discord account: %v", err)
+	}
+	if err == nil && current != nil && current.DiscordID != claims.Subject {
+		return nil, status.Error(codes.FailedPrecondition, "a different discord account is already linked")
+	}
+
+	discordUser, err := s.discordUserRepo.GetByDiscordID(ctx, claims.Subject)
+	if err != nil && !errors.Is(err, repositories.ErrDiscordUserNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to get discord user: %v", err)
+	}
+
+	now := time.Now()
+	switch {
+	case discordUser == nil:
+		discordUser = &entities.DiscordUser{
+			DiscordID:       claims.Subject,
+			UserID:          &userID,
+			DiscordUsername: claims.Name,
+			LinkedAt:        now,
+			LastSeen:        &now,
+		}
+		if err := s.discordUserRepo.Create(ctx, discordUser); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create discord user: %v", err)
+		}
+	case discordUser.UserID != nil && *discordUser.UserID == userID:
+		log.Info("discord account already linked to this user")
+		return discordUser, nil
+	case discordUser.UserID != nil:
+		log.Warn("rejecting discord account linked to another user",
+			slog.String("linked_user_id", *discordUser.UserID))
+		return nil, status.Error(codes.AlreadyExists, "discord account is already linked to another user")
+	default:
+		// Seen by the bot but never signed in to the web
+		discordUser.UserID = &userID
+		discordUser.LastSeen = &now
+		if err := s.discordUserRepo.Update(ctx, discordUser); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to link discord user: %v", err)
+		}
+	}
+
+	log.Info("discord account linked")
+	return discordUser, nil
+}
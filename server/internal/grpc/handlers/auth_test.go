@@ -0,0 +1,542 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	authpb "github.com/devilmonastery/hivemind/api/generated/go/authpb"
+	userpb "github.com/devilmonastery/hivemind/api/generated/go/userpb"
+	"github.com/devilmonastery/hivemind/internal/auth"
+	"github.com/devilmonastery/hivemind/internal/auth/oidc"
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+)
+
+func TestBuildAuthorizationURL(t *testing.T) {
+	const endpoint = "https://idp.example.com/authorize"
+	const placeholders = "redirect_uri={redirect_uri}&response_type=code"
+
+	tests := []struct {
+		name       string
+		provider   config.ProviderConfig
+		wantSuffix string
+		notContain []string
+	}{
+		{
+			name:       "default prompt",
+			provider:   config.ProviderConfig{ClientID: "abc", Scopes: []string{"openid", "email"}},
+			wantSuffix: "&code_challenge_method=S256&prompt=consent",
+		},
+		{
+			name:       "configured prompt",
+			provider:   config.ProviderConfig{ClientID: "abc", Prompt: "select_account"},
+			wantSuffix: "&prompt=select_account",
+		},
+		{
+			name: "custom params are encoded",
+			provider: config.ProviderConfig{
+				ClientID: "abc",
+				AuthParams: map[string]string{
+					"access_type": "offline",
+					"login_hint":  "user+tag@example.com",
+					"claims":      `{"id_token":{"email":null}}`,
+				},
+			},
+			wantSuffix: "&access_type=offline" +
+				"&claims=%7B%22id_token%22%3A%7B%22email%22%3Anull%7D%7D" +
+				"&login_hint=user%2Btag%40example.com" +
+				"&prompt=consent",
+		},
+		{
+			name:       "auth params override prompt",
+			provider:   config.ProviderConfig{ClientID: "abc", Prompt: "login", AuthParams: map[string]string{"prompt": "none"}},
+			wantSuffix: "&prompt=none",
+		},
+		{
+			name:       "empty auth param drops prompt",
+			provider:   config.ProviderConfig{ClientID: "abc", AuthParams: map[string]string{"prompt": ""}},
+			wantSuffix: "&code_challenge_method=S256",
+			notContain: []string{"prompt="},
+		},
+		{
+			name:       "reserved params are ignored",
+			provider:   config.ProviderConfig{ClientID: "abc", AuthParams: map[string]string{"state": "fixed", "redirect_uri": "https://evil.example.com"}},
+			wantSuffix: "&prompt=consent",
+			notContain: []string{"state=fixed", "evil.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildAuthorizationURL(endpoint, tt.provider, slog.Default())
+
+			if !strings.HasPrefix(got, endpoint+"?client_id=abc&") {
+				t.Errorf("URL %q doesn't start with the endpoint and client_id", got)
+			}
+			// Placeholders must stay unencoded for the web UI and CLI to substitute
+			for _, p := range []string{placeholders, "state={state}", "code_challenge={code_challenge}"} {
+				if !strings.Contains(got, p) {
+					t.Errorf("URL %q is missing %q", got, p)
+				}
+			}
+			if !strings.HasSuffix(got, tt.wantSuffix) {
+				t.Errorf("URL %q doesn't end with %q", got, tt.wantSuffix)
+			}
+			for _, s := range tt.notContain {
+				if strings.Contains(got, s) {
+					t.Errorf("URL %q shouldn't contain %q", got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildAuthorizationURL_EndpointWithQuery(t *testing.T) {
+	got := buildAuthorizationURL("https://idp.example.com/authorize?tenant=x", config.ProviderConfig{ClientID: "abc"}, slog.Default())
+	if !strings.HasPrefix(got, "https://idp.example.com/authorize?tenant=x&client_id=abc&") {
+		t.Errorf("URL %q should extend the endpoint's existing query", got)
+	}
+}
+
+type fakeUserRepo struct {
+	repositories.UserRepository
+	users    map[string]*entities.User
+	disabled map[string]bool
+}
+
+// GetByID refuses disabled users like the postgres repository does
+func (r *fakeUserRepo) GetByID(ctx context.Context, id string) (*entities.User, error) {
+	if r.disabled[id] {
+		return nil, repositories.ErrUserInactive
+	}
+	if u, ok := r.users[id]; ok {
+		return u, nil
+	}
+	return nil, repositories.ErrUserNotFound
+}
+
+func (r *fakeUserRepo) SetActive(ctx context.Context, id string, active bool) error {
+	if _, ok := r.users[id]; !ok {
+		return repositories.ErrUserNotFound
+	}
+	if r.disabled == nil {
+		r.disabled = map[string]bool{}
+	}
+	r.disabled[id] = !active
+	return nil
+}
+
+type fakeTokenRepo struct {
+	repositories.TokenRepository
+	tokens map[string]*entities.APIToken
+}
+
+func (r *fakeTokenRepo) GetByID(ctx context.Context, id string) (*entities.APIToken, error) {
+	if t, ok := r.tokens[id]; ok {
+		return t, nil
+	}
+	return nil, repositories.ErrTokenNotFound
+}
+
+func (r *fakeTokenRepo) RevokeAllForUser(ctx context.Context, userID string) error {
+	now := time.Now()
+	for _, t := range r.tokens {
+		if t.UserID == userID && t.RevokedAt == nil {
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+type fakeDiscordUserRepo struct {
+	repositories.DiscordUserRepository
+	byUserID    map[string]*entities.DiscordUser
+	byDiscordID map[string]*entities.DiscordUser
+}
+
+func (r *fakeDiscordUserRepo) GetByDiscordID(ctx context.Context, discordID string) (*entities.DiscordUser, error) {
+	if du, ok := r.byDiscordID[discordID]; ok {
+		return du, nil
+	}
+	return nil, repositories.ErrDiscordUserNotFound
+}
+
+func (r *fakeDiscordUserRepo) Create(ctx context.Context, du *entities.DiscordUser) error {
+	if r.byDiscordID == nil {
+		r.byDiscordID = map[string]*entities.DiscordUser{}
+	}
+	r.byDiscordID[du.DiscordID] = du
+	return nil
+}
+
+func (r *fakeDiscordUserRepo) Update(ctx context.Context, du *entities.DiscordUser) error {
+	r.byDiscordID[du.DiscordID] = du
+	return nil
+}
+
+func (r *fakeDiscordUserRepo) GetByUserID(ctx context.Context, userID string) (*entities.DiscordUser, error) {
+	if du, ok := r.byUserID[userID]; ok {
+		return du, nil
+	}
+	return nil, repositories.ErrDiscordUserNotFound
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	const method = "/hivemind.auth.v1.AuthService/GetCurrentUser"
+
+	jwtManager := auth.NewJWTManager("test-secret", time.Hour)
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	revokedAt := time.Now()
+	tz := "Europe/Berlin"
+	linkedUserID := "user-1"
+
+	tokenRepo := &fakeTokenRepo{tokens: map[string]*entities.APIToken{
+		"tok-1":       {ID: "tok-1", UserID: "user-1", ExpiresAt: expiresAt},
+		"tok-revoked": {ID: "tok-revoked", UserID: "user-1", ExpiresAt: expiresAt, RevokedAt: &revokedAt},
+	}}
+	h := NewAuthHandler(
+		&fakeUserRepo{users: map[string]*entities.User{
+			"user-1": {ID: "user-1", Email: "ada@example.com", DisplayName: "Ada", Role: entities.RoleAdmin, UserType: entities.UserTypeOIDC, IsActive: true, Timezone: &tz},
+		}},
+		tokenRepo,
+		nil,
+		&fakeDiscordUserRepo{byUserID: map[string]*entities.DiscordUser{
+			"user-1": {DiscordID: "123456789", UserID: &linkedUserID, DiscordUsername: "ada"},
+		}},
+		jwtManager,
+		&config.Config{},
+	)
+	interceptor := interceptors.NewAuthInterceptor(jwtManager, tokenRepo, nil, nil, "")
+
+	call := func(ctx context.Context) (*authpb.GetCurrentUserResponse, error) {
+		resp, err := interceptor.Unary()(ctx, &authpb.GetCurrentUserRequest{}, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return h.GetCurrentUser(ctx, req.(*authpb.GetCurrentUserRequest))
+			})
+		if err != nil {
+			return nil, err
+		}
+		return resp.(*authpb.GetCurrentUserResponse), nil
+	}
+	withToken := func(tokenID string) context.Context {
+		token, _, err := jwtManager.GenerateToken("user-1", "ada", "admin", tokenID)
+		if err != nil {
+			t.Fatalf("GenerateToken() error = %v", err)
+		}
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+
+	t.Run("authenticated", func(t *testing.T) {
+		resp, err := call(withToken("tok-1"))
+		if err != nil {
+			t.Fatalf("GetCurrentUser() error = %v", err)
+		}
+		if resp.User.UserId != "user-1" || resp.User.Email != "ada@example.com" || resp.User.Name != "Ada" {
+			t.Errorf("user = %+v", resp.User)
+		}
+		if resp.User.Role != userpb.Role_ROLE_ADMIN || resp.User.UserType != userpb.UserType_USER_TYPE_OIDC {
+			t.Errorf("role/type = %v/%v, want ROLE_ADMIN/USER_TYPE_OIDC", resp.User.Role, resp.User.UserType)
+		}
+		if resp.Timezone != tz || resp.TokenId != "tok-1" || !resp.TokenExpiresAt.AsTime().Equal(expiresAt) {
+			t.Errorf("timezone/token = %q/%q/%v", resp.Timezone, resp.TokenId, resp.TokenExpiresAt.AsTime())
+		}
+		if resp.Discord == nil || resp.Discord.DiscordId != "123456789" || resp.Discord.Username != "ada" {
+			t.Errorf("discord = %+v", resp.Discord)
+		}
+	})
+
+	rejected := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{name: "no metadata", ctx: context.Background()},
+		{name: "no token", ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs())},
+		{name: "bad token", ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer nope"))},
+		{name: "revoked token", ctx: withToken("tok-revoked")},
+	}
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := call(tt.ctx); status.Code(err) != codes.Unauthenticated {
+				t.Errorf("GetCurrentUser() error = %v, want Unauthenticated", err)
+			}
+		})
+	}
+
+	t.Run("handler without user context", func(t *testing.T) {
+		if _, err := h.GetCurrentUser(context.Background(), &authpb.GetCurrentUserRequest{}); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("GetCurrentUser() error = %v, want Unauthenticated", err)
+		}
+	})
+}
+
+func TestCheckEmailDomain(t *testing.T) {
+	tests := []struct {
+		name            string
+		allowedDomains  []string
+		allowSubdomains bool
+		email           string
+		unverified      bool
+		wantAllowed     bool
+	}{
+		{name: "empty allowlist allows all", email: "ada@anywhere.org", wantAllowed: true},
+		{name: "empty allowlist ignores verification", email: "ada@anywhere.org", unverified: true, wantAllowed: true},
+		{name: "allowed domain", allowedDomains: []string{"example.com"}, email: "ada@example.com", wantAllowed: true},
+		{name: "domain case ignored", allowedDomains: []string{"Example.com"}, email: "ada@EXAMPLE.COM", wantAllowed: true},
+		{name: "disallowed domain", allowedDomains: []string{"example.com"}, email: "ada@evil.com", wantAllowed: false},
+		{name: "lookalike suffix", allowedDomains: []string{"example.com"}, email: "ada@notexample.com", allowSubdomains: true, wantAllowed: false},
+		{name: "subdomain with exact matching", allowedDomains: []string{"example.com"}, email: "ada@eng.example.com", wantAllowed: false},
+		{name: "subdomain with suffix matching", allowedDomains: []string{"example.com"}, email: "ada@eng.example.com", allowSubdomains: true, wantAllowed: true},
+		{name: "unverified email", allowedDomains: []string{"example.com"}, email: "ada@example.com", unverified: true, wantAllowed: false},
+		{name: "no email", allowedDomains: []string{"example.com"}, wantAllowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providerConfig := &config.ProviderConfig{AllowedDomains: tt.allowedDomains, AllowSubdomains: tt.allowSubdomains}
+			claims := &oidc.Claims{Email: tt.email, EmailVerified: !tt.unverified}
+
+			err := checkEmailDomain(providerConfig, claims)
+			if tt.wantAllowed && err != nil {
+				t.Errorf("checkEmailDomain() error = %v, want allowed", err)
+			}
+			if !tt.wantAllowed && status.Code(err) != codes.PermissionDenied {
+				t.Errorf("checkEmailDomain() error = %v, want PermissionDenied", err)
+			}
+		})
+	}
+}
+
+// fakeOIDCProvider returns fixed claims for any ID token
+type fakeOIDCProvider struct {
+	name   string
+	claims *oidc.Claims
+}
+
+func (p *fakeOIDCProvider) Name() string { return p.name }
+
+func (p *fakeOIDCProvider) ValidateIDToken(ctx context.Context, idToken, accessToken string, cfg config.ProviderConfig) (*oidc.Claims, error) {
+	return p.claims, nil
+}
+
+func (p *fakeOIDCProvider) GetAuthorizationURL(clientID, redirectURI, state, codeChallenge string) string {
+	return ""
+}
+
+func TestLoginWithOIDCRejectsDisallowedDomain(t *testing.T) {
+	const provider = "test-domain-allowlist"
+	oidc.RegisterProvider(&fakeOIDCProvider{name: provider, claims: &oidc.Claims{
+		Subject:       "123456789",
+		Email:         "mallory@evil.com",
+		EmailVerified: true,
+	}})
+
+	cfg := &config.Config{}
+	cfg.Auth.Providers = []config.ProviderConfig{{Name: provider, AllowedDomains: []string{"example.com"}, AutoProvision: true}}
+	h := NewAuthHandler(&fakeUserRepo{}, &fakeTokenRepo{}, nil, &fakeDiscordUserRepo{}, auth.NewJWTManager("test-secret", time.Hour), cfg)
+
+	_, err := h.LoginWithOIDC(context.Background(), &authpb.LoginWithOIDCRequest{Provider: provider, IdToken: "token"})
+	if status.Code(err) != codes.PermissionDenied || !strings.Contains(err.Error(), "domain") {
+		t.Errorf("LoginWithOIDC() error = %v, want PermissionDenied for the email domain", err)
+	}
+}
+
+func TestLinkDiscordIdentity(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	claims := &oidc.Claims{Subject: "123456789", Name: "ada"}
+
+	tests := []struct {
+		name       string
+		repo       *fakeDiscordUserRepo
+		wantCode   codes.Code
+		wantLinked bool
+	}{
+		{
+			name:       "account the bot has never seen",
+			repo:       &fakeDiscordUserRepo{},
+			wantLinked: true,
+		},
+		{
+			name: "account seen by the bot but unlinked",
+			repo: &fakeDiscordUserRepo{byDiscordID: map[string]*entities.DiscordUser{
+				"123456789": {DiscordID: "123456789", DiscordUsername: "ada", DiscordGlobalName: strPtr("Ada")},
+			}},
+			wantLinked: true,
+		},
+		{
+			name: "already linked to the caller",
+			repo: &fakeDiscordUserRepo{
+				byUserID:    map[string]*entities.DiscordUser{"admin-1": {DiscordID: "123456789", UserID: strPtr("admin-1")}},
+				byDiscordID: map[string]*entities.DiscordUser{"123456789": {DiscordID: "123456789", UserID: strPtr("admin-1")}},
+			},
+			wantLinked: true,
+		},
+		{
+			name: "linked to a different user",
+			repo: &fakeDiscordUserRepo{byDiscordID: map[string]*entities.DiscordUser{
+				"123456789": {DiscordID: "123456789", UserID: strPtr("user-2")},
+			}},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name: "caller has another discord account",
+			repo: &fakeDiscordUserRepo{byUserID: map[string]*entities.DiscordUser{
+				"admin-1": {DiscordID: "987654321", UserID: strPtr("admin-1")},
+			}},
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewAuthHandler(&fakeUserRepo{}, &fakeTokenRepo{}, nil, tt.repo, auth.NewJWTManager("test-secret", time.Hour), &config.Config{})
+
+			got, err := h.linkDiscordIdentity(context.Background(), "admin-1", claims)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("linkDiscordIdentity() error = %v, want %s", err, tt.wantCode)
+			}
+			if !tt.wantLinked {
+				if du := tt.repo.byDiscordID["123456789"]; du != nil && du.UserID != nil && *du.UserID == "admin-1" {
+					t.Error("rejected link still changed the discord_users record")
+				}
+				return
+			}
+
+			stored := tt.repo.byDiscordID["123456789"]
+			if got == nil || stored == nil || stored.UserID == nil || *stored.UserID != "admin-1" {
+				t.Fatalf("discord_users record = %+v, want it linked to admin-1", stored)
+			}
+		})
+	}
+}
+
+func TestLinkDiscordAccountRejectsBotCallers(t *testing.T) {
+	h := NewAuthHandler(&fakeUserRepo{}, &fakeTokenRepo{}, nil, &fakeDiscordUserRepo{}, auth.NewJWTManager("test-secret", time.Hour), &config.Config{})
+	ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+		UserID:    "user-1",
+		DiscordID: "123456789",
+		Role:      interceptors.RoleBot,
+	})
+
+	_, err := h.LinkDiscordAccount(ctx, &authpb.LinkDiscordAccountRequest{Code: "code"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("LinkDiscordAccount() error = %v, want PermissionDenied", err)
+	}
+}
+
+type fakeSessionRepo struct {
+	repositories.SessionRepository
+	sessions map[string]*entities.OIDCSession
+	deleted  []string
+}
+
+func (r *fakeSessionRepo) GetOIDCSessionByUserAndProvider(ctx context.Context, userID, provider string) (*entities.OIDCSession, error) {
+	for _, session := range r.sessions {
+		if session.UserID != nil && *session.UserID == userID && session.Provider == provider {
+			return session, nil
+		}
+	}
+	return nil, errors.New("oidc session not found")
+}
+
+func (r *fakeSessionRepo) DeleteOIDCSession(ctx context.Context, id string) error {
+	delete(r.sessions, id)
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+// newFakeTokenEndpoint serves a discovery document and a token endpoint that fails
+// refreshes with the given status and OAuth error code
+func newFakeTokenEndpoint(t *testing.T, statusCode int, errorCode string) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{
+				"issuer":                 srv.URL,
+				"authorization_endpoint": srv.URL + "/authorize",
+				"token_endpoint":         srv.URL + "/token",
+				"jwks_uri":               srv.URL + "/keys",
+			})
+		case "/token":
+			w.WriteHeader(statusCode)
+			json.NewEncoder(w).Encode(map[string]string{"error": errorCode})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRefreshTokenRejectedRefreshToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		errorCode   string
+		wantDeleted bool
+	}{
+		{name: "invalid grant drops the session", statusCode: http.StatusBadRequest, errorCode: "invalid_grant", wantDeleted: true},
+		{name: "provider outage keeps the session", statusCode: http.StatusInternalServerError, errorCode: "server_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeTokenEndpoint(t, tt.statusCode, tt.errorCode)
+			userID := "user-1"
+			refreshToken := "stale-refresh-token"
+			sessionRepo := &fakeSessionRepo{sessions: map[string]*entities.OIDCSession{
+				"sess-1": {ID: "sess-1", UserID: &userID, Provider: "discord", RefreshToken: &refreshToken},
+			}}
+			h := NewAuthHandler(
+				&fakeUserRepo{users: map[string]*entities.User{
+					userID: {ID: userID, UserType: entities.UserTypeOIDC, IsActive: true},
+				}},
+				&fakeTokenRepo{tokens: map[string]*entities.APIToken{
+					"tok-1": {ID: "tok-1", UserID: userID, ExpiresAt: time.Now().Add(-time.Hour)},
+				}},
+				sessionRepo,
+				&fakeDiscordUserRepo{},
+				auth.NewJWTManager("test-secret", time.Hour),
+				&config.Config{Auth: config.AuthConfig{Providers: []config.ProviderConfig{
+					{Name: "discord", Issuer: srv.URL, ClientID: "client", ClientSecret: "secret"},
+				}}},
+			)
+
+			_, err := h.RefreshToken(context.Background(), &authpb.RefreshTokenRequest{TokenId: "tok-1"})
+			if status.Code(err) != codes.Unauthenticated {
+				t.Fatalf("RefreshToken() error = %v, want Unauthenticated", err)
+			}
+			if got := rpcerr.HasReason(err, rpcerr.ReasonReauthRequired); got != tt.wantDeleted {
+				t.Errorf("re-login required for %v = %v, want %v", err, got, tt.wantDeleted)
+			}
+			if deleted := len(sessionRepo.deleted) > 0; deleted != tt.wantDeleted {
+				t.Errorf("session deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+
+			// Once the session is gone, later refreshes ask for a new login straight away
+			if tt.wantDeleted {
+				_, err = h.RefreshToken(context.Background(), &authpb.RefreshTokenRequest{TokenId: "tok-1"})
+				if !rpcerr.HasReason(err, rpcerr.ReasonReauthRequired) {
+					t.Errorf("second RefreshToken() error = %v, want re-login required", err)
+				}
+			}
+		})
+	}
+}
@@ -2,11 +2,19 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"time"
 
 	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
 	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/internal/notify"
+	"github.com/devilmonastery/hivemind/internal/pkg/colorutil"
+	"github.com/devilmonastery/hivemind/internal/pkg/msgtemplate"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -34,7 +42,7 @@ func (h *DiscordHandler) UpsertGuild(ctx context.Context, req *discordpb.UpsertG
 		return nil, status.Error(codes.InvalidArgument, "guild_name is required")
 	}
 
-	guild, err := h.discordService.UpsertGuild(ctx, req.GuildId, req.GuildName, req.IconUrl, req.OwnerDiscordId)
+	guild, created, err := h.discordService.UpsertGuild(ctx, req.GuildId, req.GuildName, req.IconUrl, req.OwnerDiscordId)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to upsert guild: %v", err)
 	}
@@ -49,6 +57,7 @@ func (h *DiscordHandler) UpsertGuild(ctx context.Context, req *discordpb.UpsertG
 			AddedAt:        timestamppb.New(guild.AddedAt),
 			LastActivity:   timestampPtrToProto(guild.LastActivity),
 		},
+		Created: created,
 	}, nil
 }
 
@@ -87,6 +96,7 @@ func (h *DiscordHandler) GetGuild(ctx context.Context, req *discordpb.GetGuildRe
 			Enabled:        guild.Enabled,
 			AddedAt:        timestamppb.New(guild.AddedAt),
 			LastActivity:   timestampPtrToProto(guild.LastActivity),
+			LastMemberSync: timestampPtrToProto(guild.LastMemberSync),
 		},
 	}, nil
 }
@@ -114,9 +124,10 @@ func (h *DiscordHandler) UpsertGuildMember(ctx context.Context, req *discordpb.U
 		return nil, status.Error(codes.InvalidArgument, "discord_id is required")
 	}
 
-	// If user data is provided, update discord_users first
+	// If user data is provided, discord_users is updated too
+	var discordUser *entities.DiscordUser
 	if req.DiscordUsername != "" {
-		discordUser := &entities.DiscordUser{
+		discordUser = &entities.DiscordUser{
 			DiscordID:       req.DiscordId,
 			UserID:          nil,
 			DiscordUsername: req.DiscordUsername,
@@ -130,10 +141,6 @@ func (h *DiscordHandler) UpsertGuildMember(ctx context.Context, req *discordpb.U
 		}
 		now := time.Now()
 		discordUser.LastSeen = &now
-
-		if err := h.discordService.UpsertDiscordUser(ctx, discordUser); err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to upsert discord user: %v", err)
-		}
 	}
 
 	member := &entities.GuildMember{
@@ -151,7 +158,7 @@ func (h *DiscordHandler) UpsertGuildMember(ctx context.Context, req *discordpb.U
 		member.GuildAvatarHash = &req.GuildAvatarHash
 	}
 
-	if err := h.discordService.UpsertGuildMember(ctx, member); err != nil {
+	if _, err := h.discordService.UpsertGuildMember(ctx, discordUser, member); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to upsert guild member: %v", err)
 	}
 
@@ -162,7 +169,12 @@ func (h *DiscordHandler) UpsertGuildMember(ctx context.Context, req *discordpb.U
 
 // UpsertGuildMembersBatch efficiently inserts/updates multiple members
 func (h *DiscordHandler) UpsertGuildMembersBatch(ctx context.Context, req *discordpb.UpsertGuildMembersBatchRequest) (*discordpb.UpsertGuildMembersBatchResponse, error) {
+	if err := requireServiceCaller(ctx); err != nil {
+		return nil, err
+	}
+
 	if len(req.Members) == 0 {
+		h.recordMemberSync(ctx, req.SyncedGuildId)
 		return &discordpb.UpsertGuildMembersBatchResponse{Count: 0}, nil
 	}
 
@@ -223,12 +235,26 @@ func (h *DiscordHandler) UpsertGuildMembersBatch(ctx context.Context, req *disco
 	if err := h.discordService.UpsertGuildMembersBatch(ctx, members); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to batch upsert members: %v", err)
 	}
+	h.recordMemberSync(ctx, req.SyncedGuildId)
 
 	return &discordpb.UpsertGuildMembersBatchResponse{
 		Count: int32(len(members)),
 	}, nil
 }
 
+// recordMemberSync stores the time of a full member sync of guildID, if one was reported.
+// The members are already saved, so a failure is only logged.
+func (h *DiscordHandler) recordMemberSync(ctx context.Context, guildID string) {
+	if guildID == "" {
+		return
+	}
+	if err := h.discordService.RecordMemberSync(ctx, guildID); err != nil {
+		slog.Warn("failed to record guild member sync time",
+			slog.String("guild_id", guildID),
+			slog.String("error", err.Error()))
+	}
+}
+
 // RemoveGuildMember removes a member record
 func (h *DiscordHandler) RemoveGuildMember(ctx context.Context, req *discordpb.RemoveGuildMemberRequest) (*discordpb.RemoveGuildMemberResponse, error) {
 	if req.GuildId == "" {
@@ -282,16 +308,62 @@ func (h *DiscordHandler) ListUserGuilds(ctx context.Context, req *discordpb.List
 	}, nil
 }
 
-// UpdateGuildSettings updates guild-specific settings
-// Should verify that caller has appropriate permissions to modify guild settings, e.g., is a guild admin.
+// maxQuoteCooldownSeconds caps a guild's quote cooldown at a day
+const maxQuoteCooldownSeconds = 24 * 60 * 60
+
+// maxReferencesPerItem caps a guild's per-page and per-note reference limit
+const maxReferencesPerItem = 10000
+
+// UpdateGuildSettings updates guild-specific settings. Only the bot (which checks the
+// member's Discord permissions first), service accounts, and admins may call it.
 func (h *DiscordHandler) UpdateGuildSettings(ctx context.Context, req *discordpb.UpdateGuildSettingsRequest) (*discordpb.UpdateGuildSettingsResponse, error) {
+	if err := requireServiceCaller(ctx); err != nil {
+		return nil, err
+	}
 	if req.GuildId == "" {
 		return nil, status.Error(codes.InvalidArgument, "guild_id is required")
 	}
 
-	// Convert protobuf to map
-	settings := make(map[string]interface{})
-	settings["version"] = 1
+	var appearance map[string]interface{}
+	if req.Settings != nil && req.Settings.Appearance != nil {
+		var err error
+		appearance, err = appearanceSettingsToMap(req.Settings.Appearance)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if req.Settings != nil && req.Settings.Webhook != nil {
+		if err := validateWebhookURL(ctx, req.Settings.Webhook.Url); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if req.Settings != nil && req.Settings.Quotes != nil {
+		if cooldown := req.Settings.Quotes.CooldownSeconds; cooldown < 0 || cooldown > maxQuoteCooldownSeconds {
+			return nil, status.Errorf(codes.InvalidArgument, "quote cooldown must be between 0 and %d seconds", maxQuoteCooldownSeconds)
+		}
+	}
+
+	if req.Settings != nil && req.Settings.References != nil {
+		if limit := req.Settings.References.MaxPerItem; limit < 0 || limit > maxReferencesPerItem {
+			return nil, status.Errorf(codes.InvalidArgument, "reference limit must be between 0 and %d", maxReferencesPerItem)
+		}
+	}
+
+	if req.Settings != nil && req.Settings.Messages != nil {
+		for event, tmpl := range req.Settings.Messages.Templates {
+			if err := msgtemplate.Validate(event, tmpl); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+	}
+
+	// Only the sections in the request are sent; the repository merges them into the
+	// stored settings, so sections missing from the request are preserved
+	settings := map[string]interface{}{
+		"version": 1,
+	}
 
 	if req.Settings != nil && req.Settings.Announcements != nil {
 		settings["announcements"] = map[string]interface{}{
@@ -302,19 +374,102 @@ func (h *DiscordHandler) UpdateGuildSettings(ctx context.Context, req *discordpb
 			"notify_quote_create":         req.Settings.Announcements.NotifyQuoteCreate,
 			"create_threads":              req.Settings.Announcements.CreateThreads,
 			"thread_auto_archive_minutes": req.Settings.Announcements.ThreadAutoArchiveMinutes,
+			"weekly_quote_leaderboard":    req.Settings.Announcements.WeeklyQuoteLeaderboard,
+		}
+	}
+
+	if req.Settings != nil && req.Settings.Features != nil {
+		settings["features"] = map[string]interface{}{
+			"wiki_enabled":   req.Settings.Features.WikiEnabled,
+			"notes_enabled":  req.Settings.Features.NotesEnabled,
+			"quotes_enabled": req.Settings.Features.QuotesEnabled,
+		}
+	}
+
+	if appearance != nil {
+		settings["appearance"] = appearance
+	}
+
+	if req.Settings != nil && req.Settings.Permissions != nil {
+		settings["permissions"] = map[string]interface{}{
+			services.PermissionWikiEdit: nonNilStrings(req.Settings.Permissions.WikiEditRoles),
+		}
+	}
+
+	if req.Settings != nil && req.Settings.Posting != nil {
+		settings["posting"] = map[string]interface{}{
+			"reply_to_source": req.Settings.Posting.ReplyToSource,
+		}
+	}
+
+	if req.Settings != nil && req.Settings.Notes != nil {
+		settings["notes"] = map[string]interface{}{
+			"unique_titles": req.Settings.Notes.UniqueTitles,
+		}
+	}
+
+	if req.Settings != nil && req.Settings.Quotes != nil {
+		settings["quotes"] = map[string]interface{}{
+			"cooldown_seconds": req.Settings.Quotes.CooldownSeconds,
+		}
+	}
+
+	if req.Settings != nil && req.Settings.References != nil {
+		settings["references"] = map[string]interface{}{
+			"max_per_item": req.Settings.References.MaxPerItem,
+		}
+	}
+
+	if req.Settings != nil && req.Settings.Messages != nil {
+		templates := make(map[string]interface{}, len(req.Settings.Messages.Templates))
+		for event, tmpl := range req.Settings.Messages.Templates {
+			templates[event] = tmpl
+		}
+		settings["messages"] = map[string]interface{}{
+			"templates": templates,
+		}
+	}
+
+	if req.Settings != nil && req.Settings.Webhook != nil {
+		// An empty secret keeps the stored one, since GetGuildSettings never returns it
+		secret := req.Settings.Webhook.Secret
+		if secret == "" {
+			stored, err := h.discordService.GetGuildSettings(ctx, req.GuildId)
+			if err != nil {
+				return nil, guildSettingsStatus(err)
+			}
+			if existing, ok := stored["webhook"].(map[string]interface{}); ok {
+				secret = getString(existing, "secret")
+			}
+		}
+		settings["webhook"] = map[string]interface{}{
+			"url":    req.Settings.Webhook.Url,
+			"secret": secret,
 		}
 	}
 
-	err := h.discordService.UpdateGuildSettings(ctx, req.GuildId, settings)
+	merged, err := h.discordService.UpdateGuildSettings(ctx, req.GuildId, settings, req.ExpectedRevision)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update guild settings: %v", err)
+		return nil, guildSettingsStatus(err)
 	}
 
 	return &discordpb.UpdateGuildSettingsResponse{
-		Settings: req.Settings,
+		Settings: guildSettingsToProto(merged),
 	}, nil
 }
 
+// guildSettingsStatus maps guild settings errors to gRPC statuses
+func guildSettingsStatus(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrDiscordGuildNotFound):
+		return status.Error(codes.NotFound, "guild not found")
+	case errors.Is(err, repositories.ErrStaleGuildSettings):
+		return status.Error(codes.Aborted, "guild settings changed since they were read, fetch them and try again")
+	default:
+		return status.Errorf(codes.Internal, "failed to update guild settings: %v", err)
+	}
+}
+
 // GetGuildSettings retrieves guild settings
 func (h *DiscordHandler) GetGuildSettings(ctx context.Context, req *discordpb.GetGuildSettingsRequest) (*discordpb.GetGuildSettingsResponse, error) {
 	if req.GuildId == "" {
@@ -326,13 +481,26 @@ func (h *DiscordHandler) GetGuildSettings(ctx context.Context, req *discordpb.Ge
 		return nil, status.Errorf(codes.Internal, "failed to get guild settings: %v", err)
 	}
 
-	// Convert map to protobuf
-	resp := &discordpb.GetGuildSettingsResponse{
-		Settings: &discordpb.GuildSettings{},
+	return &discordpb.GetGuildSettingsResponse{
+		Settings: guildSettingsToProto(settings),
+	}, nil
+}
+
+// guildSettingsToProto converts the stored settings map to protobuf.
+// Features are always populated and default to enabled, so guilds that
+// predate feature flags keep every feature turned on.
+func guildSettingsToProto(settings map[string]interface{}) *discordpb.GuildSettings {
+	proto := &discordpb.GuildSettings{
+		Features: &discordpb.FeatureSettings{
+			WikiEnabled:   true,
+			NotesEnabled:  true,
+			QuotesEnabled: true,
+		},
+		Revision: getInt64(settings, repositories.GuildSettingsRevisionKey),
 	}
 
 	if announcements, ok := settings["announcements"].(map[string]interface{}); ok {
-		resp.Settings.Announcements = &discordpb.AnnouncementSettings{
+		proto.Announcements = &discordpb.AnnouncementSettings{
 			Enabled:                  getBool(announcements, "enabled"),
 			ChannelId:                getString(announcements, "channel_id"),
 			NotifyWikiCreate:         getBool(announcements, "notify_wiki_create"),
@@ -340,12 +508,215 @@ func (h *DiscordHandler) GetGuildSettings(ctx context.Context, req *discordpb.Ge
 			NotifyQuoteCreate:        getBool(announcements, "notify_quote_create"),
 			CreateThreads:            getBool(announcements, "create_threads"),
 			ThreadAutoArchiveMinutes: getInt32(announcements, "thread_auto_archive_minutes"),
+			WeeklyQuoteLeaderboard:   getBool(announcements, "weekly_quote_leaderboard"),
+		}
+	}
+
+	if features, ok := settings["features"].(map[string]interface{}); ok {
+		proto.Features.WikiEnabled = getBoolDefault(features, "wiki_enabled", true)
+		proto.Features.NotesEnabled = getBoolDefault(features, "notes_enabled", true)
+		proto.Features.QuotesEnabled = getBoolDefault(features, "quotes_enabled", true)
+	}
+
+	if appearance, ok := settings["appearance"].(map[string]interface{}); ok {
+		proto.Appearance = &discordpb.AppearanceSettings{
+			WikiColor:  getString(appearance, "wiki_color"),
+			NoteColor:  getString(appearance, "note_color"),
+			QuoteColor: getString(appearance, "quote_color"),
+		}
+	}
+
+	if posting, ok := settings["posting"].(map[string]interface{}); ok {
+		proto.Posting = &discordpb.PostingSettings{
+			ReplyToSource: getBool(posting, "reply_to_source"),
+		}
+	}
+
+	if notes, ok := settings["notes"].(map[string]interface{}); ok {
+		proto.Notes = &discordpb.NoteSettings{
+			UniqueTitles: getBool(notes, "unique_titles"),
+		}
+	}
+
+	if quotes, ok := settings["quotes"].(map[string]interface{}); ok {
+		proto.Quotes = &discordpb.QuoteSettings{
+			CooldownSeconds: getInt32(quotes, "cooldown_seconds"),
+		}
+	}
+
+	if references, ok := settings["references"].(map[string]interface{}); ok {
+		proto.References = &discordpb.ReferenceSettings{
+			MaxPerItem: getInt32(references, "max_per_item"),
+		}
+	}
+
+	if messages, ok := settings["messages"].(map[string]interface{}); ok {
+		proto.Messages = &discordpb.MessageSettings{Templates: map[string]string{}}
+		if templates, ok := messages["templates"].(map[string]interface{}); ok {
+			for event := range templates {
+				if tmpl := getString(templates, event); tmpl != "" {
+					proto.Messages.Templates[event] = tmpl
+				}
+			}
+		}
+	}
+
+	if webhook, ok := settings["webhook"].(map[string]interface{}); ok {
+		proto.Webhook = &discordpb.WebhookSettings{
+			Url:       getString(webhook, "url"),
+			SecretSet: getString(webhook, "secret") != "",
 		}
 	}
 
+	if _, ok := settings["permissions"].(map[string]interface{}); ok {
+		proto.Permissions = &discordpb.PermissionSettings{
+			WikiEditRoles: services.RequiredRoles(settings, services.PermissionWikiEdit),
+		}
+	}
+
+	return proto
+}
+
+// nonNilStrings stores an empty list as [] rather than null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// validateWebhookURL checks that a webhook URL is an https URL on a public address;
+// empty disables the webhook
+func validateWebhookURL(ctx context.Context, rawURL string) error {
+	if rawURL == "" {
+		return nil
+	}
+	return notify.ValidateURL(ctx, rawURL)
+}
+
+// appearanceSettingsToMap validates embed colors and converts them to the stored form.
+// Colors are normalized to "#RRGGBB"; empty values reset to the bot default.
+func appearanceSettingsToMap(appearance *discordpb.AppearanceSettings) (map[string]interface{}, error) {
+	colors := map[string]string{
+		"wiki_color":  appearance.WikiColor,
+		"note_color":  appearance.NoteColor,
+		"quote_color": appearance.QuoteColor,
+	}
+
+	result := make(map[string]interface{}, len(colors))
+	for key, value := range colors {
+		if value == "" {
+			result[key] = ""
+			continue
+		}
+		normalized, err := colorutil.Normalize(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		result[key] = normalized
+	}
+	return result, nil
+}
+
+// ListDiscordUsers lists Discord users seen recently, for the bot's profile sync
+func (h *DiscordHandler) ListDiscordUsers(ctx context.Context, req *discordpb.ListDiscordUsersRequest) (*discordpb.ListDiscordUsersResponse, error) {
+	if err := requireServiceCaller(ctx); err != nil {
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 500
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	var seenSince time.Time
+	if req.SeenSince != nil {
+		seenSince = req.SeenSince.AsTime()
+	}
+
+	users, err := h.discordService.ListActiveDiscordUsers(ctx, seenSince, req.AfterDiscordId, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list discord users: %v", err)
+	}
+
+	resp := &discordpb.ListDiscordUsersResponse{
+		Users: make([]*discordpb.DiscordUser, len(users)),
+	}
+	for i, u := range users {
+		pbUser := &discordpb.DiscordUser{
+			DiscordId:         u.DiscordID,
+			DiscordUsername:   u.DiscordUsername,
+			DiscordGlobalName: stringPtrValue(u.DiscordGlobalName),
+			AvatarHash:        stringPtrValue(u.AvatarHash),
+		}
+		if u.LastSeen != nil {
+			pbUser.LastSeen = timestamppb.New(*u.LastSeen)
+		}
+		resp.Users[i] = pbUser
+	}
+
 	return resp, nil
 }
 
+// UpdateDiscordUsersBatch refreshes cached profile info for multiple Discord users
+func (h *DiscordHandler) UpdateDiscordUsersBatch(ctx context.Context, req *discordpb.UpdateDiscordUsersBatchRequest) (*discordpb.UpdateDiscordUsersBatchResponse, error) {
+	if err := requireServiceCaller(ctx); err != nil {
+		return nil, err
+	}
+
+	discordUsers := make([]*entities.DiscordUser, 0, len(req.Users))
+	for _, u := range req.Users {
+		if u.DiscordId == "" || u.DiscordUsername == "" {
+			return nil, status.Error(codes.InvalidArgument, "discord_id and discord_username are required")
+		}
+
+		discordUser := &entities.DiscordUser{
+			DiscordID:       u.DiscordId,
+			DiscordUsername: u.DiscordUsername,
+			LinkedAt:        time.Now(),
+		}
+		if u.DiscordGlobalName != "" {
+			discordUser.DiscordGlobalName = &u.DiscordGlobalName
+		}
+		if u.AvatarHash != "" {
+			discordUser.AvatarHash = &u.AvatarHash
+		}
+		// A profile refresh isn't activity, so keep the caller's last_seen
+		lastSeen := time.Now()
+		if u.LastSeen != nil {
+			lastSeen = u.LastSeen.AsTime()
+		}
+		discordUser.LastSeen = &lastSeen
+
+		discordUsers = append(discordUsers, discordUser)
+	}
+
+	if err := h.discordService.UpsertDiscordUsersBatch(ctx, discordUsers); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to batch update discord users: %v", err)
+	}
+
+	return &discordpb.UpdateDiscordUsersBatchResponse{
+		Count: int32(len(discordUsers)),
+	}, nil
+}
+
+// requireServiceCaller restricts an RPC to the bot, service accounts, and admins
+func requireServiceCaller(ctx context.Context) error {
+	user, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "user context not found")
+	}
+	switch user.Role {
+	case interceptors.RoleBot, "service_account", "admin":
+		return nil
+	default:
+		return status.Error(codes.PermissionDenied, "only service accounts can call this method")
+	}
+}
+
 // Helper functions for type conversion
 func getBool(m map[string]interface{}, key string) bool {
 	if v, ok := m[key].(bool); ok {
@@ -354,6 +725,13 @@ func getBool(m map[string]interface{}, key string) bool {
 	return false
 }
 
+func getBoolDefault(m map[string]interface{}, key string, def bool) bool {
+	if v, ok := m[key].(bool); ok {
+		return v
+	}
+	return def
+}
+
 func getString(m map[string]interface{}, key string) string {
 	if v, ok := m[key].(string); ok {
 		return v
@@ -361,6 +739,19 @@ func getString(m map[string]interface{}, key string) string {
 	return ""
 }
 
+func getInt64(m map[string]interface{}, key string) int64 {
+	if v, ok := m[key].(float64); ok {
+		return int64(v)
+	}
+	if v, ok := m[key].(int64); ok {
+		return v
+	}
+	if v, ok := m[key].(int); ok {
+		return int64(v)
+	}
+	return 0
+}
+
 func getInt32(m map[string]interface{}, key string) int32 {
 	if v, ok := m[key].(float64); ok {
 		return int32(v)
@@ -373,3 +764,80 @@ func getInt32(m map[string]interface{}, key string) int32 {
 	}
 	return 0
 }
+
+// RecordDeadLetter records an outbound Discord call the bot dropped after its retries
+func (h *DiscordHandler) RecordDeadLetter(ctx context.Context, req *discordpb.RecordDeadLetterRequest) (*discordpb.RecordDeadLetterResponse, error) {
+	if err := requireServiceCaller(ctx); err != nil {
+		return nil, err
+	}
+
+	d := req.GetDeadLetter()
+	if d.GetOperation() == "" || d.GetFailureType() == "" {
+		return nil, status.Error(codes.InvalidArgument, "dead_letter.operation and dead_letter.failure_type are required")
+	}
+
+	deadLetter := &entities.DeadLetter{
+		Operation:   d.Operation,
+		FailureType: d.FailureType,
+		Error:       d.Error,
+		Attempts:    int(d.Attempts),
+		Payload:     d.Payload,
+	}
+	if d.GuildId != "" {
+		deadLetter.GuildID = &d.GuildId
+	}
+	if d.ChannelId != "" {
+		deadLetter.ChannelID = &d.ChannelId
+	}
+
+	if err := h.discordService.RecordDeadLetter(ctx, deadLetter); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to record dead letter: %v", err)
+	}
+
+	return &discordpb.RecordDeadLetterResponse{
+		DeadLetter: deadLetterToProto(deadLetter),
+	}, nil
+}
+
+// ListDeadLetters lists outbound Discord calls the bot dropped, most recent first
+func (h *DiscordHandler) ListDeadLetters(ctx context.Context, req *discordpb.ListDeadLettersRequest) (*discordpb.ListDeadLettersResponse, error) {
+	if err := requireServiceCaller(ctx); err != nil {
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultAuditLogLimit
+	}
+	if limit > maxAuditLogLimit {
+		limit = maxAuditLogLimit
+	}
+
+	deadLetters, total, err := h.discordService.ListDeadLetters(ctx, req.GuildId, limit, int(req.Offset))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list dead letters: %v", err)
+	}
+
+	resp := &discordpb.ListDeadLettersResponse{
+		DeadLetters: make([]*discordpb.DeadLetter, len(deadLetters)),
+		Total:       int32(total),
+	}
+	for i, deadLetter := range deadLetters {
+		resp.DeadLetters[i] = deadLetterToProto(deadLetter)
+	}
+	return resp, nil
+}
+
+func deadLetterToProto(d *entities.DeadLetter) *discordpb.DeadLetter {
+	return &discordpb.DeadLetter{
+		Id:          d.ID,
+		Operation:   d.Operation,
+		GuildId:     stringPtrValue(d.GuildID),
+		ChannelId:   stringPtrValue(d.ChannelID),
+		FailureType: d.FailureType,
+		Error:       d.Error,
+		Attempts:    int32(d.Attempts),
+		Payload:     d.Payload,
+		CreatedAt:   timestamppb.New(d.CreatedAt),
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+)
+
+func TestUpdateGuildSettings_RequiresServiceCaller(t *testing.T) {
+	ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+		UserID: "u1",
+		Role:   "user",
+	})
+
+	_, err := NewDiscordHandler(nil).UpdateGuildSettings(ctx, &discordpb.UpdateGuildSettingsRequest{
+		GuildId: "g1",
+		Settings: &discordpb.GuildSettings{
+			Webhook: &discordpb.WebhookSettings{Url: "https://93.184.216.34/hook"},
+		},
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("UpdateGuildSettings() code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestUpsertGuildMembersBatch_RequiresServiceCaller(t *testing.T) {
+	ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+		UserID: "u1",
+		Role:   "user",
+	})
+
+	_, err := NewDiscordHandler(nil).UpsertGuildMembersBatch(ctx, &discordpb.UpsertGuildMembersBatchRequest{
+		SyncedGuildId: "g1",
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("UpsertGuildMembersBatch() code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	ctx := context.Background()
+	if err := validateWebhookURL(ctx, ""); err != nil {
+		t.Errorf("validateWebhookURL(\"\") error = %v, want nil (webhook disabled)", err)
+	}
+	for _, rawURL := range []string{
+		"http://93.184.216.34/hook",
+		"https://127.0.0.1:8080/hook",
+		"https://10.0.0.5/hook",
+		"https://169.254.169.254/latest/meta-data",
+	} {
+		if err := validateWebhookURL(ctx, rawURL); err == nil {
+			t.Errorf("validateWebhookURL(%q) succeeded, want an error", rawURL)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkBodyLength returns InvalidArgument if body is longer than max bytes.
+// A max of zero or less means no limit.
+func checkBodyLength(kind, body string, max int) error {
+	if max > 0 && len(body) > max {
+		return status.Errorf(codes.InvalidArgument, "%s body is too long: %d bytes (max %d)", kind, len(body), max)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	"github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	"github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckBodyLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		length  int
+		max     int
+		wantErr bool
+	}{
+		{name: "wiki at limit", kind: "wiki page", length: 64 * 1024, max: 64 * 1024},
+		{name: "wiki over limit", kind: "wiki page", length: 64*1024 + 1, max: 64 * 1024, wantErr: true},
+		{name: "note at limit", kind: "note", length: 16 * 1024, max: 16 * 1024},
+		{name: "note over limit", kind: "note", length: 16*1024 + 1, max: 16 * 1024, wantErr: true},
+		{name: "quote at limit", kind: "quote", length: 4 * 1024, max: 4 * 1024},
+		{name: "quote over limit", kind: "quote", length: 4*1024 + 1, max: 4 * 1024, wantErr: true},
+		{name: "zero disables limit", kind: "note", length: 1 << 20, max: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBodyLength(tt.kind, strings.Repeat("a", tt.length), tt.max)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("checkBodyLength() error = %v, want nil", err)
+				}
+				return
+			}
+
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("checkBodyLength() code = %v, want InvalidArgument", status.Code(err))
+			}
+			want := fmt.Sprintf("%s body is too long: %d bytes (max %d)", tt.kind, tt.length, tt.max)
+			if msg := status.Convert(err).Message(); msg != want {
+				t.Errorf("checkBodyLength() message = %q, want %q", msg, want)
+			}
+		})
+	}
+}
+
+func TestHandlersRejectOversizedBodies(t *testing.T) {
+	ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+		UserID: "u1",
+		Role:   "admin",
+	})
+	body := strings.Repeat("a", 11)
+
+	notes := NewNoteHandler(nil, nil, 10, config.PageLimits{})
+	quotes := NewQuoteHandler(nil, nil, 10, config.PageLimits{})
+	wiki := NewWikiHandler(nil, nil, nil, nil, 10, config.PageLimits{}, slog.Default())
+
+	calls := map[string]func() error{
+		"CreateNote": func() error {
+			_, err := notes.CreateNote(ctx, &notespb.CreateNoteRequest{Title: "t", Body: body})
+			return err
+		},
+		"UpdateNote": func() error {
+			_, err := notes.UpdateNote(ctx, &notespb.UpdateNoteRequest{Id: "n1", Title: "t", Body: body})
+			return err
+		},
+		"CreateQuote": func() error {
+			_, err := quotes.CreateQuote(ctx, &quotespb.CreateQuoteRequest{Body: body})
+			return err
+		},
+		"UpdateQuote": func() error {
+			_, err := quotes.UpdateQuote(ctx, &quotespb.UpdateQuoteRequest{Id: "q1", Body: body})
+			return err
+		},
+		"CreateWikiPage": func() error {
+			_, err := wiki.CreateWikiPage(ctx, &wikipb.CreateWikiPageRequest{Title: "t", Body: body})
+			return err
+		},
+		"UpdateWikiPage": func() error {
+			_, err := wiki.UpdateWikiPage(ctx, &wikipb.UpdateWikiPageRequest{Id: "w1", Title: "t", Body: body})
+			return err
+		},
+		"UpsertWikiPage": func() error {
+			_, err := wiki.UpsertWikiPage(ctx, &wikipb.UpsertWikiPageRequest{Title: "t", Body: body})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			err := call()
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("%s() error = %v, want InvalidArgument", name, err)
+			}
+			if msg := status.Convert(err).Message(); !strings.Contains(msg, "11 bytes (max 10)") {
+				t.Errorf("%s() message = %q, want current and max length", name, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
+	"github.com/devilmonastery/hivemind/internal/pkg/textutil"
+)
+
+// Wiki pages in guilds the caller isn't a member of, and notes written by someone
+// else, are reported exactly like missing ones: codes.NotFound with the same
+// message. This is deliberate, so an ID can't be used to probe for content in
+// other guilds or other users' notes. Searches and lists leave such content out
+// for the same reason. PermissionDenied is only returned for content the caller
+// can see but isn't allowed to change, such as editing a wiki page without the
+// guild's wiki editor role.
+var (
+	errWikiPageNotFound = status.Error(codes.NotFound, "wiki page not found")
+	errNoteNotFound     = status.Error(codes.NotFound, "note not found")
+)
+
+// wikiPageStatus converts an error from a wiki page lookup or change into a gRPC status.
+// action describes the failed operation for Internal errors, e.g. "get".
+func wikiPageStatus(err error, action string) error {
+	switch {
+	case errors.Is(err, repositories.ErrWikiPageNotFound):
+		return errWikiPageNotFound
+	case errors.Is(err, repositories.ErrWikiTitleTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, textutil.ErrInvalidTags), errors.Is(err, services.ErrContentRejected):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repositories.ErrReferenceLimitReached):
+		return rpcerr.New(codes.FailedPrecondition, rpcerr.ReasonReferenceLimit, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "failed to %s wiki page: %v", action, err)
+	}
+}
+
+// noteStatus converts an error from a note lookup or change into a gRPC status.
+// action describes the failed operation for Internal errors, e.g. "get".
+func noteStatus(err error, action string) error {
+	switch {
+	case errors.Is(err, repositories.ErrNoteNotFound):
+		return errNoteNotFound
+	case errors.Is(err, textutil.ErrInvalidTags), errors.Is(err, services.ErrContentRejected):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repositories.ErrNoteTitleTaken):
+		return noteTitleTakenStatus(err)
+	case errors.Is(err, repositories.ErrReferenceLimitReached):
+		return rpcerr.New(codes.FailedPrecondition, rpcerr.ReasonReferenceLimit, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "failed to %s note: %v", action, err)
+	}
+}
+
+// noteTitleTakenStatus converts ErrNoteTitleTaken into AlreadyExists carrying
+// rpcerr.ReasonNoteTitleTaken and the ID of the note that has the title
+func noteTitleTakenStatus(err error) error {
+	var metadata map[string]string
+	var taken *repositories.NoteTitleTakenError
+	if errors.As(err, &taken) {
+		metadata = map[string]string{rpcerr.MetadataNoteID: taken.ExistingID}
+	}
+	return rpcerr.NewWithMetadata(codes.AlreadyExists, rpcerr.ReasonNoteTitleTaken, repositories.ErrNoteTitleTaken.Error(), metadata)
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	"github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+)
+
+// fakeACLWikiRepo hides pages in guilds the caller isn't a member of, like the
+// guild_members join in the postgres repository
+type fakeACLWikiRepo struct {
+	repositories.WikiPageRepository
+	pages   map[string]*entities.WikiPage
+	members map[string]string // Discord ID -> guild ID
+}
+
+func (r *fakeACLWikiRepo) GetByID(ctx context.Context, id, userDiscordID string) (*entities.WikiPage, error) {
+	page, ok := r.pages[id]
+	if !ok || (userDiscordID != "" && r.members[userDiscordID] != page.GuildID) {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, id)
+	}
+	return page, nil
+}
+
+func (r *fakeACLWikiRepo) Update(ctx context.Context, page *entities.WikiPage) error {
+	if _, ok := r.pages[page.ID]; !ok {
+		return fmt.Errorf("%w: %s", repositories.ErrWikiPageNotFound, page.ID)
+	}
+	return nil
+}
+
+func (r *fakeACLWikiRepo) Delete(ctx context.Context, id string) error {
+	delete(r.pages, id)
+	return nil
+}
+
+type fakeNoteRepo struct {
+	repositories.NoteRepository
+	notes map[string]*entities.Note
+}
+
+func (r *fakeNoteRepo) GetByID(ctx context.Context, id, userDiscordID string) (*entities.Note, error) {
+	note, ok := r.notes[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrNoteNotFound, id)
+	}
+	return note, nil
+}
+
+func (r *fakeNoteRepo) Update(ctx context.Context, note *entities.Note) error {
+	return nil
+}
+
+func (r *fakeNoteRepo) Delete(ctx context.Context, id string) error {
+	delete(r.notes, id)
+	return nil
+}
+
+func userContext(userID, role string) context.Context {
+	return context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+		UserID: userID,
+		Role:   role,
+	})
+}
+
+func TestWikiHandlerNotFoundCodes(t *testing.T) {
+	newHandler := func() wikipb.WikiServiceServer {
+		repo := &fakeACLWikiRepo{
+			pages:   map[string]*entities.WikiPage{"w1": {ID: "w1", GuildID: "g1", Title: "Rules"}},
+			members: map[string]string{"d-member": "g1", "d-outsider": "g2"},
+		}
+		discordUsers := &fakeDiscordUserRepo{byUserID: map[string]*entities.DiscordUser{
+			"member":   {DiscordID: "d-member"},
+			"outsider": {DiscordID: "d-outsider"},
+		}}
+		wikiService := services.NewWikiService(repo, nil, nil, nil, nil, nil, nil, nil, nil)
+		return NewWikiHandler(wikiService, nil, nil, discordUsers, 0, config.PageLimits{}, slog.Default())
+	}
+
+	calls := map[string]func(h wikipb.WikiServiceServer, ctx context.Context, id string) error{
+		"GetWikiPage": func(h wikipb.WikiServiceServer, ctx context.Context, id string) error {
+			_, err := h.GetWikiPage(ctx, &wikipb.GetWikiPageRequest{Id: id})
+			return err
+		},
+		"UpdateWikiPage": func(h wikipb.WikiServiceServer, ctx context.Context, id string) error {
+			_, err := h.UpdateWikiPage(ctx, &wikipb.UpdateWikiPageRequest{Id: id, Title: "Rules", Body: "Be nice"})
+			return err
+		},
+		"DeleteWikiPage": func(h wikipb.WikiServiceServer, ctx context.Context, id string) error {
+			_, err := h.DeleteWikiPage(ctx, &wikipb.DeleteWikiPageRequest{Id: id})
+			return err
+		},
+	}
+
+	tests := []struct {
+		name     string
+		userID   string
+		role     string
+		id       string
+		wantCode codes.Code
+	}{
+		{name: "missing page", userID: "admin", role: "admin", id: "nope", wantCode: codes.NotFound},
+		{name: "missing page for member", userID: "member", role: "user", id: "nope", wantCode: codes.NotFound},
+		// Reported exactly like a missing page so page IDs in other guilds can't be probed
+		{name: "page in another guild", userID: "outsider", role: "user", id: "w1", wantCode: codes.NotFound},
+		{name: "admin sees every guild", userID: "admin", role: "admin", id: "w1", wantCode: codes.OK},
+	}
+
+	for name, call := range calls {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				err := call(newHandler(), userContext(tt.userID, tt.role), tt.id)
+				if status.Code(err) != tt.wantCode {
+					t.Fatalf("%s() error = %v, want %s", name, err, tt.wantCode)
+				}
+				if tt.wantCode == codes.NotFound && status.Convert(err).Message() != "wiki page not found" {
+					t.Errorf("%s() message = %q, want the same message for missing and hidden pages", name, status.Convert(err).Message())
+				}
+			})
+		}
+	}
+}
+
+func TestNoteHandlerNotFoundCodes(t *testing.T) {
+	newHandler := func() *NoteHandler {
+		repo := &fakeNoteRepo{notes: map[string]*entities.Note{
+			"n1": {ID: "n1", AuthorID: "author", GuildID: "g1", Title: "Groceries", Body: "Eggs"},
+		}}
+		return NewNoteHandler(services.NewNoteService(repo, nil, nil, nil, nil, nil, nil), &fakeDiscordUserRepo{}, 0, config.PageLimits{})
+	}
+
+	calls := map[string]func(h *NoteHandler, ctx context.Context, id string) error{
+		"GetNote": func(h *NoteHandler, ctx context.Context, id string) error {
+			_, err := h.GetNote(ctx, &notespb.GetNoteRequest{Id: id})
+			return err
+		},
+		"UpdateNote": func(h *NoteHandler, ctx context.Context, id string) error {
+			_, err := h.UpdateNote(ctx, &notespb.UpdateNoteRequest{Id: id, Title: "Groceries", Body: "Milk"})
+			return err
+		},
+		"DeleteNote": func(h *NoteHandler, ctx context.Context, id string) error {
+			_, err := h.DeleteNote(ctx, &notespb.DeleteNoteRequest{Id: id})
+			return err
+		},
+		"ListNoteMessageReferences": func(h *NoteHandler, ctx context.Context, id string) error {
+			_, err := h.ListNoteMessageReferences(ctx, &notespb.ListNoteMessageReferencesRequest{NoteId: id})
+			return err
+		},
+	}
+
+	tests := []struct {
+		name     string
+		userID   string
+		id       string
+		wantCode codes.Code
+	}{
+		{name: "missing note", userID: "author", id: "nope", wantCode: codes.NotFound},
+		// Reported exactly like a missing note so note IDs can't be probed
+		{name: "another user's note", userID: "other", id: "n1", wantCode: codes.NotFound},
+	}
+
+	for name, call := range calls {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				err := call(newHandler(), userContext(tt.userID, "user"), tt.id)
+				if status.Code(err) != tt.wantCode {
+					t.Fatalf("%s() error = %v, want %s", name, err, tt.wantCode)
+				}
+				if status.Convert(err).Message() != "note not found" {
+					t.Errorf("%s() message = %q, want the same message for missing and hidden notes", name, status.Convert(err).Message())
+				}
+			})
+		}
+	}
+
+	for _, name := range []string{"GetNote", "UpdateNote", "DeleteNote"} {
+		if err := calls[name](newHandler(), userContext("author", "user"), "n1"); err != nil {
+			t.Errorf("%s() by the author: %v", name, err)
+		}
+	}
+}
+
+func TestReferenceLimitStatus(t *testing.T) {
+	err := fmt.Errorf("%w: %d", repositories.ErrReferenceLimitReached, 500)
+
+	for name, got := range map[string]error{
+		"wiki": wikiPageStatus(err, "add message reference to"),
+		"note": noteStatus(err, "add message reference to"),
+	} {
+		if status.Code(got) != codes.FailedPrecondition {
+			t.Errorf("%s: code = %v, want FailedPrecondition", name, status.Code(got))
+		}
+		if !rpcerr.HasReason(got, rpcerr.ReasonReferenceLimit) {
+			t.Errorf("%s: reason = %q, want %q", name, rpcerr.Reason(got), rpcerr.ReasonReferenceLimit)
+		}
+		if msg := status.Convert(got).Message(); msg != "reference limit reached: 500" {
+			t.Errorf("%s: message = %q, want %q", name, msg, "reference limit reached: 500")
+		}
+	}
+}
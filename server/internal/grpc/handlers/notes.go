@@ -2,16 +2,17 @@ package handlers
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"log/slog"
 	"strings"
 
 	"github.com/devilmonastery/hivemind/api/generated/go/commonpb"
 	"github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	"github.com/devilmonastery/hivemind/internal/config"
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
 	"github.com/devilmonastery/hivemind/internal/domain/repositories"
 	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/internal/pkg/textutil"
 	"github.com/devilmonastery/hivemind/internal/pkg/urlutil"
 	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
 	"google.golang.org/grpc/codes"
@@ -24,44 +25,46 @@ type NoteHandler struct {
 	notespb.UnimplementedNoteServiceServer
 	noteService     *services.NoteService
 	discordUserRepo repositories.DiscordUserRepository
+	maxBodyLength   int
+	pageLimits      config.PageLimits
 	log             *slog.Logger
 }
 
-// NewNoteHandler creates a new note handler
-func NewNoteHandler(noteService *services.NoteService, discordUserRepo repositories.DiscordUserRepository) *NoteHandler {
+// NewNoteHandler creates a new note handler.
+// maxBodyLength caps note bodies in bytes; zero or less means no limit.
+// pageLimits sets the default and maximum page size for listing and searching.
+func NewNoteHandler(noteService *services.NoteService, discordUserRepo repositories.DiscordUserRepository, maxBodyLength int, pageLimits config.PageLimits) *NoteHandler {
 	return &NoteHandler{
 		noteService:     noteService,
 		discordUserRepo: discordUserRepo,
+		maxBodyLength:   maxBodyLength,
+		pageLimits:      pageLimits,
 		log:             slog.Default().With(slog.String("handler", "note")),
 	}
 }
 
-// getUserDiscordID extracts Discord ID from context for ACL filtering
-// Returns empty string for admin users (no ACL filtering)
-func (h *NoteHandler) getUserDiscordID(ctx context.Context, userCtx *interceptors.UserContext) string {
-	h.log.Debug("getting user discord ID for ACL",
-		slog.String("user_id", userCtx.UserID),
-		slog.String("role", userCtx.Role))
-
-	// Admin bypass: empty string means no ACL filtering
-	if userCtx.Role == "admin" {
-		h.log.Debug("admin bypass - no ACL filtering")
-		return ""
+// getUserDiscordID returns the Discord ID to filter notes by; see callerDiscordID. Notes
+// are always filtered by author too, so a user without a linked Discord account still gets
+// their own. AutocompleteNoteTitles isn't, and uses callerDiscordID directly.
+func (h *NoteHandler) getUserDiscordID(ctx context.Context, userCtx *interceptors.UserContext) (string, error) {
+	userDiscordID, err := callerDiscordID(ctx, h.discordUserRepo, userCtx)
+	if status.Code(err) == codes.PermissionDenied {
+		return "", nil
 	}
+	return userDiscordID, err
+}
 
-	// Get user's Discord ID for ACL filtering
-	discordUser, err := h.discordUserRepo.GetByUserID(ctx, userCtx.UserID)
+// getOwnNote fetches a note the caller wrote. Other users' notes are reported as
+// missing rather than forbidden, so note IDs can't be probed (see errNoteNotFound).
+func (h *NoteHandler) getOwnNote(ctx context.Context, user *interceptors.UserContext, id, userDiscordID string) (*entities.Note, error) {
+	note, err := h.noteService.GetNote(ctx, id, userDiscordID)
 	if err != nil {
-		h.log.Debug("error getting discord user", slog.String("error", err.Error()))
-		return ""
+		return nil, noteStatus(err, "get")
 	}
-	if discordUser == nil {
-		h.log.Debug("no discord user found", slog.String("user_id", userCtx.UserID))
-		return ""
+	if note.AuthorID != user.UserID {
+		return nil, errNoteNotFound
 	}
-
-	h.log.Debug("found discord ID", slog.String("discord_id", discordUser.DiscordID))
-	return discordUser.DiscordID
+	return note, nil
 }
 
 // CreateNote creates a new note
@@ -80,8 +83,15 @@ func (h *NoteHandler) CreateNote(ctx context.Context, req *notespb.CreateNoteReq
 	if strings.TrimSpace(req.Body) == "" {
 		return nil, status.Error(codes.InvalidArgument, "note body cannot be empty")
 	}
+	if err := checkBodyLength("note", req.Body, h.maxBodyLength); err != nil {
+		return nil, err
+	}
+	if err := checkPreserveID(user, req.PreserveId); err != nil {
+		return nil, err
+	}
 
 	note := &entities.Note{
+		ID:          req.PreserveId,
 		Title:       req.Title,
 		Body:        req.Body,
 		Tags:        req.Tags,
@@ -93,6 +103,15 @@ func (h *NoteHandler) CreateNote(ctx context.Context, req *notespb.CreateNoteReq
 
 	created, err := h.noteService.CreateNote(ctx, note)
 	if err != nil {
+		if errors.Is(err, textutil.ErrInvalidTags) || errors.Is(err, services.ErrContentRejected) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, repositories.ErrNoteTitleTaken) {
+			return nil, noteTitleTakenStatus(err)
+		}
+		if errors.Is(err, services.ErrIDInUse) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to create note: %v", err)
 	}
 
@@ -106,19 +125,41 @@ func (h *NoteHandler) GetNote(ctx context.Context, req *notespb.GetNoteRequest)
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
 
-	note, err := h.noteService.GetNote(ctx, req.Id, userDiscordID)
+	note, err := h.getOwnNote(ctx, userCtx, req.Id, userDiscordID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Error(codes.NotFound, "note not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get note: %v", err)
+		return nil, err
+	}
+
+	return noteToProto(note), nil
+}
+
+// GetNoteBySlug retrieves one of the caller's notes by its permalink slug
+func (h *NoteHandler) GetNoteBySlug(ctx context.Context, req *notespb.GetNoteBySlugRequest) (*notespb.Note, error) {
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+
+	if req.Slug == "" {
+		return nil, status.Error(codes.InvalidArgument, "slug is required")
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Verify ownership - users can only access their own notes
+	note, err := h.noteService.GetNoteBySlug(ctx, req.GuildId, userCtx.UserID, req.Slug, userDiscordID)
+	if err != nil {
+		return nil, noteStatus(err, "get")
+	}
 	if note.AuthorID != userCtx.UserID {
-		return nil, status.Error(codes.PermissionDenied, "you can only view your own notes")
+		return nil, errNoteNotFound
 	}
 
 	return noteToProto(note), nil
@@ -131,19 +172,19 @@ func (h *NoteHandler) UpdateNote(ctx context.Context, req *notespb.UpdateNoteReq
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, user)
+	if err := checkBodyLength("note", req.Body, h.maxBodyLength); err != nil {
+		return nil, err
+	}
 
-	// Get existing note to verify ownership
-	existing, err := h.noteService.GetNote(ctx, req.Id, userDiscordID)
+	userDiscordID, err := h.getUserDiscordID(ctx, user)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Error(codes.NotFound, "note not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get note: %v", err)
+		return nil, err
 	}
 
-	if existing.AuthorID != user.UserID {
-		return nil, status.Error(codes.PermissionDenied, "you can only update your own notes")
+	// Verify ownership
+	existing, err := h.getOwnNote(ctx, user, req.Id, userDiscordID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Validate title is not empty
@@ -162,11 +203,12 @@ func (h *NoteHandler) UpdateNote(ctx context.Context, req *notespb.UpdateNoteReq
 		Body:     req.Body,
 		Tags:     req.Tags,
 		AuthorID: user.UserID,
+		GuildID:  existing.GuildID, // Not changed by the update; checked for unique titles
 	}
 
 	updated, err := h.noteService.UpdateNote(ctx, note, userDiscordID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update note: %v", err)
+		return nil, noteStatus(err, "update")
 	}
 
 	return noteToProto(updated), nil
@@ -179,23 +221,18 @@ func (h *NoteHandler) DeleteNote(ctx context.Context, req *notespb.DeleteNoteReq
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, user)
-
-	// Get existing note to verify ownership
-	existing, err := h.noteService.GetNote(ctx, req.Id, userDiscordID)
+	userDiscordID, err := h.getUserDiscordID(ctx, user)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Error(codes.NotFound, "note not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get note: %v", err)
+		return nil, err
 	}
 
-	if existing.AuthorID != user.UserID {
-		return nil, status.Error(codes.PermissionDenied, "you can only delete your own notes")
+	// Verify ownership
+	if _, err := h.getOwnNote(ctx, user, req.Id, userDiscordID); err != nil {
+		return nil, err
 	}
 
 	if err := h.noteService.DeleteNote(ctx, req.Id, userDiscordID); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete note: %v", err)
+		return nil, noteStatus(err, "delete")
 	}
 
 	return &commonpb.SuccessResponse{Success: true}, nil
@@ -208,7 +245,10 @@ func (h *NoteHandler) ListNotes(ctx context.Context, req *notespb.ListNotesReque
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
 
 	h.log.Debug("listing notes",
 		slog.String("user_id", userCtx.UserID),
@@ -216,17 +256,14 @@ func (h *NoteHandler) ListNotes(ctx context.Context, req *notespb.ListNotesReque
 		slog.String("guild_id", req.GuildId),
 		slog.Int("limit", int(req.Limit)))
 
-	limit := int(req.Limit)
-	if limit == 0 {
-		limit = 20
-	}
+	limit := h.pageLimits.Apply(int(req.Limit))
 
 	orderBy := req.OrderBy
 	if orderBy == "" {
 		orderBy = "created_at"
 	}
 
-	notes, total, err := h.noteService.ListNotes(ctx, userCtx.UserID, req.GuildId, req.Tags, int(req.Limit), int(req.Offset), req.OrderBy, req.Ascending, userDiscordID)
+	notes, total, err := h.noteService.ListNotes(ctx, userCtx.UserID, req.GuildId, req.Tags, limit, int(req.Offset), req.OrderBy, req.Ascending, userDiscordID)
 	if err != nil {
 		h.log.Debug("list notes error",
 			slog.String("error", err.Error()),
@@ -243,11 +280,16 @@ func (h *NoteHandler) ListNotes(ctx context.Context, req *notespb.ListNotesReque
 	protoNotes := make([]*notespb.Note, len(notes))
 	for i, note := range notes {
 		protoNotes[i] = noteToProto(note)
+		if req.OmitBody {
+			protoNotes[i].Body = ""
+		}
 	}
 
 	return &notespb.ListNotesResponse{
-		Notes: protoNotes,
-		Total: int32(total),
+		Notes:      protoNotes,
+		Total:      int32(total),
+		Limit:      int32(limit),
+		Pagination: paginationMeta(total, int(req.Offset), len(notes)),
 	}, nil
 }
 
@@ -258,26 +300,41 @@ func (h *NoteHandler) SearchNotes(ctx context.Context, req *notespb.SearchNotesR
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, user)
+	userDiscordID, err := h.getUserDiscordID(ctx, user)
+	if err != nil {
+		return nil, err
+	}
 
-	limit := int(req.Limit)
-	if limit == 0 {
-		limit = 20
+	limit := h.pageLimits.ApplySearch(int(req.Limit))
+
+	cursor, err := repositories.ParsePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
 	}
 
-	notes, total, err := h.noteService.SearchNotes(ctx, user.UserID, req.Query, req.GuildId, req.Tags, limit, int(req.Offset), userDiscordID)
+	notes, total, next, err := h.noteService.SearchNotes(ctx, user.UserID, req.Query, searchGuildIDs(req.GuildId, req.GuildIds), req.Tags, req.AuthorDiscordId, limit, int(req.Offset), cursor, req.OrderBy, req.Ascending, userDiscordID)
 	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "page_token does not match the requested ordering")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to search notes: %v", err)
 	}
 
 	protoNotes := make([]*notespb.Note, len(notes))
 	for i, note := range notes {
 		protoNotes[i] = noteToProto(note)
+		if req.OmitBody {
+			protoNotes[i].Body = ""
+		}
 	}
 
+	nextPageToken := next.Token()
 	return &notespb.SearchNotesResponse{
-		Notes: protoNotes,
-		Total: int32(total),
+		Notes:         protoNotes,
+		Total:         int32(total),
+		NextPageToken: nextPageToken,
+		Limit:         int32(limit),
+		Pagination:    cursorPaginationMeta(total, int(req.Offset), len(notes), req.PageToken, nextPageToken),
 	}, nil
 }
 
@@ -286,6 +343,7 @@ func noteToProto(note *entities.Note) *notespb.Note {
 	return &notespb.Note{
 		Id:              note.ID,
 		Title:           note.Title,
+		Slug:            note.Slug,
 		Body:            note.Body,
 		Tags:            note.Tags,
 		AuthorId:        note.AuthorID,
@@ -297,6 +355,11 @@ func noteToProto(note *entities.Note) *notespb.Note {
 		SourceChannelId: note.SourceChannelID,
 		CreatedAt:       timestamppb.New(note.CreatedAt),
 		UpdatedAt:       timestamppb.New(note.UpdatedAt),
+		Snippet:         note.Snippet,
+		Rank:            note.Rank,
+		BodyLength:      int32(note.BodyLength),
+		TagCount:        int32(note.TagCount),
+		ReferenceCount:  int32(note.ReferenceCount),
 	}
 }
 
@@ -307,19 +370,14 @@ func (h *NoteHandler) AddNoteMessageReference(ctx context.Context, req *notespb.
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, user)
-
-	// Verify note ownership
-	note, err := h.noteService.GetNote(ctx, req.NoteId, userDiscordID)
+	userDiscordID, err := h.getUserDiscordID(ctx, user)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Error(codes.NotFound, "note not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get note: %v", err)
+		return nil, err
 	}
 
-	if note.AuthorID != user.UserID {
-		return nil, status.Error(codes.PermissionDenied, "you can only add references to your own notes")
+	// Verify ownership
+	if _, err := h.getOwnNote(ctx, user, req.NoteId, userDiscordID); err != nil {
+		return nil, err
 	}
 
 	// Convert proto attachments to entity attachments
@@ -350,7 +408,7 @@ func (h *NoteHandler) AddNoteMessageReference(ctx context.Context, req *notespb.
 
 	created, err := h.noteService.AddMessageReference(ctx, ref, userDiscordID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to add message reference: %v", err)
+		return nil, noteStatus(err, "add message reference to")
 	}
 
 	return noteMessageReferenceToProto(created), nil
@@ -363,19 +421,14 @@ func (h *NoteHandler) ListNoteMessageReferences(ctx context.Context, req *notesp
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, user)
-
-	// Verify note ownership
-	note, err := h.noteService.GetNote(ctx, req.NoteId, userDiscordID)
+	userDiscordID, err := h.getUserDiscordID(ctx, user)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Error(codes.NotFound, "note not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get note: %v", err)
+		return nil, err
 	}
 
-	if note.AuthorID != user.UserID {
-		return nil, status.Error(codes.PermissionDenied, "you can only view references for your own notes")
+	// Verify ownership
+	if _, err := h.getOwnNote(ctx, user, req.NoteId, userDiscordID); err != nil {
+		return nil, err
 	}
 
 	refs, err := h.noteService.ListMessageReferences(ctx, req.NoteId, userDiscordID)
@@ -383,13 +436,16 @@ func (h *NoteHandler) ListNoteMessageReferences(ctx context.Context, req *notesp
 		return nil, status.Errorf(codes.Internal, "failed to list message references: %v", err)
 	}
 
-	protoRefs := make([]*notespb.NoteMessageReference, len(refs))
-	for i, ref := range refs {
-		protoRefs[i] = noteMessageReferenceToProto(ref)
+	start, end := pageBounds(len(refs), int(req.Limit), int(req.Offset))
+	protoRefs := make([]*notespb.NoteMessageReference, 0, end-start)
+	for _, ref := range refs[start:end] {
+		protoRefs = append(protoRefs, noteMessageReferenceToProto(ref))
 	}
 
 	return &notespb.ListNoteMessageReferencesResponse{
 		References: protoRefs,
+		Total:      int32(len(refs)),
+		Pagination: paginationMeta(len(refs), int(req.Offset), len(protoRefs)),
 	}, nil
 }
 
@@ -400,7 +456,10 @@ func (h *NoteHandler) AutocompleteNoteTitles(ctx context.Context, req *notespb.A
 	}
 
 	// Get Discord ID for ACL filtering
-	userDiscordID := h.getUserDiscordID(ctx, user)
+	userDiscordID, err := callerDiscordID(ctx, h.discordUserRepo, user)
+	if err != nil {
+		return nil, err
+	}
 
 	titles, err := h.noteService.AutocompleteNoteTitles(ctx, userDiscordID, req.GuildId)
 	if err != nil {
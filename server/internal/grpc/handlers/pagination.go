@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"github.com/devilmonastery/hivemind/api/generated/go/commonpb"
+)
+
+// pageBounds returns the [start, end) slice bounds for a page of total items.
+// A limit of zero or less means no limit; offsets past the end yield an empty page.
+func pageBounds(total, limit, offset int) (start, end int) {
+	if offset < 0 {
+		offset = 0
+	}
+	start = min(offset, total)
+	end = total
+	if limit > 0 {
+		end = min(start+limit, total)
+	}
+	return start, end
+}
+
+// paginationMeta describes a page of returned items taken at offset from total matches
+func paginationMeta(total, offset, returned int) *commonpb.Pagination {
+	if offset < 0 {
+		offset = 0
+	}
+	return &commonpb.Pagination{
+		Total:    int32(total),
+		Returned: int32(returned),
+		Offset:   int32(offset),
+		HasMore:  offset+returned < total,
+	}
+}
+
+// cursorPaginationMeta describes a page of a list that may be paged by page_token,
+// whose position in the full result set isn't known. When pageToken is set the
+// offset is reported as zero and has_more follows whether a next token was issued.
+func cursorPaginationMeta(total, offset, returned int, pageToken, nextPageToken string) *commonpb.Pagination {
+	if pageToken == "" {
+		return paginationMeta(total, offset, returned)
+	}
+	return &commonpb.Pagination{
+		Total:    int32(total),
+		Returned: int32(returned),
+		HasMore:  nextPageToken != "",
+	}
+}
@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	"github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	"github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+)
+
+func TestPageBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		limit     int
+		offset    int
+		wantStart int
+		wantEnd   int
+	}{
+		{name: "no limit returns everything", total: 12, limit: 0, offset: 0, wantStart: 0, wantEnd: 12},
+		{name: "first page", total: 12, limit: 5, offset: 0, wantStart: 0, wantEnd: 5},
+		{name: "middle page", total: 12, limit: 5, offset: 5, wantStart: 5, wantEnd: 10},
+		{name: "partial last page", total: 12, limit: 5, offset: 10, wantStart: 10, wantEnd: 12},
+		{name: "offset past end", total: 12, limit: 5, offset: 20, wantStart: 12, wantEnd: 12},
+		{name: "offset without limit", total: 12, limit: 0, offset: 4, wantStart: 4, wantEnd: 12},
+		{name: "negative offset", total: 12, limit: 5, offset: -3, wantStart: 0, wantEnd: 5},
+		{name: "empty", total: 0, limit: 5, offset: 0, wantStart: 0, wantEnd: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := pageBounds(tt.total, tt.limit, tt.offset)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("pageBounds(%d, %d, %d) = [%d, %d), want [%d, %d)",
+					tt.total, tt.limit, tt.offset, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestPaginationMeta(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		offset      int
+		returned    int
+		wantOffset  int32
+		wantHasMore bool
+	}{
+		{name: "first page", total: 12, offset: 0, returned: 5, wantOffset: 0, wantHasMore: true},
+		{name: "middle page", total: 12, offset: 5, returned: 5, wantOffset: 5, wantHasMore: true},
+		{name: "last page", total: 12, offset: 10, returned: 2, wantOffset: 10, wantHasMore: false},
+		{name: "exactly full last page", total: 10, offset: 5, returned: 5, wantOffset: 5, wantHasMore: false},
+		{name: "offset past end", total: 12, offset: 20, returned: 0, wantOffset: 20, wantHasMore: false},
+		{name: "negative offset", total: 12, offset: -3, returned: 5, wantOffset: 0, wantHasMore: true},
+		{name: "empty", total: 0, offset: 0, returned: 0, wantOffset: 0, wantHasMore: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := paginationMeta(tt.total, tt.offset, tt.returned)
+			if got.Total != int32(tt.total) || got.Returned != int32(tt.returned) || got.Offset != tt.wantOffset || got.HasMore != tt.wantHasMore {
+				t.Errorf("paginationMeta(%d, %d, %d) = %v, want offset %d has_more %v",
+					tt.total, tt.offset, tt.returned, got, tt.wantOffset, tt.wantHasMore)
+			}
+		})
+	}
+}
+
+func TestCursorPaginationMeta(t *testing.T) {
+	// Without a page token the offset is known and has_more is computed from it
+	if got := cursorPaginationMeta(12, 5, 5, "", "next"); got.Offset != 5 || !got.HasMore {
+		t.Errorf("offset paging = %v, want offset 5 with more", got)
+	}
+
+	// With a page token, has_more follows whether the server issued another token
+	if got := cursorPaginationMeta(12, 0, 5, "tok", "next"); got.Offset != 0 || !got.HasMore {
+		t.Errorf("middle cursor page = %v, want more", got)
+	}
+	if got := cursorPaginationMeta(12, 0, 2, "tok", ""); got.HasMore || got.Returned != 2 || got.Total != 12 {
+		t.Errorf("last cursor page = %v, want no more", got)
+	}
+}
+
+// pagedQuoteRepo lists total quotes, honouring limit and offset
+type pagedQuoteRepo struct {
+	repositories.QuoteRepository
+	total int
+}
+
+func (r *pagedQuoteRepo) List(ctx context.Context, guildID string, limit, offset int, orderBy string, ascending bool, userDiscordID string) ([]*entities.Quote, int, error) {
+	start, end := pageBounds(r.total, limit, offset)
+	quotes := make([]*entities.Quote, end-start)
+	for i := range quotes {
+		quotes[i] = &entities.Quote{ID: fmt.Sprintf("q%d", start+i)}
+	}
+	return quotes, r.total, nil
+}
+
+func TestListQuotesReportsPagination(t *testing.T) {
+	ctx := userContext("u1", "admin")
+	limits := config.PageLimits{DefaultLimit: 20, MaxLimit: 100}
+	handler := NewQuoteHandler(services.NewQuoteService(&pagedQuoteRepo{total: 12}, nil, nil, nil, nil), nil, 0, limits)
+
+	tests := []struct {
+		name         string
+		offset       int32
+		wantReturned int32
+		wantHasMore  bool
+	}{
+		{name: "first page", offset: 0, wantReturned: 5, wantHasMore: true},
+		{name: "middle page", offset: 5, wantReturned: 5, wantHasMore: true},
+		{name: "last page", offset: 10, wantReturned: 2, wantHasMore: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := handler.ListQuotes(ctx, &quotespb.ListQuotesRequest{GuildId: "g1", Limit: 5, Offset: tt.offset})
+			if err != nil {
+				t.Fatalf("ListQuotes() error = %v", err)
+			}
+			got := resp.GetPagination()
+			if got.GetTotal() != 12 || got.GetOffset() != tt.offset || got.GetReturned() != tt.wantReturned || got.GetHasMore() != tt.wantHasMore {
+				t.Errorf("pagination = %v, want total 12 offset %d returned %d has_more %v",
+					got, tt.offset, tt.wantReturned, tt.wantHasMore)
+			}
+			if int32(len(resp.Quotes)) != got.GetReturned() {
+				t.Errorf("returned = %d, but response has %d quotes", got.GetReturned(), len(resp.Quotes))
+			}
+		})
+	}
+}
+
+// Repositories that record the limit each list and search call was given
+
+type limitRecordingWikiRepo struct {
+	repositories.WikiPageRepository
+	limit int
+}
+
+func (r *limitRecordingWikiRepo) List(ctx context.Context, guildID string, limit, offset int, cursor *repositories.PageCursor, orderBy string, ascending bool, userDiscordID string) ([]*entities.WikiPage, int, *repositories.PageCursor, error) {
+	r.limit = limit
+	return nil, 0, nil, nil
+}
+
+func (r *limitRecordingWikiRepo) Search(ctx context.Context, guildIDs []string, query string, tags []string, authorDiscordID string, limit, offset int, userDiscordID string) ([]*entities.WikiPage, int, error) {
+	r.limit = limit
+	return nil, 0, nil
+}
+
+type limitRecordingNoteRepo struct {
+	repositories.NoteRepository
+	limit int
+}
+
+func (r *limitRecordingNoteRepo) List(ctx context.Context, authorID, guildID string, tags []string, limit, offset int, orderBy string, ascending bool, userDiscordID string) ([]*entities.Note, int, error) {
+	r.limit = limit
+	return nil, 0, nil
+}
+
+func (r *limitRecordingNoteRepo) Search(ctx context.Context, authorID, query string, guildIDs []string, tags []string, authorDiscordID string, limit, offset int, cursor *repositories.PageCursor, orderBy string, ascending bool, userDiscordID string) ([]*entities.Note, int, *repositories.PageCursor, error) {
+	r.limit = limit
+	return nil, 0, nil, nil
+}
+
+type limitRecordingQuoteRepo struct {
+	repositories.QuoteRepository
+	limit int
+}
+
+func (r *limitRecordingQuoteRepo) List(ctx context.Context, guildID string, limit, offset int, orderBy string, ascending bool, userDiscordID string) ([]*entities.Quote, int, error) {
+	r.limit = limit
+	return nil, 0, nil
+}
+
+func (r *limitRecordingQuoteRepo) Search(ctx context.Context, guildIDs []string, query string, tags []string, limit, offset int, orderBy string, ascending bool, userDiscordID string) ([]*entities.Quote, int, error) {
+	r.limit = limit
+	return nil, 0, nil
+}
+
+func TestHandlersApplyPageLimits(t *testing.T) {
+	ctx := userContext("u1", "admin")
+	limits := config.PageLimits{DefaultLimit: 20, MaxLimit: 100}
+
+	wikiRepo := &limitRecordingWikiRepo{}
+	noteRepo := &limitRecordingNoteRepo{}
+	quoteRepo := &limitRecordingQuoteRepo{}
+	wiki := NewWikiHandler(services.NewWikiService(wikiRepo, nil, nil, nil, nil, nil, nil, nil, nil), nil, nil, nil, 0, limits, slog.Default())
+	notes := NewNoteHandler(services.NewNoteService(noteRepo, nil, nil, nil, nil, nil, nil), nil, 0, limits)
+	quotes := NewQuoteHandler(services.NewQuoteService(quoteRepo, nil, nil, nil, nil), nil, 0, limits)
+
+	// Each call returns the limit reported in the response and the one the repository got
+	calls := map[string]func(limit int32) (int32, int, error){
+		"ListWikiPages": func(limit int32) (int32, int, error) {
+			resp, err := wiki.ListWikiPages(ctx, &wikipb.ListWikiPagesRequest{Limit: limit})
+			return resp.GetLimit(), wikiRepo.limit, err
+		},
+		"SearchWikiPages": func(limit int32) (int32, int, error) {
+			resp, err := wiki.SearchWikiPages(ctx, &wikipb.SearchWikiPagesRequest{Query: "q", Limit: limit})
+			return resp.GetLimit(), wikiRepo.limit, err
+		},
+		"ListNotes": func(limit int32) (int32, int, error) {
+			resp, err := notes.ListNotes(ctx, &notespb.ListNotesRequest{Limit: limit})
+			return resp.GetLimit(), noteRepo.limit, err
+		},
+		"SearchNotes": func(limit int32) (int32, int, error) {
+			resp, err := notes.SearchNotes(ctx, &notespb.SearchNotesRequest{Query: "q", Limit: limit})
+			return resp.GetLimit(), noteRepo.limit, err
+		},
+		"ListQuotes": func(limit int32) (int32, int, error) {
+			resp, err := quotes.ListQuotes(ctx, &quotespb.ListQuotesRequest{Limit: limit})
+			return resp.GetLimit(), quoteRepo.limit, err
+		},
+		"SearchQuotes": func(limit int32) (int32, int, error) {
+			resp, err := quotes.SearchQuotes(ctx, &quotespb.SearchQuotesRequest{Query: "q", Limit: limit})
+			return resp.GetLimit(), quoteRepo.limit, err
+		},
+	}
+
+	tests := []struct {
+		name      string
+		requested int32
+		want      int
+	}{
+		{name: "zero uses the default", requested: 0, want: 20},
+		{name: "negative uses the default", requested: -5, want: 20},
+		{name: "within the max is kept", requested: 40, want: 40},
+		{name: "over the max is clamped", requested: 100000, want: 100},
+	}
+
+	for name, call := range calls {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				reported, used, err := call(tt.requested)
+				if err != nil {
+					t.Fatalf("%s() error = %v", name, err)
+				}
+				if used != tt.want || int(reported) != tt.want {
+					t.Errorf("%s(limit=%d) used %d and reported %d, want %d", name, tt.requested, used, reported, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestSearchUsesSearchDefaultLimit(t *testing.T) {
+	ctx := userContext("u1", "admin")
+	limits := config.PageLimits{DefaultLimit: 50, SearchDefaultLimit: 10, MaxLimit: 100}
+
+	wikiRepo := &limitRecordingWikiRepo{}
+	wiki := NewWikiHandler(services.NewWikiService(wikiRepo, nil, nil, nil, nil, nil, nil, nil, nil), nil, nil, nil, 0, limits, slog.Default())
+
+	if _, err := wiki.SearchWikiPages(ctx, &wikipb.SearchWikiPagesRequest{Query: "q"}); err != nil || wikiRepo.limit != 10 {
+		t.Errorf("SearchWikiPages() used limit %d (err %v), want the search default 10", wikiRepo.limit, err)
+	}
+	if _, err := wiki.ListWikiPages(ctx, &wikipb.ListWikiPagesRequest{}); err != nil || wikiRepo.limit != 50 {
+		t.Errorf("ListWikiPages() used limit %d (err %v), want the list default 50", wikiRepo.limit, err)
+	}
+	if _, err := wiki.SearchWikiPages(ctx, &wikipb.SearchWikiPagesRequest{Query: "q", Limit: 500}); err != nil || wikiRepo.limit != 100 {
+		t.Errorf("SearchWikiPages(limit=500) used limit %d (err %v), want the max 100", wikiRepo.limit, err)
+	}
+}
+
+// pagedWikiRefRepo holds total references on every page
+type pagedWikiRefRepo struct {
+	repositories.WikiMessageReferenceRepository
+	total int
+}
+
+func (r *pagedWikiRefRepo) GetByPageID(ctx context.Context, pageID string) ([]*entities.WikiMessageReference, error) {
+	refs := make([]*entities.WikiMessageReference, r.total)
+	for i := range refs {
+		refs[i] = &entities.WikiMessageReference{ID: fmt.Sprintf("r%d", i), WikiPageID: pageID}
+	}
+	return refs, nil
+}
+
+func TestListWikiMessageReferencesReportsPagination(t *testing.T) {
+	wikiService := services.NewWikiService(nil, &pagedWikiRefRepo{total: 12}, nil, nil, nil, nil, nil, nil, nil)
+	h := NewWikiHandler(wikiService, nil, nil, nil, 0, config.PageLimits{}, slog.Default())
+
+	tests := []struct {
+		offset      int32
+		wantHasMore bool
+	}{
+		{offset: 0, wantHasMore: true},
+		{offset: 5, wantHasMore: true},
+		{offset: 10, wantHasMore: false},
+	}
+	for _, tt := range tests {
+		resp, err := h.ListWikiMessageReferences(context.Background(), &wikipb.ListWikiMessageReferencesRequest{
+			WikiPageId: "p1",
+			Limit:      5,
+			Offset:     tt.offset,
+		})
+		if err != nil {
+			t.Fatalf("offset %d: ListWikiMessageReferences() error = %v", tt.offset, err)
+		}
+		got := resp.GetPagination()
+		if got.GetTotal() != 12 || got.GetReturned() != int32(len(resp.References)) || got.GetHasMore() != tt.wantHasMore {
+			t.Errorf("offset %d: pagination = %v, want total 12, %d returned, has_more %v",
+				tt.offset, got, len(resp.References), tt.wantHasMore)
+		}
+	}
+}
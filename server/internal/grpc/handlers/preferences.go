@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/devilmonastery/hivemind/api/generated/go/preferencespb"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PreferencesHandler implements the PreferencesService gRPC handler
+type PreferencesHandler struct {
+	preferencespb.UnimplementedPreferencesServiceServer
+	preferencesService *services.PreferencesService
+	discordUserRepo    repositories.DiscordUserRepository
+	log                *slog.Logger
+}
+
+// NewPreferencesHandler creates a new preferences handler
+func NewPreferencesHandler(preferencesService *services.PreferencesService, discordUserRepo repositories.DiscordUserRepository) *PreferencesHandler {
+	return &PreferencesHandler{
+		preferencesService: preferencesService,
+		discordUserRepo:    discordUserRepo,
+		log:                slog.Default().With(slog.String("handler", "preferences")),
+	}
+}
+
+// GetPreferences returns the caller's preferences
+func (h *PreferencesHandler) GetPreferences(ctx context.Context, req *preferencespb.GetPreferencesRequest) (*preferencespb.UserPreferences, error) {
+	user, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+
+	prefs, err := h.preferencesService.GetPreferences(ctx, user.UserID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get preferences: %v", err)
+	}
+
+	return h.toProtoPreferences(ctx, prefs), nil
+}
+
+// SetDefaultGuild sets or clears the caller's default guild
+func (h *PreferencesHandler) SetDefaultGuild(ctx context.Context, req *preferencespb.SetDefaultGuildRequest) (*preferencespb.UserPreferences, error) {
+	user, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+
+	// Bot requests carry the Discord ID; web/CLI users resolve it from their linked account
+	discordID := user.DiscordID
+	if discordID == "" {
+		if discordUser, err := h.discordUserRepo.GetByUserID(ctx, user.UserID); err == nil && discordUser != nil {
+			discordID = discordUser.DiscordID
+		}
+	}
+
+	prefs, err := h.preferencesService.SetDefaultGuild(ctx, user.UserID, discordID, req.GuildId)
+	if err != nil {
+		if errors.Is(err, services.ErrNotGuildMember) {
+			return nil, status.Error(codes.PermissionDenied, "you are not a member of that guild")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to set default guild: %v", err)
+	}
+
+	h.log.Info("default guild updated",
+		slog.String("user_id", user.UserID),
+		slog.String("guild_id", req.GuildId))
+
+	return h.toProtoPreferences(ctx, prefs), nil
+}
+
+// SetLeaderboardOptOut keeps the caller off quote leaderboards, or puts them back on
+func (h *PreferencesHandler) SetLeaderboardOptOut(ctx context.Context, req *preferencespb.SetLeaderboardOptOutRequest) (*preferencespb.UserPreferences, error) {
+	user, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+
+	prefs, err := h.preferencesService.SetLeaderboardOptOut(ctx, user.UserID, req.OptOut)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set leaderboard opt-out: %v", err)
+	}
+
+	h.log.Info("leaderboard opt-out updated",
+		slog.String("user_id", user.UserID),
+		slog.Bool("opt_out", req.OptOut))
+
+	return h.toProtoPreferences(ctx, prefs), nil
+}
+
+// toProtoPreferences converts domain preferences to protobuf, resolving the guild name
+func (h *PreferencesHandler) toProtoPreferences(ctx context.Context, prefs *entities.UserPreferences) *preferencespb.UserPreferences {
+	proto := &preferencespb.UserPreferences{
+		UserId:            prefs.UserID,
+		DefaultGuildId:    stringPtrValue(prefs.DefaultGuildID),
+		LeaderboardOptOut: prefs.LeaderboardOptOut,
+	}
+	if proto.DefaultGuildId != "" {
+		proto.DefaultGuildName = h.preferencesService.GuildName(ctx, proto.DefaultGuildId)
+	}
+	if !prefs.UpdatedAt.IsZero() {
+		proto.UpdatedAt = timestamppb.New(prefs.UpdatedAt)
+	}
+	return proto
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+)
+
+// maxPreservedIDLength bounds caller-supplied IDs; Snowflake IDs are at most 20 digits
+const maxPreservedIDLength = 64
+
+// checkPreserveID validates a caller-supplied ID for new content.
+// Only admins may supply one (imports keep original IDs this way); an empty id is always allowed.
+func checkPreserveID(userCtx *interceptors.UserContext, id string) error {
+	if id == "" {
+		return nil
+	}
+	if userCtx.Role != "admin" {
+		return status.Error(codes.PermissionDenied, "only admins can supply preserve_id")
+	}
+	if len(id) > maxPreservedIDLength || strings.TrimSpace(id) != id || strings.ContainsAny(id, " \t\r\n/") {
+		return status.Errorf(codes.InvalidArgument, "invalid preserve_id: %q", id)
+	}
+	return nil
+}
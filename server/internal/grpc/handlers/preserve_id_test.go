@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	"github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckPreserveID(t *testing.T) {
+	admin := &interceptors.UserContext{UserID: "u1", Role: "admin"}
+	user := &interceptors.UserContext{UserID: "u2", Role: "user"}
+
+	tests := []struct {
+		name     string
+		userCtx  *interceptors.UserContext
+		id       string
+		wantCode codes.Code
+	}{
+		{name: "empty for user", userCtx: user, id: "", wantCode: codes.OK},
+		{name: "admin", userCtx: admin, id: "1234567890123456789", wantCode: codes.OK},
+		{name: "non-admin", userCtx: user, id: "1234567890123456789", wantCode: codes.PermissionDenied},
+		{name: "whitespace", userCtx: admin, id: "12 34", wantCode: codes.InvalidArgument},
+		{name: "too long", userCtx: admin, id: strings.Repeat("1", maxPreservedIDLength+1), wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(checkPreserveID(tt.userCtx, tt.id)); got != tt.wantCode {
+				t.Errorf("checkPreserveID() code = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestHandlersRejectPreserveIDFromNonAdmins(t *testing.T) {
+	ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+		UserID: "u1",
+		Role:   "user",
+	})
+
+	notes := NewNoteHandler(nil, nil, 0, config.PageLimits{})
+	_, err := notes.CreateNote(ctx, &notespb.CreateNoteRequest{Title: "t", Body: "b", PreserveId: "42"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CreateNote() code = %v, want PermissionDenied", status.Code(err))
+	}
+
+	quotes := NewQuoteHandler(nil, nil, 0, config.PageLimits{})
+	_, err = quotes.CreateQuote(ctx, &quotespb.CreateQuoteRequest{Body: "b", PreserveId: "42"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CreateQuote() code = %v, want PermissionDenied", status.Code(err))
+	}
+}
@@ -5,12 +5,16 @@ import (
 	"database/sql"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/devilmonastery/hivemind/api/generated/go/commonpb"
 	"github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	"github.com/devilmonastery/hivemind/internal/config"
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
 	"github.com/devilmonastery/hivemind/internal/domain/repositories"
 	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
+	"github.com/devilmonastery/hivemind/internal/pkg/textutil"
 	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -22,44 +26,27 @@ type QuoteHandler struct {
 	quotespb.UnimplementedQuoteServiceServer
 	quoteService    *services.QuoteService
 	discordUserRepo repositories.DiscordUserRepository
+	maxBodyLength   int
+	pageLimits      config.PageLimits
 	log             *slog.Logger
 }
 
-// NewQuoteHandler creates a new quote handler
-func NewQuoteHandler(quoteService *services.QuoteService, discordUserRepo repositories.DiscordUserRepository) *QuoteHandler {
+// NewQuoteHandler creates a new quote handler.
+// maxBodyLength caps quote bodies in bytes; zero or less means no limit.
+// pageLimits sets the default and maximum page size for listing and searching.
+func NewQuoteHandler(quoteService *services.QuoteService, discordUserRepo repositories.DiscordUserRepository, maxBodyLength int, pageLimits config.PageLimits) *QuoteHandler {
 	return &QuoteHandler{
 		quoteService:    quoteService,
 		discordUserRepo: discordUserRepo,
+		maxBodyLength:   maxBodyLength,
+		pageLimits:      pageLimits,
 		log:             slog.Default().With(slog.String("handler", "quote")),
 	}
 }
 
-// getUserDiscordID extracts Discord ID from context for ACL filtering
-// Returns empty string for admin users (no ACL filtering)
-func (h *QuoteHandler) getUserDiscordID(ctx context.Context, userCtx *interceptors.UserContext) string {
-	h.log.Debug("getting user discord ID for ACL",
-		slog.String("user_id", userCtx.UserID),
-		slog.String("role", userCtx.Role))
-
-	// Admin bypass: empty string means no ACL filtering
-	if userCtx.Role == "admin" {
-		h.log.Debug("admin bypass - no ACL filtering")
-		return ""
-	}
-
-	// Get user's Discord ID for ACL filtering
-	discordUser, err := h.discordUserRepo.GetByUserID(ctx, userCtx.UserID)
-	if err != nil {
-		h.log.Debug("error getting discord user", slog.String("error", err.Error()))
-		return ""
-	}
-	if discordUser == nil {
-		h.log.Debug("no discord user found", slog.String("user_id", userCtx.UserID))
-		return ""
-	}
-
-	h.log.Debug("found discord ID", slog.String("discord_id", discordUser.DiscordID))
-	return discordUser.DiscordID
+// getUserDiscordID returns the Discord ID to filter content by; see callerDiscordID
+func (h *QuoteHandler) getUserDiscordID(ctx context.Context, userCtx *interceptors.UserContext) (string, error) {
+	return callerDiscordID(ctx, h.discordUserRepo, userCtx)
 }
 
 // CreateQuote creates a new quote
@@ -69,7 +56,15 @@ func (h *QuoteHandler) CreateQuote(ctx context.Context, req *quotespb.CreateQuot
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
+	if err := checkBodyLength("quote", req.Body, h.maxBodyLength); err != nil {
+		return nil, err
+	}
+	if err := checkPreserveID(user, req.PreserveId); err != nil {
+		return nil, err
+	}
+
 	quote := &entities.Quote{
+		ID:                       req.PreserveId,
 		Body:                     req.Body,
 		Tags:                     req.Tags,
 		GuildID:                  req.GuildId,
@@ -83,13 +78,45 @@ func (h *QuoteHandler) CreateQuote(ctx context.Context, req *quotespb.CreateQuot
 		SourceMsgTimestamp:       req.SourceMsgTimestamp.AsTime(),
 	}
 
+	userDiscordID, err := h.getUserDiscordID(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
 	created, err := h.quoteService.CreateQuote(ctx, quote)
+	var duplicate *services.DuplicateQuoteError
+	if errors.As(err, &duplicate) {
+		// Only callers who can see the existing quote are told which one it is
+		existing, getErr := h.quoteService.GetQuote(ctx, duplicate.ExistingID, userDiscordID)
+		if errors.Is(getErr, sql.ErrNoRows) {
+			return nil, status.Error(codes.AlreadyExists, services.ErrDuplicateQuote.Error())
+		}
+		if getErr != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get duplicate quote: %v", getErr)
+		}
+		if req.RejectDuplicate {
+			return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+		}
+		resp := quoteToProto(existing)
+		resp.WasDuplicate = true
+		return resp, nil
+	}
 	if err != nil {
+		if errors.Is(err, textutil.ErrInvalidTags) || errors.Is(err, services.ErrContentRejected) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, services.ErrIDInUse) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		var cooldown *repositories.QuoteCooldownError
+		if errors.As(err, &cooldown) {
+			return nil, rpcerr.NewWithRetryDelay(codes.ResourceExhausted, rpcerr.ReasonQuoteCooldown,
+				repositories.ErrQuoteCooldown.Error(), cooldown.Remaining)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to create quote: %v", err)
 	}
 
 	// Fetch the quote back to populate guild_nick fields from guild_members JOIN
-	userDiscordID := h.getUserDiscordID(ctx, user)
 	fetched, err := h.quoteService.GetQuote(ctx, created.ID, userDiscordID)
 	if err != nil {
 		h.log.Warn("Failed to fetch quote after creation, returning without guild nicks",
@@ -108,7 +135,10 @@ func (h *QuoteHandler) GetQuote(ctx context.Context, req *quotespb.GetQuoteReque
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
 
 	quote, err := h.quoteService.GetQuote(ctx, req.Id, userDiscordID)
 	if err != nil {
@@ -121,6 +151,66 @@ func (h *QuoteHandler) GetQuote(ctx context.Context, req *quotespb.GetQuoteReque
 	return quoteToProto(quote), nil
 }
 
+// GetQuoteByCode retrieves a quote by its permalink short code
+func (h *QuoteHandler) GetQuoteByCode(ctx context.Context, req *quotespb.GetQuoteByCodeRequest) (*quotespb.Quote, error) {
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+
+	if req.GuildId == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "guild_id and code are required")
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := h.quoteService.GetQuoteByCode(ctx, req.GuildId, req.Code, userDiscordID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "quote not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get quote: %v", err)
+	}
+	if quote == nil {
+		return nil, status.Error(codes.NotFound, "quote not found")
+	}
+
+	return quoteToProto(quote), nil
+}
+
+// GetQuoteBySourceMessage retrieves the quote saved from a Discord message
+func (h *QuoteHandler) GetQuoteBySourceMessage(ctx context.Context, req *quotespb.GetQuoteBySourceMessageRequest) (*quotespb.Quote, error) {
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+
+	if req.GuildId == "" || req.SourceMsgId == "" {
+		return nil, status.Error(codes.InvalidArgument, "guild_id and source_msg_id are required")
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := h.quoteService.GetQuoteBySourceMessage(ctx, req.GuildId, req.SourceMsgId, userDiscordID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "quote not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get quote: %v", err)
+	}
+	if quote == nil {
+		return nil, status.Error(codes.NotFound, "quote not found")
+	}
+
+	return quoteToProto(quote), nil
+}
+
 // DeleteQuote deletes a quote
 func (h *QuoteHandler) DeleteQuote(ctx context.Context, req *quotespb.DeleteQuoteRequest) (*commonpb.SuccessResponse, error) {
 	user, err := interceptors.GetUserFromContext(ctx)
@@ -128,7 +218,10 @@ func (h *QuoteHandler) DeleteQuote(ctx context.Context, req *quotespb.DeleteQuot
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, user)
+	userDiscordID, err := h.getUserDiscordID(ctx, user)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get existing quote to verify ownership
 	existing, err := h.quoteService.GetQuote(ctx, req.Id, userDiscordID)
@@ -157,7 +250,14 @@ func (h *QuoteHandler) UpdateQuote(ctx context.Context, req *quotespb.UpdateQuot
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, user)
+	if err := checkBodyLength("quote", req.Body, h.maxBodyLength); err != nil {
+		return nil, err
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, user)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get existing quote to verify ownership
 	existing, err := h.quoteService.GetQuote(ctx, req.Id, userDiscordID)
@@ -168,13 +268,17 @@ func (h *QuoteHandler) UpdateQuote(ctx context.Context, req *quotespb.UpdateQuot
 		return nil, status.Errorf(codes.Internal, "failed to get quote: %v", err)
 	}
 
-	if existing.AuthorID != user.UserID {
+	// Admins may edit any quote, e.g. to correct a wrong attribution
+	if existing.AuthorID != user.UserID && user.Role != "admin" {
 		return nil, status.Error(codes.PermissionDenied, "you can only edit quotes you saved")
 	}
 
 	// Update the quote
-	updated, err := h.quoteService.UpdateQuote(ctx, req.Id, req.Body, req.Tags, userDiscordID)
+	updated, err := h.quoteService.UpdateQuote(ctx, req.Id, req.Body, req.Tags, req.SourceMsgAuthorUsername, userDiscordID)
 	if err != nil {
+		if errors.Is(err, textutil.ErrInvalidTags) || errors.Is(err, services.ErrContentRejected) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update quote: %v", err)
 	}
 
@@ -188,13 +292,13 @@ func (h *QuoteHandler) ListQuotes(ctx context.Context, req *quotespb.ListQuotesR
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
-
-	limit := int(req.Limit)
-	if limit == 0 {
-		limit = 20
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
 	}
 
+	limit := h.pageLimits.Apply(int(req.Limit))
+
 	orderBy := req.OrderBy
 	if orderBy == "" {
 		orderBy = "created_at"
@@ -208,11 +312,16 @@ func (h *QuoteHandler) ListQuotes(ctx context.Context, req *quotespb.ListQuotesR
 	protoQuotes := make([]*quotespb.Quote, len(quotes))
 	for i, quote := range quotes {
 		protoQuotes[i] = quoteToProto(quote)
+		if req.OmitBody {
+			protoQuotes[i].Body = ""
+		}
 	}
 
 	return &quotespb.ListQuotesResponse{
-		Quotes: protoQuotes,
-		Total:  int32(total),
+		Quotes:     protoQuotes,
+		Total:      int32(total),
+		Limit:      int32(limit),
+		Pagination: paginationMeta(total, int(req.Offset), len(quotes)),
 	}, nil
 }
 
@@ -223,14 +332,14 @@ func (h *QuoteHandler) SearchQuotes(ctx context.Context, req *quotespb.SearchQuo
 		return nil, status.Error(codes.Unauthenticated, "user context not found")
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
-
-	limit := int(req.Limit)
-	if limit == 0 {
-		limit = 20
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
 	}
 
-	quotes, total, err := h.quoteService.SearchQuotes(ctx, req.GuildId, req.Query, req.Tags, limit, int(req.Offset), userDiscordID)
+	limit := h.pageLimits.ApplySearch(int(req.Limit))
+
+	quotes, total, err := h.quoteService.SearchQuotes(ctx, searchGuildIDs(req.GuildId, req.GuildIds), req.Query, req.Tags, limit, int(req.Offset), req.OrderBy, req.Ascending, userDiscordID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to search quotes: %v", err)
 	}
@@ -238,11 +347,16 @@ func (h *QuoteHandler) SearchQuotes(ctx context.Context, req *quotespb.SearchQuo
 	protoQuotes := make([]*quotespb.Quote, len(quotes))
 	for i, quote := range quotes {
 		protoQuotes[i] = quoteToProto(quote)
+		if req.OmitBody {
+			protoQuotes[i].Body = ""
+		}
 	}
 
 	return &quotespb.SearchQuotesResponse{
-		Quotes: protoQuotes,
-		Total:  int32(total),
+		Quotes:     protoQuotes,
+		Total:      int32(total),
+		Limit:      int32(limit),
+		Pagination: paginationMeta(total, int(req.Offset), len(quotes)),
 	}, nil
 }
 
@@ -264,10 +378,62 @@ func (h *QuoteHandler) GetRandomQuote(ctx context.Context, req *quotespb.GetRand
 	return quoteToProto(quote), nil
 }
 
+// GetQuoteStats returns quote totals and leaderboards for a guild
+func (h *QuoteHandler) GetQuoteStats(ctx context.Context, req *quotespb.GetQuoteStatsRequest) (*quotespb.GetQuoteStatsResponse, error) {
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+
+	if req.GuildId == "" {
+		return nil, status.Error(codes.InvalidArgument, "guild_id is required")
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if req.Since != nil {
+		since = req.Since.AsTime()
+	}
+
+	stats, err := h.quoteService.GetQuoteStats(ctx, req.GuildId, int(req.Limit), since, userDiscordID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get quote stats: %v", err)
+	}
+
+	return &quotespb.GetQuoteStatsResponse{
+		TotalQuotes: int32(stats.TotalQuotes),
+		TopQuoters:  quoteStatsEntriesToProto(stats.TopQuoters),
+		MostQuoted:  quoteStatsEntriesToProto(stats.MostQuoted),
+	}, nil
+}
+
+// quoteStatsEntriesToProto converts leaderboard rows to protobuf
+func quoteStatsEntriesToProto(entries []entities.QuoteStatsEntry) []*quotespb.QuoteStatsEntry {
+	protoEntries := make([]*quotespb.QuoteStatsEntry, len(entries))
+	for i, entry := range entries {
+		protoEntries[i] = &quotespb.QuoteStatsEntry{
+			DiscordId: entry.DiscordID,
+			Count:     int32(entry.Count),
+		}
+	}
+	return protoEntries
+}
+
 // quoteToProto converts a domain quote to protobuf
 func quoteToProto(quote *entities.Quote) *quotespb.Quote {
+	// Use display name from view, falling back to the saved name for corrected attributions
+	sourceAuthorName := quote.SourceMsgAuthorDisplayName
+	if sourceAuthorName == "" {
+		sourceAuthorName = quote.SourceMsgAuthorUsername
+	}
+
 	proto := &quotespb.Quote{
 		Id:                             quote.ID,
+		ShortCode:                      quote.ShortCode,
 		Body:                           quote.Body,
 		Tags:                           quote.Tags,
 		AuthorId:                       quote.AuthorID,
@@ -282,11 +448,15 @@ func quoteToProto(quote *entities.Quote) *quotespb.Quote {
 		SourceChannelId:                quote.SourceChannelID,
 		SourceChannelName:              quote.SourceChannelName,
 		SourceMsgAuthorDiscordId:       quote.SourceMsgAuthorDiscordID,
-		SourceMsgAuthorUsername:        quote.SourceMsgAuthorDisplayName,     // Use display name from view
+		SourceMsgAuthorUsername:        sourceAuthorName,
 		SourceMsgAuthorGuildNick:       quote.SourceMsgAuthorGuildNick,       // Keep for backward compatibility
 		SourceMsgAuthorGuildAvatarHash: quote.SourceMsgAuthorGuildAvatarHash, // Guild-specific avatar of who said it
 		SourceMsgAuthorUserAvatarHash:  quote.SourceMsgAuthorUserAvatarHash,  // Global user avatar of who said it
 		CreatedAt:                      timestamppb.New(quote.CreatedAt),
+		Snippet:                        quote.Snippet,
+		Rank:                           quote.Rank,
+		BodyLength:                     int32(quote.BodyLength),
+		TagCount:                       int32(quote.TagCount),
 	}
 	if !quote.SourceMsgTimestamp.IsZero() {
 		proto.SourceMsgTimestamp = timestamppb.New(quote.SourceMsgTimestamp)
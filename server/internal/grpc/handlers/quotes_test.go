@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+)
+
+type fakeQuoteRepo struct {
+	repositories.QuoteRepository
+	quote *entities.Quote
+	// members are the Discord IDs in the quote's guild; nil lets everyone in
+	members map[string]bool
+}
+
+func (r *fakeQuoteRepo) GetByID(ctx context.Context, id string, userDiscordID string) (*entities.Quote, error) {
+	if r.quote.ID != id {
+		return nil, errors.New("quote not found")
+	}
+	// Like the real repository, non-members get no rows
+	if userDiscordID != "" && r.members != nil && !r.members[userDiscordID] {
+		return nil, sql.ErrNoRows
+	}
+	return r.quote, nil
+}
+
+func (r *fakeQuoteRepo) FindIDByShortCode(ctx context.Context, guildID, code string) (string, error) {
+	if r.quote.GuildID != guildID || r.quote.ShortCode != code {
+		return "", nil
+	}
+	return r.quote.ID, nil
+}
+
+func (r *fakeQuoteRepo) FindIDBySourceMessage(ctx context.Context, guildID, sourceMsgID string) (string, error) {
+	if r.quote.GuildID != guildID || r.quote.SourceMsgID != sourceMsgID {
+		return "", nil
+	}
+	return r.quote.ID, nil
+}
+
+func (r *fakeQuoteRepo) FindDuplicateID(ctx context.Context, guildID, sourceMsgID, body string) (string, error) {
+	if r.quote.GuildID != guildID || r.quote.SourceMsgID != sourceMsgID {
+		return "", nil
+	}
+	return r.quote.ID, nil
+}
+
+func (r *fakeQuoteRepo) Update(ctx context.Context, id, body string, tags []string, attribution string) error {
+	r.quote.Body, r.quote.Tags = body, tags
+	if attribution != "" {
+		r.quote.SourceMsgAuthorDisplayName, r.quote.SourceMsgAuthorGuildNick = attribution, ""
+	}
+	return nil
+}
+
+// linkedQuoteUsers links the users quote tests act as to Discord accounts, which quotes,
+// as guild content, require
+func linkedQuoteUsers() *fakeDiscordUserRepo {
+	return &fakeDiscordUserRepo{byUserID: map[string]*entities.DiscordUser{
+		"author": {DiscordID: "d-author"},
+		"other":  {DiscordID: "d-other"},
+	}}
+}
+
+func TestUpdateQuote_Attribution(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		role     string
+		wantCode codes.Code
+	}{
+		{name: "author", userID: "author", role: "user", wantCode: codes.OK},
+		{name: "admin", userID: "admin", role: "admin", wantCode: codes.OK},
+		{name: "other user", userID: "other", role: "user", wantCode: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeQuoteRepo{quote: &entities.Quote{
+				ID:                         "q1",
+				AuthorID:                   "author",
+				GuildID:                    "g1",
+				Body:                       "The cake is a lie",
+				SourceMsgAuthorDiscordID:   "d1",
+				SourceMsgAuthorDisplayName: "GLaDOS",
+			}}
+			h := NewQuoteHandler(services.NewQuoteService(repo, nil, nil, nil, nil), linkedQuoteUsers(), 0, config.PageLimits{})
+			ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+				UserID: tt.userID,
+				Role:   tt.role,
+			})
+
+			got, err := h.UpdateQuote(ctx, &quotespb.UpdateQuoteRequest{
+				Id:                      "q1",
+				Body:                    "The cake is a lie",
+				SourceMsgAuthorUsername: "Wheatley",
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("UpdateQuote() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if tt.wantCode != codes.OK {
+				if repo.quote.SourceMsgAuthorDisplayName != "GLaDOS" {
+					t.Errorf("rejected update changed attribution to %q", repo.quote.SourceMsgAuthorDisplayName)
+				}
+				return
+			}
+			if got.SourceMsgAuthorUsername != "Wheatley" {
+				t.Errorf("SourceMsgAuthorUsername = %q, want Wheatley", got.SourceMsgAuthorUsername)
+			}
+		})
+	}
+}
+
+func TestGetQuoteBySourceMessage(t *testing.T) {
+	repo := &fakeQuoteRepo{quote: &entities.Quote{
+		ID:          "q1",
+		AuthorID:    "author",
+		GuildID:     "g1",
+		Body:        "The cake is a lie",
+		SourceMsgID: "m1",
+	}}
+	h := NewQuoteHandler(services.NewQuoteService(repo, nil, nil, nil, nil), &fakeDiscordUserRepo{}, 0, config.PageLimits{})
+	ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+		UserID: "admin",
+		Role:   "admin",
+	})
+
+	tests := []struct {
+		name        string
+		guildID     string
+		sourceMsgID string
+		wantCode    codes.Code
+	}{
+		{name: "hit", guildID: "g1", sourceMsgID: "m1", wantCode: codes.OK},
+		{name: "miss", guildID: "g1", sourceMsgID: "m2", wantCode: codes.NotFound},
+		{name: "same message ID in another guild", guildID: "g2", sourceMsgID: "m1", wantCode: codes.NotFound},
+		{name: "missing guild", sourceMsgID: "m1", wantCode: codes.InvalidArgument},
+		{name: "missing message", guildID: "g1", wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := h.GetQuoteBySourceMessage(ctx, &quotespb.GetQuoteBySourceMessageRequest{
+				GuildId:     tt.guildID,
+				SourceMsgId: tt.sourceMsgID,
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("GetQuoteBySourceMessage() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if tt.wantCode == codes.OK && got.Id != "q1" {
+				t.Errorf("GetQuoteBySourceMessage() id = %q, want q1", got.Id)
+			}
+		})
+	}
+}
+
+func TestGetQuoteByCode(t *testing.T) {
+	repo := &fakeQuoteRepo{
+		quote:   &entities.Quote{ID: "q1", AuthorID: "author", GuildID: "g1", Body: "The cake is a lie", ShortCode: "abc123"},
+		members: map[string]bool{"d-author": true},
+	}
+	h := NewQuoteHandler(services.NewQuoteService(repo, nil, nil, nil, nil), linkedQuoteUsers(), 0, config.PageLimits{})
+
+	tests := []struct {
+		name     string
+		userID   string
+		guildID  string
+		code     string
+		wantCode codes.Code
+	}{
+		{name: "hit", userID: "author", guildID: "g1", code: "abc123", wantCode: codes.OK},
+		{name: "unknown code", userID: "author", guildID: "g1", code: "zzz999", wantCode: codes.NotFound},
+		{name: "code from another guild", userID: "author", guildID: "g2", code: "abc123", wantCode: codes.NotFound},
+		// A non-member must not learn the quote exists, nor see an internal error
+		{name: "not a guild member", userID: "other", guildID: "g1", code: "abc123", wantCode: codes.NotFound},
+		{name: "missing code", userID: "author", guildID: "g1", wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+				UserID: tt.userID,
+				Role:   "user",
+			})
+
+			got, err := h.GetQuoteByCode(ctx, &quotespb.GetQuoteByCodeRequest{GuildId: tt.guildID, Code: tt.code})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("GetQuoteByCode() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if tt.wantCode == codes.OK && got.Id != "q1" {
+				t.Errorf("GetQuoteByCode() id = %q, want q1", got.Id)
+			}
+		})
+	}
+}
+
+func TestUpdateQuote_RejectedByModeration(t *testing.T) {
+	repo := &fakeQuoteRepo{quote: &entities.Quote{ID: "q1", AuthorID: "author", GuildID: "g1", Body: "The cake is a lie"}}
+	moderation, err := services.NewModerationService([]string{`(?i)\bbuy cheap\b`}, false, nil)
+	if err != nil {
+		t.Fatalf("NewModerationService() error = %v", err)
+	}
+	h := NewQuoteHandler(services.NewQuoteService(repo, nil, nil, moderation, nil), linkedQuoteUsers(), 0, config.PageLimits{})
+
+	_, err = h.UpdateQuote(userContext("author", "user"), &quotespb.UpdateQuoteRequest{Id: "q1", Body: "Buy cheap cake"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("UpdateQuote() code = %v, want InvalidArgument", status.Code(err))
+	}
+	if repo.quote.Body != "The cake is a lie" {
+		t.Errorf("rejected update changed the body to %q", repo.quote.Body)
+	}
+}
+
+func TestCreateQuote_DuplicateHiddenFromNonMembers(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		wantCode codes.Code
+	}{
+		{name: "guild member", userID: "author", wantCode: codes.OK},
+		{name: "outsider", userID: "other", wantCode: codes.AlreadyExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeQuoteRepo{
+				quote:   &entities.Quote{ID: "q1", GuildID: "g1", SourceMsgID: "m1", Body: "The cake is a lie"},
+				members: map[string]bool{"d-author": true},
+			}
+			h := NewQuoteHandler(services.NewQuoteService(repo, nil, nil, nil, nil), linkedQuoteUsers(), 0, config.PageLimits{})
+			ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{
+				UserID: tt.userID,
+				Role:   "user",
+			})
+
+			got, err := h.CreateQuote(ctx, &quotespb.CreateQuoteRequest{GuildId: "g1", SourceMsgId: "m1", Body: "guess"})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("CreateQuote() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if tt.wantCode != codes.OK {
+				if strings.Contains(err.Error(), "q1") || strings.Contains(err.Error(), "cake") {
+					t.Errorf("error %q reveals the hidden quote", err)
+				}
+				return
+			}
+			if !got.WasDuplicate || got.Body != "The cake is a lie" {
+				t.Errorf("CreateQuote() = %+v, want the existing quote flagged as a duplicate", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package handlers
+
+// searchGuildIDs returns the guilds a search request is limited to: guild_id when it is
+// set, otherwise guild_ids. Empty means every guild the caller can see.
+func searchGuildIDs(guildID string, guildIDs []string) []string {
+	if guildID != "" {
+		return []string{guildID}
+	}
+	return guildIDs
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchGuildIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		guildID  string
+		guildIDs []string
+		want     []string
+	}{
+		{name: "single guild", guildID: "g1", want: []string{"g1"}},
+		{name: "guild_id wins", guildID: "g1", guildIDs: []string{"g2", "g3"}, want: []string{"g1"}},
+		{name: "several guilds", guildIDs: []string{"g2", "g3"}, want: []string{"g2", "g3"}},
+		{name: "every guild"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := searchGuildIDs(tt.guildID, tt.guildIDs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("searchGuildIDs(%q, %v) = %v, want %v", tt.guildID, tt.guildIDs, got, tt.want)
+			}
+		})
+	}
+}
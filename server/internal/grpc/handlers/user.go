@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/devilmonastery/hivemind/api/generated/go/userpb"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+)
+
+// UserHandler implements the UserService gRPC handler. Every RPC acts on the
+// caller's own profile, identified by their token; requests carry no user ID.
+type UserHandler struct {
+	userpb.UnimplementedUserServiceServer
+	userService *services.UserService
+	log         *slog.Logger
+}
+
+// NewUserHandler creates a new user handler
+func NewUserHandler(userService *services.UserService) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+		log:         slog.Default().With(slog.String("handler", "user")),
+	}
+}
+
+// GetProfile returns the caller's profile
+func (h *UserHandler) GetProfile(ctx context.Context, req *userpb.GetProfileRequest) (*userpb.Profile, error) {
+	user, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+
+	profile, err := h.userService.GetUserByID(ctx, user.UserID)
+	if err != nil {
+		return nil, profileStatus(err, "failed to get profile")
+	}
+
+	return toProtoProfile(profile), nil
+}
+
+// UpdateProfile changes the caller's display name and/or timezone
+func (h *UserHandler) UpdateProfile(ctx context.Context, req *userpb.UpdateProfileRequest) (*userpb.Profile, error) {
+	user, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+
+	profile, err := h.userService.UpdateProfile(ctx, user.UserID, req.DisplayName, req.Timezone)
+	if err != nil {
+		return nil, profileStatus(err, "failed to update profile")
+	}
+
+	h.log.Info("profile updated", slog.String("user_id", user.UserID))
+
+	return toProtoProfile(profile), nil
+}
+
+// UpdateAvatar sets or clears the caller's avatar
+func (h *UserHandler) UpdateAvatar(ctx context.Context, req *userpb.UpdateAvatarRequest) (*userpb.Profile, error) {
+	user, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user context not found")
+	}
+
+	profile, err := h.userService.UpdateAvatar(ctx, user.UserID, req.AvatarUrl)
+	if err != nil {
+		return nil, profileStatus(err, "failed to update avatar")
+	}
+
+	h.log.Info("avatar updated", slog.String("user_id", user.UserID))
+
+	return toProtoProfile(profile), nil
+}
+
+// profileStatus maps profile service errors to gRPC status codes
+func profileStatus(err error, message string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidDisplayName),
+		errors.Is(err, services.ErrInvalidTimezone),
+		errors.Is(err, services.ErrInvalidAvatarURL):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case services.IsUserNotFound(err):
+		return status.Error(codes.NotFound, "user not found")
+	}
+	return status.Errorf(codes.Internal, "%s: %v", message, err)
+}
+
+// toProtoProfile converts a domain user to its profile message
+func toProtoProfile(user *entities.User) *userpb.Profile {
+	profile := &userpb.Profile{
+		UserId:      user.ID,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		AvatarUrl:   stringPtrValue(user.AvatarURL),
+		Timezone:    stringPtrValue(user.Timezone),
+	}
+	if !user.CreatedAt.IsZero() {
+		profile.CreatedAt = timestamppb.New(user.CreatedAt)
+	}
+	if !user.UpdatedAt.IsZero() {
+		profile.UpdatedAt = timestamppb.New(user.UpdatedAt)
+	}
+	return profile
+}
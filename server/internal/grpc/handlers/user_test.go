@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/api/generated/go/userpb"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
+)
+
+func (r *fakeUserRepo) Update(ctx context.Context, user *entities.User) error {
+	r.users[user.ID] = user
+	return nil
+}
+
+func TestUserHandler_UpdatesOnlyCaller(t *testing.T) {
+	repo := &fakeUserRepo{users: map[string]*entities.User{
+		"u1": {ID: "u1", DisplayName: "Ada"},
+		"u2": {ID: "u2", DisplayName: "Grace"},
+	}}
+	h := NewUserHandler(services.NewUserService(repo, nil))
+	ctx := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{UserID: "u1"})
+
+	profile, err := h.UpdateProfile(ctx, &userpb.UpdateProfileRequest{DisplayName: "Ada L.", Timezone: "Europe/London"})
+	if err != nil {
+		t.Fatalf("UpdateProfile() error = %v", err)
+	}
+	if profile.UserId != "u1" || profile.DisplayName != "Ada L." || profile.Timezone != "Europe/London" {
+		t.Errorf("profile = %+v, want the caller's updated profile", profile)
+	}
+	if _, err := h.UpdateAvatar(ctx, &userpb.UpdateAvatarRequest{AvatarUrl: "https://cdn.example.com/ada.png"}); err != nil {
+		t.Fatalf("UpdateAvatar() error = %v", err)
+	}
+
+	if other := repo.users["u2"]; other.DisplayName != "Grace" || other.Timezone != nil || other.AvatarURL != nil {
+		t.Errorf("other user = %+v, want it untouched", other)
+	}
+	if got, err := h.GetProfile(ctx, &userpb.GetProfileRequest{}); err != nil || got.AvatarUrl != "https://cdn.example.com/ada.png" {
+		t.Errorf("GetProfile() = %+v, %v, want the caller's new avatar", got, err)
+	}
+}
+
+func TestUserHandler_Errors(t *testing.T) {
+	repo := &fakeUserRepo{users: map[string]*entities.User{"u1": {ID: "u1"}}}
+	h := NewUserHandler(services.NewUserService(repo, nil))
+	caller := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{UserID: "u1"})
+	deleted := context.WithValue(context.Background(), interceptors.UserContextKey, &interceptors.UserContext{UserID: "gone"})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *userpb.UpdateProfileRequest
+		want codes.Code
+	}{
+		{name: "no caller", ctx: context.Background(), req: &userpb.UpdateProfileRequest{Timezone: "UTC"}, want: codes.Unauthenticated},
+		{name: "invalid timezone", ctx: caller, req: &userpb.UpdateProfileRequest{Timezone: "Mars/Olympus_Mons"}, want: codes.InvalidArgument},
+		{name: "unknown caller", ctx: deleted, req: &userpb.UpdateProfileRequest{Timezone: "UTC"}, want: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := h.UpdateProfile(tt.ctx, tt.req)
+			if status.Code(err) != tt.want {
+				t.Errorf("UpdateProfile() code = %v, want %v", status.Code(err), tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"strings"
 
@@ -11,9 +12,11 @@ import (
 
 	commonpb "github.com/devilmonastery/hivemind/api/generated/go/commonpb"
 	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/internal/config"
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
 	"github.com/devilmonastery/hivemind/internal/domain/repositories"
 	"github.com/devilmonastery/hivemind/internal/domain/services"
+	"github.com/devilmonastery/hivemind/internal/pkg/textutil"
 	"github.com/devilmonastery/hivemind/internal/pkg/urlutil"
 	"github.com/devilmonastery/hivemind/server/internal/grpc/interceptors"
 )
@@ -24,16 +27,22 @@ type wikiHandler struct {
 	discordService  *services.DiscordService
 	guildMemberRepo repositories.GuildMemberRepository
 	discordUserRepo repositories.DiscordUserRepository
+	maxBodyLength   int
+	pageLimits      config.PageLimits
 	log             *slog.Logger
 }
 
-// NewWikiHandler creates a new wiki gRPC handler
-func NewWikiHandler(wikiService *services.WikiService, discordService *services.DiscordService, guildMemberRepo repositories.GuildMemberRepository, discordUserRepo repositories.DiscordUserRepository, logger *slog.Logger) wikipb.WikiServiceServer {
+// NewWikiHandler creates a new wiki gRPC handler.
+// maxBodyLength caps page bodies in bytes; zero or less means no limit.
+// pageLimits sets the default and maximum page size for listing and searching.
+func NewWikiHandler(wikiService *services.WikiService, discordService *services.DiscordService, guildMemberRepo repositories.GuildMemberRepository, discordUserRepo repositories.DiscordUserRepository, maxBodyLength int, pageLimits config.PageLimits, logger *slog.Logger) wikipb.WikiServiceServer {
 	return &wikiHandler{
 		wikiService:     wikiService,
 		discordService:  discordService,
 		guildMemberRepo: guildMemberRepo,
 		discordUserRepo: discordUserRepo,
+		maxBodyLength:   maxBodyLength,
+		pageLimits:      pageLimits,
 		log:             logger.With(slog.String("handler", "wiki")),
 	}
 }
@@ -45,19 +54,40 @@ func (h *wikiHandler) CreateWikiPage(ctx context.Context, req *wikipb.CreateWiki
 		return nil, err
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
+	if err := checkBodyLength("wiki page", req.Body, h.maxBodyLength); err != nil {
+		return nil, err
+	}
+	if err := checkPreserveID(userCtx, req.PreserveId); err != nil {
+		return nil, err
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.checkWikiEditRole(ctx, userCtx, req.GuildId, userDiscordID); err != nil {
+		return nil, err
+	}
 
 	page := &entities.WikiPage{
+		ID:        req.PreserveId,
 		Title:     req.Title,
 		Body:      req.Body,
 		AuthorID:  userCtx.UserID,
 		GuildID:   req.GuildId,
 		ChannelID: req.ChannelId,
 		Tags:      req.Tags,
+		Status:    wikiPageStatusFromProto(req.Status),
 	}
 
 	created, err := h.wikiService.CreateWikiPage(ctx, page, userDiscordID)
 	if err != nil {
+		if errors.Is(err, textutil.ErrInvalidTags) || errors.Is(err, services.ErrContentRejected) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, services.ErrIDInUse) || errors.Is(err, repositories.ErrWikiTitleTaken) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
 		return nil, err
 	}
 
@@ -70,11 +100,14 @@ func (h *wikiHandler) GetWikiPage(ctx context.Context, req *wikipb.GetWikiPageRe
 		return nil, err
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
 
 	page, err := h.wikiService.GetWikiPage(ctx, req.Id, userDiscordID)
 	if err != nil {
-		return nil, err
+		return nil, wikiPageStatus(err, "get")
 	}
 
 	return toProtoWikiPage(page), nil
@@ -86,14 +119,17 @@ func (h *wikiHandler) GetWikiPageByTitle(ctx context.Context, req *wikipb.GetWik
 		return nil, err
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
 
 	page, err := h.wikiService.GetWikiPageByTitle(ctx, req.GuildId, req.Title, userDiscordID)
 	if err != nil {
-		return nil, err
+		return nil, wikiPageStatus(err, "get")
 	}
 	if page == nil {
-		return nil, status.Error(codes.NotFound, "wiki page not found")
+		return nil, errWikiPageNotFound
 	}
 
 	return toProtoWikiPage(page), nil
@@ -105,14 +141,14 @@ func (h *wikiHandler) SearchWikiPages(ctx context.Context, req *wikipb.SearchWik
 		return nil, err
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
-
-	limit := int(req.Limit)
-	if limit <= 0 {
-		limit = 10
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
 	}
 
-	pages, total, err := h.wikiService.SearchWikiPages(ctx, req.GuildId, req.Query, req.Tags, limit, int(req.Offset), userDiscordID)
+	limit := h.pageLimits.ApplySearch(int(req.Limit))
+
+	pages, total, err := h.wikiService.SearchWikiPages(ctx, searchGuildIDs(req.GuildId, req.GuildIds), req.Query, req.Tags, req.AuthorDiscordId, limit, int(req.Offset), userDiscordID)
 	if err != nil {
 		return nil, err
 	}
@@ -120,11 +156,16 @@ func (h *wikiHandler) SearchWikiPages(ctx context.Context, req *wikipb.SearchWik
 	protoPages := make([]*wikipb.WikiPage, len(pages))
 	for i, page := range pages {
 		protoPages[i] = toProtoWikiPage(page)
+		if req.OmitBody {
+			protoPages[i].Body = ""
+		}
 	}
 
 	return &wikipb.SearchWikiPagesResponse{
-		Pages: protoPages,
-		Total: int32(total),
+		Pages:      protoPages,
+		Total:      int32(total),
+		Limit:      int32(limit),
+		Pagination: paginationMeta(total, int(req.Offset), len(pages)),
 	}, nil
 }
 
@@ -134,18 +175,30 @@ func (h *wikiHandler) UpdateWikiPage(ctx context.Context, req *wikipb.UpdateWiki
 		return nil, err
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
+	if err := checkBodyLength("wiki page", req.Body, h.maxBodyLength); err != nil {
+		return nil, err
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.authorizeWikiPageEdit(ctx, userCtx, req.Id, userDiscordID); err != nil {
+		return nil, err
+	}
 
 	page := &entities.WikiPage{
-		ID:    req.Id,
-		Title: req.Title,
-		Body:  req.Body,
-		Tags:  req.Tags,
+		ID:           req.Id,
+		Title:        req.Title,
+		Body:         req.Body,
+		Tags:         req.Tags,
+		LastEditorID: userCtx.UserID,
 	}
 
 	updated, err := h.wikiService.UpdateWikiPage(ctx, page, userDiscordID)
 	if err != nil {
-		return nil, err
+		return nil, wikiPageStatus(err, "update")
 	}
 
 	return toProtoWikiPage(updated), nil
@@ -158,7 +211,17 @@ func (h *wikiHandler) UpsertWikiPage(ctx context.Context, req *wikipb.UpsertWiki
 		return nil, err
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
+	if err := checkBodyLength("wiki page", req.Body, h.maxBodyLength); err != nil {
+		return nil, err
+	}
+	if err := checkPreserveID(userCtx, req.PreserveId); err != nil {
+		return nil, err
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
 
 	// Validate title is not empty
 	if strings.TrimSpace(req.Title) == "" {
@@ -170,17 +233,29 @@ func (h *wikiHandler) UpsertWikiPage(ctx context.Context, req *wikipb.UpsertWiki
 		return nil, status.Error(codes.InvalidArgument, "wiki page body cannot be empty")
 	}
 
+	if err := h.checkWikiEditRole(ctx, userCtx, req.GuildId, userDiscordID); err != nil {
+		return nil, err
+	}
+
 	page := &entities.WikiPage{
+		ID:        req.PreserveId,
 		Title:     req.Title,
 		Body:      req.Body,
 		AuthorID:  userCtx.UserID,
 		GuildID:   req.GuildId,
 		ChannelID: req.ChannelId,
 		Tags:      req.Tags,
+		Status:    wikiPageStatusFromProto(req.Status),
 	}
 
 	upserted, created, err := h.wikiService.UpsertWikiPage(ctx, page, userDiscordID)
 	if err != nil {
+		if errors.Is(err, textutil.ErrInvalidTags) || errors.Is(err, services.ErrContentRejected) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, services.ErrIDInUse) || errors.Is(err, repositories.ErrWikiTitleTaken) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
 		return nil, err
 	}
 
@@ -196,10 +271,13 @@ func (h *wikiHandler) DeleteWikiPage(ctx context.Context, req *wikipb.DeleteWiki
 		return nil, err
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := h.wikiService.DeleteWikiPage(ctx, req.Id, userDiscordID); err != nil {
-		return nil, err
+		return nil, wikiPageStatus(err, "delete")
 	}
 
 	return &commonpb.SuccessResponse{
@@ -214,31 +292,46 @@ func (h *wikiHandler) ListWikiPages(ctx context.Context, req *wikipb.ListWikiPag
 		return nil, err
 	}
 
-	userDiscordID := h.getUserDiscordID(ctx, userCtx)
-
-	limit := int(req.Limit)
-	if limit <= 0 {
-		limit = 50
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
 	}
 
+	limit := h.pageLimits.Apply(int(req.Limit))
+
 	orderBy := req.OrderBy
 	if orderBy == "" {
 		orderBy = "created_at"
 	}
 
-	pages, total, err := h.wikiService.ListWikiPages(ctx, req.GuildId, limit, int(req.Offset), orderBy, req.Ascending, userDiscordID)
+	cursor, err := repositories.ParsePageToken(req.PageToken)
 	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+
+	pages, total, next, err := h.wikiService.ListWikiPages(ctx, req.GuildId, limit, int(req.Offset), cursor, orderBy, req.Ascending, userDiscordID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "page_token does not match the requested ordering")
+		}
 		return nil, err
 	}
 
 	protoPages := make([]*wikipb.WikiPage, len(pages))
 	for i, page := range pages {
 		protoPages[i] = toProtoWikiPage(page)
+		if req.OmitBody {
+			protoPages[i].Body = ""
+		}
 	}
 
+	nextPageToken := next.Token()
 	return &wikipb.ListWikiPagesResponse{
-		Pages: protoPages,
-		Total: int32(total),
+		Pages:         protoPages,
+		Total:         int32(total),
+		NextPageToken: nextPageToken,
+		Limit:         int32(limit),
+		Pagination:    cursorPaginationMeta(total, int(req.Offset), len(pages), req.PageToken, nextPageToken),
 	}, nil
 }
 
@@ -254,37 +347,75 @@ func toProtoWikiPage(page *entities.WikiPage) *wikipb.WikiPage {
 		GuildName:      page.GuildName,
 		ChannelId:      page.ChannelID,
 		Tags:           page.Tags,
+		Pinned:         page.Pinned,
+		Status:         toProtoWikiPageStatus(page),
 		CreatedAt:      timestamppb.New(page.CreatedAt),
 		UpdatedAt:      timestamppb.New(page.UpdatedAt),
+		Snippet:        page.Snippet,
+		Rank:           page.Rank,
+		BodyLength:     int32(page.BodyLength),
+		TagCount:       int32(page.TagCount),
+		ReferenceCount: int32(page.ReferenceCount),
+
+		LastEditorId:       page.LastEditorID,
+		LastEditorUsername: page.LastEditorDisplayName,
 	}
 }
 
-// getUserDiscordID extracts Discord ID from context for ACL filtering
-// Returns empty string for admin users (no ACL filtering)
-func (h *wikiHandler) getUserDiscordID(ctx context.Context, userCtx *interceptors.UserContext) string {
-	h.log.Debug("getting user discord ID for ACL",
-		slog.String("user_id", userCtx.UserID),
-		slog.String("role", userCtx.Role))
+// wikiPageStatusFromProto converts a requested page status; anything but a draft is
+// published
+func wikiPageStatusFromProto(s wikipb.WikiPageStatus) entities.WikiPageStatus {
+	if s == wikipb.WikiPageStatus_WIKI_PAGE_STATUS_DRAFT {
+		return entities.WikiPageDraft
+	}
+	return entities.WikiPagePublished
+}
 
-	// Admin bypass: empty string means no ACL filtering
-	if userCtx.Role == "admin" {
-		h.log.Debug("admin bypass - no ACL filtering")
-		return ""
+func toProtoWikiPageStatus(page *entities.WikiPage) wikipb.WikiPageStatus {
+	if page.IsDraft() {
+		return wikipb.WikiPageStatus_WIKI_PAGE_STATUS_DRAFT
+	}
+	return wikipb.WikiPageStatus_WIKI_PAGE_STATUS_PUBLISHED
+}
+
+// getUserDiscordID returns the Discord ID to filter content by; see callerDiscordID
+func (h *wikiHandler) getUserDiscordID(ctx context.Context, userCtx *interceptors.UserContext) (string, error) {
+	return callerDiscordID(ctx, h.discordUserRepo, userCtx)
+}
+
+// checkWikiEditRole enforces the guild's wiki editor roles, which the bot also checks before
+// opening its modals. Hivemind admins bypass it.
+func (h *wikiHandler) checkWikiEditRole(ctx context.Context, userCtx *interceptors.UserContext, guildID, userDiscordID string) error {
+	if userCtx.Role == "admin" || guildID == "" {
+		return nil
 	}
 
-	// Get user's Discord ID for ACL filtering
-	discordUser, err := h.discordUserRepo.GetByUserID(ctx, userCtx.UserID)
-	if err != nil {
-		h.log.Debug("error getting discord user", slog.String("error", err.Error()))
-		return ""
+	discordID := userCtx.DiscordID
+	if discordID == "" {
+		discordID = userDiscordID
 	}
-	if discordUser == nil {
-		h.log.Debug("no discord user found", slog.String("user_id", userCtx.UserID))
-		return ""
+
+	err := h.discordService.CheckGuildRole(ctx, guildID, discordID, services.PermissionWikiEdit)
+	if errors.Is(err, services.ErrMissingRole) {
+		return status.Error(codes.PermissionDenied, "this server only lets members with a wiki editor role create or edit wiki pages")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check wiki editor roles: %v", err)
 	}
+	return nil
+}
 
-	h.log.Debug("found discord ID", slog.String("discord_id", discordUser.DiscordID))
-	return discordUser.DiscordID
+// authorizeWikiPageEdit checks that the caller may change pageID: it must be in a guild
+// they belong to, and the guild's wiki editor roles apply. Hivemind admins skip both.
+func (h *wikiHandler) authorizeWikiPageEdit(ctx context.Context, userCtx *interceptors.UserContext, pageID, userDiscordID string) error {
+	if userCtx.Role == "admin" {
+		return nil
+	}
+	page, err := h.wikiService.GetWikiPage(ctx, pageID, userDiscordID)
+	if err != nil {
+		return wikiPageStatus(err, "get")
+	}
+	return h.checkWikiEditRole(ctx, userCtx, page.GuildID, userDiscordID)
 }
 
 func (h *wikiHandler) AddWikiMessageReference(ctx context.Context, req *wikipb.AddWikiMessageReferenceRequest) (*wikipb.WikiMessageReference, error) {
@@ -294,62 +425,111 @@ func (h *wikiHandler) AddWikiMessageReference(ctx context.Context, req *wikipb.A
 		return nil, err
 	}
 
+	if req.WikiPageId == "" {
+		return nil, status.Error(codes.InvalidArgument, "wiki_page_id is required")
+	}
+
 	// Debug logging
 	h.log.Debug("AddWikiMessageReference request",
 		slog.String("guild_id", req.GuildId),
 		slog.String("content", req.Content),
 		slog.Int("content_length", len(req.Content)))
 
-	// Convert proto attachments to entity attachments
-	attachments := make([]entities.AttachmentMetadata, len(req.Attachments))
-	for i, att := range req.Attachments {
-		attachments[i] = entities.AttachmentMetadata{
-			URL:         att.Url,
-			ContentType: att.ContentType,
-			Filename:    att.Filename,
-			Width:       int(att.Width),
-			Height:      int(att.Height),
-			Size:        att.Size,
-		}
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
 	}
-
-	ref := &entities.WikiMessageReference{
-		WikiPageID:        req.WikiPageId,
-		MessageID:         req.MessageId,
-		ChannelID:         req.ChannelId,
-		GuildID:           req.GuildId,
-		Content:           req.Content,
-		AuthorID:          req.AuthorId,
-		AuthorUsername:    req.AuthorUsername,
-		AuthorDisplayName: req.AuthorDisplayName,
-		MessageTimestamp:  req.MessageTimestamp.AsTime(),
-		AttachmentURLs:    req.AttachmentUrls, // Keep for backwards compatibility
-		Attachments:       attachments,
-		AddedByUserID:     userCtx.UserID,
+	if err := h.authorizeWikiPageEdit(ctx, userCtx, req.WikiPageId, userDiscordID); err != nil {
+		return nil, err
 	}
 
+	ref := wikiMessageReferenceFromProto(req, userCtx.UserID)
+
 	err = h.wikiService.AddWikiMessageReference(ctx, ref)
 	if err != nil {
 		h.log.Error("error adding message reference", slog.String("error", err.Error()))
-		return nil, err
+		return nil, wikiPageStatus(err, "add message reference to")
 	}
 
 	return toProtoWikiMessageReference(ref), nil
 }
 
+// maxWikiReferenceBatch caps the references accepted by one AddWikiMessageReferencesBatch call
+const maxWikiReferenceBatch = 500
+
+func (h *wikiHandler) AddWikiMessageReferencesBatch(ctx context.Context, req *wikipb.AddWikiMessageReferencesBatchRequest) (*wikipb.AddWikiMessageReferencesBatchResponse, error) {
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.WikiPageId == "" {
+		return nil, status.Error(codes.InvalidArgument, "wiki_page_id is required")
+	}
+	if len(req.References) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one reference is required")
+	}
+	if len(req.References) > maxWikiReferenceBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "too many references: %d (max %d)", len(req.References), maxWikiReferenceBatch)
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.authorizeWikiPageEdit(ctx, userCtx, req.WikiPageId, userDiscordID); err != nil {
+		return nil, err
+	}
+
+	refs := make([]*entities.WikiMessageReference, len(req.References))
+	for i, r := range req.References {
+		refs[i] = wikiMessageReferenceFromProto(r, userCtx.UserID)
+	}
+
+	result, err := h.wikiService.AddWikiMessageReferences(ctx, req.WikiPageId, refs, userDiscordID)
+	if err != nil {
+		return nil, wikiPageStatus(err, "add message references to")
+	}
+
+	h.log.Info("added wiki message references",
+		slog.String("wiki_page_id", req.WikiPageId),
+		slog.Int("added", result.Added),
+		slog.Int("skipped", result.Skipped),
+		slog.Int("invalid", len(result.Invalid)))
+
+	invalid := make([]*wikipb.InvalidWikiMessageReference, len(result.Invalid))
+	for i, inv := range result.Invalid {
+		invalid[i] = &wikipb.InvalidWikiMessageReference{
+			Index:     int32(inv.Index),
+			MessageId: req.References[inv.Index].MessageId,
+			Reason:    inv.Reason,
+		}
+	}
+
+	return &wikipb.AddWikiMessageReferencesBatchResponse{
+		Added:   int32(result.Added),
+		Skipped: int32(result.Skipped),
+		Invalid: invalid,
+	}, nil
+}
+
 func (h *wikiHandler) ListWikiMessageReferences(ctx context.Context, req *wikipb.ListWikiMessageReferencesRequest) (*wikipb.ListWikiMessageReferencesResponse, error) {
 	refs, err := h.wikiService.ListWikiMessageReferences(ctx, req.WikiPageId)
 	if err != nil {
 		return nil, err
 	}
 
-	protoRefs := make([]*wikipb.WikiMessageReference, len(refs))
-	for i, ref := range refs {
-		protoRefs[i] = toProtoWikiMessageReference(ref)
+	start, end := pageBounds(len(refs), int(req.Limit), int(req.Offset))
+	protoRefs := make([]*wikipb.WikiMessageReference, 0, end-start)
+	for _, ref := range refs[start:end] {
+		protoRefs = append(protoRefs, toProtoWikiMessageReference(ref))
 	}
 
 	return &wikipb.ListWikiMessageReferencesResponse{
 		References: protoRefs,
+		Total:      int32(len(refs)),
+		Pagination: paginationMeta(len(refs), int(req.Offset), len(protoRefs)),
 	}, nil
 }
 
@@ -380,35 +560,314 @@ func (h *wikiHandler) MergeWikiPages(ctx context.Context, req *wikipb.MergeWikiP
 		return nil, err
 	}
 
-	// Validate request
-	if req.SourcePageId == "" {
-		return nil, status.Error(codes.InvalidArgument, "source_page_id is required")
+	if err := validateWikiMergePages(req.SourcePageId, req.TargetPageId); err != nil {
+		return nil, err
 	}
-	if req.TargetPageId == "" {
-		return nil, status.Error(codes.InvalidArgument, "target_page_id is required")
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+	for _, pageID := range []string{req.SourcePageId, req.TargetPageId} {
+		if err := h.authorizeWikiPageEdit(ctx, userCtx, pageID, userDiscordID); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := h.wikiService.MergeWikiPages(ctx, req.SourcePageId, req.TargetPageId, userCtx.UserID)
+	if err != nil {
+		return nil, h.wikiMergeStatus(ctx, err, req.SourcePageId, req.TargetPageId)
+	}
+
+	return toProtoWikiPage(result.Merged), nil
+}
+
+// PreviewWikiMerge computes a merge without saving anything. Pages the caller can't see
+// are not found, and the guild's wiki editor roles apply as they do to a merge.
+func (h *wikiHandler) PreviewWikiMerge(ctx context.Context, req *wikipb.PreviewWikiMergeRequest) (*wikipb.PreviewWikiMergeResponse, error) {
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateWikiMergePages(req.SourcePageId, req.TargetPageId); err != nil {
+		return nil, err
 	}
-	if req.SourcePageId == req.TargetPageId {
-		return nil, status.Error(codes.InvalidArgument, "source and target pages must be different")
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Perform merge
-	merged, err := h.wikiService.MergeWikiPages(ctx, req.SourcePageId, req.TargetPageId, userCtx.UserID)
+	result, err := h.wikiService.PreviewWikiPageMerge(ctx, req.SourcePageId, req.TargetPageId, userDiscordID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		return nil, h.wikiMergeStatus(ctx, err, req.SourcePageId, req.TargetPageId)
+	}
+	if err := h.checkWikiEditRole(ctx, userCtx, result.Source.GuildID, userDiscordID); err != nil {
+		return nil, err
+	}
+
+	return &wikipb.PreviewWikiMergeResponse{
+		Page:           toProtoWikiPage(result.Merged),
+		SourcePage:     toProtoWikiPage(result.Source),
+		ReferenceCount: int32(result.ReferenceCount),
+		TitleCount:     int32(result.TitleCount),
+	}, nil
+}
+
+// validateWikiMergePages checks the page IDs of a merge or merge preview
+func validateWikiMergePages(sourcePageID, targetPageID string) error {
+	if sourcePageID == "" {
+		return status.Error(codes.InvalidArgument, "source_page_id is required")
+	}
+	if targetPageID == "" {
+		return status.Error(codes.InvalidArgument, "target_page_id is required")
+	}
+	if sourcePageID == targetPageID {
+		return status.Error(codes.InvalidArgument, "source and target pages must be different")
+	}
+	return nil
+}
+
+// wikiMergeStatus converts a merge or merge preview error to a gRPC status
+func (h *wikiHandler) wikiMergeStatus(ctx context.Context, err error, sourcePageID, targetPageID string) error {
+	if errors.Is(err, repositories.ErrWikiPageNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if strings.Contains(err.Error(), "different guilds") {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	h.log.ErrorContext(ctx, "failed to merge wiki pages",
+		slog.String("source_page_id", sourcePageID),
+		slog.String("target_page_id", targetPageID),
+		slog.String("error", err.Error()),
+	)
+	return status.Error(codes.Internal, "failed to merge wiki pages")
+}
+
+func (h *wikiHandler) UnmergeWikiPages(ctx context.Context, req *wikipb.UnmergeWikiPagesRequest) (*wikipb.UnmergeWikiPagesResponse, error) {
+	// Get user context from auth interceptor
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.SourcePageId == "" {
+		return nil, status.Error(codes.InvalidArgument, "source_page_id is required")
+	}
+
+	isAdmin := userCtx.Role == "admin"
+	if !isAdmin {
+		// The source page is deleted until the merge is undone, so the roles of the
+		// guild it was merged into apply
+		userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+		if err != nil {
+			return nil, err
+		}
+		target, err := h.wikiService.GetWikiMergeTarget(ctx, req.SourcePageId, userDiscordID)
+		if errors.Is(err, services.ErrMergeNotFound) {
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
-		if strings.Contains(err.Error(), "different guilds") {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
+		if err != nil {
+			return nil, wikiPageStatus(err, "get")
+		}
+		if err := h.checkWikiEditRole(ctx, userCtx, target.GuildID, userDiscordID); err != nil {
+			return nil, err
+		}
+	}
+
+	source, target, err := h.wikiService.UnmergeWikiPages(ctx, req.SourcePageId, userCtx.UserID, isAdmin)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrMergeNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, services.ErrMergeUndoForbidden):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, services.ErrMergeUndoExpired), errors.Is(err, services.ErrMergeTargetModified):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
 		}
-		h.log.ErrorContext(ctx, "failed to merge wiki pages",
+		h.log.ErrorContext(ctx, "failed to unmerge wiki pages",
 			slog.String("source_page_id", req.SourcePageId),
-			slog.String("target_page_id", req.TargetPageId),
 			slog.String("error", err.Error()),
 		)
-		return nil, status.Error(codes.Internal, "failed to merge wiki pages")
+		return nil, status.Error(codes.Internal, "failed to undo wiki page merge")
+	}
+
+	return &wikipb.UnmergeWikiPagesResponse{
+		SourcePage: toProtoWikiPage(source),
+		TargetPage: toProtoWikiPage(target),
+	}, nil
+}
+
+func (h *wikiHandler) SetWikiPagePinned(ctx context.Context, req *wikipb.SetWikiPagePinnedRequest) (*wikipb.WikiPage, error) {
+	// Get user context from auth interceptor
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+	isAdmin := userCtx.Role == "admin"
+
+	page, err := h.wikiService.SetWikiPagePinned(ctx, req.Id, req.Pinned, userCtx.UserID, userDiscordID, isAdmin)
+	if err != nil {
+		if errors.Is(err, services.ErrPinForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, repositories.ErrWikiPageNotFound) {
+			return nil, errWikiPageNotFound
+		}
+		h.log.ErrorContext(ctx, "failed to set wiki page pinned",
+			slog.String("page_id", req.Id),
+			slog.Bool("pinned", req.Pinned),
+			slog.String("error", err.Error()),
+		)
+		return nil, status.Error(codes.Internal, "failed to update wiki page pin")
+	}
+
+	return toProtoWikiPage(page), nil
+}
+
+// PublishWikiPage makes a draft wiki page visible to the rest of its guild
+func (h *wikiHandler) PublishWikiPage(ctx context.Context, req *wikipb.PublishWikiPageRequest) (*wikipb.WikiPage, error) {
+	// Get user context from auth interceptor
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+	isAdmin := userCtx.Role == "admin"
+
+	page, err := h.wikiService.PublishWikiPage(ctx, req.Id, userCtx.UserID, userDiscordID, isAdmin)
+	if err != nil {
+		if errors.Is(err, services.ErrPublishForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, wikiPageStatus(err, "publish")
+	}
+
+	return toProtoWikiPage(page), nil
+}
+
+// ListWikiDrafts lists the calling user's draft pages in a guild
+func (h *wikiHandler) ListWikiDrafts(ctx context.Context, req *wikipb.ListWikiDraftsRequest) (*wikipb.ListWikiDraftsResponse, error) {
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return toProtoWikiPage(merged), nil
+	if req.GuildId == "" {
+		return nil, status.Error(codes.InvalidArgument, "guild_id is required")
+	}
+
+	pages, err := h.wikiService.ListWikiDrafts(ctx, req.GuildId, userCtx.UserID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list wiki drafts: %v", err)
+	}
+
+	protoPages := make([]*wikipb.WikiPage, len(pages))
+	for i, page := range pages {
+		protoPages[i] = toProtoWikiPage(page)
+	}
+
+	return &wikipb.ListWikiDraftsResponse{Pages: protoPages}, nil
+}
+
+// TransferWikiPageOwnership makes another guild member the author of a wiki page
+func (h *wikiHandler) TransferWikiPageOwnership(ctx context.Context, req *wikipb.TransferWikiPageOwnershipRequest) (*wikipb.WikiPage, error) {
+	// Get user context from auth interceptor
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.NewOwnerDiscordId == "" {
+		return nil, status.Error(codes.InvalidArgument, "new_owner_discord_id is required")
+	}
+
+	// A Discord user the server has never seen can't have a linked account, which
+	// the service reports the same way as a missing link
+	newOwner, err := h.discordUserRepo.GetByDiscordID(ctx, req.NewOwnerDiscordId)
+	if err != nil && !errors.Is(err, repositories.ErrDiscordUserNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to look up new owner: %v", err)
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+	isAdmin := userCtx.Role == "admin"
+
+	page, err := h.wikiService.TransferWikiPageOwnership(ctx, req.Id, newOwner, userCtx.UserID, userDiscordID, isAdmin)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrTransferForbidden):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, services.ErrNewOwnerNotLinked), errors.Is(err, services.ErrNewOwnerNotMember):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, wikiPageStatus(err, "transfer ownership of")
+	}
+
+	h.log.Info("transferred wiki page ownership",
+		slog.String("page_id", page.ID),
+		slog.String("author_id", page.AuthorID),
+		slog.String("transferred_by", userCtx.UserID),
+	)
+
+	return toProtoWikiPage(page), nil
+}
+
+// wikiMessageReferenceFromProto converts a reference request into an entity added by addedByUserID
+func wikiMessageReferenceFromProto(req *wikipb.AddWikiMessageReferenceRequest, addedByUserID string) *entities.WikiMessageReference {
+	// Convert proto attachments to entity attachments
+	attachments := make([]entities.AttachmentMetadata, len(req.Attachments))
+	for i, att := range req.Attachments {
+		attachments[i] = entities.AttachmentMetadata{
+			URL:         att.Url,
+			ContentType: att.ContentType,
+			Filename:    att.Filename,
+			Width:       int(att.Width),
+			Height:      int(att.Height),
+			Size:        att.Size,
+		}
+	}
+
+	ref := &entities.WikiMessageReference{
+		WikiPageID:        req.WikiPageId,
+		MessageID:         req.MessageId,
+		ChannelID:         req.ChannelId,
+		GuildID:           req.GuildId,
+		Content:           req.Content,
+		AuthorID:          req.AuthorId,
+		AuthorUsername:    req.AuthorUsername,
+		AuthorDisplayName: req.AuthorDisplayName,
+		AttachmentURLs:    req.AttachmentUrls, // Keep for backwards compatibility
+		Attachments:       attachments,
+		AddedByUserID:     addedByUserID,
+	}
+	if req.MessageTimestamp != nil {
+		ref.MessageTimestamp = req.MessageTimestamp.AsTime()
+	}
+	return ref
 }
 
 func toProtoWikiMessageReference(ref *entities.WikiMessageReference) *wikipb.WikiMessageReference {
@@ -447,3 +906,39 @@ func toProtoWikiMessageReference(ref *entities.WikiMessageReference) *wikipb.Wik
 		DiscordLink:           discordLink,
 	}
 }
+
+func (h *wikiHandler) SuggestTags(ctx context.Context, req *wikipb.SuggestTagsRequest) (*wikipb.SuggestTagsResponse, error) {
+	userCtx, err := interceptors.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GuildId == "" {
+		return nil, status.Error(codes.InvalidArgument, "guild_id is required")
+	}
+
+	userDiscordID, err := h.getUserDiscordID(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions, err := h.wikiService.SuggestTags(ctx, req.GuildId, req.Body, req.ExcludePageId, req.ExcludeTags, int(req.Limit), userDiscordID)
+	if err != nil {
+		h.log.ErrorContext(ctx, "failed to suggest tags",
+			slog.String("guild_id", req.GuildId),
+			slog.String("error", err.Error()),
+		)
+		return nil, status.Error(codes.Internal, "failed to suggest tags")
+	}
+
+	protoSuggestions := make([]*wikipb.TagSuggestion, len(suggestions))
+	for i, suggestion := range suggestions {
+		protoSuggestions[i] = &wikipb.TagSuggestion{
+			Tag:       suggestion.Tag,
+			Score:     float32(suggestion.Score),
+			PageCount: int32(suggestion.Pages),
+		}
+	}
+
+	return &wikipb.SuggestTagsResponse{Suggestions: protoSuggestions}, nil
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
+)
+
+// fakeRoleGuildRepo serves one guild whose wiki edits are limited to editorRole
+type fakeRoleGuildRepo struct {
+	repositories.DiscordGuildRepository
+	editorRole string
+}
+
+func (r *fakeRoleGuildRepo) GetSettings(ctx context.Context, guildID string) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"permissions": map[string]interface{}{services.PermissionWikiEdit: []interface{}{r.editorRole}},
+	}, nil
+}
+
+func (r *fakeRoleGuildRepo) GetByID(ctx context.Context, guildID string) (*entities.DiscordGuild, error) {
+	return &entities.DiscordGuild{GuildID: guildID}, nil
+}
+
+// fakeRoleMemberRepo returns members with the roles listed by Discord ID
+type fakeRoleMemberRepo struct {
+	repositories.GuildMemberRepository
+	roles map[string][]string
+}
+
+func (r *fakeRoleMemberRepo) GetMember(ctx context.Context, guildID, discordID string) (*entities.GuildMember, error) {
+	roles, ok := r.roles[discordID]
+	if !ok {
+		return nil, repositories.ErrGuildMemberNotFound
+	}
+	return &entities.GuildMember{GuildID: guildID, DiscordID: discordID, Roles: roles}, nil
+}
+
+// fakeBatchRefRepo records the references added in batches
+type fakeBatchRefRepo struct {
+	repositories.WikiMessageReferenceRepository
+	added []*entities.WikiMessageReference
+}
+
+func (r *fakeBatchRefRepo) CreateBatch(ctx context.Context, refs []*entities.WikiMessageReference, maxRefs int) (int, error) {
+	r.added = append(r.added, refs...)
+	return len(refs), nil
+}
+
+func (r *fakeBatchRefRepo) Create(ctx context.Context, ref *entities.WikiMessageReference, maxRefs int) error {
+	r.added = append(r.added, ref)
+	return nil
+}
+
+// fakeMergeLogRepo holds the latest merge of each source page
+type fakeMergeLogRepo struct {
+	repositories.WikiMergeLogRepository
+	bySource map[string]*entities.WikiMergeLog
+}
+
+func (r *fakeMergeLogRepo) GetLatestBySourcePage(ctx context.Context, sourcePageID string) (*entities.WikiMergeLog, error) {
+	return r.bySource[sourcePageID], nil
+}
+
+// newEditorRoleWikiHandler serves pages w1 and w2 in g1, where wiki edits need the
+// r-editor role: user "editor" has it, user "member" doesn't, and w2 was merged from w0
+func newEditorRoleWikiHandler(refRepo repositories.WikiMessageReferenceRepository) wikipb.WikiServiceServer {
+	pages := &fakeACLWikiRepo{
+		pages: map[string]*entities.WikiPage{
+			"w1": {ID: "w1", GuildID: "g1", Title: "Rules"},
+			"w2": {ID: "w2", GuildID: "g1", Title: "Guides"},
+		},
+		members: map[string]string{"d-editor": "g1", "d-member": "g1"},
+	}
+	merges := &fakeMergeLogRepo{bySource: map[string]*entities.WikiMergeLog{
+		"w0": {ID: "merge-1", TargetPageID: "w2", SourcePage: &entities.WikiPage{ID: "w0", GuildID: "g1"}},
+	}}
+	discordUsers := &fakeDiscordUserRepo{byUserID: map[string]*entities.DiscordUser{
+		"editor": {DiscordID: "d-editor"},
+		"member": {DiscordID: "d-member"},
+	}}
+	discordService := services.NewDiscordService(nil, &fakeRoleGuildRepo{editorRole: "r-editor"},
+		&fakeRoleMemberRepo{roles: map[string][]string{"d-editor": {"r-editor"}, "d-member": {}}},
+		nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return NewWikiHandler(services.NewWikiService(pages, refRepo, nil, merges, nil, nil, nil, nil, nil), discordService, nil, discordUsers, 0, config.PageLimits{}, slog.Default())
+}
+
+// Adding a reference, merging, and undoing a merge change pages, so members without
+// the guild's wiki editor role are turned away before anything is written
+func TestWikiPageChanges_EditorRole(t *testing.T) {
+	calls := map[string]func(h wikipb.WikiServiceServer, ctx context.Context) error{
+		"add reference": func(h wikipb.WikiServiceServer, ctx context.Context) error {
+			_, err := h.AddWikiMessageReference(ctx, &wikipb.AddWikiMessageReferenceRequest{
+				WikiPageId: "w1", MessageId: "m1", ChannelId: "c1", GuildId: "g1", AuthorId: "d1", Content: "Be nice", MessageTimestamp: timestamppb.Now(),
+			})
+			return err
+		},
+		"merge": func(h wikipb.WikiServiceServer, ctx context.Context) error {
+			_, err := h.MergeWikiPages(ctx, &wikipb.MergeWikiPagesRequest{SourcePageId: "w1", TargetPageId: "w2"})
+			return err
+		},
+		"unmerge": func(h wikipb.WikiServiceServer, ctx context.Context) error {
+			_, err := h.UnmergeWikiPages(ctx, &wikipb.UnmergeWikiPagesRequest{SourcePageId: "w0"})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			refRepo := &fakeBatchRefRepo{}
+			err := call(newEditorRoleWikiHandler(refRepo), userContext("member", "user"))
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("as a member without the role: error = %v, want PermissionDenied", err)
+			}
+			if len(refRepo.added) != 0 {
+				t.Errorf("added %d references after the role check failed", len(refRepo.added))
+			}
+		})
+	}
+
+	refRepo := &fakeBatchRefRepo{}
+	if err := calls["add reference"](newEditorRoleWikiHandler(refRepo), userContext("editor", "user")); err != nil {
+		t.Fatalf("AddWikiMessageReference() as editor error = %v", err)
+	}
+	if len(refRepo.added) != 1 {
+		t.Errorf("editor added %d references, want 1", len(refRepo.added))
+	}
+}
+
+// The batch import adds to an existing page, so it needs the same editor role as editing it
+func TestAddWikiMessageReferencesBatch_EditorRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		role     string
+		wantCode codes.Code
+	}{
+		{name: "editor", userID: "editor", role: "user", wantCode: codes.OK},
+		{name: "member without the role", userID: "member", role: "user", wantCode: codes.PermissionDenied},
+		{name: "hivemind admin", userID: "admin", role: "admin", wantCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := &fakeACLWikiRepo{
+				pages:   map[string]*entities.WikiPage{"w1": {ID: "w1", GuildID: "g1", Title: "Rules"}},
+				members: map[string]string{"d-editor": "g1", "d-member": "g1"},
+			}
+			refRepo := &fakeBatchRefRepo{}
+			discordUsers := &fakeDiscordUserRepo{byUserID: map[string]*entities.DiscordUser{
+				"editor": {DiscordID: "d-editor"},
+				"member": {DiscordID: "d-member"},
+			}}
+			discordService := services.NewDiscordService(nil, &fakeRoleGuildRepo{editorRole: "r-editor"},
+				&fakeRoleMemberRepo{roles: map[string][]string{"d-editor": {"r-editor"}, "d-member": {}}},
+				nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			h := NewWikiHandler(services.NewWikiService(pages, refRepo, nil, nil, nil, nil, nil, nil, nil), discordService, nil, discordUsers, 0, config.PageLimits{}, slog.Default())
+
+			_, err := h.AddWikiMessageReferencesBatch(userContext(tt.userID, tt.role), &wikipb.AddWikiMessageReferencesBatchRequest{
+				WikiPageId: "w1",
+				References: []*wikipb.AddWikiMessageReferenceRequest{
+					{MessageId: "m1", ChannelId: "c1", GuildId: "g1", AuthorId: "d1", Content: "Be nice", MessageTimestamp: timestamppb.Now()},
+				},
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("AddWikiMessageReferencesBatch() error = %v, want %v", err, tt.wantCode)
+			}
+			if wantAdded := tt.wantCode == codes.OK; (len(refRepo.added) > 0) != wantAdded {
+				t.Errorf("added %d references, want added = %v", len(refRepo.added), wantAdded)
+			}
+		})
+	}
+}
@@ -49,6 +49,7 @@ type UserContext struct {
 type AuthInterceptor struct {
 	jwtManager     *auth.JWTManager
 	tokenRepo      repositories.TokenRepository
+	userRepo       repositories.UserRepository
 	discordService *services.DiscordService
 	devBotToken    string // Optional dev-only bot token (not for production)
 	log            *slog.Logger
@@ -59,10 +60,11 @@ type AuthInterceptor struct {
 }
 
 // NewAuthInterceptor creates a new auth interceptor
-func NewAuthInterceptor(jwtManager *auth.JWTManager, tokenRepo repositories.TokenRepository, discordService *services.DiscordService, devBotToken string) *AuthInterceptor {
+func NewAuthInterceptor(jwtManager *auth.JWTManager, tokenRepo repositories.TokenRepository, userRepo repositories.UserRepository, discordService *services.DiscordService, devBotToken string) *AuthInterceptor {
 	return &AuthInterceptor{
 		jwtManager:     jwtManager,
 		tokenRepo:      tokenRepo,
+		userRepo:       userRepo,
 		discordService: discordService,
 		devBotToken:    devBotToken,
 		log:            slog.Default().With(slog.String("component", "auth_interceptor")),
@@ -236,6 +238,9 @@ func (i *AuthInterceptor) authenticateDiscordUser(ctx context.Context, md metada
 		nil, // TODO: Get avatar_url from metadata if available
 	)
 	if err != nil {
+		if errors.Is(err, repositories.ErrUserInactive) {
+			return nil, status.Error(codes.PermissionDenied, "user account is not active")
+		}
 		i.log.Error("failed to get/create user from Discord", slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, "failed to provision user")
 	}
@@ -319,6 +324,20 @@ func (i *AuthInterceptor) authenticateJWT(ctx context.Context, md metadata.MD) (
 		return nil, status.Error(codes.Unauthenticated, "token has been revoked")
 	}
 
+	// A deactivated account keeps its unexpired tokens unless they were revoked,
+	// so check the account on every request
+	if i.userRepo != nil {
+		if _, err := i.userRepo.GetByID(ctx, claims.UserID); err != nil {
+			switch {
+			case errors.Is(err, repositories.ErrUserInactive):
+				return nil, status.Error(codes.PermissionDenied, "user account is not active")
+			case errors.Is(err, repositories.ErrUserNotFound):
+				return nil, status.Error(codes.Unauthenticated, "user not found")
+			}
+			return nil, status.Error(codes.Internal, "user lookup failed")
+		}
+	}
+
 	return &UserContext{
 		UserID:      claims.UserID,
 		Username:    claims.Username,
@@ -0,0 +1,85 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// readMethodPrefixes are RPC name prefixes that never change data. Every other
+// RPC counts as a mutation, so new RPCs are blocked in read-only mode unless
+// they are named as reads or listed in readOnlyExemptMethods.
+var readMethodPrefixes = []string{
+	"Get", "List", "Search", "Autocomplete", "Check", "Validate", "Preview",
+}
+
+// readOnlyExemptMethods change data but stay available in read-only mode: signing
+// in and refreshing sessions, and turning read-only mode back off
+var readOnlyExemptMethods = map[string]bool{
+	"/hivemind.auth.v1.AuthService/ExchangeAuthCode":  true,
+	"/hivemind.auth.v1.AuthService/LoginWithOIDC":     true,
+	"/hivemind.auth.v1.AuthService/RefreshOAuthToken": true,
+	"/hivemind.auth.v1.AuthService/AuthenticateLocal": true,
+	"/hivemind.auth.v1.AuthService/RefreshToken":      true,
+	"/hivemind.admin.v1.AdminService/SetReadOnlyMode": true,
+}
+
+// ReadOnlyMode is the server's maintenance switch. While it is on, the interceptor
+// rejects every mutating RPC with FailedPrecondition and lets reads through.
+// The state is held in memory, so each server process is switched separately.
+type ReadOnlyMode struct {
+	enabled atomic.Bool
+}
+
+// NewReadOnlyMode creates the switch in its initial state
+func NewReadOnlyMode(enabled bool) *ReadOnlyMode {
+	m := &ReadOnlyMode{}
+	m.enabled.Store(enabled)
+	return m
+}
+
+// Enabled reports whether writes are currently blocked
+func (m *ReadOnlyMode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// Set turns read-only mode on or off
+func (m *ReadOnlyMode) Set(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// Unary returns a server interceptor that enforces read-only mode
+func (m *ReadOnlyMode) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if m.Enabled() && isMutation(info.FullMethod) {
+			return nil, status.Error(codes.FailedPrecondition, client.ReadOnlyMessage+"; changes are disabled until it ends")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// isMutation reports whether fullMethod (e.g. "/hivemind.wiki.WikiService/CreateWikiPage")
+// may change data and so is blocked in read-only mode
+func isMutation(fullMethod string) bool {
+	if readOnlyExemptMethods[fullMethod] {
+		return false
+	}
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range readMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+func TestReadOnlyMode(t *testing.T) {
+	tests := []struct {
+		method      string
+		wantBlocked bool
+	}{
+		{method: "/hivemind.wiki.WikiService/GetWikiPage"},
+		{method: "/hivemind.wiki.WikiService/SearchWikiPages"},
+		{method: "/hivemind.wiki.WikiService/AutocompleteWikiTitles"},
+		{method: "/hivemind.wiki.WikiService/PreviewWikiMerge"},
+		{method: "/hivemind.notes.v1.NoteService/ListNotes"},
+		{method: "/hivemind.discord.v1.DiscordService/CheckGuildMembership"},
+		{method: "/hivemind.auth.v1.AuthService/LoginWithOIDC"},
+		{method: "/hivemind.auth.v1.AuthService/RefreshToken"},
+		{method: "/hivemind.admin.v1.AdminService/SetReadOnlyMode"},
+		{method: "/hivemind.wiki.WikiService/CreateWikiPage", wantBlocked: true},
+		{method: "/hivemind.wiki.WikiService/UpsertWikiPage", wantBlocked: true},
+		{method: "/hivemind.wiki.WikiService/MergeWikiPages", wantBlocked: true},
+		{method: "/hivemind.wiki.WikiService/AddWikiMessageReference", wantBlocked: true},
+		{method: "/hivemind.notes.v1.NoteService/UpdateNote", wantBlocked: true},
+		{method: "/hivemind.quotes.v1.QuoteService/DeleteQuote", wantBlocked: true},
+		{method: "/hivemind.preferences.v1.PreferencesService/SetDefaultGuild", wantBlocked: true},
+		{method: "/hivemind.auth.v1.AuthService/RevokeToken", wantBlocked: true},
+		// Unrecognized names count as writes so new RPCs are covered
+		{method: "/hivemind.wiki.WikiService/ArchiveWikiPage", wantBlocked: true},
+	}
+
+	for _, enabled := range []bool{false, true} {
+		mode := NewReadOnlyMode(enabled)
+		for _, tt := range tests {
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			}
+
+			_, err := mode.Unary()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			wantBlocked := enabled && tt.wantBlocked
+			if called == wantBlocked {
+				t.Errorf("read_only=%v %s: handler called = %v, want %v", enabled, tt.method, called, !wantBlocked)
+			}
+			if wantBlocked && (status.Code(err) != codes.FailedPrecondition || !client.IsReadOnly(err)) {
+				t.Errorf("read_only=%v %s: error = %v, want a read-only FailedPrecondition", enabled, tt.method, err)
+			}
+			if !wantBlocked && err != nil {
+				t.Errorf("read_only=%v %s: error = %v, want none", enabled, tt.method, err)
+			}
+		}
+	}
+}
+
+func TestReadOnlyModeToggle(t *testing.T) {
+	mode := NewReadOnlyMode(false)
+	info := &grpc.UnaryServerInfo{FullMethod: "/hivemind.notes.v1.NoteService/CreateNote"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+
+	mode.Set(true)
+	if _, err := mode.Unary()(context.Background(), nil, info, handler); !client.IsReadOnly(err) {
+		t.Errorf("after Set(true) error = %v, want read-only rejection", err)
+	}
+	mode.Set(false)
+	if _, err := mode.Unary()(context.Background(), nil, info, handler); err != nil {
+		t.Errorf("after Set(false) error = %v, want none", err)
+	}
+}
@@ -0,0 +1,88 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc"
+
+	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	quotespb "github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/internal/domain/entities"
+)
+
+// viewedContentMethods are the RPCs that open a single wiki page, note, or quote,
+// mapped to the type of item they return
+var viewedContentMethods = map[string]string{
+	wikipb.WikiService_GetWikiPage_FullMethodName:        entities.ActivityTypeWiki,
+	wikipb.WikiService_GetWikiPageByTitle_FullMethodName: entities.ActivityTypeWiki,
+	notespb.NoteService_GetNote_FullMethodName:           entities.ActivityTypeNote,
+	notespb.NoteService_GetNoteBySlug_FullMethodName:     entities.ActivityTypeNote,
+	quotespb.QuoteService_GetQuote_FullMethodName:        entities.ActivityTypeQuote,
+	quotespb.QuoteService_GetQuoteByCode_FullMethodName:  entities.ActivityTypeQuote,
+}
+
+// ViewRecorder stores a view in a user's recently viewed history
+type ViewRecorder interface {
+	RecordView(ctx context.Context, userID, contentType, contentID string) error
+}
+
+// ViewHistory records the wiki pages, notes, and quotes users open. Recording is a side
+// effect of a read, so a failure is logged rather than failing the read, and nothing is
+// recorded in read-only mode.
+type ViewHistory struct {
+	recorder ViewRecorder
+	readOnly *ReadOnlyMode
+	log      *slog.Logger
+}
+
+// NewViewHistory creates the view history interceptor. readOnly may be nil.
+func NewViewHistory(recorder ViewRecorder, readOnly *ReadOnlyMode) *ViewHistory {
+	return &ViewHistory{
+		recorder: recorder,
+		readOnly: readOnly,
+		log:      slog.Default().With(slog.String("interceptor", "view_history")),
+	}
+}
+
+// Unary returns a server interceptor that records successful single-item reads
+func (v *ViewHistory) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if contentType, ok := viewedContentMethods[info.FullMethod]; ok && err == nil {
+			v.record(ctx, contentType, resp)
+		}
+		return resp, err
+	}
+}
+
+func (v *ViewHistory) record(ctx context.Context, contentType string, resp interface{}) {
+	if v.readOnly != nil && v.readOnly.Enabled() {
+		return
+	}
+
+	// The bot's own lookups aren't a person viewing anything
+	user, err := GetUserFromContext(ctx)
+	if err != nil || user.Role == RoleBot {
+		return
+	}
+
+	item, ok := resp.(interface{ GetId() string })
+	if !ok || item.GetId() == "" {
+		return
+	}
+
+	if err := v.recorder.RecordView(ctx, user.UserID, contentType, item.GetId()); err != nil {
+		v.log.Warn("failed to record view",
+			slog.String("user_id", user.UserID),
+			slog.String("type", contentType),
+			slog.String("id", item.GetId()),
+			slog.String("error", err.Error()))
+	}
+}
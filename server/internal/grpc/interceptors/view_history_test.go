@@ -0,0 +1,69 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	quotespb "github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+)
+
+type fakeViewRecorder struct {
+	views []string
+}
+
+func (f *fakeViewRecorder) RecordView(ctx context.Context, userID, contentType, contentID string) error {
+	f.views = append(f.views, userID+" "+contentType+" "+contentID)
+	return nil
+}
+
+func TestViewHistory(t *testing.T) {
+	ada := context.WithValue(context.Background(), UserContextKey, &UserContext{UserID: "ada", Role: "user"})
+	bot := context.WithValue(context.Background(), UserContextKey, &UserContext{UserID: "bot-dev", Role: RoleBot})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		resp     interface{}
+		err      error
+		readOnly bool
+		want     string // Recorded view, "" for none
+	}{
+		{name: "wiki page", ctx: ada, method: wikipb.WikiService_GetWikiPageByTitle_FullMethodName, resp: &wikipb.WikiPage{Id: "p1"}, want: "ada wiki p1"},
+		{name: "note", ctx: ada, method: notespb.NoteService_GetNote_FullMethodName, resp: &notespb.Note{Id: "n1"}, want: "ada note n1"},
+		{name: "quote by code", ctx: ada, method: quotespb.QuoteService_GetQuoteByCode_FullMethodName, resp: &quotespb.Quote{Id: "q1"}, want: "ada quote q1"},
+		{name: "list", ctx: ada, method: notespb.NoteService_ListNotes_FullMethodName, resp: &notespb.ListNotesResponse{}},
+		{name: "random quote", ctx: ada, method: quotespb.QuoteService_GetRandomQuote_FullMethodName, resp: &quotespb.Quote{Id: "q1"}},
+		{name: "failed read", ctx: ada, method: notespb.NoteService_GetNote_FullMethodName, err: errors.New("not found")},
+		{name: "bot", ctx: bot, method: wikipb.WikiService_GetWikiPage_FullMethodName, resp: &wikipb.WikiPage{Id: "p1"}},
+		{name: "read-only", ctx: ada, method: wikipb.WikiService_GetWikiPage_FullMethodName, resp: &wikipb.WikiPage{Id: "p1"}, readOnly: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &fakeViewRecorder{}
+			history := NewViewHistory(recorder, NewReadOnlyMode(tt.readOnly))
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return tt.resp, tt.err
+			}
+
+			resp, err := history.Unary()(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if resp != tt.resp || err != tt.err {
+				t.Errorf("interceptor returned (%v, %v), want the handler's (%v, %v)", resp, err, tt.resp, tt.err)
+			}
+
+			var want []string
+			if tt.want != "" {
+				want = []string{tt.want}
+			}
+			if len(recorder.views) != len(want) || (len(want) == 1 && recorder.views[0] != want[0]) {
+				t.Errorf("recorded views = %v, want %v", recorder.views, want)
+			}
+		})
+	}
+}
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -16,12 +19,15 @@ import (
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 
+	activitypb "github.com/devilmonastery/hivemind/api/generated/go/activitypb"
 	adminpb "github.com/devilmonastery/hivemind/api/generated/go/adminpb"
 	authpb "github.com/devilmonastery/hivemind/api/generated/go/authpb"
 	discordpb "github.com/devilmonastery/hivemind/api/generated/go/discordpb"
 	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	preferencespb "github.com/devilmonastery/hivemind/api/generated/go/preferencespb"
 	quotespb "github.com/devilmonastery/hivemind/api/generated/go/quotespb"
 	"github.com/devilmonastery/hivemind/api/generated/go/tokenspb"
+	"github.com/devilmonastery/hivemind/api/generated/go/userpb"
 	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
 	"github.com/devilmonastery/hivemind/internal/auth"
 	"github.com/devilmonastery/hivemind/internal/auth/oidc"
@@ -29,6 +35,7 @@ import (
 	"github.com/devilmonastery/hivemind/internal/domain/repositories"
 	"github.com/devilmonastery/hivemind/internal/domain/services"
 	"github.com/devilmonastery/hivemind/internal/infrastructure/database/postgres"
+	"github.com/devilmonastery/hivemind/internal/notify"
 	"github.com/devilmonastery/hivemind/internal/pkg/idgen"
 	"github.com/devilmonastery/hivemind/internal/pkg/logger"
 	_ "github.com/devilmonastery/hivemind/internal/pkg/metrics" // Initialize metrics
@@ -54,6 +61,7 @@ func newRootCommand() *cobra.Command {
 		logToStderr   bool
 		alsoLogStderr bool
 		logFormat     string
+		logSampling   logger.SamplingConfig
 	)
 
 	cmd := &cobra.Command{
@@ -82,9 +90,10 @@ func newRootCommand() *cobra.Command {
 				if !cmd.Flags().Changed("logtostderr") && cfg.Logging.Output == "stderr" {
 					logToStderr = true
 				}
+				logSampling = logger.SamplingConfig(cfg.Logging.Sampling)
 			}
 
-			return setupServerLogging(logLevel, logFile, logToStderr, alsoLogStderr, logFormat)
+			return setupServerLogging(logLevel, logFile, logToStderr, alsoLogStderr, logFormat, logSampling)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Get metrics port flag if set
@@ -110,12 +119,15 @@ func newRootCommand() *cobra.Command {
 	// Add subcommands
 	cmd.AddCommand(newUserCommand())
 	cmd.AddCommand(newTokenCommand())
+	cmd.AddCommand(newAuditCommand())
+	cmd.AddCommand(newGuildCommand())
+	cmd.AddCommand(newContentCommand())
 
 	return cmd
 }
 
 // setupServerLogging configures the global logger for the server
-func setupServerLogging(logLevel, logFile string, logToStderr, alsoLogStderr bool, logFormat string) error {
+func setupServerLogging(logLevel, logFile string, logToStderr, alsoLogStderr bool, logFormat string, sampling logger.SamplingConfig) error {
 	// Default to stderr logging unless file is specified
 	if logFile == "" {
 		logToStderr = true
@@ -127,6 +139,7 @@ func setupServerLogging(logLevel, logFile string, logToStderr, alsoLogStderr boo
 		LogToStderr:   logToStderr,
 		AlsoLogStderr: alsoLogStderr,
 		Format:        logFormat,
+		Sampling:      sampling,
 	}
 
 	globalLogger, err := logger.SetupLogger(cfg)
@@ -144,21 +157,26 @@ func runServer(configPath string, forceVersion int, metricsPortFlag int) error {
 	logger := slog.Default().With("component", "server")
 	logger.Info("Starting server initialization")
 
-	// Initialize Snowflake ID generator
-	if err := idgen.Initialize(1); err != nil {
-		return fmt.Errorf("failed to initialize ID generator: %w", err)
-	}
-
 	// Load configuration
 	cfg, err := config.Load(configPath)
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	// Report every missing or invalid setting now rather than one at a time as it's used
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
 
 	// Logging was already configured in PreRunE
 	logger = slog.Default().With("component", "server")
 	logger.Info("Configuration loaded", "path", configPath)
 
+	// Initialize Snowflake ID generator
+	nodeID := resolveNodeID(cfg, logger)
+	if err := idgen.Initialize(nodeID); err != nil {
+		return fmt.Errorf("failed to initialize ID generator: %w", err)
+	}
+
 	// Debug: log loaded providers
 	logger.Info("Loaded OAuth providers", "count", len(cfg.Auth.Providers))
 	for _, p := range cfg.Auth.Providers {
@@ -219,6 +237,15 @@ func runServer(configPath string, forceVersion int, metricsPortFlag int) error {
 	}
 	defer pgConn.Close()
 
+	// Two servers sharing a node ID would generate duplicate IDs, so refuse to start
+	// while another live server holds ours
+	releaseNodeID, err := pgConn.ClaimNodeID(context.Background(), nodeID)
+	if err != nil {
+		return fmt.Errorf("failed to claim Snowflake node ID %d (set a unique id_generator.node_id or %s): %w", nodeID, config.NodeIDEnvVar, err)
+	}
+	defer releaseNodeID()
+	logger.Info("Claimed Snowflake node ID", "node_id", nodeID)
+
 	// Handle force migration if requested
 	if forceVersion >= 0 {
 		logger.Info("Force setting migration version", "version", forceVersion)
@@ -245,46 +272,84 @@ func runServer(configPath string, forceVersion int, metricsPortFlag int) error {
 	discordGuildRepo := postgres.NewDiscordGuildRepository(pgConn.DB)
 	guildMemberRepo := postgres.NewGuildMemberRepository(pgConn.DB)
 	wikiTitleRepo := postgres.NewWikiTitleRepository(pgConn.DB.DB)
+	wikiMergeLogRepo := postgres.NewWikiMergeLogRepository(pgConn.DB.DB)
 	wikiPageRepo := postgres.NewWikiPageRepository(pgConn.DB.DB, wikiTitleRepo)
 	noteRepo := postgres.NewNoteRepository(pgConn.DB.DB)
 	noteMessageRefRepo := postgres.NewNoteMessageReferenceRepository(pgConn.DB.DB)
 	quoteRepo := postgres.NewQuoteRepository(pgConn.DB.DB)
 	wikiMessageRefRepo := postgres.NewWikiMessageReferenceRepository(pgConn.DB.DB)
+	userPrefsRepo := postgres.NewUserPreferencesRepository(pgConn.DB)
+	activityRepo := postgres.NewActivityRepository(pgConn.DB.DB)
+	recentlyViewedRepo := postgres.NewRecentlyViewedRepository(pgConn.DB.DB)
+	guildContentRepo := postgres.NewGuildContentRepository(pgConn.DB.DB)
+	moderationRepo := postgres.NewModerationRepository(pgConn.DB.DB)
+	deadLetterRepo := postgres.NewDeadLetterRepository(pgConn.DB.DB)
 
 	// Initialize JWT manager from config
-	if cfg.Auth.JWT.SigningKey == "" {
-		logger.Error("JWT signing key not configured")
-		os.Exit(1)
-	}
-	if cfg.Auth.JWT.Lifetime == 0 {
-		logger.Error("JWT lifetime not configured")
-		os.Exit(1)
-	}
 	jwtManager := auth.NewJWTManager(cfg.Auth.JWT.SigningKey, cfg.Auth.JWT.Lifetime)
 
 	// Initialize services
 	userService := services.NewUserService(userRepo, auditRepo)
 	tokenService := services.NewTokenService(tokenRepo, userRepo, auditRepo)
-	discordService := services.NewDiscordService(discordUserRepo, discordGuildRepo, guildMemberRepo, userRepo, logger)
-	wikiService := services.NewWikiService(wikiPageRepo, wikiMessageRefRepo, wikiTitleRepo)
-	noteService := services.NewNoteService(noteRepo, noteMessageRefRepo)
-	quoteService := services.NewQuoteService(quoteRepo)
+	discordService := services.NewDiscordService(discordUserRepo, discordGuildRepo, guildMemberRepo, userRepo, deadLetterRepo, logger)
+	defer discordService.Close()
+
+	// Outbound webhooks for content changes, configured per guild in its settings
+	webhookDispatcher := notify.NewDispatcher(discordService, notify.Config{})
+	defer webhookDispatcher.Close()
+
+	// Blocklist checked before wiki pages, notes, and quotes are saved; a no-op without patterns
+	moderationCfg := cfg.Content.Moderation
+	moderationService, err := services.NewModerationService(moderationCfg.Patterns, moderationCfg.Policy == config.ModerationPolicyFlag, moderationRepo)
+	if err != nil {
+		return fmt.Errorf("failed to configure content moderation: %w", err)
+	}
+	if len(moderationCfg.Patterns) > 0 {
+		logger.Info("content moderation enabled", "patterns", len(moderationCfg.Patterns), "policy", moderationCfg.Policy)
+	}
+
+	wikiService := services.NewWikiService(wikiPageRepo, wikiMessageRefRepo, wikiTitleRepo, wikiMergeLogRepo, postgres.NewTransactor(pgConn.DB.DB), webhookDispatcher, auditRepo, moderationService, discordService)
+	noteService := services.NewNoteService(noteRepo, noteMessageRefRepo, webhookDispatcher, auditRepo, moderationService, discordService, discordService)
+	quoteService := services.NewQuoteService(quoteRepo, webhookDispatcher, auditRepo, moderationService, discordService)
+	preferencesService := services.NewPreferencesService(userPrefsRepo, guildMemberRepo, discordGuildRepo)
+	activityService := services.NewActivityService(activityRepo, recentlyViewedRepo)
+	guildContentService := services.NewGuildContentService(guildContentRepo, discordGuildRepo, userRepo, auditRepo)
+	// Cancelled on SIGINT or SIGTERM to stop background jobs and drain the gRPC server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Purge expired and revoked API tokens and expired OIDC sessions in the background
+	go runAuthCleanup(ctx, cfg.Auth.Cleanup, pgConn, tokenService, sessionRepo, logger)
+
 	authHandler := handlers.NewAuthHandler(userRepo, tokenRepo, sessionRepo, discordUserRepo, jwtManager, cfg)
 
 	// Initialize auth interceptor
-	authInterceptor := interceptors.NewAuthInterceptor(jwtManager, tokenRepo, discordService, cfg.Auth.DevBotToken)
+	authInterceptor := interceptors.NewAuthInterceptor(jwtManager, tokenRepo, userRepo, discordService, cfg.Auth.DevBotToken)
 
 	// Initialize gRPC handlers
-	adminHandler := handlers.NewAdminHandler(userService)
+	// Maintenance switch that rejects writes while keeping reads available
+	readOnlyMode := interceptors.NewReadOnlyMode(cfg.Maintenance.ReadOnly)
+	if cfg.Maintenance.ReadOnly {
+		logger.Warn("starting in read-only maintenance mode")
+	}
+
+	// Records the wiki pages, notes, and quotes users open for their recently viewed history
+	viewHistory := interceptors.NewViewHistory(activityService, readOnlyMode)
+
+	adminHandler := handlers.NewAdminHandler(userService, tokenService, guildContentService, moderationService, auditRepo, readOnlyMode)
 	tokenHandler := handlers.NewTokenHandler(tokenService)
 	discordHandler := handlers.NewDiscordHandler(discordService)
-	wikiHandler := handlers.NewWikiHandler(wikiService, discordService, guildMemberRepo, discordUserRepo, logger)
-	noteHandler := handlers.NewNoteHandler(noteService, discordUserRepo)
-	quoteHandler := handlers.NewQuoteHandler(quoteService, discordUserRepo)
+	wikiHandler := handlers.NewWikiHandler(wikiService, discordService, guildMemberRepo, discordUserRepo, cfg.Content.MaxWikiBodyLength, cfg.Content.Pagination.Wiki, logger)
+	noteHandler := handlers.NewNoteHandler(noteService, discordUserRepo, cfg.Content.MaxNoteBodyLength, cfg.Content.Pagination.Notes)
+	quoteHandler := handlers.NewQuoteHandler(quoteService, discordUserRepo, cfg.Content.MaxQuoteBodyLength, cfg.Content.Pagination.Quotes)
+	preferencesHandler := handlers.NewPreferencesHandler(preferencesService, discordUserRepo)
+	activityHandler := handlers.NewActivityHandler(activityService, discordUserRepo)
+	userHandler := handlers.NewUserHandler(userService)
 
 	// Create gRPC server with interceptors and keepalive
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(authInterceptor.Unary()),
+		// Authenticate first so rejected writes are only ever reported to signed-in callers
+		grpc.ChainUnaryInterceptor(authInterceptor.Unary(), readOnlyMode.Unary(), viewHistory.Unary()),
 		grpc.StreamInterceptor(authInterceptor.Stream()),
 		// Keepalive settings to prevent connections from being dropped
 		grpc.KeepaliveParams(keepalive.ServerParameters{
@@ -308,6 +373,9 @@ func runServer(configPath string, forceVersion int, metricsPortFlag int) error {
 	wikipb.RegisterWikiServiceServer(grpcServer, wikiHandler)
 	notespb.RegisterNoteServiceServer(grpcServer, noteHandler)
 	quotespb.RegisterQuoteServiceServer(grpcServer, quoteHandler)
+	preferencespb.RegisterPreferencesServiceServer(grpcServer, preferencesHandler)
+	activitypb.RegisterActivityServiceServer(grpcServer, activityHandler)
+	userpb.RegisterUserServiceServer(grpcServer, userHandler)
 
 	// Register health check service
 	healthServer := health.NewServer()
@@ -361,6 +429,12 @@ func runServer(configPath string, forceVersion int, metricsPortFlag int) error {
 		}
 	}()
 
+	go func() {
+		<-ctx.Done()
+		logger.Info("Shutting down gRPC server")
+		grpcServer.GracefulStop()
+	}()
+
 	logger.Info("gRPC server starting", "address", listener.Addr().String())
 	if err := grpcServer.Serve(listener); err != nil {
 		return fmt.Errorf("failed to serve gRPC server: %w", err)
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/devilmonastery/hivemind/internal/config"
+	"github.com/devilmonastery/hivemind/internal/pkg/idgen"
+)
+
+// defaultNodeID is used outside Kubernetes when no node ID is configured
+const defaultNodeID = 1
+
+// resolveNodeID picks this server's Snowflake node ID: the configured one, else one
+// derived from the pod name in Kubernetes, else defaultNodeID. Choices that can
+// collide with another replica are logged as warnings.
+func resolveNodeID(cfg *config.Config, logger *slog.Logger) int64 {
+	if nodeID := cfg.IDGenerator.NodeID; nodeID != nil {
+		logger.Info("Using configured Snowflake node ID", "node_id", *nodeID)
+		return *nodeID
+	}
+
+	hostname, _ := os.Hostname()
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" && hostname != "" {
+		nodeID, fromOrdinal := idgen.NodeIDFromHostname(hostname)
+		if fromOrdinal {
+			logger.Info("Using Snowflake node ID from StatefulSet pod ordinal", "node_id", nodeID, "hostname", hostname)
+		} else {
+			logger.Warn("Snowflake node ID was hashed from the pod name and may collide with another replica; set id_generator.node_id or "+config.NodeIDEnvVar+" per replica, or run as a StatefulSet",
+				"node_id", nodeID, "hostname", hostname)
+		}
+		return nodeID
+	}
+
+	if cfg.Environment != "" && cfg.Environment != "local" {
+		logger.Warn("No Snowflake node ID configured, using the default; every server instance needs its own id_generator.node_id or "+config.NodeIDEnvVar,
+			"node_id", defaultNodeID, "environment", cfg.Environment)
+	} else {
+		logger.Info("Using default Snowflake node ID", "node_id", defaultNodeID)
+	}
+	return defaultNodeID
+}
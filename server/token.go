@@ -118,7 +118,7 @@ func newRevokeTokenCommand() *cobra.Command {
 
 func createServiceToken(configPath, name, role string, expiryDays int) error {
 	// Initialize ID generator
-	if err := idgen.Initialize(1); err != nil {
+	if err := idgen.Initialize(idgen.CLINodeID); err != nil {
 		return fmt.Errorf("failed to initialize ID generator: %w", err)
 	}
 
@@ -248,7 +248,7 @@ func createServiceToken(configPath, name, role string, expiryDays int) error {
 
 func listTokens(configPath, userID string) error {
 	// Initialize ID generator
-	if err := idgen.Initialize(1); err != nil {
+	if err := idgen.Initialize(idgen.CLINodeID); err != nil {
 		return fmt.Errorf("failed to initialize ID generator: %w", err)
 	}
 
@@ -311,7 +311,7 @@ func listTokens(configPath, userID string) error {
 
 func revokeToken(configPath, tokenID string) error {
 	// Initialize ID generator
-	if err := idgen.Initialize(1); err != nil {
+	if err := idgen.Initialize(idgen.CLINodeID); err != nil {
 		return fmt.Errorf("failed to initialize ID generator: %w", err)
 	}
 
@@ -2,14 +2,20 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/devilmonastery/hivemind/internal/config"
 	"github.com/devilmonastery/hivemind/internal/domain/entities"
+	"github.com/devilmonastery/hivemind/internal/domain/repositories"
+	"github.com/devilmonastery/hivemind/internal/domain/services"
 	"github.com/devilmonastery/hivemind/internal/infrastructure/database/postgres"
 	"github.com/devilmonastery/hivemind/internal/pkg/idgen"
 	"github.com/devilmonastery/hivemind/migrations"
@@ -23,6 +29,9 @@ func newUserCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(newUserCreateCommand())
+	cmd.AddCommand(newUserListCommand())
+	cmd.AddCommand(newUserSetActiveCommand(false))
+	cmd.AddCommand(newUserSetActiveCommand(true))
 
 	return cmd
 }
@@ -68,7 +77,7 @@ func newUserCreateCommand() *cobra.Command {
 
 func createUser(configPath, email, password, name, role, userType string, isActive bool) error {
 	// Initialize ID generator
-	if err := idgen.Initialize(1); err != nil {
+	if err := idgen.Initialize(idgen.CLINodeID); err != nil {
 		return fmt.Errorf("failed to initialize ID generator: %w", err)
 	}
 
@@ -149,3 +158,238 @@ func createUser(configPath, email, password, name, role, userType string, isActi
 
 	return nil
 }
+
+// newUserSetActiveCommand builds "user deactivate" or, when active is true, "user reactivate"
+func newUserSetActiveCommand(active bool) *cobra.Command {
+	var (
+		changedBy    string
+		revokeTokens bool
+		configPath   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "deactivate <user-id>",
+		Short: "Deactivate a user account",
+		Long: `Deactivate a user account so every authenticated request it makes is rejected.
+Existing tokens stay valid until they expire unless --revoke-tokens is set.`,
+		Example: `  # Deactivate a user and end their sessions immediately
+  server user deactivate 123456 --by 654321 --revoke-tokens`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setUserActive(configPath, args[0], active, changedBy, revokeTokens)
+		},
+	}
+	if active {
+		cmd.Use = "reactivate <user-id>"
+		cmd.Short = "Reactivate a deactivated user account"
+		cmd.Long = "Reactivate a user account, restoring access with any of its tokens that were not revoked"
+		cmd.Example = `  server user reactivate 123456 --by 654321`
+	} else {
+		cmd.Flags().BoolVar(&revokeTokens, "revoke-tokens", false, "Also revoke all of the user's tokens")
+	}
+
+	cmd.Flags().StringVar(&changedBy, "by", "", "ID of the admin making the change, recorded in the audit log (required)")
+	cmd.Flags().StringVar(&configPath, "config", "", "Path to config file (optional)")
+
+	cmd.MarkFlagRequired("by")
+
+	return cmd
+}
+
+func setUserActive(configPath, userID string, active bool, changedBy string, revokeTokens bool) error {
+	// Initialize ID generator
+	if err := idgen.Initialize(idgen.CLINodeID); err != nil {
+		return fmt.Errorf("failed to initialize ID generator: %w", err)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Initialize database
+	pgConn, err := postgres.NewConnection(cfg.Database.Postgres.ConnectionString())
+	if err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL database: %w", err)
+	}
+	defer pgConn.Close()
+
+	userRepo := postgres.NewUserRepository(pgConn.DB)
+	auditRepo := postgres.NewAuditRepository(pgConn.DB)
+	userService := services.NewUserService(userRepo, auditRepo)
+
+	ctx := context.Background()
+	if err := userService.SetUserActive(ctx, userID, active, changedBy); err != nil {
+		return err
+	}
+
+	if !active && revokeTokens {
+		tokenService := services.NewTokenService(postgres.NewTokenRepository(pgConn.DB), userRepo, auditRepo)
+		if err := tokenService.RevokeAllUserTokens(ctx, userID, changedBy); err != nil {
+			return fmt.Errorf("user deactivated but failed to revoke tokens: %w", err)
+		}
+	}
+
+	slog.Info("User active state changed",
+		"user_id", userID,
+		"is_active", active,
+		"tokens_revoked", !active && revokeTokens,
+		"changed_by", changedBy,
+	)
+
+	return nil
+}
+
+func newUserListCommand() *cobra.Command {
+	var (
+		role       string
+		userType   string
+		status     string
+		search     string
+		limit      int
+		pageToken  string
+		asJSON     bool
+		configPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List users",
+		Long:  "List users, newest first, optionally filtered by role, type, status, and email or name",
+		Example: `  # Show the newest 50 users
+  server user list
+
+  # Find deactivated admins
+  server user list --role admin --status inactive
+
+  # Search by email or name and print JSON
+  server user list --search example.com --json
+
+  # Show the next page
+  server user list --page-token <token from the previous page>`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := repositories.ListUsersOptions{
+				Limit:     limit,
+				Search:    search,
+				SortBy:    "created_at",
+				SortOrder: "desc",
+			}
+			if role != "" {
+				r := entities.Role(role)
+				opts.Role = &r
+			}
+			if userType != "" {
+				t := entities.UserType(userType)
+				opts.UserType = &t
+			}
+			switch status {
+			case "":
+			case "active", "inactive":
+				active := status == "active"
+				opts.IsActive = &active
+			default:
+				return fmt.Errorf("invalid status: %s (must be 'active' or 'inactive')", status)
+			}
+			cursor, err := repositories.ParsePageToken(pageToken)
+			if err != nil {
+				return fmt.Errorf("invalid --page-token: %w", err)
+			}
+			opts.Cursor = cursor
+			return listUsers(configPath, opts, asJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&role, "role", "", "Filter by role (user, admin)")
+	cmd.Flags().StringVar(&userType, "type", "", "Filter by user type (local, oidc, system)")
+	cmd.Flags().StringVar(&status, "status", "", "Filter by status (active, inactive)")
+	cmd.Flags().StringVar(&search, "search", "", "Filter by a substring of email or display name")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of users to show")
+	cmd.Flags().StringVar(&pageToken, "page-token", "", "Continue from a previous page")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print JSON instead of a table")
+	cmd.Flags().StringVar(&configPath, "config", "", "Path to config file (optional)")
+
+	return cmd
+}
+
+// userListEntry is one user in "user list --json" output
+type userListEntry struct {
+	ID          string     `json:"id"`
+	Email       string     `json:"email"`
+	DisplayName string     `json:"display_name"`
+	Role        string     `json:"role"`
+	UserType    string     `json:"user_type"`
+	Active      bool       `json:"active"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLogin   *time.Time `json:"last_login,omitempty"`
+}
+
+func listUsers(configPath string, opts repositories.ListUsersOptions, asJSON bool) error {
+	// Load configuration
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Initialize database
+	pgConn, err := postgres.NewConnection(cfg.Database.Postgres.ConnectionString())
+	if err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL database: %w", err)
+	}
+	defer pgConn.Close()
+
+	userService := services.NewUserService(postgres.NewUserRepository(pgConn.DB), nil)
+
+	users, total, next, err := userService.ListUsers(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		entries := make([]userListEntry, len(users))
+		for i, u := range users {
+			entries[i] = userListEntry{
+				ID:          u.ID,
+				Email:       u.Email,
+				DisplayName: u.DisplayName,
+				Role:        string(u.Role),
+				UserType:    string(u.UserType),
+				Active:      u.IsActive,
+				CreatedAt:   u.CreatedAt,
+				LastLogin:   u.LastLogin,
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Users         []userListEntry `json:"users"`
+			Total         int64           `json:"total"`
+			NextPageToken string          `json:"next_page_token,omitempty"`
+		}{entries, total, next.Token()})
+	}
+
+	if len(users) == 0 {
+		fmt.Println("No users found")
+		return nil
+	}
+
+	fmt.Printf("\nShowing %d of %d users:\n\n", len(users), total)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tEMAIL\tNAME\tROLE\tTYPE\tACTIVE\tCREATED\tLAST LOGIN")
+	for _, u := range users {
+		lastLogin := "never"
+		if u.LastLogin != nil {
+			lastLogin = u.LastLogin.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\t%s\t%s\n",
+			u.ID, u.Email, u.DisplayName, u.Role, u.UserType, u.IsActive, u.CreatedAt.Format("2006-01-02 15:04"), lastLogin)
+	}
+	w.Flush()
+
+	if token := next.Token(); token != "" {
+		fmt.Printf("\nMore users: --page-token %s\n", token)
+	}
+	fmt.Println()
+
+	return nil
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 
@@ -10,6 +11,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	authpb "github.com/devilmonastery/hivemind/api/generated/go/authpb"
+	"github.com/devilmonastery/hivemind/internal/pkg/rpcerr"
 )
 
 // SessionManager interface for accessing session data
@@ -18,6 +20,7 @@ type SessionManager interface {
 	GetToken(r *http.Request) (string, error)
 	GetTokenID(r *http.Request) (string, error)
 	SetToken(r *http.Request, w http.ResponseWriter, token, tokenID string) error
+	ClearToken(r *http.Request, w http.ResponseWriter) error
 }
 
 // requestContextKey is the key for storing request/response in context
@@ -97,6 +100,16 @@ func (i *AutoRefreshInterceptor) UnaryInterceptor() grpc.UnaryClientInterceptor
 
 				// Retry the request with new token
 				err = invoker(retryCtx, method, req, reply, cc, opts...)
+			} else if rpcerr.HasReason(refreshErr, rpcerr.ReasonReauthRequired) {
+				// The OAuth session is gone; drop the token so later requests go
+				// straight to login instead of retrying the refresh
+				i.log.Info("session expired, login required",
+					slog.String("method", method))
+				if clearErr := i.sessionManager.ClearToken(r, w); clearErr != nil {
+					i.log.Warn("failed to clear expired token",
+						slog.String("error", clearErr.Error()))
+				}
+				return err
 			} else {
 				i.log.Error("token refresh failed",
 					slog.String("method", method),
@@ -114,7 +127,10 @@ func (i *AutoRefreshInterceptor) refreshToken(ctx context.Context, r *http.Reque
 	tokenID, err := i.sessionManager.GetTokenID(r)
 	if err != nil || tokenID == "" {
 		i.log.Warn("no token ID available for refresh",
-			slog.String("error", err.Error()))
+			slog.Any("error", err))
+		if err == nil {
+			err = errors.New("no token ID in session")
+		}
 		return err
 	}
 
@@ -2,7 +2,10 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -21,6 +24,9 @@ type WebServerConfig struct {
 	Session   SessionConfig   `yaml:"session"`
 	Templates TemplatesConfig `yaml:"templates"`
 	Logging   LoggingConfig   `yaml:"logging"`
+	Security  SecurityConfig  `yaml:"security"`
+	Markdown  MarkdownConfig  `yaml:"markdown"`
+	Internal  InternalConfig  `yaml:"internal"`
 }
 
 // HTTPServer holds HTTP server configuration
@@ -42,7 +48,10 @@ type OAuthConfig struct {
 
 // SessionConfig holds session configuration
 type SessionConfig struct {
-	Secret string `yaml:"secret"` // 32-byte base64-encoded or hex string
+	Secret           string        `yaml:"secret"`              // 32-byte base64-encoded or hex string
+	MaxAge           time.Duration `yaml:"max_age"`             // Session lifetime without "remember me" (default 8h)
+	RememberMeMaxAge time.Duration `yaml:"remember_me_max_age"` // Session lifetime with "remember me" (default 720h)
+	SecureCookies    *bool         `yaml:"secure_cookies"`      // Defaults to true when oauth.redirect_uri uses https
 }
 
 // TemplatesConfig holds template loading configuration
@@ -56,6 +65,43 @@ type LoggingConfig struct {
 	Format string `yaml:"format" default:"json"` // Log format: json, text
 }
 
+// DefaultContentSecurityPolicy allows the inline scripts and styles in the templates,
+// the eval that Alpine.js needs, Google Fonts, and images from any HTTPS host (Discord
+// avatars and attachments, and images embedded in wiki pages)
+const DefaultContentSecurityPolicy = "default-src 'self'; " +
+	"script-src 'self' 'unsafe-inline' 'unsafe-eval'; " +
+	"style-src 'self' 'unsafe-inline' https://fonts.googleapis.com; " +
+	"font-src 'self' https://fonts.gstatic.com; " +
+	"img-src 'self' data: https:; " +
+	"connect-src 'self'; " +
+	"frame-ancestors 'none'; " +
+	"base-uri 'self'"
+
+// SecurityConfig holds the security headers set on every response and CORS settings.
+// Setting a header to an empty string leaves it out.
+type SecurityConfig struct {
+	ContentSecurityPolicy string   `yaml:"content_security_policy"`
+	FrameOptions          string   `yaml:"frame_options" default:"DENY"`                              // X-Frame-Options
+	ReferrerPolicy        string   `yaml:"referrer_policy" default:"strict-origin-when-cross-origin"` // Referrer-Policy
+	CORSAllowedOrigins    []string `yaml:"cors_allowed_origins"`                                      // e.g. https://example.com; "*" allows any origin without cookies
+}
+
+// MarkdownConfig overrides the HTML allowed in rendered wiki pages, notes, and quotes.
+// Each list that is left empty keeps the built-in default.
+type MarkdownConfig struct {
+	AllowedElements   []string            `yaml:"allowed_elements"`    // e.g. p, a, img
+	AllowedAttributes map[string][]string `yaml:"allowed_attributes"`  // Per element, e.g. a: [href, title]
+	AllowedURLSchemes []string            `yaml:"allowed_url_schemes"` // Schemes allowed in links and images, e.g. https
+}
+
+// InternalConfig protects the operational endpoints on the metrics port: /metrics and,
+// when enabled, the pprof handlers under /debug/pprof/. /health there is always open.
+type InternalConfig struct {
+	Token       string `yaml:"token"`                                   // Shared secret required in TokenHeader; empty leaves the endpoints open
+	TokenHeader string `yaml:"token_header" default:"X-Internal-Token"` // Header the token is sent in
+	Debug       bool   `yaml:"debug"`                                   // Serve net/http/pprof under /debug/pprof/
+}
+
 // DefaultConfigPaths defines the default locations to search for web configuration files
 var DefaultConfigPaths = []string{
 	"./config.yaml",
@@ -82,6 +128,10 @@ func Load(configPath string) (*WebServerConfig, error) {
 		OAuth: OAuthConfig{
 			RedirectURI: "http://localhost:8080/auth/callback",
 		},
+		Session: SessionConfig{
+			MaxAge:           8 * time.Hour,
+			RememberMeMaxAge: 30 * 24 * time.Hour,
+		},
 		Templates: TemplatesConfig{
 			Path: "web/templates",
 		},
@@ -89,6 +139,14 @@ func Load(configPath string) (*WebServerConfig, error) {
 			Level:  "info",
 			Format: "json",
 		},
+		Security: SecurityConfig{
+			ContentSecurityPolicy: DefaultContentSecurityPolicy,
+			FrameOptions:          "DENY",
+			ReferrerPolicy:        "strict-origin-when-cross-origin",
+		},
+		Internal: InternalConfig{
+			TokenHeader: "X-Internal-Token",
+		},
 	}
 
 	// If no config path is provided, search in default locations
@@ -155,10 +213,46 @@ func validate(config *WebServerConfig) error {
 		return fmt.Errorf("server.port must be between 1 and 65535")
 	}
 
+	if config.Session.MaxAge <= 0 || config.Session.RememberMeMaxAge <= 0 {
+		return fmt.Errorf("session.max_age and session.remember_me_max_age must be positive")
+	}
+
 	// Validate gRPC address is not empty
 	if config.GRPC.Address == "" {
 		return fmt.Errorf("grpc.address cannot be empty")
 	}
 
+	for _, origin := range config.Security.CORSAllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" || u.RawQuery != "" {
+			return fmt.Errorf("security.cors_allowed_origins: %q must be \"*\" or a scheme and host like https://example.com", origin)
+		}
+	}
+
+	// Event handlers run script, and inline styles can overlay or hide page content
+	for element, attrs := range config.Markdown.AllowedAttributes {
+		for _, attr := range attrs {
+			name := strings.ToLower(strings.TrimSpace(attr))
+			if strings.HasPrefix(name, "on") || name == "style" {
+				return fmt.Errorf("markdown.allowed_attributes: %q on %s cannot be allowed", attr, element)
+			}
+		}
+	}
+
+	if config.Internal.Token != "" && config.Internal.TokenHeader == "" {
+		return fmt.Errorf("internal.token_header cannot be empty when internal.token is set")
+	}
+
 	return nil
 }
+
+// UseSecureCookies reports whether session cookies should be marked Secure
+func (c *SessionConfig) UseSecureCookies(redirectURI string) bool {
+	if c.SecureCookies != nil {
+		return *c.SecureCookies
+	}
+	return strings.HasPrefix(redirectURI, "https://")
+}
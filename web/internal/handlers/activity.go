@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	activitypb "github.com/devilmonastery/hivemind/api/generated/go/activitypb"
+)
+
+// ActivityPage displays the recently created and edited content feed
+func (h *Handler) ActivityPage(w http.ResponseWriter, r *http.Request) {
+	client, err := h.getClient(r, w)
+	if err != nil {
+		h.log.Error("failed to create client for activity page", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+	defer client.Close()
+
+	// Get filter params
+	guildID := r.URL.Query().Get("guild_id")
+	req := &activitypb.ListRecentActivityRequest{
+		GuildId: guildID,
+		Limit:   50,
+	}
+
+	var since time.Time
+	if sinceParam := r.URL.Query().Get("since"); sinceParam != "" {
+		since, err = time.Parse(time.RFC3339Nano, sinceParam)
+		if err != nil {
+			http.Error(w, "Invalid since parameter", http.StatusBadRequest)
+			return
+		}
+		req.Since = timestamppb.New(since)
+	}
+	req.PageToken = r.URL.Query().Get("page_token")
+
+	activityClient := activitypb.NewActivityServiceClient(client.Conn())
+	resp, err := activityClient.ListRecentActivity(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to fetch recent activity",
+			slog.String("guild_id", guildID),
+			slog.String("error", err.Error()))
+		h.renderBackendError(w, r, err, ErrorPageOptions{
+			SuggestedLink:     "/",
+			SuggestedLinkText: "🏠 Back to Home",
+		})
+		return
+	}
+
+	// Newer activity comes back oldest first; show it newest first like the full feed
+	polling := req.Since != nil || req.PageToken != ""
+	items := resp.Items
+	if polling {
+		slices.Reverse(items)
+	}
+
+	data := h.newTemplateData(r)
+	data["CurrentPage"] = "activity"
+	data["Items"] = items
+	data["HasMore"] = resp.HasMore
+	data["Polling"] = polling
+	data["GuildID"] = guildID
+	if !since.IsZero() {
+		data["Since"] = since
+	}
+	data["NextPageToken"] = resp.NextPageToken
+
+	h.renderTemplate(w, "activity.html", data)
+}
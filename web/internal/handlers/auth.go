@@ -120,6 +120,8 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	session.Values["oauth_state"] = state
 	session.Values["oauth_code_verifier"] = codeVerifier
 	session.Values["oauth_provider"] = provider
+	session.Values["oauth_remember_me"] = r.URL.Query().Get("remember_me") == "1"
+	delete(session.Values, "oauth_link") // Left over from an abandoned LinkDiscord
 	if err := session.Save(r, w); err != nil {
 		h.log.Error("failed to save session",
 			slog.String("error", err.Error()))
@@ -184,6 +186,12 @@ func (h *Handler) AuthCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A flow started from the profile page links Discord to the signed-in user
+	if linking, _ := session.Values["oauth_link"].(bool); linking {
+		h.completeDiscordLink(w, r, session, code, codeVerifier)
+		return
+	}
+
 	provider, ok := session.Values["oauth_provider"].(string)
 	if !ok {
 		provider = "google"
@@ -232,8 +240,10 @@ func (h *Handler) AuthCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	rememberMe, _ := session.Values["oauth_remember_me"].(bool)
+
 	// Store the API token and token ID in session
-	if err := h.sessionManager.SetToken(r, w, resp.ApiToken, resp.TokenId); err != nil {
+	if err := h.sessionManager.StartSession(r, w, resp.ApiToken, resp.TokenId, rememberMe); err != nil {
 		h.log.Error("failed to save session",
 			slog.String("error", err.Error()))
 		http.Error(w, "Failed to save session", http.StatusInternalServerError)
@@ -244,6 +254,7 @@ func (h *Handler) AuthCallback(w http.ResponseWriter, r *http.Request) {
 	delete(session.Values, "oauth_state")
 	delete(session.Values, "oauth_code_verifier")
 	delete(session.Values, "oauth_provider")
+	delete(session.Values, "oauth_remember_me")
 	session.Save(r, w)
 
 	// Redirect to home page
@@ -313,6 +324,7 @@ func (h *Handler) renderLoginPage(w http.ResponseWriter, r *http.Request, provid
 		"Providers":     providers,
 		"CurrentPage":   "login",
 		"ShowAdminLink": len(providers) > 0, // Show admin link if there are OAuth providers
+		"RememberDays":  h.sessionManager.RememberMeDays(),
 	}
 	h.renderTemplate(w, "login.html", data)
 }
@@ -331,10 +343,11 @@ func (h *Handler) renderAdminLogin(w http.ResponseWriter, r *http.Request, provi
 	}
 
 	data := map[string]interface{}{
-		"Message":     message,
-		"Providers":   providers,
-		"CurrentPage": "login",
-		"AdminMode":   true,
+		"Message":      message,
+		"Providers":    providers,
+		"CurrentPage":  "login",
+		"AdminMode":    true,
+		"RememberDays": h.sessionManager.RememberMeDays(),
 	}
 	h.renderTemplate(w, "login.html", data)
 }
@@ -394,7 +407,8 @@ func (h *Handler) AdminLogin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Store the API token and token ID in session
-	if err := h.sessionManager.SetToken(r, w, resp.ApiToken, resp.TokenId); err != nil {
+	rememberMe := r.FormValue("remember_me") == "1"
+	if err := h.sessionManager.StartSession(r, w, resp.ApiToken, resp.TokenId, rememberMe); err != nil {
 		h.log.Error("Failed to save admin session",
 			slog.String("error", err.Error()))
 		http.Error(w, "Failed to save session", http.StatusInternalServerError)
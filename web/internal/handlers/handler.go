@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -74,13 +75,13 @@ func New(serverAddress string, sessionManager *session.Manager, templates *rende
 // This uses gRPC's built-in connection pooling, so it's efficient despite creating a new client per request
 func (h *Handler) getClient(r *http.Request, w http.ResponseWriter) (*client.Client, error) {
 	tm := session.NewSessionTokenManager(h.sessionManager, r, w)
-	return client.NewClient(h.serverAddress, "", tm)
+	return client.New(client.Options{Address: h.serverAddress, TokenManager: tm, Metrics: true})
 }
 
 // getUnauthenticatedClient creates a gRPC client without any authentication
 // Used for public endpoints like GetOAuthConfig
 func (h *Handler) getUnauthenticatedClient() (*client.Client, error) {
-	return client.NewClient(h.serverAddress, "", nil)
+	return client.New(client.Options{Address: h.serverAddress, Metrics: true})
 }
 
 // newTemplateData creates a new template data map with standard fields populated
@@ -90,9 +91,43 @@ func (h *Handler) newTemplateData(r *http.Request) map[string]interface{} {
 		"User":            h.getCurrentUser(r),
 		"DiscordGuildURL": h.discordGuildURL,
 		"DiscordUserURL":  h.discordUserURL,
+		"Meta":            h.siteMeta(r),
 	}
 }
 
+// siteMeta returns the link preview tags for a page that isn't a piece of content.
+// Crawlers aren't logged in, so this is also what they see for content links, which
+// redirect them to the login page.
+func (h *Handler) siteMeta(r *http.Request) render.PageMeta {
+	return render.PageMeta{
+		Title: "Hivemind",
+		Type:  "website",
+		URL:   h.publicURL(r.URL.RequestURI()),
+		Image: h.publicURL(render.AssetURL("img/hivemind-brain-note-512.png")),
+	}
+}
+
+// contentMeta returns the link preview tags for a wiki page, note or quote. description
+// should be empty for private content, so it can't leak through an unfurl.
+func (h *Handler) contentMeta(r *http.Request, title, description, author string) render.PageMeta {
+	meta := h.siteMeta(r)
+	meta.Title = title
+	meta.Description = description
+	meta.Author = author
+	meta.Type = "article"
+	return meta
+}
+
+// publicURL returns path as an absolute URL on the site's public origin, which is
+// taken from the OAuth redirect URI rather than the request's Host header
+func (h *Handler) publicURL(path string) string {
+	origin, err := url.Parse(h.redirectURI)
+	if err != nil || origin.Scheme == "" || origin.Host == "" {
+		return path
+	}
+	return origin.Scheme + "://" + origin.Host + path
+}
+
 // renderTemplate renders a template with data
 func (h *Handler) renderTemplate(w http.ResponseWriter, name string, data interface{}) {
 	if h.templates == nil {
@@ -171,10 +206,53 @@ func (h *Handler) renderError(w http.ResponseWriter, r *http.Request, opts Error
 	data["SuggestedLink"] = opts.SuggestedLink
 	data["SuggestedLinkText"] = opts.SuggestedLinkText
 
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	w.WriteHeader(opts.StatusCode)
+
+	// HTMX requests swap the error into the current page instead of a full reload
+	if r.Header.Get("HX-Request") == "true" {
+		h.renderContentOnly(w, "error.html", data)
+		return
+	}
 	h.renderTemplate(w, "error.html", data)
 }
 
+// renderBackendError responds to a failed gRPC call based on its status code.
+// NotFound and PermissionDenied render the error page (using the suggested link
+// from opts), Unauthenticated sends the user back to login, and InvalidArgument
+// is shown inline above the submitted form.
+func (h *Handler) renderBackendError(w http.ResponseWriter, r *http.Request, err error, opts ErrorPageOptions) {
+	resp := render.ErrorResponseFor(err)
+
+	switch {
+	case resp.RedirectToLogin:
+		if r.Header.Get("HX-Request") == "true" {
+			if clearErr := h.sessionManager.ClearToken(r, w); clearErr != nil {
+				h.log.Error("error clearing session", slog.String("error", clearErr.Error()))
+			}
+			w.Header().Set("HX-Redirect", "/login")
+			return
+		}
+		h.clearSessionAndRedirect(w, r)
+	case resp.Inline:
+		h.renderFormError(w, r, resp.Message)
+	default:
+		opts.StatusCode = resp.StatusCode
+		opts.ErrorTitle = resp.Title
+		opts.ErrorMessage = resp.Message
+		h.renderError(w, r, opts)
+	}
+}
+
+// renderFormError shows a validation message inline above the submitted form
+func (h *Handler) renderFormError(w http.ResponseWriter, r *http.Request, message string) {
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Retarget", "#form-error")
+		w.Header().Set("HX-Reswap", "innerHTML")
+	}
+	render.WriteFormError(w, http.StatusUnprocessableEntity, message)
+}
+
 // isAuthError checks if a gRPC error indicates authentication failure
 func isAuthError(err error) bool {
 	if err == nil {
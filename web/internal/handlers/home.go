@@ -7,11 +7,16 @@ import (
 	"sort"
 	"time"
 
+	activitypb "github.com/devilmonastery/hivemind/api/generated/go/activitypb"
 	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
 	quotespb "github.com/devilmonastery/hivemind/api/generated/go/quotespb"
 	wikipb "github.com/devilmonastery/hivemind/api/generated/go/wikipb"
+	"github.com/devilmonastery/hivemind/internal/pkg/urlutil"
 )
 
+// recentlyViewedLimit is the number of items in the home page's "Recently viewed" sidebar
+const recentlyViewedLimit = 10
+
 // ActivityItem represents a unified activity item for the home feed
 type ActivityItem struct {
 	Type           string // "note", "quote", "wiki"
@@ -29,6 +34,15 @@ type ActivityItem struct {
 	ReferenceCount int32 // Number of message references (for wiki pages)
 }
 
+// RecentlyViewedItem is an entry in the home page's "Recently viewed" sidebar
+type RecentlyViewedItem struct {
+	Type      string // "note", "quote", "wiki"
+	Title     string // Start of the body for quotes
+	GuildName string
+	URL       string
+	ViewedAt  time.Time
+}
+
 // Home handles the home page
 func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
 	// Only handle root path
@@ -70,6 +84,14 @@ func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
 			activity = []ActivityItem{}
 		}
 		data["RecentActivity"] = activity
+
+		// The sidebar is optional, so a failure only leaves it out
+		recentlyViewed, err := h.fetchRecentlyViewed(ctx, r, w)
+		if err != nil {
+			h.log.Error("failed to fetch recently viewed",
+				slog.String("error", err.Error()))
+		}
+		data["RecentlyViewed"] = recentlyViewed
 	}
 
 	// Render the home page template
@@ -219,6 +241,61 @@ func (h *Handler) fetchRecentActivity(ctx context.Context, r *http.Request, w ht
 	return activity, nil
 }
 
+// fetchRecentlyViewed fetches the caller's recently viewed wiki pages, notes, and quotes
+func (h *Handler) fetchRecentlyViewed(ctx context.Context, r *http.Request, w http.ResponseWriter) ([]RecentlyViewedItem, error) {
+	client, err := h.getClient(r, w)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	activityClient := activitypb.NewActivityServiceClient(client.Conn())
+	resp, err := activityClient.ListRecentlyViewed(ctx, &activitypb.ListRecentlyViewedRequest{
+		Limit: recentlyViewedLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]RecentlyViewedItem, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		title := item.Title
+		if title == "" {
+			title = truncateText(item.Snippet, 60)
+		}
+		items = append(items, RecentlyViewedItem{
+			Type:      item.Type,
+			Title:     title,
+			GuildName: item.GuildName,
+			URL:       recentlyViewedURL(item),
+			ViewedAt:  item.ViewedAt.AsTime(),
+		})
+	}
+	return items, nil
+}
+
+// recentlyViewedURL links to an item's permalink, or by ID when it has none yet
+func recentlyViewedURL(item *activitypb.RecentlyViewedItem) string {
+	var u string
+	var err error
+	switch {
+	case item.Type == "wiki":
+		u, err = urlutil.BuildWikiViewURL("", item.GuildId, item.Slug)
+	case item.Type == "note" && item.Slug != "":
+		u, err = urlutil.BuildNoteSlugURL("", item.GuildId, item.Slug)
+	case item.Type == "note":
+		u, err = urlutil.BuildNoteViewURL("", item.Id)
+	case item.Type == "quote" && item.Slug != "":
+		u, err = urlutil.BuildQuoteCodeURL("", item.GuildId, item.Slug)
+	default:
+		u, err = urlutil.BuildQuoteViewURL("", item.Id)
+	}
+	if err != nil {
+		return ""
+	}
+	return u
+}
+
 // truncateText truncates text to maxLen characters, adding "..." if truncated
 func truncateText(text string, maxLen int) string {
 	if len(text) <= maxLen {
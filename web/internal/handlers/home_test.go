@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"testing"
+
+	activitypb "github.com/devilmonastery/hivemind/api/generated/go/activitypb"
+)
+
+func TestRecentlyViewedURL(t *testing.T) {
+	tests := []struct {
+		item *activitypb.RecentlyViewedItem
+		want string
+	}{
+		{item: &activitypb.RecentlyViewedItem{Type: "wiki", Id: "1", GuildId: "456", Slug: "raid-plan"}, want: "/wiki?slug=raid-plan&guild_id=456"},
+		{item: &activitypb.RecentlyViewedItem{Type: "note", Id: "2", Slug: "todo"}, want: "/note?slug=todo"},
+		{item: &activitypb.RecentlyViewedItem{Type: "note", Id: "2"}, want: "/note?id=2"},
+		{item: &activitypb.RecentlyViewedItem{Type: "quote", Id: "3", GuildId: "456", Slug: "ab3d5fgh"}, want: "/quote?code=ab3d5fgh&guild_id=456"},
+		{item: &activitypb.RecentlyViewedItem{Type: "quote", Id: "3", GuildId: "456"}, want: "/quote?id=3"},
+	}
+
+	for _, tt := range tests {
+		if got := recentlyViewedURL(tt.item); got != tt.want {
+			t.Errorf("recentlyViewedURL(%s %s) = %q, want %q", tt.item.Type, tt.item.Id, got, tt.want)
+		}
+	}
+}
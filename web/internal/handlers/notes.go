@@ -66,9 +66,10 @@ func (h *Handler) NotesListPage(w http.ResponseWriter, r *http.Request) {
 
 // NotePage displays a single note with its message references
 func (h *Handler) NotePage(w http.ResponseWriter, r *http.Request) {
-	// Get note ID from query params
+	// Notes are opened by permalink slug, or by ID from older links
 	noteID := r.URL.Query().Get("id")
-	if noteID == "" {
+	noteSlug := r.URL.Query().Get("slug")
+	if noteID == "" && noteSlug == "" {
 		http.Error(w, "Missing note ID", http.StatusBadRequest)
 		return
 	}
@@ -86,12 +87,21 @@ func (h *Handler) NotePage(w http.ResponseWriter, r *http.Request) {
 
 	// Fetch note
 	noteClient := notespb.NewNoteServiceClient(client.Conn())
-	note, err := noteClient.GetNote(r.Context(), &notespb.GetNoteRequest{
-		Id: noteID,
-	})
+	var note *notespb.Note
+	if noteID != "" {
+		note, err = noteClient.GetNote(r.Context(), &notespb.GetNoteRequest{
+			Id: noteID,
+		})
+	} else {
+		note, err = noteClient.GetNoteBySlug(r.Context(), &notespb.GetNoteBySlugRequest{
+			GuildId: r.URL.Query().Get("guild_id"),
+			Slug:    noteSlug,
+		})
+	}
 	if err != nil {
 		h.log.Error("Failed to fetch note",
 			slog.String("note_id", noteID),
+			slog.String("slug", noteSlug),
 			slog.String("error", err.Error()))
 		h.renderError(w, r, ErrorPageOptions{
 			StatusCode:        http.StatusNotFound,
@@ -103,6 +113,10 @@ func (h *Handler) NotePage(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if redirectToPermalink(w, r, notePermalink(note)) {
+		return
+	}
+	noteID = note.Id
 
 	// Fetch message references
 	refsResp, err := noteClient.ListNoteMessageReferences(r.Context(), &notespb.ListNoteMessageReferencesRequest{
@@ -119,6 +133,8 @@ func (h *Handler) NotePage(w http.ResponseWriter, r *http.Request) {
 	data := h.newTemplateData(r)
 	data["Note"] = note
 	data["References"] = refsResp.GetReferences()
+	// Notes are private to their author, so previews never describe them
+	data["Meta"] = h.contentMeta(r, note.Title, "", note.AuthorUsername)
 
 	// Check if this is an HTMX request (e.g., from Cancel button)
 	if r.Header.Get("HX-Request") == "true" {
@@ -181,7 +197,7 @@ func (h *Handler) NotePreview(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
 		h.log.Error("Failed to parse preview form",
 			slog.String("error", err.Error()))
-		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		render.WriteFormError(w, http.StatusBadRequest, "Invalid form data")
 		return
 	}
 
@@ -219,7 +235,7 @@ func (h *Handler) NoteSave(w http.ResponseWriter, r *http.Request) {
 	title := r.FormValue("title")
 	body := r.FormValue("body")
 	if body == "" {
-		http.Error(w, "Note body cannot be empty", http.StatusBadRequest)
+		h.renderFormError(w, r, "Note body cannot be empty")
 		return
 	}
 
@@ -249,7 +265,10 @@ func (h *Handler) NoteSave(w http.ResponseWriter, r *http.Request) {
 		h.log.Error("Failed to update note",
 			slog.String("note_id", noteID),
 			slog.String("error", err.Error()))
-		http.Error(w, "Failed to update note", http.StatusInternalServerError)
+		h.renderBackendError(w, r, err, ErrorPageOptions{
+			SuggestedLink:     "/notes",
+			SuggestedLinkText: "📝 View All Notes",
+		})
 		return
 	}
 
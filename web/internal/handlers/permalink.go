@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	quotespb "github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+	"github.com/devilmonastery/hivemind/internal/pkg/urlutil"
+)
+
+// notePermalink returns the slug URL for a note, or "" for a note without a slug
+func notePermalink(note *notespb.Note) string {
+	if note.GetSlug() == "" {
+		return ""
+	}
+	u, err := urlutil.BuildNoteSlugURL("", note.GetGuildId(), note.GetSlug())
+	if err != nil {
+		return ""
+	}
+	return u
+}
+
+// quotePermalink returns the short code URL for a quote, or "" for a quote without a code
+func quotePermalink(quote *quotespb.Quote) string {
+	if quote.GetShortCode() == "" {
+		return ""
+	}
+	u, err := urlutil.BuildQuoteCodeURL("", quote.GetGuildId(), quote.GetShortCode())
+	if err != nil {
+		return ""
+	}
+	return u
+}
+
+// redirectToPermalink sends a page opened by its old ?id= URL on to its permalink and
+// reports whether it did. HTMX requests swap content into the current page and are
+// served as is. The redirect is temporary because a note's slug follows its title.
+func redirectToPermalink(w http.ResponseWriter, r *http.Request, permalink string) bool {
+	if permalink == "" || r.URL.Query().Get("id") == "" || r.Header.Get("HX-Request") == "true" {
+		return false
+	}
+	http.Redirect(w, r, permalink, http.StatusFound)
+	return true
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	notespb "github.com/devilmonastery/hivemind/api/generated/go/notespb"
+	quotespb "github.com/devilmonastery/hivemind/api/generated/go/quotespb"
+)
+
+func TestRedirectToPermalink(t *testing.T) {
+	note := &notespb.Note{Id: "123", GuildId: "456", Slug: "raid-plan"}
+
+	tests := []struct {
+		name      string
+		target    string
+		htmx      bool
+		permalink string
+		want      string // Expected Location, "" for no redirect
+	}{
+		{name: "old ID URL", target: "/note?id=123", permalink: notePermalink(note), want: "/note?slug=raid-plan&guild_id=456"},
+		{name: "personal note", target: "/note?id=123", permalink: notePermalink(&notespb.Note{Id: "123", Slug: "raid-plan"}), want: "/note?slug=raid-plan"},
+		{name: "quote", target: "/quote?id=789", permalink: quotePermalink(&quotespb.Quote{Id: "789", GuildId: "456", ShortCode: "ab3d5fgh"}), want: "/quote?code=ab3d5fgh&guild_id=456"},
+		{name: "already a permalink", target: "/note?slug=raid-plan&guild_id=456", permalink: notePermalink(note)},
+		{name: "htmx swap", target: "/note?id=123", htmx: true, permalink: notePermalink(note)},
+		{name: "no slug yet", target: "/note?id=123", permalink: notePermalink(&notespb.Note{Id: "123"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.htmx {
+				r.Header.Set("HX-Request", "true")
+			}
+			w := httptest.NewRecorder()
+
+			redirected := redirectToPermalink(w, r, tt.permalink)
+			if redirected != (tt.want != "") {
+				t.Fatalf("redirectToPermalink() = %v, want %v", redirected, tt.want != "")
+			}
+			if !redirected {
+				return
+			}
+			if w.Code != http.StatusFound {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusFound)
+			}
+			if got := w.Header().Get("Location"); got != tt.want {
+				t.Errorf("Location = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authpb "github.com/devilmonastery/hivemind/api/generated/go/authpb"
+	"github.com/devilmonastery/hivemind/internal/client"
+)
+
+// discordLinkErrors are the messages shown on the profile page when linking a Discord
+// account fails, keyed by the link_error query parameter
+var discordLinkErrors = map[string]string{
+	"taken":     "That Discord account is already linked to another Hivemind user.",
+	"conflict":  "A different Discord account is already linked to your profile.",
+	"read_only": "Hivemind is in read-only maintenance mode. Please try again later.",
+	"failed":    "Linking your Discord account failed. Please try again.",
+}
+
+// ProfilePage shows the signed-in user's profile and linked Discord account
+func (h *Handler) ProfilePage(w http.ResponseWriter, r *http.Request) {
+	grpcClient, err := h.getClient(r, w)
+	if err != nil {
+		h.log.Error("failed to create client for profile page", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+	defer grpcClient.Close()
+
+	resp, err := grpcClient.AuthClient().GetCurrentUser(r.Context(), &authpb.GetCurrentUserRequest{})
+	if err != nil {
+		h.log.Error("failed to fetch current user", slog.String("error", err.Error()))
+		h.renderBackendError(w, r, err, ErrorPageOptions{
+			SuggestedLink:     "/",
+			SuggestedLinkText: "🏠 Back to Home",
+		})
+		return
+	}
+
+	data := h.newTemplateData(r)
+	data["CurrentPage"] = "profile"
+	data["Profile"] = resp.User
+	data["Discord"] = resp.Discord
+	data["Timezone"] = resp.Timezone
+	// Linking needs the Discord OAuth provider, which also provides the install URL
+	data["CanLinkDiscord"] = resp.Discord == nil && h.discordGuildURL != ""
+	data["DiscordLinked"] = r.URL.Query().Get("linked") != ""
+	if message, ok := discordLinkErrors[r.URL.Query().Get("link_error")]; ok {
+		data["LinkError"] = message
+	}
+
+	h.renderTemplate(w, "profile.html", data)
+}
+
+// LinkDiscord starts a Discord authorization code flow that links the account to the
+// signed-in user instead of signing in. AuthCallback completes it.
+func (h *Handler) LinkDiscord(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	providers, err := h.getAvailableProviders(ctx)
+	if err != nil {
+		h.log.Error("failed to get OAuth config", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/profile?link_error=failed", http.StatusSeeOther)
+		return
+	}
+
+	var authURL string
+	for _, p := range providers {
+		if p.Name == "discord" {
+			authURL = p.AuthorizationUrl
+		}
+	}
+	if authURL == "" {
+		http.Error(w, "Discord sign-in is not configured", http.StatusNotFound)
+		return
+	}
+
+	codeVerifier := generateCodeVerifier()
+	state := generateState()
+
+	session, _ := h.sessionManager.GetSession(r)
+	session.Values["oauth_state"] = state
+	session.Values["oauth_code_verifier"] = codeVerifier
+	session.Values["oauth_provider"] = "discord"
+	session.Values["oauth_link"] = true
+	if err := session.Save(r, w); err != nil {
+		h.log.Error("failed to save session", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/profile?link_error=failed", http.StatusSeeOther)
+		return
+	}
+
+	// Discord only redirects to registered URIs, so linking shares the sign-in callback
+	authURL = strings.Replace(authURL, "{redirect_uri}", url.QueryEscape(h.redirectURI), 1)
+	authURL = strings.Replace(authURL, "{state}", url.QueryEscape(state), 1)
+	authURL = strings.Replace(authURL, "{code_challenge}", url.QueryEscape(generateCodeChallenge(codeVerifier)), 1)
+
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// completeDiscordLink finishes a flow started by LinkDiscord with the code from the
+// callback, and returns to the profile page
+func (h *Handler) completeDiscordLink(w http.ResponseWriter, r *http.Request, session *sessions.Session, code, codeVerifier string) {
+	delete(session.Values, "oauth_state")
+	delete(session.Values, "oauth_code_verifier")
+	delete(session.Values, "oauth_provider")
+	delete(session.Values, "oauth_link")
+	session.Save(r, w)
+
+	grpcClient, err := h.getClient(r, w)
+	if err != nil {
+		h.log.Error("failed to create client for discord link", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+	defer grpcClient.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	_, err = grpcClient.AuthClient().LinkDiscordAccount(ctx, &authpb.LinkDiscordAccountRequest{
+		Code:         code,
+		CodeVerifier: codeVerifier,
+		RedirectUri:  h.redirectURI,
+	})
+	if err != nil {
+		h.log.Warn("failed to link discord account", slog.String("error", err.Error()))
+		reason := "failed"
+		switch {
+		case client.IsReadOnly(err):
+			reason = "read_only"
+		case status.Code(err) == codes.AlreadyExists:
+			reason = "taken"
+		case status.Code(err) == codes.FailedPrecondition:
+			reason = "conflict"
+		}
+		http.Redirect(w, r, "/profile?link_error="+reason, http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/profile?linked=1", http.StatusSeeOther)
+}
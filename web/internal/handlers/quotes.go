@@ -48,9 +48,10 @@ func (h *Handler) QuotesListPage(w http.ResponseWriter, r *http.Request) {
 
 // QuotePage displays a single quote
 func (h *Handler) QuotePage(w http.ResponseWriter, r *http.Request) {
-	// Get quote ID from query params
+	// Quotes are opened by permalink code, or by ID from older links
 	quoteID := r.URL.Query().Get("id")
-	if quoteID == "" {
+	quoteCode := r.URL.Query().Get("code")
+	if quoteID == "" && quoteCode == "" {
 		http.Error(w, "Missing quote ID", http.StatusBadRequest)
 		return
 	}
@@ -68,12 +69,21 @@ func (h *Handler) QuotePage(w http.ResponseWriter, r *http.Request) {
 
 	// Fetch quote
 	quoteClient := quotespb.NewQuoteServiceClient(client.Conn())
-	quote, err := quoteClient.GetQuote(r.Context(), &quotespb.GetQuoteRequest{
-		Id: quoteID,
-	})
+	var quote *quotespb.Quote
+	if quoteID != "" {
+		quote, err = quoteClient.GetQuote(r.Context(), &quotespb.GetQuoteRequest{
+			Id: quoteID,
+		})
+	} else {
+		quote, err = quoteClient.GetQuoteByCode(r.Context(), &quotespb.GetQuoteByCodeRequest{
+			GuildId: r.URL.Query().Get("guild_id"),
+			Code:    quoteCode,
+		})
+	}
 	if err != nil {
 		h.log.Error("Failed to find quote",
 			slog.String("quote_id", quoteID),
+			slog.String("code", quoteCode),
 			slog.String("error", err.Error()))
 		h.renderError(w, r, ErrorPageOptions{
 			StatusCode:        http.StatusNotFound,
@@ -85,10 +95,14 @@ func (h *Handler) QuotePage(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if redirectToPermalink(w, r, quotePermalink(quote)) {
+		return
+	}
 
 	// Prepare template data
 	data := h.newTemplateData(r)
 	data["Quote"] = quote
+	data["Meta"] = h.contentMeta(r, quoteMetaTitle(quote), render.Excerpt(quote.Body, render.MaxDescriptionLength), quote.AuthorUsername)
 
 	// Check if this is an HTMX request (e.g., from Cancel button)
 	if r.Header.Get("HX-Request") == "true" {
@@ -144,7 +158,7 @@ func (h *Handler) QuotePreview(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
 		h.log.Error("Failed to parse preview form",
 			slog.String("error", err.Error()))
-		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		render.WriteFormError(w, http.StatusBadRequest, "Invalid form data")
 		return
 	}
 
@@ -181,7 +195,7 @@ func (h *Handler) QuoteSave(w http.ResponseWriter, r *http.Request) {
 
 	body := r.FormValue("body")
 	if body == "" {
-		http.Error(w, "Quote body cannot be empty", http.StatusBadRequest)
+		h.renderFormError(w, r, "Quote body cannot be empty")
 		return
 	}
 
@@ -210,7 +224,10 @@ func (h *Handler) QuoteSave(w http.ResponseWriter, r *http.Request) {
 		h.log.Error("Failed to update quote",
 			slog.String("quote_id", quoteID),
 			slog.String("error", err.Error()))
-		http.Error(w, "Failed to update quote", http.StatusInternalServerError)
+		h.renderBackendError(w, r, err, ErrorPageOptions{
+			SuggestedLink:     "/quotes",
+			SuggestedLinkText: "💬 View All Quotes",
+		})
 		return
 	}
 
@@ -226,7 +243,10 @@ func (h *Handler) QuoteSave(w http.ResponseWriter, r *http.Request) {
 		h.log.Error("Failed to fetch quote after update",
 			slog.String("quote_id", quoteID),
 			slog.String("error", err.Error()))
-		http.Error(w, "Failed to fetch quote", http.StatusInternalServerError)
+		h.renderBackendError(w, r, err, ErrorPageOptions{
+			SuggestedLink:     "/quotes",
+			SuggestedLinkText: "💬 View All Quotes",
+		})
 		return
 	}
 
@@ -236,3 +256,15 @@ func (h *Handler) QuoteSave(w http.ResponseWriter, r *http.Request) {
 
 	h.renderContentOnly(w, "quote_view.html", data)
 }
+
+// quoteMetaTitle names a quote's speaker for its link preview
+func quoteMetaTitle(quote *quotespb.Quote) string {
+	speaker := quote.SourceMsgAuthorGuildNick
+	if speaker == "" {
+		speaker = quote.SourceMsgAuthorUsername
+	}
+	if speaker == "" {
+		return "Quote"
+	}
+	return "Quote from " + speaker
+}
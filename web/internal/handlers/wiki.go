@@ -130,6 +130,7 @@ func (h *Handler) WikiPage(w http.ResponseWriter, r *http.Request) {
 	data := h.newTemplateData(r)
 	data["Page"] = page
 	data["References"] = refsResp.GetReferences()
+	data["Meta"] = h.contentMeta(r, page.Title, render.Excerpt(page.Body, render.MaxDescriptionLength), page.AuthorUsername)
 
 	// Check if this is an HTMX request (e.g., from Cancel button)
 	if r.Header.Get("HX-Request") == "true" {
@@ -197,7 +198,7 @@ func (h *Handler) WikiPreview(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
 		h.log.Error("Failed to parse preview form",
 			slog.String("error", err.Error()))
-		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		render.WriteFormError(w, http.StatusBadRequest, "Invalid form data")
 		return
 	}
 
@@ -236,7 +237,7 @@ func (h *Handler) WikiSave(w http.ResponseWriter, r *http.Request) {
 
 	body := r.FormValue("body")
 	if body == "" {
-		http.Error(w, "Wiki body cannot be empty", http.StatusBadRequest)
+		h.renderFormError(w, r, "Wiki body cannot be empty")
 		return
 	}
 
@@ -264,7 +265,10 @@ func (h *Handler) WikiSave(w http.ResponseWriter, r *http.Request) {
 			slog.String("slug", slugParam),
 			slog.String("guild_id", guildID),
 			slog.String("error", err.Error()))
-		http.Error(w, "Wiki page not found", http.StatusNotFound)
+		h.renderBackendError(w, r, err, ErrorPageOptions{
+			SuggestedLink:     "/wikis",
+			SuggestedLinkText: "📚 View All Wiki Pages",
+		})
 		return
 	}
 
@@ -283,7 +287,10 @@ func (h *Handler) WikiSave(w http.ResponseWriter, r *http.Request) {
 			slog.String("slug", slugParam),
 			slog.String("guild_id", guildID),
 			slog.String("error", err.Error()))
-		http.Error(w, "Failed to update wiki page", http.StatusInternalServerError)
+		h.renderBackendError(w, r, err, ErrorPageOptions{
+			SuggestedLink:     "/wikis",
+			SuggestedLinkText: "📚 View All Wiki Pages",
+		})
 		return
 	}
 
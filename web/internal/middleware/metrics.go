@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+// unmatchedRoute labels requests that don't match any route (404s), so arbitrary
+// paths can't blow up the cardinality of the route label
+const unmatchedRoute = "unmatched"
+
+// RecordMetrics records request count, status, and latency for every request
+// handled by router, labeled by the matched route template rather than the raw path
+func RecordMetrics(router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(router, r)
+
+		metrics.HTTPActiveRequests.Inc()
+		defer metrics.HTTPActiveRequests.Dec()
+
+		start := time.Now()
+		wrapped := &responseWriter{
+			ResponseWriter: w,
+			statusCode:     200, // default if WriteHeader not called
+		}
+
+		router.ServeHTTP(wrapped, r)
+
+		metrics.RecordHTTPRequest(r.Method, route, wrapped.statusCode, time.Since(start))
+	})
+}
+
+// routeTemplate returns the path template of the route matching r, or unmatchedRoute
+func routeTemplate(router *mux.Router, r *http.Request) string {
+	var match mux.RouteMatch
+	if !router.Match(r, &match) || match.Route == nil {
+		return unmatchedRoute
+	}
+	tmpl, err := match.Route.GetPathTemplate()
+	if err != nil {
+		return unmatchedRoute
+	}
+	return tmpl
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/devilmonastery/hivemind/internal/pkg/metrics"
+)
+
+func TestRecordMetrics(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/wiki", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}).Methods("GET")
+	router.HandleFunc("/wiki/save", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}).Methods("POST")
+	handler := RecordMetrics(router)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		route  string
+		status string
+	}{
+		{"route template, not raw path", "GET", "/wiki?slug=some-page", "/wiki", "200"},
+		{"error status", "POST", "/wiki/save", "/wiki/save", "400"},
+		{"unknown path", "GET", "/no/such/page", unmatchedRoute, "404"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := metrics.HTTPRequests.WithLabelValues(tt.method, tt.route, tt.status)
+			before := testutil.ToFloat64(counter)
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.target, nil))
+
+			if got := testutil.ToFloat64(counter) - before; got != 1 {
+				t.Errorf("request counter for route %q status %s increased by %v, want 1", tt.route, tt.status, got)
+			}
+		})
+	}
+}
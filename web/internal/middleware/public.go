@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// PublicPaths serves requests for the listed paths with public, and everything else with
+// next. Wrap app-wide middleware that can reject a request, such as an auth check, inside
+// next so health probes and static assets stay reachable whatever it requires. A path
+// ending in "/" matches every path under it.
+func PublicPaths(paths []string, public, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isPublicPath(paths, r.URL.Path) {
+			public.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isPublicPath reports whether path is one of paths or under one ending in "/"
+func isPublicPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if path == p || (strings.HasSuffix(p, "/") && strings.HasPrefix(path, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireToken rejects requests that don't send token in the given header with 401.
+// An empty token leaves next unprotected.
+func RequireToken(header, token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(header)), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
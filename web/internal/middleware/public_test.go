@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/web/internal/session"
+)
+
+func TestPublicPaths(t *testing.T) {
+	public := http.NewServeMux()
+	public.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	public.HandleFunc("/static/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("asset"))
+	})
+
+	app := http.NewServeMux()
+	app.HandleFunc("/activity", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("activity"))
+	})
+
+	// Require a session for the whole app, as a deployment fronting everything with auth would
+	authMw := NewAuthMiddleware(session.NewManager(make([]byte, 32), session.Options{}))
+	handler := PublicPaths([]string{"/health", "/static/"}, public, authMw.RequireAuth(app))
+
+	tests := []struct {
+		path       string
+		wantStatus int
+	}{
+		{path: "/health", wantStatus: http.StatusOK},
+		{path: "/static/v1/css/site.css", wantStatus: http.StatusOK},
+		{path: "/activity", wantStatus: http.StatusSeeOther},
+		{path: "/healthz", wantStatus: http.StatusSeeOther},
+		{path: "/static", wantStatus: http.StatusSeeOther},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if w.Code != tt.wantStatus {
+			t.Errorf("GET %s without a session: status = %d, want %d", tt.path, w.Code, tt.wantStatus)
+		}
+	}
+}
+
+func TestRequireToken(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("metrics"))
+	})
+
+	tests := []struct {
+		name       string
+		token      string
+		sent       string
+		wantStatus int
+	}{
+		{name: "missing token", token: "s3cret", sent: "", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", token: "s3cret", sent: "guess", wantStatus: http.StatusUnauthorized},
+		{name: "right token", token: "s3cret", sent: "s3cret", wantStatus: http.StatusOK},
+		{name: "no token configured", token: "", sent: "", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.sent != "" {
+				r.Header.Set("X-Internal-Token", tt.sent)
+			}
+			w := httptest.NewRecorder()
+			RequireToken("X-Internal-Token", tt.token, next).ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/devilmonastery/hivemind/web/internal/config"
+)
+
+// corsAllowedHeaders are the request headers cross-origin callers may send,
+// including the ones HTMX adds to its requests
+var corsAllowedHeaders = strings.Join([]string{
+	"Content-Type", "HX-Request", "HX-Current-URL", "HX-Target", "HX-Trigger",
+}, ", ")
+
+// SecurityHeaders sets the configured security headers on every response, error pages
+// and 404s included, and answers CORS requests from the allowed origins
+func SecurityHeaders(cfg config.SecurityConfig, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set("X-Content-Type-Options", "nosniff")
+		if cfg.ContentSecurityPolicy != "" {
+			h.Set("Content-Security-Policy", cfg.ContentSecurityPolicy)
+		}
+		if cfg.FrameOptions != "" {
+			h.Set("X-Frame-Options", cfg.FrameOptions)
+		}
+		if cfg.ReferrerPolicy != "" {
+			h.Set("Referrer-Policy", cfg.ReferrerPolicy)
+		}
+
+		if len(cfg.CORSAllowedOrigins) > 0 {
+			// Responses differ by origin, so caches must not share them
+			h.Add("Vary", "Origin")
+
+			origin := r.Header.Get("Origin")
+			if origin != "" && corsOriginAllowed(cfg.CORSAllowedOrigins, origin) {
+				if slices.Contains(cfg.CORSAllowedOrigins, "*") {
+					h.Set("Access-Control-Allow-Origin", "*")
+				} else {
+					h.Set("Access-Control-Allow-Origin", origin)
+					h.Set("Access-Control-Allow-Credentials", "true")
+				}
+
+				// Answer preflights here, since routes only accept their own methods
+				if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+					h.Set("Access-Control-Allow-Methods", "GET, POST")
+					h.Set("Access-Control-Allow-Headers", corsAllowedHeaders)
+					h.Set("Access-Control-Max-Age", "600")
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// corsOriginAllowed reports whether origin is in allowed or allowed contains "*"
+func corsOriginAllowed(allowed []string, origin string) bool {
+	for _, a := range allowed {
+		if a == "*" || strings.EqualFold(a, origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devilmonastery/hivemind/web/internal/config"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	cfg := config.SecurityConfig{
+		ContentSecurityPolicy: "default-src 'self'",
+		FrameOptions:          "DENY",
+		ReferrerPolicy:        "no-referrer",
+	}
+	handler := SecurityHeaders(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+
+	want := map[string]string{
+		"Content-Security-Policy": "default-src 'self'",
+		"X-Content-Type-Options":  "nosniff",
+		"X-Frame-Options":         "DENY",
+		"Referrer-Policy":         "no-referrer",
+	}
+
+	for _, target := range []string{"/", "/no/such/page"} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		for name, value := range want {
+			if got := w.Header().Get(name); got != value {
+				t.Errorf("GET %s: %s = %q, want %q", target, name, got, value)
+			}
+		}
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("GET %s: Access-Control-Allow-Origin = %q without CORS configured", target, got)
+		}
+	}
+
+	// An empty value leaves the header out
+	cfg.FrameOptions = ""
+	w := httptest.NewRecorder()
+	SecurityHeaders(cfg, http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if _, ok := w.Header()["X-Frame-Options"]; ok {
+		t.Errorf("X-Frame-Options set to %q, want it left out", w.Header().Get("X-Frame-Options"))
+	}
+}
+
+func TestSecurityHeadersCORS(t *testing.T) {
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	tests := []struct {
+		name        string
+		allowed     []string
+		method      string
+		origin      string
+		preflight   bool
+		wantOrigin  string
+		wantCreds   bool
+		wantHandled bool // Preflight answered without calling the router
+	}{
+		{name: "allowed origin", allowed: []string{"https://app.example.com"}, method: "POST", origin: "https://app.example.com", wantOrigin: "https://app.example.com", wantCreds: true},
+		{name: "other origin", allowed: []string{"https://app.example.com"}, method: "POST", origin: "https://evil.example.com"},
+		{name: "wildcard", allowed: []string{"*"}, method: "GET", origin: "https://anywhere.example.com", wantOrigin: "*"},
+		{name: "preflight", allowed: []string{"https://app.example.com"}, method: "OPTIONS", origin: "https://app.example.com", preflight: true, wantOrigin: "https://app.example.com", wantCreds: true, wantHandled: true},
+		{name: "preflight from other origin", allowed: []string{"https://app.example.com"}, method: "OPTIONS", origin: "https://evil.example.com", preflight: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			r := httptest.NewRequest(tt.method, "/api/set-timezone", nil)
+			r.Header.Set("Origin", tt.origin)
+			if tt.preflight {
+				r.Header.Set("Access-Control-Request-Method", "POST")
+			}
+			w := httptest.NewRecorder()
+
+			SecurityHeaders(config.SecurityConfig{CORSAllowedOrigins: tt.allowed}, next).ServeHTTP(w, r)
+
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials") == "true"; got != tt.wantCreds {
+				t.Errorf("Access-Control-Allow-Credentials = %v, want %v", got, tt.wantCreds)
+			}
+			if called == tt.wantHandled {
+				t.Errorf("router called = %v, want %v", called, !tt.wantHandled)
+			}
+			if tt.wantHandled && w.Code != http.StatusNoContent {
+				t.Errorf("preflight status = %d, want %d", w.Code, http.StatusNoContent)
+			}
+			if got := w.Header().Get("Vary"); got != "Origin" {
+				t.Errorf("Vary = %q, want Origin", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorResponse describes how the web UI should present a failed backend call
+type ErrorResponse struct {
+	StatusCode      int
+	Title           string
+	Message         string
+	RedirectToLogin bool // Session is no longer valid, send the user to /login
+	Inline          bool // Show Message next to the submitted form instead of an error page
+}
+
+// ErrorResponseFor maps an error returned by the gRPC backend to an HTTP status
+// and a message that is safe to show to users. Validation failures keep the
+// backend's message so the user can see what to fix; everything unexpected is
+// reported as a generic 500.
+func ErrorResponseFor(err error) ErrorResponse {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		return ErrorResponse{
+			StatusCode: http.StatusInternalServerError,
+			Title:      "Something Went Wrong",
+			Message:    "An unexpected error occurred. Please try again.",
+		}
+	}
+
+	switch st.Code() {
+	case codes.NotFound:
+		return ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Title:      "Not Found",
+			Message:    "The content you're looking for could not be found.",
+		}
+	case codes.PermissionDenied:
+		return ErrorResponse{
+			StatusCode: http.StatusForbidden,
+			Title:      "Access Denied",
+			Message:    "You don't have permission to do that.",
+		}
+	case codes.Unauthenticated:
+		return ErrorResponse{
+			StatusCode:      http.StatusUnauthorized,
+			Title:           "Sign In Required",
+			Message:         "Your session has expired. Please log in again.",
+			RedirectToLogin: true,
+		}
+	case codes.InvalidArgument:
+		return ErrorResponse{
+			StatusCode: http.StatusUnprocessableEntity,
+			Title:      "Invalid Input",
+			Message:    st.Message(),
+			Inline:     true,
+		}
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return ErrorResponse{
+			StatusCode: http.StatusServiceUnavailable,
+			Title:      "Service Unavailable",
+			Message:    "The server is temporarily unavailable. Please try again in a moment.",
+		}
+	default:
+		return ErrorResponse{
+			StatusCode: http.StatusInternalServerError,
+			Title:      "Something Went Wrong",
+			Message:    "An unexpected error occurred. Please try again.",
+		}
+	}
+}
+
+// WriteFormError writes an inline form error fragment with the given status code
+func WriteFormError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(statusCode)
+	fmt.Fprintf(w, `<div class="form-error mb-4 p-3 rounded border border-red-500/50 bg-red-900/30 text-red-300 text-sm">⚠️ %s</div>`,
+		template.HTMLEscapeString(message))
+}
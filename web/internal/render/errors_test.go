@@ -0,0 +1,101 @@
+package render
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorResponseFor(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		wantStatus      int
+		wantRedirect    bool
+		wantInline      bool
+		wantMessagePart string
+	}{
+		{
+			name:       "not found",
+			err:        status.Error(codes.NotFound, "wiki page not found"),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "permission denied",
+			err:        status.Error(codes.PermissionDenied, "not a guild member"),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:         "unauthenticated redirects to login",
+			err:          status.Error(codes.Unauthenticated, "token expired"),
+			wantStatus:   http.StatusUnauthorized,
+			wantRedirect: true,
+		},
+		{
+			name:            "invalid argument keeps backend message inline",
+			err:             status.Error(codes.InvalidArgument, "title must be 200 characters or fewer"),
+			wantStatus:      http.StatusUnprocessableEntity,
+			wantInline:      true,
+			wantMessagePart: "title must be 200 characters or fewer",
+		},
+		{
+			name:       "unavailable",
+			err:        status.Error(codes.Unavailable, "connection refused"),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "internal hides details",
+			err:        status.Error(codes.Internal, "pq: relation does not exist"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "non-gRPC error",
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ErrorResponseFor(tt.err)
+			if got.StatusCode != tt.wantStatus {
+				t.Errorf("StatusCode = %d, want %d", got.StatusCode, tt.wantStatus)
+			}
+			if got.RedirectToLogin != tt.wantRedirect {
+				t.Errorf("RedirectToLogin = %v, want %v", got.RedirectToLogin, tt.wantRedirect)
+			}
+			if got.Inline != tt.wantInline {
+				t.Errorf("Inline = %v, want %v", got.Inline, tt.wantInline)
+			}
+			if got.Message == "" {
+				t.Error("Message should not be empty")
+			}
+			if tt.wantMessagePart != "" && !strings.Contains(got.Message, tt.wantMessagePart) {
+				t.Errorf("Message = %q, want it to contain %q", got.Message, tt.wantMessagePart)
+			}
+			if strings.Contains(got.Message, "pq:") {
+				t.Errorf("Message leaks backend details: %q", got.Message)
+			}
+		})
+	}
+}
+
+func TestWriteFormError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteFormError(rec, http.StatusUnprocessableEntity, `<script>alert("x")</script>`)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Content-Type = %q, want text/html", ct)
+	}
+	if strings.Contains(rec.Body.String(), "<script>") {
+		t.Errorf("message was not escaped: %s", rec.Body.String())
+	}
+}
@@ -2,19 +2,93 @@ package render
 
 import (
 	"html/template"
+	"regexp"
+	"sync/atomic"
 
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/russross/blackfriday/v2"
 )
 
+// MarkdownPolicy lists the HTML that rendered markdown may contain. Everything else
+// is stripped, including scripts, styles, iframes, and event handler attributes
+// such as onerror, so raw HTML in a page body can't run in a reader's browser.
+type MarkdownPolicy struct {
+	// Elements are the tags kept in the output, without attributes unless listed below
+	Elements []string
+	// Attributes lists the attributes allowed on each element. A class is only kept
+	// if it names a code block language, e.g. "language-go".
+	Attributes map[string][]string
+	// URLSchemes are the schemes allowed in href and src. Relative URLs are always
+	// allowed; anything else, such as javascript: and data:, is dropped.
+	URLSchemes []string
+}
+
+// DefaultMarkdownPolicy allows the formatting, links, and images that markdown
+// produces, and nothing else
+var DefaultMarkdownPolicy = MarkdownPolicy{
+	Elements: []string{
+		"p", "br", "hr", "h1", "h2", "h3", "h4", "h5", "h6",
+		"strong", "em", "del", "code", "pre", "blockquote", "sup",
+		"ul", "ol", "li", "dl", "dt", "dd",
+		"table", "thead", "tbody", "tr", "th", "td",
+		"a", "img",
+	},
+	Attributes: map[string][]string{
+		"a":    {"href", "title"},
+		"img":  {"src", "alt", "title"},
+		"code": {"class"},
+		"ol":   {"start"},
+		"th":   {"align"},
+		"td":   {"align"},
+	},
+	URLSchemes: []string{"http", "https", "mailto"},
+}
+
+// codeLanguageClass matches the class blackfriday puts on fenced code blocks
+var codeLanguageClass = regexp.MustCompile(`^language-[\w+#-]+$`)
+
+// markdownSanitizer is built from the policy set by SetMarkdownPolicy
+var markdownSanitizer atomic.Pointer[bluemonday.Policy]
+
+func init() {
+	SetMarkdownPolicy(DefaultMarkdownPolicy)
+}
+
+// SetMarkdownPolicy replaces the policy Markdown sanitizes its output with.
+// Call it at startup, before any pages are rendered.
+func SetMarkdownPolicy(policy MarkdownPolicy) {
+	markdownSanitizer.Store(policy.sanitizer())
+}
+
+// sanitizer builds the bluemonday policy enforcing p
+func (p MarkdownPolicy) sanitizer() *bluemonday.Policy {
+	sanitizer := bluemonday.NewPolicy()
+	sanitizer.AllowElements(p.Elements...)
+	for element, attrs := range p.Attributes {
+		for _, attr := range attrs {
+			if attr == "class" {
+				sanitizer.AllowAttrs(attr).Matching(codeLanguageClass).OnElements(element)
+				continue
+			}
+			sanitizer.AllowAttrs(attr).OnElements(element)
+		}
+	}
+
+	sanitizer.RequireParseableURLs(true)
+	sanitizer.AllowRelativeURLs(true)
+	sanitizer.AllowURLSchemes(p.URLSchemes...)
+	sanitizer.RequireNoFollowOnLinks(true)
+
+	return sanitizer
+}
+
 // Markdown converts markdown text to safe HTML for use in templates
 func Markdown(markdown string) template.HTML {
 	// Convert markdown to HTML
 	unsafe := blackfriday.Run([]byte(markdown))
 
 	// Sanitize the HTML to prevent XSS
-	policy := bluemonday.UGCPolicy()
-	safe := policy.SanitizeBytes(unsafe)
+	safe := markdownSanitizer.Load().SanitizeBytes(unsafe)
 
 	return template.HTML(safe)
 }
@@ -118,6 +118,75 @@ func TestMarkdown(t *testing.T) {
 	}
 }
 
+func TestMarkdownNeutralizesXSS(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		notContains []string
+	}{
+		{name: "script tag", input: "Hi <script>alert(1)</script>", notContains: []string{"<script", "alert(1)"}},
+		{name: "javascript link", input: "[click](javascript:alert(1))", notContains: []string{"javascript:"}},
+		{name: "mixed case javascript link", input: `<a href="JaVaScRiPt:alert(1)">click</a>`, notContains: []string{"avascript", "href"}},
+		{name: "entity encoded javascript link", input: `<a href="&#106;avascript:alert(1)">click</a>`, notContains: []string{"avascript", "href"}},
+		{name: "javascript image", input: "![x](javascript:alert(1))", notContains: []string{"javascript:"}},
+		{name: "onerror attribute", input: `<img src="x" onerror="alert(1)">`, notContains: []string{"onerror", "alert(1)"}},
+		{name: "svg onload", input: `<svg onload="alert(1)"></svg>`, notContains: []string{"<svg", "onload"}},
+		{name: "iframe", input: `<iframe src="https://evil.example"></iframe>`, notContains: []string{"<iframe", "evil.example"}},
+		{name: "data URL link", input: "[x](data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==)", notContains: []string{"data:"}},
+		{name: "style tag", input: "<style>body { display: none }</style>", notContains: []string{"<style", "display: none"}},
+		{name: "style attribute", input: `<p style="position:fixed">covered</p>`, notContains: []string{"style"}},
+		{name: "arbitrary class", input: `<code class="admin-only">x</code>`, notContains: []string{"admin-only"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(Markdown(tt.input))
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("Markdown(%q) = %q, want no %q", tt.input, got, unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownKeepsSafeContent(t *testing.T) {
+	got := string(Markdown("![cat](https://example.com/cat.png \"A cat\")\n\n[docs](/wiki/g1/rules) and [mail](mailto:mods@example.com)\n\n```go\nfmt.Println()\n```\n\n| a | b |\n|:--|--:|\n| 1 | 2 |"))
+
+	for _, want := range []string{
+		`<img src="https://example.com/cat.png" alt="cat" title="A cat"`,
+		`href="/wiki/g1/rules"`,
+		`href="mailto:mods@example.com"`,
+		`rel="nofollow"`,
+		`<code class="language-go">`,
+		`<td align="left">1</td>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Markdown() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestSetMarkdownPolicy(t *testing.T) {
+	defer SetMarkdownPolicy(DefaultMarkdownPolicy)
+
+	SetMarkdownPolicy(MarkdownPolicy{
+		Elements:   []string{"p", "a"},
+		Attributes: map[string][]string{"a": {"href"}},
+		URLSchemes: []string{"https"},
+	})
+
+	got := string(Markdown("**bold** ![cat](https://example.com/cat.png) [plain](http://example.com) [secure](https://example.com)"))
+	for _, unwanted := range []string{"<strong>", "<img", `href="http://example.com"`} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("Markdown() = %q, want no %q under the custom policy", got, unwanted)
+		}
+	}
+	if !strings.Contains(got, `href="https://example.com"`) {
+		t.Errorf("Markdown() = %q, want the https link kept", got)
+	}
+}
+
 func TestMarkdownReturnsTemplateHTML(t *testing.T) {
 	input := "# Test"
 	result := Markdown(input)
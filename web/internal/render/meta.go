@@ -0,0 +1,48 @@
+package render
+
+import (
+	"html"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/russross/blackfriday/v2"
+)
+
+// MaxDescriptionLength is the longest description Excerpt produces for meta tags.
+// Discord and most social sites cut unfurl descriptions at around this length.
+const MaxDescriptionLength = 200
+
+// PageMeta holds the OpenGraph and Twitter card tags the base layout renders in a
+// page's head, so shared links unfurl with a title, description and image
+type PageMeta struct {
+	Title       string
+	Description string // Plain text; empty leaves the description tags out
+	Author      string
+	Type        string // og:type: "website", or "article" for content pages
+	URL         string // Absolute URL of the page
+	Image       string // Absolute URL of the preview image
+}
+
+// Excerpt returns markdown as a plain-text description for meta tags: the markdown and
+// any HTML in it are stripped, whitespace is collapsed, and text longer than maxLen
+// runes is cut at a word boundary with an ellipsis. The result is unescaped text, so
+// it must be output through html/template, which escapes it for the attribute.
+func Excerpt(markdown string, maxLen int) string {
+	rendered := blackfriday.Run([]byte(markdown))
+	// StrictPolicy drops every tag, and script and style contents with them
+	text := html.UnescapeString(string(bluemonday.StrictPolicy().SanitizeBytes(rendered)))
+	text = strings.Join(strings.Fields(text), " ")
+
+	runes := []rune(text)
+	if len(runes) <= maxLen {
+		return text
+	}
+	// Leave room for the ellipsis, and drop a word the cut would split
+	cut := string(runes[:maxLen-1])
+	if runes[maxLen-1] != ' ' {
+		if space := strings.LastIndex(cut, " "); space > 0 {
+			cut = cut[:space]
+		}
+	}
+	return strings.TrimRight(cut, " .,;:") + "…"
+}
@@ -0,0 +1,74 @@
+package render
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		maxLen   int
+		want     string
+	}{
+		{
+			name:     "markdown is stripped",
+			markdown: "# Raids\n\nBring **potions** and a [map](https://example.com).\n\n- Tanks first\n- Healers second",
+			maxLen:   200,
+			want:     "Raids Bring potions and a map. Tanks first Healers second",
+		},
+		{
+			name:     "html and scripts are stripped",
+			markdown: "Hello <script>alert('x')</script><b>world</b>",
+			maxLen:   200,
+			want:     "Hello world",
+		},
+		{
+			name:     "entities are decoded once",
+			markdown: "Salt & pepper < sugar",
+			maxLen:   200,
+			want:     "Salt & pepper < sugar",
+		},
+		{
+			name:     "long text is cut at a word",
+			markdown: "The quick brown fox jumps over the lazy dog",
+			maxLen:   20,
+			want:     "The quick brown fox…",
+		},
+		{
+			name:     "empty",
+			markdown: "",
+			maxLen:   200,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Excerpt(tt.markdown, tt.maxLen)
+			if got != tt.want {
+				t.Errorf("Excerpt() = %q, want %q", got, tt.want)
+			}
+			if utf8.RuneCountInString(got) > tt.maxLen {
+				t.Errorf("Excerpt() is %d runes, want at most %d", utf8.RuneCountInString(got), tt.maxLen)
+			}
+		})
+	}
+}
+
+func TestExcerpt_EscapedInMetaTag(t *testing.T) {
+	tmpl := template.Must(template.New("meta").Parse(`<meta property="og:description" content="{{.}}">`))
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, Excerpt(`Fish & chips <img src=x onerror=alert(1)> cost <5`, MaxDescriptionLength)); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := `<meta property="og:description" content="Fish &amp; chips cost &lt;5">`
+	if b.String() != want {
+		t.Errorf("meta tag = %s, want %s", b.String(), want)
+	}
+}
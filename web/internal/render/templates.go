@@ -18,6 +18,12 @@ import (
 // Version is set at build time using ldflags
 var Version = "dev"
 
+// AssetURL returns the path of a static file with the version inserted for cache
+// busting: /static/{version}/css/styles.css
+func AssetURL(filename string) string {
+	return "/static/" + Version + "/" + filename
+}
+
 // TemplateSet holds all parsed page templates
 // Each page is stored as a completely separate template.Template
 // to avoid {{define "content"}} block collisions
@@ -238,10 +244,7 @@ func LoadTemplates(path string) (*TemplateSet, error) {
 			}
 			return a / b
 		},
-		"assetURL": func(filename string) string {
-			// Insert version into path for cache busting: /static/{version}/css/styles.css
-			return "/static/" + Version + "/" + filename
-		},
+		"assetURL": AssetURL,
 		"title": func(s string) string {
 			if s == "" {
 				return ""
@@ -119,7 +119,7 @@ func IsTokenExpired(tokenString string) bool {
 func (m *Manager) GetValidatedUser(r *http.Request) (map[string]interface{}, error) {
 	tokenString, err := m.GetToken(r)
 	if err != nil {
-		if err == http.ErrNoCookie {
+		if err == http.ErrNoCookie || err == ErrSessionExpired {
 			return nil, ErrNoToken
 		}
 		return nil, err
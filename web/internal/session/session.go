@@ -1,7 +1,9 @@
 package session
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/sessions"
 )
@@ -15,33 +17,96 @@ const (
 
 	// TokenIDKey is the session key for storing the token ID for refresh
 	TokenIDKey = "token_id"
+
+	// ExpiresAtKey is the session key for the login's absolute expiry (unix seconds)
+	ExpiresAtKey = "expires_at"
+
+	// DefaultMaxAge is the session lifetime when "remember me" is not checked
+	DefaultMaxAge = 8 * time.Hour
+
+	// DefaultRememberMeMaxAge is the session lifetime when "remember me" is checked
+	DefaultRememberMeMaxAge = 30 * 24 * time.Hour
 )
 
+// ErrSessionExpired is returned when the session outlived its login expiry
+var ErrSessionExpired = errors.New("session expired")
+
+// Options configures session lifetimes and cookie attributes
+type Options struct {
+	MaxAge           time.Duration // Lifetime of a normal login (default 8h)
+	RememberMeMaxAge time.Duration // Lifetime of a "remember me" login (default 30 days)
+	Secure           bool          // Only send the cookie over HTTPS
+}
+
 // Manager wraps gorilla/sessions for our use case
 type Manager struct {
-	store *sessions.CookieStore
+	store            *sessions.CookieStore
+	maxAge           time.Duration
+	rememberMeMaxAge time.Duration
+	now              func() time.Time
 }
 
 // NewManager creates a new session manager
 // secretKey should be 32 bytes for AES-256
-func NewManager(secretKey []byte) *Manager {
+func NewManager(secretKey []byte, opts Options) *Manager {
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = DefaultMaxAge
+	}
+	if opts.RememberMeMaxAge <= 0 {
+		opts.RememberMeMaxAge = DefaultRememberMeMaxAge
+	}
+
 	store := sessions.NewCookieStore(secretKey)
 
+	// Cookies are signed to accept the longest lifetime; each session's own
+	// expiry is enforced separately via ExpiresAtKey
+	store.MaxAge(int(opts.RememberMeMaxAge.Seconds()))
+
 	// Configure session options
 	store.Options = &sessions.Options{
 		Path:     "/",
-		MaxAge:   90 * 24 * 60 * 60, // 90 days
+		MaxAge:   int(opts.MaxAge.Seconds()),
 		HttpOnly: true,
-		Secure:   false, // Set to true in production with HTTPS
+		Secure:   opts.Secure,
 		SameSite: http.SameSiteLaxMode,
 	}
 
 	return &Manager{
-		store: store,
+		store:            store,
+		maxAge:           opts.MaxAge,
+		rememberMeMaxAge: opts.RememberMeMaxAge,
+		now:              time.Now,
+	}
+}
+
+// RememberMeDays returns the "remember me" lifetime in whole days, for display
+func (m *Manager) RememberMeDays() int {
+	return int(m.rememberMeMaxAge / (24 * time.Hour))
+}
+
+// StartSession stores the token for a new login. rememberMe selects the longer lifetime.
+func (m *Manager) StartSession(r *http.Request, w http.ResponseWriter, token, tokenID string, rememberMe bool) error {
+	session, err := m.store.Get(r, SessionName)
+	if err != nil {
+		// Create new session if it doesn't exist
+		session, _ = m.store.New(r, SessionName)
+	}
+
+	lifetime := m.maxAge
+	if rememberMe {
+		lifetime = m.rememberMeMaxAge
 	}
+	expiresAt := m.now().Add(lifetime)
+
+	session.Values[TokenKey] = token
+	session.Values[TokenIDKey] = tokenID
+	session.Values[ExpiresAtKey] = expiresAt.Unix()
+	session.Options.MaxAge = int(lifetime.Seconds())
+	return session.Save(r, w)
 }
 
-// SetToken stores the JWT token and token ID in the session
+// SetToken stores a refreshed JWT token and token ID in the session
+// The login's original expiry is kept, so refreshing never extends a session
 func (m *Manager) SetToken(r *http.Request, w http.ResponseWriter, token, tokenID string) error {
 	session, err := m.store.Get(r, SessionName)
 	if err != nil {
@@ -49,8 +114,21 @@ func (m *Manager) SetToken(r *http.Request, w http.ResponseWriter, token, tokenI
 		session, _ = m.store.New(r, SessionName)
 	}
 
+	expiresAt, ok := session.Values[ExpiresAtKey].(int64)
+	if !ok {
+		// Sessions created before expiry tracking get the default lifetime
+		expiresAt = m.now().Add(m.maxAge).Unix()
+		session.Values[ExpiresAtKey] = expiresAt
+	}
+
+	remaining := time.Unix(expiresAt, 0).Sub(m.now())
+	if remaining <= 0 {
+		return ErrSessionExpired
+	}
+
 	session.Values[TokenKey] = token
 	session.Values[TokenIDKey] = tokenID
+	session.Options.MaxAge = int(remaining.Seconds())
 	return session.Save(r, w)
 }
 
@@ -66,6 +144,10 @@ func (m *Manager) GetToken(r *http.Request) (string, error) {
 		return "", http.ErrNoCookie
 	}
 
+	if expiresAt, ok := session.Values[ExpiresAtKey].(int64); ok && m.now().Unix() >= expiresAt {
+		return "", ErrSessionExpired
+	}
+
 	return token, nil
 }
 
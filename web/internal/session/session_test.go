@@ -0,0 +1,109 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// sessionCookie returns the session cookie set on a response
+func sessionCookie(t *testing.T, rec *httptest.ResponseRecorder) *http.Cookie {
+	t.Helper()
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == SessionName {
+			return c
+		}
+	}
+	t.Fatal("session cookie not set")
+	return nil
+}
+
+// requestWithCookie builds a request carrying the given cookie
+func requestWithCookie(c *http.Cookie) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(c)
+	return r
+}
+
+func TestStartSession_Lifetimes(t *testing.T) {
+	tests := []struct {
+		name       string
+		rememberMe bool
+		wantMaxAge time.Duration
+	}{
+		{name: "default session", rememberMe: false, wantMaxAge: 8 * time.Hour},
+		{name: "remember me", rememberMe: true, wantMaxAge: 30 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager(testSecret, Options{
+				MaxAge:           8 * time.Hour,
+				RememberMeMaxAge: 30 * 24 * time.Hour,
+				Secure:           true,
+			})
+
+			rec := httptest.NewRecorder()
+			if err := m.StartSession(httptest.NewRequest(http.MethodGet, "/", nil), rec, "jwt", "token-id", tt.rememberMe); err != nil {
+				t.Fatalf("StartSession() error = %v", err)
+			}
+
+			c := sessionCookie(t, rec)
+			if c.MaxAge != int(tt.wantMaxAge.Seconds()) {
+				t.Errorf("MaxAge = %d, want %d", c.MaxAge, int(tt.wantMaxAge.Seconds()))
+			}
+			if !c.Secure || !c.HttpOnly || c.SameSite != http.SameSiteLaxMode {
+				t.Errorf("cookie attributes Secure=%v HttpOnly=%v SameSite=%v, want true/true/Lax", c.Secure, c.HttpOnly, c.SameSite)
+			}
+
+			// Token stays readable until the expiry, then is rejected
+			token, err := m.GetToken(requestWithCookie(c))
+			if err != nil || token != "jwt" {
+				t.Fatalf("GetToken() = %q, %v; want jwt, nil", token, err)
+			}
+
+			m.now = func() time.Time { return time.Now().Add(tt.wantMaxAge + time.Minute) }
+			if _, err := m.GetToken(requestWithCookie(c)); err != ErrSessionExpired {
+				t.Errorf("GetToken() after expiry error = %v, want ErrSessionExpired", err)
+			}
+		})
+	}
+}
+
+func TestSetToken_KeepsLoginExpiry(t *testing.T) {
+	m := NewManager(testSecret, Options{MaxAge: 8 * time.Hour, RememberMeMaxAge: 30 * 24 * time.Hour})
+
+	rec := httptest.NewRecorder()
+	if err := m.StartSession(httptest.NewRequest(http.MethodGet, "/", nil), rec, "jwt", "token-id", false); err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+	c := sessionCookie(t, rec)
+
+	// A refresh two hours later must not extend the eight hour session
+	m.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	rec = httptest.NewRecorder()
+	if err := m.SetToken(requestWithCookie(c), rec, "jwt-2", "token-id-2"); err != nil {
+		t.Fatalf("SetToken() error = %v", err)
+	}
+
+	refreshed := sessionCookie(t, rec)
+	if maxAge := time.Duration(refreshed.MaxAge) * time.Second; maxAge > 6*time.Hour+time.Minute || maxAge < 6*time.Hour-time.Minute {
+		t.Errorf("refreshed MaxAge = %v, want about 6h", maxAge)
+	}
+	if refreshed.Secure {
+		t.Error("cookie should not be Secure when Options.Secure is false")
+	}
+}
+
+func TestNewManager_Defaults(t *testing.T) {
+	m := NewManager(testSecret, Options{})
+	if m.maxAge != DefaultMaxAge || m.rememberMeMaxAge != DefaultRememberMeMaxAge {
+		t.Errorf("defaults = %v/%v, want %v/%v", m.maxAge, m.rememberMeMaxAge, DefaultMaxAge, DefaultRememberMeMaxAge)
+	}
+	if m.RememberMeDays() != 30 {
+		t.Errorf("RememberMeDays() = %d, want 30", m.RememberMeDays())
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"strings"
 
@@ -62,6 +63,9 @@ func main() {
 	log := slog.Default().With("component", "web")
 	log.Info("starting hivemind web service")
 
+	// Restrict the HTML rendered markdown may contain before any page is rendered
+	render.SetMarkdownPolicy(markdownPolicy(cfg.Markdown))
+
 	// Load templates from configured path (defaults to "web/templates")
 	templates, err := render.LoadTemplates(cfg.Templates.Path)
 	if err != nil {
@@ -112,7 +116,11 @@ func main() {
 	}
 
 	// Initialize session manager
-	sessionMgr := session.NewManager(sessionSecret)
+	sessionMgr := session.NewManager(sessionSecret, session.Options{
+		MaxAge:           cfg.Session.MaxAge,
+		RememberMeMaxAge: cfg.Session.RememberMeMaxAge,
+		Secure:           cfg.Session.UseSecureCookies(cfg.OAuth.RedirectURI),
+	})
 
 	// Initialize auth middleware
 	authMw := middleware.NewAuthMiddleware(sessionMgr)
@@ -122,7 +130,7 @@ func main() {
 	h := handlers.New(cfg.GRPC.Address, sessionMgr, templates, cfg.OAuth.RedirectURI)
 
 	// Create HTTP router
-	router := createRouter(h, authMw)
+	router := createRouter(h, authMw, cfg.Security)
 
 	// Start metrics server on main port + 10
 	actualMetricsPort := cfg.Server.MetricsPort
@@ -132,16 +140,14 @@ func main() {
 		actualMetricsPort = cfg.Server.Port + 10
 	}
 	go func() {
-		metricsMux := http.NewServeMux()
-		metricsMux.Handle("/metrics", promhttp.Handler())
-		metricsMux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("OK"))
-		})
+		metricsRouter := createMetricsRouter(cfg.Internal)
+		if cfg.Internal.Debug && cfg.Internal.Token == "" {
+			log.Warn("internal.debug is on without internal.token, pprof is served without authentication")
+		}
 
 		metricsAddr := fmt.Sprintf(":%d", actualMetricsPort)
 		log.Info("starting metrics server", slog.String("address", metricsAddr))
-		if err := http.ListenAndServe(metricsAddr, metricsMux); err != nil {
+		if err := http.ListenAndServe(metricsAddr, metricsRouter); err != nil {
 			log.Error("metrics server failed", slog.Any("error", err))
 		}
 	}()
@@ -156,14 +162,34 @@ func main() {
 	}
 }
 
+// markdownPolicy applies the configured overrides to the default markdown policy
+func markdownPolicy(cfg config.MarkdownConfig) render.MarkdownPolicy {
+	policy := render.DefaultMarkdownPolicy
+	if len(cfg.AllowedElements) > 0 {
+		policy.Elements = cfg.AllowedElements
+	}
+	if len(cfg.AllowedAttributes) > 0 {
+		policy.Attributes = cfg.AllowedAttributes
+	}
+	if len(cfg.AllowedURLSchemes) > 0 {
+		policy.URLSchemes = cfg.AllowedURLSchemes
+	}
+	return policy
+}
+
+// publicPaths are served by the public router in createRouter, ahead of app middleware
+var publicPaths = []string{"/health", "/version", "/static/"}
+
 // createRouter sets up the HTTP router with all routes and middleware
-func createRouter(h *handlers.Handler, authMw *middleware.AuthMiddleware) http.Handler {
-	router := mux.NewRouter()
+func createRouter(h *handlers.Handler, authMw *middleware.AuthMiddleware, security config.SecurityConfig) http.Handler {
+	// Health, version, and static assets are served ahead of the app router and its
+	// middleware, so probes and assets keep working whatever the app requires
+	public := mux.NewRouter()
 
 	// Static files with version path: /static/{version}/...
 	// Strip /static/{version}/ prefix and serve from web/static/
 	staticDir := http.Dir("web/static")
-	router.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	public.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Remove version from path (format: {version}/file.ext)
 		// Split path and skip the first segment (version)
 		parts := strings.SplitN(r.URL.Path, "/", 2)
@@ -176,20 +202,22 @@ func createRouter(h *handlers.Handler, authMw *middleware.AuthMiddleware) http.H
 		http.FileServer(staticDir).ServeHTTP(w, r)
 	})))
 
-	// Health check endpoint (no auth required)
-	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+	// Health check endpoint
+	public.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain")
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("ok"))
 	}).Methods("GET")
 
 	// Version info endpoint
-	router.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+	public.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintf(w, `{"version":"%s"}`, render.Version)
 	}).Methods("GET")
 
+	router := mux.NewRouter()
+
 	// Public routes (no auth required)
 	router.HandleFunc("/", h.Home).Methods("GET")
 	router.HandleFunc("/login", h.Login).Methods("GET")
@@ -198,6 +226,11 @@ func createRouter(h *handlers.Handler, authMw *middleware.AuthMiddleware) http.H
 	router.HandleFunc("/admin/login", h.AdminLogin).Methods("POST")
 	router.HandleFunc("/api/set-timezone", h.SetTimezone).Methods("POST")
 
+	// Activity feed (auth required)
+	router.Handle("/activity", authMw.RequireAuth(http.HandlerFunc(h.ActivityPage))).Methods("GET")
+	router.Handle("/profile", authMw.RequireAuth(http.HandlerFunc(h.ProfilePage))).Methods("GET")
+	router.Handle("/profile/link-discord", authMw.RequireAuth(http.HandlerFunc(h.LinkDiscord))).Methods("GET")
+
 	// Wiki routes (auth required)
 	router.Handle("/wikis", authMw.RequireAuth(http.HandlerFunc(h.WikiListPage))).Methods("GET")
 	router.Handle("/wiki", authMw.RequireAuth(http.HandlerFunc(h.WikiPage))).Methods("GET")
@@ -222,6 +255,34 @@ func createRouter(h *handlers.Handler, authMw *middleware.AuthMiddleware) http.H
 	// 404 handler for all unmatched routes
 	router.NotFoundHandler = http.HandlerFunc(h.NotFound)
 
-	// Wrap router with logging middleware
-	return middleware.LogRequest(router)
+	// Middleware, outermost first:
+	//   1. LogRequest and SecurityHeaders wrap everything, so 404s, error pages, and the
+	//      public paths get security headers too.
+	//   2. PublicPaths sends health, version, and static requests to the public router.
+	//   3. App-wide middleware goes inside PublicPaths, around the app router, so that
+	//      anything rejecting requests (an auth check, say) can't break probes or assets.
+	return middleware.LogRequest(middleware.SecurityHeaders(security,
+		middleware.PublicPaths(publicPaths, middleware.RecordMetrics(public), middleware.RecordMetrics(router))))
+}
+
+// createMetricsRouter serves /metrics and, if enabled, pprof under /debug/pprof/ behind
+// the configured token, and an open /health for probes
+func createMetricsRouter(cfg config.InternalConfig) http.Handler {
+	protected := http.NewServeMux()
+	protected.Handle("/metrics", promhttp.Handler())
+	if cfg.Debug {
+		protected.HandleFunc("/debug/pprof/", pprof.Index)
+		protected.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		protected.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		protected.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		protected.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	metricsMux := http.NewServeMux()
+	metricsMux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	})
+	metricsMux.Handle("/", middleware.RequireToken(cfg.TokenHeader, cfg.Token, protected))
+	return metricsMux
 }